@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	protoapi "protogonos/pkg/protogonos"
+)
+
+func runAnnotate(ctx context.Context, args []string) error {
+	fs := newFlagSet("annotate")
+	runID := fs.String("run-id", "", "run id whose top genome is annotated")
+	latest := fs.Bool("latest", false, "annotate a top genome of the most recent run from run index")
+	genomeID := fs.String("genome-id", "", "top genome to annotate (default: run champion)")
+	var sets stringListFlag
+	fs.Var(&sets, "set", "annotation key=value (repeatable)")
+	var unsets stringListFlag
+	fs.Var(&unsets, "unset", "annotation key to remove (repeatable)")
+	note := fs.String("note", "", "free-form note appended to the genome provenance")
+	jsonOut := fs.Bool("json", false, "emit the annotated genome as JSON")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *runID != "" && *latest {
+		return errors.New("use either --run-id or --latest, not both")
+	}
+	if *runID == "" && !*latest {
+		return errors.New("annotate requires --run-id or --latest")
+	}
+	annotations, err := parseKeyValueFlags(sets)
+	if err != nil {
+		return err
+	}
+
+	client, err := protoapi.New(protoapi.Options{
+		StoreKind:     *storeKind,
+		DBPath:        *dbPath,
+		BenchmarksDir: benchmarksDir,
+		ExportsDir:    exportsDir,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	annotated, err := client.AnnotateGenome(ctx, protoapi.AnnotateGenomeRequest{
+		RunID:    *runID,
+		Latest:   *latest,
+		GenomeID: *genomeID,
+		Set:      annotations,
+		Unset:    unsets,
+		Note:     *note,
+	})
+	if err != nil {
+		return err
+	}
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(annotated)
+	}
+
+	fmt.Printf("annotated run_id=%s rank=%d genome_id=%s annotations=%s\n",
+		annotated.RunID,
+		annotated.Rank,
+		annotated.GenomeID,
+		formatAnnotations(annotated.Annotations),
+	)
+	if prov := annotated.Provenance; prov != nil {
+		fmt.Printf("provenance created_by=%s parent_id=%s generation=%d tuning_session_id=%s\n",
+			prov.CreatedBy, prov.ParentID, prov.Generation, prov.TuningSessionID)
+		for i, text := range prov.Notes {
+			fmt.Printf("note=%d %s\n", i+1, text)
+		}
+	}
+	return nil
+}
+
+// formatAnnotations renders annotations as sorted comma-separated key=value
+// pairs, or "-" when there are none.
+func formatAnnotations(annotations map[string]string) string {
+	if len(annotations) == 0 {
+		return "-"
+	}
+	keys := make([]string, 0, len(annotations))
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+annotations[key])
+	}
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	protoapi "protogonos/pkg/protogonos"
+)
+
+func runAutotune(ctx context.Context, args []string) error {
+	fs := newFlagSet("autotune")
+	configPath := fs.String("config", "", "optional run config JSON path (map2rec-backed) for the scape and its data")
+	runID := fs.String("run-id", "", "prefix of the trial run ids (optional)")
+	scapeName := fs.String("scape", "xor", "scape name")
+	var scapeParams stringListFlag
+	fs.Var(&scapeParams, "scape-param", "scape construction parameter key=value, repeatable")
+	population := fs.Int("pop", 50, "population size of every trial")
+	seed := fs.Int64("seed", 1, "rng seed of every trial and of the sampling")
+	workers := fs.Int("workers", 4, "worker count")
+	budget := fs.Duration("budget", time.Hour, "wall-clock budget of the search")
+	minGens := fs.Int("min-gens", 3, "generations of the shortest trials")
+	maxGens := fs.Int("max-gens", 27, "generations of the longest trials")
+	eta := fs.Int("eta", 3, "halving rate: each rung keeps 1/eta of its trials and runs them eta times longer")
+	brackets := fs.Int("brackets", 0, "stop after this many brackets (0 searches until the budget is spent)")
+	var space stringListFlag
+	fs.Var(&space, "space", "search axis run-flag=v1,v2,... (repeatable; replaces the default space): "+strings.Join(protoapi.RunOverrideKeys(), "|"))
+	outPath := fs.String("out", "", "optional path to write the JSON report to")
+	jsonOut := fs.Bool("json", false, "emit the report as JSON")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	setFlags := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		setFlags[f.Name] = true
+	})
+
+	searchSpace, err := parseAutotuneSpace(space)
+	if err != nil {
+		return err
+	}
+	scapeParamValues, err := parseScapeParams(scapeParams)
+	if err != nil {
+		return err
+	}
+	req, err := loadOrDefaultRunRequest(*configPath)
+	if err != nil {
+		return err
+	}
+	if *configPath == "" {
+		req = protoapi.RunRequest{
+			Scape:       *scapeName,
+			ScapeParams: scapeParamValues,
+			RunID:       *runID,
+			Population:  *population,
+			Seed:        *seed,
+			Workers:     *workers,
+		}
+	} else if err := overrideFromFlags(&req, setFlags, map[string]any{
+		"scape":       *scapeName,
+		"scape-param": scapeParamValues,
+		"run-id":      *runID,
+		"pop":         *population,
+		"seed":        *seed,
+		"workers":     *workers,
+	}); err != nil {
+		return err
+	}
+
+	client, err := protoapi.New(protoapi.Options{
+		StoreKind:     *storeKind,
+		DBPath:        *dbPath,
+		BenchmarksDir: benchmarksDir,
+		ExportsDir:    exportsDir,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	summary, err := client.Autotune(ctx, protoapi.AutotuneRequest{
+		Base:           req,
+		Budget:         *budget,
+		Space:          searchSpace,
+		MinGenerations: *minGens,
+		MaxGenerations: *maxGens,
+		Eta:            *eta,
+		MaxBrackets:    *brackets,
+		Progress: func(trial protoapi.AutotuneTrial) {
+			if !*jsonOut {
+				fmt.Printf("trial run_id=%s bracket=%d rung=%d config=%d gens=%d fitness=%.6f seconds=%.1f %s\n",
+					trial.RunID, trial.Bracket, trial.Rung, trial.Config, trial.Generations, trial.Fitness, trial.Seconds, protoapi.AutotuneFlags(trial.Params))
+			}
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if *outPath != "" {
+		data, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(*outPath, append(data, '\n'), 0o644); err != nil {
+			return err
+		}
+	}
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(summary)
+	}
+	fmt.Printf("autotune completed trials=%d brackets=%d seconds=%.1f budget_exhausted=%t\n",
+		len(summary.Trials), summary.Brackets, summary.Seconds, summary.BudgetExhausted)
+	for _, trial := range summary.Evidence {
+		fmt.Printf("evidence run_id=%s rung=%d gens=%d fitness=%.6f\n", trial.RunID, trial.Rung, trial.Generations, trial.Fitness)
+	}
+	fmt.Printf("best run_id=%s gens=%d fitness=%.6f\n", summary.Best.RunID, summary.Best.Generations, summary.Best.Fitness)
+	fmt.Printf("best_flags=%s\n", protoapi.AutotuneFlags(summary.Best.Params))
+	if *outPath != "" {
+		fmt.Printf("report=%s\n", *outPath)
+	}
+	return nil
+}
+
+// parseAutotuneSpace parses --space axes of the form run-flag=v1,v2,...
+func parseAutotuneSpace(values []string) ([]protoapi.AutotuneParameter, error) {
+	space := make([]protoapi.AutotuneParameter, 0, len(values))
+	for _, raw := range values {
+		name, list, ok := strings.Cut(raw, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --space axis %q: want run-flag=v1,v2,...", raw)
+		}
+		parameter := protoapi.AutotuneParameter{Name: name}
+		for _, value := range strings.Split(list, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				parameter.Values = append(parameter.Values, value)
+			}
+		}
+		if len(parameter.Values) == 0 {
+			return nil, fmt.Errorf("--space axis %s has no values", name)
+		}
+		space = append(space, parameter)
+	}
+	return space, nil
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	protoapi "protogonos/pkg/protogonos"
+)
+
+func TestParseAutotuneSpace(t *testing.T) {
+	got, err := parseAutotuneSpace([]string{"selection=elite, tournament", "w-perturb=0.5"})
+	if err != nil {
+		t.Fatalf("parse autotune space: %v", err)
+	}
+	want := []protoapi.AutotuneParameter{
+		{Name: "selection", Values: []string{"elite", "tournament"}},
+		{Name: "w-perturb", Values: []string{"0.5"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+	if _, err := parseAutotuneSpace([]string{"selection"}); err == nil {
+		t.Fatal("expected missing '=' to fail")
+	}
+	if _, err := parseAutotuneSpace([]string{"selection=,"}); err == nil {
+		t.Fatal("expected an axis without values to fail")
+	}
+}
@@ -24,7 +24,7 @@ const (
 
 func runBenchmarkExperiment(ctx context.Context, args []string) error {
 	if len(args) == 0 {
-		return errors.New("benchmark-experiment requires a subcommand: start|continue|show|list|evaluations|report|trace2graph|plot|chg-mrph|vector-compare|unconsult")
+		return errors.New("benchmark-experiment requires a subcommand: start|continue|show|list|evaluations|report|importance|trace2graph|plot|chg-mrph|vector-compare|unconsult")
 	}
 	switch args[0] {
 	case "start":
@@ -39,6 +39,8 @@ func runBenchmarkExperiment(ctx context.Context, args []string) error {
 		return runBenchmarkExperimentEvaluations(args[1:])
 	case "report":
 		return runBenchmarkExperimentReport(args[1:])
+	case "importance":
+		return runBenchmarkExperimentImportance(args[1:])
 	case "trace2graph":
 		return runBenchmarkExperimentTraceToGraph(args[1:])
 	case "plot":
@@ -55,10 +57,12 @@ func runBenchmarkExperiment(ctx context.Context, args []string) error {
 }
 
 func runBenchmarkExperimentStart(ctx context.Context, args []string) error {
-	fs := flag.NewFlagSet("benchmark-experiment start", flag.ContinueOnError)
+	fs := newFlagSet("benchmark-experiment start")
 	id := fs.String("id", "", "experiment id")
-	runs := fs.Int("runs", 1, "total benchmark runs")
+	runs := fs.Int("runs", 1, "benchmark runs (per grid cell with --grid)")
 	notes := fs.String("notes", "", "optional experiment notes")
+	var grid stringListFlag
+	fs.Var(&grid, "grid", "sweep axis benchmark-flag=v1,v2,... (repeatable); runs cover the cartesian product of all axes")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -68,6 +72,10 @@ func runBenchmarkExperimentStart(ctx context.Context, args []string) error {
 	if *runs <= 0 {
 		return errors.New("benchmark-experiment start requires --runs > 0")
 	}
+	cells, err := experimentGridCells(grid)
+	if err != nil {
+		return err
+	}
 	if existing, ok, err := stats.ReadBenchmarkExperiment(benchmarksDir, strings.TrimSpace(*id)); err != nil {
 		return err
 	} else if ok {
@@ -80,12 +88,13 @@ func runBenchmarkExperimentStart(ctx context.Context, args []string) error {
 		Notes:        strings.TrimSpace(*notes),
 		ProgressFlag: benchmarkExperimentProgressInProgress,
 		RunIndex:     1,
-		TotalRuns:    *runs,
+		TotalRuns:    *runs * cells,
 		StartedAtUTC: time.Now().UTC().Format(time.RFC3339Nano),
 		BenchmarkArgs: append(
 			[]string(nil),
 			benchmarkArgs...,
 		),
+		Grid: append([]string(nil), grid...),
 	}
 	if err := stats.WriteBenchmarkExperiment(benchmarksDir, exp); err != nil {
 		return err
@@ -94,7 +103,7 @@ func runBenchmarkExperimentStart(ctx context.Context, args []string) error {
 }
 
 func runBenchmarkExperimentContinue(ctx context.Context, args []string) error {
-	fs := flag.NewFlagSet("benchmark-experiment continue", flag.ContinueOnError)
+	fs := newFlagSet("benchmark-experiment continue")
 	id := fs.String("id", "", "experiment id")
 	if err := fs.Parse(args); err != nil {
 		return err
@@ -128,7 +137,7 @@ func runBenchmarkExperimentContinue(ctx context.Context, args []string) error {
 }
 
 func runBenchmarkExperimentShow(args []string) error {
-	fs := flag.NewFlagSet("benchmark-experiment show", flag.ContinueOnError)
+	fs := newFlagSet("benchmark-experiment show")
 	id := fs.String("id", "", "experiment id")
 	jsonOut := fs.Bool("json", false, "emit experiment as JSON")
 	if err := fs.Parse(args); err != nil {
@@ -174,11 +183,14 @@ func runBenchmarkExperimentShow(args []string) error {
 		}
 		fmt.Printf("run=%d run_id=%s morphology=%s final_best=%.6f passed=%t\n", i+1, runID, morphology, finalBest, passed)
 	}
+	if exp.ParameterImportance != nil && len(exp.ParameterImportance.Parameters) > 0 {
+		printParameterImportance(exp.ID, *exp.ParameterImportance)
+	}
 	return nil
 }
 
 func runBenchmarkExperimentList(args []string) error {
-	fs := flag.NewFlagSet("benchmark-experiment list", flag.ContinueOnError)
+	fs := newFlagSet("benchmark-experiment list")
 	jsonOut := fs.Bool("json", false, "emit experiments as JSON")
 	if err := fs.Parse(args); err != nil {
 		return err
@@ -212,7 +224,7 @@ func runBenchmarkExperimentList(args []string) error {
 }
 
 func runBenchmarkExperimentEvaluations(args []string) error {
-	fs := flag.NewFlagSet("benchmark-experiment evaluations", flag.ContinueOnError)
+	fs := newFlagSet("benchmark-experiment evaluations")
 	id := fs.String("id", "", "experiment id")
 	fitnessGoal := fs.Float64("fitness-goal", math.NaN(), "optional success fitness goal")
 	evalLimit := fs.Int("evaluations-limit", 0, "optional success evaluation limit (>0)")
@@ -271,7 +283,7 @@ func runBenchmarkExperimentEvaluations(args []string) error {
 }
 
 func runBenchmarkExperimentReport(args []string) error {
-	fs := flag.NewFlagSet("benchmark-experiment report", flag.ContinueOnError)
+	fs := newFlagSet("benchmark-experiment report")
 	id := fs.String("id", "", "experiment id")
 	name := fs.String("name", "report", "report output prefix")
 	fitnessGoal := fs.Float64("fitness-goal", math.NaN(), "optional success fitness goal")
@@ -344,6 +356,42 @@ func runBenchmarkExperimentReport(args []string) error {
 	return nil
 }
 
+func runBenchmarkExperimentImportance(args []string) error {
+	fs := newFlagSet("benchmark-experiment importance")
+	id := fs.String("id", "", "experiment id")
+	jsonOut := fs.Bool("json", false, "emit parameter importance as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if strings.TrimSpace(*id) == "" {
+		return errors.New("benchmark-experiment importance requires --id")
+	}
+	exp, ok, err := stats.ReadBenchmarkExperiment(benchmarksDir, strings.TrimSpace(*id))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("benchmark experiment not found: %s", strings.TrimSpace(*id))
+	}
+	importance, err := stats.BuildParameterImportance(benchmarksDir, exp)
+	if err != nil {
+		return err
+	}
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			ID                  string                          `json:"id"`
+			ParameterImportance stats.ParameterImportanceReport `json:"parameter_importance"`
+		}{
+			ID:                  exp.ID,
+			ParameterImportance: importance,
+		})
+	}
+	printParameterImportance(exp.ID, importance)
+	return nil
+}
+
 func loadBenchmarkExperimentEvaluationStats(
 	id string,
 	fitnessGoal float64,
@@ -381,7 +429,7 @@ func loadBenchmarkExperimentEvaluationStats(
 }
 
 func runBenchmarkExperimentTraceToGraph(args []string) error {
-	fs := flag.NewFlagSet("benchmark-experiment trace2graph", flag.ContinueOnError)
+	fs := newFlagSet("benchmark-experiment trace2graph")
 	id := fs.String("id", "", "experiment id")
 	traceFile := fs.String("trace-file", "", "trace_acc.json path for standalone trace->graph conversion")
 	name := fs.String("name", "__Graph", "graph postfix")
@@ -448,7 +496,7 @@ func runBenchmarkExperimentTraceToGraph(args []string) error {
 }
 
 func runBenchmarkExperimentPlot(args []string) error {
-	fs := flag.NewFlagSet("benchmark-experiment plot", flag.ContinueOnError)
+	fs := newFlagSet("benchmark-experiment plot")
 	id := fs.String("id", "", "experiment id")
 	mode := fs.String("mode", "avg", "plot mode: avg|max")
 	startIndex := fs.Int("start-index", -1, "index for first point (default 500 for avg, 0 for max)")
@@ -517,7 +565,7 @@ func runBenchmarkExperimentPlot(args []string) error {
 }
 
 func runBenchmarkExperimentChangeMorphology(args []string) error {
-	fs := flag.NewFlagSet("benchmark-experiment chg-mrph", flag.ContinueOnError)
+	fs := newFlagSet("benchmark-experiment chg-mrph")
 	id := fs.String("id", "", "experiment id (updates benchmark args)")
 	runID := fs.String("run-id", "", "run id (updates persisted run config)")
 	scapeName := fs.String("scape", "", "new scape/morphology tag")
@@ -730,7 +778,7 @@ func rewriteExperimentSummaryMorphology(runID, scapeName, gtsaProfile, fxProfile
 }
 
 func runBenchmarkExperimentVectorCompare(args []string) error {
-	fs := flag.NewFlagSet("benchmark-experiment vector-compare", flag.ContinueOnError)
+	fs := newFlagSet("benchmark-experiment vector-compare")
 	vectorA := fs.String("a", "", "vector A as comma-separated values")
 	vectorB := fs.String("b", "", "vector B as comma-separated values")
 	jsonOut := fs.Bool("json", false, "emit vector comparison as JSON")
@@ -768,7 +816,7 @@ func runBenchmarkExperimentVectorCompare(args []string) error {
 }
 
 func runBenchmarkExperimentUnconsult(args []string) error {
-	fs := flag.NewFlagSet("benchmark-experiment unconsult", flag.ContinueOnError)
+	fs := newFlagSet("benchmark-experiment unconsult")
 	id := fs.String("id", "", "experiment id (optional)")
 	source := fs.String("source", "run-ids", "experiment source: run-ids|summaries")
 	itemsJSON := fs.String("items-json", "", "optional explicit JSON array to dump")
@@ -1037,7 +1085,10 @@ func executeBenchmarkExperiment(ctx context.Context, exp *stats.BenchmarkExperim
 
 	for runIdx := exp.RunIndex; runIdx <= exp.TotalRuns; runIdx++ {
 		runID := fmt.Sprintf("%s-run-%03d", exp.ID, runIdx)
-		runArgs := append([]string(nil), exp.BenchmarkArgs...)
+		runArgs, err := applyExperimentGrid(exp.BenchmarkArgs, exp.Grid, runIdx)
+		if err != nil {
+			return err
+		}
 		runArgs = append(runArgs, "--run-id", runID)
 		if err := runBenchmark(ctx, runArgs); err != nil {
 			exp.ProgressFlag = benchmarkExperimentProgressInProgress
@@ -1071,15 +1122,116 @@ func executeBenchmarkExperiment(ctx context.Context, exp *stats.BenchmarkExperim
 		)
 	}
 
+	importance, err := stats.BuildParameterImportance(benchmarksDir, *exp)
+	if err != nil {
+		return err
+	}
+	exp.ParameterImportance = &importance
 	exp.ProgressFlag = benchmarkExperimentProgressCompleted
 	exp.CompletedAtUTC = time.Now().UTC().Format(time.RFC3339Nano)
 	if err := stats.WriteBenchmarkExperiment(benchmarksDir, *exp); err != nil {
 		return err
 	}
 	fmt.Printf("benchmark_experiment id=%s progress=%s runs=%d\n", exp.ID, exp.ProgressFlag, exp.TotalRuns)
+	if len(importance.Parameters) > 0 {
+		printParameterImportance(exp.ID, importance)
+	}
 	return nil
 }
 
+// experimentGridAxis is one --grid flag=v1,v2,... sweep axis.
+type experimentGridAxis struct {
+	flag   string
+	values []string
+}
+
+func parseExperimentGrid(grid []string) ([]experimentGridAxis, error) {
+	axes := make([]experimentGridAxis, 0, len(grid))
+	seen := make(map[string]bool, len(grid))
+	for _, raw := range grid {
+		name, list, ok := strings.Cut(raw, "=")
+		name = strings.TrimLeft(strings.TrimSpace(name), "-")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --grid %q: want flag=v1,v2,...", raw)
+		}
+		if name == "run-id" {
+			return nil, errors.New("--grid cannot sweep run-id")
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate --grid axis: %s", name)
+		}
+		seen[name] = true
+		axis := experimentGridAxis{flag: name}
+		for _, value := range strings.Split(list, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				axis.values = append(axis.values, value)
+			}
+		}
+		if len(axis.values) == 0 {
+			return nil, fmt.Errorf("--grid %s has no values", name)
+		}
+		axes = append(axes, axis)
+	}
+	return axes, nil
+}
+
+// experimentGridCells returns the number of cells of the grid's cartesian
+// product, 1 without a grid.
+func experimentGridCells(grid []string) (int, error) {
+	axes, err := parseExperimentGrid(grid)
+	if err != nil {
+		return 0, err
+	}
+	cells := 1
+	for _, axis := range axes {
+		cells *= len(axis.values)
+	}
+	return cells, nil
+}
+
+// applyExperimentGrid sets the grid flags for the 1-based run index. Runs
+// cycle through the cells with the last axis varying fastest, so every cell
+// is visited once before any is repeated.
+func applyExperimentGrid(args, grid []string, runIdx int) ([]string, error) {
+	axes, err := parseExperimentGrid(grid)
+	if err != nil {
+		return nil, err
+	}
+	out := append([]string(nil), args...)
+	cell := runIdx - 1
+	for i := len(axes) - 1; i >= 0; i-- {
+		axis := axes[i]
+		value := axis.values[cell%len(axis.values)]
+		cell /= len(axis.values)
+		out = append(removeLongFlagArg(out, axis.flag), "--"+axis.flag+"="+value)
+	}
+	return out, nil
+}
+
+func printParameterImportance(id string, report stats.ParameterImportanceReport) {
+	fmt.Printf("benchmark_experiment_importance id=%s runs=%d fitness_mean=%.6f fitness_std=%.6f explained=%.4f\n",
+		id,
+		report.Runs,
+		report.FitnessMean,
+		report.FitnessStd,
+		report.Explained,
+	)
+	for i, parameter := range report.Parameters {
+		best := ""
+		if len(parameter.Levels) > 0 {
+			best = parameter.Levels[0].Value
+		}
+		fmt.Printf("rank=%d field=%s importance=%.4f levels=%d best=%s aliases=%s\n",
+			i+1,
+			parameter.Field,
+			parameter.Importance,
+			len(parameter.Levels),
+			best,
+			strings.Join(parameter.Aliases, ","),
+		)
+	}
+}
+
 func sanitizeExperimentBenchmarkArgs(args []string) []string {
 	if len(args) == 0 {
 		return nil
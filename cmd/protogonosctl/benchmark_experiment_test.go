@@ -35,3 +35,39 @@ func TestBenchmarkExperimentMorphologiesFromItemsFallsBackToScape(t *testing.T)
 		t.Fatalf("unexpected fallback morphologies: got=%v want=%v", got, want)
 	}
 }
+
+func TestApplyExperimentGridCyclesCartesianProduct(t *testing.T) {
+	grid := []string{"pop=4,8", "--selection=elite,tournament"}
+	cells, err := experimentGridCells(grid)
+	if err != nil || cells != 4 {
+		t.Fatalf("expected 4 grid cells, got %d err=%v", cells, err)
+	}
+	base := []string{"--scape", "xor", "--pop", "6"}
+	var got [][]string
+	for runIdx := 1; runIdx <= 5; runIdx++ {
+		args, err := applyExperimentGrid(base, grid, runIdx)
+		if err != nil {
+			t.Fatalf("apply grid run %d: %v", runIdx, err)
+		}
+		got = append(got, args)
+	}
+	want := [][]string{
+		{"--scape", "xor", "--selection=elite", "--pop=4"},
+		{"--scape", "xor", "--selection=tournament", "--pop=4"},
+		{"--scape", "xor", "--selection=elite", "--pop=8"},
+		{"--scape", "xor", "--selection=tournament", "--pop=8"},
+		{"--scape", "xor", "--selection=elite", "--pop=4"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected grid args:\ngot  %v\nwant %v", got, want)
+	}
+	if !reflect.DeepEqual(base, []string{"--scape", "xor", "--pop", "6"}) {
+		t.Fatalf("grid mutated the base args: %v", base)
+	}
+
+	for _, bad := range [][]string{{"pop"}, {"pop="}, {"pop=4", "pop=8"}, {"run-id=a,b"}} {
+		if _, err := experimentGridCells(bad); err == nil {
+			t.Fatalf("expected grid %v to be rejected", bad)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	protoapi "protogonos/pkg/protogonos"
+)
+
+// command is one protogonosctl subcommand. Subcommands lists the actions
+// that must follow the command name, and Args the values of a positional
+// argument, so completion and --help-json can describe them.
+type command struct {
+	Name        string
+	Summary     string
+	Subcommands []string
+	Args        []string
+	Hidden      bool
+	Run         func(context.Context, []string) error
+}
+
+// commandRegistry lists every subcommand in usage order.
+func commandRegistry() []command {
+	return []command{
+		{Name: "init", Summary: "initialize the store", Run: runInit},
+		{Name: "reset", Summary: "clear every record from the store", Run: runReset},
+		{Name: "start", Summary: "start a polis and list its registered scapes", Run: runStart},
+		{Name: "run", Summary: "evolve a population on a scape", Run: runRun},
+		{Name: "es-run", Summary: "optimize a fixed topology's weights with an evolution strategies baseline", Run: runESRun},
+		{Name: "benchmark", Summary: "run an evolution and check it against a fitness threshold", Run: runBenchmark},
+		{Name: "benchmark-experiment", Summary: "manage multi-run benchmark experiments", Subcommands: []string{"start", "continue", "show", "list", "evaluations", "report", "importance", "trace2graph", "plot", "chg-mrph", "vector-compare", "unconsult"}, Run: runBenchmarkExperiment},
+		{Name: "profile", Summary: "list or show parity profiles", Subcommands: []string{"list", "show"}, Run: runProfile},
+		{Name: "fork", Summary: "start a new run from a recorded generation of another", Run: runFork},
+		{Name: "autotune", Summary: "search run settings for a scape with successive halving under a time budget", Run: runAutotune},
+		{Name: "resume", Summary: "continue an interrupted run from its last checkpoint", Run: runResume},
+		{Name: "merge-populations", Summary: "merge the final populations of several runs into one snapshot", Run: runMergePopulations},
+		{Name: "runs", Summary: "list recorded runs", Run: runRuns},
+		{Name: "leaderboard", Summary: "show the best champions ever recorded for a scape", Run: runLeaderboard},
+		{Name: "lineage", Summary: "show a run's lineage records", Run: runLineage},
+		{Name: "fitness", Summary: "show a run's fitness history", Run: runFitness},
+		{Name: "diagnostics", Summary: "show a run's per-generation diagnostics", Run: runDiagnostics},
+		{Name: "profile-run", Summary: "show which phase dominates a run's per-generation wall-clock", Run: runProfileRun},
+		{Name: "events", Summary: "show a run's evolution events", Run: runEvents},
+		{Name: "species", Summary: "show a run's species history", Run: runSpecies},
+		{Name: "species-diff", Summary: "compare the species of two generations", Run: runSpeciesDiff},
+		{Name: "monitor", Summary: "control a live run", Subcommands: []string{"pause", "continue", "stop", "goal-reached", "print-trace", "set-param"}, Run: runMonitor},
+		{Name: "population", Summary: "delete or compare population snapshots", Subcommands: []string{"delete", "diff"}, Run: runPopulation},
+		{Name: "store", Summary: "inspect and maintain the store", Subcommands: []string{"stats", "compact", "import-archive"}, Run: runStore},
+		{Name: "top", Summary: "show a run's top genomes", Run: runTop},
+		{Name: "scape-summary", Summary: "show a scape's stored summary", Run: runScapeSummary},
+		{Name: "epitopes-test", Summary: "replay a run's top genomes on the epitopes test split", Run: runEpitopesTest},
+		{Name: "replay", Summary: "replay a recorded genome on its scape", Run: runReplay},
+		{Name: "sensor-importance", Summary: "rank a recorded genome's sensors by the fitness lost when each is zeroed", Run: runSensorImportance},
+		{Name: "serve-model", Summary: "serve a champion genome over HTTP", Run: runServeModel},
+		{Name: "serve", Summary: "drive and inspect runs remotely over gRPC and a JSON/HTTP gateway", Run: runServe},
+		{Name: "similar", Summary: "find genomes similar to a query genome", Run: runSimilar},
+		{Name: "cross-eval", Summary: "evaluate run champions across each other's modes", Run: runCrossEval},
+		{Name: "plot", Summary: "plot a run's fitness, species or tuning history", Run: runPlot},
+		{Name: "annotate", Summary: "set or remove annotations on a top genome", Run: runAnnotate},
+		{Name: "module", Summary: "tag and list reusable genome modules", Subcommands: []string{"tag", "list"}, Run: runModule},
+		{Name: "genome", Summary: "render, validate or edit genomes", Subcommands: []string{"dot", "schema", "edit"}, Run: runGenome},
+		{Name: "export", Summary: "export a run's artifacts", Run: runExport},
+		{Name: "verify-artifacts", Summary: "check a run's artifact files against their recorded checksums", Run: runVerifyArtifacts},
+		{Name: "data-extract", Summary: "convert a CSV dataset into a scape table", Run: runDataExtract},
+		{Name: "completion", Summary: "print a shell completion script", Args: completionShells, Run: runCompletion},
+		{Name: protoapi.ScapeWorkerCommand, Hidden: true, Run: func(ctx context.Context, _ []string) error {
+			return protoapi.ServeScapeSandbox(ctx, os.Stdin, os.Stdout)
+		}},
+	}
+}
+
+func lookupCommand(name string) (command, bool) {
+	for _, cmd := range commandRegistry() {
+		if cmd.Name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+func commandNames() []string {
+	var names []string
+	for _, cmd := range commandRegistry() {
+		if !cmd.Hidden {
+			names = append(names, cmd.Name)
+		}
+	}
+	return names
+}
+
+// flagSetObserver, when set, receives every flag set a command creates.
+// Command introspection uses it to read a command's flags by running it
+// with -h.
+var flagSetObserver func(*flag.FlagSet)
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	if flagSetObserver != nil {
+		fs.SetOutput(io.Discard)
+		flagSetObserver(fs)
+	}
+	return fs
+}
+
+// captureFlags runs fn, which must only parse -h, and returns the flags of
+// the last flag set it created.
+func captureFlags(fn func()) []flagMetadata {
+	var sets []*flag.FlagSet
+	flagSetObserver = func(fs *flag.FlagSet) {
+		sets = append(sets, fs)
+	}
+	defer func() {
+		flagSetObserver = nil
+	}()
+	fn()
+	if len(sets) == 0 {
+		return nil
+	}
+	var flags []flagMetadata
+	sets[len(sets)-1].VisitAll(func(f *flag.Flag) {
+		flags = append(flags, describeFlag(f))
+	})
+	return flags
+}
+
+// commandMetadata describes a command for tools that build interfaces over
+// the CLI.
+type commandMetadata struct {
+	Name        string            `json:"name"`
+	Summary     string            `json:"summary,omitempty"`
+	Args        []string          `json:"args,omitempty"`
+	Flags       []flagMetadata    `json:"flags,omitempty"`
+	Subcommands []commandMetadata `json:"subcommands,omitempty"`
+}
+
+type flagMetadata struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Default    string   `json:"default,omitempty"`
+	Usage      string   `json:"usage"`
+	Values     []string `json:"values,omitempty"`
+	Repeatable bool     `json:"repeatable,omitempty"`
+}
+
+type cliMetadata struct {
+	Program     string            `json:"program"`
+	GlobalFlags []flagMetadata    `json:"global_flags"`
+	Commands    []commandMetadata `json:"commands"`
+}
+
+// flagValuesPattern matches the name|name|... enumeration that follows the
+// first colon of a flag's usage, as in "store backend: memory|sqlite|postgres|bolt".
+var flagValuesPattern = regexp.MustCompile(`^[a-z0-9_.+-]+(\|[a-z0-9_.+-]+)+`)
+
+func describeFlag(f *flag.Flag) flagMetadata {
+	meta := flagMetadata{
+		Name:    f.Name,
+		Type:    "string",
+		Default: f.DefValue,
+		Usage:   f.Usage,
+	}
+	switch value := f.Value.(type) {
+	case *stringListFlag:
+		meta.Repeatable = true
+	case flag.Getter:
+		switch value.Get().(type) {
+		case bool:
+			meta.Type = "bool"
+		case int, int64, uint, uint64:
+			meta.Type = "int"
+		case float64:
+			meta.Type = "float"
+		case time.Duration:
+			meta.Type = "duration"
+		}
+	default:
+		meta.Repeatable = strings.Contains(f.Usage, "repeatable")
+	}
+	if meta.Type == "string" {
+		if _, after, ok := strings.Cut(f.Usage, ": "); ok {
+			if values := flagValuesPattern.FindString(after); values != "" {
+				meta.Values = strings.Split(values, "|")
+			}
+		}
+	}
+	return meta
+}
+
+func describeCommand(ctx context.Context, cmd command) commandMetadata {
+	meta := commandMetadata{Name: cmd.Name, Summary: cmd.Summary, Args: cmd.Args}
+	if len(cmd.Subcommands) == 0 {
+		meta.Flags = captureFlags(func() {
+			_ = cmd.Run(ctx, []string{"-h"})
+		})
+		return meta
+	}
+	for _, sub := range cmd.Subcommands {
+		meta.Subcommands = append(meta.Subcommands, commandMetadata{
+			Name: sub,
+			Flags: captureFlags(func() {
+				_ = cmd.Run(ctx, []string{sub, "-h"})
+			}),
+		})
+	}
+	return meta
+}
+
+func describeCLI(ctx context.Context) cliMetadata {
+	meta := cliMetadata{
+		Program: "protogonosctl",
+		GlobalFlags: captureFlags(func() {
+			_, _, _ = parseGlobalOptions([]string{"-h"}, func(string) string { return "" })
+		}),
+	}
+	for _, cmd := range commandRegistry() {
+		if !cmd.Hidden {
+			meta.Commands = append(meta.Commands, describeCommand(ctx, cmd))
+		}
+	}
+	return meta
+}
+
+// runHelpJSON prints the metadata of the command path in args, or of the
+// whole CLI when args is empty.
+func runHelpJSON(ctx context.Context, args []string) error {
+	var out any
+	if len(args) == 0 {
+		out = describeCLI(ctx)
+	} else {
+		cmd, ok := lookupCommand(args[0])
+		if !ok || cmd.Hidden {
+			return usageError(fmt.Sprintf("unknown command: %s", args[0]))
+		}
+		meta := describeCommand(ctx, cmd)
+		out = meta
+		if len(args) > 1 {
+			index := slices.IndexFunc(meta.Subcommands, func(sub commandMetadata) bool {
+				return sub.Name == args[1]
+			})
+			if index < 0 {
+				return fmt.Errorf("unknown %s subcommand: %s", cmd.Name, args[1])
+			}
+			out = meta.Subcommands[index]
+		}
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
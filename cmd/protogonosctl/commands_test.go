@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDescribeCLICoversEveryCommandFlagSet(t *testing.T) {
+	meta := describeCLI(context.Background())
+	if len(meta.Commands) != len(commandNames()) {
+		t.Fatalf("expected %d described commands, got %d", len(commandNames()), len(meta.Commands))
+	}
+	for _, cmd := range meta.Commands {
+		if len(cmd.Subcommands) == 0 {
+			if len(cmd.Flags) == 0 && cmd.Name != "completion" {
+				t.Fatalf("command %s exposes no flags", cmd.Name)
+			}
+			continue
+		}
+		for _, sub := range cmd.Subcommands {
+			if len(sub.Flags) == 0 && cmd.Name+" "+sub.Name != "profile list" {
+				t.Fatalf("command %s %s exposes no flags", cmd.Name, sub.Name)
+			}
+		}
+	}
+
+	run := meta.Commands[slices.IndexFunc(meta.Commands, func(cmd commandMetadata) bool { return cmd.Name == "run" })]
+	flags := map[string]flagMetadata{}
+	for _, f := range run.Flags {
+		flags[f.Name] = f
+	}
+	if f := flags["selection"]; f.Type != "string" || !slices.Contains(f.Values, "species_tournament") || f.Default != "elite" {
+		t.Fatalf("unexpected selection flag metadata: %+v", f)
+	}
+	if f := flags["gens"]; f.Type != "int" || len(f.Values) != 0 {
+		t.Fatalf("unexpected gens flag metadata: %+v", f)
+	}
+	if f := flags["tuning"]; f.Type != "bool" {
+		t.Fatalf("unexpected tuning flag metadata: %+v", f)
+	}
+	if f := flags["scape-param"]; !f.Repeatable || len(f.Values) != 0 {
+		t.Fatalf("unexpected scape-param flag metadata: %+v", f)
+	}
+}
+
+func TestCompletionScriptsListCommandsFlagsAndValues(t *testing.T) {
+	meta := describeCLI(context.Background())
+
+	bash := bashCompletion(meta)
+	for _, want := range []string{
+		"complete -F _protogonosctl protogonosctl",
+		`store) echo "stats compact import-archive" ;;`,
+		`"run --selection") COMPREPLY=($(compgen -W "elite tournament`,
+		`"completion completion") COMPREPLY=($(compgen -W "bash zsh fish"`,
+		`"store stats") COMPREPLY=($(compgen -W "--db-path --json --limit --store"`,
+	} {
+		if !strings.Contains(bash, want) {
+			t.Fatalf("bash completion missing %q", want)
+		}
+	}
+	if zsh := zshCompletion(meta); !strings.HasPrefix(zsh, "#compdef protogonosctl") || !strings.Contains(zsh, bash) {
+		t.Fatal("zsh completion does not wrap the bash completion")
+	}
+
+	fish := fishCompletion(meta)
+	for _, want := range []string{
+		"complete -c protogonosctl -n '__fish_use_subcommand' -a run -d 'evolve a population on a scape'",
+		"complete -c protogonosctl -n '__fish_seen_subcommand_from run' -l evolution-type -x -a 'generational steady_state online'",
+		"complete -c protogonosctl -n '__fish_seen_subcommand_from store; and __fish_seen_subcommand_from stats' -l json -d",
+	} {
+		if !strings.Contains(fish, want) {
+			t.Fatalf("fish completion missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var completionShells = []string{"bash", "zsh", "fish"}
+
+func runCompletion(ctx context.Context, args []string) error {
+	fs := newFlagSet("completion")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("completion requires a shell: " + strings.Join(completionShells, "|"))
+	}
+	meta := describeCLI(ctx)
+	var script string
+	switch fs.Arg(0) {
+	case "bash":
+		script = bashCompletion(meta)
+	case "zsh":
+		script = zshCompletion(meta)
+	case "fish":
+		script = fishCompletion(meta)
+	default:
+		return fmt.Errorf("unsupported completion shell: %s", fs.Arg(0))
+	}
+	_, err := os.Stdout.WriteString(script)
+	return err
+}
+
+// completionTarget is a command, or a command and subcommand, whose flags
+// are completed together.
+type completionTarget struct {
+	key   string
+	flags []flagMetadata
+}
+
+func completionTargets(meta cliMetadata) []completionTarget {
+	var targets []completionTarget
+	for _, cmd := range meta.Commands {
+		if len(cmd.Subcommands) == 0 {
+			targets = append(targets, completionTarget{key: cmd.Name, flags: cmd.Flags})
+			continue
+		}
+		for _, sub := range cmd.Subcommands {
+			targets = append(targets, completionTarget{key: cmd.Name + " " + sub.Name, flags: sub.Flags})
+		}
+	}
+	return targets
+}
+
+func flagWords(flags []flagMetadata) string {
+	words := make([]string, 0, len(flags))
+	for _, f := range flags {
+		words = append(words, "--"+f.Name)
+	}
+	return strings.Join(words, " ")
+}
+
+func bashCompletion(meta cliMetadata) string {
+	var b strings.Builder
+	b.WriteString("# bash completion for protogonosctl\n")
+	b.WriteString("_protogonosctl_subcommands() {\n\tcase \"$1\" in\n")
+	for _, cmd := range meta.Commands {
+		if len(cmd.Subcommands) > 0 {
+			names := make([]string, 0, len(cmd.Subcommands))
+			for _, sub := range cmd.Subcommands {
+				names = append(names, sub.Name)
+			}
+			fmt.Fprintf(&b, "\t%s) echo %q ;;\n", cmd.Name, strings.Join(names, " "))
+		}
+	}
+	b.WriteString("\tesac\n}\n\n")
+
+	b.WriteString("_protogonosctl() {\n")
+	b.WriteString("\tlocal cur prev cmd=\"\" sub=\"\" cmd_index=0 i word subs key\n")
+	b.WriteString("\tCOMPREPLY=()\n")
+	b.WriteString("\tcur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	b.WriteString("\tprev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	b.WriteString("\tfor ((i = 1; i < COMP_CWORD; i++)); do\n")
+	b.WriteString("\t\tword=\"${COMP_WORDS[i]}\"\n")
+	b.WriteString("\t\tif [[ -z \"$cmd\" ]]; then\n")
+	b.WriteString("\t\t\tcase \"$word\" in\n")
+	fmt.Fprintf(&b, "\t\t\t%s) ((i++)) ;;\n", strings.ReplaceAll(valueFlagWords(meta.GlobalFlags), " ", "|"))
+	b.WriteString("\t\t\t-*) ;;\n")
+	b.WriteString("\t\t\t*) cmd=\"$word\" cmd_index=$i ;;\n")
+	b.WriteString("\t\t\tesac\n")
+	b.WriteString("\t\telif ((i == cmd_index + 1)) && [[ -n \"$(_protogonosctl_subcommands \"$cmd\")\" ]]; then\n")
+	b.WriteString("\t\t\tsub=\"$word\"\n")
+	b.WriteString("\t\tfi\n")
+	b.WriteString("\tdone\n\n")
+
+	b.WriteString("\tif [[ -z \"$cmd\" ]]; then\n")
+	b.WriteString("\t\tcase \"$prev\" in\n")
+	writeBashValueCases(&b, "", meta.GlobalFlags)
+	b.WriteString("\t\tesac\n")
+	fmt.Fprintf(&b, "\t\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(meta.commandNames(), " ")+" "+flagWords(meta.GlobalFlags))
+	b.WriteString("\t\treturn\n\tfi\n")
+	b.WriteString("\tsubs=\"$(_protogonosctl_subcommands \"$cmd\")\"\n")
+	b.WriteString("\tif [[ -n \"$subs\" && -z \"$sub\" ]]; then\n")
+	b.WriteString("\t\tCOMPREPLY=($(compgen -W \"$subs\" -- \"$cur\"))\n")
+	b.WriteString("\t\treturn\n\tfi\n")
+	b.WriteString("\tkey=\"$cmd\"\n")
+	b.WriteString("\t[[ -n \"$sub\" ]] && key=\"$cmd $sub\"\n\n")
+
+	b.WriteString("\tcase \"$key $prev\" in\n")
+	for _, target := range completionTargets(meta) {
+		writeBashValueCases(&b, target.key+" ", target.flags)
+	}
+	for _, cmd := range meta.Commands {
+		if len(cmd.Args) > 0 {
+			fmt.Fprintf(&b, "\t\"%s %s\") COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;\n", cmd.Name, cmd.Name, strings.Join(cmd.Args, " "))
+		}
+	}
+	b.WriteString("\tesac\n\n")
+
+	b.WriteString("\tcase \"$key\" in\n")
+	for _, target := range completionTargets(meta) {
+		if len(target.flags) > 0 {
+			fmt.Fprintf(&b, "\t\"%s\") COMPREPLY=($(compgen -W %q -- \"$cur\")) ;;\n", target.key, flagWords(target.flags))
+		}
+	}
+	b.WriteString("\tesac\n}\n\n")
+	b.WriteString("complete -F _protogonosctl protogonosctl\n")
+	return b.String()
+}
+
+func writeBashValueCases(b *strings.Builder, prefix string, flags []flagMetadata) {
+	for _, f := range flags {
+		if len(f.Values) > 0 {
+			fmt.Fprintf(b, "\t\"%s--%s\") COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;\n", prefix, f.Name, strings.Join(f.Values, " "))
+		}
+	}
+}
+
+// valueFlagWords lists the flags that take a value.
+func valueFlagWords(flags []flagMetadata) string {
+	var words []string
+	for _, f := range flags {
+		if f.Type != "bool" {
+			words = append(words, "--"+f.Name)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// zshCompletion wraps the bash script with zsh's bash completion emulation.
+func zshCompletion(meta cliMetadata) string {
+	return "#compdef protogonosctl\n\nautoload -U +X bashcompinit && bashcompinit\n\n" + bashCompletion(meta)
+}
+
+func fishCompletion(meta cliMetadata) string {
+	var b strings.Builder
+	b.WriteString("# fish completion for protogonosctl\n")
+	b.WriteString("complete -c protogonosctl -f\n")
+	for _, f := range meta.GlobalFlags {
+		writeFishFlag(&b, "__fish_use_subcommand", f)
+	}
+	for _, cmd := range meta.Commands {
+		fmt.Fprintf(&b, "complete -c protogonosctl -n '__fish_use_subcommand' -a %s -d %s\n", cmd.Name, fishQuote(cmd.Summary))
+		seen := "__fish_seen_subcommand_from " + cmd.Name
+		if len(cmd.Args) > 0 {
+			fmt.Fprintf(&b, "complete -c protogonosctl -n %s -a %s\n", fishQuote(seen), fishQuote(strings.Join(cmd.Args, " ")))
+		}
+		if len(cmd.Subcommands) == 0 {
+			for _, f := range cmd.Flags {
+				writeFishFlag(&b, seen, f)
+			}
+			continue
+		}
+		names := make([]string, 0, len(cmd.Subcommands))
+		for _, sub := range cmd.Subcommands {
+			names = append(names, sub.Name)
+		}
+		fmt.Fprintf(&b, "complete -c protogonosctl -n %s -a %s\n",
+			fishQuote(seen+"; and not __fish_seen_subcommand_from "+strings.Join(names, " ")),
+			fishQuote(strings.Join(names, " ")))
+		for _, sub := range cmd.Subcommands {
+			for _, f := range sub.Flags {
+				writeFishFlag(&b, seen+"; and __fish_seen_subcommand_from "+sub.Name, f)
+			}
+		}
+	}
+	return b.String()
+}
+
+func writeFishFlag(b *strings.Builder, condition string, f flagMetadata) {
+	fmt.Fprintf(b, "complete -c protogonosctl -n %s -l %s", fishQuote(condition), f.Name)
+	switch {
+	case len(f.Values) > 0:
+		fmt.Fprintf(b, " -x -a %s", fishQuote(strings.Join(f.Values, " ")))
+	case f.Type != "bool":
+		b.WriteString(" -r")
+	}
+	fmt.Fprintf(b, " -d %s\n", fishQuote(f.Usage))
+}
+
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+func (m cliMetadata) commandNames() []string {
+	names := make([]string, 0, len(m.Commands))
+	for _, cmd := range m.Commands {
+		names = append(names, cmd.Name)
+	}
+	return names
+}
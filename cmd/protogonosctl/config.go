@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -13,14 +14,10 @@ import (
 )
 
 func loadRunRequestFromConfig(path string) (protoapi.RunRequest, error) {
-	data, err := os.ReadFile(path)
+	raw, err := readRunConfigJSON(path, nil)
 	if err != nil {
 		return protoapi.RunRequest{}, err
 	}
-	var raw map[string]any
-	if err := json.Unmarshal(data, &raw); err != nil {
-		return protoapi.RunRequest{}, err
-	}
 
 	var req protoapi.RunRequest
 	if v, ok := asString(raw["run_id"]); ok {
@@ -29,12 +26,21 @@ func loadRunRequestFromConfig(path string) (protoapi.RunRequest, error) {
 	if v, ok := asString(raw["continue_population_id"]); ok {
 		req.ContinuePopulationID = v
 	}
+	if v, ok := asString(raw["init_from_champions"]); ok {
+		req.InitFromChampions = v
+	}
 	if v, ok := asString(raw["specie_identifier"]); ok {
 		req.SpecieIdentifier = v
 	}
 	if v, ok := asString(raw["scape"]); ok {
 		req.Scape = v
 	}
+	if params, ok := raw["scape_params"].(map[string]any); ok {
+		req.ScapeParams = make(map[string]string, len(params))
+		for key, value := range params {
+			req.ScapeParams[key] = fmt.Sprint(value)
+		}
+	}
 	if v, ok := asString(raw["gtsa_csv_path"]); ok {
 		req.GTSACSVPath = v
 	}
@@ -139,6 +145,12 @@ func loadRunRequestFromConfig(path string) (protoapi.RunRequest, error) {
 	if v, ok := asString(raw["evolution_type"]); ok {
 		req.EvolutionType = mapPopulationEvolutionType(v)
 	}
+	if v, ok := asString(raw["encoding"]); ok {
+		req.Encoding = v
+	}
+	if v, ok := asString(raw["algorithm"]); ok {
+		req.Algorithm = v
+	}
 	if v, ok := asInt(raw["population"]); ok {
 		req.Population = v
 	}
@@ -166,12 +178,206 @@ func loadRunRequestFromConfig(path string) (protoapi.RunRequest, error) {
 	if v, ok := asInt(raw["auto_continue_ms"]); ok {
 		req.AutoContinueAfter = time.Duration(v) * time.Millisecond
 	}
+	if v, ok := asBool(raw["no_batch_eval"]); ok {
+		req.DisableBatchEvaluation = v
+	}
+	if v, ok := asBool(raw["no_eval_cache"]); ok {
+		req.DisableEvalCache = v
+	}
+	if v, ok := asBool(raw["meter_evaluations"]); ok {
+		req.MeterEvaluations = v
+	}
+	if v, ok := asBool(raw["verify_invariants"]); ok {
+		req.VerifyInvariants = v
+	}
+	if v, ok := asBool(raw["no_regression_watch"]); ok {
+		req.DisableRegressionWatch = v
+	}
+	if v, ok := asFloat64(raw["regression_tolerance"]); ok {
+		req.RegressionTolerance = v
+	}
+	if v, ok := asInt64(raw["selection_seed"]); ok {
+		req.SelectionSeed = int64Ptr(v)
+	}
+	if v, ok := asInt64(raw["mutation_seed"]); ok {
+		req.MutationSeed = int64Ptr(v)
+	}
+	if v, ok := asInt64(raw["env_seed"]); ok {
+		req.EnvSeed = int64Ptr(v)
+	}
 	if v, ok := asInt64(raw["seed"]); ok {
 		req.Seed = v
 	}
 	if v, ok := asInt(raw["workers"]); ok {
 		req.Workers = v
 	}
+	if v, ok := asInt(raw["evaluation_trials"]); ok {
+		req.EvaluationTrials = v
+	}
+	if v, ok := asBool(raw["ci_tiebreak"]); ok {
+		req.CITieBreak = v
+	}
+	if v, ok := asBool(raw["parity_strict"]); ok {
+		req.ParityStrict = v
+	}
+	if v, ok := asString(raw["trial_aggregation"]); ok {
+		req.TrialAggregation = v
+	}
+	if v, ok := asFloat64(raw["cvar_alpha"]); ok {
+		req.CVaRAlpha = v
+	}
+	if v, ok := asString(raw["fitness_scaling"]); ok {
+		req.FitnessScaling = v
+	}
+	if v, ok := asFloat64(raw["scaling_pressure"]); ok {
+		req.ScalingPressure = v
+	}
+	if v, ok := asString(raw["species_allocation"]); ok {
+		req.SpeciesAllocation = v
+	}
+	if v, ok := asInt(raw["allocation_floor"]); ok {
+		req.AllocationFloor = v
+	}
+	if v, ok := asInt(raw["allocation_ceiling"]); ok {
+		req.AllocationCeiling = v
+	}
+	if v, ok := asInt(raw["species_soft_cap"]); ok {
+		req.SpeciesSoftCap = v
+	}
+	if v, ok := asFloat64(raw["species_cap_hysteresis"]); ok {
+		req.SpeciesCapHysteresis = v
+	}
+	if v, ok := asString(raw["population_resize"]); ok {
+		req.PopulationResize = v
+	}
+	if v, ok := asInt(raw["min_population"]); ok {
+		req.MinPopulation = v
+	}
+	if v, ok := asInt(raw["max_population"]); ok {
+		req.MaxPopulation = v
+	}
+	if v, ok := asFloat64(raw["resize_target_seconds"]); ok {
+		req.ResizeTargetSeconds = v
+	}
+	if v, ok := asFloat64(raw["low_fidelity"]); ok {
+		req.LowFidelity = v
+	}
+	if v, ok := asFloat64(raw["finalist_fraction"]); ok {
+		req.FinalistFraction = v
+	}
+	if v, ok := asFloat64(raw["activation_clamp"]); ok {
+		req.ActivationClamp = v
+	}
+	if v, ok := asFloat64(raw["weight_clamp"]); ok {
+		req.WeightClamp = v
+	}
+	if v, ok := asFloat64(raw["crossover_rate"]); ok {
+		req.CrossoverRate = v
+	}
+	if v, ok := asFloat64(raw["interspecies_mating"]); ok {
+		req.InterspeciesMating = v
+	}
+	if v, ok := asInt(raw["prune_disabled_after"]); ok {
+		req.PruneDisabledAfter = v
+	}
+	if v, ok := asFloat64(raw["prune_disabled_prob"]); ok {
+		req.PruneDisabledProb = v
+	}
+	if v, ok := asBool(raw["track_innovations"]); ok {
+		req.TrackInnovations = v
+	}
+	if v, ok := asInt(raw["initial_sensors_min"]); ok {
+		req.InitialSensorsMin = v
+	}
+	if v, ok := asInt(raw["initial_sensors_max"]); ok {
+		req.InitialSensorsMax = v
+	}
+	if v, ok := asInt(raw["eval_timeout_ms"]); ok {
+		req.EvaluationTimeout = time.Duration(v) * time.Millisecond
+	}
+	if v, ok := asBool(raw["scape_sandbox"]); ok {
+		req.ScapeSandbox = v
+	}
+	if v, ok := asString(raw["sandbox_command"]); ok {
+		req.SandboxCommand = v
+	}
+	if v, ok := asInt(raw["sandbox_cpu_seconds"]); ok {
+		req.SandboxCPUSeconds = v
+	}
+	if v, ok := asInt(raw["sandbox_memory_mb"]); ok {
+		req.SandboxMemoryMB = v
+	}
+	if v, ok := asInt(raw["sandbox_timeout_ms"]); ok {
+		req.SandboxTimeout = time.Duration(v) * time.Millisecond
+	}
+	if v, ok := asFloat64(raw["sandbox_failure_fitness"]); ok {
+		req.SandboxFailureFitness = v
+	}
+	if v, ok := asInt(raw["karma_strikes"]); ok {
+		req.KarmaStrikes = v
+	}
+	if v, ok := asInt(raw["karma_cooldown"]); ok {
+		req.KarmaCooldown = v
+	}
+	if v, ok := asString(raw["stop"]); ok {
+		req.StopCondition = v
+	}
+	if v, ok := asFloat64(raw["entropy_threshold"]); ok {
+		req.EntropyThreshold = v
+	}
+	if v, ok := asString(raw["entropy_measure"]); ok {
+		req.EntropyMeasure = v
+	}
+	if v, ok := asString(raw["entropy_action"]); ok {
+		req.EntropyAction = v
+	}
+	if v, ok := asInt(raw["entropy_cooldown"]); ok {
+		req.EntropyCooldown = v
+	}
+	if v, ok := asInt(raw["reproduction_interval"]); ok {
+		req.ReproductionInterval = v
+	}
+	if v, ok := asInt(raw["diagnostics_window"]); ok {
+		req.DiagnosticsWindow = v
+	}
+	if v, ok := asInt(raw["islands"]); ok {
+		req.Islands = v
+	}
+	if v, ok := asInt(raw["migration_interval"]); ok {
+		req.MigrationInterval = v
+	}
+	if v, ok := asInt(raw["migrants"]); ok {
+		req.Migrants = v
+	}
+	if v, ok := asInt(raw["checkpoint_interval"]); ok {
+		req.CheckpointInterval = v
+	}
+	if v, ok := asInt(raw["snapshot_interval"]); ok {
+		req.SnapshotInterval = v
+	}
+	if v, ok := asInt(raw["recurrent_loop_max_length"]); ok {
+		req.RecurrentLoopMaxLength = v
+	}
+	if v, ok := asFloat64(raw["bias_max_delta"]); ok {
+		req.BiasMaxDelta = v
+	}
+	if v, ok := asString(raw["modules"]); ok {
+		req.Modules = splitCommaList(v)
+	}
+	if xs, ok := asAnySlice(raw["modules"]); ok {
+		if joined, ok := joinStringSlice(xs); ok {
+			req.Modules = splitCommaList(joined)
+		}
+	}
+	if v, ok := asInt(raw["tournament_size"]); ok {
+		req.TournamentSize = v
+	}
+	if v, ok := asBool(raw["tournament_no_replace"]); ok {
+		req.TournamentNoReplace = v
+	}
+	if v, ok := asFloat64(raw["tournament_win_prob"]); ok {
+		req.TournamentWinProb = v
+	}
 	if v, ok := asBool(raw["enable_tuning"]); ok {
 		req.EnableTuning = v
 	}
@@ -202,6 +408,12 @@ func loadRunRequestFromConfig(path string) (protoapi.RunRequest, error) {
 	if v, ok := asFloat64(raw["tune_min_improvement"]); ok {
 		req.TuneMinImprovement = v
 	}
+	if v, ok := asBool(raw["tuning_trace"]); ok {
+		req.TuningTrace = v
+	}
+	if v, ok := asString(raw["tune_acceptance"]); ok {
+		req.TuneAcceptance = v
+	}
 	if v, ok := asString(raw["tune_duration_policy"]); ok {
 		req.TuneDurationPolicy = v
 	}
@@ -349,6 +561,82 @@ func loadRunRequestFromConfig(path string) (protoapi.RunRequest, error) {
 	return req, nil
 }
 
+// readRunConfigJSON reads a run config file and resolves its "extends" key:
+// a path, or a list of paths applied in order, to base configs relative to
+// the file's directory. Bases may extend others. The file's own keys are
+// deep-merged over its bases: objects merge key by key and any other value,
+// arrays and null included, replaces the inherited one, so null unsets an
+// inherited key.
+func readRunConfigJSON(path string, extending []string) (map[string]any, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, seen := range extending {
+		if seen == absPath {
+			return nil, fmt.Errorf("config extends cycle: %s", strings.Join(append(extending, absPath), " -> "))
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	extends, ok := raw["extends"]
+	if !ok {
+		return raw, nil
+	}
+	delete(raw, "extends")
+
+	var bases []string
+	switch v := extends.(type) {
+	case string:
+		bases = []string{v}
+	case []any:
+		for _, item := range v {
+			base, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: extends must list config paths", path)
+			}
+			bases = append(bases, base)
+		}
+	default:
+		return nil, fmt.Errorf("%s: extends must be a config path or a list of them", path)
+	}
+	merged := map[string]any{}
+	for _, base := range bases {
+		if strings.TrimSpace(base) == "" {
+			return nil, fmt.Errorf("%s: extends has an empty config path", path)
+		}
+		if !filepath.IsAbs(base) {
+			base = filepath.Join(filepath.Dir(path), base)
+		}
+		inherited, err := readRunConfigJSON(base, append(extending, absPath))
+		if err != nil {
+			return nil, err
+		}
+		merged = mergeConfigJSON(merged, inherited)
+	}
+	return mergeConfigJSON(merged, raw), nil
+}
+
+// mergeConfigJSON deep-merges override into base and returns base.
+func mergeConfigJSON(base, override map[string]any) map[string]any {
+	for key, value := range override {
+		overrideObject, ok := value.(map[string]any)
+		baseObject, baseOK := base[key].(map[string]any)
+		if ok && baseOK {
+			base[key] = mergeConfigJSON(baseObject, overrideObject)
+			continue
+		}
+		base[key] = value
+	}
+	return base
+}
+
 func asString(v any) (string, bool) {
 	s, ok := v.(string)
 	return s, ok
@@ -599,10 +887,14 @@ func overrideFromFlags(req *protoapi.RunRequest, set map[string]bool, flagValue
 			req.RunID = v.(string)
 		case "continue-pop-id":
 			req.ContinuePopulationID = v.(string)
+		case "init-from-champions":
+			req.InitFromChampions = v.(string)
 		case "specie-identifier":
 			req.SpecieIdentifier = v.(string)
 		case "scape":
 			req.Scape = v.(string)
+		case "scape-param":
+			req.ScapeParams = v.(map[string]string)
 		case "gtsa-profile":
 			req.GTSAProfile = v.(string)
 		case "gtsa-csv":
@@ -647,6 +939,10 @@ func overrideFromFlags(req *protoapi.RunRequest, set map[string]bool, flagValue
 			req.OpMode = v.(string)
 		case "evolution-type":
 			req.EvolutionType = mapPopulationEvolutionType(v.(string))
+		case "encoding":
+			req.Encoding = v.(string)
+		case "algorithm":
+			req.Algorithm = v.(string)
 		case "pop":
 			req.Population = v.(int)
 		case "gens":
@@ -665,10 +961,118 @@ func overrideFromFlags(req *protoapi.RunRequest, set map[string]bool, flagValue
 			req.StartPaused = v.(bool)
 		case "auto-continue-ms":
 			req.AutoContinueAfter = time.Duration(v.(int)) * time.Millisecond
+		case "no-batch-eval":
+			req.DisableBatchEvaluation = v.(bool)
+		case "no-eval-cache":
+			req.DisableEvalCache = v.(bool)
+		case "meter-evaluations":
+			req.MeterEvaluations = v.(bool)
+		case "verify-invariants":
+			req.VerifyInvariants = v.(bool)
+		case "no-regression-watch":
+			req.DisableRegressionWatch = v.(bool)
+		case "regression-tolerance":
+			req.RegressionTolerance = v.(float64)
 		case "seed":
 			req.Seed = v.(int64)
 		case "workers":
 			req.Workers = v.(int)
+		case "trials":
+			req.EvaluationTrials = v.(int)
+		case "ci-tiebreak":
+			req.CITieBreak = v.(bool)
+		case "parity-strict":
+			req.ParityStrict = v.(bool)
+		case "trial-aggregation":
+			req.TrialAggregation = v.(string)
+		case "cvar-alpha":
+			req.CVaRAlpha = v.(float64)
+		case "fitness-scaling":
+			req.FitnessScaling = v.(string)
+		case "scaling-pressure":
+			req.ScalingPressure = v.(float64)
+		case "species-allocation":
+			req.SpeciesAllocation = v.(string)
+		case "allocation-floor":
+			req.AllocationFloor = v.(int)
+		case "allocation-ceiling":
+			req.AllocationCeiling = v.(int)
+		case "species-soft-cap":
+			req.SpeciesSoftCap = v.(int)
+		case "species-cap-hysteresis":
+			req.SpeciesCapHysteresis = v.(float64)
+		case "population-resize":
+			req.PopulationResize = v.(string)
+		case "min-population":
+			req.MinPopulation = v.(int)
+		case "max-population":
+			req.MaxPopulation = v.(int)
+		case "resize-target-seconds":
+			req.ResizeTargetSeconds = v.(float64)
+		case "low-fidelity":
+			req.LowFidelity = v.(float64)
+		case "finalist-fraction":
+			req.FinalistFraction = v.(float64)
+		case "activation-clamp":
+			req.ActivationClamp = v.(float64)
+		case "weight-clamp":
+			req.WeightClamp = v.(float64)
+		case "crossover-rate":
+			req.CrossoverRate = v.(float64)
+		case "interspecies-mating":
+			req.InterspeciesMating = v.(float64)
+		case "prune-disabled-after":
+			req.PruneDisabledAfter = v.(int)
+		case "prune-disabled-prob":
+			req.PruneDisabledProb = v.(float64)
+		case "track-innovations":
+			req.TrackInnovations = v.(bool)
+		case "initial-sensors-min":
+			req.InitialSensorsMin = v.(int)
+		case "initial-sensors-max":
+			req.InitialSensorsMax = v.(int)
+		case "eval-timeout-ms":
+			req.EvaluationTimeout = time.Duration(v.(int)) * time.Millisecond
+		case "scape-sandbox":
+			req.ScapeSandbox = v.(bool)
+		case "sandbox-command":
+			req.SandboxCommand = v.(string)
+		case "sandbox-cpu-seconds":
+			req.SandboxCPUSeconds = v.(int)
+		case "sandbox-memory-mb":
+			req.SandboxMemoryMB = v.(int)
+		case "sandbox-timeout-ms":
+			req.SandboxTimeout = time.Duration(v.(int)) * time.Millisecond
+		case "sandbox-failure-fitness":
+			req.SandboxFailureFitness = v.(float64)
+		case "karma-strikes":
+			req.KarmaStrikes = v.(int)
+		case "karma-cooldown":
+			req.KarmaCooldown = v.(int)
+		case "stop":
+			req.StopCondition = v.(string)
+		case "entropy-threshold":
+			req.EntropyThreshold = v.(float64)
+		case "entropy-measure":
+			req.EntropyMeasure = v.(string)
+		case "entropy-action":
+			req.EntropyAction = v.(string)
+		case "entropy-cooldown":
+			req.EntropyCooldown = v.(int)
+		case "reproduction-interval":
+			req.ReproductionInterval = v.(int)
+		case "diagnostics-window":
+			req.DiagnosticsWindow = v.(int)
+		case "islands":
+			req.Islands = v.(int)
+		case "migration-interval":
+			req.MigrationInterval = v.(int)
+		case "migrants":
+			req.Migrants = v.(int)
+		case "checkpoint-interval":
+			req.CheckpointInterval = v.(int)
+		case "snapshot-interval":
+			req.SnapshotInterval = v.(int)
 		case "tuning":
 			req.EnableTuning = v.(bool)
 		case "compare-tuning":
@@ -679,6 +1083,12 @@ func overrideFromFlags(req *protoapi.RunRequest, set map[string]bool, flagValue
 			req.TestProbe = v.(bool)
 		case "selection":
 			req.Selection = v.(string)
+		case "tournament-size":
+			req.TournamentSize = v.(int)
+		case "tournament-no-replace":
+			req.TournamentNoReplace = v.(bool)
+		case "tournament-win-prob":
+			req.TournamentWinProb = v.(float64)
 		case "fitness-postprocessor":
 			req.FitnessPostprocessor = v.(string)
 		case "topo-policy":
@@ -701,6 +1111,10 @@ func overrideFromFlags(req *protoapi.RunRequest, set map[string]bool, flagValue
 			req.TuneAnnealingFactor = v.(float64)
 		case "tune-min-improvement":
 			req.TuneMinImprovement = v.(float64)
+		case "tuning-trace":
+			req.TuningTrace = v.(bool)
+		case "tune-acceptance":
+			req.TuneAcceptance = v.(string)
 		case "tune-selection":
 			req.TuneSelection = v.(string)
 		case "tune-duration-policy":
@@ -731,6 +1145,22 @@ func overrideFromFlags(req *protoapi.RunRequest, set map[string]bool, flagValue
 			req.WeightPlasticityRule = v.(float64)
 		case "w-substrate":
 			req.WeightSubstrate = v.(float64)
+		case "w-recurrent-loop":
+			req.WeightRecurrentLoop = v.(float64)
+		case "recurrent-loop-max-length":
+			req.RecurrentLoopMaxLength = v.(int)
+		case "w-duplicate-neuron":
+			req.WeightDuplicateNeuron = v.(float64)
+		case "w-toggle-synapse":
+			req.WeightToggleSynapse = v.(float64)
+		case "w-insert-module":
+			req.WeightInsertModule = v.(float64)
+		case "modules":
+			req.Modules = splitCommaList(v.(string))
+		case "w-all-biases":
+			req.WeightAllBiases = v.(float64)
+		case "bias-max-delta":
+			req.BiasMaxDelta = v.(float64)
 		}
 	}
 	if req.Scape == "" {
@@ -798,7 +1228,7 @@ func mapSpecieIdentifier(distinguishers []string) string {
 
 func mapPopulationEvolutionType(name string) string {
 	switch name {
-	case "generational", "steady_state":
+	case "generational", "steady_state", "online":
 		return name
 	default:
 		return name
@@ -826,6 +1256,10 @@ func intPtr(v int) *int {
 	return &v
 }
 
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
 func applyMutationOperatorWeights(req *protoapi.RunRequest, operators []map2rec.WeightedOperator) {
 	for _, op := range operators {
 		switch mutationWeightBucket(op.Name) {
@@ -853,6 +1287,16 @@ func applyMutationOperatorWeights(req *protoapi.RunRequest, operators []map2rec.
 			req.WeightPlasticityRule += op.Weight
 		case "substrate":
 			req.WeightSubstrate += op.Weight
+		case "recurrent_loop":
+			req.WeightRecurrentLoop += op.Weight
+		case "duplicate_neuron":
+			req.WeightDuplicateNeuron += op.Weight
+		case "toggle_synapse":
+			req.WeightToggleSynapse += op.Weight
+		case "insert_module":
+			req.WeightInsertModule += op.Weight
+		case "all_biases":
+			req.WeightAllBiases += op.Weight
 		}
 	}
 }
@@ -869,5 +1313,10 @@ func hasAnyMutationWeightValue(req protoapi.RunRequest) bool {
 		req.WeightRemoveNeuron > 0 ||
 		req.WeightPlasticityRule > 0 ||
 		req.WeightPlasticity > 0 ||
-		req.WeightSubstrate > 0
+		req.WeightSubstrate > 0 ||
+		req.WeightRecurrentLoop > 0 ||
+		req.WeightDuplicateNeuron > 0 ||
+		req.WeightToggleSynapse > 0 ||
+		req.WeightInsertModule > 0 ||
+		req.WeightAllBiases > 0
 }
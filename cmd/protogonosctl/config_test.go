@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	protoapi "protogonos/pkg/protogonos"
 )
 
 func TestLoadRunRequestFromConfigUsesConstraintAndPMP(t *testing.T) {
@@ -676,3 +679,716 @@ func TestNormalizeMutationOperatorNameLegacyCircuitAliases(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadRunRequestFromConfigParsesScapeParams(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_scape_params.json")
+	payload := map[string]any{
+		"scape": "parity",
+		"scape_params": map[string]any{
+			"n": 5,
+		},
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if req.ScapeParams["n"] != "5" {
+		t.Fatalf("expected scape_params n=5, got %+v", req.ScapeParams)
+	}
+}
+
+func TestLoadRunRequestFromConfigParsesStopCondition(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_stop.json")
+	data := []byte(`{"scape":"xor","stop":"best>=0.99 || (evals>=200000 && stagnation>=30)"}`)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if req.StopCondition != "best>=0.99 || (evals>=200000 && stagnation>=30)" {
+		t.Fatalf("unexpected stop condition: %q", req.StopCondition)
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"stop": true}, map[string]any{"stop": "generation>=10"}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.StopCondition != "generation>=10" {
+		t.Fatalf("expected --stop to override config, got %q", req.StopCondition)
+	}
+}
+
+func TestLoadRunRequestFromConfigMapsRecurrentLoopOperator(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_recurrent_loop.json")
+	payload := map[string]any{
+		"recurrent_loop_max_length": 2,
+		"constraint": map[string]any{
+			"mutation_operators": []any{
+				[]any{"add_outlink", 4.0},
+				[]any{"add_recurrent_loop", 1.5},
+			},
+		},
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if req.WeightRecurrentLoop != 1.5 || req.WeightAddSynapse != 4.0 || req.RecurrentLoopMaxLength != 2 {
+		t.Fatalf("unexpected recurrent loop mapping: weight=%f add_synapse=%f max=%d", req.WeightRecurrentLoop, req.WeightAddSynapse, req.RecurrentLoopMaxLength)
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"w-recurrent-loop": true}, map[string]any{"w-recurrent-loop": 0.25}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.WeightRecurrentLoop != 0.25 {
+		t.Fatalf("expected --w-recurrent-loop to override config, got %f", req.WeightRecurrentLoop)
+	}
+}
+
+func TestLoadRunRequestFromConfigMapsBiasControls(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_bias.json")
+	payload := map[string]any{
+		"bias_max_delta": 0.6,
+		"constraint": map[string]any{
+			"mutation_operators": []any{
+				[]any{"add_bias", 2.0},
+				[]any{"perturb_all_biases", 3.0},
+			},
+		},
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if req.WeightBias != 2.0 || req.WeightAllBiases != 3.0 || req.BiasMaxDelta != 0.6 {
+		t.Fatalf("unexpected bias mapping: bias=%f all_biases=%f max_delta=%f", req.WeightBias, req.WeightAllBiases, req.BiasMaxDelta)
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"bias-max-delta": true}, map[string]any{"bias-max-delta": 0.2}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.BiasMaxDelta != 0.2 {
+		t.Fatalf("expected --bias-max-delta to override config, got %f", req.BiasMaxDelta)
+	}
+}
+
+func TestLoadRunRequestFromConfigMapsFitnessScaling(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_scaling.json")
+	data, err := json.Marshal(map[string]any{
+		"fitness_scaling":  "sigma",
+		"scaling_pressure": 1.5,
+	})
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if req.FitnessScaling != "sigma" || req.ScalingPressure != 1.5 {
+		t.Fatalf("unexpected scaling mapping: %q %f", req.FitnessScaling, req.ScalingPressure)
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"fitness-scaling": true, "scaling-pressure": true}, map[string]any{"fitness-scaling": "rank", "scaling-pressure": 1.2}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.FitnessScaling != "rank" || req.ScalingPressure != 1.2 {
+		t.Fatalf("expected flags to override config scaling, got %q %f", req.FitnessScaling, req.ScalingPressure)
+	}
+}
+
+func TestLoadRunRequestFromConfigMapsSpeciesAllocation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_allocation.json")
+	data, err := json.Marshal(map[string]any{
+		"species_allocation": "rank",
+		"allocation_floor":   1,
+		"allocation_ceiling": 8,
+	})
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if req.SpeciesAllocation != "rank" || req.AllocationFloor != 1 || req.AllocationCeiling != 8 {
+		t.Fatalf("unexpected allocation mapping: %q floor=%d ceiling=%d", req.SpeciesAllocation, req.AllocationFloor, req.AllocationCeiling)
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"species-allocation": true, "allocation-ceiling": true}, map[string]any{"species-allocation": "equal", "allocation-ceiling": 4}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.SpeciesAllocation != "equal" || req.AllocationFloor != 1 || req.AllocationCeiling != 4 {
+		t.Fatalf("expected flags to override config allocation, got %q floor=%d ceiling=%d", req.SpeciesAllocation, req.AllocationFloor, req.AllocationCeiling)
+	}
+}
+
+func TestLoadRunRequestFromConfigMapsPopulationResize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_resize.json")
+	data, err := json.Marshal(map[string]any{
+		"population_resize":     "auto",
+		"min_population":        6,
+		"max_population":        40,
+		"resize_target_seconds": 2.5,
+	})
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if req.PopulationResize != "auto" || req.MinPopulation != 6 || req.MaxPopulation != 40 || req.ResizeTargetSeconds != 2.5 {
+		t.Fatalf("unexpected resize mapping: %q min=%d max=%d target=%f", req.PopulationResize, req.MinPopulation, req.MaxPopulation, req.ResizeTargetSeconds)
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"max-population": true, "resize-target-seconds": true}, map[string]any{"max-population": 20, "resize-target-seconds": 1.0}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.PopulationResize != "auto" || req.MinPopulation != 6 || req.MaxPopulation != 20 || req.ResizeTargetSeconds != 1 {
+		t.Fatalf("expected flags to override config resize, got %q min=%d max=%d target=%f", req.PopulationResize, req.MinPopulation, req.MaxPopulation, req.ResizeTargetSeconds)
+	}
+}
+
+func TestLoadRunRequestFromConfigMapsTuningTrace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_tuning_trace.json")
+	data, err := json.Marshal(map[string]any{"enable_tuning": true, "tuning_trace": true})
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if !req.TuningTrace {
+		t.Fatal("expected tuning_trace to map onto the request")
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"tuning-trace": true}, map[string]any{"tuning-trace": false}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.TuningTrace {
+		t.Fatal("expected --tuning-trace=false to override the config")
+	}
+}
+
+func TestLoadRunRequestFromConfigMapsTuneAcceptance(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_tune_acceptance.json")
+	data, err := json.Marshal(map[string]any{"enable_tuning": true, "tune_acceptance": "validation"})
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if req.TuneAcceptance != "validation" {
+		t.Fatalf("expected tune_acceptance to map onto the request, got=%q", req.TuneAcceptance)
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"tune-acceptance": true}, map[string]any{"tune-acceptance": "training"}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.TuneAcceptance != "training" {
+		t.Fatalf("expected --tune-acceptance to override the config, got=%q", req.TuneAcceptance)
+	}
+}
+
+func TestLoadRunRequestFromConfigMapsScapeSandbox(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_scape_sandbox.json")
+	data, err := json.Marshal(map[string]any{
+		"scape_sandbox":           true,
+		"sandbox_command":         "worker --flag",
+		"sandbox_cpu_seconds":     5,
+		"sandbox_memory_mb":       256,
+		"sandbox_timeout_ms":      1500,
+		"sandbox_failure_fitness": -1.0,
+	})
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if !req.ScapeSandbox || req.SandboxCommand != "worker --flag" || req.SandboxCPUSeconds != 5 || req.SandboxMemoryMB != 256 ||
+		req.SandboxTimeout != 1500*time.Millisecond || req.SandboxFailureFitness != -1 {
+		t.Fatalf("unexpected sandbox settings: %+v", req)
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"sandbox-timeout-ms": true}, map[string]any{"sandbox-timeout-ms": 200}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.SandboxTimeout != 200*time.Millisecond {
+		t.Fatalf("expected --sandbox-timeout-ms to override the config, got %s", req.SandboxTimeout)
+	}
+
+	if err := applySandboxCommandDefault(&req); err != nil || req.SandboxCommand != "worker --flag" {
+		t.Fatalf("expected an explicit sandbox command to be kept, got %q err=%v", req.SandboxCommand, err)
+	}
+	req.SandboxCommand = ""
+	if err := applySandboxCommandDefault(&req); err != nil || !strings.HasSuffix(req.SandboxCommand, " "+protoapi.ScapeWorkerCommand) {
+		t.Fatalf("expected the scape-worker subcommand as default sandbox command, got %q err=%v", req.SandboxCommand, err)
+	}
+}
+
+func TestLoadRunRequestFromConfigMapsMeterEvaluations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_meter_evaluations.json")
+	data, err := json.Marshal(map[string]any{"meter_evaluations": true})
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if !req.MeterEvaluations {
+		t.Fatal("expected meter_evaluations to map onto the request")
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"meter-evaluations": true}, map[string]any{"meter-evaluations": false}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.MeterEvaluations {
+		t.Fatal("expected --meter-evaluations=false to override the config")
+	}
+}
+
+func TestLoadRunRequestFromConfigMapsInitFromChampions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_init_from_champions.json")
+	data, err := json.Marshal(map[string]any{"init_from_champions": "scape=xor top=20"})
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if req.InitFromChampions != "scape=xor top=20" {
+		t.Fatalf("expected init_from_champions to map onto the request, got=%q", req.InitFromChampions)
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"init-from-champions": true}, map[string]any{"init-from-champions": "top=5"}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.InitFromChampions != "top=5" {
+		t.Fatalf("expected --init-from-champions to override the config, got=%q", req.InitFromChampions)
+	}
+}
+
+func TestLoadRunRequestFromConfigMapsVerifyInvariants(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_verify_invariants.json")
+	data, err := json.Marshal(map[string]any{"verify_invariants": true})
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if !req.VerifyInvariants {
+		t.Fatal("expected verify_invariants to map onto the request")
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"verify-invariants": true}, map[string]any{"verify-invariants": false}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.VerifyInvariants {
+		t.Fatal("expected --verify-invariants=false to override the config")
+	}
+}
+
+func TestLoadRunRequestFromConfigMapsOnlineCadence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_online.json")
+	data, err := json.Marshal(map[string]any{
+		"evolution_type":        "online",
+		"reproduction_interval": 3,
+		"diagnostics_window":    25,
+	})
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if req.EvolutionType != "online" || req.ReproductionInterval != 3 || req.DiagnosticsWindow != 25 {
+		t.Fatalf("unexpected online settings: type=%q interval=%d window=%d", req.EvolutionType, req.ReproductionInterval, req.DiagnosticsWindow)
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"diagnostics-window": true}, map[string]any{"diagnostics-window": 10}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.DiagnosticsWindow != 10 {
+		t.Fatalf("expected --diagnostics-window to override the config, got %d", req.DiagnosticsWindow)
+	}
+}
+
+func TestLoadRunRequestFromConfigMapsIslands(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_islands.json")
+	data, err := json.Marshal(map[string]any{
+		"islands":            4,
+		"migration_interval": 10,
+		"migrants":           2,
+	})
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if req.Islands != 4 || req.MigrationInterval != 10 || req.Migrants != 2 {
+		t.Fatalf("unexpected island settings: islands=%d interval=%d migrants=%d", req.Islands, req.MigrationInterval, req.Migrants)
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"migrants": true}, map[string]any{"migrants": 1}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.Migrants != 1 {
+		t.Fatalf("expected --migrants to override the config, got %d", req.Migrants)
+	}
+}
+
+func TestLoadRunRequestFromConfigMapsCheckpointInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_checkpoint.json")
+	if err := os.WriteFile(path, []byte(`{"checkpoint_interval": 5}`), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if req.CheckpointInterval != 5 {
+		t.Fatalf("expected checkpoint interval 5, got %d", req.CheckpointInterval)
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"checkpoint-interval": true}, map[string]any{"checkpoint-interval": 2}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.CheckpointInterval != 2 {
+		t.Fatalf("expected --checkpoint-interval to override the config, got %d", req.CheckpointInterval)
+	}
+}
+
+func TestLoadRunRequestFromConfigMapsSnapshotInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_snapshot.json")
+	if err := os.WriteFile(path, []byte(`{"snapshot_interval": 5}`), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if req.SnapshotInterval != 5 {
+		t.Fatalf("expected snapshot interval 5, got %d", req.SnapshotInterval)
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"snapshot-interval": true}, map[string]any{"snapshot-interval": 2}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.SnapshotInterval != 2 {
+		t.Fatalf("expected --snapshot-interval to override the config, got %d", req.SnapshotInterval)
+	}
+}
+
+func TestLoadRunRequestFromConfigMapsEncoding(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_encoding.json")
+	data, err := json.Marshal(map[string]any{"encoding": "substrate"})
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if req.Encoding != "substrate" {
+		t.Fatalf("expected encoding to map onto the request, got %q", req.Encoding)
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"encoding": true}, map[string]any{"encoding": "direct"}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.Encoding != "direct" {
+		t.Fatalf("expected --encoding to override the config, got %q", req.Encoding)
+	}
+}
+
+func TestLoadRunRequestFromConfigMapsAlgorithm(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_algorithm.json")
+	data, err := json.Marshal(map[string]any{"algorithm": "random_search"})
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if req.Algorithm != "random_search" {
+		t.Fatalf("expected algorithm to map onto the request, got %q", req.Algorithm)
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"algorithm": true}, map[string]any{"algorithm": "hill_climb"}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.Algorithm != "hill_climb" {
+		t.Fatalf("expected --algorithm to override the config, got %q", req.Algorithm)
+	}
+}
+
+func TestLoadRunRequestFromConfigMapsSpeciesSoftCap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_soft_cap.json")
+	data, err := json.Marshal(map[string]any{"species_soft_cap": 12, "species_cap_hysteresis": 0.25})
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if req.SpeciesSoftCap != 12 || req.SpeciesCapHysteresis != 0.25 {
+		t.Fatalf("expected the soft cap to map onto the request, got %d/%f", req.SpeciesSoftCap, req.SpeciesCapHysteresis)
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"species-soft-cap": true}, map[string]any{"species-soft-cap": 20}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.SpeciesSoftCap != 20 || req.SpeciesCapHysteresis != 0.25 {
+		t.Fatalf("expected --species-soft-cap to override only the cap, got %d/%f", req.SpeciesSoftCap, req.SpeciesCapHysteresis)
+	}
+}
+
+func TestLoadRunRequestFromConfigMapsDisabledSynapsePruning(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_prune.json")
+	data, err := json.Marshal(map[string]any{"prune_disabled_after": 10, "prune_disabled_prob": 0.2})
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if req.PruneDisabledAfter != 10 || req.PruneDisabledProb != 0.2 {
+		t.Fatalf("expected pruning to map onto the request, got %d/%f", req.PruneDisabledAfter, req.PruneDisabledProb)
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"prune-disabled-prob": true}, map[string]any{"prune-disabled-prob": 0.9}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.PruneDisabledAfter != 10 || req.PruneDisabledProb != 0.9 {
+		t.Fatalf("expected --prune-disabled-prob to override only the probability, got %d/%f", req.PruneDisabledAfter, req.PruneDisabledProb)
+	}
+}
+
+func TestLoadRunRequestFromConfigMapsTrackInnovations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_innovations.json")
+	if err := os.WriteFile(path, []byte(`{"track_innovations": true}`), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if !req.TrackInnovations {
+		t.Fatal("expected track_innovations to map onto the request")
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"track-innovations": true}, map[string]any{"track-innovations": false}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.TrackInnovations {
+		t.Fatal("expected --track-innovations=false to override the config")
+	}
+}
+
+func TestLoadRunRequestFromConfigMapsInitialSensorSubsets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_sensor_subsets.json")
+	if err := os.WriteFile(path, []byte(`{"initial_sensors_min": 2, "initial_sensors_max": 5}`), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if req.InitialSensorsMin != 2 || req.InitialSensorsMax != 5 {
+		t.Fatalf("expected sensor subset bounds to map onto the request, got %d/%d", req.InitialSensorsMin, req.InitialSensorsMax)
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"initial-sensors-max": true}, map[string]any{"initial-sensors-max": 8}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.InitialSensorsMin != 2 || req.InitialSensorsMax != 8 {
+		t.Fatalf("expected --initial-sensors-max to override only the maximum, got %d/%d", req.InitialSensorsMin, req.InitialSensorsMax)
+	}
+}
+
+func TestLoadRunRequestFromConfigDeepMergesExtendedConfigs(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, payload string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(payload), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+		return path
+	}
+	write("base.json", `{
+		"run_id": "base-run",
+		"scape": "xor",
+		"seed": 1,
+		"workers": 2,
+		"pmp": {"survival_percentage": 0.6, "generation_limit": 9, "specie_size_limit": 3},
+		"scape_params": {"a": 1, "b": 2}
+	}`)
+	write("families/fx.json", `{
+		"extends": "../base.json",
+		"scape": "fx",
+		"pmp": {"generation_limit": 20},
+		"scape_params": {"b": 3}
+	}`)
+	write("tuning.json", `{"tune_min_improvement": 0.015, "workers": 4}`)
+	leaf := write("experiments/fx-tuned.json", `{
+		"extends": ["../families/fx.json", "../tuning.json"],
+		"run_id": null,
+		"seed": 7
+	}`)
+
+	req, err := loadRunRequestFromConfig(leaf)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if req.Scape != "fx" || req.Seed != 7 || req.Workers != 4 || req.TuneMinImprovement != 0.015 {
+		t.Fatalf("expected later layers to override earlier ones, got scape=%s seed=%d workers=%d tune_min_improvement=%f", req.Scape, req.Seed, req.Workers, req.TuneMinImprovement)
+	}
+	if req.Generations != 20 || req.SurvivalPercentage != 0.6 || req.SpecieSizeLimit != 3 {
+		t.Fatalf("expected nested objects to merge key by key, got generations=%d survival=%f specie_size_limit=%d", req.Generations, req.SurvivalPercentage, req.SpecieSizeLimit)
+	}
+	if req.ScapeParams["a"] != "1" || req.ScapeParams["b"] != "3" {
+		t.Fatalf("expected merged scape params, got %v", req.ScapeParams)
+	}
+	if req.RunID != "" {
+		t.Fatalf("expected null to unset the inherited run id, got %q", req.RunID)
+	}
+
+	write("cycle-a.json", `{"extends": "cycle-b.json"}`)
+	cycle := write("cycle-b.json", `{"extends": ["cycle-a.json"]}`)
+	if _, err := loadRunRequestFromConfig(cycle); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("expected an extends cycle to fail, got %v", err)
+	}
+	if _, err := loadRunRequestFromConfig(write("bad.json", `{"extends": 3}`)); err == nil {
+		t.Fatal("expected a non-path extends to fail")
+	}
+	if _, err := loadRunRequestFromConfig(write("missing.json", `{"extends": "nowhere.json"}`)); err == nil {
+		t.Fatal("expected a missing base config to fail")
+	}
+}
+
+func TestLoadRunRequestFromConfigMapsParityStrict(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run_config_parity_strict.json")
+	if err := os.WriteFile(path, []byte(`{"parity_strict": true}`), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	req, err := loadRunRequestFromConfig(path)
+	if err != nil {
+		t.Fatalf("load run request: %v", err)
+	}
+	if !req.ParityStrict {
+		t.Fatal("expected parity_strict to map onto the request")
+	}
+	if err := overrideFromFlags(&req, map[string]bool{"parity-strict": true}, map[string]any{"parity-strict": false}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if req.ParityStrict {
+		t.Fatal("expected --parity-strict=false to override the config")
+	}
+}
+
+func TestParseScapeParams(t *testing.T) {
+	params, err := parseScapeParams([]string{"n=5", " mode = fast "})
+	if err != nil {
+		t.Fatalf("parse scape params: %v", err)
+	}
+	if params["n"] != "5" || params["mode"] != "fast" {
+		t.Fatalf("unexpected scape params: %+v", params)
+	}
+	if params, err := parseScapeParams(nil); err != nil || params != nil {
+		t.Fatalf("expected nil params for no flags, got %+v err=%v", params, err)
+	}
+	for _, bad := range []string{"n", "=5"} {
+		if _, err := parseScapeParams([]string{bad}); err == nil {
+			t.Fatalf("expected %q to be rejected", bad)
+		}
+	}
+}
@@ -3,7 +3,6 @@ package main
 import (
 	"context"
 	"errors"
-	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -14,7 +13,7 @@ import (
 )
 
 func runDataExtract(_ context.Context, args []string) error {
-	fs := flag.NewFlagSet("data-extract", flag.ContinueOnError)
+	fs := newFlagSet("data-extract")
 	scapeName := fs.String("scape", "", "target dataset shape: simple|gtsa|fx|epitopes|mnist|wine|chr-hmm|chrom-hmm-expanded|vowel-recognition|abc-pred1|hedge-fund|mines-vs-rocks")
 	inputPath := fs.String("in", "", "input CSV path")
 	outputPath := fs.String("out", "", "output CSV path")
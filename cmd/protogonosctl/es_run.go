@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"protogonos/internal/baseline"
+	protoapi "protogonos/pkg/protogonos"
+)
+
+func runESRun(ctx context.Context, args []string) error {
+	fs := newFlagSet("es-run")
+	configPath := fs.String("config", "", "optional run config JSON path (map2rec-backed) for the scape and its data")
+	runID := fs.String("run-id", "", "explicit run id (optional)")
+	scapeName := fs.String("scape", "xor", "scape name")
+	var scapeParams stringListFlag
+	fs.Var(&scapeParams, "scape-param", "scape construction parameter key=value, repeatable")
+	genomeEncoding := fs.String("encoding", "auto", "genotype-phenotype encoding: auto|direct|substrate")
+	population := fs.Int("pop", 50, "candidates per iteration (even: antithetic pairs)")
+	generations := fs.Int("gens", 100, "iteration count; the budget is pop*gens evaluations, as for run")
+	seed := fs.Int64("seed", 1, "rng seed")
+	workers := fs.Int("workers", 4, "worker count")
+	topologyRunID := fs.String("topology-run", "", "optimize the weights of this run's champion instead of the scape's seed genome")
+	sigma := fs.Float64("sigma", baseline.DefaultESSigma, "noise standard deviation")
+	learningRate := fs.Float64("learning-rate", baseline.DefaultESLearningRate, "Adam step size")
+	weightDecay := fs.Float64("weight-decay", 0, "L2 weight decay per step")
+	jsonOut := fs.Bool("json", false, "emit the summary as JSON")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	setFlags := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		setFlags[f.Name] = true
+	})
+
+	scapeParamValues, err := parseScapeParams(scapeParams)
+	if err != nil {
+		return err
+	}
+	req, err := loadOrDefaultRunRequest(*configPath)
+	if err != nil {
+		return err
+	}
+	if *configPath == "" {
+		req = protoapi.RunRequest{
+			Scape:       *scapeName,
+			ScapeParams: scapeParamValues,
+			Encoding:    *genomeEncoding,
+			RunID:       *runID,
+			Population:  *population,
+			Generations: *generations,
+			Seed:        *seed,
+			Workers:     *workers,
+		}
+	} else if err := overrideFromFlags(&req, setFlags, map[string]any{
+		"scape":       *scapeName,
+		"scape-param": scapeParamValues,
+		"encoding":    *genomeEncoding,
+		"run-id":      *runID,
+		"pop":         *population,
+		"gens":        *generations,
+		"seed":        *seed,
+		"workers":     *workers,
+	}); err != nil {
+		return err
+	}
+
+	client, err := protoapi.New(protoapi.Options{
+		StoreKind:     *storeKind,
+		DBPath:        *dbPath,
+		BenchmarksDir: benchmarksDir,
+		ExportsDir:    exportsDir,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	summary, err := client.ESRun(ctx, protoapi.ESRunRequest{
+		Run:           req,
+		TopologyRunID: *topologyRunID,
+		Sigma:         *sigma,
+		LearningRate:  *learningRate,
+		WeightDecay:   *weightDecay,
+	})
+	if err != nil {
+		return err
+	}
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(summary)
+	}
+	fmt.Printf("es_run completed run_id=%s scape=%s pop=%d gens=%d seed=%d parameters=%d evaluations=%d\n",
+		summary.RunID, req.Scape, req.Population, req.Generations, req.Seed, summary.Parameters, summary.Evaluations)
+	for i, best := range summary.BestByGeneration {
+		fmt.Printf("generation=%d best_fitness=%.6f\n", i+1, best)
+	}
+	fmt.Printf("final_best_fitness=%.6f\n", summary.FinalBestFitness)
+	fmt.Printf("artifacts_dir=%s\n", filepath.Clean(summary.ArtifactsDir))
+	return nil
+}
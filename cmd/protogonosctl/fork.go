@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	protoapi "protogonos/pkg/protogonos"
+)
+
+func runFork(ctx context.Context, args []string) error {
+	fs := newFlagSet("fork")
+	parentRunID := fs.String("run-id", "", "parent run id to branch from")
+	atGen := fs.Int("at-gen", 0, "parent generation to fork at (default: final generation; earlier ones need a parent run with --snapshot-interval)")
+	newRunID := fs.String("new-run-id", "", "explicit run id for the fork (optional)")
+	var sets stringListFlag
+	fs.Var(&sets, "set", "parameter override key=value (repeatable): "+strings.Join(protoapi.RunOverrideKeys(), "|"))
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *parentRunID == "" {
+		return errors.New("fork requires --run-id")
+	}
+	overrides, err := parseKeyValueFlags(sets)
+	if err != nil {
+		return err
+	}
+	if sandboxed, err := strconv.ParseBool(overrides["scape-sandbox"]); err == nil && sandboxed && overrides["sandbox-command"] == "" {
+		if overrides["sandbox-command"], err = defaultSandboxCommand(); err != nil {
+			return err
+		}
+	}
+
+	client, err := protoapi.New(protoapi.Options{
+		StoreKind:     *storeKind,
+		DBPath:        *dbPath,
+		BenchmarksDir: benchmarksDir,
+		ExportsDir:    exportsDir,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	summary, err := client.Fork(ctx, protoapi.ForkRequest{
+		ParentRunID:  *parentRunID,
+		AtGeneration: *atGen,
+		RunID:        *newRunID,
+		Set:          overrides,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("fork completed run_id=%s forked_from=%s overrides=%d\n", summary.RunID, *parentRunID, len(overrides))
+	for i, best := range summary.BestByGeneration {
+		fmt.Printf("generation=%d best_fitness=%.6f\n", i+1, best)
+	}
+	fmt.Printf("final_best_fitness=%.6f\n", summary.FinalBestFitness)
+	fmt.Printf("artifacts_dir=%s\n", filepath.Clean(summary.ArtifactsDir))
+	return nil
+}
+
+func parseKeyValueFlags(values []string) (map[string]string, error) {
+	out := make(map[string]string, len(values))
+	for _, raw := range values {
+		key, value, ok := strings.Cut(raw, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid key=value pair: %q", raw)
+		}
+		out[key] = strings.TrimSpace(value)
+	}
+	return out, nil
+}
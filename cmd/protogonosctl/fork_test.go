@@ -0,0 +1,19 @@
+package main
+
+import "testing"
+
+func TestParseKeyValueFlags(t *testing.T) {
+	got, err := parseKeyValueFlags([]string{"w-add-neuron=0.2", " selection = tournament "})
+	if err != nil {
+		t.Fatalf("parse key/value flags: %v", err)
+	}
+	if got["w-add-neuron"] != "0.2" || got["selection"] != "tournament" {
+		t.Fatalf("unexpected parsed overrides: %+v", got)
+	}
+	if _, err := parseKeyValueFlags([]string{"w-add-neuron"}); err == nil {
+		t.Fatal("expected missing '=' to fail")
+	}
+	if _, err := parseKeyValueFlags([]string{"=1"}); err == nil {
+		t.Fatal("expected empty key to fail")
+	}
+}
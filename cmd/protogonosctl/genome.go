@@ -0,0 +1,23 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+func runGenome(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("genome requires a subcommand: dot|schema|edit")
+	}
+	switch args[0] {
+	case "dot":
+		return runGenomeDot(ctx, args[1:])
+	case "schema":
+		return runGenomeSchema(ctx, args[1:])
+	case "edit":
+		return runGenomeEdit(ctx, args[1:])
+	default:
+		return fmt.Errorf("unsupported genome subcommand: %s", args[0])
+	}
+}
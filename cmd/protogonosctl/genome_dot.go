@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"protogonos/internal/model"
+	"protogonos/internal/stats"
+	"protogonos/internal/storage"
+	protoapi "protogonos/pkg/protogonos"
+)
+
+func runGenomeDot(ctx context.Context, args []string) error {
+	fs := newFlagSet("genome dot")
+	runID := fs.String("run-id", "", "run id whose top genome is rendered")
+	latest := fs.Bool("latest", false, "render a top genome of the most recent run from run index")
+	genomeID := fs.String("genome-id", "", "top genome to render (default: run champion)")
+	genomeFile := fs.String("genome", "", "render a genome JSON file instead of a run's top genome")
+	format := fs.String("format", "dot", "output format: dot|svg (svg uses a built-in layered layout, no GraphViz needed)")
+	outPath := fs.String("out", "", "write the graph to this file instead of stdout")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "dot" && *format != "svg" {
+		return fmt.Errorf("unsupported --format %q (want dot|svg)", *format)
+	}
+	if *runID != "" && *latest {
+		return errors.New("use either --run-id or --latest, not both")
+	}
+	fromRun := *runID != "" || *latest
+	if fromRun == (*genomeFile != "") {
+		return errors.New("genome dot requires either --run-id/--latest or --genome")
+	}
+
+	var genome model.Genome
+	if *genomeFile != "" {
+		data, err := os.ReadFile(*genomeFile)
+		if err != nil {
+			return err
+		}
+		genome, err = storage.DecodeGenome(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", *genomeFile, err)
+		}
+	} else {
+		var err error
+		genome, err = loadTopGenome(ctx, *storeKind, *dbPath, *runID, *latest, *genomeID)
+		if err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	if *format == "svg" {
+		buf.Write(stats.RenderGenomeSVG(genome))
+	} else if err := stats.WriteGenomeDOT(&buf, genome); err != nil {
+		return err
+	}
+	if *outPath == "" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	if err := os.WriteFile(*outPath, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("genome_dot genome_id=%s format=%s neurons=%d synapses=%d path=%s\n", genome.ID, *format, len(genome.Neurons), len(genome.Synapses), *outPath)
+	return nil
+}
+
+// loadTopGenome returns genomeID from the run's top genomes, or the champion
+// when genomeID is empty.
+func loadTopGenome(ctx context.Context, storeKind, dbPath, runID string, latest bool, genomeID string) (model.Genome, error) {
+	client, err := protoapi.New(protoapi.Options{
+		StoreKind:     storeKind,
+		DBPath:        dbPath,
+		BenchmarksDir: benchmarksDir,
+		ExportsDir:    exportsDir,
+	})
+	if err != nil {
+		return model.Genome{}, err
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	top, err := client.TopGenomes(ctx, protoapi.TopGenomesRequest{RunID: runID, Latest: latest})
+	if err != nil {
+		return model.Genome{}, err
+	}
+	if len(top) == 0 {
+		return model.Genome{}, errors.New("no top genomes")
+	}
+	if genomeID == "" {
+		return top[0].Genome, nil
+	}
+	for _, item := range top {
+		if item.Genome.ID == genomeID {
+			return item.Genome, nil
+		}
+	}
+	return model.Genome{}, fmt.Errorf("genome %s not found among top genomes", genomeID)
+}
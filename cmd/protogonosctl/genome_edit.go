@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	protoapi "protogonos/pkg/protogonos"
+)
+
+func runGenomeEdit(ctx context.Context, args []string) error {
+	fs := newFlagSet("genome edit")
+	runID := fs.String("run-id", "", "run id whose top genome is edited")
+	latest := fs.Bool("latest", false, "edit a top genome of the most recent run from run index")
+	genomeID := fs.String("genome-id", "", "top genome to edit (default: run champion)")
+	var freezeNeurons stringListFlag
+	fs.Var(&freezeNeurons, "freeze-neuron", "neuron id whose bias and incoming weights are frozen (repeatable)")
+	var unfreezeNeurons stringListFlag
+	fs.Var(&unfreezeNeurons, "unfreeze-neuron", "neuron id to unfreeze (repeatable)")
+	var freezeSynapses stringListFlag
+	fs.Var(&freezeSynapses, "freeze-synapse", "synapse id whose weight is frozen (repeatable)")
+	var unfreezeSynapses stringListFlag
+	fs.Var(&unfreezeSynapses, "unfreeze-synapse", "synapse id to unfreeze (repeatable)")
+	jsonOut := fs.Bool("json", false, "emit the edited genome masks as JSON")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *runID != "" && *latest {
+		return errors.New("use either --run-id or --latest, not both")
+	}
+	if *runID == "" && !*latest {
+		return errors.New("genome edit requires --run-id or --latest")
+	}
+
+	client, err := protoapi.New(protoapi.Options{
+		StoreKind:     *storeKind,
+		DBPath:        *dbPath,
+		BenchmarksDir: benchmarksDir,
+		ExportsDir:    exportsDir,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	edited, err := client.EditGenome(ctx, protoapi.EditGenomeRequest{
+		RunID:            *runID,
+		Latest:           *latest,
+		GenomeID:         *genomeID,
+		FreezeNeurons:    freezeNeurons,
+		UnfreezeNeurons:  unfreezeNeurons,
+		FreezeSynapses:   freezeSynapses,
+		UnfreezeSynapses: unfreezeSynapses,
+	})
+	if err != nil {
+		return err
+	}
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(edited)
+	}
+	fmt.Printf("edited run_id=%s rank=%d genome_id=%s frozen_neurons=%s frozen_synapses=%s\n",
+		edited.RunID,
+		edited.Rank,
+		edited.GenomeID,
+		formatIDList(edited.FrozenNeurons),
+		formatIDList(edited.FrozenSynapses),
+	)
+	return nil
+}
+
+// formatIDList renders ids comma-separated, or "-" when there are none.
+func formatIDList(ids []string) string {
+	if len(ids) == 0 {
+		return "-"
+	}
+	return strings.Join(ids, ",")
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"protogonos/internal/storage"
+)
+
+func runGenomeSchema(_ context.Context, args []string) error {
+	fs := newFlagSet("genome schema")
+	validate := fs.String("validate", "", "strictly decode a genome JSON file instead of printing the schema")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *validate == "" {
+		_, err := os.Stdout.Write(storage.GenomeSchema())
+		return err
+	}
+
+	data, err := os.ReadFile(*validate)
+	if err != nil {
+		return err
+	}
+	genome, err := storage.DecodeGenomeWithOptions(data, storage.DecodeOptions{Strict: true})
+	if err != nil {
+		return fmt.Errorf("%s: %w", *validate, err)
+	}
+	fmt.Printf("valid genome_id=%s neurons=%d synapses=%d\n", genome.ID, len(genome.Neurons), len(genome.Synapses))
+	return nil
+}
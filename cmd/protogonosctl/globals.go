@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"protogonos/internal/storage"
+)
+
+// Environment variables that set the global options.
+const (
+	envStore         = "PROTOGONOS_STORE"
+	envDBPath        = "PROTOGONOS_DB_PATH"
+	envArtifactsRoot = "PROTOGONOS_ARTIFACTS_ROOT"
+)
+
+// globalOptions are the store and artifact locations shared by every
+// subcommand. Each is resolved, highest precedence first, from:
+//
+//  1. the subcommand's own --store/--db-path flag
+//  2. a global --store/--db-path/--artifacts-root flag before the subcommand
+//  3. PROTOGONOS_STORE, PROTOGONOS_DB_PATH or PROTOGONOS_ARTIFACTS_ROOT
+//  4. the "store" and "db_path" keys of a run or benchmark --config file
+//  5. the built-in default
+//
+// Benchmark and export artifacts live in the benchmarks and exports
+// directories under ArtifactsRoot, the working directory by default.
+type globalOptions struct {
+	StoreKind     string
+	DBPath        string
+	ArtifactsRoot string
+
+	// explicit holds the options set by a global flag or environment
+	// variable, which a config file must not override.
+	explicit map[string]bool
+}
+
+var (
+	globals       = defaultGlobalOptions()
+	benchmarksDir = "benchmarks"
+	exportsDir    = "exports"
+)
+
+func defaultGlobalOptions() globalOptions {
+	return globalOptions{
+		StoreKind: storage.DefaultStoreKind(),
+		DBPath:    "protogonos.db",
+		explicit:  map[string]bool{},
+	}
+}
+
+// parseGlobalOptions resolves the global options from the environment and
+// the flags preceding the subcommand, and returns the remaining arguments.
+func parseGlobalOptions(args []string, getenv func(string) string) (globalOptions, []string, error) {
+	opts := defaultGlobalOptions()
+	for _, env := range []struct {
+		name  string
+		flag  string
+		value *string
+	}{
+		{envStore, "store", &opts.StoreKind},
+		{envDBPath, "db-path", &opts.DBPath},
+		{envArtifactsRoot, "artifacts-root", &opts.ArtifactsRoot},
+	} {
+		if value := strings.TrimSpace(getenv(env.name)); value != "" {
+			*env.value = value
+			opts.explicit[env.flag] = true
+		}
+	}
+
+	fs := newFlagSet("protogonosctl")
+	fs.StringVar(&opts.StoreKind, "store", opts.StoreKind, "default store backend for every subcommand: memory|sqlite|postgres|bolt (env "+envStore+")")
+	fs.StringVar(&opts.DBPath, "db-path", opts.DBPath, "default sqlite or bolt database path, or postgres connection string, for every subcommand (env "+envDBPath+")")
+	fs.StringVar(&opts.ArtifactsRoot, "artifacts-root", opts.ArtifactsRoot, "directory holding the benchmarks and exports artifact directories (env "+envArtifactsRoot+")")
+	if err := fs.Parse(args); err != nil {
+		return globalOptions{}, nil, err
+	}
+	fs.Visit(func(f *flag.Flag) {
+		opts.explicit[f.Name] = true
+	})
+	return opts, fs.Args(), nil
+}
+
+func applyGlobalOptions(opts globalOptions) {
+	globals = opts
+	benchmarksDir = filepath.Join(opts.ArtifactsRoot, "benchmarks")
+	exportsDir = filepath.Join(opts.ArtifactsRoot, "exports")
+}
+
+// applyConfigStoreOptions fills storeKind and dbPath from the "store" and
+// "db_path" keys of a run config when neither a flag nor the environment
+// set them.
+func applyConfigStoreOptions(configPath string, setFlags map[string]bool, storeKind, dbPath *string) error {
+	if configPath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if v, ok := asString(raw["store"]); ok && v != "" && !setFlags["store"] && !globals.explicit["store"] {
+		*storeKind = v
+	}
+	if v, ok := asString(raw["db_path"]); ok && v != "" && !setFlags["db-path"] && !globals.explicit["db-path"] {
+		*dbPath = v
+	}
+	return nil
+}
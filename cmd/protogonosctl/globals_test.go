@@ -0,0 +1,71 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseGlobalOptionsPrefersFlagsOverEnvironment(t *testing.T) {
+	env := map[string]string{
+		envStore:         "sqlite",
+		envDBPath:        "env.db",
+		envArtifactsRoot: "env-root",
+	}
+	opts, rest, err := parseGlobalOptions([]string{"--db-path", "flag.db", "runs", "--limit", "2"}, func(name string) string {
+		return env[name]
+	})
+	if err != nil {
+		t.Fatalf("parse global options: %v", err)
+	}
+	if opts.StoreKind != "sqlite" || opts.DBPath != "flag.db" || opts.ArtifactsRoot != "env-root" {
+		t.Fatalf("unexpected global options: %+v", opts)
+	}
+	if len(rest) != 3 || rest[0] != "runs" || rest[1] != "--limit" {
+		t.Fatalf("unexpected remaining args: %v", rest)
+	}
+
+	opts, _, err = parseGlobalOptions([]string{"runs"}, func(string) string { return "" })
+	if err != nil {
+		t.Fatalf("parse default global options: %v", err)
+	}
+	if opts.DBPath != "protogonos.db" || opts.ArtifactsRoot != "" || len(opts.explicit) != 0 {
+		t.Fatalf("unexpected default global options: %+v", opts)
+	}
+}
+
+func TestApplyConfigStoreOptionsYieldsToFlagsAndEnvironment(t *testing.T) {
+	t.Cleanup(func() { applyGlobalOptions(defaultGlobalOptions()) })
+
+	path := filepath.Join(t.TempDir(), "run.json")
+	if err := os.WriteFile(path, []byte(`{"scape":"xor","store":"sqlite","db_path":"config.db"}`), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	applyGlobalOptions(defaultGlobalOptions())
+	storeKind, dbPath := "memory", "protogonos.db"
+	if err := applyConfigStoreOptions(path, map[string]bool{}, &storeKind, &dbPath); err != nil {
+		t.Fatalf("apply config store options: %v", err)
+	}
+	if storeKind != "sqlite" || dbPath != "config.db" {
+		t.Fatalf("expected config store options, got store=%s db_path=%s", storeKind, dbPath)
+	}
+
+	opts, _, err := parseGlobalOptions(nil, func(name string) string {
+		if name == envDBPath {
+			return "env.db"
+		}
+		return ""
+	})
+	if err != nil {
+		t.Fatalf("parse global options: %v", err)
+	}
+	applyGlobalOptions(opts)
+	storeKind, dbPath = "memory", "env.db"
+	if err := applyConfigStoreOptions(path, map[string]bool{"store": true}, &storeKind, &dbPath); err != nil {
+		t.Fatalf("apply config store options: %v", err)
+	}
+	if storeKind != "memory" || dbPath != "env.db" {
+		t.Fatalf("expected flag and env to win over config, got store=%s db_path=%s", storeKind, dbPath)
+	}
+}
@@ -8,8 +8,13 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"net"
+	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"protogonos/internal/evo"
@@ -20,11 +25,9 @@ import (
 	"protogonos/internal/storage"
 	"protogonos/internal/tuning"
 	protoapi "protogonos/pkg/protogonos"
-)
+	"protogonos/pkg/protogonos/remote"
 
-const (
-	benchmarksDir = "benchmarks"
-	exportsDir    = "exports"
+	"google.golang.org/grpc"
 )
 
 func main() {
@@ -35,60 +38,34 @@ func main() {
 }
 
 func run(ctx context.Context, args []string) error {
+	helpJSON := false
+	if index := slices.Index(args, "--help-json"); index >= 0 {
+		helpJSON = true
+		args = slices.Concat(args[:index], args[index+1:])
+	}
+	opts, args, err := parseGlobalOptions(args, os.Getenv)
+	if err != nil {
+		return err
+	}
+	applyGlobalOptions(opts)
+	if helpJSON {
+		return runHelpJSON(ctx, args)
+	}
 	if len(args) == 0 {
 		return usageError("missing command")
 	}
 
-	switch args[0] {
-	case "init":
-		return runInit(ctx, args[1:])
-	case "reset":
-		return runReset(ctx, args[1:])
-	case "start":
-		return runStart(ctx, args[1:])
-	case "run":
-		return runRun(ctx, args[1:])
-	case "benchmark":
-		return runBenchmark(ctx, args[1:])
-	case "benchmark-experiment":
-		return runBenchmarkExperiment(ctx, args[1:])
-	case "profile":
-		return runProfile(ctx, args[1:])
-	case "runs":
-		return runRuns(ctx, args[1:])
-	case "lineage":
-		return runLineage(ctx, args[1:])
-	case "fitness":
-		return runFitness(ctx, args[1:])
-	case "diagnostics":
-		return runDiagnostics(ctx, args[1:])
-	case "species":
-		return runSpecies(ctx, args[1:])
-	case "species-diff":
-		return runSpeciesDiff(ctx, args[1:])
-	case "monitor":
-		return runMonitor(ctx, args[1:])
-	case "population":
-		return runPopulation(ctx, args[1:])
-	case "top":
-		return runTop(ctx, args[1:])
-	case "scape-summary":
-		return runScapeSummary(ctx, args[1:])
-	case "epitopes-test":
-		return runEpitopesTest(ctx, args[1:])
-	case "export":
-		return runExport(ctx, args[1:])
-	case "data-extract":
-		return runDataExtract(ctx, args[1:])
-	default:
+	cmd, ok := lookupCommand(args[0])
+	if !ok {
 		return usageError(fmt.Sprintf("unknown command: %s", args[0]))
 	}
+	return cmd.Run(ctx, args[1:])
 }
 
 func runInit(ctx context.Context, args []string) error {
-	fs := flag.NewFlagSet("init", flag.ContinueOnError)
-	storeKind := fs.String("store", storage.DefaultStoreKind(), "store backend: memory|sqlite")
-	dbPath := fs.String("db-path", "protogonos.db", "sqlite database path")
+	fs := newFlagSet("init")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -111,9 +88,9 @@ func runInit(ctx context.Context, args []string) error {
 }
 
 func runReset(ctx context.Context, args []string) error {
-	fs := flag.NewFlagSet("reset", flag.ContinueOnError)
-	storeKind := fs.String("store", storage.DefaultStoreKind(), "store backend: memory|sqlite")
-	dbPath := fs.String("db-path", "protogonos.db", "sqlite database path")
+	fs := newFlagSet("reset")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -136,9 +113,9 @@ func runReset(ctx context.Context, args []string) error {
 }
 
 func runStart(ctx context.Context, args []string) error {
-	fs := flag.NewFlagSet("start", flag.ContinueOnError)
-	storeKind := fs.String("store", storage.DefaultStoreKind(), "store backend: memory|sqlite")
-	dbPath := fs.String("db-path", "protogonos.db", "sqlite database path")
+	fs := newFlagSet("start")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -164,14 +141,19 @@ func runStart(ctx context.Context, args []string) error {
 }
 
 func runRun(ctx context.Context, args []string) error {
-	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs := newFlagSet("run")
 	configPath := fs.String("config", "", "optional run config JSON path (map2rec-backed)")
 	runID := fs.String("run-id", "", "explicit run id (optional)")
 	continuePopID := fs.String("continue-pop-id", "", "continue from persisted population snapshot id")
+	initFromChampions := fs.String("init-from-champions", "", "seed part of the initial population with mutated copies of archived champions: scape=<name> top=<n> fraction=<0..1>")
 	specieIdentifier := fs.String("specie-identifier", "topology", "species identifier: topology|tot_n|fingerprint")
 	opMode := fs.String("op-mode", "gt", "operation mode: gt|validation|test (or composite gt+validation/test)")
-	evolutionType := fs.String("evolution-type", "generational", "evolution type: generational|steady_state")
+	evolutionType := fs.String("evolution-type", "generational", "evolution type: generational|steady_state|online")
+	genomeEncoding := fs.String("encoding", "auto", "genotype-phenotype encoding: auto|direct|substrate|grammar")
+	algorithm := fs.String("algorithm", "neuroevolution", "search algorithm: neuroevolution or a baseline with the same evaluation budget: random_search|hill_climb|es")
 	scapeName := fs.String("scape", "xor", "scape name")
+	var scapeParams stringListFlag
+	fs.Var(&scapeParams, "scape-param", "scape construction parameter key=value, repeatable (parity|majority: n=<inputs>; function-approx: fn=sine|polynomial|step|saddle|gaussian, samples, noise, seed, folds; epitopes: folds; stack-machine: task=reverse|sum|arith, length, width, examples, seed; pole2-balancing: fitness=default|gruau; dtm: right_reward, left_reward, runs, switch_floor; fx: instrument=<price csv>, steps)")
 	gtsaCSV := fs.String("gtsa-csv", "", "optional GTSA CSV table path")
 	gtsaProfile := fs.String("gtsa-profile", "", "optional GTSA seed profile override: default|core")
 	gtsaTrainEnd := fs.Int("gtsa-train-end", 0, "optional GTSA train_end cutoff for loaded CSV")
@@ -207,20 +189,86 @@ func runRun(ctx context.Context, args []string) error {
 	specieSizeLimit := fs.Int("specie-size-limit", 0, "maximum parent-pool size retained per species (0 disables)")
 	fitnessGoal := fs.Float64("fitness-goal", 0.0, "early-stop best fitness goal (0 disables)")
 	evaluationsLimit := fs.Int("evaluations-limit", 0, "early-stop total evaluation limit (0 disables)")
+	restarts := fs.Int("restarts", 0, "run up to N independent restarts with seeds seed, seed+1, ... until one reaches --fitness-goal, then report evaluations-to-goal (0 disables)")
+	restartsParallel := fs.Int("restarts-parallel", 1, "restarts run concurrently with --restarts")
+	restartsAll := fs.Bool("restarts-all", false, "run every restart even after one reaches the goal, to sample the evaluations-to-goal distribution")
+	stopCondition := fs.String("stop", "", "compound stop condition over best, mean, evals, generation, stagnation, species and elapsed, e.g. \"best>=0.99 || (evals>=200000 && stagnation>=30)\"; replaces --fitness-goal/--evaluations-limit")
 	traceStepSize := fs.Int("trace-step-size", 500, "trace update cadence in total evaluations (0 uses runtime default)")
 	startPaused := fs.Bool("start-paused", false, "start monitor in paused state (requires continue)")
 	autoContinueMS := fs.Int("auto-continue-ms", 0, "auto-send continue after N milliseconds when start-paused is set (0 disables)")
+	noBatchEval := fs.Bool("no-batch-eval", false, "disable batched dataset evaluation for batch-capable scapes")
+	noEvalCache := fs.Bool("no-eval-cache", false, "disable neuron activation caching across tuning evaluations on batch-capable scapes")
+	meterEvaluations := fs.Bool("meter-evaluations", false, "measure per-evaluation cpu time and allocations into diagnostics and the run summary")
+	verifyInvariants := fs.Bool("verify-invariants", false, "check population size, unique ids, species assignments and elite preservation every generation, failing fast with a population dump")
+	noRegressionWatch := fs.Bool("no-regression-watch", false, "skip scoring the run's champion against the best earlier champion of the scape on the run's validation split after the run")
+	regressionTolerance := fs.Float64("regression-tolerance", 0, "fraction of the reference champion's validation fitness the run's champion may fall short by before the regression watch flags a regression (0 uses 0.05)")
+	dryRun := fs.Bool("dry-run", false, "resolve and validate the run, print its effective configuration and estimated evaluation budget, and exit without evaluating")
 	seed := fs.Int64("seed", 1, "rng seed")
+	seedSelect := fs.Int64("seed-select", 0, "optional parent selection rng seed (defaults to --seed)")
+	seedMutate := fs.Int64("seed-mutate", 0, "optional mutation rng seed (defaults to --seed)")
+	seedEnv := fs.Int64("seed-env", 0, "optional scape environment noise seed (unset keeps the scape default)")
+	progress := fs.Bool("progress", true, "print per-generation wall clock, throughput and ETA while running")
+	output := fs.String("output", outputText, "output format: text|jsonl (jsonl streams one JSON record per generation and a final summary record to stdout)")
 	workers := fs.Int("workers", 4, "worker count")
-	storeKind := fs.String("store", storage.DefaultStoreKind(), "store backend: memory|sqlite")
-	dbPath := fs.String("db-path", "protogonos.db", "sqlite database path")
+	trials := fs.Int("trials", 1, "repeated evaluation trials per genome; above 1 scores by trial mean with a bootstrap CI")
+	ciTieBreak := fs.Bool("ci-tiebreak", false, "break equal-fitness ranking ties by bootstrap CI lower bound (requires --trials > 1)")
+	trialAggregation := fs.String("trial-aggregation", "mean", "repeated-trial fitness aggregation: mean|cvar|worst")
+	cvarAlpha := fs.Float64("cvar-alpha", 0.1, "tail fraction for --trial-aggregation=cvar in (0,1]")
+	fitnessScaling := fs.String("fitness-scaling", "none", "fitness scaling applied before parent selection: none|sigma|rank|minmax")
+	scalingPressure := fs.Float64("scaling-pressure", 0, "sigma multiple for --fitness-scaling=sigma (default 2) or linear ranking pressure in [1,2] for rank (default 1.5)")
+	speciesAllocation := fs.String("species-allocation", "proportional", "offspring allocation across species: proportional|rank|equal")
+	allocationFloor := fs.Int("allocation-floor", 0, "minimum offspring per species each generation")
+	allocationCeiling := fs.Int("allocation-ceiling", 0, "maximum offspring per species each generation (0 = unbounded)")
+	speciesSoftCap := fs.Int("species-soft-cap", 0, "soft species size cap: offspring of larger species go to smaller ones (generational only; 0 disables)")
+	speciesCapHysteresis := fs.Float64("species-cap-hysteresis", 0, "fraction below --species-soft-cap a capped species must shrink to before it is released, in [0,1)")
+	populationResize := fs.String("population-resize", "off", "population size control: off, guard (keep elites + 2x species) or auto (also grow on stagnation, shrink to the time target)")
+	minPopulation := fs.Int("min-population", 0, "smallest population resize may shrink to (0 = viable floor only)")
+	maxPopulation := fs.Int("max-population", 0, "largest population resize may grow to (0 = unbounded, or 4x population under auto)")
+	resizeTargetSeconds := fs.Float64("resize-target-seconds", 0, "per-generation wall-clock target for auto population resize (0 = none)")
+	lowFidelity := fs.Float64("low-fidelity", 0, "screen every genome at this fraction of the full episode/data in (0,1); 0 disables multi-fidelity")
+	finalistFraction := fs.Float64("finalist-fraction", 0.25, "fraction of screened genomes re-evaluated at full fidelity when --low-fidelity is set")
+	activationClamp := fs.Float64("activation-clamp", 0, "clamp aggregated neuron input to this magnitude and zero NaN/Inf values, counting clamp events per genome (0 disables)")
+	weightClamp := fs.Float64("weight-clamp", 0, "clamp synapse weights to this magnitude during evaluation, counting clamp events per genome (0 disables)")
+	crossoverRate := fs.Float64("crossover-rate", 0, "probability an offspring is bred from two parents of the same species before mutation (generational and steady_state only)")
+	interspeciesMating := fs.Float64("interspecies-mating", 0, "probability a crossover mate is drawn from another species, producing a hybrid")
+	pruneDisabledAfter := fs.Int("prune-disabled-after", 0, "remove offspring synapses disabled for this many generations (0 disables)")
+	pruneDisabledProb := fs.Float64("prune-disabled-prob", 0, "probability each synapse due for --prune-disabled-after pruning is removed (default 0.5)")
+	trackInnovations := fs.Bool("track-innovations", false, "mark new neurons and synapses with innovation numbers from a per-run registry persisted in the store")
+	initialSensorsMin := fs.Int("initial-sensors-min", 0, "fewest morphology sensors a seed genome starts with when --initial-sensors-max is set (default 1)")
+	initialSensorsMax := fs.Int("initial-sensors-max", 0, "start each seed genome with a random subset of at most N morphology sensors (0 keeps all)")
+	evalTimeoutMS := fs.Int("eval-timeout-ms", 0, "fail a scape evaluation that runs longer than N milliseconds (0 disables)")
+	scapeSandbox := fs.Bool("scape-sandbox", false, "evaluate the scape in sandboxed worker subprocesses; crashes, stalls and scape errors score --sandbox-failure-fitness")
+	sandboxCommand := fs.String("sandbox-command", "", "sandbox worker command line (default: this binary's scape-worker subcommand)")
+	sandboxCPUSeconds := fs.Int("sandbox-cpu-seconds", 0, "CPU seconds a sandboxed evaluation may use before its worker is killed (0 disables)")
+	sandboxMemoryMB := fs.Int("sandbox-memory-mb", 0, "memory limit of each sandbox worker in MiB (0 disables)")
+	sandboxTimeoutMS := fs.Int("sandbox-timeout-ms", 0, "kill a sandbox worker whose evaluation runs longer than N milliseconds (0 disables)")
+	sandboxFailureFitness := fs.Float64("sandbox-failure-fitness", 0, "fitness of an evaluation that fails inside the sandbox")
+	entropyThreshold := fs.Float64("entropy-threshold", 0, "trigger --entropy-action when normalized population entropy drops below this value in (0,1] (0 disables)")
+	entropyMeasure := fs.String("entropy-measure", "", "entropy measure for --entropy-threshold: genotype (default) or behavior")
+	entropyAction := fs.String("entropy-action", "", "action on low entropy: mutation (default, triples mutation counts), immigrants (replaces the worst quarter) or restart (reseeds all but elites)")
+	entropyCooldown := fs.Int("entropy-cooldown", 0, "generations the entropy detector rests after triggering (default 5 when --entropy-threshold is set)")
+	reproductionInterval := fs.Int("reproduction-interval", 0, "online evolution: evaluations between reproduction events (default population size)")
+	diagnosticsWindow := fs.Int("diagnostics-window", 0, "online evolution: evaluations per rolling diagnostics window (default population size)")
+	islands := fs.Int("islands", 0, "split the population into this many islands that breed apart (generational evolution)")
+	migrationInterval := fs.Int("migration-interval", 0, "islands: generations between champion migrations (default 10)")
+	migrants := fs.Int("migrants", 0, "islands: best genomes each island sends to the next at a migration (default 1)")
+	checkpointInterval := fs.Int("checkpoint-interval", 0, "save a resumable checkpoint every N generations (0 disables; see resume)")
+	snapshotInterval := fs.Int("snapshot-interval", 0, "save the scored population every N generations so fork --at-gen can branch from it (0 disables)")
+	karmaStrikes := fs.Int("karma-strikes", 0, "score timeouts, panics and NaN/Inf results as degenerate and ban a fingerprint from parenthood after N degenerate generations (0 disables)")
+	karmaCooldown := fs.Int("karma-cooldown", 0, "generations a banned fingerprint is excluded from parenthood (default 5 when --karma-strikes is set)")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
 	enableTuning := fs.Bool("tuning", false, "enable exoself tuning")
 	compareTuning := fs.Bool("compare-tuning", false, "run with and without tuning and emit side-by-side metrics")
 	validationProbe := fs.Bool("validation-probe", false, "evaluate per-species champions in validation probe during gt runs")
 	testProbe := fs.Bool("test-probe", false, "evaluate per-species champions in test probe during gt runs")
 	profileName := fs.String("profile", "", "optional parity profile id (from testdata/fixtures/parity/ref_benchmarker_profiles.json)")
-	selectionName := fs.String("selection", "elite", "parent selection strategy: elite|tournament|species_tournament|species_shared_tournament|hof_competition|hof_rank|hof_top3|hof_efficiency|hof_random|competition|top3")
-	postprocessorName := fs.String("fitness-postprocessor", "none", "fitness postprocessor: none|size_proportional|nsize_proportional|novelty_proportional")
+	parityStrict := fs.Bool("parity-strict", false, "restrict operators, selection, tuning selection and speciation to reference (DXNN) equivalents, failing on any option without one")
+	selectionName := fs.String("selection", "elite", "parent selection strategy: elite|tournament|species_tournament|species_shared_tournament|hof_competition|hof_rank|hof_top3|hof_efficiency|hof_random|competition|top3|afpo|nsga2")
+	tournamentSize := fs.Int("tournament-size", 3, "candidates sampled per tournament for tournament-based selection")
+	tournamentNoReplace := fs.Bool("tournament-no-replace", false, "sample distinct tournament candidates (without replacement)")
+	tournamentWinProb := fs.Float64("tournament-win-prob", 1, "probability the fittest tournament candidate wins; below 1 lets weaker candidates win")
+	postprocessorName := fs.String("fitness-postprocessor", "none", "fitness postprocessor: none|size_proportional|nsize_proportional|novelty_proportional|novelty_archive|numeric_fragility|cost_proportional")
 	topoPolicyName := fs.String("topo-policy", "const", "topological mutation count policy: const|ncount_linear|ncount_exponential")
 	topoCount := fs.Int("topo-count", 1, "mutation count for topo-policy=const")
 	topoParam := fs.Float64("topo-param", 0.5, "policy parameter (multiplier/power) for topo-policy")
@@ -231,9 +279,11 @@ func runRun(ctx context.Context, args []string) error {
 	tunePerturbationRange := fs.Float64("tune-perturbation-range", 1.0, "tuning perturbation spread multiplier")
 	tuneAnnealingFactor := fs.Float64("tune-annealing-factor", 1.0, "tuning per-step annealing factor")
 	tuneMinImprovement := fs.Float64("tune-min-improvement", 0.0, "minimum fitness gain required to accept a tuning candidate")
+	tuningTrace := fs.Bool("tuning-trace", false, "record every tuning candidate per agent to tuning_trace.jsonl (requires --tuning)")
+	tuneAcceptance := fs.String("tune-acceptance", "training", "fitness tuning candidates must improve: training|validation (validation scores each candidate on the validation split)")
 	tuneSelection := fs.String("tune-selection", tuning.CandidateSelectBestSoFar, "tuner candidate selection: best_so_far|original|dynamic|dynamic_random|all|all_random|active|active_random|recent|recent_random|current|current_random|lastgen|lastgen_random")
-	tuneDurationPolicy := fs.String("tune-duration-policy", "fixed", "tuning attempt policy: fixed|const|linear_decay|topology_scaled|nsize_proportional|wsize_proportional")
-	tuneDurationParam := fs.Float64("tune-duration-param", 1.0, "tuning attempt policy parameter")
+	tuneDurationPolicy := fs.String("tune-duration-policy", "fixed", "tuning attempt policy: fixed|const|linear_decay|topology_scaled|nsize_proportional|wsize_proportional|wall_clock")
+	tuneDurationParam := fs.Float64("tune-duration-param", 1.0, "tuning attempt policy parameter (wall_clock: run tuning budget in seconds)")
 	wPerturb := fs.Float64("w-perturb", 0.70, "weight for perturb_random_weight mutation")
 	wBias := fs.Float64("w-bias", 0.00, "weight for perturb_random_bias mutation")
 	wRemoveBias := fs.Float64("w-remove-bias", 0.00, "weight for remove_random_bias mutation")
@@ -246,6 +296,14 @@ func runRun(ctx context.Context, args []string) error {
 	wPlasticityRule := fs.Float64("w-plasticity-rule", 0.00, "weight for change_plasticity_rule mutation")
 	wPlasticity := fs.Float64("w-plasticity", 0.03, "weight for perturb_plasticity_rate mutation")
 	wSubstrate := fs.Float64("w-substrate", 0.02, "weight for perturb_substrate_parameter mutation")
+	wRecurrentLoop := fs.Float64("w-recurrent-loop", 0.00, "weight for add_recurrent_loop mutation (self-loops and short cycles; 0 disables)")
+	recurrentLoopMaxLength := fs.Int("recurrent-loop-max-length", 0, "max neurons in a cycle closed by add_recurrent_loop (default 3; 1 allows self-loops only)")
+	wDuplicateNeuron := fs.Float64("w-duplicate-neuron", 0.00, "weight for duplicate_neuron mutation (copies a hidden neuron with jittered synapses; 0 disables)")
+	wToggleSynapse := fs.Float64("w-toggle-synapse", 0.00, "weight for toggle_synapse_enabled mutation (NEAT enable/disable gene; 0 disables)")
+	wInsertModule := fs.Float64("w-insert-module", 0.00, "weight for insert_module mutation (grafts a module from the module library; 0 disables)")
+	modules := fs.String("modules", "", "comma-separated module library ids insert_module may graft (default: whole library)")
+	wAllBiases := fs.Float64("w-all-biases", 0.00, "weight for perturb_all_biases mutation (perturbs a proportional subset of all biases; 0 disables)")
+	biasMaxDelta := fs.Float64("bias-max-delta", 0.00, "max per-step bias change for add_bias and perturb_all_biases (default 0.3)")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -254,13 +312,21 @@ func runRun(ctx context.Context, args []string) error {
 		setFlags[f.Name] = true
 	})
 
+	scapeParamValues, err := parseScapeParams(scapeParams)
+	if err != nil {
+		return err
+	}
 	req, err := loadOrDefaultRunRequest(*configPath)
 	if err != nil {
 		return err
 	}
+	if err := applyConfigStoreOptions(*configPath, setFlags, storeKind, dbPath); err != nil {
+		return err
+	}
 	if *configPath == "" {
 		req = protoapi.RunRequest{
 			Scape:                   *scapeName,
+			ScapeParams:             scapeParamValues,
 			GTSACSVPath:             *gtsaCSV,
 			GTSAProfile:             *gtsaProfile,
 			GTSATrainEnd:            *gtsaTrainEnd,
@@ -284,8 +350,11 @@ func runRun(ctx context.Context, args []string) error {
 			EpitopesBenchmarkEnd:    *epitopesBenchmarkEnd,
 			OpMode:                  *opMode,
 			EvolutionType:           *evolutionType,
+			Encoding:                *genomeEncoding,
+			Algorithm:               *algorithm,
 			RunID:                   *runID,
 			ContinuePopulationID:    *continuePopID,
+			InitFromChampions:       *initFromChampions,
 			SpecieIdentifier:        *specieIdentifier,
 			Population:              *population,
 			Generations:             *generations,
@@ -296,9 +365,74 @@ func runRun(ctx context.Context, args []string) error {
 			TraceStepSize:           *traceStepSize,
 			StartPaused:             *startPaused,
 			AutoContinueAfter:       time.Duration(*autoContinueMS) * time.Millisecond,
+			DisableBatchEvaluation:  *noBatchEval,
+			DisableEvalCache:        *noEvalCache,
+			MeterEvaluations:        *meterEvaluations,
+			VerifyInvariants:        *verifyInvariants,
+			DisableRegressionWatch:  *noRegressionWatch,
+			RegressionTolerance:     *regressionTolerance,
 			Seed:                    *seed,
 			Workers:                 *workers,
+			EvaluationTrials:        *trials,
+			CITieBreak:              *ciTieBreak,
+			ParityStrict:            *parityStrict,
+			TrialAggregation:        *trialAggregation,
+			CVaRAlpha:               *cvarAlpha,
+			FitnessScaling:          *fitnessScaling,
+			ScalingPressure:         *scalingPressure,
+			SpeciesAllocation:       *speciesAllocation,
+			AllocationFloor:         *allocationFloor,
+			AllocationCeiling:       *allocationCeiling,
+			SpeciesSoftCap:          *speciesSoftCap,
+			SpeciesCapHysteresis:    *speciesCapHysteresis,
+			PopulationResize:        *populationResize,
+			MinPopulation:           *minPopulation,
+			MaxPopulation:           *maxPopulation,
+			ResizeTargetSeconds:     *resizeTargetSeconds,
+			LowFidelity:             *lowFidelity,
+			FinalistFraction:        *finalistFraction,
+			ActivationClamp:         *activationClamp,
+			WeightClamp:             *weightClamp,
+			CrossoverRate:           *crossoverRate,
+			InterspeciesMating:      *interspeciesMating,
+			PruneDisabledAfter:      *pruneDisabledAfter,
+			PruneDisabledProb:       *pruneDisabledProb,
+			TrackInnovations:        *trackInnovations,
+			InitialSensorsMin:       *initialSensorsMin,
+			InitialSensorsMax:       *initialSensorsMax,
+			EvaluationTimeout:       time.Duration(*evalTimeoutMS) * time.Millisecond,
+			ScapeSandbox:            *scapeSandbox,
+			SandboxCommand:          *sandboxCommand,
+			SandboxCPUSeconds:       *sandboxCPUSeconds,
+			SandboxMemoryMB:         *sandboxMemoryMB,
+			SandboxTimeout:          time.Duration(*sandboxTimeoutMS) * time.Millisecond,
+			SandboxFailureFitness:   *sandboxFailureFitness,
+			KarmaStrikes:            *karmaStrikes,
+			KarmaCooldown:           *karmaCooldown,
+			StopCondition:           *stopCondition,
+			EntropyThreshold:        *entropyThreshold,
+			EntropyMeasure:          *entropyMeasure,
+			EntropyAction:           *entropyAction,
+			EntropyCooldown:         *entropyCooldown,
+			ReproductionInterval:    *reproductionInterval,
+			DiagnosticsWindow:       *diagnosticsWindow,
+			Islands:                 *islands,
+			MigrationInterval:       *migrationInterval,
+			Migrants:                *migrants,
+			CheckpointInterval:      *checkpointInterval,
+			SnapshotInterval:        *snapshotInterval,
+			WeightRecurrentLoop:     *wRecurrentLoop,
+			RecurrentLoopMaxLength:  *recurrentLoopMaxLength,
+			WeightDuplicateNeuron:   *wDuplicateNeuron,
+			WeightToggleSynapse:     *wToggleSynapse,
+			WeightInsertModule:      *wInsertModule,
+			Modules:                 splitCommaList(*modules),
+			WeightAllBiases:         *wAllBiases,
+			BiasMaxDelta:            *biasMaxDelta,
 			Selection:               *selectionName,
+			TournamentSize:          *tournamentSize,
+			TournamentNoReplace:     *tournamentNoReplace,
+			TournamentWinProb:       *tournamentWinProb,
 			FitnessPostprocessor:    *postprocessorName,
 			TopologicalPolicy:       *topoPolicyName,
 			TopologicalCount:        *topoCount,
@@ -317,6 +451,8 @@ func runRun(ctx context.Context, args []string) error {
 			TunePerturbationRange:   *tunePerturbationRange,
 			TuneAnnealingFactor:     *tuneAnnealingFactor,
 			TuneMinImprovement:      *tuneMinImprovement,
+			TuningTrace:             *tuningTrace,
+			TuneAcceptance:          *tuneAcceptance,
 			WeightPerturb:           *wPerturb,
 			WeightBias:              *wBias,
 			WeightRemoveBias:        *wRemoveBias,
@@ -333,6 +469,7 @@ func runRun(ctx context.Context, args []string) error {
 	} else {
 		err := overrideFromFlags(&req, setFlags, map[string]any{
 			"scape":                     *scapeName,
+			"scape-param":               scapeParamValues,
 			"gtsa-profile":              *gtsaProfile,
 			"gtsa-csv":                  *gtsaCSV,
 			"gtsa-train-end":            *gtsaTrainEnd,
@@ -355,8 +492,11 @@ func runRun(ctx context.Context, args []string) error {
 			"epitopes-benchmark-end":    *epitopesBenchmarkEnd,
 			"op-mode":                   *opMode,
 			"evolution-type":            *evolutionType,
+			"encoding":                  *genomeEncoding,
+			"algorithm":                 *algorithm,
 			"run-id":                    *runID,
 			"continue-pop-id":           *continuePopID,
+			"init-from-champions":       *initFromChampions,
 			"specie-identifier":         *specieIdentifier,
 			"pop":                       *population,
 			"gens":                      *generations,
@@ -364,16 +504,81 @@ func runRun(ctx context.Context, args []string) error {
 			"specie-size-limit":         *specieSizeLimit,
 			"fitness-goal":              *fitnessGoal,
 			"evaluations-limit":         *evaluationsLimit,
+			"stop":                      *stopCondition,
+			"entropy-threshold":         *entropyThreshold,
+			"entropy-measure":           *entropyMeasure,
+			"entropy-action":            *entropyAction,
+			"entropy-cooldown":          *entropyCooldown,
+			"reproduction-interval":     *reproductionInterval,
+			"diagnostics-window":        *diagnosticsWindow,
+			"islands":                   *islands,
+			"migration-interval":        *migrationInterval,
+			"migrants":                  *migrants,
+			"checkpoint-interval":       *checkpointInterval,
+			"snapshot-interval":         *snapshotInterval,
+			"w-recurrent-loop":          *wRecurrentLoop,
+			"recurrent-loop-max-length": *recurrentLoopMaxLength,
+			"w-duplicate-neuron":        *wDuplicateNeuron,
+			"w-toggle-synapse":          *wToggleSynapse,
+			"w-insert-module":           *wInsertModule,
+			"modules":                   *modules,
+			"w-all-biases":              *wAllBiases,
+			"bias-max-delta":            *biasMaxDelta,
 			"trace-step-size":           *traceStepSize,
 			"start-paused":              *startPaused,
 			"auto-continue-ms":          *autoContinueMS,
+			"no-batch-eval":             *noBatchEval,
+			"no-eval-cache":             *noEvalCache,
+			"meter-evaluations":         *meterEvaluations,
+			"verify-invariants":         *verifyInvariants,
+			"no-regression-watch":       *noRegressionWatch,
+			"regression-tolerance":      *regressionTolerance,
 			"seed":                      *seed,
 			"workers":                   *workers,
+			"trials":                    *trials,
+			"ci-tiebreak":               *ciTieBreak,
+			"parity-strict":             *parityStrict,
+			"trial-aggregation":         *trialAggregation,
+			"cvar-alpha":                *cvarAlpha,
+			"fitness-scaling":           *fitnessScaling,
+			"scaling-pressure":          *scalingPressure,
+			"species-allocation":        *speciesAllocation,
+			"allocation-floor":          *allocationFloor,
+			"allocation-ceiling":        *allocationCeiling,
+			"species-soft-cap":          *speciesSoftCap,
+			"species-cap-hysteresis":    *speciesCapHysteresis,
+			"population-resize":         *populationResize,
+			"min-population":            *minPopulation,
+			"max-population":            *maxPopulation,
+			"resize-target-seconds":     *resizeTargetSeconds,
+			"low-fidelity":              *lowFidelity,
+			"finalist-fraction":         *finalistFraction,
+			"activation-clamp":          *activationClamp,
+			"weight-clamp":              *weightClamp,
+			"crossover-rate":            *crossoverRate,
+			"interspecies-mating":       *interspeciesMating,
+			"prune-disabled-after":      *pruneDisabledAfter,
+			"prune-disabled-prob":       *pruneDisabledProb,
+			"track-innovations":         *trackInnovations,
+			"initial-sensors-min":       *initialSensorsMin,
+			"initial-sensors-max":       *initialSensorsMax,
+			"eval-timeout-ms":           *evalTimeoutMS,
+			"scape-sandbox":             *scapeSandbox,
+			"sandbox-command":           *sandboxCommand,
+			"sandbox-cpu-seconds":       *sandboxCPUSeconds,
+			"sandbox-memory-mb":         *sandboxMemoryMB,
+			"sandbox-timeout-ms":        *sandboxTimeoutMS,
+			"sandbox-failure-fitness":   *sandboxFailureFitness,
+			"karma-strikes":             *karmaStrikes,
+			"karma-cooldown":            *karmaCooldown,
 			"tuning":                    *enableTuning,
 			"compare-tuning":            *compareTuning,
 			"validation-probe":          *validationProbe,
 			"test-probe":                *testProbe,
 			"selection":                 *selectionName,
+			"tournament-size":           *tournamentSize,
+			"tournament-no-replace":     *tournamentNoReplace,
+			"tournament-win-prob":       *tournamentWinProb,
 			"fitness-postprocessor":     *postprocessorName,
 			"topo-policy":               *topoPolicyName,
 			"topo-count":                *topoCount,
@@ -385,6 +590,8 @@ func runRun(ctx context.Context, args []string) error {
 			"tune-perturbation-range":   *tunePerturbationRange,
 			"tune-annealing-factor":     *tuneAnnealingFactor,
 			"tune-min-improvement":      *tuneMinImprovement,
+			"tuning-trace":              *tuningTrace,
+			"tune-acceptance":           *tuneAcceptance,
 			"tune-selection":            *tuneSelection,
 			"tune-duration-policy":      *tuneDurationPolicy,
 			"tune-duration-param":       *tuneDurationParam,
@@ -416,6 +623,25 @@ func runRun(ctx context.Context, args []string) error {
 		MaxAge:             *flatlandMaxAge,
 		ForageGoal:         *flatlandForageGoal,
 	})
+	applySeedFlagOverrides(&req, setFlags, *seedSelect, *seedMutate, *seedEnv)
+	if err := applySandboxCommandDefault(&req); err != nil {
+		return err
+	}
+	if *progress {
+		req.Progress = printRunProgress
+	}
+	outputMode, err := parseOutputMode(*output)
+	if err != nil {
+		return err
+	}
+	var jsonl *jsonlWriter
+	if outputMode == outputJSONL {
+		if *dryRun || *restarts > 0 {
+			return errors.New("--output jsonl does not support --dry-run or --restarts")
+		}
+		jsonl = newJSONLWriter(os.Stdout)
+		req.Progress = jsonl.progress
+	}
 	if *profileName != "" {
 		preset, err := loadParityPreset(*profileName)
 		if err != nil {
@@ -440,6 +666,15 @@ func runRun(ctx context.Context, args []string) error {
 		req.WeightPlasticityRule = preset.WeightPlasticityRule
 		req.WeightPlasticity = preset.WeightPlasticity
 		req.WeightSubstrate = preset.WeightSubstrate
+		if !setFlags["w-all-biases"] {
+			req.WeightAllBiases = preset.WeightAllBiases
+		}
+		if !setFlags["bias-max-delta"] {
+			req.BiasMaxDelta = preset.BiasMaxDelta
+		}
+	}
+	if *profileName == "" {
+		applyParityStrictFlagDefaults(&req, setFlags, *configPath == "")
 	}
 	req.TuneSelection = normalizeTuneSelection(req.TuneSelection)
 	if req.WeightPerturb < 0 || req.WeightBias < 0 || req.WeightRemoveBias < 0 || req.WeightActivation < 0 || req.WeightAggregator < 0 || req.WeightAddSynapse < 0 || req.WeightRemoveSynapse < 0 || req.WeightAddNeuron < 0 || req.WeightRemoveNeuron < 0 || req.WeightPlasticityRule < 0 || req.WeightPlasticity < 0 || req.WeightSubstrate < 0 {
@@ -449,6 +684,15 @@ func runRun(ctx context.Context, args []string) error {
 	if weightSum <= 0 && (*configPath == "" || *profileName != "" || hasAnyWeightOverrideFlag(setFlags)) {
 		return errors.New("at least one mutation weight must be > 0")
 	}
+	if *restarts < 0 {
+		return errors.New("--restarts must be >= 0")
+	}
+	if *restarts == 0 && (setFlags["restarts-parallel"] || setFlags["restarts-all"]) {
+		return errors.New("--restarts-parallel and --restarts-all require --restarts")
+	}
+	if *restarts > 0 && *restartsParallel <= 0 {
+		return errors.New("--restarts-parallel must be > 0")
+	}
 
 	client, err := protoapi.New(protoapi.Options{
 		StoreKind:     *storeKind,
@@ -466,28 +710,145 @@ func runRun(ctx context.Context, args []string) error {
 		return err
 	}
 
+	if *dryRun {
+		return printRunPlan(ctx, client, req, max(1, *restarts))
+	}
+	if *restarts > 0 {
+		summary, err := client.RunRestarts(ctx, protoapi.RestartsRequest{Run: req, Restarts: *restarts, Parallel: *restartsParallel, All: *restartsAll})
+		if err != nil {
+			return err
+		}
+		printRestartsSummary(summary)
+		return nil
+	}
+
 	runSummary, err := client.Run(ctx, req)
 	if err != nil {
 		return err
 	}
+	if jsonl != nil {
+		return jsonl.summary("run", req, runSummary, nil)
+	}
 	fmt.Printf("run completed run_id=%s scape=%s pop=%d gens=%d seed=%d\n", runSummary.RunID, req.Scape, req.Population, req.Generations, req.Seed)
 	for i, best := range runSummary.BestByGeneration {
 		fmt.Printf("generation=%d best_fitness=%.6f\n", i+1, best)
 	}
 	fmt.Printf("final_best_fitness=%.6f\n", runSummary.FinalBestFitness)
+	if len(runSummary.IslandBestFitness) > 0 {
+		fmt.Printf("island_best_fitness=%s\n", formatFitnessList(runSummary.IslandBestFitness))
+	}
+	if ci := runSummary.ChampionFitnessCI; ci != nil {
+		fmt.Printf("champion_fitness_ci mean=%.6f lower=%.6f upper=%.6f confidence=%.2f trials=%d\n", ci.Mean, ci.Lower, ci.Upper, ci.Confidence, ci.Trials)
+	}
 	if runSummary.Compare != nil {
-		fmt.Printf("compare_tuning without_final=%.6f with_final=%.6f improvement=%.6f\n",
+		fmt.Printf("compare_tuning without_final=%.6f with_final=%.6f improvement=%.6f pairing=%s paired_mean=%.6f\n",
 			runSummary.Compare.WithoutFinalBest,
 			runSummary.Compare.WithFinalBest,
 			runSummary.Compare.FinalImprovement,
+			runSummary.Compare.Pairing,
+			runSummary.Compare.MeanPairedImprovement,
 		)
 	}
+	if cost := runSummary.EvaluationCost; cost != nil {
+		printEvaluationCost(cost)
+	}
+	printRegressionCheck(runSummary)
 	fmt.Printf("artifacts_dir=%s\n", filepath.Clean(runSummary.ArtifactsDir))
 	return nil
 }
 
+// printRunPlan prints the effective configuration of req and its evaluation
+// budget, scaled by the repetitions of --restarts or --success-rate, without
+// running it.
+func printRunPlan(ctx context.Context, client *protoapi.Client, req protoapi.RunRequest, repetitions int) error {
+	plan, err := client.PlanRun(ctx, req)
+	if err != nil {
+		return err
+	}
+	config, err := json.MarshalIndent(plan.Config, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("dry_run run_id=%s scape=%s pop=%d gens=%d seed=%d inputs=%d outputs=%d\n",
+		plan.RunID, plan.Config.Scape, plan.Config.PopulationSize, plan.Config.Generations, plan.Config.Seed, len(plan.InputNeuronIDs), len(plan.OutputNeuronIDs))
+	fmt.Printf("%s\n", config)
+	budget := plan.Budget
+	fmt.Printf("budget generations=%d capped=%t runs=%d evaluations=%d episodes_per_evaluation=%d episodes=%d min_tuning_evaluations=%d\n",
+		budget.Generations, budget.Capped, budget.Runs, budget.Evaluations, budget.EpisodesPerEvaluation, budget.Episodes, budget.MinTuningEvaluations)
+	if repetitions > 1 {
+		fmt.Printf("budget_total repetitions=%d evaluations=%d episodes=%d min_tuning_evaluations=%d\n",
+			repetitions, repetitions*budget.Evaluations, repetitions*budget.Episodes, repetitions*budget.MinTuningEvaluations)
+	}
+	return nil
+}
+
+func printRestartsSummary(summary protoapi.RestartsSummary) {
+	for _, restart := range summary.Restarts {
+		fmt.Printf("restart=%d run_id=%s seed=%d reached_goal=%t generation=%d evaluations=%d final_best_fitness=%.6f\n",
+			restart.Restart,
+			restart.RunID,
+			restart.Seed,
+			restart.ReachedGoal,
+			restart.Generation,
+			restart.Evaluations,
+			restart.FinalBestFitness,
+		)
+	}
+	toGoal := summary.EvaluationsToGoal
+	fmt.Printf("restarts goal=%.6f restarts=%d successes=%d success_rate=%.6f first_success=%s total_evaluations=%d\n",
+		summary.Goal,
+		len(summary.Restarts),
+		summary.Successes,
+		summary.SuccessRate,
+		summary.FirstSuccess,
+		summary.TotalEvaluations,
+	)
+	fmt.Printf("evaluations_to_goal count=%d mean=%.2f std=%.2f median=%.1f min=%d max=%d\n",
+		toGoal.Count,
+		toGoal.Mean,
+		toGoal.Std,
+		toGoal.Median,
+		toGoal.Min,
+		toGoal.Max,
+	)
+}
+
+// printRegressionCheck prints the run's champion regression check, if it
+// has one.
+func printRegressionCheck(summary protoapi.RunSummary) {
+	check := summary.Regression
+	if check == nil {
+		return
+	}
+	if check.Error != "" {
+		fmt.Printf("regression_watch mode=%s error=%q\n", check.Mode, check.Error)
+		return
+	}
+	fmt.Printf("regression_watch mode=%s reference_run_id=%s reference_genome_id=%s champion=%.6f reference=%.6f delta=%.6f tolerance=%.6f regressed=%t\n",
+		check.Mode,
+		check.ReferenceRunID,
+		check.ReferenceGenomeID,
+		check.ChampionFitness,
+		check.ReferenceFitness,
+		check.Delta,
+		check.Tolerance,
+		check.Regressed,
+	)
+}
+
+func printEvaluationCost(cost *stats.EvaluationCost) {
+	fmt.Printf("evaluation_cost scape=%s evaluations=%d cpu_seconds=%.6f mean_cpu_ms=%.6f max_cpu_ms=%.6f mean_alloc_bytes=%.0f\n",
+		cost.Scape,
+		cost.Evaluations,
+		cost.CPUSeconds,
+		cost.MeanCPUSeconds*1000,
+		cost.MaxCPUSeconds*1000,
+		cost.MeanAllocBytes,
+	)
+}
+
 func runRuns(_ context.Context, args []string) error {
-	fs := flag.NewFlagSet("runs", flag.ContinueOnError)
+	fs := newFlagSet("runs")
 	limit := fs.Int("limit", 20, "max runs to list")
 	showCompare := fs.Bool("show-compare", false, "show compare-tuning improvement when available")
 	jsonOut := fs.Bool("json", false, "emit runs list as JSON")
@@ -521,7 +882,11 @@ func runRuns(_ context.Context, args []string) error {
 			Generations        int      `json:"generations"`
 			TuningEnabled      bool     `json:"tuning_enabled"`
 			FinalBestFitness   float64  `json:"final_best_fitness"`
+			ForkedFrom         string   `json:"forked_from,omitempty"`
+			ForkGeneration     int      `json:"fork_generation,omitempty"`
 			CompareImprovement *float64 `json:"compare_improvement,omitempty"`
+
+			Resources *stats.RunResources `json:"resources,omitempty"`
 		}
 		items := make([]runsItem, 0, len(entries))
 		for _, e := range entries {
@@ -546,7 +911,10 @@ func runRuns(_ context.Context, args []string) error {
 				Generations:        e.Generations,
 				TuningEnabled:      e.TuningEnabled,
 				FinalBestFitness:   e.FinalBestFitness,
+				ForkedFrom:         e.ForkedFrom,
+				ForkGeneration:     e.ForkGeneration,
 				CompareImprovement: compare,
+				Resources:          e.Resources,
 			})
 		}
 		enc := json.NewEncoder(os.Stdout)
@@ -566,7 +934,11 @@ func runRuns(_ context.Context, args []string) error {
 			}
 		}
 
-		fmt.Printf("run_id=%s created_at=%s scape=%s morphology=%s seed=%d pop=%d gens=%d tuning=%t final_best_fitness=%.6f compare_improvement=%s\n",
+		forkDisplay := ""
+		if e.ForkedFrom != "" {
+			forkDisplay = fmt.Sprintf(" forked_from=%s fork_generation=%d", e.ForkedFrom, e.ForkGeneration)
+		}
+		fmt.Printf("run_id=%s created_at=%s scape=%s morphology=%s seed=%d pop=%d gens=%d tuning=%t final_best_fitness=%.6f compare_improvement=%s%s\n",
 			e.RunID,
 			e.CreatedAtUTC,
 			e.Scape,
@@ -577,19 +949,21 @@ func runRuns(_ context.Context, args []string) error {
 			e.TuningEnabled,
 			e.FinalBestFitness,
 			compareDisplay,
+			forkDisplay,
 		)
 	}
 	return nil
 }
 
 func runLineage(ctx context.Context, args []string) error {
-	fs := flag.NewFlagSet("lineage", flag.ContinueOnError)
+	fs := newFlagSet("lineage")
 	runID := fs.String("run-id", "", "run id")
 	latest := fs.Bool("latest", false, "show lineage for the most recent run from run index")
 	limit := fs.Int("limit", 50, "max lineage rows to print (<=0 for all)")
 	jsonOut := fs.Bool("json", false, "emit lineage rows as JSON")
-	storeKind := fs.String("store", storage.DefaultStoreKind(), "store backend: memory|sqlite")
-	dbPath := fs.String("db-path", "protogonos.db", "sqlite database path")
+	replay := fs.Bool("replay", false, "re-apply the recorded mutations from the run's seed population and verify every fingerprint; fails on a mismatch")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -613,6 +987,9 @@ func runLineage(ctx context.Context, args []string) error {
 		_ = client.Close()
 	}()
 
+	if *replay {
+		return printLineageReplay(ctx, client, *runID, *latest, *limit, *jsonOut)
+	}
 	lineage, err := client.Lineage(ctx, protoapi.LineageRequest{
 		RunID:  *runID,
 		Latest: *latest,
@@ -645,14 +1022,123 @@ func runLineage(ctx context.Context, args []string) error {
 	return nil
 }
 
+// printLineageReplay prints mismatched and skipped records, up to limit, and a
+// summary line, or the whole report with --json. Any mismatch fails the command so
+// it can gate storage audits.
+func printLineageReplay(ctx context.Context, client *protoapi.Client, runID string, latest bool, limit int, jsonOut bool) error {
+	report, err := client.ReplayLineage(ctx, protoapi.LineageReplayRequest{RunID: runID, Latest: latest})
+	if err != nil {
+		return err
+	}
+	if jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		printed := 0
+		for _, rec := range report.Records {
+			if rec.Status == evo.ReplayMatched || (limit > 0 && printed >= limit) {
+				continue
+			}
+			printed++
+			fmt.Printf("gen=%d genome_id=%s parent_id=%s op=%s status=%s detail=%q\n",
+				rec.Generation,
+				rec.GenomeID,
+				rec.ParentID,
+				rec.Operation,
+				rec.Status,
+				rec.Detail,
+			)
+		}
+		fmt.Printf("replay run_id=%s records=%d matched=%d mismatched=%d skipped=%d\n",
+			report.RunID,
+			len(report.Records),
+			report.Matched,
+			report.Mismatched,
+			report.Skipped,
+		)
+	}
+	if report.Mismatched > 0 {
+		return fmt.Errorf("lineage replay found %d mismatched records for run %s", report.Mismatched, report.RunID)
+	}
+	return nil
+}
+
+func runLeaderboard(ctx context.Context, args []string) error {
+	fs := newFlagSet("leaderboard")
+	scapeName := fs.String("scape", "", "scape whose leaderboard to show")
+	limit := fs.Int("limit", 10, "max champions to print (<=0 for all)")
+	jsonOut := fs.Bool("json", false, "emit leaderboard as JSON")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if strings.TrimSpace(*scapeName) == "" {
+		return errors.New("leaderboard requires --scape")
+	}
+	if *limit < 0 {
+		*limit = 0
+	}
+
+	client, err := protoapi.New(protoapi.Options{
+		StoreKind:     *storeKind,
+		DBPath:        *dbPath,
+		BenchmarksDir: benchmarksDir,
+		ExportsDir:    exportsDir,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	entries, err := client.Leaderboard(ctx, protoapi.LeaderboardRequest{
+		Scape: *scapeName,
+		Limit: *limit,
+	})
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Println("no champions recorded")
+		return nil
+	}
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+
+	for i, entry := range entries {
+		validation := "n/a"
+		if entry.ValidationFitness != nil {
+			validation = fmt.Sprintf("%.6f", *entry.ValidationFitness)
+		}
+		fmt.Printf("rank=%d run_id=%s genome_id=%s validation_fitness=%s fitness=%.6f created_at=%s config=%q\n",
+			i+1,
+			entry.RunID,
+			entry.GenomeID,
+			validation,
+			entry.Fitness,
+			entry.CreatedAtUTC,
+			entry.Config,
+		)
+	}
+	return nil
+}
+
 func runFitness(ctx context.Context, args []string) error {
-	fs := flag.NewFlagSet("fitness", flag.ContinueOnError)
+	fs := newFlagSet("fitness")
 	runID := fs.String("run-id", "", "run id")
 	latest := fs.Bool("latest", false, "show fitness history for the most recent run from run index")
 	limit := fs.Int("limit", 50, "max generations to print (<=0 for all)")
 	jsonOut := fs.Bool("json", false, "emit fitness history as JSON")
-	storeKind := fs.String("store", storage.DefaultStoreKind(), "store backend: memory|sqlite")
-	dbPath := fs.String("db-path", "protogonos.db", "sqlite database path")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -700,14 +1186,85 @@ func runFitness(ctx context.Context, args []string) error {
 	return nil
 }
 
+func runEvents(ctx context.Context, args []string) error {
+	fs := newFlagSet("events")
+	runID := fs.String("run-id", "", "run id")
+	latest := fs.Bool("latest", false, "show events for the most recent run from run index")
+	eventType := fs.String("type", "", "only show events of this type: "+strings.Join(evo.EventTypes(), "|"))
+	limit := fs.Int("limit", 0, "max events to print (<=0 for all)")
+	jsonOut := fs.Bool("json", false, "emit events as JSON")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *runID != "" && *latest {
+		return errors.New("use either --run-id or --latest, not both")
+	}
+	if *runID == "" && !*latest {
+		return errors.New("events requires --run-id or --latest")
+	}
+
+	client, err := protoapi.New(protoapi.Options{
+		StoreKind:     *storeKind,
+		DBPath:        *dbPath,
+		BenchmarksDir: benchmarksDir,
+		ExportsDir:    exportsDir,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	events, err := client.Events(ctx, protoapi.EventsRequest{
+		RunID:  *runID,
+		Latest: *latest,
+		Type:   *eventType,
+		Limit:  *limit,
+	})
+	if err != nil {
+		return err
+	}
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(events)
+	}
+	if len(events) == 0 {
+		fmt.Println("no events")
+		return nil
+	}
+
+	for _, event := range events {
+		line := fmt.Sprintf("generation=%d type=%s", event.Generation, event.Type)
+		if event.SpeciesKey != "" {
+			line += " species=" + event.SpeciesKey
+		}
+		if event.GenomeID != "" {
+			line += " genome_id=" + event.GenomeID
+		}
+		switch event.Type {
+		case evo.EventChampionImproved, evo.EventCurriculumAdvanced:
+			line += fmt.Sprintf(" value=%.6f previous=%.6f", event.Value, event.Previous)
+		}
+		if event.Detail != "" {
+			line += fmt.Sprintf(" detail=%q", event.Detail)
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
 func runDiagnostics(ctx context.Context, args []string) error {
-	fs := flag.NewFlagSet("diagnostics", flag.ContinueOnError)
+	fs := newFlagSet("diagnostics")
 	runID := fs.String("run-id", "", "run id")
 	latest := fs.Bool("latest", false, "show diagnostics for the most recent run from run index")
 	limit := fs.Int("limit", 50, "max generations to print (<=0 for all)")
 	jsonOut := fs.Bool("json", false, "emit diagnostics as JSON")
-	storeKind := fs.String("store", storage.DefaultStoreKind(), "store backend: memory|sqlite")
-	dbPath := fs.String("db-path", "protogonos.db", "sqlite database path")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -750,7 +1307,7 @@ func runDiagnostics(ctx context.Context, args []string) error {
 	}
 
 	for _, d := range diagnostics {
-		fmt.Printf("generation=%d best=%.6f mean=%.6f min=%.6f species=%d fingerprints=%d threshold=%.4f target_species=%d mean_species_size=%.2f largest_species=%d tuning_invocations=%d tuning_attempts=%d tuning_evaluations=%d tuning_accepted=%d tuning_rejected=%d tuning_goal_hits=%d tuning_accept_rate=%.4f tuning_evals_per_attempt=%.4f\n",
+		fmt.Printf("generation=%d best=%.6f mean=%.6f min=%.6f species=%d fingerprints=%d threshold=%.4f target_species=%d mean_species_size=%.2f largest_species=%d tuning_invocations=%d tuning_attempts=%d tuning_evaluations=%d tuning_accepted=%d tuning_rejected=%d tuning_goal_hits=%d tuning_accept_rate=%.4f tuning_evals_per_attempt=%.4f wall_clock_seconds=%.3f evaluations=%d evals_per_sec=%.2f eta_seconds=%.1f\n",
 			d.Generation,
 			d.BestFitness,
 			d.MeanFitness,
@@ -769,19 +1326,86 @@ func runDiagnostics(ctx context.Context, args []string) error {
 			d.TuningGoalHits,
 			d.TuningAcceptRate,
 			d.TuningEvalsPerAttempt,
+			d.WallClockSeconds,
+			d.TotalEvaluations,
+			d.EvaluationsPerSecond,
+			d.ETASeconds,
 		)
+		if d.FidelityFinalists > 0 {
+			fmt.Printf("fidelity generation=%d finalists=%d offset=%.6f\n", d.Generation, d.FidelityFinalists, d.FidelityOffset)
+		}
+		if d.ClampEvents > 0 {
+			fmt.Printf("numeric_guard generation=%d clamp_events=%d\n", d.Generation, d.ClampEvents)
+		}
+		if d.CrossoverIntra > 0 || d.CrossoverHybrid > 0 {
+			fmt.Printf("crossover generation=%d intraspecies=%d intraspecies_mean=%.6f hybrid=%d hybrid_mean=%.6f\n",
+				d.Generation,
+				d.CrossoverIntra,
+				d.CrossoverIntraMean,
+				d.CrossoverHybrid,
+				d.CrossoverHybridMean,
+			)
+		}
+		if d.DegenerateGenomes > 0 || d.BannedFingerprints > 0 {
+			fmt.Printf("karma generation=%d degenerate=%d banned_fingerprints=%d\n", d.Generation, d.DegenerateGenomes, d.BannedFingerprints)
+		}
+		if d.EntropyAction != "" {
+			fmt.Printf("entropy generation=%d genotype=%.4f behavior=%.4f action=%s\n", d.Generation, d.GenotypeEntropy, d.BehaviorEntropy, d.EntropyAction)
+		}
+		if d.EvalCacheHits > 0 || d.EvalCacheMisses > 0 {
+			fmt.Printf("eval_cache generation=%d hits=%d misses=%d\n", d.Generation, d.EvalCacheHits, d.EvalCacheMisses)
+		}
+		if cost := d.EvaluationCost; cost != nil {
+			fmt.Printf("evaluation_cost generation=%d evaluations=%d mean_cpu_ms=%.6f max_cpu_ms=%.6f mean_alloc_bytes=%.0f\n", d.Generation, cost.Evaluations, cost.MeanCPUSeconds*1000, cost.MaxCPUSeconds*1000, cost.MeanAllocBytes)
+		}
+		if len(d.IslandBest) > 0 {
+			fmt.Printf("islands generation=%d best=%s migrants=%d\n", d.Generation, formatFitnessList(d.IslandBest), d.Migrants)
+		}
+		if d.ScapePoolWaitSeconds > 0 {
+			fmt.Printf("scape_pool generation=%d wait_ms=%.3f\n", d.Generation, d.ScapePoolWaitSeconds*1000)
+		}
+		if d.Strategies != nil {
+			fmt.Printf("strategies generation=%d %s\n", d.Generation, formatStrategyDistribution(d.Strategies))
+		}
+		for _, change := range d.ScapeParamChanges {
+			fmt.Printf("scape_param generation=%d name=%s previous=%g value=%g\n", d.Generation, change.Name, change.Previous, change.Value)
+		}
+		if w := d.PopulationWeights; w != nil {
+			fmt.Printf("weights generation=%d count=%d mean=%.6f std=%.6f p05=%.6f p50=%.6f p95=%.6f saturated=%.4f\n",
+				d.Generation,
+				w.Count,
+				w.Mean,
+				w.Std,
+				w.P05,
+				w.P50,
+				w.P95,
+				w.SaturatedFraction,
+			)
+		}
+		for _, layer := range d.ChampionLayerWeights {
+			fmt.Printf("champion_layer generation=%d layer=%d neurons=%d weights=%d mean=%.6f std=%.6f max_abs=%.6f saturated=%.4f\n",
+				d.Generation,
+				layer.Layer,
+				layer.Neurons,
+				layer.Count,
+				layer.Mean,
+				layer.Std,
+				math.Max(math.Abs(layer.Min), math.Abs(layer.Max)),
+				layer.SaturatedFraction,
+			)
+		}
 	}
 	return nil
 }
 
 func runTop(ctx context.Context, args []string) error {
-	fs := flag.NewFlagSet("top", flag.ContinueOnError)
+	fs := newFlagSet("top")
 	runID := fs.String("run-id", "", "run id")
 	latest := fs.Bool("latest", false, "show top genomes for the most recent run from run index")
 	limit := fs.Int("limit", 5, "max top genomes to print (<=0 for all)")
 	jsonOut := fs.Bool("json", false, "emit top genomes as JSON")
-	storeKind := fs.String("store", storage.DefaultStoreKind(), "store backend: memory|sqlite")
-	dbPath := fs.String("db-path", "protogonos.db", "sqlite database path")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -831,18 +1455,21 @@ func runTop(ctx context.Context, args []string) error {
 			len(item.Genome.Neurons),
 			len(item.Genome.Synapses),
 		)
+		if len(item.Genome.Annotations) > 0 {
+			fmt.Printf("annotations rank=%d %s\n", item.Rank, formatAnnotations(item.Genome.Annotations))
+		}
 	}
 	return nil
 }
 
 func runSpecies(ctx context.Context, args []string) error {
-	fs := flag.NewFlagSet("species", flag.ContinueOnError)
+	fs := newFlagSet("species")
 	runID := fs.String("run-id", "", "run id")
 	latest := fs.Bool("latest", false, "show species history for the most recent run from run index")
 	limit := fs.Int("limit", 50, "max generations to print (<=0 for all)")
 	jsonOut := fs.Bool("json", false, "emit species history as JSON")
-	storeKind := fs.String("store", storage.DefaultStoreKind(), "store backend: memory|sqlite")
-	dbPath := fs.String("db-path", "protogonos.db", "sqlite database path")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -892,21 +1519,63 @@ func runSpecies(ctx context.Context, args []string) error {
 		)
 		for _, item := range generation.Species {
 			fmt.Printf("species_key=%s size=%d mean=%.6f best=%.6f\n", item.Key, item.Size, item.MeanFitness, item.BestFitness)
+			if item.Strategies != nil {
+				fmt.Printf("strategies species_key=%s %s\n", item.Key, formatStrategyDistribution(item.Strategies))
+			}
 		}
 	}
 	return nil
 }
 
+// formatStrategyDistribution renders each strategy field as value:count
+// pairs, most common value first.
+func formatStrategyDistribution(dist *evo.StrategyDistribution) string {
+	return fmt.Sprintf("tuning_selection=%s annealing_factor=%s topological_mode=%s heredity_type=%s",
+		formatStrategyCounts(dist.TuningSelection),
+		formatStrategyCounts(dist.AnnealingFactor),
+		formatStrategyCounts(dist.TopologicalMode),
+		formatStrategyCounts(dist.HeredityType),
+	)
+}
+
+func formatStrategyCounts(counts map[string]int) string {
+	values := make([]string, 0, len(counts))
+	for value := range counts {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if counts[values[i]] != counts[values[j]] {
+			return counts[values[i]] > counts[values[j]]
+		}
+		return values[i] < values[j]
+	})
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		parts = append(parts, fmt.Sprintf("%s:%d", value, counts[value]))
+	}
+	return strings.Join(parts, ",")
+}
+
+// formatFitnessList renders fitness values comma-separated.
+func formatFitnessList(values []float64) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = fmt.Sprintf("%.6f", value)
+	}
+	return strings.Join(parts, ",")
+}
+
 func runSpeciesDiff(ctx context.Context, args []string) error {
-	fs := flag.NewFlagSet("species-diff", flag.ContinueOnError)
+	fs := newFlagSet("species-diff")
 	runID := fs.String("run-id", "", "run id")
 	latest := fs.Bool("latest", false, "diff species history for the most recent run from run index")
 	fromGen := fs.Int("from-gen", 0, "from generation (default: previous generation)")
 	toGen := fs.Int("to-gen", 0, "to generation (default: latest generation)")
 	showDiagnostics := fs.Bool("show-diagnostics", false, "print from/to generation diagnostics snapshots alongside species diff")
+	championDeltas := fs.Bool("champion-deltas", false, "include the structural delta between from/to champions of changed species")
 	jsonOut := fs.Bool("json", false, "emit species diff as JSON")
-	storeKind := fs.String("store", storage.DefaultStoreKind(), "store backend: memory|sqlite")
-	dbPath := fs.String("db-path", "protogonos.db", "sqlite database path")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -935,6 +1604,7 @@ func runSpeciesDiff(ctx context.Context, args []string) error {
 		Latest:         *latest,
 		FromGeneration: *fromGen,
 		ToGeneration:   *toGen,
+		ChampionDeltas: *championDeltas,
 	})
 	if err != nil {
 		return err
@@ -1015,15 +1685,29 @@ func runSpeciesDiff(ctx context.Context, args []string) error {
 			item.ToBestFitness,
 			item.BestDelta,
 		)
+		if champion := item.Champion; champion != nil {
+			fmt.Printf("champion species_key=%s from=%s to=%s neurons=+%d/-%d synapses=+%d/-%d shared_synapses=%d weight_drift=%.6f max_weight_drift=%.6f\n",
+				item.Key,
+				champion.FromGenomeID,
+				champion.ToGenomeID,
+				len(champion.NeuronsAdded),
+				len(champion.NeuronsRemoved),
+				len(champion.SynapsesAdded),
+				len(champion.SynapsesRemoved),
+				champion.SharedSynapses,
+				champion.WeightDrift,
+				champion.MaxWeightDrift,
+			)
+		}
 	}
 	return nil
 }
 
 func runScapeSummary(ctx context.Context, args []string) error {
-	fs := flag.NewFlagSet("scape-summary", flag.ContinueOnError)
+	fs := newFlagSet("scape-summary")
 	scapeName := fs.String("scape", "", "scape name")
-	storeKind := fs.String("store", storage.DefaultStoreKind(), "store backend: memory|sqlite")
-	dbPath := fs.String("db-path", "protogonos.db", "sqlite database path")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -1057,14 +1741,14 @@ func runScapeSummary(ctx context.Context, args []string) error {
 }
 
 func runEpitopesTest(ctx context.Context, args []string) error {
-	fs := flag.NewFlagSet("epitopes-test", flag.ContinueOnError)
+	fs := newFlagSet("epitopes-test")
 	runID := fs.String("run-id", "", "run id")
 	latest := fs.Bool("latest", false, "replay top genomes for the most recent run from run index")
 	limit := fs.Int("limit", 0, "max top genomes to replay (<=0 for all)")
 	mode := fs.String("mode", "benchmark", "replay mode: benchmark|gt|validation|test")
 	jsonOut := fs.Bool("json", false, "emit replay summary as JSON")
-	storeKind := fs.String("store", storage.DefaultStoreKind(), "store backend: memory|sqlite")
-	dbPath := fs.String("db-path", "protogonos.db", "sqlite database path")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -1136,76 +1820,659 @@ func runEpitopesTest(ctx context.Context, args []string) error {
 	return nil
 }
 
-func runBenchmark(ctx context.Context, args []string) error {
-	fs := flag.NewFlagSet("benchmark", flag.ContinueOnError)
-	configPath := fs.String("config", "", "optional run config JSON path (map2rec-backed)")
-	runID := fs.String("run-id", "", "explicit run id (optional)")
-	continuePopID := fs.String("continue-pop-id", "", "continue from persisted population snapshot id")
-	specieIdentifier := fs.String("specie-identifier", "topology", "species identifier: topology|tot_n|fingerprint")
-	opMode := fs.String("op-mode", "gt", "operation mode: gt|validation|test (or composite gt+validation/test)")
-	evolutionType := fs.String("evolution-type", "generational", "evolution type: generational|steady_state")
-	scapeName := fs.String("scape", "xor", "scape name")
-	gtsaCSV := fs.String("gtsa-csv", "", "optional GTSA CSV table path")
-	gtsaProfile := fs.String("gtsa-profile", "", "optional GTSA seed profile override: default|core")
-	gtsaTrainEnd := fs.Int("gtsa-train-end", 0, "optional GTSA train_end cutoff for loaded CSV")
-	gtsaValidationEnd := fs.Int("gtsa-validation-end", 0, "optional GTSA validation_end cutoff for loaded CSV")
-	gtsaTestEnd := fs.Int("gtsa-test-end", 0, "optional GTSA test_end cutoff for loaded CSV")
-	fxCSV := fs.String("fx-csv", "", "optional FX CSV price-series path")
-	fxProfile := fs.String("fx-profile", "", "optional FX seed profile override: default|market")
-	epitopesProfile := fs.String("epitopes-profile", "", "optional epitopes seed profile override: default|core")
-	epitopesCSV := fs.String("epitopes-csv", "", "optional epitopes CSV table path")
-	epitopesTable := fs.String("epitopes-table", "", "optional built-in epitopes table name (abc_pred10|abc_pred12|abc_pred14|abc_pred16|abc_pred18|abc_pred20)")
-	llvmProfile := fs.String("llvm-profile", "", "optional llvm-phase-ordering seed profile override: default|core")
-	llvmWorkflowJSON := fs.String("llvm-workflow-json", "", "optional LLVM workflow JSON path")
-	flatlandScannerProfile := fs.String("flatland-scanner-profile", "", "optional flatland scanner profile override: balanced5|core3|forward5")
-	flatlandScannerSpread := fs.Float64("flatland-scanner-spread", 0, "optional flatland scanner spread override in [0.05,1]")
-	flatlandScannerOffset := fs.Float64("flatland-scanner-offset", 0, "optional flatland scanner offset override in [-1,1]")
-	flatlandLayoutRandomize := fs.Bool("flatland-layout-randomize", false, "optional flatland layout randomization override")
-	flatlandLayoutVariants := fs.Int("flatland-layout-variants", 0, "optional flatland layout variants override (>0)")
-	flatlandForceLayoutVariant := fs.Int("flatland-force-layout-variant", 0, "optional flatland forced layout variant index")
-	flatlandBenchmarkTrials := fs.Int("flatland-benchmark-trials", 0, "optional flatland benchmark trial count override (>0)")
-	flatlandMaxAge := fs.Int("flatland-max-age", 0, "optional flatland max age override (>0)")
-	flatlandForageGoal := fs.Int("flatland-forage-goal", 0, "optional flatland forage goal override (>0)")
-	epitopesGTStart := fs.Int("epitopes-gt-start", 0, "optional epitopes GT start index for loaded CSV")
-	epitopesGTEnd := fs.Int("epitopes-gt-end", 0, "optional epitopes GT end index for loaded CSV")
-	epitopesValidationStart := fs.Int("epitopes-validation-start", 0, "optional epitopes validation start index for loaded CSV")
-	epitopesValidationEnd := fs.Int("epitopes-validation-end", 0, "optional epitopes validation end index for loaded CSV")
-	epitopesTestStart := fs.Int("epitopes-test-start", 0, "optional epitopes test start index for loaded CSV")
-	epitopesTestEnd := fs.Int("epitopes-test-end", 0, "optional epitopes test end index for loaded CSV")
-	epitopesBenchmarkStart := fs.Int("epitopes-benchmark-start", 0, "optional epitopes benchmark start index for loaded CSV")
-	epitopesBenchmarkEnd := fs.Int("epitopes-benchmark-end", 0, "optional epitopes benchmark end index for loaded CSV")
-	population := fs.Int("pop", 50, "population size")
-	generations := fs.Int("gens", 100, "generation count")
-	survivalPercentage := fs.Float64("survival-percentage", 0.0, "survival percentage used to derive elite retention when elite count is unset")
-	specieSizeLimit := fs.Int("specie-size-limit", 0, "maximum parent-pool size retained per species (0 disables)")
-	fitnessGoal := fs.Float64("fitness-goal", 0.0, "early-stop best fitness goal (0 disables)")
-	evaluationsLimit := fs.Int("evaluations-limit", 0, "early-stop total evaluation limit (0 disables)")
-	traceStepSize := fs.Int("trace-step-size", 500, "trace update cadence in total evaluations (0 uses runtime default)")
-	startPaused := fs.Bool("start-paused", false, "start monitor in paused state (requires continue)")
-	autoContinueMS := fs.Int("auto-continue-ms", 0, "auto-send continue after N milliseconds when start-paused is set (0 disables)")
-	seed := fs.Int64("seed", 1, "rng seed")
-	workers := fs.Int("workers", 4, "worker count")
-	storeKind := fs.String("store", storage.DefaultStoreKind(), "store backend: memory|sqlite")
-	dbPath := fs.String("db-path", "protogonos.db", "sqlite database path")
-	enableTuning := fs.Bool("tuning", false, "enable exoself tuning")
-	validationProbe := fs.Bool("validation-probe", false, "evaluate per-species champions in validation probe during gt runs")
-	testProbe := fs.Bool("test-probe", false, "evaluate per-species champions in test probe during gt runs")
-	profileName := fs.String("profile", "", "optional parity profile id (from testdata/fixtures/parity/ref_benchmarker_profiles.json)")
-	selectionName := fs.String("selection", "elite", "parent selection strategy: elite|tournament|species_tournament|species_shared_tournament|hof_competition|hof_rank|hof_top3|hof_efficiency|hof_random|competition|top3")
-	postprocessorName := fs.String("fitness-postprocessor", "none", "fitness postprocessor: none|size_proportional|nsize_proportional|novelty_proportional")
-	topoPolicyName := fs.String("topo-policy", "const", "topological mutation count policy: const|ncount_linear|ncount_exponential")
-	topoCount := fs.Int("topo-count", 1, "mutation count for topo-policy=const")
-	topoParam := fs.Float64("topo-param", 0.5, "policy parameter (multiplier/power) for topo-policy")
-	topoMax := fs.Int("topo-max", 8, "maximum mutation count for non-const topo policies (<=0 disables cap)")
-	tuneAttempts := fs.Int("attempts", 4, "tuning attempts per agent evaluation")
-	tuneSteps := fs.Int("tune-steps", 6, "tuning perturbation steps per attempt")
+func runReplay(ctx context.Context, args []string) error {
+	fs := newFlagSet("replay")
+	runID := fs.String("run-id", "", "run id")
+	latest := fs.Bool("latest", false, "replay the most recent run from run index")
+	genomeID := fs.String("genome-id", "", "top genome to replay (defaults to the champion)")
+	mode := fs.String("mode", "gt", "replay mode: gt|validation|test|benchmark")
+	render := fs.Bool("render", false, "record the episode and write a playback file into the run's replay artifacts (flatland, dtm)")
+	format := fs.String("format", "svg", "render format: svg (animated) | json (frame playback)")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *runID != "" && *latest {
+		return errors.New("use either --run-id or --latest, not both")
+	}
+	if *runID == "" && !*latest {
+		return errors.New("replay requires --run-id or --latest")
+	}
+
+	client, err := protoapi.New(protoapi.Options{
+		StoreKind:     *storeKind,
+		DBPath:        *dbPath,
+		BenchmarksDir: benchmarksDir,
+		ExportsDir:    exportsDir,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	summary, err := client.Replay(ctx, protoapi.ReplayRequest{
+		RunID:    *runID,
+		Latest:   *latest,
+		GenomeID: *genomeID,
+		Mode:     *mode,
+		Render:   *render,
+		Format:   *format,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("replay run_id=%s scape=%s mode=%s genome_id=%s fitness=%.6f\n",
+		summary.RunID,
+		summary.Scape,
+		summary.Mode,
+		summary.GenomeID,
+		summary.Fitness,
+	)
+	if summary.Report != "" {
+		fmt.Println(summary.Report)
+	}
+	if summary.RenderPath != "" {
+		fmt.Printf("rendered frames=%d path=%s\n", summary.Frames, summary.RenderPath)
+	}
+	return nil
+}
+
+func runSensorImportance(ctx context.Context, args []string) error {
+	fs := newFlagSet("sensor-importance")
+	runID := fs.String("run-id", "", "run id")
+	latest := fs.Bool("latest", false, "analyze the most recent run from run index")
+	genomeID := fs.String("genome-id", "", "top genome to analyze (defaults to the champion)")
+	mode := fs.String("mode", "gt", "evaluation mode: gt|validation|test|benchmark")
+	jsonOut := fs.Bool("json", false, "emit the sensor ranking as JSON")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *runID != "" && *latest {
+		return errors.New("use either --run-id or --latest, not both")
+	}
+	if *runID == "" && !*latest {
+		return errors.New("sensor-importance requires --run-id or --latest")
+	}
+
+	client, err := protoapi.New(protoapi.Options{
+		StoreKind:     *storeKind,
+		DBPath:        *dbPath,
+		BenchmarksDir: benchmarksDir,
+		ExportsDir:    exportsDir,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	report, err := client.SensorImportance(ctx, protoapi.SensorImportanceRequest{
+		RunID:    *runID,
+		Latest:   *latest,
+		GenomeID: *genomeID,
+		Mode:     *mode,
+	})
+	if err != nil {
+		return err
+	}
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	fmt.Printf("sensor_importance run_id=%s scape=%s mode=%s genome_id=%s baseline_fitness=%.6f sensors=%d path=%s\n",
+		report.RunID,
+		report.Scape,
+		report.Mode,
+		report.GenomeID,
+		report.BaselineFitness,
+		len(report.Sensors),
+		report.Path,
+	)
+	for i, sensor := range report.Sensors {
+		fmt.Printf("rank=%d sensor_id=%s importance=%.6f ablated_fitness=%.6f\n",
+			i+1,
+			sensor.SensorID,
+			sensor.Importance,
+			sensor.AblatedFitness,
+		)
+	}
+	return nil
+}
+
+func runSimilar(ctx context.Context, args []string) error {
+	fs := newFlagSet("similar")
+	genomeID := fs.String("genome-id", "", "query genome id (stored genome or run top genome)")
+	runID := fs.String("run-id", "", "query the champion of this run (or scope --genome-id to it)")
+	latest := fs.Bool("latest", false, "query the champion of the most recent run from run index")
+	k := fs.Int("k", 5, "number of nearest genomes to return")
+	metric := fs.String("metric", "compatibility", "distance metric: compatibility|embedding")
+	jsonOut := fs.Bool("json", false, "emit matches as JSON")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *runID != "" && *latest {
+		return errors.New("use either --run-id or --latest, not both")
+	}
+	if *genomeID == "" && *runID == "" && !*latest {
+		return errors.New("similar requires --genome-id, --run-id or --latest")
+	}
+	if *k <= 0 {
+		return errors.New("k must be > 0")
+	}
+
+	client, err := protoapi.New(protoapi.Options{
+		StoreKind:     *storeKind,
+		DBPath:        *dbPath,
+		BenchmarksDir: benchmarksDir,
+		ExportsDir:    exportsDir,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	result, err := client.SimilarGenomes(ctx, protoapi.SimilarGenomesRequest{
+		GenomeID: *genomeID,
+		RunID:    *runID,
+		Latest:   *latest,
+		K:        *k,
+		Metric:   *metric,
+	})
+	if err != nil {
+		return err
+	}
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+	fmt.Printf("similar query_genome_id=%s query_run_id=%s metric=%s fingerprint=%s candidates=%d\n",
+		result.QueryGenomeID,
+		result.QueryRunID,
+		result.Metric,
+		result.QueryFingerprint,
+		result.CandidateCount,
+	)
+	for i, match := range result.Matches {
+		fmt.Printf("rank=%d genome_id=%s run_id=%s distance=%.6f fitness=%.6f same_fingerprint=%t\n",
+			i+1,
+			match.GenomeID,
+			match.RunID,
+			match.Distance,
+			match.Fitness,
+			match.SameFingerprint,
+		)
+	}
+	return nil
+}
+
+func runPlot(ctx context.Context, args []string) error {
+	fs := newFlagSet("plot")
+	runID := fs.String("run-id", "", "run id")
+	latest := fs.Bool("latest", false, "plot the most recent run from run index")
+	what := fs.String("what", protoapi.PlotFitness, "what to plot: fitness|species|tuning")
+	outPath := fs.String("out", "plot.svg", "output file; the format follows the extension (.svg or .png) unless --format is set")
+	format := fs.String("format", "", "output format: svg|png (default from --out extension)")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *runID != "" && *latest {
+		return errors.New("use either --run-id or --latest, not both")
+	}
+	if *runID == "" && !*latest {
+		return errors.New("plot requires --run-id or --latest")
+	}
+	if strings.TrimSpace(*outPath) == "" {
+		return errors.New("plot requires --out")
+	}
+	plotFormat := strings.TrimSpace(*format)
+	if plotFormat == "" {
+		plotFormat = protoapi.PlotFormatSVG
+		if strings.EqualFold(filepath.Ext(*outPath), ".png") {
+			plotFormat = protoapi.PlotFormatPNG
+		}
+	}
+
+	client, err := protoapi.New(protoapi.Options{
+		StoreKind:     *storeKind,
+		DBPath:        *dbPath,
+		BenchmarksDir: benchmarksDir,
+		ExportsDir:    exportsDir,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	result, err := client.Plot(ctx, protoapi.PlotRequest{
+		RunID:  *runID,
+		Latest: *latest,
+		What:   *what,
+		Format: plotFormat,
+	})
+	if err != nil {
+		return err
+	}
+	path := filepath.Clean(*outPath)
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	if err := os.WriteFile(path, result.Data, 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("plot run_id=%s what=%s format=%s generations=%d out=%s\n", result.RunID, result.What, result.Format, result.Generations, path)
+	return nil
+}
+
+func runCrossEval(ctx context.Context, args []string) error {
+	fs := newFlagSet("cross-eval")
+	runs := fs.String("runs", "", "comma-separated run ids whose champions are cross-evaluated (at least two)")
+	modes := fs.String("modes", "validation,test", "comma-separated evaluation modes: gt|validation|test|benchmark")
+	jsonOut := fs.Bool("json", false, "emit the matrix as JSON")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	runIDs := splitCommaList(*runs)
+	if len(runIDs) < 2 {
+		return errors.New("cross-eval requires --runs with at least two run ids")
+	}
+
+	client, err := protoapi.New(protoapi.Options{
+		StoreKind:     *storeKind,
+		DBPath:        *dbPath,
+		BenchmarksDir: benchmarksDir,
+		ExportsDir:    exportsDir,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	result, err := client.CrossEval(ctx, protoapi.CrossEvalRequest{
+		RunIDs: runIDs,
+		Modes:  splitCommaList(*modes),
+	})
+	if err != nil {
+		return err
+	}
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	fmt.Printf("cross_eval runs=%s modes=%s\n", strings.Join(result.RunIDs, ","), strings.Join(result.Modes, ","))
+	var failed []protoapi.CrossEvalCell
+	for _, mode := range result.Modes {
+		for _, champion := range result.RunIDs {
+			row := make([]string, 0, len(result.RunIDs))
+			for _, cell := range result.Cells {
+				if cell.Mode != mode || cell.ChampionRunID != champion {
+					continue
+				}
+				if cell.Error != "" {
+					row = append(row, cell.EvalRunID+"=error")
+					failed = append(failed, cell)
+					continue
+				}
+				row = append(row, fmt.Sprintf("%s=%.6f", cell.EvalRunID, cell.Fitness))
+			}
+			fmt.Printf("mode=%s champion_run=%s %s\n", mode, champion, strings.Join(row, " "))
+		}
+	}
+	for _, gap := range result.Gaps {
+		fmt.Printf("gap mode=%s champion_run=%s self=%.6f mean_other=%.6f gap=%.6f others=%d\n",
+			gap.Mode,
+			gap.ChampionRunID,
+			gap.Self,
+			gap.MeanOther,
+			gap.Gap,
+			gap.Others,
+		)
+	}
+	for _, cell := range failed {
+		fmt.Printf("error mode=%s champion_run=%s eval_run=%s detail=%q\n", cell.Mode, cell.ChampionRunID, cell.EvalRunID, cell.Error)
+	}
+	return nil
+}
+
+// splitCommaList splits a comma-separated flag value, dropping blank items.
+func splitCommaList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func runServeModel(ctx context.Context, args []string) error {
+	fs := newFlagSet("serve-model")
+	champion := fs.String("champion", "", "run id whose champion is served")
+	latest := fs.Bool("latest", false, "serve the champion of the most recent run from run index")
+	genomeID := fs.String("genome-id", "", "top genome to serve instead of the champion")
+	compactFile := fs.String("compact", "", "serve the genome of a compact export (.pgc) instead of a stored run")
+	listen := fs.String("listen", ":8080", "http listen address")
+	maxBatch := fs.Int("max-batch", 32, "maximum rows merged into one forward pass")
+	batchWindow := fs.Duration("batch-window", 2*time.Millisecond, "how long to wait for concurrent requests to join a batch")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *champion != "" && *latest {
+		return errors.New("use either --champion or --latest, not both")
+	}
+	if *compactFile != "" && (*champion != "" || *latest || *genomeID != "") {
+		return errors.New("--compact cannot be combined with --champion, --latest or --genome-id")
+	}
+	if *champion == "" && !*latest && *compactFile == "" {
+		return errors.New("serve-model requires --champion, --latest or --compact")
+	}
+	if *maxBatch <= 0 {
+		return errors.New("--max-batch must be > 0")
+	}
+	if *batchWindow <= 0 {
+		return errors.New("--batch-window must be > 0")
+	}
+
+	client, err := protoapi.New(protoapi.Options{
+		StoreKind:     *storeKind,
+		DBPath:        *dbPath,
+		BenchmarksDir: benchmarksDir,
+		ExportsDir:    exportsDir,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	server, err := client.NewModelServer(ctx, protoapi.ServeModelRequest{
+		RunID:       *champion,
+		Latest:      *latest,
+		GenomeID:    *genomeID,
+		CompactFile: *compactFile,
+		MaxBatch:    *maxBatch,
+		BatchWindow: *batchWindow,
+	})
+	if err != nil {
+		return err
+	}
+	defer server.Close()
+
+	listener, err := net.Listen("tcp", *listen)
+	if err != nil {
+		return err
+	}
+	info := server.Info()
+	fmt.Printf("serve_model run_id=%s scape=%s genome_id=%s inputs=%d outputs=%d batched=%t listen=%s\n",
+		info.RunID,
+		info.Scape,
+		info.GenomeID,
+		info.Inputs,
+		info.Outputs,
+		info.Batched,
+		listener.Addr(),
+	)
+
+	httpServer := &http.Server{Handler: server.Handler()}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- httpServer.Serve(listener)
+	}()
+	select {
+	case err := <-serveErr:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return httpServer.Shutdown(shutdownCtx)
+	}
+}
+
+func runServe(ctx context.Context, args []string) error {
+	fs := newFlagSet("serve")
+	grpcListen := fs.String("grpc-listen", ":9090", "gRPC listen address (empty disables)")
+	httpListen := fs.String("http-listen", ":8080", "JSON/HTTP gateway listen address (empty disables)")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *grpcListen == "" && *httpListen == "" {
+		return errors.New("serve requires --grpc-listen or --http-listen")
+	}
+
+	client, err := protoapi.New(protoapi.Options{
+		StoreKind:     *storeKind,
+		DBPath:        *dbPath,
+		BenchmarksDir: benchmarksDir,
+		ExportsDir:    exportsDir,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+	server, err := remote.NewServer(ctx, client)
+	if err != nil {
+		return err
+	}
+	defer server.Close()
+
+	serveErr := make(chan error, 2)
+	var grpcServer *grpc.Server
+	if *grpcListen != "" {
+		listener, err := net.Listen("tcp", *grpcListen)
+		if err != nil {
+			return err
+		}
+		grpcServer = grpc.NewServer()
+		server.RegisterGRPC(grpcServer)
+		fmt.Printf("serve grpc=%s service=%s\n", listener.Addr(), remote.ServiceName)
+		go func() {
+			serveErr <- grpcServer.Serve(listener)
+		}()
+		defer grpcServer.Stop()
+	}
+	var httpServer *http.Server
+	if *httpListen != "" {
+		listener, err := net.Listen("tcp", *httpListen)
+		if err != nil {
+			return err
+		}
+		httpServer = &http.Server{Handler: server.Handler()}
+		fmt.Printf("serve http=%s prefix=%s\n", listener.Addr(), remote.GatewayPrefix)
+		go func() {
+			serveErr <- httpServer.Serve(listener)
+		}()
+	}
+
+	select {
+	case err := <-serveErr:
+		if errors.Is(err, http.ErrServerClosed) || errors.Is(err, grpc.ErrServerStopped) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		if httpServer == nil {
+			return nil
+		}
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return httpServer.Shutdown(shutdownCtx)
+	}
+}
+
+func runBenchmark(ctx context.Context, args []string) error {
+	fs := newFlagSet("benchmark")
+	configPath := fs.String("config", "", "optional run config JSON path (map2rec-backed)")
+	runID := fs.String("run-id", "", "explicit run id (optional)")
+	continuePopID := fs.String("continue-pop-id", "", "continue from persisted population snapshot id")
+	initFromChampions := fs.String("init-from-champions", "", "seed part of the initial population with mutated copies of archived champions: scape=<name> top=<n> fraction=<0..1>")
+	specieIdentifier := fs.String("specie-identifier", "topology", "species identifier: topology|tot_n|fingerprint")
+	opMode := fs.String("op-mode", "gt", "operation mode: gt|validation|test (or composite gt+validation/test)")
+	evolutionType := fs.String("evolution-type", "generational", "evolution type: generational|steady_state|online")
+	genomeEncoding := fs.String("encoding", "auto", "genotype-phenotype encoding: auto|direct|substrate|grammar")
+	algorithm := fs.String("algorithm", "neuroevolution", "search algorithm: neuroevolution or a baseline with the same evaluation budget: random_search|hill_climb|es")
+	scapeName := fs.String("scape", "xor", "scape name")
+	var scapeParams stringListFlag
+	fs.Var(&scapeParams, "scape-param", "scape construction parameter key=value, repeatable (parity|majority: n=<inputs>; function-approx: fn=sine|polynomial|step|saddle|gaussian, samples, noise, seed, folds; epitopes: folds; stack-machine: task=reverse|sum|arith, length, width, examples, seed; pole2-balancing: fitness=default|gruau; dtm: right_reward, left_reward, runs, switch_floor; fx: instrument=<price csv>, steps)")
+	gtsaCSV := fs.String("gtsa-csv", "", "optional GTSA CSV table path")
+	gtsaProfile := fs.String("gtsa-profile", "", "optional GTSA seed profile override: default|core")
+	gtsaTrainEnd := fs.Int("gtsa-train-end", 0, "optional GTSA train_end cutoff for loaded CSV")
+	gtsaValidationEnd := fs.Int("gtsa-validation-end", 0, "optional GTSA validation_end cutoff for loaded CSV")
+	gtsaTestEnd := fs.Int("gtsa-test-end", 0, "optional GTSA test_end cutoff for loaded CSV")
+	fxCSV := fs.String("fx-csv", "", "optional FX CSV price-series path")
+	fxProfile := fs.String("fx-profile", "", "optional FX seed profile override: default|market")
+	epitopesProfile := fs.String("epitopes-profile", "", "optional epitopes seed profile override: default|core")
+	epitopesCSV := fs.String("epitopes-csv", "", "optional epitopes CSV table path")
+	epitopesTable := fs.String("epitopes-table", "", "optional built-in epitopes table name (abc_pred10|abc_pred12|abc_pred14|abc_pred16|abc_pred18|abc_pred20)")
+	llvmProfile := fs.String("llvm-profile", "", "optional llvm-phase-ordering seed profile override: default|core")
+	llvmWorkflowJSON := fs.String("llvm-workflow-json", "", "optional LLVM workflow JSON path")
+	flatlandScannerProfile := fs.String("flatland-scanner-profile", "", "optional flatland scanner profile override: balanced5|core3|forward5")
+	flatlandScannerSpread := fs.Float64("flatland-scanner-spread", 0, "optional flatland scanner spread override in [0.05,1]")
+	flatlandScannerOffset := fs.Float64("flatland-scanner-offset", 0, "optional flatland scanner offset override in [-1,1]")
+	flatlandLayoutRandomize := fs.Bool("flatland-layout-randomize", false, "optional flatland layout randomization override")
+	flatlandLayoutVariants := fs.Int("flatland-layout-variants", 0, "optional flatland layout variants override (>0)")
+	flatlandForceLayoutVariant := fs.Int("flatland-force-layout-variant", 0, "optional flatland forced layout variant index")
+	flatlandBenchmarkTrials := fs.Int("flatland-benchmark-trials", 0, "optional flatland benchmark trial count override (>0)")
+	flatlandMaxAge := fs.Int("flatland-max-age", 0, "optional flatland max age override (>0)")
+	flatlandForageGoal := fs.Int("flatland-forage-goal", 0, "optional flatland forage goal override (>0)")
+	epitopesGTStart := fs.Int("epitopes-gt-start", 0, "optional epitopes GT start index for loaded CSV")
+	epitopesGTEnd := fs.Int("epitopes-gt-end", 0, "optional epitopes GT end index for loaded CSV")
+	epitopesValidationStart := fs.Int("epitopes-validation-start", 0, "optional epitopes validation start index for loaded CSV")
+	epitopesValidationEnd := fs.Int("epitopes-validation-end", 0, "optional epitopes validation end index for loaded CSV")
+	epitopesTestStart := fs.Int("epitopes-test-start", 0, "optional epitopes test start index for loaded CSV")
+	epitopesTestEnd := fs.Int("epitopes-test-end", 0, "optional epitopes test end index for loaded CSV")
+	epitopesBenchmarkStart := fs.Int("epitopes-benchmark-start", 0, "optional epitopes benchmark start index for loaded CSV")
+	epitopesBenchmarkEnd := fs.Int("epitopes-benchmark-end", 0, "optional epitopes benchmark end index for loaded CSV")
+	population := fs.Int("pop", 50, "population size")
+	generations := fs.Int("gens", 100, "generation count")
+	survivalPercentage := fs.Float64("survival-percentage", 0.0, "survival percentage used to derive elite retention when elite count is unset")
+	specieSizeLimit := fs.Int("specie-size-limit", 0, "maximum parent-pool size retained per species (0 disables)")
+	fitnessGoal := fs.Float64("fitness-goal", 0.0, "early-stop best fitness goal (0 disables)")
+	evaluationsLimit := fs.Int("evaluations-limit", 0, "early-stop total evaluation limit (0 disables)")
+	successRate := fs.Int("success-rate", 0, "repeat the run over N consecutive seeds and report the fraction reaching --fitness-goal within --evaluations-limit (0 disables)")
+	stopCondition := fs.String("stop", "", "compound stop condition over best, mean, evals, generation, stagnation, species and elapsed, e.g. \"best>=0.99 || (evals>=200000 && stagnation>=30)\"; replaces --fitness-goal/--evaluations-limit")
+	traceStepSize := fs.Int("trace-step-size", 500, "trace update cadence in total evaluations (0 uses runtime default)")
+	startPaused := fs.Bool("start-paused", false, "start monitor in paused state (requires continue)")
+	autoContinueMS := fs.Int("auto-continue-ms", 0, "auto-send continue after N milliseconds when start-paused is set (0 disables)")
+	noBatchEval := fs.Bool("no-batch-eval", false, "disable batched dataset evaluation for batch-capable scapes")
+	noEvalCache := fs.Bool("no-eval-cache", false, "disable neuron activation caching across tuning evaluations on batch-capable scapes")
+	meterEvaluations := fs.Bool("meter-evaluations", false, "measure per-evaluation cpu time and allocations into diagnostics and the run summary")
+	verifyInvariants := fs.Bool("verify-invariants", false, "check population size, unique ids, species assignments and elite preservation every generation, failing fast with a population dump")
+	noRegressionWatch := fs.Bool("no-regression-watch", false, "skip scoring the run's champion against the best earlier champion of the scape on the run's validation split after the run")
+	regressionTolerance := fs.Float64("regression-tolerance", 0, "fraction of the reference champion's validation fitness the run's champion may fall short by before the regression watch flags a regression (0 uses 0.05)")
+	dryRun := fs.Bool("dry-run", false, "resolve and validate the run, print its effective configuration and estimated evaluation budget, and exit without evaluating")
+	seed := fs.Int64("seed", 1, "rng seed")
+	seedSelect := fs.Int64("seed-select", 0, "optional parent selection rng seed (defaults to --seed)")
+	seedMutate := fs.Int64("seed-mutate", 0, "optional mutation rng seed (defaults to --seed)")
+	seedEnv := fs.Int64("seed-env", 0, "optional scape environment noise seed (unset keeps the scape default)")
+	progress := fs.Bool("progress", true, "print per-generation wall clock, throughput and ETA while running")
+	output := fs.String("output", outputText, "output format: text|jsonl (jsonl streams one JSON record per generation and a final summary record to stdout)")
+	workers := fs.Int("workers", 4, "worker count")
+	trials := fs.Int("trials", 1, "repeated evaluation trials per genome; above 1 scores by trial mean with a bootstrap CI")
+	ciTieBreak := fs.Bool("ci-tiebreak", false, "break equal-fitness ranking ties by bootstrap CI lower bound (requires --trials > 1)")
+	trialAggregation := fs.String("trial-aggregation", "mean", "repeated-trial fitness aggregation: mean|cvar|worst")
+	cvarAlpha := fs.Float64("cvar-alpha", 0.1, "tail fraction for --trial-aggregation=cvar in (0,1]")
+	fitnessScaling := fs.String("fitness-scaling", "none", "fitness scaling applied before parent selection: none|sigma|rank|minmax")
+	scalingPressure := fs.Float64("scaling-pressure", 0, "sigma multiple for --fitness-scaling=sigma (default 2) or linear ranking pressure in [1,2] for rank (default 1.5)")
+	speciesAllocation := fs.String("species-allocation", "proportional", "offspring allocation across species: proportional|rank|equal")
+	allocationFloor := fs.Int("allocation-floor", 0, "minimum offspring per species each generation")
+	allocationCeiling := fs.Int("allocation-ceiling", 0, "maximum offspring per species each generation (0 = unbounded)")
+	speciesSoftCap := fs.Int("species-soft-cap", 0, "soft species size cap: offspring of larger species go to smaller ones (generational only; 0 disables)")
+	speciesCapHysteresis := fs.Float64("species-cap-hysteresis", 0, "fraction below --species-soft-cap a capped species must shrink to before it is released, in [0,1)")
+	populationResize := fs.String("population-resize", "off", "population size control: off, guard (keep elites + 2x species) or auto (also grow on stagnation, shrink to the time target)")
+	minPopulation := fs.Int("min-population", 0, "smallest population resize may shrink to (0 = viable floor only)")
+	maxPopulation := fs.Int("max-population", 0, "largest population resize may grow to (0 = unbounded, or 4x population under auto)")
+	resizeTargetSeconds := fs.Float64("resize-target-seconds", 0, "per-generation wall-clock target for auto population resize (0 = none)")
+	lowFidelity := fs.Float64("low-fidelity", 0, "screen every genome at this fraction of the full episode/data in (0,1); 0 disables multi-fidelity")
+	finalistFraction := fs.Float64("finalist-fraction", 0.25, "fraction of screened genomes re-evaluated at full fidelity when --low-fidelity is set")
+	activationClamp := fs.Float64("activation-clamp", 0, "clamp aggregated neuron input to this magnitude and zero NaN/Inf values, counting clamp events per genome (0 disables)")
+	weightClamp := fs.Float64("weight-clamp", 0, "clamp synapse weights to this magnitude during evaluation, counting clamp events per genome (0 disables)")
+	crossoverRate := fs.Float64("crossover-rate", 0, "probability an offspring is bred from two parents of the same species before mutation (generational and steady_state only)")
+	interspeciesMating := fs.Float64("interspecies-mating", 0, "probability a crossover mate is drawn from another species, producing a hybrid")
+	pruneDisabledAfter := fs.Int("prune-disabled-after", 0, "remove offspring synapses disabled for this many generations (0 disables)")
+	pruneDisabledProb := fs.Float64("prune-disabled-prob", 0, "probability each synapse due for --prune-disabled-after pruning is removed (default 0.5)")
+	trackInnovations := fs.Bool("track-innovations", false, "mark new neurons and synapses with innovation numbers from a per-run registry persisted in the store")
+	initialSensorsMin := fs.Int("initial-sensors-min", 0, "fewest morphology sensors a seed genome starts with when --initial-sensors-max is set (default 1)")
+	initialSensorsMax := fs.Int("initial-sensors-max", 0, "start each seed genome with a random subset of at most N morphology sensors (0 keeps all)")
+	evalTimeoutMS := fs.Int("eval-timeout-ms", 0, "fail a scape evaluation that runs longer than N milliseconds (0 disables)")
+	scapeSandbox := fs.Bool("scape-sandbox", false, "evaluate the scape in sandboxed worker subprocesses; crashes, stalls and scape errors score --sandbox-failure-fitness")
+	sandboxCommand := fs.String("sandbox-command", "", "sandbox worker command line (default: this binary's scape-worker subcommand)")
+	sandboxCPUSeconds := fs.Int("sandbox-cpu-seconds", 0, "CPU seconds a sandboxed evaluation may use before its worker is killed (0 disables)")
+	sandboxMemoryMB := fs.Int("sandbox-memory-mb", 0, "memory limit of each sandbox worker in MiB (0 disables)")
+	sandboxTimeoutMS := fs.Int("sandbox-timeout-ms", 0, "kill a sandbox worker whose evaluation runs longer than N milliseconds (0 disables)")
+	sandboxFailureFitness := fs.Float64("sandbox-failure-fitness", 0, "fitness of an evaluation that fails inside the sandbox")
+	entropyThreshold := fs.Float64("entropy-threshold", 0, "trigger --entropy-action when normalized population entropy drops below this value in (0,1] (0 disables)")
+	entropyMeasure := fs.String("entropy-measure", "", "entropy measure for --entropy-threshold: genotype (default) or behavior")
+	entropyAction := fs.String("entropy-action", "", "action on low entropy: mutation (default, triples mutation counts), immigrants (replaces the worst quarter) or restart (reseeds all but elites)")
+	entropyCooldown := fs.Int("entropy-cooldown", 0, "generations the entropy detector rests after triggering (default 5 when --entropy-threshold is set)")
+	reproductionInterval := fs.Int("reproduction-interval", 0, "online evolution: evaluations between reproduction events (default population size)")
+	diagnosticsWindow := fs.Int("diagnostics-window", 0, "online evolution: evaluations per rolling diagnostics window (default population size)")
+	islands := fs.Int("islands", 0, "split the population into this many islands that breed apart (generational evolution)")
+	migrationInterval := fs.Int("migration-interval", 0, "islands: generations between champion migrations (default 10)")
+	migrants := fs.Int("migrants", 0, "islands: best genomes each island sends to the next at a migration (default 1)")
+	checkpointInterval := fs.Int("checkpoint-interval", 0, "save a resumable checkpoint every N generations (0 disables; see resume)")
+	snapshotInterval := fs.Int("snapshot-interval", 0, "save the scored population every N generations so fork --at-gen can branch from it (0 disables)")
+	karmaStrikes := fs.Int("karma-strikes", 0, "score timeouts, panics and NaN/Inf results as degenerate and ban a fingerprint from parenthood after N degenerate generations (0 disables)")
+	karmaCooldown := fs.Int("karma-cooldown", 0, "generations a banned fingerprint is excluded from parenthood (default 5 when --karma-strikes is set)")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+	enableTuning := fs.Bool("tuning", false, "enable exoself tuning")
+	validationProbe := fs.Bool("validation-probe", false, "evaluate per-species champions in validation probe during gt runs")
+	testProbe := fs.Bool("test-probe", false, "evaluate per-species champions in test probe during gt runs")
+	profileName := fs.String("profile", "", "optional parity profile id (from testdata/fixtures/parity/ref_benchmarker_profiles.json)")
+	parityStrict := fs.Bool("parity-strict", false, "restrict operators, selection, tuning selection and speciation to reference (DXNN) equivalents, failing on any option without one")
+	selectionName := fs.String("selection", "elite", "parent selection strategy: elite|tournament|species_tournament|species_shared_tournament|hof_competition|hof_rank|hof_top3|hof_efficiency|hof_random|competition|top3|afpo|nsga2")
+	tournamentSize := fs.Int("tournament-size", 3, "candidates sampled per tournament for tournament-based selection")
+	tournamentNoReplace := fs.Bool("tournament-no-replace", false, "sample distinct tournament candidates (without replacement)")
+	tournamentWinProb := fs.Float64("tournament-win-prob", 1, "probability the fittest tournament candidate wins; below 1 lets weaker candidates win")
+	postprocessorName := fs.String("fitness-postprocessor", "none", "fitness postprocessor: none|size_proportional|nsize_proportional|novelty_proportional|novelty_archive|numeric_fragility|cost_proportional")
+	topoPolicyName := fs.String("topo-policy", "const", "topological mutation count policy: const|ncount_linear|ncount_exponential")
+	topoCount := fs.Int("topo-count", 1, "mutation count for topo-policy=const")
+	topoParam := fs.Float64("topo-param", 0.5, "policy parameter (multiplier/power) for topo-policy")
+	topoMax := fs.Int("topo-max", 8, "maximum mutation count for non-const topo policies (<=0 disables cap)")
+	tuneAttempts := fs.Int("attempts", 4, "tuning attempts per agent evaluation")
+	tuneSteps := fs.Int("tune-steps", 6, "tuning perturbation steps per attempt")
 	tuneStepSize := fs.Float64("tune-step-size", 0.35, "tuning perturbation magnitude")
 	tunePerturbationRange := fs.Float64("tune-perturbation-range", 1.0, "tuning perturbation spread multiplier")
 	tuneAnnealingFactor := fs.Float64("tune-annealing-factor", 1.0, "tuning per-step annealing factor")
 	tuneMinImprovement := fs.Float64("tune-min-improvement", 0.0, "minimum fitness gain required to accept a tuning candidate")
+	tuningTrace := fs.Bool("tuning-trace", false, "record every tuning candidate per agent to tuning_trace.jsonl (requires --tuning)")
+	tuneAcceptance := fs.String("tune-acceptance", "training", "fitness tuning candidates must improve: training|validation (validation scores each candidate on the validation split)")
 	tuneSelection := fs.String("tune-selection", tuning.CandidateSelectBestSoFar, "tuner candidate selection: best_so_far|original|dynamic|dynamic_random|all|all_random|active|active_random|recent|recent_random|current|current_random|lastgen|lastgen_random")
-	tuneDurationPolicy := fs.String("tune-duration-policy", "fixed", "tuning attempt policy: fixed|const|linear_decay|topology_scaled|nsize_proportional|wsize_proportional")
-	tuneDurationParam := fs.Float64("tune-duration-param", 1.0, "tuning attempt policy parameter")
+	tuneDurationPolicy := fs.String("tune-duration-policy", "fixed", "tuning attempt policy: fixed|const|linear_decay|topology_scaled|nsize_proportional|wsize_proportional|wall_clock")
+	tuneDurationParam := fs.Float64("tune-duration-param", 1.0, "tuning attempt policy parameter (wall_clock: run tuning budget in seconds)")
 	wPerturb := fs.Float64("w-perturb", 0.70, "weight for perturb_random_weight mutation")
 	wBias := fs.Float64("w-bias", 0.00, "weight for perturb_random_bias mutation")
 	wRemoveBias := fs.Float64("w-remove-bias", 0.00, "weight for remove_random_bias mutation")
@@ -1218,6 +2485,14 @@ func runBenchmark(ctx context.Context, args []string) error {
 	wPlasticityRule := fs.Float64("w-plasticity-rule", 0.00, "weight for change_plasticity_rule mutation")
 	wPlasticity := fs.Float64("w-plasticity", 0.03, "weight for perturb_plasticity_rate mutation")
 	wSubstrate := fs.Float64("w-substrate", 0.02, "weight for perturb_substrate_parameter mutation")
+	wRecurrentLoop := fs.Float64("w-recurrent-loop", 0.00, "weight for add_recurrent_loop mutation (self-loops and short cycles; 0 disables)")
+	recurrentLoopMaxLength := fs.Int("recurrent-loop-max-length", 0, "max neurons in a cycle closed by add_recurrent_loop (default 3; 1 allows self-loops only)")
+	wDuplicateNeuron := fs.Float64("w-duplicate-neuron", 0.00, "weight for duplicate_neuron mutation (copies a hidden neuron with jittered synapses; 0 disables)")
+	wToggleSynapse := fs.Float64("w-toggle-synapse", 0.00, "weight for toggle_synapse_enabled mutation (NEAT enable/disable gene; 0 disables)")
+	wInsertModule := fs.Float64("w-insert-module", 0.00, "weight for insert_module mutation (grafts a module from the module library; 0 disables)")
+	modules := fs.String("modules", "", "comma-separated module library ids insert_module may graft (default: whole library)")
+	wAllBiases := fs.Float64("w-all-biases", 0.00, "weight for perturb_all_biases mutation (perturbs a proportional subset of all biases; 0 disables)")
+	biasMaxDelta := fs.Float64("bias-max-delta", 0.00, "max per-step bias change for add_bias and perturb_all_biases (default 0.3)")
 	minImprovement := fs.Float64("min-improvement", 0.001, "minimum expected fitness improvement")
 	if err := fs.Parse(args); err != nil {
 		return err
@@ -1227,13 +2502,21 @@ func runBenchmark(ctx context.Context, args []string) error {
 		setFlags[f.Name] = true
 	})
 
+	scapeParamValues, err := parseScapeParams(scapeParams)
+	if err != nil {
+		return err
+	}
 	req, err := loadOrDefaultRunRequest(*configPath)
 	if err != nil {
 		return err
 	}
+	if err := applyConfigStoreOptions(*configPath, setFlags, storeKind, dbPath); err != nil {
+		return err
+	}
 	if *configPath == "" {
 		req = protoapi.RunRequest{
 			Scape:                   *scapeName,
+			ScapeParams:             scapeParamValues,
 			GTSACSVPath:             *gtsaCSV,
 			GTSAProfile:             *gtsaProfile,
 			GTSATrainEnd:            *gtsaTrainEnd,
@@ -1257,8 +2540,11 @@ func runBenchmark(ctx context.Context, args []string) error {
 			EpitopesBenchmarkEnd:    *epitopesBenchmarkEnd,
 			OpMode:                  *opMode,
 			EvolutionType:           *evolutionType,
+			Encoding:                *genomeEncoding,
+			Algorithm:               *algorithm,
 			RunID:                   *runID,
 			ContinuePopulationID:    *continuePopID,
+			InitFromChampions:       *initFromChampions,
 			SpecieIdentifier:        *specieIdentifier,
 			Population:              *population,
 			Generations:             *generations,
@@ -1269,9 +2555,74 @@ func runBenchmark(ctx context.Context, args []string) error {
 			TraceStepSize:           *traceStepSize,
 			StartPaused:             *startPaused,
 			AutoContinueAfter:       time.Duration(*autoContinueMS) * time.Millisecond,
+			DisableBatchEvaluation:  *noBatchEval,
+			DisableEvalCache:        *noEvalCache,
+			MeterEvaluations:        *meterEvaluations,
+			VerifyInvariants:        *verifyInvariants,
+			DisableRegressionWatch:  *noRegressionWatch,
+			RegressionTolerance:     *regressionTolerance,
 			Seed:                    *seed,
 			Workers:                 *workers,
+			EvaluationTrials:        *trials,
+			CITieBreak:              *ciTieBreak,
+			ParityStrict:            *parityStrict,
+			TrialAggregation:        *trialAggregation,
+			CVaRAlpha:               *cvarAlpha,
+			FitnessScaling:          *fitnessScaling,
+			ScalingPressure:         *scalingPressure,
+			SpeciesAllocation:       *speciesAllocation,
+			AllocationFloor:         *allocationFloor,
+			AllocationCeiling:       *allocationCeiling,
+			SpeciesSoftCap:          *speciesSoftCap,
+			SpeciesCapHysteresis:    *speciesCapHysteresis,
+			PopulationResize:        *populationResize,
+			MinPopulation:           *minPopulation,
+			MaxPopulation:           *maxPopulation,
+			ResizeTargetSeconds:     *resizeTargetSeconds,
+			LowFidelity:             *lowFidelity,
+			FinalistFraction:        *finalistFraction,
+			ActivationClamp:         *activationClamp,
+			WeightClamp:             *weightClamp,
+			CrossoverRate:           *crossoverRate,
+			InterspeciesMating:      *interspeciesMating,
+			PruneDisabledAfter:      *pruneDisabledAfter,
+			PruneDisabledProb:       *pruneDisabledProb,
+			TrackInnovations:        *trackInnovations,
+			InitialSensorsMin:       *initialSensorsMin,
+			InitialSensorsMax:       *initialSensorsMax,
+			EvaluationTimeout:       time.Duration(*evalTimeoutMS) * time.Millisecond,
+			ScapeSandbox:            *scapeSandbox,
+			SandboxCommand:          *sandboxCommand,
+			SandboxCPUSeconds:       *sandboxCPUSeconds,
+			SandboxMemoryMB:         *sandboxMemoryMB,
+			SandboxTimeout:          time.Duration(*sandboxTimeoutMS) * time.Millisecond,
+			SandboxFailureFitness:   *sandboxFailureFitness,
+			KarmaStrikes:            *karmaStrikes,
+			KarmaCooldown:           *karmaCooldown,
+			StopCondition:           *stopCondition,
+			EntropyThreshold:        *entropyThreshold,
+			EntropyMeasure:          *entropyMeasure,
+			EntropyAction:           *entropyAction,
+			EntropyCooldown:         *entropyCooldown,
+			ReproductionInterval:    *reproductionInterval,
+			DiagnosticsWindow:       *diagnosticsWindow,
+			Islands:                 *islands,
+			MigrationInterval:       *migrationInterval,
+			Migrants:                *migrants,
+			CheckpointInterval:      *checkpointInterval,
+			SnapshotInterval:        *snapshotInterval,
+			WeightRecurrentLoop:     *wRecurrentLoop,
+			RecurrentLoopMaxLength:  *recurrentLoopMaxLength,
+			WeightDuplicateNeuron:   *wDuplicateNeuron,
+			WeightToggleSynapse:     *wToggleSynapse,
+			WeightInsertModule:      *wInsertModule,
+			Modules:                 splitCommaList(*modules),
+			WeightAllBiases:         *wAllBiases,
+			BiasMaxDelta:            *biasMaxDelta,
 			Selection:               *selectionName,
+			TournamentSize:          *tournamentSize,
+			TournamentNoReplace:     *tournamentNoReplace,
+			TournamentWinProb:       *tournamentWinProb,
 			FitnessPostprocessor:    *postprocessorName,
 			TopologicalPolicy:       *topoPolicyName,
 			TopologicalCount:        *topoCount,
@@ -1289,6 +2640,8 @@ func runBenchmark(ctx context.Context, args []string) error {
 			TunePerturbationRange:   *tunePerturbationRange,
 			TuneAnnealingFactor:     *tuneAnnealingFactor,
 			TuneMinImprovement:      *tuneMinImprovement,
+			TuningTrace:             *tuningTrace,
+			TuneAcceptance:          *tuneAcceptance,
 			WeightPerturb:           *wPerturb,
 			WeightBias:              *wBias,
 			WeightRemoveBias:        *wRemoveBias,
@@ -1305,6 +2658,7 @@ func runBenchmark(ctx context.Context, args []string) error {
 	} else {
 		err := overrideFromFlags(&req, setFlags, map[string]any{
 			"scape":                     *scapeName,
+			"scape-param":               scapeParamValues,
 			"gtsa-profile":              *gtsaProfile,
 			"gtsa-csv":                  *gtsaCSV,
 			"gtsa-train-end":            *gtsaTrainEnd,
@@ -1327,8 +2681,11 @@ func runBenchmark(ctx context.Context, args []string) error {
 			"epitopes-benchmark-end":    *epitopesBenchmarkEnd,
 			"op-mode":                   *opMode,
 			"evolution-type":            *evolutionType,
+			"encoding":                  *genomeEncoding,
+			"algorithm":                 *algorithm,
 			"run-id":                    *runID,
 			"continue-pop-id":           *continuePopID,
+			"init-from-champions":       *initFromChampions,
 			"specie-identifier":         *specieIdentifier,
 			"pop":                       *population,
 			"gens":                      *generations,
@@ -1336,15 +2693,80 @@ func runBenchmark(ctx context.Context, args []string) error {
 			"specie-size-limit":         *specieSizeLimit,
 			"fitness-goal":              *fitnessGoal,
 			"evaluations-limit":         *evaluationsLimit,
+			"stop":                      *stopCondition,
+			"entropy-threshold":         *entropyThreshold,
+			"entropy-measure":           *entropyMeasure,
+			"entropy-action":            *entropyAction,
+			"entropy-cooldown":          *entropyCooldown,
+			"reproduction-interval":     *reproductionInterval,
+			"diagnostics-window":        *diagnosticsWindow,
+			"islands":                   *islands,
+			"migration-interval":        *migrationInterval,
+			"migrants":                  *migrants,
+			"checkpoint-interval":       *checkpointInterval,
+			"snapshot-interval":         *snapshotInterval,
+			"w-recurrent-loop":          *wRecurrentLoop,
+			"recurrent-loop-max-length": *recurrentLoopMaxLength,
+			"w-duplicate-neuron":        *wDuplicateNeuron,
+			"w-toggle-synapse":          *wToggleSynapse,
+			"w-insert-module":           *wInsertModule,
+			"modules":                   *modules,
+			"w-all-biases":              *wAllBiases,
+			"bias-max-delta":            *biasMaxDelta,
 			"trace-step-size":           *traceStepSize,
 			"start-paused":              *startPaused,
 			"auto-continue-ms":          *autoContinueMS,
+			"no-batch-eval":             *noBatchEval,
+			"no-eval-cache":             *noEvalCache,
+			"meter-evaluations":         *meterEvaluations,
+			"verify-invariants":         *verifyInvariants,
+			"no-regression-watch":       *noRegressionWatch,
+			"regression-tolerance":      *regressionTolerance,
 			"seed":                      *seed,
 			"workers":                   *workers,
+			"trials":                    *trials,
+			"ci-tiebreak":               *ciTieBreak,
+			"parity-strict":             *parityStrict,
+			"trial-aggregation":         *trialAggregation,
+			"cvar-alpha":                *cvarAlpha,
+			"fitness-scaling":           *fitnessScaling,
+			"scaling-pressure":          *scalingPressure,
+			"species-allocation":        *speciesAllocation,
+			"allocation-floor":          *allocationFloor,
+			"allocation-ceiling":        *allocationCeiling,
+			"species-soft-cap":          *speciesSoftCap,
+			"species-cap-hysteresis":    *speciesCapHysteresis,
+			"population-resize":         *populationResize,
+			"min-population":            *minPopulation,
+			"max-population":            *maxPopulation,
+			"resize-target-seconds":     *resizeTargetSeconds,
+			"low-fidelity":              *lowFidelity,
+			"finalist-fraction":         *finalistFraction,
+			"activation-clamp":          *activationClamp,
+			"weight-clamp":              *weightClamp,
+			"crossover-rate":            *crossoverRate,
+			"interspecies-mating":       *interspeciesMating,
+			"prune-disabled-after":      *pruneDisabledAfter,
+			"prune-disabled-prob":       *pruneDisabledProb,
+			"track-innovations":         *trackInnovations,
+			"initial-sensors-min":       *initialSensorsMin,
+			"initial-sensors-max":       *initialSensorsMax,
+			"eval-timeout-ms":           *evalTimeoutMS,
+			"scape-sandbox":             *scapeSandbox,
+			"sandbox-command":           *sandboxCommand,
+			"sandbox-cpu-seconds":       *sandboxCPUSeconds,
+			"sandbox-memory-mb":         *sandboxMemoryMB,
+			"sandbox-timeout-ms":        *sandboxTimeoutMS,
+			"sandbox-failure-fitness":   *sandboxFailureFitness,
+			"karma-strikes":             *karmaStrikes,
+			"karma-cooldown":            *karmaCooldown,
 			"tuning":                    *enableTuning,
 			"validation-probe":          *validationProbe,
 			"test-probe":                *testProbe,
 			"selection":                 *selectionName,
+			"tournament-size":           *tournamentSize,
+			"tournament-no-replace":     *tournamentNoReplace,
+			"tournament-win-prob":       *tournamentWinProb,
 			"fitness-postprocessor":     *postprocessorName,
 			"topo-policy":               *topoPolicyName,
 			"topo-count":                *topoCount,
@@ -1356,6 +2778,8 @@ func runBenchmark(ctx context.Context, args []string) error {
 			"tune-perturbation-range":   *tunePerturbationRange,
 			"tune-annealing-factor":     *tuneAnnealingFactor,
 			"tune-min-improvement":      *tuneMinImprovement,
+			"tuning-trace":              *tuningTrace,
+			"tune-acceptance":           *tuneAcceptance,
 			"tune-selection":            *tuneSelection,
 			"tune-duration-policy":      *tuneDurationPolicy,
 			"tune-duration-param":       *tuneDurationParam,
@@ -1387,6 +2811,25 @@ func runBenchmark(ctx context.Context, args []string) error {
 		MaxAge:             *flatlandMaxAge,
 		ForageGoal:         *flatlandForageGoal,
 	})
+	applySeedFlagOverrides(&req, setFlags, *seedSelect, *seedMutate, *seedEnv)
+	if err := applySandboxCommandDefault(&req); err != nil {
+		return err
+	}
+	if *progress {
+		req.Progress = printRunProgress
+	}
+	outputMode, err := parseOutputMode(*output)
+	if err != nil {
+		return err
+	}
+	var jsonl *jsonlWriter
+	if outputMode == outputJSONL {
+		if *dryRun || *successRate > 0 {
+			return errors.New("--output jsonl does not support --dry-run or --success-rate")
+		}
+		jsonl = newJSONLWriter(os.Stdout)
+		req.Progress = jsonl.progress
+	}
 	if *profileName != "" {
 		preset, err := loadParityPreset(*profileName)
 		if err != nil {
@@ -1411,6 +2854,15 @@ func runBenchmark(ctx context.Context, args []string) error {
 		req.WeightPlasticityRule = preset.WeightPlasticityRule
 		req.WeightPlasticity = preset.WeightPlasticity
 		req.WeightSubstrate = preset.WeightSubstrate
+		if !setFlags["w-all-biases"] {
+			req.WeightAllBiases = preset.WeightAllBiases
+		}
+		if !setFlags["bias-max-delta"] {
+			req.BiasMaxDelta = preset.BiasMaxDelta
+		}
+	}
+	if *profileName == "" {
+		applyParityStrictFlagDefaults(&req, setFlags, *configPath == "")
 	}
 	req.TuneSelection = normalizeTuneSelection(req.TuneSelection)
 	if req.WeightPerturb < 0 || req.WeightBias < 0 || req.WeightRemoveBias < 0 || req.WeightActivation < 0 || req.WeightAggregator < 0 || req.WeightAddSynapse < 0 || req.WeightRemoveSynapse < 0 || req.WeightAddNeuron < 0 || req.WeightRemoveNeuron < 0 || req.WeightPlasticityRule < 0 || req.WeightPlasticity < 0 || req.WeightSubstrate < 0 {
@@ -1436,6 +2888,12 @@ func runBenchmark(ctx context.Context, args []string) error {
 	if err := morphology.EnsureScapeCompatibility(req.Scape); err != nil {
 		return err
 	}
+	if *dryRun {
+		return printRunPlan(ctx, client, req, max(1, *successRate))
+	}
+	if *successRate > 0 {
+		return runSuccessRateBenchmark(ctx, client, req, *successRate)
+	}
 
 	runSummary, err := client.Run(ctx, req)
 	if err != nil {
@@ -1470,30 +2928,124 @@ func runBenchmark(ctx context.Context, args []string) error {
 		Improvement:            improvement,
 		MinImprovement:         *minImprovement,
 		Passed:                 passed,
+		EvaluationCost:         runSummary.EvaluationCost,
+	}
+	if err := stats.WriteBenchmarkSummary(runSummary.ArtifactsDir, report); err != nil {
+		return err
+	}
+	if err := stats.WriteBenchmarkSeries(runSummary.ArtifactsDir, runSummary.BestByGeneration); err != nil {
+		return err
+	}
+	if jsonl != nil {
+		return jsonl.summary("benchmark", req, runSummary, &report)
+	}
+
+	fmt.Printf("benchmark run_id=%s scape=%s morphology=%s initial_best=%.6f final_best=%.6f mean_best=%.6f std_best=%.6f best_min=%.6f best_max=%.6f improvement=%.6f threshold=%.6f passed=%t\n",
+		runSummary.RunID,
+		req.Scape,
+		report.Morphology,
+		initialBest,
+		runSummary.FinalBestFitness,
+		bestMean,
+		bestStd,
+		bestMin,
+		bestMax,
+		improvement,
+		*minImprovement,
+		passed,
+	)
+	if cost := report.EvaluationCost; cost != nil {
+		printEvaluationCost(cost)
+	}
+	printRegressionCheck(runSummary)
+	fmt.Printf("benchmark_summary=%s\n", filepath.Join(runSummary.ArtifactsDir, "benchmark_summary.json"))
+	fmt.Printf("benchmark_series=%s\n", filepath.Join(runSummary.ArtifactsDir, "benchmark_series.csv"))
+	return nil
+}
+
+// runSuccessRateBenchmark repeats the run over repetitions consecutive
+// seeds and writes the success rate to the benchmark_summary.json of the
+// benchmark run id; each repetition keeps its own run artifacts.
+func runSuccessRateBenchmark(ctx context.Context, client *protoapi.Client, req protoapi.RunRequest, repetitions int) error {
+	if req.FitnessGoal <= 0 || req.EvaluationsLimit <= 0 {
+		return errors.New("--success-rate requires --fitness-goal > 0 and --evaluations-limit > 0")
+	}
+	if req.RunID == "" {
+		req.RunID = fmt.Sprintf("%s-success-%d-%d", req.Scape, req.Seed, time.Now().UTC().Unix())
+	}
+	restarts, err := client.RunRestarts(ctx, protoapi.RestartsRequest{Run: req, Restarts: repetitions, All: true})
+	if err != nil {
+		return err
+	}
+
+	rate := &stats.SuccessRate{
+		Repetitions:      len(restarts.Restarts),
+		FitnessGoal:      req.FitnessGoal,
+		EvaluationsLimit: req.EvaluationsLimit,
+		Runs:             make([]stats.SuccessRateRun, 0, len(restarts.Restarts)),
+	}
+	var toSuccess []float64
+	finalBest := math.Inf(-1)
+	for _, restart := range restarts.Restarts {
+		success := restart.ReachedGoal && restart.Evaluations <= req.EvaluationsLimit
+		rate.Runs = append(rate.Runs, stats.SuccessRateRun{
+			RunID:       restart.RunID,
+			Seed:        restart.Seed,
+			Success:     success,
+			Evaluations: restart.Evaluations,
+			FinalBest:   restart.FinalBestFitness,
+		})
+		finalBest = math.Max(finalBest, restart.FinalBestFitness)
+		if success {
+			toSuccess = append(toSuccess, float64(restart.Evaluations))
+		}
+	}
+	rate.Successes = len(toSuccess)
+	rate.Rate = float64(rate.Successes) / float64(rate.Repetitions)
+	if len(toSuccess) > 0 {
+		rate.MeanEvaluationsToSuccess, rate.StdEvaluationsToSuccess, _, _ = bestSeriesStats(toSuccess)
+	}
+
+	report := stats.BenchmarkSummary{
+		RunID:                  req.RunID,
+		Scape:                  req.Scape,
+		Morphology:             stats.BenchmarkMorphologyLabel(req.Scape, req.GTSAProfile, req.FXProfile, req.EpitopesProfile, req.LLVMProfile, req.FlatlandScannerProfile),
+		GTSAProfile:            req.GTSAProfile,
+		FXProfile:              req.FXProfile,
+		EpitopesProfile:        req.EpitopesProfile,
+		LLVMProfile:            req.LLVMProfile,
+		FlatlandScannerProfile: req.FlatlandScannerProfile,
+		PopulationSize:         req.Population,
+		Generations:            req.Generations,
+		Seed:                   req.Seed,
+		FinalBest:              finalBest,
+		Passed:                 rate.Successes > 0,
+		SuccessRate:            rate,
 	}
-	if err := stats.WriteBenchmarkSummary(runSummary.ArtifactsDir, report); err != nil {
+	runDir := filepath.Join(benchmarksDir, req.RunID)
+	if err := os.MkdirAll(runDir, 0o755); err != nil {
 		return err
 	}
-	if err := stats.WriteBenchmarkSeries(runSummary.ArtifactsDir, runSummary.BestByGeneration); err != nil {
+	if err := stats.WriteBenchmarkSummary(runDir, report); err != nil {
 		return err
 	}
 
-	fmt.Printf("benchmark run_id=%s scape=%s morphology=%s initial_best=%.6f final_best=%.6f mean_best=%.6f std_best=%.6f best_min=%.6f best_max=%.6f improvement=%.6f threshold=%.6f passed=%t\n",
-		runSummary.RunID,
-		req.Scape,
+	for _, run := range rate.Runs {
+		fmt.Printf("repetition run_id=%s seed=%d success=%t evaluations=%d final_best=%.6f\n", run.RunID, run.Seed, run.Success, run.Evaluations, run.FinalBest)
+	}
+	fmt.Printf("benchmark run_id=%s scape=%s morphology=%s success_rate=%.6f successes=%d/%d goal=%.6f evaluations_limit=%d mean_evaluations_to_success=%.2f std_evaluations_to_success=%.2f\n",
+		report.RunID,
+		report.Scape,
 		report.Morphology,
-		initialBest,
-		runSummary.FinalBestFitness,
-		bestMean,
-		bestStd,
-		bestMin,
-		bestMax,
-		improvement,
-		*minImprovement,
-		passed,
+		rate.Rate,
+		rate.Successes,
+		rate.Repetitions,
+		rate.FitnessGoal,
+		rate.EvaluationsLimit,
+		rate.MeanEvaluationsToSuccess,
+		rate.StdEvaluationsToSuccess,
 	)
-	fmt.Printf("benchmark_summary=%s\n", filepath.Join(runSummary.ArtifactsDir, "benchmark_summary.json"))
-	fmt.Printf("benchmark_series=%s\n", filepath.Join(runSummary.ArtifactsDir, "benchmark_series.csv"))
+	fmt.Printf("benchmark_summary=%s\n", filepath.Join(runDir, "benchmark_summary.json"))
 	return nil
 }
 
@@ -1529,6 +3081,10 @@ func runProfile(_ context.Context, args []string) error {
 	}
 	switch args[0] {
 	case "list":
+		fs := newFlagSet("profile list")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
 		profiles, err := listParityProfiles()
 		if err != nil {
 			return err
@@ -1555,7 +3111,7 @@ func runProfile(_ context.Context, args []string) error {
 		}
 		return nil
 	case "show":
-		fs := flag.NewFlagSet("profile show", flag.ContinueOnError)
+		fs := newFlagSet("profile show")
 		id := fs.String("id", "", "profile id")
 		asJSON := fs.Bool("json", false, "print resolved profile as JSON")
 		if err := fs.Parse(args[1:]); err != nil {
@@ -1573,7 +3129,7 @@ func runProfile(_ context.Context, args []string) error {
 			enc.SetIndent("", "  ")
 			return enc.Encode(resolved)
 		}
-		fmt.Printf("id=%s morphology=%s gtsa_profile=%s fx_profile=%s epitopes_profile=%s llvm_profile=%s flatland_scanner_profile=%s selection=%s expected_selection=%s tune_selection=%s expected_tune_selection=%s mutation_ops=%d w_perturb=%.3f w_bias=%.3f w_remove_bias=%.3f w_activation=%.3f w_aggregator=%.3f w_add_syn=%.3f w_remove_syn=%.3f w_add_neuron=%.3f w_remove_neuron=%.3f w_plasticity_rule=%.3f w_plasticity=%.3f w_substrate=%.3f\n",
+		fmt.Printf("id=%s morphology=%s gtsa_profile=%s fx_profile=%s epitopes_profile=%s llvm_profile=%s flatland_scanner_profile=%s selection=%s expected_selection=%s tune_selection=%s expected_tune_selection=%s mutation_ops=%d w_perturb=%.3f w_bias=%.3f w_remove_bias=%.3f w_activation=%.3f w_aggregator=%.3f w_add_syn=%.3f w_remove_syn=%.3f w_add_neuron=%.3f w_remove_neuron=%.3f w_plasticity_rule=%.3f w_plasticity=%.3f w_substrate=%.3f w_all_biases=%.3f bias_max_delta=%.3f\n",
 			resolved.ID,
 			resolved.Morphology,
 			resolved.GTSAProfile,
@@ -1598,6 +3154,8 @@ func runProfile(_ context.Context, args []string) error {
 			resolved.WeightPlasticityRule,
 			resolved.WeightPlasticity,
 			resolved.WeightSubstrate,
+			resolved.WeightAllBiases,
+			resolved.BiasMaxDelta,
 		)
 		return nil
 	default:
@@ -1605,11 +3163,13 @@ func runProfile(_ context.Context, args []string) error {
 	}
 }
 
-func runExport(_ context.Context, args []string) error {
-	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+func runExport(ctx context.Context, args []string) error {
+	fs := newFlagSet("export")
 	runID := fs.String("run-id", "", "run id")
 	latest := fs.Bool("latest", false, "export the most recent run from run index")
 	outDir := fs.String("out", exportsDir, "export output directory")
+	profile := fs.String("profile", protoapi.ExportProfileFull, "export profile: full (raw artifacts), paper (anonymized zip with stats, champions, plots and checksums) or compact (champion as a binary .pgc file)")
+	quantization := fs.String("quantization", "", "compact profile weight encoding: float64 (lossless, default), float32, int16 or int8")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -1619,6 +3179,12 @@ func runExport(_ context.Context, args []string) error {
 	if *runID == "" && !*latest {
 		return errors.New("export requires --run-id or --latest")
 	}
+	if *quantization != "" && *profile != protoapi.ExportProfileCompact {
+		return errors.New("--quantization requires --profile compact")
+	}
+	if *profile != protoapi.ExportProfileFull {
+		return runExportProfile(ctx, *runID, *latest, *outDir, *profile, *quantization)
+	}
 	if *latest {
 		entries, err := stats.ListRunIndex(benchmarksDir)
 		if err != nil {
@@ -1648,21 +3214,53 @@ func runExport(_ context.Context, args []string) error {
 	return nil
 }
 
+// runExportProfile exports through the API client, which only reads the run
+// artifacts, so an in-memory store suffices.
+func runExportProfile(ctx context.Context, runID string, latest bool, outDir, profile, quantization string) error {
+	client, err := protoapi.New(protoapi.Options{
+		StoreKind:     "memory",
+		BenchmarksDir: benchmarksDir,
+		ExportsDir:    exportsDir,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	summary, err := client.Export(ctx, protoapi.ExportRequest{RunID: runID, Latest: latest, OutDir: outDir, Profile: profile, Quantization: quantization})
+	if err != nil {
+		return err
+	}
+	if summary.Profile == protoapi.ExportProfileCompact {
+		fmt.Printf("exported run_id=%s morphology=%s profile=%s archive=%s bytes=%d quantization=%s\n", summary.RunID, summary.Morphology, summary.Profile, summary.Archive, summary.Bytes, summary.Quantization)
+		return nil
+	}
+	fmt.Printf("exported run_id=%s morphology=%s profile=%s archive=%s files=%d\n", summary.RunID, summary.Morphology, summary.Profile, summary.Archive, summary.Files)
+	return nil
+}
+
 func runMonitor(ctx context.Context, args []string) error {
 	if len(args) == 0 {
-		return errors.New("monitor requires an action: pause|continue|stop|goal-reached|print-trace")
+		return errors.New("monitor requires an action: pause|continue|stop|goal-reached|print-trace|set-param")
 	}
 	action := args[0]
-	fs := flag.NewFlagSet("monitor", flag.ContinueOnError)
+	fs := newFlagSet("monitor")
 	runID := fs.String("run-id", "", "run id")
-	storeKind := fs.String("store", storage.DefaultStoreKind(), "store backend: memory|sqlite")
-	dbPath := fs.String("db-path", "protogonos.db", "sqlite database path")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+	paramName := fs.String("name", "", "scape parameter name (set-param)")
+	paramValue := fs.Float64("value", 0, "scape parameter value (set-param)")
 	if err := fs.Parse(args[1:]); err != nil {
 		return err
 	}
 	if *runID == "" {
 		return errors.New("monitor requires --run-id")
 	}
+	if action == "set-param" && *paramName == "" {
+		return errors.New("monitor set-param requires --name")
+	}
 
 	client, err := protoapi.New(protoapi.Options{
 		StoreKind:     *storeKind,
@@ -1689,6 +3287,12 @@ func runMonitor(ctx context.Context, args []string) error {
 		err = client.GoalReachedRun(ctx, req)
 	case "print-trace":
 		err = client.PrintTraceRun(ctx, req)
+	case "set-param":
+		err = client.SetScapeParamRun(ctx, protoapi.SetScapeParamRequest{
+			RunID: *runID,
+			Name:  *paramName,
+			Value: *paramValue,
+		})
 	default:
 		return fmt.Errorf("unknown monitor action: %s", action)
 	}
@@ -1696,20 +3300,24 @@ func runMonitor(ctx context.Context, args []string) error {
 		return err
 	}
 
+	if action == "set-param" {
+		fmt.Printf("monitor action=%s run_id=%s name=%s value=%g\n", action, *runID, *paramName, *paramValue)
+		return nil
+	}
 	fmt.Printf("monitor action=%s run_id=%s\n", action, *runID)
 	return nil
 }
 
 func runPopulation(ctx context.Context, args []string) error {
 	if len(args) == 0 {
-		return errors.New("population requires a subcommand: delete")
+		return errors.New("population requires a subcommand: delete|diff")
 	}
 	switch args[0] {
 	case "delete":
-		fs := flag.NewFlagSet("population delete", flag.ContinueOnError)
+		fs := newFlagSet("population delete")
 		populationID := fs.String("id", "", "population id")
-		storeKind := fs.String("store", storage.DefaultStoreKind(), "store backend: memory|sqlite")
-		dbPath := fs.String("db-path", "protogonos.db", "sqlite database path")
+		storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+		dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
@@ -1735,11 +3343,297 @@ func runPopulation(ctx context.Context, args []string) error {
 		}
 		fmt.Printf("population deleted id=%s\n", *populationID)
 		return nil
+	case "diff":
+		fs := newFlagSet("population diff")
+		fromID := fs.String("from-id", "", "baseline population id")
+		toID := fs.String("to-id", "", "compared population id")
+		jsonOut := fs.Bool("json", false, "emit population diff as JSON")
+		storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+		dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *fromID == "" || *toID == "" {
+			return errors.New("population diff requires --from-id and --to-id")
+		}
+
+		client, err := protoapi.New(protoapi.Options{
+			StoreKind:     *storeKind,
+			DBPath:        *dbPath,
+			BenchmarksDir: benchmarksDir,
+			ExportsDir:    exportsDir,
+		})
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = client.Close()
+		}()
+
+		diff, err := client.PopulationDiff(ctx, protoapi.PopulationDiffRequest{FromID: *fromID, ToID: *toID})
+		if err != nil {
+			return err
+		}
+		if *jsonOut {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(diff)
+		}
+
+		fmt.Printf("from_id=%s to_id=%s from_generation=%d to_generation=%d from_size=%d to_size=%d added=%d removed=%d retained=%d species_source=%s species_added=%d species_removed=%d species_changed=%d\n",
+			diff.FromID,
+			diff.ToID,
+			diff.FromGeneration,
+			diff.ToGeneration,
+			diff.FromSize,
+			diff.ToSize,
+			len(diff.Added),
+			len(diff.Removed),
+			diff.RetainedCount,
+			diff.SpeciesSource,
+			len(diff.SpeciesAdded),
+			len(diff.SpeciesRemoved),
+			len(diff.SpeciesChanged),
+		)
+		if diff.FitnessAvailable {
+			fmt.Printf("fitness from_mean=%.6f to_mean=%.6f mean_delta=%+.6f from_best=%.6f to_best=%.6f best_delta=%+.6f ks=%.4f\n",
+				diff.FromMeanFitness,
+				diff.ToMeanFitness,
+				diff.MeanFitnessDelta,
+				diff.FromBestFitness,
+				diff.ToBestFitness,
+				diff.BestFitnessDelta,
+				diff.KSStatistic,
+			)
+		} else {
+			fmt.Println("fitness unavailable (snapshot predates stored member fitness)")
+		}
+		for _, id := range diff.Added {
+			fmt.Printf("added genome_id=%s\n", id)
+		}
+		for _, id := range diff.Removed {
+			fmt.Printf("removed genome_id=%s\n", id)
+		}
+		for _, item := range diff.SpeciesAdded {
+			fmt.Printf("species_added key=%s size=%d\n", item.Key, item.ToSize)
+		}
+		for _, item := range diff.SpeciesRemoved {
+			fmt.Printf("species_removed key=%s size=%d\n", item.Key, item.FromSize)
+		}
+		for _, item := range diff.SpeciesChanged {
+			fmt.Printf("species_changed key=%s from_size=%d to_size=%d delta=%+d\n", item.Key, item.FromSize, item.ToSize, item.SizeDelta)
+		}
+		return nil
 	default:
 		return fmt.Errorf("unsupported population subcommand: %s", args[0])
 	}
 }
 
+func runStore(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("store requires a subcommand: stats|compact|import-archive")
+	}
+	switch args[0] {
+	case "stats":
+		fs := newFlagSet("store stats")
+		limit := fs.Int("limit", 10, "max runs to list by stored bytes")
+		jsonOut := fs.Bool("json", false, "emit store stats as JSON")
+		storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+		dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *limit <= 0 {
+			return errors.New("limit must be > 0")
+		}
+
+		client, err := protoapi.New(protoapi.Options{
+			StoreKind:     *storeKind,
+			DBPath:        *dbPath,
+			BenchmarksDir: benchmarksDir,
+			ExportsDir:    exportsDir,
+		})
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = client.Close()
+		}()
+
+		report, err := client.StoreStats(ctx, protoapi.StoreStatsRequest{Limit: *limit})
+		if err != nil {
+			return err
+		}
+		if *jsonOut {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(report)
+		}
+		fmt.Printf("store=%s schema_version=%d codec_version=%d total_bytes=%d file_bytes=%d orphan_genomes=%d dangling_members=%d decode_errors=%d\n",
+			report.StoreKind,
+			report.SchemaVersion,
+			report.CodecVersion,
+			report.TotalBytes,
+			report.FileBytes,
+			report.OrphanGenomes,
+			report.DanglingMembers,
+			len(report.DecodeErrors),
+		)
+		for _, entity := range report.Entities {
+			fmt.Printf("entity=%s count=%d bytes=%d\n", entity.Name, entity.Count, entity.Bytes)
+		}
+		for _, item := range report.Runs {
+			fmt.Printf("run_id=%s bytes=%d\n", item.RunID, item.Bytes)
+		}
+		for _, msg := range report.DecodeErrors {
+			fmt.Printf("decode_error %s\n", msg)
+		}
+		for _, finding := range report.Integrity {
+			fmt.Printf("integrity %s\n", finding)
+		}
+		return nil
+	case "compact":
+		fs := newFlagSet("store compact")
+		archiveOlderThan := fs.Duration("archive-older-than", 0, "offload runs created longer ago than this to compressed archives before compacting (0 disables)")
+		archiveDir := fs.String("archive-dir", "archives", "directory for offloaded run archives")
+		jsonOut := fs.Bool("json", false, "emit compaction summary as JSON")
+		storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+		dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		client, err := protoapi.New(protoapi.Options{
+			StoreKind:     *storeKind,
+			DBPath:        *dbPath,
+			BenchmarksDir: benchmarksDir,
+			ExportsDir:    exportsDir,
+		})
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = client.Close()
+		}()
+
+		summary, err := client.CompactStore(ctx, protoapi.CompactStoreRequest{
+			ArchiveOlderThan: *archiveOlderThan,
+			ArchiveDir:       *archiveDir,
+		})
+		if err != nil {
+			return err
+		}
+		if *jsonOut {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(summary)
+		}
+		for i, runID := range summary.ArchivedRuns {
+			fmt.Printf("archived run_id=%s path=%s\n", runID, summary.ArchiveFiles[i])
+		}
+		fmt.Printf("store compacted store=%s archived_runs=%d referenced_genomes=%d removed_genomes=%d vacuumed=%t\n",
+			*storeKind,
+			len(summary.ArchivedRuns),
+			summary.ReferencedGenomes,
+			summary.RemovedGenomes,
+			summary.Vacuumed,
+		)
+		return nil
+	case "import-archive":
+		fs := newFlagSet("store import-archive")
+		path := fs.String("path", "", "run archive path (.json.gz) written by store compact")
+		storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+		dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *path == "" {
+			return errors.New("store import-archive requires --path")
+		}
+
+		client, err := protoapi.New(protoapi.Options{
+			StoreKind:     *storeKind,
+			DBPath:        *dbPath,
+			BenchmarksDir: benchmarksDir,
+			ExportsDir:    exportsDir,
+		})
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = client.Close()
+		}()
+
+		runID, err := client.ImportRunArchive(ctx, *path)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("archive imported run_id=%s path=%s\n", runID, *path)
+		return nil
+	default:
+		return fmt.Errorf("unsupported store subcommand: %s", args[0])
+	}
+}
+
+func runMergePopulations(ctx context.Context, args []string) error {
+	fs := newFlagSet("merge-populations")
+	var runIDs stringListFlag
+	fs.Var(&runIDs, "run-id", "source run id whose final population is merged (repeatable, at least two)")
+	outPopID := fs.String("out-pop-id", "", "population id for the merged snapshot")
+	jsonOut := fs.Bool("json", false, "emit merge summary as JSON")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if len(runIDs) < 2 {
+		return errors.New("merge-populations requires at least two --run-id values")
+	}
+	if *outPopID == "" {
+		return errors.New("merge-populations requires --out-pop-id")
+	}
+
+	client, err := protoapi.New(protoapi.Options{
+		StoreKind:     *storeKind,
+		DBPath:        *dbPath,
+		BenchmarksDir: benchmarksDir,
+		ExportsDir:    exportsDir,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	summary, err := client.MergePopulations(ctx, protoapi.MergePopulationsRequest{
+		RunIDs:          runIDs,
+		OutPopulationID: *outPopID,
+	})
+	if err != nil {
+		return err
+	}
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(summary)
+	}
+
+	fmt.Printf("merged population id=%s scape=%s generation=%d size=%d duplicates=%d species=%d\n",
+		summary.PopulationID,
+		summary.Scape,
+		summary.Generation,
+		summary.MergedSize,
+		summary.DuplicateCount,
+		summary.SpeciesCount,
+	)
+	for _, runID := range runIDs {
+		fmt.Printf("source run_id=%s size=%d\n", runID, summary.SourceSizes[runID])
+	}
+	fmt.Printf("continue with: protogonosctl run --continue-pop-id %s --scape %s\n", summary.PopulationID, summary.Scape)
+	return nil
+}
+
 func registerDefaultScapes(p *platform.Polis) error {
 	if err := p.RegisterScape(scape.XORScape{}); err != nil {
 		return err
@@ -1771,6 +3665,18 @@ func registerDefaultScapes(p *platform.Polis) error {
 	if err := p.RegisterScape(scape.LLVMPhaseOrderingScape{}); err != nil {
 		return err
 	}
+	if err := p.RegisterScape(scape.ParityScape{Inputs: scape.DefaultParityInputs}); err != nil {
+		return err
+	}
+	if err := p.RegisterScape(scape.ParityScape{Inputs: scape.DefaultParityInputs, Majority: true}); err != nil {
+		return err
+	}
+	if err := p.RegisterScape(scape.FunctionApproxScape{}); err != nil {
+		return err
+	}
+	if err := p.RegisterScape(scape.StackMachineScape{}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -1828,8 +3734,38 @@ func defaultMutationPolicy(
 	}
 }
 
+// stringListFlag collects repeated occurrences of a string flag.
+type stringListFlag []string
+
+func (f *stringListFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringListFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// parseScapeParams collects repeated key=value scape parameters; a later
+// occurrence of a key replaces an earlier one.
+func parseScapeParams(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	params := make(map[string]string, len(values))
+	for _, value := range values {
+		key, raw, ok := strings.Cut(value, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --scape-param %q: want key=value", value)
+		}
+		params[key] = strings.TrimSpace(raw)
+	}
+	return params, nil
+}
+
 func usageError(msg string) error {
-	return fmt.Errorf("%s\nusage: protogonosctl <init|reset|start|run|benchmark|benchmark-experiment|profile|runs|lineage|fitness|diagnostics|species|species-diff|monitor|population|top|scape-summary|epitopes-test|export> [flags]", msg)
+	return fmt.Errorf("%s\nusage: protogonosctl [--store kind] [--db-path path] [--artifacts-root dir] [--help-json] <%s> [flags]", msg, strings.Join(commandNames(), "|"))
 }
 
 func selectionFromName(name string) (evo.Selector, error) {
@@ -1879,6 +3815,10 @@ func selectionFromName(name string) (evo.Selector, error) {
 		return evo.EfficiencySelector{PoolSize: 0}, nil
 	case "random":
 		return evo.RandomSelector{PoolSize: 0}, nil
+	case "afpo":
+		return evo.AFPOSelector{TournamentSize: 2, Newcomers: 1}, nil
+	case "nsga2":
+		return evo.NSGA2Selector{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported selection strategy: %s", name)
 	}
@@ -1933,6 +3873,72 @@ func applyFlatlandFlagOverrides(req *protoapi.RunRequest, setFlags map[string]bo
 	}
 }
 
+func printRunProgress(p protoapi.RunProgress) {
+	fmt.Printf("progress generation=%d best=%.6f wall_clock=%s evaluations=%d evals_per_sec=%.2f eta=%s\n",
+		p.Generation,
+		p.BestFitness,
+		p.WallClock.Round(time.Millisecond),
+		p.TotalEvaluations,
+		p.EvaluationsPerSecond,
+		p.ETA.Round(time.Second),
+	)
+}
+
+// applySeedFlagOverrides sets the decoupled search and environment seeds only
+// when their flags were given, leaving them tied to --seed otherwise.
+func applySeedFlagOverrides(req *protoapi.RunRequest, setFlags map[string]bool, selectSeed, mutateSeed, envSeed int64) {
+	if req == nil {
+		return
+	}
+	if setFlags["seed-select"] {
+		req.SelectionSeed = int64Ptr(selectSeed)
+	}
+	if setFlags["seed-mutate"] {
+		req.MutationSeed = int64Ptr(mutateSeed)
+	}
+	if setFlags["seed-env"] {
+		req.EnvSeed = int64Ptr(envSeed)
+	}
+}
+
+// applySandboxCommandDefault points a sandboxed run that names no worker
+// command at this binary's scape-worker subcommand.
+func applySandboxCommandDefault(req *protoapi.RunRequest) error {
+	if !req.ScapeSandbox || strings.TrimSpace(req.SandboxCommand) != "" {
+		return nil
+	}
+	command, err := defaultSandboxCommand()
+	if err != nil {
+		return err
+	}
+	req.SandboxCommand = command
+	return nil
+}
+
+func defaultSandboxCommand() (string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("resolve scape sandbox command: %w", err)
+	}
+	return executable + " " + protoapi.ScapeWorkerCommand, nil
+}
+
+// applyParityStrictFlagDefaults replaces the selection and tuning selection
+// flag defaults of a parity-strict run, which have no reference counterpart,
+// with the reference defaults. Values given as flags, or set by the config
+// file when fromFlags is false, are kept so the run can reject them.
+func applyParityStrictFlagDefaults(req *protoapi.RunRequest, setFlags map[string]bool, fromFlags bool) {
+	if req == nil || !req.ParityStrict {
+		return
+	}
+	if !setFlags["selection"] && (fromFlags || req.Selection == "") {
+		req.Selection = protoapi.ParityStrictSelection
+	}
+	if !setFlags["tune-selection"] && (fromFlags || req.TuneSelection == "") {
+		req.TuneSelection = protoapi.ParityStrictTuneSelection
+	}
+}
+
 func postprocessorFromName(name string) (evo.FitnessPostprocessor, error) {
 	switch name {
 	case "none":
@@ -1943,6 +3949,12 @@ func postprocessorFromName(name string) (evo.FitnessPostprocessor, error) {
 		return evo.SizeProportionalPostprocessor{}, nil
 	case "novelty_proportional":
 		return evo.NoveltyProportionalPostprocessor{}, nil
+	case "novelty_archive":
+		return evo.NoveltyArchivePostprocessor{}, nil
+	case "numeric_fragility":
+		return evo.NumericFragilityPostprocessor{}, nil
+	case "cost_proportional":
+		return evo.CostProportionalPostprocessor{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported fitness postprocessor: %s", name)
 	}
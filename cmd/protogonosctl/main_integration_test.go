@@ -9,11 +9,14 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 
 	"protogonos/internal/stats"
 	"protogonos/internal/storage"
+	protoapi "protogonos/pkg/protogonos"
 )
 
 func TestRunCommandSQLiteCreatesArtifacts(t *testing.T) {
@@ -383,6 +386,66 @@ func TestRunsCommandSQLiteListsPersistedRun(t *testing.T) {
 	if _, ok := parsed[0]["morphology"]; !ok {
 		t.Fatalf("expected morphology field in runs json output: %v", parsed[0])
 	}
+	resources, ok := parsed[0]["resources"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected resources field in runs json output: %v", parsed[0])
+	}
+	for _, field := range []string{"cpu_seconds", "evaluations", "storage_bytes"} {
+		if _, ok := resources[field]; !ok {
+			t.Fatalf("expected resources.%s in runs json output: %v", field, resources)
+		}
+	}
+}
+
+func TestGlobalStoreOptionsApplyToEverySubcommand(t *testing.T) {
+	origWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+		applyGlobalOptions(defaultGlobalOptions())
+	})
+
+	dbPath := filepath.Join(workdir, "env.db")
+	t.Setenv(envDBPath, dbPath)
+	t.Setenv(envStore, "memory")
+	if err := run(context.Background(), []string{
+		"--store", "sqlite",
+		"--artifacts-root", "artifacts",
+		"run",
+		"--run-id", "global-options-run",
+		"--scape", "xor",
+		"--pop", "6",
+		"--gens", "2",
+		"--workers", "2",
+	}); err != nil {
+		t.Fatalf("run command: %v", err)
+	}
+	if _, err := os.Stat(dbPath); err != nil {
+		t.Fatalf("expected the sqlite store at the env db path: %v", err)
+	}
+	if _, ok, err := stats.ReadRunConfig(filepath.Join("artifacts", "benchmarks"), "global-options-run"); err != nil || !ok {
+		t.Fatalf("expected run artifacts under the artifacts root: ok=%t err=%v", ok, err)
+	}
+
+	out, err := captureStdout(func() error {
+		return run(context.Background(), []string{"--store", "sqlite", "--artifacts-root", "artifacts", "lineage", "--latest", "--limit", "1"})
+	})
+	if err != nil {
+		t.Fatalf("lineage command: %v", err)
+	}
+	if !strings.Contains(out, "op=seed") {
+		t.Fatalf("expected lineage of the latest run, got: %s", out)
+	}
+
+	if err := run(context.Background(), []string{"lineage", "--latest"}); err == nil {
+		t.Fatal("expected lineage without the global options to find no run")
+	}
 }
 
 func TestRunCommandSQLiteCanContinueFromPopulationSnapshot(t *testing.T) {
@@ -551,6 +614,22 @@ func TestExportCommandRecoversLegacyMorphologyLabel(t *testing.T) {
 	if _, err := os.Stat(filepath.Join("exports", runID, "config.json")); err != nil {
 		t.Fatalf("expected exported config artifact: %v", err)
 	}
+	for _, dir := range []string{"benchmarks", "exports"} {
+		if _, err := captureStdout(func() error {
+			return run(context.Background(), []string{"verify-artifacts", "--run-id", runID, "--dir", dir})
+		}); err != nil {
+			t.Fatalf("verify %s artifacts: %v", dir, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join("exports", runID, "config.json"), []byte("{}"), 0o644); err != nil {
+		t.Fatalf("corrupt exported config: %v", err)
+	}
+	output, err = captureStdout(func() error {
+		return run(context.Background(), []string{"verify-artifacts", "--run-id", runID, "--dir", "exports"})
+	})
+	if err == nil || !strings.Contains(output, "problem file=config.json kind=size_mismatch") {
+		t.Fatalf("expected the corrupted export to fail verification, got err=%v output=%s", err, output)
+	}
 }
 
 func TestBenchmarkExperimentShowRecoversLegacySummaryMorphology(t *testing.T) {
@@ -683,6 +762,121 @@ func TestLineageCommandSQLiteReadsPersistedLineage(t *testing.T) {
 	}
 }
 
+func TestLineageCommandReplayVerifiesPersistedLineage(t *testing.T) {
+	origWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	dbPath := filepath.Join(workdir, "protogonos.db")
+	if err := run(context.Background(), []string{
+		"run",
+		"--store", "sqlite",
+		"--db-path", dbPath,
+		"--run-id", "replay-audit",
+		"--scape", "xor",
+		"--pop", "6",
+		"--gens", "4",
+		"--seed", "43",
+		"--selection", "afpo",
+	}); err != nil {
+		t.Fatalf("run command: %v", err)
+	}
+
+	out, err := captureStdout(func() error {
+		return run(context.Background(), []string{
+			"lineage",
+			"--store", "sqlite",
+			"--db-path", dbPath,
+			"--run-id", "replay-audit",
+			"--replay",
+		})
+	})
+	if err != nil {
+		t.Fatalf("lineage replay command: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "replay run_id=replay-audit records=30 matched=30 mismatched=0 skipped=0") {
+		t.Fatalf("unexpected lineage replay output: %s", out)
+	}
+}
+
+func TestLeaderboardCommandRanksChampionsAcrossRuns(t *testing.T) {
+	origWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	dbPath := filepath.Join(workdir, "protogonos.db")
+	for _, runID := range []string{"board-a", "board-b"} {
+		if err := run(context.Background(), []string{
+			"run",
+			"--store", "sqlite",
+			"--db-path", dbPath,
+			"--run-id", runID,
+			"--scape", "xor",
+			"--pop", "6",
+			"--gens", "2",
+			"--seed", "42",
+		}); err != nil {
+			t.Fatalf("run command %s: %v", runID, err)
+		}
+	}
+
+	if err := run(context.Background(), []string{"leaderboard", "--store", "sqlite", "--db-path", dbPath}); err == nil {
+		t.Fatal("expected leaderboard without --scape to fail")
+	}
+
+	out, err := captureStdout(func() error {
+		return run(context.Background(), []string{
+			"leaderboard",
+			"--store", "sqlite",
+			"--db-path", dbPath,
+			"--scape", "xor",
+		})
+	})
+	if err != nil {
+		t.Fatalf("leaderboard command: %v", err)
+	}
+	if !strings.Contains(out, "rank=1 run_id=board-") || !strings.Contains(out, "rank=2 run_id=board-") || !strings.Contains(out, "validation_fitness=") || !strings.Contains(out, "population=6") {
+		t.Fatalf("unexpected leaderboard output: %s", out)
+	}
+
+	jsonOut, err := captureStdout(func() error {
+		return run(context.Background(), []string{
+			"leaderboard",
+			"--store", "sqlite",
+			"--db-path", dbPath,
+			"--scape", "xor",
+			"--limit", "1",
+			"--json",
+		})
+	})
+	if err != nil {
+		t.Fatalf("leaderboard json command: %v", err)
+	}
+	var parsed []map[string]any
+	if err := json.Unmarshal([]byte(jsonOut), &parsed); err != nil {
+		t.Fatalf("decode leaderboard json output: %v\n%s", err, jsonOut)
+	}
+	if len(parsed) != 1 || parsed[0]["run_id"] == nil || parsed[0]["validation_fitness"] == nil {
+		t.Fatalf("expected one champion in leaderboard json output, got %v", parsed)
+	}
+}
+
 func TestFitnessCommandSQLiteReadsPersistedHistory(t *testing.T) {
 	origWD, err := os.Getwd()
 	if err != nil {
@@ -749,6 +943,80 @@ func TestFitnessCommandSQLiteReadsPersistedHistory(t *testing.T) {
 	}
 }
 
+func TestRunAndBenchmarkCommandsStreamJSONL(t *testing.T) {
+	origWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	dbPath := filepath.Join(workdir, "protogonos.db")
+	for _, command := range []string{"run", "benchmark"} {
+		out, err := captureStdout(func() error {
+			return run(context.Background(), []string{
+				command,
+				"--store", "sqlite",
+				"--db-path", dbPath,
+				"--run-id", "jsonl-" + command,
+				"--scape", "xor",
+				"--pop", "6",
+				"--gens", "3",
+				"--seed", "42",
+				"--workers", "2",
+				"--output", "jsonl",
+			})
+		})
+		if err != nil {
+			t.Fatalf("%s command: %v", command, err)
+		}
+
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		if len(lines) != 4 {
+			t.Fatalf("%s: expected 3 generation records and a summary, got:\n%s", command, out)
+		}
+		for i, line := range lines[:3] {
+			var record map[string]any
+			if err := json.Unmarshal([]byte(line), &record); err != nil {
+				t.Fatalf("%s: decode generation record %q: %v", command, line, err)
+			}
+			if record["type"] != "generation" || record["generation"] != float64(i+1) {
+				t.Fatalf("%s: unexpected generation record %d: %s", command, i, line)
+			}
+			for _, key := range []string{"best_fitness", "mean_fitness", "species_count", "tuning", "total_evaluations"} {
+				if _, ok := record[key]; !ok {
+					t.Fatalf("%s: generation record missing %s: %s", command, key, line)
+				}
+			}
+		}
+		var summary struct {
+			Type             string                  `json:"type"`
+			Command          string                  `json:"command"`
+			RunID            string                  `json:"run_id"`
+			BestByGeneration []float64               `json:"best_by_generation"`
+			Benchmark        *stats.BenchmarkSummary `json:"benchmark"`
+		}
+		if err := json.Unmarshal([]byte(lines[3]), &summary); err != nil {
+			t.Fatalf("%s: decode summary record: %v", command, err)
+		}
+		if summary.Type != "summary" || summary.Command != command || summary.RunID != "jsonl-"+command || len(summary.BestByGeneration) != 3 {
+			t.Fatalf("%s: unexpected summary record: %s", command, lines[3])
+		}
+		if (summary.Benchmark != nil) != (command == "benchmark") {
+			t.Fatalf("%s: unexpected benchmark section in summary: %s", command, lines[3])
+		}
+	}
+
+	if err := run(context.Background(), []string{"run", "--store", "memory", "--output", "yaml"}); err == nil || !strings.Contains(err.Error(), "unsupported --output") {
+		t.Fatalf("expected unsupported output error, got %v", err)
+	}
+}
+
 func TestDiagnosticsCommandSQLiteReadsPersistedDiagnostics(t *testing.T) {
 	origWD, err := os.Getwd()
 	if err != nil {
@@ -792,6 +1060,12 @@ func TestDiagnosticsCommandSQLiteReadsPersistedDiagnostics(t *testing.T) {
 	if !strings.Contains(out, "generation=1") || !strings.Contains(out, "species=") || !strings.Contains(out, "tuning_invocations=") || !strings.Contains(out, "tuning_accept_rate=") || !strings.Contains(out, "tuning_evals_per_attempt=") {
 		t.Fatalf("unexpected diagnostics output: %s", out)
 	}
+	if !strings.Contains(out, "weights generation=1") || !strings.Contains(out, "champion_layer generation=1") {
+		t.Fatalf("expected weight statistics in diagnostics output: %s", out)
+	}
+	if !strings.Contains(out, "strategies generation=1 tuning_selection=") || !strings.Contains(out, "heredity_type=") {
+		t.Fatalf("expected strategy distribution in diagnostics output: %s", out)
+	}
 
 	jsonOut, err := captureStdout(func() error {
 		return run(context.Background(), []string{
@@ -821,6 +1095,85 @@ func TestDiagnosticsCommandSQLiteReadsPersistedDiagnostics(t *testing.T) {
 	}
 }
 
+func TestEventsCommandFiltersByType(t *testing.T) {
+	origWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	dbPath := filepath.Join(workdir, "protogonos.db")
+	if err := run(context.Background(), []string{
+		"run",
+		"--store", "sqlite",
+		"--db-path", dbPath,
+		"--scape", "xor",
+		"--pop", "8",
+		"--gens", "4",
+		"--seed", "47",
+		"--workers", "2",
+	}); err != nil {
+		t.Fatalf("run command: %v", err)
+	}
+
+	out, err := captureStdout(func() error {
+		return run(context.Background(), []string{
+			"events",
+			"--store", "sqlite",
+			"--db-path", dbPath,
+			"--latest",
+			"--type", "species_created",
+		})
+	})
+	if err != nil {
+		t.Fatalf("events command: %v", err)
+	}
+	if !strings.Contains(out, "generation=1 type=species_created species=") {
+		t.Fatalf("expected species_created events: %s", out)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if !strings.Contains(line, "type=species_created") {
+			t.Fatalf("unexpected event outside type filter: %s", line)
+		}
+	}
+
+	jsonOut, err := captureStdout(func() error {
+		return run(context.Background(), []string{
+			"events",
+			"--store", "sqlite",
+			"--db-path", dbPath,
+			"--latest",
+			"--json",
+		})
+	})
+	if err != nil {
+		t.Fatalf("events json command: %v", err)
+	}
+	var parsed []map[string]any
+	if err := json.Unmarshal([]byte(jsonOut), &parsed); err != nil {
+		t.Fatalf("decode events json output: %v\n%s", err, jsonOut)
+	}
+	if len(parsed) == 0 || parsed[0]["type"] == nil || parsed[0]["generation"] == nil {
+		t.Fatalf("unexpected events json output: %s", jsonOut)
+	}
+
+	if err := run(context.Background(), []string{
+		"events",
+		"--store", "sqlite",
+		"--db-path", dbPath,
+		"--latest",
+		"--type", "bogus",
+	}); err == nil || !strings.Contains(err.Error(), "unknown event type") {
+		t.Fatalf("expected unknown event type error, got %v", err)
+	}
+}
+
 func TestSpeciesCommandSQLiteReadsPersistedSpeciesHistory(t *testing.T) {
 	origWD, err := os.Getwd()
 	if err != nil {
@@ -864,6 +1217,9 @@ func TestSpeciesCommandSQLiteReadsPersistedSpeciesHistory(t *testing.T) {
 	if !strings.Contains(out, "generation=1") || !strings.Contains(out, "species_key=") {
 		t.Fatalf("unexpected species output: %s", out)
 	}
+	if !strings.Contains(out, "strategies species_key=") || !strings.Contains(out, "topological_mode=") {
+		t.Fatalf("expected per-species strategy distribution in species output: %s", out)
+	}
 
 	jsonOut, err := captureStdout(func() error {
 		return run(context.Background(), []string{
@@ -959,6 +1315,26 @@ func TestSpeciesDiffCommandSQLiteReadsPersistedSpeciesHistory(t *testing.T) {
 	if _, ok := parsed["tuning_attempts_delta"]; !ok {
 		t.Fatalf("expected tuning delta in species-diff json: %v", parsed)
 	}
+
+	deltaOut, err := captureStdout(func() error {
+		return run(context.Background(), []string{
+			"species-diff",
+			"--store", "sqlite",
+			"--db-path", dbPath,
+			"--latest",
+			"--from-gen", "1",
+			"--to-gen", "3",
+			"--champion-deltas",
+		})
+	})
+	if err != nil {
+		t.Fatalf("species-diff champion deltas command: %v", err)
+	}
+	// Seed 431 keeps one species whose champion gains a synapse between
+	// generations 1 and 3.
+	if !strings.Contains(deltaOut, "changed species_key=") || !strings.Contains(deltaOut, "\nchampion species_key=") || !strings.Contains(deltaOut, " synapses=+1/-0 ") || !strings.Contains(deltaOut, "weight_drift=") {
+		t.Fatalf("expected a champion delta line for the changed species: %s", deltaOut)
+	}
 }
 
 func TestTopCommandSQLiteReadsPersistedTopGenomes(t *testing.T) {
@@ -1148,7 +1524,7 @@ func TestEpitopesTestCommandReplaysGenerationChampions(t *testing.T) {
 	}
 }
 
-func TestBenchmarkCommandWritesSummary(t *testing.T) {
+func TestSensorImportanceCommandRanksSensors(t *testing.T) {
 	origWD, err := os.Getwd()
 	if err != nil {
 		t.Fatalf("getwd: %v", err)
@@ -1162,27 +1538,128 @@ func TestBenchmarkCommandWritesSummary(t *testing.T) {
 	})
 
 	dbPath := filepath.Join(workdir, "protogonos.db")
-	args := []string{
-		"benchmark",
+	if err := run(context.Background(), []string{
+		"run",
 		"--store", "sqlite",
 		"--db-path", dbPath,
-		"--scape", "xor",
-		"--pop", "8",
-		"--gens", "3",
-		"--seed", "9",
-		"--workers", "2",
-		"--min-improvement", "0.0001",
-	}
-	if err := run(context.Background(), args); err != nil {
-		t.Fatalf("benchmark command: %v", err)
+		"--scape", "fx",
+		"--pop", "4",
+		"--gens", "1",
+		"--seed", "21",
+	}); err != nil {
+		t.Fatalf("run command: %v", err)
 	}
 
-	entries, err := stats.ListRunIndex("benchmarks")
-	if err != nil {
-		t.Fatalf("list run index: %v", err)
+	if err := run(context.Background(), []string{"sensor-importance", "--store", "sqlite", "--db-path", dbPath}); err == nil {
+		t.Fatal("expected sensor-importance without --run-id or --latest to fail")
 	}
-	if len(entries) == 0 {
-		t.Fatal("expected at least one indexed run")
+
+	out, err := captureStdout(func() error {
+		return run(context.Background(), []string{
+			"sensor-importance",
+			"--store", "sqlite",
+			"--db-path", dbPath,
+			"--latest",
+		})
+	})
+	if err != nil {
+		t.Fatalf("sensor-importance command: %v", err)
+	}
+	if !strings.Contains(out, "sensor_importance run_id=") || !strings.Contains(out, "scape=fx") || !strings.Contains(out, "rank=1 sensor_id=") {
+		t.Fatalf("unexpected sensor-importance output: %s", out)
+	}
+	matches, err := filepath.Glob(filepath.Join(workdir, "benchmarks", "*", "sensor_importance", "*-gt.json"))
+	if err != nil || len(matches) != 1 {
+		t.Fatalf("expected one sensor importance artifact, got %v err=%v", matches, err)
+	}
+}
+
+func TestReplayCommandRendersTrajectory(t *testing.T) {
+	origWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	dbPath := filepath.Join(workdir, "protogonos.db")
+	if err := run(context.Background(), []string{
+		"run",
+		"--store", "sqlite",
+		"--db-path", dbPath,
+		"--scape", "dtm",
+		"--pop", "4",
+		"--gens", "1",
+		"--seed", "21",
+	}); err != nil {
+		t.Fatalf("run command: %v", err)
+	}
+
+	if err := run(context.Background(), []string{"replay", "--store", "sqlite", "--db-path", dbPath}); err == nil {
+		t.Fatal("expected replay without --run-id or --latest to fail")
+	}
+
+	out, err := captureStdout(func() error {
+		return run(context.Background(), []string{
+			"replay",
+			"--store", "sqlite",
+			"--db-path", dbPath,
+			"--latest",
+			"--render",
+		})
+	})
+	if err != nil {
+		t.Fatalf("replay command: %v", err)
+	}
+	if !strings.Contains(out, "replay run_id=") || !strings.Contains(out, "scape=dtm") || !strings.Contains(out, "rendered frames=") {
+		t.Fatalf("unexpected replay output: %s", out)
+	}
+	rendered, err := filepath.Glob(filepath.Join(workdir, "benchmarks", "*", "replay", "*-gt.svg"))
+	if err != nil || len(rendered) != 1 {
+		t.Fatalf("expected one rendered svg in run replay artifacts, got %v (err=%v)", rendered, err)
+	}
+}
+
+func TestBenchmarkCommandWritesSummary(t *testing.T) {
+	origWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	dbPath := filepath.Join(workdir, "protogonos.db")
+	args := []string{
+		"benchmark",
+		"--store", "sqlite",
+		"--db-path", dbPath,
+		"--scape", "xor",
+		"--pop", "8",
+		"--gens", "3",
+		"--seed", "9",
+		"--workers", "2",
+		"--min-improvement", "0.0001",
+	}
+	if err := run(context.Background(), args); err != nil {
+		t.Fatalf("benchmark command: %v", err)
+	}
+
+	entries, err := stats.ListRunIndex("benchmarks")
+	if err != nil {
+		t.Fatalf("list run index: %v", err)
+	}
+	if len(entries) == 0 {
+		t.Fatal("expected at least one indexed run")
 	}
 	runID := entries[0].RunID
 
@@ -1210,6 +1687,150 @@ func TestBenchmarkCommandWritesSummary(t *testing.T) {
 	}
 }
 
+func TestBenchmarkCommandSuccessRateWritesSummary(t *testing.T) {
+	origWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	args := []string{
+		"benchmark",
+		"--store", "memory",
+		"--run-id", "xor-success",
+		"--scape", "xor",
+		"--pop", "8",
+		"--gens", "50",
+		"--seed", "1",
+		"--fitness-goal", "1.3",
+		"--success-rate", "5",
+	}
+	if err := run(context.Background(), args); err == nil || !strings.Contains(err.Error(), "--evaluations-limit") {
+		t.Fatalf("expected success-rate without an evaluations limit to be rejected, got %v", err)
+	}
+	if err := run(context.Background(), append(args, "--evaluations-limit", "200")); err != nil {
+		t.Fatalf("benchmark command: %v", err)
+	}
+
+	summary, ok, err := stats.ReadBenchmarkSummary("benchmarks", "xor-success")
+	if err != nil || !ok {
+		t.Fatalf("read benchmark summary: ok=%t err=%v", ok, err)
+	}
+	rate := summary.SuccessRate
+	if rate == nil || rate.Repetitions != 5 || len(rate.Runs) != 5 || rate.FitnessGoal != 1.3 || rate.EvaluationsLimit != 200 {
+		t.Fatalf("unexpected success-rate summary: %+v", rate)
+	}
+	var toSuccess []float64
+	for i, repetition := range rate.Runs {
+		if repetition.Seed != int64(i+1) {
+			t.Fatalf("expected repetition %d to use seed %d, got %+v", i, i+1, repetition)
+		}
+		if repetition.Success {
+			if repetition.Evaluations > 200 || repetition.FinalBest < 1.3 {
+				t.Fatalf("success outside the goal or limit: %+v", repetition)
+			}
+			toSuccess = append(toSuccess, float64(repetition.Evaluations))
+		}
+	}
+	if rate.Successes != len(toSuccess) || rate.Rate != float64(len(toSuccess))/5 {
+		t.Fatalf("success count mismatch: %+v", rate)
+	}
+	if len(toSuccess) == 0 || rate.Successes == 5 {
+		t.Fatalf("expected a mix of successes and failures for this setup, got %+v", rate)
+	}
+	mean, _, _, _ := bestSeriesStats(toSuccess)
+	if rate.MeanEvaluationsToSuccess != mean {
+		t.Fatalf("expected mean evaluations-to-success %f, got %f", mean, rate.MeanEvaluationsToSuccess)
+	}
+}
+
+func TestRunCommandParityStrictUsesReferenceDefaults(t *testing.T) {
+	origWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	out, err := captureStdout(func() error {
+		return run(context.Background(), []string{
+			"run", "--store", "memory", "--scape", "xor", "--pop", "6", "--gens", "2",
+			"--tuning", "--parity-strict", "--dry-run",
+		})
+	})
+	if err != nil {
+		t.Fatalf("parity strict dry run: %v", err)
+	}
+	for _, want := range []string{`"parity_strict": true`, `"selection": "hof_competition"`, `"tune_selection": "dynamic_random"`} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in dry run output:\n%s", want, out)
+		}
+	}
+
+	err = run(context.Background(), []string{
+		"benchmark", "--store", "memory", "--scape", "xor", "--pop", "6", "--gens", "2",
+		"--parity-strict", "--selection", "elite", "--w-all-biases", "1", "--dry-run",
+	})
+	if err == nil || !strings.Contains(err.Error(), "selection elite") || !strings.Contains(err.Error(), "perturb_all_biases") {
+		t.Fatalf("expected parity strict to reject non-reference options, got %v", err)
+	}
+}
+
+func TestRunCommandDryRunPrintsPlanWithoutRunning(t *testing.T) {
+	origWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+	if err := os.WriteFile("run.json", []byte(`{"scape":"xor","population":12,"generations":9,"crossover_rate":0.25}`), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	out, err := captureStdout(func() error {
+		return run(context.Background(), []string{
+			"run", "--store", "memory", "--config", "run.json",
+			"--run-id", "xor-dry", "--gens", "6", "--evaluations-limit", "30",
+			"--restarts", "3", "--fitness-goal", "1.2", "--dry-run",
+		})
+	})
+	if err != nil {
+		t.Fatalf("run dry run: %v", err)
+	}
+	if !strings.Contains(out, "dry_run run_id=xor-dry scape=xor pop=12 gens=6") {
+		t.Fatalf("expected resolved run header, got:\n%s", out)
+	}
+	for _, want := range []string{`"crossover_rate": 0.25`, `"evaluations_limit": 30`, "budget generations=3 capped=true runs=1 evaluations=36", "budget_total repetitions=3 evaluations=108"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in dry run output:\n%s", want, out)
+		}
+	}
+	if _, err := os.Stat("benchmarks"); !os.IsNotExist(err) {
+		t.Fatalf("expected a dry run to write no artifacts, stat err=%v", err)
+	}
+
+	err = run(context.Background(), []string{"benchmark", "--store", "memory", "--scape", "flatland", "--scape-param", "bogus=1", "--dry-run"})
+	if err == nil {
+		t.Fatal("expected benchmark dry run to reject invalid scape params")
+	}
+}
+
 func TestBenchmarkExperimentStartListAndShow(t *testing.T) {
 	origWD, err := os.Getwd()
 	if err != nil {
@@ -1283,6 +1904,89 @@ func TestBenchmarkExperimentStartListAndShow(t *testing.T) {
 	}
 }
 
+func TestBenchmarkExperimentGridReportsParameterImportance(t *testing.T) {
+	origWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	startOut, err := captureStdout(func() error {
+		return run(context.Background(), []string{
+			"benchmark-experiment", "start",
+			"--id", "exp-grid",
+			"--runs", "2",
+			"--grid", "pop=4,10",
+			"--",
+			"--store", "memory",
+			"--scape", "xor",
+			"--gens", "2",
+			"--seed", "5",
+			"--min-improvement", "-1",
+		})
+	})
+	if err != nil {
+		t.Fatalf("benchmark-experiment start: %v", err)
+	}
+
+	exp, ok, err := stats.ReadBenchmarkExperiment("benchmarks", "exp-grid")
+	if err != nil || !ok {
+		t.Fatalf("read benchmark experiment: ok=%t err=%v", ok, err)
+	}
+	if exp.TotalRuns != 4 || len(exp.RunIDs) != 4 || !reflect.DeepEqual(exp.Grid, []string{"pop=4,10"}) {
+		t.Fatalf("expected 2 runs per grid cell, got %+v", exp)
+	}
+	populations := make([]int, 0, len(exp.RunIDs))
+	for _, runID := range exp.RunIDs {
+		cfg, ok, err := stats.ReadRunConfig("benchmarks", runID)
+		if err != nil || !ok {
+			t.Fatalf("read run config %s: ok=%t err=%v", runID, ok, err)
+		}
+		populations = append(populations, cfg.PopulationSize)
+	}
+	if !reflect.DeepEqual(populations, []int{4, 10, 4, 10}) {
+		t.Fatalf("expected runs to cycle through the grid, got populations %v", populations)
+	}
+	if exp.ParameterImportance == nil || exp.ParameterImportance.Runs != 4 {
+		t.Fatalf("expected parameter importance over 4 runs, got %+v", exp.ParameterImportance)
+	}
+	var population *stats.ParameterImportance
+	for i := range exp.ParameterImportance.Parameters {
+		parameter := &exp.ParameterImportance.Parameters[i]
+		if parameter.Field == "population_size" || slices.Contains(parameter.Aliases, "population_size") {
+			population = parameter
+		}
+	}
+	if population == nil || len(population.Levels) != 2 {
+		t.Fatalf("expected population_size among the ranked parameters, got %+v", exp.ParameterImportance.Parameters)
+	}
+	if !strings.Contains(startOut, "benchmark_experiment_importance id=exp-grid runs=4") {
+		t.Fatalf("expected importance summary in start output, got %s", startOut)
+	}
+
+	importanceOut, err := captureStdout(func() error {
+		return run(context.Background(), []string{"benchmark-experiment", "importance", "--id", "exp-grid", "--json"})
+	})
+	if err != nil {
+		t.Fatalf("benchmark-experiment importance: %v", err)
+	}
+	var payload struct {
+		ParameterImportance stats.ParameterImportanceReport `json:"parameter_importance"`
+	}
+	if err := json.Unmarshal([]byte(importanceOut), &payload); err != nil {
+		t.Fatalf("decode importance json: %v\n%s", err, importanceOut)
+	}
+	if !reflect.DeepEqual(payload.ParameterImportance, *exp.ParameterImportance) {
+		t.Fatalf("expected recomputed importance to match the stored summary:\ngot  %+v\nwant %+v", payload.ParameterImportance, *exp.ParameterImportance)
+	}
+}
+
 func TestBenchmarkExperimentContinue(t *testing.T) {
 	origWD, err := os.Getwd()
 	if err != nil {
@@ -2058,6 +2762,43 @@ func TestMonitorCommandValidation(t *testing.T) {
 	if err := run(context.Background(), []string{"monitor", "invalid", "--run-id", "x"}); err == nil {
 		t.Fatal("expected unknown action error")
 	}
+
+	if err := run(context.Background(), []string{"monitor", "set-param", "--run-id", "x"}); err == nil {
+		t.Fatal("expected missing set-param name error")
+	}
+}
+
+func TestServeModelCommandValidation(t *testing.T) {
+	if err := run(context.Background(), []string{"serve-model"}); err == nil {
+		t.Fatal("expected missing champion error")
+	}
+	if err := run(context.Background(), []string{"serve-model", "--champion", "x", "--latest"}); err == nil {
+		t.Fatal("expected champion/latest conflict error")
+	}
+	if err := run(context.Background(), []string{"serve-model", "--champion", "x", "--max-batch", "0"}); err == nil {
+		t.Fatal("expected invalid max-batch error")
+	}
+	if err := run(context.Background(), []string{"serve-model", "--compact", "champion.pgc", "--latest"}); err == nil {
+		t.Fatal("expected compact/latest conflict error")
+	}
+}
+
+func TestServeCommandValidation(t *testing.T) {
+	if err := run(context.Background(), []string{"serve", "--grpc-listen", "", "--http-listen", ""}); err == nil {
+		t.Fatal("expected missing listen address error")
+	}
+}
+
+func TestSimilarCommandValidation(t *testing.T) {
+	if err := run(context.Background(), []string{"similar"}); err == nil {
+		t.Fatal("expected missing query error")
+	}
+	if err := run(context.Background(), []string{"similar", "--run-id", "x", "--latest"}); err == nil {
+		t.Fatal("expected run-id/latest conflict error")
+	}
+	if err := run(context.Background(), []string{"similar", "--genome-id", "g", "--k", "0"}); err == nil {
+		t.Fatal("expected invalid k error")
+	}
 }
 
 func TestPopulationDeleteCommand(t *testing.T) {
@@ -2106,23 +2847,845 @@ func TestPopulationDeleteCommand(t *testing.T) {
 	}
 }
 
-func captureStdout(fn func() error) (string, error) {
-	origStdout := os.Stdout
-	r, w, err := os.Pipe()
+func TestPopulationDiffCommand(t *testing.T) {
+	origWD, err := os.Getwd()
 	if err != nil {
-		return "", err
+		t.Fatalf("getwd: %v", err)
 	}
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
 
-	os.Stdout = w
-	runErr := fn()
-	_ = w.Close()
-	os.Stdout = origStdout
-
-	var buf bytes.Buffer
-	if _, err := io.Copy(&buf, r); err != nil {
-		_ = r.Close()
-		return "", err
+	dbPath := filepath.Join(workdir, "protogonos.db")
+	for _, runID := range []string{"pop-diff-a", "pop-diff-b"} {
+		if err := run(context.Background(), []string{
+			"run",
+			"--store", "sqlite",
+			"--db-path", dbPath,
+			"--run-id", runID,
+			"--scape", "xor",
+			"--pop", "6",
+			"--gens", "2",
+			"--seed", "71",
+		}); err != nil {
+			t.Fatalf("seed run command %s: %v", runID, err)
+		}
+	}
+
+	out, err := captureStdout(func() error {
+		return run(context.Background(), []string{
+			"population", "diff",
+			"--store", "sqlite",
+			"--db-path", dbPath,
+			"--from-id", "pop-diff-a",
+			"--to-id", "pop-diff-b",
+			"--json",
+		})
+	})
+	if err != nil {
+		t.Fatalf("population diff command: %v", err)
+	}
+	var diff struct {
+		FromID           string  `json:"from_id"`
+		ToID             string  `json:"to_id"`
+		FromSize         int     `json:"from_size"`
+		ToSize           int     `json:"to_size"`
+		FitnessAvailable bool    `json:"fitness_available"`
+		KSStatistic      float64 `json:"ks_statistic"`
+	}
+	if err := json.Unmarshal([]byte(out), &diff); err != nil {
+		t.Fatalf("decode population diff: %v\n%s", err, out)
+	}
+	if diff.FromID != "pop-diff-a" || diff.ToID != "pop-diff-b" {
+		t.Fatalf("unexpected population diff ids: %+v", diff)
+	}
+	if diff.FromSize != 6 || diff.ToSize != 6 {
+		t.Fatalf("unexpected population diff sizes: %+v", diff)
+	}
+	if !diff.FitnessAvailable || diff.KSStatistic < 0 || diff.KSStatistic > 1 {
+		t.Fatalf("expected bounded ks statistic from stored fitness: %+v", diff)
+	}
+
+	if err := run(context.Background(), []string{
+		"population", "diff",
+		"--store", "sqlite",
+		"--db-path", dbPath,
+		"--from-id", "pop-diff-a",
+	}); err == nil {
+		t.Fatal("expected population diff without --to-id to fail")
+	}
+}
+
+func TestStoreCompactArchivesAndReimportsRuns(t *testing.T) {
+	origWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	dbPath := filepath.Join(workdir, "protogonos.db")
+	runID := "compact-run"
+	if err := run(context.Background(), []string{
+		"run",
+		"--store", "sqlite",
+		"--db-path", dbPath,
+		"--run-id", runID,
+		"--scape", "xor",
+		"--pop", "6",
+		"--gens", "2",
+		"--seed", "81",
+	}); err != nil {
+		t.Fatalf("seed run command: %v", err)
+	}
+
+	if err := run(context.Background(), []string{
+		"store", "compact",
+		"--store", "sqlite",
+		"--db-path", dbPath,
+		"--archive-older-than", "1ns",
+		"--archive-dir", "archives",
+	}); err != nil {
+		t.Fatalf("store compact command: %v", err)
+	}
+	archivePath := filepath.Join("archives", runID+".json.gz")
+	if _, err := os.Stat(archivePath); err != nil {
+		t.Fatalf("expected run archive %s: %v", archivePath, err)
+	}
+	if err := run(context.Background(), []string{"fitness", "--store", "sqlite", "--db-path", dbPath, "--run-id", runID}); err == nil {
+		t.Fatal("expected archived run fitness history to be offloaded")
+	}
+
+	if err := run(context.Background(), []string{
+		"store", "import-archive",
+		"--store", "sqlite",
+		"--db-path", dbPath,
+		"--path", archivePath,
+	}); err != nil {
+		t.Fatalf("store import-archive command: %v", err)
+	}
+	if err := run(context.Background(), []string{"fitness", "--store", "sqlite", "--db-path", dbPath, "--run-id", runID}); err != nil {
+		t.Fatalf("expected re-imported fitness history: %v", err)
+	}
+}
+
+func TestStoreStatsCommandSQLite(t *testing.T) {
+	origWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	dbPath := filepath.Join(workdir, "protogonos.db")
+	if err := run(context.Background(), []string{
+		"run",
+		"--store", "sqlite",
+		"--db-path", dbPath,
+		"--run-id", "stats-run",
+		"--scape", "xor",
+		"--pop", "6",
+		"--gens", "2",
+		"--seed", "91",
+	}); err != nil {
+		t.Fatalf("seed run command: %v", err)
+	}
+
+	out, err := captureStdout(func() error {
+		return run(context.Background(), []string{
+			"store", "stats",
+			"--store", "sqlite",
+			"--db-path", dbPath,
+			"--json",
+		})
+	})
+	if err != nil {
+		t.Fatalf("store stats command: %v", err)
+	}
+	var report struct {
+		StoreKind     string `json:"store_kind"`
+		FileBytes     int64  `json:"file_bytes"`
+		SchemaVersion int    `json:"schema_version"`
+		Entities      []struct {
+			Name  string `json:"name"`
+			Count int    `json:"count"`
+		} `json:"entities"`
+		Runs []struct {
+			RunID string `json:"run_id"`
+		} `json:"runs"`
+		Integrity []string `json:"integrity"`
+	}
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("decode store stats: %v\n%s", err, out)
+	}
+	if report.StoreKind != "sqlite" || report.FileBytes <= 0 || report.SchemaVersion != storage.CurrentSchemaVersion {
+		t.Fatalf("unexpected store stats header: %+v", report)
+	}
+	if len(report.Runs) == 0 || report.Runs[0].RunID != "stats-run" {
+		t.Fatalf("expected stats-run in largest runs: %+v", report.Runs)
+	}
+	if len(report.Integrity) != 1 || report.Integrity[0] != "ok" {
+		t.Fatalf("expected clean integrity check: %+v", report.Integrity)
+	}
+}
+
+func captureStdout(fn func() error) (string, error) {
+	origStdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+
+	// Drain the pipe while fn runs so output larger than the pipe buffer
+	// does not block it.
+	var buf bytes.Buffer
+	copied := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(&buf, r)
+		copied <- err
+	}()
+
+	os.Stdout = w
+	runErr := fn()
+	_ = w.Close()
+	os.Stdout = origStdout
+
+	err = <-copied
+	_ = r.Close()
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), runErr
+}
+
+func TestCrossEvalCommandPrintsMatrix(t *testing.T) {
+	origWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	dbPath := filepath.Join(workdir, "protogonos.db")
+	for _, runID := range []string{"cross-a", "cross-b"} {
+		if err := run(context.Background(), []string{
+			"run",
+			"--store", "sqlite",
+			"--db-path", dbPath,
+			"--run-id", runID,
+			"--scape", "xor",
+			"--pop", "6",
+			"--gens", "2",
+			"--workers", "2",
+		}); err != nil {
+			t.Fatalf("run command %s: %v", runID, err)
+		}
+	}
+
+	if err := run(context.Background(), []string{"cross-eval", "--store", "sqlite", "--db-path", dbPath, "--runs", "cross-a"}); err == nil {
+		t.Fatal("expected cross-eval with one run to fail")
+	}
+	out, err := captureStdout(func() error {
+		return run(context.Background(), []string{
+			"cross-eval",
+			"--store", "sqlite",
+			"--db-path", dbPath,
+			"--runs", "cross-a, cross-b",
+			"--modes", "validation",
+		})
+	})
+	if err != nil {
+		t.Fatalf("cross-eval command: %v", err)
+	}
+	for _, want := range []string{
+		"cross_eval runs=cross-a,cross-b modes=validation",
+		"mode=validation champion_run=cross-a cross-a=",
+		"mode=validation champion_run=cross-b cross-a=",
+		"gap mode=validation champion_run=cross-a ",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in cross-eval output: %s", want, out)
+		}
+	}
+}
+
+func TestPlotCommandWritesSVGAndPNG(t *testing.T) {
+	origWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	dbPath := filepath.Join(workdir, "protogonos.db")
+	if err := run(context.Background(), []string{
+		"run",
+		"--store", "sqlite",
+		"--db-path", dbPath,
+		"--run-id", "plot-run",
+		"--scape", "xor",
+		"--pop", "6",
+		"--gens", "3",
+		"--workers", "2",
+	}); err != nil {
+		t.Fatalf("run command: %v", err)
+	}
+
+	out, err := captureStdout(func() error {
+		return run(context.Background(), []string{"plot", "--store", "sqlite", "--db-path", dbPath, "--run-id", "plot-run", "--out", "plots/fitness.svg"})
+	})
+	if err != nil {
+		t.Fatalf("plot command: %v", err)
+	}
+	if !strings.Contains(out, "plot run_id=plot-run what=fitness format=svg generations=3") {
+		t.Fatalf("unexpected plot output: %s", out)
+	}
+	svg, err := os.ReadFile(filepath.Join(workdir, "plots", "fitness.svg"))
+	if err != nil || !strings.Contains(string(svg), "<svg") {
+		t.Fatalf("expected svg plot file, err=%v", err)
+	}
+
+	if _, err := captureStdout(func() error {
+		return run(context.Background(), []string{"plot", "--store", "sqlite", "--db-path", dbPath, "--latest", "--what", "species", "--out", "species.png"})
+	}); err != nil {
+		t.Fatalf("plot png command: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(workdir, "species.png"))
+	if err != nil || !strings.HasPrefix(string(data), "\x89PNG") {
+		t.Fatalf("expected png plot file, err=%v", err)
+	}
+
+	if err := run(context.Background(), []string{"plot", "--store", "sqlite", "--db-path", dbPath, "--out", "x.svg"}); err == nil {
+		t.Fatal("expected plot without a run selector to fail")
+	}
+}
+
+func TestAnnotateCommandAnnotatesChampion(t *testing.T) {
+	origWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	dbPath := filepath.Join(workdir, "protogonos.db")
+	if err := run(context.Background(), []string{
+		"run",
+		"--store", "sqlite",
+		"--db-path", dbPath,
+		"--run-id", "annotate-run",
+		"--scape", "xor",
+		"--pop", "6",
+		"--gens", "2",
+		"--workers", "2",
+	}); err != nil {
+		t.Fatalf("run command: %v", err)
+	}
+
+	out, err := captureStdout(func() error {
+		return run(context.Background(), []string{
+			"annotate",
+			"--store", "sqlite",
+			"--db-path", dbPath,
+			"--run-id", "annotate-run",
+			"--set", "reviewed=yes",
+			"--set", "task=xor",
+			"--note", "inspected after run",
+		})
+	})
+	if err != nil {
+		t.Fatalf("annotate command: %v", err)
+	}
+	if !strings.Contains(out, "annotated run_id=annotate-run rank=1") || !strings.Contains(out, "annotations=reviewed=yes,task=xor") {
+		t.Fatalf("unexpected annotate output: %s", out)
+	}
+	if !strings.Contains(out, "note=1 inspected after run") {
+		t.Fatalf("expected note in annotate output: %s", out)
+	}
+
+	out, err = captureStdout(func() error {
+		return run(context.Background(), []string{"top", "--store", "sqlite", "--db-path", dbPath, "--run-id", "annotate-run", "--limit", "1"})
+	})
+	if err != nil {
+		t.Fatalf("top command: %v", err)
+	}
+	if !strings.Contains(out, "annotations rank=1 reviewed=yes,task=xor") {
+		t.Fatalf("expected persisted annotations in top output: %s", out)
+	}
+
+	if err := run(context.Background(), []string{"annotate", "--store", "sqlite", "--db-path", dbPath, "--run-id", "annotate-run"}); err == nil {
+		t.Fatal("expected annotate without edits to fail")
+	}
+}
+
+func TestModuleCommandTagsListsAndGraftsModules(t *testing.T) {
+	origWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	dbPath := filepath.Join(workdir, "protogonos.db")
+	if err := run(context.Background(), []string{
+		"run",
+		"--store", "sqlite",
+		"--db-path", dbPath,
+		"--run-id", "module-src",
+		"--scape", "xor",
+		"--pop", "6",
+		"--gens", "2",
+		"--workers", "2",
+		"--w-add-neuron", "1",
+	}); err != nil {
+		t.Fatalf("source run command: %v", err)
+	}
+
+	out, err := captureStdout(func() error {
+		return run(context.Background(), []string{
+			"module", "tag",
+			"--store", "sqlite",
+			"--db-path", dbPath,
+			"--run-id", "module-src",
+			"--id", "xor-hidden",
+		})
+	})
+	if err != nil {
+		t.Fatalf("module tag command: %v", err)
+	}
+	if !strings.Contains(out, "module id=xor-hidden source_run_id=module-src") {
+		t.Fatalf("unexpected module tag output: %s", out)
+	}
+
+	out, err = captureStdout(func() error {
+		return run(context.Background(), []string{"module", "list", "--store", "sqlite", "--db-path", dbPath})
+	})
+	if err != nil {
+		t.Fatalf("module list command: %v", err)
+	}
+	if !strings.Contains(out, "module id=xor-hidden") {
+		t.Fatalf("expected tagged module in listing: %s", out)
+	}
+
+	if err := run(context.Background(), []string{
+		"run",
+		"--store", "sqlite",
+		"--db-path", dbPath,
+		"--run-id", "module-graft",
+		"--scape", "xor",
+		"--pop", "6",
+		"--gens", "2",
+		"--workers", "2",
+		"--w-insert-module", "3",
+		"--modules", "xor-hidden",
+	}); err != nil {
+		t.Fatalf("graft run command: %v", err)
+	}
+	cfg, ok, err := stats.ReadRunConfig(benchmarksDir, "module-graft")
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.WeightInsertModule != 3 || len(cfg.Modules) != 1 || cfg.Modules[0] != "xor-hidden" {
+		t.Fatalf("expected insert module settings in run config, got %+v", cfg)
+	}
+}
+
+func TestGenomeEditCommandFreezesChampionMasks(t *testing.T) {
+	origWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	dbPath := filepath.Join(workdir, "protogonos.db")
+	if err := run(context.Background(), []string{
+		"run",
+		"--store", "sqlite",
+		"--db-path", dbPath,
+		"--run-id", "genome-edit-run",
+		"--scape", "xor",
+		"--pop", "6",
+		"--gens", "2",
+		"--workers", "2",
+	}); err != nil {
+		t.Fatalf("run command: %v", err)
+	}
+	top, ok, err := stats.ReadTopGenomes(benchmarksDir, "genome-edit-run")
+	if err != nil || !ok || len(top) == 0 {
+		t.Fatalf("read top genomes: ok=%t err=%v", ok, err)
+	}
+	synapseID := top[0].Genome.Synapses[0].ID
+
+	out, err := captureStdout(func() error {
+		return run(context.Background(), []string{
+			"genome", "edit",
+			"--store", "sqlite",
+			"--db-path", dbPath,
+			"--run-id", "genome-edit-run",
+			"--freeze-synapse", synapseID,
+		})
+	})
+	if err != nil {
+		t.Fatalf("genome edit command: %v", err)
+	}
+	if !strings.Contains(out, "edited run_id=genome-edit-run rank=1") ||
+		!strings.Contains(out, "frozen_neurons=- frozen_synapses="+synapseID) {
+		t.Fatalf("unexpected genome edit output: %s", out)
+	}
+
+	top, _, err = stats.ReadTopGenomes(benchmarksDir, "genome-edit-run")
+	if err != nil {
+		t.Fatalf("reread top genomes: %v", err)
+	}
+	if !top[0].Genome.Synapses[0].Frozen {
+		t.Fatalf("expected frozen synapse in top genome artifact, got %+v", top[0].Genome.Synapses[0])
+	}
+
+	if err := run(context.Background(), []string{"genome", "edit", "--run-id", "genome-edit-run", "--store", "sqlite", "--db-path", dbPath}); err == nil {
+		t.Fatal("expected genome edit without masks to fail")
+	}
+}
+
+func TestESRunCommandRecordsABaselineRun(t *testing.T) {
+	origWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	dbPath := filepath.Join(workdir, "protogonos.db")
+	out, err := captureStdout(func() error {
+		return run(context.Background(), []string{
+			"es-run",
+			"--store", "sqlite",
+			"--db-path", dbPath,
+			"--run-id", "xor-es",
+			"--scape", "xor",
+			"--pop", "6",
+			"--gens", "2",
+			"--workers", "2",
+			"--sigma", "0.05",
+		})
+	})
+	if err != nil {
+		t.Fatalf("es-run command: %v", err)
+	}
+	for _, want := range []string{
+		"es_run completed run_id=xor-es scape=xor pop=6 gens=2 seed=1 ",
+		" evaluations=12\n",
+		"generation=2 best_fitness=",
+		"final_best_fitness=",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected es-run output to contain %q, got:\n%s", want, out)
+		}
+	}
+	top, err := captureStdout(func() error {
+		return run(context.Background(), []string{"top", "--store", "sqlite", "--db-path", dbPath, "--run-id", "xor-es"})
+	})
+	if err != nil {
+		t.Fatalf("top command: %v", err)
+	}
+	if !strings.Contains(top, "rank=1 ") {
+		t.Fatalf("expected the es run's top genomes to be stored, got:\n%s", top)
+	}
+	if err := run(context.Background(), []string{"es-run", "--store", "sqlite", "--db-path", dbPath, "--scape", "xor", "--pop", "5", "--gens", "1"}); err == nil {
+		t.Fatal("expected an odd es population to fail")
+	}
+}
+
+func TestResumeCommandSQLiteFinishesInterruptedRun(t *testing.T) {
+	origWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	dbPath := filepath.Join(workdir, "protogonos.db")
+	client, err := protoapi.New(protoapi.Options{StoreKind: "sqlite", DBPath: dbPath, BenchmarksDir: "benchmarks", ExportsDir: "exports"})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err = client.Run(ctx, protoapi.RunRequest{
+		RunID:              "resume-run",
+		Scape:              "xor",
+		Population:         6,
+		Generations:        4,
+		Seed:               3,
+		CheckpointInterval: 1,
+		Progress: func(progress protoapi.RunProgress) {
+			if progress.Generation == 2 {
+				cancel()
+			}
+		},
+	})
+	_ = client.Close()
+	if err == nil {
+		t.Fatal("expected the run to be interrupted")
+	}
+
+	output, err := captureStdout(func() error {
+		return run(context.Background(), []string{"resume", "--store", "sqlite", "--db-path", dbPath, "--run-id", "resume-run"})
+	})
+	if err != nil {
+		t.Fatalf("resume command: %v", err)
+	}
+	if !strings.Contains(output, "resume completed run_id=resume-run") || !strings.Contains(output, "generation=4 best_fitness=") {
+		t.Fatalf("unexpected resume output: %s", output)
+	}
+	if _, err := os.Stat(filepath.Join("benchmarks", "resume-run", "config.json")); err != nil {
+		t.Fatalf("expected the resumed run's artifacts: %v", err)
+	}
+	if err := run(context.Background(), []string{"resume", "--store", "sqlite", "--db-path", dbPath, "--run-id", "resume-run"}); err == nil {
+		t.Fatal("expected the finished run's checkpoint to be gone")
+	}
+}
+
+func TestHelpJSONDescribesCommandsAndSubcommands(t *testing.T) {
+	out, err := captureStdout(func() error {
+		return run(context.Background(), []string{"--help-json"})
+	})
+	if err != nil {
+		t.Fatalf("--help-json: %v", err)
+	}
+	var meta cliMetadata
+	if err := json.Unmarshal([]byte(out), &meta); err != nil {
+		t.Fatalf("decode --help-json output: %v", err)
+	}
+	if meta.Program != "protogonosctl" || len(meta.GlobalFlags) != 3 || len(meta.Commands) != len(commandNames()) {
+		t.Fatalf("unexpected CLI metadata: program=%s global_flags=%d commands=%d", meta.Program, len(meta.GlobalFlags), len(meta.Commands))
+	}
+
+	out, err = captureStdout(func() error {
+		return run(context.Background(), []string{"store", "compact", "--help-json"})
+	})
+	if err != nil {
+		t.Fatalf("store compact --help-json: %v", err)
+	}
+	var sub commandMetadata
+	if err := json.Unmarshal([]byte(out), &sub); err != nil {
+		t.Fatalf("decode subcommand metadata: %v", err)
+	}
+	if sub.Name != "compact" || len(sub.Flags) == 0 {
+		t.Fatalf("unexpected store compact metadata: %+v", sub)
+	}
+
+	if err := run(context.Background(), []string{"store", "vacuum", "--help-json"}); err == nil {
+		t.Fatal("expected an unknown subcommand to be rejected")
+	}
+}
+
+func TestGenomeSchemaCommandPrintsSchemaAndValidatesGenomes(t *testing.T) {
+	out, err := captureStdout(func() error {
+		return run(context.Background(), []string{"genome", "schema"})
+	})
+	if err != nil {
+		t.Fatalf("genome schema command: %v", err)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(out), &schema); err != nil || schema["$id"] != "urn:protogonos:genome:1" {
+		t.Fatalf("unexpected genome schema output: err=%v %s", err, out)
+	}
+
+	out, err = captureStdout(func() error {
+		return run(context.Background(), []string{"genome", "schema", "--validate", filepath.Join("..", "..", "testdata", "fixtures", "minimal_genome_v1.json")})
+	})
+	if err != nil {
+		t.Fatalf("validate fixture: %v", err)
+	}
+	if !strings.Contains(out, "valid genome_id=genome-minimal-1 neurons=2 synapses=1") {
+		t.Fatalf("unexpected validate output: %s", out)
+	}
+
+	path := filepath.Join(t.TempDir(), "genome.json")
+	if err := os.WriteFile(path, []byte(`{"schema_version": 1, "codec_version": 1, "id": "g", "neuron": []}`), 0o644); err != nil {
+		t.Fatalf("write genome: %v", err)
+	}
+	err = run(context.Background(), []string{"genome", "schema", "--validate", path})
+	if err == nil || !strings.Contains(err.Error(), "line 1 column 54: field neuron") {
+		t.Fatalf("expected a located unknown-field error, got %v", err)
+	}
+}
+
+func TestGenomeDotCommandRendersChampionAndGenomeFiles(t *testing.T) {
+	origWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	fixture := filepath.Join(origWD, "..", "..", "testdata", "fixtures", "minimal_genome_v1.json")
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	dbPath := filepath.Join(workdir, "protogonos.db")
+	if err := run(context.Background(), []string{
+		"run",
+		"--store", "sqlite",
+		"--db-path", dbPath,
+		"--run-id", "genome-dot-run",
+		"--scape", "xor",
+		"--pop", "6",
+		"--gens", "2",
+		"--workers", "2",
+	}); err != nil {
+		t.Fatalf("run command: %v", err)
+	}
+	top, ok, err := stats.ReadTopGenomes(benchmarksDir, "genome-dot-run")
+	if err != nil || !ok || len(top) == 0 {
+		t.Fatalf("read top genomes: ok=%t err=%v", ok, err)
+	}
+	champion := top[0].Genome
+
+	out, err := captureStdout(func() error {
+		return run(context.Background(), []string{"genome", "dot", "--run-id", "genome-dot-run", "--store", "sqlite", "--db-path", dbPath})
+	})
+	if err != nil {
+		t.Fatalf("genome dot command: %v", err)
+	}
+	if !strings.HasPrefix(out, "digraph \""+champion.ID+"\" {") || strings.Count(out, "[label=") < len(champion.Neurons) {
+		t.Fatalf("unexpected dot output for champion %s: %s", champion.ID, out)
+	}
+
+	svgPath := filepath.Join(workdir, "champion.svg")
+	out, err = captureStdout(func() error {
+		return run(context.Background(), []string{"genome", "dot", "--latest", "--genome-id", champion.ID, "--format", "svg", "--out", svgPath, "--store", "sqlite", "--db-path", dbPath})
+	})
+	if err != nil {
+		t.Fatalf("genome dot svg command: %v", err)
+	}
+	if !strings.Contains(out, "genome_dot genome_id="+champion.ID+" format=svg") {
+		t.Fatalf("unexpected genome dot svg output: %s", out)
+	}
+	svg, err := os.ReadFile(svgPath)
+	if err != nil || !bytes.HasPrefix(svg, []byte("<svg")) {
+		t.Fatalf("expected an svg file: err=%v %s", err, svg)
+	}
+
+	out, err = captureStdout(func() error {
+		return run(context.Background(), []string{"genome", "dot", "--genome", fixture})
+	})
+	if err != nil {
+		t.Fatalf("genome dot file command: %v", err)
+	}
+	if !strings.Contains(out, `"n:n-input" -> "n:n-output"`) {
+		t.Fatalf("unexpected dot output for genome file: %s", out)
+	}
+
+	if err := run(context.Background(), []string{"genome", "dot", "--genome", fixture, "--run-id", "genome-dot-run"}); err == nil {
+		t.Fatal("expected genome dot with both a run and a genome file to fail")
+	}
+	if err := run(context.Background(), []string{"genome", "dot", "--run-id", "genome-dot-run", "--genome-id", "missing", "--store", "sqlite", "--db-path", dbPath}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected missing genome error, got %v", err)
+	}
+	if err := run(context.Background(), []string{"genome-dot", "--genome", fixture}); err == nil {
+		t.Fatal("expected the top-level genome-dot command to be gone")
+	}
+	if err := run(context.Background(), []string{"genome", "render", "--genome", fixture}); err == nil {
+		t.Fatal("expected an unknown genome subcommand to be rejected")
+	}
+}
+
+func TestExportCommandPaperProfileWritesArchive(t *testing.T) {
+	origWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	workdir := t.TempDir()
+	if err := os.Chdir(workdir); err != nil {
+		t.Fatalf("chdir tempdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	dbPath := filepath.Join(workdir, "paper.db")
+	if err := run(context.Background(), []string{
+		"run",
+		"--store", "sqlite",
+		"--db-path", dbPath,
+		"--run-id", "paper-cli",
+		"--scape", "xor",
+		"--pop", "6",
+		"--gens", "2",
+		"--seed", "13",
+	}); err != nil {
+		t.Fatalf("run command: %v", err)
+	}
+
+	if err := run(context.Background(), []string{"export", "--run-id", "paper-cli", "--profile", "zine"}); err == nil {
+		t.Fatal("expected unknown export profile to be rejected")
+	}
+	output, err := captureStdout(func() error {
+		return run(context.Background(), []string{"export", "--latest", "--profile", "paper"})
+	})
+	if err != nil {
+		t.Fatalf("export command: %v", err)
+	}
+	if !strings.Contains(output, "run_id=paper-cli") || !strings.Contains(output, "profile=paper") || !strings.Contains(output, "files=7") {
+		t.Fatalf("unexpected export output: %s", output)
+	}
+	if _, err := os.Stat(filepath.Join("exports", "paper-cli-paper.zip")); err != nil {
+		t.Fatalf("expected paper archive: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join("exports", "paper-cli")); !os.IsNotExist(err) {
+		t.Fatalf("expected the paper profile to skip the raw artifact copy, stat err=%v", err)
 	}
-	_ = r.Close()
-	return buf.String(), runErr
 }
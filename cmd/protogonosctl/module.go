@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	protoapi "protogonos/pkg/protogonos"
+)
+
+func runModule(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("module requires a subcommand: tag|list")
+	}
+	switch args[0] {
+	case "tag":
+		fs := newFlagSet("module tag")
+		runID := fs.String("run-id", "", "run id whose top genome the module is cut from")
+		latest := fs.Bool("latest", false, "cut the module from the most recent run from run index")
+		genomeID := fs.String("genome-id", "", "top genome to cut the module from (default: run champion)")
+		moduleID := fs.String("id", "", "module library id")
+		neurons := fs.String("neurons", "", "comma-separated neuron ids spanning the module (default: all hidden neurons)")
+		jsonOut := fs.Bool("json", false, "emit the tagged module as JSON")
+		storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+		dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *runID != "" && *latest {
+			return errors.New("use either --run-id or --latest, not both")
+		}
+		if *runID == "" && !*latest {
+			return errors.New("module tag requires --run-id or --latest")
+		}
+		if strings.TrimSpace(*moduleID) == "" {
+			return errors.New("module tag requires --id")
+		}
+
+		client, err := protoapi.New(protoapi.Options{
+			StoreKind:     *storeKind,
+			DBPath:        *dbPath,
+			BenchmarksDir: benchmarksDir,
+			ExportsDir:    exportsDir,
+		})
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = client.Close()
+		}()
+
+		module, err := client.TagModule(ctx, protoapi.TagModuleRequest{
+			RunID:     *runID,
+			Latest:    *latest,
+			GenomeID:  *genomeID,
+			ModuleID:  *moduleID,
+			NeuronIDs: splitCommaList(*neurons),
+		})
+		if err != nil {
+			return err
+		}
+		if *jsonOut {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(module)
+		}
+		fmt.Printf("module id=%s source_run_id=%s source_genome_id=%s neurons=%d synapses=%d inputs=%s outputs=%s\n",
+			module.ID,
+			module.SourceRunID,
+			module.SourceGenomeID,
+			len(module.Neurons),
+			len(module.Synapses),
+			strings.Join(module.Inputs, ","),
+			strings.Join(module.Outputs, ","),
+		)
+		return nil
+	case "list":
+		fs := newFlagSet("module list")
+		jsonOut := fs.Bool("json", false, "emit the module library as JSON")
+		storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+		dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		client, err := protoapi.New(protoapi.Options{
+			StoreKind:     *storeKind,
+			DBPath:        *dbPath,
+			BenchmarksDir: benchmarksDir,
+			ExportsDir:    exportsDir,
+		})
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = client.Close()
+		}()
+
+		modules, err := client.Modules(ctx)
+		if err != nil {
+			return err
+		}
+		if *jsonOut {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(modules)
+		}
+		for _, module := range modules {
+			fmt.Printf("module id=%s source_run_id=%s source_genome_id=%s neurons=%d synapses=%d inputs=%d outputs=%d\n",
+				module.ID,
+				module.SourceRunID,
+				module.SourceGenomeID,
+				len(module.Neurons),
+				len(module.Synapses),
+				len(module.Inputs),
+				len(module.Outputs),
+			)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported module subcommand: %s", args[0])
+	}
+}
@@ -35,6 +35,16 @@ func mutationWeightBucket(name string) string {
 		return "add_neuron"
 	case "remove_neuron":
 		return "remove_neuron"
+	case "add_recurrent_loop":
+		return "recurrent_loop"
+	case "duplicate_neuron":
+		return "duplicate_neuron"
+	case "toggle_synapse_enabled":
+		return "toggle_synapse"
+	case "insert_module":
+		return "insert_module"
+	case "perturb_all_biases":
+		return "all_biases"
 	case "mutate_plasticity_parameters":
 		return "plasticity"
 	case "mutate_pf":
@@ -22,6 +22,11 @@ func TestMutationWeightBucketCoversGenomeMutatorSurface(t *testing.T) {
 		"outsplice":                        "add_neuron",
 		"insplice":                         "add_neuron",
 		"remove_neuron":                    "remove_neuron",
+		"add_recurrent_loop":               "recurrent_loop",
+		"duplicate_neuron":                 "duplicate_neuron",
+		"toggle_synapse_enabled":           "toggle_synapse",
+		"insert_module":                    "insert_module",
+		"perturb_all_biases":               "all_biases",
 		"mutate_pf":                        "plasticity_rule",
 		"mutate_plasticity_parameters":     "plasticity",
 		"add_sensor":                       "substrate",
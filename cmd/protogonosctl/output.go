@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"protogonos/internal/stats"
+	protoapi "protogonos/pkg/protogonos"
+)
+
+// Output modes of run and benchmark. jsonl streams one JSON record per
+// scored generation and a final summary record to stdout, so orchestrators
+// can follow a run without polling the HTTP server.
+const (
+	outputText  = "text"
+	outputJSONL = "jsonl"
+)
+
+func parseOutputMode(value string) (string, error) {
+	switch value {
+	case "", outputText:
+		return outputText, nil
+	case outputJSONL:
+		return outputJSONL, nil
+	default:
+		return "", fmt.Errorf("unsupported --output %q (want text|jsonl)", value)
+	}
+}
+
+type jsonlGenerationRecord struct {
+	Type                 string            `json:"type"`
+	Generation           int               `json:"generation"`
+	BestFitness          float64           `json:"best_fitness"`
+	MeanFitness          float64           `json:"mean_fitness"`
+	SpeciesCount         int               `json:"species_count"`
+	Tuning               jsonlTuningRecord `json:"tuning"`
+	WallClockSeconds     float64           `json:"wall_clock_seconds"`
+	TotalEvaluations     int               `json:"total_evaluations"`
+	EvaluationsPerSecond float64           `json:"evaluations_per_second"`
+	ETASeconds           float64           `json:"eta_seconds"`
+}
+
+type jsonlTuningRecord struct {
+	Invocations int     `json:"invocations"`
+	Evaluations int     `json:"evaluations"`
+	Accepted    int     `json:"accepted"`
+	AcceptRate  float64 `json:"accept_rate"`
+}
+
+type jsonlCompareRecord struct {
+	WithoutFinalBest      float64 `json:"without_final_best"`
+	WithFinalBest         float64 `json:"with_final_best"`
+	FinalImprovement      float64 `json:"final_improvement"`
+	Pairing               string  `json:"pairing"`
+	MeanPairedImprovement float64 `json:"mean_paired_improvement"`
+}
+
+// jsonlSummaryRecord closes a jsonl stream; Benchmark is set by the
+// benchmark command only.
+type jsonlSummaryRecord struct {
+	Type              string                    `json:"type"`
+	Command           string                    `json:"command"`
+	RunID             string                    `json:"run_id"`
+	Scape             string                    `json:"scape"`
+	Population        int                       `json:"population"`
+	Generations       int                       `json:"generations"`
+	Seed              int64                     `json:"seed"`
+	BestByGeneration  []float64                 `json:"best_by_generation"`
+	FinalBestFitness  float64                   `json:"final_best_fitness"`
+	IslandBestFitness []float64                 `json:"island_best_fitness,omitempty"`
+	ChampionFitnessCI *protoapi.FitnessInterval `json:"champion_fitness_ci,omitempty"`
+	Compare           *jsonlCompareRecord       `json:"compare_tuning,omitempty"`
+	EvaluationCost    *stats.EvaluationCost     `json:"evaluation_cost,omitempty"`
+	Regression        *protoapi.RegressionCheck `json:"regression_watch,omitempty"`
+	Benchmark         *stats.BenchmarkSummary   `json:"benchmark,omitempty"`
+	ArtifactsDir      string                    `json:"artifacts_dir"`
+}
+
+// jsonlWriter serializes records onto one line each. Progress callbacks may
+// come from the evolution goroutine, so writes are locked.
+type jsonlWriter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func newJSONLWriter(w io.Writer) *jsonlWriter {
+	return &jsonlWriter{enc: json.NewEncoder(w)}
+}
+
+func (w *jsonlWriter) write(record any) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.enc.Encode(record)
+}
+
+// progress is a RunRequest.Progress callback; a failed write to stdout has
+// nowhere better to be reported, as with the text progress lines.
+func (w *jsonlWriter) progress(p protoapi.RunProgress) {
+	_ = w.write(jsonlGenerationRecord{
+		Type:         "generation",
+		Generation:   p.Generation,
+		BestFitness:  p.BestFitness,
+		MeanFitness:  p.MeanFitness,
+		SpeciesCount: p.SpeciesCount,
+		Tuning: jsonlTuningRecord{
+			Invocations: p.TuningInvocations,
+			Evaluations: p.TuningEvaluations,
+			Accepted:    p.TuningAccepted,
+			AcceptRate:  p.TuningAcceptRate,
+		},
+		WallClockSeconds:     p.WallClock.Seconds(),
+		TotalEvaluations:     p.TotalEvaluations,
+		EvaluationsPerSecond: p.EvaluationsPerSecond,
+		ETASeconds:           p.ETA.Seconds(),
+	})
+}
+
+func (w *jsonlWriter) summary(command string, req protoapi.RunRequest, summary protoapi.RunSummary, benchmark *stats.BenchmarkSummary) error {
+	record := jsonlSummaryRecord{
+		Type:              "summary",
+		Command:           command,
+		RunID:             summary.RunID,
+		Scape:             req.Scape,
+		Population:        req.Population,
+		Generations:       req.Generations,
+		Seed:              req.Seed,
+		BestByGeneration:  summary.BestByGeneration,
+		FinalBestFitness:  summary.FinalBestFitness,
+		IslandBestFitness: summary.IslandBestFitness,
+		ChampionFitnessCI: summary.ChampionFitnessCI,
+		EvaluationCost:    summary.EvaluationCost,
+		Regression:        summary.Regression,
+		Benchmark:         benchmark,
+		ArtifactsDir:      summary.ArtifactsDir,
+	}
+	if compare := summary.Compare; compare != nil {
+		record.Compare = &jsonlCompareRecord{
+			WithoutFinalBest:      compare.WithoutFinalBest,
+			WithFinalBest:         compare.WithFinalBest,
+			FinalImprovement:      compare.FinalImprovement,
+			Pairing:               compare.Pairing,
+			MeanPairedImprovement: compare.MeanPairedImprovement,
+		}
+	}
+	return w.write(record)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	protoapi "protogonos/pkg/protogonos"
+)
+
+func runProfileRun(ctx context.Context, args []string) error {
+	fs := newFlagSet("profile-run")
+	runID := fs.String("run-id", "", "run id")
+	perGeneration := fs.Bool("generations", false, "also print every generation's phase breakdown")
+	jsonOut := fs.Bool("json", false, "emit the profile as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *runID == "" {
+		return errors.New("profile-run requires --run-id")
+	}
+
+	client, err := protoapi.New(protoapi.Options{
+		StoreKind:     globals.StoreKind,
+		DBPath:        globals.DBPath,
+		BenchmarksDir: benchmarksDir,
+		ExportsDir:    exportsDir,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	profile, err := client.ProfileRun(ctx, protoapi.ProfileRunRequest{RunID: *runID})
+	if err != nil {
+		return err
+	}
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(profile)
+	}
+	fmt.Printf("run_id=%s generations=%d wall_clock_seconds=%.3f dominant=%s\n",
+		profile.RunID, len(profile.Generations), profile.WallClockSeconds, profile.Dominant)
+	for _, phase := range profile.Phases {
+		fmt.Printf("phase=%s seconds=%.3f share=%.1f%%\n", phase.Phase, phase.Seconds, 100*phase.Share)
+	}
+	if *perGeneration {
+		for _, generation := range profile.Generations {
+			t := generation.Timings
+			fmt.Printf("generation=%d wall_clock_seconds=%.3f selection=%.3f mutation=%.3f evaluation=%.3f tuning=%.3f speciation=%.3f persistence=%.3f other=%.3f dominant=%s\n",
+				generation.Generation, t.WallClockSeconds, t.SelectionSeconds, t.MutationSeconds, t.EvaluationSeconds, t.TuningSeconds, t.SpeciationSeconds, t.PersistenceSeconds, t.OtherSeconds, generation.Dominant)
+		}
+	}
+	return nil
+}
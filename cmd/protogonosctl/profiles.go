@@ -32,6 +32,25 @@ type parityPreset struct {
 	WeightPlasticityRule   float64
 	WeightPlasticity       float64
 	WeightSubstrate        float64
+	WeightAllBiases        float64
+	BiasMaxDelta           float64
+}
+
+// parityBiasControls are the bias mutation controls a profile starts from,
+// kept apart from the add_bias weight the reference operator list maps to.
+type parityBiasControls struct {
+	WeightAllBiases float64
+	BiasMaxDelta    float64
+}
+
+// parityBiasDefaults lists the profiles whose scapes benefit from bias-heavy
+// search. Profiles not listed leave perturb_all_biases off and keep the
+// default bias step.
+var parityBiasDefaults = map[string]parityBiasControls{
+	"ref-flatland-bias":    {WeightAllBiases: 20, BiasMaxDelta: 0.5},
+	"ref-time-series-gtsa": {WeightAllBiases: 5},
+	"parity-gtsa-core":     {WeightAllBiases: 5},
+	"parity-fx-market":     {WeightAllBiases: 5},
 }
 
 type parityProfileFixture struct {
@@ -98,6 +117,8 @@ type parityProfileResolved struct {
 	WeightPlasticityRule   float64
 	WeightPlasticity       float64
 	WeightSubstrate        float64
+	WeightAllBiases        float64
+	BiasMaxDelta           float64
 }
 
 func loadParityFixture() (parityProfileFixture, error) {
@@ -141,6 +162,8 @@ func loadParityPreset(profileID string) (parityPreset, error) {
 		WeightPlasticityRule:   resolved.WeightPlasticityRule,
 		WeightPlasticity:       resolved.WeightPlasticity,
 		WeightSubstrate:        resolved.WeightSubstrate,
+		WeightAllBiases:        resolved.WeightAllBiases,
+		BiasMaxDelta:           resolved.BiasMaxDelta,
 	}, nil
 }
 
@@ -197,7 +220,15 @@ func resolveParityProfile(profileID string) (parityProfileResolved, error) {
 				resolved.WeightPlasticityRule += op.Weight
 			case "substrate":
 				resolved.WeightSubstrate += op.Weight
+			case "all_biases":
+				resolved.WeightAllBiases += op.Weight
+			}
+		}
+		if defaults, ok := parityBiasDefaults[profile.ID]; ok {
+			if resolved.WeightAllBiases == 0 {
+				resolved.WeightAllBiases = defaults.WeightAllBiases
 			}
+			resolved.BiasMaxDelta = defaults.BiasMaxDelta
 		}
 		if resolved.WeightPerturb+resolved.WeightBias+resolved.WeightRemoveBias+resolved.WeightActivation+resolved.WeightAggregator+resolved.WeightAddSyn+resolved.WeightRemoveSyn+resolved.WeightAddNeuro+resolved.WeightRemoveNeuro+resolved.WeightPlasticityRule+resolved.WeightPlasticity+resolved.WeightSubstrate <= 0 {
 			return parityProfileResolved{}, fmt.Errorf("profile %s has no mapped mutation weights", profileID)
@@ -39,6 +39,27 @@ func TestLoadParityPresetCarriesSeedProfiles(t *testing.T) {
 	}
 }
 
+func TestLoadParityPresetCarriesBiasDefaults(t *testing.T) {
+	preset, err := loadParityPreset("ref-flatland-bias")
+	if err != nil {
+		t.Fatalf("load preset: %v", err)
+	}
+	if preset.WeightAllBiases != 20 || preset.BiasMaxDelta != 0.5 {
+		t.Fatalf("expected bias-heavy flatland defaults, got all_biases=%g max_delta=%g", preset.WeightAllBiases, preset.BiasMaxDelta)
+	}
+	if preset.WeightBias <= 0 {
+		t.Fatalf("expected add_bias weight to stay mapped from the operator list, got %g", preset.WeightBias)
+	}
+
+	preset, err = loadParityPreset("ref-default-xorandxor")
+	if err != nil {
+		t.Fatalf("load preset: %v", err)
+	}
+	if preset.WeightAllBiases != 0 || preset.BiasMaxDelta != 0 {
+		t.Fatalf("expected unlisted profile to leave bias controls at defaults, got all_biases=%g max_delta=%g", preset.WeightAllBiases, preset.BiasMaxDelta)
+	}
+}
+
 func TestLoadParityPresetMissing(t *testing.T) {
 	_, err := loadParityPreset("missing-profile")
 	if err == nil {
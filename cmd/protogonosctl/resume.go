@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	protoapi "protogonos/pkg/protogonos"
+)
+
+func runResume(ctx context.Context, args []string) error {
+	fs := newFlagSet("resume")
+	runID := fs.String("run-id", "", "id of the interrupted run to resume")
+	storeKind := fs.String("store", globals.StoreKind, "store backend: memory|sqlite|postgres|bolt")
+	dbPath := fs.String("db-path", globals.DBPath, "sqlite or bolt database path, or postgres connection string")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *runID == "" {
+		return errors.New("resume requires --run-id")
+	}
+
+	client, err := protoapi.New(protoapi.Options{
+		StoreKind:     *storeKind,
+		DBPath:        *dbPath,
+		BenchmarksDir: benchmarksDir,
+		ExportsDir:    exportsDir,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	summary, err := client.Resume(ctx, protoapi.ResumeRequest{RunID: *runID})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("resume completed run_id=%s\n", summary.RunID)
+	for i, best := range summary.BestByGeneration {
+		fmt.Printf("generation=%d best_fitness=%.6f\n", i+1, best)
+	}
+	fmt.Printf("final_best_fitness=%.6f\n", summary.FinalBestFitness)
+	fmt.Printf("artifacts_dir=%s\n", filepath.Clean(summary.ArtifactsDir))
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"protogonos/internal/stats"
+)
+
+func runVerifyArtifacts(_ context.Context, args []string) error {
+	fs := newFlagSet("verify-artifacts")
+	runID := fs.String("run-id", "", "run id")
+	latest := fs.Bool("latest", false, "verify the most recent run from run index")
+	dir := fs.String("dir", benchmarksDir, "directory holding the run artifact directories (e.g. an export directory)")
+	jsonOut := fs.Bool("json", false, "emit the verification as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *runID != "" && *latest {
+		return errors.New("use either --run-id or --latest, not both")
+	}
+	if *runID == "" && !*latest {
+		return errors.New("verify-artifacts requires --run-id or --latest")
+	}
+	if *latest {
+		entries, err := stats.ListRunIndex(benchmarksDir)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return errors.New("no runs available to verify")
+		}
+		*runID = entries[0].RunID
+	}
+
+	verification, err := stats.VerifyRunArtifacts(*dir, *runID)
+	if err != nil {
+		return err
+	}
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(verification); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("verify_artifacts run_id=%s checked=%d problems=%d unlisted=%d\n", verification.RunID, verification.Checked, len(verification.Problems), len(verification.Unlisted))
+		for _, problem := range verification.Problems {
+			if problem.Detail != "" {
+				fmt.Printf("problem file=%s kind=%s detail=%q\n", problem.Name, problem.Problem, problem.Detail)
+			} else {
+				fmt.Printf("problem file=%s kind=%s\n", problem.Name, problem.Problem)
+			}
+		}
+		for _, name := range verification.Unlisted {
+			fmt.Printf("unlisted file=%s\n", name)
+		}
+	}
+	if !verification.OK() {
+		return fmt.Errorf("run %s failed artifact verification: %d problem(s)", verification.RunID, len(verification.Problems))
+	}
+	return nil
+}
@@ -62,6 +62,8 @@ type Cortex struct {
 	outputNeuronIDs []string
 	substrate       substrate.Runtime
 	nnState         *nn.ForwardState
+	guard           *nn.ForwardGuard
+	weightLimit     float64
 	mu              sync.Mutex
 	status          CortexStatus
 	weightBackup    *model.Genome
@@ -152,6 +154,51 @@ func (c *Cortex) RegisteredActuator(id string) (protoio.Actuator, bool) {
 	return nil, false
 }
 
+// SetNumericGuard enables evaluator guards: NaN/Inf neuron values become zero,
+// aggregated neuron input is clamped to activationLimit and synapse weights to
+// weightLimit (a non-positive limit disables that clamp). Weight clamps are
+// applied to the cortex's own genome copy and re-applied after plasticity.
+func (c *Cortex) SetNumericGuard(activationLimit, weightLimit float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.guard = &nn.ForwardGuard{ActivationLimit: activationLimit}
+	c.weightLimit = weightLimit
+	c.genome = genotype.CloneGenome(c.genome)
+	c.clampWeights()
+	c.nnState.WithGuard(c.guard)
+}
+
+// ClampCounts returns the guard interventions made since SetNumericGuard.
+func (c *Cortex) ClampCounts() nn.ClampCounts {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.guard == nil {
+		return nn.ClampCounts{}
+	}
+	return c.guard.Counts
+}
+
+func (c *Cortex) newForwardState() *nn.ForwardState {
+	return nn.NewForwardState().WithGuard(c.guard)
+}
+
+func (c *Cortex) clampWeights() {
+	if c.guard == nil || c.weightLimit <= 0 {
+		return
+	}
+	for i := range c.genome.Synapses {
+		weight := c.genome.Synapses[i].Weight
+		if math.Abs(weight) <= c.weightLimit && !math.IsNaN(weight) {
+			continue
+		}
+		if math.IsNaN(weight) {
+			weight = 0
+		}
+		c.genome.Synapses[i].Weight = math.Max(-c.weightLimit, math.Min(c.weightLimit, weight))
+		c.guard.Counts.Weight++
+	}
+}
+
 func (c *Cortex) Status() CortexStatus {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -164,7 +211,7 @@ func (c *Cortex) Reactivate() error {
 	if c.status == CortexStatusTerminated {
 		return ErrCortexTerminated
 	}
-	c.nnState = nn.NewForwardState()
+	c.nnState = c.newForwardState()
 	if managed, ok := c.substrate.(substrate.StatefulRuntime); ok {
 		managed.Reset()
 	}
@@ -204,7 +251,8 @@ func (c *Cortex) ApplyGenome(genome model.Genome) error {
 		return ErrCortexTerminated
 	}
 	c.genome = genotype.CloneGenome(genome)
-	c.nnState = nn.NewForwardState()
+	c.clampWeights()
+	c.nnState = c.newForwardState()
 	if managed, ok := c.substrate.(substrate.StatefulRuntime); ok {
 		managed.Reset()
 	}
@@ -223,7 +271,7 @@ func (c *Cortex) RestoreWeights() error {
 		}
 	}
 	c.genome = genotype.CloneGenome(*c.weightBackup)
-	c.nnState = nn.NewForwardState()
+	c.nnState = c.newForwardState()
 	return nil
 }
 
@@ -286,6 +334,50 @@ func (c *Cortex) RunStep(ctx context.Context, inputs []float64) ([]float64, erro
 	return c.execute(ctx, inputs)
 }
 
+// BatchEvaluable reports whether RunBatch can score independent input rows
+// with the same results as repeated RunStep calls.
+func (c *Cortex) BatchEvaluable() bool {
+	if c.substrate != nil || c.guard != nil {
+		return false
+	}
+	if len(c.genome.SensorNeuronLinks) > 0 || len(c.genome.NeuronActuatorLinks) > 0 {
+		return false
+	}
+	return nn.BatchEvaluable(c.genome)
+}
+
+// RunBatch evaluates independent input rows in one vectorized pass. Actuators
+// are not dispatched; each returned row is what the actuators would have
+// received, including any actuator tunable offsets. Neuron columns are reused
+// from an nn.BatchCache attached to ctx.
+func (c *Cortex) RunBatch(ctx context.Context, rows [][]float64) ([][]float64, error) {
+	if err := c.ensureExecutable(ctx); err != nil {
+		return nil, err
+	}
+	if !c.BatchEvaluable() {
+		return nil, nn.ErrBatchUnsupported
+	}
+	outputs, err := nn.ForwardBatchCached(c.genome, c.inputNeuronIDs, rows, c.outputNeuronIDs, nn.BatchCacheFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if len(c.genome.ActuatorIDs) == 0 || len(c.genome.ActuatorTunables) == 0 {
+		return outputs, nil
+	}
+	for s, row := range outputs {
+		chunks, err := splitOutputsForActuators(row, len(c.genome.ActuatorIDs))
+		if err != nil {
+			return nil, err
+		}
+		adjusted := make([]float64, 0, len(row))
+		for i, actuatorID := range c.genome.ActuatorIDs {
+			adjusted = append(adjusted, applyActuatorOffset(chunks[i], c.genome.ActuatorTunables[actuatorID])...)
+		}
+		outputs[s] = adjusted
+	}
+	return outputs, nil
+}
+
 func (c *Cortex) RunUntilEvaluationComplete(ctx context.Context, maxCycles int) (EvaluationReport, error) {
 	report := EvaluationReport{}
 	if maxCycles <= 0 {
@@ -358,6 +450,7 @@ func (c *Cortex) executeInputMap(ctx context.Context, inputByNeuron map[string]f
 		if err := nn.ApplyPlasticity(&c.genome, values, *c.genome.Plasticity); err != nil {
 			return nil, err
 		}
+		c.clampWeights()
 	}
 
 	outputs := make([]float64, len(c.outputNeuronIDs))
@@ -1166,3 +1166,40 @@ func TestCortexPerturbWeightsNoSynapses(t *testing.T) {
 		t.Fatalf("expected ErrNoSynapses, got %v", err)
 	}
 }
+
+func TestCortexNumericGuardClampsWeightsOnOwnCopy(t *testing.T) {
+	genome := model.Genome{
+		ID: "g",
+		Neurons: []model.Neuron{
+			{ID: "i", Activation: "identity"},
+			{ID: "o", Activation: "identity"},
+		},
+		Synapses: []model.Synapse{
+			{ID: "s", From: "i", To: "o", Weight: 50, Enabled: true},
+		},
+	}
+	cortex, err := NewCortex("g", genome, nil, nil, []string{"i"}, []string{"o"}, nil)
+	if err != nil {
+		t.Fatalf("new cortex: %v", err)
+	}
+	cortex.SetNumericGuard(0, 2)
+	if cortex.BatchEvaluable() {
+		t.Fatal("expected guarded cortex to opt out of batch evaluation")
+	}
+	if got := cortex.SnapshotGenome().Synapses[0].Weight; got != 2 {
+		t.Fatalf("expected cortex weight clamped to 2, got %f", got)
+	}
+	if genome.Synapses[0].Weight != 50 {
+		t.Fatalf("expected caller genome to keep its weight, got %f", genome.Synapses[0].Weight)
+	}
+	out, err := cortex.RunStep(context.Background(), []float64{0.25})
+	if err != nil {
+		t.Fatalf("run step: %v", err)
+	}
+	if out[0] != 0.5 {
+		t.Fatalf("expected output from clamped weight, got %f", out[0])
+	}
+	if counts := cortex.ClampCounts(); counts.Weight != 1 || counts.Total() != 1 {
+		t.Fatalf("unexpected clamp counts: %+v", counts)
+	}
+}
@@ -0,0 +1,117 @@
+// Package baseline holds the control optimizers neuroevolution runs are
+// compared against: OpenAI-ES on a fixed topology, random search and a
+// hill climber. Each evaluates Population candidates per iteration, so a
+// baseline with a run's population and generation count spends the same
+// evaluation budget.
+package baseline
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"protogonos/internal/model"
+)
+
+// Evaluator scores one candidate genome; higher is better. It is called
+// from up to Workers goroutines at once.
+type Evaluator func(ctx context.Context, genome model.Genome) (float64, error)
+
+// Candidate is one evaluated sample.
+type Candidate struct {
+	Genome  model.Genome
+	Fitness float64
+}
+
+// Iteration summarizes the candidates evaluated in one iteration.
+type Iteration struct {
+	Iteration   int
+	BestFitness float64
+	MeanFitness float64
+	MinFitness  float64
+	// Evaluations counts the evaluations of the run so far.
+	Evaluations int
+	// Seconds is the wall-clock time the iteration took.
+	Seconds float64
+}
+
+// Result is the outcome of an optimizer. Best is the best candidate
+// evaluated and Final the candidates of the last iteration. ES also sets
+// Mean, the genome at the final search distribution mean (not evaluated),
+// and Parameters, the number of optimized values.
+type Result struct {
+	Best        model.Genome
+	BestFitness float64
+	Final       []Candidate
+	Mean        model.Genome
+	Iterations  []Iteration
+	Evaluations int
+	Parameters  int
+}
+
+// record adds an iteration's evaluated candidates to r.
+func (r *Result) record(iteration int, candidates []model.Genome, fitness []float64) {
+	r.Evaluations += len(candidates)
+	summary := Iteration{Iteration: iteration, BestFitness: math.Inf(-1), MinFitness: math.Inf(1), Evaluations: r.Evaluations}
+	r.Final = make([]Candidate, 0, len(candidates))
+	for j, f := range fitness {
+		r.Final = append(r.Final, Candidate{Genome: candidates[j], Fitness: f})
+		summary.MeanFitness += f / float64(len(fitness))
+		summary.MinFitness = math.Min(summary.MinFitness, f)
+		summary.BestFitness = math.Max(summary.BestFitness, f)
+		if (len(r.Iterations) == 0 && j == 0) || f > r.BestFitness {
+			r.BestFitness = f
+			r.Best = candidates[j]
+		}
+	}
+	r.Iterations = append(r.Iterations, summary)
+}
+
+// evaluateAll scores candidates over workers goroutines, keeping their
+// order. The first error cancels the remaining evaluations.
+func evaluateAll(ctx context.Context, workers int, candidates []model.Genome, evaluate Evaluator) ([]float64, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	fitness := make([]float64, len(candidates))
+	jobs := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	for w := 0; w < min(max(workers, 1), len(candidates)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				f, err := evaluate(ctx, candidates[j])
+				if err != nil {
+					once.Do(func() {
+						firstErr = fmt.Errorf("evaluate %s: %w", candidates[j].ID, err)
+						cancel()
+					})
+					continue
+				}
+				fitness[j] = f
+			}
+		}()
+	}
+feed:
+	for j := range candidates {
+		select {
+		case jobs <- j:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return fitness, nil
+}
@@ -0,0 +1,213 @@
+package baseline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"protogonos/internal/genotype"
+	"protogonos/internal/model"
+)
+
+const (
+	// DefaultESSigma is the noise standard deviation when ESConfig.Sigma is
+	// unset.
+	DefaultESSigma = 0.1
+	// DefaultESLearningRate is the Adam step size when
+	// ESConfig.LearningRate is unset.
+	DefaultESLearningRate = 0.03
+
+	adamBeta1   = 0.9
+	adamBeta2   = 0.999
+	adamEpsilon = 1e-8
+)
+
+// ESConfig sizes an OpenAI-ES optimization. Population candidates are
+// evaluated per iteration, as antithetic pairs θ+σε and θ-σε, so Population
+// must be even and the whole run costs Population*Iterations evaluations.
+type ESConfig struct {
+	Population   int
+	Iterations   int
+	Sigma        float64
+	LearningRate float64
+	// WeightDecay shrinks the parameters towards zero every step.
+	WeightDecay float64
+	Workers     int
+	Seed        int64
+}
+
+// WithDefaults fills the unset Sigma, LearningRate and Workers.
+func (c ESConfig) WithDefaults() ESConfig {
+	if c.Sigma == 0 {
+		c.Sigma = DefaultESSigma
+	}
+	if c.LearningRate == 0 {
+		c.LearningRate = DefaultESLearningRate
+	}
+	if c.Workers <= 0 {
+		c.Workers = 1
+	}
+	return c
+}
+
+// ES optimizes the weights of seed's fixed topology with antithetic
+// OpenAI-ES search gradients, ranked fitness shaping and Adam. The optimized
+// parameters are the weights of enabled synapses and the biases of neurons
+// with an enabled incoming synapse; frozen synapses and neurons are left as
+// they are, as is the topology.
+func ES(ctx context.Context, cfg ESConfig, seed model.Genome, evaluate Evaluator) (Result, error) {
+	if evaluate == nil {
+		return Result{}, errors.New("es evaluator is required")
+	}
+	if cfg.Population < 2 || cfg.Population%2 != 0 {
+		return Result{}, fmt.Errorf("es population must be an even number >= 2, got %d", cfg.Population)
+	}
+	if cfg.Iterations <= 0 {
+		return Result{}, errors.New("es iterations must be > 0")
+	}
+	if cfg.Sigma < 0 || cfg.LearningRate < 0 || cfg.WeightDecay < 0 {
+		return Result{}, errors.New("es sigma, learning rate and weight decay must be >= 0")
+	}
+	cfg = cfg.WithDefaults()
+
+	params := newParameters(seed)
+	if len(params.refs) == 0 {
+		return Result{}, fmt.Errorf("genome %s has no weights to optimize", seed.ID)
+	}
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	theta := params.values(seed)
+	dims := len(theta)
+	first := make([]float64, dims)
+	second := make([]float64, dims)
+	pairs := cfg.Population / 2
+
+	result := Result{BestFitness: math.Inf(-1), Parameters: dims}
+	for iteration := 1; iteration <= cfg.Iterations; iteration++ {
+		started := time.Now()
+		noise := make([][]float64, pairs)
+		for k := range noise {
+			noise[k] = make([]float64, dims)
+			for d := range noise[k] {
+				noise[k][d] = rng.NormFloat64()
+			}
+		}
+		candidates := make([]model.Genome, cfg.Population)
+		for j := range candidates {
+			sign := 1.0
+			if j%2 == 1 {
+				sign = -1
+			}
+			point := make([]float64, dims)
+			for d := range point {
+				point[d] = theta[d] + sign*cfg.Sigma*noise[j/2][d]
+			}
+			candidates[j] = params.genome(seed, point, fmt.Sprintf("%s-es-%d-%d", seed.ID, iteration, j))
+		}
+		fitness, err := evaluateAll(ctx, cfg.Workers, candidates, evaluate)
+		if err != nil {
+			return Result{}, err
+		}
+		result.record(iteration, candidates, fitness)
+
+		ranks := centeredRanks(fitness)
+		for d := range theta {
+			gradient := 0.0
+			for k := 0; k < pairs; k++ {
+				gradient += (ranks[2*k] - ranks[2*k+1]) * noise[k][d]
+			}
+			gradient = gradient/(float64(cfg.Population)*cfg.Sigma) - cfg.WeightDecay*theta[d]
+			first[d] = adamBeta1*first[d] + (1-adamBeta1)*gradient
+			second[d] = adamBeta2*second[d] + (1-adamBeta2)*gradient*gradient
+			firstHat := first[d] / (1 - math.Pow(adamBeta1, float64(iteration)))
+			secondHat := second[d] / (1 - math.Pow(adamBeta2, float64(iteration)))
+			theta[d] += cfg.LearningRate * firstHat / (math.Sqrt(secondHat) + adamEpsilon)
+		}
+		result.Iterations[len(result.Iterations)-1].Seconds = time.Since(started).Seconds()
+	}
+	result.Mean = params.genome(seed, theta, seed.ID+"-es-mean")
+	return result, nil
+}
+
+// centeredRanks maps fitness values to their ranks scaled into
+// [-0.5, 0.5], which makes the update invariant to fitness scale. Ties keep
+// their sampling order.
+func centeredRanks(fitness []float64) []float64 {
+	order := make([]int, len(fitness))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return fitness[order[a]] < fitness[order[b]] })
+	ranks := make([]float64, len(fitness))
+	if len(fitness) < 2 {
+		return ranks
+	}
+	for rank, i := range order {
+		ranks[i] = float64(rank)/float64(len(fitness)-1) - 0.5
+	}
+	return ranks
+}
+
+// parameter locates one optimized value: a synapse weight or, with bias
+// set, a neuron bias.
+type parameter struct {
+	index int
+	bias  bool
+}
+
+type parameters struct {
+	refs []parameter
+}
+
+func newParameters(genome model.Genome) parameters {
+	frozen := make(map[string]bool, len(genome.Neurons))
+	for _, neuron := range genome.Neurons {
+		frozen[neuron.ID] = neuron.Frozen
+	}
+	var p parameters
+	fed := make(map[string]bool, len(genome.Neurons))
+	for i, synapse := range genome.Synapses {
+		if !synapse.Enabled {
+			continue
+		}
+		fed[synapse.To] = true
+		if synapse.Frozen || frozen[synapse.To] {
+			continue
+		}
+		p.refs = append(p.refs, parameter{index: i})
+	}
+	for i, neuron := range genome.Neurons {
+		if fed[neuron.ID] && !neuron.Frozen {
+			p.refs = append(p.refs, parameter{index: i, bias: true})
+		}
+	}
+	return p
+}
+
+func (p parameters) values(genome model.Genome) []float64 {
+	out := make([]float64, len(p.refs))
+	for i, ref := range p.refs {
+		if ref.bias {
+			out[i] = genome.Neurons[ref.index].Bias
+		} else {
+			out[i] = genome.Synapses[ref.index].Weight
+		}
+	}
+	return out
+}
+
+func (p parameters) genome(seed model.Genome, values []float64, id string) model.Genome {
+	out := genotype.CloneGenome(seed)
+	out.ID = id
+	for i, ref := range p.refs {
+		if ref.bias {
+			out.Neurons[ref.index].Bias = values[i]
+		} else {
+			out.Synapses[ref.index].Weight = values[i]
+		}
+	}
+	return out
+}
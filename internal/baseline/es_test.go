@@ -0,0 +1,108 @@
+package baseline
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func esTestGenome() model.Genome {
+	return model.Genome{
+		ID: "seed",
+		Neurons: []model.Neuron{
+			{ID: "i", Activation: "identity"},
+			{ID: "h", Activation: "tanh"},
+			{ID: "o", Activation: "identity", Frozen: true},
+		},
+		Synapses: []model.Synapse{
+			{ID: "s1", From: "i", To: "h", Weight: 0, Enabled: true},
+			{ID: "s2", From: "i", To: "h", Weight: 0, Enabled: false},
+			{ID: "s3", From: "h", To: "o", Weight: 0.5, Enabled: true},
+		},
+	}
+}
+
+// distance scores genomes by how close s1 and the bias of h are to 1 and -1.
+func distance(_ context.Context, genome model.Genome) (float64, error) {
+	w, b := genome.Synapses[0].Weight, genome.Neurons[1].Bias
+	return -((w-1)*(w-1) + (b+1)*(b+1)), nil
+}
+
+func TestESClimbsTowardsTheOptimumWithinTheBudget(t *testing.T) {
+	var calls atomic.Int64
+	evaluate := func(ctx context.Context, genome model.Genome) (float64, error) {
+		calls.Add(1)
+		return distance(ctx, genome)
+	}
+	seed := esTestGenome()
+	result, err := ES(context.Background(), ESConfig{Population: 20, Iterations: 60, LearningRate: 0.05, Workers: 4, Seed: 1}, seed, evaluate)
+	if err != nil {
+		t.Fatalf("optimize: %v", err)
+	}
+	if result.Evaluations != 20*60 || calls.Load() != 20*60 {
+		t.Fatalf("expected exactly population*iterations evaluations, got %d (%d calls)", result.Evaluations, calls.Load())
+	}
+	if result.Parameters != 2 || len(result.Iterations) != 60 {
+		t.Fatalf("expected 2 parameters over 60 iterations, got %d over %d", result.Parameters, len(result.Iterations))
+	}
+	if mean, _ := distance(context.Background(), result.Mean); mean < -0.01 {
+		t.Fatalf("expected the mean to approach the optimum, got fitness %f", mean)
+	}
+	if result.BestFitness < result.Iterations[0].BestFitness {
+		t.Fatalf("expected the best fitness %f to be at least the first iteration's %f", result.BestFitness, result.Iterations[0].BestFitness)
+	}
+	if result.Best.Synapses[1].Weight != 0 || result.Best.Synapses[2].Weight != 0.5 || result.Best.Neurons[2].Bias != 0 {
+		t.Fatalf("expected disabled and frozen values to be left alone, got %+v", result.Best)
+	}
+	if !reflect.DeepEqual(seed, esTestGenome()) {
+		t.Fatal("expected the seed genome to be left unchanged")
+	}
+}
+
+func TestESIsIndependentOfTheWorkerCount(t *testing.T) {
+	run := func(workers int) Result {
+		result, err := ES(context.Background(), ESConfig{Population: 6, Iterations: 5, Workers: workers, Seed: 7}, esTestGenome(), distance)
+		if err != nil {
+			t.Fatalf("optimize with %d workers: %v", workers, err)
+		}
+		for i := range result.Iterations {
+			result.Iterations[i].Seconds = 0
+		}
+		return result
+	}
+	if one, many := run(1), run(5); !reflect.DeepEqual(one, many) {
+		t.Fatal("expected the same result with one and five workers")
+	}
+}
+
+func TestESRejectsInvalidConfigsAndStopsOnErrors(t *testing.T) {
+	ctx := context.Background()
+	if _, err := ES(ctx, ESConfig{Population: 5, Iterations: 1}, esTestGenome(), distance); err == nil {
+		t.Fatal("expected an odd population to be rejected")
+	}
+	if _, err := ES(ctx, ESConfig{Population: 4, Iterations: 1}, model.Genome{ID: "empty"}, distance); err == nil {
+		t.Fatal("expected a genome without weights to be rejected")
+	}
+	failure := errors.New("scape failed")
+	_, err := ES(ctx, ESConfig{Population: 4, Iterations: 3, Workers: 2}, esTestGenome(), func(context.Context, model.Genome) (float64, error) {
+		return 0, failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("expected the evaluation error, got %v", err)
+	}
+}
+
+func TestCenteredRanksSpanHalfUnitInterval(t *testing.T) {
+	got := centeredRanks([]float64{3, -1, 10, 0})
+	want := []float64{1.0 / 6, -0.5, 0.5, -1.0 / 6}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-12 {
+			t.Fatalf("expected ranks %v, got %v", want, got)
+		}
+	}
+}
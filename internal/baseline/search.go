@@ -0,0 +1,117 @@
+package baseline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"protogonos/internal/model"
+)
+
+// SearchConfig sizes random search and hill climbing: Generations
+// iterations of Population candidates each.
+type SearchConfig struct {
+	Population  int
+	Generations int
+	Workers     int
+}
+
+func (c SearchConfig) validate() error {
+	if c.Population <= 0 {
+		return errors.New("baseline population must be > 0")
+	}
+	if c.Generations <= 0 {
+		return errors.New("baseline generations must be > 0")
+	}
+	return nil
+}
+
+// Sampler returns the Population candidates of one random search
+// generation, counted from 1.
+type Sampler func(generation int) ([]model.Genome, error)
+
+// Mutator returns a mutated copy of parent named id. It is called from one
+// goroutine at a time.
+type Mutator func(ctx context.Context, parent model.Genome, id string) (model.Genome, error)
+
+// RandomSearch evaluates an independent sample of genomes every generation
+// and keeps the best one seen.
+func RandomSearch(ctx context.Context, cfg SearchConfig, sample Sampler, evaluate Evaluator) (Result, error) {
+	if sample == nil || evaluate == nil {
+		return Result{}, errors.New("random search requires a sampler and an evaluator")
+	}
+	if err := cfg.validate(); err != nil {
+		return Result{}, err
+	}
+	result := Result{BestFitness: math.Inf(-1)}
+	for generation := 1; generation <= cfg.Generations; generation++ {
+		started := time.Now()
+		candidates, err := sample(generation)
+		if err != nil {
+			return Result{}, fmt.Errorf("sample generation %d: %w", generation, err)
+		}
+		if len(candidates) != cfg.Population {
+			return Result{}, fmt.Errorf("sample generation %d: expected %d genomes, got %d", generation, cfg.Population, len(candidates))
+		}
+		fitness, err := evaluateAll(ctx, cfg.Workers, candidates, evaluate)
+		if err != nil {
+			return Result{}, err
+		}
+		result.record(generation, candidates, fitness)
+		result.Iterations[len(result.Iterations)-1].Seconds = time.Since(started).Seconds()
+	}
+	return result, nil
+}
+
+// HillClimb evaluates initial as its first generation and climbs from the
+// best of it: every later generation evaluates Population mutants of the
+// incumbent, and the best mutant replaces it when it scores at least as
+// well, so the climber drifts across plateaus.
+func HillClimb(ctx context.Context, cfg SearchConfig, initial []model.Genome, mutate Mutator, evaluate Evaluator) (Result, error) {
+	if mutate == nil || evaluate == nil {
+		return Result{}, errors.New("hill climbing requires a mutator and an evaluator")
+	}
+	if err := cfg.validate(); err != nil {
+		return Result{}, err
+	}
+	if len(initial) == 0 {
+		return Result{}, errors.New("hill climbing requires an initial population")
+	}
+	result := Result{BestFitness: math.Inf(-1)}
+	var (
+		incumbent        model.Genome
+		incumbentFitness float64
+		root             string
+	)
+	for generation := 1; generation <= cfg.Generations; generation++ {
+		started := time.Now()
+		candidates := initial
+		if generation > 1 {
+			candidates = make([]model.Genome, cfg.Population)
+			for j := range candidates {
+				mutant, err := mutate(ctx, incumbent, fmt.Sprintf("%s-hc%d-i%d", root, generation, j))
+				if err != nil {
+					return Result{}, fmt.Errorf("mutate %s: %w", incumbent.ID, err)
+				}
+				candidates[j] = mutant
+			}
+		}
+		fitness, err := evaluateAll(ctx, cfg.Workers, candidates, evaluate)
+		if err != nil {
+			return Result{}, err
+		}
+		for j, f := range fitness {
+			if (generation == 1 && j == 0) || f >= incumbentFitness {
+				incumbent, incumbentFitness = candidates[j], f
+			}
+		}
+		if generation == 1 {
+			root = incumbent.ID
+		}
+		result.record(generation, candidates, fitness)
+		result.Iterations[len(result.Iterations)-1].Seconds = time.Since(started).Seconds()
+	}
+	return result, nil
+}
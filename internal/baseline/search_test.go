@@ -0,0 +1,89 @@
+package baseline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+// weighted scores a genome by its first synapse weight.
+func weighted(_ context.Context, genome model.Genome) (float64, error) {
+	return genome.Synapses[0].Weight, nil
+}
+
+func weightGenome(id string, weight float64) model.Genome {
+	return model.Genome{ID: id, Synapses: []model.Synapse{{ID: "s", From: "i", To: "o", Weight: weight, Enabled: true}}}
+}
+
+func TestRandomSearchSamplesEveryGenerationAndKeepsTheBest(t *testing.T) {
+	var sampled []int
+	sample := func(generation int) ([]model.Genome, error) {
+		sampled = append(sampled, generation)
+		// Generation 2 samples the best genome; later ones are worse.
+		peak := 1.0
+		if generation == 2 {
+			peak = 5
+		}
+		return []model.Genome{
+			weightGenome(fmt.Sprintf("g%d-a", generation), peak),
+			weightGenome(fmt.Sprintf("g%d-b", generation), -1),
+			weightGenome(fmt.Sprintf("g%d-c", generation), 0),
+		}, nil
+	}
+	result, err := RandomSearch(context.Background(), SearchConfig{Population: 3, Generations: 3, Workers: 2}, sample, weighted)
+	if err != nil {
+		t.Fatalf("random search: %v", err)
+	}
+	if fmt.Sprint(sampled) != "[1 2 3]" || result.Evaluations != 9 {
+		t.Fatalf("expected three samples of three genomes, got %v and %d evaluations", sampled, result.Evaluations)
+	}
+	if result.Best.ID != "g2-a" || result.BestFitness != 5 {
+		t.Fatalf("expected the generation 2 peak to be kept, got %s=%f", result.Best.ID, result.BestFitness)
+	}
+	if len(result.Final) != 3 || result.Final[0].Genome.ID != "g3-a" {
+		t.Fatalf("expected the last generation's candidates as final, got %+v", result.Final)
+	}
+	if it := result.Iterations[0]; it.BestFitness != 1 || it.MinFitness != -1 || it.MeanFitness != 0 || it.Evaluations != 3 {
+		t.Fatalf("unexpected first generation summary %+v", it)
+	}
+
+	short := func(int) ([]model.Genome, error) { return []model.Genome{weightGenome("x", 0)}, nil }
+	if _, err := RandomSearch(context.Background(), SearchConfig{Population: 3, Generations: 1}, short, weighted); err == nil {
+		t.Fatal("expected a sample of the wrong size to be rejected")
+	}
+}
+
+func TestHillClimbMutatesTheIncumbentAndAcceptsOnlyImprovementsOrTies(t *testing.T) {
+	var parents []string
+	step := 0
+	mutate := func(_ context.Context, parent model.Genome, id string) (model.Genome, error) {
+		parents = append(parents, parent.ID)
+		step++
+		// Every other mutant loses half a unit; the rest gain one.
+		delta := 1.0
+		if step%2 == 0 {
+			delta = -0.5
+		}
+		return weightGenome(id, parent.Synapses[0].Weight+delta), nil
+	}
+	initial := []model.Genome{weightGenome("seed-a", 0), weightGenome("seed-b", 2)}
+	result, err := HillClimb(context.Background(), SearchConfig{Population: 2, Generations: 3, Workers: 2}, initial, mutate, weighted)
+	if err != nil {
+		t.Fatalf("hill climb: %v", err)
+	}
+	if result.Evaluations != 6 {
+		t.Fatalf("expected 6 evaluations, got %d", result.Evaluations)
+	}
+	if want := "seed-b,seed-b,seed-b-hc2-i0,seed-b-hc2-i0"; strings.Join(parents, ",") != want {
+		t.Fatalf("expected mutants of the incumbent, got parents %v", parents)
+	}
+	if result.Best.ID != "seed-b-hc3-i0" || result.BestFitness != 4 {
+		t.Fatalf("expected the climber to reach 4, got %s=%f", result.Best.ID, result.BestFitness)
+	}
+	if _, err := HillClimb(context.Background(), SearchConfig{Population: 2, Generations: 1}, nil, mutate, weighted); err == nil {
+		t.Fatal("expected an empty initial population to be rejected")
+	}
+}
@@ -0,0 +1,171 @@
+// Package encoding maps genomes to the networks a cortex runs. Each
+// Encoding is one genotype-phenotype mapping; runs select one by name, so
+// new indirect encodings register here without changes to the evolution
+// engine.
+package encoding
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+
+	"protogonos/internal/model"
+	"protogonos/internal/substrate"
+)
+
+const (
+	// Auto expresses a genome through its substrate when it carries a
+	// substrate configuration and directly otherwise.
+	Auto = "auto"
+	// Direct runs the genome's neurons and synapses as they are.
+	Direct = "direct"
+	// Substrate always runs the genome as a CPPN driving a substrate,
+	// using the default substrate configuration for genomes without one.
+	Substrate = "substrate"
+	// Grammar develops the network from the genome's L-system; the
+	// genome's own neurons and synapses only supply the input and output
+	// neurons.
+	Grammar = "grammar"
+)
+
+// Phenotype is the network expressed from one genome.
+type Phenotype struct {
+	// Network holds the neurons and synapses the cortex runs.
+	Network model.Genome
+	// Substrate is the runtime that turns Network's outputs into the
+	// agent's outputs; nil when Network drives the actuators itself.
+	Substrate substrate.Runtime
+}
+
+// Encoding is a genotype-phenotype mapping.
+type Encoding interface {
+	Name() string
+	// Express builds the phenotype of genome. inputNeuronIDs and
+	// outputNeuronIDs are the morphology's input and output neurons.
+	Express(genome model.Genome, inputNeuronIDs, outputNeuronIDs []string) (Phenotype, error)
+	// Direct reports whether expressed networks keep the genome's own
+	// neuron and synapse IDs, so weights tuned on a running network can be
+	// written back into the genome.
+	Direct() bool
+}
+
+// Initializer is implemented by encodings whose genes seed populations lack.
+// Initialize returns genome with those genes added, drawing random values
+// from rng, and leaves genomes that already carry them as they are.
+type Initializer interface {
+	Initialize(genome model.Genome, inputNeuronIDs, outputNeuronIDs []string, rng *rand.Rand) model.Genome
+}
+
+var registry = struct {
+	mu     sync.RWMutex
+	byName map[string]Encoding
+}{byName: map[string]Encoding{}}
+
+func init() {
+	for _, e := range []Encoding{autoEncoding{}, directEncoding{}, substrateEncoding{}, grammarEncoding{}} {
+		if err := Register(e); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Register makes an encoding selectable by its name.
+func Register(e Encoding) error {
+	if e == nil {
+		return fmt.Errorf("encoding is required")
+	}
+	name := strings.TrimSpace(e.Name())
+	if name == "" {
+		return fmt.Errorf("encoding name is required")
+	}
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if _, exists := registry.byName[name]; exists {
+		return fmt.Errorf("encoding already registered: %s", name)
+	}
+	registry.byName[name] = e
+	return nil
+}
+
+// Lookup returns the encoding registered under name; an empty name selects
+// Auto.
+func Lookup(name string) (Encoding, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = Auto
+	}
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	e, ok := registry.byName[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown encoding: %s (want one of %s)", name, strings.Join(namesLocked(), "|"))
+	}
+	return e, nil
+}
+
+// Names lists the registered encodings in sorted order.
+func Names() []string {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	return namesLocked()
+}
+
+func namesLocked() []string {
+	names := make([]string, 0, len(registry.byName))
+	for name := range registry.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type autoEncoding struct{}
+
+func (autoEncoding) Name() string { return Auto }
+
+func (autoEncoding) Direct() bool { return true }
+
+func (autoEncoding) Express(genome model.Genome, inputNeuronIDs, outputNeuronIDs []string) (Phenotype, error) {
+	if genome.Substrate == nil {
+		return Phenotype{Network: genome}, nil
+	}
+	return substrateEncoding{}.Express(genome, inputNeuronIDs, outputNeuronIDs)
+}
+
+type directEncoding struct{}
+
+func (directEncoding) Name() string { return Direct }
+
+func (directEncoding) Direct() bool { return true }
+
+func (directEncoding) Express(genome model.Genome, _, _ []string) (Phenotype, error) {
+	return Phenotype{Network: genome}, nil
+}
+
+type substrateEncoding struct{}
+
+func (substrateEncoding) Name() string { return Substrate }
+
+func (substrateEncoding) Direct() bool { return true }
+
+func (substrateEncoding) Express(genome model.Genome, _, outputNeuronIDs []string) (Phenotype, error) {
+	rt, err := NewSubstrateRuntime(genome, outputNeuronIDs)
+	if err != nil {
+		return Phenotype{}, err
+	}
+	return Phenotype{Network: genome, Substrate: rt}, nil
+}
+
+// DefaultSubstrateConfig is the substrate a genome without its own
+// configuration is expressed through.
+func DefaultSubstrateConfig() *model.SubstrateConfig {
+	return &model.SubstrateConfig{
+		CPPName:    substrate.DefaultCPPName,
+		CEPName:    substrate.DefaultCEPName,
+		CEPNames:   []string{substrate.DefaultCEPName},
+		Dimensions: []int{1, 1},
+		Parameters: map[string]float64{},
+	}
+}
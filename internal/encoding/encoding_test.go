@@ -0,0 +1,65 @@
+package encoding
+
+import (
+	"strings"
+	"testing"
+
+	"protogonos/internal/model"
+	"protogonos/internal/substrate"
+)
+
+func TestBuiltinEncodingsExpressSubstrateByConfiguration(t *testing.T) {
+	plain := model.Genome{ID: "plain"}
+	configured := model.Genome{
+		ID: "configured",
+		Substrate: &model.SubstrateConfig{
+			CPPName: substrate.DefaultCPPName,
+			CEPName: substrate.DefaultCEPName,
+		},
+	}
+	cases := []struct {
+		name          string
+		genome        model.Genome
+		wantSubstrate bool
+	}{
+		{name: Auto, genome: plain, wantSubstrate: false},
+		{name: Auto, genome: configured, wantSubstrate: true},
+		{name: Direct, genome: configured, wantSubstrate: false},
+		{name: Substrate, genome: plain, wantSubstrate: true},
+	}
+	for _, tc := range cases {
+		e, err := Lookup(tc.name)
+		if err != nil {
+			t.Fatalf("lookup %s: %v", tc.name, err)
+		}
+		phenotype, err := e.Express(tc.genome, []string{"i"}, []string{"o"})
+		if err != nil {
+			t.Fatalf("%s express %s: %v", tc.name, tc.genome.ID, err)
+		}
+		if got := phenotype.Substrate != nil; got != tc.wantSubstrate {
+			t.Fatalf("%s express %s: substrate=%t want %t", tc.name, tc.genome.ID, got, tc.wantSubstrate)
+		}
+		if phenotype.Network.ID != tc.genome.ID {
+			t.Fatalf("%s express %s: unexpected network %s", tc.name, tc.genome.ID, phenotype.Network.ID)
+		}
+	}
+	if plain.Substrate != nil {
+		t.Fatal("expected expression to leave the genome unchanged")
+	}
+}
+
+func TestLookupDefaultsToAutoAndRejectsUnknownNames(t *testing.T) {
+	e, err := Lookup(" ")
+	if err != nil || e.Name() != Auto {
+		t.Fatalf("expected an empty name to select auto, got %v err=%v", e, err)
+	}
+	if _, err := Lookup("DIRECT"); err != nil {
+		t.Fatalf("expected names to be case-insensitive: %v", err)
+	}
+	if _, err := Lookup("morphogen"); err == nil || !strings.Contains(err.Error(), "auto|direct|grammar|substrate") {
+		t.Fatalf("expected unknown encoding error listing the choices, got %v", err)
+	}
+	if err := Register(directEncoding{}); err == nil {
+		t.Fatal("expected duplicate registration to fail")
+	}
+}
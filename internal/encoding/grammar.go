@@ -0,0 +1,204 @@
+package encoding
+
+import (
+	"fmt"
+	"math/rand"
+
+	"protogonos/internal/genotype"
+	"protogonos/internal/model"
+)
+
+// MaxGrammarCells bounds the expanded word of a grammar genome, and so the
+// hidden neurons it develops into; longer words are truncated.
+const MaxGrammarCells = 48
+
+// DefaultGrammarActivation is the activation of cells that name none.
+const DefaultGrammarActivation = "tanh"
+
+type grammarEncoding struct{}
+
+func (grammarEncoding) Name() string { return Grammar }
+
+// Direct is false: tuned network weights belong to developed neurons, not
+// to the genome.
+func (grammarEncoding) Direct() bool { return false }
+
+// Express develops genome's grammar into a layered network. The expanded
+// word's cells become hidden neurons fed by every input neuron and feeding
+// every output neuron, and each cell feeds the one after it. Genomes without
+// a grammar develop DefaultGrammarConfig.
+func (grammarEncoding) Express(genome model.Genome, inputNeuronIDs, outputNeuronIDs []string) (Phenotype, error) {
+	cfg := genome.Grammar
+	if cfg == nil {
+		cfg = DefaultGrammarConfig(len(inputNeuronIDs), len(outputNeuronIDs))
+	}
+	byID := make(map[string]model.Neuron, len(genome.Neurons))
+	for _, neuron := range genome.Neurons {
+		byID[neuron.ID] = neuron
+	}
+	network := genome
+	network.Neurons = make([]model.Neuron, 0, len(inputNeuronIDs)+len(outputNeuronIDs))
+	network.Synapses = nil
+	for _, ids := range [][]string{inputNeuronIDs, outputNeuronIDs} {
+		for _, id := range ids {
+			neuron, ok := byID[id]
+			if !ok {
+				return Phenotype{}, fmt.Errorf("express grammar of genome %s: missing io neuron %s", genome.ID, id)
+			}
+			network.Neurons = append(network.Neurons, neuron)
+		}
+	}
+
+	cells := make(map[string]model.GrammarCell, len(cfg.Cells))
+	for _, cell := range cfg.Cells {
+		if _, exists := cells[cell.Symbol]; !exists {
+			cells[cell.Symbol] = cell
+		}
+	}
+	addSynapse := func(from, to string, weight float64) {
+		network.Synapses = append(network.Synapses, model.Synapse{
+			ID:      fmt.Sprintf("grammar-s%d", len(network.Synapses)),
+			From:    from,
+			To:      to,
+			Weight:  weight,
+			Enabled: true,
+		})
+	}
+	previous := ""
+	var previousCell model.GrammarCell
+	for _, symbol := range ExpandGrammar(cfg) {
+		cell, ok := cells[symbol]
+		if !ok {
+			continue
+		}
+		id := fmt.Sprintf("grammar-h%d", len(network.Neurons)-len(inputNeuronIDs)-len(outputNeuronIDs))
+		activation := cell.Activation
+		if activation == "" {
+			activation = DefaultGrammarActivation
+		}
+		network.Neurons = append(network.Neurons, model.Neuron{ID: id, Activation: activation, Bias: cell.Bias})
+		for i, input := range inputNeuronIDs {
+			if weight := cycledWeight(cell.InputWeights, i); weight != 0 {
+				addSynapse(input, id, weight)
+			}
+		}
+		if previous != "" && previousCell.ChainWeight != 0 {
+			addSynapse(previous, id, previousCell.ChainWeight)
+		}
+		for i, output := range outputNeuronIDs {
+			if weight := cycledWeight(cell.OutputWeights, i); weight != 0 {
+				addSynapse(id, output, weight)
+			}
+		}
+		previous, previousCell = id, cell
+	}
+	return Phenotype{Network: network}, nil
+}
+
+// Initialize gives genomes without a grammar a random one: two symbols, A
+// rewriting to AB and B kept, each cell with uniform weights in [-1,1].
+func (grammarEncoding) Initialize(genome model.Genome, inputNeuronIDs, outputNeuronIDs []string, rng *rand.Rand) model.Genome {
+	if genome.Grammar != nil {
+		return genome
+	}
+	out := genotype.CloneGenome(genome)
+	out.Grammar = &model.GrammarConfig{
+		Axiom:      "A",
+		Iterations: 1,
+		Rules: []model.GrammarRule{
+			{Symbol: "A", Successor: "AB"},
+			{Symbol: "B", Successor: "B"},
+		},
+		Cells: []model.GrammarCell{
+			RandomGrammarCell("A", len(inputNeuronIDs), len(outputNeuronIDs), rng),
+			RandomGrammarCell("B", len(inputNeuronIDs), len(outputNeuronIDs), rng),
+		},
+	}
+	return out
+}
+
+// DefaultGrammarConfig is a single cell wired with unit weights.
+func DefaultGrammarConfig(inputs, outputs int) *model.GrammarConfig {
+	cell := model.GrammarCell{
+		Symbol:        "A",
+		Activation:    DefaultGrammarActivation,
+		InputWeights:  make([]float64, max(inputs, 1)),
+		OutputWeights: make([]float64, max(outputs, 1)),
+	}
+	for i := range cell.InputWeights {
+		cell.InputWeights[i] = 1
+	}
+	for i := range cell.OutputWeights {
+		cell.OutputWeights[i] = 1
+	}
+	return &model.GrammarConfig{Axiom: "A", Cells: []model.GrammarCell{cell}}
+}
+
+// RandomGrammarCell builds a cell for symbol with one weight per input and
+// output neuron, weights and bias drawn uniformly from [-1,1].
+func RandomGrammarCell(symbol string, inputs, outputs int, rng *rand.Rand) model.GrammarCell {
+	cell := model.GrammarCell{
+		Symbol:        symbol,
+		Activation:    DefaultGrammarActivation,
+		Bias:          rng.Float64()*2 - 1,
+		InputWeights:  make([]float64, max(inputs, 1)),
+		OutputWeights: make([]float64, max(outputs, 1)),
+		ChainWeight:   rng.Float64()*2 - 1,
+	}
+	for i := range cell.InputWeights {
+		cell.InputWeights[i] = rng.Float64()*2 - 1
+	}
+	for i := range cell.OutputWeights {
+		cell.OutputWeights[i] = rng.Float64()*2 - 1
+	}
+	return cell
+}
+
+// ExpandGrammar rewrites the axiom Iterations times and returns the word's
+// symbols, at most MaxGrammarCells of them. Each character is one symbol;
+// when a symbol has several rules the first applies.
+func ExpandGrammar(cfg *model.GrammarConfig) []string {
+	if cfg == nil {
+		return nil
+	}
+	successors := make(map[string]string, len(cfg.Rules))
+	for _, rule := range cfg.Rules {
+		if _, exists := successors[rule.Symbol]; !exists {
+			successors[rule.Symbol] = rule.Successor
+		}
+	}
+	word := grammarSymbols(cfg.Axiom)
+	for i := 0; i < cfg.Iterations; i++ {
+		next := make([]string, 0, len(word))
+		for _, symbol := range word {
+			if successor, ok := successors[symbol]; ok {
+				next = append(next, grammarSymbols(successor)...)
+			} else {
+				next = append(next, symbol)
+			}
+			if len(next) >= MaxGrammarCells {
+				break
+			}
+		}
+		word = next
+	}
+	if len(word) > MaxGrammarCells {
+		word = word[:MaxGrammarCells]
+	}
+	return word
+}
+
+func grammarSymbols(word string) []string {
+	symbols := make([]string, 0, len(word))
+	for _, r := range word {
+		symbols = append(symbols, string(r))
+	}
+	return symbols
+}
+
+func cycledWeight(weights []float64, i int) float64 {
+	if len(weights) == 0 {
+		return 0
+	}
+	return weights[i%len(weights)]
+}
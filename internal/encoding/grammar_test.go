@@ -0,0 +1,106 @@
+package encoding
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func TestExpandGrammarRewritesAndCapsTheWord(t *testing.T) {
+	cfg := &model.GrammarConfig{
+		Axiom:      "A",
+		Iterations: 3,
+		Rules: []model.GrammarRule{
+			{Symbol: "A", Successor: "AB"},
+			{Symbol: "B", Successor: "A"},
+			{Symbol: "A", Successor: "C"},
+		},
+	}
+	if got := strings.Join(ExpandGrammar(cfg), ""); got != "ABAAB" {
+		t.Fatalf("expected the first rule per symbol to apply, got %q", got)
+	}
+
+	cfg.Rules = []model.GrammarRule{{Symbol: "A", Successor: "AA"}}
+	cfg.Iterations = 20
+	if got := len(ExpandGrammar(cfg)); got != MaxGrammarCells {
+		t.Fatalf("expected the word to be capped at %d symbols, got %d", MaxGrammarCells, got)
+	}
+}
+
+func TestGrammarEncodingDevelopsCellsIntoHiddenNeurons(t *testing.T) {
+	genome := model.Genome{
+		ID: "g",
+		Neurons: []model.Neuron{
+			{ID: "i1", Activation: "identity"},
+			{ID: "i2", Activation: "identity"},
+			{ID: "o", Activation: "tanh"},
+			{ID: "stale", Activation: "relu"},
+		},
+		Synapses: []model.Synapse{{ID: "s", From: "i1", To: "o", Weight: 1, Enabled: true}},
+		Grammar: &model.GrammarConfig{
+			Axiom:      "A",
+			Iterations: 1,
+			Rules:      []model.GrammarRule{{Symbol: "A", Successor: "ABX"}},
+			Cells: []model.GrammarCell{
+				{Symbol: "A", Bias: 0.5, InputWeights: []float64{1, 0}, OutputWeights: []float64{2}, ChainWeight: 3},
+				{Symbol: "B", Activation: "relu", InputWeights: []float64{4}, OutputWeights: []float64{5}},
+			},
+		},
+	}
+	e, err := Lookup(Grammar)
+	if err != nil {
+		t.Fatalf("lookup grammar: %v", err)
+	}
+	if e.Direct() {
+		t.Fatal("expected the grammar encoding not to be direct")
+	}
+	phenotype, err := e.Express(genome, []string{"i1", "i2"}, []string{"o"})
+	if err != nil {
+		t.Fatalf("express: %v", err)
+	}
+	network := phenotype.Network
+	var ids []string
+	for _, neuron := range network.Neurons {
+		ids = append(ids, neuron.ID+":"+neuron.Activation)
+	}
+	if got := strings.Join(ids, ","); got != "i1:identity,i2:identity,o:tanh,grammar-h0:tanh,grammar-h1:relu" {
+		t.Fatalf("unexpected developed neurons: %s", got)
+	}
+	var links []string
+	for _, synapse := range network.Synapses {
+		links = append(links, synapse.From+">"+synapse.To)
+	}
+	// A feeds from i1 only (its i2 weight is zero), B from both inputs, and
+	// A chains into B; X has no cell and develops nothing.
+	if got := strings.Join(links, ","); got != "i1>grammar-h0,grammar-h0>o,i1>grammar-h1,i2>grammar-h1,grammar-h0>grammar-h1,grammar-h1>o" {
+		t.Fatalf("unexpected developed synapses: %s", got)
+	}
+	if len(genome.Neurons) != 4 || len(genome.Synapses) != 1 {
+		t.Fatal("expected expression to leave the genome unchanged")
+	}
+
+	if _, err := e.Express(genome, []string{"missing"}, []string{"o"}); err == nil {
+		t.Fatal("expected a missing io neuron to fail")
+	}
+}
+
+func TestGrammarEncodingInitializesGenomesWithoutGrammar(t *testing.T) {
+	initializer, ok := Encoding(grammarEncoding{}).(Initializer)
+	if !ok {
+		t.Fatal("expected the grammar encoding to initialize genomes")
+	}
+	rng := rand.New(rand.NewSource(1))
+	genome := initializer.Initialize(model.Genome{ID: "seed"}, []string{"i1", "i2"}, []string{"o"}, rng)
+	if genome.Grammar == nil || len(genome.Grammar.Cells) != 2 {
+		t.Fatalf("expected a two-cell grammar, got %+v", genome.Grammar)
+	}
+	if cell := genome.Grammar.Cells[0]; len(cell.InputWeights) != 2 || len(cell.OutputWeights) != 1 {
+		t.Fatalf("expected one weight per io neuron, got %+v", cell)
+	}
+	again := initializer.Initialize(genome, []string{"i1", "i2"}, []string{"o"}, rng)
+	if again.Grammar != genome.Grammar {
+		t.Fatal("expected genomes with a grammar to be left as they are")
+	}
+}
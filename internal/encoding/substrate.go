@@ -0,0 +1,48 @@
+package encoding
+
+import (
+	"fmt"
+
+	"protogonos/internal/genotype"
+	"protogonos/internal/model"
+	"protogonos/internal/substrate"
+)
+
+// NewSubstrateRuntime builds the substrate runtime configured by genome,
+// reading CEP fan-in from its endpoint links and falling back to
+// outputNeuronIDs. Genomes without a substrate configuration use
+// DefaultSubstrateConfig.
+func NewSubstrateRuntime(genome model.Genome, outputNeuronIDs []string) (substrate.Runtime, error) {
+	if genome.Substrate == nil {
+		genome.Substrate = DefaultSubstrateConfig()
+	}
+	cfg := genome.Substrate
+	faninByCEP := genotype.ResolveSubstrateCEPFaninPIDsByCEP(genome, outputNeuronIDs)
+	faninPIDs := genotype.ResolveSubstrateCEPFaninPIDs(genome, outputNeuronIDs)
+	if flattened := genotype.FlattenSubstrateCEPFaninPIDsByCEP(faninByCEP); len(flattened) > 0 {
+		faninPIDs = flattened
+	}
+	spec := substrate.Spec{
+		CPPName:           cfg.CPPName,
+		CPPIDs:            append([]string(nil), cfg.CPPIDs...),
+		CEPName:           cfg.CEPName,
+		CEPNames:          append([]string(nil), cfg.CEPNames...),
+		CEPIDs:            append([]string(nil), cfg.CEPIDs...),
+		CEPFaninPIDs:      faninPIDs,
+		CEPFaninPIDsByCEP: faninByCEP,
+		Dimensions:        append([]int(nil), cfg.Dimensions...),
+		Parameters:        map[string]float64{},
+	}
+	for k, v := range cfg.Parameters {
+		spec.Parameters[k] = v
+	}
+	weightCount := cfg.WeightCount
+	if weightCount <= 0 {
+		weightCount = len(outputNeuronIDs)
+	}
+	rt, err := substrate.NewSimpleRuntime(spec, weightCount)
+	if err != nil {
+		return nil, fmt.Errorf("build substrate runtime for genome %s: %w", genome.ID, err)
+	}
+	return rt, nil
+}
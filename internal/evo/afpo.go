@@ -0,0 +1,180 @@
+package evo
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"protogonos/internal/genotype"
+	"protogonos/internal/model"
+)
+
+// AFPOSelector implements Age-Fitness Pareto Optimization. Instead of
+// speciation, diversity is maintained by keeping the Pareto front of genome age
+// (generations since the lineage was founded, lower is better) versus fitness,
+// and injecting freshly seeded newcomers with age zero every generation.
+type AFPOSelector struct {
+	TournamentSize int
+	Newcomers      int
+}
+
+func (AFPOSelector) Name() string {
+	return "afpo"
+}
+
+// PickParent runs a fitness-only tournament. The monitor uses the age-aware
+// generation path instead; this keeps the selector usable wherever a plain
+// Selector is expected.
+func (s AFPOSelector) PickParent(rng *rand.Rand, ranked []ScoredGenome, eliteCount int) (model.Genome, error) {
+	return TournamentSelector{TournamentSize: s.tournamentSize()}.PickParent(rng, ranked, eliteCount)
+}
+
+// PickParentByAge samples a tournament without replacement and returns a
+// candidate no other sampled candidate dominates on age and fitness, preferring
+// higher fitness.
+func (s AFPOSelector) PickParentByAge(rng *rand.Rand, ranked []ScoredGenome, ages map[string]int) (model.Genome, error) {
+	if rng == nil {
+		return model.Genome{}, fmt.Errorf("random source is required")
+	}
+	if len(ranked) == 0 {
+		return model.Genome{}, fmt.Errorf("no genomes to select from")
+	}
+	size := s.tournamentSize()
+	if size > len(ranked) {
+		size = len(ranked)
+	}
+	sample := make([]ScoredGenome, 0, size)
+	for _, idx := range rng.Perm(len(ranked))[:size] {
+		sample = append(sample, ranked[idx])
+	}
+	front := AgeFitnessParetoFront(sample, ages)
+	best := front[0]
+	for _, candidate := range front[1:] {
+		if ScoredBefore(candidate, best) {
+			best = candidate
+		}
+	}
+	return best.Genome, nil
+}
+
+func (s AFPOSelector) tournamentSize() int {
+	if s.TournamentSize <= 0 {
+		return 2
+	}
+	return s.TournamentSize
+}
+
+func (s AFPOSelector) newcomers() int {
+	if s.Newcomers <= 0 {
+		return 1
+	}
+	return s.Newcomers
+}
+
+// AgeFitnessParetoFront returns the genomes not dominated by any other genome,
+// where a dominates b when a is no older and no less fit, and strictly better
+// in at least one objective. Input order is preserved.
+func AgeFitnessParetoFront(scored []ScoredGenome, ages map[string]int) []ScoredGenome {
+	front := make([]ScoredGenome, 0, len(scored))
+	for i, candidate := range scored {
+		dominated := false
+		for j, other := range scored {
+			if i != j && ageFitnessDominates(other, candidate, ages) {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			front = append(front, candidate)
+		}
+	}
+	return front
+}
+
+func ageFitnessDominates(a, b ScoredGenome, ages map[string]int) bool {
+	ageA, ageB := ages[a.Genome.ID], ages[b.Genome.ID]
+	if a.Fitness < b.Fitness || ageA > ageB {
+		return false
+	}
+	return a.Fitness > b.Fitness || ageA < ageB
+}
+
+// nextAFPOGeneration carries the age-fitness Pareto front forward (capped at
+// half the population so ties cannot crowd out offspring), adds newcomers, and
+// fills the rest with mutated offspring that inherit their parent's age. Every
+// genome ages by one generation.
+func (m *PopulationMonitor) nextAFPOGeneration(ctx context.Context, selector AFPOSelector, ranked []ScoredGenome, generation int) ([]model.Genome, []LineageRecord, error) {
+	next := make([]model.Genome, 0, m.cfg.PopulationSize)
+	lineage := make([]LineageRecord, 0, m.cfg.PopulationSize)
+	nextAges := make(map[string]int, m.cfg.PopulationSize)
+	nextGeneration := generation + 1
+
+	newcomers := selector.newcomers()
+	if m.cfg.NewcomerFactory == nil {
+		newcomers = 0
+	}
+	if newcomers >= m.cfg.PopulationSize {
+		newcomers = m.cfg.PopulationSize - 1
+	}
+
+	maxSurvivors := m.cfg.PopulationSize / 2
+	if maxSurvivors < 1 {
+		maxSurvivors = 1
+	}
+	front := AgeFitnessParetoFront(ranked, m.ages)
+	for _, survivor := range front {
+		if len(next) >= maxSurvivors || len(next) >= m.cfg.PopulationSize-newcomers {
+			break
+		}
+		clone := genotype.CloneAgent(survivor.Genome, survivor.Genome.ID)
+		sig := ComputeGenomeSignature(clone)
+		next = append(next, clone)
+		nextAges[clone.ID] = m.ages[survivor.Genome.ID] + 1
+		lineage = append(lineage, LineageRecord{
+			GenomeID:    clone.ID,
+			ParentID:    survivor.Genome.ID,
+			Generation:  nextGeneration,
+			Operation:   "afpo_survivor",
+			Fingerprint: sig.Fingerprint,
+			Summary:     sig.Summary,
+		})
+	}
+
+	for i := 0; i < newcomers; i++ {
+		newcomer, err := m.cfg.NewcomerFactory(nextGeneration, i)
+		if err != nil {
+			return nil, nil, fmt.Errorf("afpo newcomer: %w", err)
+		}
+		newcomer = m.innovations.Mark(newcomer)
+		sig := ComputeGenomeSignature(newcomer)
+		next = append(next, newcomer)
+		nextAges[newcomer.ID] = 0
+		lineage = append(lineage, LineageRecord{
+			GenomeID:    newcomer.ID,
+			Generation:  nextGeneration,
+			Operation:   "afpo_newcomer",
+			Fingerprint: sig.Fingerprint,
+			Summary:     sig.Summary,
+		})
+	}
+
+	for len(next) < m.cfg.PopulationSize {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		parent, err := selector.PickParentByAge(m.rng, ranked, m.ages)
+		if err != nil {
+			return nil, nil, err
+		}
+		child, record, err := m.mutateFromParent(ctx, parent, generation, len(next))
+		if err != nil {
+			return nil, nil, err
+		}
+		next = append(next, child)
+		nextAges[child.ID] = m.ages[parent.ID] + 1
+		lineage = append(lineage, record)
+	}
+
+	m.ages = nextAges
+	return next, lineage, nil
+}
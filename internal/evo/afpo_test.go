@@ -0,0 +1,101 @@
+package evo
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func TestAgeFitnessParetoFront(t *testing.T) {
+	scored := []ScoredGenome{
+		{Genome: model.Genome{ID: "old-best"}, Fitness: 3},
+		{Genome: model.Genome{ID: "old-worse"}, Fitness: 2},
+		{Genome: model.Genome{ID: "young-mid"}, Fitness: 2},
+		{Genome: model.Genome{ID: "newcomer"}, Fitness: 0.5},
+	}
+	ages := map[string]int{"old-best": 5, "old-worse": 5, "young-mid": 1}
+	front := AgeFitnessParetoFront(scored, ages)
+	got := make([]string, 0, len(front))
+	for _, item := range front {
+		got = append(got, item.Genome.ID)
+	}
+	want := []string{"old-best", "young-mid", "newcomer"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("unexpected front: got=%v want=%v", got, want)
+	}
+
+	selector := AFPOSelector{TournamentSize: len(scored)}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		parent, err := selector.PickParentByAge(rng, scored, ages)
+		if err != nil {
+			t.Fatalf("pick parent: %v", err)
+		}
+		if parent.ID == "old-worse" {
+			t.Fatal("expected dominated genome never to win an afpo tournament")
+		}
+	}
+}
+
+func TestPopulationMonitorAFPOInjectsNewcomersAndTracksAge(t *testing.T) {
+	initial := []model.Genome{
+		newLinearGenome("g0", 0.1),
+		newLinearGenome("g1", 0.2),
+		newLinearGenome("g2", 0.3),
+		newLinearGenome("g3", 0.4),
+	}
+	var requested []string
+	monitor, err := NewPopulationMonitor(MonitorConfig{
+		Scape:           trialScape{},
+		Mutation:        namedNoopMutation{name: "noop"},
+		Selector:        AFPOSelector{TournamentSize: 2, Newcomers: 1},
+		PopulationSize:  len(initial),
+		EliteCount:      1,
+		Generations:     3,
+		Workers:         2,
+		Seed:            5,
+		InputNeuronIDs:  []string{"i"},
+		OutputNeuronIDs: []string{"o"},
+		NewcomerFactory: func(generation, index int) (model.Genome, error) {
+			id := fmt.Sprintf("new-g%d-n%d", generation, index)
+			requested = append(requested, id)
+			return newLinearGenome(id, 0.5), nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	result, err := monitor.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(requested) != 3 {
+		t.Fatalf("expected one newcomer per generation transition, got %v", requested)
+	}
+	if len(result.FinalPopulation) != len(initial) {
+		t.Fatalf("expected population size %d, got %d", len(initial), len(result.FinalPopulation))
+	}
+	newcomers := 0
+	for _, record := range result.Lineage {
+		if record.Operation == "afpo_newcomer" {
+			newcomers++
+			if record.ParentID != "" {
+				t.Fatalf("expected newcomer without parent, got %+v", record)
+			}
+		}
+	}
+	if newcomers != 3 {
+		t.Fatalf("expected 3 newcomer lineage records, got %d", newcomers)
+	}
+	// All genomes tie on fitness, so the youngest genome is always on the
+	// front: the previous newcomer survives one generation older.
+	if age, ok := monitor.ages[requested[1]]; !ok || age != 1 {
+		t.Fatalf("expected surviving newcomer age 1, got %d (present=%t)", age, ok)
+	}
+	if age, ok := monitor.ages[requested[2]]; !ok || age != 0 {
+		t.Fatalf("expected latest newcomer age 0, got %d (present=%t)", age, ok)
+	}
+}
@@ -0,0 +1,180 @@
+package evo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"protogonos/internal/genotype"
+	"protogonos/internal/model"
+	"protogonos/internal/scape"
+)
+
+// DefaultBehaviorCloneIterations is the random search budget CloneBehavior
+// uses when none is configured.
+const DefaultBehaviorCloneIterations = 200
+
+const (
+	defaultCloneSigma = 0.5
+	minCloneSigma     = 0.01
+	maxCloneSigma     = 4.0
+)
+
+// ScriptedPolicy is a hand-written controller: it maps one scape observation
+// to the action the scape expects from an agent's outputs.
+type ScriptedPolicy func(observation []float64) ([]float64, error)
+
+// Demonstration is one observation and the action a scripted policy took.
+type Demonstration struct {
+	Observation []float64
+	Action      []float64
+}
+
+// policyAgent runs a ScriptedPolicy as a step agent and records every step.
+type policyAgent struct {
+	policy         ScriptedPolicy
+	demonstrations []Demonstration
+}
+
+func (*policyAgent) ID() string {
+	return "scripted-policy"
+}
+
+func (a *policyAgent) RunStep(_ context.Context, input []float64) ([]float64, error) {
+	action, err := a.policy(append([]float64(nil), input...))
+	if err != nil {
+		return nil, err
+	}
+	a.demonstrations = append(a.demonstrations, Demonstration{
+		Observation: append([]float64(nil), input...),
+		Action:      append([]float64(nil), action...),
+	})
+	return action, nil
+}
+
+// RecordDemonstrations runs policy through one gt evaluation of target and
+// returns the observation/action pairs it produced with the fitness the
+// policy earned. The scape must accept step agents.
+func RecordDemonstrations(ctx context.Context, target scape.Scape, policy ScriptedPolicy) ([]Demonstration, scape.Fitness, error) {
+	if policy == nil {
+		return nil, 0, errors.New("scripted policy is required")
+	}
+	runner := &policyAgent{policy: policy}
+	var (
+		fitness scape.Fitness
+		err     error
+	)
+	if modeAware, ok := target.(scape.ModeAwareScape); ok {
+		fitness, _, err = modeAware.EvaluateMode(ctx, runner, OpModeGT)
+	} else {
+		fitness, _, err = target.Evaluate(ctx, runner)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("run scripted policy on %s: %w", target.Name(), err)
+	}
+	if len(runner.demonstrations) == 0 {
+		return nil, 0, fmt.Errorf("scripted policy took no steps on %s", target.Name())
+	}
+	return runner.demonstrations, fitness, nil
+}
+
+// BehaviorCloneConfig sizes the imitation fit of CloneBehavior.
+type BehaviorCloneConfig struct {
+	// Iterations is the number of perturbations tried; defaults to 200.
+	Iterations int
+	// Sigma is the initial perturbation std; defaults to 0.5.
+	Sigma float64
+	Seed  int64
+}
+
+// CloneBehavior fits a copy of genome to imitate demonstrations by random
+// search: each iteration perturbs every synapse weight and every non-input
+// neuron bias, and keeps the perturbation when it lowers the mean squared
+// error between the agent's outputs and the demonstrated actions. The step
+// size grows after an improvement and shrinks after a failure. build turns a
+// genome into the step agent whose outputs are compared; inputNeuronIDs are
+// left unbiased. It returns the fitted genome and its error.
+func CloneBehavior(
+	ctx context.Context,
+	genome model.Genome,
+	inputNeuronIDs []string,
+	demonstrations []Demonstration,
+	build func(model.Genome) (scape.StepAgent, error),
+	cfg BehaviorCloneConfig,
+) (model.Genome, float64, error) {
+	if len(demonstrations) == 0 {
+		return model.Genome{}, 0, errors.New("behavior cloning needs demonstrations")
+	}
+	if cfg.Iterations <= 0 {
+		cfg.Iterations = DefaultBehaviorCloneIterations
+	}
+	if cfg.Sigma <= 0 {
+		cfg.Sigma = defaultCloneSigma
+	}
+	inputs := make(map[string]bool, len(inputNeuronIDs))
+	for _, id := range inputNeuronIDs {
+		inputs[id] = true
+	}
+
+	best := genotype.CloneGenome(genome)
+	bestLoss, err := imitationLoss(ctx, best, demonstrations, build)
+	if err != nil {
+		return model.Genome{}, 0, err
+	}
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	sigma := cfg.Sigma
+	for i := 0; i < cfg.Iterations && bestLoss > 0; i++ {
+		candidate := genotype.CloneGenome(best)
+		for j := range candidate.Synapses {
+			candidate.Synapses[j].Weight += rng.NormFloat64() * sigma
+		}
+		for j := range candidate.Neurons {
+			if !inputs[candidate.Neurons[j].ID] {
+				candidate.Neurons[j].Bias += rng.NormFloat64() * sigma
+			}
+		}
+		loss, err := imitationLoss(ctx, candidate, demonstrations, build)
+		if err != nil {
+			return model.Genome{}, 0, err
+		}
+		if loss < bestLoss {
+			best, bestLoss = candidate, loss
+			sigma = math.Min(sigma*1.5, maxCloneSigma)
+		} else {
+			sigma = math.Max(sigma*0.9, minCloneSigma)
+		}
+	}
+	return best, bestLoss, nil
+}
+
+func imitationLoss(ctx context.Context, genome model.Genome, demonstrations []Demonstration, build func(model.Genome) (scape.StepAgent, error)) (float64, error) {
+	runner, err := build(genome)
+	if err != nil {
+		return 0, err
+	}
+	sum := 0.0
+	count := 0
+	for _, demonstration := range demonstrations {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		out, err := runner.RunStep(ctx, demonstration.Observation)
+		if err != nil {
+			return 0, err
+		}
+		if len(out) != len(demonstration.Action) {
+			return 0, fmt.Errorf("genome %s has %d outputs, scripted policy took %d actions", genome.ID, len(out), len(demonstration.Action))
+		}
+		for k := range out {
+			delta := out[k] - demonstration.Action[k]
+			sum += delta * delta
+		}
+		count += len(out)
+	}
+	if count == 0 {
+		return 0, nil
+	}
+	return sum / float64(count), nil
+}
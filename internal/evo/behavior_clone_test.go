@@ -0,0 +1,82 @@
+package evo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"protogonos/internal/agent"
+	"protogonos/internal/model"
+	"protogonos/internal/scape"
+)
+
+func proportionalCartPolicy(observation []float64) ([]float64, error) {
+	if len(observation) != 2 {
+		return nil, errors.New("expected position and velocity")
+	}
+	return []float64{-1.2*observation[0] - 0.6*observation[1]}, nil
+}
+
+func TestRecordDemonstrationsRunsScriptedPolicyThroughScape(t *testing.T) {
+	demonstrations, fitness, err := RecordDemonstrations(context.Background(), scape.CartPoleLiteScape{}, proportionalCartPolicy)
+	if err != nil {
+		t.Fatalf("record demonstrations: %v", err)
+	}
+	if len(demonstrations) != 5*60 {
+		t.Fatalf("expected one demonstration per gt step, got %d", len(demonstrations))
+	}
+	if fitness <= 0.5 {
+		t.Fatalf("expected the scripted controller to balance, got fitness %f", fitness)
+	}
+	first := demonstrations[0]
+	if first.Observation[0] != -0.8 || first.Action[0] != -1.2*-0.8 {
+		t.Fatalf("unexpected first demonstration: %+v", first)
+	}
+
+	if _, _, err := RecordDemonstrations(context.Background(), scape.CartPoleLiteScape{}, nil); err == nil {
+		t.Fatal("expected a missing policy to be rejected")
+	}
+}
+
+func TestCloneBehaviorFitsGenomeToDemonstrations(t *testing.T) {
+	demonstrations, _, err := RecordDemonstrations(context.Background(), scape.CartPoleLiteScape{}, proportionalCartPolicy)
+	if err != nil {
+		t.Fatalf("record demonstrations: %v", err)
+	}
+	genome := model.Genome{
+		ID: "g",
+		Neurons: []model.Neuron{
+			{ID: "x", Activation: "identity"},
+			{ID: "v", Activation: "identity"},
+			{ID: "f", Activation: "identity"},
+		},
+		Synapses: []model.Synapse{
+			{ID: "s1", From: "x", To: "f", Weight: 0.5, Enabled: true},
+			{ID: "s2", From: "v", To: "f", Weight: 0.5, Enabled: true},
+		},
+	}
+	build := func(genome model.Genome) (scape.StepAgent, error) {
+		return agent.NewCortex(genome.ID, genome, nil, nil, []string{"x", "v"}, []string{"f"}, nil)
+	}
+	initialLoss, err := imitationLoss(context.Background(), genome, demonstrations, build)
+	if err != nil {
+		t.Fatalf("initial loss: %v", err)
+	}
+
+	fitted, loss, err := CloneBehavior(context.Background(), genome, []string{"x", "v"}, demonstrations, build, BehaviorCloneConfig{Iterations: 300, Seed: 1})
+	if err != nil {
+		t.Fatalf("clone behavior: %v", err)
+	}
+	if loss > initialLoss/10 {
+		t.Fatalf("expected the fit to cut the imitation error tenfold, got %f from %f", loss, initialLoss)
+	}
+	if fitted.Synapses[0].Weight >= 0 || fitted.Synapses[1].Weight >= 0 {
+		t.Fatalf("expected the fitted weights to take the policy's signs, got %+v", fitted.Synapses)
+	}
+	if fitted.Neurons[0].Bias != 0 || fitted.Neurons[1].Bias != 0 {
+		t.Fatalf("expected input biases left alone, got %+v", fitted.Neurons)
+	}
+	if genome.Synapses[0].Weight != 0.5 {
+		t.Fatal("expected the input genome to be left unchanged")
+	}
+}
@@ -0,0 +1,420 @@
+package evo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"protogonos/internal/genotype"
+	"protogonos/internal/model"
+)
+
+// RandStreams creates the random streams of one run and tracks how far each
+// has advanced, so a Checkpoint can record them and a resumed run can wind
+// freshly created streams forward to the same positions. A nil RandStreams
+// creates untracked streams.
+type RandStreams struct {
+	mu      sync.Mutex
+	sources []*countedSource
+}
+
+// RandState is the position of one tracked random stream: its seed and the
+// number of values drawn from it.
+type RandState struct {
+	Seed  int64  `json:"seed"`
+	Draws uint64 `json:"draws"`
+}
+
+// countedSource counts the values drawn from a math/rand source; every
+// Int63 and Uint64 call advances the source by one step.
+type countedSource struct {
+	src   rand.Source64
+	seed  int64
+	draws uint64
+}
+
+func (s *countedSource) Int63() int64 {
+	s.draws++
+	return s.src.Int63()
+}
+
+func (s *countedSource) Uint64() uint64 {
+	s.draws++
+	return s.src.Uint64()
+}
+
+func (s *countedSource) Seed(seed int64) {
+	s.src.Seed(seed)
+	s.seed = seed
+	s.draws = 0
+}
+
+// New returns a stream seeded with seed and tracked by s.
+func (s *RandStreams) New(seed int64) *rand.Rand {
+	if s == nil {
+		return rand.New(rand.NewSource(seed))
+	}
+	source := &countedSource{src: rand.NewSource(seed).(rand.Source64), seed: seed}
+	s.mu.Lock()
+	s.sources = append(s.sources, source)
+	s.mu.Unlock()
+	return rand.New(source)
+}
+
+// State returns the position of every stream, in creation order.
+func (s *RandStreams) State() []RandState {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	states := make([]RandState, len(s.sources))
+	for i, source := range s.sources {
+		states[i] = RandState{Seed: source.seed, Draws: source.draws}
+	}
+	return states
+}
+
+// Restore winds the streams forward to states. The streams must have been
+// created in the same order and from the same seeds as the ones states were
+// taken from, and not drawn from since.
+func (s *RandStreams) Restore(states []RandState) error {
+	if s == nil {
+		if len(states) > 0 {
+			return errors.New("checkpoint has random streams but the run tracks none")
+		}
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(states) != len(s.sources) {
+		return fmt.Errorf("checkpoint has %d random streams, the run has %d", len(states), len(s.sources))
+	}
+	for i, state := range states {
+		source := s.sources[i]
+		if source.seed != state.Seed || source.draws != 0 {
+			return fmt.Errorf("random stream %d does not match the checkpoint", i)
+		}
+		for source.draws < state.Draws {
+			source.Uint64()
+		}
+	}
+	return nil
+}
+
+// CheckpointState is implemented by selectors and fitness postprocessors
+// that carry state from one generation to the next, so checkpoints can
+// save and restore it.
+type CheckpointState interface {
+	SaveCheckpointState() (json.RawMessage, error)
+	RestoreCheckpointState(json.RawMessage) error
+}
+
+// Checkpoint is the state of a generational run between two generations:
+// the population about to be evaluated, the histories accumulated so far,
+// the monitor's bookkeeping and the positions of the run's random streams.
+// A monitor built from the same configuration, with Resume set to it,
+// continues the run as if it had not stopped.
+type Checkpoint struct {
+	// Generation counts the generations evaluated before the checkpoint.
+	Generation     int                                   `json:"generation"`
+	PopulationSize int                                   `json:"population_size"`
+	Population     []model.Genome                        `json:"population"`
+	BestHistory    []float64                             `json:"best_history"`
+	Diagnostics    []GenerationDiagnostics               `json:"diagnostics"`
+	SpeciesHistory []SpeciesGeneration                   `json:"species_history"`
+	TraceAcc       []TraceGeneration                     `json:"trace_acc"`
+	Lineage        []LineageRecord                       `json:"lineage"`
+	PrevSpecies    []string                              `json:"prev_species,omitempty"`
+	EvoHistory     map[string][]genotype.EvoHistoryEvent `json:"evo_history,omitempty"`
+	Rands          []RandState                           `json:"rands,omitempty"`
+	Monitor        MonitorCheckpoint                     `json:"monitor"`
+}
+
+// MonitorCheckpoint is the population monitor's share of a Checkpoint.
+type MonitorCheckpoint struct {
+	TotalEvaluations       int                      `json:"total_evaluations"`
+	NextTraceEvaluation    int                      `json:"next_trace_evaluation"`
+	StepEvaluations        int                      `json:"step_evaluations,omitempty"`
+	StepCycles             float64                  `json:"step_cycles,omitempty"`
+	StepTime               float64                  `json:"step_time,omitempty"`
+	StepSpeciesEvaluations map[string]int           `json:"step_species_evaluations,omitempty"`
+	LastTraceSpecies       []TraceSpeciesMetrics    `json:"last_trace_species,omitempty"`
+	Ages                   map[string]int           `json:"ages,omitempty"`
+	ScapeParams            map[string]float64       `json:"scape_params,omitempty"`
+	CrossoverOffspring     map[string]string        `json:"crossover_offspring,omitempty"`
+	CappedSpecies          map[string]bool          `json:"capped_species,omitempty"`
+	TuningTraces           []model.TuningTrace      `json:"tuning_traces,omitempty"`
+	KarmaStrikes           map[string]int           `json:"karma_strikes,omitempty"`
+	KarmaBannedUntil       map[string]int           `json:"karma_banned_until,omitempty"`
+	Events                 []EvolutionEvent         `json:"events,omitempty"`
+	EventChampion          float64                  `json:"event_champion,omitempty"`
+	EventHasChampion       bool                     `json:"event_has_champion,omitempty"`
+	StopBest               float64                  `json:"stop_best,omitempty"`
+	StopHasBest            bool                     `json:"stop_has_best,omitempty"`
+	StopStagnation         int                      `json:"stop_stagnation,omitempty"`
+	EntropyPending         string                   `json:"entropy_pending,omitempty"`
+	EntropyQuietUntil      int                      `json:"entropy_quiet_until,omitempty"`
+	EntropyBoostUntil      int                      `json:"entropy_boost_until,omitempty"`
+	InvariantElites        map[string]float64       `json:"invariant_elites,omitempty"`
+	Innovations            []model.InnovationRecord `json:"innovations,omitempty"`
+	Speciation             *SpeciationCheckpoint    `json:"speciation,omitempty"`
+	Selector               json.RawMessage          `json:"selector,omitempty"`
+	Postprocessor          json.RawMessage          `json:"postprocessor,omitempty"`
+}
+
+// SpeciationCheckpoint is the adaptive speciation state: the compatibility
+// threshold, the species representatives and the next species number.
+type SpeciationCheckpoint struct {
+	Threshold       float64                 `json:"threshold"`
+	Representatives map[string]model.Genome `json:"representatives,omitempty"`
+	NextSpeciesID   int                     `json:"next_species_id"`
+}
+
+func validateCheckpoints(cfg MonitorConfig) error {
+	if cfg.CheckpointInterval < 0 {
+		return errors.New("checkpoint interval must be >= 0")
+	}
+	if (cfg.CheckpointInterval > 0 || cfg.Resume != nil) && cfg.EvolutionType != EvolutionTypeGenerational {
+		return errors.New("checkpoints require generational evolution")
+	}
+	if cfg.SnapshotInterval < 0 {
+		return errors.New("snapshot interval must be >= 0")
+	}
+	if cfg.SnapshotInterval > 0 && cfg.EvolutionType != EvolutionTypeGenerational {
+		return errors.New("population snapshots require generational evolution")
+	}
+	return nil
+}
+
+// Checkpoint captures the run between generations. It is only meaningful
+// while the run is not done.
+func (r *GenerationalRun) Checkpoint() (Checkpoint, error) {
+	m := r.m
+	prevSpecies := make([]string, 0, len(r.prevSpeciesSet))
+	for key := range r.prevSpeciesSet {
+		prevSpecies = append(prevSpecies, key)
+	}
+	sort.Strings(prevSpecies)
+	monitor, err := m.checkpointState()
+	if err != nil {
+		return Checkpoint{}, err
+	}
+	return Checkpoint{
+		Generation:     r.gen,
+		PopulationSize: m.cfg.PopulationSize,
+		Population:     r.population,
+		BestHistory:    r.bestHistory,
+		Diagnostics:    r.diagnostics,
+		SpeciesHistory: r.speciesHistory,
+		TraceAcc:       r.traceAcc,
+		Lineage:        r.lineage,
+		PrevSpecies:    prevSpecies,
+		EvoHistory:     r.evoHistoryByGenomeID,
+		Rands:          m.cfg.Rands.State(),
+		Monitor:        monitor,
+	}, nil
+}
+
+func (m *PopulationMonitor) checkpointState() (MonitorCheckpoint, error) {
+	state := MonitorCheckpoint{
+		TotalEvaluations:       m.totalEvaluations,
+		NextTraceEvaluation:    m.nextTraceEvaluation,
+		StepEvaluations:        m.stepEvaluations,
+		StepCycles:             m.stepCycles,
+		StepTime:               m.stepTime,
+		StepSpeciesEvaluations: m.stepSpeciesEvaluations,
+		LastTraceSpecies:       m.lastTraceSpecies,
+		Ages:                   m.ages,
+		ScapeParams:            m.scapeParams,
+		CrossoverOffspring:     m.crossoverOffspring,
+		CappedSpecies:          m.cappedSpecies,
+		TuningTraces:           m.tuningTraces,
+		KarmaStrikes:           m.karma.strikes,
+		KarmaBannedUntil:       m.karma.bannedUntil,
+		Events:                 m.events.events,
+		EventChampion:          m.events.champion,
+		EventHasChampion:       m.events.hasChampion,
+		StopBest:               m.stopBest,
+		StopHasBest:            m.stopHasBest,
+		StopStagnation:         m.stopStagnation,
+		EntropyPending:         m.entropy.pending,
+		EntropyQuietUntil:      m.entropy.quietUntil,
+		EntropyBoostUntil:      m.entropy.boostUntil,
+		Innovations:            m.innovations.Records(),
+	}
+	if len(m.invariantElites) > 0 {
+		state.InvariantElites = make(map[string]float64, len(m.invariantElites))
+		for _, elite := range m.invariantElites {
+			state.InvariantElites[elite.id] = elite.fitness
+		}
+	}
+	if m.speciation != nil {
+		state.Speciation = &SpeciationCheckpoint{
+			Threshold:       m.speciation.Threshold,
+			Representatives: m.speciation.representatives,
+			NextSpeciesID:   m.speciation.nextSpeciesID,
+		}
+	}
+	var err error
+	if stateful, ok := m.cfg.Selector.(CheckpointState); ok {
+		if state.Selector, err = stateful.SaveCheckpointState(); err != nil {
+			return MonitorCheckpoint{}, fmt.Errorf("checkpoint selector: %w", err)
+		}
+	}
+	if stateful, ok := m.cfg.Postprocessor.(CheckpointState); ok {
+		if state.Postprocessor, err = stateful.SaveCheckpointState(); err != nil {
+			return MonitorCheckpoint{}, fmt.Errorf("checkpoint postprocessor: %w", err)
+		}
+	}
+	return state, nil
+}
+
+// resumeGenerational rebuilds the run m.cfg.Resume captured, after
+// StartGenerational has reset the monitor.
+func (m *PopulationMonitor) resumeGenerational(cp Checkpoint) (*GenerationalRun, error) {
+	if cp.Generation <= 0 || cp.Generation >= m.cfg.Generations {
+		return nil, fmt.Errorf("checkpoint generation %d is outside the run's %d generations", cp.Generation, m.cfg.Generations)
+	}
+	if len(cp.Population) != cp.PopulationSize {
+		return nil, fmt.Errorf("checkpoint population mismatch: got=%d want=%d", len(cp.Population), cp.PopulationSize)
+	}
+	if err := m.cfg.Rands.Restore(cp.Rands); err != nil {
+		return nil, err
+	}
+	if err := m.restoreCheckpointState(cp.Monitor); err != nil {
+		return nil, err
+	}
+	m.cfg.PopulationSize = cp.PopulationSize
+	m.events.generation = m.cfg.GenerationOffset + cp.Generation
+	if len(cp.Diagnostics) > 0 {
+		m.lastDiagnostics = cp.Diagnostics[len(cp.Diagnostics)-1]
+		m.hasDiagnostics = true
+	}
+
+	prevSpecies := make(map[string]struct{}, len(cp.PrevSpecies))
+	for _, key := range cp.PrevSpecies {
+		prevSpecies[key] = struct{}{}
+	}
+	evoHistory := cp.EvoHistory
+	if evoHistory == nil {
+		evoHistory = map[string][]genotype.EvoHistoryEvent{}
+	}
+	return &GenerationalRun{
+		m:                    m,
+		gen:                  cp.Generation,
+		population:           cp.Population,
+		bestHistory:          cp.BestHistory,
+		diagnostics:          cp.Diagnostics,
+		speciesHistory:       cp.SpeciesHistory,
+		traceAcc:             cp.TraceAcc,
+		lineage:              cp.Lineage,
+		prevSpeciesSet:       prevSpecies,
+		evoHistoryByGenomeID: evoHistory,
+	}, nil
+}
+
+func (m *PopulationMonitor) restoreCheckpointState(state MonitorCheckpoint) error {
+	m.totalEvaluations = state.TotalEvaluations
+	m.nextTraceEvaluation = state.NextTraceEvaluation
+	m.stepEvaluations = state.StepEvaluations
+	m.stepCycles = state.StepCycles
+	m.stepTime = state.StepTime
+	m.stepSpeciesEvaluations = orEmpty(state.StepSpeciesEvaluations)
+	m.lastTraceSpecies = state.LastTraceSpecies
+	m.ages = orEmpty(state.Ages)
+	m.scapeParams = state.ScapeParams
+	m.crossoverOffspring = orEmpty(state.CrossoverOffspring)
+	m.cappedSpecies = orEmpty(state.CappedSpecies)
+	m.tuningTraces = state.TuningTraces
+	m.karma = &karmaLedger{strikes: orEmpty(state.KarmaStrikes), bannedUntil: orEmpty(state.KarmaBannedUntil)}
+	m.events.events = state.Events
+	m.events.champion = state.EventChampion
+	m.events.hasChampion = state.EventHasChampion
+	m.stopBest = state.StopBest
+	m.stopHasBest = state.StopHasBest
+	m.stopStagnation = state.StopStagnation
+	m.entropy = entropyDetector{
+		pending:    state.EntropyPending,
+		quietUntil: state.EntropyQuietUntil,
+		boostUntil: state.EntropyBoostUntil,
+	}
+	m.invariantElites = nil
+	for id, fitness := range state.InvariantElites {
+		m.invariantElites = append(m.invariantElites, invariantElite{id: id, fitness: fitness})
+	}
+	sort.Slice(m.invariantElites, func(i, j int) bool { return m.invariantElites[i].id < m.invariantElites[j].id })
+	if m.cfg.TrackInnovations {
+		m.innovations = genotype.NewInnovationRegistry(state.Innovations)
+	}
+	if state.Speciation != nil {
+		if m.cfg.SpeciationMode == SpeciationModeFingerprint {
+			return errors.New("checkpoint has adaptive speciation state but the run speciates by fingerprint")
+		}
+		if m.speciation == nil {
+			m.speciation = NewAdaptiveSpeciation(m.basePopulation)
+		}
+		m.speciation.Threshold = state.Speciation.Threshold
+		m.speciation.representatives = orEmpty(state.Speciation.Representatives)
+		m.speciation.nextSpeciesID = state.Speciation.NextSpeciesID
+	}
+	if len(state.Selector) > 0 {
+		stateful, ok := m.cfg.Selector.(CheckpointState)
+		if !ok {
+			return fmt.Errorf("checkpoint has state for a selector %s does not keep", m.cfg.Selector.Name())
+		}
+		if err := stateful.RestoreCheckpointState(state.Selector); err != nil {
+			return fmt.Errorf("restore selector: %w", err)
+		}
+	}
+	if len(state.Postprocessor) > 0 {
+		stateful, ok := m.cfg.Postprocessor.(CheckpointState)
+		if !ok {
+			return fmt.Errorf("checkpoint has state for a postprocessor %s does not keep", m.cfg.Postprocessor.Name())
+		}
+		if err := stateful.RestoreCheckpointState(state.Postprocessor); err != nil {
+			return fmt.Errorf("restore postprocessor: %w", err)
+		}
+	}
+	return nil
+}
+
+// saveCheckpoint hands the run to CheckpointHook every CheckpointInterval
+// generations while it has generations left.
+func (r *GenerationalRun) saveCheckpoint(ctx context.Context) error {
+	m := r.m
+	if m.cfg.CheckpointInterval <= 0 || m.cfg.CheckpointHook == nil || r.done || r.gen%m.cfg.CheckpointInterval != 0 {
+		return nil
+	}
+	cp, err := r.Checkpoint()
+	if err != nil {
+		return err
+	}
+	if err := m.cfg.CheckpointHook(ctx, cp); err != nil {
+		return fmt.Errorf("save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// saveSnapshot hands the scored population of the given logical generation
+// to SnapshotHook every SnapshotInterval generations.
+func (m *PopulationMonitor) saveSnapshot(ctx context.Context, generation int, scored []ScoredGenome) error {
+	if m.cfg.SnapshotInterval <= 0 || m.cfg.SnapshotHook == nil || generation%m.cfg.SnapshotInterval != 0 {
+		return nil
+	}
+	if err := m.cfg.SnapshotHook(ctx, generation, scored); err != nil {
+		return fmt.Errorf("save population snapshot: %w", err)
+	}
+	return nil
+}
+
+func orEmpty[K comparable, V any](values map[K]V) map[K]V {
+	if values == nil {
+		return map[K]V{}
+	}
+	return values
+}
@@ -0,0 +1,149 @@
+package evo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func checkpointMonitorConfig(resume *Checkpoint, hook func(context.Context, Checkpoint) error) MonitorConfig {
+	rands := &RandStreams{}
+	cfg := islandMonitorConfig()
+	cfg.Generations = 6
+	cfg.Mutation = &PerturbRandomWeight{Rand: rands.New(99), MaxDelta: 0.2}
+	cfg.Selector = &SpeciesSharedTournamentSelector{
+		Identifier:            TopologySpecieIdentifier{},
+		TournamentSize:        2,
+		StagnationGenerations: 1,
+	}
+	cfg.Rands = rands
+	cfg.CheckpointInterval = 2
+	cfg.CheckpointHook = hook
+	cfg.Resume = resume
+	return cfg
+}
+
+func TestResumedRunContinuesFromCheckpoint(t *testing.T) {
+	initial := make([]model.Genome, 8)
+	for i := range initial {
+		initial[i] = newLinearGenome(fmt.Sprintf("g%d", i), 0.1*float64(i+1))
+	}
+	var saved [][]byte
+	monitor, err := NewPopulationMonitor(checkpointMonitorConfig(nil, func(_ context.Context, cp Checkpoint) error {
+		data, err := json.Marshal(cp)
+		saved = append(saved, data)
+		return err
+	}))
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	full, err := monitor.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(saved) != 2 {
+		t.Fatalf("expected checkpoints after generations 2 and 4, got %d", len(saved))
+	}
+
+	for _, data := range saved {
+		var cp Checkpoint
+		if err := json.Unmarshal(data, &cp); err != nil {
+			t.Fatalf("decode checkpoint: %v", err)
+		}
+		resumed, err := NewPopulationMonitor(checkpointMonitorConfig(&cp, nil))
+		if err != nil {
+			t.Fatalf("new monitor: %v", err)
+		}
+		result, err := resumed.Run(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("resume from generation %d: %v", cp.Generation, err)
+		}
+		if !reflect.DeepEqual(result.BestByGeneration, full.BestByGeneration) {
+			t.Fatalf("resume from generation %d: best history %v, want %v", cp.Generation, result.BestByGeneration, full.BestByGeneration)
+		}
+		if !reflect.DeepEqual(result.Lineage, full.Lineage) {
+			t.Fatalf("resume from generation %d: lineage diverged from the uninterrupted run", cp.Generation)
+		}
+		if len(result.GenerationDiagnostics) != len(full.GenerationDiagnostics) {
+			t.Fatalf("resume from generation %d: expected %d diagnostics, got %d", cp.Generation, len(full.GenerationDiagnostics), len(result.GenerationDiagnostics))
+		}
+	}
+}
+
+func TestRandStreamsRestoreRequiresMatchingStreams(t *testing.T) {
+	streams := &RandStreams{}
+	rng := streams.New(3)
+	rng.Intn(10)
+	rng.Float64()
+	state := streams.State()
+
+	restored := &RandStreams{}
+	replay := restored.New(3)
+	if err := restored.Restore(state[:0]); err == nil {
+		t.Fatal("expected a stream count mismatch to be rejected")
+	}
+	if err := restored.Restore([]RandState{{Seed: 4, Draws: state[0].Draws}}); err == nil {
+		t.Fatal("expected a seed mismatch to be rejected")
+	}
+	if err := restored.Restore(state); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if rng.Int63() != replay.Int63() {
+		t.Fatal("expected the restored stream to continue where the original left off")
+	}
+	if state[0].Draws == 0 {
+		t.Fatal("expected draws to be counted")
+	}
+}
+
+func TestCheckpointConfigValidation(t *testing.T) {
+	cfg := checkpointMonitorConfig(nil, nil)
+	cfg.CheckpointInterval = -1
+	if _, err := NewPopulationMonitor(cfg); err == nil {
+		t.Fatal("expected a negative checkpoint interval to be rejected")
+	}
+	cfg = checkpointMonitorConfig(nil, nil)
+	cfg.Islands = 0
+	cfg.EvolutionType = EvolutionTypeSteadyState
+	if _, err := NewPopulationMonitor(cfg); err == nil {
+		t.Fatal("expected checkpoints to require generational evolution")
+	}
+}
+
+func TestSnapshotHookReceivesScoredGenerations(t *testing.T) {
+	initial := make([]model.Genome, 8)
+	for i := range initial {
+		initial[i] = newLinearGenome(fmt.Sprintf("g%d", i), 0.1*float64(i+1))
+	}
+	cfg := checkpointMonitorConfig(nil, nil)
+	cfg.CheckpointInterval = 0
+	cfg.SnapshotInterval = 3
+	var generations []int
+	cfg.SnapshotHook = func(_ context.Context, generation int, scored []ScoredGenome) error {
+		if len(scored) != len(initial) {
+			return fmt.Errorf("snapshot of generation %d has %d genomes", generation, len(scored))
+		}
+		generations = append(generations, generation)
+		return nil
+	}
+	monitor, err := NewPopulationMonitor(cfg)
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	if _, err := monitor.Run(context.Background(), initial); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if !reflect.DeepEqual(generations, []int{3, 6}) {
+		t.Fatalf("expected snapshots of generations 3 and 6, got %v", generations)
+	}
+
+	cfg.Islands = 0
+	cfg.EvolutionType = EvolutionTypeSteadyState
+	if _, err := NewPopulationMonitor(cfg); err == nil {
+		t.Fatal("expected population snapshots to require generational evolution")
+	}
+}
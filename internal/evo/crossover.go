@@ -0,0 +1,166 @@
+package evo
+
+import (
+	"context"
+	"math"
+	"math/rand"
+
+	"protogonos/internal/genotype"
+	"protogonos/internal/model"
+)
+
+const (
+	offspringIntraspecies = "intraspecies"
+	offspringHybrid       = "hybrid"
+)
+
+// heredityCrossover is the strategy heredity type, set by
+// MutateHeredityType, of genomes that always breed by crossover.
+const heredityCrossover = "crossover"
+
+// disabledGeneInheritance is the chance a synapse disabled in either parent
+// stays disabled in the child, as in NEAT.
+const disabledGeneInheritance = 0.75
+
+// CrossoverGenomes recombines parent with mate. The parent supplies the
+// topology, id and every gene the mate lacks; neurons and synapses present in
+// both take their bias or weight from either parent with equal odds. Neurons
+// match by id and synapses by innovation number, falling back to id for
+// unmarked genes (see genotype.MatchSynapses). A matching synapse disabled in either parent is disabled in the
+// child with probability disabledGeneInheritance, whatever its Enabled flag
+// in the parent.
+func CrossoverGenomes(rng *rand.Rand, parent, mate model.Genome) model.Genome {
+	child := cloneGenome(parent)
+	mateNeurons := mapNeuronsByID(mate.Neurons)
+	for i, neuron := range child.Neurons {
+		if other, ok := mateNeurons[neuron.ID]; ok && rng.Intn(2) == 1 {
+			child.Neurons[i].Bias = other.Bias
+		}
+	}
+	for i, j := range genotype.MatchSynapses(child.Synapses, mate.Synapses) {
+		if j < 0 {
+			continue
+		}
+		synapse, other := child.Synapses[i], mate.Synapses[j]
+		if rng.Intn(2) == 1 {
+			child.Synapses[i].Weight = other.Weight
+		}
+		if synapse.Enabled && other.Enabled {
+			continue
+		}
+		if rng.Float64() < disabledGeneInheritance {
+			child.Synapses[i].Enabled = false
+			child.Synapses[i].DisabledFor = max(synapse.DisabledFor, other.DisabledFor)
+		} else {
+			child.Synapses[i].Enabled = true
+			child.Synapses[i].DisabledFor = 0
+		}
+	}
+	return child
+}
+
+// pickMate chooses a crossover partner for parent. The mate comes from the
+// parent's species unless an InterspeciesMating draw selects another
+// species; hybrid reports the latter. ok is false when no partner other than
+// the parent is available.
+func (m *PopulationMonitor) pickMate(parentPool []ScoredGenome, speciesByGenomeID map[string]string, parent model.Genome, generation int) (mate model.Genome, hybrid bool, ok bool, err error) {
+	species := speciesByGenomeID[parent.ID]
+	var within, across []ScoredGenome
+	for _, item := range parentPool {
+		if item.Genome.ID == parent.ID {
+			continue
+		}
+		if speciesByGenomeID[item.Genome.ID] == species {
+			within = append(within, item)
+		} else {
+			across = append(across, item)
+		}
+	}
+	candidates := within
+	if m.cfg.InterspeciesMating > 0 && len(across) > 0 && m.rng.Float64() < m.cfg.InterspeciesMating {
+		candidates, hybrid = across, true
+	}
+	if len(candidates) == 0 {
+		return model.Genome{}, false, false, nil
+	}
+	mate, err = m.pickParentForSpecies(parentPool, candidates, speciesByGenomeID, generation)
+	if err != nil {
+		return model.Genome{}, false, false, err
+	}
+	return mate, hybrid, true, nil
+}
+
+// reproduce produces one offspring of parent, then mutates it as usual. When
+// crossover is enabled (CrossoverRate > 0) the parent is first recombined
+// with a mate if its heredity type is crossover, or otherwise with
+// probability CrossoverRate. The fitter of the
+// two supplies the child's topology and lineage parent, so disjoint and
+// excess genes come from it; on a tie that is parent.
+func (m *PopulationMonitor) reproduce(ctx context.Context, parentPool []ScoredGenome, speciesByGenomeID map[string]string, parent model.Genome, generation, nextIndex int) (model.Genome, LineageRecord, error) {
+	if m.cfg.CrossoverRate <= 0 {
+		return m.mutateFromParent(ctx, parent, generation, nextIndex)
+	}
+	if parent.Strategy == nil || parent.Strategy.HeredityType != heredityCrossover {
+		if m.rng.Float64() >= m.cfg.CrossoverRate {
+			return m.mutateFromParent(ctx, parent, generation, nextIndex)
+		}
+	}
+	mate, hybrid, ok, err := m.pickMate(parentPool, speciesByGenomeID, parent, generation)
+	if err != nil {
+		return model.Genome{}, LineageRecord{}, err
+	}
+	if !ok {
+		return m.mutateFromParent(ctx, parent, generation, nextIndex)
+	}
+	if scoredFitness(parentPool, mate.ID) > scoredFitness(parentPool, parent.ID) {
+		parent, mate = mate, parent
+	}
+	child, record, err := m.mutateFromParent(ctx, CrossoverGenomes(m.rng, parent, mate), generation, nextIndex)
+	if err != nil {
+		return model.Genome{}, LineageRecord{}, err
+	}
+	record.MateID = mate.ID
+	record.Operation = "crossover+" + record.Operation
+	kind := offspringIntraspecies
+	if hybrid {
+		kind = offspringHybrid
+	}
+	m.crossoverOffspring[child.ID] = kind
+	return child, record, nil
+}
+
+// scoredFitness returns the fitness of the genome with id in pool, or -Inf
+// when it is not there.
+func scoredFitness(pool []ScoredGenome, id string) float64 {
+	for _, item := range pool {
+		if item.Genome.ID == id {
+			return item.Fitness
+		}
+	}
+	return math.Inf(-1)
+}
+
+// annotateCrossoverOutcomes reports how the previous generation's crossover
+// offspring scored, split by intraspecies and hybrid mating.
+func (m *PopulationMonitor) annotateCrossoverOutcomes(diag *GenerationDiagnostics, scored []ScoredGenome) {
+	if len(m.crossoverOffspring) == 0 {
+		return
+	}
+	var intraSum, hybridSum float64
+	for _, item := range scored {
+		switch m.crossoverOffspring[item.Genome.ID] {
+		case offspringIntraspecies:
+			diag.CrossoverIntra++
+			intraSum += item.Fitness
+		case offspringHybrid:
+			diag.CrossoverHybrid++
+			hybridSum += item.Fitness
+		}
+	}
+	if diag.CrossoverIntra > 0 {
+		diag.CrossoverIntraMean = intraSum / float64(diag.CrossoverIntra)
+	}
+	if diag.CrossoverHybrid > 0 {
+		diag.CrossoverHybridMean = hybridSum / float64(diag.CrossoverHybrid)
+	}
+}
@@ -0,0 +1,305 @@
+package evo
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func TestCrossoverGenomesTakesMatchingGenesFromEitherParent(t *testing.T) {
+	parent := newComplexLinearGenome("p", 1.0)
+	parent.Neurons[0].Bias = 0.5
+	mate := newLinearGenome("m", -1.0)
+	mate.Neurons[0].Bias = -0.5
+
+	sawParent, sawMate := false, false
+	rng := rand.New(rand.NewSource(3))
+	for i := 0; i < 32; i++ {
+		child := CrossoverGenomes(rng, parent, mate)
+		if child.ID != parent.ID || len(child.Synapses) != len(parent.Synapses) || len(child.Neurons) != len(parent.Neurons) {
+			t.Fatalf("expected parent topology, got %+v", child)
+		}
+		switch child.Synapses[0].Weight {
+		case 1.0:
+			sawParent = true
+		case -1.0:
+			sawMate = true
+		default:
+			t.Fatalf("unexpected matching weight %f", child.Synapses[0].Weight)
+		}
+		if bias := child.Neurons[0].Bias; bias != 0.5 && bias != -0.5 {
+			t.Fatalf("unexpected matching bias %f", bias)
+		}
+		for _, synapse := range child.Synapses[1:] {
+			if synapse.Weight != 0.1 {
+				t.Fatalf("expected disjoint synapse %s to keep parent weight, got %f", synapse.ID, synapse.Weight)
+			}
+		}
+	}
+	if !sawParent || !sawMate {
+		t.Fatalf("expected matching weight from both parents: parent=%t mate=%t", sawParent, sawMate)
+	}
+	if parent.Synapses[0].Weight != 1.0 {
+		t.Fatal("crossover mutated the parent genome")
+	}
+}
+
+func TestCrossoverGenomesUsuallyKeepsGenesDisabledInEitherParent(t *testing.T) {
+	parent := newComplexLinearGenome("p", 1.0)
+	mate := newComplexLinearGenome("m", -1.0)
+	mate.Synapses[1].Enabled = false
+	mate.Synapses[1].DisabledFor = 3
+
+	rng := rand.New(rand.NewSource(11))
+	disabled := 0
+	const trials = 400
+	for i := 0; i < trials; i++ {
+		child := CrossoverGenomes(rng, parent, mate)
+		for _, synapse := range child.Synapses {
+			if synapse.ID != "s1" && !synapse.Enabled {
+				t.Fatalf("expected genes enabled in both parents to stay enabled, got %+v", synapse)
+			}
+		}
+		synapse := child.Synapses[1]
+		if synapse.Enabled {
+			if synapse.DisabledFor != 0 {
+				t.Fatalf("expected a re-enabled gene to reset its disabled age, got %+v", synapse)
+			}
+			continue
+		}
+		disabled++
+		if synapse.DisabledFor != 3 {
+			t.Fatalf("expected the disabled gene to keep its age, got %+v", synapse)
+		}
+	}
+	if share := float64(disabled) / trials; share < 0.65 || share > 0.85 {
+		t.Fatalf("expected about %.0f%% of children to inherit the disabled gene, got %.2f", disabledGeneInheritance*100, share)
+	}
+}
+
+func TestPopulationMonitorCrossoverMatesWithinSpeciesByDefault(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		interspecies float64
+		wantHybrid   bool
+	}{
+		{name: "within", interspecies: 0, wantHybrid: false},
+		{name: "hybrid", interspecies: 1, wantHybrid: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			initial := []model.Genome{
+				newLinearGenome("a0", 0.2),
+				newLinearGenome("a1", 0.4),
+				newComplexLinearGenome("b0", 0.3),
+				newComplexLinearGenome("b1", 0.5),
+			}
+			monitor, err := NewPopulationMonitor(MonitorConfig{
+				Scape:              oneDimScape{},
+				Mutation:           PerturbWeightAt{Index: 0, Delta: 0.1},
+				SpeciationMode:     SpeciationModeFingerprint,
+				PopulationSize:     len(initial),
+				EliteCount:         1,
+				Generations:        3,
+				Workers:            1,
+				Seed:               5,
+				InputNeuronIDs:     []string{"i"},
+				OutputNeuronIDs:    []string{"o"},
+				CrossoverRate:      1,
+				InterspeciesMating: tc.interspecies,
+			})
+			if err != nil {
+				t.Fatalf("new monitor: %v", err)
+			}
+			result, err := monitor.Run(context.Background(), initial)
+			if err != nil {
+				t.Fatalf("run: %v", err)
+			}
+
+			crossed, crossSpecies := 0, 0
+			for _, record := range result.Lineage {
+				// Offspring bred after the last generation are never scored.
+				if record.MateID == "" || record.Generation >= len(result.GenerationDiagnostics) {
+					continue
+				}
+				crossed++
+				if !strings.HasPrefix(record.Operation, "crossover+") {
+					t.Fatalf("expected crossover operation, got %q", record.Operation)
+				}
+				if record.MateID[0] != record.GenomeID[0] {
+					crossSpecies++
+				}
+			}
+			if crossed == 0 {
+				t.Fatal("expected crossover offspring in lineage")
+			}
+
+			intra, hybrid := 0, 0
+			for _, diag := range result.GenerationDiagnostics {
+				intra += diag.CrossoverIntra
+				hybrid += diag.CrossoverHybrid
+			}
+			if intra+hybrid != crossed {
+				t.Fatalf("expected %d crossover offspring in diagnostics, got intra=%d hybrid=%d", crossed, intra, hybrid)
+			}
+			// A hybrid draw falls back to the parent's species once only one
+			// species remains, so hybrid mode only guarantees some hybrids.
+			if tc.wantHybrid && (hybrid == 0 || crossSpecies == 0) {
+				t.Fatalf("expected hybrid offspring, got intra=%d hybrid=%d cross-species=%d", intra, hybrid, crossSpecies)
+			}
+			if !tc.wantHybrid && (hybrid != 0 || crossSpecies != 0) {
+				t.Fatalf("expected only intraspecies offspring, got hybrid=%d cross-species=%d", hybrid, crossSpecies)
+			}
+			if first := result.GenerationDiagnostics[0]; first.CrossoverIntra != 0 || first.CrossoverHybrid != 0 {
+				t.Fatalf("expected no crossover outcomes for the seed generation: %+v", first)
+			}
+		})
+	}
+}
+
+func TestPopulationMonitorRejectsInvalidCrossoverConfig(t *testing.T) {
+	base := MonitorConfig{
+		Scape:           oneDimScape{},
+		Mutation:        PerturbWeightAt{Index: 0, Delta: 0.1},
+		PopulationSize:  2,
+		Generations:     1,
+		InputNeuronIDs:  []string{"i"},
+		OutputNeuronIDs: []string{"o"},
+	}
+	for name, mutate := range map[string]func(*MonitorConfig){
+		"rate":         func(c *MonitorConfig) { c.CrossoverRate = 1.5 },
+		"interspecies": func(c *MonitorConfig) { c.InterspeciesMating = -0.1 },
+		"online":       func(c *MonitorConfig) { c.CrossoverRate = 0.5; c.EvolutionType = EvolutionTypeOnline },
+	} {
+		cfg := base
+		mutate(&cfg)
+		if _, err := NewPopulationMonitor(cfg); err == nil {
+			t.Fatalf("%s: expected config error", name)
+		}
+	}
+}
+
+func TestReproduceTakesDisjointGenesFromFitterParent(t *testing.T) {
+	monitor, err := NewPopulationMonitor(MonitorConfig{
+		Scape:           oneDimScape{},
+		Mutation:        namedNoopMutation{name: "noop"},
+		PopulationSize:  2,
+		EliteCount:      1,
+		Generations:     1,
+		Seed:            7,
+		InputNeuronIDs:  []string{"i"},
+		OutputNeuronIDs: []string{"o"},
+		CrossoverRate:   1,
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	monitor.crossoverOffspring = map[string]string{}
+	weak := newLinearGenome("weak", 0.2)
+	strong := newComplexLinearGenome("strong", 0.4)
+	pool := []ScoredGenome{{Genome: strong, Fitness: 0.9}, {Genome: weak, Fitness: 0.1}}
+	species := map[string]string{"weak": "s", "strong": "s"}
+
+	child, record, err := monitor.reproduce(context.Background(), pool, species, weak, 1, 0)
+	if err != nil {
+		t.Fatalf("reproduce: %v", err)
+	}
+	if len(child.Synapses) != len(strong.Synapses) || len(child.Neurons) != len(strong.Neurons) {
+		t.Fatalf("expected the fitter mate's topology, got %d synapses and %d neurons", len(child.Synapses), len(child.Neurons))
+	}
+	if record.ParentID != strong.ID || record.MateID != weak.ID {
+		t.Fatalf("expected the fitter genome as lineage parent, got parent=%s mate=%s", record.ParentID, record.MateID)
+	}
+}
+
+func TestReproduceCrossesParentsWithCrossoverHeredity(t *testing.T) {
+	newMonitor := func(crossoverRate float64) *PopulationMonitor {
+		monitor, err := NewPopulationMonitor(MonitorConfig{
+			Scape:           oneDimScape{},
+			Mutation:        namedNoopMutation{name: "noop"},
+			PopulationSize:  2,
+			EliteCount:      1,
+			Generations:     1,
+			Seed:            7,
+			InputNeuronIDs:  []string{"i"},
+			OutputNeuronIDs: []string{"o"},
+			CrossoverRate:   crossoverRate,
+		})
+		if err != nil {
+			t.Fatalf("new monitor: %v", err)
+		}
+		monitor.crossoverOffspring = map[string]string{}
+		return monitor
+	}
+	asexual := newLinearGenome("asexual", 0.2)
+	crossing := newLinearGenome("crossing", 0.4)
+	crossing.Strategy = &model.StrategyConfig{HeredityType: heredityCrossover}
+	pool := []ScoredGenome{{Genome: crossing, Fitness: 0.9}, {Genome: asexual, Fitness: 0.5}}
+	species := map[string]string{"asexual": "s", "crossing": "s"}
+
+	if _, record, err := newMonitor(0).reproduce(context.Background(), pool, species, crossing, 1, 0); err != nil || record.MateID != "" {
+		t.Fatalf("expected crossover heredity to mutate alone with crossover disabled, got %+v err=%v", record, err)
+	}
+	monitor := newMonitor(1e-9)
+	if _, record, err := monitor.reproduce(context.Background(), pool, species, asexual, 1, 0); err != nil || record.MateID != "" {
+		t.Fatalf("expected asexual heredity to follow the crossover rate, got %+v err=%v", record, err)
+	}
+	_, record, err := monitor.reproduce(context.Background(), pool, species, crossing, 1, 1)
+	if err != nil {
+		t.Fatalf("reproduce: %v", err)
+	}
+	if record.MateID != asexual.ID || !strings.HasPrefix(record.Operation, "crossover+") {
+		t.Fatalf("expected crossover heredity to breed with a mate, got %+v", record)
+	}
+}
+
+func TestPopulationMonitorSteadyStateCrossover(t *testing.T) {
+	initial := []model.Genome{
+		newLinearGenome("a0", 0.2),
+		newLinearGenome("a1", 0.4),
+		newLinearGenome("a2", 0.6),
+		newLinearGenome("a3", 0.8),
+	}
+	monitor, err := NewPopulationMonitor(MonitorConfig{
+		Scape:           oneDimScape{},
+		Mutation:        PerturbWeightAt{Index: 0, Delta: 0.1},
+		EvolutionType:   EvolutionTypeSteadyState,
+		PopulationSize:  len(initial),
+		EliteCount:      1,
+		Generations:     6,
+		Workers:         1,
+		Seed:            5,
+		InputNeuronIDs:  []string{"i"},
+		OutputNeuronIDs: []string{"o"},
+		CrossoverRate:   1,
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	result, err := monitor.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	crossed := 0
+	for _, record := range result.Lineage {
+		if record.MateID == "" {
+			continue
+		}
+		crossed++
+		if !strings.HasPrefix(record.Operation, "crossover+") {
+			t.Fatalf("expected crossover operation, got %q", record.Operation)
+		}
+	}
+	if crossed == 0 {
+		t.Fatal("expected steady-state replacements bred by crossover")
+	}
+	intra := 0
+	for _, diag := range result.GenerationDiagnostics {
+		intra += diag.CrossoverIntra
+	}
+	if intra == 0 {
+		t.Fatal("expected steady-state diagnostics to report crossover offspring")
+	}
+}
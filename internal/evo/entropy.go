@@ -0,0 +1,257 @@
+package evo
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"protogonos/internal/model"
+)
+
+// Entropy measures and actions of the entropy restart detector.
+const (
+	EntropyMeasureGenotype = "genotype"
+	EntropyMeasureBehavior = "behavior"
+
+	EntropyActionMutation   = "mutation"
+	EntropyActionImmigrants = "immigrants"
+	EntropyActionRestart    = "restart"
+)
+
+const (
+	defaultEntropyCooldown = 5
+	// entropyMutationBoost multiplies mutation counts while a mutation
+	// action is active.
+	entropyMutationBoost = 3
+	// entropyImmigrantFraction of the population is replaced by an
+	// immigrants action.
+	entropyImmigrantFraction = 0.25
+)
+
+// entropyDetector holds the state of the entropy restart detector between
+// generations: the action due on the next reproduction, the generation the
+// detector is quiet until, and the end of an active mutation boost.
+type entropyDetector struct {
+	pending    string
+	quietUntil int
+	boostUntil int
+}
+
+func (m *PopulationMonitor) entropyEnabled() bool {
+	return m.cfg.EntropyThreshold > 0
+}
+
+func validateEntropyRestart(cfg *MonitorConfig) error {
+	if cfg.EntropyThreshold < 0 || cfg.EntropyThreshold > 1 || math.IsNaN(cfg.EntropyThreshold) {
+		return fmt.Errorf("entropy threshold must be in [0, 1], got %g", cfg.EntropyThreshold)
+	}
+	if cfg.EntropyCooldown < 0 {
+		return fmt.Errorf("entropy cooldown must be >= 0")
+	}
+	if cfg.EntropyThreshold == 0 {
+		return nil
+	}
+	if cfg.EntropyMeasure == "" {
+		cfg.EntropyMeasure = EntropyMeasureGenotype
+	}
+	if cfg.EntropyAction == "" {
+		cfg.EntropyAction = EntropyActionMutation
+	}
+	if cfg.EntropyCooldown == 0 {
+		cfg.EntropyCooldown = defaultEntropyCooldown
+	}
+	switch cfg.EntropyMeasure {
+	case EntropyMeasureGenotype, EntropyMeasureBehavior:
+	default:
+		return fmt.Errorf("unsupported entropy measure: %s", cfg.EntropyMeasure)
+	}
+	switch cfg.EntropyAction {
+	case EntropyActionMutation:
+	case EntropyActionImmigrants, EntropyActionRestart:
+		if cfg.NewcomerFactory == nil {
+			return fmt.Errorf("entropy action %s requires a newcomer factory", cfg.EntropyAction)
+		}
+	default:
+		return fmt.Errorf("unsupported entropy action: %s", cfg.EntropyAction)
+	}
+	return nil
+}
+
+// GenotypeEntropy is the Shannon entropy of the population's topology
+// fingerprints, normalized by log(population size) to [0, 1].
+func GenotypeEntropy(scored []ScoredGenome) float64 {
+	keys := make([]string, len(scored))
+	for i, item := range scored {
+		keys[i] = ComputeGenomeSignature(item.Genome).Fingerprint
+	}
+	return normalizedEntropy(keys)
+}
+
+// BehaviorEntropy is the normalized Shannon entropy of the population's
+// fitness outcomes at six significant digits, a proxy for how many distinct
+// behaviours the population expresses.
+func BehaviorEntropy(scored []ScoredGenome) float64 {
+	keys := make([]string, len(scored))
+	for i, item := range scored {
+		keys[i] = strconv.FormatFloat(item.Fitness, 'g', 6, 64)
+	}
+	return normalizedEntropy(keys)
+}
+
+func normalizedEntropy(keys []string) float64 {
+	if len(keys) < 2 {
+		return 0
+	}
+	counts := map[string]int{}
+	for _, key := range keys {
+		counts[key]++
+	}
+	total := float64(len(keys))
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / total
+		entropy -= p * math.Log(p)
+	}
+	return entropy / math.Log(total)
+}
+
+// detectEntropy reports the generation's population entropy and, when the
+// configured measure falls below the threshold outside the cooldown, arms
+// the configured action for the next reproduction. Every low reading is
+// logged with its decision.
+func (m *PopulationMonitor) detectEntropy(diag *GenerationDiagnostics, scored []ScoredGenome, generation int) {
+	if !m.entropyEnabled() {
+		return
+	}
+	diag.GenotypeEntropy = GenotypeEntropy(scored)
+	diag.BehaviorEntropy = BehaviorEntropy(scored)
+	value := diag.GenotypeEntropy
+	if m.cfg.EntropyMeasure == EntropyMeasureBehavior {
+		value = diag.BehaviorEntropy
+	}
+	if value >= m.cfg.EntropyThreshold {
+		return
+	}
+	reading := fmt.Sprintf("%s entropy %.4f < %g", m.cfg.EntropyMeasure, value, m.cfg.EntropyThreshold)
+	if generation < m.entropy.quietUntil {
+		m.events.record(EvolutionEvent{
+			Generation: diag.Generation,
+			Type:       EventEntropyLow,
+			Value:      value,
+			Detail:     fmt.Sprintf("%s: cooldown until generation %d", reading, m.entropy.quietUntil),
+		})
+		return
+	}
+	m.entropy.pending = m.cfg.EntropyAction
+	m.entropy.quietUntil = generation + m.cfg.EntropyCooldown
+	diag.EntropyAction = m.cfg.EntropyAction
+	m.events.record(EvolutionEvent{
+		Generation: diag.Generation,
+		Type:       EventEntropyLow,
+		Value:      value,
+		Detail:     fmt.Sprintf("%s: %s", reading, m.entropyActionDetail()),
+	})
+}
+
+func (m *PopulationMonitor) entropyActionDetail() string {
+	switch m.cfg.EntropyAction {
+	case EntropyActionMutation:
+		return fmt.Sprintf("mutation x%d for %d generations", entropyMutationBoost, m.cfg.EntropyCooldown)
+	case EntropyActionImmigrants:
+		return fmt.Sprintf("immigrants %d", m.entropyReplacements())
+	default:
+		return fmt.Sprintf("restart %d keeping %d", m.entropyReplacements(), m.entropyKeep())
+	}
+}
+
+// entropyMutationCount applies an active mutation boost to count.
+func (m *PopulationMonitor) entropyMutationCount(count, generation int) int {
+	if !m.entropyEnabled() || generation >= m.entropy.boostUntil {
+		return count
+	}
+	return count * entropyMutationBoost
+}
+
+// beginEntropyReproduction starts a pending mutation boost before the
+// population reproducing from generation is bred.
+func (m *PopulationMonitor) beginEntropyReproduction(generation int) {
+	if m.entropy.pending != EntropyActionMutation {
+		return
+	}
+	m.entropy.pending = ""
+	m.entropy.boostUntil = generation + m.cfg.EntropyCooldown
+}
+
+// entropyKeep is how many leading genomes (elites, or the best in ranked
+// order) an immigrants or restart action never replaces.
+func (m *PopulationMonitor) entropyKeep() int {
+	return m.cfg.EliteCount
+}
+
+func (m *PopulationMonitor) entropyReplacements() int {
+	available := m.cfg.PopulationSize - m.entropyKeep()
+	if available < 0 {
+		available = 0
+	}
+	if m.cfg.EntropyAction == EntropyActionRestart {
+		return available
+	}
+	count := int(math.Ceil(entropyImmigrantFraction * float64(m.cfg.PopulationSize)))
+	if count > available {
+		count = available
+	}
+	return count
+}
+
+// finishEntropyReproduction applies a pending immigrants or restart action
+// to the next population by replacing its trailing genomes with newcomers.
+func (m *PopulationMonitor) finishEntropyReproduction(next []model.Genome, lineage []LineageRecord, generation int) ([]model.Genome, []LineageRecord, error) {
+	defer m.phases.reproduced(time.Now())
+	action := m.entropy.pending
+	if action != EntropyActionImmigrants && action != EntropyActionRestart {
+		return next, lineage, nil
+	}
+	m.entropy.pending = ""
+	count := m.entropyReplacements()
+	if count > len(next)-m.entropyKeep() {
+		count = len(next) - m.entropyKeep()
+	}
+	if count <= 0 {
+		return next, lineage, nil
+	}
+	nextGeneration := generation + 1
+	replaced := make(map[string]struct{}, count)
+	records := make([]LineageRecord, 0, count)
+	for i := 0; i < count; i++ {
+		slot := len(next) - count + i
+		// Index past the population so ids never collide with AFPO newcomers.
+		newcomer, err := m.cfg.NewcomerFactory(nextGeneration, len(next)+i)
+		if err != nil {
+			return nil, nil, fmt.Errorf("entropy %s: %w", action, err)
+		}
+		newcomer = m.innovations.Mark(newcomer)
+		replaced[next[slot].ID] = struct{}{}
+		if _, aged := m.ages[next[slot].ID]; aged {
+			delete(m.ages, next[slot].ID)
+			m.ages[newcomer.ID] = 0
+		}
+		next[slot] = newcomer
+		sig := ComputeGenomeSignature(newcomer)
+		records = append(records, LineageRecord{
+			GenomeID:    newcomer.ID,
+			Generation:  nextGeneration,
+			Operation:   "entropy_" + action,
+			Fingerprint: sig.Fingerprint,
+			Summary:     sig.Summary,
+		})
+	}
+	kept := lineage[:0]
+	for _, record := range lineage {
+		if _, ok := replaced[record.GenomeID]; ok {
+			continue
+		}
+		kept = append(kept, record)
+	}
+	return next, append(kept, records...), nil
+}
@@ -0,0 +1,150 @@
+package evo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func TestPopulationEntropyMeasures(t *testing.T) {
+	linear := newLinearGenome("a", 0.1)
+	wide := newLinearGenome("b", 0.1)
+	wide.Neurons = append(wide.Neurons, model.Neuron{ID: "h", Activation: "tanh"})
+	scored := []ScoredGenome{
+		{Genome: linear, Fitness: 1},
+		{Genome: newLinearGenome("c", 0.3), Fitness: 1},
+		{Genome: wide, Fitness: 2},
+		{Genome: newLinearGenome("d", 0.5), Fitness: 3},
+	}
+	// Fingerprints split 3:1, fitness outcomes 2:1:1, over log(4).
+	wantGenotype := -(0.75*math.Log(0.75) + 0.25*math.Log(0.25)) / math.Log(4)
+	if got := GenotypeEntropy(scored); math.Abs(got-wantGenotype) > 1e-12 {
+		t.Fatalf("genotype entropy=%f, want %f", got, wantGenotype)
+	}
+	wantBehavior := -(0.5*math.Log(0.5) + 2*0.25*math.Log(0.25)) / math.Log(4)
+	if got := BehaviorEntropy(scored); math.Abs(got-wantBehavior) > 1e-12 {
+		t.Fatalf("behavior entropy=%f, want %f", got, wantBehavior)
+	}
+	if got := BehaviorEntropy(scored[:1]); got != 0 {
+		t.Fatalf("expected a single genome to have zero entropy, got %f", got)
+	}
+}
+
+func TestPopulationMonitorEntropyImmigrantsWithCooldown(t *testing.T) {
+	initial := make([]model.Genome, 8)
+	for i := range initial {
+		initial[i] = newLinearGenome(fmt.Sprintf("g%d", i), -1+0.2*float64(i))
+	}
+	monitor, err := NewPopulationMonitor(MonitorConfig{
+		Scape:            oneDimScape{},
+		Mutation:         namedNoopMutation{name: "noop"},
+		PopulationSize:   len(initial),
+		EliteCount:       1,
+		Generations:      4,
+		Workers:          2,
+		Seed:             3,
+		InputNeuronIDs:   []string{"i"},
+		OutputNeuronIDs:  []string{"o"},
+		EntropyThreshold: 0.5,
+		EntropyAction:    EntropyActionImmigrants,
+		EntropyCooldown:  2,
+		NewcomerFactory: func(generation, index int) (model.Genome, error) {
+			return newLinearGenome(fmt.Sprintf("new-g%d-n%d", generation, index), 0.5), nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	result, err := monitor.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	// Every genome shares one topology, so genotype entropy stays at zero:
+	// the detector fires, rests a generation, then fires again.
+	var actions []string
+	for _, diag := range result.GenerationDiagnostics {
+		if diag.GenotypeEntropy != 0 {
+			t.Fatalf("expected zero genotype entropy, got %+v", diag)
+		}
+		actions = append(actions, diag.EntropyAction)
+	}
+	if strings.Join(actions, ",") != "immigrants,,immigrants," {
+		t.Fatalf("unexpected entropy actions: %q", actions)
+	}
+	var details []string
+	for _, event := range result.Events {
+		if event.Type == EventEntropyLow {
+			details = append(details, event.Detail)
+		}
+	}
+	if len(details) != 4 ||
+		details[0] != "genotype entropy 0.0000 < 0.5: immigrants 2" ||
+		details[1] != "genotype entropy 0.0000 < 0.5: cooldown until generation 2" {
+		t.Fatalf("unexpected entropy events: %q", details)
+	}
+
+	immigrants := 0
+	for _, record := range result.Lineage {
+		if record.Operation != "entropy_immigrants" {
+			continue
+		}
+		immigrants++
+		if record.ParentID != "" || !strings.HasPrefix(record.GenomeID, "new-g") {
+			t.Fatalf("unexpected immigrant lineage record: %+v", record)
+		}
+	}
+	if immigrants != 4 {
+		t.Fatalf("expected two immigrants per triggered generation, got %d", immigrants)
+	}
+}
+
+func TestEntropyMutationBoostAndValidation(t *testing.T) {
+	monitor, err := NewPopulationMonitor(MonitorConfig{
+		Scape:            oneDimScape{},
+		Mutation:         namedNoopMutation{name: "noop"},
+		PopulationSize:   4,
+		EliteCount:       1,
+		Generations:      1,
+		InputNeuronIDs:   []string{"i"},
+		OutputNeuronIDs:  []string{"o"},
+		EntropyThreshold: 0.3,
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	if monitor.cfg.EntropyMeasure != EntropyMeasureGenotype || monitor.cfg.EntropyAction != EntropyActionMutation || monitor.cfg.EntropyCooldown != defaultEntropyCooldown {
+		t.Fatalf("unexpected entropy defaults: %+v", monitor.cfg)
+	}
+	monitor.resetRunState()
+	monitor.entropy.pending = EntropyActionMutation
+	monitor.beginEntropyReproduction(4)
+	if got := monitor.entropyMutationCount(2, 4); got != 2*entropyMutationBoost {
+		t.Fatalf("expected boosted mutation count, got %d", got)
+	}
+	if got := monitor.entropyMutationCount(2, 4+defaultEntropyCooldown); got != 2 {
+		t.Fatalf("expected boost to expire after the cooldown, got %d", got)
+	}
+
+	for _, cfg := range []MonitorConfig{
+		{EntropyThreshold: 1.5},
+		{EntropyThreshold: 0.3, EntropyMeasure: "phenotype"},
+		{EntropyThreshold: 0.3, EntropyAction: "explode"},
+		{EntropyThreshold: 0.3, EntropyAction: EntropyActionRestart},
+	} {
+		cfg.Scape = oneDimScape{}
+		cfg.Mutation = namedNoopMutation{name: "noop"}
+		cfg.PopulationSize = 4
+		cfg.EliteCount = 1
+		cfg.Generations = 1
+		cfg.InputNeuronIDs = []string{"i"}
+		cfg.OutputNeuronIDs = []string{"o"}
+		if _, err := NewPopulationMonitor(cfg); err == nil {
+			t.Fatalf("expected entropy config %+v to be rejected", cfg)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package evo
+
+import (
+	"context"
+	"runtime"
+	"runtime/metrics"
+	"sync"
+	"time"
+
+	"protogonos/internal/model"
+)
+
+const heapAllocsMetric = "/gc/heap/allocs:bytes"
+
+type EvaluationCostStats = model.EvaluationCostStats
+
+// EvaluationCost is the metered cost of a genome's scape evaluations,
+// including tuning candidates. CPU time is the evaluating thread's own time
+// where the platform reports it (Linux) and wall time elsewhere. Allocated
+// bytes are process-wide deltas, so they are exact with one worker and
+// include concurrent workers' allocations otherwise.
+type EvaluationCost struct {
+	Evaluations int
+	CPUSeconds  float64
+	AllocBytes  uint64
+}
+
+// MeanCPUSeconds returns the CPU seconds per evaluation.
+func (c EvaluationCost) MeanCPUSeconds() float64 {
+	if c.Evaluations == 0 {
+		return 0
+	}
+	return c.CPUSeconds / float64(c.Evaluations)
+}
+
+// MeanAllocBytes returns the allocated bytes per evaluation.
+func (c EvaluationCost) MeanAllocBytes() float64 {
+	if c.Evaluations == 0 {
+		return 0
+	}
+	return float64(c.AllocBytes) / float64(c.Evaluations)
+}
+
+// evaluationMeter accumulates the cost of one job's evaluations.
+type evaluationMeter struct {
+	mu   sync.Mutex
+	cost EvaluationCost
+}
+
+type evaluationMeterKey struct{}
+
+func withEvaluationMeter(ctx context.Context, meter *evaluationMeter) context.Context {
+	return context.WithValue(ctx, evaluationMeterKey{}, meter)
+}
+
+func evaluationMeterFrom(ctx context.Context) *evaluationMeter {
+	meter, _ := ctx.Value(evaluationMeterKey{}).(*evaluationMeter)
+	return meter
+}
+
+// Cost returns the accumulated cost; nil meters report zero.
+func (m *evaluationMeter) Cost() EvaluationCost {
+	if m == nil {
+		return EvaluationCost{}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cost
+}
+
+// start samples the evaluating thread and heap before an evaluation and
+// returns the function that records its cost. The goroutine stays on its
+// thread in between so the thread CPU delta is its own.
+func (m *evaluationMeter) start() func() {
+	if m == nil {
+		return func() {}
+	}
+	runtime.LockOSThread()
+	began := time.Now()
+	cpuStart, threadCPU := threadCPUSeconds()
+	allocStart := heapAllocBytes()
+	return func() {
+		allocEnd := heapAllocBytes()
+		cpu := time.Since(began).Seconds()
+		if threadCPU {
+			if cpuEnd, ok := threadCPUSeconds(); ok && cpuEnd >= cpuStart {
+				cpu = cpuEnd - cpuStart
+			}
+		}
+		runtime.UnlockOSThread()
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.cost.Evaluations++
+		m.cost.CPUSeconds += cpu
+		if allocEnd > allocStart {
+			m.cost.AllocBytes += allocEnd - allocStart
+		}
+	}
+}
+
+func heapAllocBytes() uint64 {
+	sample := []metrics.Sample{{Name: heapAllocsMetric}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
+
+// SummarizeEvaluationCost aggregates the metered costs of a generation; it
+// returns nil when nothing was metered. MaxCPUSeconds is the highest
+// per-evaluation mean of any one genome.
+func SummarizeEvaluationCost(scored []ScoredGenome) *EvaluationCostStats {
+	var summary EvaluationCostStats
+	for _, item := range scored {
+		if item.Cost.Evaluations == 0 {
+			continue
+		}
+		summary.Evaluations += item.Cost.Evaluations
+		summary.CPUSeconds += item.Cost.CPUSeconds
+		summary.AllocBytes += item.Cost.AllocBytes
+		if mean := item.Cost.MeanCPUSeconds(); mean > summary.MaxCPUSeconds {
+			summary.MaxCPUSeconds = mean
+		}
+	}
+	if summary.Evaluations == 0 {
+		return nil
+	}
+	summary.MeanCPUSeconds = summary.CPUSeconds / float64(summary.Evaluations)
+	summary.MeanAllocBytes = float64(summary.AllocBytes) / float64(summary.Evaluations)
+	return &summary
+}
@@ -0,0 +1,21 @@
+//go:build linux
+
+package evo
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// clockThreadCPUTimeID is CLOCK_THREAD_CPUTIME_ID, which unlike
+// RUSAGE_THREAD resolves below a microsecond.
+const clockThreadCPUTimeID = 3
+
+// threadCPUSeconds returns the calling thread's CPU time.
+func threadCPUSeconds() (float64, bool) {
+	var ts syscall.Timespec
+	if _, _, errno := syscall.Syscall(syscall.SYS_CLOCK_GETTIME, clockThreadCPUTimeID, uintptr(unsafe.Pointer(&ts)), 0); errno != 0 {
+		return 0, false
+	}
+	return float64(ts.Sec) + float64(ts.Nsec)/1e9, true
+}
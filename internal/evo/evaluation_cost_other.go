@@ -0,0 +1,9 @@
+//go:build !linux
+
+package evo
+
+// threadCPUSeconds is unavailable off Linux; evaluations are metered by
+// wall time instead.
+func threadCPUSeconds() (float64, bool) {
+	return 0, false
+}
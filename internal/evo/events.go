@@ -0,0 +1,130 @@
+package evo
+
+import (
+	"strconv"
+
+	"protogonos/internal/model"
+)
+
+type EvolutionEvent = model.EvolutionEvent
+
+// Event types recorded in RunResult.Events.
+const (
+	EventSpeciesCreated      = "species_created"
+	EventSpeciesExtinct      = "species_extinct"
+	EventChampionImproved    = "champion_improved"
+	EventStagnationTriggered = "stagnation_triggered"
+	EventCurriculumAdvanced  = "curriculum_advanced"
+	EventMonitorAction       = "monitor_action"
+	EventEntropyLow          = "entropy_low"
+)
+
+// EventTypes lists every event type in the order it is documented.
+func EventTypes() []string {
+	return []string{
+		EventSpeciesCreated,
+		EventSpeciesExtinct,
+		EventChampionImproved,
+		EventStagnationTriggered,
+		EventCurriculumAdvanced,
+		EventMonitorAction,
+		EventEntropyLow,
+	}
+}
+
+func IsEventType(eventType string) bool {
+	for _, known := range EventTypes() {
+		if eventType == known {
+			return true
+		}
+	}
+	return false
+}
+
+// eventLog accumulates a run's events. generation is the generation an event
+// raised outside the generation bookkeeping (a control command) is filed
+// under: the one being evaluated, or the last one evaluated between
+// generations.
+type eventLog struct {
+	events      []EvolutionEvent
+	generation  int
+	champion    float64
+	hasChampion bool
+}
+
+func newEventLog(generation int) *eventLog {
+	return &eventLog{generation: generation}
+}
+
+func (l *eventLog) record(event EvolutionEvent) {
+	l.events = append(l.events, event)
+}
+
+// recordMonitorAction logs a control command received by the monitor.
+func (m *PopulationMonitor) recordMonitorAction(cmd MonitorCommand) {
+	m.events.record(EvolutionEvent{
+		Generation: m.events.generation,
+		Type:       EventMonitorAction,
+		Detail:     string(cmd),
+	})
+}
+
+// recordCurriculum logs scape parameter changes taking effect at the start
+// of generation.
+func (m *PopulationMonitor) recordCurriculum(generation int, changes []ScapeParamChange) {
+	for _, change := range changes {
+		m.events.record(EvolutionEvent{
+			Generation: generation,
+			Type:       EventCurriculumAdvanced,
+			Value:      change.Value,
+			Previous:   change.Previous,
+			Detail: change.Name + ": " + strconv.FormatFloat(change.Previous, 'g', -1, 64) +
+				" -> " + strconv.FormatFloat(change.Value, 'g', -1, 64),
+		})
+	}
+}
+
+// recordGenerationEvents logs species turnover and champion improvements of
+// an evaluated generation. The first generation of a run sets the champion
+// baseline without an event.
+func (m *PopulationMonitor) recordGenerationEvents(ranked []ScoredGenome, history SpeciesGeneration) {
+	for _, key := range history.NewSpecies {
+		m.events.record(EvolutionEvent{Generation: history.Generation, Type: EventSpeciesCreated, SpeciesKey: key})
+	}
+	for _, key := range history.ExtinctSpecies {
+		m.events.record(EvolutionEvent{Generation: history.Generation, Type: EventSpeciesExtinct, SpeciesKey: key})
+	}
+	if len(ranked) == 0 {
+		return
+	}
+	best := ranked[0]
+	if m.events.hasChampion && best.Fitness > m.events.champion {
+		m.events.record(EvolutionEvent{
+			Generation: history.Generation,
+			Type:       EventChampionImproved,
+			GenomeID:   best.Genome.ID,
+			Value:      best.Fitness,
+			Previous:   m.events.champion,
+		})
+	}
+	if !m.events.hasChampion || best.Fitness > m.events.champion {
+		m.events.champion = best.Fitness
+		m.events.hasChampion = true
+	}
+}
+
+// recordStagnation logs species the selector excluded as stagnant while
+// breeding from generation.
+func (m *PopulationMonitor) recordStagnation(generation int) {
+	reporter, ok := m.cfg.Selector.(StagnationReporter)
+	if !ok {
+		return
+	}
+	for _, key := range reporter.DrainStagnantSpecies() {
+		m.events.record(EvolutionEvent{
+			Generation: generation,
+			Type:       EventStagnationTriggered,
+			SpeciesKey: key,
+		})
+	}
+}
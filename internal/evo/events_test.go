@@ -0,0 +1,94 @@
+package evo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"protogonos/internal/model"
+)
+
+func TestPopulationMonitorRecordsEventLog(t *testing.T) {
+	initial := []model.Genome{
+		newLinearGenome("g0", 0.1),
+		newLinearGenome("g1", 0.2),
+	}
+	control := make(chan MonitorCommand, 4)
+	control <- CommandPause
+
+	monitor, err := NewPopulationMonitor(MonitorConfig{
+		Scape:           levelScape{},
+		Mutation:        namedNoopMutation{name: "noop"},
+		PopulationSize:  len(initial),
+		EliteCount:      1,
+		Generations:     3,
+		Workers:         2,
+		Seed:            1,
+		InputNeuronIDs:  []string{"i"},
+		OutputNeuronIDs: []string{"o"},
+		Control:         control,
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+
+	done := make(chan RunResult, 1)
+	errs := make(chan error, 1)
+	go func() {
+		result, runErr := monitor.Run(context.Background(), initial)
+		if runErr != nil {
+			errs <- runErr
+			return
+		}
+		done <- result
+	}()
+	time.Sleep(30 * time.Millisecond)
+
+	control <- SetScapeParamCommand("level", 4)
+	control <- CommandContinue
+
+	var result RunResult
+	select {
+	case runErr := <-errs:
+		t.Fatalf("run failed: %v", runErr)
+	case result = <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for run completion")
+	}
+
+	want := []EvolutionEvent{
+		{Generation: 0, Type: EventMonitorAction, Detail: string(CommandPause)},
+		{Generation: 1, Type: EventSpeciesCreated},
+		{Generation: 1, Type: EventMonitorAction, Detail: string(SetScapeParamCommand("level", 4))},
+		{Generation: 1, Type: EventMonitorAction, Detail: string(CommandContinue)},
+		{Generation: 2, Type: EventCurriculumAdvanced, Value: 4, Previous: 1, Detail: "level: 1 -> 4"},
+		{Generation: 2, Type: EventChampionImproved, Value: 4, Previous: 1},
+	}
+	if len(result.Events) != len(want) {
+		t.Fatalf("unexpected event log: %+v", result.Events)
+	}
+	for i, event := range result.Events {
+		expected := want[i]
+		if event.Generation != expected.Generation || event.Type != expected.Type || event.Detail != expected.Detail ||
+			event.Value != expected.Value || event.Previous != expected.Previous {
+			t.Fatalf("event %d: got %+v want %+v", i, event, expected)
+		}
+	}
+	if result.Events[1].SpeciesKey == "" {
+		t.Fatalf("expected species key on species_created event: %+v", result.Events[1])
+	}
+	if result.Events[5].GenomeID == "" {
+		t.Fatalf("expected champion genome id on champion_improved event: %+v", result.Events[5])
+	}
+}
+
+func TestIsEventType(t *testing.T) {
+	for _, eventType := range EventTypes() {
+		if !IsEventType(eventType) {
+			t.Fatalf("expected %q to be a known event type", eventType)
+		}
+	}
+	if IsEventType("champion") {
+		t.Fatal("expected unknown event type to be rejected")
+	}
+}
@@ -2,9 +2,14 @@ package evo
 
 import (
 	"math"
+	"sort"
 )
 
-const sizeProportionalEfficiency = 0.05
+const (
+	sizeProportionalEfficiency = 0.05
+	costProportionalEfficiency = 0.05
+	numericFragilityRate       = 0.01
+)
 
 // FitnessPostprocessor adjusts fitness values after scape evaluation and
 // before ranking/selection.
@@ -46,8 +51,8 @@ func (SizeProportionalPostprocessor) Process(scored []ScoredGenome) []ScoredGeno
 // novelty based on topology-level differences.
 //
 // Reference DXNN2 leaves novelty_proportional as a placeholder (`void`).
-// Keep this as a no-op for parity and stability until a reference-backed
-// novelty definition is adopted.
+// Keep this as a no-op for parity and stability; NoveltyArchivePostprocessor
+// is the behavior-based novelty search.
 type NoveltyProportionalPostprocessor struct{}
 
 func (NoveltyProportionalPostprocessor) Name() string {
@@ -58,6 +63,68 @@ func (NoveltyProportionalPostprocessor) Process(scored []ScoredGenome) []ScoredG
 	return cloneScored(scored)
 }
 
+// NumericFragilityPostprocessor penalizes genomes that needed numeric guard
+// interventions (NaN/Inf replacement, activation or weight clamps). Each
+// event costs 1% of the fitness magnitude, capped at the full magnitude, so
+// the penalty also pushes negative fitness further down.
+type NumericFragilityPostprocessor struct{}
+
+func (NumericFragilityPostprocessor) Name() string {
+	return "numeric_fragility"
+}
+
+func (NumericFragilityPostprocessor) Process(scored []ScoredGenome) []ScoredGenome {
+	out := cloneScored(scored)
+	for i := range out {
+		events := out[i].Clamps.Total()
+		if events == 0 {
+			continue
+		}
+		penalty := math.Min(1, numericFragilityRate*float64(events))
+		out[i].Fitness -= math.Abs(out[i].Fitness) * penalty
+	}
+	return out
+}
+
+// CostProportionalPostprocessor trades fitness against measured evaluation
+// cost rather than genome size. Each genome's mean CPU seconds per
+// evaluation is taken relative to the generation median, and its fitness
+// moves by |fitness| * (1 - relative^-0.05): genomes costlier than the
+// median lose fitness, cheaper ones gain it, and negative fitness is pushed
+// the same way. Genomes without a metered cost are left unchanged.
+type CostProportionalPostprocessor struct{}
+
+func (CostProportionalPostprocessor) Name() string {
+	return "cost_proportional"
+}
+
+func (CostProportionalPostprocessor) Process(scored []ScoredGenome) []ScoredGenome {
+	out := cloneScored(scored)
+	costs := make([]float64, 0, len(out))
+	for _, item := range out {
+		if cost := item.Cost.MeanCPUSeconds(); cost > 0 {
+			costs = append(costs, cost)
+		}
+	}
+	if len(costs) < 2 {
+		return out
+	}
+	sort.Float64s(costs)
+	median := costs[len(costs)/2]
+	if len(costs)%2 == 0 {
+		median = (costs[len(costs)/2-1] + costs[len(costs)/2]) / 2
+	}
+	for i := range out {
+		cost := out[i].Cost.MeanCPUSeconds()
+		if cost <= 0 {
+			continue
+		}
+		penalty := 1 - math.Pow(cost/median, -costProportionalEfficiency)
+		out[i].Fitness -= math.Abs(out[i].Fitness) * penalty
+	}
+	return out
+}
+
 func cloneScored(scored []ScoredGenome) []ScoredGenome {
 	out := make([]ScoredGenome, len(scored))
 	copy(out, scored)
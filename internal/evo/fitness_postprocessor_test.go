@@ -1,8 +1,12 @@
 package evo
 
 import (
+	"context"
 	"math"
 	"testing"
+
+	"protogonos/internal/model"
+	"protogonos/internal/nn"
 )
 
 func TestSizeProportionalPostprocessorUsesReferenceEfficiencyExponent(t *testing.T) {
@@ -55,3 +59,133 @@ func TestNoveltyProportionalPostprocessorIsNoopForReferenceParity(t *testing.T)
 		t.Fatal("expected postprocessor output to be cloned from input")
 	}
 }
+
+func TestNumericFragilityPostprocessorPenalizesClampEvents(t *testing.T) {
+	scored := []ScoredGenome{
+		{Genome: newLinearGenome("clean", 1), Fitness: 2},
+		{Genome: newLinearGenome("fragile", 1), Fitness: 2, Clamps: nn.ClampCounts{NonFinite: 5, Weight: 5}},
+		{Genome: newLinearGenome("negative", 1), Fitness: -2, Clamps: nn.ClampCounts{Activation: 10}},
+		{Genome: newLinearGenome("broken", 1), Fitness: 2, Clamps: nn.ClampCounts{Activation: 500}},
+	}
+	out := NumericFragilityPostprocessor{}.Process(scored)
+	want := []float64{2, 1.8, -2.2, 0}
+	for i := range want {
+		if math.Abs(out[i].Fitness-want[i]) > 1e-9 {
+			t.Fatalf("genome %s: got fitness %f want %f", out[i].Genome.ID, out[i].Fitness, want[i])
+		}
+	}
+	if scored[1].Fitness != 2 {
+		t.Fatal("expected postprocessor output to be cloned from input")
+	}
+}
+
+func TestCostProportionalPostprocessorTradesFitnessForCost(t *testing.T) {
+	scored := []ScoredGenome{
+		{Genome: newLinearGenome("cheap", 1), Fitness: 2, Cost: EvaluationCost{Evaluations: 2, CPUSeconds: 0.001}},
+		{Genome: newLinearGenome("median", 1), Fitness: 2, Cost: EvaluationCost{Evaluations: 1, CPUSeconds: 0.001}},
+		{Genome: newLinearGenome("median2", 1), Fitness: 2, Cost: EvaluationCost{Evaluations: 3, CPUSeconds: 0.003}},
+		{Genome: newLinearGenome("costly", 1), Fitness: 2, Cost: EvaluationCost{Evaluations: 1, CPUSeconds: 0.002}},
+		{Genome: newLinearGenome("negative", 1), Fitness: -2, Cost: EvaluationCost{Evaluations: 1, CPUSeconds: 0.002}},
+		{Genome: newLinearGenome("unmetered", 1), Fitness: 2},
+	}
+	out := CostProportionalPostprocessor{}.Process(scored)
+	penalty := 2 * (1 - math.Pow(2, -costProportionalEfficiency))
+	boost := 2 * (math.Pow(0.5, -costProportionalEfficiency) - 1)
+	want := []float64{2 + boost, 2, 2, 2 - penalty, -2 - penalty, 2}
+	for i := range want {
+		if math.Abs(out[i].Fitness-want[i]) > 1e-9 {
+			t.Fatalf("genome %s: got fitness %f want %f", out[i].Genome.ID, out[i].Fitness, want[i])
+		}
+	}
+	if scored[3].Fitness != 2 {
+		t.Fatal("expected postprocessor output to be cloned from input")
+	}
+}
+
+func TestPopulationMonitorMetersEvaluationCost(t *testing.T) {
+	initial := []model.Genome{
+		newLinearGenome("a", 0.5),
+		newLinearGenome("b", 1),
+		newLinearGenome("c", 1.5),
+	}
+	run := func(postprocessor FitnessPostprocessor) []GenerationDiagnostics {
+		t.Helper()
+		monitor, err := NewPopulationMonitor(MonitorConfig{
+			Scape:           oneDimScape{},
+			Mutation:        PerturbWeightAt{Index: 0, Delta: 0.1},
+			Postprocessor:   postprocessor,
+			PopulationSize:  len(initial),
+			EliteCount:      1,
+			Generations:     2,
+			Workers:         2,
+			Seed:            3,
+			InputNeuronIDs:  []string{"i"},
+			OutputNeuronIDs: []string{"o"},
+		})
+		if err != nil {
+			t.Fatalf("new monitor: %v", err)
+		}
+		result, err := monitor.Run(context.Background(), initial)
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+		return result.GenerationDiagnostics
+	}
+
+	for _, diag := range run(CostProportionalPostprocessor{}) {
+		cost := diag.EvaluationCost
+		if cost == nil {
+			t.Fatalf("generation %d: expected cost_proportional to meter evaluations", diag.Generation)
+		}
+		if cost.Evaluations != len(initial) || cost.CPUSeconds <= 0 || cost.MaxCPUSeconds < cost.MeanCPUSeconds {
+			t.Fatalf("generation %d: unexpected evaluation cost %+v", diag.Generation, *cost)
+		}
+	}
+	for _, diag := range run(NoopFitnessPostprocessor{}) {
+		if diag.EvaluationCost != nil {
+			t.Fatalf("generation %d: expected no evaluation cost without metering", diag.Generation)
+		}
+	}
+}
+
+func TestPopulationMonitorCountsClampEventsPerGenome(t *testing.T) {
+	initial := []model.Genome{
+		newLinearGenome("tame", 0.5),
+		newLinearGenome("wild", 80),
+	}
+	monitor, err := NewPopulationMonitor(MonitorConfig{
+		Scape:           oneDimScape{},
+		Mutation:        PerturbWeightAt{Index: 0, Delta: 0.1},
+		Postprocessor:   NumericFragilityPostprocessor{},
+		PopulationSize:  len(initial),
+		EliteCount:      1,
+		Generations:     1,
+		Workers:         1,
+		Seed:            2,
+		InputNeuronIDs:  []string{"i"},
+		OutputNeuronIDs: []string{"o"},
+		WeightClamp:     10,
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	result, err := monitor.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if got := result.GenerationDiagnostics[0].ClampEvents; got != 1 {
+		t.Fatalf("expected a single weight clamp in the generation, got %d", got)
+	}
+
+	if _, err := NewPopulationMonitor(MonitorConfig{
+		Scape:           oneDimScape{},
+		Mutation:        PerturbWeightAt{Index: 0, Delta: 0.1},
+		PopulationSize:  1,
+		Generations:     1,
+		InputNeuronIDs:  []string{"i"},
+		OutputNeuronIDs: []string{"o"},
+		ActivationClamp: -1,
+	}); err == nil {
+		t.Fatal("expected negative activation clamp to be rejected")
+	}
+}
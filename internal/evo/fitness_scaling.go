@@ -0,0 +1,144 @@
+package evo
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	FitnessScalingNone   = "none"
+	FitnessScalingSigma  = "sigma"
+	FitnessScalingRank   = "rank"
+	FitnessScalingMinMax = "minmax"
+
+	// DefaultSigmaScalingC is the number of standard deviations below the
+	// mean at which sigma scaling truncates fitness to zero.
+	DefaultSigmaScalingC = 2.0
+	// DefaultRankingPressure is the expected offspring share of the best
+	// genome under linear ranking, relative to the population average.
+	DefaultRankingPressure = 1.5
+)
+
+// FitnessScalingNames lists the supported selection fitness scalings.
+func FitnessScalingNames() []string {
+	return []string{FitnessScalingNone, FitnessScalingSigma, FitnessScalingRank, FitnessScalingMinMax}
+}
+
+// DefaultScalingPressure returns the pressure a scaling mode uses when none
+// is configured: the sigma multiple for sigma, the selective pressure for
+// rank, and zero for modes without a parameter.
+func DefaultScalingPressure(mode string) float64 {
+	switch mode {
+	case FitnessScalingSigma:
+		return DefaultSigmaScalingC
+	case FitnessScalingRank:
+		return DefaultRankingPressure
+	default:
+		return 0
+	}
+}
+
+// ValidateFitnessScaling reports whether mode and pressure form a usable
+// scaling configuration.
+func ValidateFitnessScaling(mode string, pressure float64) error {
+	switch mode {
+	case "", FitnessScalingNone, FitnessScalingMinMax:
+		if pressure != 0 {
+			return fmt.Errorf("fitness scaling %q takes no scaling pressure, got %g", mode, pressure)
+		}
+	case FitnessScalingSigma:
+		if pressure <= 0 || math.IsInf(pressure, 0) || math.IsNaN(pressure) {
+			return fmt.Errorf("sigma scaling pressure must be > 0, got %g", pressure)
+		}
+	case FitnessScalingRank:
+		if pressure < 1 || pressure > 2 {
+			return fmt.Errorf("rank scaling pressure must be in [1,2], got %g", pressure)
+		}
+	default:
+		return fmt.Errorf("unsupported fitness scaling: %s", mode)
+	}
+	return nil
+}
+
+// ScaleFitness returns a copy of ranked, ordered best first, with fitness
+// rescaled for selection only:
+//
+//   - sigma:  f - (mean - c*stddev), truncated at zero (Goldberg's sigma
+//     truncation), with c = pressure
+//   - rank:   linear ranking, 2 - sp + 2*(sp-1)*(n-1-rank)/(n-1) with
+//     sp = pressure; tied genomes share their mean rank
+//   - minmax: (f - min) / (max - min) within the generation
+//
+// Every transform is monotone, so the ranked order stays valid; a generation
+// whose fitness is all equal scales to 1 everywhere. Raw fitness on scapes
+// such as fx can span orders of magnitude, which makes fitness-proportional
+// selectors degenerate into picking the champion; scaling restores a
+// controlled selection pressure.
+func ScaleFitness(ranked []ScoredGenome, mode string, pressure float64) ([]ScoredGenome, error) {
+	if err := ValidateFitnessScaling(mode, pressure); err != nil {
+		return nil, err
+	}
+	out := cloneScored(ranked)
+	if len(out) == 0 {
+		return out, nil
+	}
+	switch mode {
+	case FitnessScalingSigma:
+		mean, variance := 0.0, 0.0
+		for _, item := range out {
+			mean += item.Fitness
+		}
+		mean /= float64(len(out))
+		for _, item := range out {
+			delta := item.Fitness - mean
+			variance += delta * delta
+		}
+		stddev := math.Sqrt(variance / float64(len(out)))
+		if stddev == 0 {
+			setScaledFitness(out, 1)
+			return out, nil
+		}
+		floor := mean - pressure*stddev
+		for i := range out {
+			out[i].Fitness = math.Max(0, out[i].Fitness-floor)
+		}
+	case FitnessScalingRank:
+		n := len(out)
+		if n == 1 {
+			setScaledFitness(out, 1)
+			return out, nil
+		}
+		for start := 0; start < n; {
+			end := start + 1
+			for end < n && out[end].Fitness == out[start].Fitness {
+				end++
+			}
+			meanRank := float64(start+end-1) / 2
+			scaled := 2 - pressure + 2*(pressure-1)*(float64(n-1)-meanRank)/float64(n-1)
+			for i := start; i < end; i++ {
+				out[i].Fitness = scaled
+			}
+			start = end
+		}
+	case FitnessScalingMinMax:
+		lo, hi := out[0].Fitness, out[0].Fitness
+		for _, item := range out[1:] {
+			lo = math.Min(lo, item.Fitness)
+			hi = math.Max(hi, item.Fitness)
+		}
+		if hi == lo {
+			setScaledFitness(out, 1)
+			return out, nil
+		}
+		for i := range out {
+			out[i].Fitness = (out[i].Fitness - lo) / (hi - lo)
+		}
+	}
+	return out, nil
+}
+
+func setScaledFitness(scored []ScoredGenome, fitness float64) {
+	for i := range scored {
+		scored[i].Fitness = fitness
+	}
+}
@@ -0,0 +1,112 @@
+package evo
+
+import (
+	"math"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func scalingFixture(fitness ...float64) []ScoredGenome {
+	scored := make([]ScoredGenome, len(fitness))
+	for i, f := range fitness {
+		scored[i] = ScoredGenome{Genome: model.Genome{ID: string(rune('a' + i))}, Fitness: f}
+	}
+	return scored
+}
+
+func scaledValues(scored []ScoredGenome) []float64 {
+	out := make([]float64, len(scored))
+	for i, item := range scored {
+		out[i] = item.Fitness
+	}
+	return out
+}
+
+func TestScaleFitnessTransforms(t *testing.T) {
+	ranked := scalingFixture(1000, 10, 10, 1)
+	cases := []struct {
+		mode     string
+		pressure float64
+		want     []float64
+	}{
+		{mode: FitnessScalingNone, want: []float64{1000, 10, 10, 1}},
+		{mode: FitnessScalingMinMax, want: []float64{1, 9.0 / 999, 9.0 / 999, 0}},
+		// Ranks 0, 1.5, 1.5, 3 over n=4 with sp=2: 2*(3-rank)/3.
+		{mode: FitnessScalingRank, pressure: 2, want: []float64{2, 1, 1, 0}},
+		{mode: FitnessScalingRank, pressure: 1, want: []float64{1, 1, 1, 1}},
+	}
+	for _, tc := range cases {
+		scaled, err := ScaleFitness(ranked, tc.mode, tc.pressure)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.mode, err)
+		}
+		got := scaledValues(scaled)
+		for i := range tc.want {
+			if math.Abs(got[i]-tc.want[i]) > 1e-12 {
+				t.Fatalf("%s: want %v, got %v", tc.mode, tc.want, got)
+			}
+		}
+		for i := range scaled {
+			if scaled[i].Genome.ID != ranked[i].Genome.ID {
+				t.Fatalf("%s: expected ranked order to be kept", tc.mode)
+			}
+		}
+	}
+	if ranked[0].Fitness != 1000 {
+		t.Fatal("expected scaling to leave the input untouched")
+	}
+
+	// Mean 255.25, stddev ~429.5: with c=0.5 everything but the outlier
+	// truncates to zero, with c=2 all stay positive and keep their gaps.
+	sigma, err := ScaleFitness(ranked, FitnessScalingSigma, 0.5)
+	if err != nil {
+		t.Fatalf("sigma: %v", err)
+	}
+	if got := scaledValues(sigma); got[0] <= 0 || got[1] != 0 || got[3] != 0 {
+		t.Fatalf("unexpected sigma truncation: %v", got)
+	}
+	sigma, err = ScaleFitness(ranked, FitnessScalingSigma, 2)
+	if err != nil {
+		t.Fatalf("sigma: %v", err)
+	}
+	got := scaledValues(sigma)
+	if got[3] <= 0 || math.Abs((got[0]-got[1])-990) > 1e-9 {
+		t.Fatalf("unexpected sigma scaling: %v", got)
+	}
+
+	for _, mode := range []string{FitnessScalingSigma, FitnessScalingRank, FitnessScalingMinMax} {
+		flat, err := ScaleFitness(scalingFixture(3, 3, 3), mode, DefaultScalingPressure(mode))
+		if err != nil {
+			t.Fatalf("%s flat: %v", mode, err)
+		}
+		for _, v := range scaledValues(flat) {
+			if v != 1 {
+				t.Fatalf("%s: expected flat generation to scale to 1, got %v", mode, scaledValues(flat))
+			}
+		}
+	}
+}
+
+func TestValidateFitnessScaling(t *testing.T) {
+	for _, tc := range []struct {
+		mode     string
+		pressure float64
+	}{
+		{mode: "boltzmann"},
+		{mode: FitnessScalingSigma, pressure: 0},
+		{mode: FitnessScalingRank, pressure: 2.5},
+		{mode: FitnessScalingRank, pressure: 0.5},
+		{mode: FitnessScalingMinMax, pressure: 1},
+		{mode: FitnessScalingNone, pressure: 1},
+	} {
+		if err := ValidateFitnessScaling(tc.mode, tc.pressure); err == nil {
+			t.Fatalf("expected %s pressure=%g to be rejected", tc.mode, tc.pressure)
+		}
+	}
+	for _, mode := range FitnessScalingNames() {
+		if err := ValidateFitnessScaling(mode, DefaultScalingPressure(mode)); err != nil {
+			t.Fatalf("expected %s defaults to validate: %v", mode, err)
+		}
+	}
+}
@@ -0,0 +1,305 @@
+package evo
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"strings"
+
+	"protogonos/internal/encoding"
+	"protogonos/internal/model"
+)
+
+// Grammar mutations evolve the L-system of genomes expressed through the
+// grammar encoding. They leave the genome's neurons and synapses alone and
+// find no choice in genomes without a grammar.
+
+const grammarSymbolAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// DefaultGrammarMaxIterations caps MutateGrammarIterations when Max is unset.
+const DefaultGrammarMaxIterations = 4
+
+// PerturbGrammarCell perturbs the bias and weights of one grammar cell, each
+// value with probability 1/sqrt(n) and at least one of them.
+type PerturbGrammarCell struct {
+	Rand     *rand.Rand
+	MaxDelta float64
+}
+
+func (o *PerturbGrammarCell) Name() string {
+	return "perturb_grammar_cell"
+}
+
+func (o *PerturbGrammarCell) Applicable(genome model.Genome, _ string) bool {
+	return genome.Grammar != nil && len(genome.Grammar.Cells) > 0
+}
+
+func (o *PerturbGrammarCell) Apply(_ context.Context, genome model.Genome) (model.Genome, error) {
+	if !o.Applicable(genome, "") {
+		return model.Genome{}, ErrNoMutationChoice
+	}
+	if o == nil || o.Rand == nil {
+		return model.Genome{}, errors.New("random source is required")
+	}
+	if o.MaxDelta <= 0 {
+		return model.Genome{}, errors.New("max delta must be > 0")
+	}
+	mutated := cloneGenome(genome)
+	cell := &mutated.Grammar.Cells[o.Rand.Intn(len(mutated.Grammar.Cells))]
+	values := []*float64{&cell.Bias, &cell.ChainWeight}
+	for i := range cell.InputWeights {
+		values = append(values, &cell.InputWeights[i])
+	}
+	for i := range cell.OutputWeights {
+		values = append(values, &cell.OutputWeights[i])
+	}
+	probability := 1 / math.Sqrt(float64(len(values)))
+	changed := false
+	for _, value := range values {
+		if o.Rand.Float64() < probability {
+			*value += (o.Rand.Float64()*2 - 1) * o.MaxDelta
+			changed = true
+		}
+	}
+	if !changed {
+		*values[o.Rand.Intn(len(values))] += (o.Rand.Float64()*2 - 1) * o.MaxDelta
+	}
+	return mutated, nil
+}
+
+// MutateGrammarRule rewrites the successor of one rule by replacing,
+// inserting or deleting a single symbol.
+type MutateGrammarRule struct {
+	Rand *rand.Rand
+}
+
+func (o *MutateGrammarRule) Name() string {
+	return "mutate_grammar_rule"
+}
+
+func (o *MutateGrammarRule) Applicable(genome model.Genome, _ string) bool {
+	return genome.Grammar != nil && len(genome.Grammar.Rules) > 0 && len(genome.Grammar.Cells) > 0
+}
+
+func (o *MutateGrammarRule) Apply(_ context.Context, genome model.Genome) (model.Genome, error) {
+	if !o.Applicable(genome, "") {
+		return model.Genome{}, ErrNoMutationChoice
+	}
+	if o == nil || o.Rand == nil {
+		return model.Genome{}, errors.New("random source is required")
+	}
+	mutated := cloneGenome(genome)
+	rule := &mutated.Grammar.Rules[o.Rand.Intn(len(mutated.Grammar.Rules))]
+	symbols := []rune(rule.Successor)
+	symbol := []rune(mutated.Grammar.Cells[o.Rand.Intn(len(mutated.Grammar.Cells))].Symbol)
+	if len(symbol) != 1 {
+		return model.Genome{}, ErrNoMutationChoice
+	}
+	switch op := o.Rand.Intn(3); {
+	case len(symbols) == 0 || op == 0:
+		at := o.Rand.Intn(len(symbols) + 1)
+		symbols = append(symbols[:at], append([]rune{symbol[0]}, symbols[at:]...)...)
+	case op == 1 && len(symbols) > 1:
+		at := o.Rand.Intn(len(symbols))
+		symbols = append(symbols[:at], symbols[at+1:]...)
+	default:
+		symbols[o.Rand.Intn(len(symbols))] = symbol[0]
+	}
+	rule.Successor = string(symbols)
+	return mutated, nil
+}
+
+// AddGrammarSymbol introduces a new symbol: a random cell sized like the
+// existing ones, a rule keeping the symbol as it is, and one occurrence
+// inserted into a random rule's successor.
+type AddGrammarSymbol struct {
+	Rand *rand.Rand
+}
+
+func (o *AddGrammarSymbol) Name() string {
+	return "add_grammar_symbol"
+}
+
+func (o *AddGrammarSymbol) Applicable(genome model.Genome, _ string) bool {
+	return genome.Grammar != nil && len(genome.Grammar.Rules) > 0 && len(genome.Grammar.Cells) > 0 &&
+		unusedGrammarSymbol(genome.Grammar) != ""
+}
+
+func (o *AddGrammarSymbol) Apply(_ context.Context, genome model.Genome) (model.Genome, error) {
+	if !o.Applicable(genome, "") {
+		return model.Genome{}, ErrNoMutationChoice
+	}
+	if o == nil || o.Rand == nil {
+		return model.Genome{}, errors.New("random source is required")
+	}
+	mutated := cloneGenome(genome)
+	grammar := mutated.Grammar
+	symbol := unusedGrammarSymbol(grammar)
+	template := grammar.Cells[0]
+	grammar.Cells = append(grammar.Cells, encoding.RandomGrammarCell(symbol, len(template.InputWeights), len(template.OutputWeights), o.Rand))
+	grammar.Rules = append(grammar.Rules, model.GrammarRule{Symbol: symbol, Successor: symbol})
+	rule := &grammar.Rules[o.Rand.Intn(len(grammar.Rules)-1)]
+	at := o.Rand.Intn(len(rule.Successor) + 1)
+	rule.Successor = rule.Successor[:at] + symbol + rule.Successor[at:]
+	return mutated, nil
+}
+
+// RemoveGrammarSymbol drops one symbol's cell and rule and erases it from
+// the axiom and every successor, keeping the axiom non-empty.
+type RemoveGrammarSymbol struct {
+	Rand *rand.Rand
+}
+
+func (o *RemoveGrammarSymbol) Name() string {
+	return "remove_grammar_symbol"
+}
+
+func (o *RemoveGrammarSymbol) Applicable(genome model.Genome, _ string) bool {
+	return len(removableGrammarSymbols(genome.Grammar)) > 0
+}
+
+func (o *RemoveGrammarSymbol) Apply(_ context.Context, genome model.Genome) (model.Genome, error) {
+	removable := removableGrammarSymbols(genome.Grammar)
+	if len(removable) == 0 {
+		return model.Genome{}, ErrNoMutationChoice
+	}
+	if o == nil || o.Rand == nil {
+		return model.Genome{}, errors.New("random source is required")
+	}
+	symbol := removable[o.Rand.Intn(len(removable))]
+	mutated := cloneGenome(genome)
+	grammar := mutated.Grammar
+	cells := grammar.Cells[:0]
+	for _, cell := range grammar.Cells {
+		if cell.Symbol != symbol {
+			cells = append(cells, cell)
+		}
+	}
+	grammar.Cells = cells
+	rules := grammar.Rules[:0]
+	for _, rule := range grammar.Rules {
+		if rule.Symbol != symbol {
+			rule.Successor = strings.ReplaceAll(rule.Successor, symbol, "")
+			rules = append(rules, rule)
+		}
+	}
+	grammar.Rules = rules
+	grammar.Axiom = strings.ReplaceAll(grammar.Axiom, symbol, "")
+	return mutated, nil
+}
+
+// MutateGrammarIterations moves the number of expansion steps by one,
+// within [0, Max].
+type MutateGrammarIterations struct {
+	Rand *rand.Rand
+	Max  int
+}
+
+func (o *MutateGrammarIterations) Name() string {
+	return "mutate_grammar_iterations"
+}
+
+func (o *MutateGrammarIterations) Applicable(genome model.Genome, _ string) bool {
+	return genome.Grammar != nil && o.max() > 0
+}
+
+func (o *MutateGrammarIterations) Apply(_ context.Context, genome model.Genome) (model.Genome, error) {
+	if o == nil || o.Rand == nil {
+		return model.Genome{}, errors.New("random source is required")
+	}
+	if !o.Applicable(genome, "") {
+		return model.Genome{}, ErrNoMutationChoice
+	}
+	mutated := cloneGenome(genome)
+	grammar := mutated.Grammar
+	switch {
+	case grammar.Iterations <= 0:
+		grammar.Iterations = 1
+	case grammar.Iterations >= o.max():
+		grammar.Iterations = o.max() - 1
+	case o.Rand.Intn(2) == 0:
+		grammar.Iterations--
+	default:
+		grammar.Iterations++
+	}
+	return mutated, nil
+}
+
+func (o *MutateGrammarIterations) max() int {
+	if o.Max > 0 {
+		return o.Max
+	}
+	return DefaultGrammarMaxIterations
+}
+
+// MutateGrammarActivation changes the activation of one grammar cell.
+type MutateGrammarActivation struct {
+	Rand        *rand.Rand
+	Activations []string
+}
+
+func (o *MutateGrammarActivation) Name() string {
+	return "mutate_grammar_af"
+}
+
+func (o *MutateGrammarActivation) Applicable(genome model.Genome, _ string) bool {
+	return genome.Grammar != nil && len(genome.Grammar.Cells) > 0
+}
+
+func (o *MutateGrammarActivation) Apply(_ context.Context, genome model.Genome) (model.Genome, error) {
+	if !o.Applicable(genome, "") {
+		return model.Genome{}, ErrNoMutationChoice
+	}
+	if o == nil || o.Rand == nil {
+		return model.Genome{}, errors.New("random source is required")
+	}
+	activations := o.Activations
+	if len(activations) == 0 {
+		activations = []string{"identity", "relu", "tanh", "sigmoid"}
+	}
+	mutated := cloneGenome(genome)
+	cell := &mutated.Grammar.Cells[o.Rand.Intn(len(mutated.Grammar.Cells))]
+	current := cell.Activation
+	if current == "" {
+		current = encoding.DefaultGrammarActivation
+	}
+	options := filterOutString(normalizeNonEmptyStrings(activations), current)
+	if len(options) == 0 {
+		return model.Genome{}, ErrNoMutationChoice
+	}
+	cell.Activation = options[o.Rand.Intn(len(options))]
+	return mutated, nil
+}
+
+func unusedGrammarSymbol(grammar *model.GrammarConfig) string {
+	used := make(map[string]struct{}, len(grammar.Cells)+len(grammar.Rules))
+	for _, cell := range grammar.Cells {
+		used[cell.Symbol] = struct{}{}
+	}
+	for _, rule := range grammar.Rules {
+		used[rule.Symbol] = struct{}{}
+	}
+	for _, r := range grammarSymbolAlphabet {
+		if _, ok := used[string(r)]; !ok {
+			return string(r)
+		}
+	}
+	return ""
+}
+
+// removableGrammarSymbols lists the cell symbols whose removal leaves at
+// least one cell and a non-empty axiom.
+func removableGrammarSymbols(grammar *model.GrammarConfig) []string {
+	if grammar == nil || len(grammar.Cells) < 2 {
+		return nil
+	}
+	var out []string
+	for _, cell := range grammar.Cells {
+		if cell.Symbol == "" || strings.ReplaceAll(grammar.Axiom, cell.Symbol, "") == "" {
+			continue
+		}
+		out = append(out, cell.Symbol)
+	}
+	return out
+}
@@ -0,0 +1,145 @@
+package evo
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func grammarTestGenome() model.Genome {
+	return model.Genome{
+		ID:      "grammar",
+		Neurons: []model.Neuron{{ID: "i", Activation: "identity"}, {ID: "o", Activation: "identity"}},
+		Grammar: &model.GrammarConfig{
+			Axiom:      "A",
+			Iterations: 1,
+			Rules: []model.GrammarRule{
+				{Symbol: "A", Successor: "AB"},
+				{Symbol: "B", Successor: "B"},
+			},
+			Cells: []model.GrammarCell{
+				{Symbol: "A", Activation: "tanh", InputWeights: []float64{0.1}, OutputWeights: []float64{0.2}},
+				{Symbol: "B", Activation: "tanh", InputWeights: []float64{0.3}, OutputWeights: []float64{0.4}},
+			},
+		},
+	}
+}
+
+func TestGrammarMutationsRequireAGrammar(t *testing.T) {
+	ops := []Operator{
+		&PerturbGrammarCell{Rand: rand.New(rand.NewSource(1)), MaxDelta: 1},
+		&MutateGrammarRule{Rand: rand.New(rand.NewSource(1))},
+		&AddGrammarSymbol{Rand: rand.New(rand.NewSource(1))},
+		&RemoveGrammarSymbol{Rand: rand.New(rand.NewSource(1))},
+		&MutateGrammarIterations{Rand: rand.New(rand.NewSource(1))},
+		&MutateGrammarActivation{Rand: rand.New(rand.NewSource(1))},
+	}
+	plain := grammarTestGenome()
+	plain.Grammar = nil
+	for _, op := range ops {
+		if _, err := op.Apply(context.Background(), plain); !errors.Is(err, ErrNoMutationChoice) {
+			t.Fatalf("%s: expected ErrNoMutationChoice without a grammar, got %v", op.Name(), err)
+		}
+	}
+}
+
+func TestGrammarMutationsChangeOnlyTheGrammar(t *testing.T) {
+	ctx := context.Background()
+	genome := grammarTestGenome()
+	original := grammarTestGenome()
+
+	perturbed, err := (&PerturbGrammarCell{Rand: rand.New(rand.NewSource(2)), MaxDelta: 0.5}).Apply(ctx, genome)
+	if err != nil {
+		t.Fatalf("perturb cell: %v", err)
+	}
+	if reflect.DeepEqual(perturbed.Grammar.Cells, genome.Grammar.Cells) {
+		t.Fatal("expected a cell value to change")
+	}
+
+	added, err := (&AddGrammarSymbol{Rand: rand.New(rand.NewSource(3))}).Apply(ctx, genome)
+	if err != nil {
+		t.Fatalf("add symbol: %v", err)
+	}
+	if len(added.Grammar.Cells) != 3 || added.Grammar.Cells[2].Symbol != "C" {
+		t.Fatalf("expected a new cell for symbol C, got %+v", added.Grammar.Cells)
+	}
+	if len(added.Grammar.Cells[2].InputWeights) != 1 || len(added.Grammar.Cells[2].OutputWeights) != 1 {
+		t.Fatalf("expected the new cell sized like the others, got %+v", added.Grammar.Cells[2])
+	}
+	if !strings.Contains(added.Grammar.Rules[0].Successor+added.Grammar.Rules[1].Successor, "C") {
+		t.Fatalf("expected C to be inserted into a successor, got %+v", added.Grammar.Rules)
+	}
+
+	removed, err := (&RemoveGrammarSymbol{Rand: rand.New(rand.NewSource(4))}).Apply(ctx, genome)
+	if err != nil {
+		t.Fatalf("remove symbol: %v", err)
+	}
+	// The axiom is only A, so B is the one removable symbol.
+	if len(removed.Grammar.Cells) != 1 || removed.Grammar.Cells[0].Symbol != "A" || removed.Grammar.Rules[0].Successor != "A" {
+		t.Fatalf("expected B to be removed everywhere, got %+v", removed.Grammar)
+	}
+	if (&RemoveGrammarSymbol{}).Applicable(removed, "") {
+		t.Fatal("expected the last cell to be kept")
+	}
+
+	// A replacement may write the symbol it replaces, so only some rewrites
+	// change the rules; all of them must stay within the known symbols.
+	rule := &MutateGrammarRule{Rand: rand.New(rand.NewSource(5))}
+	var rewritten model.Genome
+	rewrites := 0
+	for i := 0; i < 8; i++ {
+		if rewritten, err = rule.Apply(ctx, genome); err != nil {
+			t.Fatalf("mutate rule: %v", err)
+		}
+		for _, r := range rewritten.Grammar.Rules {
+			if strings.Trim(r.Successor, "AB") != "" {
+				t.Fatalf("expected successors over known symbols, got %q", r.Successor)
+			}
+		}
+		if !reflect.DeepEqual(rewritten.Grammar.Rules, genome.Grammar.Rules) {
+			rewrites++
+		}
+	}
+	if rewrites == 0 {
+		t.Fatal("expected rule mutations to rewrite a successor")
+	}
+
+	iterations := &MutateGrammarIterations{Rand: rand.New(rand.NewSource(6)), Max: 2}
+	deeper := genome
+	for i := 0; i < 10; i++ {
+		if deeper, err = iterations.Apply(ctx, deeper); err != nil {
+			t.Fatalf("mutate iterations: %v", err)
+		}
+		if deeper.Grammar.Iterations < 0 || deeper.Grammar.Iterations > 2 {
+			t.Fatalf("expected iterations within [0,2], got %d", deeper.Grammar.Iterations)
+		}
+	}
+
+	activated, err := (&MutateGrammarActivation{Rand: rand.New(rand.NewSource(7))}).Apply(ctx, genome)
+	if err != nil {
+		t.Fatalf("mutate activation: %v", err)
+	}
+	changed := 0
+	for i, cell := range activated.Grammar.Cells {
+		if cell.Activation != genome.Grammar.Cells[i].Activation {
+			changed++
+		}
+	}
+	if changed != 1 {
+		t.Fatalf("expected one cell activation to change, got %d", changed)
+	}
+
+	if !reflect.DeepEqual(genome, original) {
+		t.Fatal("expected mutations to leave the parent genome unchanged")
+	}
+	for _, mutated := range []model.Genome{perturbed, added, removed, rewritten, deeper, activated} {
+		if !reflect.DeepEqual(mutated.Neurons, original.Neurons) || len(mutated.Synapses) != 0 {
+			t.Fatal("expected grammar mutations to leave neurons and synapses alone")
+		}
+	}
+}
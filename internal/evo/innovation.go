@@ -0,0 +1,14 @@
+package evo
+
+import "protogonos/internal/model"
+
+// markInnovations returns a copy of genomes with every gene marked by the
+// run's innovation registry. Genes stay unmarked when the run does not
+// track innovations.
+func (m *PopulationMonitor) markInnovations(genomes []model.Genome) []model.Genome {
+	marked := make([]model.Genome, len(genomes))
+	for i, genome := range genomes {
+		marked[i] = m.innovations.Mark(genome)
+	}
+	return marked
+}
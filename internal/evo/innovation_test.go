@@ -0,0 +1,65 @@
+package evo
+
+import (
+	"context"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func TestPopulationMonitorMarksEveryGeneWhenTrackingInnovations(t *testing.T) {
+	initial := []model.Genome{
+		newLinearGenome("a", 0.2),
+		newLinearGenome("b", 0.4),
+		newComplexLinearGenome("c", 0.3),
+		newComplexLinearGenome("d", 0.5),
+	}
+	monitor, err := NewPopulationMonitor(MonitorConfig{
+		Scape:            oneDimScape{},
+		Mutation:         PerturbWeightAt{Index: 0, Delta: 0.1},
+		PopulationSize:   len(initial),
+		EliteCount:       1,
+		Generations:      3,
+		Workers:          1,
+		Seed:             7,
+		InputNeuronIDs:   []string{"i"},
+		OutputNeuronIDs:  []string{"o"},
+		CrossoverRate:    0.5,
+		TrackInnovations: true,
+		Innovations:      []model.InnovationRecord{{Key: "n:legacy", Innovation: 40}},
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	result, err := monitor.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	byEndpoints := map[string]int{}
+	for _, scored := range result.FinalPopulation {
+		for _, neuron := range scored.Genome.Neurons {
+			if neuron.Innovation == 0 {
+				t.Fatalf("expected neuron %s of %s to be marked", neuron.ID, scored.Genome.ID)
+			}
+		}
+		for _, synapse := range scored.Genome.Synapses {
+			if synapse.Innovation == 0 {
+				t.Fatalf("expected synapse %s of %s to be marked", synapse.ID, scored.Genome.ID)
+			}
+			if synapse.From != "i" || synapse.To != "o" {
+				continue
+			}
+			if seen, ok := byEndpoints["i->o"]; ok && seen != synapse.Innovation {
+				t.Fatalf("expected i->o to carry one innovation across the population, got %d and %d", seen, synapse.Innovation)
+			}
+			byEndpoints["i->o"] = synapse.Innovation
+		}
+	}
+	if len(result.Innovations) < 8 || result.Innovations[0].Innovation != 40 {
+		t.Fatalf("expected the registry to continue from the given records, got %+v", result.Innovations)
+	}
+	if byEndpoints["i->o"] <= 40 {
+		t.Fatalf("expected new markings numbered after the given records, got %d", byEndpoints["i->o"])
+	}
+}
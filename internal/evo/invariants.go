@@ -0,0 +1,213 @@
+package evo
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"protogonos/internal/model"
+)
+
+// Invariants checked per generation under MonitorConfig.VerifyInvariants.
+const (
+	InvariantPopulationSize    = "population_size"
+	InvariantUniqueGenomeIDs   = "unique_genome_ids"
+	InvariantElitePreserved    = "elite_preserved"
+	InvariantEliteFitness      = "elite_fitness"
+	InvariantSpeciesAssignment = "species_assignment"
+)
+
+// eliteFitnessTolerance absorbs float noise when an elite is re-evaluated.
+const eliteFitnessTolerance = 1e-9
+
+// InvariantGenome is one population member in an InvariantViolation dump.
+type InvariantGenome struct {
+	ID          string  `json:"id"`
+	Fitness     float64 `json:"fitness"`
+	Species     string  `json:"species,omitempty"`
+	Fingerprint string  `json:"fingerprint"`
+	Elite       bool    `json:"elite,omitempty"`
+}
+
+// InvariantViolation is the error a run fails with when a generation breaks
+// an invariant. Population dumps the generation the check ran on.
+type InvariantViolation struct {
+	Generation int               `json:"generation"`
+	Invariant  string            `json:"invariant"`
+	Detail     string            `json:"detail"`
+	Population []InvariantGenome `json:"population"`
+}
+
+func (v *InvariantViolation) Error() string {
+	return fmt.Sprintf("invariant %s violated at generation %d: %s", v.Invariant, v.Generation, v.Detail)
+}
+
+// invariantElite is an elite cloned into the next generation, kept to check
+// that its re-evaluated fitness did not drop.
+type invariantElite struct {
+	id      string
+	fitness float64
+}
+
+// checkScoredInvariants verifies a scored and speciated generation: its size,
+// unique ids, a species for every genome matching the reported species
+// count, and that last generation's elites were re-evaluated at no lower
+// fitness. The fitness check is skipped when the fitness landscape may have
+// moved: relative postprocessing, repeated trials, low-fidelity screening or
+// a scape parameter change this generation.
+func (m *PopulationMonitor) checkScoredInvariants(generation int, scored []ScoredGenome, speciesByGenomeID map[string]string, speciesCount int, landscapeChanged bool) error {
+	elites := m.invariantElites
+	m.invariantElites = nil
+	eliteIDs := make(map[string]bool, len(elites))
+	for _, elite := range elites {
+		eliteIDs[elite.id] = true
+	}
+	dump := func(invariant, format string, args ...any) error {
+		return &InvariantViolation{
+			Generation: generation,
+			Invariant:  invariant,
+			Detail:     fmt.Sprintf(format, args...),
+			Population: invariantDump(scored, speciesByGenomeID, eliteIDs),
+		}
+	}
+	if len(scored) != m.cfg.PopulationSize {
+		return dump(InvariantPopulationSize, "scored %d genomes, configured population is %d", len(scored), m.cfg.PopulationSize)
+	}
+	if id, ok := duplicateScoredID(scored); ok {
+		return dump(InvariantUniqueGenomeIDs, "genome id %s appears more than once", id)
+	}
+
+	species := make(map[string]struct{}, speciesCount)
+	for _, item := range scored {
+		key, ok := speciesByGenomeID[item.Genome.ID]
+		if !ok || key == "" {
+			return dump(InvariantSpeciesAssignment, "genome %s has no species", item.Genome.ID)
+		}
+		species[key] = struct{}{}
+	}
+	if len(speciesByGenomeID) != len(scored) {
+		return dump(InvariantSpeciesAssignment, "%d species assignments for %d genomes", len(speciesByGenomeID), len(scored))
+	}
+	if len(species) != speciesCount {
+		return dump(InvariantSpeciesAssignment, "genomes span %d species, diagnostics report %d", len(species), speciesCount)
+	}
+
+	if landscapeChanged || !m.eliteFitnessComparable() {
+		return nil
+	}
+	byID := make(map[string]ScoredGenome, len(scored))
+	for _, item := range scored {
+		byID[item.Genome.ID] = item
+	}
+	for _, elite := range elites {
+		item, ok := byID[elite.id]
+		if !ok {
+			return dump(InvariantElitePreserved, "elite %s was not evaluated", elite.id)
+		}
+		if item.Degenerate != "" {
+			continue
+		}
+		if item.Fitness < elite.fitness-eliteFitnessTolerance*math.Max(1, math.Abs(elite.fitness)) {
+			return dump(InvariantEliteFitness, "elite %s fitness dropped from %g to %g", elite.id, elite.fitness, item.Fitness)
+		}
+	}
+	return nil
+}
+
+// checkNextGenerationInvariants verifies the reproduced population before it
+// is evaluated: its size against the configured bounds, unique ids and, unless
+// AFPO replaces elitism, that the top EliteCount ranked genomes were carried
+// over unchanged.
+func (m *PopulationMonitor) checkNextGenerationInvariants(generation int, ranked []ScoredGenome, population []model.Genome) error {
+	// Carried elites keep their ranked fitness in the dump; offspring are
+	// not scored yet.
+	eliteCount := min(m.cfg.EliteCount, len(ranked))
+	fitness := make(map[string]float64, eliteCount)
+	eliteIDs := make(map[string]bool, eliteCount)
+	for _, item := range ranked[:eliteCount] {
+		fitness[item.Genome.ID] = item.Fitness
+		eliteIDs[item.Genome.ID] = true
+	}
+	next := make([]ScoredGenome, len(population))
+	for i, genome := range population {
+		next[i] = ScoredGenome{Genome: genome, Fitness: fitness[genome.ID]}
+	}
+	dump := func(invariant, format string, args ...any) error {
+		return &InvariantViolation{
+			Generation: generation,
+			Invariant:  invariant,
+			Detail:     fmt.Sprintf(format, args...),
+			Population: invariantDump(next, nil, eliteIDs),
+		}
+	}
+	if len(next) != m.cfg.PopulationSize {
+		return dump(InvariantPopulationSize, "reproduced %d genomes, configured population is %d", len(next), m.cfg.PopulationSize)
+	}
+	if (m.cfg.MinPopulation > 0 && len(next) < m.cfg.MinPopulation) || (m.cfg.MaxPopulation > 0 && len(next) > m.cfg.MaxPopulation) {
+		return dump(InvariantPopulationSize, "population %d outside [%d, %d]", len(next), m.cfg.MinPopulation, m.cfg.MaxPopulation)
+	}
+	if id, ok := duplicateScoredID(next); ok {
+		return dump(InvariantUniqueGenomeIDs, "genome id %s appears more than once", id)
+	}
+	if _, afpo := m.cfg.Selector.(AFPOSelector); afpo {
+		return nil
+	}
+
+	byID := make(map[string]int, len(next))
+	for i, item := range next {
+		byID[item.Genome.ID] = i
+	}
+	elites := make([]invariantElite, 0, eliteCount)
+	for _, elite := range ranked[:eliteCount] {
+		i, ok := byID[elite.Genome.ID]
+		if !ok {
+			return dump(InvariantElitePreserved, "elite %s (fitness %g) is missing from the next generation", elite.Genome.ID, elite.Fitness)
+		}
+		carried := next[i].Genome
+		if !reflect.DeepEqual(carried.Neurons, elite.Genome.Neurons) || !reflect.DeepEqual(carried.Synapses, elite.Genome.Synapses) {
+			return dump(InvariantElitePreserved, "elite %s changed while carried over", elite.Genome.ID)
+		}
+		elites = append(elites, invariantElite{id: elite.Genome.ID, fitness: elite.Fitness})
+	}
+	m.invariantElites = elites
+	return nil
+}
+
+// eliteFitnessComparable reports whether an elite's fitness is expected to be
+// reproducible from one generation to the next.
+func (m *PopulationMonitor) eliteFitnessComparable() bool {
+	if _, ok := m.cfg.Postprocessor.(NoopFitnessPostprocessor); !ok {
+		return false
+	}
+	return m.cfg.EvaluationTrials <= 1 && !(m.cfg.LowFidelity > 0 && m.cfg.LowFidelity < 1)
+}
+
+func invariantDump(population []ScoredGenome, speciesByGenomeID map[string]string, eliteIDs map[string]bool) []InvariantGenome {
+	out := make([]InvariantGenome, 0, len(population))
+	for _, item := range population {
+		out = append(out, InvariantGenome{
+			ID:          item.Genome.ID,
+			Fitness:     item.Fitness,
+			Species:     speciesByGenomeID[item.Genome.ID],
+			Fingerprint: ComputeGenomeSignature(item.Genome).Fingerprint,
+			Elite:       eliteIDs[item.Genome.ID],
+		})
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return strings.Compare(out[i].ID, out[j].ID) < 0
+	})
+	return out
+}
+
+func duplicateScoredID(population []ScoredGenome) (string, bool) {
+	seen := make(map[string]struct{}, len(population))
+	for _, item := range population {
+		if _, ok := seen[item.Genome.ID]; ok {
+			return item.Genome.ID, true
+		}
+		seen[item.Genome.ID] = struct{}{}
+	}
+	return "", false
+}
@@ -0,0 +1,146 @@
+package evo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func newInvariantMonitor(t *testing.T, populationSize, eliteCount int) *PopulationMonitor {
+	t.Helper()
+	monitor, err := NewPopulationMonitor(MonitorConfig{
+		Scape:            oneDimScape{},
+		Mutation:         PerturbWeightAt{Index: 0, Delta: 0.2},
+		PopulationSize:   populationSize,
+		EliteCount:       eliteCount,
+		Generations:      5,
+		Workers:          2,
+		Seed:             1,
+		InputNeuronIDs:   []string{"i"},
+		OutputNeuronIDs:  []string{"o"},
+		VerifyInvariants: true,
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	return monitor
+}
+
+func TestVerifyInvariantsPassesGenerationalRun(t *testing.T) {
+	initial := []model.Genome{
+		newLinearGenome("g0", -1.0),
+		newLinearGenome("g1", -0.6),
+		newLinearGenome("g2", -0.2),
+		newLinearGenome("g3", 0.2),
+		newLinearGenome("g4", 0.6),
+	}
+	monitor := newInvariantMonitor(t, len(initial), 2)
+	result, err := monitor.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(result.BestByGeneration) != 5 {
+		t.Fatalf("expected 5 generations, got %d", len(result.BestByGeneration))
+	}
+	for i := 1; i < len(result.BestByGeneration); i++ {
+		if result.BestByGeneration[i] < result.BestByGeneration[i-1] {
+			t.Fatalf("best fitness dropped despite elitism: %v", result.BestByGeneration)
+		}
+	}
+}
+
+func TestVerifyInvariantsRejectsSteadyState(t *testing.T) {
+	_, err := NewPopulationMonitor(MonitorConfig{
+		Scape:            oneDimScape{},
+		Mutation:         PerturbWeightAt{Index: 0, Delta: 0.2},
+		PopulationSize:   4,
+		EliteCount:       1,
+		Generations:      2,
+		InputNeuronIDs:   []string{"i"},
+		OutputNeuronIDs:  []string{"o"},
+		EvolutionType:    EvolutionTypeSteadyState,
+		VerifyInvariants: true,
+	})
+	if err == nil || !strings.Contains(err.Error(), "generational") {
+		t.Fatalf("expected steady-state rejection, got %v", err)
+	}
+}
+
+func TestVerifyInvariantsReportsViolations(t *testing.T) {
+	ranked := []ScoredGenome{
+		{Genome: newLinearGenome("g0", 1.0), Fitness: 0.9},
+		{Genome: newLinearGenome("g1", 0.5), Fitness: 0.5},
+		{Genome: newLinearGenome("g2", 0.0), Fitness: 0.1},
+	}
+	species := map[string]string{"g0": "s1", "g1": "s1", "g2": "s2"}
+
+	expectViolation := func(t *testing.T, err error, invariant string) *InvariantViolation {
+		t.Helper()
+		var violation *InvariantViolation
+		if !errors.As(err, &violation) {
+			t.Fatalf("expected invariant violation, got %v", err)
+		}
+		if violation.Invariant != invariant {
+			t.Fatalf("expected %s violation, got %s: %s", invariant, violation.Invariant, violation.Detail)
+		}
+		if violation.Generation != 3 || len(violation.Population) == 0 {
+			t.Fatalf("expected generation 3 with a population dump, got %+v", violation)
+		}
+		return violation
+	}
+
+	t.Run("clean generation", func(t *testing.T) {
+		monitor := newInvariantMonitor(t, 3, 1)
+		if err := monitor.checkScoredInvariants(3, ranked, species, 2, false); err != nil {
+			t.Fatalf("unexpected violation: %v", err)
+		}
+		next := []model.Genome{ranked[0].Genome, newLinearGenome("c1", 0.2), newLinearGenome("c2", 0.3)}
+		if err := monitor.checkNextGenerationInvariants(3, ranked, next); err != nil {
+			t.Fatalf("unexpected violation: %v", err)
+		}
+	})
+	t.Run("population size", func(t *testing.T) {
+		monitor := newInvariantMonitor(t, 3, 1)
+		err := monitor.checkNextGenerationInvariants(3, ranked, []model.Genome{ranked[0].Genome, newLinearGenome("c1", 0.2)})
+		expectViolation(t, err, InvariantPopulationSize)
+	})
+	t.Run("duplicate id", func(t *testing.T) {
+		monitor := newInvariantMonitor(t, 3, 1)
+		duplicated := append([]ScoredGenome(nil), ranked...)
+		duplicated[2].Genome.ID = "g1"
+		expectViolation(t, monitor.checkScoredInvariants(3, duplicated, species, 2, false), InvariantUniqueGenomeIDs)
+	})
+	t.Run("species count", func(t *testing.T) {
+		monitor := newInvariantMonitor(t, 3, 1)
+		expectViolation(t, monitor.checkScoredInvariants(3, ranked, species, 3, false), InvariantSpeciesAssignment)
+	})
+	t.Run("dropped elite", func(t *testing.T) {
+		monitor := newInvariantMonitor(t, 3, 2)
+		next := []model.Genome{ranked[0].Genome, newLinearGenome("c1", 0.2), newLinearGenome("c2", 0.3)}
+		violation := expectViolation(t, monitor.checkNextGenerationInvariants(3, ranked, next), InvariantElitePreserved)
+		if !strings.Contains(violation.Detail, "g1") {
+			t.Fatalf("expected detail to name the dropped elite, got %q", violation.Detail)
+		}
+	})
+	t.Run("mutated elite", func(t *testing.T) {
+		monitor := newInvariantMonitor(t, 3, 1)
+		next := []model.Genome{newLinearGenome("g0", 0.7), newLinearGenome("c1", 0.2), newLinearGenome("c2", 0.3)}
+		expectViolation(t, monitor.checkNextGenerationInvariants(3, ranked, next), InvariantElitePreserved)
+	})
+	t.Run("elite fitness drop", func(t *testing.T) {
+		monitor := newInvariantMonitor(t, 3, 1)
+		monitor.invariantElites = []invariantElite{{id: "g0", fitness: 0.95}}
+		violation := expectViolation(t, monitor.checkScoredInvariants(3, ranked, species, 2, false), InvariantEliteFitness)
+		if !violation.Population[0].Elite {
+			t.Fatalf("expected dump to flag the elite, got %+v", violation.Population[0])
+		}
+
+		monitor.invariantElites = []invariantElite{{id: "g0", fitness: 0.95}}
+		if err := monitor.checkScoredInvariants(3, ranked, species, 2, true); err != nil {
+			t.Fatalf("expected fitness check skipped after a landscape change, got %v", err)
+		}
+	})
+}
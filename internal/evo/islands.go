@@ -0,0 +1,201 @@
+package evo
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"protogonos/internal/genotype"
+	"protogonos/internal/model"
+)
+
+const (
+	defaultMigrationInterval = 10
+	defaultMigrants          = 1
+	// islandSeedStride separates the RNG streams of successive islands.
+	islandSeedStride = 7919
+)
+
+// islandStreams are the selection and mutation RNG streams of one island,
+// with the mutation operators it breeds with.
+type islandStreams struct {
+	rng         *rand.Rand
+	mutationRNG *rand.Rand
+	mutation    Operator
+	policy      []WeightedMutation
+}
+
+func validateIslands(cfg *MonitorConfig) error {
+	if cfg.Islands < 0 {
+		return fmt.Errorf("islands must be >= 0")
+	}
+	if cfg.MigrationInterval < 0 {
+		return fmt.Errorf("migration interval must be >= 0")
+	}
+	if cfg.Migrants < 0 {
+		return fmt.Errorf("migrants must be >= 0")
+	}
+	if cfg.Islands <= 1 {
+		return nil
+	}
+	if cfg.EvolutionType != EvolutionTypeGenerational {
+		return fmt.Errorf("islands require generational evolution")
+	}
+	if _, ok := cfg.Selector.(AFPOSelector); ok {
+		return fmt.Errorf("islands do not support the afpo selector")
+	}
+	if cfg.PopulationResize == PopulationResizeGuard || cfg.PopulationResize == PopulationResizeAuto {
+		return fmt.Errorf("islands do not support population resize")
+	}
+	if cfg.EntropyThreshold > 0 && (cfg.EntropyAction == EntropyActionImmigrants || cfg.EntropyAction == EntropyActionRestart) {
+		return fmt.Errorf("islands do not support entropy action %s", cfg.EntropyAction)
+	}
+	if cfg.MigrationInterval == 0 {
+		cfg.MigrationInterval = defaultMigrationInterval
+	}
+	if cfg.Migrants == 0 {
+		cfg.Migrants = defaultMigrants
+	}
+	smallest := islandSizes(cfg.PopulationSize, cfg.Islands)[cfg.Islands-1]
+	if smallest < cfg.EliteCount+cfg.Migrants {
+		return fmt.Errorf("islands of %d genomes cannot hold %d elites and %d migrants", smallest, cfg.EliteCount, cfg.Migrants)
+	}
+	return nil
+}
+
+// islandSizes splits total genomes into n contiguous islands, the first
+// total%n of them one genome larger.
+func islandSizes(total, n int) []int {
+	sizes := make([]int, n)
+	for i := range sizes {
+		sizes[i] = total / n
+		if i < total%n {
+			sizes[i]++
+		}
+	}
+	return sizes
+}
+
+// newIslandStreams seeds one selection and mutation stream per island from
+// the run's seeds, offset by the island index, sharing one stream per
+// island under the same rule as the monitor's own streams. IslandMutations,
+// when set, builds each island's operators from the same offset.
+func newIslandStreams(cfg MonitorConfig) []islandStreams {
+	if cfg.Islands <= 1 {
+		return nil
+	}
+	streams := make([]islandStreams, cfg.Islands)
+	for i := range streams {
+		offset := int64(i+1) * islandSeedStride
+		streams[i].rng = cfg.Rands.New(seedOr(cfg.SelectionSeed, cfg.Seed) + offset)
+		streams[i].mutationRNG = streams[i].rng
+		if cfg.SelectionSeed != nil || cfg.MutationSeed != nil {
+			streams[i].mutationRNG = cfg.Rands.New(seedOr(cfg.MutationSeed, cfg.Seed) + offset)
+		}
+		streams[i].mutation, streams[i].policy = cfg.Mutation, cfg.MutationPolicy
+		if cfg.IslandMutations != nil {
+			streams[i].mutation, streams[i].policy = cfg.IslandMutations(offset)
+		}
+	}
+	return streams
+}
+
+// assignIslands records the island of each genome of population, which
+// holds the islands as contiguous blocks.
+func (m *PopulationMonitor) assignIslands(population []model.Genome) {
+	if m.cfg.Islands <= 1 {
+		return
+	}
+	m.islandOf = make(map[string]int, len(population))
+	start := 0
+	for island, size := range islandSizes(len(population), m.cfg.Islands) {
+		for _, genome := range population[start : start+size] {
+			m.islandOf[genome.ID] = island
+		}
+		start += size
+	}
+}
+
+// islandBests returns the best fitness on each island of ranked.
+func (m *PopulationMonitor) islandBests(ranked []ScoredGenome) []float64 {
+	if m.cfg.Islands <= 1 {
+		return nil
+	}
+	bests := make([]float64, m.cfg.Islands)
+	seen := make([]bool, m.cfg.Islands)
+	for _, item := range ranked {
+		island := m.islandOf[item.Genome.ID]
+		if !seen[island] {
+			bests[island] = item.Fitness
+			seen[island] = true
+		}
+	}
+	return bests
+}
+
+// nextIslandGeneration breeds every island from its own ranked members on
+// its own RNG streams and mutation operators, then, every MigrationInterval
+// generations, copies the Migrants best genomes of each island over the last
+// offspring bred for the next island in the ring.
+func (m *PopulationMonitor) nextIslandGeneration(ctx context.Context, ranked []ScoredGenome, speciesByGenomeID map[string]string, generation int) ([]model.Genome, []LineageRecord, error) {
+	members := make([][]ScoredGenome, m.cfg.Islands)
+	for _, item := range ranked {
+		island := m.islandOf[item.Genome.ID]
+		members[island] = append(members[island], item)
+	}
+	sizes := islandSizes(m.cfg.PopulationSize, m.cfg.Islands)
+	next := make([]model.Genome, 0, m.cfg.PopulationSize)
+	lineage := make([]LineageRecord, 0, m.cfg.PopulationSize)
+	starts := make([]int, m.cfg.Islands)
+	allocation := map[string]int{}
+	rng, mutationRNG := m.rng, m.mutationRNG
+	mutation, policy := m.cfg.Mutation, m.cfg.MutationPolicy
+	defer func() {
+		m.rng, m.mutationRNG = rng, mutationRNG
+		m.cfg.Mutation, m.cfg.MutationPolicy = mutation, policy
+	}()
+	for island, size := range sizes {
+		if len(members[island]) == 0 {
+			return nil, nil, fmt.Errorf("island %d has no genomes", island)
+		}
+		m.rng, m.mutationRNG = m.islands[island].rng, m.islands[island].mutationRNG
+		m.cfg.Mutation, m.cfg.MutationPolicy = m.islands[island].mutation, m.islands[island].policy
+		starts[island] = len(next)
+		bred, records, err := m.breed(ctx, members[island], speciesByGenomeID, generation, size, len(next))
+		if err != nil {
+			return nil, nil, err
+		}
+		next = append(next, bred...)
+		lineage = append(lineage, records...)
+		for key, count := range m.lastAllocation {
+			allocation[key] += count
+		}
+	}
+	m.lastAllocation = allocation
+
+	if (generation+1)%m.cfg.MigrationInterval != 0 {
+		return next, lineage, nil
+	}
+	nextGeneration := generation + 1
+	for island := range sizes {
+		target := (island + 1) % m.cfg.Islands
+		end := starts[target] + sizes[target]
+		for i, source := range members[island][:min(m.cfg.Migrants, len(members[island]))] {
+			slot := end - 1 - i
+			migrant := genotype.CloneAgent(source.Genome, fmt.Sprintf("%s-g%d-m%d", source.Genome.ID, nextGeneration, target))
+			sig := ComputeGenomeSignature(migrant)
+			delete(m.crossoverOffspring, next[slot].ID)
+			next[slot] = migrant
+			lineage[slot] = LineageRecord{
+				GenomeID:    migrant.ID,
+				ParentID:    source.Genome.ID,
+				Generation:  nextGeneration,
+				Operation:   "migrate",
+				Fingerprint: sig.Fingerprint,
+				Summary:     sig.Summary,
+			}
+			m.lastMigrants++
+		}
+	}
+	return next, lineage, nil
+}
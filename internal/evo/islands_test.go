@@ -0,0 +1,180 @@
+package evo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func islandMonitorConfig() MonitorConfig {
+	return MonitorConfig{
+		Scape:             oneDimScape{},
+		Mutation:          PerturbWeightAt{Index: 0, Delta: 0.05},
+		PopulationSize:    8,
+		EliteCount:        1,
+		Generations:       5,
+		Workers:           2,
+		Seed:              11,
+		InputNeuronIDs:    []string{"i"},
+		OutputNeuronIDs:   []string{"o"},
+		Islands:           2,
+		MigrationInterval: 2,
+	}
+}
+
+func TestIslandRunMigratesChampionsAroundTheRing(t *testing.T) {
+	initial := make([]model.Genome, 8)
+	for i := range initial {
+		initial[i] = newLinearGenome(fmt.Sprintf("g%d", i), 0.1*float64(i+1))
+	}
+	monitor, err := NewPopulationMonitor(islandMonitorConfig())
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	result, err := monitor.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	for _, diag := range result.GenerationDiagnostics {
+		if len(diag.IslandBest) != 2 {
+			t.Fatalf("generation %d: expected two island bests, got %v", diag.Generation, diag.IslandBest)
+		}
+		if math.Max(diag.IslandBest[0], diag.IslandBest[1]) != diag.BestFitness {
+			t.Fatalf("generation %d: expected the global best among island bests, got %v best=%f", diag.Generation, diag.IslandBest, diag.BestFitness)
+		}
+		wantMigrants := 0
+		if diag.Generation%2 == 0 && diag.Generation < 5 {
+			wantMigrants = 2
+		}
+		if diag.Migrants != wantMigrants {
+			t.Fatalf("generation %d: expected %d migrants, got %d", diag.Generation, wantMigrants, diag.Migrants)
+		}
+	}
+
+	// Before the first migration island 0 holds the weak seeds and island 1
+	// the strong ones; island 1's champion must cross over to island 0.
+	if got := result.GenerationDiagnostics[0].IslandBest; got[0] >= got[1] {
+		t.Fatalf("expected the strong seeds to lead on island 1, got %v", got)
+	}
+	migrations := 0
+	for _, record := range result.Lineage {
+		if record.Operation != "migrate" {
+			continue
+		}
+		migrations++
+		if record.Generation%2 != 0 || !strings.Contains(record.GenomeID, fmt.Sprintf("-g%d-m", record.Generation)) {
+			t.Fatalf("unexpected migration record: %+v", record)
+		}
+	}
+	if migrations != 4 {
+		t.Fatalf("expected two migrations per migrating generation, got %d", migrations)
+	}
+
+	// Each island breeds on its own streams, so a repeat run matches.
+	again, err := NewPopulationMonitor(islandMonitorConfig())
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	repeat, err := again.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("repeat run: %v", err)
+	}
+	if !reflect.DeepEqual(repeat.BestByGeneration, result.BestByGeneration) || len(repeat.Lineage) != len(result.Lineage) {
+		t.Fatal("expected island runs to be reproducible from the seed")
+	}
+}
+
+func TestIslandStreamsDifferPerIsland(t *testing.T) {
+	streams := newIslandStreams(MonitorConfig{Islands: 3, Seed: 5})
+	if len(streams) != 3 {
+		t.Fatalf("expected one stream per island, got %d", len(streams))
+	}
+	first := streams[0].rng.Int63()
+	for _, stream := range streams[1:] {
+		if stream.mutationRNG != stream.rng {
+			t.Fatal("expected selection and mutation to share an island stream without explicit seeds")
+		}
+		if stream.rng.Int63() == first {
+			t.Fatal("expected distinct island streams")
+		}
+	}
+	if newIslandStreams(MonitorConfig{Islands: 1}) != nil {
+		t.Fatal("expected no island streams for a single population")
+	}
+}
+
+func TestIslandsBreedWithTheirOwnMutationOperators(t *testing.T) {
+	cfg := islandMonitorConfig()
+	cfg.IslandMutations = func(seedOffset int64) (Operator, []WeightedMutation) {
+		return namedNoopMutation{name: fmt.Sprintf("island-%d", seedOffset/islandSeedStride-1)}, nil
+	}
+	initial := make([]model.Genome, 8)
+	for i := range initial {
+		initial[i] = newLinearGenome(fmt.Sprintf("g%d", i), 0.1*float64(i+1))
+	}
+	monitor, err := NewPopulationMonitor(cfg)
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	result, err := monitor.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	sizes := islandSizes(cfg.PopulationSize, cfg.Islands)
+	seen := map[int]bool{}
+	for start := 0; start+cfg.PopulationSize <= len(result.Lineage); start += cfg.PopulationSize {
+		for i, record := range result.Lineage[start : start+cfg.PopulationSize] {
+			island := 0
+			if i >= sizes[0] {
+				island = 1
+			}
+			if strings.Contains(record.Operation, "island-") {
+				if !strings.Contains(record.Operation, fmt.Sprintf("island-%d", island)) {
+					t.Fatalf("expected island %d offspring to use its own operators, got %+v", island, record)
+				}
+				seen[island] = true
+			}
+			if strings.Contains(record.Operation, cfg.Mutation.Name()) {
+				t.Fatalf("expected no offspring bred with the shared mutation, got %+v", record)
+			}
+		}
+	}
+	if !seen[0] || !seen[1] {
+		t.Fatalf("expected offspring bred by both islands' operators, got %v", seen)
+	}
+	if monitor.cfg.Mutation != cfg.Mutation {
+		t.Fatal("expected the run-wide mutation to be restored after breeding the islands")
+	}
+}
+
+func TestIslandConfigValidation(t *testing.T) {
+	for name, mutate := range map[string]func(*MonitorConfig){
+		"negative islands":  func(cfg *MonitorConfig) { cfg.Islands = -1 },
+		"steady state":      func(cfg *MonitorConfig) { cfg.EvolutionType = EvolutionTypeSteadyState },
+		"afpo":              func(cfg *MonitorConfig) { cfg.Selector = AFPOSelector{} },
+		"population resize": func(cfg *MonitorConfig) { cfg.PopulationResize = PopulationResizeGuard },
+		"too small islands": func(cfg *MonitorConfig) { cfg.Islands = 4; cfg.EliteCount = 2 },
+	} {
+		cfg := islandMonitorConfig()
+		mutate(&cfg)
+		if _, err := NewPopulationMonitor(cfg); err == nil {
+			t.Fatalf("%s: expected island config to be rejected", name)
+		}
+	}
+
+	cfg := islandMonitorConfig()
+	cfg.MigrationInterval = 0
+	monitor, err := NewPopulationMonitor(cfg)
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	if monitor.cfg.MigrationInterval != defaultMigrationInterval || monitor.cfg.Migrants != defaultMigrants {
+		t.Fatalf("expected migration defaults, got interval=%d migrants=%d", monitor.cfg.MigrationInterval, monitor.cfg.Migrants)
+	}
+}
@@ -0,0 +1,138 @@
+package evo
+
+import (
+	"context"
+	"errors"
+	"math"
+)
+
+var (
+	ErrEvaluationTimeout = errors.New("evaluation timed out")
+	ErrEvaluationPanic   = errors.New("evaluation panicked")
+)
+
+// Degenerate evaluation outcomes recorded in ScoredGenome.Degenerate.
+const (
+	DegenerateTimeout   = "timeout"
+	DegeneratePanic     = "panic"
+	DegenerateNonFinite = "non_finite"
+)
+
+const defaultKarmaCooldown = 5
+
+// karmaLedger counts degenerate generations per topology fingerprint. A
+// fingerprint reaching KarmaStrikes is banned from parenthood for
+// KarmaCooldown generations, after which it starts over with no strikes.
+type karmaLedger struct {
+	strikes     map[string]int
+	bannedUntil map[string]int
+}
+
+func newKarmaLedger() *karmaLedger {
+	return &karmaLedger{strikes: map[string]int{}, bannedUntil: map[string]int{}}
+}
+
+func (m *PopulationMonitor) karmaEnabled() bool {
+	return m.cfg.KarmaStrikes > 0
+}
+
+// degenerateReason classifies an evaluation error that the karma ledger
+// absorbs instead of failing the run. Cancellation of the run itself is never
+// degenerate.
+func (m *PopulationMonitor) degenerateReason(ctx context.Context, err error) string {
+	if !m.karmaEnabled() || err == nil || ctx.Err() != nil {
+		return ""
+	}
+	switch {
+	case errors.Is(err, ErrEvaluationPanic):
+		return DegeneratePanic
+	case errors.Is(err, ErrEvaluationTimeout), errors.Is(err, context.DeadlineExceeded):
+		return DegenerateTimeout
+	}
+	return ""
+}
+
+// settleDegenerate flags genomes with non-finite fitness or NaN/Inf neuron
+// values and scores every genome whose fitness is unusable at the worst finite
+// fitness of the stage.
+func (m *PopulationMonitor) settleDegenerate(scored []ScoredGenome) {
+	if !m.karmaEnabled() {
+		return
+	}
+	unusable := make([]bool, len(scored))
+	worst, found := 0.0, false
+	for i := range scored {
+		item := &scored[i]
+		switch {
+		case item.Degenerate != "":
+			unusable[i] = true
+		case math.IsNaN(item.Fitness) || math.IsInf(item.Fitness, 0):
+			item.Degenerate = DegenerateNonFinite
+			unusable[i] = true
+		default:
+			if item.Clamps.NonFinite > 0 {
+				item.Degenerate = DegenerateNonFinite
+			}
+			if !found || item.Fitness < worst {
+				worst, found = item.Fitness, true
+			}
+		}
+	}
+	for i := range scored {
+		if unusable[i] {
+			scored[i].Fitness = worst
+		}
+	}
+}
+
+// recordKarma strikes the fingerprint of every degenerate genome once per
+// generation, bans fingerprints that reach the strike limit and reports the
+// generation's degenerate count and active bans.
+func (m *PopulationMonitor) recordKarma(diag *GenerationDiagnostics, scored []ScoredGenome, generation int) {
+	if !m.karmaEnabled() {
+		return
+	}
+	struck := map[string]struct{}{}
+	for _, item := range scored {
+		if item.Degenerate == "" {
+			continue
+		}
+		diag.DegenerateGenomes++
+		fingerprint := ComputeGenomeSignature(item.Genome).Fingerprint
+		if _, ok := struck[fingerprint]; ok {
+			continue
+		}
+		struck[fingerprint] = struct{}{}
+		m.karma.strikes[fingerprint]++
+		if m.karma.strikes[fingerprint] >= m.cfg.KarmaStrikes {
+			delete(m.karma.strikes, fingerprint)
+			m.karma.bannedUntil[fingerprint] = generation + m.cfg.KarmaCooldown
+		}
+	}
+	for fingerprint, until := range m.karma.bannedUntil {
+		if until <= generation {
+			delete(m.karma.bannedUntil, fingerprint)
+			continue
+		}
+		diag.BannedFingerprints++
+	}
+}
+
+// excludeBannedParents drops genomes whose fingerprint is banned. The pool is
+// returned unchanged if every member is banned.
+func (m *PopulationMonitor) excludeBannedParents(pool []ScoredGenome) []ScoredGenome {
+	if !m.karmaEnabled() || len(m.karma.bannedUntil) == 0 {
+		return pool
+	}
+	out := make([]ScoredGenome, 0, len(pool))
+	for _, item := range pool {
+		if _, banned := m.karma.bannedUntil[ComputeGenomeSignature(item.Genome).Fingerprint]; banned {
+			continue
+		}
+		out = append(out, item)
+	}
+	if len(out) == 0 {
+		return pool
+	}
+	return out
+}
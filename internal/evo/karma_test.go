@@ -0,0 +1,133 @@
+package evo
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"protogonos/internal/model"
+	"protogonos/internal/scape"
+)
+
+// volatileScape scores like oneDimScape but panics on saturated positive
+// outputs and returns NaN fitness on saturated negative ones.
+type volatileScape struct{}
+
+func (volatileScape) Name() string { return "one-dim" }
+
+func (volatileScape) Evaluate(ctx context.Context, a scape.Agent) (scape.Fitness, scape.Trace, error) {
+	out, err := a.(scape.StepAgent).RunStep(ctx, []float64{1.0})
+	if err != nil {
+		return 0, nil, err
+	}
+	switch {
+	case out[0] > 0.95:
+		panic("volatile output")
+	case out[0] < -0.95:
+		return scape.Fitness(math.NaN()), nil, nil
+	}
+	delta := out[0] - 1
+	return scape.Fitness(1 - delta*delta), nil, nil
+}
+
+// slowScape blocks until its context is done.
+type slowScape struct{}
+
+func (slowScape) Name() string { return "one-dim" }
+
+func (slowScape) Evaluate(ctx context.Context, _ scape.Agent) (scape.Fitness, scape.Trace, error) {
+	select {
+	case <-ctx.Done():
+		return 0, nil, ctx.Err()
+	case <-time.After(time.Second):
+		return 1, nil, nil
+	}
+}
+
+func karmaMonitorConfig(sc scape.Scape, size int) MonitorConfig {
+	return MonitorConfig{
+		Scape:           sc,
+		Mutation:        PerturbWeightAt{Index: 0, Delta: 0},
+		SpeciationMode:  SpeciationModeFingerprint,
+		PopulationSize:  size,
+		EliteCount:      1,
+		Generations:     3,
+		Workers:         2,
+		Seed:            3,
+		InputNeuronIDs:  []string{"i"},
+		OutputNeuronIDs: []string{"o"},
+	}
+}
+
+func TestPopulationMonitorEvaluationPanicFailsRunWithoutKarma(t *testing.T) {
+	initial := []model.Genome{newLinearGenome("g0", 1), newLinearGenome("g1", 5)}
+	monitor, err := NewPopulationMonitor(karmaMonitorConfig(volatileScape{}, len(initial)))
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	if _, err := monitor.Run(context.Background(), initial); !errors.Is(err, ErrEvaluationPanic) {
+		t.Fatalf("expected evaluation panic error, got %v", err)
+	}
+}
+
+func TestPopulationMonitorKarmaBansDegenerateFingerprints(t *testing.T) {
+	initial := []model.Genome{
+		newLinearGenome("good0", 0.9),
+		newLinearGenome("good1", 0.5),
+		newComplexLinearGenome("bad0", 5),
+		newComplexLinearGenome("bad1", -5),
+	}
+	cfg := karmaMonitorConfig(volatileScape{}, len(initial))
+	cfg.KarmaStrikes = 1
+	cfg.KarmaCooldown = 10
+	monitor, err := NewPopulationMonitor(cfg)
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	result, err := monitor.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	first := result.GenerationDiagnostics[0]
+	if first.DegenerateGenomes != 2 || first.BannedFingerprints != 1 {
+		t.Fatalf("expected two degenerate genomes sharing one banned fingerprint, got %+v", first)
+	}
+	if math.IsNaN(first.MeanFitness) || first.MinFitness != 0.75 {
+		t.Fatalf("expected degenerate genomes scored at the worst finite fitness, got %+v", first)
+	}
+	for _, record := range result.Lineage {
+		if record.Generation > 0 && (record.ParentID == "bad0" || record.ParentID == "bad1") {
+			t.Fatalf("banned genome %s bred %s", record.ParentID, record.GenomeID)
+		}
+	}
+	if last := result.GenerationDiagnostics[len(result.GenerationDiagnostics)-1]; last.DegenerateGenomes != 0 || last.BannedFingerprints != 1 {
+		t.Fatalf("expected degenerate lineage gone with the ban still active, got %+v", last)
+	}
+}
+
+func TestPopulationMonitorKarmaScoresTimeoutsAsDegenerate(t *testing.T) {
+	initial := []model.Genome{newLinearGenome("g0", 1), newLinearGenome("g1", 0.5)}
+	cfg := karmaMonitorConfig(slowScape{}, len(initial))
+	cfg.Generations = 1
+	cfg.EvaluationTimeout = time.Millisecond
+	cfg.KarmaStrikes = 2
+	monitor, err := NewPopulationMonitor(cfg)
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	result, err := monitor.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	for _, item := range result.FinalPopulation {
+		if item.Degenerate != DegenerateTimeout {
+			t.Fatalf("expected timeout outcome for %s, got %q", item.Genome.ID, item.Degenerate)
+		}
+	}
+	if diag := result.GenerationDiagnostics[0]; diag.DegenerateGenomes != 2 || diag.BannedFingerprints != 0 {
+		t.Fatalf("expected two timeouts and no ban after one strike, got %+v", diag)
+	}
+}
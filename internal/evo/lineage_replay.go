@@ -0,0 +1,176 @@
+package evo
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"protogonos/internal/genotype"
+	"protogonos/internal/model"
+)
+
+// Outcomes of replaying one lineage record.
+const (
+	ReplayMatched    = "matched"
+	ReplayMismatched = "mismatched"
+	ReplaySkipped    = "skipped"
+)
+
+const fallbackOperationSuffix = "(fallback)"
+
+// LineageReplay is the outcome of replaying one lineage record.
+// ReplayedFingerprint is empty when the record was skipped.
+type LineageReplay struct {
+	GenomeID            string `json:"genome_id"`
+	ParentID            string `json:"parent_id,omitempty"`
+	Generation          int    `json:"generation"`
+	Operation           string `json:"operation"`
+	Status              string `json:"status"`
+	Fingerprint         string `json:"fingerprint"`
+	ReplayedFingerprint string `json:"replayed_fingerprint,omitempty"`
+	Detail              string `json:"detail,omitempty"`
+}
+
+// LineageReplayConfig is what a run started from. Newcomer rebuilds an
+// injected genome from its record; without it newcomers are skipped.
+type LineageReplayConfig struct {
+	Seeds     []model.Genome
+	Operators []Operator
+	Newcomer  func(record LineageRecord) (model.Genome, error)
+}
+
+// ReplayLineage re-applies a run's recorded mutation events, in lineage order,
+// starting from its seed genomes, and checks every derived genome against its
+// recorded fingerprint and changed ids.
+//
+// Operators must be freshly built the way the run built them: each draws
+// from its own rng, so applying them in the recorded order reproduces the
+// run's structural choices. Weight changes from crossover and tuning draw
+// from other streams and are not replayed; fingerprints only cover structure.
+// Attempts the run discarded (inapplicable or IO-incompatible results, and
+// offspring replaced by entropy immigrants) still advanced their operator's
+// rng without leaving a record, so later records of that operator can
+// mismatch without the stored lineage being wrong. The first mismatch is the
+// one to inspect.
+//
+// Records that cannot be rebuilt are skipped, and so is everything
+// descending from them.
+func ReplayLineage(ctx context.Context, cfg LineageReplayConfig, lineage []LineageRecord) ([]LineageReplay, error) {
+	byName := make(map[string]Operator, len(cfg.Operators))
+	for _, operator := range cfg.Operators {
+		if operator == nil {
+			continue
+		}
+		if _, ok := byName[operator.Name()]; ok {
+			return nil, fmt.Errorf("replay operators share the name %s", operator.Name())
+		}
+		byName[operator.Name()] = operator
+	}
+	seedByID := make(map[string]model.Genome, len(cfg.Seeds))
+	for _, genome := range cfg.Seeds {
+		seedByID[genome.ID] = genome
+	}
+
+	genomes := make(map[string]model.Genome, len(cfg.Seeds))
+	out := make([]LineageReplay, 0, len(lineage))
+	for _, record := range lineage {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		result := LineageReplay{
+			GenomeID:    record.GenomeID,
+			ParentID:    record.ParentID,
+			Generation:  record.Generation,
+			Operation:   record.Operation,
+			Fingerprint: record.Fingerprint,
+		}
+		genome, detail, err := replayLineageRecord(ctx, cfg, record, seedByID, genomes, byName)
+		if err != nil {
+			return nil, err
+		}
+		if genome == nil {
+			result.Status = ReplaySkipped
+			result.Detail = detail
+			// A stale entry would let descendants replay from the wrong genome.
+			delete(genomes, record.GenomeID)
+			out = append(out, result)
+			continue
+		}
+		result.ReplayedFingerprint = ComputeGenomeSignature(*genome).Fingerprint
+		result.Status = ReplayMatched
+		if result.ReplayedFingerprint != record.Fingerprint {
+			result.Status = ReplayMismatched
+			result.Detail = fmt.Sprintf("fingerprint %s, recorded %s", result.ReplayedFingerprint, record.Fingerprint)
+		}
+		if detail != "" {
+			result.Status = ReplayMismatched
+			result.Detail = detail
+		}
+		genomes[record.GenomeID] = *genome
+		out = append(out, result)
+	}
+	return out, nil
+}
+
+// replayLineageRecord rebuilds the genome a record describes. A nil genome
+// means the record could not be replayed; a genome with a detail was rebuilt
+// but disagrees with the recorded events. Every event is applied even after a
+// disagreement so the operators' rngs stay in step with the run.
+func replayLineageRecord(ctx context.Context, cfg LineageReplayConfig, record LineageRecord, seeds, genomes map[string]model.Genome, operators map[string]Operator) (*model.Genome, string, error) {
+	switch {
+	case record.Operation == "seed" || record.Operation == "continue_seed":
+		seed, ok := seeds[record.GenomeID]
+		if !ok {
+			return nil, "seed genome not available", nil
+		}
+		return &seed, "", nil
+	case record.Operation == "afpo_newcomer" || strings.HasPrefix(record.Operation, "entropy_"):
+		if cfg.Newcomer == nil {
+			return nil, "newcomer factory not available", nil
+		}
+		newcomer, err := cfg.Newcomer(record)
+		if err != nil {
+			return nil, fmt.Sprintf("rebuild newcomer: %v", err), nil
+		}
+		return &newcomer, "", nil
+	}
+
+	parent, ok := genomes[record.ParentID]
+	if !ok {
+		return nil, fmt.Sprintf("parent %s was not replayed", record.ParentID), nil
+	}
+	child := genotype.CloneAgent(parent, record.GenomeID)
+	if record.Operation == "elite_clone" || record.Operation == "afpo_survivor" {
+		return &child, "", nil
+	}
+	if len(record.Events) == 0 {
+		return nil, "no recorded mutation events", nil
+	}
+	detail := ""
+	mismatch := func(format string, args ...any) {
+		if detail == "" {
+			detail = fmt.Sprintf(format, args...)
+		}
+	}
+	for i, event := range record.Events {
+		name := strings.TrimSuffix(event.Mutation, fallbackOperationSuffix)
+		operator, ok := operators[name]
+		if !ok {
+			return nil, fmt.Sprintf("event %d: unknown operator %s", i, name), nil
+		}
+		next, err := operator.Apply(ctx, child)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, "", ctxErr
+			}
+			mismatch("event %d: %s failed: %v", i, name, err)
+			continue
+		}
+		if ids := mutationChangedIDs(child, next); !slices.Equal(ids, event.IDs) {
+			mismatch("event %d: %s changed %v, recorded %v", i, name, ids, event.IDs)
+		}
+		child = next
+	}
+	return &child, detail, nil
+}
@@ -0,0 +1,141 @@
+package evo
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"protogonos/internal/genotype"
+	"protogonos/internal/model"
+)
+
+func TestReplayLineageReproducesMonitorRun(t *testing.T) {
+	operators := func() (Operator, []WeightedMutation) {
+		policy := []WeightedMutation{
+			{Operator: &MutateWeights{Rand: rand.New(rand.NewSource(11)), MaxDelta: 1.0}, Weight: 2},
+			{Operator: &AddNeuron{Rand: rand.New(rand.NewSource(12))}, Weight: 1},
+			{Operator: &MutateAF{Rand: rand.New(rand.NewSource(13))}, Weight: 1},
+		}
+		return &PerturbWeightsProportional{Rand: rand.New(rand.NewSource(10)), MaxDelta: 1.0}, policy
+	}
+	initial := []model.Genome{
+		newComplexLinearGenome("g0", -0.5),
+		newComplexLinearGenome("g1", 0.0),
+		newComplexLinearGenome("g2", 0.5),
+		newComplexLinearGenome("g3", 1.0),
+	}
+	mutation, policy := operators()
+	monitor, err := NewPopulationMonitor(MonitorConfig{
+		Scape:           oneDimScape{},
+		Mutation:        mutation,
+		MutationPolicy:  policy,
+		PopulationSize:  len(initial),
+		EliteCount:      1,
+		Generations:     5,
+		Workers:         2,
+		Seed:            3,
+		CrossoverRate:   0.5,
+		InputNeuronIDs:  []string{"i"},
+		OutputNeuronIDs: []string{"o"},
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	result, err := monitor.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	replayConfig := func() LineageReplayConfig {
+		mutation, policy := operators()
+		ops := []Operator{mutation}
+		for _, item := range policy {
+			ops = append(ops, item.Operator)
+		}
+		return LineageReplayConfig{Seeds: initial, Operators: ops}
+	}
+	replays, err := ReplayLineage(context.Background(), replayConfig(), result.Lineage)
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if len(replays) != len(result.Lineage) {
+		t.Fatalf("expected one replay per record, got %d of %d", len(replays), len(result.Lineage))
+	}
+	mutated := 0
+	for i, replay := range replays {
+		if replay.Status != ReplayMatched {
+			t.Fatalf("record %d (%s) %s: %s", i, replay.GenomeID, replay.Status, replay.Detail)
+		}
+		if len(result.Lineage[i].Events) > 0 {
+			mutated++
+		}
+	}
+	if mutated == 0 {
+		t.Fatal("expected mutated offspring in the lineage")
+	}
+
+	// Dropping one mutated record orphans its descendants and leaves its
+	// operators out of step for later records.
+	tampered := append([]LineageRecord(nil), result.Lineage...)
+	dropped := -1
+	for i, record := range tampered {
+		if len(record.Events) > 0 {
+			dropped = i
+			break
+		}
+	}
+	orphan := tampered[dropped].GenomeID
+	tampered = append(tampered[:dropped], tampered[dropped+1:]...)
+	replays, err = ReplayLineage(context.Background(), replayConfig(), tampered)
+	if err != nil {
+		t.Fatalf("replay tampered lineage: %v", err)
+	}
+	mismatched := 0
+	for i, replay := range replays {
+		switch {
+		case tampered[i].ParentID == orphan && replay.Status != ReplaySkipped:
+			t.Fatalf("expected child of dropped %s skipped, got %+v", orphan, replay)
+		case replay.Status == ReplayMismatched:
+			mismatched++
+		}
+	}
+	if mismatched == 0 {
+		t.Fatal("expected the dropped record to desynchronize later records")
+	}
+}
+
+func TestReplayLineageReportsUnknownOperatorsAndNewcomers(t *testing.T) {
+	seed := newLinearGenome("g0", 0.5)
+	lineage := []LineageRecord{
+		{GenomeID: "g0", Operation: "seed", Fingerprint: ComputeGenomeSignature(seed).Fingerprint},
+		{GenomeID: "g0", ParentID: "g0", Generation: 1, Operation: "elite_clone", Fingerprint: ComputeGenomeSignature(seed).Fingerprint},
+		{GenomeID: "c1", ParentID: "g0", Generation: 1, Operation: "mystery", Events: []genotype.EvoHistoryEvent{{Mutation: "mystery"}}},
+		{GenomeID: "n1", Generation: 1, Operation: "afpo_newcomer", Fingerprint: ComputeGenomeSignature(seed).Fingerprint},
+		{GenomeID: "g9", Operation: "seed"},
+	}
+	replays, err := ReplayLineage(context.Background(), LineageReplayConfig{Seeds: []model.Genome{seed}}, lineage)
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	want := []string{ReplayMatched, ReplayMatched, ReplaySkipped, ReplaySkipped, ReplaySkipped}
+	for i, replay := range replays {
+		if replay.Status != want[i] {
+			t.Fatalf("record %d: expected %s, got %+v", i, want[i], replay)
+		}
+	}
+	if !strings.Contains(replays[2].Detail, "unknown operator mystery") {
+		t.Fatalf("expected unknown operator detail, got %q", replays[2].Detail)
+	}
+
+	newcomer := func(record LineageRecord) (model.Genome, error) {
+		return newLinearGenome(record.GenomeID, 0.1), nil
+	}
+	replays, err = ReplayLineage(context.Background(), LineageReplayConfig{Seeds: []model.Genome{seed}, Newcomer: newcomer}, lineage[:4])
+	if err != nil {
+		t.Fatalf("replay with newcomers: %v", err)
+	}
+	if replays[3].Status != ReplayMatched {
+		t.Fatalf("expected rebuilt newcomer to match, got %+v", replays[3])
+	}
+}
@@ -0,0 +1,84 @@
+package evo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"protogonos/internal/model"
+	"protogonos/internal/scape"
+)
+
+type fidelityGenerationStats struct {
+	Finalists int
+	Offset    float64
+}
+
+func (m *PopulationMonitor) multiFidelityEnabled() bool {
+	return m.cfg.OpMode == OpModeGT && m.cfg.LowFidelity > 0 && m.cfg.LowFidelity < 1
+}
+
+func validateMultiFidelity(cfg MonitorConfig) error {
+	if cfg.LowFidelity == 0 {
+		return nil
+	}
+	if math.IsNaN(cfg.LowFidelity) || cfg.LowFidelity < 0 || cfg.LowFidelity >= 1 {
+		return fmt.Errorf("low fidelity must be in (0, 1), got %f", cfg.LowFidelity)
+	}
+	if math.IsNaN(cfg.FinalistFraction) || cfg.FinalistFraction <= 0 || cfg.FinalistFraction > 1 {
+		return fmt.Errorf("finalist fraction must be in (0, 1], got %f", cfg.FinalistFraction)
+	}
+	if fidelity, ok := cfg.Scape.(scape.FidelityScape); !ok || !fidelity.SupportsFidelity() {
+		return fmt.Errorf("scape %s does not support multi-fidelity evaluation", cfg.Scape.Name())
+	}
+	return nil
+}
+
+// evaluatePopulationMultiFidelity screens every genome at LowFidelity without
+// tuning, then runs the regular (tuned, full-fidelity) evaluation on the top
+// FinalistFraction. Screened-out genomes keep their low-fidelity fitness
+// shifted by the finalists' mean full-minus-low gap, capped at the weakest
+// finalist so a fully evaluated genome is never outranked by a screened one.
+func (m *PopulationMonitor) evaluatePopulationMultiFidelity(ctx context.Context, population []model.Genome, generation int) ([]ScoredGenome, tuningGenerationStats, []bool, error) {
+	screened, _, counted, err := m.evaluatePopulationStage(scape.WithFidelity(ctx, m.cfg.LowFidelity), population, generation, false)
+	if err != nil {
+		return nil, tuningGenerationStats{}, nil, err
+	}
+
+	order := make([]int, len(screened))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return ScoredBefore(screened[order[i]], screened[order[j]])
+	})
+	finalistCount := int(math.Ceil(m.cfg.FinalistFraction * float64(len(population))))
+	finalistCount = max(1, min(finalistCount, len(population)))
+	finalists := make([]model.Genome, finalistCount)
+	for i, idx := range order[:finalistCount] {
+		finalists[i] = population[idx]
+	}
+
+	full, tuningStats, finalistCounted, err := m.evaluatePopulationStage(ctx, finalists, generation, true)
+	if err != nil {
+		return nil, tuningGenerationStats{}, nil, err
+	}
+	m.totalEvaluations += countTrue(finalistCounted)
+
+	scored := make([]ScoredGenome, len(screened))
+	copy(scored, screened)
+	gap := 0.0
+	floor := math.Inf(1)
+	for i, idx := range order[:finalistCount] {
+		gap += full[i].Fitness - screened[idx].Fitness
+		floor = math.Min(floor, full[i].Fitness)
+		scored[idx] = full[i]
+	}
+	gap /= float64(finalistCount)
+	for _, idx := range order[finalistCount:] {
+		scored[idx].Fitness = math.Min(screened[idx].Fitness+gap, floor)
+	}
+	m.generationFidelity = fidelityGenerationStats{Finalists: finalistCount, Offset: gap}
+	return scored, tuningStats, counted, nil
+}
@@ -0,0 +1,99 @@
+package evo
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"protogonos/internal/model"
+	"protogonos/internal/scape"
+)
+
+// fidelityScape scores the agent's output directly, minus one when evaluated
+// at low fidelity, so the calibration offset is known exactly.
+type fidelityScape struct{}
+
+func (fidelityScape) Name() string { return "fidelity-scape" }
+
+func (fidelityScape) SupportsFidelity() bool { return true }
+
+func (fidelityScape) Evaluate(ctx context.Context, a scape.Agent) (scape.Fitness, scape.Trace, error) {
+	out, err := a.(scape.StepAgent).RunStep(ctx, []float64{1.0})
+	if err != nil {
+		return 0, nil, err
+	}
+	fitness := out[0]
+	if scape.FidelityFromContext(ctx) < 1 {
+		fitness--
+	}
+	return scape.Fitness(fitness), scape.Trace{}, nil
+}
+
+func TestPopulationMonitorMultiFidelityScreensThenPromotesFinalists(t *testing.T) {
+	initial := []model.Genome{
+		newLinearGenome("g0", 0.1),
+		newLinearGenome("g1", 0.2),
+		newLinearGenome("g2", 0.3),
+		newLinearGenome("g3", 0.4),
+	}
+	monitor, err := NewPopulationMonitor(MonitorConfig{
+		Scape:            fidelityScape{},
+		Mutation:         namedNoopMutation{name: "noop"},
+		PopulationSize:   len(initial),
+		EliteCount:       1,
+		Generations:      1,
+		Workers:          2,
+		Seed:             1,
+		InputNeuronIDs:   []string{"i"},
+		OutputNeuronIDs:  []string{"o"},
+		LowFidelity:      0.2,
+		FinalistFraction: 0.5,
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	result, err := monitor.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	want := map[string]float64{"g3": 0.4, "g2": 0.3, "g1": 0.2, "g0": 0.1}
+	for _, scored := range result.FinalPopulation {
+		if math.Abs(scored.Fitness-want[scored.Genome.ID]) > 1e-9 {
+			t.Fatalf("expected calibrated fitness %f for %s, got %f", want[scored.Genome.ID], scored.Genome.ID, scored.Fitness)
+		}
+	}
+	diag := result.GenerationDiagnostics[0]
+	if diag.FidelityFinalists != 2 || math.Abs(diag.FidelityOffset-1) > 1e-9 {
+		t.Fatalf("expected two finalists with offset 1, got %+v", diag)
+	}
+	if diag.TotalEvaluations != 6 {
+		t.Fatalf("expected screening plus finalist evaluations to be counted, got %d", diag.TotalEvaluations)
+	}
+}
+
+func TestMultiFidelityValidation(t *testing.T) {
+	base := MonitorConfig{
+		Scape:           fidelityScape{},
+		Mutation:        namedNoopMutation{name: "noop"},
+		PopulationSize:  2,
+		EliteCount:      1,
+		Generations:     1,
+		InputNeuronIDs:  []string{"i"},
+		OutputNeuronIDs: []string{"o"},
+		LowFidelity:     0.5,
+	}
+	if _, err := NewPopulationMonitor(base); err == nil {
+		t.Fatal("expected missing finalist fraction to fail")
+	}
+	base.FinalistFraction = 0.5
+	base.LowFidelity = 1
+	if _, err := NewPopulationMonitor(base); err == nil {
+		t.Fatal("expected full fidelity screening to fail")
+	}
+	base.LowFidelity = 0.5
+	base.Scape = oneDimScape{}
+	if _, err := NewPopulationMonitor(base); err == nil {
+		t.Fatal("expected scape without fidelity support to fail")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 
+	"protogonos/internal/encoding"
 	"protogonos/internal/genotype"
 	protoio "protogonos/internal/io"
 	"protogonos/internal/model"
@@ -73,11 +74,12 @@ func (o *PerturbRandomWeight) Name() string {
 }
 
 func (o *PerturbRandomWeight) Applicable(genome model.Genome, _ string) bool {
-	return len(genome.Synapses) > 0
+	return len(mutableSynapseIndexes(genome)) > 0
 }
 
 func (o *PerturbRandomWeight) Apply(_ context.Context, genome model.Genome) (model.Genome, error) {
-	if len(genome.Synapses) == 0 {
+	mutable := mutableSynapseIndexes(genome)
+	if len(mutable) == 0 {
 		return model.Genome{}, ErrNoMutationChoice
 	}
 	if o == nil || o.Rand == nil {
@@ -87,7 +89,7 @@ func (o *PerturbRandomWeight) Apply(_ context.Context, genome model.Genome) (mod
 		return model.Genome{}, errors.New("max delta must be > 0")
 	}
 
-	idx := o.Rand.Intn(len(genome.Synapses))
+	idx := mutable[o.Rand.Intn(len(mutable))]
 	delta := (o.Rand.Float64()*2 - 1) * o.MaxDelta
 
 	mutated := cloneGenome(genome)
@@ -97,7 +99,7 @@ func (o *PerturbRandomWeight) Apply(_ context.Context, genome model.Genome) (mod
 
 // PerturbWeightsProportional mutates a random subset of synapses using the
 // reference-style mutate probability 1/sqrt(total_weights). At least one
-// synapse is always perturbed when synapses are present.
+// synapse is always perturbed when unfrozen synapses are present.
 type PerturbWeightsProportional struct {
 	Rand     *rand.Rand
 	MaxDelta float64
@@ -108,13 +110,17 @@ func (o *PerturbWeightsProportional) Name() string {
 }
 
 func (o *PerturbWeightsProportional) Applicable(genome model.Genome, _ string) bool {
-	return len(genome.Synapses) > 0
+	return len(mutableSynapseIndexes(genome)) > 0
 }
 
 func (o *PerturbWeightsProportional) Apply(_ context.Context, genome model.Genome) (model.Genome, error) {
 	if len(genome.Synapses) == 0 {
 		return model.Genome{}, ErrNoSynapses
 	}
+	mutable := mutableSynapseIndexes(genome)
+	if len(mutable) == 0 {
+		return model.Genome{}, ErrNoMutationChoice
+	}
 	if o == nil || o.Rand == nil {
 		return model.Genome{}, errors.New("random source is required")
 	}
@@ -123,9 +129,9 @@ func (o *PerturbWeightsProportional) Apply(_ context.Context, genome model.Genom
 	}
 
 	mutated := cloneGenome(genome)
-	mp := 1 / math.Sqrt(float64(len(mutated.Synapses)))
+	mp := 1 / math.Sqrt(float64(len(mutable)))
 	mutatedCount := 0
-	for i := range mutated.Synapses {
+	for _, i := range mutable {
 		if o.Rand.Float64() >= mp {
 			continue
 		}
@@ -134,14 +140,15 @@ func (o *PerturbWeightsProportional) Apply(_ context.Context, genome model.Genom
 		mutatedCount++
 	}
 	if mutatedCount == 0 {
-		idx := o.Rand.Intn(len(mutated.Synapses))
+		idx := mutable[o.Rand.Intn(len(mutable))]
 		delta := (o.Rand.Float64()*2 - 1) * o.MaxDelta
 		mutated.Synapses[idx].Weight += delta
 	}
 	return mutated, nil
 }
 
-// MutateWeights mirrors the reference mutate_weights operator name.
+// MutateWeights mirrors the reference mutate_weights operator name. Frozen
+// synapses and the incoming synapses of frozen neurons are never perturbed.
 type MutateWeights struct {
 	Rand     *rand.Rand
 	MaxDelta float64
@@ -152,11 +159,12 @@ func (o *MutateWeights) Name() string {
 }
 
 func (o *MutateWeights) Applicable(genome model.Genome, _ string) bool {
-	return len(genome.Synapses) > 0 || len(genome.ActuatorIDs) > 0
+	return len(mutableSynapseIndexes(genome)) > 0 || len(genome.ActuatorIDs) > 0
 }
 
 func (o *MutateWeights) Apply(ctx context.Context, genome model.Genome) (model.Genome, error) {
-	if len(genome.Synapses) == 0 && len(genome.ActuatorIDs) == 0 {
+	mutable := mutableSynapseIndexes(genome)
+	if len(mutable) == 0 && len(genome.ActuatorIDs) == 0 {
 		return model.Genome{}, ErrNoMutationChoice
 	}
 	if o == nil || o.Rand == nil {
@@ -220,14 +228,14 @@ func (o *MutateWeights) Apply(ctx context.Context, genome model.Genome) (model.G
 	}
 
 	if changed == 0 {
-		if len(mutated.Synapses) == 0 {
+		if len(mutable) == 0 {
 			return model.Genome{}, ErrNoMutationChoice
 		}
 		idx := 0
 		if len(candidateFallback) > 0 {
 			idx = candidateFallback[o.Rand.Intn(len(candidateFallback))]
 		} else {
-			idx = o.Rand.Intn(len(mutated.Synapses))
+			idx = mutable[o.Rand.Intn(len(mutable))]
 		}
 		delta := (o.Rand.Float64()*2 - 1) * o.MaxDelta
 		mutated.Synapses[idx].Weight += delta
@@ -246,13 +254,17 @@ func (o *PerturbRandomBias) Name() string {
 }
 
 func (o *PerturbRandomBias) Applicable(genome model.Genome, _ string) bool {
-	return len(genome.Neurons) > 0
+	return len(mutableNeuronIndexes(genome)) > 0
 }
 
 func (o *PerturbRandomBias) Apply(_ context.Context, genome model.Genome) (model.Genome, error) {
 	if len(genome.Neurons) == 0 {
 		return model.Genome{}, ErrNoNeurons
 	}
+	mutable := mutableNeuronIndexes(genome)
+	if len(mutable) == 0 {
+		return model.Genome{}, ErrNoMutationChoice
+	}
 	if o == nil || o.Rand == nil {
 		return model.Genome{}, errors.New("random source is required")
 	}
@@ -260,7 +272,7 @@ func (o *PerturbRandomBias) Apply(_ context.Context, genome model.Genome) (model
 		return model.Genome{}, errors.New("max delta must be > 0")
 	}
 
-	idx := o.Rand.Intn(len(genome.Neurons))
+	idx := mutable[o.Rand.Intn(len(mutable))]
 	delta := (o.Rand.Float64()*2 - 1) * o.MaxDelta
 
 	mutated := cloneGenome(genome)
@@ -269,6 +281,57 @@ func (o *PerturbRandomBias) Apply(_ context.Context, genome model.Genome) (model
 	return mutated, nil
 }
 
+// PerturbAllBiases is the bias counterpart of PerturbWeightsProportional:
+// every unfrozen neuron bias is perturbed with probability 1/sqrt(neurons),
+// and at least one always is.
+type PerturbAllBiases struct {
+	Rand     *rand.Rand
+	MaxDelta float64
+}
+
+func (o *PerturbAllBiases) Name() string {
+	return "perturb_all_biases"
+}
+
+func (o *PerturbAllBiases) Applicable(genome model.Genome, _ string) bool {
+	return len(mutableNeuronIndexes(genome)) > 0
+}
+
+func (o *PerturbAllBiases) Apply(_ context.Context, genome model.Genome) (model.Genome, error) {
+	if len(genome.Neurons) == 0 {
+		return model.Genome{}, ErrNoNeurons
+	}
+	mutable := mutableNeuronIndexes(genome)
+	if len(mutable) == 0 {
+		return model.Genome{}, ErrNoMutationChoice
+	}
+	if o == nil || o.Rand == nil {
+		return model.Genome{}, errors.New("random source is required")
+	}
+	if o.MaxDelta <= 0 {
+		return model.Genome{}, errors.New("max delta must be > 0")
+	}
+
+	mutated := cloneGenome(genome)
+	currentGeneration := currentGenomeGeneration(mutated)
+	mp := 1 / math.Sqrt(float64(len(mutable)))
+	perturbed := 0
+	for _, idx := range mutable {
+		if o.Rand.Float64() >= mp {
+			continue
+		}
+		mutated.Neurons[idx].Bias += (o.Rand.Float64()*2 - 1) * o.MaxDelta
+		mutated.Neurons[idx].Generation = currentGeneration
+		perturbed++
+	}
+	if perturbed == 0 {
+		idx := mutable[o.Rand.Intn(len(mutable))]
+		mutated.Neurons[idx].Bias += (o.Rand.Float64()*2 - 1) * o.MaxDelta
+		mutated.Neurons[idx].Generation = currentGeneration
+	}
+	return mutated, nil
+}
+
 // AddBias mirrors the reference add_bias operator name.
 type AddBias struct {
 	Rand     *rand.Rand
@@ -280,7 +343,7 @@ func (o *AddBias) Name() string {
 }
 
 func (o *AddBias) Applicable(genome model.Genome, _ string) bool {
-	return len(genome.Neurons) > 0
+	return len(mutableNeuronIndexes(genome)) > 0
 }
 
 func (o *AddBias) Apply(ctx context.Context, genome model.Genome) (model.Genome, error) {
@@ -297,17 +360,21 @@ func (o *RemoveRandomBias) Name() string {
 }
 
 func (o *RemoveRandomBias) Applicable(genome model.Genome, _ string) bool {
-	return len(genome.Neurons) > 0
+	return len(mutableNeuronIndexes(genome)) > 0
 }
 
 func (o *RemoveRandomBias) Apply(_ context.Context, genome model.Genome) (model.Genome, error) {
 	if len(genome.Neurons) == 0 {
 		return model.Genome{}, ErrNoNeurons
 	}
+	mutable := mutableNeuronIndexes(genome)
+	if len(mutable) == 0 {
+		return model.Genome{}, ErrNoMutationChoice
+	}
 	if o == nil || o.Rand == nil {
 		return model.Genome{}, errors.New("random source is required")
 	}
-	idx := o.Rand.Intn(len(genome.Neurons))
+	idx := mutable[o.Rand.Intn(len(mutable))]
 	mutated := cloneGenome(genome)
 	mutated.Neurons[idx].Bias = 0
 	mutated.Neurons[idx].Generation = currentGenomeGeneration(mutated)
@@ -691,6 +758,274 @@ func (o *AddRandomOutlink) Apply(_ context.Context, genome model.Genome) (model.
 	return addDirectedRandomSynapse(genome, o.Rand, o.MaxAbsWeight, fromCandidates, toCandidates)
 }
 
+// defaultRecurrentLoopMaxLength bounds the cycles add_recurrent_loop closes
+// when MaxCycleLength is unset.
+const defaultRecurrentLoopMaxLength = 3
+
+// AddRecurrentLoop deliberately introduces recurrence, which the directional
+// feedforward-only link operators never create: it adds either a self-loop
+// or a back edge from a neuron to one of its upstream neurons, closing a
+// cycle of at most MaxCycleLength neurons (a self-loop has length 1). The new
+// synapse is marked recurrent so it reads the previous step's output.
+// Input neurons never receive a loop.
+type AddRecurrentLoop struct {
+	Rand           *rand.Rand
+	MaxAbsWeight   float64
+	MaxCycleLength int
+	InputNeuronIDs []string
+}
+
+func (o *AddRecurrentLoop) Name() string {
+	return "add_recurrent_loop"
+}
+
+func (o *AddRecurrentLoop) Applicable(genome model.Genome, _ string) bool {
+	return len(o.candidates(genome)) > 0
+}
+
+func (o *AddRecurrentLoop) Apply(_ context.Context, genome model.Genome) (model.Genome, error) {
+	if o == nil || o.Rand == nil {
+		return model.Genome{}, errors.New("random source is required")
+	}
+	if len(genome.Neurons) == 0 {
+		return model.Genome{}, ErrNoNeurons
+	}
+	if o.MaxAbsWeight <= 0 {
+		return model.Genome{}, errors.New("max abs weight must be > 0")
+	}
+	if o.MaxCycleLength < 0 {
+		return model.Genome{}, errors.New("max cycle length must be >= 0")
+	}
+	candidates := o.candidates(genome)
+	if len(candidates) == 0 {
+		return model.Genome{}, ErrNoMutationChoice
+	}
+	selected := candidates[o.Rand.Intn(len(candidates))]
+	weight := (o.Rand.Float64()*2 - 1) * o.MaxAbsWeight
+
+	mutated := cloneGenome(genome)
+	mutated.Synapses = append(mutated.Synapses, model.Synapse{
+		ID:        uniqueSynapseID(genome, o.Rand),
+		From:      selected.from,
+		To:        selected.to,
+		Weight:    weight,
+		Enabled:   true,
+		Recurrent: true,
+	})
+	return mutated, nil
+}
+
+type neuronPair struct {
+	from string
+	to   string
+}
+
+// candidates lists the loops the operator may close: every self-loop, and
+// every back edge from->to where to already reaches from over at most
+// MaxCycleLength-1 enabled synapses. Pairs already joined by a synapse in
+// that direction are skipped.
+func (o *AddRecurrentLoop) candidates(genome model.Genome) []neuronPair {
+	maxLength := o.MaxCycleLength
+	if maxLength == 0 {
+		maxLength = defaultRecurrentLoopMaxLength
+	}
+	inputSet := toIDSet(o.InputNeuronIDs)
+	successors := make(map[string][]string, len(genome.Neurons))
+	for _, syn := range genome.Synapses {
+		if syn.Enabled {
+			successors[syn.From] = append(successors[syn.From], syn.To)
+		}
+	}
+
+	var out []neuronPair
+	for _, to := range genome.Neurons {
+		if _, isInput := inputSet[to.ID]; isInput {
+			continue
+		}
+		// Breadth-first search downstream of to: any neuron reached within
+		// maxLength-1 hops can close a cycle back into to.
+		depth := map[string]int{to.ID: 0}
+		frontier := []string{to.ID}
+		for hop := 1; hop < maxLength && len(frontier) > 0; hop++ {
+			var next []string
+			for _, id := range frontier {
+				for _, succ := range successors[id] {
+					if _, seen := depth[succ]; seen {
+						continue
+					}
+					depth[succ] = hop
+					next = append(next, succ)
+				}
+			}
+			frontier = next
+		}
+		for _, from := range genome.Neurons {
+			if _, reachable := depth[from.ID]; !reachable {
+				continue
+			}
+			if hasDirectedSynapse(genome, from.ID, to.ID) {
+				continue
+			}
+			out = append(out, neuronPair{from: from.ID, to: to.ID})
+		}
+	}
+	return out
+}
+
+// InsertModule grafts a random module from Library into the genome: the
+// module's neurons and internal synapses are copied under fresh ids, a source
+// neuron feeds every module input and every module output feeds a target
+// neuron. The target never reaches the source over feedforward synapses, so
+// the graft adds no unmarked cycle. Output neurons are never sources and
+// input neurons never targets.
+type InsertModule struct {
+	Rand            *rand.Rand
+	Library         []model.Module
+	MaxAbsWeight    float64
+	InputNeuronIDs  []string
+	OutputNeuronIDs []string
+}
+
+func (o *InsertModule) Name() string {
+	return "insert_module"
+}
+
+func (o *InsertModule) Applicable(genome model.Genome, _ string) bool {
+	return len(o.Library) > 0 && len(o.insertionPoints(genome)) > 0
+}
+
+func (o *InsertModule) Apply(_ context.Context, genome model.Genome) (model.Genome, error) {
+	if o == nil || o.Rand == nil {
+		return model.Genome{}, errors.New("random source is required")
+	}
+	if len(o.Library) == 0 {
+		return model.Genome{}, errors.New("module library is empty")
+	}
+	if len(genome.Neurons) == 0 {
+		return model.Genome{}, ErrNoNeurons
+	}
+	if o.MaxAbsWeight <= 0 {
+		return model.Genome{}, errors.New("max abs weight must be > 0")
+	}
+	points := o.insertionPoints(genome)
+	if len(points) == 0 {
+		return model.Genome{}, ErrNoMutationChoice
+	}
+	module := o.Library[o.Rand.Intn(len(o.Library))]
+	if len(module.Neurons) == 0 || len(module.Inputs) == 0 || len(module.Outputs) == 0 {
+		return model.Genome{}, fmt.Errorf("module %s has no neurons or ports", module.ID)
+	}
+	point := points[o.Rand.Intn(len(points))]
+
+	mutated := cloneGenome(genome)
+	prefix := uniqueModulePrefix(genome, module, o.Rand)
+	generation := currentGenomeGeneration(mutated)
+	for _, neuron := range module.Neurons {
+		neuron.ID = prefix + neuron.ID
+		neuron.Generation = generation
+		neuron.Innovation = 0
+		neuron.PlasticityBiasParams = append([]float64(nil), neuron.PlasticityBiasParams...)
+		mutated.Neurons = append(mutated.Neurons, neuron)
+	}
+	for _, syn := range module.Synapses {
+		syn.ID = prefix + syn.ID
+		syn.From = prefix + syn.From
+		syn.To = prefix + syn.To
+		syn.Innovation = 0
+		syn.PlasticityParams = append([]float64(nil), syn.PlasticityParams...)
+		mutated.Synapses = append(mutated.Synapses, syn)
+	}
+	for _, input := range module.Inputs {
+		mutated.Synapses = append(mutated.Synapses, model.Synapse{
+			ID:      uniqueSynapseID(mutated, o.Rand),
+			From:    point.from,
+			To:      prefix + input,
+			Weight:  (o.Rand.Float64()*2 - 1) * o.MaxAbsWeight,
+			Enabled: true,
+		})
+	}
+	for _, output := range module.Outputs {
+		mutated.Synapses = append(mutated.Synapses, model.Synapse{
+			ID:      uniqueSynapseID(mutated, o.Rand),
+			From:    prefix + output,
+			To:      point.to,
+			Weight:  (o.Rand.Float64()*2 - 1) * o.MaxAbsWeight,
+			Enabled: true,
+		})
+	}
+	return mutated, nil
+}
+
+// insertionPoints lists the source/target pairs a module can be spliced
+// between: the source is not an output neuron, the target is not an input
+// neuron, and the target is not upstream of the source.
+func (o *InsertModule) insertionPoints(genome model.Genome) []neuronPair {
+	inputSet := toIDSet(o.InputNeuronIDs)
+	outputSet := toIDSet(o.OutputNeuronIDs)
+	predecessors := make(map[string][]string, len(genome.Neurons))
+	for _, syn := range genome.Synapses {
+		if syn.Enabled && !syn.Recurrent {
+			predecessors[syn.To] = append(predecessors[syn.To], syn.From)
+		}
+	}
+
+	var out []neuronPair
+	for _, from := range genome.Neurons {
+		if _, isOutput := outputSet[from.ID]; isOutput {
+			continue
+		}
+		upstream := map[string]struct{}{from.ID: {}}
+		frontier := []string{from.ID}
+		for len(frontier) > 0 {
+			var next []string
+			for _, id := range frontier {
+				for _, pred := range predecessors[id] {
+					if _, seen := upstream[pred]; seen {
+						continue
+					}
+					upstream[pred] = struct{}{}
+					next = append(next, pred)
+				}
+			}
+			frontier = next
+		}
+		for _, to := range genome.Neurons {
+			if _, isInput := inputSet[to.ID]; isInput {
+				continue
+			}
+			if _, isUpstream := upstream[to.ID]; isUpstream {
+				continue
+			}
+			out = append(out, neuronPair{from: from.ID, to: to.ID})
+		}
+	}
+	return out
+}
+
+// uniqueModulePrefix returns an id prefix under which none of the module's
+// neurons or synapses collide with ids already in the genome.
+func uniqueModulePrefix(g model.Genome, module model.Module, rng *rand.Rand) string {
+	for {
+		prefix := fmt.Sprintf("%s-%d-", module.ID, rng.Int63())
+		clash := false
+		for _, neuron := range module.Neurons {
+			if hasNeuron(g, prefix+neuron.ID) {
+				clash = true
+				break
+			}
+		}
+		for _, syn := range module.Synapses {
+			if clash {
+				break
+			}
+			clash = hasSynapse(g, prefix+syn.ID)
+		}
+		if !clash {
+			return prefix
+		}
+	}
+}
+
 // RemoveRandomSynapse removes a random synapse.
 type RemoveRandomSynapse struct {
 	Rand *rand.Rand
@@ -718,6 +1053,30 @@ func (o *RemoveRandomSynapse) Apply(_ context.Context, genome model.Genome) (mod
 	return mutated, nil
 }
 
+// ToggleRandomSynapseEnabled flips the Enabled flag of a random synapse,
+// NEAT's enable/disable gene mutation; see ToggleSynapseEnabled.
+type ToggleRandomSynapseEnabled struct {
+	Rand *rand.Rand
+}
+
+func (o *ToggleRandomSynapseEnabled) Name() string {
+	return "toggle_synapse_enabled"
+}
+
+func (o *ToggleRandomSynapseEnabled) Applicable(genome model.Genome, _ string) bool {
+	return len(genome.Synapses) > 0
+}
+
+func (o *ToggleRandomSynapseEnabled) Apply(ctx context.Context, genome model.Genome) (model.Genome, error) {
+	if o == nil || o.Rand == nil {
+		return model.Genome{}, errors.New("random source is required")
+	}
+	if len(genome.Synapses) == 0 {
+		return model.Genome{}, ErrNoSynapses
+	}
+	return ToggleSynapseEnabled{ID: genome.Synapses[o.Rand.Intn(len(genome.Synapses))].ID}.Apply(ctx, genome)
+}
+
 // RemoveRandomInlink removes a synapse biased toward input->non-input direction.
 type RemoveRandomInlink struct {
 	Rand            *rand.Rand
@@ -1206,6 +1565,55 @@ func (o *RemoveNeuronMutation) Apply(ctx context.Context, genome model.Genome) (
 	return (&RemoveRandomNeuron{Rand: o.Rand, Protected: o.Protected}).Apply(ctx, genome)
 }
 
+// DuplicateRandomNeuron duplicates a random unprotected neuron with jittered
+// copies of its synapses; see DuplicateNeuron.
+type DuplicateRandomNeuron struct {
+	Rand      *rand.Rand
+	MaxJitter float64
+	Protected map[string]struct{}
+}
+
+func (o *DuplicateRandomNeuron) Name() string {
+	return "duplicate_neuron"
+}
+
+func (o *DuplicateRandomNeuron) Applicable(genome model.Genome, _ string) bool {
+	for _, neuron := range genome.Neurons {
+		if _, protected := o.Protected[neuron.ID]; !protected {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *DuplicateRandomNeuron) Apply(ctx context.Context, genome model.Genome) (model.Genome, error) {
+	if o == nil || o.Rand == nil {
+		return model.Genome{}, errors.New("random source is required")
+	}
+	if len(genome.Neurons) == 0 {
+		return model.Genome{}, ErrNoNeurons
+	}
+
+	candidates := make([]string, 0, len(genome.Neurons))
+	for _, n := range genome.Neurons {
+		if _, protected := o.Protected[n.ID]; protected {
+			continue
+		}
+		candidates = append(candidates, n.ID)
+	}
+	if len(candidates) == 0 {
+		return model.Genome{}, ErrNoMutationChoice
+	}
+
+	target := candidates[o.Rand.Intn(len(candidates))]
+	return DuplicateNeuron{
+		ID:        target,
+		NewID:     uniqueNeuronID(genome, o.Rand),
+		Rand:      o.Rand,
+		MaxJitter: o.MaxJitter,
+	}.Apply(ctx, genome)
+}
+
 // PerturbPlasticityRate mutates the plasticity learning rate when configured.
 type PerturbPlasticityRate struct {
 	Rand     *rand.Rand
@@ -2442,6 +2850,35 @@ func (o RemoveSynapse) Apply(_ context.Context, genome model.Genome) (model.Geno
 	return mutated, nil
 }
 
+// ToggleSynapseEnabled flips the Enabled flag of one synapse by id. The
+// synapse keeps its id, endpoints and weight, so it stays aligned with the
+// matching gene of a crossover mate and re-enabling it restores the link.
+type ToggleSynapseEnabled struct {
+	ID string
+}
+
+func (o ToggleSynapseEnabled) Name() string {
+	return "toggle_synapse_enabled"
+}
+
+func (o ToggleSynapseEnabled) Apply(_ context.Context, genome model.Genome) (model.Genome, error) {
+	if o.ID == "" {
+		return model.Genome{}, errors.New("synapse id is required")
+	}
+	for i := range genome.Synapses {
+		if genome.Synapses[i].ID != o.ID {
+			continue
+		}
+		mutated := cloneGenome(genome)
+		mutated.Synapses[i].Enabled = !mutated.Synapses[i].Enabled
+		if mutated.Synapses[i].Enabled {
+			mutated.Synapses[i].DisabledFor = 0
+		}
+		return mutated, nil
+	}
+	return model.Genome{}, fmt.Errorf("%w: %s", ErrSynapseNotFound, o.ID)
+}
+
 // AddNeuronAtSynapse splits one synapse with a new hidden neuron.
 type AddNeuronAtSynapse struct {
 	SynapseIndex int
@@ -2541,6 +2978,80 @@ func (o RemoveNeuron) Apply(_ context.Context, genome model.Genome) (model.Genom
 	return mutated, nil
 }
 
+// DuplicateNeuron copies neuron ID as NewID together with all of its
+// incoming and outgoing synapses, the duplicate-and-diverge step used to grow
+// modular networks. Each copied weight is shifted by a jitter drawn uniformly
+// from [-MaxJitter, MaxJitter] so the twins can drift apart; Rand may be nil
+// when MaxJitter is 0. A self-loop on the source becomes a self-loop on the
+// copy. Copied synapse IDs are the source synapse ID suffixed with NewID.
+type DuplicateNeuron struct {
+	ID        string
+	NewID     string
+	Rand      *rand.Rand
+	MaxJitter float64
+}
+
+func (o DuplicateNeuron) Name() string {
+	return "duplicate_neuron"
+}
+
+func (o DuplicateNeuron) Apply(_ context.Context, genome model.Genome) (model.Genome, error) {
+	if o.ID == "" || o.NewID == "" {
+		return model.Genome{}, errors.New("neuron id and new neuron id are required")
+	}
+	if o.MaxJitter < 0 {
+		return model.Genome{}, errors.New("max jitter must be >= 0")
+	}
+	if o.MaxJitter > 0 && o.Rand == nil {
+		return model.Genome{}, errors.New("random source is required")
+	}
+	if hasNeuron(genome, o.NewID) {
+		return model.Genome{}, fmt.Errorf("%w: %s", ErrNeuronExists, o.NewID)
+	}
+	sourceIdx := -1
+	for i := range genome.Neurons {
+		if genome.Neurons[i].ID == o.ID {
+			sourceIdx = i
+			break
+		}
+	}
+	if sourceIdx < 0 {
+		return model.Genome{}, fmt.Errorf("%w: %s", ErrNeuronNotFound, o.ID)
+	}
+
+	mutated := cloneGenome(genome)
+	twin := mutated.Neurons[sourceIdx]
+	twin.ID = o.NewID
+	twin.Generation = currentGenomeGeneration(mutated)
+	twin.Innovation = 0
+	twin.PlasticityBiasParams = append([]float64(nil), twin.PlasticityBiasParams...)
+	mutated.Neurons = append(mutated.Neurons, twin)
+
+	for _, syn := range genome.Synapses {
+		if syn.From != o.ID && syn.To != o.ID {
+			continue
+		}
+		copied := syn
+		copied.ID = syn.ID + "-" + o.NewID
+		copied.Innovation = 0
+		if hasSynapse(mutated, copied.ID) {
+			return model.Genome{}, fmt.Errorf("%w: %s", ErrSynapseExists, copied.ID)
+		}
+		if copied.From == o.ID {
+			copied.From = o.NewID
+		}
+		if copied.To == o.ID {
+			copied.To = o.NewID
+		}
+		if o.MaxJitter > 0 {
+			copied.Weight += (o.Rand.Float64()*2 - 1) * o.MaxJitter
+		}
+		copied.PlasticityParams = append([]float64(nil), syn.PlasticityParams...)
+		mutated.Synapses = append(mutated.Synapses, copied)
+	}
+	return mutated, nil
+}
+
 func cloneGenome(g model.Genome) model.Genome {
 	return genotype.CloneGenome(g)
 }
@@ -3830,10 +4341,50 @@ func deleteActuatorTunable(genome *model.Genome, actuatorID string) {
 	}
 }
 
+// incomingSynapseIndexes lists the unfrozen synapses feeding neuronID, or
+// none when the neuron itself is frozen.
 func incomingSynapseIndexes(genome model.Genome, neuronID string) []int {
+	for _, neuron := range genome.Neurons {
+		if neuron.ID == neuronID && neuron.Frozen {
+			return nil
+		}
+	}
+	indexes := make([]int, 0, len(genome.Synapses))
+	for i, syn := range genome.Synapses {
+		if syn.To == neuronID && !syn.Frozen {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// mutableSynapseIndexes lists the synapses weight mutation may touch: those
+// neither frozen themselves nor feeding a frozen neuron.
+func mutableSynapseIndexes(genome model.Genome) []int {
+	frozen := map[string]struct{}{}
+	for _, neuron := range genome.Neurons {
+		if neuron.Frozen {
+			frozen[neuron.ID] = struct{}{}
+		}
+	}
 	indexes := make([]int, 0, len(genome.Synapses))
 	for i, syn := range genome.Synapses {
-		if syn.To == neuronID {
+		if syn.Frozen {
+			continue
+		}
+		if _, ok := frozen[syn.To]; ok {
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
+// mutableNeuronIndexes lists the neurons whose bias may be mutated.
+func mutableNeuronIndexes(genome model.Genome) []int {
+	indexes := make([]int, 0, len(genome.Neurons))
+	for i, neuron := range genome.Neurons {
+		if !neuron.Frozen {
 			indexes = append(indexes, i)
 		}
 	}
@@ -3954,13 +4505,7 @@ func ensureSubstrateConfig(genome *model.Genome) {
 		}
 		return
 	}
-	genome.Substrate = &model.SubstrateConfig{
-		CPPName:    substrate.DefaultCPPName,
-		CEPName:    substrate.DefaultCEPName,
-		CEPNames:   []string{substrate.DefaultCEPName},
-		Dimensions: []int{1, 1},
-		Parameters: map[string]float64{},
-	}
+	genome.Substrate = encoding.DefaultSubstrateConfig()
 }
 
 func setPrimarySubstrateCEP(cfg *model.SubstrateConfig, name string) {
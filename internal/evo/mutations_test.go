@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 
 	protoio "protogonos/internal/io"
@@ -105,6 +106,20 @@ func TestRemoveNeuronMatchesFixture(t *testing.T) {
 	}
 }
 
+func TestDuplicateNeuronMatchesFixture(t *testing.T) {
+	input := decodeGenomeFixture(t, filepath.Join("..", "..", "testdata", "fixtures", "mutations", "expected_add_neuron_v1.json"))
+	expected := decodeGenomeFixture(t, filepath.Join("..", "..", "testdata", "fixtures", "mutations", "expected_duplicate_neuron_v1.json"))
+
+	op := DuplicateNeuron{ID: "n-hidden", NewID: "n-hidden-2"}
+	actual, err := op.Apply(context.Background(), input)
+	if err != nil {
+		t.Fatalf("apply operator: %v", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("mutation mismatch\nactual=%+v\nexpected=%+v", actual, expected)
+	}
+}
+
 func TestAddNeuronOnIOXORGenomeMatchesFixture(t *testing.T) {
 	input := decodeGenomeFixture(t, filepath.Join("..", "..", "testdata", "fixtures", "io_xor_genome_v1.json"))
 	expected := decodeGenomeFixture(t, filepath.Join("..", "..", "testdata", "fixtures", "mutations", "expected_io_xor_add_neuron_v1.json"))
@@ -514,6 +529,156 @@ func TestRemoveNeuronInvariants(t *testing.T) {
 	}
 }
 
+func TestDuplicateNeuronInvariants(t *testing.T) {
+	rng := rand.New(rand.NewSource(29))
+	for i := 0; i < 200; i++ {
+		genome := randomGenome(rng)
+		target := genome.Neurons[rng.Intn(len(genome.Neurons))].ID
+		incident := 0
+		for _, s := range genome.Synapses {
+			if s.From == target || s.To == target {
+				incident++
+			}
+		}
+
+		newID := "n-dup-" + strconv.Itoa(i)
+		op := DuplicateNeuron{ID: target, NewID: newID, Rand: rng, MaxJitter: 0.1}
+		mutated, err := op.Apply(context.Background(), genome)
+		if err != nil {
+			t.Fatalf("apply failed: %v", err)
+		}
+		if len(mutated.Neurons) != len(genome.Neurons)+1 {
+			t.Fatalf("neuron count mismatch")
+		}
+		if len(mutated.Synapses) != len(genome.Synapses)+incident {
+			t.Fatalf("synapse count mismatch: got=%d want=%d", len(mutated.Synapses), len(genome.Synapses)+incident)
+		}
+		for _, s := range mutated.Synapses[len(genome.Synapses):] {
+			if s.From != newID && s.To != newID {
+				t.Fatalf("copied synapse does not touch the duplicate: %+v", s)
+			}
+			if s.From == newID && s.To == newID && !s.Recurrent {
+				t.Fatalf("expected copied self-loop to stay recurrent: %+v", s)
+			}
+		}
+		if !reflect.DeepEqual(mutated.Synapses[:len(genome.Synapses)], genome.Synapses) {
+			t.Fatalf("expected source synapses to be unchanged")
+		}
+		assertNoDanglingSynapses(t, mutated)
+	}
+}
+
+func TestDuplicateNeuronJittersCopiedWeights(t *testing.T) {
+	genome := model.Genome{
+		Neurons: []model.Neuron{
+			{ID: "i1", Activation: "identity"},
+			{ID: "h1", Activation: "tanh"},
+			{ID: "o1", Activation: "sigmoid"},
+		},
+		Synapses: []model.Synapse{
+			{ID: "s1", From: "i1", To: "h1", Weight: 0.5, Enabled: true},
+			{ID: "s2", From: "h1", To: "o1", Weight: -0.5, Enabled: true},
+			{ID: "s3", From: "h1", To: "h1", Weight: 0.2, Enabled: true, Recurrent: true},
+		},
+	}
+	op := DuplicateNeuron{ID: "h1", NewID: "h2", Rand: rand.New(rand.NewSource(3)), MaxJitter: 0.05}
+	mutated, err := op.Apply(context.Background(), genome)
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	copies := mutated.Synapses[len(genome.Synapses):]
+	if len(copies) != 3 {
+		t.Fatalf("expected three copied synapses, got %+v", copies)
+	}
+	for i, copied := range copies {
+		source := genome.Synapses[i]
+		if copied.ID != source.ID+"-h2" {
+			t.Fatalf("unexpected copied synapse id: %s", copied.ID)
+		}
+		if delta := math.Abs(copied.Weight - source.Weight); delta == 0 || delta > 0.05 {
+			t.Fatalf("expected copied weight within jitter of %f, got %f", source.Weight, copied.Weight)
+		}
+	}
+	if copies[2].From != "h2" || copies[2].To != "h2" || !copies[2].Recurrent {
+		t.Fatalf("expected self-loop to be copied onto the duplicate, got %+v", copies[2])
+	}
+
+	if _, err := (DuplicateNeuron{ID: "h1", NewID: "o1"}).Apply(context.Background(), genome); !errors.Is(err, ErrNeuronExists) {
+		t.Fatalf("expected ErrNeuronExists, got %v", err)
+	}
+	if _, err := (DuplicateNeuron{ID: "missing", NewID: "h9"}).Apply(context.Background(), genome); !errors.Is(err, ErrNeuronNotFound) {
+		t.Fatalf("expected ErrNeuronNotFound, got %v", err)
+	}
+
+	random := &DuplicateRandomNeuron{
+		Rand:      rand.New(rand.NewSource(8)),
+		MaxJitter: 0.1,
+		Protected: map[string]struct{}{"i1": {}, "o1": {}},
+	}
+	mutated, err = random.Apply(context.Background(), genome)
+	if err != nil {
+		t.Fatalf("apply random duplicate: %v", err)
+	}
+	if len(mutated.Neurons) != 4 || len(mutated.Synapses) != 6 {
+		t.Fatalf("expected only the hidden neuron to be duplicated, got neurons=%d synapses=%d", len(mutated.Neurons), len(mutated.Synapses))
+	}
+	allProtected := &DuplicateRandomNeuron{Rand: rand.New(rand.NewSource(8)), Protected: map[string]struct{}{"i1": {}, "h1": {}, "o1": {}}}
+	if allProtected.Applicable(genome, "xor") {
+		t.Fatal("expected duplicate_neuron to be inapplicable when all neurons are protected")
+	}
+}
+
+func TestToggleSynapseEnabledFlipsOneLinkInPlace(t *testing.T) {
+	genome := model.Genome{
+		Neurons: []model.Neuron{
+			{ID: "i1", Activation: "identity"},
+			{ID: "o1", Activation: "identity"},
+		},
+		Synapses: []model.Synapse{
+			{ID: "s1", From: "i1", To: "o1", Weight: 0.5, Enabled: true},
+			{ID: "s2", From: "o1", To: "o1", Weight: 0.2, Recurrent: true, DisabledFor: 4},
+		},
+	}
+	mutated, err := (ToggleSynapseEnabled{ID: "s1"}).Apply(context.Background(), genome)
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if mutated.Synapses[0].Enabled || mutated.Synapses[0].Weight != 0.5 || mutated.Synapses[0].ID != "s1" {
+		t.Fatalf("expected s1 disabled with its weight and id kept, got %+v", mutated.Synapses[0])
+	}
+	if !genome.Synapses[0].Enabled {
+		t.Fatal("toggle mutated the input genome")
+	}
+	mutated, err = (ToggleSynapseEnabled{ID: "s2"}).Apply(context.Background(), genome)
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if !mutated.Synapses[1].Enabled || mutated.Synapses[1].DisabledFor != 0 {
+		t.Fatalf("expected s2 re-enabled with its disabled age reset, got %+v", mutated.Synapses[1])
+	}
+	if _, err := (ToggleSynapseEnabled{ID: "missing"}).Apply(context.Background(), genome); !errors.Is(err, ErrSynapseNotFound) {
+		t.Fatalf("expected ErrSynapseNotFound, got %v", err)
+	}
+
+	random := &ToggleRandomSynapseEnabled{Rand: rand.New(rand.NewSource(2))}
+	mutated, err = random.Apply(context.Background(), genome)
+	if err != nil {
+		t.Fatalf("apply random toggle: %v", err)
+	}
+	flipped := 0
+	for i := range genome.Synapses {
+		if mutated.Synapses[i].Enabled != genome.Synapses[i].Enabled {
+			flipped++
+		}
+	}
+	if flipped != 1 || len(mutated.Synapses) != len(genome.Synapses) {
+		t.Fatalf("expected exactly one synapse toggled, got %+v", mutated.Synapses)
+	}
+	if random.Applicable(model.Genome{}, "xor") {
+		t.Fatal("expected toggle_synapse_enabled to be inapplicable without synapses")
+	}
+}
+
 func TestRemoveRandomNeuronCancelsWhenAllProtected(t *testing.T) {
 	genome := model.Genome{
 		Neurons: []model.Neuron{
@@ -1003,6 +1168,150 @@ func TestAddRandomOutlinkTargetsOutput(t *testing.T) {
 	}
 }
 
+func TestAddRecurrentLoopClosesShortCycles(t *testing.T) {
+	genome := model.Genome{
+		Neurons: []model.Neuron{
+			{ID: "i1", Activation: "identity"},
+			{ID: "h1", Activation: "tanh"},
+			{ID: "h2", Activation: "tanh"},
+			{ID: "o1", Activation: "sigmoid"},
+		},
+		Synapses: []model.Synapse{
+			{ID: "s1", From: "i1", To: "h1", Weight: 1, Enabled: true},
+			{ID: "s2", From: "h1", To: "h2", Weight: 1, Enabled: true},
+			{ID: "s3", From: "h2", To: "o1", Weight: 1, Enabled: true},
+		},
+	}
+	pairs := func(op *AddRecurrentLoop) map[string]bool {
+		out := map[string]bool{}
+		for _, pair := range op.candidates(genome) {
+			out[pair.from+"->"+pair.to] = true
+		}
+		return out
+	}
+
+	selfOnly := pairs(&AddRecurrentLoop{MaxCycleLength: 1, InputNeuronIDs: []string{"i1"}})
+	if len(selfOnly) != 3 || !selfOnly["h1->h1"] || !selfOnly["h2->h2"] || !selfOnly["o1->o1"] {
+		t.Fatalf("expected self-loops only on non-input neurons, got %v", selfOnly)
+	}
+
+	short := pairs(&AddRecurrentLoop{InputNeuronIDs: []string{"i1"}})
+	for _, want := range []string{"h2->h1", "o1->h2", "o1->h1"} {
+		if !short[want] {
+			t.Fatalf("expected back edge %s within the default cycle bound, got %v", want, short)
+		}
+	}
+	if short["h1->i1"] || short["i1->i1"] {
+		t.Fatalf("expected no loops into input neurons, got %v", short)
+	}
+	if short["h1->h2"] {
+		t.Fatalf("expected existing forward synapses to be skipped, got %v", short)
+	}
+	bounded := pairs(&AddRecurrentLoop{MaxCycleLength: 2, InputNeuronIDs: []string{"i1"}})
+	if !bounded["o1->h2"] || bounded["o1->h1"] {
+		t.Fatalf("expected a 2-neuron cycle bound to exclude o1->h1, got %v", bounded)
+	}
+
+	op := &AddRecurrentLoop{
+		Rand:           rand.New(rand.NewSource(5)),
+		MaxAbsWeight:   1.0,
+		InputNeuronIDs: []string{"i1"},
+	}
+	if op.Name() != "add_recurrent_loop" || !op.Applicable(genome, "xor") {
+		t.Fatal("expected add_recurrent_loop to be applicable")
+	}
+	mutated, err := op.Apply(context.Background(), genome)
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if len(mutated.Synapses) != 4 || len(genome.Synapses) != 3 {
+		t.Fatalf("expected one synapse added to a clone, got=%d original=%d", len(mutated.Synapses), len(genome.Synapses))
+	}
+	added := mutated.Synapses[3]
+	if !added.Recurrent || !added.Enabled || !short[added.From+"->"+added.To] {
+		t.Fatalf("unexpected recurrent synapse: %+v", added)
+	}
+
+	inputOnly := model.Genome{Neurons: []model.Neuron{{ID: "i1", Activation: "identity"}}}
+	if op.Applicable(inputOnly, "xor") {
+		t.Fatal("expected add_recurrent_loop to be inapplicable with only input neurons")
+	}
+	if _, err := op.Apply(context.Background(), inputOnly); !errors.Is(err, ErrNoMutationChoice) {
+		t.Fatalf("expected ErrNoMutationChoice, got %v", err)
+	}
+}
+
+func TestInsertModuleGraftsLibraryModuleBetweenPorts(t *testing.T) {
+	genome := model.Genome{
+		Neurons: []model.Neuron{
+			{ID: "i1", Activation: "identity"},
+			{ID: "h1", Activation: "tanh"},
+			{ID: "o1", Activation: "sigmoid"},
+		},
+		Synapses: []model.Synapse{
+			{ID: "s1", From: "i1", To: "h1", Weight: 1, Enabled: true},
+			{ID: "s2", From: "h1", To: "o1", Weight: 1, Enabled: true},
+		},
+	}
+	module := model.Module{
+		ID:      "m",
+		Neurons: []model.Neuron{{ID: "a", Activation: "tanh", Bias: 0.2}, {ID: "b", Activation: "relu"}},
+		Synapses: []model.Synapse{
+			{ID: "ab", From: "a", To: "b", Weight: 0.7, Enabled: true},
+		},
+		Inputs:  []string{"a"},
+		Outputs: []string{"b"},
+	}
+	op := &InsertModule{
+		Rand:            rand.New(rand.NewSource(9)),
+		Library:         []model.Module{module},
+		MaxAbsWeight:    1.0,
+		InputNeuronIDs:  []string{"i1"},
+		OutputNeuronIDs: []string{"o1"},
+	}
+
+	points := map[string]bool{}
+	for _, pair := range op.insertionPoints(genome) {
+		points[pair.from+"->"+pair.to] = true
+	}
+	for _, want := range []string{"i1->h1", "i1->o1", "h1->o1"} {
+		if !points[want] {
+			t.Fatalf("expected insertion point %s, got %v", want, points)
+		}
+	}
+	if points["h1->h1"] || points["o1->h1"] || points["h1->i1"] {
+		t.Fatalf("expected no upstream, output-sourced or input-targeted points, got %v", points)
+	}
+
+	if op.Name() != "insert_module" || !op.Applicable(genome, "xor") {
+		t.Fatal("expected insert_module to be applicable")
+	}
+	mutated, err := op.Apply(context.Background(), genome)
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if len(mutated.Neurons) != 5 || len(mutated.Synapses) != 5 || len(genome.Neurons) != 3 {
+		t.Fatalf("expected module grafted into a clone, neurons=%d synapses=%d", len(mutated.Neurons), len(mutated.Synapses))
+	}
+	assertNoDanglingSynapses(t, mutated)
+	a, b := mutated.Neurons[3], mutated.Neurons[4]
+	if !strings.HasSuffix(a.ID, "a") || !strings.HasSuffix(b.ID, "b") || a.Bias != 0.2 {
+		t.Fatalf("unexpected grafted neurons: %+v %+v", a, b)
+	}
+	internal, in, out := mutated.Synapses[2], mutated.Synapses[3], mutated.Synapses[4]
+	if internal.From != a.ID || internal.To != b.ID || internal.Weight != 0.7 {
+		t.Fatalf("expected internal module synapse to be renamed, got %+v", internal)
+	}
+	if in.To != a.ID || out.From != b.ID || !points[in.From+"->"+out.To] {
+		t.Fatalf("expected ports wired to an insertion point, in=%+v out=%+v", in, out)
+	}
+
+	empty := &InsertModule{Rand: rand.New(rand.NewSource(1)), MaxAbsWeight: 1}
+	if empty.Applicable(genome, "xor") {
+		t.Fatal("expected insert_module to be inapplicable without a library")
+	}
+}
+
 func TestAddRandomOutlinkNoDirectionalCandidates(t *testing.T) {
 	genome := model.Genome{
 		Neurons: []model.Neuron{
@@ -3513,3 +3822,112 @@ func assertNoDanglingSynapses(t *testing.T, g model.Genome) {
 		}
 	}
 }
+
+func TestWeightMutationsRespectFrozenMasks(t *testing.T) {
+	genome := model.Genome{
+		Neurons: []model.Neuron{
+			{ID: "i1", Activation: "identity", Bias: 0.1},
+			{ID: "h1", Activation: "tanh", Bias: 0.2, Frozen: true},
+			{ID: "o1", Activation: "sigmoid", Bias: 0.3},
+		},
+		Synapses: []model.Synapse{
+			{ID: "s1", From: "i1", To: "h1", Weight: 1, Enabled: true},
+			{ID: "s2", From: "h1", To: "o1", Weight: 2, Enabled: true, Frozen: true},
+			{ID: "s3", From: "i1", To: "o1", Weight: 3, Enabled: true},
+		},
+	}
+	for seed := int64(1); seed <= 20; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		ops := []Operator{
+			&MutateWeights{Rand: rng, MaxDelta: 1},
+			&PerturbWeightsProportional{Rand: rng, MaxDelta: 1},
+			&PerturbRandomWeight{Rand: rng, MaxDelta: 1},
+			&PerturbRandomBias{Rand: rng, MaxDelta: 1},
+			&RemoveRandomBias{Rand: rng},
+		}
+		for _, op := range ops {
+			mutated, err := op.Apply(context.Background(), genome)
+			if err != nil {
+				t.Fatalf("%s seed=%d: %v", op.Name(), seed, err)
+			}
+			if mutated.Synapses[0].Weight != 1 || mutated.Synapses[1].Weight != 2 {
+				t.Fatalf("%s seed=%d changed a frozen weight: %+v", op.Name(), seed, mutated.Synapses)
+			}
+			if mutated.Neurons[1].Bias != 0.2 {
+				t.Fatalf("%s seed=%d changed a frozen bias: %+v", op.Name(), seed, mutated.Neurons[1])
+			}
+		}
+	}
+
+	allFrozen := cloneGenome(genome)
+	for i := range allFrozen.Synapses {
+		allFrozen.Synapses[i].Frozen = true
+	}
+	for i := range allFrozen.Neurons {
+		allFrozen.Neurons[i].Frozen = true
+	}
+	rng := rand.New(rand.NewSource(1))
+	for _, op := range []interface {
+		Operator
+		Applicable(model.Genome, string) bool
+	}{
+		&MutateWeights{Rand: rng, MaxDelta: 1},
+		&PerturbWeightsProportional{Rand: rng, MaxDelta: 1},
+		&PerturbRandomWeight{Rand: rng, MaxDelta: 1},
+		&PerturbRandomBias{Rand: rng, MaxDelta: 1},
+		&RemoveRandomBias{Rand: rng},
+	} {
+		if op.Applicable(allFrozen, "xor") {
+			t.Fatalf("expected %s to be inapplicable on a fully frozen genome", op.Name())
+		}
+		if _, err := op.Apply(context.Background(), allFrozen); !errors.Is(err, ErrNoMutationChoice) {
+			t.Fatalf("expected %s to report ErrNoMutationChoice, got %v", op.Name(), err)
+		}
+	}
+}
+
+func TestPerturbAllBiasesPerturbsProportionalSubset(t *testing.T) {
+	genome := model.Genome{}
+	for i := 0; i < 16; i++ {
+		genome.Neurons = append(genome.Neurons, model.Neuron{ID: "n" + strconv.Itoa(i), Activation: "tanh"})
+	}
+	genome.Neurons[3].Frozen = true
+	genome.Neurons[3].Bias = 0.5
+
+	op := &PerturbAllBiases{Rand: rand.New(rand.NewSource(3)), MaxDelta: 0.4}
+	if op.Name() != "perturb_all_biases" || !op.Applicable(genome, "xor") {
+		t.Fatal("expected perturb_all_biases to be applicable")
+	}
+	changedTotal := 0
+	for round := 0; round < 50; round++ {
+		mutated, err := op.Apply(context.Background(), genome)
+		if err != nil {
+			t.Fatalf("apply failed: %v", err)
+		}
+		changed := 0
+		for i, neuron := range mutated.Neurons {
+			if neuron.Bias == genome.Neurons[i].Bias {
+				continue
+			}
+			if neuron.Frozen {
+				t.Fatalf("frozen neuron bias changed: %+v", neuron)
+			}
+			if math.Abs(neuron.Bias-genome.Neurons[i].Bias) > 0.4 {
+				t.Fatalf("bias delta exceeds max delta: %+v", neuron)
+			}
+			changed++
+		}
+		if changed == 0 {
+			t.Fatal("expected at least one bias to be perturbed")
+		}
+		changedTotal += changed
+	}
+	// 15 unfrozen neurons at probability 1/sqrt(15) average ~3.9 per call.
+	if mean := float64(changedTotal) / 50; mean < 2 || mean > 7 {
+		t.Fatalf("expected a proportional subset per call, got mean %.2f", mean)
+	}
+
+	if _, err := op.Apply(context.Background(), model.Genome{Neurons: []model.Neuron{{ID: "f", Frozen: true}}}); !errors.Is(err, ErrNoMutationChoice) {
+		t.Fatalf("expected ErrNoMutationChoice for fully frozen genome, got %v", err)
+	}
+}
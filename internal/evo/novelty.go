@@ -0,0 +1,185 @@
+package evo
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"sort"
+
+	"protogonos/internal/scape"
+)
+
+const (
+	defaultNoveltyNeighbors   = 15
+	defaultNoveltyPerGen      = 2
+	defaultNoveltyArchiveSize = 500
+)
+
+// NoveltyArchive keeps the behavior descriptors of past genomes that were
+// novel when seen, and scores a generation by how far each genome's behavior
+// lies from its nearest neighbours among the generation and the archive.
+type NoveltyArchive struct {
+	// Neighbors is the k of the k-nearest-neighbour novelty score.
+	Neighbors int
+	// PerGeneration is how many of each generation's most novel behaviors
+	// join the archive.
+	PerGeneration int
+	// Capacity bounds the archive; the oldest behaviors are dropped first.
+	Capacity int
+
+	behaviors []scape.Behavior
+}
+
+// NewNoveltyArchive returns an empty archive scoring against the 15 nearest
+// neighbours and archiving the 2 most novel behaviors of each generation, up
+// to 500.
+func NewNoveltyArchive() *NoveltyArchive {
+	return &NoveltyArchive{
+		Neighbors:     defaultNoveltyNeighbors,
+		PerGeneration: defaultNoveltyPerGen,
+		Capacity:      defaultNoveltyArchiveSize,
+	}
+}
+
+// Len returns the number of archived behaviors.
+func (a *NoveltyArchive) Len() int {
+	return len(a.behaviors)
+}
+
+// Score returns the novelty of each behavior: its mean distance to the
+// Neighbors nearest of the other behaviors and the archived ones.
+func (a *NoveltyArchive) Score(behaviors []scape.Behavior) []float64 {
+	k := a.Neighbors
+	if k <= 0 {
+		k = defaultNoveltyNeighbors
+	}
+	scores := make([]float64, len(behaviors))
+	distances := make([]float64, 0, len(behaviors)+len(a.behaviors))
+	for i, behavior := range behaviors {
+		distances = distances[:0]
+		for j, other := range behaviors {
+			if i != j {
+				distances = append(distances, behaviorDistance(behavior, other))
+			}
+		}
+		for _, archived := range a.behaviors {
+			distances = append(distances, behaviorDistance(behavior, archived))
+		}
+		if len(distances) == 0 {
+			continue
+		}
+		sort.Float64s(distances)
+		n := min(k, len(distances))
+		for _, distance := range distances[:n] {
+			scores[i] += distance
+		}
+		scores[i] /= float64(n)
+	}
+	return scores
+}
+
+// Add archives the PerGeneration behaviors with the highest scores, evicting
+// the oldest archived behaviors beyond Capacity.
+func (a *NoveltyArchive) Add(behaviors []scape.Behavior, scores []float64) {
+	indexes := make([]int, len(behaviors))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return scores[indexes[i]] > scores[indexes[j]]
+	})
+	for _, idx := range indexes[:min(max(a.PerGeneration, 0), len(indexes))] {
+		a.behaviors = append(a.behaviors, append(scape.Behavior(nil), behaviors[idx]...))
+	}
+	if a.Capacity > 0 && len(a.behaviors) > a.Capacity {
+		a.behaviors = append([]scape.Behavior(nil), a.behaviors[len(a.behaviors)-a.Capacity:]...)
+	}
+}
+
+// NoveltyArchivePostprocessor replaces fitness with k-nearest-neighbour
+// novelty against the generation and a NoveltyArchive, turning the run into
+// novelty search. Behaviors come from the behavior descriptor scapes report
+// (see scape.TraceBehavior); genomes whose trace reports none are
+// characterized by their scalar fitness alone. The objective fitness is kept
+// in the trace under objective_fitness and the score under novelty.
+//
+// Archive carries novel behaviors across generations, so each run needs its
+// own: the population monitor gives a postprocessor without one a fresh
+// NewNoveltyArchive. Called directly with a nil Archive, Process compares
+// genomes within their generation only.
+type NoveltyArchivePostprocessor struct {
+	Archive *NoveltyArchive
+}
+
+func (NoveltyArchivePostprocessor) Name() string {
+	return "novelty_archive"
+}
+
+// SaveCheckpointState records the archived behaviors.
+func (p NoveltyArchivePostprocessor) SaveCheckpointState() (json.RawMessage, error) {
+	if p.Archive == nil {
+		return nil, nil
+	}
+	return json.Marshal(p.Archive.behaviors)
+}
+
+// RestoreCheckpointState replaces the archived behaviors with saved ones.
+func (p NoveltyArchivePostprocessor) RestoreCheckpointState(raw json.RawMessage) error {
+	if p.Archive == nil {
+		return errors.New("novelty postprocessor has no archive to restore")
+	}
+	return json.Unmarshal(raw, &p.Archive.behaviors)
+}
+
+func (p NoveltyArchivePostprocessor) Process(scored []ScoredGenome) []ScoredGenome {
+	out := cloneScored(scored)
+	behaviors := make([]scape.Behavior, len(out))
+	for i, item := range out {
+		behaviors[i] = scoredBehavior(item)
+	}
+	archive := p.Archive
+	if archive == nil {
+		archive = &NoveltyArchive{}
+	}
+	scores := archive.Score(behaviors)
+	for i := range out {
+		trace := make(scape.Trace, len(out[i].Trace)+2)
+		for key, value := range out[i].Trace {
+			trace[key] = value
+		}
+		trace["objective_fitness"] = out[i].Fitness
+		trace["novelty"] = scores[i]
+		out[i].Trace = trace
+		out[i].Fitness = scores[i]
+	}
+	if p.Archive != nil {
+		p.Archive.Add(behaviors, scores)
+	}
+	return out
+}
+
+// scoredBehavior returns the behavior descriptor the genome's trace reports,
+// or its scalar fitness as a one-dimensional behavior.
+func scoredBehavior(scored ScoredGenome) scape.Behavior {
+	if behavior, ok := scape.TraceBehaviorOf(scored.Trace); ok {
+		return behavior
+	}
+	return scape.Behavior{scored.Fitness}
+}
+
+// behaviorDistance is the Euclidean distance between a and b, with the
+// shorter descriptor padded with zeros.
+func behaviorDistance(a, b scape.Behavior) float64 {
+	sum := 0.0
+	for i := 0; i < max(len(a), len(b)); i++ {
+		var x, y float64
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		sum += (x - y) * (x - y)
+	}
+	return math.Sqrt(sum)
+}
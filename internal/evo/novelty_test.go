@@ -0,0 +1,104 @@
+package evo
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"protogonos/internal/model"
+	"protogonos/internal/scape"
+)
+
+func TestNoveltyArchiveScoresKNearestNeighbours(t *testing.T) {
+	archive := &NoveltyArchive{Neighbors: 2}
+	behaviors := []scape.Behavior{{0, 0}, {1, 0}, {0, 1}, {5, 5}}
+	scores := archive.Score(behaviors)
+
+	want := []float64{1, (1 + math.Sqrt2) / 2, (1 + math.Sqrt2) / 2, (math.Sqrt(41) + math.Sqrt(41)) / 2}
+	for i := range want {
+		if math.Abs(scores[i]-want[i]) > 1e-9 {
+			t.Fatalf("unexpected novelty at %d: got=%f want=%f", i, scores[i], want[i])
+		}
+	}
+}
+
+func TestNoveltyArchiveKeepsMostNovelBehaviorsUpToCapacity(t *testing.T) {
+	archive := &NoveltyArchive{Neighbors: 1, PerGeneration: 1, Capacity: 2}
+	for _, x := range []float64{1, 2, 3} {
+		behaviors := []scape.Behavior{{0}, {x * 10}}
+		archive.Add(behaviors, archive.Score(behaviors))
+	}
+	if archive.Len() != 2 {
+		t.Fatalf("expected archive capped at 2, got %d", archive.Len())
+	}
+	if archive.behaviors[0][0] != 20 || archive.behaviors[1][0] != 30 {
+		t.Fatalf("expected the oldest behavior evicted, got %v", archive.behaviors)
+	}
+
+	// A behavior already in the archive is no longer novel.
+	scores := archive.Score([]scape.Behavior{{30}, {100}})
+	if scores[0] != 0 || scores[1] != 70 {
+		t.Fatalf("expected archived behaviors to count as neighbours, got %v", scores)
+	}
+}
+
+func TestNoveltyArchivePostprocessorReplacesFitnessWithNovelty(t *testing.T) {
+	scored := []ScoredGenome{
+		{Genome: newLinearGenome("a", 1), Fitness: 10, Trace: scape.Trace{scape.TraceBehavior: scape.Behavior{0, 0}}},
+		{Genome: newLinearGenome("b", 1), Fitness: 9, Trace: scape.Trace{scape.TraceBehavior: scape.Behavior{0, 1}}},
+		{Genome: newLinearGenome("c", 1), Fitness: 1, Trace: scape.Trace{scape.TraceBehavior: scape.Behavior{4, 4}}},
+	}
+	archive := &NoveltyArchive{Neighbors: 1, PerGeneration: 1}
+	out := NoveltyArchivePostprocessor{Archive: archive}.Process(scored)
+
+	if out[2].Fitness <= out[0].Fitness || out[2].Fitness <= out[1].Fitness {
+		t.Fatalf("expected the outlying behavior to be most novel, got %+v", out)
+	}
+	if out[0].Trace["objective_fitness"] != 10.0 || out[2].Trace["novelty"] != out[2].Fitness {
+		t.Fatalf("expected objective fitness and novelty in the trace, got %+v", out[0].Trace)
+	}
+	if _, ok := scored[0].Trace["novelty"]; ok || scored[0].Fitness != 10 {
+		t.Fatal("expected postprocessor output to be cloned from input")
+	}
+	if archive.Len() != 1 || archive.behaviors[0][0] != 4 {
+		t.Fatalf("expected the most novel behavior archived, got %v", archive.behaviors)
+	}
+}
+
+func TestPopulationMonitorGivesNoveltyArchivePerRun(t *testing.T) {
+	initial := []model.Genome{newLinearGenome("a", 0.2), newLinearGenome("b", 0.5), newLinearGenome("c", 0.9)}
+	postprocessor := NoveltyArchivePostprocessor{}
+	for run := 0; run < 2; run++ {
+		monitor, err := NewPopulationMonitor(MonitorConfig{
+			Scape:           oneDimScape{},
+			Mutation:        PerturbWeightAt{Index: 0, Delta: 0.1},
+			Postprocessor:   postprocessor,
+			PopulationSize:  len(initial),
+			EliteCount:      1,
+			Generations:     3,
+			Workers:         1,
+			Seed:            4,
+			InputNeuronIDs:  []string{"i"},
+			OutputNeuronIDs: []string{"o"},
+		})
+		if err != nil {
+			t.Fatalf("new monitor: %v", err)
+		}
+		novelty, ok := monitor.cfg.Postprocessor.(NoveltyArchivePostprocessor)
+		if !ok || novelty.Archive == nil {
+			t.Fatalf("expected the monitor to give the run an archive, got %#v", monitor.cfg.Postprocessor)
+		}
+		result, err := monitor.Run(context.Background(), initial)
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+		if novelty.Archive.Len() != 3*defaultNoveltyPerGen {
+			t.Fatalf("run %d: expected a fresh archive filled over three generations, got %d", run, novelty.Archive.Len())
+		}
+		for _, item := range result.FinalPopulation {
+			if item.Trace["novelty"] != item.Fitness {
+				t.Fatalf("expected novelty fitness, got %f trace=%+v", item.Fitness, item.Trace)
+			}
+		}
+	}
+}
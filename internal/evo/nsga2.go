@@ -0,0 +1,189 @@
+package evo
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"protogonos/internal/model"
+	"protogonos/internal/scape"
+)
+
+// NSGA2Selector implements NSGA-II selection over the objectives scapes report
+// in their traces (see scape.TraceObjectives); genomes whose trace reports
+// none compete on their scalar fitness alone. Genomes are ranked by
+// non-dominated front, and within a front by crowding distance so sparse
+// regions of the trade-off are preferred. The monitor carries elites forward
+// in that order.
+type NSGA2Selector struct{}
+
+func (NSGA2Selector) Name() string {
+	return "nsga2"
+}
+
+// PickParent runs a binary tournament: the candidate on the better front
+// wins, then the less crowded one, then the one ScoredBefore ranks ahead.
+func (NSGA2Selector) PickParent(rng *rand.Rand, ranked []ScoredGenome, eliteCount int) (model.Genome, error) {
+	if rng == nil {
+		return model.Genome{}, fmt.Errorf("random source is required")
+	}
+	if eliteCount <= 0 || eliteCount > len(ranked) {
+		return model.Genome{}, fmt.Errorf("invalid elite count: %d", eliteCount)
+	}
+	fronts := NonDominatedSort(ranked)
+	rank := make(map[string]int, len(ranked))
+	crowding := make(map[string]float64, len(ranked))
+	for i, front := range fronts {
+		distances := CrowdingDistance(front)
+		for j, scored := range front {
+			rank[scored.Genome.ID] = i
+			crowding[scored.Genome.ID] = distances[j]
+		}
+	}
+	a := ranked[rng.Intn(len(ranked))]
+	b := ranked[rng.Intn(len(ranked))]
+	if nsga2Before(b, a, rank, crowding) {
+		return b.Genome, nil
+	}
+	return a.Genome, nil
+}
+
+// NSGA2Order returns scored ordered by front, then by descending crowding
+// distance, then by ScoredBefore.
+func NSGA2Order(scored []ScoredGenome) []ScoredGenome {
+	ordered := make([]ScoredGenome, 0, len(scored))
+	for _, front := range NonDominatedSort(scored) {
+		distances := CrowdingDistance(front)
+		indexes := make([]int, len(front))
+		for i := range indexes {
+			indexes[i] = i
+		}
+		sort.SliceStable(indexes, func(i, j int) bool {
+			a, b := indexes[i], indexes[j]
+			if distances[a] != distances[b] {
+				return distances[a] > distances[b]
+			}
+			return ScoredBefore(front[a], front[b])
+		})
+		for _, idx := range indexes {
+			ordered = append(ordered, front[idx])
+		}
+	}
+	return ordered
+}
+
+// NonDominatedSort splits scored into successive Pareto fronts: the first
+// holds the genomes no other genome dominates, each later one those dominated
+// only by genomes of earlier fronts. Input order is preserved within a front.
+func NonDominatedSort(scored []ScoredGenome) [][]ScoredGenome {
+	objectives := make([]scape.Objectives, len(scored))
+	for i, candidate := range scored {
+		objectives[i] = scoredObjectives(candidate)
+	}
+	dominatedBy := make([]int, len(scored))
+	dominates := make([][]int, len(scored))
+	for i := range scored {
+		for j := range scored {
+			if i != j && objectivesDominate(objectives[i], objectives[j]) {
+				dominates[i] = append(dominates[i], j)
+				dominatedBy[j]++
+			}
+		}
+	}
+
+	fronts := make([][]ScoredGenome, 0, 1)
+	current := make([]int, 0, len(scored))
+	for i := range scored {
+		if dominatedBy[i] == 0 {
+			current = append(current, i)
+		}
+	}
+	for len(current) > 0 {
+		front := make([]ScoredGenome, 0, len(current))
+		next := make([]int, 0)
+		for _, i := range current {
+			front = append(front, scored[i])
+			for _, j := range dominates[i] {
+				dominatedBy[j]--
+				if dominatedBy[j] == 0 {
+					next = append(next, j)
+				}
+			}
+		}
+		sort.Ints(next)
+		fronts = append(fronts, front)
+		current = next
+	}
+	return fronts
+}
+
+// CrowdingDistance returns, for each genome of front, the sum over objectives
+// of the normalized gap between its neighbours on that objective. Genomes at
+// either end of an objective's range get +Inf so the extremes are kept.
+func CrowdingDistance(front []ScoredGenome) []float64 {
+	distances := make([]float64, len(front))
+	if len(front) == 0 {
+		return distances
+	}
+	objectives := make([]scape.Objectives, len(front))
+	width := math.MaxInt
+	for i, scored := range front {
+		objectives[i] = scoredObjectives(scored)
+		width = min(width, len(objectives[i]))
+	}
+	indexes := make([]int, len(front))
+	for k := 0; k < width; k++ {
+		for i := range indexes {
+			indexes[i] = i
+		}
+		sort.SliceStable(indexes, func(i, j int) bool {
+			return objectives[indexes[i]][k] < objectives[indexes[j]][k]
+		})
+		low, high := objectives[indexes[0]][k], objectives[indexes[len(indexes)-1]][k]
+		distances[indexes[0]] = math.Inf(1)
+		distances[indexes[len(indexes)-1]] = math.Inf(1)
+		if high == low {
+			continue
+		}
+		for i := 1; i < len(indexes)-1; i++ {
+			gap := objectives[indexes[i+1]][k] - objectives[indexes[i-1]][k]
+			distances[indexes[i]] += gap / (high - low)
+		}
+	}
+	return distances
+}
+
+func nsga2Before(a, b ScoredGenome, rank map[string]int, crowding map[string]float64) bool {
+	if rank[a.Genome.ID] != rank[b.Genome.ID] {
+		return rank[a.Genome.ID] < rank[b.Genome.ID]
+	}
+	if crowding[a.Genome.ID] != crowding[b.Genome.ID] {
+		return crowding[a.Genome.ID] > crowding[b.Genome.ID]
+	}
+	return ScoredBefore(a, b)
+}
+
+// scoredObjectives returns the objectives the genome's trace reports, or its
+// scalar fitness as the only objective.
+func scoredObjectives(scored ScoredGenome) scape.Objectives {
+	if objectives, ok := scape.TraceObjectivesOf(scored.Trace); ok {
+		return objectives
+	}
+	return scape.Objectives{scored.Fitness}
+}
+
+// objectivesDominate reports whether a is no worse than b on every objective
+// they share and strictly better on at least one.
+func objectivesDominate(a, b scape.Objectives) bool {
+	better := false
+	for k := 0; k < min(len(a), len(b)); k++ {
+		if a[k] < b[k] {
+			return false
+		}
+		if a[k] > b[k] {
+			better = true
+		}
+	}
+	return better
+}
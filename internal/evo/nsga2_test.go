@@ -0,0 +1,106 @@
+package evo
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"protogonos/internal/model"
+	"protogonos/internal/scape"
+)
+
+func objectiveScored(id string, objectives ...float64) ScoredGenome {
+	return ScoredGenome{
+		Genome:  model.Genome{ID: id},
+		Fitness: objectives[0],
+		Trace:   scape.Trace{scape.TraceObjectives: scape.Objectives(objectives)},
+	}
+}
+
+func scoredIDs(scored []ScoredGenome) []string {
+	ids := make([]string, 0, len(scored))
+	for _, item := range scored {
+		ids = append(ids, item.Genome.ID)
+	}
+	return ids
+}
+
+func TestNonDominatedSortSplitsFronts(t *testing.T) {
+	scored := []ScoredGenome{
+		objectiveScored("profit", 3, -2),
+		objectiveScored("dominated", 1, -3),
+		objectiveScored("safe", 1, 0),
+		objectiveScored("balanced", 2, -1),
+		objectiveScored("worst", 0, -4),
+	}
+	fronts := NonDominatedSort(scored)
+	got := make([][]string, 0, len(fronts))
+	for _, front := range fronts {
+		got = append(got, scoredIDs(front))
+	}
+	want := [][]string{{"profit", "safe", "balanced"}, {"dominated"}, {"worst"}}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("unexpected fronts: got=%v want=%v", got, want)
+	}
+}
+
+func TestNonDominatedSortFallsBackToFitness(t *testing.T) {
+	scored := []ScoredGenome{
+		{Genome: model.Genome{ID: "low"}, Fitness: 1},
+		{Genome: model.Genome{ID: "high"}, Fitness: 2},
+		{Genome: model.Genome{ID: "tied"}, Fitness: 2},
+	}
+	fronts := NonDominatedSort(scored)
+	if len(fronts) != 2 || fmt.Sprint(scoredIDs(fronts[0])) != "[high tied]" || fmt.Sprint(scoredIDs(fronts[1])) != "[low]" {
+		t.Fatalf("expected scalar fitness fronts, got %v", fronts)
+	}
+}
+
+func TestCrowdingDistanceKeepsExtremes(t *testing.T) {
+	front := []ScoredGenome{
+		objectiveScored("a", 0, 4),
+		objectiveScored("b", 1, 3),
+		objectiveScored("c", 3, 1),
+		objectiveScored("d", 4, 0),
+	}
+	distances := CrowdingDistance(front)
+	if !math.IsInf(distances[0], 1) || !math.IsInf(distances[3], 1) {
+		t.Fatalf("expected infinite distance at the extremes, got %v", distances)
+	}
+	if math.Abs(distances[1]-1.5) > 1e-9 || math.Abs(distances[2]-1.5) > 1e-9 {
+		t.Fatalf("unexpected interior distances: %v", distances)
+	}
+
+	ordered := NSGA2Order(append(front, objectiveScored("e", 0, 0)))
+	if got := scoredIDs(ordered); fmt.Sprint(got) != "[d a c b e]" {
+		t.Fatalf("unexpected nsga2 order: %v", got)
+	}
+}
+
+func TestNSGA2SelectorPrefersBetterFront(t *testing.T) {
+	scored := []ScoredGenome{
+		objectiveScored("front", 2, 0),
+		objectiveScored("dominated", 1, -1),
+	}
+	selector := NSGA2Selector{}
+	if selector.Name() != "nsga2" {
+		t.Fatalf("unexpected selector name: %s", selector.Name())
+	}
+	rng := rand.New(rand.NewSource(3))
+	picked := map[string]int{}
+	for i := 0; i < 50; i++ {
+		parent, err := selector.PickParent(rng, scored, 1)
+		if err != nil {
+			t.Fatalf("pick parent: %v", err)
+		}
+		picked[parent.ID]++
+	}
+	// The dominated genome only wins a tournament against itself.
+	if picked["front"] <= picked["dominated"] {
+		t.Fatalf("expected the first front to win most tournaments, got %v", picked)
+	}
+	if _, err := selector.PickParent(rng, scored, 0); err == nil {
+		t.Fatal("expected invalid elite count to fail")
+	}
+}
@@ -0,0 +1,274 @@
+package evo
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"protogonos/internal/model"
+)
+
+// onlineMember is one slot of an online population. Scored holds the
+// genome's latest evaluation; a newborn is not evaluated until its first
+// turn.
+type onlineMember struct {
+	scored    ScoredGenome
+	evaluated bool
+}
+
+func validateOnline(cfg *MonitorConfig) error {
+	if cfg.ReproductionInterval < 0 {
+		return fmt.Errorf("reproduction interval must be >= 0")
+	}
+	if cfg.DiagnosticsWindow < 0 {
+		return fmt.Errorf("diagnostics window must be >= 0")
+	}
+	if cfg.EvolutionType != EvolutionTypeOnline {
+		return nil
+	}
+	if cfg.LowFidelity > 0 {
+		return fmt.Errorf("multi-fidelity evaluation is not supported with online evolution")
+	}
+	if cfg.EntropyThreshold > 0 {
+		return fmt.Errorf("entropy restarts are not supported with online evolution")
+	}
+	if cfg.ReproductionInterval == 0 {
+		cfg.ReproductionInterval = cfg.PopulationSize
+	}
+	if cfg.DiagnosticsWindow == 0 {
+		cfg.DiagnosticsWindow = cfg.PopulationSize
+	}
+	return nil
+}
+
+// runOnline evolves a population that persists for the whole run. Genomes
+// are evaluated one at a time, round robin, and keep only their latest
+// score, so fitness follows a live scape that drifts between evaluations.
+// Every ReproductionInterval evaluations the selector picks a parent among
+// the evaluated genomes, its mutated child replaces the weakest of them and
+// is evaluated next.
+//
+// Generation-indexed outputs (diagnostics, species history, trace entries
+// and lineage generations) count windows of DiagnosticsWindow evaluations
+// instead, and stop rules are checked as each window closes. Generations
+// bounds the number of windows; at zero only a goal, limit, stop condition
+// or stop command ends the run.
+func (m *PopulationMonitor) runOnline(ctx context.Context, initial []model.Genome) (RunResult, error) {
+	members := make([]onlineMember, len(initial))
+	for i, genome := range initial {
+		members[i] = onlineMember{scored: ScoredGenome{Genome: genome}}
+	}
+
+	var (
+		bestHistory    []float64
+		diagnostics    []GenerationDiagnostics
+		speciesHistory []SpeciesGeneration
+		traceAcc       []TraceGeneration
+		finalScored    []ScoredGenome
+	)
+	lineage := make([]LineageRecord, 0, len(initial))
+	prevSpeciesSet := map[string]struct{}{}
+	evoHistoryByGenomeID := initializeEvoHistoryByGenomeID(initial)
+	for _, genome := range initial {
+		sig := ComputeGenomeSignature(genome)
+		operation := "seed"
+		if m.cfg.GenerationOffset > 0 {
+			operation = "continue_seed"
+		}
+		lineage = append(lineage, LineageRecord{
+			GenomeID:    genome.ID,
+			ParentID:    "",
+			Generation:  m.cfg.GenerationOffset,
+			Operation:   operation,
+			Fingerprint: sig.Fingerprint,
+			Summary:     sig.Summary,
+		})
+	}
+
+	cursor := 0
+	births := 0
+	sinceReproduction := 0
+	for window := 0; m.cfg.Generations == 0 || window < m.cfg.Generations; window++ {
+		if err := ctx.Err(); err != nil {
+			return RunResult{}, err
+		}
+		logicalGeneration := m.cfg.GenerationOffset + window
+		m.events.generation = logicalGeneration + 1
+		genCtx, paramChanges := m.beginGeneration(ctx)
+		m.recordCurriculum(logicalGeneration+1, paramChanges)
+
+		tuningStats := tuningGenerationStats{}
+		evaluatedInWindow := map[string]bool{}
+		windowEvaluations := 0
+		stop := false
+		for windowEvaluations < m.cfg.DiagnosticsWindow {
+			var err error
+			stop, err = m.applyControl(ctx, true)
+			if err != nil {
+				return RunResult{}, err
+			}
+			if stop || m.goalReached {
+				stop = true
+				break
+			}
+
+			next := -1
+			if m.cfg.OpMode == OpModeGT && sinceReproduction >= m.cfg.ReproductionInterval {
+				born, record, err := m.reproduceOnline(ctx, members, logicalGeneration, births)
+				if err != nil {
+					return RunResult{}, err
+				}
+				if born >= 0 {
+					births++
+					sinceReproduction = 0
+					next = born
+					lineage = append(lineage, record)
+					evoHistoryByGenomeID = evolveHistoryByGenomeID(onlineGenomes(members), []LineageRecord{record}, evoHistoryByGenomeID)
+				}
+			}
+			if next < 0 {
+				next = cursor
+				cursor = (cursor + 1) % len(members)
+			}
+
+			scored, evalStats, counted, err := m.evaluatePopulationStage(genCtx, []model.Genome{members[next].scored.Genome}, logicalGeneration, true)
+			if err != nil {
+				return RunResult{}, err
+			}
+			members[next] = onlineMember{scored: scored[0], evaluated: true}
+			tuningStats.add(evalStats)
+			if counted[0] {
+				m.totalEvaluations++
+				evaluatedInWindow[scored[0].Genome.ID] = true
+			}
+			windowEvaluations++
+			sinceReproduction++
+			if m.cfg.EvaluationsLimit > 0 && m.totalEvaluations >= m.cfg.EvaluationsLimit {
+				break
+			}
+		}
+		if windowEvaluations == 0 {
+			break
+		}
+
+		ranked := m.rankOnline(members)
+		finalScored = ranked
+		bestHistory = append(bestHistory, ranked[0].Fitness)
+		speciesByGenomeID, speciationStats := m.assignSpecies(ranked, evoHistoryByGenomeID)
+		windowDiagnostics := summarizeGeneration(ranked, logicalGeneration+1, speciationStats, tuningStats)
+		windowDiagnostics.ScapeParamChanges = paramChanges
+		m.annotateWeightStats(&windowDiagnostics, ranked)
+		m.annotateStrategies(&windowDiagnostics, ranked)
+		windowDiagnostics.ClampEvents = totalClampEvents(ranked)
+		windowDiagnostics.EvaluationCost = SummarizeEvaluationCost(ranked)
+		m.recordKarma(&windowDiagnostics, ranked, logicalGeneration)
+		m.annotateProgress(&windowDiagnostics, window+1)
+		diagnostics = append(diagnostics, windowDiagnostics)
+		m.recordGenerationDiagnostics(windowDiagnostics)
+		counted := make([]bool, len(ranked))
+		for i, item := range ranked {
+			counted[i] = evaluatedInWindow[item.Genome.ID]
+		}
+		m.accumulateStepWindow(ranked, speciesByGenomeID, counted)
+		if err := m.captureTraceSpecies(genCtx, ranked, speciesByGenomeID); err != nil {
+			return RunResult{}, err
+		}
+		m.emitStepTraceUpdates()
+		history, currentSet := summarizeSpeciesGeneration(ranked, speciesByGenomeID, logicalGeneration+1, prevSpeciesSet)
+		speciesHistory = append(speciesHistory, history)
+		m.emitSpeciesGeneration(history)
+		m.recordGenerationEvents(ranked, history)
+		traceAcc = append(traceAcc, m.traceGeneration(logicalGeneration+1, ranked, speciesByGenomeID))
+		m.emitTraceGeneration(traceAcc[len(traceAcc)-1])
+		prevSpeciesSet = currentSet
+		m.recordStagnation(logicalGeneration + 1)
+
+		if stop || m.cfg.OpMode != OpModeGT || m.stopRequested {
+			break
+		}
+		if m.shouldStop(windowDiagnostics) {
+			break
+		}
+	}
+
+	result := RunResult{
+		BestByGeneration:      bestHistory,
+		GenerationDiagnostics: diagnostics,
+		SpeciesHistory:        speciesHistory,
+		TraceAcc:              traceAcc,
+		FinalPopulation:       finalScored,
+		Lineage:               lineage,
+		Events:                m.events.events,
+		TuningTraces:          m.tuningTraces,
+		Innovations:           m.innovations.Records(),
+	}
+	m.emitTraceUpdate(TraceUpdateReasonCompleted, m.totalEvaluations)
+	return result, nil
+}
+
+// reproduceOnline replaces the weakest evaluated member with a mutated child
+// of a selected parent and returns its index, or -1 while fewer than two
+// members have been evaluated.
+func (m *PopulationMonitor) reproduceOnline(ctx context.Context, members []onlineMember, generation, births int) (int, LineageRecord, error) {
+	defer m.phases.reproduced(time.Now())
+	ranked := m.rankOnline(members)
+	if len(ranked) < 2 {
+		return -1, LineageRecord{}, nil
+	}
+	parentPool := m.excludeBannedParents(ranked)
+	if len(parentPool) == 0 {
+		parentPool = ranked
+	}
+	parent, err := m.cfg.Selector.PickParent(m.rng, parentPool, min(m.cfg.EliteCount, len(parentPool)))
+	if err != nil {
+		return -1, LineageRecord{}, err
+	}
+	child, record, err := m.mutateFromParent(ctx, parent, generation, births)
+	if err != nil {
+		return -1, LineageRecord{}, err
+	}
+	born := []model.Genome{child}
+	stampProvenance(born, []LineageRecord{record})
+
+	weakest := ranked[len(ranked)-1].Genome.ID
+	index := slices.IndexFunc(members, func(member onlineMember) bool {
+		return member.scored.Genome.ID == weakest
+	})
+	members[index] = onlineMember{scored: ScoredGenome{Genome: born[0]}}
+	return index, record, nil
+}
+
+// rankOnline ranks the evaluated members by their latest score.
+func (m *PopulationMonitor) rankOnline(members []onlineMember) []ScoredGenome {
+	scored := make([]ScoredGenome, 0, len(members))
+	for _, member := range members {
+		if member.evaluated {
+			scored = append(scored, member.scored)
+		}
+	}
+	if m.cfg.OpMode == OpModeGT {
+		scored = m.cfg.Postprocessor.Process(scored)
+	}
+	m.rankScored(scored)
+	return scored
+}
+
+func onlineGenomes(members []onlineMember) []model.Genome {
+	genomes := make([]model.Genome, len(members))
+	for i, member := range members {
+		genomes[i] = member.scored.Genome
+	}
+	return genomes
+}
+
+func (s *tuningGenerationStats) add(other tuningGenerationStats) {
+	s.Invocations += other.Invocations
+	s.Attempts += other.Attempts
+	s.Evaluations += other.Evaluations
+	s.Accepted += other.Accepted
+	s.Rejected += other.Rejected
+	s.GoalHits += other.GoalHits
+	s.CacheHits += other.CacheHits
+	s.CacheMisses += other.CacheMisses
+}
@@ -0,0 +1,142 @@
+package evo
+
+import (
+	"context"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func newOnlineMonitor(t *testing.T, population int, mutate func(*MonitorConfig)) *PopulationMonitor {
+	t.Helper()
+	cfg := MonitorConfig{
+		Scape:           oneDimScape{},
+		OpMode:          OpModeGT,
+		EvolutionType:   EvolutionTypeOnline,
+		Mutation:        PerturbWeightAt{Index: 0, Delta: 0.2},
+		PopulationSize:  population,
+		EliteCount:      1,
+		Workers:         1,
+		Seed:            7,
+		InputNeuronIDs:  []string{"i"},
+		OutputNeuronIDs: []string{"o"},
+	}
+	mutate(&cfg)
+	monitor, err := NewPopulationMonitor(cfg)
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	return monitor
+}
+
+func onlineInitial() []model.Genome {
+	return []model.Genome{
+		newLinearGenome("g0", -1.0),
+		newLinearGenome("g1", -0.6),
+		newLinearGenome("g2", -0.2),
+		newLinearGenome("g3", 0.2),
+	}
+}
+
+func TestOnlineEvolutionReproducesAtEvaluationCadence(t *testing.T) {
+	initial := onlineInitial()
+	monitor := newOnlineMonitor(t, len(initial), func(cfg *MonitorConfig) {
+		cfg.Generations = 3
+		cfg.ReproductionInterval = 2
+		cfg.DiagnosticsWindow = 4
+	})
+	result, err := monitor.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(result.GenerationDiagnostics) != 3 || len(result.BestByGeneration) != 3 || len(result.SpeciesHistory) != 3 {
+		t.Fatalf("expected one entry per window, got diagnostics=%d best=%d species=%d",
+			len(result.GenerationDiagnostics), len(result.BestByGeneration), len(result.SpeciesHistory))
+	}
+	for i, diag := range result.GenerationDiagnostics {
+		if diag.Generation != i+1 {
+			t.Fatalf("window %d has generation %d", i, diag.Generation)
+		}
+		if diag.TotalEvaluations != 4*(i+1) {
+			t.Fatalf("window %d closed after %d evaluations, want %d", i, diag.TotalEvaluations, 4*(i+1))
+		}
+	}
+	// Twelve evaluations reproduce before evaluations 3, 5, 7, 9 and 11.
+	births := result.Lineage[len(initial):]
+	if len(births) != 5 {
+		t.Fatalf("expected 5 births, got %d", len(births))
+	}
+	seen := map[string]bool{}
+	for _, record := range result.Lineage {
+		if seen[record.GenomeID] {
+			t.Fatalf("duplicate lineage genome id %s", record.GenomeID)
+		}
+		seen[record.GenomeID] = true
+	}
+	for _, record := range births {
+		if record.ParentID == "" || record.Generation < 1 || record.Generation > 3 {
+			t.Fatalf("unexpected birth record %+v", record)
+		}
+	}
+	if len(result.FinalPopulation) != len(initial) {
+		t.Fatalf("population did not persist: got %d genomes", len(result.FinalPopulation))
+	}
+	last := births[len(births)-1].GenomeID
+	found := false
+	for _, item := range result.FinalPopulation {
+		found = found || item.Genome.ID == last
+	}
+	if !found {
+		t.Fatalf("latest newborn %s missing from the final population", last)
+	}
+}
+
+func TestOnlineEvolutionRunsUnboundedUntilEvaluationsLimit(t *testing.T) {
+	initial := onlineInitial()
+	monitor := newOnlineMonitor(t, len(initial), func(cfg *MonitorConfig) {
+		cfg.EvaluationsLimit = 10
+	})
+	result, err := monitor.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	// Windows default to the population size; the limit closes a partial one.
+	if len(result.GenerationDiagnostics) != 3 {
+		t.Fatalf("expected 3 windows, got %d", len(result.GenerationDiagnostics))
+	}
+	if got := result.GenerationDiagnostics[2].TotalEvaluations; got != 10 {
+		t.Fatalf("expected the run to stop at 10 evaluations, got %d", got)
+	}
+}
+
+func TestOnlineEvolutionRejectsUnsupportedSettings(t *testing.T) {
+	base := MonitorConfig{
+		Scape:           oneDimScape{},
+		OpMode:          OpModeGT,
+		EvolutionType:   EvolutionTypeOnline,
+		Mutation:        PerturbWeightAt{Index: 0, Delta: 0.2},
+		PopulationSize:  4,
+		EliteCount:      1,
+		InputNeuronIDs:  []string{"i"},
+		OutputNeuronIDs: []string{"o"},
+	}
+	cases := map[string]func(*MonitorConfig){
+		"negative interval": func(cfg *MonitorConfig) { cfg.ReproductionInterval = -1 },
+		"negative window":   func(cfg *MonitorConfig) { cfg.DiagnosticsWindow = -1 },
+		"entropy":           func(cfg *MonitorConfig) { cfg.EntropyThreshold = 0.5 },
+		"invariants":        func(cfg *MonitorConfig) { cfg.VerifyInvariants = true },
+		"crossover":         func(cfg *MonitorConfig) { cfg.CrossoverRate = 0.5 },
+	}
+	for name, mutate := range cases {
+		cfg := base
+		mutate(&cfg)
+		if _, err := NewPopulationMonitor(cfg); err == nil {
+			t.Fatalf("%s: expected an error", name)
+		}
+	}
+
+	monitor := newOnlineMonitor(t, 4, func(*MonitorConfig) {})
+	if _, err := monitor.StartGenerational(onlineInitial()); err == nil {
+		t.Fatal("expected online runs to reject stepping")
+	}
+}
@@ -0,0 +1,81 @@
+package evo
+
+import (
+	"time"
+
+	"protogonos/internal/model"
+)
+
+type PhaseTimings = model.PhaseTimings
+
+// phaseClock accumulates the time spent in each phase since the previous
+// trace generation entry. Reproduction covers selection and mutation
+// together; selection is what remains of it once mutation is taken out.
+type phaseClock struct {
+	windowStart  time.Time
+	reproduction time.Duration
+	mutation     time.Duration
+	evaluation   time.Duration
+	tuning       time.Duration
+	speciation   time.Duration
+	persistence  time.Duration
+}
+
+func newPhaseClock(now time.Time) phaseClock {
+	return phaseClock{windowStart: now}
+}
+
+func (c *phaseClock) reproduced(start time.Time) {
+	c.reproduction += time.Since(start)
+}
+
+func (c *phaseClock) mutated(start time.Time) {
+	c.mutation += time.Since(start)
+}
+
+func (c *phaseClock) speciated(start time.Time) {
+	c.speciation += time.Since(start)
+}
+
+func (c *phaseClock) persisted(start time.Time) {
+	c.persistence += time.Since(start)
+}
+
+// addEvaluation splits the wall-clock of one parallel evaluation pass
+// between evaluation and tuning by the worker time each consumed.
+func (c *phaseClock) addEvaluation(wall, evaluationWork, tuningWork time.Duration) {
+	if tuningWork <= 0 || evaluationWork+tuningWork <= 0 {
+		c.evaluation += wall
+		return
+	}
+	tuning := time.Duration(float64(wall) * float64(tuningWork) / float64(evaluationWork+tuningWork))
+	c.tuning += tuning
+	c.evaluation += wall - tuning
+}
+
+// take closes the current window at now and starts the next one.
+func (c *phaseClock) take(now time.Time) *PhaseTimings {
+	selection := max(0, c.reproduction-c.mutation)
+	wall := now.Sub(c.windowStart)
+	accounted := selection + c.mutation + c.evaluation + c.tuning + c.speciation + c.persistence
+	timings := &PhaseTimings{
+		WallClockSeconds:   wall.Seconds(),
+		SelectionSeconds:   selection.Seconds(),
+		MutationSeconds:    c.mutation.Seconds(),
+		EvaluationSeconds:  c.evaluation.Seconds(),
+		TuningSeconds:      c.tuning.Seconds(),
+		SpeciationSeconds:  c.speciation.Seconds(),
+		PersistenceSeconds: c.persistence.Seconds(),
+		OtherSeconds:       max(0, wall-accounted).Seconds(),
+	}
+	*c = newPhaseClock(now)
+	return timings
+}
+
+// traceGeneration builds the trace entry of a scored generation and closes
+// its phase timing window.
+func (m *PopulationMonitor) traceGeneration(generation int, scored []ScoredGenome, speciesByGenomeID map[string]string) TraceGeneration {
+	entry := buildTraceGeneration(generation, scored, speciesByGenomeID, m.lastTraceSpecies)
+	entry.Phases = m.phases.take(time.Now())
+	return entry
+}
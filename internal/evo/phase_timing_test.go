@@ -0,0 +1,100 @@
+package evo
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"protogonos/internal/model"
+)
+
+func TestPhaseClockSplitsEvaluationByWorkerTimeAndClosesWindows(t *testing.T) {
+	start := time.Unix(100, 0)
+	clock := newPhaseClock(start)
+	clock.reproduction = 300 * time.Millisecond
+	clock.mutation = 200 * time.Millisecond
+	clock.speciation = 50 * time.Millisecond
+	clock.persistence = 10 * time.Millisecond
+	// Four workers: 3s of evaluation and 1s of tuning work in a 1s pass.
+	clock.addEvaluation(time.Second, 3*time.Second, time.Second)
+
+	timings := clock.take(start.Add(2 * time.Second))
+	want := PhaseTimings{
+		WallClockSeconds:   2,
+		SelectionSeconds:   0.1,
+		MutationSeconds:    0.2,
+		EvaluationSeconds:  0.75,
+		TuningSeconds:      0.25,
+		SpeciationSeconds:  0.05,
+		PersistenceSeconds: 0.01,
+		OtherSeconds:       0.64,
+	}
+	got := *timings
+	for _, pair := range [][2]float64{
+		{got.WallClockSeconds, want.WallClockSeconds},
+		{got.SelectionSeconds, want.SelectionSeconds},
+		{got.MutationSeconds, want.MutationSeconds},
+		{got.EvaluationSeconds, want.EvaluationSeconds},
+		{got.TuningSeconds, want.TuningSeconds},
+		{got.SpeciationSeconds, want.SpeciationSeconds},
+		{got.PersistenceSeconds, want.PersistenceSeconds},
+		{got.OtherSeconds, want.OtherSeconds},
+	} {
+		if math.Abs(pair[0]-pair[1]) > 1e-9 {
+			t.Fatalf("expected %+v, got %+v", want, got)
+		}
+	}
+
+	next := clock.take(start.Add(3 * time.Second))
+	if *next != (PhaseTimings{WallClockSeconds: 1, OtherSeconds: 1}) {
+		t.Fatalf("expected take to start a fresh window, got %+v", *next)
+	}
+}
+
+func TestGenerationalRunRecordsPhaseTimingsInTheTrace(t *testing.T) {
+	initial := []model.Genome{
+		newLinearGenome("g0", 0.1),
+		newLinearGenome("g1", 0.2),
+		newLinearGenome("g2", 0.3),
+	}
+	var streamed []TraceGeneration
+	monitor, err := NewPopulationMonitor(MonitorConfig{
+		Scape:           levelScape{},
+		Mutation:        namedNoopMutation{name: "noop"},
+		PopulationSize:  len(initial),
+		EliteCount:      1,
+		Generations:     3,
+		Workers:         2,
+		Seed:            1,
+		InputNeuronIDs:  []string{"i"},
+		OutputNeuronIDs: []string{"o"},
+		TraceGenerationHook: func(generation TraceGeneration) {
+			streamed = append(streamed, generation)
+		},
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	result, err := monitor.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(result.TraceAcc) != 3 || len(streamed) != 3 {
+		t.Fatalf("expected 3 trace generations, got %d (streamed %d)", len(result.TraceAcc), len(streamed))
+	}
+	for i, entry := range result.TraceAcc {
+		phases := entry.Phases
+		if phases == nil || streamed[i].Phases == nil {
+			t.Fatalf("generation %d: expected phase timings", entry.Generation)
+		}
+		parts := phases.SelectionSeconds + phases.MutationSeconds + phases.EvaluationSeconds + phases.TuningSeconds +
+			phases.SpeciationSeconds + phases.PersistenceSeconds + phases.OtherSeconds
+		if phases.EvaluationSeconds <= 0 || parts < phases.WallClockSeconds-1e-9 {
+			t.Fatalf("generation %d: expected evaluation time and phases covering the wall-clock, got %+v", entry.Generation, *phases)
+		}
+		if i > 0 && phases.MutationSeconds <= 0 {
+			t.Fatalf("generation %d: expected the reproduction that bred it to be timed, got %+v", entry.Generation, *phases)
+		}
+	}
+}
@@ -1,6 +1,110 @@
 package evo
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"protogonos/internal/genotype"
+	"protogonos/internal/model"
+	"protogonos/internal/morphology"
+)
+
 type WeightedMutation struct {
 	Operator Operator
 	Weight   float64
 }
+
+// PolicyMutator applies single mutations drawn from a weighted policy, the
+// way a population monitor mutates offspring, for optimizers that mutate
+// genomes outside one. It is not safe for concurrent use.
+type PolicyMutator struct {
+	ScapeName string
+	// Mutation is the fallback operator, applied when no policy operator
+	// is applicable or the chosen one fails.
+	Mutation Operator
+	Policy   []WeightedMutation
+	Rand     *rand.Rand
+}
+
+// Mutate returns a copy of genome named id with one successful mutation
+// that keeps the genome compatible with the scape's morphology, and the
+// name of the operator that applied it.
+func (p *PolicyMutator) Mutate(ctx context.Context, genome model.Genome, id string) (model.Genome, string, error) {
+	if p.Rand == nil {
+		return model.Genome{}, "", errors.New("random source is required")
+	}
+	child := genotype.CloneAgent(genome, id)
+	maxAttempts := 4 + 4*len(p.Policy)
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return model.Genome{}, "", err
+		}
+		operator := chooseMutation(p.Policy, p.Mutation, child, p.ScapeName, p.Rand)
+		if operator == nil {
+			return model.Genome{}, "", errors.New("no mutation operator configured")
+		}
+		next, err := operator.Apply(ctx, child)
+		name := operator.Name()
+		if err != nil && p.Mutation != nil && operator != p.Mutation {
+			next, err = p.Mutation.Apply(ctx, child)
+			name = p.Mutation.Name() + "(fallback)"
+		}
+		if err != nil {
+			if errors.Is(err, ErrNoSynapses) || errors.Is(err, ErrNoNeurons) || errors.Is(err, ErrNoMutationChoice) {
+				continue
+			}
+			return model.Genome{}, "", err
+		}
+		if err := morphology.EnsureGenomeIOCompatibility(p.ScapeName, next); err != nil {
+			continue
+		}
+		return next, name, nil
+	}
+	return model.Genome{}, "", fmt.Errorf("failed to mutate genome %s after %d attempts", genome.ID, maxAttempts)
+}
+
+// chooseMutation picks a policy operator applicable to genome with
+// probability proportional to its weight, falling back to mutation.
+func chooseMutation(policy []WeightedMutation, mutation Operator, genome model.Genome, scapeName string, rng *rand.Rand) Operator {
+	if len(policy) == 0 {
+		return mutation
+	}
+
+	total := 0.0
+	candidates := make([]WeightedMutation, 0, len(policy))
+	for _, item := range policy {
+		if !operatorApplicable(item.Operator, genome, scapeName) {
+			continue
+		}
+		candidates = append(candidates, item)
+		total += item.Weight
+	}
+	if total <= 0 {
+		if operatorApplicable(mutation, genome, scapeName) {
+			return mutation
+		}
+		// No compatible operator; fall back to legacy behavior.
+		return policy[len(policy)-1].Operator
+	}
+	pick := rng.Float64() * total
+	acc := 0.0
+	for _, item := range candidates {
+		acc += item.Weight
+		if pick <= acc {
+			return item.Operator
+		}
+	}
+	return candidates[len(candidates)-1].Operator
+}
+
+func operatorApplicable(operator Operator, genome model.Genome, scapeName string) bool {
+	if operator == nil {
+		return false
+	}
+	if contextual, ok := operator.(ContextualOperator); ok {
+		return contextual.Applicable(genome, scapeName)
+	}
+	return true
+}
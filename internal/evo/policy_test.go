@@ -0,0 +1,47 @@
+package evo
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+type noChoiceMutation struct{}
+
+func (noChoiceMutation) Name() string { return "no_choice" }
+
+func (noChoiceMutation) Apply(context.Context, model.Genome) (model.Genome, error) {
+	return model.Genome{}, ErrNoMutationChoice
+}
+
+func TestPolicyMutatorNamesTheChildAndFallsBack(t *testing.T) {
+	parent := model.Genome{ID: "parent", Neurons: []model.Neuron{{ID: "i"}, {ID: "o"}}}
+	mutator := &PolicyMutator{
+		ScapeName: "xor",
+		Mutation:  namedNoopMutation{name: "noop"},
+		Policy:    []WeightedMutation{{Operator: failingMutation{name: "broken"}, Weight: 1}},
+		Rand:      rand.New(rand.NewSource(1)),
+	}
+	child, operation, err := mutator.Mutate(context.Background(), parent, "child")
+	if err != nil {
+		t.Fatalf("mutate: %v", err)
+	}
+	if child.ID != "child" || operation != "noop(fallback)" {
+		t.Fatalf("expected the fallback to mutate a child named child, got %s via %s", child.ID, operation)
+	}
+	if parent.ID != "parent" {
+		t.Fatal("expected the parent to be left unchanged")
+	}
+
+	stuck := &PolicyMutator{
+		ScapeName: "xor",
+		Policy:    []WeightedMutation{{Operator: noChoiceMutation{}, Weight: 1}},
+		Rand:      rand.New(rand.NewSource(1)),
+	}
+	if _, _, err := stuck.Mutate(context.Background(), parent, "child"); err == nil || errors.Is(err, ErrNoMutationChoice) {
+		t.Fatalf("expected mutation to give up after its attempts, got %v", err)
+	}
+}
@@ -4,20 +4,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"math"
 	"math/rand"
 	"reflect"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"protogonos/internal/agent"
+	"protogonos/internal/encoding"
 	"protogonos/internal/genotype"
 	protoio "protogonos/internal/io"
 	"protogonos/internal/model"
 	"protogonos/internal/morphology"
+	"protogonos/internal/nn"
 	"protogonos/internal/scape"
-	"protogonos/internal/substrate"
+	"protogonos/internal/stats"
 	"protogonos/internal/tuning"
 )
 
@@ -25,6 +29,19 @@ type ScoredGenome struct {
 	Genome  model.Genome
 	Fitness float64
 	Trace   scape.Trace
+	// FitnessCI is the bootstrap interval over repeated trials; nil when the
+	// genome was evaluated once.
+	FitnessCI *stats.ConfidenceInterval
+	// Clamps counts numeric guard interventions across the genome's
+	// evaluations; always zero unless a clamp is configured.
+	Clamps nn.ClampCounts
+	// Degenerate names why the evaluation was unusable (timeout, panic or
+	// non_finite) when the karma ledger is enabled; such genomes are scored
+	// at the worst fitness of their generation.
+	Degenerate string
+	// Cost is the metered cost of the genome's evaluations; zero unless
+	// MeterEvaluations is on.
+	Cost EvaluationCost
 }
 
 type RunResult struct {
@@ -34,6 +51,13 @@ type RunResult struct {
 	TraceAcc              []TraceGeneration
 	FinalPopulation       []ScoredGenome
 	Lineage               []LineageRecord
+	Events                []EvolutionEvent
+	// TuningTraces holds the sessions of tuners that record traces, by
+	// generation and population order.
+	TuningTraces []model.TuningTrace
+	// Innovations is the run's innovation registry when TrackInnovations
+	// is on.
+	Innovations []model.InnovationRecord
 }
 
 type SpeciesGeneration struct {
@@ -48,6 +72,8 @@ type SpeciesMetrics struct {
 	Size        int     `json:"size"`
 	MeanFitness float64 `json:"mean_fitness"`
 	BestFitness float64 `json:"best_fitness"`
+	// Strategies is the distribution of the species members' strategies.
+	Strategies *StrategyDistribution `json:"strategies,omitempty"`
 }
 
 type GenerationDiagnostics struct {
@@ -69,6 +95,72 @@ type GenerationDiagnostics struct {
 	TuningGoalHits        int     `json:"tuning_goal_hits"`
 	TuningAcceptRate      float64 `json:"tuning_accept_rate"`
 	TuningEvalsPerAttempt float64 `json:"tuning_evals_per_attempt"`
+	// EvalCacheHits and EvalCacheMisses count neuron activation columns
+	// reused from or computed into the tuning evaluation cache.
+	EvalCacheHits   int `json:"eval_cache_hits,omitempty"`
+	EvalCacheMisses int `json:"eval_cache_misses,omitempty"`
+	// Progress fields: wall-clock time spent on this generation (including
+	// reproduction of the previous one), cumulative throughput, and the
+	// estimated time until the generation or evaluation limit is reached.
+	WallClockSeconds     float64 `json:"wall_clock_seconds,omitempty"`
+	TotalEvaluations     int     `json:"total_evaluations,omitempty"`
+	EvaluationsPerSecond float64 `json:"evaluations_per_second,omitempty"`
+	ETASeconds           float64 `json:"eta_seconds,omitempty"`
+	// ScapeParamChanges lists scape parameters changed through the control
+	// API that took effect at the start of this generation.
+	ScapeParamChanges []ScapeParamChange `json:"scape_param_changes,omitempty"`
+	// Multi-fidelity fields: how many genomes got the full evaluation and the
+	// mean full-minus-low fitness gap applied to the screened-out rest.
+	FidelityFinalists int     `json:"fidelity_finalists,omitempty"`
+	FidelityOffset    float64 `json:"fidelity_offset,omitempty"`
+	// Weight health: enabled-weight statistics over the whole population and
+	// per inferred layer of the generation champion.
+	PopulationWeights    *WeightStats       `json:"population_weights,omitempty"`
+	ChampionLayerWeights []LayerWeightStats `json:"champion_layer_weights,omitempty"`
+	// ClampEvents sums numeric guard interventions over the generation.
+	ClampEvents int `json:"clamp_events,omitempty"`
+	// Crossover outcomes: how many of this generation's genomes were bred by
+	// intraspecies or hybrid (interspecies) crossover, and their mean fitness.
+	CrossoverIntra      int     `json:"crossover_intra,omitempty"`
+	CrossoverHybrid     int     `json:"crossover_hybrid,omitempty"`
+	CrossoverIntraMean  float64 `json:"crossover_intra_mean,omitempty"`
+	CrossoverHybridMean float64 `json:"crossover_hybrid_mean,omitempty"`
+	// DegenerateGenomes counts genomes whose evaluation timed out, panicked
+	// or produced NaN/Inf; BannedFingerprints is the number of fingerprints
+	// excluded from parenthood after this generation.
+	DegenerateGenomes  int `json:"degenerate_genomes,omitempty"`
+	BannedFingerprints int `json:"banned_fingerprints,omitempty"`
+	// Entropy detector fields: normalized genotype and behavior entropy of
+	// the generation and the action it triggered, if any.
+	GenotypeEntropy float64 `json:"genotype_entropy,omitempty"`
+	BehaviorEntropy float64 `json:"behavior_entropy,omitempty"`
+	EntropyAction   string  `json:"entropy_action,omitempty"`
+	// SpeciesAllocation is the offspring count each species was allotted to
+	// breed the next generation, besides elites; empty for selectors and
+	// evolution types that do not allocate by species.
+	SpeciesAllocation map[string]int `json:"species_allocation,omitempty"`
+	// SpeciesRebalancing lists the soft-cap actions behind SpeciesAllocation:
+	// species capped or released, and quotas trimmed or topped up.
+	SpeciesRebalancing []SpeciesRebalance `json:"species_rebalancing,omitempty"`
+	// PopulationSize is the number of genomes evaluated this generation and
+	// PopulationResize why the next one differs in size (guard, stagnation,
+	// throughput or bounds); both are set only when population resize is on.
+	PopulationSize   int    `json:"population_size,omitempty"`
+	PopulationResize string `json:"population_resize,omitempty"`
+	// Strategies is the distribution of evolvable strategy values (tuning
+	// selection, annealing, topological mode, heredity) over the population.
+	Strategies *StrategyDistribution `json:"strategies,omitempty"`
+	// EvaluationCost is the metered CPU time and allocation of the
+	// generation's evaluations; set only when MeterEvaluations is on.
+	EvaluationCost *EvaluationCostStats `json:"evaluation_cost,omitempty"`
+	// ScapePoolWaitSeconds is the time the generation's evaluations spent
+	// waiting for a pooled scape instance; set only for pooled scapes.
+	ScapePoolWaitSeconds float64 `json:"scape_pool_wait_seconds,omitempty"`
+	// IslandBest is the best fitness on each island of an island-model run,
+	// and Migrants the number of genomes that migrated into the next
+	// generation.
+	IslandBest []float64 `json:"island_best,omitempty"`
+	Migrants   int       `json:"migrants,omitempty"`
 }
 
 type TraceUpdateReason string
@@ -110,6 +202,7 @@ type TraceSpeciesMetrics struct {
 type TraceGeneration struct {
 	Generation int              `json:"generation"`
 	Stats      []TraceStatEntry `json:"stats"`
+	Phases     *PhaseTimings    `json:"phases,omitempty"`
 }
 
 type TraceStatEntry struct {
@@ -126,6 +219,7 @@ type LineageRecord struct {
 	ParentID    string                     `json:"parent_id"`
 	Generation  int                        `json:"generation"`
 	Operation   string                     `json:"operation"`
+	MateID      string                     `json:"mate_id,omitempty"`
 	Events      []genotype.EvoHistoryEvent `json:"events,omitempty"`
 	Fingerprint string                     `json:"fingerprint,omitempty"`
 	Summary     TopologySummary            `json:"summary,omitempty"`
@@ -151,6 +245,9 @@ type MonitorConfig struct {
 	EvaluationsLimit     int
 	Workers              int
 	Seed                 int64
+	SelectionSeed        *int64
+	MutationSeed         *int64
+	EnvSeed              *int64
 	InputNeuronIDs       []string
 	OutputNeuronIDs      []string
 	Tuner                tuning.Tuner
@@ -161,11 +258,183 @@ type MonitorConfig struct {
 	Control              <-chan MonitorCommand
 	TraceStepSize        int
 	TraceUpdateHook      func(TraceUpdate)
+	// TraceGenerationHook receives each trace_acc generation entry as soon as
+	// it is accumulated, so callers can stream the trace while a run is live.
+	TraceGenerationHook func(TraceGeneration)
+	// ProgressHook receives each generation's diagnostics, including
+	// throughput and ETA, as soon as the generation is scored.
+	ProgressHook func(GenerationDiagnostics)
+	// SpeciesHook receives each generation's species summary once the
+	// generation is scored and speciated, after ProgressHook.
+	SpeciesHook func(SpeciesGeneration)
+	// DisableBatchEval forces stepwise evaluation even when the scape
+	// and genome both support batched dataset passes.
+	DisableBatchEval bool
+	// DisableEvalCache turns off memoization of neuron activation columns
+	// across the candidate evaluations of one tuning job on a batch scape.
+	DisableEvalCache bool
+	// ScapePool, when set, supplies the started instances evaluations run
+	// on in place of Scape, which still names the scape and answers
+	// capability checks. The caller sizes it to Workers and closes it.
+	ScapePool *scape.ScapePool
+	// Encoding expresses genomes into the networks evaluations run; nil
+	// selects encoding.Auto.
+	Encoding encoding.Encoding
+	// MeterEvaluations measures each evaluation's CPU time and allocations
+	// into ScoredGenome.Cost and the generation diagnostics. It is implied by
+	// CostProportionalPostprocessor.
+	MeterEvaluations bool
+	// EvaluationTrials repeats each final genome evaluation; values above one
+	// score the genome by its trial mean and attach a bootstrap interval.
+	EvaluationTrials int
+	// VerifyInvariants checks every generation for a stable population size,
+	// unique genome ids, consistent species assignments and preserved
+	// elites whose fitness does not drop, failing the run with an
+	// *InvariantViolation that dumps the offending population.
+	VerifyInvariants bool
+	// CITieBreak ranks genomes with equal fitness by their interval lower
+	// bound, preferring the more reliably good one.
+	CITieBreak bool
+	// TrialAggregation selects how repeated trial fitness is combined:
+	// mean (default), cvar (mean of the worst CVaRAlpha tail) or worst.
+	TrialAggregation string
+	CVaRAlpha        float64
+	// FitnessScaling rescales fitness for parent selection only (none,
+	// sigma, rank or minmax); ranking, elitism and reported fitness keep the
+	// raw values. ScalingPressure is the sigma multiple or the linear
+	// ranking pressure.
+	FitnessScaling  string
+	ScalingPressure float64
+	// SpeciesAllocation divides each generation's offspring between species:
+	// proportional (default) to the species' mean fitness, i.e. its summed
+	// fitness-sharing adjusted fitness; rank, weighting species 1..n by mean
+	// fitness; or equal. Every species gets at least AllocationFloor
+	// offspring and at most AllocationCeiling (zero is unbounded).
+	SpeciesAllocation string
+	AllocationFloor   int
+	AllocationCeiling int
+	// SpeciesSoftCap is a soft limit on species size for generational runs.
+	// A species larger than the cap is capped: its offspring are trimmed to
+	// the cap and the excess goes to species below it. A capped species
+	// stays capped until its size falls to SpeciesSoftCap*(1-
+	// SpeciesCapHysteresis), so allocations do not flip every generation.
+	// Excess no species can take stays with its species. Zero disables.
+	SpeciesSoftCap       int
+	SpeciesCapHysteresis float64
+	// PopulationResize lets the generational loop change the population
+	// size: off (default) keeps PopulationSize; guard grows it back to a
+	// viable floor of EliteCount plus two genomes per species, at least
+	// MinPopulation; auto also grows it after stagnation and shrinks
+	// generations slower than ResizeTargetSeconds. Sizes never exceed
+	// MaxPopulation, which defaults to four times PopulationSize under auto.
+	PopulationResize    string
+	MinPopulation       int
+	MaxPopulation       int
+	ResizeTargetSeconds float64
+	// NewcomerFactory builds a freshly seeded genome for selectors that inject
+	// random immigrants (AFPO). Generation is the one the genome will join.
+	NewcomerFactory func(generation, index int) (model.Genome, error)
+	// LowFidelity enables two-stage evaluation when in (0, 1): every genome is
+	// screened at this fraction of the scape's episode length or data, and
+	// only the top FinalistFraction get the full (and tuned) evaluation.
+	LowFidelity      float64
+	FinalistFraction float64
+	// CommonRandomNumbers keys scape noise by population slot and generation
+	// rather than genome, so paired runs see matched environments.
+	CommonRandomNumbers bool
+	// ActivationClamp and WeightClamp enable the evaluator numeric guard when
+	// either is positive: NaN/Inf neuron values are zeroed and aggregated
+	// input and weights are clamped to the given magnitudes (0 leaves that
+	// bound off). Interventions are reported in ScoredGenome.Clamps.
+	ActivationClamp float64
+	WeightClamp     float64
+	// CrossoverRate is the probability that a generational or steady-state
+	// offspring is bred from two parents before mutation; while it is
+	// positive, parents whose heredity type is crossover always are, and at
+	// zero no offspring is. Mates come from the parent's
+	// species; InterspeciesMating is the chance of drawing one from another
+	// species instead, producing a hybrid.
+	CrossoverRate      float64
+	InterspeciesMating float64
+	// PruneDisabledAfter removes synapses of bred offspring that have been
+	// disabled for at least this many generations of descent, each with
+	// probability PruneDisabledProb (DefaultPruneDisabledProb when zero).
+	// Removals are recorded in the child's lineage as OperationPruneDisabled.
+	// Zero disables pruning.
+	PruneDisabledAfter int
+	PruneDisabledProb  float64
+	// TrackInnovations gives every neuron and synapse a historical marking
+	// from a run-wide genotype.InnovationRegistry, continued from
+	// Innovations when a run is resumed. The registry is returned in
+	// RunResult.Innovations.
+	TrackInnovations bool
+	Innovations      []model.InnovationRecord
+	// EvaluationTimeout bounds each scape evaluation; 0 disables it.
+	EvaluationTimeout time.Duration
+	// KarmaStrikes enables the karma ledger when positive: timeouts, panics
+	// and NaN/Inf results are scored as degenerate instead of failing the
+	// run, and a fingerprint degenerate in KarmaStrikes generations is
+	// excluded from parenthood for KarmaCooldown generations (default 5).
+	KarmaStrikes  int
+	KarmaCooldown int
+	// StopCondition, when set, is a compound condition (see
+	// ParseStopCondition) checked after every generation. It replaces the
+	// FitnessGoal/EvaluationsLimit pair as the run's stop rule; FitnessGoal
+	// still guides the tuner.
+	StopCondition string
+	// EntropyThreshold enables the entropy restart detector when in (0, 1]:
+	// each generation's normalized EntropyMeasure (genotype fingerprints,
+	// the default, or behavior) below it triggers EntropyAction on the next
+	// reproduction: mutation (the default) triples mutation counts,
+	// immigrants replaces the worst quarter with newcomers and restart
+	// reseeds all but the elites. The detector then rests for
+	// EntropyCooldown generations (default 5).
+	EntropyThreshold float64
+	EntropyMeasure   string
+	EntropyAction    string
+	EntropyCooldown  int
+	// ReproductionInterval and DiagnosticsWindow shape online evolution: a
+	// newborn replaces the weakest evaluated genome every
+	// ReproductionInterval evaluations, and diagnostics, trace entries and
+	// stop checks cover rolling windows of DiagnosticsWindow evaluations.
+	// Both default to PopulationSize.
+	ReproductionInterval int
+	DiagnosticsWindow    int
+	// Islands splits a generational population into that many contiguous
+	// sub-populations that select and breed apart, each on its own RNG
+	// stream. Every MigrationInterval generations (default 10) the Migrants
+	// best genomes of each island (default 1) overwrite the last offspring
+	// bred for the next island in a ring, before they are evaluated.
+	// EliteCount applies per island. IslandMutations, when set, builds the
+	// fallback mutation and weighted policy of the island whose streams are
+	// offset by seedOffset, so each island's operators draw from their own
+	// RNGs; otherwise the islands share Mutation and MutationPolicy.
+	Islands           int
+	MigrationInterval int
+	Migrants          int
+	IslandMutations   func(seedOffset int64) (Operator, []WeightedMutation)
+	// Rands, when set, creates the monitor's random streams so checkpoints
+	// can record their positions. Every CheckpointInterval generations a
+	// generational run that has generations left hands a Checkpoint to
+	// CheckpointHook; an error from the hook fails the run. Resume restores
+	// such a checkpoint in StartGenerational in place of the initial
+	// population; the monitor must be configured as the checkpointed one was,
+	// with Rands holding freshly created streams made in the same order.
+	Rands              *RandStreams
+	CheckpointInterval int
+	CheckpointHook     func(context.Context, Checkpoint) error
+	Resume             *Checkpoint
+	// Every SnapshotInterval logical generations a generational run hands
+	// its scored population to SnapshotHook, so the population can later be
+	// forked from; an error from the hook fails the run.
+	SnapshotInterval int
+	SnapshotHook     func(ctx context.Context, generation int, scored []ScoredGenome) error
 }
 
 type PopulationMonitor struct {
 	cfg                    MonitorConfig
 	rng                    *rand.Rand
+	mutationRNG            *rand.Rand
 	speciation             *AdaptiveSpeciation
 	paused                 bool
 	stopRequested          bool
@@ -179,6 +448,32 @@ type PopulationMonitor struct {
 	lastTraceSpecies       []TraceSpeciesMetrics
 	lastDiagnostics        GenerationDiagnostics
 	hasDiagnostics         bool
+	ages                   map[string]int
+	runStartedAt           time.Time
+	lastProgressAt         time.Time
+	lastPoolWait           time.Duration
+	scapeParams            map[string]float64
+	pendingScapeParams     []ScapeParamChange
+	generationFidelity     fidelityGenerationStats
+	crossoverOffspring     map[string]string
+	lastAllocation         map[string]int
+	lastRebalancing        []SpeciesRebalance
+	cappedSpecies          map[string]bool
+	tuningTraces           []model.TuningTrace
+	basePopulation         int
+	karma                  *karmaLedger
+	events                 *eventLog
+	stopCondition          *StopCondition
+	stopBest               float64
+	stopHasBest            bool
+	stopStagnation         int
+	entropy                entropyDetector
+	invariantElites        []invariantElite
+	phases                 phaseClock
+	innovations            *genotype.InnovationRegistry
+	islands                []islandStreams
+	islandOf               map[string]int
+	lastMigrants           int
 }
 
 type goalAwareTuner interface {
@@ -192,6 +487,8 @@ type tuningGenerationStats struct {
 	Accepted    int
 	Rejected    int
 	GoalHits    int
+	CacheHits   int
+	CacheMisses int
 }
 
 type MonitorCommand string
@@ -213,6 +510,7 @@ const (
 const (
 	EvolutionTypeGenerational = "generational"
 	EvolutionTypeSteadyState  = "steady_state"
+	EvolutionTypeOnline       = "online"
 )
 
 const (
@@ -246,7 +544,7 @@ func NewPopulationMonitor(cfg MonitorConfig) (*PopulationMonitor, error) {
 		cfg.EvolutionType = EvolutionTypeGenerational
 	}
 	switch cfg.EvolutionType {
-	case EvolutionTypeGenerational, EvolutionTypeSteadyState:
+	case EvolutionTypeGenerational, EvolutionTypeSteadyState, EvolutionTypeOnline:
 	default:
 		return nil, fmt.Errorf("unsupported evolution type: %s", cfg.EvolutionType)
 	}
@@ -289,7 +587,25 @@ func NewPopulationMonitor(cfg MonitorConfig) (*PopulationMonitor, error) {
 	if cfg.EliteCount <= 0 || cfg.EliteCount > cfg.PopulationSize {
 		return nil, fmt.Errorf("elite count must be in [1, population size]")
 	}
-	if cfg.Generations <= 0 {
+	if cfg.PopulationResize == PopulationResizeAuto && cfg.MaxPopulation == 0 {
+		cfg.MaxPopulation = resizeDefaultMaxFactor * cfg.PopulationSize
+	}
+	if cfg.EvolutionType != EvolutionTypeGenerational &&
+		(cfg.PopulationResize == PopulationResizeGuard || cfg.PopulationResize == PopulationResizeAuto) {
+		return nil, fmt.Errorf("population resize requires generational evolution")
+	}
+	if cfg.EvolutionType != EvolutionTypeGenerational && cfg.VerifyInvariants {
+		return nil, fmt.Errorf("verify invariants requires generational evolution")
+	}
+	if cfg.MaxPopulation > 0 && cfg.MaxPopulation < cfg.EliteCount {
+		return nil, fmt.Errorf("max population %d is below elite count %d", cfg.MaxPopulation, cfg.EliteCount)
+	}
+	if cfg.EvolutionType == EvolutionTypeOnline {
+		// Online runs count diagnostics windows; zero leaves them unbounded.
+		if cfg.Generations < 0 {
+			return nil, fmt.Errorf("generations must be >= 0")
+		}
+	} else if cfg.Generations <= 0 {
 		return nil, fmt.Errorf("generations must be > 0")
 	}
 	if cfg.GenerationOffset < 0 {
@@ -307,6 +623,59 @@ func NewPopulationMonitor(cfg MonitorConfig) (*PopulationMonitor, error) {
 	if cfg.TraceStepSize == 0 {
 		cfg.TraceStepSize = defaultTraceStepSize
 	}
+	var stopCondition *StopCondition
+	if strings.TrimSpace(cfg.StopCondition) != "" {
+		parsed, err := ParseStopCondition(cfg.StopCondition)
+		if err != nil {
+			return nil, err
+		}
+		stopCondition = parsed
+	}
+	if err := validateMultiFidelity(cfg); err != nil {
+		return nil, err
+	}
+	if cfg.ActivationClamp < 0 || math.IsNaN(cfg.ActivationClamp) || math.IsInf(cfg.ActivationClamp, 0) {
+		return nil, fmt.Errorf("activation clamp must be a finite value >= 0, got %g", cfg.ActivationClamp)
+	}
+	if cfg.WeightClamp < 0 || math.IsNaN(cfg.WeightClamp) || math.IsInf(cfg.WeightClamp, 0) {
+		return nil, fmt.Errorf("weight clamp must be a finite value >= 0, got %g", cfg.WeightClamp)
+	}
+	if !(cfg.CrossoverRate >= 0 && cfg.CrossoverRate <= 1) {
+		return nil, fmt.Errorf("crossover rate must be in [0, 1], got %g", cfg.CrossoverRate)
+	}
+	if !(cfg.InterspeciesMating >= 0 && cfg.InterspeciesMating <= 1) {
+		return nil, fmt.Errorf("interspecies mating probability must be in [0, 1], got %g", cfg.InterspeciesMating)
+	}
+	if cfg.CrossoverRate > 0 && cfg.EvolutionType != EvolutionTypeGenerational && cfg.EvolutionType != EvolutionTypeSteadyState {
+		return nil, fmt.Errorf("crossover requires generational or steady_state evolution")
+	}
+	if err := ValidateDisabledSynapsePruning(cfg.PruneDisabledAfter, cfg.PruneDisabledProb); err != nil {
+		return nil, err
+	}
+	if cfg.EvaluationTimeout < 0 {
+		return nil, fmt.Errorf("evaluation timeout must be >= 0")
+	}
+	if cfg.KarmaStrikes < 0 {
+		return nil, fmt.Errorf("karma strikes must be >= 0")
+	}
+	if cfg.KarmaCooldown < 0 {
+		return nil, fmt.Errorf("karma cooldown must be >= 0")
+	}
+	if cfg.KarmaStrikes > 0 && cfg.KarmaCooldown == 0 {
+		cfg.KarmaCooldown = defaultKarmaCooldown
+	}
+	if err := validateEntropyRestart(&cfg); err != nil {
+		return nil, err
+	}
+	if err := validateOnline(&cfg); err != nil {
+		return nil, err
+	}
+	if err := validateIslands(&cfg); err != nil {
+		return nil, err
+	}
+	if err := validateCheckpoints(cfg); err != nil {
+		return nil, err
+	}
 	if cfg.SpeciationMode == "" {
 		cfg.SpeciationMode = SpeciationModeAdaptive
 	}
@@ -341,6 +710,12 @@ func NewPopulationMonitor(cfg MonitorConfig) (*PopulationMonitor, error) {
 	if cfg.Postprocessor == nil {
 		cfg.Postprocessor = NoopFitnessPostprocessor{}
 	}
+	if _, ok := cfg.Postprocessor.(CostProportionalPostprocessor); ok {
+		cfg.MeterEvaluations = true
+	}
+	if novelty, ok := cfg.Postprocessor.(NoveltyArchivePostprocessor); ok && novelty.Archive == nil {
+		cfg.Postprocessor = NoveltyArchivePostprocessor{Archive: NewNoveltyArchive()}
+	}
 	if cfg.TopologicalMutations == nil {
 		cfg.TopologicalMutations = ConstTopologicalMutations{Count: 1}
 	}
@@ -350,39 +725,112 @@ func NewPopulationMonitor(cfg MonitorConfig) (*PopulationMonitor, error) {
 		adaptiveSpeciation = NewAdaptiveSpeciation(cfg.PopulationSize)
 	}
 
+	// Selection and mutation share one stream unless either seed is set
+	// explicitly, which keeps runs configured only by Seed reproducible.
+	rng := cfg.Rands.New(seedOr(cfg.SelectionSeed, cfg.Seed))
+	mutationRNG := rng
+	if cfg.SelectionSeed != nil || cfg.MutationSeed != nil {
+		mutationRNG = cfg.Rands.New(seedOr(cfg.MutationSeed, cfg.Seed))
+	}
 	return &PopulationMonitor{
-		cfg:        cfg,
-		rng:        rand.New(rand.NewSource(cfg.Seed)),
-		speciation: adaptiveSpeciation,
+		cfg:            cfg,
+		rng:            rng,
+		mutationRNG:    mutationRNG,
+		speciation:     adaptiveSpeciation,
+		stopCondition:  stopCondition,
+		basePopulation: cfg.PopulationSize,
+		islands:        newIslandStreams(cfg),
 	}, nil
 }
 
-func (m *PopulationMonitor) Run(ctx context.Context, initial []model.Genome) (RunResult, error) {
-	if len(initial) != m.cfg.PopulationSize {
-		return RunResult{}, fmt.Errorf("initial population mismatch: got=%d want=%d", len(initial), m.cfg.PopulationSize)
+func seedOr(seed *int64, fallback int64) int64 {
+	if seed == nil {
+		return fallback
 	}
-	m.resetRunState()
-	if m.cfg.EvolutionType == EvolutionTypeSteadyState {
+	return *seed
+}
+
+func (m *PopulationMonitor) Run(ctx context.Context, initial []model.Genome) (RunResult, error) {
+	if m.cfg.EvolutionType != EvolutionTypeGenerational {
+		m.cfg.PopulationSize = m.basePopulation
+		if len(initial) != m.cfg.PopulationSize {
+			return RunResult{}, fmt.Errorf("initial population mismatch: got=%d want=%d", len(initial), m.cfg.PopulationSize)
+		}
+		m.resetRunState()
+		initial = m.markInnovations(initial)
+		if m.cfg.EnvSeed != nil {
+			ctx = scape.WithEnvSeed(ctx, *m.cfg.EnvSeed)
+		}
+		if m.cfg.EvolutionType == EvolutionTypeOnline {
+			return m.runOnline(ctx, initial)
+		}
 		return m.runSteadyState(ctx, initial)
 	}
+	run, err := m.StartGenerational(initial)
+	if err != nil {
+		return RunResult{}, err
+	}
+	for !run.Done() {
+		if err := run.Step(ctx); err != nil {
+			return RunResult{}, err
+		}
+	}
+	return run.Result(), nil
+}
 
-	population := make([]model.Genome, len(initial))
-	copy(population, initial)
+// GenerationalRun is a generational Run driven one generation at a time, for
+// callers that schedule evolution themselves. A monitor drives one run at a
+// time.
+type GenerationalRun struct {
+	m                    *PopulationMonitor
+	gen                  int
+	done                 bool
+	completed            bool
+	population           []model.Genome
+	scored               []ScoredGenome
+	bestHistory          []float64
+	diagnostics          []GenerationDiagnostics
+	speciesHistory       []SpeciesGeneration
+	traceAcc             []TraceGeneration
+	lineage              []LineageRecord
+	prevSpeciesSet       map[string]struct{}
+	evoHistoryByGenomeID map[string][]genotype.EvoHistoryEvent
+}
 
-	bestHistory := make([]float64, 0, m.cfg.Generations)
-	diagnostics := make([]GenerationDiagnostics, 0, m.cfg.Generations)
-	speciesHistory := make([]SpeciesGeneration, 0, m.cfg.Generations)
-	traceAcc := make([]TraceGeneration, 0, m.cfg.Generations)
-	lineage := make([]LineageRecord, 0, len(initial)*(m.cfg.Generations+1))
-	prevSpeciesSet := map[string]struct{}{}
-	evoHistoryByGenomeID := initializeEvoHistoryByGenomeID(population)
+// StartGenerational resets the monitor and records the seed lineage of
+// initial without evaluating anything.
+func (m *PopulationMonitor) StartGenerational(initial []model.Genome) (*GenerationalRun, error) {
+	if m.cfg.EvolutionType != EvolutionTypeGenerational {
+		return nil, errors.New("stepping requires generational evolution")
+	}
+	m.cfg.PopulationSize = m.basePopulation
+	if m.cfg.Resume == nil && len(initial) != m.cfg.PopulationSize {
+		return nil, fmt.Errorf("initial population mismatch: got=%d want=%d", len(initial), m.cfg.PopulationSize)
+	}
+	m.resetRunState()
+	if m.cfg.Resume != nil {
+		return m.resumeGenerational(*m.cfg.Resume)
+	}
+
+	population := m.markInnovations(initial)
+	run := &GenerationalRun{
+		m:                    m,
+		population:           population,
+		bestHistory:          make([]float64, 0, m.cfg.Generations),
+		diagnostics:          make([]GenerationDiagnostics, 0, m.cfg.Generations),
+		speciesHistory:       make([]SpeciesGeneration, 0, m.cfg.Generations),
+		traceAcc:             make([]TraceGeneration, 0, m.cfg.Generations),
+		lineage:              make([]LineageRecord, 0, len(initial)*(m.cfg.Generations+1)),
+		prevSpeciesSet:       map[string]struct{}{},
+		evoHistoryByGenomeID: initializeEvoHistoryByGenomeID(population),
+	}
 	for _, genome := range population {
 		sig := ComputeGenomeSignature(genome)
 		operation := "seed"
 		if m.cfg.GenerationOffset > 0 {
 			operation = "continue_seed"
 		}
-		lineage = append(lineage, LineageRecord{
+		run.lineage = append(run.lineage, LineageRecord{
 			GenomeID:    genome.ID,
 			ParentID:    "",
 			Generation:  m.cfg.GenerationOffset,
@@ -391,90 +839,180 @@ func (m *PopulationMonitor) Run(ctx context.Context, initial []model.Genome) (Ru
 			Summary:     sig.Summary,
 		})
 	}
-	var scored []ScoredGenome
+	return run, nil
+}
 
-	for gen := 0; gen < m.cfg.Generations; gen++ {
-		if err := ctx.Err(); err != nil {
-			return RunResult{}, err
-		}
-		if m.stopRequested {
-			break
-		}
-		stop, err := m.applyControl(ctx, false)
-		if err != nil {
-			return RunResult{}, err
-		}
-		if stop {
-			break
-		}
+// Done reports whether the run has finished: all generations ran, a stop
+// condition or command ended it, or a non-GT mode evaluated its one
+// generation.
+func (r *GenerationalRun) Done() bool {
+	return r.done
+}
 
-		logicalGeneration := m.cfg.GenerationOffset + gen
-		var tuningStats tuningGenerationStats
-		var countedEvaluations []bool
-		scored, tuningStats, countedEvaluations, err = m.evaluatePopulation(ctx, population, logicalGeneration)
-		if err != nil {
-			return RunResult{}, err
-		}
-		if m.cfg.OpMode == OpModeGT {
-			scored = m.cfg.Postprocessor.Process(scored)
-		}
+// Scored returns the most recently evaluated generation, best first.
+func (r *GenerationalRun) Scored() []ScoredGenome {
+	return r.scored
+}
 
-		sort.Slice(scored, func(i, j int) bool {
-			return scored[i].Fitness > scored[j].Fitness
-		})
-		m.totalEvaluations += countTrue(countedEvaluations)
-		bestHistory = append(bestHistory, scored[0].Fitness)
-		speciesByGenomeID, speciationStats := m.assignSpecies(scored, evoHistoryByGenomeID)
-		generationDiagnostics := summarizeGeneration(scored, logicalGeneration+1, speciationStats, tuningStats)
-		diagnostics = append(diagnostics, generationDiagnostics)
-		m.recordGenerationDiagnostics(generationDiagnostics)
-		m.accumulateStepWindow(scored, speciesByGenomeID, countedEvaluations)
-		if err := m.captureTraceSpecies(ctx, scored, speciesByGenomeID); err != nil {
-			return RunResult{}, err
-		}
-		m.emitStepTraceUpdates()
-		history, currentSet := summarizeSpeciesGeneration(scored, speciesByGenomeID, logicalGeneration+1, prevSpeciesSet)
-		speciesHistory = append(speciesHistory, history)
-		traceAcc = append(traceAcc, buildTraceGeneration(logicalGeneration+1, scored, speciesByGenomeID, m.lastTraceSpecies))
-		prevSpeciesSet = currentSet
-		if m.cfg.OpMode != OpModeGT {
-			break
-		}
-		if m.stopRequested {
-			break
-		}
-		if (m.cfg.FitnessGoal > 0 && scored[0].Fitness >= m.cfg.FitnessGoal) ||
-			(m.cfg.EvaluationsLimit > 0 && m.totalEvaluations >= m.cfg.EvaluationsLimit) ||
-			m.goalReached {
-			break
-		}
-		stop, err = m.applyControl(ctx, true)
-		if err != nil {
-			return RunResult{}, err
-		}
-		if stop {
-			break
-		}
+// Diagnostics returns the diagnostics of every evaluated generation.
+func (r *GenerationalRun) Diagnostics() []GenerationDiagnostics {
+	return r.diagnostics
+}
 
-		var generationLineage []LineageRecord
-		population, generationLineage, err = m.nextGeneration(ctx, scored, speciesByGenomeID, logicalGeneration)
-		if err != nil {
-			return RunResult{}, err
+// TotalEvaluations counts the evaluations spent so far.
+func (r *GenerationalRun) TotalEvaluations() int {
+	return r.m.totalEvaluations
+}
+
+// Step evaluates one generation and, unless the run ends with it, breeds
+// the next. Control commands are honoured between generations as in Run.
+// Stepping a finished run does nothing.
+func (r *GenerationalRun) Step(ctx context.Context) error {
+	if r.done {
+		return nil
+	}
+	m := r.m
+	if m.cfg.EnvSeed != nil {
+		ctx = scape.WithEnvSeed(ctx, *m.cfg.EnvSeed)
+	}
+	if r.gen >= m.cfg.Generations {
+		r.done = true
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if m.stopRequested {
+		r.done = true
+		return nil
+	}
+	stop, err := m.applyControl(ctx, false)
+	if err != nil {
+		return err
+	}
+	if stop {
+		r.done = true
+		return nil
+	}
+
+	gen := r.gen
+	r.gen++
+	logicalGeneration := m.cfg.GenerationOffset + gen
+	m.events.generation = logicalGeneration + 1
+	genCtx, paramChanges := m.beginGeneration(ctx)
+	m.recordCurriculum(logicalGeneration+1, paramChanges)
+	m.assignIslands(r.population)
+	scored, tuningStats, countedEvaluations, err := m.evaluatePopulation(genCtx, r.population, logicalGeneration)
+	if err != nil {
+		return err
+	}
+	if m.cfg.OpMode == OpModeGT {
+		scored = m.cfg.Postprocessor.Process(scored)
+	}
+	r.scored = scored
+
+	m.rankScored(scored)
+	m.totalEvaluations += countTrue(countedEvaluations)
+	r.bestHistory = append(r.bestHistory, scored[0].Fitness)
+	speciesByGenomeID, speciationStats := m.assignSpecies(scored, r.evoHistoryByGenomeID)
+	generationDiagnostics := summarizeGeneration(scored, logicalGeneration+1, speciationStats, tuningStats)
+	generationDiagnostics.ScapeParamChanges = paramChanges
+	if m.cfg.VerifyInvariants {
+		if err := m.checkScoredInvariants(logicalGeneration+1, scored, speciesByGenomeID, generationDiagnostics.SpeciesCount, len(paramChanges) > 0); err != nil {
+			return err
+		}
+	}
+	generationDiagnostics.FidelityFinalists = m.generationFidelity.Finalists
+	generationDiagnostics.FidelityOffset = m.generationFidelity.Offset
+	generationDiagnostics.IslandBest = m.islandBests(scored)
+	m.annotateWeightStats(&generationDiagnostics, scored)
+	m.annotateStrategies(&generationDiagnostics, scored)
+	generationDiagnostics.ClampEvents = totalClampEvents(scored)
+	generationDiagnostics.EvaluationCost = SummarizeEvaluationCost(scored)
+	m.annotateCrossoverOutcomes(&generationDiagnostics, scored)
+	m.recordKarma(&generationDiagnostics, scored, logicalGeneration)
+	m.detectEntropy(&generationDiagnostics, scored, logicalGeneration)
+	m.annotateProgress(&generationDiagnostics, gen+1)
+	r.diagnostics = append(r.diagnostics, generationDiagnostics)
+	m.recordGenerationDiagnostics(generationDiagnostics)
+	m.accumulateStepWindow(scored, speciesByGenomeID, countedEvaluations)
+	if err := m.captureTraceSpecies(genCtx, scored, speciesByGenomeID); err != nil {
+		return err
+	}
+	m.emitStepTraceUpdates()
+	history, currentSet := summarizeSpeciesGeneration(scored, speciesByGenomeID, logicalGeneration+1, r.prevSpeciesSet)
+	r.speciesHistory = append(r.speciesHistory, history)
+	m.emitSpeciesGeneration(history)
+	m.recordGenerationEvents(scored, history)
+	r.traceAcc = append(r.traceAcc, m.traceGeneration(logicalGeneration+1, scored, speciesByGenomeID))
+	m.emitTraceGeneration(r.traceAcc[len(r.traceAcc)-1])
+	r.prevSpeciesSet = currentSet
+	if err := m.saveSnapshot(ctx, logicalGeneration+1, scored); err != nil {
+		return err
+	}
+	if m.cfg.OpMode != OpModeGT || m.stopRequested || m.shouldStop(generationDiagnostics) {
+		r.done = true
+		return nil
+	}
+	stop, err = m.applyControl(ctx, true)
+	if err != nil {
+		return err
+	}
+	if stop {
+		r.done = true
+		return nil
+	}
+
+	m.resizePopulation(&r.diagnostics[len(r.diagnostics)-1])
+	m.beginEntropyReproduction(logicalGeneration)
+	m.lastAllocation = nil
+	m.lastRebalancing = nil
+	m.lastMigrants = 0
+	population, generationLineage, err := m.nextGeneration(ctx, scored, speciesByGenomeID, logicalGeneration)
+	if err != nil {
+		return err
+	}
+	r.diagnostics[len(r.diagnostics)-1].SpeciesAllocation = m.lastAllocation
+	r.diagnostics[len(r.diagnostics)-1].SpeciesRebalancing = m.lastRebalancing
+	r.diagnostics[len(r.diagnostics)-1].Migrants = m.lastMigrants
+	population, generationLineage, err = m.finishEntropyReproduction(population, generationLineage, logicalGeneration)
+	if err != nil {
+		return err
+	}
+	if m.cfg.VerifyInvariants {
+		if err := m.checkNextGenerationInvariants(logicalGeneration+1, scored, population); err != nil {
+			return err
 		}
-		lineage = append(lineage, generationLineage...)
-		evoHistoryByGenomeID = evolveHistoryByGenomeID(population, generationLineage, evoHistoryByGenomeID)
 	}
+	stampProvenance(population, generationLineage)
+	m.recordStagnation(logicalGeneration + 1)
+	r.population = population
+	r.lineage = append(r.lineage, generationLineage...)
+	r.evoHistoryByGenomeID = evolveHistoryByGenomeID(population, generationLineage, r.evoHistoryByGenomeID)
+	if r.gen >= m.cfg.Generations {
+		r.done = true
+	}
+	return r.saveCheckpoint(ctx)
+}
 
-	result := RunResult{
-		BestByGeneration:      bestHistory,
-		GenerationDiagnostics: diagnostics,
-		SpeciesHistory:        speciesHistory,
-		TraceAcc:              traceAcc,
-		FinalPopulation:       scored,
-		Lineage:               lineage,
+// Result reports the run so far; once the run is done the first call also
+// emits the completed trace update.
+func (r *GenerationalRun) Result() RunResult {
+	if r.done && !r.completed {
+		r.completed = true
+		r.m.emitTraceUpdate(TraceUpdateReasonCompleted, r.m.totalEvaluations)
+	}
+	return RunResult{
+		BestByGeneration:      r.bestHistory,
+		GenerationDiagnostics: r.diagnostics,
+		SpeciesHistory:        r.speciesHistory,
+		TraceAcc:              r.traceAcc,
+		FinalPopulation:       r.scored,
+		Lineage:               r.lineage,
+		Events:                r.m.events.events,
+		TuningTraces:          r.m.tuningTraces,
+		Innovations:           r.m.innovations.Records(),
 	}
-	m.emitTraceUpdate(TraceUpdateReasonCompleted, m.totalEvaluations)
-	return result, nil
 }
 
 func (m *PopulationMonitor) runSteadyState(ctx context.Context, initial []model.Genome) (RunResult, error) {
@@ -522,7 +1060,10 @@ func (m *PopulationMonitor) runSteadyState(ctx context.Context, initial []model.
 		}
 
 		logicalGeneration := m.cfg.GenerationOffset + gen
-		scored, tuningStats, countedEvaluations, err := m.evaluatePopulation(ctx, population, logicalGeneration)
+		m.events.generation = logicalGeneration + 1
+		genCtx, paramChanges := m.beginGeneration(ctx)
+		m.recordCurriculum(logicalGeneration+1, paramChanges)
+		scored, tuningStats, countedEvaluations, err := m.evaluatePopulation(genCtx, population, logicalGeneration)
 		if err != nil {
 			return RunResult{}, err
 		}
@@ -531,24 +1072,36 @@ func (m *PopulationMonitor) runSteadyState(ctx context.Context, initial []model.
 		}
 
 		ranked := append([]ScoredGenome(nil), scored...)
-		sort.Slice(ranked, func(i, j int) bool {
-			return ranked[i].Fitness > ranked[j].Fitness
-		})
+		m.rankScored(ranked)
 		finalScored = ranked
 		m.totalEvaluations += countTrue(countedEvaluations)
 		bestHistory = append(bestHistory, ranked[0].Fitness)
 		speciesByGenomeID, speciationStats := m.assignSpecies(ranked, evoHistoryByGenomeID)
 		generationDiagnostics := summarizeGeneration(ranked, logicalGeneration+1, speciationStats, tuningStats)
+		generationDiagnostics.ScapeParamChanges = paramChanges
+		generationDiagnostics.FidelityFinalists = m.generationFidelity.Finalists
+		generationDiagnostics.FidelityOffset = m.generationFidelity.Offset
+		m.annotateWeightStats(&generationDiagnostics, ranked)
+		m.annotateStrategies(&generationDiagnostics, ranked)
+		generationDiagnostics.ClampEvents = totalClampEvents(ranked)
+		generationDiagnostics.EvaluationCost = SummarizeEvaluationCost(ranked)
+		m.annotateCrossoverOutcomes(&generationDiagnostics, ranked)
+		m.recordKarma(&generationDiagnostics, ranked, logicalGeneration)
+		m.detectEntropy(&generationDiagnostics, ranked, logicalGeneration)
+		m.annotateProgress(&generationDiagnostics, gen+1)
 		diagnostics = append(diagnostics, generationDiagnostics)
 		m.recordGenerationDiagnostics(generationDiagnostics)
 		m.accumulateStepWindow(ranked, speciesByGenomeID, countedEvaluations)
-		if err := m.captureTraceSpecies(ctx, ranked, speciesByGenomeID); err != nil {
+		if err := m.captureTraceSpecies(genCtx, ranked, speciesByGenomeID); err != nil {
 			return RunResult{}, err
 		}
 		m.emitStepTraceUpdates()
 		history, currentSet := summarizeSpeciesGeneration(ranked, speciesByGenomeID, logicalGeneration+1, prevSpeciesSet)
 		speciesHistory = append(speciesHistory, history)
-		traceAcc = append(traceAcc, buildTraceGeneration(logicalGeneration+1, ranked, speciesByGenomeID, m.lastTraceSpecies))
+		m.emitSpeciesGeneration(history)
+		m.recordGenerationEvents(ranked, history)
+		traceAcc = append(traceAcc, m.traceGeneration(logicalGeneration+1, ranked, speciesByGenomeID))
+		m.emitTraceGeneration(traceAcc[len(traceAcc)-1])
 		prevSpeciesSet = currentSet
 
 		if m.cfg.OpMode != OpModeGT {
@@ -557,9 +1110,7 @@ func (m *PopulationMonitor) runSteadyState(ctx context.Context, initial []model.
 		if m.stopRequested {
 			break
 		}
-		if (m.cfg.FitnessGoal > 0 && ranked[0].Fitness >= m.cfg.FitnessGoal) ||
-			(m.cfg.EvaluationsLimit > 0 && m.totalEvaluations >= m.cfg.EvaluationsLimit) ||
-			m.goalReached {
+		if m.shouldStop(generationDiagnostics) {
 			break
 		}
 		stop, err = m.applyControl(ctx, true)
@@ -570,10 +1121,17 @@ func (m *PopulationMonitor) runSteadyState(ctx context.Context, initial []model.
 			break
 		}
 
+		m.beginEntropyReproduction(logicalGeneration)
 		nextPopulation, generationLineage, err := m.nextSteadyStatePopulation(ctx, ranked, speciesByGenomeID, logicalGeneration)
 		if err != nil {
 			return RunResult{}, err
 		}
+		nextPopulation, generationLineage, err = m.finishEntropyReproduction(nextPopulation, generationLineage, logicalGeneration)
+		if err != nil {
+			return RunResult{}, err
+		}
+		stampProvenance(nextPopulation, generationLineage)
+		m.recordStagnation(logicalGeneration + 1)
 		population = nextPopulation
 		lineage = append(lineage, generationLineage...)
 		evoHistoryByGenomeID = evolveHistoryByGenomeID(population, generationLineage, evoHistoryByGenomeID)
@@ -586,6 +1144,9 @@ func (m *PopulationMonitor) runSteadyState(ctx context.Context, initial []model.
 		TraceAcc:              traceAcc,
 		FinalPopulation:       finalScored,
 		Lineage:               lineage,
+		Events:                m.events.events,
+		TuningTraces:          m.tuningTraces,
+		Innovations:           m.innovations.Records(),
 	}
 	m.emitTraceUpdate(TraceUpdateReasonCompleted, m.totalEvaluations)
 	return result, nil
@@ -597,6 +1158,7 @@ func (m *PopulationMonitor) nextSteadyStatePopulation(
 	speciesByGenomeID map[string]string,
 	generation int,
 ) ([]model.Genome, []LineageRecord, error) {
+	defer m.phases.reproduced(time.Now())
 	if len(ranked) == 0 {
 		return nil, nil, fmt.Errorf("steady-state population is empty")
 	}
@@ -607,6 +1169,7 @@ func (m *PopulationMonitor) nextSteadyStatePopulation(
 			parentPool = ranked
 		}
 	}
+	parentPool = m.excludeBannedParents(parentPool)
 
 	next := make([]model.Genome, len(ranked))
 	for i, item := range ranked {
@@ -629,7 +1192,8 @@ func (m *PopulationMonitor) nextSteadyStatePopulation(
 	if err != nil {
 		return nil, nil, err
 	}
-	child, record, err := m.mutateFromParent(ctx, parent, generation, replacementIndex)
+	m.crossoverOffspring = map[string]string{}
+	child, record, err := m.reproduce(ctx, parentPool, speciesByGenomeID, parent, generation, replacementIndex)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -693,6 +1257,7 @@ type monitorCommandAction struct {
 }
 
 func (m *PopulationMonitor) handleCommand(cmd MonitorCommand) monitorCommandAction {
+	m.recordMonitorAction(cmd)
 	switch cmd {
 	case CommandPause:
 		m.paused = true
@@ -707,25 +1272,132 @@ func (m *PopulationMonitor) handleCommand(cmd MonitorCommand) monitorCommandActi
 		m.paused = false
 	case CommandPrintTrace:
 		return monitorCommandAction{printTrace: true}
+	default:
+		if name, value, ok := parseSetScapeParamCommand(cmd); ok {
+			m.queueScapeParam(name, value)
+		}
 	}
 	return monitorCommandAction{}
 }
 
+// shouldStop reports whether the run ends after the generation summarized by
+// diag: a goal_reached command, the configured stop condition, or without
+// one the fitness goal or evaluations limit.
+func (m *PopulationMonitor) shouldStop(diag GenerationDiagnostics) bool {
+	if !m.stopHasBest || diag.BestFitness > m.stopBest {
+		m.stopBest = diag.BestFitness
+		m.stopHasBest = true
+		m.stopStagnation = 0
+	} else {
+		m.stopStagnation++
+	}
+	if m.goalReached {
+		return true
+	}
+	if m.stopCondition != nil {
+		return m.stopCondition.Met(StopState{
+			Best:           diag.BestFitness,
+			Mean:           diag.MeanFitness,
+			Evaluations:    m.totalEvaluations,
+			Generation:     diag.Generation,
+			Stagnation:     m.stopStagnation,
+			Species:        diag.SpeciesCount,
+			ElapsedSeconds: time.Since(m.runStartedAt).Seconds(),
+		})
+	}
+	return (m.cfg.FitnessGoal > 0 && diag.BestFitness >= m.cfg.FitnessGoal) ||
+		(m.cfg.EvaluationsLimit > 0 && m.totalEvaluations >= m.cfg.EvaluationsLimit)
+}
+
 func (m *PopulationMonitor) resetRunState() {
 	m.paused = false
 	m.stopRequested = false
 	m.goalReached = false
 	m.totalEvaluations = 0
+	m.invariantElites = nil
 	m.resetStepWindow()
 	m.lastTraceSpecies = nil
 	m.lastDiagnostics = GenerationDiagnostics{}
 	m.hasDiagnostics = false
 	m.nextTraceEvaluation = m.cfg.TraceStepSize
+	m.ages = map[string]int{}
+	m.runStartedAt = time.Now()
+	m.lastProgressAt = m.runStartedAt
+	m.cappedSpecies = map[string]bool{}
+	m.phases = newPhaseClock(m.runStartedAt)
+	m.lastPoolWait = 0
+	if m.cfg.ScapePool != nil {
+		m.lastPoolWait, _ = m.cfg.ScapePool.Wait()
+	}
+	m.scapeParams = nil
+	m.pendingScapeParams = nil
+	m.crossoverOffspring = map[string]string{}
+	m.karma = newKarmaLedger()
+	m.events = newEventLog(m.cfg.GenerationOffset)
+	m.stopBest = 0
+	m.stopHasBest = false
+	m.stopStagnation = 0
+	m.entropy = entropyDetector{}
+	m.tuningTraces = nil
+	m.innovations = nil
+	if m.cfg.TrackInnovations {
+		m.innovations = genotype.NewInnovationRegistry(m.cfg.Innovations)
+	}
+	if policy, ok := m.cfg.TuneAttemptPolicy.(tuning.CostAwareAttemptPolicy); ok {
+		policy.BeginRun(m.cfg.Workers)
+	}
+	if reporter, ok := m.cfg.Selector.(StagnationReporter); ok {
+		reporter.DrainStagnantSpecies()
+	}
 }
 
 func (m *PopulationMonitor) recordGenerationDiagnostics(diag GenerationDiagnostics) {
 	m.lastDiagnostics = diag
 	m.hasDiagnostics = true
+	if m.cfg.ProgressHook != nil {
+		defer m.phases.persisted(time.Now())
+		m.cfg.ProgressHook(diag)
+	}
+}
+
+// annotateProgress fills the wall-clock, throughput and ETA fields for the
+// completed-th generation of this run.
+func (m *PopulationMonitor) annotateProgress(diag *GenerationDiagnostics, completed int) {
+	now := time.Now()
+	diag.WallClockSeconds = now.Sub(m.lastProgressAt).Seconds()
+	m.lastProgressAt = now
+	diag.TotalEvaluations = m.totalEvaluations
+	elapsed := now.Sub(m.runStartedAt).Seconds()
+	if elapsed > 0 {
+		diag.EvaluationsPerSecond = float64(m.totalEvaluations) / elapsed
+	}
+	diag.ETASeconds = estimateRemainingSeconds(elapsed, completed, m.cfg.Generations, m.totalEvaluations, m.cfg.EvaluationsLimit)
+	if m.cfg.ScapePool != nil {
+		wait, _ := m.cfg.ScapePool.Wait()
+		diag.ScapePoolWaitSeconds = (wait - m.lastPoolWait).Seconds()
+		m.lastPoolWait = wait
+	}
+}
+
+// estimateRemainingSeconds projects the time until the run stops at whichever
+// of the generation or evaluation limits comes first, assuming the average
+// pace so far holds.
+func estimateRemainingSeconds(elapsed float64, completed, generations, evaluations, evaluationsLimit int) float64 {
+	if completed <= 0 || elapsed <= 0 {
+		return 0
+	}
+	eta := math.Inf(1)
+	if generations > 0 {
+		eta = elapsed / float64(completed) * float64(max(0, generations-completed))
+	}
+	if evaluationsLimit > 0 && evaluations > 0 {
+		evalETA := elapsed / float64(evaluations) * float64(max(0, evaluationsLimit-evaluations))
+		eta = math.Min(eta, evalETA)
+	}
+	if math.IsInf(eta, 1) {
+		return 0
+	}
+	return eta
 }
 
 func (m *PopulationMonitor) emitStepTraceUpdates() {
@@ -834,6 +1506,7 @@ func (m *PopulationMonitor) captureTraceSpecies(ctx context.Context, scored []Sc
 			ChampionGenomeID: bucket.champion.ID,
 		}
 		if m.cfg.OpMode == OpModeGT && (m.cfg.ValidationProbe || m.cfg.TestProbe) {
+			probeStart := time.Now()
 			runValidation := m.cfg.ValidationProbe
 			runTest := m.cfg.TestProbe || runValidation
 			if m.cfg.ValidationProbe {
@@ -855,6 +1528,7 @@ func (m *PopulationMonitor) captureTraceSpecies(ctx context.Context, scored []Sc
 			if !runValidation {
 				entry.ValidationFitness = nil
 			}
+			m.phases.evaluation += time.Since(probeStart)
 		}
 		out = append(out, entry)
 	}
@@ -883,9 +1557,26 @@ func (m *PopulationMonitor) emitTraceUpdate(reason TraceUpdateReason, totalEvalu
 	if m.hasDiagnostics {
 		update.Diagnostics = m.lastDiagnostics
 	}
+	defer m.phases.persisted(time.Now())
 	m.cfg.TraceUpdateHook(update)
 }
 
+func (m *PopulationMonitor) emitTraceGeneration(generation TraceGeneration) {
+	if m.cfg.TraceGenerationHook == nil {
+		return
+	}
+	defer m.phases.persisted(time.Now())
+	m.cfg.TraceGenerationHook(generation)
+}
+
+func (m *PopulationMonitor) emitSpeciesGeneration(generation SpeciesGeneration) {
+	if m.cfg.SpeciesHook == nil {
+		return
+	}
+	defer m.phases.persisted(time.Now())
+	m.cfg.SpeciesHook(generation)
+}
+
 func cloneSpeciesEvaluationCounts(in map[string]int) map[string]int {
 	out := make(map[string]int, len(in))
 	for key, value := range in {
@@ -1024,6 +1715,8 @@ func summarizeGeneration(scored []ScoredGenome, generation int, speciationStats
 			TuningGoalHits:        tuningStats.GoalHits,
 			TuningAcceptRate:      acceptRate,
 			TuningEvalsPerAttempt: evalsPerAttempt,
+			EvalCacheHits:         tuningStats.CacheHits,
+			EvalCacheMisses:       tuningStats.CacheMisses,
 		}
 	}
 
@@ -1058,6 +1751,8 @@ func summarizeGeneration(scored []ScoredGenome, generation int, speciationStats
 		TuningGoalHits:        tuningStats.GoalHits,
 		TuningAcceptRate:      acceptRate,
 		TuningEvalsPerAttempt: evalsPerAttempt,
+		EvalCacheHits:         tuningStats.CacheHits,
+		EvalCacheMisses:       tuningStats.CacheMisses,
 	}
 }
 
@@ -1097,6 +1792,7 @@ func countTrue(values []bool) int {
 }
 
 func (m *PopulationMonitor) assignSpecies(scored []ScoredGenome, evoHistoryByGenomeID map[string][]genotype.EvoHistoryEvent) (map[string]string, SpeciationStats) {
+	defer m.phases.speciated(time.Now())
 	genomes := make([]model.Genome, 0, len(scored))
 	for _, item := range scored {
 		genomes = append(genomes, item.Genome)
@@ -1608,6 +2304,14 @@ func summarizeStaticSpeciation(bySpecies map[string][]model.Genome) SpeciationSt
 }
 
 func (m *PopulationMonitor) evaluatePopulation(ctx context.Context, population []model.Genome, generation int) ([]ScoredGenome, tuningGenerationStats, []bool, error) {
+	m.generationFidelity = fidelityGenerationStats{}
+	if m.multiFidelityEnabled() {
+		return m.evaluatePopulationMultiFidelity(ctx, population, generation)
+	}
+	return m.evaluatePopulationStage(ctx, population, generation, true)
+}
+
+func (m *PopulationMonitor) evaluatePopulationStage(ctx context.Context, population []model.Genome, generation int, allowTuning bool) ([]ScoredGenome, tuningGenerationStats, []bool, error) {
 	type job struct {
 		idx    int
 		genome model.Genome
@@ -1616,9 +2320,17 @@ func (m *PopulationMonitor) evaluatePopulation(ctx context.Context, population [
 		idx    int
 		scored ScoredGenome
 		tune   tuning.TuneReport
+		cache  nn.BatchCacheStats
 		err    error
+		// busy is the job's worker time and tuned the part of it spent
+		// tuning.
+		busy  time.Duration
+		tuned time.Duration
 	}
 
+	stageStart := time.Now()
+	persistedBefore := m.phases.persistence
+	var evaluationWork, tuningWork time.Duration
 	jobs := make(chan job)
 	results := make(chan result, len(population))
 
@@ -1637,25 +2349,45 @@ func (m *PopulationMonitor) evaluatePopulation(ctx context.Context, population [
 					results <- result{idx: j.idx, err: err}
 					continue
 				}
+				jobStart := time.Now()
+				evalCtx := ctx
+				if m.cfg.CommonRandomNumbers {
+					evalCtx = scape.WithNoiseSlot(ctx, generation, j.idx)
+				}
+				var meter *evaluationMeter
+				if m.cfg.MeterEvaluations {
+					meter = &evaluationMeter{}
+					evalCtx = withEvaluationMeter(evalCtx, meter)
+				}
 
 				candidate := j.genome
 				tuneReport := tuning.TuneReport{}
+				var tuned time.Duration
 				attempts := m.cfg.TuneAttempts
 				if m.cfg.TuneAttemptPolicy != nil {
 					attempts = m.cfg.TuneAttemptPolicy.Attempts(m.cfg.TuneAttempts, generation, m.cfg.Generations, j.genome)
 				}
-				if m.cfg.OpMode == OpModeGT && m.cfg.Tuner != nil && attempts > 0 {
-					if runtimeTuner, ok := m.cfg.Tuner.(tuning.RuntimeReportingTuner); ok && len(j.genome.Synapses) > 0 {
-						scoredRuntime, runtimeReport, err := m.evaluateGenomeWithRuntimeTuning(ctx, j.genome, attempts, runtimeTuner)
+				var evalCache *nn.BatchCache
+				if allowTuning && m.cfg.OpMode == OpModeGT && m.cfg.Tuner != nil && attempts > 0 && m.evalCacheEnabled() {
+					evalCache = nn.NewBatchCache(0)
+					evalCtx = nn.WithBatchCache(evalCtx, evalCache)
+				}
+				if allowTuning && m.cfg.OpMode == OpModeGT && m.cfg.Tuner != nil && attempts > 0 {
+					tuneStart := time.Now()
+					if runtimeTuner, ok := m.cfg.Tuner.(tuning.RuntimeReportingTuner); ok && len(j.genome.Synapses) > 0 && m.encoding().Direct() {
+						scoredRuntime, runtimeReport, err := m.evaluateGenomeWithRuntimeTuning(evalCtx, j.genome, attempts, runtimeTuner)
 						if err != nil {
 							results <- result{idx: j.idx, err: err}
 							continue
 						}
-						results <- result{idx: j.idx, scored: scoredRuntime, tune: runtimeReport}
+						m.observeTuningCost(runtimeReport, time.Since(tuneStart))
+						scoredRuntime.Cost = meter.Cost()
+						busy := time.Since(jobStart)
+						results <- result{idx: j.idx, scored: scoredRuntime, tune: runtimeReport, cache: evalCache.Stats(), busy: busy, tuned: busy}
 						continue
 					}
 					if reporting, ok := m.cfg.Tuner.(tuning.ReportingTuner); ok {
-						tuned, report, err := reporting.TuneWithReport(ctx, j.genome, attempts, func(ctx context.Context, g model.Genome) (float64, error) {
+						tunedGenome, report, err := reporting.TuneWithReport(evalCtx, j.genome, attempts, func(ctx context.Context, g model.Genome) (float64, error) {
 							fitness, _, err := m.evaluateGenome(ctx, g, OpModeGT)
 							if err != nil {
 								return 0, err
@@ -1667,9 +2399,10 @@ func (m *PopulationMonitor) evaluatePopulation(ctx context.Context, population [
 							results <- result{idx: j.idx, err: err}
 							continue
 						}
-						candidate = tuned
+						m.observeTuningCost(report, time.Since(tuneStart))
+						candidate = tunedGenome
 					} else {
-						tuned, err := m.cfg.Tuner.Tune(ctx, j.genome, attempts, func(ctx context.Context, g model.Genome) (float64, error) {
+						tunedGenome, err := m.cfg.Tuner.Tune(evalCtx, j.genome, attempts, func(ctx context.Context, g model.Genome) (float64, error) {
 							fitness, _, err := m.evaluateGenome(ctx, g, OpModeGT)
 							if err != nil {
 								return 0, err
@@ -1682,16 +2415,18 @@ func (m *PopulationMonitor) evaluatePopulation(ctx context.Context, population [
 						}
 						tuneReport.AttemptsPlanned = attempts
 						tuneReport.AttemptsExecuted = attempts
-						candidate = tuned
+						candidate = tunedGenome
 					}
+					tuned = time.Since(tuneStart)
 				}
 
-				fitness, trace, err := m.evaluateGenome(ctx, candidate, m.cfg.OpMode)
+				scoredGenome, err := m.evaluateGenomeTrials(evalCtx, candidate, m.cfg.OpMode)
 				if err != nil {
 					results <- result{idx: j.idx, err: err}
 					continue
 				}
-				results <- result{idx: j.idx, scored: ScoredGenome{Genome: candidate, Fitness: fitness, Trace: trace}, tune: tuneReport}
+				scoredGenome.Cost = meter.Cost()
+				results <- result{idx: j.idx, scored: scoredGenome, tune: tuneReport, cache: evalCache.Stats(), busy: time.Since(jobStart), tuned: tuned}
 			}
 		}()
 	}
@@ -1705,6 +2440,7 @@ func (m *PopulationMonitor) evaluatePopulation(ctx context.Context, population [
 	countedEvaluations := make([]bool, len(population))
 	shouldCountEvaluations := !m.goalReached
 	tuningStats := tuningGenerationStats{}
+	traces := make([]model.TuningTrace, len(population))
 	control := m.cfg.Control
 	for received := 0; received < len(population); received++ {
 		if m.goalReached {
@@ -1730,9 +2466,16 @@ func (m *PopulationMonitor) evaluatePopulation(ctx context.Context, population [
 				}
 			}
 		}
+		if reason := m.degenerateReason(ctx, res.err); reason != "" {
+			res.scored = ScoredGenome{Genome: population[res.idx], Degenerate: reason}
+			res.err = nil
+		}
 		if res.err != nil {
 			return nil, tuningGenerationStats{}, nil, res.err
 		}
+		if res.tune.AttemptsExecuted > 0 && res.scored.Degenerate == "" {
+			res.scored.Genome = withTuningSession(res.scored.Genome, tuningSessionID(generation, res.scored.Genome.ID))
+		}
 		scored[res.idx] = res.scored
 		if shouldCountEvaluations {
 			countedEvaluations[res.idx] = true
@@ -1747,12 +2490,50 @@ func (m *PopulationMonitor) evaluatePopulation(ctx context.Context, population [
 		if res.tune.GoalReached {
 			tuningStats.GoalHits++
 		}
+		tuningStats.CacheHits += res.cache.Hits
+		tuningStats.CacheMisses += res.cache.Misses
+		evaluationWork += res.busy - res.tuned
+		tuningWork += res.tuned
+		if len(res.tune.Trace) > 0 {
+			traces[res.idx] = model.TuningTrace{
+				Generation: generation + 1,
+				GenomeID:   res.scored.Genome.ID,
+				SessionID:  tuningSessionID(generation, res.scored.Genome.ID),
+				Selection:  res.tune.Selection,
+				Candidates: res.tune.Trace,
+			}
+		}
 	}
 	wg.Wait()
+	m.phases.addEvaluation(time.Since(stageStart)-(m.phases.persistence-persistedBefore), evaluationWork, tuningWork)
+	m.settleDegenerate(scored)
+	for _, trace := range traces {
+		if len(trace.Candidates) > 0 {
+			m.tuningTraces = append(m.tuningTraces, trace)
+		}
+	}
 
 	return scored, tuningStats, countedEvaluations, nil
 }
 
+// observeTuningCost feeds a tuning session's measured cost to attempt
+// policies that adapt to it.
+func (m *PopulationMonitor) observeTuningCost(report tuning.TuneReport, elapsed time.Duration) {
+	if policy, ok := m.cfg.TuneAttemptPolicy.(tuning.CostAwareAttemptPolicy); ok {
+		policy.ObserveTuning(report.AttemptsPlanned, report.CandidateEvaluations+report.ValidationEvaluations, elapsed)
+	}
+}
+
+// evalCacheEnabled reports whether tuning jobs memoize neuron columns: the
+// scape must take the batched dataset path, where rows are fixed per mode.
+func (m *PopulationMonitor) evalCacheEnabled() bool {
+	if m.cfg.DisableEvalCache || m.cfg.DisableBatchEval {
+		return false
+	}
+	_, ok := m.cfg.Scape.(scape.BatchScape)
+	return ok
+}
+
 func (m *PopulationMonitor) evaluateGenomeWithRuntimeTuning(
 	ctx context.Context,
 	genome model.Genome,
@@ -1798,6 +2579,7 @@ func (m *PopulationMonitor) evaluateGenomeWithRuntimeTuning(
 		Genome:  runtimeResult.Genome,
 		Fitness: fitness,
 		Trace:   trace,
+		Clamps:  cortex.ClampCounts(),
 	}, runtimeResult.Report, nil
 }
 
@@ -1824,11 +2606,121 @@ func (m *PopulationMonitor) applyQueuedControl(ctx context.Context) error {
 }
 
 func (m *PopulationMonitor) evaluateGenome(ctx context.Context, genome model.Genome, mode string) (float64, scape.Trace, error) {
+	fitness, trace, _, err := m.evaluateGenomeGuarded(ctx, genome, mode)
+	return fitness, trace, err
+}
+
+// evaluateGenomeGuarded is evaluateGenome that also reports the numeric guard
+// interventions made during the evaluation.
+func (m *PopulationMonitor) evaluateGenomeGuarded(ctx context.Context, genome model.Genome, mode string) (float64, scape.Trace, nn.ClampCounts, error) {
 	cortex, err := m.buildCortex(genome)
 	if err != nil {
-		return 0, nil, err
+		return 0, nil, nn.ClampCounts{}, err
+	}
+	fitness, trace, err := m.evaluateCortex(ctx, cortex, mode)
+	if err != nil {
+		return 0, nil, nn.ClampCounts{}, err
+	}
+	return fitness, trace, cortex.ClampCounts(), nil
+}
+
+// evaluateGenomeTrials scores genome over EvaluationTrials repeated trials,
+// tagging each with its trial index so stochastic scapes vary the episode.
+func (m *PopulationMonitor) evaluateGenomeTrials(ctx context.Context, genome model.Genome, mode string) (ScoredGenome, error) {
+	if m.cfg.EvaluationTrials <= 1 {
+		fitness, trace, clamps, err := m.evaluateGenomeGuarded(ctx, genome, mode)
+		if err != nil {
+			return ScoredGenome{}, err
+		}
+		return ScoredGenome{Genome: genome, Fitness: fitness, Trace: trace, Clamps: clamps}, nil
+	}
+	samples := make([]float64, m.cfg.EvaluationTrials)
+	var (
+		firstTrace scape.Trace
+		clamps     nn.ClampCounts
+	)
+	for trial := range samples {
+		fitness, trace, trialClamps, err := m.evaluateGenomeGuarded(scape.WithTrial(ctx, trial), genome, mode)
+		if err != nil {
+			return ScoredGenome{}, err
+		}
+		samples[trial] = fitness
+		clamps.Add(trialClamps)
+		if trial == 0 {
+			firstTrace = trace
+		}
+	}
+	ci := stats.BootstrapMeanCI(samples, bootstrapResamples, bootstrapConfidence, rand.New(rand.NewSource(trialBootstrapSeed(m.cfg.Seed, genome.ID))))
+	fitness, err := AggregateTrialFitness(samples, m.cfg.TrialAggregation, m.cfg.CVaRAlpha)
+	if err != nil {
+		return ScoredGenome{}, err
+	}
+	return ScoredGenome{Genome: genome, Fitness: fitness, Trace: firstTrace, FitnessCI: &ci, Clamps: clamps}, nil
+}
+
+const (
+	TrialAggregationMean  = "mean"
+	TrialAggregationCVaR  = "cvar"
+	TrialAggregationWorst = "worst"
+)
+
+// AggregateTrialFitness combines repeated trial fitness samples into a single
+// score. Risk-sensitive modes optimize for tail behavior instead of the mean.
+func AggregateTrialFitness(samples []float64, mode string, alpha float64) (float64, error) {
+	if len(samples) == 0 {
+		return 0, errors.New("no trial samples to aggregate")
+	}
+	switch mode {
+	case "", TrialAggregationMean:
+		return stats.CVaR(samples, 1), nil
+	case TrialAggregationCVaR:
+		if alpha <= 0 || alpha > 1 {
+			return 0, fmt.Errorf("cvar alpha must be in (0,1], got %g", alpha)
+		}
+		return stats.CVaR(samples, alpha), nil
+	case TrialAggregationWorst:
+		worst := samples[0]
+		for _, sample := range samples[1:] {
+			worst = math.Min(worst, sample)
+		}
+		return worst, nil
+	default:
+		return 0, fmt.Errorf("unsupported trial aggregation: %s", mode)
 	}
-	return m.evaluateCortex(ctx, cortex, mode)
+}
+
+const (
+	bootstrapResamples  = 1000
+	bootstrapConfidence = 0.95
+)
+
+func trialBootstrapSeed(seed int64, genomeID string) int64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(genomeID))
+	return seed ^ int64(hash.Sum64())
+}
+
+// rankScored orders genomes by descending fitness, breaking exact ties by the
+// bootstrap interval lower bound when CITieBreak is enabled and then by
+// genome ID, so the ranking is reproducible whatever order evaluations
+// finished in.
+func (m *PopulationMonitor) rankScored(scored []ScoredGenome) {
+	sort.SliceStable(scored, func(i, j int) bool {
+		if m.cfg.CITieBreak && scored[i].Fitness == scored[j].Fitness {
+			lowerI, lowerJ := fitnessLowerBound(scored[i]), fitnessLowerBound(scored[j])
+			if lowerI != lowerJ {
+				return lowerI > lowerJ
+			}
+		}
+		return ScoredBefore(scored[i], scored[j])
+	})
+}
+
+func fitnessLowerBound(scored ScoredGenome) float64 {
+	if scored.FitnessCI == nil {
+		return scored.Fitness
+	}
+	return scored.FitnessCI.Lower
 }
 
 func (m *PopulationMonitor) buildCortex(genome model.Genome) (*agent.Cortex, error) {
@@ -1836,39 +2728,85 @@ func (m *PopulationMonitor) buildCortex(genome model.Genome) (*agent.Cortex, err
 	if err != nil {
 		return nil, err
 	}
-	substrateRuntime, err := m.buildSubstrate(genome)
+	phenotype, err := m.encoding().Express(genome, m.cfg.InputNeuronIDs, m.cfg.OutputNeuronIDs)
 	if err != nil {
 		return nil, err
 	}
 
 	cortex, err := agent.NewCortex(
 		genome.ID,
-		genome,
+		phenotype.Network,
 		sensors,
 		actuators,
 		m.cfg.InputNeuronIDs,
 		m.cfg.OutputNeuronIDs,
-		substrateRuntime,
+		phenotype.Substrate,
 	)
 	if err != nil {
 		return nil, err
 	}
+	if m.cfg.ActivationClamp > 0 || m.cfg.WeightClamp > 0 {
+		cortex.SetNumericGuard(m.cfg.ActivationClamp, m.cfg.WeightClamp)
+	}
 	return cortex, nil
 }
 
-func (m *PopulationMonitor) evaluateCortex(ctx context.Context, cortex *agent.Cortex, mode string) (float64, scape.Trace, error) {
+func totalClampEvents(scored []ScoredGenome) int {
+	total := 0
+	for _, item := range scored {
+		total += item.Clamps.Total()
+	}
+	return total
+}
+
+// evaluateCortex runs one scape evaluation under the configured
+// EvaluationTimeout, converting a scape panic into ErrEvaluationPanic. An
+// evaluation that ignores its deadline fails with ErrEvaluationTimeout once
+// it returns.
+func (m *PopulationMonitor) evaluateCortex(ctx context.Context, cortex *agent.Cortex, mode string) (fitness float64, trace scape.Trace, err error) {
 	if cortex == nil {
 		return 0, nil, fmt.Errorf("cortex is required")
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			fitness, trace, err = 0, nil, fmt.Errorf("%w: genome %s: %v", ErrEvaluationPanic, cortex.ID(), r)
+		}
+	}()
+	defer evaluationMeterFrom(ctx).start()()
+	if m.cfg.EvaluationTimeout <= 0 {
+		return m.evaluateScape(ctx, cortex, mode)
+	}
+	evalCtx, cancel := context.WithTimeout(ctx, m.cfg.EvaluationTimeout)
+	defer cancel()
+	start := time.Now()
+	fitness, trace, err = m.evaluateScape(evalCtx, cortex, mode)
+	if ctx.Err() == nil && (errors.Is(err, context.DeadlineExceeded) || (err == nil && time.Since(start) > m.cfg.EvaluationTimeout)) {
+		return 0, nil, fmt.Errorf("%w: genome %s after %s", ErrEvaluationTimeout, cortex.ID(), m.cfg.EvaluationTimeout)
+	}
+	return fitness, trace, err
+}
+
+func (m *PopulationMonitor) evaluateScape(ctx context.Context, cortex *agent.Cortex, mode string) (float64, scape.Trace, error) {
 	var (
 		fitness scape.Fitness
 		trace   scape.Trace
 		err     error
 	)
-	if modeAware, ok := m.cfg.Scape.(scape.ModeAwareScape); ok {
+	target := m.cfg.Scape
+	if pool := m.cfg.ScapePool; pool != nil {
+		instance, err := pool.Checkout(ctx)
+		if err != nil {
+			return 0, nil, err
+		}
+		defer pool.Checkin(instance)
+		target = instance
+	}
+	if batchScape, ok := target.(scape.BatchScape); ok && !m.cfg.DisableBatchEval && cortex.BatchEvaluable() {
+		fitness, trace, err = batchScape.EvaluateBatch(ctx, cortex, mode)
+	} else if modeAware, ok := target.(scape.ModeAwareScape); ok {
 		fitness, trace, err = modeAware.EvaluateMode(ctx, cortex, mode)
 	} else {
-		fitness, trace, err = m.cfg.Scape.Evaluate(ctx, cortex)
+		fitness, trace, err = target.Evaluate(ctx, cortex)
 	}
 	if err != nil {
 		return 0, nil, err
@@ -1948,44 +2886,36 @@ func (m *PopulationMonitor) buildIO(genome model.Genome) (map[string]protoio.Sen
 	return sensors, actuators, nil
 }
 
-func (m *PopulationMonitor) buildSubstrate(genome model.Genome) (substrate.Runtime, error) {
-	if genome.Substrate == nil {
-		return nil, nil
-	}
-	cfg := genome.Substrate
-	faninByCEP := genotype.ResolveSubstrateCEPFaninPIDsByCEP(genome, m.cfg.OutputNeuronIDs)
-	faninPIDs := genotype.ResolveSubstrateCEPFaninPIDs(genome, m.cfg.OutputNeuronIDs)
-	if flattened := genotype.FlattenSubstrateCEPFaninPIDsByCEP(faninByCEP); len(flattened) > 0 {
-		faninPIDs = flattened
+// encoding returns the run's genotype-phenotype mapping.
+func (m *PopulationMonitor) encoding() encoding.Encoding {
+	if m.cfg.Encoding != nil {
+		return m.cfg.Encoding
 	}
-	spec := substrate.Spec{
-		CPPName:           cfg.CPPName,
-		CPPIDs:            append([]string(nil), cfg.CPPIDs...),
-		CEPName:           cfg.CEPName,
-		CEPNames:          append([]string(nil), cfg.CEPNames...),
-		CEPIDs:            append([]string(nil), cfg.CEPIDs...),
-		CEPFaninPIDs:      faninPIDs,
-		CEPFaninPIDsByCEP: faninByCEP,
-		Dimensions:        append([]int(nil), cfg.Dimensions...),
-		Parameters:        map[string]float64{},
-	}
-	for k, v := range cfg.Parameters {
-		spec.Parameters[k] = v
+	e, _ := encoding.Lookup(encoding.Auto)
+	return e
+}
+
+func (m *PopulationMonitor) nextGeneration(ctx context.Context, ranked []ScoredGenome, speciesByGenomeID map[string]string, generation int) ([]model.Genome, []LineageRecord, error) {
+	defer m.phases.reproduced(time.Now())
+	ranked, err := ScaleFitness(ranked, m.cfg.FitnessScaling, m.cfg.ScalingPressure)
+	if err != nil {
+		return nil, nil, err
 	}
-	weightCount := cfg.WeightCount
-	if weightCount <= 0 {
-		weightCount = len(m.cfg.OutputNeuronIDs)
+	if afpo, ok := m.cfg.Selector.(AFPOSelector); ok {
+		return m.nextAFPOGeneration(ctx, afpo, ranked, generation)
 	}
-	rt, err := substrate.NewSimpleRuntime(spec, weightCount)
-	if err != nil {
-		return nil, fmt.Errorf("build substrate runtime for genome %s: %w", genome.ID, err)
+	m.crossoverOffspring = map[string]string{}
+	if m.cfg.Islands > 1 {
+		return m.nextIslandGeneration(ctx, ranked, speciesByGenomeID, generation)
 	}
-	return rt, nil
+	return m.breed(ctx, ranked, speciesByGenomeID, generation, m.cfg.PopulationSize, 0)
 }
 
-func (m *PopulationMonitor) nextGeneration(ctx context.Context, ranked []ScoredGenome, speciesByGenomeID map[string]string, generation int) ([]model.Genome, []LineageRecord, error) {
-	next := make([]model.Genome, 0, m.cfg.PopulationSize)
-	lineage := make([]LineageRecord, 0, m.cfg.PopulationSize)
+// breed produces size genomes from ranked: elite clones, then offspring
+// allotted by species. Offspring ids are indexed from offset.
+func (m *PopulationMonitor) breed(ctx context.Context, ranked []ScoredGenome, speciesByGenomeID map[string]string, generation, size, offset int) ([]model.Genome, []LineageRecord, error) {
+	next := make([]model.Genome, 0, size)
+	lineage := make([]LineageRecord, 0, size)
 	nextGeneration := generation + 1
 	parentPool := ranked
 	if m.cfg.SpecieSizeLimit > 0 {
@@ -1994,14 +2924,19 @@ func (m *PopulationMonitor) nextGeneration(ctx context.Context, ranked []ScoredG
 			parentPool = ranked
 		}
 	}
+	parentPool = m.excludeBannedParents(parentPool)
 
+	elites := ranked
+	if _, ok := m.cfg.Selector.(NSGA2Selector); ok {
+		elites = NSGA2Order(ranked)
+	}
 	for i := 0; i < m.cfg.EliteCount; i++ {
-		elite := genotype.CloneAgent(ranked[i].Genome, ranked[i].Genome.ID)
+		elite := genotype.CloneAgent(elites[i].Genome, elites[i].Genome.ID)
 		sig := ComputeGenomeSignature(elite)
 		next = append(next, elite)
 		lineage = append(lineage, LineageRecord{
 			GenomeID:    elite.ID,
-			ParentID:    ranked[i].Genome.ID,
+			ParentID:    elites[i].Genome.ID,
 			Generation:  nextGeneration,
 			Operation:   "elite_clone",
 			Fingerprint: sig.Fingerprint,
@@ -2009,10 +2944,12 @@ func (m *PopulationMonitor) nextGeneration(ctx context.Context, ranked []ScoredG
 		})
 	}
 
-	remaining := m.cfg.PopulationSize - len(next)
-	offspringPlan := buildSpeciesOffspringPlan(parentPool, speciesByGenomeID, remaining)
+	remaining := size - len(next)
+	offspringPlan := buildSpeciesOffspringPlan(parentPool, speciesByGenomeID, remaining, m.speciesAllocation())
+	offspringPlan = m.applySpeciesSoftCap(offspringPlan, ranked, parentPool, speciesByGenomeID)
+	m.lastAllocation = allocationByKey(offspringPlan)
 	for _, item := range offspringPlan {
-		if len(next) >= m.cfg.PopulationSize {
+		if len(next) >= size {
 			break
 		}
 		speciesRanked := filterRankedBySpecies(parentPool, speciesByGenomeID, item.SpeciesKey)
@@ -2020,7 +2957,7 @@ func (m *PopulationMonitor) nextGeneration(ctx context.Context, ranked []ScoredG
 			continue
 		}
 		for i := 0; i < item.Count; i++ {
-			if len(next) >= m.cfg.PopulationSize {
+			if len(next) >= size {
 				break
 			}
 			if err := ctx.Err(); err != nil {
@@ -2031,7 +2968,7 @@ func (m *PopulationMonitor) nextGeneration(ctx context.Context, ranked []ScoredG
 			if err != nil {
 				return nil, nil, err
 			}
-			child, record, err := m.mutateFromParent(ctx, parent, generation, len(next))
+			child, record, err := m.reproduce(ctx, parentPool, speciesByGenomeID, parent, generation, offset+len(next))
 			if err != nil {
 				return nil, nil, err
 			}
@@ -2040,7 +2977,7 @@ func (m *PopulationMonitor) nextGeneration(ctx context.Context, ranked []ScoredG
 		}
 	}
 
-	for len(next) < m.cfg.PopulationSize {
+	for len(next) < size {
 		if err := ctx.Err(); err != nil {
 			return nil, nil, err
 		}
@@ -2049,7 +2986,7 @@ func (m *PopulationMonitor) nextGeneration(ctx context.Context, ranked []ScoredG
 		if err != nil {
 			return nil, nil, err
 		}
-		child, record, err := m.mutateFromParent(ctx, parent, generation, len(next))
+		child, record, err := m.reproduce(ctx, parentPool, speciesByGenomeID, parent, generation, offset+len(next))
 		if err != nil {
 			return nil, nil, err
 		}
@@ -2098,11 +3035,13 @@ func (m *PopulationMonitor) pickParentForSpecies(allRanked, speciesRanked []Scor
 }
 
 func (m *PopulationMonitor) mutateFromParent(ctx context.Context, parent model.Genome, generation, nextIndex int) (model.Genome, LineageRecord, error) {
+	defer m.phases.mutated(time.Now())
 	child := genotype.CloneAgent(parent, fmt.Sprintf("%s-g%d-i%d", parent.ID, generation+1, nextIndex))
-	mutationCount, err := m.cfg.TopologicalMutations.MutationCount(parent, generation, m.rng)
+	mutationCount, err := m.cfg.TopologicalMutations.MutationCount(parent, generation, m.mutationRNG)
 	if err != nil {
 		return model.Genome{}, LineageRecord{}, err
 	}
+	mutationCount = m.entropyMutationCount(mutationCount, generation)
 	if mutationCount <= 0 {
 		return model.Genome{}, LineageRecord{}, fmt.Errorf("invalid mutation count from policy: %d", mutationCount)
 	}
@@ -2145,6 +3084,11 @@ func (m *PopulationMonitor) mutateFromParent(ctx context.Context, parent model.G
 		operationEvents = append(operationEvents, deriveMutationEvent(beforeMutation, next, operationName))
 		successes++
 	}
+	if pruned := m.pruneDisabledSynapses(&mutated); len(pruned.IDs) > 0 {
+		operationNames = append(operationNames, pruned.Mutation)
+		operationEvents = append(operationEvents, pruned)
+	}
+	mutated = m.innovations.Mark(mutated)
 
 	sig := ComputeGenomeSignature(mutated)
 	return mutated, LineageRecord{
@@ -2167,98 +3111,6 @@ func (m *PopulationMonitor) maxMutationAttemptsPerStep() int {
 	return base
 }
 
-type speciesQuota struct {
-	SpeciesKey string
-	Count      int
-}
-
-func buildSpeciesOffspringPlan(ranked []ScoredGenome, speciesByGenomeID map[string]string, totalOffspring int) []speciesQuota {
-	if totalOffspring <= 0 || len(ranked) == 0 {
-		return nil
-	}
-	type agg struct {
-		key   string
-		sum   float64
-		size  int
-		score float64
-	}
-	byKey := map[string]*agg{}
-	for _, item := range ranked {
-		key := speciesByGenomeID[item.Genome.ID]
-		if key == "" {
-			key = "species:unknown"
-		}
-		if byKey[key] == nil {
-			byKey[key] = &agg{key: key}
-		}
-		byKey[key].sum += item.Fitness
-		byKey[key].size++
-	}
-	keys := make([]string, 0, len(byKey))
-	minMean := 0.0
-	for key, bucket := range byKey {
-		bucket.score = bucket.sum / float64(bucket.size)
-		if len(keys) == 0 || bucket.score < minMean {
-			minMean = bucket.score
-		}
-		keys = append(keys, key)
-	}
-	sort.Strings(keys)
-	shift := 0.0
-	if minMean <= 0 {
-		shift = -minMean + 1e-9
-	}
-	totalScore := 0.0
-	for _, key := range keys {
-		byKey[key].score += shift
-		totalScore += byKey[key].score
-	}
-	if totalScore <= 0 {
-		for _, key := range keys {
-			byKey[key].score = 1.0
-		}
-		totalScore = float64(len(keys))
-	}
-
-	type alloc struct {
-		key       string
-		count     int
-		remainder float64
-	}
-	allocs := make([]alloc, 0, len(keys))
-	assigned := 0
-	for _, key := range keys {
-		share := byKey[key].score / totalScore * float64(totalOffspring)
-		base := int(math.Floor(share))
-		allocs = append(allocs, alloc{
-			key:       key,
-			count:     base,
-			remainder: share - float64(base),
-		})
-		assigned += base
-	}
-	left := totalOffspring - assigned
-	sort.Slice(allocs, func(i, j int) bool {
-		if allocs[i].remainder == allocs[j].remainder {
-			return allocs[i].key < allocs[j].key
-		}
-		return allocs[i].remainder > allocs[j].remainder
-	})
-	for i := 0; i < left; i++ {
-		allocs[i%len(allocs)].count++
-	}
-	sort.Slice(allocs, func(i, j int) bool { return allocs[i].key < allocs[j].key })
-
-	out := make([]speciesQuota, 0, len(allocs))
-	for _, item := range allocs {
-		if item.count <= 0 {
-			continue
-		}
-		out = append(out, speciesQuota{SpeciesKey: item.key, Count: item.count})
-	}
-	return out
-}
-
 func filterRankedBySpecies(ranked []ScoredGenome, speciesByGenomeID map[string]string, speciesKey string) []ScoredGenome {
 	out := make([]ScoredGenome, 0, len(ranked))
 	for _, item := range ranked {
@@ -2271,9 +3123,10 @@ func filterRankedBySpecies(ranked []ScoredGenome, speciesByGenomeID map[string]s
 
 func summarizeSpeciesGeneration(ranked []ScoredGenome, speciesByGenomeID map[string]string, generation int, prevSpeciesSet map[string]struct{}) (SpeciesGeneration, map[string]struct{}) {
 	type aggregate struct {
-		size int
-		sum  float64
-		best float64
+		size    int
+		sum     float64
+		best    float64
+		genomes []model.Genome
 	}
 	bySpecies := map[string]*aggregate{}
 	currentSet := map[string]struct{}{}
@@ -2290,6 +3143,7 @@ func summarizeSpeciesGeneration(ranked []ScoredGenome, speciesByGenomeID map[str
 		}
 		bucket.size++
 		bucket.sum += item.Fitness
+		bucket.genomes = append(bucket.genomes, item.Genome)
 		if item.Fitness > bucket.best {
 			bucket.best = item.Fitness
 		}
@@ -2307,6 +3161,7 @@ func summarizeSpeciesGeneration(ranked []ScoredGenome, speciesByGenomeID map[str
 			Size:        item.size,
 			MeanFitness: item.sum / float64(item.size),
 			BestFitness: item.best,
+			Strategies:  ComputeStrategyDistribution(item.genomes),
 		})
 	}
 
@@ -2335,43 +3190,5 @@ func summarizeSpeciesGeneration(ranked []ScoredGenome, speciesByGenomeID map[str
 }
 
 func (m *PopulationMonitor) chooseMutation(genome model.Genome) Operator {
-	if len(m.cfg.MutationPolicy) == 0 {
-		return m.cfg.Mutation
-	}
-
-	total := 0.0
-	candidates := make([]WeightedMutation, 0, len(m.cfg.MutationPolicy))
-	for _, item := range m.cfg.MutationPolicy {
-		if !m.isOperatorApplicable(item.Operator, genome) {
-			continue
-		}
-		candidates = append(candidates, item)
-		total += item.Weight
-	}
-	if total <= 0 {
-		if m.isOperatorApplicable(m.cfg.Mutation, genome) {
-			return m.cfg.Mutation
-		}
-		// No compatible operator; fall back to legacy behavior.
-		return m.cfg.MutationPolicy[len(m.cfg.MutationPolicy)-1].Operator
-	}
-	pick := m.rng.Float64() * total
-	acc := 0.0
-	for _, item := range candidates {
-		acc += item.Weight
-		if pick <= acc {
-			return item.Operator
-		}
-	}
-	return candidates[len(candidates)-1].Operator
-}
-
-func (m *PopulationMonitor) isOperatorApplicable(operator Operator, genome model.Genome) bool {
-	if operator == nil {
-		return false
-	}
-	if contextual, ok := operator.(ContextualOperator); ok {
-		return contextual.Applicable(genome, m.cfg.Scape.Name())
-	}
-	return true
+	return chooseMutation(m.cfg.MutationPolicy, m.cfg.Mutation, genome, m.cfg.Scape.Name(), m.mutationRNG)
 }
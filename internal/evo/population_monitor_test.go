@@ -5,11 +5,13 @@ import (
 	"errors"
 	"math"
 	"math/rand"
+	"reflect"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"protogonos/internal/encoding"
 	"protogonos/internal/genotype"
 	protoio "protogonos/internal/io"
 	"protogonos/internal/model"
@@ -1664,14 +1666,14 @@ func TestPopulationMonitorBuildSubstrateUsesCEPNamesChain(t *testing.T) {
 		t.Fatalf("new monitor: %v", err)
 	}
 
-	rt, err := monitor.buildSubstrate(model.Genome{
+	rt, err := encoding.NewSubstrateRuntime(model.Genome{
 		ID: "sub-chain-0",
 		Substrate: &model.SubstrateConfig{
 			CPPName:  substrate.DefaultCPPName,
 			CEPName:  substrate.SetWeightCEPName,
 			CEPNames: []string{substrate.SetWeightCEPName, substrate.DefaultCEPName},
 		},
-	})
+	}, monitor.cfg.OutputNeuronIDs)
 	if err != nil {
 		t.Fatalf("build substrate: %v", err)
 	}
@@ -1704,14 +1706,14 @@ func TestPopulationMonitorBuildSubstrateExpandsCEPChainFromCEPIDs(t *testing.T)
 		t.Fatalf("new monitor: %v", err)
 	}
 
-	rt, err := monitor.buildSubstrate(model.Genome{
+	rt, err := encoding.NewSubstrateRuntime(model.Genome{
 		ID: "sub-ids-chain-0",
 		Substrate: &model.SubstrateConfig{
 			CPPName: substrate.DefaultCPPName,
 			CEPName: substrate.DefaultCEPName,
 			CEPIDs:  []string{"cep_1", "cep_2"},
 		},
-	})
+	}, monitor.cfg.OutputNeuronIDs)
 	if err != nil {
 		t.Fatalf("build substrate: %v", err)
 	}
@@ -1744,7 +1746,7 @@ func TestPopulationMonitorBuildSubstrateExpandsSingularCEPNamesByCEPIDs(t *testi
 		t.Fatalf("new monitor: %v", err)
 	}
 
-	rt, err := monitor.buildSubstrate(model.Genome{
+	rt, err := encoding.NewSubstrateRuntime(model.Genome{
 		ID: "sub-singular-chain-0",
 		Substrate: &model.SubstrateConfig{
 			CPPName:  substrate.DefaultCPPName,
@@ -1752,7 +1754,7 @@ func TestPopulationMonitorBuildSubstrateExpandsSingularCEPNamesByCEPIDs(t *testi
 			CEPNames: []string{substrate.SetWeightCEPName},
 			CEPIDs:   []string{"cep_1", "cep_2"},
 		},
-	})
+	}, monitor.cfg.OutputNeuronIDs)
 	if err != nil {
 		t.Fatalf("build substrate: %v", err)
 	}
@@ -1785,7 +1787,7 @@ func TestPopulationMonitorBuildSubstrateKeepsOutputFallbackOverCPPIDs(t *testing
 		t.Fatalf("new monitor: %v", err)
 	}
 
-	rt, err := monitor.buildSubstrate(model.Genome{
+	rt, err := encoding.NewSubstrateRuntime(model.Genome{
 		ID: "sub-cpp-fanin-0",
 		Substrate: &model.SubstrateConfig{
 			CPPName:     substrate.DefaultCPPName,
@@ -1793,7 +1795,7 @@ func TestPopulationMonitorBuildSubstrateKeepsOutputFallbackOverCPPIDs(t *testing
 			CEPName:     substrate.DefaultCEPName,
 			WeightCount: 1,
 		},
-	})
+	}, monitor.cfg.OutputNeuronIDs)
 	if err != nil {
 		t.Fatalf("build substrate: %v", err)
 	}
@@ -2027,7 +2029,7 @@ func TestBuildSpeciesOffspringPlanAllocatesBySharedFitness(t *testing.T) {
 		"b1": "sp-b",
 	}
 
-	plan := buildSpeciesOffspringPlan(ranked, speciesByGenomeID, 6)
+	plan := buildSpeciesOffspringPlan(ranked, speciesByGenomeID, 6, speciesAllocation{})
 	got := map[string]int{}
 	total := 0
 	for _, item := range plan {
@@ -2422,3 +2424,84 @@ func newComplexLinearGenome(id string, weight float64) model.Genome {
 	)
 	return g
 }
+
+func TestEstimateRemainingSeconds(t *testing.T) {
+	if got := estimateRemainingSeconds(10, 2, 10, 20, 0); math.Abs(got-40) > 1e-9 {
+		t.Fatalf("expected generation-limited eta 40, got %f", got)
+	}
+	if got := estimateRemainingSeconds(10, 2, 10, 20, 30); math.Abs(got-5) > 1e-9 {
+		t.Fatalf("expected evaluation-limited eta 5, got %f", got)
+	}
+	if got := estimateRemainingSeconds(10, 10, 10, 100, 0); got != 0 {
+		t.Fatalf("expected zero eta once complete, got %f", got)
+	}
+	if got := estimateRemainingSeconds(0, 0, 10, 0, 0); got != 0 {
+		t.Fatalf("expected zero eta before progress, got %f", got)
+	}
+}
+
+func TestGenerationalRunStepsMatchRun(t *testing.T) {
+	newMonitor := func() *PopulationMonitor {
+		monitor, err := NewPopulationMonitor(MonitorConfig{
+			Scape:           oneDimScape{},
+			Mutation:        PerturbWeightAt{Index: 0, Delta: 0.2},
+			PopulationSize:  4,
+			EliteCount:      1,
+			Generations:     4,
+			Workers:         1,
+			Seed:            5,
+			InputNeuronIDs:  []string{"i"},
+			OutputNeuronIDs: []string{"o"},
+		})
+		if err != nil {
+			t.Fatalf("new monitor: %v", err)
+		}
+		return monitor
+	}
+	initial := []model.Genome{
+		newLinearGenome("g0", -1.0),
+		newLinearGenome("g1", -0.4),
+		newLinearGenome("g2", 0.2),
+		newLinearGenome("g3", 0.8),
+	}
+	whole, err := newMonitor().Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	run, err := newMonitor().StartGenerational(initial)
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	steps := 0
+	for !run.Done() {
+		if err := run.Step(context.Background()); err != nil {
+			t.Fatalf("step: %v", err)
+		}
+		steps++
+		if len(run.Diagnostics()) != steps || run.Scored()[0].Fitness != run.Diagnostics()[steps-1].BestFitness {
+			t.Fatalf("step %d: expected one new ranked generation, got %d", steps, len(run.Diagnostics()))
+		}
+	}
+	stepped := run.Result()
+	if steps != 4 || !reflect.DeepEqual(stepped.BestByGeneration, whole.BestByGeneration) || len(stepped.Lineage) != len(whole.Lineage) {
+		t.Fatalf("stepped run diverged: %v vs %v", stepped.BestByGeneration, whole.BestByGeneration)
+	}
+
+	steady, err := NewPopulationMonitor(MonitorConfig{
+		Scape:           oneDimScape{},
+		Mutation:        PerturbWeightAt{Index: 0, Delta: 0.2},
+		PopulationSize:  4,
+		EliteCount:      1,
+		Generations:     2,
+		InputNeuronIDs:  []string{"i"},
+		OutputNeuronIDs: []string{"o"},
+		EvolutionType:   EvolutionTypeSteadyState,
+	})
+	if err != nil {
+		t.Fatalf("new steady-state monitor: %v", err)
+	}
+	if _, err := steady.StartGenerational(initial); err == nil {
+		t.Fatal("expected steady-state stepping to be rejected")
+	}
+}
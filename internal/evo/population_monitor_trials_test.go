@@ -0,0 +1,165 @@
+package evo
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+
+	"protogonos/internal/model"
+	"protogonos/internal/scape"
+	"protogonos/internal/stats"
+)
+
+// trialScape scores every agent by its repeated-trial index so tests can
+// observe how trials are aggregated.
+type trialScape struct{}
+
+func (trialScape) Name() string { return "trial-scape" }
+
+func (trialScape) Evaluate(ctx context.Context, _ scape.Agent) (scape.Fitness, scape.Trace, error) {
+	return scape.Fitness(1 + 0.1*float64(scape.TrialFromContext(ctx))), scape.Trace{}, nil
+}
+
+func TestPopulationMonitorEvaluationTrialsAttachBootstrapCI(t *testing.T) {
+	initial := []model.Genome{
+		newLinearGenome("g0", 0.1),
+		newLinearGenome("g1", 0.2),
+	}
+	monitor, err := NewPopulationMonitor(MonitorConfig{
+		Scape:            trialScape{},
+		Mutation:         namedNoopMutation{name: "noop"},
+		PopulationSize:   len(initial),
+		EliteCount:       1,
+		Generations:      1,
+		Workers:          2,
+		Seed:             3,
+		InputNeuronIDs:   []string{"i"},
+		OutputNeuronIDs:  []string{"o"},
+		EvaluationTrials: 5,
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	result, err := monitor.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	champion := result.FinalPopulation[0]
+	if math.Abs(champion.Fitness-1.2) > 1e-9 {
+		t.Fatalf("expected trial-mean fitness 1.2, got %f", champion.Fitness)
+	}
+	ci := champion.FitnessCI
+	if ci == nil || ci.Trials != 5 {
+		t.Fatalf("expected 5-trial bootstrap interval, got %+v", ci)
+	}
+	if ci.Lower > ci.Mean || ci.Upper < ci.Mean || ci.Lower < 1.0 || ci.Upper > 1.4 {
+		t.Fatalf("unexpected interval: %+v", ci)
+	}
+}
+
+func TestRankScoredBreaksTiesByIntervalLowerBound(t *testing.T) {
+	scored := []ScoredGenome{
+		{Genome: model.Genome{ID: "wide"}, Fitness: 1, FitnessCI: &stats.ConfidenceInterval{Mean: 1, Lower: 0.2, Upper: 1.8}},
+		{Genome: model.Genome{ID: "best"}, Fitness: 2},
+		{Genome: model.Genome{ID: "narrow"}, Fitness: 1, FitnessCI: &stats.ConfidenceInterval{Mean: 1, Lower: 0.9, Upper: 1.1}},
+	}
+	monitor := &PopulationMonitor{cfg: MonitorConfig{CITieBreak: true}}
+	monitor.rankScored(scored)
+	got := []string{scored[0].Genome.ID, scored[1].Genome.ID, scored[2].Genome.ID}
+	want := []string{"best", "narrow", "wide"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("unexpected ranking: got=%v want=%v", got, want)
+		}
+	}
+}
+
+func TestRankScoredFallsBackToGenomeID(t *testing.T) {
+	scored := []ScoredGenome{
+		{Genome: model.Genome{ID: "g3"}, Fitness: 1},
+		{Genome: model.Genome{ID: "g2"}, Fitness: 1, FitnessCI: &stats.ConfidenceInterval{Mean: 1, Lower: 0.5, Upper: 1.5}},
+		{Genome: model.Genome{ID: "g1"}, Fitness: 1, FitnessCI: &stats.ConfidenceInterval{Mean: 1, Lower: 0.5, Upper: 1.5}},
+	}
+	cases := map[bool][]string{
+		false: {"g1", "g2", "g3"},
+		// g3 has no interval, so its lower bound is its fitness.
+		true: {"g3", "g1", "g2"},
+	}
+	for ciTieBreak, want := range cases {
+		ranked := append([]ScoredGenome(nil), scored...)
+		monitor := &PopulationMonitor{cfg: MonitorConfig{CITieBreak: ciTieBreak}}
+		monitor.rankScored(ranked)
+		for i := range want {
+			if ranked[i].Genome.ID != want[i] {
+				t.Fatalf("ci=%v: unexpected ranking at %d: got %s want %v", ciTieBreak, i, ranked[i].Genome.ID, want)
+			}
+		}
+	}
+}
+
+func TestAggregateTrialFitness(t *testing.T) {
+	samples := []float64{0.9, 0.1, 0.8, 0.6}
+	cases := []struct {
+		mode  string
+		alpha float64
+		want  float64
+	}{
+		{mode: "", want: 0.6},
+		{mode: TrialAggregationMean, want: 0.6},
+		{mode: TrialAggregationCVaR, alpha: 0.5, want: 0.35},
+		{mode: TrialAggregationWorst, want: 0.1},
+	}
+	for _, tc := range cases {
+		got, err := AggregateTrialFitness(samples, tc.mode, tc.alpha)
+		if err != nil {
+			t.Fatalf("aggregate %q: %v", tc.mode, err)
+		}
+		if math.Abs(got-tc.want) > 1e-12 {
+			t.Fatalf("aggregate %q: want %f, got %f", tc.mode, tc.want, got)
+		}
+	}
+	if _, err := AggregateTrialFitness(samples, TrialAggregationCVaR, 0); err == nil {
+		t.Fatal("expected cvar with zero alpha to fail")
+	}
+	if _, err := AggregateTrialFitness(samples, "median", 0); err == nil {
+		t.Fatal("expected unsupported aggregation to fail")
+	}
+}
+
+func TestPopulationMonitorSeedsDecoupleSelectionAndMutation(t *testing.T) {
+	base := MonitorConfig{
+		Scape:           trialScape{},
+		Mutation:        namedNoopMutation{name: "noop"},
+		PopulationSize:  1,
+		EliteCount:      1,
+		Generations:     1,
+		Seed:            3,
+		InputNeuronIDs:  []string{"i"},
+		OutputNeuronIDs: []string{"o"},
+	}
+	shared, err := NewPopulationMonitor(base)
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	if shared.rng != shared.mutationRNG {
+		t.Fatal("expected selection and mutation to share one stream when only seed is set")
+	}
+
+	mutationSeed := int64(99)
+	decoupled := base
+	decoupled.MutationSeed = &mutationSeed
+	monitor, err := NewPopulationMonitor(decoupled)
+	if err != nil {
+		t.Fatalf("new decoupled monitor: %v", err)
+	}
+	if monitor.rng == monitor.mutationRNG {
+		t.Fatal("expected a dedicated mutation stream when mutation seed is set")
+	}
+	if got, want := monitor.rng.Int63(), rand.New(rand.NewSource(3)).Int63(); got != want {
+		t.Fatalf("expected selection stream seeded by run seed: got=%d want=%d", got, want)
+	}
+	if got, want := monitor.mutationRNG.Int63(), rand.New(rand.NewSource(99)).Int63(); got != want {
+		t.Fatalf("expected mutation stream seeded by mutation seed: got=%d want=%d", got, want)
+	}
+}
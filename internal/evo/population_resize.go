@@ -0,0 +1,118 @@
+package evo
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	PopulationResizeOff   = "off"
+	PopulationResizeGuard = "guard"
+	PopulationResizeAuto  = "auto"
+
+	// Auto resize grows the population by resizeGrowFactor after every
+	// resizeStagnationWindow generations without a new best fitness, and
+	// shrinks an over-budget generation by at most resizeShrinkLimit.
+	resizeStagnationWindow = 3
+	resizeGrowFactor       = 1.25
+	resizeShrinkLimit      = 0.8
+	// resizeDefaultMaxFactor bounds auto growth when no maximum is set.
+	resizeDefaultMaxFactor = 4
+)
+
+// Population resize reasons recorded in GenerationDiagnostics.PopulationResize.
+const (
+	ResizeReasonGuard      = "guard"
+	ResizeReasonStagnation = "stagnation"
+	ResizeReasonThroughput = "throughput"
+	ResizeReasonBounds     = "bounds"
+)
+
+// PopulationResizeNames lists the population resize modes.
+func PopulationResizeNames() []string {
+	return []string{PopulationResizeOff, PopulationResizeGuard, PopulationResizeAuto}
+}
+
+// ValidatePopulationResize reports whether mode, the population bounds and
+// the per-generation time target form a usable resize configuration. Zero
+// bounds and a zero target are unset.
+func ValidatePopulationResize(mode string, minPopulation, maxPopulation int, targetSeconds float64) error {
+	switch mode {
+	case "", PopulationResizeOff, PopulationResizeGuard, PopulationResizeAuto:
+	default:
+		return fmt.Errorf("unsupported population resize: %s", mode)
+	}
+	if minPopulation < 0 {
+		return fmt.Errorf("min population must be >= 0, got %d", minPopulation)
+	}
+	if maxPopulation < 0 {
+		return fmt.Errorf("max population must be >= 0, got %d", maxPopulation)
+	}
+	if maxPopulation > 0 && minPopulation > maxPopulation {
+		return fmt.Errorf("min population %d exceeds max population %d", minPopulation, maxPopulation)
+	}
+	if targetSeconds < 0 || math.IsNaN(targetSeconds) || math.IsInf(targetSeconds, 0) {
+		return fmt.Errorf("resize target seconds must be a finite value >= 0, got %f", targetSeconds)
+	}
+	if mode == "" || mode == PopulationResizeOff {
+		if minPopulation > 0 || maxPopulation > 0 || targetSeconds > 0 {
+			return fmt.Errorf("population bounds and resize target require population resize guard or auto")
+		}
+	}
+	if targetSeconds > 0 && mode != PopulationResizeAuto {
+		return fmt.Errorf("resize target seconds requires population resize auto")
+	}
+	return nil
+}
+
+// viablePopulationFloor is the smallest population that still holds every
+// elite plus two genomes per species, raised to MinPopulation.
+func (m *PopulationMonitor) viablePopulationFloor(speciesCount int) int {
+	return max(m.cfg.EliteCount+2*speciesCount, m.cfg.MinPopulation)
+}
+
+// resizePopulation picks the size of the generation bred after diag and
+// stores it in cfg.PopulationSize. It records the evaluated size and, when
+// the size changes, the reason on diag.
+func (m *PopulationMonitor) resizePopulation(diag *GenerationDiagnostics) {
+	mode := m.cfg.PopulationResize
+	if mode != PopulationResizeGuard && mode != PopulationResizeAuto {
+		return
+	}
+	size := m.cfg.PopulationSize
+	diag.PopulationSize = size
+	target := size
+	reason := ""
+	if mode == PopulationResizeAuto {
+		budget := m.cfg.ResizeTargetSeconds
+		switch {
+		case budget > 0 && diag.WallClockSeconds > budget:
+			factor := math.Max(resizeShrinkLimit, budget/diag.WallClockSeconds)
+			target = int(math.Floor(float64(size) * factor))
+			reason = ResizeReasonThroughput
+		case m.stopStagnation > 0 && m.stopStagnation%resizeStagnationWindow == 0:
+			grown := int(math.Ceil(float64(size) * resizeGrowFactor))
+			// Only grow when the larger generation is projected to fit the
+			// time budget at the last observed per-genome cost.
+			if budget <= 0 || diag.WallClockSeconds*float64(grown)/float64(size) <= budget {
+				target = grown
+				reason = ResizeReasonStagnation
+			}
+		}
+	}
+	if floor := m.viablePopulationFloor(diag.SpeciesCount); target < floor {
+		target = floor
+		reason = ResizeReasonGuard
+	}
+	if m.cfg.MaxPopulation > 0 && target > m.cfg.MaxPopulation {
+		target = m.cfg.MaxPopulation
+		if target < size || reason == "" {
+			reason = ResizeReasonBounds
+		}
+	}
+	if target == size {
+		return
+	}
+	m.cfg.PopulationSize = target
+	diag.PopulationResize = reason
+}
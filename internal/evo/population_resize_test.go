@@ -0,0 +1,174 @@
+package evo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func TestResizePopulationDecisions(t *testing.T) {
+	cases := []struct {
+		name       string
+		cfg        MonitorConfig
+		stagnation int
+		diag       GenerationDiagnostics
+		wantSize   int
+		wantReason string
+	}{
+		{
+			name:     "guard keeps a viable population",
+			cfg:      MonitorConfig{PopulationResize: PopulationResizeGuard, PopulationSize: 10, EliteCount: 2},
+			diag:     GenerationDiagnostics{SpeciesCount: 3},
+			wantSize: 10,
+		},
+		{
+			name:       "guard grows to elites plus two per species",
+			cfg:        MonitorConfig{PopulationResize: PopulationResizeGuard, PopulationSize: 6, EliteCount: 2},
+			diag:       GenerationDiagnostics{SpeciesCount: 4},
+			wantSize:   10,
+			wantReason: ResizeReasonGuard,
+		},
+		{
+			name:       "guard floor capped by max",
+			cfg:        MonitorConfig{PopulationResize: PopulationResizeGuard, PopulationSize: 6, EliteCount: 2, MaxPopulation: 8},
+			diag:       GenerationDiagnostics{SpeciesCount: 4},
+			wantSize:   8,
+			wantReason: ResizeReasonGuard,
+		},
+		{
+			name:       "auto grows after stagnation",
+			cfg:        MonitorConfig{PopulationResize: PopulationResizeAuto, PopulationSize: 10, EliteCount: 2, MaxPopulation: 40},
+			stagnation: resizeStagnationWindow,
+			diag:       GenerationDiagnostics{SpeciesCount: 1},
+			wantSize:   13,
+			wantReason: ResizeReasonStagnation,
+		},
+		{
+			name:       "auto growth stops at max",
+			cfg:        MonitorConfig{PopulationResize: PopulationResizeAuto, PopulationSize: 12, EliteCount: 2, MaxPopulation: 12},
+			stagnation: resizeStagnationWindow,
+			diag:       GenerationDiagnostics{SpeciesCount: 1},
+			wantSize:   12,
+		},
+		{
+			name:       "auto skips growth projected over the time target",
+			cfg:        MonitorConfig{PopulationResize: PopulationResizeAuto, PopulationSize: 10, EliteCount: 2, MaxPopulation: 40, ResizeTargetSeconds: 1.1},
+			stagnation: resizeStagnationWindow,
+			diag:       GenerationDiagnostics{SpeciesCount: 1, WallClockSeconds: 1},
+			wantSize:   10,
+		},
+		{
+			name:       "auto shrinks a slow generation by at most a fifth",
+			cfg:        MonitorConfig{PopulationResize: PopulationResizeAuto, PopulationSize: 20, EliteCount: 2, MaxPopulation: 40, ResizeTargetSeconds: 1},
+			diag:       GenerationDiagnostics{SpeciesCount: 1, WallClockSeconds: 4},
+			wantSize:   16,
+			wantReason: ResizeReasonThroughput,
+		},
+		{
+			name:       "auto shrink stops at the viable floor",
+			cfg:        MonitorConfig{PopulationResize: PopulationResizeAuto, PopulationSize: 10, EliteCount: 2, MaxPopulation: 40, MinPopulation: 9, ResizeTargetSeconds: 1},
+			diag:       GenerationDiagnostics{SpeciesCount: 1, WallClockSeconds: 4},
+			wantSize:   9,
+			wantReason: ResizeReasonGuard,
+		},
+		{
+			name:     "off never resizes",
+			cfg:      MonitorConfig{PopulationSize: 4, EliteCount: 2, MinPopulation: 10},
+			diag:     GenerationDiagnostics{SpeciesCount: 4},
+			wantSize: 4,
+		},
+	}
+	for _, tc := range cases {
+		m := &PopulationMonitor{cfg: tc.cfg, stopStagnation: tc.stagnation}
+		diag := tc.diag
+		m.resizePopulation(&diag)
+		if m.cfg.PopulationSize != tc.wantSize || diag.PopulationResize != tc.wantReason {
+			t.Fatalf("%s: want size=%d reason=%q, got size=%d reason=%q", tc.name, tc.wantSize, tc.wantReason, m.cfg.PopulationSize, diag.PopulationResize)
+		}
+	}
+}
+
+func TestPopulationMonitorGuardGrowsCollapsedPopulation(t *testing.T) {
+	initial := make([]model.Genome, 4)
+	for i := range initial {
+		initial[i] = newLinearGenome(fmt.Sprintf("g%d", i), -1+0.5*float64(i))
+	}
+	monitor, err := NewPopulationMonitor(MonitorConfig{
+		Scape:            oneDimScape{},
+		Mutation:         namedNoopMutation{name: "noop"},
+		PopulationSize:   len(initial),
+		EliteCount:       1,
+		Generations:      3,
+		Workers:          2,
+		Seed:             5,
+		InputNeuronIDs:   []string{"i"},
+		OutputNeuronIDs:  []string{"o"},
+		PopulationResize: PopulationResizeGuard,
+		MinPopulation:    6,
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	result, err := monitor.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(result.GenerationDiagnostics) != 3 {
+		t.Fatalf("expected 3 generations, got %d", len(result.GenerationDiagnostics))
+	}
+	first := result.GenerationDiagnostics[0]
+	if first.PopulationSize != 4 || first.PopulationResize != ResizeReasonGuard {
+		t.Fatalf("expected the first generation to grow by guard, got %+v", first)
+	}
+	for _, diag := range result.GenerationDiagnostics[1:] {
+		if diag.PopulationSize != 6 || diag.PopulationResize != "" {
+			t.Fatalf("expected a steady population of 6, got %+v", diag)
+		}
+	}
+	if len(result.FinalPopulation) != 6 {
+		t.Fatalf("expected final population of 6, got %d", len(result.FinalPopulation))
+	}
+}
+
+func TestValidatePopulationResize(t *testing.T) {
+	for _, mode := range PopulationResizeNames() {
+		if err := ValidatePopulationResize(mode, 0, 0, 0); err != nil {
+			t.Fatalf("%s: %v", mode, err)
+		}
+	}
+	if err := ValidatePopulationResize(PopulationResizeAuto, 4, 40, 2.5); err != nil {
+		t.Fatalf("auto with bounds: %v", err)
+	}
+	for _, tc := range []struct {
+		mode     string
+		min, max int
+		target   float64
+	}{
+		{mode: "elastic"},
+		{mode: PopulationResizeGuard, min: -1},
+		{mode: PopulationResizeGuard, max: -1},
+		{mode: PopulationResizeGuard, min: 8, max: 4},
+		{mode: PopulationResizeAuto, target: -1},
+		{mode: PopulationResizeOff, min: 4},
+		{mode: PopulationResizeGuard, target: 1},
+	} {
+		if err := ValidatePopulationResize(tc.mode, tc.min, tc.max, tc.target); err == nil {
+			t.Fatalf("expected %+v to be rejected", tc)
+		}
+	}
+
+	_, err := NewPopulationMonitor(MonitorConfig{
+		Scape:            oneDimScape{},
+		Mutation:         namedNoopMutation{name: "noop"},
+		PopulationSize:   4,
+		EliteCount:       1,
+		Generations:      1,
+		EvolutionType:    EvolutionTypeSteadyState,
+		PopulationResize: PopulationResizeGuard,
+	})
+	if err == nil {
+		t.Fatal("expected steady-state population resize to be rejected")
+	}
+}
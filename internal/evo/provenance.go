@@ -0,0 +1,50 @@
+package evo
+
+import (
+	"fmt"
+
+	"protogonos/internal/model"
+)
+
+// stampProvenance records on each newly created genome of a generation the
+// lineage operation that produced it. Genomes carried over under their own
+// ID (elite clones, steady-state survivors) keep their existing provenance.
+func stampProvenance(population []model.Genome, lineage []LineageRecord) {
+	if len(lineage) == 0 {
+		return
+	}
+	byGenomeID := make(map[string]LineageRecord, len(lineage))
+	for _, record := range lineage {
+		byGenomeID[record.GenomeID] = record
+	}
+	for i := range population {
+		record, ok := byGenomeID[population[i].ID]
+		if !ok || record.ParentID == population[i].ID {
+			continue
+		}
+		population[i].Provenance = &model.GenomeProvenance{
+			CreatedBy:  record.Operation,
+			ParentID:   record.ParentID,
+			Generation: record.Generation,
+		}
+	}
+}
+
+// tuningSessionID names one tuning invocation. It is unique within a run:
+// a genome is tuned at most once per generation.
+func tuningSessionID(generation int, genomeID string) string {
+	return fmt.Sprintf("tune-g%d-%s", generation, genomeID)
+}
+
+// withTuningSession returns genome with its provenance pointing at the given
+// tuning session. The provenance is copied so the untuned genome, which may
+// share the pointer, is left unchanged.
+func withTuningSession(genome model.Genome, sessionID string) model.Genome {
+	prov := model.GenomeProvenance{}
+	if genome.Provenance != nil {
+		prov = *genome.Provenance
+	}
+	prov.TuningSessionID = sessionID
+	genome.Provenance = &prov
+	return genome
+}
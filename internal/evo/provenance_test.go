@@ -0,0 +1,94 @@
+package evo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func TestPopulationMonitorStampsGenomeProvenance(t *testing.T) {
+	initial := []model.Genome{
+		newLinearGenome("g0", -1.0),
+		newLinearGenome("g1", -0.5),
+		newLinearGenome("g2", 0.0),
+		newLinearGenome("g3", 0.5),
+	}
+	initial[0].Annotations = map[string]string{"origin": "seed"}
+
+	monitor, err := NewPopulationMonitor(MonitorConfig{
+		Scape:           oneDimScape{},
+		Mutation:        PerturbWeightAt{Index: 0, Delta: 0.1},
+		PopulationSize:  len(initial),
+		EliteCount:      1,
+		Generations:     2,
+		Workers:         1,
+		Seed:            3,
+		InputNeuronIDs:  []string{"i"},
+		OutputNeuronIDs: []string{"o"},
+		Tuner:           &recordingTuner{},
+		TuneAttempts:    2,
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	result, err := monitor.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if initial[0].Provenance != nil {
+		t.Fatalf("expected seed genome to be left untouched, got %+v", initial[0].Provenance)
+	}
+
+	offspring := 0
+	for _, item := range result.FinalPopulation {
+		prov := item.Genome.Provenance
+		if prov == nil {
+			t.Fatalf("expected provenance on %s", item.Genome.ID)
+		}
+		if !strings.HasPrefix(prov.TuningSessionID, "tune-g1-") {
+			t.Fatalf("expected generation-1 tuning session on %s, got %+v", item.Genome.ID, prov)
+		}
+		if prov.ParentID == "" {
+			continue
+		}
+		offspring++
+		if prov.CreatedBy != "perturb_weight_at" || prov.Generation != 1 {
+			t.Fatalf("unexpected offspring provenance on %s: %+v", item.Genome.ID, prov)
+		}
+		if !strings.HasPrefix(item.Genome.ID, prov.ParentID+"-") {
+			t.Fatalf("expected parent %s to prefix child id %s", prov.ParentID, item.Genome.ID)
+		}
+	}
+	if offspring == 0 {
+		t.Fatal("expected offspring with recorded provenance")
+	}
+}
+
+func TestStampProvenanceKeepsCarriedOverGenomes(t *testing.T) {
+	kept := &model.GenomeProvenance{CreatedBy: "seed", Notes: []string{"keep"}}
+	population := []model.Genome{
+		{ID: "a", Provenance: kept},
+		{ID: "a-g2-i1"},
+	}
+	stampProvenance(population, []LineageRecord{
+		{GenomeID: "a", ParentID: "a", Generation: 2, Operation: "elite_clone"},
+		{GenomeID: "a-g2-i1", ParentID: "a", Generation: 2, Operation: "add_neuron+add_synapse"},
+	})
+	if population[0].Provenance != kept {
+		t.Fatalf("expected elite provenance to be kept, got %+v", population[0].Provenance)
+	}
+	want := model.GenomeProvenance{CreatedBy: "add_neuron+add_synapse", ParentID: "a", Generation: 2}
+	if got := population[1].Provenance; got == nil || got.CreatedBy != want.CreatedBy || got.ParentID != want.ParentID || got.Generation != want.Generation {
+		t.Fatalf("unexpected child provenance: %+v", got)
+	}
+
+	tuned := withTuningSession(population[0], "tune-g2-a")
+	if tuned.Provenance.TuningSessionID != "tune-g2-a" || tuned.Provenance.Notes[0] != "keep" {
+		t.Fatalf("unexpected tuned provenance: %+v", tuned.Provenance)
+	}
+	if kept.TuningSessionID != "" {
+		t.Fatal("expected the untuned provenance to be left unchanged")
+	}
+}
@@ -0,0 +1,65 @@
+package evo
+
+import (
+	"errors"
+	"fmt"
+
+	"protogonos/internal/genotype"
+	"protogonos/internal/model"
+)
+
+// OperationPruneDisabled is the lineage operation of the disabled synapse
+// pruning pass.
+const OperationPruneDisabled = "prune_disabled_synapses"
+
+// DefaultPruneDisabledProb is the removal probability of a synapse due for
+// pruning when none is configured.
+const DefaultPruneDisabledProb = 0.5
+
+// ValidateDisabledSynapsePruning reports whether a pruning age and
+// probability are usable. A zero age disables pruning.
+func ValidateDisabledSynapsePruning(after int, probability float64) error {
+	if after < 0 {
+		return fmt.Errorf("disabled synapse pruning age must be >= 0, got %d", after)
+	}
+	if probability < 0 || probability > 1 {
+		return fmt.Errorf("disabled synapse pruning probability must be in [0,1], got %g", probability)
+	}
+	if probability > 0 && after == 0 {
+		return errors.New("disabled synapse pruning probability requires a pruning age")
+	}
+	return nil
+}
+
+// pruneDisabledSynapses ages the disabled synapses of a freshly bred child
+// by one generation, resets the age of enabled ones, and removes each
+// synapse disabled for at least PruneDisabledAfter generations with
+// probability PruneDisabledProb. A disabled synapse carries no signal, so
+// removing it leaves the network's behaviour unchanged. It returns the
+// pruning event, with no IDs when nothing was removed.
+func (m *PopulationMonitor) pruneDisabledSynapses(genome *model.Genome) genotype.EvoHistoryEvent {
+	event := genotype.EvoHistoryEvent{Mutation: OperationPruneDisabled}
+	if m.cfg.PruneDisabledAfter <= 0 {
+		return event
+	}
+	probability := m.cfg.PruneDisabledProb
+	if probability == 0 {
+		probability = DefaultPruneDisabledProb
+	}
+	kept := make([]model.Synapse, 0, len(genome.Synapses))
+	for _, synapse := range genome.Synapses {
+		if synapse.Enabled {
+			synapse.DisabledFor = 0
+			kept = append(kept, synapse)
+			continue
+		}
+		synapse.DisabledFor++
+		if synapse.DisabledFor >= m.cfg.PruneDisabledAfter && m.mutationRNG.Float64() < probability {
+			event.IDs = append(event.IDs, synapse.ID)
+			continue
+		}
+		kept = append(kept, synapse)
+	}
+	genome.Synapses = kept
+	return event
+}
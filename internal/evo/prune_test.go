@@ -0,0 +1,144 @@
+package evo
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func TestPruneDisabledSynapsesAgesThenRemovesLongDisabledLinks(t *testing.T) {
+	monitor, err := NewPopulationMonitor(MonitorConfig{
+		Scape:              oneDimScape{},
+		Mutation:           PerturbWeightAt{Index: 0, Delta: 0.1},
+		PopulationSize:     1,
+		EliteCount:         1,
+		Generations:        1,
+		Workers:            1,
+		Seed:               1,
+		InputNeuronIDs:     []string{"i"},
+		OutputNeuronIDs:    []string{"o"},
+		PruneDisabledAfter: 2,
+		PruneDisabledProb:  1,
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+
+	genome := newComplexLinearGenome("g", 1)
+	genome.Synapses[1].Enabled = false
+	genome.Synapses[2].Enabled = false
+	genome.Synapses[2].DisabledFor = 1
+	genome.Synapses[3].DisabledFor = 7
+
+	event := monitor.pruneDisabledSynapses(&genome)
+	if event.Mutation != OperationPruneDisabled || !slices.Equal(event.IDs, []string{"s2"}) {
+		t.Fatalf("expected s2 pruned, got %+v", event)
+	}
+	if len(genome.Synapses) != 4 {
+		t.Fatalf("expected 4 synapses left, got %d", len(genome.Synapses))
+	}
+	if genome.Synapses[1].ID != "s1" || genome.Synapses[1].DisabledFor != 1 {
+		t.Fatalf("expected s1 aged to 1, got %+v", genome.Synapses[1])
+	}
+	if genome.Synapses[2].ID != "s3" || genome.Synapses[2].DisabledFor != 0 {
+		t.Fatalf("expected enabled s3 to reset its age, got %+v", genome.Synapses[2])
+	}
+
+	event = monitor.pruneDisabledSynapses(&genome)
+	if !slices.Equal(event.IDs, []string{"s1"}) || len(genome.Synapses) != 3 {
+		t.Fatalf("expected s1 pruned on its second generation, got %+v", event)
+	}
+}
+
+func TestPruneDisabledSynapsesIsOffByDefault(t *testing.T) {
+	monitor, err := NewPopulationMonitor(MonitorConfig{
+		Scape:           oneDimScape{},
+		Mutation:        PerturbWeightAt{Index: 0, Delta: 0.1},
+		PopulationSize:  1,
+		EliteCount:      1,
+		Generations:     1,
+		Workers:         1,
+		Seed:            1,
+		InputNeuronIDs:  []string{"i"},
+		OutputNeuronIDs: []string{"o"},
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	genome := newComplexLinearGenome("g", 1)
+	genome.Synapses[1].Enabled = false
+	genome.Synapses[1].DisabledFor = 100
+	if event := monitor.pruneDisabledSynapses(&genome); len(event.IDs) != 0 || len(genome.Synapses) != 5 {
+		t.Fatalf("expected no pruning, got %+v", event)
+	}
+	if genome.Synapses[1].DisabledFor != 100 {
+		t.Fatalf("expected age untouched while pruning is off, got %d", genome.Synapses[1].DisabledFor)
+	}
+}
+
+func TestPopulationMonitorRecordsDisabledSynapsePruningInLineage(t *testing.T) {
+	initial := make([]model.Genome, 4)
+	for i := range initial {
+		initial[i] = newComplexLinearGenome("g"+string(rune('0'+i)), 0.2*float64(i+1))
+		initial[i].Synapses[2].Enabled = false
+	}
+	monitor, err := NewPopulationMonitor(MonitorConfig{
+		Scape:              oneDimScape{},
+		Mutation:           PerturbWeightAt{Index: 0, Delta: 0.1},
+		PopulationSize:     len(initial),
+		EliteCount:         1,
+		Generations:        4,
+		Workers:            1,
+		Seed:               3,
+		InputNeuronIDs:     []string{"i"},
+		OutputNeuronIDs:    []string{"o"},
+		PruneDisabledAfter: 2,
+		PruneDisabledProb:  0.5,
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	result, err := monitor.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	pruned := 0
+	for _, record := range result.Lineage {
+		if !strings.Contains(record.Operation, OperationPruneDisabled) {
+			continue
+		}
+		pruned++
+		if record.Events[len(record.Events)-1].Mutation != OperationPruneDisabled {
+			t.Fatalf("expected pruning as the last event, got %+v", record.Events)
+		}
+		if !slices.Equal(record.Events[len(record.Events)-1].IDs, []string{"s2"}) {
+			t.Fatalf("expected only the disabled synapse pruned, got %+v", record.Events)
+		}
+	}
+	if pruned == 0 {
+		t.Fatal("expected pruning operations in lineage")
+	}
+}
+
+func TestValidateDisabledSynapsePruning(t *testing.T) {
+	for _, tc := range []struct {
+		after       int
+		probability float64
+		wantErr     bool
+	}{
+		{after: 0, probability: 0},
+		{after: 5, probability: 0},
+		{after: 5, probability: 1},
+		{after: -1, probability: 0, wantErr: true},
+		{after: 5, probability: 1.5, wantErr: true},
+		{after: 0, probability: 0.5, wantErr: true},
+	} {
+		if err := ValidateDisabledSynapsePruning(tc.after, tc.probability); (err != nil) != tc.wantErr {
+			t.Fatalf("after=%d probability=%g: got err %v, want error %t", tc.after, tc.probability, err, tc.wantErr)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package evo
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"protogonos/internal/scape"
+)
+
+const setScapeParamCommandPrefix = "set_scape_param:"
+
+// ScapeParamChange records a scape parameter adjusted through the monitor
+// control API. It takes effect from the start of the generation whose
+// diagnostics carry it.
+type ScapeParamChange struct {
+	Name     string  `json:"name"`
+	Previous float64 `json:"previous"`
+	Value    float64 `json:"value"`
+}
+
+// SetScapeParamCommand encodes a request to change a tunable scape parameter.
+// The monitor queues it and applies it before the next generation is
+// evaluated, so every genome of a generation sees the same settings.
+func SetScapeParamCommand(name string, value float64) MonitorCommand {
+	return MonitorCommand(setScapeParamCommandPrefix + name + "=" + strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+func parseSetScapeParamCommand(cmd MonitorCommand) (string, float64, bool) {
+	raw, ok := strings.CutPrefix(string(cmd), setScapeParamCommandPrefix)
+	if !ok {
+		return "", 0, false
+	}
+	name, rawValue, ok := strings.Cut(raw, "=")
+	if !ok || name == "" {
+		return "", 0, false
+	}
+	value, err := strconv.ParseFloat(rawValue, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return name, value, true
+}
+
+// queueScapeParam buffers a parameter change until the next generation
+// boundary. Changes the scape rejects are dropped; callers going through the
+// polis are validated before the command is sent.
+func (m *PopulationMonitor) queueScapeParam(name string, value float64) {
+	if err := scape.ValidateScapeParam(m.cfg.Scape, name, value); err != nil {
+		return
+	}
+	m.pendingScapeParams = append(m.pendingScapeParams, ScapeParamChange{Name: name, Value: value})
+}
+
+// beginGeneration applies queued scape parameter changes and returns the
+// context the generation should be evaluated with, together with the changes
+// that took effect.
+func (m *PopulationMonitor) beginGeneration(ctx context.Context) (context.Context, []ScapeParamChange) {
+	var changes []ScapeParamChange
+	for _, pending := range m.pendingScapeParams {
+		previous, ok := m.scapeParams[pending.Name]
+		if !ok {
+			if param, err := scape.LookupScapeParam(m.cfg.Scape, pending.Name); err == nil {
+				previous = param.Default
+			}
+		}
+		if m.scapeParams == nil {
+			m.scapeParams = map[string]float64{}
+		}
+		m.scapeParams[pending.Name] = pending.Value
+		changes = append(changes, ScapeParamChange{Name: pending.Name, Previous: previous, Value: pending.Value})
+	}
+	m.pendingScapeParams = nil
+	if len(m.scapeParams) == 0 {
+		return ctx, changes
+	}
+	return scape.WithScapeParams(ctx, m.scapeParams), changes
+}
@@ -0,0 +1,100 @@
+package evo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"protogonos/internal/model"
+	"protogonos/internal/scape"
+)
+
+type levelScape struct{}
+
+func (levelScape) Name() string { return "level-scape" }
+
+func (levelScape) TunableParams() []scape.ScapeParam {
+	return []scape.ScapeParam{{Name: "level", Default: 1, Min: 0, Max: 10}}
+}
+
+func (levelScape) Evaluate(ctx context.Context, _ scape.Agent) (scape.Fitness, scape.Trace, error) {
+	level := 1.0
+	if value, ok := scape.ScapeParamsFromContext(ctx)["level"]; ok {
+		level = value
+	}
+	return scape.Fitness(level), scape.Trace{}, nil
+}
+
+func TestSetScapeParamCommandRoundTrip(t *testing.T) {
+	name, value, ok := parseSetScapeParamCommand(SetScapeParamCommand("noise_std", 0.25))
+	if !ok || name != "noise_std" || value != 0.25 {
+		t.Fatalf("unexpected round trip: name=%q value=%f ok=%t", name, value, ok)
+	}
+	for _, cmd := range []MonitorCommand{CommandPause, "set_scape_param:", "set_scape_param:x=abc", "set_scape_param:=1"} {
+		if _, _, ok := parseSetScapeParamCommand(cmd); ok {
+			t.Fatalf("expected %q not to parse as a scape parameter command", cmd)
+		}
+	}
+}
+
+func TestPopulationMonitorAppliesScapeParamsBetweenGenerations(t *testing.T) {
+	initial := []model.Genome{
+		newLinearGenome("g0", 0.1),
+		newLinearGenome("g1", 0.2),
+	}
+	control := make(chan MonitorCommand, 4)
+	control <- CommandPause
+
+	monitor, err := NewPopulationMonitor(MonitorConfig{
+		Scape:           levelScape{},
+		Mutation:        namedNoopMutation{name: "noop"},
+		PopulationSize:  len(initial),
+		EliteCount:      1,
+		Generations:     3,
+		Workers:         2,
+		Seed:            1,
+		InputNeuronIDs:  []string{"i"},
+		OutputNeuronIDs: []string{"o"},
+		Control:         control,
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+
+	done := make(chan RunResult, 1)
+	errs := make(chan error, 1)
+	go func() {
+		result, runErr := monitor.Run(context.Background(), initial)
+		if runErr != nil {
+			errs <- runErr
+			return
+		}
+		done <- result
+	}()
+	time.Sleep(30 * time.Millisecond)
+
+	control <- SetScapeParamCommand("level", 20)
+	control <- SetScapeParamCommand("level", 4)
+	control <- CommandContinue
+
+	var result RunResult
+	select {
+	case runErr := <-errs:
+		t.Fatalf("run failed: %v", runErr)
+	case result = <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for run completion")
+	}
+
+	if got := result.BestByGeneration; len(got) != 3 || got[0] != 1 || got[1] != 4 || got[2] != 4 {
+		t.Fatalf("expected level change to apply from the second generation, got %v", got)
+	}
+	diagnostics := result.GenerationDiagnostics
+	if len(diagnostics[0].ScapeParamChanges) != 0 || len(diagnostics[2].ScapeParamChanges) != 0 {
+		t.Fatalf("expected changes recorded only where they took effect, got %+v", diagnostics)
+	}
+	changes := diagnostics[1].ScapeParamChanges
+	if len(changes) != 1 || changes[0] != (ScapeParamChange{Name: "level", Previous: 1, Value: 4}) {
+		t.Fatalf("expected single validated change event, got %+v", changes)
+	}
+}
@@ -1,6 +1,7 @@
 package evo
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"sort"
@@ -25,6 +26,30 @@ type SpeciesAwareGenerationSelector interface {
 	PickParentForGenerationWithSpecies(rng *rand.Rand, ranked []ScoredGenome, eliteCount, generation int, speciesByGenomeID map[string]string) (model.Genome, error)
 }
 
+// StagnationReporter is implemented by selectors that exclude stagnant
+// species. DrainStagnantSpecies returns the species that became stagnant
+// since the previous call, each once per stagnation episode.
+type StagnationReporter interface {
+	DrainStagnantSpecies() []string
+}
+
+// ScoredBefore reports whether a ranks ahead of b: higher fitness first, with
+// exact ties broken by ascending genome ID so rankings never depend on
+// evaluation order, sort internals or platform.
+func ScoredBefore(a, b ScoredGenome) bool {
+	if a.Fitness != b.Fitness {
+		return a.Fitness > b.Fitness
+	}
+	return a.Genome.ID < b.Genome.ID
+}
+
+// SortScored orders scored by ScoredBefore.
+func SortScored(scored []ScoredGenome) {
+	sort.SliceStable(scored, func(i, j int) bool {
+		return ScoredBefore(scored[i], scored[j])
+	})
+}
+
 // EliteSelector picks uniformly from the top elite set.
 type EliteSelector struct{}
 
@@ -43,9 +68,14 @@ func (EliteSelector) PickParent(rng *rand.Rand, ranked []ScoredGenome, eliteCoun
 }
 
 // TournamentSelector samples candidates and picks the best fitness among them.
+// WithoutReplacement samples distinct candidates. WinProbability below 1 makes
+// the tournament stochastic: the k-th best sampled candidate wins with
+// probability p*(1-p)^k.
 type TournamentSelector struct {
-	PoolSize       int
-	TournamentSize int
+	PoolSize           int
+	TournamentSize     int
+	WithoutReplacement bool
+	WinProbability     float64
 }
 
 func (TournamentSelector) Name() string {
@@ -71,22 +101,47 @@ func (s TournamentSelector) PickParent(rng *rand.Rand, ranked []ScoredGenome, el
 		poolSize = len(ranked)
 	}
 
-	tournamentSize := s.TournamentSize
+	return runTournament(rng, ranked[:poolSize], s.TournamentSize, s.WithoutReplacement, s.WinProbability).Genome, nil
+}
+
+// runTournament samples tournamentSize candidates (default 3, clamped to the
+// candidate count) and returns the winner. With the defaults it draws with
+// replacement and the fittest sample always wins.
+func runTournament(rng *rand.Rand, candidates []ScoredGenome, tournamentSize int, withoutReplacement bool, winProbability float64) ScoredGenome {
 	if tournamentSize <= 0 {
 		tournamentSize = 3
 	}
-	if tournamentSize > poolSize {
-		tournamentSize = poolSize
+	if tournamentSize > len(candidates) {
+		tournamentSize = len(candidates)
+	}
+
+	sample := make([]ScoredGenome, 0, tournamentSize)
+	if withoutReplacement {
+		for _, idx := range rng.Perm(len(candidates))[:tournamentSize] {
+			sample = append(sample, candidates[idx])
+		}
+	} else {
+		for i := 0; i < tournamentSize; i++ {
+			sample = append(sample, candidates[rng.Intn(len(candidates))])
+		}
 	}
 
-	best := ranked[rng.Intn(poolSize)]
-	for i := 1; i < tournamentSize; i++ {
-		candidate := ranked[rng.Intn(poolSize)]
-		if candidate.Fitness > best.Fitness {
-			best = candidate
+	if winProbability <= 0 || winProbability >= 1 {
+		best := sample[0]
+		for _, candidate := range sample[1:] {
+			if ScoredBefore(candidate, best) {
+				best = candidate
+			}
 		}
+		return best
 	}
-	return best.Genome, nil
+	SortScored(sample)
+	for _, candidate := range sample[:len(sample)-1] {
+		if rng.Float64() < winProbability {
+			return candidate
+		}
+	}
+	return sample[len(sample)-1]
 }
 
 // RankSelector picks from a pool weighted by descending rank.
@@ -252,9 +307,11 @@ func (s TopKFitnessSelector) PickParent(rng *rand.Rand, ranked []ScoredGenome, e
 // SpeciesTournamentSelector first samples a species uniformly and then runs
 // tournament selection inside that species.
 type SpeciesTournamentSelector struct {
-	Identifier     SpecieIdentifier
-	PoolSize       int
-	TournamentSize int
+	Identifier         SpecieIdentifier
+	PoolSize           int
+	TournamentSize     int
+	WithoutReplacement bool
+	WinProbability     float64
 }
 
 func (SpeciesTournamentSelector) Name() string {
@@ -306,27 +363,13 @@ func (s SpeciesTournamentSelector) pickParentInternal(rng *rand.Rand, ranked []S
 	chosenSpecies := speciesKeys[rng.Intn(len(speciesKeys))]
 	candidates := bySpecies[chosenSpecies]
 
-	tournamentSize := s.TournamentSize
-	if tournamentSize <= 0 {
-		tournamentSize = 3
-	}
-	if tournamentSize > len(candidates) {
-		tournamentSize = len(candidates)
-	}
-
-	best := candidates[rng.Intn(len(candidates))]
-	for i := 1; i < tournamentSize; i++ {
-		candidate := candidates[rng.Intn(len(candidates))]
-		if candidate.Fitness > best.Fitness {
-			best = candidate
-		}
-	}
-	return best.Genome, nil
+	return runTournament(rng, candidates, s.TournamentSize, s.WithoutReplacement, s.WinProbability).Genome, nil
 }
 
 type speciesState struct {
 	bestFitness    float64
 	lastImprovedAt int
+	stagnant       bool
 }
 
 // SpeciesSharedTournamentSelector picks a species using shared-fitness weighting,
@@ -335,13 +378,16 @@ type SpeciesSharedTournamentSelector struct {
 	Identifier            SpecieIdentifier
 	PoolSize              int
 	TournamentSize        int
+	WithoutReplacement    bool
+	WinProbability        float64
 	StagnationGenerations int
 
-	mu    sync.Mutex
-	state map[string]speciesState
+	mu        sync.Mutex
+	state     map[string]speciesState
+	stagnated []string
 }
 
-func (SpeciesSharedTournamentSelector) Name() string {
+func (*SpeciesSharedTournamentSelector) Name() string {
 	return "species_shared_tournament"
 }
 
@@ -449,22 +495,7 @@ func (s *SpeciesSharedTournamentSelector) pickParentInternal(rng *rand.Rand, ran
 	}
 	candidates := bySpecies[chosenKey]
 
-	tournamentSize := s.TournamentSize
-	if tournamentSize <= 0 {
-		tournamentSize = 3
-	}
-	if tournamentSize > len(candidates) {
-		tournamentSize = len(candidates)
-	}
-
-	best := candidates[rng.Intn(len(candidates))]
-	for i := 1; i < tournamentSize; i++ {
-		candidate := candidates[rng.Intn(len(candidates))]
-		if candidate.Fitness > best.Fitness {
-			best = candidate
-		}
-	}
-	return best.Genome, nil
+	return runTournament(rng, candidates, s.TournamentSize, s.WithoutReplacement, s.WinProbability).Genome, nil
 }
 
 func (s *SpeciesSharedTournamentSelector) shouldKeepSpecies(key string, bestFitness float64, generation int) bool {
@@ -478,7 +509,72 @@ func (s *SpeciesSharedTournamentSelector) shouldKeepSpecies(key string, bestFitn
 		s.state[key] = speciesState{bestFitness: bestFitness, lastImprovedAt: generation}
 		return true
 	}
-	return generation-prev.lastImprovedAt <= s.StagnationGenerations
+	if generation-prev.lastImprovedAt <= s.StagnationGenerations {
+		return true
+	}
+	if !prev.stagnant {
+		prev.stagnant = true
+		s.state[key] = prev
+		s.stagnated = append(s.stagnated, key)
+	}
+	return false
+}
+
+func (s *SpeciesSharedTournamentSelector) DrainStagnantSpecies() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := s.stagnated
+	s.stagnated = nil
+	return out
+}
+
+type speciesCheckpoint struct {
+	BestFitness    float64 `json:"best_fitness"`
+	LastImprovedAt int     `json:"last_improved_at"`
+	Stagnant       bool    `json:"stagnant,omitempty"`
+}
+
+type speciesSharedTournamentCheckpoint struct {
+	Species   map[string]speciesCheckpoint `json:"species,omitempty"`
+	Stagnated []string                     `json:"stagnated,omitempty"`
+}
+
+// SaveCheckpointState records the stagnation bookkeeping of every species.
+func (s *SpeciesSharedTournamentSelector) SaveCheckpointState() (json.RawMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := speciesSharedTournamentCheckpoint{Stagnated: s.stagnated}
+	if len(s.state) > 0 {
+		state.Species = make(map[string]speciesCheckpoint, len(s.state))
+		for key, species := range s.state {
+			state.Species[key] = speciesCheckpoint{
+				BestFitness:    species.bestFitness,
+				LastImprovedAt: species.lastImprovedAt,
+				Stagnant:       species.stagnant,
+			}
+		}
+	}
+	return json.Marshal(state)
+}
+
+// RestoreCheckpointState replaces the stagnation bookkeeping with a saved one.
+func (s *SpeciesSharedTournamentSelector) RestoreCheckpointState(raw json.RawMessage) error {
+	var state speciesSharedTournamentCheckpoint
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = make(map[string]speciesState, len(state.Species))
+	for key, species := range state.Species {
+		s.state[key] = speciesState{
+			bestFitness:    species.BestFitness,
+			lastImprovedAt: species.LastImprovedAt,
+			stagnant:       species.Stagnant,
+		}
+	}
+	s.stagnated = state.Stagnated
+	return nil
 }
 
 func buildSpeciesBuckets(pool []ScoredGenome, identifier SpecieIdentifier, speciesByGenomeID map[string]string) map[string][]ScoredGenome {
@@ -158,6 +158,13 @@ func TestSpeciesSharedTournamentSelectorFiltersStagnantSpecies(t *testing.T) {
 	if _, ok := keptSpecies[activeSpecies]; !ok {
 		t.Fatalf("expected active species %q to be selected", activeSpecies)
 	}
+	stagnant := selector.DrainStagnantSpecies()
+	if len(stagnant) != 1 || stagnant[0] != id.Identify(gen4[len(gen4)-1].Genome) {
+		t.Fatalf("expected stagnant species reported once, got %v", stagnant)
+	}
+	if again := selector.DrainStagnantSpecies(); len(again) != 0 {
+		t.Fatalf("expected drained stagnation reports to reset, got %v", again)
+	}
 }
 
 func TestSpeciesTournamentSelectorUsesProvidedSpeciesAssignments(t *testing.T) {
@@ -349,3 +356,107 @@ func TestTopKFitnessSelectorBiasesTowardBestInTopK(t *testing.T) {
 		t.Fatalf("expected outside top-k genome to never be selected, got %d", counts["outside"])
 	}
 }
+
+func TestTournamentSelectorReplacementAndWinProbability(t *testing.T) {
+	scored := []ScoredGenome{
+		{Genome: newLinearGenome("best", 1), Fitness: 3},
+		{Genome: newLinearGenome("mid", 1), Fitness: 2},
+		{Genome: newLinearGenome("worst", 1), Fitness: 1},
+	}
+
+	// Sampling the whole pool without replacement always includes the best.
+	exhaustive := TournamentSelector{PoolSize: len(scored), TournamentSize: len(scored), WithoutReplacement: true}
+	rng := rand.New(rand.NewSource(7))
+	for i := 0; i < 50; i++ {
+		parent, err := exhaustive.PickParent(rng, scored, 1)
+		if err != nil {
+			t.Fatalf("pick parent: %v", err)
+		}
+		if parent.ID != "best" {
+			t.Fatalf("expected exhaustive tournament to pick best, got %s", parent.ID)
+		}
+	}
+
+	// With replacement, the same draws can miss the best candidate.
+	withReplacement := TournamentSelector{PoolSize: len(scored), TournamentSize: len(scored)}
+	rng = rand.New(rand.NewSource(7))
+	missed := false
+	for i := 0; i < 200 && !missed; i++ {
+		parent, err := withReplacement.PickParent(rng, scored, 1)
+		if err != nil {
+			t.Fatalf("pick parent: %v", err)
+		}
+		missed = parent.ID != "best"
+	}
+	if !missed {
+		t.Fatal("expected sampling with replacement to sometimes miss the best candidate")
+	}
+
+	// A stochastic win probability lets weaker candidates win roughly
+	// p*(1-p)^k of the time.
+	stochastic := TournamentSelector{PoolSize: len(scored), TournamentSize: len(scored), WithoutReplacement: true, WinProbability: 0.5}
+	rng = rand.New(rand.NewSource(11))
+	counts := map[string]int{}
+	const draws = 4000
+	for i := 0; i < draws; i++ {
+		parent, err := stochastic.PickParent(rng, scored, 1)
+		if err != nil {
+			t.Fatalf("pick parent: %v", err)
+		}
+		counts[parent.ID]++
+	}
+	want := map[string]float64{"best": 0.5, "mid": 0.25, "worst": 0.25}
+	for id, share := range want {
+		got := float64(counts[id]) / draws
+		if got < share-0.05 || got > share+0.05 {
+			t.Fatalf("unexpected win share for %s: got %.3f want %.2f (counts=%v)", id, got, share, counts)
+		}
+	}
+}
+
+func TestSortScoredBreaksTiesByGenomeID(t *testing.T) {
+	want := []string{"top", "a", "b", "c", "low"}
+	for seed := int64(1); seed <= 20; seed++ {
+		scored := []ScoredGenome{
+			{Genome: newLinearGenome("c", 1), Fitness: 1},
+			{Genome: newLinearGenome("a", 1), Fitness: 1},
+			{Genome: newLinearGenome("low", 1), Fitness: 0},
+			{Genome: newLinearGenome("b", 1), Fitness: 1},
+			{Genome: newLinearGenome("top", 1), Fitness: 2},
+		}
+		rng := rand.New(rand.NewSource(seed))
+		rng.Shuffle(len(scored), func(i, j int) { scored[i], scored[j] = scored[j], scored[i] })
+		SortScored(scored)
+		for i, id := range want {
+			if scored[i].Genome.ID != id {
+				t.Fatalf("seed %d: expected order %v, got %s at %d", seed, want, scored[i].Genome.ID, i)
+			}
+		}
+	}
+}
+
+func TestTournamentSelectorBreaksTiesByGenomeID(t *testing.T) {
+	for _, winProbability := range []float64{0, 0.5} {
+		scored := []ScoredGenome{
+			{Genome: newLinearGenome("z", 1), Fitness: 1},
+			{Genome: newLinearGenome("m", 1), Fitness: 1},
+			{Genome: newLinearGenome("a", 1), Fitness: 1},
+		}
+		selector := TournamentSelector{PoolSize: len(scored), TournamentSize: len(scored), WithoutReplacement: true, WinProbability: winProbability}
+		rng := rand.New(rand.NewSource(3))
+		counts := map[string]int{}
+		for i := 0; i < 200; i++ {
+			parent, err := selector.PickParent(rng, scored, 1)
+			if err != nil {
+				t.Fatalf("pick parent: %v", err)
+			}
+			counts[parent.ID]++
+		}
+		if winProbability == 0 && counts["a"] != 200 {
+			t.Fatalf("expected tied deterministic tournament to pick lowest id, got %v", counts)
+		}
+		if winProbability > 0 && (counts["a"] <= counts["m"] || counts["m"] <= counts["z"]) {
+			t.Fatalf("expected tied stochastic tournament to favor ids in order, got %v", counts)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package evo
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"protogonos/internal/model"
+)
+
+const (
+	SimilarityMetricCompatibility = "compatibility"
+	SimilarityMetricEmbedding     = "embedding"
+)
+
+// GenomeEmbedding is a named feature vector derived from a genome's topology
+// signature plus weight statistics. Unlike the compatibility distance it
+// separates genomes that share a topology but carry different weights.
+type GenomeEmbedding map[string]float64
+
+// ComputeGenomeEmbedding maps a genome to log-scaled structure counts,
+// activation/aggregator proportions and enabled-weight moments.
+func ComputeGenomeEmbedding(genome model.Genome) GenomeEmbedding {
+	summary := ComputeGenomeSignature(genome).Summary
+	embedding := GenomeEmbedding{
+		"neurons":   math.Log1p(float64(summary.TotalNeurons)),
+		"synapses":  math.Log1p(float64(summary.TotalSynapses)),
+		"recurrent": math.Log1p(float64(summary.TotalRecurrentSynapses)),
+		"sensors":   math.Log1p(float64(summary.TotalSensors)),
+		"actuators": math.Log1p(float64(summary.TotalActuators)),
+	}
+	for name, count := range summary.ActivationDistribution {
+		embedding["af:"+name] = proportion(count, summary.TotalNeurons)
+	}
+	for name, count := range summary.AggregatorDistribution {
+		embedding["aggr:"+name] = proportion(count, summary.TotalNeurons)
+	}
+
+	var sum, sumSq float64
+	enabled := 0
+	for _, synapse := range genome.Synapses {
+		if !synapse.Enabled {
+			continue
+		}
+		enabled++
+		sum += synapse.Weight
+		sumSq += synapse.Weight * synapse.Weight
+	}
+	if enabled > 0 {
+		mean := sum / float64(enabled)
+		embedding["weight_mean"] = mean
+		embedding["weight_std"] = math.Sqrt(math.Max(0, sumSq/float64(enabled)-mean*mean))
+	}
+	return embedding
+}
+
+// EmbeddingDistance is the Euclidean distance between two embeddings; a
+// feature missing from one side counts as zero.
+func EmbeddingDistance(a, b GenomeEmbedding) float64 {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	total := 0.0
+	for _, key := range keys {
+		delta := a[key] - b[key]
+		total += delta * delta
+	}
+	return math.Sqrt(total)
+}
+
+// GenomeDistanceFunc returns the pairwise distance for a similarity metric.
+// An empty metric selects the compatibility distance used by speciation.
+func GenomeDistanceFunc(metric string) (func(a, b model.Genome) float64, error) {
+	switch metric {
+	case "", SimilarityMetricCompatibility:
+		return GenomeCompatibilityDistance, nil
+	case SimilarityMetricEmbedding:
+		return func(a, b model.Genome) float64 {
+			return EmbeddingDistance(ComputeGenomeEmbedding(a), ComputeGenomeEmbedding(b))
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported similarity metric: %s", metric)
+	}
+}
@@ -0,0 +1,39 @@
+package evo
+
+import "testing"
+
+func TestEmbeddingDistanceSeparatesWeightOnlyDifferences(t *testing.T) {
+	a := newLinearGenome("a", 0.2)
+	b := newLinearGenome("b", 0.9)
+	complex := newComplexLinearGenome("c", 0.2)
+
+	if got := GenomeCompatibilityDistance(a, b); got != 0 {
+		t.Fatalf("expected same-topology compatibility distance 0, got %f", got)
+	}
+	embeddingFn, err := GenomeDistanceFunc(SimilarityMetricEmbedding)
+	if err != nil {
+		t.Fatalf("embedding metric: %v", err)
+	}
+	if got := embeddingFn(a, a); got != 0 {
+		t.Fatalf("expected zero self distance, got %f", got)
+	}
+	weightOnly := embeddingFn(a, b)
+	if weightOnly <= 0 {
+		t.Fatalf("expected embedding to separate weight-only differences, got %f", weightOnly)
+	}
+	if structural := embeddingFn(a, complex); structural <= 0 {
+		t.Fatalf("expected embedding to separate topologies, got %f", structural)
+	}
+	if embeddingFn(a, b) != embeddingFn(b, a) {
+		t.Fatal("expected symmetric embedding distance")
+	}
+}
+
+func TestGenomeDistanceFuncRejectsUnknownMetric(t *testing.T) {
+	if _, err := GenomeDistanceFunc("cosine"); err == nil {
+		t.Fatal("expected unknown metric error")
+	}
+	if _, err := GenomeDistanceFunc(""); err != nil {
+		t.Fatalf("expected empty metric to default to compatibility: %v", err)
+	}
+}
@@ -0,0 +1,353 @@
+package evo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"protogonos/internal/model"
+)
+
+const (
+	SpeciesAllocationProportional = "proportional"
+	SpeciesAllocationRank         = "rank"
+	SpeciesAllocationEqual        = "equal"
+)
+
+// SpeciesAllocationNames lists the offspring allocation policies.
+func SpeciesAllocationNames() []string {
+	return []string{SpeciesAllocationProportional, SpeciesAllocationRank, SpeciesAllocationEqual}
+}
+
+// ValidateSpeciesAllocation reports whether policy, floor and ceiling form a
+// usable offspring allocation. A zero ceiling means unbounded.
+func ValidateSpeciesAllocation(policy string, floor, ceiling int) error {
+	switch policy {
+	case "", SpeciesAllocationProportional, SpeciesAllocationRank, SpeciesAllocationEqual:
+	default:
+		return fmt.Errorf("unsupported species allocation: %s", policy)
+	}
+	if floor < 0 {
+		return fmt.Errorf("species allocation floor must be >= 0, got %d", floor)
+	}
+	if ceiling < 0 {
+		return fmt.Errorf("species allocation ceiling must be >= 0, got %d", ceiling)
+	}
+	if ceiling > 0 && floor > ceiling {
+		return fmt.Errorf("species allocation floor %d exceeds ceiling %d", floor, ceiling)
+	}
+	return nil
+}
+
+// ValidateSpeciesSoftCap reports whether a species soft cap and its release
+// hysteresis are usable. A zero cap disables soft capping.
+func ValidateSpeciesSoftCap(softCap int, hysteresis float64) error {
+	if softCap < 0 {
+		return fmt.Errorf("species soft cap must be >= 0, got %d", softCap)
+	}
+	if hysteresis < 0 || hysteresis >= 1 {
+		return fmt.Errorf("species cap hysteresis must be in [0,1), got %g", hysteresis)
+	}
+	if hysteresis > 0 && softCap == 0 {
+		return errors.New("species cap hysteresis requires a species soft cap")
+	}
+	return nil
+}
+
+// speciesAllocation selects how buildSpeciesOffspringPlan divides offspring
+// between species; see MonitorConfig.SpeciesAllocation.
+type speciesAllocation struct {
+	policy  string
+	floor   int
+	ceiling int
+}
+
+func (m *PopulationMonitor) speciesAllocation() speciesAllocation {
+	return speciesAllocation{
+		policy:  m.cfg.SpeciesAllocation,
+		floor:   m.cfg.AllocationFloor,
+		ceiling: m.cfg.AllocationCeiling,
+	}
+}
+
+type speciesQuota struct {
+	SpeciesKey string
+	Count      int
+}
+
+// buildSpeciesOffspringPlan divides totalOffspring between the species of
+// ranked. Each species first gets the floor (lowered to an even split when
+// the floor cannot be met for every species); the rest is shared by policy
+// weight with largest-remainder rounding, capping species at the ceiling and
+// re-sharing the overflow among the others. When every species is capped the
+// plan allots fewer than totalOffspring and the caller fills the remainder
+// from the whole pool.
+func buildSpeciesOffspringPlan(ranked []ScoredGenome, speciesByGenomeID map[string]string, totalOffspring int, allocation speciesAllocation) []speciesQuota {
+	if totalOffspring <= 0 || len(ranked) == 0 {
+		return nil
+	}
+	keys, weights := speciesAllocationWeights(ranked, speciesByGenomeID, allocation.policy)
+
+	counts := make(map[string]int, len(keys))
+	floor := min(allocation.floor, totalOffspring/len(keys))
+	for _, key := range keys {
+		counts[key] = floor
+	}
+	left := totalOffspring - floor*len(keys)
+	open := keys
+	for left > 0 && len(open) > 0 {
+		shares := largestRemainderShares(open, weights, left)
+		next := make([]string, 0, len(open))
+		for _, key := range open {
+			share := shares[key]
+			if allocation.ceiling > 0 && counts[key]+share >= allocation.ceiling {
+				share = allocation.ceiling - counts[key]
+			} else {
+				next = append(next, key)
+			}
+			counts[key] += share
+			left -= share
+		}
+		open = next
+	}
+
+	out := make([]speciesQuota, 0, len(keys))
+	for _, key := range keys {
+		if counts[key] <= 0 {
+			continue
+		}
+		out = append(out, speciesQuota{SpeciesKey: key, Count: counts[key]})
+	}
+	return out
+}
+
+// speciesAllocationWeights returns the sorted species keys of ranked and
+// their positive allocation weights: the mean fitness shifted to be positive
+// for proportional, 1..n by ascending mean fitness for rank, and 1 for equal.
+func speciesAllocationWeights(ranked []ScoredGenome, speciesByGenomeID map[string]string, policy string) ([]string, map[string]float64) {
+	type agg struct {
+		sum  float64
+		size int
+	}
+	byKey := map[string]*agg{}
+	for _, item := range ranked {
+		key := speciesByGenomeID[item.Genome.ID]
+		if key == "" {
+			key = "species:unknown"
+		}
+		if byKey[key] == nil {
+			byKey[key] = &agg{}
+		}
+		byKey[key].sum += item.Fitness
+		byKey[key].size++
+	}
+	keys := make([]string, 0, len(byKey))
+	means := make(map[string]float64, len(byKey))
+	for key, bucket := range byKey {
+		keys = append(keys, key)
+		means[key] = bucket.sum / float64(bucket.size)
+	}
+	sort.Strings(keys)
+
+	weights := make(map[string]float64, len(keys))
+	switch policy {
+	case SpeciesAllocationEqual:
+		for _, key := range keys {
+			weights[key] = 1
+		}
+	case SpeciesAllocationRank:
+		order := append([]string(nil), keys...)
+		sort.SliceStable(order, func(i, j int) bool {
+			return means[order[i]] > means[order[j]]
+		})
+		for i, key := range order {
+			weights[key] = float64(len(order) - i)
+		}
+	default:
+		minMean := means[keys[0]]
+		for _, key := range keys[1:] {
+			minMean = math.Min(minMean, means[key])
+		}
+		shift := 0.0
+		if minMean <= 0 {
+			shift = -minMean + 1e-9
+		}
+		total := 0.0
+		for _, key := range keys {
+			weights[key] = means[key] + shift
+			total += weights[key]
+		}
+		if total <= 0 {
+			for _, key := range keys {
+				weights[key] = 1
+			}
+		}
+	}
+	return keys, weights
+}
+
+// largestRemainderShares splits total between keys in proportion to weights,
+// rounding down and handing the leftover out by descending remainder with
+// ties broken by key.
+func largestRemainderShares(keys []string, weights map[string]float64, total int) map[string]int {
+	type alloc struct {
+		key       string
+		remainder float64
+	}
+	sum := 0.0
+	for _, key := range keys {
+		sum += weights[key]
+	}
+	shares := make(map[string]int, len(keys))
+	allocs := make([]alloc, 0, len(keys))
+	assigned := 0
+	for _, key := range keys {
+		share := weights[key] / sum * float64(total)
+		base := int(math.Floor(share))
+		shares[key] = base
+		allocs = append(allocs, alloc{key: key, remainder: share - float64(base)})
+		assigned += base
+	}
+	sort.Slice(allocs, func(i, j int) bool {
+		if allocs[i].remainder == allocs[j].remainder {
+			return allocs[i].key < allocs[j].key
+		}
+		return allocs[i].remainder > allocs[j].remainder
+	})
+	for i := 0; i < total-assigned; i++ {
+		shares[allocs[i%len(allocs)].key]++
+	}
+	return shares
+}
+
+// Species soft-cap actions reported in SpeciesRebalance.Action.
+const (
+	SpeciesRebalanceCapped   = "capped"
+	SpeciesRebalanceReleased = "released"
+	SpeciesRebalanceTrimmed  = "trimmed"
+	SpeciesRebalanceReceived = "received"
+)
+
+type SpeciesRebalance = model.SpeciesRebalance
+
+// applySpeciesSoftCap updates which species are capped from their sizes in
+// ranked, trims the quotas of capped species to the soft cap and re-shares
+// the excess among the other species of parentPool by allocation weight,
+// up to the cap and ceiling. The actions taken are kept for diagnostics.
+func (m *PopulationMonitor) applySpeciesSoftCap(plan []speciesQuota, ranked, parentPool []ScoredGenome, speciesByGenomeID map[string]string) []speciesQuota {
+	softCap := m.cfg.SpeciesSoftCap
+	if softCap <= 0 {
+		return plan
+	}
+	sizes := map[string]int{}
+	for _, item := range ranked {
+		key := speciesByGenomeID[item.Genome.ID]
+		if key == "" {
+			key = "species:unknown"
+		}
+		sizes[key]++
+	}
+	planned := make(map[string]int, len(plan))
+	for _, item := range plan {
+		planned[item.SpeciesKey] = item.Count
+	}
+
+	var actions []SpeciesRebalance
+	record := func(key, action string, allocated int) {
+		actions = append(actions, SpeciesRebalance{SpeciesKey: key, Action: action, Size: sizes[key], Planned: planned[key], Allocated: allocated})
+	}
+	release := int(math.Floor(float64(softCap) * (1 - m.cfg.SpeciesCapHysteresis)))
+	for key := range m.cappedSpecies {
+		if sizes[key] == 0 {
+			delete(m.cappedSpecies, key)
+		}
+	}
+	keys, weights := speciesAllocationWeights(parentPool, speciesByGenomeID, m.cfg.SpeciesAllocation)
+	for _, key := range keys {
+		switch {
+		case !m.cappedSpecies[key] && sizes[key] > softCap:
+			m.cappedSpecies[key] = true
+			record(key, SpeciesRebalanceCapped, planned[key])
+		case m.cappedSpecies[key] && sizes[key] <= release:
+			delete(m.cappedSpecies, key)
+			record(key, SpeciesRebalanceReleased, planned[key])
+		}
+	}
+
+	limit := softCap
+	if m.cfg.AllocationCeiling > 0 {
+		limit = min(limit, m.cfg.AllocationCeiling)
+	}
+	counts := make(map[string]int, len(keys))
+	excess := 0
+	var open []string
+	for _, key := range keys {
+		counts[key] = planned[key]
+		if m.cappedSpecies[key] {
+			if counts[key] > softCap {
+				excess += counts[key] - softCap
+				counts[key] = softCap
+			}
+			continue
+		}
+		if counts[key] < limit {
+			open = append(open, key)
+		}
+	}
+	trimmed := excess
+	for excess > 0 && len(open) > 0 {
+		shares := largestRemainderShares(open, weights, excess)
+		next := make([]string, 0, len(open))
+		for _, key := range open {
+			share := shares[key]
+			if counts[key]+share >= limit {
+				share = limit - counts[key]
+			} else {
+				next = append(next, key)
+			}
+			counts[key] += share
+			excess -= share
+		}
+		open = next
+	}
+	// The cap is soft: what no species could take goes back to the capped
+	// species in key order.
+	for _, key := range keys {
+		if excess == 0 {
+			break
+		}
+		if m.cappedSpecies[key] && planned[key] > counts[key] {
+			back := min(excess, planned[key]-counts[key])
+			counts[key] += back
+			excess -= back
+		}
+	}
+
+	out := make([]speciesQuota, 0, len(keys))
+	for _, key := range keys {
+		switch {
+		case trimmed > 0 && counts[key] < planned[key]:
+			record(key, SpeciesRebalanceTrimmed, counts[key])
+		case trimmed > 0 && counts[key] > planned[key]:
+			record(key, SpeciesRebalanceReceived, counts[key])
+		}
+		if counts[key] > 0 {
+			out = append(out, speciesQuota{SpeciesKey: key, Count: counts[key]})
+		}
+	}
+	m.lastRebalancing = actions
+	return out
+}
+
+// allocationByKey flattens a plan for diagnostics.
+func allocationByKey(plan []speciesQuota) map[string]int {
+	if len(plan) == 0 {
+		return nil
+	}
+	out := make(map[string]int, len(plan))
+	for _, item := range plan {
+		out[item.SpeciesKey] = item.Count
+	}
+	return out
+}
@@ -0,0 +1,154 @@
+package evo
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func allocationFixture() ([]ScoredGenome, map[string]string) {
+	ranked := []ScoredGenome{
+		{Genome: model.Genome{ID: "a0"}, Fitness: 9},
+		{Genome: model.Genome{ID: "a1"}, Fitness: 7},
+		{Genome: model.Genome{ID: "b0"}, Fitness: 2},
+		{Genome: model.Genome{ID: "b1"}, Fitness: 2},
+		{Genome: model.Genome{ID: "c0"}, Fitness: 0},
+	}
+	speciesByGenomeID := map[string]string{"a0": "sp-a", "a1": "sp-a", "b0": "sp-b", "b1": "sp-b", "c0": "sp-c"}
+	return ranked, speciesByGenomeID
+}
+
+func TestSpeciesOffspringPlanPolicies(t *testing.T) {
+	ranked, speciesByGenomeID := allocationFixture()
+	cases := []struct {
+		name       string
+		allocation speciesAllocation
+		want       map[string]int
+	}{
+		// Means 8, 2, 0 (shifted by 1e-9): 12 offspring split 9.6/2.4/0.
+		{name: "proportional", allocation: speciesAllocation{}, want: map[string]int{"sp-a": 10, "sp-b": 2}},
+		// Rank weights 3, 2, 1 over 12: 6/4/2.
+		{name: "rank", allocation: speciesAllocation{policy: SpeciesAllocationRank}, want: map[string]int{"sp-a": 6, "sp-b": 4, "sp-c": 2}},
+		{name: "equal", allocation: speciesAllocation{policy: SpeciesAllocationEqual}, want: map[string]int{"sp-a": 4, "sp-b": 4, "sp-c": 4}},
+		// The floor keeps sp-c breeding; the other 9 split 7.2/1.8/0.
+		{name: "floor", allocation: speciesAllocation{floor: 1}, want: map[string]int{"sp-a": 8, "sp-b": 3, "sp-c": 1}},
+		// The ceiling caps sp-a and re-shares the overflow.
+		{name: "ceiling", allocation: speciesAllocation{ceiling: 5}, want: map[string]int{"sp-a": 5, "sp-b": 5, "sp-c": 2}},
+		// Every species capped: the plan falls short of the total.
+		{name: "capped", allocation: speciesAllocation{policy: SpeciesAllocationEqual, ceiling: 3}, want: map[string]int{"sp-a": 3, "sp-b": 3, "sp-c": 3}},
+	}
+	for _, tc := range cases {
+		got := allocationByKey(buildSpeciesOffspringPlan(ranked, speciesByGenomeID, 12, tc.allocation))
+		if len(got) != len(tc.want) {
+			t.Fatalf("%s: want %v, got %v", tc.name, tc.want, got)
+		}
+		for key, count := range tc.want {
+			if got[key] != count {
+				t.Fatalf("%s: want %v, got %v", tc.name, tc.want, got)
+			}
+		}
+	}
+
+	// A floor that cannot be met for every species drops to an even split.
+	got := allocationByKey(buildSpeciesOffspringPlan(ranked, speciesByGenomeID, 4, speciesAllocation{floor: 2}))
+	if got["sp-a"]+got["sp-b"]+got["sp-c"] != 4 || got["sp-c"] < 1 {
+		t.Fatalf("expected lowered floor to keep every species, got %v", got)
+	}
+}
+
+func TestSpeciesSoftCapRebalancesWithHysteresis(t *testing.T) {
+	monitor := &PopulationMonitor{
+		cfg:           MonitorConfig{SpeciesAllocation: SpeciesAllocationRank, SpeciesSoftCap: 3, SpeciesCapHysteresis: 0.5},
+		cappedSpecies: map[string]bool{},
+	}
+	population := func(sizeA int) ([]ScoredGenome, map[string]string) {
+		ranked := []ScoredGenome{}
+		speciesByGenomeID := map[string]string{"b0": "sp-b", "c0": "sp-c"}
+		for i := 0; i < sizeA; i++ {
+			id := fmt.Sprintf("a%d", i)
+			ranked = append(ranked, ScoredGenome{Genome: model.Genome{ID: id}, Fitness: float64(9 - i)})
+			speciesByGenomeID[id] = "sp-a"
+		}
+		ranked = append(ranked,
+			ScoredGenome{Genome: model.Genome{ID: "b0"}, Fitness: 2},
+			ScoredGenome{Genome: model.Genome{ID: "c0"}, Fitness: 0},
+		)
+		return ranked, speciesByGenomeID
+	}
+	rebalance := func(sizeA int) map[string]int {
+		ranked, speciesByGenomeID := population(sizeA)
+		plan := buildSpeciesOffspringPlan(ranked, speciesByGenomeID, 12, monitor.speciesAllocation())
+		return allocationByKey(monitor.applySpeciesSoftCap(plan, ranked, ranked, speciesByGenomeID))
+	}
+
+	// Rank weights 3/2/1 plan 6/4/2. sp-a (4 > 3) is capped and trimmed;
+	// sp-c takes one offspring up to the cap and, as sp-b is already at it,
+	// the other two stay with sp-a.
+	got := rebalance(4)
+	if got["sp-a"] != 5 || got["sp-b"] != 4 || got["sp-c"] != 3 {
+		t.Fatalf("expected 5/4/3 after rebalancing, got %v", got)
+	}
+	want := []SpeciesRebalance{
+		{SpeciesKey: "sp-a", Action: SpeciesRebalanceCapped, Size: 4, Planned: 6, Allocated: 6},
+		{SpeciesKey: "sp-a", Action: SpeciesRebalanceTrimmed, Size: 4, Planned: 6, Allocated: 5},
+		{SpeciesKey: "sp-c", Action: SpeciesRebalanceReceived, Size: 1, Planned: 2, Allocated: 3},
+	}
+	if !reflect.DeepEqual(monitor.lastRebalancing, want) {
+		t.Fatalf("expected actions %+v, got %+v", want, monitor.lastRebalancing)
+	}
+
+	// Within the hysteresis band (release at 1) sp-a stays capped.
+	rebalance(2)
+	if !monitor.cappedSpecies["sp-a"] || len(monitor.lastRebalancing) == 0 || monitor.lastRebalancing[0].Action != SpeciesRebalanceTrimmed {
+		t.Fatalf("expected sp-a to stay capped and trimmed, got %+v", monitor.lastRebalancing)
+	}
+	rebalance(1)
+	if monitor.cappedSpecies["sp-a"] || len(monitor.lastRebalancing) != 1 || monitor.lastRebalancing[0].Action != SpeciesRebalanceReleased {
+		t.Fatalf("expected sp-a to be released, got %+v", monitor.lastRebalancing)
+	}
+}
+
+func TestValidateSpeciesAllocation(t *testing.T) {
+	for _, policy := range SpeciesAllocationNames() {
+		if err := ValidateSpeciesAllocation(policy, 1, 3); err != nil {
+			t.Fatalf("%s: %v", policy, err)
+		}
+	}
+	for _, tc := range []struct {
+		policy         string
+		floor, ceiling int
+	}{
+		{policy: "lottery"},
+		{policy: SpeciesAllocationEqual, floor: -1},
+		{policy: SpeciesAllocationEqual, ceiling: -1},
+		{policy: SpeciesAllocationEqual, floor: 4, ceiling: 3},
+	} {
+		if err := ValidateSpeciesAllocation(tc.policy, tc.floor, tc.ceiling); err == nil {
+			t.Fatalf("expected %+v to be rejected", tc)
+		}
+	}
+}
+
+func TestValidateSpeciesSoftCap(t *testing.T) {
+	if err := ValidateSpeciesSoftCap(0, 0); err != nil {
+		t.Fatalf("disabled cap: %v", err)
+	}
+	if err := ValidateSpeciesSoftCap(8, 0.25); err != nil {
+		t.Fatalf("cap with hysteresis: %v", err)
+	}
+	for _, tc := range []struct {
+		softCap    int
+		hysteresis float64
+	}{
+		{softCap: -1},
+		{softCap: 8, hysteresis: 1},
+		{softCap: 8, hysteresis: -0.1},
+		{hysteresis: 0.2},
+	} {
+		if err := ValidateSpeciesSoftCap(tc.softCap, tc.hysteresis); err == nil {
+			t.Fatalf("expected %+v to be rejected", tc)
+		}
+	}
+}
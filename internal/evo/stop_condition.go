@@ -0,0 +1,310 @@
+package evo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// StopState is what a stop condition sees after each generation.
+type StopState struct {
+	// Best and Mean are the generation's best and mean fitness.
+	Best float64
+	Mean float64
+	// Evaluations counts genome evaluations of this run so far.
+	Evaluations int
+	// Generation is the logical generation just evaluated.
+	Generation int
+	// Stagnation counts generations since the run's best fitness last
+	// improved.
+	Stagnation int
+	// Species is the generation's species count.
+	Species int
+	// ElapsedSeconds is the wall-clock time since the run started.
+	ElapsedSeconds float64
+}
+
+// StopVariables lists the variables a stop condition may reference.
+func StopVariables() []string {
+	return []string{"best", "mean", "evals", "generation", "stagnation", "species", "elapsed"}
+}
+
+func (s StopState) value(name string) float64 {
+	switch name {
+	case "best":
+		return s.Best
+	case "mean":
+		return s.Mean
+	case "evals":
+		return float64(s.Evaluations)
+	case "generation":
+		return float64(s.Generation)
+	case "stagnation":
+		return float64(s.Stagnation)
+	case "species":
+		return float64(s.Species)
+	default:
+		return s.ElapsedSeconds
+	}
+}
+
+// StopCondition is a compiled compound stop condition such as
+//
+//	best>=0.99 || (evals>=200000 && stagnation>=30)
+//
+// Comparisons (<, <=, >, >=, ==, !=) relate a variable from StopVariables
+// to a number or another variable, and combine with &&, || and ! (tightest
+// first: !, &&, ||) and parentheses.
+type StopCondition struct {
+	source string
+	root   stopNode
+}
+
+// ParseStopCondition compiles expr.
+func ParseStopCondition(expr string) (*StopCondition, error) {
+	tokens, err := tokenizeStopCondition(expr)
+	if err != nil {
+		return nil, fmt.Errorf("stop condition %q: %w", expr, err)
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("stop condition is empty")
+	}
+	p := &stopParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err == nil && p.pos < len(p.tokens) {
+		err = fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("stop condition %q: %w", expr, err)
+	}
+	return &StopCondition{source: strings.TrimSpace(expr), root: root}, nil
+}
+
+func (c *StopCondition) String() string {
+	return c.source
+}
+
+// Met reports whether the condition holds for state.
+func (c *StopCondition) Met(state StopState) bool {
+	return c.root.eval(state)
+}
+
+type stopNode interface {
+	eval(StopState) bool
+}
+
+type stopOr struct{ left, right stopNode }
+
+func (n stopOr) eval(s StopState) bool { return n.left.eval(s) || n.right.eval(s) }
+
+type stopAnd struct{ left, right stopNode }
+
+func (n stopAnd) eval(s StopState) bool { return n.left.eval(s) && n.right.eval(s) }
+
+type stopNot struct{ operand stopNode }
+
+func (n stopNot) eval(s StopState) bool { return !n.operand.eval(s) }
+
+// stopOperand is a variable reference when name is set, otherwise a number.
+type stopOperand struct {
+	name  string
+	value float64
+}
+
+func (o stopOperand) resolve(s StopState) float64 {
+	if o.name != "" {
+		return s.value(o.name)
+	}
+	return o.value
+}
+
+type stopComparison struct {
+	left, right stopOperand
+	op          string
+}
+
+func (n stopComparison) eval(s StopState) bool {
+	left, right := n.left.resolve(s), n.right.resolve(s)
+	switch n.op {
+	case "<":
+		return left < right
+	case "<=":
+		return left <= right
+	case ">":
+		return left > right
+	case ">=":
+		return left >= right
+	case "==":
+		return left == right
+	default:
+		return left != right
+	}
+}
+
+type stopTokenKind int
+
+const (
+	stopTokenIdent stopTokenKind = iota
+	stopTokenNumber
+	stopTokenOp
+)
+
+type stopToken struct {
+	kind stopTokenKind
+	text string
+}
+
+func tokenizeStopCondition(expr string) ([]stopToken, error) {
+	var tokens []stopToken
+	for i := 0; i < len(expr); {
+		ch := rune(expr[i])
+		switch {
+		case unicode.IsSpace(ch):
+			i++
+		case unicode.IsLetter(ch) || ch == '_':
+			start := i
+			for i < len(expr) && (unicode.IsLetter(rune(expr[i])) || unicode.IsDigit(rune(expr[i])) || expr[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, stopToken{kind: stopTokenIdent, text: strings.ToLower(expr[start:i])})
+		case unicode.IsDigit(ch) || ch == '.':
+			start := i
+			for i < len(expr) && (unicode.IsDigit(rune(expr[i])) || expr[i] == '.' || expr[i] == 'e' || expr[i] == 'E' ||
+				((expr[i] == '+' || expr[i] == '-') && (expr[i-1] == 'e' || expr[i-1] == 'E'))) {
+				i++
+			}
+			tokens = append(tokens, stopToken{kind: stopTokenNumber, text: expr[start:i]})
+		default:
+			op := ""
+			for _, candidate := range []string{"&&", "||", ">=", "<=", "==", "!=", ">", "<", "!", "(", ")"} {
+				if strings.HasPrefix(expr[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q", ch)
+			}
+			tokens = append(tokens, stopToken{kind: stopTokenOp, text: op})
+			i += len(op)
+		}
+	}
+	return tokens, nil
+}
+
+type stopParser struct {
+	tokens []stopToken
+	pos    int
+}
+
+func (p *stopParser) peekOp(op string) bool {
+	return p.pos < len(p.tokens) && p.tokens[p.pos].kind == stopTokenOp && p.tokens[p.pos].text == op
+}
+
+func (p *stopParser) parseOr() (stopNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekOp("||") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = stopOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *stopParser) parseAnd() (stopNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekOp("&&") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = stopAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *stopParser) parseUnary() (stopNode, error) {
+	switch {
+	case p.peekOp("!"):
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return stopNot{operand: operand}, nil
+	case p.peekOp("("):
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.peekOp(")") {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return inner, nil
+	default:
+		return p.parseComparison()
+	}
+}
+
+func (p *stopParser) parseComparison() (stopNode, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != stopTokenOp {
+		return nil, fmt.Errorf("expected comparison after %q", p.tokens[p.pos-1].text)
+	}
+	op := p.tokens[p.pos].text
+	switch op {
+	case "<", "<=", ">", ">=", "==", "!=":
+	default:
+		return nil, fmt.Errorf("expected comparison, got %q", op)
+	}
+	p.pos++
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	if left.name == "" && right.name == "" {
+		return nil, fmt.Errorf("comparison %g %s %g references no variable", left.value, op, right.value)
+	}
+	return stopComparison{left: left, right: right, op: op}, nil
+}
+
+func (p *stopParser) parseOperand() (stopOperand, error) {
+	if p.pos >= len(p.tokens) {
+		return stopOperand{}, fmt.Errorf("unexpected end of condition")
+	}
+	token := p.tokens[p.pos]
+	p.pos++
+	switch token.kind {
+	case stopTokenIdent:
+		for _, name := range StopVariables() {
+			if token.text == name {
+				return stopOperand{name: name}, nil
+			}
+		}
+		return stopOperand{}, fmt.Errorf("unknown variable %q (want one of %s)", token.text, strings.Join(StopVariables(), ", "))
+	case stopTokenNumber:
+		value, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return stopOperand{}, fmt.Errorf("invalid number %q", token.text)
+		}
+		return stopOperand{value: value}, nil
+	default:
+		return stopOperand{}, fmt.Errorf("expected variable or number, got %q", token.text)
+	}
+}
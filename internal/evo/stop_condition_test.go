@@ -0,0 +1,128 @@
+package evo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func TestStopConditionEvaluatesCompoundExpression(t *testing.T) {
+	cond, err := ParseStopCondition("best>=0.99 || (evals>=200000 && stagnation>=30)")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	cases := []struct {
+		state StopState
+		want  bool
+	}{
+		{StopState{Best: 0.995}, true},
+		{StopState{Best: 0.5, Evaluations: 250000, Stagnation: 10}, false},
+		{StopState{Best: 0.5, Evaluations: 250000, Stagnation: 30}, true},
+		{StopState{Best: 0.5, Evaluations: 1000, Stagnation: 50}, false},
+	}
+	for _, tc := range cases {
+		if got := cond.Met(tc.state); got != tc.want {
+			t.Fatalf("Met(%+v)=%t, want %t", tc.state, got, tc.want)
+		}
+	}
+	if cond.String() != "best>=0.99 || (evals>=200000 && stagnation>=30)" {
+		t.Fatalf("unexpected source: %q", cond.String())
+	}
+}
+
+func TestStopConditionPrecedenceAndOperators(t *testing.T) {
+	cases := []struct {
+		expr  string
+		state StopState
+		want  bool
+	}{
+		{"generation>=3 || species<2 && mean>0.5", StopState{Generation: 1, Species: 1, Mean: 0.1}, false},
+		{"(generation>=3 || species<2) && mean>0.5", StopState{Generation: 5, Mean: 0.1}, false},
+		{"!(best<1e-1) && elapsed<=60", StopState{Best: 0.2, ElapsedSeconds: 60}, true},
+		{"best == mean", StopState{Best: 0.3, Mean: 0.3}, true},
+		{"species != 4", StopState{Species: 4}, false},
+		{"2.5e2 < evals", StopState{Evaluations: 251}, true},
+		{"EVALS > 10", StopState{Evaluations: 11}, true},
+	}
+	for _, tc := range cases {
+		cond, err := ParseStopCondition(tc.expr)
+		if err != nil {
+			t.Fatalf("parse %q: %v", tc.expr, err)
+		}
+		if got := cond.Met(tc.state); got != tc.want {
+			t.Fatalf("%q on %+v = %t, want %t", tc.expr, tc.state, got, tc.want)
+		}
+	}
+}
+
+func TestParseStopConditionRejectsInvalidExpressions(t *testing.T) {
+	cases := map[string]string{
+		"":                       "empty",
+		"fitness>=1":             "unknown variable",
+		"best>=":                 "unexpected end",
+		"best":                   "expected comparison",
+		"(best>=1":               "missing closing parenthesis",
+		"best>=1 evals>=2":       "unexpected",
+		"1 < 2":                  "references no variable",
+		"best>=1 & evals>=2":     "unexpected character",
+		"best>=1 || && evals>=2": "expected variable or number",
+		"best>=1.2.3":            "invalid number",
+	}
+	for expr, want := range cases {
+		_, err := ParseStopCondition(expr)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("ParseStopCondition(%q) error=%v, want %q", expr, err, want)
+		}
+	}
+}
+
+func TestPopulationMonitorStopsOnStopCondition(t *testing.T) {
+	initial := []model.Genome{
+		newLinearGenome("g0", -1.0),
+		newLinearGenome("g1", -0.8),
+		newLinearGenome("g2", -0.6),
+		newLinearGenome("g3", -0.4),
+	}
+
+	// The noop mutation never improves the champion, so stagnation grows by
+	// one each generation. The evaluations limit is superseded by the
+	// condition and would otherwise stop the run after one generation.
+	monitor, err := NewPopulationMonitor(MonitorConfig{
+		Scape:            oneDimScape{},
+		Mutation:         namedNoopMutation{name: "noop"},
+		PopulationSize:   len(initial),
+		EliteCount:       1,
+		Generations:      6,
+		EvaluationsLimit: len(initial),
+		StopCondition:    "best>=10 || stagnation>=2",
+		Workers:          2,
+		Seed:             1,
+		InputNeuronIDs:   []string{"i"},
+		OutputNeuronIDs:  []string{"o"},
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+
+	result, err := monitor.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(result.BestByGeneration) != 3 {
+		t.Fatalf("expected stop after three generations, got %d", len(result.BestByGeneration))
+	}
+
+	if _, err := NewPopulationMonitor(MonitorConfig{
+		Scape:           oneDimScape{},
+		Mutation:        namedNoopMutation{name: "noop"},
+		PopulationSize:  len(initial),
+		Generations:     1,
+		StopCondition:   "best>>1",
+		InputNeuronIDs:  []string{"i"},
+		OutputNeuronIDs: []string{"o"},
+	}); err == nil {
+		t.Fatal("expected invalid stop condition to be rejected")
+	}
+}
@@ -0,0 +1,55 @@
+package evo
+
+import (
+	"fmt"
+	"strconv"
+
+	"protogonos/internal/model"
+	"protogonos/internal/tuning"
+)
+
+type StrategyDistribution = model.StrategyDistribution
+
+// EffectiveStrategy returns the strategy genome evolves under: its own
+// StrategyConfig with unset fields filled by the defaults mutation applies.
+// The genome is not modified.
+func EffectiveStrategy(genome model.Genome) model.StrategyConfig {
+	var strategy model.StrategyConfig
+	if genome.Strategy != nil {
+		strategy = *genome.Strategy
+	}
+	probe := model.Genome{Strategy: &strategy}
+	ensureStrategyConfig(&probe)
+	strategy.TuningSelection = tuning.NormalizeCandidateSelectionName(strategy.TuningSelection)
+	return strategy
+}
+
+// ComputeStrategyDistribution counts genomes by their effective strategy
+// values. It returns nil for an empty slice.
+func ComputeStrategyDistribution(genomes []model.Genome) *StrategyDistribution {
+	if len(genomes) == 0 {
+		return nil
+	}
+	dist := &StrategyDistribution{
+		TuningSelection: map[string]int{},
+		AnnealingFactor: map[string]int{},
+		TopologicalMode: map[string]int{},
+		HeredityType:    map[string]int{},
+	}
+	for _, genome := range genomes {
+		strategy := EffectiveStrategy(genome)
+		dist.TuningSelection[strategy.TuningSelection]++
+		dist.AnnealingFactor[strconv.FormatFloat(strategy.AnnealingFactor, 'g', -1, 64)]++
+		dist.TopologicalMode[fmt.Sprintf("%s(%g)", strategy.TopologicalMode, strategy.TopologicalParam)]++
+		dist.HeredityType[strategy.HeredityType]++
+	}
+	return dist
+}
+
+func (m *PopulationMonitor) annotateStrategies(diag *GenerationDiagnostics, ranked []ScoredGenome) {
+	genomes := make([]model.Genome, 0, len(ranked))
+	for _, item := range ranked {
+		genomes = append(genomes, item.Genome)
+	}
+	diag.Strategies = ComputeStrategyDistribution(genomes)
+}
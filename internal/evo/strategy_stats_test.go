@@ -0,0 +1,60 @@
+package evo
+
+import (
+	"testing"
+
+	"protogonos/internal/model"
+	"protogonos/internal/tuning"
+)
+
+func TestComputeStrategyDistribution(t *testing.T) {
+	if dist := ComputeStrategyDistribution(nil); dist != nil {
+		t.Fatalf("expected nil distribution for no genomes, got %+v", dist)
+	}
+	genomes := []model.Genome{
+		{ID: "default"},
+		{ID: "partial", Strategy: &model.StrategyConfig{TuningSelection: tuning.CandidateSelectDynamicA, AnnealingFactor: 0.5}},
+		{ID: "full", Strategy: &model.StrategyConfig{
+			TuningSelection:  tuning.CandidateSelectDynamicA,
+			AnnealingFactor:  0.5,
+			TopologicalMode:  "ncount_exponential",
+			TopologicalParam: 0.8,
+			HeredityType:     "darwinian",
+		}},
+	}
+	dist := ComputeStrategyDistribution(genomes)
+	if dist.TuningSelection[tuning.CandidateSelectBestSoFar] != 1 || dist.TuningSelection[tuning.CandidateSelectDynamicA] != 2 {
+		t.Fatalf("unexpected tuning selection counts: %v", dist.TuningSelection)
+	}
+	if dist.AnnealingFactor["1"] != 1 || dist.AnnealingFactor["0.5"] != 2 {
+		t.Fatalf("unexpected annealing counts: %v", dist.AnnealingFactor)
+	}
+	if dist.TopologicalMode["const(1)"] != 2 || dist.TopologicalMode["ncount_exponential(0.8)"] != 1 {
+		t.Fatalf("unexpected topological mode counts: %v", dist.TopologicalMode)
+	}
+	if dist.HeredityType["asexual"] != 2 || dist.HeredityType["darwinian"] != 1 {
+		t.Fatalf("unexpected heredity counts: %v", dist.HeredityType)
+	}
+	if genomes[0].Strategy != nil || genomes[1].Strategy.TopologicalMode != "" {
+		t.Fatal("expected strategy distribution to leave genomes unchanged")
+	}
+}
+
+func TestSummarizeSpeciesGenerationRecordsStrategies(t *testing.T) {
+	ranked := []ScoredGenome{
+		{Genome: model.Genome{ID: "a0", Strategy: &model.StrategyConfig{HeredityType: "darwinian"}}, Fitness: 2},
+		{Genome: model.Genome{ID: "a1"}, Fitness: 1},
+		{Genome: model.Genome{ID: "b0"}, Fitness: 0},
+	}
+	history, _ := summarizeSpeciesGeneration(ranked, map[string]string{"a0": "sp-a", "a1": "sp-a", "b0": "sp-b"}, 1, nil)
+	if len(history.Species) != 2 {
+		t.Fatalf("expected two species, got %+v", history.Species)
+	}
+	a, b := history.Species[0].Strategies, history.Species[1].Strategies
+	if a == nil || a.HeredityType["darwinian"] != 1 || a.HeredityType["asexual"] != 1 {
+		t.Fatalf("unexpected sp-a strategies: %+v", a)
+	}
+	if b == nil || b.HeredityType["asexual"] != 1 || len(b.HeredityType) != 1 {
+		t.Fatalf("unexpected sp-b strategies: %+v", b)
+	}
+}
@@ -0,0 +1,127 @@
+package evo
+
+import (
+	"math"
+	"sort"
+
+	"protogonos/internal/model"
+)
+
+type WeightStats = model.WeightStats
+
+type LayerWeightStats = model.LayerWeightStats
+
+// WeightSaturationLimit matches the cortex weight clamp applied during
+// tuning perturbation; mutation does not clamp, so weights at or beyond it are
+// counted as saturated.
+const WeightSaturationLimit = math.Pi * 10
+
+const weightHistogramBins = 10
+
+// ComputeWeightStats summarizes weights. It returns the zero value for an
+// empty slice.
+func ComputeWeightStats(weights []float64) WeightStats {
+	if len(weights) == 0 {
+		return WeightStats{}
+	}
+	sorted := append([]float64(nil), weights...)
+	sort.Float64s(sorted)
+
+	var sum, sumSq float64
+	saturated := 0
+	histogram := make([]int, weightHistogramBins)
+	binWidth := 2 * WeightSaturationLimit / weightHistogramBins
+	for _, w := range sorted {
+		sum += w
+		sumSq += w * w
+		if math.Abs(w) >= WeightSaturationLimit {
+			saturated++
+		}
+		bin := int((w + WeightSaturationLimit) / binWidth)
+		bin = max(0, min(weightHistogramBins-1, bin))
+		histogram[bin]++
+	}
+	n := float64(len(sorted))
+	mean := sum / n
+	return WeightStats{
+		Count:             len(sorted),
+		Mean:              mean,
+		Std:               math.Sqrt(math.Max(0, sumSq/n-mean*mean)),
+		Min:               sorted[0],
+		Max:               sorted[len(sorted)-1],
+		P05:               weightPercentile(sorted, 0.05),
+		P50:               weightPercentile(sorted, 0.50),
+		P95:               weightPercentile(sorted, 0.95),
+		SaturatedFraction: float64(saturated) / n,
+		Histogram:         histogram,
+	}
+}
+
+// PopulationWeightStats pools the enabled synapse weights of every genome.
+func PopulationWeightStats(genomes []model.Genome) WeightStats {
+	var weights []float64
+	for _, genome := range genomes {
+		for _, synapse := range genome.Synapses {
+			if synapse.Enabled {
+				weights = append(weights, synapse.Weight)
+			}
+		}
+	}
+	return ComputeWeightStats(weights)
+}
+
+// LayerWeightBreakdown groups a genome's enabled weights by the inferred
+// feedforward layer of their target neuron, in ascending layer order.
+func LayerWeightBreakdown(genome model.Genome, inputNeuronIDs, outputNeuronIDs []string) []LayerWeightStats {
+	layers := inferFeedforwardLayers(genome, inputNeuronIDs, outputNeuronIDs)
+	weightsByLayer := map[int][]float64{}
+	neuronsByLayer := map[int]int{}
+	for _, neuron := range genome.Neurons {
+		neuronsByLayer[layers[neuron.ID]]++
+	}
+	for _, synapse := range genome.Synapses {
+		if !synapse.Enabled {
+			continue
+		}
+		layer, ok := layers[synapse.To]
+		if !ok {
+			continue
+		}
+		weightsByLayer[layer] = append(weightsByLayer[layer], synapse.Weight)
+	}
+	order := make([]int, 0, len(weightsByLayer))
+	for layer := range weightsByLayer {
+		order = append(order, layer)
+	}
+	sort.Ints(order)
+	out := make([]LayerWeightStats, 0, len(order))
+	for _, layer := range order {
+		out = append(out, LayerWeightStats{
+			Layer:       layer,
+			Neurons:     neuronsByLayer[layer],
+			WeightStats: ComputeWeightStats(weightsByLayer[layer]),
+		})
+	}
+	return out
+}
+
+func (m *PopulationMonitor) annotateWeightStats(diag *GenerationDiagnostics, ranked []ScoredGenome) {
+	if len(ranked) == 0 {
+		return
+	}
+	genomes := make([]model.Genome, 0, len(ranked))
+	for _, item := range ranked {
+		genomes = append(genomes, item.Genome)
+	}
+	population := PopulationWeightStats(genomes)
+	if population.Count == 0 {
+		return
+	}
+	diag.PopulationWeights = &population
+	diag.ChampionLayerWeights = LayerWeightBreakdown(ranked[0].Genome, m.cfg.InputNeuronIDs, m.cfg.OutputNeuronIDs)
+}
+
+func weightPercentile(sorted []float64, q float64) float64 {
+	idx := int(q * float64(len(sorted)-1))
+	return sorted[idx]
+}
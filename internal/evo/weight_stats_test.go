@@ -0,0 +1,91 @@
+package evo
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func TestComputeWeightStats(t *testing.T) {
+	weights := []float64{-2, -1, 0, 1, 2, WeightSaturationLimit, -40}
+	stats := ComputeWeightStats(weights)
+	if stats.Count != len(weights) || stats.Min != -40 || stats.Max != WeightSaturationLimit || stats.P50 != 0 {
+		t.Fatalf("unexpected order statistics: %+v", stats)
+	}
+	if math.Abs(stats.SaturatedFraction-2.0/7.0) > 1e-9 {
+		t.Fatalf("expected 2/7 saturated weights, got %f", stats.SaturatedFraction)
+	}
+	total := 0
+	for _, count := range stats.Histogram {
+		total += count
+	}
+	if len(stats.Histogram) != weightHistogramBins || total != len(weights) {
+		t.Fatalf("expected every weight in a histogram bin, got %v", stats.Histogram)
+	}
+	if stats.Histogram[0] != 1 || stats.Histogram[weightHistogramBins-1] != 1 {
+		t.Fatalf("expected out-of-range weights in the end bins, got %v", stats.Histogram)
+	}
+	if empty := ComputeWeightStats(nil); empty.Count != 0 || empty.Histogram != nil {
+		t.Fatalf("expected zero stats for no weights, got %+v", empty)
+	}
+}
+
+func TestLayerWeightBreakdownGroupsByTargetLayer(t *testing.T) {
+	genome := model.Genome{
+		Neurons: []model.Neuron{{ID: "i"}, {ID: "h"}, {ID: "o"}},
+		Synapses: []model.Synapse{
+			{ID: "s1", From: "i", To: "h", Weight: 0.5, Enabled: true},
+			{ID: "s2", From: "h", To: "o", Weight: -3, Enabled: true},
+			{ID: "s3", From: "i", To: "o", Weight: 100, Enabled: false},
+		},
+	}
+	layers := LayerWeightBreakdown(genome, []string{"i"}, []string{"o"})
+	if len(layers) != 2 {
+		t.Fatalf("expected hidden and output layers, got %+v", layers)
+	}
+	if layers[0].Layer != 1 || layers[0].Count != 1 || layers[0].Mean != 0.5 {
+		t.Fatalf("unexpected hidden layer stats: %+v", layers[0])
+	}
+	if layers[1].Layer != 2 || layers[1].Count != 1 || layers[1].Mean != -3 {
+		t.Fatalf("expected disabled synapse to be ignored in output layer: %+v", layers[1])
+	}
+}
+
+func TestPopulationMonitorRecordsWeightStats(t *testing.T) {
+	initial := []model.Genome{
+		newLinearGenome("g0", -1.0),
+		newLinearGenome("g1", 0.5),
+		newLinearGenome("g2", 40),
+		newLinearGenome("g3", 1.0),
+	}
+	monitor, err := NewPopulationMonitor(MonitorConfig{
+		Scape:           oneDimScape{},
+		Mutation:        PerturbWeightAt{Index: 0, Delta: 0.1},
+		PopulationSize:  len(initial),
+		EliteCount:      1,
+		Generations:     2,
+		Workers:         2,
+		Seed:            3,
+		InputNeuronIDs:  []string{"i"},
+		OutputNeuronIDs: []string{"o"},
+	})
+	if err != nil {
+		t.Fatalf("new monitor: %v", err)
+	}
+	result, err := monitor.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	first := result.GenerationDiagnostics[0]
+	if first.PopulationWeights == nil || first.PopulationWeights.Count != len(initial) {
+		t.Fatalf("expected population weight stats over every genome, got %+v", first.PopulationWeights)
+	}
+	if first.PopulationWeights.SaturatedFraction != 0.25 {
+		t.Fatalf("expected the exploded weight to be flagged as saturated, got %f", first.PopulationWeights.SaturatedFraction)
+	}
+	if len(first.ChampionLayerWeights) != 1 || first.ChampionLayerWeights[0].Layer != 2 {
+		t.Fatalf("expected champion output-layer breakdown, got %+v", first.ChampionLayerWeights)
+	}
+}
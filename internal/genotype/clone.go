@@ -53,6 +53,7 @@ func CloneGenome(g model.Genome) model.Genome {
 		}
 		out.Substrate = &sub
 	}
+	out.Grammar = CloneGrammarConfig(g.Grammar)
 	if g.Plasticity != nil {
 		p := *g.Plasticity
 		out.Plasticity = &p
@@ -61,9 +62,35 @@ func CloneGenome(g model.Genome) model.Genome {
 		s := *g.Strategy
 		out.Strategy = &s
 	}
+	if g.Annotations != nil {
+		out.Annotations = make(map[string]string, len(g.Annotations))
+		for k, v := range g.Annotations {
+			out.Annotations[k] = v
+		}
+	}
+	if g.Provenance != nil {
+		prov := *g.Provenance
+		prov.Notes = append([]string(nil), g.Provenance.Notes...)
+		out.Provenance = &prov
+	}
 	return out
 }
 
+// CloneGrammarConfig deep-copies a grammar genotype; nil stays nil.
+func CloneGrammarConfig(cfg *model.GrammarConfig) *model.GrammarConfig {
+	if cfg == nil {
+		return nil
+	}
+	out := *cfg
+	out.Rules = append([]model.GrammarRule(nil), cfg.Rules...)
+	out.Cells = append([]model.GrammarCell(nil), cfg.Cells...)
+	for i := range out.Cells {
+		out.Cells[i].InputWeights = append([]float64(nil), cfg.Cells[i].InputWeights...)
+		out.Cells[i].OutputWeights = append([]float64(nil), cfg.Cells[i].OutputWeights...)
+	}
+	return &out
+}
+
 // CloneGenomeWithRemappedIDs clones a genome and remaps neuron/synapse IDs while
 // preserving connectivity. Neuron IDs listed in preserveNeuronIDs are kept
 // unchanged so callers can retain stable input/output anchors.
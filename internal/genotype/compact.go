@@ -0,0 +1,532 @@
+package genotype
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"protogonos/internal/model"
+)
+
+// Compact genome encoding: a 4-byte magic, a version and a quantization byte,
+// then a deflated payload. The payload holds the genome's non-graph fields as
+// JSON, the neurons, the synapses with their endpoints as delta-coded neuron
+// indexes, and the biases and weights as one block each in the chosen
+// quantization. Integer quantizations scale each block by its largest
+// magnitude and store successive differences as varints.
+const (
+	compactMagic   = "PGNC"
+	compactVersion = 1
+)
+
+// Weight quantizations of the compact encoding; float64 is lossless.
+const (
+	QuantizeFloat64 = "float64"
+	QuantizeFloat32 = "float32"
+	QuantizeInt16   = "int16"
+	QuantizeInt8    = "int8"
+)
+
+var compactQuantizations = []string{QuantizeFloat64, QuantizeFloat32, QuantizeInt16, QuantizeInt8}
+
+const (
+	compactNeuronFrozen = 1 << iota
+	compactNeuronPlastic
+	compactNeuronInnovation
+)
+
+const (
+	compactSynapseEnabled = 1 << iota
+	compactSynapseRecurrent
+	compactSynapseFrozen
+	compactSynapsePlastic
+	compactSynapseDisabledFor
+	compactSynapseInnovation
+)
+
+// CompactGenome is the content of a compact export: the genome plus the scape,
+// I/O neuron ids and encoding needed to run it without the originating run.
+// Quantization is the weight quantization the genome was decoded from.
+type CompactGenome struct {
+	Scape           string       `json:"scape,omitempty"`
+	InputNeuronIDs  []string     `json:"input_neuron_ids,omitempty"`
+	OutputNeuronIDs []string     `json:"output_neuron_ids,omitempty"`
+	Encoding        string       `json:"encoding,omitempty"`
+	Quantization    string       `json:"-"`
+	Genome          model.Genome `json:"genome"`
+}
+
+// NormalizeQuantization maps an empty name to float64 and reports whether
+// name is a known quantization.
+func NormalizeQuantization(name string) (string, bool) {
+	if name == "" {
+		return QuantizeFloat64, true
+	}
+	for _, known := range compactQuantizations {
+		if name == known {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// EncodeCompactGenome writes compact in the compact binary encoding with
+// biases and weights quantized as named. Integer quantizations reject
+// non-finite values.
+func EncodeCompactGenome(compact CompactGenome, quantization string) ([]byte, error) {
+	normalized, ok := NormalizeQuantization(quantization)
+	if !ok {
+		return nil, fmt.Errorf("unsupported quantization: %s", quantization)
+	}
+	quantization = normalized
+	genome := compact.Genome
+	meta := compact
+	meta.Genome.Neurons = nil
+	meta.Genome.Synapses = nil
+	metaData, err := json.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload compactWriter
+	payload.bytes(metaData)
+	payload.uvarint(uint64(len(genome.Neurons)))
+	indexes := make(map[string]int, len(genome.Neurons))
+	biases := make([]float64, len(genome.Neurons))
+	for i, neuron := range genome.Neurons {
+		indexes[neuron.ID] = i
+		biases[i] = neuron.Bias
+		payload.string(neuron.ID)
+		payload.string(neuron.Activation)
+		payload.string(neuron.Aggregator)
+		payload.string(neuron.PlasticityRule)
+		payload.uvarint(uint64(neuron.Generation))
+		plastic := neuron.PlasticityRate != 0 || neuron.PlasticityA != 0 || neuron.PlasticityB != 0 ||
+			neuron.PlasticityC != 0 || neuron.PlasticityD != 0 || len(neuron.PlasticityBiasParams) > 0
+		var flags byte
+		if neuron.Frozen {
+			flags |= compactNeuronFrozen
+		}
+		if plastic {
+			flags |= compactNeuronPlastic
+		}
+		if neuron.Innovation > 0 {
+			flags |= compactNeuronInnovation
+		}
+		payload.byte(flags)
+		if plastic {
+			for _, value := range []float64{neuron.PlasticityRate, neuron.PlasticityA, neuron.PlasticityB, neuron.PlasticityC, neuron.PlasticityD} {
+				payload.float64(value)
+			}
+			payload.floats(neuron.PlasticityBiasParams)
+		}
+		if neuron.Innovation > 0 {
+			payload.uvarint(uint64(neuron.Innovation))
+		}
+	}
+	if err := payload.quantized(biases, quantization); err != nil {
+		return nil, fmt.Errorf("biases: %w", err)
+	}
+
+	payload.uvarint(uint64(len(genome.Synapses)))
+	weights := make([]float64, len(genome.Synapses))
+	prevFrom, prevTo := 0, 0
+	for i, synapse := range genome.Synapses {
+		weights[i] = synapse.Weight
+		payload.string(synapse.ID)
+		prevFrom = payload.neuronRef(synapse.From, indexes, prevFrom)
+		prevTo = payload.neuronRef(synapse.To, indexes, prevTo)
+		var flags byte
+		if synapse.Enabled {
+			flags |= compactSynapseEnabled
+		}
+		if synapse.Recurrent {
+			flags |= compactSynapseRecurrent
+		}
+		if synapse.Frozen {
+			flags |= compactSynapseFrozen
+		}
+		if len(synapse.PlasticityParams) > 0 {
+			flags |= compactSynapsePlastic
+		}
+		if synapse.DisabledFor > 0 {
+			flags |= compactSynapseDisabledFor
+		}
+		if synapse.Innovation > 0 {
+			flags |= compactSynapseInnovation
+		}
+		payload.byte(flags)
+		if len(synapse.PlasticityParams) > 0 {
+			payload.floats(synapse.PlasticityParams)
+		}
+		if synapse.DisabledFor > 0 {
+			payload.uvarint(uint64(synapse.DisabledFor))
+		}
+		if synapse.Innovation > 0 {
+			payload.uvarint(uint64(synapse.Innovation))
+		}
+	}
+	if err := payload.quantized(weights, quantization); err != nil {
+		return nil, fmt.Errorf("weights: %w", err)
+	}
+
+	var out bytes.Buffer
+	out.WriteString(compactMagic)
+	out.WriteByte(compactVersion)
+	out.WriteByte(quantizationCode(quantization))
+	deflate, err := flate.NewWriter(&out, flate.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := deflate.Write(payload.buf.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := deflate.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// DecodeCompactGenome reads a genome written by EncodeCompactGenome.
+func DecodeCompactGenome(data []byte) (CompactGenome, error) {
+	if len(data) < len(compactMagic)+2 || string(data[:len(compactMagic)]) != compactMagic {
+		return CompactGenome{}, errors.New("not a compact genome")
+	}
+	if version := data[len(compactMagic)]; version != compactVersion {
+		return CompactGenome{}, fmt.Errorf("unsupported compact genome version %d", version)
+	}
+	code := int(data[len(compactMagic)+1])
+	if code >= len(compactQuantizations) {
+		return CompactGenome{}, fmt.Errorf("unsupported compact genome quantization %d", code)
+	}
+	quantization := compactQuantizations[code]
+	inflated, err := io.ReadAll(flate.NewReader(bytes.NewReader(data[len(compactMagic)+2:])))
+	if err != nil {
+		return CompactGenome{}, fmt.Errorf("inflate compact genome: %w", err)
+	}
+
+	payload := compactReader{r: bytes.NewReader(inflated)}
+	var compact CompactGenome
+	if metaData := payload.bytes(); payload.err == nil {
+		if err := json.Unmarshal(metaData, &compact); err != nil {
+			return CompactGenome{}, fmt.Errorf("decode compact genome metadata: %w", err)
+		}
+	}
+	compact.Quantization = quantization
+	neuronCount := payload.count()
+	neurons := make([]model.Neuron, neuronCount)
+	for i := range neurons {
+		neuron := &neurons[i]
+		neuron.ID = payload.string()
+		neuron.Activation = payload.string()
+		neuron.Aggregator = payload.string()
+		neuron.PlasticityRule = payload.string()
+		neuron.Generation = int(payload.uvarint())
+		flags := payload.byte()
+		neuron.Frozen = flags&compactNeuronFrozen != 0
+		if flags&compactNeuronPlastic != 0 {
+			neuron.PlasticityRate = payload.float64()
+			neuron.PlasticityA = payload.float64()
+			neuron.PlasticityB = payload.float64()
+			neuron.PlasticityC = payload.float64()
+			neuron.PlasticityD = payload.float64()
+			neuron.PlasticityBiasParams = payload.floats()
+		}
+		if flags&compactNeuronInnovation != 0 {
+			neuron.Innovation = int(payload.uvarint())
+		}
+	}
+	for i, bias := range payload.quantized(len(neurons), quantization) {
+		neurons[i].Bias = bias
+	}
+
+	synapseCount := payload.count()
+	synapses := make([]model.Synapse, synapseCount)
+	prevFrom, prevTo := 0, 0
+	for i := range synapses {
+		synapse := &synapses[i]
+		synapse.ID = payload.string()
+		synapse.From, prevFrom = payload.neuronRef(neurons, prevFrom)
+		synapse.To, prevTo = payload.neuronRef(neurons, prevTo)
+		flags := payload.byte()
+		synapse.Enabled = flags&compactSynapseEnabled != 0
+		synapse.Recurrent = flags&compactSynapseRecurrent != 0
+		synapse.Frozen = flags&compactSynapseFrozen != 0
+		if flags&compactSynapsePlastic != 0 {
+			synapse.PlasticityParams = payload.floats()
+		}
+		if flags&compactSynapseDisabledFor != 0 {
+			synapse.DisabledFor = int(payload.uvarint())
+		}
+		if flags&compactSynapseInnovation != 0 {
+			synapse.Innovation = int(payload.uvarint())
+		}
+	}
+	for i, weight := range payload.quantized(len(synapses), quantization) {
+		synapses[i].Weight = weight
+	}
+	if payload.err != nil {
+		return CompactGenome{}, fmt.Errorf("decode compact genome: %w", payload.err)
+	}
+	if payload.r.Len() != 0 {
+		return CompactGenome{}, fmt.Errorf("decode compact genome: %d trailing bytes", payload.r.Len())
+	}
+	compact.Genome.Neurons = neurons
+	compact.Genome.Synapses = synapses
+	return compact, nil
+}
+
+func quantizationCode(quantization string) byte {
+	for i, known := range compactQuantizations {
+		if quantization == known {
+			return byte(i)
+		}
+	}
+	return 0
+}
+
+func quantizationMax(quantization string) float64 {
+	switch quantization {
+	case QuantizeInt16:
+		return math.MaxInt16
+	case QuantizeInt8:
+		return math.MaxInt8
+	}
+	return 0
+}
+
+type compactWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *compactWriter) byte(value byte) {
+	w.buf.WriteByte(value)
+}
+
+func (w *compactWriter) uvarint(value uint64) {
+	w.buf.Write(binary.AppendUvarint(nil, value))
+}
+
+func (w *compactWriter) varint(value int64) {
+	w.buf.Write(binary.AppendVarint(nil, value))
+}
+
+func (w *compactWriter) bytes(value []byte) {
+	w.uvarint(uint64(len(value)))
+	w.buf.Write(value)
+}
+
+func (w *compactWriter) string(value string) {
+	w.bytes([]byte(value))
+}
+
+func (w *compactWriter) float64(value float64) {
+	w.buf.Write(binary.LittleEndian.AppendUint64(nil, math.Float64bits(value)))
+}
+
+func (w *compactWriter) floats(values []float64) {
+	w.uvarint(uint64(len(values)))
+	for _, value := range values {
+		w.float64(value)
+	}
+}
+
+// neuronRef writes a synapse endpoint as the signed distance from the
+// previous endpoint's neuron index, shifted left one bit; a set low bit marks
+// an id that is not a genome neuron, written literally. It returns the index
+// the next endpoint is coded against.
+func (w *compactWriter) neuronRef(id string, indexes map[string]int, prev int) int {
+	idx, ok := indexes[id]
+	if !ok {
+		w.uvarint(1)
+		w.string(id)
+		return prev
+	}
+	delta := int64(idx - prev)
+	w.uvarint(uint64((delta<<1)^(delta>>63)) << 1)
+	return idx
+}
+
+func (w *compactWriter) quantized(values []float64, quantization string) error {
+	switch quantization {
+	case QuantizeFloat64:
+		for _, value := range values {
+			w.float64(value)
+		}
+		return nil
+	case QuantizeFloat32:
+		for _, value := range values {
+			w.buf.Write(binary.LittleEndian.AppendUint32(nil, math.Float32bits(float32(value))))
+		}
+		return nil
+	}
+	limit := quantizationMax(quantization)
+	maxAbs := 0.0
+	for _, value := range values {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return fmt.Errorf("cannot quantize non-finite value %v to %s", value, quantization)
+		}
+		maxAbs = math.Max(maxAbs, math.Abs(value))
+	}
+	scale := maxAbs / limit
+	w.float64(scale)
+	prev := int64(0)
+	for _, value := range values {
+		q := int64(0)
+		if scale > 0 {
+			q = int64(math.Max(-limit, math.Min(limit, math.Round(value/scale))))
+		}
+		w.varint(q - prev)
+		prev = q
+	}
+	return nil
+}
+
+// compactReader decodes a payload, keeping the first error; once it is set
+// every read returns a zero value.
+type compactReader struct {
+	r   *bytes.Reader
+	err error
+}
+
+func (r *compactReader) fail(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+func (r *compactReader) byte() byte {
+	if r.err != nil {
+		return 0
+	}
+	value, err := r.r.ReadByte()
+	if err != nil {
+		r.fail(io.ErrUnexpectedEOF)
+	}
+	return value
+}
+
+func (r *compactReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	value, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		r.fail(io.ErrUnexpectedEOF)
+	}
+	return value
+}
+
+func (r *compactReader) varint() int64 {
+	if r.err != nil {
+		return 0
+	}
+	value, err := binary.ReadVarint(r.r)
+	if err != nil {
+		r.fail(io.ErrUnexpectedEOF)
+	}
+	return value
+}
+
+// count reads a length that must fit in the remaining payload.
+func (r *compactReader) count() int {
+	n := r.uvarint()
+	if n > uint64(r.r.Len()) {
+		r.fail(fmt.Errorf("length %d exceeds remaining %d bytes", n, r.r.Len()))
+		return 0
+	}
+	return int(n)
+}
+
+func (r *compactReader) bytes() []byte {
+	n := r.count()
+	if r.err != nil {
+		return nil
+	}
+	value := make([]byte, n)
+	if _, err := io.ReadFull(r.r, value); err != nil {
+		r.fail(io.ErrUnexpectedEOF)
+	}
+	return value
+}
+
+func (r *compactReader) string() string {
+	return string(r.bytes())
+}
+
+func (r *compactReader) float64() float64 {
+	if r.err != nil {
+		return 0
+	}
+	var raw [8]byte
+	if _, err := io.ReadFull(r.r, raw[:]); err != nil {
+		r.fail(io.ErrUnexpectedEOF)
+		return 0
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(raw[:]))
+}
+
+func (r *compactReader) floats() []float64 {
+	n := r.count()
+	if n == 0 {
+		return nil
+	}
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = r.float64()
+	}
+	return values
+}
+
+func (r *compactReader) neuronRef(neurons []model.Neuron, prev int) (string, int) {
+	code := r.uvarint()
+	if code&1 == 1 {
+		return r.string(), prev
+	}
+	zigzag := code >> 1
+	idx := prev + int(int64(zigzag>>1)^-int64(zigzag&1))
+	if r.err == nil && (idx < 0 || idx >= len(neurons)) {
+		r.fail(fmt.Errorf("synapse endpoint index %d out of range", idx))
+		return "", prev
+	}
+	if r.err != nil {
+		return "", prev
+	}
+	return neurons[idx].ID, idx
+}
+
+func (r *compactReader) quantized(n int, quantization string) []float64 {
+	values := make([]float64, n)
+	switch quantization {
+	case QuantizeFloat64:
+		for i := range values {
+			values[i] = r.float64()
+		}
+		return values
+	case QuantizeFloat32:
+		for i := range values {
+			var raw [4]byte
+			if r.err != nil {
+				break
+			}
+			if _, err := io.ReadFull(r.r, raw[:]); err != nil {
+				r.fail(io.ErrUnexpectedEOF)
+				break
+			}
+			values[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(raw[:])))
+		}
+		return values
+	}
+	scale := r.float64()
+	q := int64(0)
+	for i := range values {
+		q += r.varint()
+		values[i] = float64(q) * scale
+	}
+	return values
+}
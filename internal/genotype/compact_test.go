@@ -0,0 +1,135 @@
+package genotype
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func compactTestGenome(hidden int) model.Genome {
+	genome := model.Genome{
+		ID:          "champion",
+		SensorIDs:   []string{"xor_input_left"},
+		ActuatorIDs: []string{"xor_output"},
+		Strategy:    &model.StrategyConfig{TuningSelection: "dynamic_random", AnnealingFactor: 0.5},
+		Annotations: map[string]string{"note": "kept"},
+		Neurons: []model.Neuron{
+			{ID: "i1", Activation: "identity"},
+			{ID: "o", Activation: "sigmoid", Bias: -0.75, Generation: 3},
+		},
+	}
+	for i := 0; i < hidden; i++ {
+		id := fmt.Sprintf("h%d", i)
+		genome.Neurons = append(genome.Neurons, model.Neuron{ID: id, Activation: "tanh", Aggregator: "dot_product", Bias: math.Sin(float64(i))})
+		genome.Synapses = append(genome.Synapses,
+			model.Synapse{ID: "in-" + id, From: "i1", To: id, Weight: math.Cos(float64(i)) * 3, Enabled: true},
+			model.Synapse{ID: "out-" + id, From: id, To: "o", Weight: -float64(i) / 7, Enabled: i%3 != 0, Frozen: i == 1},
+		)
+	}
+	genome.Synapses[1].DisabledFor = 300
+	genome.Synapses[2].Innovation = 1 << 20
+	genome.Neurons[2].Innovation = 7
+	genome.Neurons[1].PlasticityRule = "hebbian"
+	genome.Neurons[1].PlasticityRate = 0.1
+	genome.Neurons[1].PlasticityBiasParams = []float64{0.2}
+	genome.Synapses = append(genome.Synapses, model.Synapse{ID: "loop", From: "o", To: "o", Weight: 0.5, Recurrent: true, Enabled: true, PlasticityParams: []float64{0.3, 0.4}})
+	genome.Synapses = append(genome.Synapses, model.Synapse{ID: "ext", From: "sensor:x", To: "o", Weight: 1.25, Enabled: true})
+	return genome
+}
+
+func TestCompactGenomeRoundTripsLosslessly(t *testing.T) {
+	compact := CompactGenome{
+		Scape:           "xor",
+		InputNeuronIDs:  []string{"i1"},
+		OutputNeuronIDs: []string{"o"},
+		Genome:          compactTestGenome(8),
+	}
+	data, err := EncodeCompactGenome(compact, "")
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	decoded, err := DecodeCompactGenome(data)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if decoded.Quantization != QuantizeFloat64 {
+		t.Fatalf("expected lossless default quantization, got %s", decoded.Quantization)
+	}
+	decoded.Quantization = ""
+	if !reflect.DeepEqual(decoded, compact) {
+		t.Fatalf("compact genome did not round trip:\ngot  %+v\nwant %+v", decoded, compact)
+	}
+}
+
+func TestCompactGenomeQuantizationBoundsError(t *testing.T) {
+	genome := compactTestGenome(200)
+	jsonData, err := json.Marshal(genome)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	maxAbs := 0.0
+	for _, synapse := range genome.Synapses {
+		maxAbs = math.Max(maxAbs, math.Abs(synapse.Weight))
+	}
+	sizes := map[string]int{}
+	for _, quantization := range []string{QuantizeFloat64, QuantizeFloat32, QuantizeInt16, QuantizeInt8} {
+		data, err := EncodeCompactGenome(CompactGenome{Genome: genome}, quantization)
+		if err != nil {
+			t.Fatalf("encode %s: %v", quantization, err)
+		}
+		sizes[quantization] = len(data)
+		decoded, err := DecodeCompactGenome(data)
+		if err != nil {
+			t.Fatalf("decode %s: %v", quantization, err)
+		}
+		tolerance := 0.0
+		switch quantization {
+		case QuantizeFloat32:
+			tolerance = 1e-6 * maxAbs
+		case QuantizeInt16:
+			tolerance = maxAbs / math.MaxInt16
+		case QuantizeInt8:
+			tolerance = maxAbs / math.MaxInt8
+		}
+		for i, synapse := range decoded.Genome.Synapses {
+			if diff := math.Abs(synapse.Weight - genome.Synapses[i].Weight); diff > tolerance {
+				t.Fatalf("%s: synapse %s weight off by %g (tolerance %g)", quantization, synapse.ID, diff, tolerance)
+			}
+			if synapse.From != genome.Synapses[i].From || synapse.To != genome.Synapses[i].To || synapse.Enabled != genome.Synapses[i].Enabled {
+				t.Fatalf("%s: synapse %d topology changed: %+v", quantization, i, synapse)
+			}
+		}
+	}
+	if sizes[QuantizeFloat64] >= len(jsonData)/3 {
+		t.Fatalf("expected compact encoding well below json size %d, got %d", len(jsonData), sizes[QuantizeFloat64])
+	}
+	if !(sizes[QuantizeInt8] < sizes[QuantizeInt16] && sizes[QuantizeInt16] < sizes[QuantizeFloat64]) {
+		t.Fatalf("expected coarser quantization to shrink the encoding, got %v", sizes)
+	}
+}
+
+func TestCompactGenomeRejectsBadInput(t *testing.T) {
+	genome := compactTestGenome(2)
+	if _, err := EncodeCompactGenome(CompactGenome{Genome: genome}, "int4"); err == nil || !strings.Contains(err.Error(), "int4") {
+		t.Fatalf("expected unknown quantization to be rejected, got %v", err)
+	}
+	genome.Synapses[0].Weight = math.NaN()
+	if _, err := EncodeCompactGenome(CompactGenome{Genome: genome}, QuantizeInt8); err == nil {
+		t.Fatal("expected non-finite weight to be rejected by integer quantization")
+	}
+	data, err := EncodeCompactGenome(CompactGenome{Genome: genome}, QuantizeFloat32)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if _, err := DecodeCompactGenome(data[:len(data)/2]); err == nil {
+		t.Fatal("expected truncated data to be rejected")
+	}
+	if _, err := DecodeCompactGenome([]byte(`{"id":"g"}`)); err == nil {
+		t.Fatal("expected json to be rejected")
+	}
+}
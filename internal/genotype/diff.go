@@ -0,0 +1,98 @@
+package genotype
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"protogonos/internal/model"
+)
+
+// GenomeDelta is the structural difference between two genomes. Synapses are
+// matched by their from->to endpoints (parallel synapses by occurrence), so
+// the delta is meaningful across clones that renamed synapse ids. Weight
+// drift covers the synapses present in both genomes.
+type GenomeDelta struct {
+	NeuronsAdded    []string `json:"neurons_added,omitempty"`
+	NeuronsRemoved  []string `json:"neurons_removed,omitempty"`
+	SynapsesAdded   []string `json:"synapses_added,omitempty"`
+	SynapsesRemoved []string `json:"synapses_removed,omitempty"`
+	SharedSynapses  int      `json:"shared_synapses"`
+	// WeightDrift is the L2 norm of the weight changes of shared synapses;
+	// MaxWeightDrift the largest single change.
+	WeightDrift    float64 `json:"weight_drift"`
+	MaxWeightDrift float64 `json:"max_weight_drift"`
+}
+
+// DiffGenomes reports how to differs structurally from from.
+func DiffGenomes(from, to model.Genome) GenomeDelta {
+	delta := GenomeDelta{}
+	fromNeurons := make(map[string]struct{}, len(from.Neurons))
+	for _, neuron := range from.Neurons {
+		fromNeurons[neuron.ID] = struct{}{}
+	}
+	toNeurons := make(map[string]struct{}, len(to.Neurons))
+	for _, neuron := range to.Neurons {
+		toNeurons[neuron.ID] = struct{}{}
+		if _, ok := fromNeurons[neuron.ID]; !ok {
+			delta.NeuronsAdded = append(delta.NeuronsAdded, neuron.ID)
+		}
+	}
+	for _, neuron := range from.Neurons {
+		if _, ok := toNeurons[neuron.ID]; !ok {
+			delta.NeuronsRemoved = append(delta.NeuronsRemoved, neuron.ID)
+		}
+	}
+
+	fromSynapses := synapsesByEndpoints(from.Synapses)
+	toSynapses := synapsesByEndpoints(to.Synapses)
+	// Sum in key order so the drift is bit-for-bit reproducible.
+	toKeys := make([]string, 0, len(toSynapses))
+	for key := range toSynapses {
+		toKeys = append(toKeys, key)
+	}
+	sort.Strings(toKeys)
+	sumSquares := 0.0
+	for _, key := range toKeys {
+		toWeight := toSynapses[key]
+		fromWeight, ok := fromSynapses[key]
+		if !ok {
+			delta.SynapsesAdded = append(delta.SynapsesAdded, key)
+			continue
+		}
+		delta.SharedSynapses++
+		drift := math.Abs(toWeight - fromWeight)
+		sumSquares += drift * drift
+		if drift > delta.MaxWeightDrift {
+			delta.MaxWeightDrift = drift
+		}
+	}
+	for key := range fromSynapses {
+		if _, ok := toSynapses[key]; !ok {
+			delta.SynapsesRemoved = append(delta.SynapsesRemoved, key)
+		}
+	}
+	delta.WeightDrift = math.Sqrt(sumSquares)
+	sort.Strings(delta.NeuronsAdded)
+	sort.Strings(delta.NeuronsRemoved)
+	sort.Strings(delta.SynapsesRemoved)
+	return delta
+}
+
+// synapsesByEndpoints keys synapse weights by "from->to", suffixing "#n" to
+// the n-th parallel synapse between the same neurons.
+func synapsesByEndpoints(synapses []model.Synapse) map[string]float64 {
+	out := make(map[string]float64, len(synapses))
+	seen := make(map[string]int, len(synapses))
+	for _, synapse := range synapses {
+		key := synapse.From + "->" + synapse.To
+		if n := seen[key]; n > 0 {
+			seen[key]++
+			key += "#" + strconv.Itoa(n)
+		} else {
+			seen[key] = 1
+		}
+		out[key] = synapse.Weight
+	}
+	return out
+}
@@ -0,0 +1,53 @@
+package genotype
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func TestDiffGenomesReportsStructureAndWeightDrift(t *testing.T) {
+	from := model.Genome{
+		ID:      "a",
+		Neurons: []model.Neuron{{ID: "i"}, {ID: "h"}, {ID: "o"}},
+		Synapses: []model.Synapse{
+			{ID: "s1", From: "i", To: "h", Weight: 0.5},
+			{ID: "s2", From: "h", To: "o", Weight: -1},
+			{ID: "s3", From: "i", To: "o", Weight: 2},
+		},
+	}
+	to := model.Genome{
+		ID:      "b",
+		Neurons: []model.Neuron{{ID: "i"}, {ID: "h"}, {ID: "o"}, {ID: "h2"}},
+		Synapses: []model.Synapse{
+			{ID: "x1", From: "i", To: "h", Weight: 0.8},
+			{ID: "x2", From: "h", To: "o", Weight: -1.4},
+			{ID: "x3", From: "i", To: "h2", Weight: 1},
+			{ID: "x4", From: "h2", To: "o", Weight: 1},
+			{ID: "x5", From: "h2", To: "o", Weight: 1},
+		},
+	}
+
+	delta := DiffGenomes(from, to)
+	if !reflect.DeepEqual(delta.NeuronsAdded, []string{"h2"}) || len(delta.NeuronsRemoved) != 0 {
+		t.Fatalf("unexpected neuron delta: %+v", delta)
+	}
+	if !reflect.DeepEqual(delta.SynapsesAdded, []string{"h2->o", "h2->o#1", "i->h2"}) {
+		t.Fatalf("unexpected added synapses: %v", delta.SynapsesAdded)
+	}
+	if !reflect.DeepEqual(delta.SynapsesRemoved, []string{"i->o"}) {
+		t.Fatalf("unexpected removed synapses: %v", delta.SynapsesRemoved)
+	}
+	if delta.SharedSynapses != 2 {
+		t.Fatalf("expected two shared synapses, got %d", delta.SharedSynapses)
+	}
+	if math.Abs(delta.WeightDrift-0.5) > 1e-12 || math.Abs(delta.MaxWeightDrift-0.4) > 1e-12 {
+		t.Fatalf("unexpected weight drift: l2=%f max=%f", delta.WeightDrift, delta.MaxWeightDrift)
+	}
+
+	if same := DiffGenomes(from, from); same.WeightDrift != 0 || len(same.SynapsesAdded)+len(same.SynapsesRemoved)+len(same.NeuronsAdded)+len(same.NeuronsRemoved) != 0 {
+		t.Fatalf("expected empty self delta, got %+v", same)
+	}
+}
@@ -25,10 +25,18 @@ func TestCloneGenomeDeepCopy(t *testing.T) {
 			CEPIDs:     []string{"substrate:cep:d3:0"},
 			Parameters: map[string]float64{"scale": 1},
 		},
-		Plasticity: &model.PlasticityConfig{Rule: "hebbian", Rate: 0.1},
+		Plasticity:  &model.PlasticityConfig{Rule: "hebbian", Rate: 0.1},
+		Annotations: map[string]string{"reviewed": "yes"},
+		Provenance:  &model.GenomeProvenance{CreatedBy: "add_neuron", Notes: []string{"stable"}},
 	}
 
 	out := CloneGenome(in)
+	if out.Annotations["reviewed"] != "yes" || out.Provenance.CreatedBy != "add_neuron" || out.Provenance.Notes[0] != "stable" {
+		t.Fatalf("expected annotations and provenance to be cloned, got %+v %+v", out.Annotations, out.Provenance)
+	}
+	out.Annotations["reviewed"] = "no"
+	out.Provenance.CreatedBy = "elite_clone"
+	out.Provenance.Notes[0] = "changed"
 	out.Neurons[0].Activation = "relu"
 	out.Neurons[0].PlasticityBiasParams[0] = 7
 	out.Synapses[0].PlasticityParams[0] = 9
@@ -59,6 +67,12 @@ func TestCloneGenomeDeepCopy(t *testing.T) {
 	if in.Synapses[0].PlasticityParams[0] != 0.3 {
 		t.Fatal("expected original synapse plasticity parameters to remain unchanged")
 	}
+	if in.Annotations["reviewed"] != "yes" {
+		t.Fatal("expected original annotations to remain unchanged")
+	}
+	if in.Provenance.CreatedBy != "add_neuron" || in.Provenance.Notes[0] != "stable" {
+		t.Fatal("expected original provenance to remain unchanged")
+	}
 }
 
 func TestSavePopulationSnapshot(t *testing.T) {
@@ -162,6 +176,16 @@ func TestSavePopulationSnapshotReconcilesMembership(t *testing.T) {
 	} else if !ok {
 		t.Fatal("expected new genome to be saved")
 	}
+
+	if err := SavePopulationSnapshot(ctx, store, GenerationSnapshotID("pop1", 2), 2, genomes); err != nil {
+		t.Fatalf("save generation snapshot: %v", err)
+	}
+	if err := SavePopulationSnapshot(ctx, store, "pop1", 3, genomes[:1]); err != nil {
+		t.Fatalf("save next snapshot: %v", err)
+	}
+	if _, _, err := LoadPopulationSnapshot(ctx, store, "pop1@g2"); err != nil {
+		t.Fatalf("expected genomes shared with a generation snapshot to remain: %v", err)
+	}
 }
 
 func TestLoadPopulationSnapshot(t *testing.T) {
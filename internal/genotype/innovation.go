@@ -0,0 +1,168 @@
+package genotype
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+
+	"protogonos/internal/model"
+)
+
+// InnovationRegistry hands out the historical markings of a run: every new
+// neuron and synapse gets an innovation number the first time its
+// structural key is seen, and the same number every time after. Synapses
+// are keyed by their from->to endpoints, so the same link added
+// independently in two lineages shares a number; neurons are keyed by id.
+// Markings survive id remapping and are what crossover alignment,
+// compatibility distance and genome diffing match genes by. It is safe for
+// concurrent use.
+type InnovationRegistry struct {
+	mu    sync.Mutex
+	next  int
+	byKey map[string]int
+}
+
+// NewInnovationRegistry returns a registry that continues from records,
+// such as the ones a run persisted before it was continued.
+func NewInnovationRegistry(records []model.InnovationRecord) *InnovationRegistry {
+	r := &InnovationRegistry{byKey: make(map[string]int, len(records))}
+	for _, record := range records {
+		r.observe(record.Key, record.Innovation)
+	}
+	return r
+}
+
+// Mark returns genome with every unmarked neuron and synapse given its
+// innovation number. Genes that already carry a number are registered
+// under it, so genomes marked by an earlier run keep their markings. Two
+// genes of one genome never share a number: a second synapse between the
+// same neurons is keyed by its occurrence. The input genome is not
+// modified.
+func (r *InnovationRegistry) Mark(genome model.Genome) model.Genome {
+	if r == nil {
+		return genome
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	neurons := genome.Neurons
+	copied := false
+	for i, neuron := range neurons {
+		key := "n:" + neuron.ID
+		if neuron.Innovation > 0 {
+			r.observe(key, neuron.Innovation)
+			continue
+		}
+		if !copied {
+			neurons = append([]model.Neuron(nil), genome.Neurons...)
+			copied = true
+		}
+		neurons[i].Innovation = r.assign(key)
+	}
+
+	synapses := genome.Synapses
+	used := make(map[int]struct{}, len(synapses))
+	for _, synapse := range synapses {
+		if synapse.Innovation > 0 {
+			used[synapse.Innovation] = struct{}{}
+		}
+	}
+	occurrences := make(map[string]int, len(synapses))
+	copied = false
+	for i, synapse := range synapses {
+		endpoints := "s:" + synapse.From + "->" + synapse.To
+		key := endpoints
+		if n := occurrences[endpoints]; n > 0 {
+			key += "#" + strconv.Itoa(n)
+		}
+		occurrences[endpoints]++
+		if synapse.Innovation > 0 {
+			r.observe(key, synapse.Innovation)
+			continue
+		}
+		innovation := r.assign(key)
+		for n := occurrences[endpoints]; ; n++ {
+			if _, clash := used[innovation]; !clash {
+				break
+			}
+			innovation = r.assign(endpoints + "#" + strconv.Itoa(n))
+		}
+		used[innovation] = struct{}{}
+		if !copied {
+			synapses = append([]model.Synapse(nil), genome.Synapses...)
+			copied = true
+		}
+		synapses[i].Innovation = innovation
+	}
+
+	genome.Neurons = neurons
+	genome.Synapses = synapses
+	return genome
+}
+
+// Records lists the registry's markings by innovation number, for
+// persisting them with the run.
+func (r *InnovationRegistry) Records() []model.InnovationRecord {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	records := make([]model.InnovationRecord, 0, len(r.byKey))
+	for key, innovation := range r.byKey {
+		records = append(records, model.InnovationRecord{Key: key, Innovation: innovation})
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Innovation != records[j].Innovation {
+			return records[i].Innovation < records[j].Innovation
+		}
+		return records[i].Key < records[j].Key
+	})
+	return records
+}
+
+func (r *InnovationRegistry) assign(key string) int {
+	if innovation, ok := r.byKey[key]; ok {
+		return innovation
+	}
+	r.next++
+	r.byKey[key] = r.next
+	return r.next
+}
+
+func (r *InnovationRegistry) observe(key string, innovation int) {
+	if _, ok := r.byKey[key]; !ok {
+		r.byKey[key] = innovation
+	}
+	r.next = max(r.next, innovation)
+}
+
+// MatchSynapses pairs the synapses of a with the matching genes of b: by
+// innovation number when both carry one, by id otherwise. The result holds,
+// for each synapse of a, the index of its match in b or -1 for a disjoint
+// or excess gene.
+func MatchSynapses(a, b []model.Synapse) []int {
+	byInnovation := make(map[int]int, len(b))
+	byID := make(map[string]int, len(b))
+	for j, synapse := range b {
+		if synapse.Innovation > 0 {
+			byInnovation[synapse.Innovation] = j
+		}
+		byID[synapse.ID] = j
+	}
+	matches := make([]int, len(a))
+	for i, synapse := range a {
+		matches[i] = -1
+		if synapse.Innovation > 0 {
+			if j, ok := byInnovation[synapse.Innovation]; ok {
+				matches[i] = j
+				continue
+			}
+		}
+		if j, ok := byID[synapse.ID]; ok && (synapse.Innovation == 0 || b[j].Innovation == 0) {
+			matches[i] = j
+		}
+	}
+	return matches
+}
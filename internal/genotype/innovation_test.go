@@ -0,0 +1,91 @@
+package genotype
+
+import (
+	"reflect"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func innovationGenome(id string, synapses ...model.Synapse) model.Genome {
+	return model.Genome{
+		ID: id,
+		Neurons: []model.Neuron{
+			{ID: "i", Activation: "identity"},
+			{ID: "h", Activation: "tanh"},
+			{ID: "o", Activation: "identity"},
+		},
+		Synapses: synapses,
+	}
+}
+
+func TestInnovationRegistryMarksTheSameStructureWithTheSameNumber(t *testing.T) {
+	registry := NewInnovationRegistry(nil)
+	a := innovationGenome("a",
+		model.Synapse{ID: "a1", From: "i", To: "h"},
+		model.Synapse{ID: "a2", From: "h", To: "o"},
+	)
+	b := innovationGenome("b",
+		model.Synapse{ID: "b1", From: "h", To: "o"},
+		model.Synapse{ID: "b2", From: "i", To: "o"},
+		model.Synapse{ID: "b3", From: "i", To: "o"},
+	)
+
+	markedA := registry.Mark(a)
+	markedB := registry.Mark(b)
+	if a.Synapses[0].Innovation != 0 || a.Neurons[0].Innovation != 0 {
+		t.Fatal("mark modified its input genome")
+	}
+	for i, neuron := range markedB.Neurons {
+		if neuron.Innovation == 0 || neuron.Innovation != markedA.Neurons[i].Innovation {
+			t.Fatalf("expected neuron %s marked alike in both genomes, got %d and %d", neuron.ID, markedA.Neurons[i].Innovation, neuron.Innovation)
+		}
+	}
+	if markedB.Synapses[0].Innovation != markedA.Synapses[1].Innovation {
+		t.Fatalf("expected h->o to share its innovation, got %d and %d", markedA.Synapses[1].Innovation, markedB.Synapses[0].Innovation)
+	}
+	if markedB.Synapses[1].Innovation == markedB.Synapses[2].Innovation {
+		t.Fatalf("expected parallel synapses to be marked apart, got %d", markedB.Synapses[1].Innovation)
+	}
+
+	records := registry.Records()
+	if len(records) != 7 || records[0].Innovation != 1 || records[len(records)-1].Innovation != 7 {
+		t.Fatalf("expected seven markings numbered from 1, got %+v", records)
+	}
+	if remarked := registry.Mark(markedB); !reflect.DeepEqual(remarked, markedB) {
+		t.Fatalf("expected marking to be idempotent, got %+v", remarked.Synapses)
+	}
+}
+
+func TestInnovationRegistryContinuesFromRecords(t *testing.T) {
+	first := NewInnovationRegistry(nil)
+	marked := first.Mark(innovationGenome("a", model.Synapse{ID: "a1", From: "i", To: "h"}))
+
+	resumed := NewInnovationRegistry(first.Records())
+	grown := marked
+	grown.Synapses = append(append([]model.Synapse(nil), marked.Synapses...), model.Synapse{ID: "a2", From: "h", To: "o"})
+	grown = resumed.Mark(grown)
+	if grown.Synapses[0].Innovation != marked.Synapses[0].Innovation {
+		t.Fatalf("expected the existing marking kept, got %d", grown.Synapses[0].Innovation)
+	}
+	if grown.Synapses[1].Innovation != 5 {
+		t.Fatalf("expected the new synapse numbered after the resumed registry, got %d", grown.Synapses[1].Innovation)
+	}
+}
+
+func TestMatchSynapsesPrefersInnovationsOverIDs(t *testing.T) {
+	a := []model.Synapse{
+		{ID: "x", Innovation: 3},
+		{ID: "y", Innovation: 4},
+		{ID: "z"},
+		{ID: "w", Innovation: 9},
+	}
+	b := []model.Synapse{
+		{ID: "renamed", Innovation: 3},
+		{ID: "y", Innovation: 8},
+		{ID: "z"},
+	}
+	if got, want := MatchSynapses(a, b), []int{0, -1, 2, -1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected matches: got %v want %v", got, want)
+	}
+}
@@ -44,6 +44,20 @@ type SeedPopulationOptions struct {
 	// LLVMProfile controls the llvm-phase-ordering seed scaffold.
 	// Supported values: "default" (full) and "core".
 	LLVMProfile string
+
+	// ParityInputs sets the input width of the parity and majority seed
+	// scaffolds. Zero uses three inputs.
+	ParityInputs int
+
+	// FunctionInputs sets the input width of the function-approx seed
+	// scaffold. Zero uses one input.
+	FunctionInputs int
+
+	// SensorSubsetMin and SensorSubsetMax bound the random number of
+	// morphology sensors each seed genome starts with. A zero maximum
+	// keeps every sensor.
+	SensorSubsetMin int
+	SensorSubsetMax int
 }
 
 const (
@@ -67,6 +81,14 @@ func ConstructSeedPopulation(scapeName string, size int, seed int64) (SeedPopula
 }
 
 func ConstructSeedPopulationWithOptions(scapeName string, size int, seed int64, options SeedPopulationOptions) (SeedPopulation, error) {
+	population, err := constructSeedPopulation(scapeName, size, seed, options)
+	if err != nil {
+		return SeedPopulation{}, err
+	}
+	return applySensorSubsets(population, seed, options)
+}
+
+func constructSeedPopulation(scapeName string, size int, seed int64, options SeedPopulationOptions) (SeedPopulation, error) {
 	scapeName, options = applySeedMorphologyLabel(scapeName, options)
 	scapeName = scapeid.Normalize(scapeName)
 	switch scapeName {
@@ -76,6 +98,12 @@ func ConstructSeedPopulationWithOptions(scapeName string, size int, seed int64,
 			InputNeuronIDs:  []string{"i1", "i2"},
 			OutputNeuronIDs: []string{"o"},
 		}, nil
+	case "parity", "majority":
+		return constructParitySeedPopulation(scapeName, size, seed, options.ParityInputs), nil
+	case "function-approx":
+		return constructFunctionApproxSeedPopulation(size, seed, options.FunctionInputs), nil
+	case "stack-machine":
+		return constructStackMachineSeedPopulation(size, seed), nil
 	case "regression-mimic":
 		return SeedPopulation{
 			Genomes:         seedRegressionMimicPopulation(size, seed),
@@ -470,6 +498,83 @@ func seedXORPopulation(size int, seed int64) []model.Genome {
 	return population
 }
 
+// constructParitySeedPopulation seeds an n-input network with one sigmoid
+// hidden neuron per input, fully connected from the inputs. Parity scapes are
+// driven through RunStep, so the genomes carry no sensors or actuators.
+func constructParitySeedPopulation(scapeName string, size int, seed int64, inputs int) SeedPopulation {
+	if inputs <= 0 {
+		inputs = 3
+	}
+	return constructDenseSeedPopulation(fmt.Sprintf("%s%d", scapeName, inputs), size, seed, inputs, inputs, "sigmoid", "sigmoid")
+}
+
+func constructFunctionApproxSeedPopulation(size int, seed int64, inputs int) SeedPopulation {
+	if inputs <= 0 {
+		inputs = 1
+	}
+	return constructDenseSeedPopulation(fmt.Sprintf("fnapprox%d", inputs), size, seed, inputs, 4, "tanh", "identity")
+}
+
+// constructStackMachineSeedPopulation seeds the three stack-machine sensors
+// (program position, stack depth, previous opcode) into a tanh output whose
+// value selects the next opcode.
+func constructStackMachineSeedPopulation(size int, seed int64) SeedPopulation {
+	return constructDenseSeedPopulation("stackmachine", size, seed, 3, 4, "tanh", "tanh")
+}
+
+// constructDenseSeedPopulation seeds sensorless genomes with identity inputs
+// i1..iN fully connected to a hidden layer h1..hM, itself fully connected to
+// the single output o.
+func constructDenseSeedPopulation(prefix string, size int, seed int64, inputs, hidden int, hiddenActivation, outputActivation string) SeedPopulation {
+	inputIDs := make([]string, inputs)
+	for i := range inputIDs {
+		inputIDs[i] = fmt.Sprintf("i%d", i+1)
+	}
+	rng := rand.New(rand.NewSource(seed))
+	population := make([]model.Genome, 0, size)
+	for g := 0; g < size; g++ {
+		neurons := make([]model.Neuron, 0, inputs+hidden+1)
+		for _, id := range inputIDs {
+			neurons = append(neurons, model.Neuron{ID: id, Activation: "identity", Bias: 0})
+		}
+		synapses := make([]model.Synapse, 0, inputs*hidden+hidden)
+		for h := 1; h <= hidden; h++ {
+			hiddenID := fmt.Sprintf("h%d", h)
+			neurons = append(neurons, model.Neuron{ID: hiddenID, Activation: hiddenActivation, Bias: jitter(rng, 2)})
+			for _, id := range inputIDs {
+				synapses = append(synapses, model.Synapse{
+					ID:      fmt.Sprintf("s%d", len(synapses)+1),
+					From:    id,
+					To:      hiddenID,
+					Weight:  jitter(rng, 6),
+					Enabled: true,
+				})
+			}
+		}
+		neurons = append(neurons, model.Neuron{ID: "o", Activation: outputActivation, Bias: jitter(rng, 2)})
+		for h := 1; h <= hidden; h++ {
+			synapses = append(synapses, model.Synapse{
+				ID:      fmt.Sprintf("s%d", len(synapses)+1),
+				From:    fmt.Sprintf("h%d", h),
+				To:      "o",
+				Weight:  jitter(rng, 6),
+				Enabled: true,
+			})
+		}
+		population = append(population, model.Genome{
+			VersionedRecord: model.VersionedRecord{SchemaVersion: storage.CurrentSchemaVersion, CodecVersion: storage.CurrentCodecVersion},
+			ID:              fmt.Sprintf("%s-g0-%d", prefix, g),
+			Neurons:         neurons,
+			Synapses:        synapses,
+		})
+	}
+	return SeedPopulation{
+		Genomes:         population,
+		InputNeuronIDs:  inputIDs,
+		OutputNeuronIDs: []string{"o"},
+	}
+}
+
 func seedRegressionMimicPopulation(size int, seed int64) []model.Genome {
 	rng := rand.New(rand.NewSource(seed))
 	population := make([]model.Genome, 0, size)
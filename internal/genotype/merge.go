@@ -0,0 +1,112 @@
+package genotype
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"protogonos/internal/model"
+	"protogonos/internal/storage"
+)
+
+// MergeSummary reports how source population snapshots were combined.
+type MergeSummary struct {
+	PopulationID   string
+	Generation     int
+	SourceSizes    map[string]int
+	MergedSize     int
+	DuplicateCount int
+	SpeciesCount   int
+	SpeciesSizes   map[string]int
+}
+
+// GenomeContentFingerprint hashes the full genome payload, weights included,
+// with the genome id cleared. Two genomes with the same content fingerprint
+// are interchangeable members of a population.
+func GenomeContentFingerprint(genome model.Genome) (string, error) {
+	genome.ID = ""
+	genome.VersionedRecord = model.VersionedRecord{}
+	payload, err := json.Marshal(genome)
+	if err != nil {
+		return "", err
+	}
+	digest := sha1.Sum(payload)
+	return hex.EncodeToString(digest[:]), nil
+}
+
+// MergePopulationSnapshots unions the members of the source snapshots into a
+// new snapshot. Members are deduplicated by id and by content fingerprint,
+// keeping the first occurrence in source order, and the merged population is
+// re-speciated by topology fingerprint. Stored member fitness is carried over.
+func MergePopulationSnapshots(ctx context.Context, store storage.Store, outPopulationID string, sourceIDs []string) (MergeSummary, error) {
+	if store == nil {
+		return MergeSummary{}, fmt.Errorf("store is required")
+	}
+	if outPopulationID == "" {
+		return MergeSummary{}, fmt.Errorf("output population id is required")
+	}
+	if len(sourceIDs) < 2 {
+		return MergeSummary{}, fmt.Errorf("at least two source populations are required")
+	}
+
+	summary := MergeSummary{
+		PopulationID: outPopulationID,
+		SourceSizes:  make(map[string]int, len(sourceIDs)),
+	}
+	seenSources := make(map[string]struct{}, len(sourceIDs))
+	seenIDs := make(map[string]struct{})
+	seenContent := make(map[string]struct{})
+	merged := make([]model.Genome, 0)
+	fitness := make(map[string]float64)
+	for _, sourceID := range sourceIDs {
+		if sourceID == outPopulationID {
+			return MergeSummary{}, fmt.Errorf("output population id must differ from source ids: %s", sourceID)
+		}
+		if _, ok := seenSources[sourceID]; ok {
+			return MergeSummary{}, fmt.Errorf("duplicate source population id: %s", sourceID)
+		}
+		seenSources[sourceID] = struct{}{}
+
+		pop, genomes, err := LoadPopulationSnapshot(ctx, store, sourceID)
+		if err != nil {
+			return MergeSummary{}, err
+		}
+		summary.SourceSizes[sourceID] = len(genomes)
+		if pop.Generation > summary.Generation {
+			summary.Generation = pop.Generation
+		}
+		for _, genome := range genomes {
+			content, err := GenomeContentFingerprint(genome)
+			if err != nil {
+				return MergeSummary{}, err
+			}
+			_, dupID := seenIDs[genome.ID]
+			_, dupContent := seenContent[content]
+			if dupID || dupContent {
+				summary.DuplicateCount++
+				continue
+			}
+			seenIDs[genome.ID] = struct{}{}
+			seenContent[content] = struct{}{}
+			merged = append(merged, genome)
+			if value, ok := pop.Fitness[genome.ID]; ok {
+				fitness[genome.ID] = value
+			}
+		}
+	}
+
+	species := SpeciateByFingerprint(merged)
+	summary.MergedSize = len(merged)
+	summary.SpeciesCount = len(species)
+	summary.SpeciesSizes = make(map[string]int, len(species))
+	for key, members := range species {
+		summary.SpeciesSizes[key] = len(members)
+	}
+
+	if err := SaveScoredPopulationSnapshot(ctx, store, outPopulationID, summary.Generation, merged, fitness); err != nil {
+		return MergeSummary{}, err
+	}
+	return summary, nil
+}
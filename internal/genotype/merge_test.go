@@ -0,0 +1,62 @@
+package genotype
+
+import (
+	"context"
+	"testing"
+
+	"protogonos/internal/model"
+	"protogonos/internal/storage"
+)
+
+func TestMergePopulationSnapshotsDeduplicatesByContent(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewMemoryStore()
+	if err := store.Init(ctx); err != nil {
+		t.Fatalf("init store: %v", err)
+	}
+
+	genome := func(id string, weight float64) model.Genome {
+		return model.Genome{
+			VersionedRecord: model.VersionedRecord{SchemaVersion: 1, CodecVersion: 1},
+			ID:              id,
+			Neurons:         []model.Neuron{{ID: "i", Activation: "identity"}, {ID: "o", Activation: "tanh"}},
+			Synapses:        []model.Synapse{{ID: "s", From: "i", To: "o", Weight: weight, Enabled: true}},
+		}
+	}
+	if err := SaveScoredPopulationSnapshot(ctx, store, "pop-a", 3, []model.Genome{genome("a1", 0.5), genome("a2", 0.7)}, map[string]float64{"a1": 1, "a2": 2}); err != nil {
+		t.Fatalf("save pop-a: %v", err)
+	}
+	// b1 duplicates a1's content under a different id.
+	if err := SaveScoredPopulationSnapshot(ctx, store, "pop-b", 5, []model.Genome{genome("b1", 0.5), genome("b2", -0.2)}, map[string]float64{"b1": 1, "b2": 3}); err != nil {
+		t.Fatalf("save pop-b: %v", err)
+	}
+
+	summary, err := MergePopulationSnapshots(ctx, store, "pop-m", []string{"pop-a", "pop-b"})
+	if err != nil {
+		t.Fatalf("merge populations: %v", err)
+	}
+	if summary.MergedSize != 3 || summary.DuplicateCount != 1 || summary.Generation != 5 {
+		t.Fatalf("unexpected merge summary: %+v", summary)
+	}
+	if summary.SpeciesCount != 1 {
+		t.Fatalf("expected identical topologies to share one species, got %+v", summary)
+	}
+
+	pop, genomes, err := LoadPopulationSnapshot(ctx, store, "pop-m")
+	if err != nil {
+		t.Fatalf("load merged population: %v", err)
+	}
+	if len(genomes) != 3 || pop.AgentIDs[0] != "a1" || pop.AgentIDs[2] != "b2" {
+		t.Fatalf("unexpected merged members: %+v", pop.AgentIDs)
+	}
+	if pop.Fitness["b2"] != 3 || len(pop.Fitness) != 3 {
+		t.Fatalf("expected member fitness to carry over: %+v", pop.Fitness)
+	}
+
+	if _, err := MergePopulationSnapshots(ctx, store, "pop-a", []string{"pop-a", "pop-b"}); err == nil {
+		t.Fatal("expected merge into a source id to fail")
+	}
+	if _, err := MergePopulationSnapshots(ctx, store, "pop-x", []string{"pop-a"}); err == nil {
+		t.Fatal("expected merge of a single source to fail")
+	}
+}
@@ -0,0 +1,81 @@
+package genotype
+
+import (
+	"fmt"
+
+	"protogonos/internal/model"
+	"protogonos/internal/storage"
+)
+
+// ExtractModule cuts the subgraph spanned by neuronIDs out of genome. Only
+// synapses with both endpoints inside the subgraph are kept. A module neuron
+// becomes an input port when it was fed from outside the subgraph (or had no
+// internal inputs at all) and an output port when it fed a neuron outside it
+// (or had no internal outputs at all). Ports follow the genome neuron order.
+func ExtractModule(genome model.Genome, neuronIDs []string, moduleID string) (model.Module, error) {
+	if moduleID == "" {
+		return model.Module{}, fmt.Errorf("module id is required")
+	}
+	if len(neuronIDs) == 0 {
+		return model.Module{}, fmt.Errorf("module requires at least one neuron")
+	}
+	selected := make(map[string]struct{}, len(neuronIDs))
+	for _, id := range neuronIDs {
+		selected[id] = struct{}{}
+	}
+
+	module := model.Module{
+		VersionedRecord: model.VersionedRecord{
+			SchemaVersion: storage.CurrentSchemaVersion,
+			CodecVersion:  storage.CurrentCodecVersion,
+		},
+		ID:             moduleID,
+		SourceGenomeID: genome.ID,
+	}
+	for _, neuron := range genome.Neurons {
+		if _, ok := selected[neuron.ID]; ok {
+			module.Neurons = append(module.Neurons, neuron)
+		}
+	}
+	if len(module.Neurons) != len(selected) {
+		found := make(map[string]struct{}, len(module.Neurons))
+		for _, neuron := range module.Neurons {
+			found[neuron.ID] = struct{}{}
+		}
+		for _, id := range neuronIDs {
+			if _, ok := found[id]; !ok {
+				return model.Module{}, fmt.Errorf("neuron %s not found in genome %s", id, genome.ID)
+			}
+		}
+	}
+
+	fedExternally := map[string]bool{}
+	feedsExternally := map[string]bool{}
+	hasInternalIn := map[string]bool{}
+	hasInternalOut := map[string]bool{}
+	for _, syn := range genome.Synapses {
+		_, fromInside := selected[syn.From]
+		_, toInside := selected[syn.To]
+		switch {
+		case fromInside && toInside:
+			module.Synapses = append(module.Synapses, syn)
+			if syn.From != syn.To {
+				hasInternalOut[syn.From] = true
+				hasInternalIn[syn.To] = true
+			}
+		case toInside:
+			fedExternally[syn.To] = true
+		case fromInside:
+			feedsExternally[syn.From] = true
+		}
+	}
+	for _, neuron := range module.Neurons {
+		if fedExternally[neuron.ID] || !hasInternalIn[neuron.ID] {
+			module.Inputs = append(module.Inputs, neuron.ID)
+		}
+		if feedsExternally[neuron.ID] || !hasInternalOut[neuron.ID] {
+			module.Outputs = append(module.Outputs, neuron.ID)
+		}
+	}
+	return module, nil
+}
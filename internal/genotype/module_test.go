@@ -0,0 +1,54 @@
+package genotype
+
+import (
+	"reflect"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func TestExtractModuleKeepsInternalSynapsesAndPorts(t *testing.T) {
+	genome := model.Genome{
+		ID:      "g",
+		Neurons: []model.Neuron{{ID: "i"}, {ID: "h1"}, {ID: "h2"}, {ID: "h3"}, {ID: "o"}},
+		Synapses: []model.Synapse{
+			{ID: "s1", From: "i", To: "h1", Weight: 1},
+			{ID: "s2", From: "h1", To: "h2", Weight: 0.5},
+			{ID: "s3", From: "h2", To: "h2", Weight: 0.1, Recurrent: true},
+			{ID: "s4", From: "h2", To: "o", Weight: -1},
+			{ID: "s5", From: "h3", To: "o", Weight: 2},
+		},
+	}
+
+	module, err := ExtractModule(genome, []string{"h1", "h2"}, "m1")
+	if err != nil {
+		t.Fatalf("extract module: %v", err)
+	}
+	if module.ID != "m1" || module.SourceGenomeID != "g" {
+		t.Fatalf("unexpected module identity: %+v", module)
+	}
+	if len(module.Neurons) != 2 || module.Neurons[0].ID != "h1" || module.Neurons[1].ID != "h2" {
+		t.Fatalf("unexpected module neurons: %+v", module.Neurons)
+	}
+	if len(module.Synapses) != 2 || module.Synapses[0].ID != "s2" || module.Synapses[1].ID != "s3" {
+		t.Fatalf("unexpected module synapses: %+v", module.Synapses)
+	}
+	if !reflect.DeepEqual(module.Inputs, []string{"h1"}) || !reflect.DeepEqual(module.Outputs, []string{"h2"}) {
+		t.Fatalf("unexpected ports inputs=%v outputs=%v", module.Inputs, module.Outputs)
+	}
+
+	single, err := ExtractModule(genome, []string{"h3"}, "m2")
+	if err != nil {
+		t.Fatalf("extract single neuron module: %v", err)
+	}
+	if !reflect.DeepEqual(single.Inputs, []string{"h3"}) || !reflect.DeepEqual(single.Outputs, []string{"h3"}) {
+		t.Fatalf("expected lone neuron to be both ports, got %+v", single)
+	}
+
+	if _, err := ExtractModule(genome, []string{"h1", "missing"}, "m3"); err == nil {
+		t.Fatal("expected unknown neuron to be rejected")
+	}
+	if _, err := ExtractModule(genome, nil, "m4"); err == nil {
+		t.Fatal("expected empty neuron set to be rejected")
+	}
+}
@@ -0,0 +1,87 @@
+package genotype
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"protogonos/internal/model"
+)
+
+// sensorSubsetSeedOffset keeps the subset draws off the stream that jitters
+// the seed weights, so enabling subsets leaves those weights unchanged.
+const sensorSubsetSeedOffset = 1_400
+
+// ValidateSensorSubset reports whether initial sensor subset bounds are
+// usable. A zero maximum keeps every morphology sensor; a zero minimum
+// means one.
+func ValidateSensorSubset(minSensors, maxSensors int) error {
+	if minSensors < 0 {
+		return fmt.Errorf("initial sensor subset minimum must be >= 0, got %d", minSensors)
+	}
+	if maxSensors < 0 {
+		return fmt.Errorf("initial sensor subset maximum must be >= 0, got %d", maxSensors)
+	}
+	if maxSensors == 0 {
+		if minSensors > 0 {
+			return errors.New("initial sensor subset minimum requires a maximum")
+		}
+		return nil
+	}
+	if minSensors > maxSensors {
+		return fmt.Errorf("initial sensor subset minimum %d exceeds maximum %d", minSensors, maxSensors)
+	}
+	return nil
+}
+
+// applySensorSubsets gives every seed genome a random subset of its
+// morphology's sensors, between options.SensorSubsetMin and
+// options.SensorSubsetMax of them, so which sensors a network reads is
+// itself under selection from generation zero. The seed scaffolds feed
+// sensor i into input neuron i by position; a kept sensor is linked to its
+// input neuron explicitly so dropping its neighbours does not shift its
+// signal. Input neurons of dropped sensors stay in place, silent, for
+// add_sensor to reconnect later.
+func applySensorSubsets(population SeedPopulation, seed int64, options SeedPopulationOptions) (SeedPopulation, error) {
+	if err := ValidateSensorSubset(options.SensorSubsetMin, options.SensorSubsetMax); err != nil {
+		return SeedPopulation{}, err
+	}
+	if options.SensorSubsetMax == 0 {
+		return population, nil
+	}
+	minSensors := max(options.SensorSubsetMin, 1)
+	rng := rand.New(rand.NewSource(seed + sensorSubsetSeedOffset))
+	for i, genome := range population.Genomes {
+		if len(genome.SensorIDs) != len(population.InputNeuronIDs) {
+			return SeedPopulation{}, fmt.Errorf("initial sensor subsets need one input neuron per sensor, genome %s has %d sensors and %d inputs", genome.ID, len(genome.SensorIDs), len(population.InputNeuronIDs))
+		}
+		maxSensors := min(options.SensorSubsetMax, len(genome.SensorIDs))
+		if minSensors > maxSensors {
+			return SeedPopulation{}, fmt.Errorf("initial sensor subset minimum %d exceeds the %d sensors of genome %s", minSensors, len(genome.SensorIDs), genome.ID)
+		}
+		count := minSensors + rng.Intn(maxSensors-minSensors+1)
+		kept := rng.Perm(len(genome.SensorIDs))[:count]
+		keep := make([]bool, len(genome.SensorIDs))
+		for _, index := range kept {
+			keep[index] = true
+		}
+
+		sensorIDs := make([]string, 0, count)
+		links := make([]model.SensorNeuronLink, 0, count)
+		for index, sensorID := range genome.SensorIDs {
+			if !keep[index] {
+				continue
+			}
+			sensorIDs = append(sensorIDs, sensorID)
+			links = append(links, model.SensorNeuronLink{
+				SensorID: sensorID,
+				NeuronID: population.InputNeuronIDs[index],
+			})
+		}
+		genome.SensorIDs = sensorIDs
+		genome.SensorNeuronLinks = links
+		genome.SensorLinks = len(links)
+		population.Genomes[i] = genome
+	}
+	return population, nil
+}
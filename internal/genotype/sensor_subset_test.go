@@ -0,0 +1,79 @@
+package genotype
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConstructSeedPopulationWithSensorSubsets(t *testing.T) {
+	full, err := ConstructSeedPopulationWithOptions("fx", 12, 9, SeedPopulationOptions{})
+	if err != nil {
+		t.Fatalf("construct full population: %v", err)
+	}
+	subset, err := ConstructSeedPopulationWithOptions("fx", 12, 9, SeedPopulationOptions{SensorSubsetMin: 2, SensorSubsetMax: 4})
+	if err != nil {
+		t.Fatalf("construct subset population: %v", err)
+	}
+
+	inputBySensor := make(map[string]string, len(full.Genomes[0].SensorIDs))
+	for i, sensorID := range full.Genomes[0].SensorIDs {
+		inputBySensor[sensorID] = full.InputNeuronIDs[i]
+	}
+	distinct := map[string]struct{}{}
+	for i, genome := range subset.Genomes {
+		if len(genome.SensorIDs) < 2 || len(genome.SensorIDs) > 4 {
+			t.Fatalf("genome %s: expected 2-4 sensors, got %v", genome.ID, genome.SensorIDs)
+		}
+		if len(genome.SensorNeuronLinks) != len(genome.SensorIDs) || genome.SensorLinks != len(genome.SensorIDs) {
+			t.Fatalf("genome %s: expected one link per kept sensor, got %+v", genome.ID, genome.SensorNeuronLinks)
+		}
+		for j, link := range genome.SensorNeuronLinks {
+			if link.SensorID != genome.SensorIDs[j] || link.NeuronID != inputBySensor[link.SensorID] {
+				t.Fatalf("genome %s: expected %s linked to its scaffold input %s, got %+v", genome.ID, link.SensorID, inputBySensor[link.SensorID], link)
+			}
+		}
+		if !reflect.DeepEqual(genome.Synapses, full.Genomes[i].Synapses) {
+			t.Fatalf("genome %s: expected seed weights unchanged by subset selection", genome.ID)
+		}
+		distinct[strings.Join(genome.SensorIDs, ",")] = struct{}{}
+	}
+	if len(distinct) < 2 {
+		t.Fatal("expected sensor subsets to vary across the population")
+	}
+}
+
+func TestConstructSeedPopulationSensorSubsetClampsToMorphology(t *testing.T) {
+	population, err := ConstructSeedPopulationWithOptions("xor", 4, 1, SeedPopulationOptions{SensorSubsetMax: 10})
+	if err != nil {
+		t.Fatalf("construct population: %v", err)
+	}
+	for _, genome := range population.Genomes {
+		if len(genome.SensorIDs) < 1 || len(genome.SensorIDs) > 2 {
+			t.Fatalf("expected 1-2 xor sensors, got %v", genome.SensorIDs)
+		}
+	}
+	if _, err := ConstructSeedPopulationWithOptions("xor", 4, 1, SeedPopulationOptions{SensorSubsetMin: 3, SensorSubsetMax: 10}); err == nil {
+		t.Fatal("expected a minimum above the morphology's sensor count to fail")
+	}
+}
+
+func TestValidateSensorSubset(t *testing.T) {
+	for _, tc := range []struct {
+		minSensors int
+		maxSensors int
+		wantErr    bool
+	}{
+		{minSensors: 0, maxSensors: 0},
+		{minSensors: 0, maxSensors: 3},
+		{minSensors: 3, maxSensors: 3},
+		{minSensors: -1, maxSensors: 3, wantErr: true},
+		{minSensors: 0, maxSensors: -1, wantErr: true},
+		{minSensors: 2, maxSensors: 0, wantErr: true},
+		{minSensors: 4, maxSensors: 3, wantErr: true},
+	} {
+		if err := ValidateSensorSubset(tc.minSensors, tc.maxSensors); (err != nil) != tc.wantErr {
+			t.Fatalf("min=%d max=%d: got err %v, want error %t", tc.minSensors, tc.maxSensors, err, tc.wantErr)
+		}
+	}
+}
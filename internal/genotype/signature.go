@@ -66,6 +66,9 @@ func ComputeGenomeSignature(genome model.Genome) GenomeSignature {
 	if genome.Substrate != nil {
 		encodingType = "substrate"
 	}
+	if genome.Grammar != nil {
+		encodingType = "grammar"
+	}
 
 	summary := TopologySummary{
 		Type:                   encodingType,
@@ -104,6 +107,11 @@ func ComputeGenomeSignature(genome model.Genome) GenomeSignature {
 	}
 	appendDist("af", actDist)
 	appendDist("aggr", aggrDist)
+	if genome.Grammar != nil {
+		// Grammar genomes share their stored topology; their developmental
+		// program is what sets them apart.
+		parts = append(parts, "g="+grammarSignature(genome.Grammar))
+	}
 
 	digest := sha1.Sum([]byte(strings.Join(parts, "|")))
 	fingerprint := hex.EncodeToString(digest[:8])
@@ -112,3 +120,15 @@ func ComputeGenomeSignature(genome model.Genome) GenomeSignature {
 		Summary:     summary,
 	}
 }
+
+func grammarSignature(cfg *model.GrammarConfig) string {
+	rules := make([]string, 0, len(cfg.Rules))
+	for _, rule := range cfg.Rules {
+		rules = append(rules, rule.Symbol+">"+rule.Successor)
+	}
+	cells := make([]string, 0, len(cfg.Cells))
+	for _, cell := range cfg.Cells {
+		cells = append(cells, cell.Symbol+":"+cell.Activation)
+	}
+	return fmt.Sprintf("%s/%d/%s/%s", cfg.Axiom, cfg.Iterations, strings.Join(rules, ","), strings.Join(cells, ","))
+}
@@ -3,12 +3,21 @@ package genotype
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"protogonos/internal/model"
 	"protogonos/internal/storage"
 )
 
 func SavePopulationSnapshot(ctx context.Context, store storage.Store, populationID string, generation int, genomes []model.Genome) error {
+	return SaveScoredPopulationSnapshot(ctx, store, populationID, generation, genomes, nil)
+}
+
+// SaveScoredPopulationSnapshot persists a population snapshot together with
+// the last evaluated fitness of each member. Fitness entries for genomes that
+// are not part of the snapshot are dropped.
+func SaveScoredPopulationSnapshot(ctx context.Context, store storage.Store, populationID string, generation int, genomes []model.Genome, fitness map[string]float64) error {
 	if store == nil {
 		return fmt.Errorf("store is required")
 	}
@@ -36,6 +45,16 @@ func SavePopulationSnapshot(ctx context.Context, store storage.Store, population
 		return err
 	}
 
+	var memberFitness map[string]float64
+	if len(fitness) > 0 {
+		memberFitness = make(map[string]float64, len(agentIDs))
+		for _, id := range agentIDs {
+			if value, ok := fitness[id]; ok {
+				memberFitness[id] = value
+			}
+		}
+	}
+
 	return store.SavePopulation(ctx, model.Population{
 		VersionedRecord: model.VersionedRecord{
 			SchemaVersion: storage.CurrentSchemaVersion,
@@ -44,6 +63,7 @@ func SavePopulationSnapshot(ctx context.Context, store storage.Store, population
 		ID:         populationID,
 		AgentIDs:   agentIDs,
 		Generation: generation,
+		Fitness:    memberFitness,
 	})
 }
 
@@ -94,6 +114,29 @@ func DeletePopulationSnapshot(ctx context.Context, store storage.Store, populati
 	return store.DeletePopulation(ctx, populationID)
 }
 
+// GenerationSnapshotID is the population id under which a run saves the
+// scored population of one of its generations.
+func GenerationSnapshotID(runID string, generation int) string {
+	return fmt.Sprintf("%s@g%d", runID, generation)
+}
+
+// ParseGenerationSnapshotID splits a GenerationSnapshotID into its run id and
+// generation; ok is false for any other population id.
+func ParseGenerationSnapshotID(populationID string) (string, int, bool) {
+	i := strings.LastIndex(populationID, "@g")
+	if i <= 0 {
+		return "", 0, false
+	}
+	generation, err := strconv.Atoi(populationID[i+2:])
+	if err != nil || generation <= 0 {
+		return "", 0, false
+	}
+	return populationID[:i], generation, true
+}
+
+// reconcilePopulationMembership removes the agents that left populationID.
+// Their genomes are deleted unless another persisted population, such as a
+// generation snapshot of the same run, still references them.
 func reconcilePopulationMembership(ctx context.Context, store storage.Store, populationID string, keep map[string]struct{}) error {
 	population, ok, err := store.GetPopulation(ctx, populationID)
 	if err != nil {
@@ -103,13 +146,52 @@ func reconcilePopulationMembership(ctx context.Context, store storage.Store, pop
 		return nil
 	}
 
+	var shared map[string]struct{}
 	for _, agentID := range population.AgentIDs {
 		if _, stillPresent := keep[agentID]; stillPresent {
 			continue
 		}
+		if shared == nil {
+			if shared, err = sharedGenomeIDs(ctx, store, populationID); err != nil {
+				return err
+			}
+		}
+		if _, ok := shared[agentID]; ok {
+			continue
+		}
 		if err := DeleteAgentFromPopulation(ctx, store, populationID, agentID); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// sharedGenomeIDs lists the members of every persisted population other than
+// populationID; it is empty when the store cannot list populations.
+func sharedGenomeIDs(ctx context.Context, store storage.Store, populationID string) (map[string]struct{}, error) {
+	shared := map[string]struct{}{}
+	lister, ok := store.(storage.Lister)
+	if !ok {
+		return shared, nil
+	}
+	populationIDs, err := lister.ListPopulationIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range populationIDs {
+		if id == populationID {
+			continue
+		}
+		other, ok, err := store.GetPopulation(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		for _, agentID := range other.AgentIDs {
+			shared[agentID] = struct{}{}
+		}
+	}
+	return shared, nil
+}
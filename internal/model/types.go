@@ -1,5 +1,7 @@
 package model
 
+import "encoding/json"
+
 // VersionedRecord captures schema and codec evolution for persistent data.
 type VersionedRecord struct {
 	SchemaVersion int `json:"schema_version"`
@@ -20,8 +22,37 @@ type Genome struct {
 	SensorLinks         int                  `json:"sensor_links,omitempty"`
 	ActuatorLinks       int                  `json:"actuator_links,omitempty"`
 	Substrate           *SubstrateConfig     `json:"substrate,omitempty"`
+	Grammar             *GrammarConfig       `json:"grammar,omitempty"`
 	Plasticity          *PlasticityConfig    `json:"plasticity,omitempty"`
 	Strategy            *StrategyConfig      `json:"strategy,omitempty"`
+	Annotations         map[string]string    `json:"annotations,omitempty"`
+	Provenance          *GenomeProvenance    `json:"provenance,omitempty"`
+}
+
+// GenomeProvenance records how a genome came to exist: the operator chain
+// that produced it, its parent and generation, the tuning session that last
+// adjusted its weights, and any notes added after inspection.
+type GenomeProvenance struct {
+	CreatedBy       string   `json:"created_by,omitempty"`
+	ParentID        string   `json:"parent_id,omitempty"`
+	Generation      int      `json:"generation,omitempty"`
+	TuningSessionID string   `json:"tuning_session_id,omitempty"`
+	Notes           []string `json:"notes,omitempty"`
+}
+
+// Module is a reusable subgraph cut out of an evolved genome. Inputs are the
+// module neurons that were fed from outside the subgraph and Outputs the ones
+// that fed neurons outside it; insert_module wires exactly these ports when it
+// grafts the module into another genome.
+type Module struct {
+	VersionedRecord
+	ID             string    `json:"id"`
+	Neurons        []Neuron  `json:"neurons"`
+	Synapses       []Synapse `json:"synapses"`
+	Inputs         []string  `json:"inputs"`
+	Outputs        []string  `json:"outputs"`
+	SourceRunID    string    `json:"source_run_id,omitempty"`
+	SourceGenomeID string    `json:"source_genome_id,omitempty"`
 }
 
 type SensorNeuronLink struct {
@@ -53,6 +84,36 @@ type SubstrateConfig struct {
 	WeightCount int                `json:"weight_count"`
 }
 
+// GrammarConfig is the genotype of the grammar encoding: an L-system whose
+// Axiom is rewritten Iterations times by Rules. Every symbol of the expanded
+// word develops into one hidden neuron as its Cell describes.
+type GrammarConfig struct {
+	Axiom      string        `json:"axiom"`
+	Iterations int           `json:"iterations"`
+	Rules      []GrammarRule `json:"rules"`
+	Cells      []GrammarCell `json:"cells"`
+}
+
+// GrammarRule rewrites Symbol into Successor on every expansion step.
+// Symbols without a rule are copied unchanged.
+type GrammarRule struct {
+	Symbol    string `json:"symbol"`
+	Successor string `json:"successor"`
+}
+
+// GrammarCell describes the hidden neuron a symbol develops into. Input and
+// output weights are indexed by input and output neuron, cycling when there
+// are fewer weights than neurons; ChainWeight feeds the cell into the one
+// that follows it in the expanded word.
+type GrammarCell struct {
+	Symbol        string    `json:"symbol"`
+	Activation    string    `json:"activation"`
+	Bias          float64   `json:"bias"`
+	InputWeights  []float64 `json:"input_weights"`
+	OutputWeights []float64 `json:"output_weights"`
+	ChainWeight   float64   `json:"chain_weight"`
+}
+
 type PlasticityConfig struct {
 	Rule            string  `json:"rule"`
 	Rate            float64 `json:"rate"`
@@ -76,6 +137,12 @@ type Neuron struct {
 	PlasticityD          float64   `json:"plasticity_d,omitempty"`
 	PlasticityBiasParams []float64 `json:"plasticity_bias_params,omitempty"`
 	Bias                 float64   `json:"bias"`
+	// Frozen excludes the neuron's bias and incoming weights from weight
+	// mutation and tuning.
+	Frozen bool `json:"frozen,omitempty"`
+	// Innovation is the neuron's historical marking from the run's
+	// innovation registry; zero when the run does not track innovations.
+	Innovation int `json:"innovation,omitempty"`
 }
 
 type Synapse struct {
@@ -86,6 +153,21 @@ type Synapse struct {
 	Enabled          bool      `json:"enabled"`
 	Recurrent        bool      `json:"recurrent"`
 	PlasticityParams []float64 `json:"plasticity_params,omitempty"`
+	// Frozen excludes the weight from weight mutation and tuning.
+	Frozen bool `json:"frozen,omitempty"`
+	// DisabledFor counts the consecutive generations of descent the synapse
+	// has been disabled, while disabled synapse pruning is on.
+	DisabledFor int `json:"disabled_for,omitempty"`
+	// Innovation is the synapse's historical marking from the run's
+	// innovation registry; zero when the run does not track innovations.
+	Innovation int `json:"innovation,omitempty"`
+}
+
+// InnovationRecord is one entry of a run's innovation registry: the
+// structural key of a gene and the innovation number assigned to it.
+type InnovationRecord struct {
+	Key        string `json:"key"`
+	Innovation int    `json:"innovation"`
 }
 
 type Agent struct {
@@ -96,9 +178,10 @@ type Agent struct {
 
 type Population struct {
 	VersionedRecord
-	ID         string   `json:"id"`
-	AgentIDs   []string `json:"agent_ids"`
-	Generation int      `json:"generation"`
+	ID         string             `json:"id"`
+	AgentIDs   []string           `json:"agent_ids"`
+	Generation int                `json:"generation"`
+	Fitness    map[string]float64 `json:"fitness,omitempty"`
 }
 
 type LineageSummary struct {
@@ -126,30 +209,146 @@ type LineageRecord struct {
 	ParentID    string            `json:"parent_id"`
 	Generation  int               `json:"generation"`
 	Operation   string            `json:"operation"`
+	MateID      string            `json:"mate_id,omitempty"`
 	Events      []EvoHistoryEvent `json:"events,omitempty"`
 	Fingerprint string            `json:"fingerprint,omitempty"`
 	Summary     LineageSummary    `json:"summary,omitempty"`
 }
 
 type GenerationDiagnostics struct {
-	Generation            int     `json:"generation"`
-	BestFitness           float64 `json:"best_fitness"`
-	MeanFitness           float64 `json:"mean_fitness"`
-	MinFitness            float64 `json:"min_fitness"`
-	SpeciesCount          int     `json:"species_count"`
-	FingerprintDiversity  int     `json:"fingerprint_diversity"`
-	SpeciationThreshold   float64 `json:"speciation_threshold"`
-	TargetSpeciesCount    int     `json:"target_species_count"`
-	MeanSpeciesSize       float64 `json:"mean_species_size"`
-	LargestSpeciesSize    int     `json:"largest_species_size"`
-	TuningInvocations     int     `json:"tuning_invocations"`
-	TuningAttempts        int     `json:"tuning_attempts"`
-	TuningEvaluations     int     `json:"tuning_evaluations"`
-	TuningAccepted        int     `json:"tuning_accepted"`
-	TuningRejected        int     `json:"tuning_rejected"`
-	TuningGoalHits        int     `json:"tuning_goal_hits"`
-	TuningAcceptRate      float64 `json:"tuning_accept_rate"`
-	TuningEvalsPerAttempt float64 `json:"tuning_evals_per_attempt"`
+	Generation            int                `json:"generation"`
+	BestFitness           float64            `json:"best_fitness"`
+	MeanFitness           float64            `json:"mean_fitness"`
+	MinFitness            float64            `json:"min_fitness"`
+	SpeciesCount          int                `json:"species_count"`
+	FingerprintDiversity  int                `json:"fingerprint_diversity"`
+	SpeciationThreshold   float64            `json:"speciation_threshold"`
+	TargetSpeciesCount    int                `json:"target_species_count"`
+	MeanSpeciesSize       float64            `json:"mean_species_size"`
+	LargestSpeciesSize    int                `json:"largest_species_size"`
+	TuningInvocations     int                `json:"tuning_invocations"`
+	TuningAttempts        int                `json:"tuning_attempts"`
+	TuningEvaluations     int                `json:"tuning_evaluations"`
+	TuningAccepted        int                `json:"tuning_accepted"`
+	TuningRejected        int                `json:"tuning_rejected"`
+	TuningGoalHits        int                `json:"tuning_goal_hits"`
+	TuningAcceptRate      float64            `json:"tuning_accept_rate"`
+	TuningEvalsPerAttempt float64            `json:"tuning_evals_per_attempt"`
+	WallClockSeconds      float64            `json:"wall_clock_seconds,omitempty"`
+	TotalEvaluations      int                `json:"total_evaluations,omitempty"`
+	EvaluationsPerSecond  float64            `json:"evaluations_per_second,omitempty"`
+	ETASeconds            float64            `json:"eta_seconds,omitempty"`
+	ScapeParamChanges     []ScapeParamChange `json:"scape_param_changes,omitempty"`
+	FidelityFinalists     int                `json:"fidelity_finalists,omitempty"`
+	FidelityOffset        float64            `json:"fidelity_offset,omitempty"`
+	PopulationWeights     *WeightStats       `json:"population_weights,omitempty"`
+	ChampionLayerWeights  []LayerWeightStats `json:"champion_layer_weights,omitempty"`
+	ClampEvents           int                `json:"clamp_events,omitempty"`
+	EvalCacheHits         int                `json:"eval_cache_hits,omitempty"`
+	EvalCacheMisses       int                `json:"eval_cache_misses,omitempty"`
+	CrossoverIntra        int                `json:"crossover_intra,omitempty"`
+	CrossoverHybrid       int                `json:"crossover_hybrid,omitempty"`
+	CrossoverIntraMean    float64            `json:"crossover_intra_mean,omitempty"`
+	CrossoverHybridMean   float64            `json:"crossover_hybrid_mean,omitempty"`
+	DegenerateGenomes     int                `json:"degenerate_genomes,omitempty"`
+	BannedFingerprints    int                `json:"banned_fingerprints,omitempty"`
+	GenotypeEntropy       float64            `json:"genotype_entropy,omitempty"`
+	BehaviorEntropy       float64            `json:"behavior_entropy,omitempty"`
+	EntropyAction         string             `json:"entropy_action,omitempty"`
+	SpeciesAllocation     map[string]int     `json:"species_allocation,omitempty"`
+	SpeciesRebalancing    []SpeciesRebalance `json:"species_rebalancing,omitempty"`
+	PopulationSize        int                `json:"population_size,omitempty"`
+	PopulationResize      string             `json:"population_resize,omitempty"`
+	// Strategies is the population's strategy value distribution.
+	Strategies *StrategyDistribution `json:"strategies,omitempty"`
+	// EvaluationCost is the metered cost of the generation's evaluations.
+	EvaluationCost *EvaluationCostStats `json:"evaluation_cost,omitempty"`
+	// ScapePoolWaitSeconds is the time evaluations waited for a pooled
+	// scape instance.
+	ScapePoolWaitSeconds float64 `json:"scape_pool_wait_seconds,omitempty"`
+	// IslandBest is the best fitness on each island and Migrants the number
+	// of genomes that migrated into the next generation.
+	IslandBest []float64 `json:"island_best,omitempty"`
+	Migrants   int       `json:"migrants,omitempty"`
+}
+
+// EvaluationCostStats aggregates metered scape evaluation cost: totals,
+// per-evaluation means and the most expensive genome's per-evaluation CPU.
+type EvaluationCostStats struct {
+	Evaluations    int     `json:"evaluations"`
+	CPUSeconds     float64 `json:"cpu_seconds"`
+	AllocBytes     uint64  `json:"alloc_bytes"`
+	MeanCPUSeconds float64 `json:"mean_cpu_seconds"`
+	MaxCPUSeconds  float64 `json:"max_cpu_seconds"`
+	MeanAllocBytes float64 `json:"mean_alloc_bytes"`
+}
+
+// SpeciesRebalance is one species soft-cap action: a species becoming
+// capped or released, or its offspring being trimmed to the cap or topped
+// up with another species' excess. Size is the species' population size;
+// Planned and Allocated are its offspring before and after rebalancing.
+type SpeciesRebalance struct {
+	SpeciesKey string `json:"species_key"`
+	Action     string `json:"action"`
+	Size       int    `json:"size"`
+	Planned    int    `json:"planned"`
+	Allocated  int    `json:"allocated"`
+}
+
+// PhaseTimings breaks the wall-clock between two trace generation entries
+// down by phase. Selection and mutation are the reproduction that bred the
+// generation; evaluation and tuning share the parallel evaluation wall-clock
+// in proportion to the worker time each took; speciation is species
+// assignment; persistence is time spent in the run's trace and progress
+// hooks; other is the remaining bookkeeping.
+type PhaseTimings struct {
+	WallClockSeconds   float64 `json:"wall_clock_seconds"`
+	SelectionSeconds   float64 `json:"selection_seconds"`
+	MutationSeconds    float64 `json:"mutation_seconds"`
+	EvaluationSeconds  float64 `json:"evaluation_seconds"`
+	TuningSeconds      float64 `json:"tuning_seconds"`
+	SpeciationSeconds  float64 `json:"speciation_seconds"`
+	PersistenceSeconds float64 `json:"persistence_seconds"`
+	OtherSeconds       float64 `json:"other_seconds"`
+}
+
+// WeightStats summarizes a set of enabled synapse weights. Histogram has
+// equal-width bins over [-limit, limit] of the weight saturation limit, with
+// out-of-range weights counted in the end bins.
+type WeightStats struct {
+	Count             int     `json:"count"`
+	Mean              float64 `json:"mean"`
+	Std               float64 `json:"std"`
+	Min               float64 `json:"min"`
+	Max               float64 `json:"max"`
+	P05               float64 `json:"p05"`
+	P50               float64 `json:"p50"`
+	P95               float64 `json:"p95"`
+	SaturatedFraction float64 `json:"saturated_fraction"`
+	Histogram         []int   `json:"histogram,omitempty"`
+}
+
+// StrategyDistribution counts a population's genomes by the effective value
+// of each evolvable strategy field. Topological modes are keyed mode(param).
+type StrategyDistribution struct {
+	TuningSelection map[string]int `json:"tuning_selection"`
+	AnnealingFactor map[string]int `json:"annealing_factor"`
+	TopologicalMode map[string]int `json:"topological_mode"`
+	HeredityType    map[string]int `json:"heredity_type"`
+}
+
+// LayerWeightStats breaks a genome's weights down by the inferred
+// feedforward layer of the synapse target neuron.
+type LayerWeightStats struct {
+	Layer   int `json:"layer"`
+	Neurons int `json:"neurons"`
+	WeightStats
+}
+
+type ScapeParamChange struct {
+	Name     string  `json:"name"`
+	Previous float64 `json:"previous"`
+	Value    float64 `json:"value"`
 }
 
 type SpeciesGeneration struct {
@@ -160,16 +359,83 @@ type SpeciesGeneration struct {
 }
 
 type SpeciesMetrics struct {
-	Key         string  `json:"key"`
-	Size        int     `json:"size"`
-	MeanFitness float64 `json:"mean_fitness"`
-	BestFitness float64 `json:"best_fitness"`
+	Key         string                `json:"key"`
+	Size        int                   `json:"size"`
+	MeanFitness float64               `json:"mean_fitness"`
+	BestFitness float64               `json:"best_fitness"`
+	Strategies  *StrategyDistribution `json:"strategies,omitempty"`
+}
+
+// TuningTrace is one agent's tuning session: every candidate the tuner
+// evaluated for GenomeID in Generation, in evaluation order.
+type TuningTrace struct {
+	Generation int                    `json:"generation"`
+	GenomeID   string                 `json:"genome_id"`
+	SessionID  string                 `json:"session_id"`
+	Selection  string                 `json:"selection,omitempty"`
+	Candidates []TuningTraceCandidate `json:"candidates"`
+}
+
+// TuningTraceCandidate records one tuning candidate: the perturbed elements
+// (neuron:<id> or actuator:<id>), the Euclidean norm of the weight change
+// from its base, the incumbent fitness it was compared against and its own
+// fitness, and whether it was accepted.
+type TuningTraceCandidate struct {
+	Attempt       int      `json:"attempt"`
+	Candidate     int      `json:"candidate"`
+	Elements      []string `json:"elements,omitempty"`
+	Delta         float64  `json:"delta"`
+	FitnessBefore float64  `json:"fitness_before"`
+	FitnessAfter  float64  `json:"fitness_after"`
+	Accepted      bool     `json:"accepted"`
+	// ValidationBefore and ValidationAfter are the validation-split fitnesses
+	// acceptance compared, set only under validation acceptance.
+	ValidationBefore *float64 `json:"validation_before,omitempty"`
+	ValidationAfter  *float64 `json:"validation_after,omitempty"`
+}
+
+// EvolutionEvent is one entry of a run's event log. Value and Previous carry
+// the fitness or scape parameter an event reports, when it reports one.
+type EvolutionEvent struct {
+	Generation int     `json:"generation"`
+	Type       string  `json:"type"`
+	SpeciesKey string  `json:"species_key,omitempty"`
+	GenomeID   string  `json:"genome_id,omitempty"`
+	Value      float64 `json:"value,omitempty"`
+	Previous   float64 `json:"previous,omitempty"`
+	Detail     string  `json:"detail,omitempty"`
 }
 
 type TopGenomeRecord struct {
-	Rank    int     `json:"rank"`
-	Fitness float64 `json:"fitness"`
-	Genome  Genome  `json:"genome"`
+	Rank        int       `json:"rank"`
+	Fitness     float64   `json:"fitness"`
+	FoldFitness []float64 `json:"fold_fitness,omitempty"`
+	Genome      Genome    `json:"genome"`
+}
+
+// LeaderboardEntry is one champion on a scape's leaderboard. Config
+// summarizes the run that evolved it, and ValidationFitness is the
+// champion's score on the scape's validation split, when it could be scored
+// there.
+type LeaderboardEntry struct {
+	RunID             string   `json:"run_id"`
+	GenomeID          string   `json:"genome_id"`
+	Fitness           float64  `json:"fitness"`
+	ValidationFitness *float64 `json:"validation_fitness,omitempty"`
+	Config            string   `json:"config"`
+	CreatedAtUTC      string   `json:"created_at_utc"`
+}
+
+// RunCheckpoint is the latest saved state of an unfinished run: Generation
+// counts the generations it had evaluated, Config is the run's configuration
+// and State the population monitor's checkpoint, both as JSON, so the run
+// can be resumed after a crash.
+type RunCheckpoint struct {
+	RunID      string          `json:"run_id"`
+	Generation int             `json:"generation"`
+	Config     json.RawMessage `json:"config"`
+	State      json.RawMessage `json:"state"`
+	SavedAtUTC string          `json:"saved_at_utc"`
 }
 
 type ScapeSummary struct {
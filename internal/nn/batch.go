@@ -0,0 +1,180 @@
+package nn
+
+import (
+	"errors"
+	"fmt"
+
+	"protogonos/internal/model"
+)
+
+// ErrBatchUnsupported reports a genome whose forward pass carries state from
+// one input row to the next, so rows cannot be evaluated independently.
+var ErrBatchUnsupported = errors.New("genome does not support batched evaluation")
+
+// BatchEvaluable reports whether a genome's outputs depend only on the current
+// input row: no plasticity, no recurrent synapses and no diff_product neurons.
+func BatchEvaluable(genome model.Genome) bool {
+	if genome.Plasticity != nil {
+		return false
+	}
+	for _, synapse := range genome.Synapses {
+		if synapse.Enabled && synapse.Recurrent {
+			return false
+		}
+	}
+	for _, neuron := range genome.Neurons {
+		if neuron.Aggregator == "diff_product" {
+			return false
+		}
+	}
+	return true
+}
+
+// ForwardBatch evaluates every input row in a single pass over the topology,
+// computing each neuron for the whole batch before moving to the next. Rows
+// are mapped onto inputNeuronIDs positionally, matching single-row execution,
+// and the returned rows hold the outputNeuronIDs values.
+func ForwardBatch(genome model.Genome, inputNeuronIDs []string, rows [][]float64, outputNeuronIDs []string) ([][]float64, error) {
+	return ForwardBatchCached(genome, inputNeuronIDs, rows, outputNeuronIDs, nil)
+}
+
+// ForwardBatchCached is ForwardBatch reusing neuron columns memoized in cache.
+// A nil cache computes every column.
+func ForwardBatchCached(genome model.Genome, inputNeuronIDs []string, rows [][]float64, outputNeuronIDs []string, cache *BatchCache) ([][]float64, error) {
+	if !BatchEvaluable(genome) {
+		return nil, ErrBatchUnsupported
+	}
+	size := len(rows)
+	if size == 0 {
+		return nil, nil
+	}
+	width := len(rows[0])
+	for i, row := range rows {
+		if len(row) != width {
+			return nil, fmt.Errorf("batch row %d has %d inputs, want %d", i, len(row), width)
+		}
+	}
+	if width > len(inputNeuronIDs) {
+		width = len(inputNeuronIDs)
+	}
+
+	columns := make(map[string][]float64, len(genome.Neurons)+width)
+	for i := 0; i < width; i++ {
+		column, ok := columns[inputNeuronIDs[i]]
+		if !ok {
+			column = make([]float64, size)
+			columns[inputNeuronIDs[i]] = column
+		}
+		for s, row := range rows {
+			column[s] += row[i]
+		}
+	}
+	fixedInputs := make(map[string]struct{}, len(columns))
+	var keys map[string]uint64
+	if cache != nil {
+		keys = make(map[string]uint64, len(genome.Neurons)+len(columns))
+	}
+	for neuronID, column := range columns {
+		fixedInputs[neuronID] = struct{}{}
+		if keys != nil {
+			keys[neuronID] = inputColumnKey(column)
+		}
+	}
+
+	incoming := make(map[string][]model.Synapse, len(genome.Neurons))
+	for _, synapse := range genome.Synapses {
+		if !synapse.Enabled {
+			continue
+		}
+		incoming[synapse.To] = append(incoming[synapse.To], synapse)
+	}
+
+	for _, neuron := range genome.Neurons {
+		if _, fixedInput := fixedInputs[neuron.ID]; fixedInput {
+			continue
+		}
+		var key uint64
+		if keys != nil {
+			key = neuronColumnKey(neuron, incoming[neuron.ID], keys, size)
+			if column, ok := cache.lookup(key, size); ok {
+				columns[neuron.ID] = column
+				keys[neuron.ID] = key
+				continue
+			}
+		}
+		column, err := aggregateIncomingBatch(neuron, incoming[neuron.ID], columns, size)
+		if err != nil {
+			return nil, fmt.Errorf("neuron %s: %w", neuron.ID, err)
+		}
+		activation, err := GetActivation(neuron.Activation)
+		if err != nil {
+			return nil, fmt.Errorf("neuron %s: unsupported activation: %s", neuron.ID, neuron.Activation)
+		}
+		for s := range column {
+			column[s] = saturate(activation(column[s]), -outputSaturationLimit, outputSaturationLimit)
+		}
+		columns[neuron.ID] = column
+		if keys != nil {
+			cache.store(key, column)
+			keys[neuron.ID] = key
+		}
+	}
+
+	out := make([][]float64, size)
+	for s := range out {
+		out[s] = make([]float64, len(outputNeuronIDs))
+		for k, neuronID := range outputNeuronIDs {
+			if column, ok := columns[neuronID]; ok {
+				out[s][k] = column[s]
+			}
+		}
+	}
+	return out, nil
+}
+
+func aggregateIncomingBatch(neuron model.Neuron, synapses []model.Synapse, columns map[string][]float64, size int) ([]float64, error) {
+	column := make([]float64, size)
+	switch neuron.Aggregator {
+	case "", "dot_product":
+		for s := range column {
+			column[s] = neuron.Bias
+		}
+		for _, synapse := range synapses {
+			source := columns[synapse.From]
+			for s := range column {
+				column[s] += batchValue(source, s) * synapse.Weight
+			}
+		}
+	case "mult_product":
+		if len(synapses) == 0 {
+			for s := range column {
+				column[s] = neuron.Bias
+			}
+			return column, nil
+		}
+		for s := range column {
+			column[s] = 1
+		}
+		for _, synapse := range synapses {
+			source := columns[synapse.From]
+			for s := range column {
+				column[s] *= batchValue(source, s) * synapse.Weight
+			}
+		}
+		if neuron.Bias != 0 {
+			for s := range column {
+				column[s] *= neuron.Bias
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported aggregator: %s", neuron.Aggregator)
+	}
+	return column, nil
+}
+
+func batchValue(column []float64, s int) float64 {
+	if column == nil {
+		return 0
+	}
+	return column[s]
+}
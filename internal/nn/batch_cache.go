@@ -0,0 +1,156 @@
+package nn
+
+import (
+	"context"
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+	"sync"
+
+	"protogonos/internal/model"
+)
+
+// DefaultBatchCacheEntries bounds a BatchCache created with a non-positive
+// size.
+const DefaultBatchCacheEntries = 4096
+
+// BatchCacheStats counts neuron columns served from and added to a cache.
+type BatchCacheStats struct {
+	Hits   int `json:"hits"`
+	Misses int `json:"misses"`
+}
+
+func (s *BatchCacheStats) Add(other BatchCacheStats) {
+	s.Hits += other.Hits
+	s.Misses += other.Misses
+}
+
+// BatchCache memoizes per-neuron activation columns across ForwardBatchCached
+// calls. A column is keyed by the neuron's aggregator, activation and bias,
+// its incoming weights and the keys of its upstream columns, so the key of an
+// input column is a hash of the input values themselves. When tuning perturbs
+// a few weights over a fixed dataset only the perturbed neurons and their
+// downstream cone miss; every other column is reused. Because keys are
+// content addressed a cache can be shared by any genomes evaluated on the
+// same rows. When the cache reaches its entry limit it is cleared.
+type BatchCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	columns    map[uint64][]float64
+	stats      BatchCacheStats
+}
+
+func NewBatchCache(maxEntries int) *BatchCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultBatchCacheEntries
+	}
+	return &BatchCache{
+		maxEntries: maxEntries,
+		columns:    make(map[uint64][]float64),
+	}
+}
+
+func (c *BatchCache) Stats() BatchCacheStats {
+	if c == nil {
+		return BatchCacheStats{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+func (c *BatchCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.columns)
+}
+
+func (c *BatchCache) lookup(key uint64, size int) ([]float64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	column, ok := c.columns[key]
+	if !ok || len(column) != size {
+		c.stats.Misses++
+		return nil, false
+	}
+	c.stats.Hits++
+	return column, true
+}
+
+func (c *BatchCache) store(key uint64, column []float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.columns) >= c.maxEntries {
+		c.columns = make(map[uint64][]float64)
+	}
+	c.columns[key] = column
+}
+
+type batchCacheKey struct{}
+
+// WithBatchCache makes cache available to batched evaluations under ctx.
+func WithBatchCache(ctx context.Context, cache *BatchCache) context.Context {
+	return context.WithValue(ctx, batchCacheKey{}, cache)
+}
+
+// BatchCacheFromContext returns the cache attached by WithBatchCache, or nil.
+func BatchCacheFromContext(ctx context.Context) *BatchCache {
+	cache, _ := ctx.Value(batchCacheKey{}).(*BatchCache)
+	return cache
+}
+
+type columnHasher struct {
+	buf [8]byte
+	h   hash.Hash64
+}
+
+func newColumnHasher(tag string) *columnHasher {
+	hasher := &columnHasher{h: fnv.New64a()}
+	_, _ = hasher.h.Write([]byte(tag))
+	return hasher
+}
+
+func (h *columnHasher) float(v float64) {
+	binary.LittleEndian.PutUint64(h.buf[:], math.Float64bits(v))
+	_, _ = h.h.Write(h.buf[:])
+}
+
+func (h *columnHasher) uint(v uint64) {
+	binary.LittleEndian.PutUint64(h.buf[:], v)
+	_, _ = h.h.Write(h.buf[:])
+}
+
+func (h *columnHasher) string(v string) {
+	h.uint(uint64(len(v)))
+	_, _ = h.h.Write([]byte(v))
+}
+
+func inputColumnKey(column []float64) uint64 {
+	hasher := newColumnHasher("input")
+	hasher.uint(uint64(len(column)))
+	for _, v := range column {
+		hasher.float(v)
+	}
+	return hasher.h.Sum64()
+}
+
+// neuronColumnKey hashes everything aggregateIncomingBatch and the activation
+// read. A source without a column yet (absent or later in neuron order) reads
+// as zero and hashes as key zero.
+func neuronColumnKey(neuron model.Neuron, synapses []model.Synapse, keys map[string]uint64, size int) uint64 {
+	hasher := newColumnHasher("neuron")
+	hasher.uint(uint64(size))
+	hasher.string(neuron.Aggregator)
+	hasher.string(neuron.Activation)
+	hasher.float(neuron.Bias)
+	hasher.uint(uint64(len(synapses)))
+	for _, synapse := range synapses {
+		hasher.uint(keys[synapse.From])
+		hasher.float(synapse.Weight)
+	}
+	return hasher.h.Sum64()
+}
@@ -0,0 +1,158 @@
+package nn
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func TestForwardBatchMatchesForwardPerRow(t *testing.T) {
+	genome := model.Genome{
+		Neurons: []model.Neuron{
+			{ID: "i1", Activation: "identity"},
+			{ID: "i2", Activation: "identity"},
+			{ID: "h", Activation: "tanh", Bias: 0.1},
+			{ID: "m", Activation: "sigmoid", Aggregator: "mult_product", Bias: 0.5},
+			{ID: "o", Activation: "sigmoid", Bias: -0.2},
+			{ID: "late", Activation: "relu"},
+		},
+		Synapses: []model.Synapse{
+			{From: "i1", To: "h", Weight: 1.5, Enabled: true},
+			{From: "i2", To: "h", Weight: -0.7, Enabled: true},
+			{From: "i1", To: "m", Weight: 0.9, Enabled: true},
+			{From: "h", To: "m", Weight: 2.0, Enabled: true},
+			{From: "h", To: "o", Weight: 1.2, Enabled: true},
+			{From: "m", To: "o", Weight: -0.4, Enabled: true},
+			{From: "late", To: "o", Weight: 3.0, Enabled: true},
+			{From: "i2", To: "o", Weight: 9.0, Enabled: false},
+			{From: "h", To: "late", Weight: 1.0, Enabled: true},
+		},
+	}
+	inputs := []string{"i1", "i2"}
+	outputs := []string{"o", "h"}
+	rows := [][]float64{{0, 0}, {0, 1}, {1, 0}, {1, 1}, {-0.5, 0.25}}
+
+	batch, err := ForwardBatch(genome, inputs, rows, outputs)
+	if err != nil {
+		t.Fatalf("forward batch: %v", err)
+	}
+	if len(batch) != len(rows) {
+		t.Fatalf("expected %d output rows, got %d", len(rows), len(batch))
+	}
+	for s, row := range rows {
+		values, err := Forward(genome, map[string]float64{"i1": row[0], "i2": row[1]})
+		if err != nil {
+			t.Fatalf("forward row %d: %v", s, err)
+		}
+		for k, neuronID := range outputs {
+			if math.Abs(batch[s][k]-values[neuronID]) > 1e-12 {
+				t.Fatalf("row %d output %s: batch=%f forward=%f", s, neuronID, batch[s][k], values[neuronID])
+			}
+		}
+	}
+}
+
+func TestForwardBatchRejectsStatefulGenomes(t *testing.T) {
+	recurrent := model.Genome{
+		Neurons:  []model.Neuron{{ID: "i", Activation: "identity"}, {ID: "o", Activation: "tanh"}},
+		Synapses: []model.Synapse{{From: "o", To: "o", Weight: 0.5, Enabled: true, Recurrent: true}},
+	}
+	diff := model.Genome{
+		Neurons: []model.Neuron{{ID: "i", Activation: "identity"}, {ID: "o", Activation: "tanh", Aggregator: "diff_product"}},
+	}
+	plastic := model.Genome{
+		Neurons:    []model.Neuron{{ID: "i", Activation: "identity"}, {ID: "o", Activation: "tanh"}},
+		Plasticity: &model.PlasticityConfig{Rule: "hebbian", Rate: 0.1},
+	}
+	for name, genome := range map[string]model.Genome{"recurrent": recurrent, "diff_product": diff, "plasticity": plastic} {
+		if BatchEvaluable(genome) {
+			t.Fatalf("%s genome should not be batch evaluable", name)
+		}
+		if _, err := ForwardBatch(genome, []string{"i"}, [][]float64{{1}}, []string{"o"}); !errors.Is(err, ErrBatchUnsupported) {
+			t.Fatalf("%s: expected ErrBatchUnsupported, got %v", name, err)
+		}
+	}
+	if _, err := ForwardBatch(model.Genome{}, []string{"i1", "i2"}, [][]float64{{1, 2}, {1}}, nil); err == nil {
+		t.Fatal("expected ragged batch rows to fail")
+	}
+}
+
+func TestForwardBatchCachedReusesUnchangedColumns(t *testing.T) {
+	genome := model.Genome{
+		Neurons: []model.Neuron{
+			{ID: "i1", Activation: "identity"},
+			{ID: "i2", Activation: "identity"},
+			{ID: "h", Activation: "tanh", Bias: 0.1},
+			{ID: "m", Activation: "sigmoid", Aggregator: "mult_product", Bias: 0.5},
+			{ID: "o", Activation: "sigmoid", Bias: -0.2},
+		},
+		Synapses: []model.Synapse{
+			{From: "i1", To: "h", Weight: 1.5, Enabled: true},
+			{From: "i2", To: "h", Weight: -0.7, Enabled: true},
+			{From: "h", To: "m", Weight: 2.0, Enabled: true},
+			{From: "h", To: "o", Weight: 1.2, Enabled: true},
+			{From: "m", To: "o", Weight: -0.4, Enabled: true},
+		},
+	}
+	inputs := []string{"i1", "i2"}
+	outputs := []string{"o"}
+	rows := [][]float64{{0, 0}, {0, 1}, {1, 0}, {1, 1}}
+	cache := NewBatchCache(0)
+
+	check := func(g model.Genome, wantHits, wantMisses int) {
+		t.Helper()
+		before := cache.Stats()
+		cached, err := ForwardBatchCached(g, inputs, rows, outputs, cache)
+		if err != nil {
+			t.Fatalf("cached forward: %v", err)
+		}
+		plain, err := ForwardBatch(g, inputs, rows, outputs)
+		if err != nil {
+			t.Fatalf("forward: %v", err)
+		}
+		for s := range plain {
+			if cached[s][0] != plain[s][0] {
+				t.Fatalf("row %d: cached=%f plain=%f", s, cached[s][0], plain[s][0])
+			}
+		}
+		after := cache.Stats()
+		if hits, misses := after.Hits-before.Hits, after.Misses-before.Misses; hits != wantHits || misses != wantMisses {
+			t.Fatalf("expected %d hits/%d misses, got %d/%d", wantHits, wantMisses, hits, misses)
+		}
+	}
+
+	check(genome, 0, 3)
+	check(genome, 3, 0)
+
+	perturbed := genome
+	perturbed.Synapses = append([]model.Synapse(nil), genome.Synapses...)
+	perturbed.Synapses[4].Weight = 0.3
+	check(perturbed, 2, 1)
+
+	shifted := genome
+	shifted.Synapses = append([]model.Synapse(nil), genome.Synapses...)
+	shifted.Synapses[0].Weight = -1.5
+	check(shifted, 0, 3)
+
+	if _, err := ForwardBatchCached(genome, inputs, [][]float64{{2, 2}}, outputs, cache); err != nil {
+		t.Fatalf("cached forward on new rows: %v", err)
+	}
+	if cache.Len() != 10 {
+		t.Fatalf("expected 10 cached columns, got %d", cache.Len())
+	}
+}
+
+func TestBatchCacheClearsAtEntryLimit(t *testing.T) {
+	cache := NewBatchCache(2)
+	for key := uint64(1); key <= 3; key++ {
+		cache.store(key, []float64{float64(key)})
+	}
+	if cache.Len() != 1 {
+		t.Fatalf("expected cache to clear at its limit, got %d entries", cache.Len())
+	}
+	if _, ok := cache.lookup(3, 1); !ok {
+		t.Fatal("expected most recent column to survive the clear")
+	}
+}
@@ -0,0 +1,70 @@
+package nn
+
+import "math"
+
+// ClampCounts tallies numeric guard interventions during evaluation.
+type ClampCounts struct {
+	NonFinite  int `json:"non_finite"`
+	Activation int `json:"activation"`
+	Weight     int `json:"weight"`
+}
+
+func (c ClampCounts) Total() int {
+	return c.NonFinite + c.Activation + c.Weight
+}
+
+func (c *ClampCounts) Add(other ClampCounts) {
+	c.NonFinite += other.NonFinite
+	c.Activation += other.Activation
+	c.Weight += other.Weight
+}
+
+// ForwardGuard replaces NaN/Inf neuron values with zero and, when
+// ActivationLimit is positive, clamps aggregated neuron input to
+// [-ActivationLimit, ActivationLimit] before activation. Interventions are
+// counted in Counts, which outlives the ForwardState it is attached to.
+type ForwardGuard struct {
+	ActivationLimit float64
+	Counts          ClampCounts
+}
+
+// WithGuard attaches guard to the state and returns it. A nil guard disables
+// guarding.
+func (s *ForwardState) WithGuard(guard *ForwardGuard) *ForwardState {
+	s.guard = guard
+	return s
+}
+
+func (g *ForwardGuard) guardInput(total float64) float64 {
+	if g == nil {
+		return total
+	}
+	if math.IsNaN(total) {
+		g.Counts.NonFinite++
+		return 0
+	}
+	if g.ActivationLimit <= 0 {
+		if math.IsInf(total, 0) {
+			g.Counts.NonFinite++
+			return 0
+		}
+		return total
+	}
+	if math.IsInf(total, 0) {
+		g.Counts.NonFinite++
+	} else if math.Abs(total) > g.ActivationLimit {
+		g.Counts.Activation++
+	}
+	return saturate(total, -g.ActivationLimit, g.ActivationLimit)
+}
+
+func (g *ForwardGuard) guardOutput(value float64) float64 {
+	if g == nil {
+		return value
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		g.Counts.NonFinite++
+		return 0
+	}
+	return value
+}
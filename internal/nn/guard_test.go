@@ -0,0 +1,43 @@
+package nn
+
+import (
+	"math"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func TestForwardGuardClampsAndCountsInterventions(t *testing.T) {
+	genome := model.Genome{
+		Neurons: []model.Neuron{
+			{ID: "i", Activation: "identity"},
+			{ID: "big", Activation: "identity"},
+			{ID: "nan", Activation: "identity", Bias: math.NaN()},
+		},
+		Synapses: []model.Synapse{
+			{From: "i", To: "big", Weight: 1e6, Enabled: true},
+		},
+	}
+	guard := &ForwardGuard{ActivationLimit: 5}
+	values, err := ForwardWithState(genome, map[string]float64{"i": 1}, NewForwardState().WithGuard(guard))
+	if err != nil {
+		t.Fatalf("forward: %v", err)
+	}
+	if values["nan"] != 0 {
+		t.Fatalf("expected NaN neuron to be zeroed, got %f", values["nan"])
+	}
+	if values["big"] != 1 {
+		t.Fatalf("expected clamped input to still saturate the output, got %f", values["big"])
+	}
+	if guard.Counts != (ClampCounts{NonFinite: 1, Activation: 1}) {
+		t.Fatalf("unexpected clamp counts: %+v", guard.Counts)
+	}
+
+	unguarded, err := Forward(genome, map[string]float64{"i": 1})
+	if err != nil {
+		t.Fatalf("forward without guard: %v", err)
+	}
+	if !math.IsNaN(unguarded["nan"]) {
+		t.Fatalf("expected unguarded forward to leave NaN untouched, got %f", unguarded["nan"])
+	}
+}
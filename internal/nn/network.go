@@ -12,6 +12,7 @@ const outputSaturationLimit = 1.0
 type ForwardState struct {
 	prevDiffInputs map[string][]float64
 	prevOutputs    map[string]float64
+	guard          *ForwardGuard
 }
 
 func NewForwardState() *ForwardState {
@@ -30,9 +31,13 @@ func ForwardWithState(genome model.Genome, inputByNeuron map[string]float64, sta
 	for neuronID, value := range inputByNeuron {
 		values[neuronID] = value
 	}
-	var prevOutputs map[string]float64
+	var (
+		prevOutputs map[string]float64
+		guard       *ForwardGuard
+	)
 	if state != nil {
 		prevOutputs = state.prevOutputs
+		guard = state.guard
 	}
 
 	incoming := make(map[string][]model.Synapse, len(genome.Neurons))
@@ -53,11 +58,11 @@ func ForwardWithState(genome model.Genome, inputByNeuron map[string]float64, sta
 			return nil, fmt.Errorf("neuron %s: %w", neuron.ID, err)
 		}
 
-		activated, err := applyActivation(neuron.Activation, total)
+		activated, err := applyActivation(neuron.Activation, guard.guardInput(total))
 		if err != nil {
 			return nil, fmt.Errorf("neuron %s: %w", neuron.ID, err)
 		}
-		values[neuron.ID] = saturate(activated, -outputSaturationLimit, outputSaturationLimit)
+		values[neuron.ID] = saturate(guard.guardOutput(activated), -outputSaturationLimit, outputSaturationLimit)
 	}
 
 	if state != nil {
@@ -2,11 +2,13 @@ package platform
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"sync"
 	"time"
 
+	"protogonos/internal/encoding"
 	"protogonos/internal/evo"
 	"protogonos/internal/genotype"
 	"protogonos/internal/model"
@@ -133,12 +135,16 @@ type EvolutionConfig struct {
 	EliteCount           int
 	Workers              int
 	Seed                 int64
+	SelectionSeed        *int64
+	MutationSeed         *int64
+	EnvSeed              *int64
 	InputNeuronIDs       []string
 	OutputNeuronIDs      []string
 	Mutation             evo.Operator
 	MutationPolicy       []evo.WeightedMutation
 	Selector             evo.Selector
 	Postprocessor        evo.FitnessPostprocessor
+	Encoding             encoding.Encoding
 	TopologicalMutations evo.TopologicalMutationPolicy
 	Tuner                tuning.Tuner
 	TuneAttempts         int
@@ -146,6 +152,59 @@ type EvolutionConfig struct {
 	ValidationProbe      bool
 	TestProbe            bool
 	Control              chan evo.MonitorCommand
+	TraceUpdateHook      func(evo.TraceUpdate)
+	TraceGenerationHook  func(evo.TraceGeneration)
+	ProgressHook         func(evo.GenerationDiagnostics)
+	SpeciesHook          func(evo.SpeciesGeneration)
+	DisableBatchEval     bool
+	DisableEvalCache     bool
+	MeterEvaluations     bool
+	VerifyInvariants     bool
+	EvaluationTrials     int
+	CITieBreak           bool
+	TrialAggregation     string
+	CVaRAlpha            float64
+	FitnessScaling       string
+	ScalingPressure      float64
+	SpeciesAllocation    string
+	AllocationFloor      int
+	AllocationCeiling    int
+	SpeciesSoftCap       int
+	SpeciesCapHysteresis float64
+	PopulationResize     string
+	MinPopulation        int
+	MaxPopulation        int
+	ResizeTargetSeconds  float64
+	LowFidelity          float64
+	FinalistFraction     float64
+	ActivationClamp      float64
+	WeightClamp          float64
+	CrossoverRate        float64
+	InterspeciesMating   float64
+	PruneDisabledAfter   int
+	PruneDisabledProb    float64
+	TrackInnovations     bool
+	EvaluationTimeout    time.Duration
+	KarmaStrikes         int
+	KarmaCooldown        int
+	StopCondition        string
+	EntropyThreshold     float64
+	EntropyMeasure       string
+	EntropyAction        string
+	EntropyCooldown      int
+	ReproductionInterval int
+	DiagnosticsWindow    int
+	Islands              int
+	MigrationInterval    int
+	Migrants             int
+	IslandMutations      func(seedOffset int64) (evo.Operator, []evo.WeightedMutation)
+	Rands                *evo.RandStreams
+	CheckpointInterval   int
+	CheckpointConfig     json.RawMessage
+	Resume               *evo.Checkpoint
+	SnapshotInterval     int
+	NewcomerFactory      func(generation, index int) (model.Genome, error)
+	CommonRandomNumbers  bool
 	Initial              []model.Genome
 }
 
@@ -157,6 +216,8 @@ type EvolutionResult struct {
 	BestFinalFitness      float64
 	TopFinal              []evo.ScoredGenome
 	Lineage               []evo.LineageRecord
+	Events                []model.EvolutionEvent
+	TuningTraces          []model.TuningTrace
 }
 
 type SupervisionFailure struct {
@@ -172,6 +233,7 @@ type Polis struct {
 	mu sync.RWMutex
 
 	scapes               map[string]scape.Scape
+	lifecycles           map[string]*scapeLifecycle
 	supportModules       map[string]SupportModule
 	publicScapes         map[string]PublicScapeSummary
 	publicScapeByType    map[string]string
@@ -179,6 +241,7 @@ type Polis struct {
 	started              bool
 	lastStopReason       StopReason
 	runs                 map[string]chan evo.MonitorCommand
+	runScapes            map[string]string
 
 	mailboxActive bool
 	mailboxCallCh chan polisCallEnvelope
@@ -200,11 +263,13 @@ func NewPolis(cfg Config) *Polis {
 	p := &Polis{
 		store:                cfg.Store,
 		scapes:               make(map[string]scape.Scape),
+		lifecycles:           make(map[string]*scapeLifecycle),
 		supportModules:       make(map[string]SupportModule),
 		publicScapes:         make(map[string]PublicScapeSummary),
 		publicScapeByType:    make(map[string]string),
 		publicScapeTypeOrder: make(map[string][]string),
 		runs:                 make(map[string]chan evo.MonitorCommand),
+		runScapes:            make(map[string]string),
 		config:               cfg,
 		lastStopReason:       StopReasonNormal,
 	}
@@ -410,11 +475,16 @@ func (p *Polis) RegisterScape(s scape.Scape) error {
 	}
 
 	p.mu.Lock()
-	defer p.mu.Unlock()
 	if !p.started {
+		p.mu.Unlock()
 		return fmt.Errorf("polis is not initialized")
 	}
+	replaced := p.replaceScapeLifecycleLocked(name, s)
 	p.scapes[name] = s
+	p.mu.Unlock()
+	if replaced != nil {
+		replaced.close()
+	}
 	return nil
 }
 
@@ -545,6 +615,9 @@ func (p *Polis) RemovePublicScape(ctx context.Context, name string, reason StopR
 	if err := stopPublicScapeWithReason(ctx, sc, reason); err != nil {
 		return fmt.Errorf("stop public scape %s: %w", name, err)
 	}
+	if replaced := p.replaceScapeLifecycleLocked(name, nil); replaced != nil {
+		replaced.close()
+	}
 	delete(p.scapes, name)
 	delete(p.publicScapes, name)
 	orderedNames := p.publicScapeTypeOrder[summary.Type]
@@ -712,6 +785,7 @@ func (p *Polis) stopRuntimeLocked(reason StopReason) {
 	for _, sc := range p.scapes {
 		_ = stopPublicScapeWithReason(context.Background(), sc, reason)
 	}
+	p.closeScapeLifecyclesLocked()
 	for _, module := range p.supportModules {
 		_ = stopSupportModuleWithReason(context.Background(), module, reason)
 	}
@@ -720,14 +794,125 @@ func (p *Polis) stopRuntimeLocked(reason StopReason) {
 }
 
 func (p *Polis) RunEvolution(ctx context.Context, cfg EvolutionConfig) (EvolutionResult, error) {
-	if len(cfg.Initial) != cfg.PopulationSize {
-		return EvolutionResult{}, fmt.Errorf("initial population mismatch: got=%d want=%d", len(cfg.Initial), cfg.PopulationSize)
+	cfg, runID, monitor, pool, err := p.newEvolutionMonitor(cfg)
+	if err != nil {
+		return EvolutionResult{}, err
+	}
+	defer p.unregisterRunControl(runID)
+	if pool != nil {
+		defer pool.Close()
+	}
+	if err := p.prepareRunScape(ctx, cfg.ScapeName, pool); err != nil {
+		return EvolutionResult{}, err
+	}
+
+	result, err := monitor.Run(ctx, cfg.Initial)
+	if err != nil {
+		return EvolutionResult{}, err
+	}
+	return p.persistEvolution(ctx, cfg, runID, result)
+}
+
+// EvolutionRun is a generational evolution its caller steps one generation
+// at a time. Finish persists it the way RunEvolution does; Close releases a
+// run that will not be finished.
+type EvolutionRun struct {
+	p      *Polis
+	cfg    EvolutionConfig
+	runID  string
+	run    *evo.GenerationalRun
+	pool   *scape.ScapePool
+	closed bool
+	// prepared is set once the first Step has prepared the run's scape.
+	prepared bool
+}
+
+// StartEvolution validates cfg and registers the run's control channel like
+// RunEvolution, but returns before the first generation is evaluated.
+func (p *Polis) StartEvolution(cfg EvolutionConfig) (*EvolutionRun, error) {
+	if cfg.EvolutionType != "" && cfg.EvolutionType != evo.EvolutionTypeGenerational {
+		return nil, fmt.Errorf("stepping requires generational evolution")
+	}
+	cfg, runID, monitor, pool, err := p.newEvolutionMonitor(cfg)
+	if err != nil {
+		return nil, err
+	}
+	run, err := monitor.StartGenerational(cfg.Initial)
+	if err != nil {
+		p.unregisterRunControl(runID)
+		return nil, err
+	}
+	return &EvolutionRun{p: p, cfg: cfg, runID: runID, run: run, pool: pool}, nil
+}
+
+// Step evaluates the next generation; see evo.GenerationalRun.Step.
+func (r *EvolutionRun) Step(ctx context.Context) error {
+	if r.closed {
+		return fmt.Errorf("evolution run %s is closed", r.runID)
+	}
+	if !r.prepared {
+		if err := r.p.prepareRunScape(ctx, r.cfg.ScapeName, r.pool); err != nil {
+			return err
+		}
+		r.prepared = true
+	}
+	return r.run.Step(ctx)
+}
+
+func (r *EvolutionRun) Done() bool {
+	return r.run.Done()
+}
+
+// Scored returns the most recently evaluated generation, best first.
+func (r *EvolutionRun) Scored() []evo.ScoredGenome {
+	return r.run.Scored()
+}
+
+func (r *EvolutionRun) Diagnostics() []evo.GenerationDiagnostics {
+	return r.run.Diagnostics()
+}
+
+func (r *EvolutionRun) TotalEvaluations() int {
+	return r.run.TotalEvaluations()
+}
+
+// Finish persists the generations run so far and closes the run. It does not
+// require the run to be done, so an embedder may stop early and still keep
+// its results.
+func (r *EvolutionRun) Finish(ctx context.Context) (EvolutionResult, error) {
+	if r.closed {
+		return EvolutionResult{}, fmt.Errorf("evolution run %s is closed", r.runID)
+	}
+	r.Close()
+	return r.p.persistEvolution(ctx, r.cfg, r.runID, r.run.Result())
+}
+
+// Close unregisters the run's control channel and closes its scape pool. It
+// is safe to call more than once and after Finish.
+func (r *EvolutionRun) Close() {
+	if r.closed {
+		return
+	}
+	r.closed = true
+	r.p.unregisterRunControl(r.runID)
+	if r.pool != nil {
+		_ = r.pool.Close()
+	}
+}
+
+// newEvolutionMonitor validates cfg, fills its defaults and builds the
+// monitor for it. The run's control channel is registered under the returned
+// run id; the caller unregisters it. For a stateful scape it also returns the
+// pool of instances, sized to the workers, that the caller starts and closes.
+func (p *Polis) newEvolutionMonitor(cfg EvolutionConfig) (EvolutionConfig, string, *evo.PopulationMonitor, *scape.ScapePool, error) {
+	if cfg.Resume == nil && len(cfg.Initial) != cfg.PopulationSize {
+		return cfg, "", nil, nil, fmt.Errorf("initial population mismatch: got=%d want=%d", len(cfg.Initial), cfg.PopulationSize)
 	}
 	if cfg.Mutation == nil {
-		return EvolutionResult{}, fmt.Errorf("mutation operator is required")
+		return cfg, "", nil, nil, fmt.Errorf("mutation operator is required")
 	}
 	if cfg.ScapeName == "" {
-		return EvolutionResult{}, fmt.Errorf("scape name is required")
+		return cfg, "", nil, nil, fmt.Errorf("scape name is required")
 	}
 	if cfg.EliteCount <= 0 {
 		cfg.EliteCount = 1
@@ -742,10 +927,10 @@ func (p *Polis) RunEvolution(ctx context.Context, cfg EvolutionConfig) (Evolutio
 	p.mu.RUnlock()
 
 	if !started {
-		return EvolutionResult{}, fmt.Errorf("polis is not initialized")
+		return cfg, "", nil, nil, fmt.Errorf("polis is not initialized")
 	}
 	if !ok {
-		return EvolutionResult{}, fmt.Errorf("scape not registered: %s", cfg.ScapeName)
+		return cfg, "", nil, nil, fmt.Errorf("scape not registered: %s", cfg.ScapeName)
 	}
 
 	runID := cfg.RunID
@@ -756,13 +941,31 @@ func (p *Polis) RunEvolution(ctx context.Context, cfg EvolutionConfig) (Evolutio
 	if control == nil {
 		control = make(chan evo.MonitorCommand, 16)
 	}
-	if err := p.registerRunControl(runID, control); err != nil {
-		return EvolutionResult{}, err
+	var pool *scape.ScapePool
+	if stateful, ok := targetScape.(scape.StatefulScape); ok {
+		var err error
+		if pool, err = scape.NewScapePool(stateful, cfg.Workers); err != nil {
+			return cfg, "", nil, nil, err
+		}
+	}
+	if err := p.registerRunControl(runID, cfg.ScapeName, control); err != nil {
+		return cfg, "", nil, nil, err
+	}
+
+	innovations, err := p.priorInnovations(context.Background(), cfg, runID)
+	if err != nil {
+		p.unregisterRunControl(runID)
+		return cfg, "", nil, nil, err
+	}
+	checkpointHook, err := p.checkpointHook(cfg, runID)
+	if err != nil {
+		p.unregisterRunControl(runID)
+		return cfg, "", nil, nil, err
 	}
-	defer p.unregisterRunControl(runID)
 
 	monitor, err := evo.NewPopulationMonitor(evo.MonitorConfig{
 		Scape:                targetScape,
+		ScapePool:            pool,
 		OpMode:               cfg.OpMode,
 		EvolutionType:        cfg.EvolutionType,
 		SpeciationMode:       cfg.SpeciationMode,
@@ -778,11 +981,15 @@ func (p *Polis) RunEvolution(ctx context.Context, cfg EvolutionConfig) (Evolutio
 		TraceStepSize:        cfg.TraceStepSize,
 		Workers:              cfg.Workers,
 		Seed:                 cfg.Seed,
+		SelectionSeed:        cfg.SelectionSeed,
+		MutationSeed:         cfg.MutationSeed,
+		EnvSeed:              cfg.EnvSeed,
 		InputNeuronIDs:       cfg.InputNeuronIDs,
 		OutputNeuronIDs:      cfg.OutputNeuronIDs,
 		MutationPolicy:       cfg.MutationPolicy,
 		Selector:             cfg.Selector,
 		Postprocessor:        cfg.Postprocessor,
+		Encoding:             cfg.Encoding,
 		TopologicalMutations: cfg.TopologicalMutations,
 		Tuner:                cfg.Tuner,
 		TuneAttempts:         cfg.TuneAttempts,
@@ -790,29 +997,99 @@ func (p *Polis) RunEvolution(ctx context.Context, cfg EvolutionConfig) (Evolutio
 		ValidationProbe:      cfg.ValidationProbe,
 		TestProbe:            cfg.TestProbe,
 		Control:              control,
+		TraceUpdateHook:      cfg.TraceUpdateHook,
+		TraceGenerationHook:  cfg.TraceGenerationHook,
+		ProgressHook:         cfg.ProgressHook,
+		SpeciesHook:          cfg.SpeciesHook,
+		DisableBatchEval:     cfg.DisableBatchEval,
+		DisableEvalCache:     cfg.DisableEvalCache,
+		MeterEvaluations:     cfg.MeterEvaluations,
+		VerifyInvariants:     cfg.VerifyInvariants,
+		EvaluationTrials:     cfg.EvaluationTrials,
+		CITieBreak:           cfg.CITieBreak,
+		TrialAggregation:     cfg.TrialAggregation,
+		CVaRAlpha:            cfg.CVaRAlpha,
+		FitnessScaling:       cfg.FitnessScaling,
+		ScalingPressure:      cfg.ScalingPressure,
+		SpeciesAllocation:    cfg.SpeciesAllocation,
+		AllocationFloor:      cfg.AllocationFloor,
+		AllocationCeiling:    cfg.AllocationCeiling,
+		SpeciesSoftCap:       cfg.SpeciesSoftCap,
+		SpeciesCapHysteresis: cfg.SpeciesCapHysteresis,
+		PopulationResize:     cfg.PopulationResize,
+		MinPopulation:        cfg.MinPopulation,
+		MaxPopulation:        cfg.MaxPopulation,
+		ResizeTargetSeconds:  cfg.ResizeTargetSeconds,
+		LowFidelity:          cfg.LowFidelity,
+		FinalistFraction:     cfg.FinalistFraction,
+		ActivationClamp:      cfg.ActivationClamp,
+		WeightClamp:          cfg.WeightClamp,
+		CrossoverRate:        cfg.CrossoverRate,
+		InterspeciesMating:   cfg.InterspeciesMating,
+		PruneDisabledAfter:   cfg.PruneDisabledAfter,
+		PruneDisabledProb:    cfg.PruneDisabledProb,
+		TrackInnovations:     cfg.TrackInnovations,
+		Innovations:          innovations,
+		EvaluationTimeout:    cfg.EvaluationTimeout,
+		KarmaStrikes:         cfg.KarmaStrikes,
+		KarmaCooldown:        cfg.KarmaCooldown,
+		StopCondition:        cfg.StopCondition,
+		EntropyThreshold:     cfg.EntropyThreshold,
+		EntropyMeasure:       cfg.EntropyMeasure,
+		EntropyAction:        cfg.EntropyAction,
+		EntropyCooldown:      cfg.EntropyCooldown,
+		ReproductionInterval: cfg.ReproductionInterval,
+		DiagnosticsWindow:    cfg.DiagnosticsWindow,
+		Islands:              cfg.Islands,
+		MigrationInterval:    cfg.MigrationInterval,
+		Migrants:             cfg.Migrants,
+		IslandMutations:      cfg.IslandMutations,
+		Rands:                cfg.Rands,
+		CheckpointInterval:   cfg.CheckpointInterval,
+		CheckpointHook:       checkpointHook,
+		Resume:               cfg.Resume,
+		SnapshotInterval:     cfg.SnapshotInterval,
+		SnapshotHook:         p.snapshotHook(cfg, runID),
+		NewcomerFactory:      cfg.NewcomerFactory,
+		CommonRandomNumbers:  cfg.CommonRandomNumbers,
 	})
 	if err != nil {
-		return EvolutionResult{}, err
+		p.unregisterRunControl(runID)
+		return cfg, "", nil, nil, err
 	}
+	return cfg, runID, monitor, pool, nil
+}
 
-	result, err := monitor.Run(ctx, cfg.Initial)
-	if err != nil {
-		return EvolutionResult{}, err
+// RecordRun stores a run optimized outside the polis, such as a baseline
+// optimizer's, the way RunEvolution stores the runs it evolves. cfg needs
+// the run id and scape name.
+func (p *Polis) RecordRun(ctx context.Context, cfg EvolutionConfig, result evo.RunResult) (EvolutionResult, error) {
+	if cfg.RunID == "" || cfg.ScapeName == "" {
+		return EvolutionResult{}, fmt.Errorf("recorded run requires a run id and scape name")
 	}
+	return p.persistEvolution(ctx, cfg, cfg.RunID, result)
+}
+
+// persistEvolution stores a finished run's population, histories and top
+// genomes and builds its EvolutionResult.
+func (p *Polis) persistEvolution(ctx context.Context, cfg EvolutionConfig, runID string, result evo.RunResult) (EvolutionResult, error) {
 	if cfg.InitialGeneration > 0 {
-		result, err = p.mergeExistingRunHistory(ctx, persistenceRunID(cfg, runID), result)
+		merged, err := p.mergeExistingRunHistory(ctx, persistenceRunID(cfg, runID), result)
 		if err != nil {
 			return EvolutionResult{}, err
 		}
+		result = merged
 	}
 	finalGenomes := make([]model.Genome, 0, len(result.FinalPopulation))
+	finalFitness := make(map[string]float64, len(result.FinalPopulation))
 	for _, scored := range result.FinalPopulation {
 		finalGenomes = append(finalGenomes, scored.Genome)
+		finalFitness[scored.Genome.ID] = scored.Fitness
 	}
 	executedGenerations := len(result.BestByGeneration) + cfg.InitialGeneration
 	persistenceRunID := persistenceRunID(cfg, runID)
 	populationID := persistenceRunID
-	if err := genotype.SavePopulationSnapshot(ctx, p.store, populationID, executedGenerations, finalGenomes); err != nil {
+	if err := genotype.SaveScoredPopulationSnapshot(ctx, p.store, populationID, executedGenerations, finalGenomes, finalFitness); err != nil {
 		return EvolutionResult{}, err
 	}
 	if err := p.store.SaveFitnessHistory(ctx, persistenceRunID, result.BestByGeneration); err != nil {
@@ -827,12 +1104,23 @@ func (p *Polis) RunEvolution(ctx context.Context, cfg EvolutionConfig) (Evolutio
 	if err := p.store.SaveLineage(ctx, persistenceRunID, toModelLineage(result.Lineage)); err != nil {
 		return EvolutionResult{}, err
 	}
+	if innovationStore, ok := p.store.(storage.InnovationStore); ok && len(result.Innovations) > 0 {
+		if err := innovationStore.SaveInnovations(ctx, persistenceRunID, result.Innovations); err != nil {
+			return EvolutionResult{}, err
+		}
+	}
+	if checkpointStore, ok := p.store.(storage.CheckpointStore); ok && (cfg.CheckpointInterval > 0 || cfg.Resume != nil) {
+		if err := checkpointStore.DeleteCheckpoint(ctx, persistenceRunID); err != nil {
+			return EvolutionResult{}, err
+		}
+	}
 
 	bestFinal := 0.0
 	topFinal := []evo.ScoredGenome{}
 	if len(result.FinalPopulation) > 0 {
 		ranked := append([]evo.ScoredGenome(nil), result.FinalPopulation...)
-		sort.Slice(ranked, func(i, j int) bool {
+		// Stable so equal-fitness genomes keep the monitor's tie-break order.
+		sort.SliceStable(ranked, func(i, j int) bool {
 			return ranked[i].Fitness > ranked[j].Fitness
 		})
 		bestFinal = ranked[0].Fitness
@@ -857,9 +1145,71 @@ func (p *Polis) RunEvolution(ctx context.Context, cfg EvolutionConfig) (Evolutio
 		BestFinalFitness:      bestFinal,
 		TopFinal:              topFinal,
 		Lineage:               result.Lineage,
+		Events:                result.Events,
+		TuningTraces:          result.TuningTraces,
+	}, nil
+}
+
+// checkpointHook saves the monitor's checkpoints of a run with a
+// CheckpointInterval to the store, each replacing the last, together with
+// the run configuration needed to resume it.
+func (p *Polis) checkpointHook(cfg EvolutionConfig, runID string) (func(context.Context, evo.Checkpoint) error, error) {
+	if cfg.CheckpointInterval <= 0 {
+		return nil, nil
+	}
+	checkpointStore, ok := p.store.(storage.CheckpointStore)
+	if !ok {
+		return nil, fmt.Errorf("store does not support checkpoints")
+	}
+	runID = persistenceRunID(cfg, runID)
+	return func(ctx context.Context, checkpoint evo.Checkpoint) error {
+		state, err := json.Marshal(checkpoint)
+		if err != nil {
+			return err
+		}
+		return checkpointStore.SaveCheckpoint(ctx, model.RunCheckpoint{
+			RunID:      runID,
+			Generation: cfg.InitialGeneration + checkpoint.Generation,
+			Config:     cfg.CheckpointConfig,
+			State:      state,
+			SavedAtUTC: time.Now().UTC().Format(time.RFC3339Nano),
+		})
 	}, nil
 }
 
+// snapshotHook saves the scored population of every SnapshotInterval-th
+// generation of a run under genotype.GenerationSnapshotID, next to the final
+// snapshot, so the run can be forked from that generation.
+func (p *Polis) snapshotHook(cfg EvolutionConfig, runID string) func(context.Context, int, []evo.ScoredGenome) error {
+	if cfg.SnapshotInterval <= 0 {
+		return nil
+	}
+	runID = persistenceRunID(cfg, runID)
+	return func(ctx context.Context, generation int, scored []evo.ScoredGenome) error {
+		genomes := make([]model.Genome, 0, len(scored))
+		fitness := make(map[string]float64, len(scored))
+		for _, item := range scored {
+			genomes = append(genomes, item.Genome)
+			fitness[item.Genome.ID] = item.Fitness
+		}
+		return genotype.SaveScoredPopulationSnapshot(ctx, p.store, genotype.GenerationSnapshotID(runID, generation), generation, genomes, fitness)
+	}
+}
+
+// priorInnovations loads the innovation registry a continued run persisted,
+// so its genes keep their markings and new ones continue the numbering.
+func (p *Polis) priorInnovations(ctx context.Context, cfg EvolutionConfig, runID string) ([]model.InnovationRecord, error) {
+	if !cfg.TrackInnovations || cfg.InitialGeneration == 0 {
+		return nil, nil
+	}
+	innovationStore, ok := p.store.(storage.InnovationStore)
+	if !ok {
+		return nil, nil
+	}
+	records, _, err := innovationStore.GetInnovations(ctx, persistenceRunID(cfg, runID))
+	return records, err
+}
+
 func persistenceRunID(cfg EvolutionConfig, fallback string) string {
 	if cfg.RunID != "" {
 		return cfg.RunID
@@ -919,6 +1269,7 @@ func (p *Polis) mergeExistingRunHistory(ctx context.Context, runID string, curre
 					Size:        metric.Size,
 					MeanFitness: metric.MeanFitness,
 					BestFitness: metric.BestFitness,
+					Strategies:  metric.Strategies,
 				})
 			}
 			prefix = append(prefix, evo.SpeciesGeneration{
@@ -941,6 +1292,7 @@ func (p *Polis) mergeExistingRunHistory(ctx context.Context, runID string, curre
 				ParentID:    rec.ParentID,
 				Generation:  rec.Generation,
 				Operation:   rec.Operation,
+				MateID:      rec.MateID,
 				Events:      toGenotypeEvoHistory(rec.Events),
 				Fingerprint: rec.Fingerprint,
 				Summary: evo.TopologySummary{
@@ -972,9 +1324,7 @@ func (p *Polis) mergeExistingRunHistory(ctx context.Context, runID string, curre
 			})
 		}
 		merged = append(merged, current.FinalPopulation...)
-		sort.Slice(merged, func(i, j int) bool {
-			return merged[i].Fitness > merged[j].Fitness
-		})
+		evo.SortScored(merged)
 		seen := make(map[string]struct{}, len(merged))
 		unique := make([]evo.ScoredGenome, 0, len(merged))
 		for _, item := range merged {
@@ -1004,6 +1354,7 @@ func toModelLineage(lineage []evo.LineageRecord) []model.LineageRecord {
 			ParentID:    rec.ParentID,
 			Generation:  rec.Generation,
 			Operation:   rec.Operation,
+			MateID:      rec.MateID,
 			Events:      toModelEvoHistory(rec.Events),
 			Fingerprint: rec.Fingerprint,
 			Summary: model.LineageSummary{
@@ -1074,6 +1425,51 @@ func toModelDiagnostics(diags []evo.GenerationDiagnostics) []model.GenerationDia
 			TuningGoalHits:        d.TuningGoalHits,
 			TuningAcceptRate:      d.TuningAcceptRate,
 			TuningEvalsPerAttempt: d.TuningEvalsPerAttempt,
+			WallClockSeconds:      d.WallClockSeconds,
+			TotalEvaluations:      d.TotalEvaluations,
+			EvaluationsPerSecond:  d.EvaluationsPerSecond,
+			ETASeconds:            d.ETASeconds,
+			ScapeParamChanges:     toModelScapeParamChanges(d.ScapeParamChanges),
+			FidelityFinalists:     d.FidelityFinalists,
+			FidelityOffset:        d.FidelityOffset,
+			PopulationWeights:     d.PopulationWeights,
+			ChampionLayerWeights:  d.ChampionLayerWeights,
+			ClampEvents:           d.ClampEvents,
+			EvalCacheHits:         d.EvalCacheHits,
+			EvalCacheMisses:       d.EvalCacheMisses,
+			CrossoverIntra:        d.CrossoverIntra,
+			CrossoverHybrid:       d.CrossoverHybrid,
+			CrossoverIntraMean:    d.CrossoverIntraMean,
+			CrossoverHybridMean:   d.CrossoverHybridMean,
+			DegenerateGenomes:     d.DegenerateGenomes,
+			BannedFingerprints:    d.BannedFingerprints,
+			GenotypeEntropy:       d.GenotypeEntropy,
+			BehaviorEntropy:       d.BehaviorEntropy,
+			EntropyAction:         d.EntropyAction,
+			SpeciesAllocation:     d.SpeciesAllocation,
+			SpeciesRebalancing:    d.SpeciesRebalancing,
+			PopulationSize:        d.PopulationSize,
+			PopulationResize:      d.PopulationResize,
+			Strategies:            d.Strategies,
+			EvaluationCost:        d.EvaluationCost,
+			ScapePoolWaitSeconds:  d.ScapePoolWaitSeconds,
+			IslandBest:            d.IslandBest,
+			Migrants:              d.Migrants,
+		})
+	}
+	return out
+}
+
+func toModelScapeParamChanges(changes []evo.ScapeParamChange) []model.ScapeParamChange {
+	if len(changes) == 0 {
+		return nil
+	}
+	out := make([]model.ScapeParamChange, 0, len(changes))
+	for _, change := range changes {
+		out = append(out, model.ScapeParamChange{
+			Name:     change.Name,
+			Previous: change.Previous,
+			Value:    change.Value,
 		})
 	}
 	return out
@@ -1082,10 +1478,12 @@ func toModelDiagnostics(diags []evo.GenerationDiagnostics) []model.GenerationDia
 func toModelTopGenomes(top []evo.ScoredGenome) []model.TopGenomeRecord {
 	out := make([]model.TopGenomeRecord, 0, len(top))
 	for i, item := range top {
+		foldFitness, _ := scape.TraceFoldFitnessOf(item.Trace)
 		out = append(out, model.TopGenomeRecord{
-			Rank:    i + 1,
-			Fitness: item.Fitness,
-			Genome:  item.Genome,
+			Rank:        i + 1,
+			Fitness:     item.Fitness,
+			FoldFitness: foldFitness,
+			Genome:      item.Genome,
 		})
 	}
 	return out
@@ -1101,6 +1499,7 @@ func toModelSpeciesHistory(history []evo.SpeciesGeneration) []model.SpeciesGener
 				Size:        item.Size,
 				MeanFitness: item.MeanFitness,
 				BestFitness: item.BestFitness,
+				Strategies:  item.Strategies,
 			})
 		}
 		out = append(out, model.SpeciesGeneration{
@@ -1154,7 +1553,30 @@ func (p *Polis) PrintTraceRun(runID string) error {
 	return p.sendRunCommand(runID, evo.CommandPrintTrace)
 }
 
-func (p *Polis) registerRunControl(runID string, control chan evo.MonitorCommand) error {
+// SetScapeParamRun changes a tunable parameter of the active run's scape. The
+// value is validated against the scape before the command is queued and takes
+// effect from the next generation.
+func (p *Polis) SetScapeParamRun(runID, name string, value float64) error {
+	if runID == "" {
+		return fmt.Errorf("run id is required")
+	}
+	p.mu.RLock()
+	scapeName, active := p.runScapes[runID]
+	target, registered := p.scapes[scapeName]
+	p.mu.RUnlock()
+	if !active {
+		return fmt.Errorf("run not active: %s", runID)
+	}
+	if !registered {
+		return fmt.Errorf("scape not registered: %s", scapeName)
+	}
+	if err := scape.ValidateScapeParam(target, name, value); err != nil {
+		return err
+	}
+	return p.sendRunCommand(runID, evo.SetScapeParamCommand(name, value))
+}
+
+func (p *Polis) registerRunControl(runID, scapeName string, control chan evo.MonitorCommand) error {
 	if runID == "" {
 		return fmt.Errorf("run id is required")
 	}
@@ -1167,6 +1589,7 @@ func (p *Polis) registerRunControl(runID string, control chan evo.MonitorCommand
 		return fmt.Errorf("run already active: %s", runID)
 	}
 	p.runs[runID] = control
+	p.runScapes[runID] = scapeName
 	return nil
 }
 
@@ -1176,6 +1599,7 @@ func (p *Polis) unregisterRunControl(runID string) {
 	}
 	p.mu.Lock()
 	delete(p.runs, runID)
+	delete(p.runScapes, runID)
 	p.mu.Unlock()
 }
 
@@ -1371,11 +1795,13 @@ func isValidStopReason(reason StopReason) bool {
 func (p *Polis) resetRuntimeStateLocked() {
 	p.started = false
 	p.scapes = make(map[string]scape.Scape)
+	p.lifecycles = make(map[string]*scapeLifecycle)
 	p.supportModules = make(map[string]SupportModule)
 	p.publicScapes = make(map[string]PublicScapeSummary)
 	p.publicScapeByType = make(map[string]string)
 	p.publicScapeTypeOrder = make(map[string][]string)
 	p.runs = make(map[string]chan evo.MonitorCommand)
+	p.runScapes = make(map[string]string)
 	if p.supervisor != nil {
 		p.supervisor.StopAll()
 	}
@@ -2,7 +2,9 @@ package platform
 
 import (
 	"context"
+	"errors"
 	"math/rand"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -569,6 +571,155 @@ func TestPolisRunEvolutionAppendsHistoryForSameRunIDContinuation(t *testing.T) {
 	}
 }
 
+type lifecycleLinearScape struct {
+	linearScape
+	initCalls   int
+	warmupCalls int
+	closeCalls  int
+	warm        bool
+}
+
+func (s *lifecycleLinearScape) Init(context.Context) error {
+	s.initCalls++
+	return nil
+}
+
+func (s *lifecycleLinearScape) Warmup(context.Context) error {
+	s.warmupCalls++
+	s.warm = true
+	return nil
+}
+
+func (s *lifecycleLinearScape) Close() error {
+	s.closeCalls++
+	return nil
+}
+
+func (s *lifecycleLinearScape) Evaluate(ctx context.Context, a scape.Agent) (scape.Fitness, scape.Trace, error) {
+	if !s.warm {
+		return 0, nil, errors.New("evaluated before warmup")
+	}
+	return s.linearScape.Evaluate(ctx, a)
+}
+
+func TestPolisPreparesLifecycleScapesOncePerInstance(t *testing.T) {
+	p := NewPolis(Config{Store: storage.NewMemoryStore()})
+	if err := p.Init(context.Background()); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	first := &lifecycleLinearScape{}
+	if err := p.RegisterScape(first); err != nil {
+		t.Fatalf("register scape: %v", err)
+	}
+	run := func(runID string) {
+		t.Helper()
+		initial := []model.Genome{linearGenome("g0", -1), linearGenome("g1", -0.5)}
+		if _, err := p.RunEvolution(context.Background(), EvolutionConfig{
+			RunID:           runID,
+			ScapeName:       "linear",
+			PopulationSize:  len(initial),
+			Generations:     2,
+			EliteCount:      1,
+			Seed:            3,
+			InputNeuronIDs:  []string{"i"},
+			OutputNeuronIDs: []string{"o"},
+			Mutation:        &evo.PerturbRandomWeight{Rand: rand.New(rand.NewSource(3)), MaxDelta: 0.4},
+			Initial:         initial,
+		}); err != nil {
+			t.Fatalf("run evolution %s: %v", runID, err)
+		}
+	}
+	run("lifecycle-1")
+	if err := p.RegisterScape(first); err != nil {
+		t.Fatalf("re-register scape: %v", err)
+	}
+	run("lifecycle-2")
+	if first.initCalls != 1 || first.warmupCalls != 1 || first.closeCalls != 0 {
+		t.Fatalf("expected one init and warmup across runs, got init=%d warmup=%d close=%d", first.initCalls, first.warmupCalls, first.closeCalls)
+	}
+
+	second := &lifecycleLinearScape{}
+	if err := p.RegisterScape(second); err != nil {
+		t.Fatalf("replace scape: %v", err)
+	}
+	if first.closeCalls != 1 {
+		t.Fatalf("expected the replaced instance to be closed, got %d closes", first.closeCalls)
+	}
+	if err := p.PrepareScape(context.Background(), "linear"); err != nil {
+		t.Fatalf("prepare scape: %v", err)
+	}
+	p.Stop()
+	if second.initCalls != 1 || second.closeCalls != 1 {
+		t.Fatalf("expected stop to close the prepared instance, got init=%d close=%d", second.initCalls, second.closeCalls)
+	}
+}
+
+type statefulLinearScape struct {
+	linearScape
+	instances *atomic.Int32
+	busy      atomic.Bool
+}
+
+func (s *statefulLinearScape) NewInstance() (scape.Scape, error) {
+	s.instances.Add(1)
+	return &statefulLinearScape{instances: s.instances}, nil
+}
+
+func (s *statefulLinearScape) Evaluate(ctx context.Context, a scape.Agent) (scape.Fitness, scape.Trace, error) {
+	if s.instances == nil {
+		return 0, nil, errors.New("evaluated the registered template")
+	}
+	if !s.busy.CompareAndSwap(false, true) {
+		return 0, nil, errors.New("instance evaluated concurrently")
+	}
+	defer s.busy.Store(false)
+	return s.linearScape.Evaluate(ctx, a)
+}
+
+func TestPolisRunEvolutionPoolsStatefulScapeInstances(t *testing.T) {
+	p := NewPolis(Config{Store: storage.NewMemoryStore()})
+	if err := p.Init(context.Background()); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	template := &statefulLinearScape{}
+	if err := p.RegisterScape(template); err != nil {
+		t.Fatalf("register scape: %v", err)
+	}
+	template.instances = &atomic.Int32{}
+
+	initial := []model.Genome{
+		linearGenome("g0", -1),
+		linearGenome("g1", -0.6),
+		linearGenome("g2", -0.2),
+		linearGenome("g3", 0.2),
+		linearGenome("g4", 0.6),
+		linearGenome("g5", 1),
+	}
+	result, err := p.RunEvolution(context.Background(), EvolutionConfig{
+		ScapeName:       "linear",
+		PopulationSize:  len(initial),
+		Generations:     2,
+		EliteCount:      1,
+		Workers:         3,
+		Seed:            5,
+		InputNeuronIDs:  []string{"i"},
+		OutputNeuronIDs: []string{"o"},
+		Mutation:        &evo.PerturbRandomWeight{Rand: rand.New(rand.NewSource(5)), MaxDelta: 0.4},
+		Initial:         initial,
+	})
+	if err != nil {
+		t.Fatalf("run evolution: %v", err)
+	}
+	if got := template.instances.Load(); got != 3 {
+		t.Fatalf("expected one pooled instance per worker, got %d", got)
+	}
+	for _, diag := range result.GenerationDiagnostics {
+		if diag.ScapePoolWaitSeconds <= 0 {
+			t.Fatalf("generation %d: expected pool wait time in diagnostics", diag.Generation)
+		}
+	}
+}
+
 func linearGenome(id string, weight float64) model.Genome {
 	return model.Genome{
 		VersionedRecord: model.VersionedRecord{SchemaVersion: storage.CurrentSchemaVersion, CodecVersion: storage.CurrentCodecVersion},
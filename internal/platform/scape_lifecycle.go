@@ -0,0 +1,113 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"protogonos/internal/scape"
+	"protogonos/internal/scapeid"
+)
+
+// scapeLifecycle tracks the Init/Warmup state of one registered
+// LifecycleScape instance.
+type scapeLifecycle struct {
+	scape    scape.LifecycleScape
+	mu       sync.Mutex
+	prepared bool
+}
+
+func (l *scapeLifecycle) prepare(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.prepared {
+		return nil
+	}
+	if err := scape.InitScape(ctx, l.scape); err != nil {
+		return err
+	}
+	l.prepared = true
+	return nil
+}
+
+// close runs the scape's Close hook if it was prepared.
+func (l *scapeLifecycle) close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.prepared {
+		return
+	}
+	l.prepared = false
+	_ = l.scape.Close()
+}
+
+// PrepareScape runs the Init and Warmup hooks of the scape registered under
+// name, once per registered instance. Runs prepare their scape before the
+// first evaluation; callers evaluating a scape directly may prepare it
+// themselves. Scapes without lifecycle hooks need no preparation.
+func (p *Polis) PrepareScape(ctx context.Context, name string) error {
+	name = scapeid.Normalize(name)
+	p.mu.Lock()
+	s, ok := p.scapes[name]
+	if !ok {
+		p.mu.Unlock()
+		return fmt.Errorf("scape not registered: %s", name)
+	}
+	lifecycle, ok := p.lifecycles[name]
+	if !ok {
+		managed, hooks := s.(scape.LifecycleScape)
+		if !hooks {
+			p.mu.Unlock()
+			return nil
+		}
+		lifecycle = &scapeLifecycle{scape: managed}
+		p.lifecycles[name] = lifecycle
+	}
+	p.mu.Unlock()
+
+	if err := lifecycle.prepare(ctx); err != nil {
+		return fmt.Errorf("prepare scape %s: %w", name, err)
+	}
+	return nil
+}
+
+// prepareRunScape prepares the scape a run evaluates and starts the run's
+// pool of its instances, if it has one.
+func (p *Polis) prepareRunScape(ctx context.Context, name string, pool *scape.ScapePool) error {
+	if err := p.PrepareScape(ctx, name); err != nil {
+		return err
+	}
+	if pool != nil {
+		return pool.Start(ctx)
+	}
+	return nil
+}
+
+// replaceScapeLifecycleLocked forgets the lifecycle of the instance
+// registered under name unless next is that same instance, returning the
+// lifecycle to close once p.mu is released.
+func (p *Polis) replaceScapeLifecycleLocked(name string, next scape.Scape) *scapeLifecycle {
+	lifecycle, ok := p.lifecycles[name]
+	if !ok || (next != nil && sameScapeInstance(lifecycle.scape, next)) {
+		return nil
+	}
+	delete(p.lifecycles, name)
+	return lifecycle
+}
+
+func (p *Polis) closeScapeLifecyclesLocked() {
+	for _, lifecycle := range p.lifecycles {
+		lifecycle.close()
+	}
+	p.lifecycles = make(map[string]*scapeLifecycle)
+}
+
+// sameScapeInstance reports whether a and b are the same instance.
+// Registering the same instance again keeps its prepared state.
+func sameScapeInstance(a, b scape.Scape) bool {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.TypeOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
@@ -0,0 +1,101 @@
+package scape
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// assetCache holds prepared scape assets (parsed data tables, workflows,
+// lookup tables) shared by every agent and run in the process. Assets are
+// read-only once prepared.
+var assetCache = struct {
+	mu      sync.Mutex
+	entries map[string]*assetEntry
+	hits    int
+	misses  int
+}{entries: map[string]*assetEntry{}}
+
+type assetEntry struct {
+	ready chan struct{}
+	value any
+	err   error
+}
+
+// AssetCacheStats counts the prepared assets cached in the process and the
+// lookups that found or had to prepare them.
+type AssetCacheStats struct {
+	Entries int
+	Hits    int
+	Misses  int
+}
+
+// AssetStats reports the process-wide asset cache counters.
+func AssetStats() AssetCacheStats {
+	assetCache.mu.Lock()
+	defer assetCache.mu.Unlock()
+	return AssetCacheStats{Entries: len(assetCache.entries), Hits: assetCache.hits, Misses: assetCache.misses}
+}
+
+// ResetAssetCache drops every cached asset.
+func ResetAssetCache() {
+	assetCache.mu.Lock()
+	defer assetCache.mu.Unlock()
+	assetCache.entries = map[string]*assetEntry{}
+	assetCache.hits = 0
+	assetCache.misses = 0
+}
+
+// loadAsset returns the asset cached under key, preparing it with load on
+// first use. Concurrent callers share one load; a failed load is not cached.
+func loadAsset[T any](key string, load func() (T, error)) (T, error) {
+	assetCache.mu.Lock()
+	entry, ok := assetCache.entries[key]
+	if ok {
+		assetCache.hits++
+		assetCache.mu.Unlock()
+		<-entry.ready
+	} else {
+		assetCache.misses++
+		entry = &assetEntry{ready: make(chan struct{})}
+		assetCache.entries[key] = entry
+		assetCache.mu.Unlock()
+
+		entry.value, entry.err = load()
+		if entry.err != nil {
+			assetCache.mu.Lock()
+			if assetCache.entries[key] == entry {
+				delete(assetCache.entries, key)
+			}
+			assetCache.mu.Unlock()
+		}
+		close(entry.ready)
+	}
+	if entry.err != nil {
+		var zero T
+		return zero, entry.err
+	}
+	return entry.value.(T), nil
+}
+
+// loadFileAsset caches the asset load parses from path. The key includes the
+// file's size and modification time, so an edited file is read again. kind
+// separates assets parsed differently from the same file.
+func loadFileAsset[T any](kind, path string, load func(string) (T, error)) (T, error) {
+	path = strings.TrimSpace(path)
+	info, err := os.Stat(path)
+	if err != nil {
+		// Let load report the missing file in its own words.
+		return load(path)
+	}
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		absolute = path
+	}
+	key := fmt.Sprintf("%s|%s|%d|%d", kind, absolute, info.Size(), info.ModTime().UnixNano())
+	return loadAsset(key, func() (T, error) {
+		return load(path)
+	})
+}
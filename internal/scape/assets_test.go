@@ -0,0 +1,122 @@
+package scape
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLoadAssetSharesOneLoadAndSkipsFailures(t *testing.T) {
+	ResetAssetCache()
+	t.Cleanup(ResetAssetCache)
+
+	var loads int
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := loadAsset("shared", func() (int, error) {
+				mu.Lock()
+				loads++
+				mu.Unlock()
+				time.Sleep(5 * time.Millisecond)
+				return 42, nil
+			})
+			if err != nil || value != 42 {
+				t.Errorf("load shared asset: value=%d err=%v", value, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if loads != 1 {
+		t.Fatalf("expected one load for concurrent callers, got %d", loads)
+	}
+	if stats := AssetStats(); stats.Entries != 1 || stats.Misses != 1 || stats.Hits != 7 {
+		t.Fatalf("unexpected cache stats: %+v", stats)
+	}
+
+	failing := errors.New("unavailable")
+	if _, err := loadAsset("flaky", func() (int, error) { return 0, failing }); !errors.Is(err, failing) {
+		t.Fatalf("expected the load error, got %v", err)
+	}
+	value, err := loadAsset("flaky", func() (int, error) { return 7, nil })
+	if err != nil || value != 7 {
+		t.Fatalf("expected a failed load to be retried, got value=%d err=%v", value, err)
+	}
+}
+
+func TestLoadFileAssetRereadsEditedFiles(t *testing.T) {
+	ResetAssetCache()
+	t.Cleanup(ResetAssetCache)
+
+	path := filepath.Join(t.TempDir(), "series.txt")
+	if err := os.WriteFile(path, []byte("one"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	reads := 0
+	read := func(path string) (string, error) {
+		reads++
+		data, err := os.ReadFile(path)
+		return string(data), err
+	}
+	for i := 0; i < 3; i++ {
+		if got, err := loadFileAsset("text", path, read); err != nil || got != "one" {
+			t.Fatalf("load file asset: got=%q err=%v", got, err)
+		}
+	}
+	if reads != 1 {
+		t.Fatalf("expected one read for an unchanged file, got %d", reads)
+	}
+
+	if err := os.WriteFile(path, []byte("three"), 0o644); err != nil {
+		t.Fatalf("rewrite file: %v", err)
+	}
+	if got, err := loadFileAsset("text", path, read); err != nil || got != "three" {
+		t.Fatalf("expected the edited file to be read again, got=%q err=%v", got, err)
+	}
+	if _, err := loadFileAsset("text", filepath.Join(t.TempDir(), "missing.txt"), read); err == nil {
+		t.Fatal("expected a missing file to fail")
+	}
+}
+
+func TestLLVMWarmupPreparesTablesWithoutChangingFitness(t *testing.T) {
+	ResetAssetCache()
+	t.Cleanup(ResetAssetCache)
+
+	s := LLVMPhaseOrderingScape{}
+	if err := InitScape(context.Background(), s); err != nil {
+		t.Fatalf("init scape: %v", err)
+	}
+	if stats := AssetStats(); stats.Entries != 3 {
+		t.Fatalf("expected tables for the default workflow's three programs, got %+v", stats)
+	}
+
+	policy := scriptedStepAgent{
+		id: "policy",
+		fn: func(in []float64) []float64 { return []float64{in[2] - in[7]} },
+	}
+	for _, mode := range []string{"gt", "validation", "test"} {
+		cached, _, err := s.EvaluateMode(context.Background(), policy, mode)
+		if err != nil {
+			t.Fatalf("evaluate %s: %v", mode, err)
+		}
+		cfg, err := llvmPhaseOrderingConfigForMode(mode, defaultLLVMWorkflow())
+		if err != nil {
+			t.Fatalf("config %s: %v", mode, err)
+		}
+		cfg.tables = nil
+		direct, _, err := evaluateLLVMPhaseOrderingWithStep(context.Background(), policy, cfg)
+		if err != nil {
+			t.Fatalf("evaluate %s without tables: %v", mode, err)
+		}
+		if cached != direct {
+			t.Fatalf("%s: cached tables changed fitness: %f vs %f", mode, cached, direct)
+		}
+	}
+}
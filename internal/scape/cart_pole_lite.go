@@ -3,7 +3,9 @@ package scape
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"math"
+	"math/rand"
 	"strings"
 
 	protoio "protogonos/internal/io"
@@ -20,11 +22,30 @@ func (CartPoleLiteScape) Evaluate(ctx context.Context, agent Agent) (Fitness, Tr
 	return CartPoleLiteScape{}.EvaluateMode(ctx, agent, "gt")
 }
 
+// TunableParams exposes the gt episode length and observation noise for
+// curriculum adjustments during a run.
+func (CartPoleLiteScape) TunableParams() []ScapeParam {
+	return []ScapeParam{
+		{Name: "steps_per_episode", Description: "gt episode length in steps", Default: 60, Min: 1, Max: 10000},
+		{Name: "observation_noise_std", Description: "std of gaussian noise added to observed position and velocity", Default: 0, Min: 0, Max: 1},
+	}
+}
+
+// SupportsFidelity reports that low-fidelity gt evaluations shorten each
+// episode; fitness is a per-step average so it stays on the same scale.
+func (CartPoleLiteScape) SupportsFidelity() bool {
+	return true
+}
+
 func (CartPoleLiteScape) EvaluateMode(ctx context.Context, agent Agent, mode string) (Fitness, Trace, error) {
 	cfg, err := cartPoleLiteConfigForMode(mode)
 	if err != nil {
 		return 0, nil, err
 	}
+	if cfg.mode == "gt" {
+		cfg = applyCartPoleLiteParams(ctx, cfg, agent.ID())
+		cfg.stepsPerEpisode = fidelitySteps(ctx, cfg.stepsPerEpisode)
+	}
 
 	if ticker, ok := agent.(TickAgent); ok {
 		fitness, trace, err := evaluateCartPoleLiteWithTick(ctx, ticker, cfg)
@@ -41,9 +62,22 @@ func (CartPoleLiteScape) EvaluateMode(ctx context.Context, agent Agent, mode str
 }
 
 type cartPoleLiteModeConfig struct {
-	mode            string
-	startPositions  []float64
-	stepsPerEpisode int
+	mode             string
+	startPositions   []float64
+	stepsPerEpisode  int
+	observationNoise float64
+	noiseKey         string
+}
+
+func applyCartPoleLiteParams(ctx context.Context, cfg cartPoleLiteModeConfig, agentID string) cartPoleLiteModeConfig {
+	if steps, ok := scapeParamFromContext(ctx, "steps_per_episode"); ok && steps >= 1 {
+		cfg.stepsPerEpisode = int(steps)
+	}
+	if noise, ok := scapeParamFromContext(ctx, "observation_noise_std"); ok && noise > 0 {
+		cfg.observationNoise = noise
+		cfg.noiseKey = trialAgentKey(ctx, agentID)
+	}
+	return cfg
 }
 
 func cartPoleLiteConfigForMode(mode string) (cartPoleLiteModeConfig, error) {
@@ -131,6 +165,13 @@ func evaluateCartPoleLite(
 ) (Fitness, Trace, error) {
 	totalReward := 0.0
 	stepsSurvived := 0
+	finalPositions := make(Behavior, 0, len(cfg.startPositions))
+	var noise *rand.Rand
+	if cfg.observationNoise > 0 {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(cfg.noiseKey))
+		noise = rand.New(rand.NewSource(int64(h.Sum64())))
+	}
 
 	for _, start := range cfg.startPositions {
 		x := start
@@ -141,7 +182,12 @@ func evaluateCartPoleLite(
 				return 0, nil, err
 			}
 
-			force, err := chooseForce(ctx, x, v)
+			observedX, observedV := x, v
+			if noise != nil {
+				observedX += noise.NormFloat64() * cfg.observationNoise
+				observedV += noise.NormFloat64() * cfg.observationNoise
+			}
+			force, err := chooseForce(ctx, observedX, observedV)
 			if err != nil {
 				return 0, nil, err
 			}
@@ -153,6 +199,7 @@ func evaluateCartPoleLite(
 				break
 			}
 		}
+		finalPositions = append(finalPositions, x)
 	}
 
 	if stepsSurvived == 0 {
@@ -165,13 +212,18 @@ func evaluateCartPoleLite(
 		}, nil
 	}
 	avgReward := totalReward / float64(stepsSurvived)
-	return Fitness(avgReward), Trace{
+	trace := Trace{
 		"avg_reward":        avgReward,
 		"steps_survived":    stepsSurvived,
 		"mode":              cfg.mode,
 		"episodes":          len(cfg.startPositions),
 		"steps_per_episode": cfg.stepsPerEpisode,
-	}, nil
+		TraceBehavior:       finalPositions,
+	}
+	if cfg.observationNoise > 0 {
+		trace["observation_noise_std"] = cfg.observationNoise
+	}
+	return Fitness(avgReward), trace, nil
 }
 
 func cartPoleLiteStep(x, v, force float64) (nextX, nextV, reward float64) {
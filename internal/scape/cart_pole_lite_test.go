@@ -2,6 +2,7 @@ package scape
 
 import (
 	"context"
+	"math"
 	"testing"
 
 	"protogonos/internal/agent"
@@ -42,6 +43,15 @@ func TestCartPoleLiteScapeEvaluateWithHandBuiltAgent(t *testing.T) {
 	if fitness <= 0.5 {
 		t.Fatalf("expected fitness > 0.5, got %f", fitness)
 	}
+	behavior, ok := TraceBehaviorOf(trace)
+	if !ok || len(behavior) != trace["episodes"].(int) {
+		t.Fatalf("expected one final cart position per episode as behavior, got %+v", trace)
+	}
+	for _, position := range behavior {
+		if math.Abs(position) > 2.0 {
+			t.Fatalf("expected a balancing controller to end episodes in bounds, got %v", behavior)
+		}
+	}
 }
 
 func TestCartPoleLiteScapeEvaluateWithIOComponents(t *testing.T) {
@@ -162,3 +172,37 @@ func TestCartPoleLiteScapeEvaluateModeAnnotatesMode(t *testing.T) {
 		t.Fatalf("expected test mode trace marker, got %+v", testTrace)
 	}
 }
+
+func TestCartPoleLiteScapeLowFidelityShortensGTEpisodes(t *testing.T) {
+	genome := model.Genome{
+		Neurons: []model.Neuron{
+			{ID: "x", Activation: "identity"},
+			{ID: "v", Activation: "identity"},
+			{ID: "f", Activation: "identity"},
+		},
+		Synapses: []model.Synapse{
+			{From: "x", To: "f", Weight: -1.2, Enabled: true},
+			{From: "v", To: "f", Weight: -0.6, Enabled: true},
+		},
+	}
+	cortex, err := agent.NewCortex("cp-fidelity", genome, nil, nil, []string{"x", "v"}, []string{"f"}, nil)
+	if err != nil {
+		t.Fatalf("new cortex: %v", err)
+	}
+
+	ctx := WithFidelity(context.Background(), 0.25)
+	_, trace, err := CartPoleLiteScape{}.Evaluate(ctx, cortex)
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if trace["steps_per_episode"] != 15 {
+		t.Fatalf("expected a quarter of the 60 gt steps, got %+v", trace)
+	}
+	_, validationTrace, err := CartPoleLiteScape{}.EvaluateMode(ctx, cortex, "validation")
+	if err != nil {
+		t.Fatalf("evaluate validation: %v", err)
+	}
+	if validationTrace["steps_per_episode"] != 48 {
+		t.Fatalf("expected validation to stay at full fidelity, got %+v", validationTrace)
+	}
+}
@@ -0,0 +1,73 @@
+package scape
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+const (
+	// TraceFoldFitness is the trace key under which a cross-validated
+	// evaluation reports the fitness of each fold, in fold order.
+	TraceFoldFitness = "fold_fitness"
+
+	// MaxFolds bounds the folds construction parameter of dataset scapes.
+	MaxFolds = 64
+)
+
+// TraceFoldFitnessOf returns the per-fold fitness trace reports under
+// TraceFoldFitness; ok is false when the evaluation was not cross-validated.
+func TraceFoldFitnessOf(trace Trace) ([]float64, bool) {
+	folds, ok := trace[TraceFoldFitness].([]float64)
+	if !ok || len(folds) == 0 {
+		return nil, false
+	}
+	return folds, true
+}
+
+// foldBounds returns the [start, end) range of fold among folds contiguous
+// folds of n samples; fold sizes differ by at most one.
+func foldBounds(n, fold, folds int) (int, int) {
+	return fold * n / folds, (fold + 1) * n / folds
+}
+
+// crossValidate scores folds folds of samples samples with evaluate, which
+// is given each fold's sample range, and returns the mean fold fitness. The
+// trace is the first fold's, with folds, fold_fitness and fold_std set to
+// the cross-validation results and fold_traces holding every fold's trace.
+func crossValidate(ctx context.Context, samples, folds int, evaluate func(ctx context.Context, start, end int) (Fitness, Trace, error)) (Fitness, Trace, error) {
+	if folds > samples {
+		return 0, nil, fmt.Errorf("%d folds need at least as many samples, got %d", folds, samples)
+	}
+	foldFitness := make([]float64, 0, folds)
+	foldTraces := make([]Trace, 0, folds)
+	mean := 0.0
+	for fold := 0; fold < folds; fold++ {
+		if err := ctx.Err(); err != nil {
+			return 0, nil, err
+		}
+		start, end := foldBounds(samples, fold, folds)
+		fitness, trace, err := evaluate(ctx, start, end)
+		if err != nil {
+			return 0, nil, fmt.Errorf("fold %d: %w", fold, err)
+		}
+		foldFitness = append(foldFitness, float64(fitness))
+		foldTraces = append(foldTraces, trace)
+		mean += float64(fitness)
+	}
+	mean /= float64(folds)
+	variance := 0.0
+	for _, fitness := range foldFitness {
+		variance += (fitness - mean) * (fitness - mean)
+	}
+
+	trace := make(Trace, len(foldTraces[0])+4)
+	for key, value := range foldTraces[0] {
+		trace[key] = value
+	}
+	trace["folds"] = folds
+	trace[TraceFoldFitness] = foldFitness
+	trace["fold_std"] = math.Sqrt(variance / float64(folds))
+	trace["fold_traces"] = foldTraces
+	return Fitness(mean), trace, nil
+}
@@ -39,13 +39,17 @@ type LLVMDataSource struct {
 }
 
 // WithDataSources returns a context carrying optional per-run dataset overrides.
+// Parsed sources are cached for the process, so runs over the same files
+// share them.
 func WithDataSources(ctx context.Context, sources DataSources) (context.Context, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
 	if strings.TrimSpace(sources.GTSA.CSVPath) != "" {
-		table, err := loadGTSATableCSV(sources.GTSA.CSVPath, sources.GTSA.Bounds)
+		table, err := loadFileAsset(fmt.Sprintf("gtsa%+v", sources.GTSA.Bounds), sources.GTSA.CSVPath, func(path string) (gtsaTable, error) {
+			return loadGTSATableCSV(path, sources.GTSA.Bounds)
+		})
 		if err != nil {
 			return nil, fmt.Errorf("configure gtsa data source: %w", err)
 		}
@@ -53,7 +57,7 @@ func WithDataSources(ctx context.Context, sources DataSources) (context.Context,
 	}
 
 	if strings.TrimSpace(sources.FX.CSVPath) != "" {
-		series, err := loadFXSeriesCSV(sources.FX.CSVPath)
+		series, err := loadFileAsset("fx", sources.FX.CSVPath, loadFXSeriesCSV)
 		if err != nil {
 			return nil, fmt.Errorf("configure fx data source: %w", err)
 		}
@@ -64,20 +68,24 @@ func WithDataSources(ctx context.Context, sources DataSources) (context.Context,
 	epitopesTableName := strings.TrimSpace(sources.Epitopes.TableName)
 	switch {
 	case epitopesCSVPath != "":
-		source, err := loadEpitopesSourceCSVWithName(epitopesCSVPath, sources.Epitopes.Bounds, epitopesTableName)
+		source, err := loadFileAsset(fmt.Sprintf("epitopes%+v|%s", sources.Epitopes.Bounds, epitopesTableName), epitopesCSVPath, func(path string) (epitopesSource, error) {
+			return loadEpitopesSourceCSVWithName(path, sources.Epitopes.Bounds, epitopesTableName)
+		})
 		if err != nil {
 			return nil, fmt.Errorf("configure epitopes data source: %w", err)
 		}
 		ctx = context.WithValue(ctx, epitopesDataSourceContextKey{}, source)
 	case epitopesTableName != "" || hasAnyEpitopesBounds(sources.Epitopes.Bounds):
-		source, err := loadDefaultEpitopesSource(epitopesTableName, sources.Epitopes.Bounds)
+		source, err := loadAsset(fmt.Sprintf("epitopes-default%+v|%s", sources.Epitopes.Bounds, epitopesTableName), func() (epitopesSource, error) {
+			return loadDefaultEpitopesSource(epitopesTableName, sources.Epitopes.Bounds)
+		})
 		if err != nil {
 			return nil, fmt.Errorf("configure epitopes data source: %w", err)
 		}
 		ctx = context.WithValue(ctx, epitopesDataSourceContextKey{}, source)
 	}
 	if strings.TrimSpace(sources.LLVM.WorkflowJSONPath) != "" {
-		workflow, err := loadLLVMWorkflowJSON(sources.LLVM.WorkflowJSONPath)
+		workflow, err := loadFileAsset("llvm", sources.LLVM.WorkflowJSONPath, loadLLVMWorkflowJSON)
 		if err != nil {
 			return nil, fmt.Errorf("configure llvm workflow source: %w", err)
 		}
@@ -4,27 +4,91 @@ import (
 	"context"
 	"fmt"
 	"hash/fnv"
+	"sort"
 	"strings"
 
 	protoio "protogonos/internal/io"
 )
 
 // DTMScape mirrors the delayed T-maze benchmark behavior from scape.erl.
-type DTMScape struct{}
+// DTMScape is the discrete T-maze. Its construction parameters set the maze
+// map and the gt schedule: right_reward and left_reward are the rewards at
+// the ends of the arms before the switch swaps them, runs the number of gt
+// runs, and switch_floor the earliest gt run the rewards may swap at.
+type DTMScape struct {
+	rewards     *dtmRewards
+	gtRuns      int
+	switchFloor *int
+}
+
+type dtmRewards struct {
+	right float64
+	left  float64
+}
+
+const (
+	dtmDefaultRightReward = 1.0
+	dtmDefaultLeftReward  = 0.2
+	dtmDefaultGTRuns      = 100
+	dtmDefaultSwitchFloor = 35
+)
 
 func (DTMScape) Name() string {
 	return "dtm"
 }
 
-func (DTMScape) Evaluate(ctx context.Context, agent Agent) (Fitness, Trace, error) {
-	return DTMScape{}.EvaluateMode(ctx, agent, "gt")
+// RecordsTrajectory reports that evaluations emit maze positions and run
+// outcomes to a TrajectoryRecorder carried by the context.
+func (DTMScape) RecordsTrajectory() bool {
+	return true
+}
+
+// Configure implements Configurable.
+func (DTMScape) Configure(params map[string]string) (Scape, error) {
+	if err := checkFactoryParams(params, "right_reward", "left_reward", "runs", "switch_floor"); err != nil {
+		return nil, err
+	}
+	right, err := floatFactoryParam(params, "right_reward", dtmDefaultRightReward, 0, 10)
+	if err != nil {
+		return nil, err
+	}
+	left, err := floatFactoryParam(params, "left_reward", dtmDefaultLeftReward, 0, 10)
+	if err != nil {
+		return nil, err
+	}
+	runs, err := intFactoryParam(params, "runs", dtmDefaultGTRuns, 2, 1000)
+	if err != nil {
+		return nil, err
+	}
+	switchFloor, err := intFactoryParam(params, "switch_floor", min(dtmDefaultSwitchFloor, runs-1), 0, runs-1)
+	if err != nil {
+		return nil, err
+	}
+	return DTMScape{
+		rewards:     &dtmRewards{right: right, left: left},
+		gtRuns:      runs,
+		switchFloor: &switchFloor,
+	}, nil
+}
+
+func (s DTMScape) Evaluate(ctx context.Context, agent Agent) (Fitness, Trace, error) {
+	return s.EvaluateMode(ctx, agent, "gt")
 }
 
-func (DTMScape) EvaluateMode(ctx context.Context, agent Agent, mode string) (Fitness, Trace, error) {
+func (s DTMScape) EvaluateMode(ctx context.Context, agent Agent, mode string) (Fitness, Trace, error) {
 	cfg, err := dtmConfigForMode(mode)
 	if err != nil {
 		return 0, nil, err
 	}
+	cfg.rewards = s.rewards
+	if cfg.mode == "gt" {
+		if s.gtRuns > 0 {
+			cfg.totalRuns = s.gtRuns
+		}
+		if s.switchFloor != nil {
+			cfg.switchFloor = *s.switchFloor
+		}
+	}
 
 	if ticker, ok := agent.(TickAgent); ok {
 		fitness, trace, err := evaluateDTMWithTick(ctx, ticker, cfg)
@@ -76,6 +140,8 @@ type dtmModeConfig struct {
 	maxStepsPerRun int
 	switchFloor    int
 	switchSpread   int
+	// rewards overrides the reference arm rewards when set.
+	rewards *dtmRewards
 }
 
 func dtmConfigForMode(mode string) (dtmModeConfig, error) {
@@ -83,9 +149,9 @@ func dtmConfigForMode(mode string) (dtmModeConfig, error) {
 	case "", "gt":
 		return dtmModeConfig{
 			mode:           "gt",
-			totalRuns:      100,
+			totalRuns:      dtmDefaultGTRuns,
 			maxStepsPerRun: 0,
-			switchFloor:    35,
+			switchFloor:    dtmDefaultSwitchFloor,
 			switchSpread:   30,
 		}, nil
 	case "validation":
@@ -205,7 +271,12 @@ func evaluateDTM(
 	controlSurface string,
 	chooseMove func(context.Context, dtmSenseInput) (float64, error),
 ) (Fitness, Trace, error) {
-	episode := newDTMEpisode(agentID, cfg)
+	episode := newDTMEpisode(trialAgentKey(ctx, agentID), cfg)
+	recorder := trajectoryRecorderFromContext(ctx)
+	if recorder != nil {
+		recorder.begin("dtm", agentID, cfg.mode, -1, 0, 1, 1, episode.trajectorySectors())
+		recorder.record(episode.trajectoryFrame(episode.position, ""))
+	}
 	terminalRuns := 0
 	crashRuns := 0
 	timeoutRuns := 0
@@ -280,6 +351,22 @@ func evaluateDTM(
 				return 0, nil, err
 			}
 
+			if recorder != nil {
+				position, event := episode.position, ""
+				switch {
+				case reachedTerminal:
+					position, event = terminalPosition, fmt.Sprintf("terminal reward=%g", reward)
+				case crashed:
+					event = "crash"
+				}
+				frame := episode.trajectoryFrame(position, event)
+				if done {
+					frame.Episode = episode.runIndex - 1
+					frame.Step = runSteps + 1
+				}
+				recorder.record(frame)
+			}
+
 			steps++
 			runSteps++
 			if runSteps > maxRunStepIndex {
@@ -375,7 +462,7 @@ func newDTMEpisode(agentID string, cfg dtmModeConfig) dtmEpisode {
 		totalRuns = 1
 	}
 	return dtmEpisode{
-		sectors:     buildTMazeSectors(),
+		sectors:     buildTMazeSectors(cfg.rewards),
 		position:    dtmCoord{x: 0, y: 0},
 		direction:   90,
 		totalRuns:   totalRuns,
@@ -408,7 +495,10 @@ func deterministicDTMSwitch(agentID string, totalRuns, floor, spread int) int {
 	return switchEvent
 }
 
-func buildTMazeSectors() map[dtmCoord]dtmSector {
+func buildTMazeSectors(rewards *dtmRewards) map[dtmCoord]dtmSector {
+	if rewards == nil {
+		rewards = &dtmRewards{right: dtmDefaultRightReward, left: dtmDefaultLeftReward}
+	}
 	return map[dtmCoord]dtmSector{
 		{x: 0, y: 0}: {
 			reward: 0,
@@ -429,7 +519,7 @@ func buildTMazeSectors() map[dtmCoord]dtmSector {
 			},
 		},
 		{x: 1, y: 1}: {
-			reward: 1,
+			reward: rewards.right,
 			views: map[int]dtmView{
 				0:   {hasNext: false, rangeLeft: 0, rangeFwd: 0, rangeRight: 0},
 				90:  {hasNext: false, rangeLeft: 2, rangeFwd: 0, rangeRight: 0},
@@ -438,7 +528,7 @@ func buildTMazeSectors() map[dtmCoord]dtmSector {
 			},
 		},
 		{x: -1, y: 1}: {
-			reward: 0.2,
+			reward: rewards.left,
 			views: map[int]dtmView{
 				0:   {hasNext: true, next: dtmCoord{x: 0, y: 1}, rangeLeft: 0, rangeFwd: 2, rangeRight: 0},
 				90:  {hasNext: false, rangeLeft: 0, rangeFwd: 0, rangeRight: 2},
@@ -524,6 +614,42 @@ func (e *dtmEpisode) applyTransition(view dtmView) (done bool, crashed bool, rea
 	return false, false, false, 0, dtmCoord{}, nil
 }
 
+func (e *dtmEpisode) trajectorySectors() []TrajectoryObject {
+	coords := make([]dtmCoord, 0, len(e.sectors))
+	for coord := range e.sectors {
+		coords = append(coords, coord)
+	}
+	sort.Slice(coords, func(i, j int) bool {
+		if coords[i].y != coords[j].y {
+			return coords[i].y < coords[j].y
+		}
+		return coords[i].x < coords[j].x
+	})
+	sectors := make([]TrajectoryObject, 0, len(coords))
+	for _, coord := range coords {
+		kind := "sector"
+		if e.sectors[coord].reward > 0 {
+			kind = "reward"
+		}
+		sectors = append(sectors, TrajectoryObject{Kind: kind, X: float64(coord.x), Y: float64(coord.y)})
+	}
+	return sectors
+}
+
+// trajectoryFrame records the agent at position, which differs from
+// e.position only for the terminal step before the run is reset.
+func (e *dtmEpisode) trajectoryFrame(position dtmCoord, event string) TrajectoryFrame {
+	return TrajectoryFrame{
+		Episode: e.runIndex,
+		Step:    e.stepIndex,
+		X:       float64(position.x),
+		Y:       float64(position.y),
+		Heading: float64(e.direction),
+		Reward:  e.fitnessAcc,
+		Event:   event,
+	}
+}
+
 func (e *dtmEpisode) resetRun() {
 	e.position = dtmCoord{x: 0, y: 0}
 	e.direction = 90
@@ -499,3 +499,61 @@ func TestDTMScapeSingleRunDoesNotTriggerRewardSwitch(t *testing.T) {
 		t.Fatalf("expected single completed run to report last_run_index=0, got %+v", trace)
 	}
 }
+
+func TestDTMScapeConfigureMazeMap(t *testing.T) {
+	rightTurn := scriptedStepAgent{
+		id: "right-turn",
+		fn: func(in []float64) []float64 {
+			if len(in) >= 3 && in[0] > 0.5 && in[2] > 0.5 {
+				return []float64{1}
+			}
+			return []float64{0}
+		},
+	}
+	_, reference, err := DTMScape{}.Evaluate(context.Background(), rightTurn)
+	if err != nil {
+		t.Fatalf("evaluate reference maze: %v", err)
+	}
+
+	configured, err := ConfigureScape(DTMScape{}, map[string]string{"right_reward": "2", "left_reward": "0", "runs": "20", "switch_floor": "19"})
+	if err != nil {
+		t.Fatalf("configure dtm: %v", err)
+	}
+	_, trace, err := configured.Evaluate(context.Background(), rightTurn)
+	if err != nil {
+		t.Fatalf("evaluate configured maze: %v", err)
+	}
+	if runs, _ := trace["total_runs"].(int); runs != 20 {
+		t.Fatalf("expected 20 gt runs, got %+v", trace["total_runs"])
+	}
+	if switchAt, _ := trace["switch_event"].(int); switchAt != 19 {
+		t.Fatalf("expected the switch clamped to the last run, got %+v", trace["switch_event"])
+	}
+	// The always-right policy earns 2 on the 19 runs before the switch and
+	// nothing once the rewards swap onto the left arm.
+	if total, _ := trace["terminal_reward_total"].(float64); total != 38 || total == reference["terminal_reward_total"] {
+		t.Fatalf("expected a terminal reward total of 38, got %+v", trace["terminal_reward_total"])
+	}
+
+	_, validation, err := configured.(DTMScape).EvaluateMode(context.Background(), rightTurn, "validation")
+	if err != nil {
+		t.Fatalf("evaluate validation: %v", err)
+	}
+	if runs, _ := validation["total_runs"].(int); runs != 72 {
+		t.Fatalf("expected runs to leave validation unchanged, got %+v", validation["total_runs"])
+	}
+
+	for _, params := range []map[string]string{
+		{"map": "cross"},
+		{"runs": "1"},
+		{"runs": "10", "switch_floor": "10"},
+		{"left_reward": "-1"},
+	} {
+		if _, err := ConfigureScape(DTMScape{}, params); err == nil {
+			t.Fatalf("expected params %v to be rejected", params)
+		}
+	}
+	if _, err := ConfigureScape(XORScape{}, map[string]string{"n": "2"}); err == nil {
+		t.Fatal("expected a scape without Configure to reject params")
+	}
+}
@@ -16,7 +16,12 @@ import (
 )
 
 // EpitopesScape is a deterministic sequence classification proxy for epitopes:sim.
-type EpitopesScape struct{}
+// Folds, set with the folds construction parameter, cross-validates gt
+// evaluations: the gt window is split into that many contiguous folds and
+// fitness is the mean fold accuracy. Other modes score their window whole.
+type EpitopesScape struct {
+	Folds int
+}
 
 type epitopesWindows struct {
 	gtStart         int
@@ -327,17 +332,41 @@ func LoadEpitopesTableCSV(path string, bounds EpitopesTableBounds) error {
 	return nil
 }
 
-func (EpitopesScape) Evaluate(ctx context.Context, agent Agent) (Fitness, Trace, error) {
-	return EpitopesScape{}.EvaluateMode(ctx, agent, "gt")
+// Configure implements Configurable.
+func (EpitopesScape) Configure(params map[string]string) (Scape, error) {
+	if err := checkFactoryParams(params, "folds"); err != nil {
+		return nil, err
+	}
+	folds, err := intFactoryParam(params, "folds", 1, 1, MaxFolds)
+	if err != nil {
+		return nil, err
+	}
+	return EpitopesScape{Folds: folds}, nil
 }
 
-func (EpitopesScape) EvaluateMode(ctx context.Context, agent Agent, mode string) (Fitness, Trace, error) {
+func (s EpitopesScape) Evaluate(ctx context.Context, agent Agent) (Fitness, Trace, error) {
+	return s.EvaluateMode(ctx, agent, "gt")
+}
+
+func (s EpitopesScape) EvaluateMode(ctx context.Context, agent Agent, mode string) (Fitness, Trace, error) {
 	source := currentEpitopesSource(ctx)
 	cfg, err := epitopesConfigForMode(mode, source)
 	if err != nil {
 		return 0, nil, err
 	}
+	if cfg.mode != "gt" || s.Folds <= 1 {
+		return evaluateEpitopesAgent(ctx, agent, cfg)
+	}
+	return crossValidate(ctx, cfg.maxSamples, s.Folds, func(ctx context.Context, start, end int) (Fitness, Trace, error) {
+		fold := cfg
+		fold.startIndex = cfg.startIndex + start
+		fold.endIndex = cfg.startIndex + end - 1
+		fold.maxSamples = end - start
+		return evaluateEpitopesAgent(ctx, agent, fold)
+	})
+}
 
+func evaluateEpitopesAgent(ctx context.Context, agent Agent, cfg epitopesModeConfig) (Fitness, Trace, error) {
 	if ticker, ok := agent.(TickAgent); ok {
 		fitness, trace, err := evaluateEpitopesWithTick(ctx, ticker, cfg)
 		if err == nil {
@@ -49,6 +49,49 @@ func TestEpitopesScapeRewardsMemoryAwarePolicy(t *testing.T) {
 	}
 }
 
+func TestEpitopesScapeCrossValidatesGTWindow(t *testing.T) {
+	signalOnly := scriptedStepAgent{
+		id: "signal-only",
+		fn: func(in []float64) []float64 {
+			if len(in) == 0 {
+				return []float64{0}
+			}
+			return []float64{in[0]}
+		},
+	}
+	configured, err := EpitopesScape{}.Configure(map[string]string{"folds": "4"})
+	if err != nil {
+		t.Fatalf("configure folds: %v", err)
+	}
+	fitness, trace, err := configured.Evaluate(context.Background(), signalOnly)
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	folds, ok := TraceFoldFitnessOf(trace)
+	if !ok || len(folds) != 4 {
+		t.Fatalf("expected four fold results, got %+v", trace)
+	}
+	foldTraces, _ := trace["fold_traces"].([]Trace)
+	total := 0
+	mean := 0.0
+	for i, foldTrace := range foldTraces {
+		total += foldTrace["total"].(int)
+		mean += folds[i] / 4
+	}
+	if len(foldTraces) != 4 || total != 64 {
+		t.Fatalf("expected folds to cover the 64-row gt window, got %d traces over %d rows", len(foldTraces), total)
+	}
+	if math.Abs(float64(fitness)-mean) > 1e-12 {
+		t.Fatalf("expected fitness %f to be the mean fold accuracy %f", fitness, mean)
+	}
+	if _, trace, err := configured.(EpitopesScape).EvaluateMode(context.Background(), signalOnly, "validation"); err != nil || trace[TraceFoldFitness] != nil {
+		t.Fatalf("expected validation to score its window whole, got trace=%+v err=%v", trace, err)
+	}
+	if _, err := (EpitopesScape{}).Configure(map[string]string{"rows": "4"}); err == nil {
+		t.Fatal("expected unknown parameter to be rejected")
+	}
+}
+
 func TestEpitopesScapeEvaluateWithIOComponents(t *testing.T) {
 	genome := model.Genome{
 		SensorIDs: []string{
@@ -0,0 +1,162 @@
+package scape
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Factory builds a scape from construction parameters given as key=value
+// strings. Unlike TunableScape parameters, construction parameters may change
+// the scape's morphology (e.g. its input width), so they are fixed for a run.
+// A factory must accept empty params and reject keys it does not recognise.
+type Factory func(params map[string]string) (Scape, error)
+
+var factoryRegistry = struct {
+	mu     sync.RWMutex
+	byName map[string]Factory
+}{byName: map[string]Factory{}}
+
+// RegisterFactory makes a parameterized scape constructible by name.
+func RegisterFactory(name string, factory Factory) error {
+	if name == "" {
+		return fmt.Errorf("scape factory name is required")
+	}
+	if factory == nil {
+		return fmt.Errorf("scape factory %s is nil", name)
+	}
+	factoryRegistry.mu.Lock()
+	defer factoryRegistry.mu.Unlock()
+	if _, exists := factoryRegistry.byName[name]; exists {
+		return fmt.Errorf("scape factory already registered: %s", name)
+	}
+	factoryRegistry.byName[name] = factory
+	return nil
+}
+
+// LookupFactory returns the factory registered under name.
+func LookupFactory(name string) (Factory, bool) {
+	factoryRegistry.mu.RLock()
+	defer factoryRegistry.mu.RUnlock()
+	factory, ok := factoryRegistry.byName[name]
+	return factory, ok
+}
+
+// FactoryNames lists the registered parameterized scapes in sorted order.
+func FactoryNames() []string {
+	factoryRegistry.mu.RLock()
+	defer factoryRegistry.mu.RUnlock()
+	names := make([]string, 0, len(factoryRegistry.byName))
+	for name := range factoryRegistry.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewFromFactory builds the named scape from params.
+func NewFromFactory(name string, params map[string]string) (Scape, error) {
+	factory, ok := LookupFactory(name)
+	if !ok {
+		return nil, fmt.Errorf("scape %s does not accept construction parameters", name)
+	}
+	s, err := factory(params)
+	if err != nil {
+		return nil, fmt.Errorf("construct scape %s: %w", name, err)
+	}
+	return s, nil
+}
+
+// Configurable is implemented by scapes registered as plain instances that
+// still accept construction parameters. Configure returns a copy of the
+// scape set up by params, leaving the receiver as is; like a Factory it must
+// accept empty params and reject keys it does not recognise. Parameters that
+// change a scape's morphology belong in a Factory instead.
+type Configurable interface {
+	Scape
+	Configure(params map[string]string) (Scape, error)
+}
+
+// ConfigureScape builds a copy of s from params through its Configurable
+// implementation.
+func ConfigureScape(s Scape, params map[string]string) (Scape, error) {
+	configurable, ok := s.(Configurable)
+	if !ok {
+		return nil, fmt.Errorf("scape %s does not accept construction parameters", s.Name())
+	}
+	configured, err := configurable.Configure(params)
+	if err != nil {
+		return nil, fmt.Errorf("construct scape %s: %w", s.Name(), err)
+	}
+	return configured, nil
+}
+
+// checkFactoryParams rejects parameters outside known.
+func checkFactoryParams(params map[string]string, known ...string) error {
+	for key := range params {
+		found := false
+		for _, name := range known {
+			if key == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown parameter %q (want one of %s)", key, strings.Join(known, ", "))
+		}
+	}
+	return nil
+}
+
+// intFactoryParam parses params[key] as an integer in [minValue, maxValue],
+// returning fallback when the key is absent.
+func intFactoryParam(params map[string]string, key string, fallback, minValue, maxValue int) (int, error) {
+	raw, ok := params[key]
+	if !ok {
+		return fallback, nil
+	}
+	value, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil {
+		return 0, fmt.Errorf("parameter %s must be an integer: %q", key, raw)
+	}
+	if value < minValue || value > maxValue {
+		return 0, fmt.Errorf("parameter %s must be in [%d,%d], got %d", key, minValue, maxValue, value)
+	}
+	return value, nil
+}
+
+// floatFactoryParam parses params[key] as a finite float in
+// [minValue, maxValue], returning fallback when the key is absent.
+func floatFactoryParam(params map[string]string, key string, fallback, minValue, maxValue float64) (float64, error) {
+	raw, ok := params[key]
+	if !ok {
+		return fallback, nil
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("parameter %s must be a finite number: %q", key, raw)
+	}
+	if value < minValue || value > maxValue {
+		return 0, fmt.Errorf("parameter %s must be in [%g,%g], got %g", key, minValue, maxValue, value)
+	}
+	return value, nil
+}
+
+// choiceFactoryParam returns params[key] lowercased when it is one of
+// choices, or fallback when the key is absent.
+func choiceFactoryParam(params map[string]string, key, fallback string, choices ...string) (string, error) {
+	raw, ok := params[key]
+	if !ok {
+		return fallback, nil
+	}
+	value := strings.TrimSpace(strings.ToLower(raw))
+	for _, choice := range choices {
+		if value == choice {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("parameter %s must be one of %s, got %q", key, strings.Join(choices, ", "), raw)
+}
@@ -0,0 +1,47 @@
+package scape
+
+import (
+	"context"
+	"math"
+)
+
+// FidelityScape optionally supports cheaper low-fidelity gt evaluations.
+// Implementations read the requested fraction with FidelityFromContext and
+// shorten episodes or subsample data in proportion, keeping fitness on the
+// same per-step or per-sample scale where they can.
+type FidelityScape interface {
+	Scape
+	SupportsFidelity() bool
+}
+
+type fidelityContextKey struct{}
+
+// WithFidelity returns a context asking fidelity-aware scapes to evaluate at
+// the given fraction of their full episode length or dataset. Values outside
+// (0, 1) mean full fidelity.
+func WithFidelity(ctx context.Context, fraction float64) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, fidelityContextKey{}, fraction)
+}
+
+// FidelityFromContext returns the evaluation fidelity carried by ctx, or 1.
+func FidelityFromContext(ctx context.Context) float64 {
+	if ctx == nil {
+		return 1
+	}
+	fraction, ok := ctx.Value(fidelityContextKey{}).(float64)
+	if !ok || fraction <= 0 || fraction >= 1 {
+		return 1
+	}
+	return fraction
+}
+
+func fidelitySteps(ctx context.Context, steps int) int {
+	fidelity := FidelityFromContext(ctx)
+	if fidelity >= 1 || steps <= 1 {
+		return steps
+	}
+	return max(1, int(math.Ceil(float64(steps)*fidelity)))
+}
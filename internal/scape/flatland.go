@@ -392,6 +392,12 @@ func defaultFlatlandPublicPolicy(sense flatlandSenseInput) []float64 {
 	return []float64{clamp(drive, -1, 1)}
 }
 
+// RecordsTrajectory reports that evaluations emit ring positions and active
+// resources to a TrajectoryRecorder carried by the context.
+func (FlatlandScape) RecordsTrajectory() bool {
+	return true
+}
+
 func (FlatlandScape) Evaluate(ctx context.Context, agent Agent) (Fitness, Trace, error) {
 	return FlatlandScape{}.EvaluateMode(ctx, agent, "gt")
 }
@@ -684,7 +690,13 @@ func evaluateFlatland(
 	cfg flatlandModeConfig,
 	chooseMove func(context.Context, flatlandSenseInput) (flatlandControl, error),
 ) (Fitness, Trace, error) {
-	episode := newFlatlandEpisodeForAgent(cfg, agentID)
+	episode := newFlatlandEpisodeForAgent(cfg, trialAgentKey(ctx, agentID))
+	recorder := trajectoryRecorderFromContext(ctx)
+	recordEpisode := -1
+	if recorder != nil {
+		recordEpisode = recorder.begin("flatland", agentID, cfg.mode, -1, -1, 1, 1, episode.trajectoryWalls())
+		recorder.record(episode.trajectoryFrame(recordEpisode, ""))
+	}
 	movementSteps := 0
 	foodCollisions := 0
 	poisonCollisions := 0
@@ -749,6 +761,16 @@ func evaluateFlatland(
 		if hitPoison {
 			poisonCollisions++
 		}
+		if recorder != nil {
+			event := reason
+			switch {
+			case hitFood:
+				event = "food"
+			case hitPoison:
+				event = "poison"
+			}
+			recorder.record(episode.trajectoryFrame(recordEpisode, event))
+		}
 		if reason != "" {
 			terminalReason = reason
 			break
@@ -1994,6 +2016,58 @@ func (e *flatlandEpisode) isWall(position int) bool {
 	return blocked
 }
 
+// flatlandRingPoint maps a ring cell onto the unit circle for 2D playback.
+func flatlandRingPoint(position int) (float64, float64) {
+	angle := 2 * math.Pi * float64(wrapFlatlandPosition(position)) / flatlandWorldSize
+	return math.Cos(angle), math.Sin(angle)
+}
+
+func (e *flatlandEpisode) trajectoryWalls() []TrajectoryObject {
+	positions := make([]int, 0, len(e.walls))
+	for position := range e.walls {
+		positions = append(positions, position)
+	}
+	sort.Ints(positions)
+	walls := make([]TrajectoryObject, 0, len(positions))
+	for _, position := range positions {
+		x, y := flatlandRingPoint(position)
+		walls = append(walls, TrajectoryObject{Kind: "wall", X: x, Y: y})
+	}
+	return walls
+}
+
+func (e *flatlandEpisode) trajectoryFrame(episode int, event string) TrajectoryFrame {
+	x, y := flatlandRingPoint(e.position)
+	frame := TrajectoryFrame{
+		Episode: episode,
+		Step:    e.age,
+		X:       x,
+		Y:       y,
+		Heading: float64(e.heading),
+		Reward:  e.rewardAcc,
+		Energy:  e.energy,
+		Event:   event,
+	}
+	for _, group := range []struct {
+		kind      string
+		resources []flatlandResource
+	}{
+		{kind: "food", resources: e.food},
+		{kind: "poison", resources: e.poison},
+		{kind: "prey", resources: e.prey},
+		{kind: "predator", resources: e.predators},
+	} {
+		for _, resource := range group.resources {
+			if resource.cooldown != 0 {
+				continue
+			}
+			rx, ry := flatlandRingPoint(resource.position)
+			frame.Objects = append(frame.Objects, TrajectoryObject{Kind: group.kind, X: rx, Y: ry})
+		}
+	}
+	return frame
+}
+
 func wrapFlatlandPosition(position int) int {
 	wrapped := position % flatlandWorldSize
 	if wrapped < 0 {
@@ -0,0 +1,328 @@
+package scape
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+)
+
+const (
+	FunctionApproxScapeName = "function-approx"
+
+	FunctionSine       = "sine"
+	FunctionPolynomial = "polynomial"
+	FunctionStep       = "step"
+	FunctionSaddle     = "saddle"
+	FunctionGaussian   = "gaussian"
+
+	DefaultFunctionSamples = 21
+	MaxFunctionSamples     = 4096
+)
+
+// FunctionNames lists the target functions of the function-approx suite.
+func FunctionNames() []string {
+	return []string{FunctionSine, FunctionPolynomial, FunctionStep, FunctionSaddle, FunctionGaussian}
+}
+
+func init() {
+	if err := RegisterFactory(FunctionApproxScapeName, newFunctionApproxScape); err != nil {
+		panic(err)
+	}
+}
+
+// FunctionApproxScape generalizes RegressionMimicScape into a suite of
+// regression targets over [-1,1] (or [-1,1]^2 for the surfaces):
+//
+//   - sine:       y = sin(pi*x)
+//   - polynomial: y = x^4 + x^3 + x^2 + x (Koza-1 quartic)
+//   - step:       y = -0.5 for x < 0, 0.5 otherwise
+//   - saddle:     y = x1*x2
+//   - gaussian:   y = exp(-2*(x1^2 + x2^2))
+//
+// The gt mode samples a uniform grid and adds Gaussian noise with standard
+// deviation Noise to the targets; validation samples the cell centers between
+// the grid points and test/benchmark uniform random points, both noise free, so scores measure
+// generalization rather than memorized samples. Fitness is 1-MSE, as for
+// regression-mimic. With Folds above one gt evaluations are cross-validated:
+// the gt samples are split into that many contiguous folds and fitness is the
+// mean fold fitness. Fields are set with the construction parameters fn,
+// samples, noise, seed and folds.
+type FunctionApproxScape struct {
+	Function string
+	Samples  int
+	Noise    float64
+	Seed     int64
+	Folds    int
+}
+
+func newFunctionApproxScape(params map[string]string) (Scape, error) {
+	if err := checkFactoryParams(params, "fn", "samples", "noise", "seed", "folds"); err != nil {
+		return nil, err
+	}
+	function, err := choiceFactoryParam(params, "fn", FunctionSine, FunctionNames()...)
+	if err != nil {
+		return nil, err
+	}
+	samples, err := intFactoryParam(params, "samples", DefaultFunctionSamples, 2, MaxFunctionSamples)
+	if err != nil {
+		return nil, err
+	}
+	noise, err := floatFactoryParam(params, "noise", 0, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := intFactoryParam(params, "seed", 1, math.MinInt32, math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+	folds, err := intFactoryParam(params, "folds", 1, 1, MaxFolds)
+	if err != nil {
+		return nil, err
+	}
+	return FunctionApproxScape{Function: function, Samples: samples, Noise: noise, Seed: int64(seed), Folds: folds}, nil
+}
+
+// FunctionApproxInputs returns the input width of the target function
+// configured by params: two for surfaces, one otherwise.
+func FunctionApproxInputs(params map[string]string) int {
+	function, err := choiceFactoryParam(params, "fn", FunctionSine, FunctionNames()...)
+	if err != nil {
+		return 1
+	}
+	return functionInputs(function)
+}
+
+func functionInputs(function string) int {
+	switch function {
+	case FunctionSaddle, FunctionGaussian:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func (FunctionApproxScape) Name() string {
+	return FunctionApproxScapeName
+}
+
+func (s FunctionApproxScape) function() string {
+	if s.Function == "" {
+		return FunctionSine
+	}
+	return s.Function
+}
+
+func (s FunctionApproxScape) Evaluate(ctx context.Context, agent Agent) (Fitness, Trace, error) {
+	return s.EvaluateMode(ctx, agent, "gt")
+}
+
+func (s FunctionApproxScape) EvaluateMode(ctx context.Context, agent Agent, mode string) (Fitness, Trace, error) {
+	cfg, err := s.configForMode(mode)
+	if err != nil {
+		return 0, nil, err
+	}
+	runner, ok := agent.(StepAgent)
+	if !ok {
+		return 0, nil, fmt.Errorf("agent %s does not implement step runner", agent.ID())
+	}
+	return s.evaluate(ctx, cfg, func(ctx context.Context, in []float64) (float64, error) {
+		out, err := runner.RunStep(ctx, in)
+		if err != nil {
+			return 0, err
+		}
+		if len(out) != 1 {
+			return 0, fmt.Errorf("%s requires one output, got %d", FunctionApproxScapeName, len(out))
+		}
+		return out[0], nil
+	})
+}
+
+func (s FunctionApproxScape) EvaluateBatch(ctx context.Context, agent BatchAgent, mode string) (Fitness, Trace, error) {
+	cfg, err := s.configForMode(mode)
+	if err != nil {
+		return 0, nil, err
+	}
+	outputs, err := runBatchRows(ctx, agent, cfg.inputs)
+	if err != nil {
+		return 0, nil, err
+	}
+	next := 0
+	return s.evaluate(ctx, cfg, func(context.Context, []float64) (float64, error) {
+		out := outputs[next]
+		next++
+		if len(out) != 1 {
+			return 0, fmt.Errorf("%s requires one output, got %d", FunctionApproxScapeName, len(out))
+		}
+		return out[0], nil
+	})
+}
+
+// evaluate scores cfg with predict, cross-validating gt evaluations over
+// s.Folds folds. Either way predict sees every sample once, in order.
+func (s FunctionApproxScape) evaluate(
+	ctx context.Context,
+	cfg functionApproxModeConfig,
+	predict func(context.Context, []float64) (float64, error),
+) (Fitness, Trace, error) {
+	if cfg.mode != "gt" || s.Folds <= 1 {
+		return evaluateFunctionApprox(ctx, cfg, predict)
+	}
+	return crossValidate(ctx, len(cfg.inputs), s.Folds, func(ctx context.Context, start, end int) (Fitness, Trace, error) {
+		fold := cfg
+		fold.inputs = cfg.inputs[start:end]
+		fold.targets = cfg.targets[start:end]
+		return evaluateFunctionApprox(ctx, fold, predict)
+	})
+}
+
+type functionApproxModeConfig struct {
+	mode     string
+	function string
+	inputs   [][]float64
+	targets  []float64
+}
+
+func (s FunctionApproxScape) configForMode(mode string) (functionApproxModeConfig, error) {
+	function := s.function()
+	target, ok := functionTarget(function)
+	if !ok {
+		return functionApproxModeConfig{}, fmt.Errorf("unsupported %s function: %s", FunctionApproxScapeName, function)
+	}
+	samples := s.Samples
+	if samples <= 0 {
+		samples = DefaultFunctionSamples
+	}
+	dims := functionInputs(function)
+
+	var inputs [][]float64
+	noise := 0.0
+	mode = strings.TrimSpace(strings.ToLower(mode))
+	switch mode {
+	case "", "gt":
+		mode = "gt"
+		inputs = gridSamples(samples, dims, false)
+		noise = s.Noise
+	case "validation":
+		inputs = gridSamples(samples, dims, true)
+	case "test", "benchmark":
+		rng := rand.New(rand.NewSource(s.Seed ^ 0x5eed))
+		inputs = make([][]float64, samples)
+		for i := range inputs {
+			inputs[i] = make([]float64, dims)
+			for d := range inputs[i] {
+				inputs[i][d] = 2*rng.Float64() - 1
+			}
+		}
+	default:
+		return functionApproxModeConfig{}, fmt.Errorf("unsupported %s mode: %s", FunctionApproxScapeName, mode)
+	}
+
+	targets := make([]float64, len(inputs))
+	var rng *rand.Rand
+	if noise > 0 {
+		rng = rand.New(rand.NewSource(s.Seed))
+	}
+	for i, in := range inputs {
+		targets[i] = target(in)
+		if rng != nil {
+			targets[i] += noise * rng.NormFloat64()
+		}
+	}
+	return functionApproxModeConfig{mode: mode, function: function, inputs: inputs, targets: targets}, nil
+}
+
+func functionTarget(function string) (func([]float64) float64, bool) {
+	switch function {
+	case FunctionSine:
+		return func(in []float64) float64 { return math.Sin(math.Pi * in[0]) }, true
+	case FunctionPolynomial:
+		return func(in []float64) float64 {
+			x := in[0]
+			return x*x*x*x + x*x*x + x*x + x
+		}, true
+	case FunctionStep:
+		return func(in []float64) float64 {
+			if in[0] < 0 {
+				return -0.5
+			}
+			return 0.5
+		}, true
+	case FunctionSaddle:
+		return func(in []float64) float64 { return in[0] * in[1] }, true
+	case FunctionGaussian:
+		return func(in []float64) float64 { return math.Exp(-2 * (in[0]*in[0] + in[1]*in[1])) }, true
+	default:
+		return nil, false
+	}
+}
+
+// gridSamples spreads samples points over [-1,1]^dims on a uniform grid; a
+// two-dimensional grid uses round(sqrt(samples)) points per axis. With
+// centers set the points are the centers of as many equal cells instead, so
+// they fall between the points of the plain grid.
+func gridSamples(samples, dims int, centers bool) [][]float64 {
+	perAxis := samples
+	if dims == 2 {
+		perAxis = int(math.Round(math.Sqrt(float64(samples))))
+		if perAxis < 2 {
+			perAxis = 2
+		}
+	}
+	axis := make([]float64, perAxis)
+	for i := range axis {
+		if centers {
+			axis[i] = -1 + float64(2*i+1)/float64(perAxis)
+		} else {
+			axis[i] = -1 + 2*float64(i)/float64(perAxis-1)
+		}
+	}
+	if dims == 1 {
+		points := make([][]float64, perAxis)
+		for i, x := range axis {
+			points[i] = []float64{x}
+		}
+		return points
+	}
+	points := make([][]float64, 0, perAxis*perAxis)
+	for _, x1 := range axis {
+		for _, x2 := range axis {
+			points = append(points, []float64{x1, x2})
+		}
+	}
+	return points
+}
+
+func evaluateFunctionApprox(
+	ctx context.Context,
+	cfg functionApproxModeConfig,
+	predict func(context.Context, []float64) (float64, error),
+) (Fitness, Trace, error) {
+	predictions := make([]float64, 0, len(cfg.inputs))
+	var squaredErr float64
+	for i, in := range cfg.inputs {
+		if err := ctx.Err(); err != nil {
+			return 0, nil, err
+		}
+		predicted, err := predict(ctx, in)
+		if err != nil {
+			return 0, nil, err
+		}
+		predictions = append(predictions, predicted)
+		delta := predicted - cfg.targets[i]
+		squaredErr += delta * delta
+	}
+
+	mse := 0.0
+	if len(cfg.inputs) > 0 {
+		mse = squaredErr / float64(len(cfg.inputs))
+	}
+	return Fitness(1.0 - mse), Trace{
+		"mse":         mse,
+		"predictions": predictions,
+		"mode":        cfg.mode,
+		"function":    cfg.function,
+		"samples":     len(cfg.inputs),
+	}, nil
+}
@@ -0,0 +1,212 @@
+package scape
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"protogonos/internal/agent"
+	"protogonos/internal/model"
+)
+
+func TestFunctionApproxFactoryValidatesParams(t *testing.T) {
+	s, err := NewFromFactory(FunctionApproxScapeName, nil)
+	if err != nil {
+		t.Fatalf("default function-approx: %v", err)
+	}
+	if got := s.(FunctionApproxScape); got.Function != FunctionSine || got.Samples != DefaultFunctionSamples || got.Noise != 0 {
+		t.Fatalf("unexpected defaults: %+v", got)
+	}
+	s, err = NewFromFactory(FunctionApproxScapeName, map[string]string{"fn": "Saddle", "samples": "16", "noise": "0.1", "seed": "9"})
+	if err != nil {
+		t.Fatalf("saddle: %v", err)
+	}
+	if got := s.(FunctionApproxScape); got.Function != FunctionSaddle || got.Samples != 16 || got.Noise != 0.1 || got.Seed != 9 {
+		t.Fatalf("unexpected saddle scape: %+v", got)
+	}
+	for _, params := range []map[string]string{
+		{"fn": "cosine"},
+		{"samples": "1"},
+		{"noise": "-0.1"},
+		{"noise": "NaN"},
+		{"n": "3"},
+	} {
+		if _, err := NewFromFactory(FunctionApproxScapeName, params); err == nil {
+			t.Fatalf("expected params %v to be rejected", params)
+		}
+	}
+	if got := FunctionApproxInputs(map[string]string{"fn": "gaussian"}); got != 2 {
+		t.Fatalf("expected surface input width 2, got %d", got)
+	}
+	if got := FunctionApproxInputs(nil); got != 1 {
+		t.Fatalf("expected default input width 1, got %d", got)
+	}
+}
+
+func TestFunctionApproxModeSamples(t *testing.T) {
+	s := FunctionApproxScape{Function: FunctionStep, Samples: 5}
+	gt, err := s.configForMode("gt")
+	if err != nil {
+		t.Fatalf("gt: %v", err)
+	}
+	wantInputs := []float64{-1, -0.5, 0, 0.5, 1}
+	wantTargets := []float64{-0.5, -0.5, 0.5, 0.5, 0.5}
+	for i := range wantInputs {
+		if gt.inputs[i][0] != wantInputs[i] || gt.targets[i] != wantTargets[i] {
+			t.Fatalf("gt sample %d: got x=%v y=%f", i, gt.inputs[i], gt.targets[i])
+		}
+	}
+	validation, err := s.configForMode("validation")
+	if err != nil {
+		t.Fatalf("validation: %v", err)
+	}
+	if len(validation.inputs) != 5 || math.Abs(validation.inputs[0][0]+0.8) > 1e-12 {
+		t.Fatalf("expected cell-center validation samples, got %v", validation.inputs)
+	}
+	test, err := s.configForMode("test")
+	if err != nil {
+		t.Fatalf("test: %v", err)
+	}
+	benchmark, err := s.configForMode("benchmark")
+	if err != nil {
+		t.Fatalf("benchmark: %v", err)
+	}
+	for i := range test.inputs {
+		if x := test.inputs[i][0]; x < -1 || x > 1 || x != benchmark.inputs[i][0] {
+			t.Fatalf("expected matching in-range test/benchmark samples, got %v and %v", test.inputs, benchmark.inputs)
+		}
+	}
+
+	surface, err := FunctionApproxScape{Function: FunctionSaddle, Samples: 10}.configForMode("gt")
+	if err != nil {
+		t.Fatalf("saddle: %v", err)
+	}
+	if len(surface.inputs) != 9 || len(surface.inputs[0]) != 2 {
+		t.Fatalf("expected a 3x3 two-input grid, got %v", surface.inputs)
+	}
+	if _, err := s.configForMode("bogus"); err == nil {
+		t.Fatal("expected unsupported mode error")
+	}
+}
+
+func TestFunctionApproxNoiseOnlyPerturbsTrainingTargets(t *testing.T) {
+	clean := FunctionApproxScape{Function: FunctionSine, Samples: 11, Seed: 3}
+	noisy := FunctionApproxScape{Function: FunctionSine, Samples: 11, Noise: 0.2, Seed: 3}
+	cleanGT, _ := clean.configForMode("gt")
+	noisyGT, _ := noisy.configForMode("gt")
+	again, _ := noisy.configForMode("gt")
+	perturbed := false
+	for i := range cleanGT.targets {
+		if noisyGT.targets[i] != again.targets[i] {
+			t.Fatalf("expected deterministic noise, sample %d: %f vs %f", i, noisyGT.targets[i], again.targets[i])
+		}
+		if noisyGT.targets[i] != cleanGT.targets[i] {
+			perturbed = true
+		}
+	}
+	if !perturbed {
+		t.Fatal("expected noise to perturb gt targets")
+	}
+	cleanValidation, _ := clean.configForMode("validation")
+	noisyValidation, _ := noisy.configForMode("validation")
+	for i := range cleanValidation.targets {
+		if cleanValidation.targets[i] != noisyValidation.targets[i] {
+			t.Fatalf("expected noise-free validation targets, sample %d differs", i)
+		}
+	}
+}
+
+func TestFunctionApproxScapeEvaluateStepMatchesBatch(t *testing.T) {
+	// o = x1 * 1 + x2 * 0 is an imperfect fit of the saddle surface.
+	genome := model.Genome{
+		Neurons: []model.Neuron{
+			{ID: "i1", Activation: "identity"},
+			{ID: "i2", Activation: "identity"},
+			{ID: "o", Activation: "identity"},
+		},
+		Synapses: []model.Synapse{
+			{From: "i1", To: "o", Weight: 1, Enabled: true},
+			{From: "i2", To: "o", Weight: 0, Enabled: true},
+		},
+	}
+	cortex, err := agent.NewCortex("fnapprox-agent", genome, nil, nil, []string{"i1", "i2"}, []string{"o"}, nil)
+	if err != nil {
+		t.Fatalf("new cortex: %v", err)
+	}
+
+	s := FunctionApproxScape{Function: FunctionSaddle, Samples: 9}
+	stepFitness, trace, err := s.Evaluate(context.Background(), cortex)
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if trace["function"] != FunctionSaddle || trace["samples"] != 9 {
+		t.Fatalf("unexpected trace: %+v", trace)
+	}
+	if mse, _ := trace["mse"].(float64); mse <= 0 || float64(stepFitness) != 1-mse {
+		t.Fatalf("expected fitness 1-mse with nonzero mse, got fitness=%f trace=%+v", stepFitness, trace)
+	}
+	batchFitness, _, err := s.EvaluateBatch(context.Background(), cortex, "gt")
+	if err != nil {
+		t.Fatalf("evaluate batch: %v", err)
+	}
+	if diff := float64(stepFitness - batchFitness); diff < -1e-9 || diff > 1e-9 {
+		t.Fatalf("expected batch fitness %f to match step fitness %f", batchFitness, stepFitness)
+	}
+}
+
+func TestFunctionApproxScapeCrossValidatesGT(t *testing.T) {
+	// o = x is an imperfect fit of the sine target.
+	genome := model.Genome{
+		Neurons:  []model.Neuron{{ID: "i", Activation: "identity"}, {ID: "o", Activation: "identity"}},
+		Synapses: []model.Synapse{{From: "i", To: "o", Weight: 1, Enabled: true}},
+	}
+	cortex, err := agent.NewCortex("fnapprox-cv-agent", genome, nil, nil, []string{"i"}, []string{"o"}, nil)
+	if err != nil {
+		t.Fatalf("new cortex: %v", err)
+	}
+
+	built, err := NewFromFactory(FunctionApproxScapeName, map[string]string{"samples": "20", "folds": "4"})
+	if err != nil {
+		t.Fatalf("function-approx with folds: %v", err)
+	}
+	s := built.(FunctionApproxScape)
+	fitness, trace, err := s.Evaluate(context.Background(), cortex)
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	folds, ok := TraceFoldFitnessOf(trace)
+	if !ok || len(folds) != 4 || trace["folds"] != 4 || trace["samples"] != 5 {
+		t.Fatalf("expected four folds of five samples, got %+v", trace)
+	}
+	mean := 0.0
+	spread := false
+	for _, fold := range folds {
+		mean += fold / 4
+		spread = spread || fold != folds[0]
+	}
+	if math.Abs(float64(fitness)-mean) > 1e-12 || !spread {
+		t.Fatalf("expected fitness %f to be the mean of distinct fold fitness %v", fitness, folds)
+	}
+	// Equal folds average to the whole-window score.
+	whole, _, err := FunctionApproxScape{Samples: 20}.Evaluate(context.Background(), cortex)
+	if err != nil {
+		t.Fatalf("evaluate without folds: %v", err)
+	}
+	if math.Abs(float64(fitness-whole)) > 1e-9 {
+		t.Fatalf("expected cross-validated fitness %f to match whole-window fitness %f", fitness, whole)
+	}
+	batchFitness, _, err := s.EvaluateBatch(context.Background(), cortex, "gt")
+	if err != nil || math.Abs(float64(batchFitness-fitness)) > 1e-9 {
+		t.Fatalf("expected batch fitness %f to match step fitness %f, err=%v", batchFitness, fitness, err)
+	}
+	if _, trace, err := s.EvaluateMode(context.Background(), cortex, "validation"); err != nil || trace[TraceFoldFitness] != nil {
+		t.Fatalf("expected validation to score its window whole, got trace=%+v err=%v", trace, err)
+	}
+
+	if _, err := NewFromFactory(FunctionApproxScapeName, map[string]string{"folds": "0"}); err == nil {
+		t.Fatal("expected zero folds to be rejected")
+	}
+	if _, _, err := (FunctionApproxScape{Samples: 3, Folds: 4}).Evaluate(context.Background(), cortex); err == nil {
+		t.Fatal("expected more folds than samples to fail")
+	}
+}
@@ -15,7 +15,20 @@ import (
 	protoio "protogonos/internal/io"
 )
 
-type FXScape struct{}
+// FXScape trades a price series. Its construction parameters are
+// instrument, a price CSV that replaces the active series for this scape,
+// and steps, the length of the gt trading window. Its traces report return and
+// negated drawdown as Objectives, so profit and risk can be traded off.
+type FXScape struct {
+	instrument *fxSeries
+	gtSteps    int
+}
+
+const (
+	fxDefaultGTSteps = 64
+	// fxMaxGTSteps keeps the gt window clear of the validation window.
+	fxMaxGTSteps = 128
+)
 
 type fxSeries struct {
 	name   string
@@ -31,15 +44,41 @@ func (FXScape) Name() string {
 	return "fx"
 }
 
-func (FXScape) Evaluate(ctx context.Context, agent Agent) (Fitness, Trace, error) {
-	return FXScape{}.EvaluateMode(ctx, agent, "gt")
+// Configure implements Configurable.
+func (FXScape) Configure(params map[string]string) (Scape, error) {
+	if err := checkFactoryParams(params, "instrument", "steps"); err != nil {
+		return nil, err
+	}
+	steps, err := intFactoryParam(params, "steps", fxDefaultGTSteps, 8, fxMaxGTSteps)
+	if err != nil {
+		return nil, err
+	}
+	configured := FXScape{gtSteps: steps}
+	if path, ok := params["instrument"]; ok {
+		series, err := loadFileAsset("fx", path, loadFXSeriesCSV)
+		if err != nil {
+			return nil, fmt.Errorf("parameter instrument: %w", err)
+		}
+		configured.instrument = &series
+	}
+	return configured, nil
+}
+
+func (s FXScape) Evaluate(ctx context.Context, agent Agent) (Fitness, Trace, error) {
+	return s.EvaluateMode(ctx, agent, "gt")
 }
 
-func (FXScape) EvaluateMode(ctx context.Context, agent Agent, mode string) (Fitness, Trace, error) {
+func (s FXScape) EvaluateMode(ctx context.Context, agent Agent, mode string) (Fitness, Trace, error) {
 	cfg, err := fxConfigForMode(mode)
 	if err != nil {
 		return 0, nil, err
 	}
+	if cfg.mode == "gt" && s.gtSteps > 0 {
+		cfg.steps = s.gtSteps
+	}
+	if s.instrument != nil {
+		ctx = context.WithValue(ctx, fxDataSourceContextKey{}, *s.instrument)
+	}
 
 	if ticker, ok := agent.(TickAgent); ok {
 		fitness, trace, err := evaluateFXWithTick(ctx, ticker, cfg)
@@ -279,6 +318,7 @@ func evaluateFX(
 		"prev_percentage_change": prevPercentageChange,
 		"profit":                 orderProfit,
 		"feature_width":          fxPerceptWidth,
+		TraceObjectives:          Objectives{returnPct, -drawdownRatio},
 	}, nil
 }
 
@@ -698,7 +738,7 @@ func currentFXSeries(ctx context.Context) fxSeries {
 func fxConfigForMode(mode string) (fxModeConfig, error) {
 	switch strings.TrimSpace(strings.ToLower(mode)) {
 	case "", "gt":
-		return fxModeConfig{mode: "gt", steps: 64, startStep: 0}, nil
+		return fxModeConfig{mode: "gt", steps: fxDefaultGTSteps, startStep: 0}, nil
 	case "validation":
 		return fxModeConfig{mode: "validation", steps: 48, startStep: 128}, nil
 	case "test":
@@ -226,6 +226,9 @@ func TestFXScapeEvaluateWithExtendedIOComponents(t *testing.T) {
 	if width, ok := trace["feature_width"].(int); !ok || width < 10 {
 		t.Fatalf("expected extended feature width in trace, got %+v", trace)
 	}
+	if objectives, ok := TraceObjectivesOf(trace); !ok || len(objectives) != 2 || objectives[1] > 0 {
+		t.Fatalf("expected return and negated drawdown objectives, got %+v", trace)
+	}
 	if surface, _ := trace["sensor_surface"].(string); surface != "extended" {
 		t.Fatalf("expected extended sensor surface, got %+v", trace)
 	}
@@ -476,3 +479,60 @@ func fxFollowSignalAction(input []float64) []float64 {
 		return []float64{0}
 	}
 }
+
+func TestFXScapeConfigureInstrumentAndSteps(t *testing.T) {
+	ResetFXSeriesSource()
+	t.Cleanup(ResetFXSeriesSource)
+
+	path := filepath.Join(t.TempDir(), "eurusd.csv")
+	var builder strings.Builder
+	for i := 0; i < 300; i++ {
+		fmt.Fprintf(&builder, "%0.6f\n", 1.1+0.001*math.Sin(float64(i)/5))
+	}
+	if err := os.WriteFile(path, []byte(builder.String()), 0o644); err != nil {
+		t.Fatalf("write fx csv: %v", err)
+	}
+	configured, err := ConfigureScape(FXScape{}, map[string]string{"instrument": path, "steps": "20"})
+	if err != nil {
+		t.Fatalf("configure fx: %v", err)
+	}
+	if configured.Name() != "fx" {
+		t.Fatalf("expected configured scape to keep its name, got %s", configured.Name())
+	}
+	follow := scriptedStepAgent{id: "follow", fn: fxFollowSignalAction}
+
+	_, gtTrace, err := configured.Evaluate(context.Background(), follow)
+	if err != nil {
+		t.Fatalf("evaluate gt: %v", err)
+	}
+	if name, _ := gtTrace["series_name"].(string); !strings.Contains(name, "eurusd.csv") {
+		t.Fatalf("expected instrument series in trace, got %+v", gtTrace)
+	}
+	if steps, _ := gtTrace["steps"].(int); steps != 20 {
+		t.Fatalf("expected 20 gt steps, got %+v", gtTrace["steps"])
+	}
+	_, validationTrace, err := configured.(FXScape).EvaluateMode(context.Background(), follow, "validation")
+	if err != nil {
+		t.Fatalf("evaluate validation: %v", err)
+	}
+	if steps, _ := validationTrace["steps"].(int); steps != 48 {
+		t.Fatalf("expected steps to leave validation unchanged, got %+v", validationTrace["steps"])
+	}
+	_, defaultTrace, err := FXScape{}.Evaluate(context.Background(), follow)
+	if err != nil {
+		t.Fatalf("evaluate default: %v", err)
+	}
+	if name, _ := defaultTrace["series_name"].(string); name != "fx.synthetic.v2" {
+		t.Fatalf("expected configuring to leave the default instance alone, got %q", name)
+	}
+
+	for _, params := range []map[string]string{
+		{"pair": "eurusd"},
+		{"steps": "500"},
+		{"instrument": filepath.Join(t.TempDir(), "missing.csv")},
+	} {
+		if _, err := ConfigureScape(FXScape{}, params); err == nil {
+			t.Fatalf("expected params %v to be rejected", params)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package scape
+
+import "context"
+
+// LifecycleScape is implemented by scapes with setup worth doing once per
+// process rather than per evaluation. The platform calls Init and then
+// Warmup before the first evaluation of a registered instance, with the
+// context of the run about to evaluate it, and Close when the instance is
+// replaced or the polis stops. Init prepares what evaluations need and
+// reports configuration errors; Warmup front-loads work evaluations would
+// otherwise do lazily. Prepared assets belong in the process-wide asset
+// cache, so instances built for later runs find them ready.
+type LifecycleScape interface {
+	Scape
+	Init(ctx context.Context) error
+	Warmup(ctx context.Context) error
+	Close() error
+}
+
+// InitScape runs the Init and Warmup hooks of s, if it has them.
+func InitScape(ctx context.Context, s Scape) error {
+	lifecycle, ok := s.(LifecycleScape)
+	if !ok {
+		return nil
+	}
+	if err := lifecycle.Init(ctx); err != nil {
+		return err
+	}
+	return lifecycle.Warmup(ctx)
+}
+
+// CloseScape runs the Close hook of s, if it has one.
+func CloseScape(s Scape) error {
+	if lifecycle, ok := s.(LifecycleScape); ok {
+		return lifecycle.Close()
+	}
+	return nil
+}
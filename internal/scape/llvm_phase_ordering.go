@@ -67,6 +67,25 @@ func LoadLLVMWorkflowJSON(path string) error {
 	return nil
 }
 
+// Init has nothing to prepare: every workflow source is validated when it
+// is loaded.
+func (LLVMPhaseOrderingScape) Init(context.Context) error {
+	return nil
+}
+
+// Warmup prepares the program tables of every mode of the run's workflow.
+func (LLVMPhaseOrderingScape) Warmup(ctx context.Context) error {
+	workflow := currentLLVMWorkflow(ctx)
+	for _, profile := range workflow.modes {
+		llvmProgramTablesFor(profile.Program, workflow.optimizations)
+	}
+	return nil
+}
+
+func (LLVMPhaseOrderingScape) Close() error {
+	return nil
+}
+
 func (LLVMPhaseOrderingScape) Evaluate(ctx context.Context, agent Agent) (Fitness, Trace, error) {
 	return LLVMPhaseOrderingScape{}.EvaluateMode(ctx, agent, "gt")
 }
@@ -201,7 +220,7 @@ func evaluateLLVMPhaseOrdering(
 			runtimeGain = (runtimeBaseline - runtimeCurrent) / runtimeBaseline
 		}
 		percept := llvmPerceptVector(
-			cfg.program,
+			cfg,
 			phase,
 			cfg.maxPhases,
 			complexity,
@@ -403,6 +422,7 @@ type llvmPhaseOrderingConfig struct {
 	baseRuntime       float64
 	workflowName      string
 	optimizations     []string
+	tables            *llvmProgramTables
 }
 
 func llvmPhaseOrderingConfigForMode(mode string, workflow llvmWorkflow) (llvmPhaseOrderingConfig, error) {
@@ -423,9 +443,51 @@ func llvmPhaseOrderingConfigForMode(mode string, workflow llvmWorkflow) (llvmPha
 		baseRuntime:       profile.BaseRuntime,
 		workflowName:      workflow.name,
 		optimizations:     append([]string(nil), workflow.optimizations...),
+		tables:            llvmProgramTablesFor(profile.Program, workflow.optimizations),
 	}, nil
 }
 
+// llvmProgramTables holds a program's optimization affinities and percept
+// feature biases, which every phase of every evaluation reads.
+type llvmProgramTables struct {
+	affinity    map[string]float64
+	featureBias []float64
+}
+
+func llvmProgramTablesFor(program string, optimizations []string) *llvmProgramTables {
+	key := "llvm-tables|" + program + "|" + strings.Join(optimizations, ",")
+	tables, _ := loadAsset(key, func() (*llvmProgramTables, error) {
+		tables := &llvmProgramTables{
+			affinity:    make(map[string]float64, len(optimizations)),
+			featureBias: make([]float64, llvmPerceptWidth),
+		}
+		for _, optimization := range optimizations {
+			tables.affinity[optimization] = llvmProgramOptimizationAffinity(program, optimization)
+		}
+		for i := range tables.featureBias {
+			tables.featureBias[i] = llvmProgramFeatureBias(program, i)
+		}
+		return tables, nil
+	})
+	return tables
+}
+
+func (cfg llvmPhaseOrderingConfig) optimizationAffinity(optimization string) float64 {
+	if cfg.tables != nil {
+		if affinity, ok := cfg.tables.affinity[optimization]; ok {
+			return affinity
+		}
+	}
+	return llvmProgramOptimizationAffinity(cfg.program, optimization)
+}
+
+func (cfg llvmPhaseOrderingConfig) featureBias(feature int) float64 {
+	if cfg.tables != nil && feature < len(cfg.tables.featureBias) {
+		return cfg.tables.featureBias[feature]
+	}
+	return llvmProgramFeatureBias(cfg.program, feature)
+}
+
 type llvmDecision struct {
 	mode              string
 	scalarAction      float64
@@ -658,7 +720,7 @@ func decodeLLVMDecision(output []float64, passNorm float64, optimizations []stri
 }
 
 func llvmPerceptVector(
-	program string,
+	cfg llvmPhaseOrderingConfig,
 	phaseIndex, maxPhases int,
 	complexity, passNorm, alignment, diversity, runtimeGain float64,
 	history []string,
@@ -675,7 +737,7 @@ func llvmPerceptVector(
 		base := math.Sin(float64(i)*0.37 + float64(phaseIndex)*0.21)
 		trend := math.Cos(float64(i)*0.11 + complexity*1.7)
 		historyBias := llvmHistorySignal(history, i)
-		programBias := llvmProgramFeatureBias(program, i)
+		programBias := cfg.featureBias(i)
 		value := 0.5 + 0.18*base + 0.17*trend + 0.12*historyBias + 0.20*programBias + 0.33*inversePhase
 		percept[i] = clampLLVM(value, 0, 1)
 	}
@@ -691,7 +753,7 @@ func llvmOptimizationGain(cfg llvmPhaseOrderingConfig, decision llvmDecision, ph
 		return clampLLVM(gain, -0.03, 0.11)
 	}
 
-	affinity := llvmProgramOptimizationAffinity(cfg.program, decision.optimization)
+	affinity := cfg.optimizationAffinity(decision.optimization)
 	progress := float64(phase) / float64(maxIntLLVM(1, cfg.maxPhases))
 	gain := (0.015 + 0.055*affinity) * (1.0 - 0.45*progress)
 	gain = gain * (0.55 + 0.45*complexity)
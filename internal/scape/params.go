@@ -0,0 +1,103 @@
+package scape
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ScapeParam describes a runtime-adjustable scape parameter. Default is the
+// value used in gt mode when the parameter has not been set.
+type ScapeParam struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Default     float64 `json:"default"`
+	Min         float64 `json:"min"`
+	Max         float64 `json:"max"`
+}
+
+// TunableScape optionally exposes parameters that can be changed between
+// generations of a running evolution, e.g. for manual curriculum steps. The
+// values only affect gt evaluations so validation and test scores stay
+// comparable across changes.
+type TunableScape interface {
+	Scape
+	TunableParams() []ScapeParam
+}
+
+type scapeParamsContextKey struct{}
+
+// WithScapeParams returns a context carrying scape parameter values. The map
+// is copied so later changes by the caller do not leak into evaluations that
+// already hold the context.
+func WithScapeParams(ctx context.Context, params map[string]float64) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	copied := make(map[string]float64, len(params))
+	for name, value := range params {
+		copied[name] = value
+	}
+	return context.WithValue(ctx, scapeParamsContextKey{}, copied)
+}
+
+// ScapeParamsFromContext returns a copy of the scape parameters carried by ctx.
+func ScapeParamsFromContext(ctx context.Context) map[string]float64 {
+	if ctx == nil {
+		return nil
+	}
+	params, _ := ctx.Value(scapeParamsContextKey{}).(map[string]float64)
+	if len(params) == 0 {
+		return nil
+	}
+	copied := make(map[string]float64, len(params))
+	for name, value := range params {
+		copied[name] = value
+	}
+	return copied
+}
+
+func scapeParamFromContext(ctx context.Context, name string) (float64, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	params, _ := ctx.Value(scapeParamsContextKey{}).(map[string]float64)
+	value, ok := params[name]
+	return value, ok
+}
+
+// LookupScapeParam returns the named parameter of s, or an error naming the
+// parameters it does support.
+func LookupScapeParam(s Scape, name string) (ScapeParam, error) {
+	tunable, ok := s.(TunableScape)
+	if !ok {
+		return ScapeParam{}, fmt.Errorf("scape %s has no tunable parameters", s.Name())
+	}
+	params := tunable.TunableParams()
+	names := make([]string, 0, len(params))
+	for _, param := range params {
+		if param.Name == name {
+			return param, nil
+		}
+		names = append(names, param.Name)
+	}
+	sort.Strings(names)
+	return ScapeParam{}, fmt.Errorf("unknown %s parameter %q (supported: %v)", s.Name(), name, names)
+}
+
+// ValidateScapeParam checks that value is a finite, in-range setting for the
+// named parameter of s.
+func ValidateScapeParam(s Scape, name string, value float64) error {
+	param, err := LookupScapeParam(s, name)
+	if err != nil {
+		return err
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return fmt.Errorf("%s parameter %s must be finite", s.Name(), name)
+	}
+	if value < param.Min || value > param.Max {
+		return fmt.Errorf("%s parameter %s must be within [%g, %g], got %g", s.Name(), name, param.Min, param.Max, value)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package scape
+
+import (
+	"context"
+	"testing"
+
+	"protogonos/internal/agent"
+	"protogonos/internal/model"
+)
+
+func TestValidateScapeParam(t *testing.T) {
+	if err := ValidateScapeParam(CartPoleLiteScape{}, "steps_per_episode", 20); err != nil {
+		t.Fatalf("expected valid parameter, got %v", err)
+	}
+	if err := ValidateScapeParam(CartPoleLiteScape{}, "steps_per_episode", 0); err == nil {
+		t.Fatal("expected out-of-range value to fail")
+	}
+	if err := ValidateScapeParam(CartPoleLiteScape{}, "gravity", 1); err == nil {
+		t.Fatal("expected unknown parameter to fail")
+	}
+	if err := ValidateScapeParam(XORScape{}, "noise", 0.1); err == nil {
+		t.Fatal("expected scape without tunable parameters to fail")
+	}
+}
+
+func TestCartPoleLiteScapeParamsAffectGTOnly(t *testing.T) {
+	genome := model.Genome{
+		Neurons: []model.Neuron{
+			{ID: "x", Activation: "identity"},
+			{ID: "v", Activation: "identity"},
+			{ID: "f", Activation: "identity"},
+		},
+		Synapses: []model.Synapse{
+			{From: "x", To: "f", Weight: -1.2, Enabled: true},
+			{From: "v", To: "f", Weight: -0.6, Enabled: true},
+		},
+	}
+	cortex, err := agent.NewCortex("cp-params", genome, nil, nil, []string{"x", "v"}, []string{"f"}, nil)
+	if err != nil {
+		t.Fatalf("new cortex: %v", err)
+	}
+
+	ctx := WithScapeParams(context.Background(), map[string]float64{
+		"steps_per_episode":     12,
+		"observation_noise_std": 0.2,
+	})
+	baseline, _, err := CartPoleLiteScape{}.Evaluate(context.Background(), cortex)
+	if err != nil {
+		t.Fatalf("evaluate baseline: %v", err)
+	}
+	noisy, trace, err := CartPoleLiteScape{}.Evaluate(ctx, cortex)
+	if err != nil {
+		t.Fatalf("evaluate with params: %v", err)
+	}
+	if trace["steps_per_episode"] != 12 || trace["observation_noise_std"] != 0.2 {
+		t.Fatalf("expected parameters reflected in trace, got %+v", trace)
+	}
+	if noisy == baseline {
+		t.Fatalf("expected parameters to change gt fitness, both %f", noisy)
+	}
+	repeat, _, err := CartPoleLiteScape{}.Evaluate(ctx, cortex)
+	if err != nil {
+		t.Fatalf("evaluate repeat: %v", err)
+	}
+	if repeat != noisy {
+		t.Fatalf("expected deterministic noise per agent, got %f and %f", noisy, repeat)
+	}
+
+	_, validationTrace, err := CartPoleLiteScape{}.EvaluateMode(ctx, cortex, "validation")
+	if err != nil {
+		t.Fatalf("evaluate validation: %v", err)
+	}
+	if validationTrace["steps_per_episode"] != 48 {
+		t.Fatalf("expected validation mode to ignore parameters, got %+v", validationTrace)
+	}
+}
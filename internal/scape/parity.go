@@ -0,0 +1,181 @@
+package scape
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	ParityScapeName   = "parity"
+	MajorityScapeName = "majority"
+
+	DefaultParityInputs = 3
+	MaxParityInputs     = 12
+)
+
+func init() {
+	for _, name := range []string{ParityScapeName, MajorityScapeName} {
+		majority := name == MajorityScapeName
+		if err := RegisterFactory(name, func(params map[string]string) (Scape, error) {
+			return newParityFamilyScape(params, majority)
+		}); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// ParityScape generalizes XORScape to N binary inputs. The target is the
+// parity of the inputs (XOR-N), or with Majority set whether strictly more
+// than half of them are on. Every mode scores the full 2^N truth table, in a
+// mode-specific order, with the XOR fitness 1/(SSE+1e-6); parity with two
+// inputs is XOR. Inputs is set with the construction parameter n.
+type ParityScape struct {
+	Inputs   int
+	Majority bool
+}
+
+func newParityFamilyScape(params map[string]string, majority bool) (Scape, error) {
+	if err := checkFactoryParams(params, "n"); err != nil {
+		return nil, err
+	}
+	inputs, err := intFactoryParam(params, "n", DefaultParityInputs, 2, MaxParityInputs)
+	if err != nil {
+		return nil, err
+	}
+	return ParityScape{Inputs: inputs, Majority: majority}, nil
+}
+
+// ParityInputs returns the input width n configured by params, or the default
+// when n is unset or invalid; construction reports invalid values.
+func ParityInputs(params map[string]string) int {
+	inputs, err := intFactoryParam(params, "n", DefaultParityInputs, 2, MaxParityInputs)
+	if err != nil {
+		return DefaultParityInputs
+	}
+	return inputs
+}
+
+func (s ParityScape) Name() string {
+	if s.Majority {
+		return MajorityScapeName
+	}
+	return ParityScapeName
+}
+
+func (s ParityScape) inputs() int {
+	if s.Inputs <= 0 {
+		return DefaultParityInputs
+	}
+	return s.Inputs
+}
+
+func (s ParityScape) Evaluate(ctx context.Context, agent Agent) (Fitness, Trace, error) {
+	return s.EvaluateMode(ctx, agent, "gt")
+}
+
+func (s ParityScape) EvaluateMode(ctx context.Context, agent Agent, mode string) (Fitness, Trace, error) {
+	cfg, err := s.configForMode(mode)
+	if err != nil {
+		return 0, nil, err
+	}
+	runner, ok := agent.(StepAgent)
+	if !ok {
+		return 0, nil, fmt.Errorf("agent %s does not implement step runner", agent.ID())
+	}
+	fitness, trace, err := evaluateXOR(ctx, cfg, func(ctx context.Context, in []float64) (float64, error) {
+		out, err := runner.RunStep(ctx, in)
+		if err != nil {
+			return 0, err
+		}
+		if len(out) != 1 {
+			return 0, fmt.Errorf("%s requires one output, got %d", s.Name(), len(out))
+		}
+		return out[0], nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	trace["inputs"] = s.inputs()
+	return fitness, trace, nil
+}
+
+func (s ParityScape) EvaluateBatch(ctx context.Context, agent BatchAgent, mode string) (Fitness, Trace, error) {
+	cfg, err := s.configForMode(mode)
+	if err != nil {
+		return 0, nil, err
+	}
+	rows := make([][]float64, len(cfg.cases))
+	for i, c := range cfg.cases {
+		rows[i] = c.in
+	}
+	outputs, err := runBatchRows(ctx, agent, rows)
+	if err != nil {
+		return 0, nil, err
+	}
+	next := 0
+	fitness, trace, err := evaluateXOR(ctx, cfg, func(context.Context, []float64) (float64, error) {
+		out := outputs[next]
+		next++
+		if len(out) != 1 {
+			return 0, fmt.Errorf("%s requires one output, got %d", s.Name(), len(out))
+		}
+		return out[0], nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	trace["inputs"] = s.inputs()
+	return fitness, trace, nil
+}
+
+// truthTable lists every input combination in ascending binary order, the
+// first input being the most significant bit.
+func (s ParityScape) truthTable() []xorCase {
+	n := s.inputs()
+	cases := make([]xorCase, 1<<n)
+	for row := range cases {
+		in := make([]float64, n)
+		on := 0
+		for bit := 0; bit < n; bit++ {
+			if row&(1<<(n-1-bit)) != 0 {
+				in[bit] = 1
+				on++
+			}
+		}
+		want := float64(on % 2)
+		if s.Majority {
+			want = 0
+			if 2*on > n {
+				want = 1
+			}
+		}
+		cases[row] = xorCase{in: in, want: want}
+	}
+	return cases
+}
+
+// configForMode orders the truth table ascending for gt, descending for
+// validation and in Gray-code order for test and benchmark, so recurrent
+// genomes cannot exploit a fixed presentation order across modes.
+func (s ParityScape) configForMode(mode string) (xorModeConfig, error) {
+	base := s.truthTable()
+	mode = strings.TrimSpace(strings.ToLower(mode))
+	ordered := make([]xorCase, len(base))
+	switch mode {
+	case "", "gt":
+		mode = "gt"
+		copy(ordered, base)
+	case "validation":
+		for i := range base {
+			ordered[i] = base[len(base)-1-i]
+		}
+	case "test", "benchmark":
+		for i := range base {
+			ordered[i] = base[i^(i>>1)]
+		}
+	default:
+		return xorModeConfig{}, fmt.Errorf("unsupported %s mode: %s", s.Name(), mode)
+	}
+	return xorModeConfig{mode: mode, cases: ordered}, nil
+}
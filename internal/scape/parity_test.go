@@ -0,0 +1,164 @@
+package scape
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"protogonos/internal/agent"
+	"protogonos/internal/model"
+)
+
+func TestParityScapeTwoInputsMatchesXORCases(t *testing.T) {
+	cases := ParityScape{Inputs: 2}.truthTable()
+	want := [][3]float64{{0, 0, 0}, {0, 1, 1}, {1, 0, 1}, {1, 1, 0}}
+	if len(cases) != len(want) {
+		t.Fatalf("expected %d cases, got %d", len(want), len(cases))
+	}
+	for i, c := range cases {
+		if c.in[0] != want[i][0] || c.in[1] != want[i][1] || c.want != want[i][2] {
+			t.Fatalf("case %d: got in=%v want=%f, expected %v", i, c.in, c.want, want[i])
+		}
+	}
+}
+
+func TestMajorityScapeTargets(t *testing.T) {
+	cases := ParityScape{Inputs: 4, Majority: true}.truthTable()
+	if len(cases) != 16 {
+		t.Fatalf("expected 16 cases, got %d", len(cases))
+	}
+	for _, c := range cases {
+		on := 0
+		for _, v := range c.in {
+			if v == 1 {
+				on++
+			}
+		}
+		want := 0.0
+		if on >= 3 {
+			want = 1
+		}
+		if c.want != want {
+			t.Fatalf("inputs %v: expected target %f, got %f", c.in, want, c.want)
+		}
+	}
+}
+
+func TestParityScapeModesPermuteTruthTable(t *testing.T) {
+	s := ParityScape{Inputs: 4}
+	base := s.truthTable()
+	for _, mode := range []string{"gt", "validation", "test", "benchmark"} {
+		cfg, err := s.configForMode(mode)
+		if err != nil {
+			t.Fatalf("mode %s: %v", mode, err)
+		}
+		if len(cfg.cases) != len(base) {
+			t.Fatalf("mode %s: expected %d cases, got %d", mode, len(base), len(cfg.cases))
+		}
+		seen := map[string]bool{}
+		for _, c := range cfg.cases {
+			seen[caseKey(c.in)] = true
+		}
+		if len(seen) != len(base) {
+			t.Fatalf("mode %s: expected every row once, got %d distinct rows", mode, len(seen))
+		}
+	}
+	if _, err := s.configForMode("bogus"); err == nil {
+		t.Fatal("expected unsupported mode error")
+	}
+}
+
+func caseKey(in []float64) string {
+	var b strings.Builder
+	for _, v := range in {
+		if v == 1 {
+			b.WriteByte('1')
+		} else {
+			b.WriteByte('0')
+		}
+	}
+	return b.String()
+}
+
+func TestParityFactoryValidatesParams(t *testing.T) {
+	s, err := NewFromFactory(ParityScapeName, nil)
+	if err != nil {
+		t.Fatalf("default parity: %v", err)
+	}
+	if got := s.(ParityScape).Inputs; got != DefaultParityInputs {
+		t.Fatalf("expected default inputs %d, got %d", DefaultParityInputs, got)
+	}
+	s, err = NewFromFactory(MajorityScapeName, map[string]string{"n": "5"})
+	if err != nil {
+		t.Fatalf("majority n=5: %v", err)
+	}
+	if p := s.(ParityScape); p.Inputs != 5 || !p.Majority || p.Name() != MajorityScapeName {
+		t.Fatalf("unexpected majority scape: %+v", p)
+	}
+	for _, params := range []map[string]string{
+		{"n": "1"},
+		{"n": "13"},
+		{"n": "five"},
+		{"width": "4"},
+	} {
+		if _, err := NewFromFactory(ParityScapeName, params); err == nil {
+			t.Fatalf("expected params %v to be rejected", params)
+		}
+	}
+	if _, err := NewFromFactory("xor", map[string]string{"n": "2"}); err == nil {
+		t.Fatal("expected xor to reject construction parameters")
+	}
+	if got := ParityInputs(map[string]string{"n": "bad"}); got != DefaultParityInputs {
+		t.Fatalf("expected fallback inputs, got %d", got)
+	}
+}
+
+func TestParityScapeEvaluateWithHandBuiltAgent(t *testing.T) {
+	// Two-input parity is XOR; reuse the XOR network.
+	genome := model.Genome{
+		Neurons: []model.Neuron{
+			{ID: "i1", Activation: "identity"},
+			{ID: "i2", Activation: "identity"},
+			{ID: "h1", Activation: "sigmoid", Bias: -10},
+			{ID: "h2", Activation: "sigmoid", Bias: 30},
+			{ID: "o", Activation: "sigmoid", Bias: -30},
+		},
+		Synapses: []model.Synapse{
+			{From: "i1", To: "h1", Weight: 20, Enabled: true},
+			{From: "i2", To: "h1", Weight: 20, Enabled: true},
+			{From: "i1", To: "h2", Weight: -20, Enabled: true},
+			{From: "i2", To: "h2", Weight: -20, Enabled: true},
+			{From: "h1", To: "o", Weight: 20, Enabled: true},
+			{From: "h2", To: "o", Weight: 20, Enabled: true},
+		},
+	}
+	cortex, err := agent.NewCortex("parity-agent", genome, nil, nil, []string{"i1", "i2"}, []string{"o"}, nil)
+	if err != nil {
+		t.Fatalf("new cortex: %v", err)
+	}
+
+	s := ParityScape{Inputs: 2}
+	for _, mode := range []string{"gt", "validation", "test"} {
+		_, trace, err := s.EvaluateMode(context.Background(), cortex, mode)
+		if err != nil {
+			t.Fatalf("evaluate %s: %v", mode, err)
+		}
+		if mse, _ := trace["mse"].(float64); mse > 0.05 {
+			t.Fatalf("mode %s: expected mse <= 0.05, got %f", mode, mse)
+		}
+		if inputs, _ := trace["inputs"].(int); inputs != 2 {
+			t.Fatalf("mode %s: expected inputs trace 2, got %+v", mode, trace["inputs"])
+		}
+	}
+	stepFitness, _, err := s.EvaluateMode(context.Background(), cortex, "gt")
+	if err != nil {
+		t.Fatalf("evaluate step: %v", err)
+	}
+	batchFitness, _, err := s.EvaluateBatch(context.Background(), cortex, "gt")
+	if err != nil {
+		t.Fatalf("evaluate batch: %v", err)
+	}
+	if diff := float64(stepFitness - batchFitness); diff < -1e-9 || diff > 1e-9 {
+		t.Fatalf("expected batch fitness %f to match step fitness %f", batchFitness, stepFitness)
+	}
+}
@@ -9,23 +9,95 @@ import (
 	protoio "protogonos/internal/io"
 )
 
+const (
+	Pole2FitnessDefault = "default"
+	// Pole2FitnessGruau selects the anti-wiggle fitness of Gruau et al. (1996)
+	// for double pole balancing without velocity information, the setting
+	// reported by the classic NEAT and ESP papers.
+	Pole2FitnessGruau = "gruau"
+)
+
+func init() {
+	if err := RegisterFactory("pole2-balancing", func(params map[string]string) (Scape, error) {
+		if err := checkFactoryParams(params, "fitness"); err != nil {
+			return nil, err
+		}
+		fitness, err := choiceFactoryParam(params, "fitness", Pole2FitnessDefault, Pole2FitnessDefault, Pole2FitnessGruau)
+		if err != nil {
+			return nil, err
+		}
+		return Pole2BalancingScape{Fitness: fitness}, nil
+	}); err != nil {
+		panic(err)
+	}
+}
+
 // Pole2BalancingScape mirrors the reference pole2 double-pole control task.
-type Pole2BalancingScape struct{}
+//
+// With Fitness set to gruau (construction parameter fitness=gruau) velocity
+// inputs are hidden and every mode scores 1000-step episodes with the Gruau
+// fitness 0.1*t/1000 + 0.9*f2, where f2 is 0.75 over the summed
+// |x|+|x'|+|theta1|+|theta1'| of the last 100 steps (0 before step 100). The
+// benchmark mode then runs the standard generalization protocol instead: the
+// genome must balance 100,000 steps from the standard start and is scored by
+// how many of the 625 generalization starts it balances for 1000 steps.
+type Pole2BalancingScape struct {
+	Fitness string
+}
 
 func (Pole2BalancingScape) Name() string {
 	return "pole2-balancing"
 }
 
-func (Pole2BalancingScape) Evaluate(ctx context.Context, agent Agent) (Fitness, Trace, error) {
-	return Pole2BalancingScape{}.EvaluateMode(ctx, agent, "gt")
+func (s Pole2BalancingScape) gruau() bool {
+	return s.Fitness == Pole2FitnessGruau
+}
+
+func (s Pole2BalancingScape) Evaluate(ctx context.Context, agent Agent) (Fitness, Trace, error) {
+	return s.EvaluateMode(ctx, agent, "gt")
+}
+
+// TunableParams exposes the gt episode length so long runs can start on
+// shorter balancing horizons and extend them as the population improves.
+func (s Pole2BalancingScape) TunableParams() []ScapeParam {
+	steps := 100000.0
+	if s.gruau() {
+		steps = gruauEpisodeSteps
+	}
+	return []ScapeParam{
+		{Name: "max_steps", Description: "gt episode length and goal in steps", Default: steps, Min: 1, Max: 1000000},
+	}
 }
 
-func (Pole2BalancingScape) EvaluateMode(ctx context.Context, agent Agent, mode string) (Fitness, Trace, error) {
+// SupportsFidelity reports that low-fidelity gt evaluations cap the episode
+// at a fraction of max_steps.
+func (Pole2BalancingScape) SupportsFidelity() bool {
+	return true
+}
+
+func (s Pole2BalancingScape) EvaluateMode(ctx context.Context, agent Agent, mode string) (Fitness, Trace, error) {
 	cfg, err := pole2ConfigForMode(mode)
 	if err != nil {
 		return 0, nil, err
 	}
+	if s.gruau() {
+		if cfg.mode == "benchmark" {
+			return evaluatePole2GruauBenchmark(ctx, agent)
+		}
+		cfg = gruauConfig(cfg)
+	}
+	if steps, ok := scapeParamFromContext(ctx, "max_steps"); ok && steps >= 1 && cfg.mode == "gt" {
+		cfg.maxSteps = int(steps)
+		cfg.goalSteps = int(steps)
+	}
+	if cfg.mode == "gt" {
+		cfg.maxSteps = fidelitySteps(ctx, cfg.maxSteps)
+		cfg.goalSteps = fidelitySteps(ctx, cfg.goalSteps)
+	}
+	return evaluatePole2Episode(ctx, agent, cfg)
+}
 
+func evaluatePole2Episode(ctx context.Context, agent Agent, cfg pole2ModeConfig) (Fitness, Trace, error) {
 	if ticker, ok := agent.(TickAgent); ok {
 		fitness, trace, err := evaluatePole2BalancingWithTick(ctx, ticker, cfg)
 		if err == nil {
@@ -64,6 +136,13 @@ type pole2ModeConfig struct {
 	initAngle2 float64
 	damping    bool
 	doublePole bool
+
+	// Gruau variant: hidden velocities, anti-wiggle fitness and the
+	// generalization starts' nonzero cart and long pole state.
+	gruau         bool
+	initPosition  float64
+	initVelocity  float64
+	initVelocity1 float64
 }
 
 func pole2ConfigForMode(mode string) (pole2ModeConfig, error) {
@@ -122,8 +201,11 @@ func pole2ConfigForMode(mode string) (pole2ModeConfig, error) {
 
 func initialPole2State(cfg pole2ModeConfig) pole2State {
 	return pole2State{
-		angle1: cfg.initAngle1,
-		angle2: cfg.initAngle2,
+		cartPosition: cfg.initPosition,
+		cartVelocity: cfg.initVelocity,
+		angle1:       cfg.initAngle1,
+		velocity1:    cfg.initVelocity1,
+		angle2:       cfg.initAngle2,
 	}
 }
 
@@ -138,6 +220,7 @@ type pole2EpisodeResult struct {
 	vectorControlSteps int
 	dampingOffSteps    int
 	singlePoleSteps    int
+	wiggle             float64
 }
 
 func evaluatePole2BalancingWithStep(ctx context.Context, runner StepAgent, cfg pole2ModeConfig) (Fitness, Trace, error) {
@@ -279,6 +362,10 @@ func evaluatePole2Balancing(
 	stepProgressAcc := 0.0
 	fitnessSignalAcc := 0.0
 	controlDecisions := 0
+	var wiggles []float64
+	if cfg.gruau {
+		wiggles = make([]float64, 0, gruauWiggleWindow)
+	}
 
 	for step := 0; step < cfg.maxSteps; step++ {
 		if err := ctx.Err(); err != nil {
@@ -290,10 +377,16 @@ func evaluatePole2Balancing(
 			stepProgress:  pole2StepProgress(stepsSurvived, cfg.maxSteps),
 			fitnessSignal: lastStepFitness,
 		}
-		control, err := chooseControl(ctx, state, workflow)
+		control, err := chooseControl(ctx, observePole2(state, cfg), workflow)
 		if err != nil {
 			return 0, nil, err
 		}
+		if cfg.gruau {
+			// The Gruau task always balances both poles; its fitness does
+			// not reward switching damping off.
+			control.damping = true
+			control.doublePole = true
+		}
 		runProgressAcc += workflow.runProgress
 		stepProgressAcc += workflow.stepProgress
 		fitnessSignalAcc += workflow.fitnessSignal
@@ -318,6 +411,9 @@ func evaluatePole2Balancing(
 		stepFitness := pole2StepFitness(stepsSurvived, state, control.damping)
 		fitnessAcc += stepFitness
 		lastStepFitness = stepFitness
+		if cfg.gruau {
+			wiggles = appendGruauWiggle(wiggles, state)
+		}
 
 		terminated, reason, reachedGoal := pole2Termination(state, cfg, stepsSurvived, control.doublePole)
 		if terminated {
@@ -354,8 +450,15 @@ func evaluatePole2Balancing(
 		dampingOffSteps:    dampingOffSteps,
 		singlePoleSteps:    singlePoleSteps,
 	}
+	fitness := summarizePole2Outcome(result, cfg)
+	if cfg.gruau {
+		for _, wiggle := range wiggles {
+			result.wiggle += wiggle
+		}
+		fitness = gruauFitness(result)
+	}
 
-	return summarizePole2Outcome(result, cfg), Trace{
+	trace := Trace{
 		"steps_survived":       stepsSurvived,
 		"max_steps":            cfg.maxSteps,
 		"goal_steps":           cfg.goalSteps,
@@ -365,6 +468,7 @@ func evaluatePole2Balancing(
 		"fitness_acc":          fitnessAcc,
 		"avg_step_fitness":     avgStepFitness,
 		"cart_position":        state.cartPosition,
+		TraceBehavior:          Behavior{state.cartPosition, state.angle1, state.angle2},
 		"cart_velocity":        state.cartVelocity,
 		"angle1":               state.angle1,
 		"velocity1":            state.velocity1,
@@ -387,7 +491,12 @@ func evaluatePole2Balancing(
 		"last_run_progress":    pole2RunProgress(stepsSurvived, cfg.goalSteps),
 		"last_step_progress":   pole2StepProgress(stepsSurvived, cfg.maxSteps),
 		"last_fitness_signal":  lastStepFitness,
-	}, nil
+	}
+	if cfg.gruau {
+		trace["fitness_variant"] = Pole2FitnessGruau
+		trace["gruau_wiggle"] = result.wiggle
+	}
+	return fitness, trace, nil
 }
 
 func pole2Observation(state pole2State, angleLimit float64) []float64 {
@@ -0,0 +1,177 @@
+package scape
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+const (
+	gruauEpisodeSteps         = 1000
+	gruauWiggleWindow         = 100
+	gruauBalanceSteps         = 100000
+	gruauGeneralizationTrials = 625
+	// gruauSolvedSuccesses is the generalization score a genome needs to
+	// count as a solution in the NEAT and ESP comparisons.
+	gruauSolvedSuccesses = 200
+)
+
+// gruauStandardAngle1 is the standard start of the long pole, 4.5 degrees.
+var gruauStandardAngle1 = 4.5 * math.Pi / 180
+
+// gruauConfig adapts a mode config to the Gruau task: hidden velocities and
+// 1000-step episodes, with gt starting from the standard start.
+func gruauConfig(cfg pole2ModeConfig) pole2ModeConfig {
+	cfg.gruau = true
+	cfg.maxSteps = gruauEpisodeSteps
+	cfg.goalSteps = gruauEpisodeSteps
+	if cfg.mode == "gt" {
+		cfg.initAngle1 = gruauStandardAngle1
+		cfg.initAngle2 = 0
+	}
+	return cfg
+}
+
+// observePole2 returns the state the controller sees; the Gruau task hides
+// every velocity.
+func observePole2(state pole2State, cfg pole2ModeConfig) pole2State {
+	if !cfg.gruau {
+		return state
+	}
+	state.cartVelocity = 0
+	state.velocity1 = 0
+	state.velocity2 = 0
+	return state
+}
+
+// appendGruauWiggle keeps the per-step |x|+|x'|+|theta1|+|theta1'| of the
+// last gruauWiggleWindow steps.
+func appendGruauWiggle(wiggles []float64, state pole2State) []float64 {
+	wiggle := math.Abs(state.cartPosition) + math.Abs(state.cartVelocity) + math.Abs(state.angle1) + math.Abs(state.velocity1)
+	if len(wiggles) == gruauWiggleWindow {
+		copy(wiggles, wiggles[1:])
+		wiggles = wiggles[:gruauWiggleWindow-1]
+	}
+	return append(wiggles, wiggle)
+}
+
+func gruauFitness(result pole2EpisodeResult) Fitness {
+	f1 := float64(result.stepsSurvived) / gruauEpisodeSteps
+	f2 := 0.0
+	if result.stepsSurvived >= gruauWiggleWindow {
+		f2 = 0.75 / math.Max(result.wiggle, 1e-9)
+	}
+	fitness := 0.1*f1 + 0.9*f2
+	if math.IsNaN(fitness) || math.IsInf(fitness, 0) {
+		return 0
+	}
+	return Fitness(fitness)
+}
+
+// gruauGeneralizationStarts returns the 625 generalization starts: cart
+// position, cart velocity, long pole angle and long pole angular velocity
+// each take the values 0.05, 0.25, 0.5, 0.75 and 0.95 of their ranges
+// +-2.16 m, +-1.35 m/s, +-3.6 deg and +-8.6 deg/s, with the short pole
+// upright and at rest.
+func gruauGeneralizationStarts() []pole2State {
+	levels := []float64{0.05, 0.25, 0.5, 0.75, 0.95}
+	scale := func(level, limit float64) float64 {
+		return (2*level - 1) * limit
+	}
+	rad := math.Pi / 180
+	starts := make([]pole2State, 0, gruauGeneralizationTrials)
+	for _, x := range levels {
+		for _, v := range levels {
+			for _, a := range levels {
+				for _, w := range levels {
+					starts = append(starts, pole2State{
+						cartPosition: scale(x, 2.16),
+						cartVelocity: scale(v, 1.35),
+						angle1:       scale(a, 3.6*rad),
+						velocity1:    scale(w, 8.6*rad),
+					})
+				}
+			}
+		}
+	}
+	return starts
+}
+
+// evaluatePole2GruauBenchmark runs the standard generalization protocol. The
+// fitness is the fraction of the 100,000 balancing steps survived plus, once
+// those are all balanced, the number of generalization starts balanced for
+// 1000 steps, so solutions score in [1, 626].
+func evaluatePole2GruauBenchmark(ctx context.Context, agent Agent) (Fitness, Trace, error) {
+	base, err := pole2ConfigForMode("benchmark")
+	if err != nil {
+		return 0, nil, err
+	}
+	base.gruau = true
+
+	balance := base
+	balance.maxSteps = gruauBalanceSteps
+	balance.goalSteps = gruauBalanceSteps
+	balance.initAngle1 = gruauStandardAngle1
+	balance.initAngle2 = 0
+	_, balanceTrace, err := evaluatePole2Episode(ctx, agent, balance)
+	if err != nil {
+		return 0, nil, err
+	}
+	balanceSteps, _ := balanceTrace["steps_survived"].(int)
+	balanced := balanceSteps >= gruauBalanceSteps
+
+	successes := 0
+	if balanced {
+		for _, start := range gruauGeneralizationStarts() {
+			trial := base
+			trial.maxSteps = gruauEpisodeSteps
+			trial.goalSteps = gruauEpisodeSteps
+			trial.initPosition = start.cartPosition
+			trial.initVelocity = start.cartVelocity
+			trial.initAngle1 = start.angle1
+			trial.initVelocity1 = start.velocity1
+			trial.initAngle2 = 0
+			_, trace, err := evaluatePole2Episode(ctx, agent, trial)
+			if err != nil {
+				return 0, nil, err
+			}
+			if goal, _ := trace["goal_reached"].(bool); goal {
+				successes++
+			}
+		}
+	}
+
+	fitness := float64(balanceSteps) / gruauBalanceSteps
+	if balanced {
+		fitness += float64(successes)
+	}
+	return Fitness(fitness), Trace{
+		"mode":                     "benchmark",
+		"fitness_variant":          Pole2FitnessGruau,
+		"balance_steps":            balanceSteps,
+		"balanced_100k":            balanced,
+		"generalization_successes": successes,
+		"generalization_trials":    gruauGeneralizationTrials,
+		"solved":                   balanced && successes >= gruauSolvedSuccesses,
+	}, nil
+}
+
+// Report formats a Gruau benchmark trace like the NEAT and ESP double pole
+// tables: the 100,000-step balance test, the generalization score out of 625
+// and whether the genome counts as a solution.
+func (s Pole2BalancingScape) Report(mode string, trace Trace) (string, bool) {
+	if !s.gruau() || mode != "benchmark" {
+		return "", false
+	}
+	balanced, _ := trace["balanced_100k"].(bool)
+	balanceSteps, _ := trace["balance_steps"].(int)
+	successes, _ := trace["generalization_successes"].(int)
+	solved, _ := trace["solved"].(bool)
+	return fmt.Sprintf("pole2_gruau task=dpnv balanced_100k=%t balance_steps=%d generalization=%d/%d solved=%t",
+		balanced,
+		balanceSteps,
+		successes,
+		gruauGeneralizationTrials,
+		solved,
+	), true
+}
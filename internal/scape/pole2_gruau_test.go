@@ -0,0 +1,139 @@
+package scape
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestPole2FactoryBuildsGruauVariant(t *testing.T) {
+	built, err := NewFromFactory("pole2-balancing", map[string]string{"fitness": "Gruau"})
+	if err != nil {
+		t.Fatalf("build gruau scape: %v", err)
+	}
+	s, ok := built.(Pole2BalancingScape)
+	if !ok || !s.gruau() {
+		t.Fatalf("expected gruau pole2 scape, got %#v", built)
+	}
+	if params := s.TunableParams(); params[0].Default != gruauEpisodeSteps {
+		t.Fatalf("expected gruau max_steps default %d, got %+v", gruauEpisodeSteps, params)
+	}
+	if params := (Pole2BalancingScape{}).TunableParams(); params[0].Default != 100000 {
+		t.Fatalf("expected default max_steps 100000, got %+v", params)
+	}
+	if _, err := NewFromFactory("pole2-balancing", map[string]string{"fitness": "wiggle"}); err == nil {
+		t.Fatal("expected unknown fitness variant to be rejected")
+	}
+	if _, err := NewFromFactory("pole2-balancing", map[string]string{"steps": "10"}); err == nil {
+		t.Fatal("expected unknown parameter to be rejected")
+	}
+}
+
+func TestPole2GruauHidesVelocitiesAndScoresShortEpisodes(t *testing.T) {
+	var inputs [][]float64
+	agent := scriptedStepAgent{id: "idle", fn: func(input []float64) []float64 {
+		inputs = append(inputs, append([]float64(nil), input...))
+		return []float64{0}
+	}}
+
+	fitness, trace, err := Pole2BalancingScape{Fitness: Pole2FitnessGruau}.Evaluate(context.Background(), agent)
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	steps, _ := trace["steps_survived"].(int)
+	if steps <= 0 || steps >= gruauWiggleWindow {
+		t.Fatalf("expected an idle controller to drop the poles early, survived %d", steps)
+	}
+	if trace["fitness_variant"] != Pole2FitnessGruau || trace["max_steps"] != gruauEpisodeSteps {
+		t.Fatalf("unexpected gruau trace: %+v", trace)
+	}
+	if init, _ := trace["init_angle1"].(float64); math.Abs(init-gruauStandardAngle1) > 1e-12 {
+		t.Fatalf("expected the standard 4.5 degree start, got %f", init)
+	}
+	// Before step 100 only the survival term counts.
+	if want := 0.1 * float64(steps) / gruauEpisodeSteps; math.Abs(float64(fitness)-want) > 1e-12 {
+		t.Fatalf("expected fitness %f, got %f", want, fitness)
+	}
+	for i, in := range inputs {
+		if in[1] != 0 || in[3] != 0 || in[5] != 0 {
+			t.Fatalf("step %d: expected hidden velocities, got %v", i, in)
+		}
+	}
+	if len(inputs) > 1 && inputs[len(inputs)-1][2] == inputs[0][2] {
+		t.Fatal("expected observed long pole angle to change")
+	}
+}
+
+func TestPole2GruauFitnessRewardsLowWiggle(t *testing.T) {
+	calm := gruauFitness(pole2EpisodeResult{stepsSurvived: 1000, wiggle: 5})
+	wiggly := gruauFitness(pole2EpisodeResult{stepsSurvived: 1000, wiggle: 50})
+	if want := 0.1 + 0.9*0.75/5; math.Abs(float64(calm)-want) > 1e-12 {
+		t.Fatalf("expected fitness %f, got %f", want, calm)
+	}
+	if calm <= wiggly {
+		t.Fatalf("expected lower wiggle to score higher: calm=%f wiggly=%f", calm, wiggly)
+	}
+
+	var wiggles []float64
+	for i := 0; i < 150; i++ {
+		wiggles = appendGruauWiggle(wiggles, pole2State{cartPosition: float64(i)})
+	}
+	if len(wiggles) != gruauWiggleWindow || wiggles[0] != 50 || wiggles[len(wiggles)-1] != 149 {
+		t.Fatalf("expected the last %d steps in the window, got len=%d first=%f last=%f", gruauWiggleWindow, len(wiggles), wiggles[0], wiggles[len(wiggles)-1])
+	}
+}
+
+func TestPole2GruauGeneralizationStarts(t *testing.T) {
+	starts := gruauGeneralizationStarts()
+	if len(starts) != gruauGeneralizationTrials {
+		t.Fatalf("expected %d starts, got %d", gruauGeneralizationTrials, len(starts))
+	}
+	seen := map[pole2State]bool{}
+	rad := math.Pi / 180
+	for _, start := range starts {
+		if math.Abs(start.cartPosition) > 2.16 || math.Abs(start.cartVelocity) > 1.35 ||
+			math.Abs(start.angle1) > 3.6*rad || math.Abs(start.velocity1) > 8.6*rad {
+			t.Fatalf("start outside the generalization ranges: %+v", start)
+		}
+		if start.angle2 != 0 || start.velocity2 != 0 {
+			t.Fatalf("expected the short pole at rest, got %+v", start)
+		}
+		seen[start] = true
+	}
+	if len(seen) != len(starts) {
+		t.Fatalf("expected distinct starts, got %d unique", len(seen))
+	}
+}
+
+func TestPole2GruauBenchmarkReport(t *testing.T) {
+	s := Pole2BalancingScape{Fitness: Pole2FitnessGruau}
+	agent := scriptedStepAgent{id: "idle", fn: func([]float64) []float64 { return []float64{0} }}
+	fitness, trace, err := s.EvaluateMode(context.Background(), agent, "benchmark")
+	if err != nil {
+		t.Fatalf("benchmark: %v", err)
+	}
+	if balanced, _ := trace["balanced_100k"].(bool); balanced || fitness >= 1 {
+		t.Fatalf("expected an idle controller to fail the balance test, fitness=%f trace=%+v", fitness, trace)
+	}
+	report, ok := s.Report("benchmark", trace)
+	if !ok || !strings.Contains(report, "balanced_100k=false") || !strings.Contains(report, "generalization=0/625") {
+		t.Fatalf("unexpected report %q", report)
+	}
+
+	report, ok = s.Report("benchmark", Trace{
+		"balanced_100k":            true,
+		"balance_steps":            100000,
+		"generalization_successes": 286,
+		"solved":                   true,
+	})
+	if !ok || report != "pole2_gruau task=dpnv balanced_100k=true balance_steps=100000 generalization=286/625 solved=true" {
+		t.Fatalf("unexpected solved report %q", report)
+	}
+	if _, ok := s.Report("gt", trace); ok {
+		t.Fatal("expected no report outside benchmark mode")
+	}
+	if _, ok := (Pole2BalancingScape{}).Report("benchmark", trace); ok {
+		t.Fatal("expected no report for the default fitness variant")
+	}
+}
@@ -0,0 +1,145 @@
+package scape
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// StatefulScape is implemented by scapes whose instances keep state across
+// an evaluation (a simulator, an open environment handle) and so cannot
+// evaluate two agents at once. NewInstance builds an independent instance;
+// runs evaluate on a pool of them instead of on the registered scape.
+type StatefulScape interface {
+	Scape
+	NewInstance() (Scape, error)
+}
+
+// ScapePool holds pre-initialized instances of a stateful scape, one per
+// concurrent evaluation. Evaluations check an instance out and check it back
+// in when done; a checkout waits while every instance is busy, and the pool
+// reports the total time spent waiting.
+type ScapePool struct {
+	template  StatefulScape
+	size      int
+	idle      chan Scape
+	mu        sync.Mutex
+	instances []Scape
+	started   bool
+	closed    bool
+	wait      time.Duration
+	checkouts int
+}
+
+// NewScapePool sizes a pool of template's instances; Start builds them.
+func NewScapePool(template StatefulScape, size int) (*ScapePool, error) {
+	if template == nil {
+		return nil, errors.New("pooled scape is required")
+	}
+	if size <= 0 {
+		return nil, errors.New("scape pool size must be > 0")
+	}
+	return &ScapePool{template: template, size: size, idle: make(chan Scape, size)}, nil
+}
+
+func (p *ScapePool) Name() string {
+	return p.template.Name()
+}
+
+func (p *ScapePool) Size() int {
+	return p.size
+}
+
+// Start builds the pool's instances and runs their Init and Warmup hooks.
+// Starting a started pool does nothing; on failure the instances built so
+// far are closed.
+func (p *ScapePool) Start(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return fmt.Errorf("scape pool %s is closed", p.Name())
+	}
+	if p.started {
+		return nil
+	}
+	instances := make([]Scape, 0, p.size)
+	for i := 0; i < p.size; i++ {
+		instance, err := p.template.NewInstance()
+		if err == nil {
+			err = InitScape(ctx, instance)
+		}
+		if err != nil {
+			for _, built := range instances {
+				_ = CloseScape(built)
+			}
+			return fmt.Errorf("start scape pool %s instance %d: %w", p.Name(), i, err)
+		}
+		instances = append(instances, instance)
+	}
+	for _, instance := range instances {
+		p.idle <- instance
+	}
+	p.instances = instances
+	p.started = true
+	return nil
+}
+
+// Checkout returns an idle instance, waiting for one while all are busy.
+func (p *ScapePool) Checkout(ctx context.Context) (Scape, error) {
+	p.mu.Lock()
+	started, closed := p.started, p.closed
+	p.mu.Unlock()
+	if closed {
+		return nil, fmt.Errorf("scape pool %s is closed", p.Name())
+	}
+	if !started {
+		return nil, fmt.Errorf("scape pool %s is not started", p.Name())
+	}
+
+	began := time.Now()
+	var instance Scape
+	select {
+	case instance = <-p.idle:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	p.mu.Lock()
+	p.wait += time.Since(began)
+	p.checkouts++
+	p.mu.Unlock()
+	return instance, nil
+}
+
+// Checkin returns an instance taken by Checkout.
+func (p *ScapePool) Checkin(instance Scape) {
+	p.idle <- instance
+}
+
+// Wait returns the total time checkouts spent waiting and their count.
+func (p *ScapePool) Wait() (time.Duration, int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.wait, p.checkouts
+}
+
+// Close runs the Close hook of every instance once they are all checked in.
+func (p *ScapePool) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	instances := p.instances
+	p.mu.Unlock()
+
+	var errs []error
+	for range instances {
+		if err := CloseScape(<-p.idle); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,110 @@
+package scape
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type countingStatefulScape struct {
+	built  *atomic.Int32
+	closed *atomic.Int32
+	busy   atomic.Bool
+	warm   bool
+}
+
+func (s *countingStatefulScape) Name() string { return "counting-stateful" }
+
+func (s *countingStatefulScape) Evaluate(context.Context, Agent) (Fitness, Trace, error) {
+	if !s.warm {
+		return 0, nil, errors.New("instance evaluated before warmup")
+	}
+	if !s.busy.CompareAndSwap(false, true) {
+		return 0, nil, errors.New("instance evaluated concurrently")
+	}
+	defer s.busy.Store(false)
+	time.Sleep(2 * time.Millisecond)
+	return 1, nil, nil
+}
+
+func (s *countingStatefulScape) NewInstance() (Scape, error) {
+	s.built.Add(1)
+	return &countingStatefulScape{built: s.built, closed: s.closed}, nil
+}
+
+func (s *countingStatefulScape) Init(context.Context) error { return nil }
+
+func (s *countingStatefulScape) Warmup(context.Context) error {
+	s.warm = true
+	return nil
+}
+
+func (s *countingStatefulScape) Close() error {
+	s.closed.Add(1)
+	return nil
+}
+
+func TestScapePoolChecksOutStartedInstances(t *testing.T) {
+	template := &countingStatefulScape{built: &atomic.Int32{}, closed: &atomic.Int32{}}
+	pool, err := NewScapePool(template, 2)
+	if err != nil {
+		t.Fatalf("new pool: %v", err)
+	}
+	if _, err := pool.Checkout(context.Background()); err == nil {
+		t.Fatal("expected checkout before start to fail")
+	}
+	if err := pool.Start(context.Background()); err != nil {
+		t.Fatalf("start pool: %v", err)
+	}
+	if got := template.built.Load(); got != 2 {
+		t.Fatalf("expected two instances, got %d", got)
+	}
+
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		go func() {
+			instance, err := pool.Checkout(context.Background())
+			if err == nil {
+				_, _, err = instance.Evaluate(context.Background(), nil)
+				pool.Checkin(instance)
+			}
+			errs <- err
+		}()
+	}
+	for i := 0; i < 8; i++ {
+		if err := <-errs; err != nil {
+			t.Fatalf("pooled evaluation: %v", err)
+		}
+	}
+	if wait, checkouts := pool.Wait(); checkouts != 8 || wait <= 0 {
+		t.Fatalf("expected eight checkouts with some waiting, got checkouts=%d wait=%s", checkouts, wait)
+	}
+
+	held, err := pool.Checkout(context.Background())
+	if err != nil {
+		t.Fatalf("checkout: %v", err)
+	}
+	other, err := pool.Checkout(context.Background())
+	if err != nil {
+		t.Fatalf("checkout: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	if _, err := pool.Checkout(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected an exhausted pool to wait for the context, got %v", err)
+	}
+	pool.Checkin(held)
+	pool.Checkin(other)
+
+	if err := pool.Close(); err != nil {
+		t.Fatalf("close pool: %v", err)
+	}
+	if got := template.closed.Load(); got != 2 {
+		t.Fatalf("expected both instances closed, got %d", got)
+	}
+	if _, err := pool.Checkout(context.Background()); err == nil {
+		t.Fatal("expected checkout after close to fail")
+	}
+}
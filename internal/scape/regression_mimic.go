@@ -39,6 +39,34 @@ func (RegressionMimicScape) EvaluateMode(ctx context.Context, agent Agent, mode
 	return evaluateRegressionMimicWithStep(ctx, runner, cfg)
 }
 
+func (RegressionMimicScape) EvaluateBatch(ctx context.Context, agent BatchAgent, mode string) (Fitness, Trace, error) {
+	cfg, err := regressionConfigForMode(mode)
+	if err != nil {
+		return 0, nil, err
+	}
+	rows := make([][]float64, len(cfg.inputs))
+	for i, x := range cfg.inputs {
+		rows[i] = []float64{x}
+	}
+	outputs, err := runBatchRows(ctx, agent, rows)
+	if err != nil {
+		return 0, nil, err
+	}
+	next := 0
+	return evaluateRegressionMimic(
+		ctx,
+		cfg,
+		func(context.Context, float64) (float64, error) {
+			out := outputs[next]
+			next++
+			if len(out) != 1 {
+				return 0, fmt.Errorf("regression-mimic requires one output, got %d", len(out))
+			}
+			return out[0], nil
+		},
+	)
+}
+
 type regressionModeConfig struct {
 	mode   string
 	inputs []float64
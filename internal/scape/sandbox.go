@@ -0,0 +1,700 @@
+package scape
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Sandboxed scapes run in a worker subprocess and talk to the engine over a
+// line-delimited JSON protocol on the worker's stdin and stdout:
+//
+//	engine -> worker  {"type":"init","scape":...,"scape_params":...,"sources":...,"cpu_seconds":...,"memory_mb":...}
+//	worker -> engine  {"type":"ready"} or {"type":"error","error":...}
+//	engine -> worker  {"type":"evaluate","mode":...,"agent_id":...,"batch":...,"params":...}
+//	worker -> engine  {"type":"step","values":[...]} or {"type":"batch","rows":[[...]]}
+//	engine -> worker  {"type":"output","values":[...]} / {"type":"output","rows":[[...]]} or {"type":"error","error":...}
+//	worker -> engine  {"type":"result","fitness":...,"trace":{...},"error":...}
+//
+// The agent stays in the engine; the worker drives it through step and batch
+// messages, so scapes run in a sandbox see a StepAgent (and a BatchAgent for
+// batch evaluations), never a TickAgent. Non-finite numbers are encoded as the
+// strings "NaN", "+Inf" and "-Inf". Closing the worker's stdin stops it.
+const (
+	sandboxMsgInit     = "init"
+	sandboxMsgReady    = "ready"
+	sandboxMsgEvaluate = "evaluate"
+	sandboxMsgStep     = "step"
+	sandboxMsgBatch    = "batch"
+	sandboxMsgOutput   = "output"
+	sandboxMsgResult   = "result"
+	sandboxMsgError    = "error"
+)
+
+// sandboxStderrTail bounds the worker stderr kept for failure traces.
+const sandboxStderrTail = 4096
+
+type sandboxMessage struct {
+	Type        string             `json:"type"`
+	Scape       string             `json:"scape,omitempty"`
+	ScapeParams map[string]string  `json:"scape_params,omitempty"`
+	Sources     *DataSources       `json:"sources,omitempty"`
+	CPUSeconds  int                `json:"cpu_seconds,omitempty"`
+	MemoryMB    int                `json:"memory_mb,omitempty"`
+	Mode        string             `json:"mode,omitempty"`
+	AgentID     string             `json:"agent_id,omitempty"`
+	Batch       bool               `json:"batch,omitempty"`
+	Params      map[string]float64 `json:"params,omitempty"`
+	Values      []wireFloat        `json:"values,omitempty"`
+	Rows        [][]wireFloat      `json:"rows,omitempty"`
+	Fitness     wireFloat          `json:"fitness,omitempty"`
+	Trace       Trace              `json:"trace,omitempty"`
+	Error       string             `json:"error,omitempty"`
+}
+
+// wireFloat is a float64 that survives JSON when it is not finite.
+type wireFloat float64
+
+func (f wireFloat) MarshalJSON() ([]byte, error) {
+	v := float64(f)
+	switch {
+	case math.IsNaN(v):
+		return []byte(`"NaN"`), nil
+	case math.IsInf(v, 1):
+		return []byte(`"+Inf"`), nil
+	case math.IsInf(v, -1):
+		return []byte(`"-Inf"`), nil
+	}
+	return strconv.AppendFloat(nil, v, 'g', -1, 64), nil
+}
+
+func (f *wireFloat) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		v, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return fmt.Errorf("invalid wire float %q", text)
+		}
+		*f = wireFloat(v)
+		return nil
+	}
+	var v float64
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*f = wireFloat(v)
+	return nil
+}
+
+func toWireValues(values []float64) []wireFloat {
+	out := make([]wireFloat, len(values))
+	for i, v := range values {
+		out[i] = wireFloat(v)
+	}
+	return out
+}
+
+func fromWireValues(values []wireFloat) []float64 {
+	out := make([]float64, len(values))
+	for i, v := range values {
+		out[i] = float64(v)
+	}
+	return out
+}
+
+func toWireRows(rows [][]float64) [][]wireFloat {
+	out := make([][]wireFloat, len(rows))
+	for i, row := range rows {
+		out[i] = toWireValues(row)
+	}
+	return out
+}
+
+func fromWireRows(rows [][]wireFloat) [][]float64 {
+	out := make([][]float64, len(rows))
+	for i, row := range rows {
+		out[i] = fromWireValues(row)
+	}
+	return out
+}
+
+// SandboxConfig configures a SandboxScape.
+type SandboxConfig struct {
+	// Command is the worker argv. The worker must serve the protocol above,
+	// e.g. via ServeSandbox.
+	Command []string
+	// ScapeParams and Sources are passed to the worker to construct the
+	// scape and its data sources.
+	ScapeParams map[string]string
+	Sources     DataSources
+	// CPUSeconds and MemoryMB limit the worker per evaluation and overall;
+	// 0 disables a limit.
+	CPUSeconds int
+	MemoryMB   int
+	// Timeout bounds each evaluation; 0 disables it.
+	Timeout time.Duration
+	// FailureFitness scores evaluations that fail inside the sandbox.
+	FailureFitness float64
+}
+
+// SandboxScape evaluates a scape in worker subprocesses so a scape that
+// crashes, stalls or exhausts its limits cannot take the engine down. Such
+// evaluations, and evaluations the scape itself fails, score FailureFitness
+// with the failure in the trace's sandbox_error (and the worker's recent
+// stderr in sandbox_stderr). Workers are pooled, one per concurrent
+// evaluation, and replaced after a failure.
+type SandboxScape struct {
+	inner  Scape
+	cfg    SandboxConfig
+	mu     sync.Mutex
+	idle   []*sandboxWorker
+	closed bool
+}
+
+// NewSandboxScape sandboxes inner, which names the scape the workers build and
+// supplies its tunable parameters.
+func NewSandboxScape(inner Scape, cfg SandboxConfig) (*SandboxScape, error) {
+	if inner == nil {
+		return nil, errors.New("sandboxed scape is required")
+	}
+	if len(cfg.Command) == 0 || cfg.Command[0] == "" {
+		return nil, errors.New("sandbox command is required")
+	}
+	if cfg.CPUSeconds < 0 {
+		return nil, errors.New("sandbox cpu seconds must be >= 0")
+	}
+	if cfg.MemoryMB < 0 {
+		return nil, errors.New("sandbox memory limit must be >= 0")
+	}
+	if cfg.Timeout < 0 {
+		return nil, errors.New("sandbox timeout must be >= 0")
+	}
+	if math.IsNaN(cfg.FailureFitness) || math.IsInf(cfg.FailureFitness, 0) {
+		return nil, errors.New("sandbox failure fitness must be finite")
+	}
+	return &SandboxScape{inner: inner, cfg: cfg}, nil
+}
+
+func (s *SandboxScape) Name() string {
+	return s.inner.Name()
+}
+
+func (s *SandboxScape) TunableParams() []ScapeParam {
+	if tunable, ok := s.inner.(TunableScape); ok {
+		return tunable.TunableParams()
+	}
+	return nil
+}
+
+func (s *SandboxScape) Evaluate(ctx context.Context, agent Agent) (Fitness, Trace, error) {
+	return s.evaluate(ctx, agent, "", false)
+}
+
+func (s *SandboxScape) EvaluateMode(ctx context.Context, agent Agent, mode string) (Fitness, Trace, error) {
+	return s.evaluate(ctx, agent, mode, false)
+}
+
+// EvaluateBatch asks the worker for a batched evaluation; workers whose scape
+// is not a BatchScape fall back to stepping the agent.
+func (s *SandboxScape) EvaluateBatch(ctx context.Context, agent BatchAgent, mode string) (Fitness, Trace, error) {
+	return s.evaluate(ctx, agent, mode, true)
+}
+
+func (s *SandboxScape) Init(context.Context) error {
+	return nil
+}
+
+// Warmup starts a worker ahead of the first evaluation, so worker startup
+// stays out of the run's evaluation timings.
+func (s *SandboxScape) Warmup(ctx context.Context) error {
+	s.mu.Lock()
+	ready := s.closed || len(s.idle) > 0
+	s.mu.Unlock()
+	if ready {
+		return nil
+	}
+	worker, err := startSandboxWorker(ctx, s.inner.Name(), s.cfg)
+	if err != nil {
+		return err
+	}
+	s.release(worker)
+	return nil
+}
+
+// Close stops the idle workers and any worker released afterwards.
+func (s *SandboxScape) Close() error {
+	s.mu.Lock()
+	idle := s.idle
+	s.idle = nil
+	s.closed = true
+	s.mu.Unlock()
+	for _, worker := range idle {
+		worker.stop()
+	}
+	return nil
+}
+
+func (s *SandboxScape) evaluate(ctx context.Context, agent Agent, mode string, batch bool) (Fitness, Trace, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, nil, err
+	}
+	worker, err := s.acquire(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	fitness, trace, err := worker.evaluate(ctx, agent, mode, batch, s.cfg.Timeout)
+	var failure *sandboxFailure
+	switch {
+	case errors.As(err, &failure):
+		if failure.crashed {
+			worker.stop()
+		} else {
+			s.release(worker)
+		}
+		return Fitness(s.cfg.FailureFitness), failure.trace(worker), nil
+	case err != nil:
+		worker.stop()
+		return 0, nil, err
+	}
+	s.release(worker)
+	return fitness, trace, nil
+}
+
+func (s *SandboxScape) acquire(ctx context.Context) (*sandboxWorker, error) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("sandbox for scape %s is closed", s.inner.Name())
+	}
+	if n := len(s.idle); n > 0 {
+		worker := s.idle[n-1]
+		s.idle = s.idle[:n-1]
+		s.mu.Unlock()
+		return worker, nil
+	}
+	s.mu.Unlock()
+	return startSandboxWorker(ctx, s.inner.Name(), s.cfg)
+}
+
+func (s *SandboxScape) release(worker *sandboxWorker) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		worker.stop()
+		return
+	}
+	s.idle = append(s.idle, worker)
+	s.mu.Unlock()
+}
+
+// sandboxFailure is an evaluation failure scored at FailureFitness. crashed
+// failures leave the worker unusable.
+type sandboxFailure struct {
+	reason  string
+	crashed bool
+}
+
+func (f *sandboxFailure) Error() string {
+	return f.reason
+}
+
+func (f *sandboxFailure) trace(worker *sandboxWorker) Trace {
+	trace := Trace{"sandbox_error": f.reason}
+	if f.crashed {
+		if tail := worker.stderr.String(); tail != "" {
+			trace["sandbox_stderr"] = tail
+		}
+	}
+	return trace
+}
+
+type sandboxReply struct {
+	msg sandboxMessage
+	err error
+}
+
+type sandboxWorker struct {
+	cmd      *exec.Cmd
+	stdin    io.WriteCloser
+	enc      *json.Encoder
+	replies  chan sandboxReply
+	quit     chan struct{}
+	stderr   *tailBuffer
+	stopOnce sync.Once
+	waitErr  error
+}
+
+func startSandboxWorker(ctx context.Context, scapeName string, cfg SandboxConfig) (*sandboxWorker, error) {
+	// The worker outlives ctx, which only scopes the evaluation that needed it.
+	cmd := exec.Command(cfg.Command[0], cfg.Command[1:]...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	worker := &sandboxWorker{
+		cmd:     cmd,
+		stdin:   stdin,
+		enc:     json.NewEncoder(stdin),
+		replies: make(chan sandboxReply),
+		quit:    make(chan struct{}),
+		stderr:  &tailBuffer{limit: sandboxStderrTail},
+	}
+	cmd.Stderr = worker.stderr
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start scape sandbox %s: %w", cfg.Command[0], err)
+	}
+	go worker.read(stdout)
+
+	sources := cfg.Sources
+	init := sandboxMessage{
+		Type:        sandboxMsgInit,
+		Scape:       scapeName,
+		ScapeParams: cfg.ScapeParams,
+		Sources:     &sources,
+		CPUSeconds:  cfg.CPUSeconds,
+		MemoryMB:    cfg.MemoryMB,
+	}
+	if err := worker.enc.Encode(init); err != nil {
+		return nil, worker.startupError()
+	}
+	select {
+	case <-ctx.Done():
+		worker.stop()
+		return nil, ctx.Err()
+	case <-worker.quit:
+		return nil, worker.startupError()
+	case reply := <-worker.replies:
+		switch {
+		case reply.err != nil:
+			return nil, worker.startupError()
+		case reply.msg.Type == sandboxMsgError:
+			worker.stop()
+			return nil, fmt.Errorf("scape sandbox: %s", reply.msg.Error)
+		case reply.msg.Type != sandboxMsgReady:
+			worker.stop()
+			return nil, fmt.Errorf("scape sandbox: unexpected %q message during startup", reply.msg.Type)
+		}
+	}
+	return worker, nil
+}
+
+// startupError stops a worker that failed to start and reports its exit.
+func (w *sandboxWorker) startupError() error {
+	w.stop()
+	err := fmt.Errorf("scape sandbox %s exited during startup: %v", w.cmd.Path, w.waitErr)
+	if tail := strings.TrimSpace(w.stderr.String()); tail != "" {
+		err = fmt.Errorf("%w: %s", err, tail)
+	}
+	return err
+}
+
+func (w *sandboxWorker) read(stdout io.Reader) {
+	dec := json.NewDecoder(stdout)
+	for {
+		var msg sandboxMessage
+		err := dec.Decode(&msg)
+		if err != nil {
+			// Reap the worker before reporting so the reply carries its exit.
+			w.stop()
+		}
+		select {
+		case w.replies <- sandboxReply{msg: msg, err: err}:
+		case <-w.quit:
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// stop kills the worker and records how it exited.
+func (w *sandboxWorker) stop() {
+	w.stopOnce.Do(func() {
+		_ = w.stdin.Close()
+		_ = w.cmd.Process.Kill()
+		w.waitErr = w.cmd.Wait()
+		close(w.quit)
+	})
+}
+
+func (w *sandboxWorker) evaluate(ctx context.Context, agent Agent, mode string, batch bool, timeout time.Duration) (Fitness, Trace, error) {
+	request := sandboxMessage{
+		Type:    sandboxMsgEvaluate,
+		Mode:    mode,
+		AgentID: agent.ID(),
+		Batch:   batch,
+		Params:  ScapeParamsFromContext(ctx),
+	}
+	if err := w.enc.Encode(request); err != nil {
+		return 0, nil, w.crashed(fmt.Sprintf("send evaluation: %v", err))
+	}
+	var deadline <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	var agentErr error
+	for {
+		select {
+		case <-ctx.Done():
+			return 0, nil, ctx.Err()
+		case <-deadline:
+			return 0, nil, &sandboxFailure{reason: fmt.Sprintf("evaluation stalled after %s", timeout), crashed: true}
+		case <-w.quit:
+			return 0, nil, w.crashed("scape worker exited")
+		case reply := <-w.replies:
+			if reply.err != nil {
+				return 0, nil, w.crashed("scape worker exited")
+			}
+			msg := reply.msg
+			switch msg.Type {
+			case sandboxMsgStep:
+				response := sandboxMessage{Type: sandboxMsgOutput}
+				runner, ok := agent.(StepAgent)
+				if !ok {
+					agentErr = fmt.Errorf("agent %s does not implement step runner", agent.ID())
+				} else if output, err := runner.RunStep(ctx, fromWireValues(msg.Values)); err != nil {
+					agentErr = err
+				} else {
+					response.Values = toWireValues(output)
+				}
+				if agentErr != nil {
+					response = sandboxMessage{Type: sandboxMsgError, Error: agentErr.Error()}
+				}
+				if err := w.enc.Encode(response); err != nil {
+					return 0, nil, w.crashed(fmt.Sprintf("send step output: %v", err))
+				}
+			case sandboxMsgBatch:
+				response := sandboxMessage{Type: sandboxMsgOutput}
+				runner, ok := agent.(BatchAgent)
+				if !ok {
+					agentErr = fmt.Errorf("agent %s does not implement batch runner", agent.ID())
+				} else if outputs, err := runner.RunBatch(ctx, fromWireRows(msg.Rows)); err != nil {
+					agentErr = err
+				} else {
+					response.Rows = toWireRows(outputs)
+				}
+				if agentErr != nil {
+					response = sandboxMessage{Type: sandboxMsgError, Error: agentErr.Error()}
+				}
+				if err := w.enc.Encode(response); err != nil {
+					return 0, nil, w.crashed(fmt.Sprintf("send batch output: %v", err))
+				}
+			case sandboxMsgResult:
+				// Agent failures are the engine's, not the scape's.
+				if agentErr != nil {
+					return 0, nil, agentErr
+				}
+				if msg.Error != "" {
+					return 0, nil, &sandboxFailure{reason: msg.Error}
+				}
+				return Fitness(msg.Fitness), msg.Trace, nil
+			default:
+				return 0, nil, w.crashed(fmt.Sprintf("unexpected %q message", msg.Type))
+			}
+		}
+	}
+}
+
+// crashed stops the worker and describes the failure with its exit status.
+func (w *sandboxWorker) crashed(reason string) *sandboxFailure {
+	w.stop()
+	if w.waitErr != nil {
+		reason = fmt.Sprintf("%s: %v", reason, w.waitErr)
+	}
+	return &sandboxFailure{reason: reason, crashed: true}
+}
+
+// tailBuffer keeps the last limit bytes written to it.
+type tailBuffer struct {
+	mu    sync.Mutex
+	limit int
+	data  []byte
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.data = append(b.data, p...)
+	if over := len(b.data) - b.limit; over > 0 {
+		b.data = append(b.data[:0], b.data[over:]...)
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.data)
+}
+
+// SandboxInit is what a sandbox worker is asked to build.
+type SandboxInit struct {
+	Scape   string
+	Params  map[string]string
+	Sources DataSources
+}
+
+// SandboxResolver builds the scape a worker serves, returning the context its
+// evaluations run under (e.g. one carrying the data sources).
+type SandboxResolver func(ctx context.Context, init SandboxInit) (context.Context, Scape, error)
+
+// ServeSandbox runs the worker side of the sandbox protocol on in and out
+// until in is closed. It applies the requested resource limits to the whole
+// process, so it must own the process it runs in: a worker exceeding its CPU
+// limit exits.
+func ServeSandbox(ctx context.Context, in io.Reader, out io.Writer, resolve SandboxResolver) error {
+	dec := json.NewDecoder(in)
+	enc := json.NewEncoder(out)
+	var init sandboxMessage
+	if err := dec.Decode(&init); err != nil {
+		return fmt.Errorf("read sandbox init: %w", err)
+	}
+	fail := func(err error) error {
+		_ = enc.Encode(sandboxMessage{Type: sandboxMsgError, Error: err.Error()})
+		return err
+	}
+	if init.Type != sandboxMsgInit {
+		return fail(fmt.Errorf("expected %q message, got %q", sandboxMsgInit, init.Type))
+	}
+	if err := applySandboxLimits(init.CPUSeconds, init.MemoryMB); err != nil {
+		return fail(fmt.Errorf("apply sandbox limits: %w", err))
+	}
+	request := SandboxInit{Scape: init.Scape, Params: init.ScapeParams}
+	if init.Sources != nil {
+		request.Sources = *init.Sources
+	}
+	evalCtx, target, err := resolve(ctx, request)
+	if err != nil {
+		return fail(err)
+	}
+	if err := enc.Encode(sandboxMessage{Type: sandboxMsgReady}); err != nil {
+		return err
+	}
+
+	session := &sandboxSession{dec: dec, enc: enc}
+	for {
+		var msg sandboxMessage
+		if err := dec.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("read sandbox request: %w", err)
+		}
+		if msg.Type != sandboxMsgEvaluate {
+			return fail(fmt.Errorf("expected %q message, got %q", sandboxMsgEvaluate, msg.Type))
+		}
+		result := sandboxMessage{Type: sandboxMsgResult}
+		if err := limitSandboxCPU(init.CPUSeconds); err != nil {
+			result.Error = fmt.Sprintf("limit sandbox cpu: %v", err)
+		} else {
+			fitness, trace, err := session.evaluate(evalCtx, target, msg)
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Fitness = wireFloat(fitness)
+				result.Trace = trace
+			}
+		}
+		if _, err := json.Marshal(result.Trace); err != nil {
+			result.Trace = Trace{"sandbox_trace_error": err.Error()}
+		}
+		if err := enc.Encode(result); err != nil {
+			return err
+		}
+	}
+}
+
+type sandboxSession struct {
+	dec *json.Decoder
+	enc *json.Encoder
+}
+
+func (s *sandboxSession) evaluate(ctx context.Context, target Scape, msg sandboxMessage) (fitness Fitness, trace Trace, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			fitness, trace, err = 0, nil, fmt.Errorf("scape %s panicked: %v", target.Name(), r)
+		}
+	}()
+	if len(msg.Params) > 0 {
+		ctx = WithScapeParams(ctx, msg.Params)
+	}
+	agent := &sandboxAgent{id: msg.AgentID, session: s}
+	if msg.Batch {
+		if batchScape, ok := target.(BatchScape); ok {
+			return batchScape.EvaluateBatch(ctx, &sandboxBatchAgent{agent}, msg.Mode)
+		}
+	}
+	if msg.Mode != "" {
+		if modeAware, ok := target.(ModeAwareScape); ok {
+			return modeAware.EvaluateMode(ctx, agent, msg.Mode)
+		}
+	}
+	return target.Evaluate(ctx, agent)
+}
+
+// call sends request to the engine and waits for its output.
+func (s *sandboxSession) call(request sandboxMessage) (sandboxMessage, error) {
+	if err := s.enc.Encode(request); err != nil {
+		return sandboxMessage{}, err
+	}
+	var reply sandboxMessage
+	if err := s.dec.Decode(&reply); err != nil {
+		return sandboxMessage{}, err
+	}
+	switch reply.Type {
+	case sandboxMsgOutput:
+		return reply, nil
+	case sandboxMsgError:
+		return sandboxMessage{}, errors.New(reply.Error)
+	default:
+		return sandboxMessage{}, fmt.Errorf("expected %q message, got %q", sandboxMsgOutput, reply.Type)
+	}
+}
+
+// sandboxAgent stands in for the engine's agent inside a worker.
+type sandboxAgent struct {
+	id      string
+	session *sandboxSession
+}
+
+func (a *sandboxAgent) ID() string {
+	return a.id
+}
+
+func (a *sandboxAgent) RunStep(_ context.Context, input []float64) ([]float64, error) {
+	reply, err := a.session.call(sandboxMessage{Type: sandboxMsgStep, Values: toWireValues(input)})
+	if err != nil {
+		return nil, err
+	}
+	return fromWireValues(reply.Values), nil
+}
+
+type sandboxBatchAgent struct {
+	*sandboxAgent
+}
+
+func (a *sandboxBatchAgent) RunBatch(_ context.Context, rows [][]float64) ([][]float64, error) {
+	reply, err := a.session.call(sandboxMessage{Type: sandboxMsgBatch, Rows: toWireRows(rows)})
+	if err != nil {
+		return nil, err
+	}
+	return fromWireRows(reply.Rows), nil
+}
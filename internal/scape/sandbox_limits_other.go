@@ -0,0 +1,19 @@
+//go:build !(linux || darwin)
+
+package scape
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func applySandboxLimits(cpuSeconds, memoryMB int) error {
+	if cpuSeconds > 0 || memoryMB > 0 {
+		return fmt.Errorf("sandbox resource limits are not supported on %s", runtime.GOOS)
+	}
+	return nil
+}
+
+func limitSandboxCPU(int) error {
+	return nil
+}
@@ -0,0 +1,60 @@
+//go:build linux || darwin
+
+package scape
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"syscall"
+)
+
+// sandboxExitCPULimit is the exit status of a worker that ran out of CPU.
+const sandboxExitCPULimit = 3
+
+// applySandboxLimits caps the worker's data segment at memoryMB and makes it
+// exit once it exceeds a CPU limit set by limitSandboxCPU.
+func applySandboxLimits(cpuSeconds, memoryMB int) error {
+	if memoryMB > 0 {
+		limit := uint64(memoryMB) << 20
+		debug.SetMemoryLimit(int64(limit))
+		if err := syscall.Setrlimit(syscall.RLIMIT_DATA, &syscall.Rlimit{Cur: limit, Max: limit}); err != nil {
+			return err
+		}
+	}
+	if cpuSeconds > 0 {
+		// The Go runtime ignores SIGXCPU unless asked for it.
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGXCPU)
+		go func() {
+			<-signals
+			fmt.Fprintln(os.Stderr, "scape sandbox: cpu limit exceeded")
+			os.Exit(sandboxExitCPULimit)
+		}()
+	}
+	return nil
+}
+
+// limitSandboxCPU allows the worker seconds of CPU time beyond what it has
+// used so far, so the limit applies to the evaluation about to start.
+func limitSandboxCPU(seconds int) error {
+	if seconds <= 0 {
+		return nil
+	}
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return err
+	}
+	var current syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_CPU, &current); err != nil {
+		return err
+	}
+	// Round the partial second used so far up.
+	used := uint64(usage.Utime.Sec) + uint64(usage.Stime.Sec) + 1
+	soft := used + uint64(seconds)
+	if soft > current.Max {
+		soft = current.Max
+	}
+	return syscall.Setrlimit(syscall.RLIMIT_CPU, &syscall.Rlimit{Cur: soft, Max: current.Max})
+}
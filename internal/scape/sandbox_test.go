@@ -0,0 +1,223 @@
+package scape
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"protogonos/internal/agent"
+	"protogonos/internal/model"
+)
+
+// TestSandboxWorkerProcess is the worker the sandbox tests spawn; it is
+// skipped unless run as one.
+func TestSandboxWorkerProcess(t *testing.T) {
+	if os.Getenv("PROTOGONOS_SANDBOX_WORKER") != "1" {
+		t.Skip("sandbox worker helper process")
+	}
+	resolve := func(ctx context.Context, init SandboxInit) (context.Context, Scape, error) {
+		if init.Scape == "xor" {
+			return ctx, XORScape{}, nil
+		}
+		return ctx, sandboxTestScape{name: init.Scape}, nil
+	}
+	if err := ServeSandbox(context.Background(), os.Stdin, os.Stdout, resolve); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// sandboxTestScape misbehaves the way its name says.
+type sandboxTestScape struct {
+	name string
+}
+
+func (s sandboxTestScape) Name() string {
+	return s.name
+}
+
+func (s sandboxTestScape) Evaluate(context.Context, Agent) (Fitness, Trace, error) {
+	switch s.name {
+	case "crash":
+		fmt.Fprintln(os.Stderr, "scape exploded")
+		os.Exit(7)
+	case "stall":
+		time.Sleep(time.Hour)
+	case "spin":
+		for i := 0; ; i++ {
+			_ = math.Sqrt(float64(i))
+		}
+	case "fail":
+		return 0, nil, errors.New("scape rejected agent")
+	}
+	return 0, nil, fmt.Errorf("unknown test scape %s", s.name)
+}
+
+func newTestSandbox(t *testing.T, inner Scape, cfg SandboxConfig) *SandboxScape {
+	t.Helper()
+	t.Setenv("PROTOGONOS_SANDBOX_WORKER", "1")
+	cfg.Command = []string{os.Args[0], "-test.run=^TestSandboxWorkerProcess$"}
+	sandbox, err := NewSandboxScape(inner, cfg)
+	if err != nil {
+		t.Fatalf("new sandbox scape: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = sandbox.Close()
+	})
+	return sandbox
+}
+
+func newXORTestCortex(t *testing.T) *agent.Cortex {
+	t.Helper()
+	genome := model.Genome{
+		Neurons: []model.Neuron{
+			{ID: "i1", Activation: "identity"},
+			{ID: "i2", Activation: "identity"},
+			{ID: "h1", Activation: "sigmoid", Bias: -10},
+			{ID: "h2", Activation: "sigmoid", Bias: 30},
+			{ID: "o", Activation: "sigmoid", Bias: -30},
+		},
+		Synapses: []model.Synapse{
+			{From: "i1", To: "h1", Weight: 20, Enabled: true},
+			{From: "i2", To: "h1", Weight: 20, Enabled: true},
+			{From: "i1", To: "h2", Weight: -20, Enabled: true},
+			{From: "i2", To: "h2", Weight: -20, Enabled: true},
+			{From: "h1", To: "o", Weight: 20, Enabled: true},
+			{From: "h2", To: "o", Weight: 20, Enabled: true},
+		},
+	}
+	cortex, err := agent.NewCortex("xor-agent", genome, nil, nil, []string{"i1", "i2"}, []string{"o"}, nil)
+	if err != nil {
+		t.Fatalf("new cortex: %v", err)
+	}
+	return cortex
+}
+
+func TestSandboxScapeMatchesInProcessEvaluation(t *testing.T) {
+	cortex := newXORTestCortex(t)
+	want, wantTrace, err := XORScape{}.EvaluateMode(context.Background(), cortex, "validation")
+	if err != nil {
+		t.Fatalf("evaluate in process: %v", err)
+	}
+	sandbox := newTestSandbox(t, XORScape{}, SandboxConfig{})
+
+	got, trace, err := sandbox.EvaluateMode(context.Background(), cortex, "validation")
+	if err != nil {
+		t.Fatalf("evaluate in sandbox: %v", err)
+	}
+	if got != want || trace["mse"] != wantTrace["mse"] {
+		t.Fatalf("sandboxed evaluation diverged: got=%f trace=%v want=%f trace=%v", got, trace, want, wantTrace)
+	}
+	batched, _, err := sandbox.EvaluateBatch(context.Background(), cortex, "validation")
+	if err != nil {
+		t.Fatalf("evaluate batch in sandbox: %v", err)
+	}
+	if math.Abs(float64(batched-want)) > 1e-9 {
+		t.Fatalf("sandboxed batch evaluation diverged: got=%f want=%f", batched, want)
+	}
+	if len(sandbox.idle) != 1 {
+		t.Fatalf("expected one pooled worker reused across evaluations, got %d", len(sandbox.idle))
+	}
+}
+
+func TestSandboxScapeCrashScoresFailureFitness(t *testing.T) {
+	sandbox := newTestSandbox(t, sandboxTestScape{name: "crash"}, SandboxConfig{FailureFitness: -1})
+
+	fitness, trace, err := sandbox.Evaluate(context.Background(), newXORTestCortex(t))
+	if err != nil {
+		t.Fatalf("expected a crash to be scored, got error: %v", err)
+	}
+	if fitness != -1 {
+		t.Fatalf("expected failure fitness -1, got %f", fitness)
+	}
+	if reason, _ := trace["sandbox_error"].(string); !strings.Contains(reason, "exit status 7") {
+		t.Fatalf("expected exit status in sandbox_error, got %v", trace)
+	}
+	if stderr, _ := trace["sandbox_stderr"].(string); !strings.Contains(stderr, "scape exploded") {
+		t.Fatalf("expected worker stderr in trace, got %v", trace)
+	}
+	if len(sandbox.idle) != 0 {
+		t.Fatalf("expected crashed worker to be discarded, got %d idle", len(sandbox.idle))
+	}
+}
+
+func TestSandboxScapeTimeoutKillsStalledWorker(t *testing.T) {
+	sandbox := newTestSandbox(t, sandboxTestScape{name: "stall"}, SandboxConfig{Timeout: 200 * time.Millisecond})
+
+	fitness, trace, err := sandbox.Evaluate(context.Background(), newXORTestCortex(t))
+	if err != nil {
+		t.Fatalf("expected a stall to be scored, got error: %v", err)
+	}
+	if fitness != 0 {
+		t.Fatalf("expected default failure fitness 0, got %f", fitness)
+	}
+	if reason, _ := trace["sandbox_error"].(string); !strings.Contains(reason, "stalled") {
+		t.Fatalf("expected stall in sandbox_error, got %v", trace)
+	}
+}
+
+func TestSandboxScapeCPULimitKillsSpinningWorker(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skip("sandbox resource limits are not supported on " + runtime.GOOS)
+	}
+	sandbox := newTestSandbox(t, sandboxTestScape{name: "spin"}, SandboxConfig{CPUSeconds: 1, Timeout: time.Minute})
+
+	_, trace, err := sandbox.Evaluate(context.Background(), newXORTestCortex(t))
+	if err != nil {
+		t.Fatalf("expected a cpu limit to be scored, got error: %v", err)
+	}
+	if reason, _ := trace["sandbox_error"].(string); !strings.Contains(reason, fmt.Sprintf("exit status %d", sandboxExitCPULimit)) {
+		t.Fatalf("expected cpu limit exit in sandbox_error, got %v", trace)
+	}
+}
+
+func TestSandboxScapeErrorKeepsWorker(t *testing.T) {
+	sandbox := newTestSandbox(t, sandboxTestScape{name: "fail"}, SandboxConfig{FailureFitness: -2})
+
+	fitness, trace, err := sandbox.Evaluate(context.Background(), newXORTestCortex(t))
+	if err != nil {
+		t.Fatalf("expected a scape error to be scored, got error: %v", err)
+	}
+	if fitness != -2 || trace["sandbox_error"] != "scape rejected agent" {
+		t.Fatalf("expected failure fitness and scape error, got fitness=%f trace=%v", fitness, trace)
+	}
+	if len(sandbox.idle) != 1 {
+		t.Fatalf("expected worker to survive a scape error, got %d idle", len(sandbox.idle))
+	}
+}
+
+func TestNewSandboxScapeValidatesConfig(t *testing.T) {
+	if _, err := NewSandboxScape(XORScape{}, SandboxConfig{}); err == nil {
+		t.Fatal("expected missing command to be rejected")
+	}
+	if _, err := NewSandboxScape(XORScape{}, SandboxConfig{Command: []string{"worker"}, CPUSeconds: -1}); err == nil {
+		t.Fatal("expected negative cpu limit to be rejected")
+	}
+	if _, err := NewSandboxScape(XORScape{}, SandboxConfig{Command: []string{"worker"}, FailureFitness: math.NaN()}); err == nil {
+		t.Fatal("expected non-finite failure fitness to be rejected")
+	}
+}
+
+func TestWireFloatRoundTripsNonFinite(t *testing.T) {
+	values := []float64{1.5, math.NaN(), math.Inf(1), math.Inf(-1), -0.25}
+	data, err := json.Marshal(toWireValues(values))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded []wireFloat
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal %s: %v", data, err)
+	}
+	got := fromWireValues(decoded)
+	if got[0] != 1.5 || !math.IsNaN(got[1]) || !math.IsInf(got[2], 1) || !math.IsInf(got[3], -1) || got[4] != -0.25 {
+		t.Fatalf("non-finite values did not round trip: %s -> %v", data, got)
+	}
+}
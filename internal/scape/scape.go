@@ -1,11 +1,51 @@
 package scape
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 type Fitness float64
 
 type Trace map[string]any
 
+// TraceObjectives is the trace key under which a scape reports its fitness as
+// Objectives for multi-objective selection.
+const TraceObjectives = "objectives"
+
+// Objectives is a vector fitness, one score per objective, each maximized.
+// Scapes that report it still return a scalar Fitness that weighs the
+// objectives for ranking and reporting.
+type Objectives []float64
+
+// TraceObjectivesOf returns the objectives trace reports under
+// TraceObjectives; ok is false when it reports none.
+func TraceObjectivesOf(trace Trace) (Objectives, bool) {
+	objectives, ok := trace[TraceObjectives].(Objectives)
+	if !ok || len(objectives) == 0 {
+		return nil, false
+	}
+	return objectives, true
+}
+
+// TraceBehavior is the trace key under which a scape characterizes what an
+// agent did, rather than how well it did, for novelty search.
+const TraceBehavior = "behavior"
+
+// Behavior is a behavior descriptor: a fixed-width vector, such as an output
+// table or a final position, compared by Euclidean distance.
+type Behavior []float64
+
+// TraceBehaviorOf returns the behavior descriptor trace reports under
+// TraceBehavior; ok is false when it reports none.
+func TraceBehaviorOf(trace Trace) (Behavior, bool) {
+	behavior, ok := trace[TraceBehavior].(Behavior)
+	if !ok || len(behavior) == 0 {
+		return nil, false
+	}
+	return behavior, true
+}
+
 type Agent interface {
 	ID() string
 }
@@ -25,3 +65,37 @@ type ModeAwareScape interface {
 	Scape
 	EvaluateMode(ctx context.Context, agent Agent, mode string) (Fitness, Trace, error)
 }
+
+// BatchAgent evaluates independent input rows in one pass. Rows are laid out
+// like StepAgent.RunStep inputs.
+type BatchAgent interface {
+	Agent
+	RunBatch(ctx context.Context, rows [][]float64) ([][]float64, error)
+}
+
+// BatchScape is the capability flag for dataset scapes (classification,
+// regression) whose samples are independent, so a whole split can be scored
+// from a single batched pass instead of stepwise interaction.
+type BatchScape interface {
+	Scape
+	EvaluateBatch(ctx context.Context, agent BatchAgent, mode string) (Fitness, Trace, error)
+}
+
+// ReportScape optionally formats an evaluation trace as a one-line result
+// laid out like the literature the scape reproduces, so replayed scores can be
+// compared with published tables. ok is false when mode has no such report.
+type ReportScape interface {
+	Scape
+	Report(mode string, trace Trace) (report string, ok bool)
+}
+
+func runBatchRows(ctx context.Context, agent BatchAgent, rows [][]float64) ([][]float64, error) {
+	outputs, err := agent.RunBatch(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(outputs) != len(rows) {
+		return nil, fmt.Errorf("agent %s returned %d batch outputs for %d rows", agent.ID(), len(outputs), len(rows))
+	}
+	return outputs, nil
+}
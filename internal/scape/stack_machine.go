@@ -0,0 +1,404 @@
+package scape
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+)
+
+const (
+	StackMachineScapeName = "stack-machine"
+
+	StackTaskReverse = "reverse"
+	StackTaskSum     = "sum"
+	StackTaskArith   = "arith"
+
+	DefaultStackProgramLength = 12
+	MaxStackProgramLength     = 64
+	DefaultStackListWidth     = 3
+	MaxStackListWidth         = 8
+	DefaultStackExamples      = 8
+	MaxStackExamples          = 256
+
+	// stackValueRange bounds the integers drawn for example inputs.
+	stackValueRange = 5
+)
+
+// Stack machine opcodes, in the order the agent output selects them.
+const (
+	StackOpNop  = "nop"
+	StackOpIn   = "in"
+	StackOpDup  = "dup"
+	StackOpSwap = "swap"
+	StackOpPop  = "pop"
+	StackOpAdd  = "add"
+	StackOpSub  = "sub"
+	StackOpMul  = "mul"
+)
+
+// StackTaskNames lists the tasks of the stack-machine suite.
+func StackTaskNames() []string {
+	return []string{StackTaskReverse, StackTaskSum, StackTaskArith}
+}
+
+// StackOpcodes lists the instruction set of the stack machine.
+func StackOpcodes() []string {
+	return []string{StackOpNop, StackOpIn, StackOpDup, StackOpSwap, StackOpPop, StackOpAdd, StackOpSub, StackOpMul}
+}
+
+func init() {
+	if err := RegisterFactory(StackMachineScapeName, newStackMachineScape); err != nil {
+		panic(err)
+	}
+}
+
+// StackMachineScape is a program synthesis benchmark: the agent writes a
+// fixed-length program for a small integer stack machine, one instruction per
+// step, and the program is then run on a set of I/O examples.
+//
+// At each step the agent senses the program position, the stack depth the
+// program has reached so far and the previous opcode, all scaled to [-1,1],
+// and its single output in [-1,1] selects one of the opcodes in StackOpcodes
+// by equal-width bins. "in" pushes the next example input, the arithmetic
+// opcodes pop two values and push the result, and an instruction without
+// enough operands is a no-op. The tasks are:
+//
+//   - reverse: leave the input list on the stack in reverse order
+//   - sum:     leave the sum of the input list
+//   - arith:   leave (x1 + x2) * x3
+//
+// The final stack, read from the top, is compared position by position with
+// the expected output; each example scores its matching positions over the
+// longer of the two, so leftover values cost as much as missing ones. Fitness
+// is the mean example score. The gt, validation and test/benchmark modes draw
+// independent random example sets from Seed, so scores measure whether the
+// program generalizes. Fields are set with the construction parameters task,
+// length, width, examples and seed.
+type StackMachineScape struct {
+	Task     string
+	Length   int
+	Width    int
+	Examples int
+	Seed     int64
+}
+
+func newStackMachineScape(params map[string]string) (Scape, error) {
+	if err := checkFactoryParams(params, "task", "length", "width", "examples", "seed"); err != nil {
+		return nil, err
+	}
+	task, err := choiceFactoryParam(params, "task", StackTaskReverse, StackTaskNames()...)
+	if err != nil {
+		return nil, err
+	}
+	length, err := intFactoryParam(params, "length", DefaultStackProgramLength, 1, MaxStackProgramLength)
+	if err != nil {
+		return nil, err
+	}
+	width, err := intFactoryParam(params, "width", DefaultStackListWidth, 1, MaxStackListWidth)
+	if err != nil {
+		return nil, err
+	}
+	if task == StackTaskArith && width != 3 {
+		return nil, fmt.Errorf("%s task %s requires width 3, got %d", StackMachineScapeName, task, width)
+	}
+	examples, err := intFactoryParam(params, "examples", DefaultStackExamples, 1, MaxStackExamples)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := intFactoryParam(params, "seed", 1, math.MinInt32, math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+	return StackMachineScape{Task: task, Length: length, Width: width, Examples: examples, Seed: int64(seed)}, nil
+}
+
+func (StackMachineScape) Name() string {
+	return StackMachineScapeName
+}
+
+func (s StackMachineScape) Evaluate(ctx context.Context, agent Agent) (Fitness, Trace, error) {
+	return s.EvaluateMode(ctx, agent, "gt")
+}
+
+func (s StackMachineScape) EvaluateMode(ctx context.Context, agent Agent, mode string) (Fitness, Trace, error) {
+	cfg, err := s.configForMode(mode)
+	if err != nil {
+		return 0, nil, err
+	}
+	runner, ok := agent.(StepAgent)
+	if !ok {
+		return 0, nil, fmt.Errorf("agent %s does not implement step runner", agent.ID())
+	}
+	program, err := s.writeProgram(ctx, runner)
+	if err != nil {
+		return 0, nil, err
+	}
+	return evaluateStackProgram(ctx, cfg, program)
+}
+
+type stackExample struct {
+	inputs []int
+	want   []int
+}
+
+type stackMachineModeConfig struct {
+	mode     string
+	task     string
+	examples []stackExample
+}
+
+func (s StackMachineScape) task() string {
+	if s.Task == "" {
+		return StackTaskReverse
+	}
+	return s.Task
+}
+
+func (s StackMachineScape) length() int {
+	if s.Length <= 0 {
+		return DefaultStackProgramLength
+	}
+	return s.Length
+}
+
+func (s StackMachineScape) width() int {
+	if s.task() == StackTaskArith {
+		return 3
+	}
+	if s.Width <= 0 {
+		return DefaultStackListWidth
+	}
+	return s.Width
+}
+
+func (s StackMachineScape) configForMode(mode string) (stackMachineModeConfig, error) {
+	task := s.task()
+	target, ok := stackTaskTarget(task)
+	if !ok {
+		return stackMachineModeConfig{}, fmt.Errorf("unsupported %s task: %s", StackMachineScapeName, task)
+	}
+	count := s.Examples
+	if count <= 0 {
+		count = DefaultStackExamples
+	}
+
+	var salt int64
+	mode = strings.TrimSpace(strings.ToLower(mode))
+	switch mode {
+	case "", "gt":
+		mode = "gt"
+	case "validation":
+		salt = 0x7a11d
+	case "test", "benchmark":
+		salt = 0x5eed
+	default:
+		return stackMachineModeConfig{}, fmt.Errorf("unsupported %s mode: %s", StackMachineScapeName, mode)
+	}
+
+	rng := rand.New(rand.NewSource(s.Seed ^ salt))
+	width := s.width()
+	examples := make([]stackExample, count)
+	for i := range examples {
+		inputs := make([]int, width)
+		for j := range inputs {
+			inputs[j] = rng.Intn(2*stackValueRange+1) - stackValueRange
+		}
+		examples[i] = stackExample{inputs: inputs, want: target(inputs)}
+	}
+	return stackMachineModeConfig{mode: mode, task: task, examples: examples}, nil
+}
+
+// stackTaskTarget returns the expected final stack, top first, for a task.
+func stackTaskTarget(task string) (func([]int) []int, bool) {
+	switch task {
+	case StackTaskReverse:
+		return func(in []int) []int {
+			out := make([]int, len(in))
+			for i, v := range in {
+				out[len(in)-1-i] = v
+			}
+			return out
+		}, true
+	case StackTaskSum:
+		return func(in []int) []int {
+			sum := 0
+			for _, v := range in {
+				sum += v
+			}
+			return []int{sum}
+		}, true
+	case StackTaskArith:
+		return func(in []int) []int { return []int{(in[0] + in[1]) * in[2]} }, true
+	default:
+		return nil, false
+	}
+}
+
+// writeProgram steps the agent once per instruction. The stack depth it
+// senses is tracked symbolically, since it does not depend on the values an
+// example pushes.
+func (s StackMachineScape) writeProgram(ctx context.Context, runner StepAgent) ([]string, error) {
+	opcodes := StackOpcodes()
+	length := s.length()
+	width := s.width()
+	program := make([]string, 0, length)
+	depth, consumed, previous := 0, 0, 0
+	for pos := 0; pos < length; pos++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		position := 0.0
+		if length > 1 {
+			position = 2*float64(pos)/float64(length-1) - 1
+		}
+		in := []float64{
+			position,
+			2*math.Min(float64(depth)/float64(width), 1) - 1,
+			2*float64(previous)/float64(len(opcodes)-1) - 1,
+		}
+		out, err := runner.RunStep(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		if len(out) != 1 {
+			return nil, fmt.Errorf("%s requires one output, got %d", StackMachineScapeName, len(out))
+		}
+		op := stackOpcodeIndex(out[0], len(opcodes))
+		program = append(program, opcodes[op])
+		depth, consumed = stackDepthAfter(opcodes[op], depth, consumed, width)
+		previous = op
+	}
+	return program, nil
+}
+
+// stackOpcodeIndex maps an output in [-1,1] onto n equal-width opcode bins;
+// values outside the range clamp to the end bins.
+func stackOpcodeIndex(out float64, n int) int {
+	if math.IsNaN(out) {
+		return 0
+	}
+	idx := int(math.Floor((out + 1) / 2 * float64(n)))
+	if idx < 0 {
+		return 0
+	}
+	if idx >= n {
+		return n - 1
+	}
+	return idx
+}
+
+func stackDepthAfter(op string, depth, consumed, width int) (int, int) {
+	switch op {
+	case StackOpIn:
+		if consumed < width {
+			return depth + 1, consumed + 1
+		}
+	case StackOpDup:
+		if depth >= 1 {
+			return depth + 1, consumed
+		}
+	case StackOpPop:
+		if depth >= 1 {
+			return depth - 1, consumed
+		}
+	case StackOpAdd, StackOpSub, StackOpMul:
+		if depth >= 2 {
+			return depth - 1, consumed
+		}
+	}
+	return depth, consumed
+}
+
+// runStackProgram executes program on inputs and returns the final stack,
+// top first.
+func runStackProgram(program []string, inputs []int) []int {
+	stack := make([]int, 0, len(program))
+	next := 0
+	for _, op := range program {
+		n := len(stack)
+		switch op {
+		case StackOpIn:
+			if next < len(inputs) {
+				stack = append(stack, inputs[next])
+				next++
+			}
+		case StackOpDup:
+			if n >= 1 {
+				stack = append(stack, stack[n-1])
+			}
+		case StackOpSwap:
+			if n >= 2 {
+				stack[n-1], stack[n-2] = stack[n-2], stack[n-1]
+			}
+		case StackOpPop:
+			if n >= 1 {
+				stack = stack[:n-1]
+			}
+		case StackOpAdd, StackOpSub, StackOpMul:
+			if n >= 2 {
+				a, b := stack[n-2], stack[n-1]
+				var v int
+				switch op {
+				case StackOpAdd:
+					v = a + b
+				case StackOpSub:
+					v = a - b
+				default:
+					v = a * b
+				}
+				stack = append(stack[:n-2], v)
+			}
+		}
+	}
+	top := make([]int, len(stack))
+	for i, v := range stack {
+		top[len(stack)-1-i] = v
+	}
+	return top
+}
+
+func scoreStackOutput(got, want []int) float64 {
+	size := len(want)
+	if len(got) > size {
+		size = len(got)
+	}
+	if size == 0 {
+		return 1
+	}
+	matches := 0
+	for i := 0; i < len(got) && i < len(want); i++ {
+		if got[i] == want[i] {
+			matches++
+		}
+	}
+	return float64(matches) / float64(size)
+}
+
+func evaluateStackProgram(ctx context.Context, cfg stackMachineModeConfig, program []string) (Fitness, Trace, error) {
+	var total float64
+	solved := 0
+	for _, example := range cfg.examples {
+		if err := ctx.Err(); err != nil {
+			return 0, nil, err
+		}
+		score := scoreStackOutput(runStackProgram(program, example.inputs), example.want)
+		total += score
+		if score == 1 {
+			solved++
+		}
+	}
+	accuracy := 0.0
+	if len(cfg.examples) > 0 {
+		accuracy = total / float64(len(cfg.examples))
+	}
+	return Fitness(accuracy), Trace{
+		"accuracy": accuracy,
+		"solved":   solved,
+		"examples": len(cfg.examples),
+		"program":  strings.Join(program, " "),
+		"mode":     cfg.mode,
+		"task":     cfg.task,
+	}, nil
+}
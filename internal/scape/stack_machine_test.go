@@ -0,0 +1,139 @@
+package scape
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+// stackProgramAgent writes program one opcode per step, reading its position
+// back from the first sensor.
+func stackProgramAgent(program []string, length int) scriptedStepAgent {
+	index := map[string]int{}
+	for i, op := range StackOpcodes() {
+		index[op] = i
+	}
+	n := float64(len(StackOpcodes()))
+	return scriptedStepAgent{id: "stack-agent", fn: func(input []float64) []float64 {
+		pos := int(math.Round((input[0] + 1) / 2 * float64(length-1)))
+		op := StackOpNop
+		if pos < len(program) {
+			op = program[pos]
+		}
+		return []float64{-1 + (2*float64(index[op])+1)/n}
+	}}
+}
+
+func TestRunStackProgramExecutesOpcodes(t *testing.T) {
+	cases := []struct {
+		program []string
+		inputs  []int
+		want    []int
+	}{
+		{[]string{"in", "in", "in"}, []int{1, 2, 3}, []int{3, 2, 1}},
+		{[]string{"in", "in", "add", "in", "mul"}, []int{2, 3, 4}, []int{20}},
+		{[]string{"in", "in", "sub", "dup", "swap"}, []int{5, 7}, []int{-2, -2}},
+		{[]string{"add", "pop", "in", "in", "in", "pop"}, []int{4, 9}, []int{4}},
+	}
+	for i, c := range cases {
+		got := runStackProgram(c.program, c.inputs)
+		if len(got) != len(c.want) {
+			t.Fatalf("case %d: expected stack %v, got %v", i, c.want, got)
+		}
+		for j := range got {
+			if got[j] != c.want[j] {
+				t.Fatalf("case %d: expected stack %v, got %v", i, c.want, got)
+			}
+		}
+	}
+	if got := scoreStackOutput([]int{3, 2, 9, 9}, []int{3, 2}); got != 0.5 {
+		t.Fatalf("expected leftover values to halve the score, got %f", got)
+	}
+}
+
+func TestStackMachineScapeScoresHandWrittenPrograms(t *testing.T) {
+	cases := []struct {
+		task    string
+		program []string
+	}{
+		{StackTaskReverse, []string{"in", "in", "in"}},
+		{StackTaskSum, []string{"in", "in", "add", "in", "add"}},
+		{StackTaskArith, []string{"in", "in", "add", "in", "mul"}},
+	}
+	for _, c := range cases {
+		s := StackMachineScape{Task: c.task, Length: 8, Seed: 3}
+		for _, mode := range []string{"gt", "validation", "test", "benchmark"} {
+			fitness, trace, err := s.EvaluateMode(context.Background(), stackProgramAgent(c.program, 8), mode)
+			if err != nil {
+				t.Fatalf("%s %s: %v", c.task, mode, err)
+			}
+			if fitness != 1 {
+				t.Fatalf("%s %s: expected fitness 1, got %f trace=%+v", c.task, mode, fitness, trace)
+			}
+			if solved, _ := trace["solved"].(int); solved != DefaultStackExamples {
+				t.Fatalf("%s %s: expected every example solved, got %+v", c.task, mode, trace["solved"])
+			}
+		}
+	}
+
+	s := StackMachineScape{Task: StackTaskSum, Length: 8, Seed: 3}
+	fitness, trace, err := s.Evaluate(context.Background(), stackProgramAgent([]string{"in", "in", "in"}, 8))
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if fitness >= 1 {
+		t.Fatalf("expected a wrong program to score below 1, got %f", fitness)
+	}
+	if program, _ := trace["program"].(string); program != "in in in nop nop nop nop nop" {
+		t.Fatalf("unexpected program trace %q", program)
+	}
+	if _, _, err := s.EvaluateMode(context.Background(), stackProgramAgent(nil, 8), "bogus"); err == nil {
+		t.Fatal("expected unsupported mode error")
+	}
+}
+
+func TestStackMachineModesDrawDistinctExamples(t *testing.T) {
+	s := StackMachineScape{Task: StackTaskReverse, Seed: 7}
+	gt, err := s.configForMode("gt")
+	if err != nil {
+		t.Fatalf("gt: %v", err)
+	}
+	validation, err := s.configForMode("validation")
+	if err != nil {
+		t.Fatalf("validation: %v", err)
+	}
+	same := true
+	for i := range gt.examples {
+		for j := range gt.examples[i].inputs {
+			if gt.examples[i].inputs[j] != validation.examples[i].inputs[j] {
+				same = false
+			}
+		}
+	}
+	if same {
+		t.Fatal("expected validation examples to differ from gt examples")
+	}
+}
+
+func TestStackMachineFactoryValidatesParams(t *testing.T) {
+	s, err := NewFromFactory(StackMachineScapeName, map[string]string{"task": "arith", "length": "6", "examples": "4"})
+	if err != nil {
+		t.Fatalf("arith: %v", err)
+	}
+	if got := s.(StackMachineScape); got.Task != StackTaskArith || got.Length != 6 || got.Examples != 4 {
+		t.Fatalf("unexpected scape: %+v", got)
+	}
+	for _, params := range []map[string]string{
+		{"task": "sort"},
+		{"length": "0"},
+		{"length": "65"},
+		{"width": "9"},
+		{"task": "arith", "width": "4"},
+		{"examples": "0"},
+		{"inputs": "3"},
+	} {
+		if _, err := NewFromFactory(StackMachineScapeName, params); err == nil {
+			t.Fatalf("expected params %v to be rejected", params)
+		}
+	}
+}
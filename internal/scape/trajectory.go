@@ -0,0 +1,184 @@
+package scape
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"sync"
+)
+
+// TrajectoryObject is a positioned entity in a recorded frame or in the static
+// layout of a trajectory, e.g. food, a wall or a maze sector.
+type TrajectoryObject struct {
+	Kind string  `json:"kind"`
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+}
+
+// TrajectoryFrame is the agent state after one step of a recorded episode.
+type TrajectoryFrame struct {
+	Episode int                `json:"episode"`
+	Step    int                `json:"step"`
+	X       float64            `json:"x"`
+	Y       float64            `json:"y"`
+	Heading float64            `json:"heading"`
+	Reward  float64            `json:"reward"`
+	Energy  float64            `json:"energy,omitempty"`
+	Event   string             `json:"event,omitempty"`
+	Objects []TrajectoryObject `json:"objects,omitempty"`
+}
+
+// Trajectory is a JSON-playable recording of an agent moving through a 2D
+// scape. Coordinates are in scape units inside Bounds.
+type Trajectory struct {
+	Scape   string             `json:"scape"`
+	AgentID string             `json:"agent_id"`
+	Mode    string             `json:"mode"`
+	MinX    float64            `json:"min_x"`
+	MinY    float64            `json:"min_y"`
+	MaxX    float64            `json:"max_x"`
+	MaxY    float64            `json:"max_y"`
+	Static  []TrajectoryObject `json:"static,omitempty"`
+	Frames  []TrajectoryFrame  `json:"frames"`
+}
+
+// TrajectoryRecorder collects frames from scapes that support episode
+// recording (flatland and dtm). Attach it with WithTrajectoryRecorder.
+type TrajectoryRecorder struct {
+	mu         sync.Mutex
+	trajectory Trajectory
+	episodes   int
+}
+
+func NewTrajectoryRecorder() *TrajectoryRecorder {
+	return &TrajectoryRecorder{}
+}
+
+// Trajectory returns a copy of everything recorded so far.
+func (r *TrajectoryRecorder) Trajectory() Trajectory {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := r.trajectory
+	out.Static = append([]TrajectoryObject(nil), r.trajectory.Static...)
+	out.Frames = append([]TrajectoryFrame(nil), r.trajectory.Frames...)
+	return out
+}
+
+func (r *TrajectoryRecorder) begin(scapeName, agentID, mode string, minX, minY, maxX, maxY float64, static []TrajectoryObject) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.trajectory.Scape == "" {
+		r.trajectory = Trajectory{
+			Scape:   scapeName,
+			AgentID: agentID,
+			Mode:    mode,
+			MinX:    minX,
+			MinY:    minY,
+			MaxX:    maxX,
+			MaxY:    maxY,
+			Static:  append([]TrajectoryObject(nil), static...),
+		}
+	}
+	episode := r.episodes
+	r.episodes++
+	return episode
+}
+
+func (r *TrajectoryRecorder) record(frame TrajectoryFrame) {
+	r.mu.Lock()
+	r.trajectory.Frames = append(r.trajectory.Frames, frame)
+	r.mu.Unlock()
+}
+
+type trajectoryRecorderContextKey struct{}
+
+// WithTrajectoryRecorder returns a context whose evaluations append their
+// agent trajectory to rec.
+func WithTrajectoryRecorder(ctx context.Context, rec *TrajectoryRecorder) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, trajectoryRecorderContextKey{}, rec)
+}
+
+func trajectoryRecorderFromContext(ctx context.Context) *TrajectoryRecorder {
+	if ctx == nil {
+		return nil
+	}
+	rec, _ := ctx.Value(trajectoryRecorderContextKey{}).(*TrajectoryRecorder)
+	return rec
+}
+
+// TrajectoryScape is implemented by scapes that emit frames to a
+// TrajectoryRecorder carried by the evaluation context.
+type TrajectoryScape interface {
+	Scape
+	RecordsTrajectory() bool
+}
+
+var trajectoryObjectColors = map[string]string{
+	"wall":     "#555555",
+	"food":     "#2e9e44",
+	"poison":   "#b03a2e",
+	"prey":     "#d4ac0d",
+	"predator": "#6c3483",
+	"sector":   "#d5d8dc",
+	"reward":   "#f5b041",
+}
+
+// RenderTrajectorySVG draws the static layout, the visited path and an agent
+// marker animated through every frame (SMIL, 10 frames per second).
+func RenderTrajectorySVG(t Trajectory) []byte {
+	const (
+		size   = 400.0
+		margin = 30.0
+	)
+	spanX := t.MaxX - t.MinX
+	spanY := t.MaxY - t.MinY
+	if spanX <= 0 {
+		spanX = 1
+	}
+	if spanY <= 0 {
+		spanY = 1
+	}
+	scale := (size - 2*margin) / max(spanX, spanY)
+	project := func(x, y float64) (float64, float64) {
+		// SVG y grows downwards; flip so scape coordinates read naturally.
+		return margin + (x-t.MinX)*scale, size - margin - (y-t.MinY)*scale
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f">`+"\n", size, size, size, size)
+	fmt.Fprintf(&b, `<title>%s %s agent=%s frames=%d</title>`+"\n", html.EscapeString(t.Scape), html.EscapeString(t.Mode), html.EscapeString(t.AgentID), len(t.Frames))
+	b.WriteString(`<rect width="100%" height="100%" fill="#ffffff"/>` + "\n")
+	for _, object := range t.Static {
+		x, y := project(object.X, object.Y)
+		color, ok := trajectoryObjectColors[object.Kind]
+		if !ok {
+			color = "#999999"
+		}
+		fmt.Fprintf(&b, `<rect x="%.2f" y="%.2f" width="12" height="12" fill="%s"><title>%s</title></rect>`+"\n", x-6, y-6, color, object.Kind)
+	}
+	if len(t.Frames) == 0 {
+		b.WriteString("</svg>\n")
+		return []byte(b.String())
+	}
+
+	points := make([]string, 0, len(t.Frames))
+	xs := make([]string, 0, len(t.Frames))
+	ys := make([]string, 0, len(t.Frames))
+	for _, frame := range t.Frames {
+		x, y := project(frame.X, frame.Y)
+		points = append(points, fmt.Sprintf("%.2f,%.2f", x, y))
+		xs = append(xs, fmt.Sprintf("%.2f", x))
+		ys = append(ys, fmt.Sprintf("%.2f", y))
+	}
+	fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="#5dade2" stroke-opacity="0.35" stroke-width="2"/>`+"\n", strings.Join(points, " "))
+	duration := max(1, float64(len(t.Frames))/10)
+	fmt.Fprintf(&b, `<circle cx="%s" cy="%s" r="7" fill="#1f618d">`+"\n", xs[0], ys[0])
+	fmt.Fprintf(&b, `<animate attributeName="cx" values="%s" dur="%.1fs" calcMode="discrete" repeatCount="indefinite"/>`+"\n", strings.Join(xs, ";"), duration)
+	fmt.Fprintf(&b, `<animate attributeName="cy" values="%s" dur="%.1fs" calcMode="discrete" repeatCount="indefinite"/>`+"\n", strings.Join(ys, ";"), duration)
+	b.WriteString("</circle>\n</svg>\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,60 @@
+package scape
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestFlatlandRecordsTrajectoryFrames(t *testing.T) {
+	recorder := NewTrajectoryRecorder()
+	ctx := WithTrajectoryRecorder(context.Background(), recorder)
+	forager := scriptedStepAgent{id: "forager", fn: flatlandGreedyForager}
+
+	if _, _, err := (FlatlandScape{}).Evaluate(ctx, forager); err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	trajectory := recorder.Trajectory()
+	if trajectory.Scape != "flatland" || trajectory.AgentID != "forager" {
+		t.Fatalf("unexpected trajectory header: %+v", trajectory)
+	}
+	if len(trajectory.Frames) < 2 {
+		t.Fatalf("expected initial and step frames, got %d", len(trajectory.Frames))
+	}
+	if trajectory.Frames[0].Step != 0 {
+		t.Fatalf("expected first frame to be the initial state, got step %d", trajectory.Frames[0].Step)
+	}
+	for _, frame := range trajectory.Frames {
+		if frame.X < trajectory.MinX || frame.X > trajectory.MaxX || frame.Y < trajectory.MinY || frame.Y > trajectory.MaxY {
+			t.Fatalf("frame outside bounds: %+v", frame)
+		}
+	}
+
+	svg := string(RenderTrajectorySVG(trajectory))
+	if !strings.HasPrefix(svg, "<svg") || !strings.Contains(svg, "<animate") || !strings.Contains(svg, "<polyline") {
+		t.Fatalf("expected animated svg, got %q", svg)
+	}
+}
+
+func TestDTMRecordsTrajectoryFrames(t *testing.T) {
+	recorder := NewTrajectoryRecorder()
+	ctx := WithTrajectoryRecorder(context.Background(), recorder)
+	forward := scriptedStepAgent{id: "forward", fn: func(_ []float64) []float64 { return []float64{0} }}
+
+	if _, _, err := (DTMScape{}).Evaluate(ctx, forward); err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	trajectory := recorder.Trajectory()
+	if trajectory.Scape != "dtm" || len(trajectory.Frames) == 0 || len(trajectory.Static) == 0 {
+		t.Fatalf("expected dtm frames and maze layout, got %+v", trajectory)
+	}
+	terminal := false
+	for _, frame := range trajectory.Frames {
+		if strings.HasPrefix(frame.Event, "terminal") || frame.Event == "crash" {
+			terminal = true
+		}
+	}
+	if !terminal {
+		t.Fatal("expected at least one terminal or crash frame")
+	}
+}
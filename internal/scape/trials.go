@@ -0,0 +1,84 @@
+package scape
+
+import (
+	"context"
+	"fmt"
+)
+
+type trialContextKey struct{}
+
+type envSeedContextKey struct{}
+
+type noiseSlotContextKey struct{}
+
+type noiseSlot struct {
+	generation int
+	slot       int
+}
+
+// WithTrial returns a context tagging an evaluation as the given repeated
+// trial. Stochastic scapes fold the trial into their per-agent randomization
+// so repeated trials of one agent see different episodes; trial 0 keeps the
+// untagged behavior.
+func WithTrial(ctx context.Context, trial int) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, trialContextKey{}, trial)
+}
+
+// TrialFromContext returns the repeated-trial index carried by ctx, or 0.
+func TrialFromContext(ctx context.Context) int {
+	if ctx == nil {
+		return 0
+	}
+	trial, _ := ctx.Value(trialContextKey{}).(int)
+	return trial
+}
+
+// WithEnvSeed returns a context carrying an explicit environment seed.
+// Stochastic scapes fold it into their per-agent randomization, so the
+// environment noise can be held fixed or varied independently of the search
+// seeds. Without it scapes keep their seed-independent behavior.
+func WithEnvSeed(ctx context.Context, seed int64) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, envSeedContextKey{}, seed)
+}
+
+// EnvSeedFromContext returns the environment seed carried by ctx, if any.
+func EnvSeedFromContext(ctx context.Context) (int64, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	seed, ok := ctx.Value(envSeedContextKey{}).(int64)
+	return seed, ok
+}
+
+// WithNoiseSlot returns a context that keys per-agent randomization by
+// population slot and generation instead of agent identity. Two runs that tag
+// evaluations this way draw common random numbers: slot i of generation g sees
+// the same episode in both, whatever genome occupies it.
+func WithNoiseSlot(ctx context.Context, generation, slot int) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, noiseSlotContextKey{}, noiseSlot{generation: generation, slot: slot})
+}
+
+func trialAgentKey(ctx context.Context, agentID string) string {
+	key := agentID
+	if ctx != nil {
+		if slot, ok := ctx.Value(noiseSlotContextKey{}).(noiseSlot); ok {
+			key = fmt.Sprintf("slot-g%d-%d", slot.generation, slot.slot)
+		}
+	}
+	if seed, ok := EnvSeedFromContext(ctx); ok {
+		key = fmt.Sprintf("%s#env-%d", key, seed)
+	}
+	if trial := TrialFromContext(ctx); trial > 0 {
+		key = fmt.Sprintf("%s#trial-%d", key, trial)
+	}
+	return key
+}
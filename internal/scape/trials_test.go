@@ -0,0 +1,57 @@
+package scape
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTrialAgentKeyFoldsEnvSeedAndTrial(t *testing.T) {
+	ctx := context.Background()
+	if got := trialAgentKey(ctx, "agent"); got != "agent" {
+		t.Fatalf("expected untagged context to keep agent id, got %q", got)
+	}
+	if _, ok := EnvSeedFromContext(ctx); ok {
+		t.Fatal("expected no env seed on a plain context")
+	}
+
+	seeded := WithEnvSeed(ctx, 42)
+	if seed, ok := EnvSeedFromContext(seeded); !ok || seed != 42 {
+		t.Fatalf("expected env seed 42, got %d (ok=%t)", seed, ok)
+	}
+	if got := trialAgentKey(seeded, "agent"); got != "agent#env-42" {
+		t.Fatalf("unexpected env-seeded key: %q", got)
+	}
+	if got := trialAgentKey(WithTrial(seeded, 2), "agent"); got != "agent#env-42#trial-2" {
+		t.Fatalf("unexpected env-seeded trial key: %q", got)
+	}
+	if got := trialAgentKey(WithEnvSeed(ctx, 0), "agent"); got != "agent#env-0" {
+		t.Fatalf("expected an explicit zero env seed to be honored, got %q", got)
+	}
+}
+
+func TestEnvSeedVariesFlatlandBenchmarkLayout(t *testing.T) {
+	cfg, err := flatlandConfigForMode("benchmark")
+	if err != nil {
+		t.Fatalf("flatland benchmark config: %v", err)
+	}
+	baseline, _, _ := flatlandLayoutVariant(cfg, trialAgentKey(WithEnvSeed(context.Background(), 0), "agent-0"))
+	for seed := int64(1); seed < 64; seed++ {
+		candidate, _, _ := flatlandLayoutVariant(cfg, trialAgentKey(WithEnvSeed(context.Background(), seed), "agent-0"))
+		if candidate != baseline {
+			return
+		}
+	}
+	t.Fatalf("expected env seed to change the benchmark layout variant, baseline=%d", baseline)
+}
+
+func TestNoiseSlotKeysRandomizationBySlotNotAgent(t *testing.T) {
+	ctx := WithNoiseSlot(WithEnvSeed(context.Background(), 7), 3, 1)
+	a := trialAgentKey(ctx, "arm-a-genome")
+	b := trialAgentKey(ctx, "arm-b-genome")
+	if a != b || a != "slot-g3-1#env-7" {
+		t.Fatalf("expected slot-keyed common random numbers, got %q and %q", a, b)
+	}
+	if other := trialAgentKey(WithNoiseSlot(context.Background(), 3, 2), "arm-a-genome"); other == a {
+		t.Fatalf("expected different slots to draw different noise, got %q", other)
+	}
+}
@@ -43,6 +43,34 @@ func (XORScape) EvaluateMode(ctx context.Context, agent Agent, mode string) (Fit
 	return evaluateXORWithStep(ctx, runner, cfg)
 }
 
+func (XORScape) EvaluateBatch(ctx context.Context, agent BatchAgent, mode string) (Fitness, Trace, error) {
+	cfg, err := xorConfigForMode(mode)
+	if err != nil {
+		return 0, nil, err
+	}
+	rows := make([][]float64, len(cfg.cases))
+	for i, c := range cfg.cases {
+		rows[i] = c.in
+	}
+	outputs, err := runBatchRows(ctx, agent, rows)
+	if err != nil {
+		return 0, nil, err
+	}
+	next := 0
+	return evaluateXOR(
+		ctx,
+		cfg,
+		func(context.Context, []float64) (float64, error) {
+			out := outputs[next]
+			next++
+			if len(out) != 1 {
+				return 0, fmt.Errorf("xor requires one output, got %d", len(out))
+			}
+			return out[0], nil
+		},
+	)
+}
+
 type xorCase struct {
 	in   []float64
 	want float64
@@ -172,6 +200,7 @@ func evaluateXOR(
 		"predictions": predictions,
 		"mode":        cfg.mode,
 		"cases":       len(cfg.cases),
+		TraceBehavior: Behavior(predictions),
 	}, nil
 }
 
@@ -2,6 +2,7 @@ package scape
 
 import (
 	"context"
+	"math"
 	"testing"
 
 	"protogonos/internal/agent"
@@ -55,6 +56,15 @@ func TestXORScapeEvaluateWithHandBuiltAgent(t *testing.T) {
 	if diff := float64(fitness - wantFitness); diff < -1e-9 || diff > 1e-9 {
 		t.Fatalf("expected reciprocal-sse fitness %f, got %f (trace=%+v)", wantFitness, fitness, trace)
 	}
+	behavior, ok := TraceBehaviorOf(trace)
+	if !ok || len(behavior) != 4 {
+		t.Fatalf("expected the four-case output table as behavior, got %+v", trace)
+	}
+	for i, want := range []float64{0, 1, 1, 0} {
+		if math.Abs(behavior[i]-want) > 0.25 {
+			t.Fatalf("expected behavior to follow the xor table, got %v", behavior)
+		}
+	}
 }
 
 func TestXORScapeEvaluateWithIOComponents(t *testing.T) {
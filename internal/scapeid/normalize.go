@@ -104,6 +104,14 @@ func canonicalScapeName(alias string) (string, bool) {
 		return "epitopes", true
 	case "llvm-phase-ordering":
 		return "llvm-phase-ordering", true
+	case "parity":
+		return "parity", true
+	case "majority":
+		return "majority", true
+	case "function-approx":
+		return "function-approx", true
+	case "stack-machine":
+		return "stack-machine", true
 	}
 
 	compact := strings.ReplaceAll(alias, "-", "")
@@ -128,6 +136,14 @@ func canonicalScapeName(alias string) (string, bool) {
 		return "epitopes", true
 	case "llvmphaseordering":
 		return "llvm-phase-ordering", true
+	case "parity", "xorn":
+		return "parity", true
+	case "majority":
+		return "majority", true
+	case "functionapprox", "fnapprox":
+		return "function-approx", true
+	case "stackmachine", "stack":
+		return "stack-machine", true
 	default:
 		return "", false
 	}
@@ -1,6 +1,7 @@
 package stats
 
 import (
+	"bytes"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
@@ -10,18 +11,30 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"protogonos/internal/model"
 )
 
 const runIndexFile = "run_index.json"
 
+// runIndexMu serializes the read-modify-write of AppendRunIndex so runs
+// finishing concurrently, such as parallel restarts, neither read a
+// half-written index nor drop each other's entries.
+var runIndexMu sync.Mutex
+
 type RunConfig struct {
 	RunID                   string   `json:"run_id"`
 	ContinuePopulationID    string   `json:"continue_population_id,omitempty"`
+	InitFromChampions       string   `json:"init_from_champions,omitempty"`
+	InitChampionSources     []string `json:"init_champion_sources,omitempty"`
+	ForkedFrom              string   `json:"forked_from,omitempty"`
+	ForkGeneration          int      `json:"fork_generation,omitempty"`
 	SpecieIdentifier        string   `json:"specie_identifier,omitempty"`
 	OpMode                  string   `json:"op_mode,omitempty"`
 	EvolutionType           string   `json:"evolution_type,omitempty"`
+	Encoding                string   `json:"encoding,omitempty"`
+	Algorithm               string   `json:"algorithm,omitempty"`
 	InitialGeneration       int      `json:"initial_generation"`
 	Scape                   string   `json:"scape"`
 	GTSACSVPath             string   `json:"gtsa_csv_path,omitempty"`
@@ -63,9 +76,51 @@ type RunConfig struct {
 	StartPaused             bool     `json:"start_paused"`
 	AutoContinueAfterMS     int64    `json:"auto_continue_after_ms"`
 	Seed                    int64    `json:"seed"`
+	SelectionSeed           *int64   `json:"selection_seed,omitempty"`
+	MutationSeed            *int64   `json:"mutation_seed,omitempty"`
+	EnvSeed                 *int64   `json:"env_seed,omitempty"`
 	Workers                 int      `json:"workers"`
+	EvaluationTrials        int      `json:"evaluation_trials,omitempty"`
+	CITieBreak              bool     `json:"ci_tiebreak,omitempty"`
+	TrialAggregation        string   `json:"trial_aggregation,omitempty"`
+	CVaRAlpha               float64  `json:"cvar_alpha,omitempty"`
+	FitnessScaling          string   `json:"fitness_scaling,omitempty"`
+	ScalingPressure         float64  `json:"scaling_pressure,omitempty"`
+	SpeciesAllocation       string   `json:"species_allocation,omitempty"`
+	AllocationFloor         int      `json:"allocation_floor,omitempty"`
+	AllocationCeiling       int      `json:"allocation_ceiling,omitempty"`
+	SpeciesSoftCap          int      `json:"species_soft_cap,omitempty"`
+	SpeciesCapHysteresis    float64  `json:"species_cap_hysteresis,omitempty"`
+	PopulationResize        string   `json:"population_resize,omitempty"`
+	MinPopulation           int      `json:"min_population,omitempty"`
+	MaxPopulation           int      `json:"max_population,omitempty"`
+	ResizeTargetSeconds     float64  `json:"resize_target_seconds,omitempty"`
+	LowFidelity             float64  `json:"low_fidelity,omitempty"`
+	FinalistFraction        float64  `json:"finalist_fraction,omitempty"`
+	ActivationClamp         float64  `json:"activation_clamp,omitempty"`
+	WeightClamp             float64  `json:"weight_clamp,omitempty"`
+	CrossoverRate           float64  `json:"crossover_rate,omitempty"`
+	InterspeciesMating      float64  `json:"interspecies_mating,omitempty"`
+	PruneDisabledAfter      int      `json:"prune_disabled_after,omitempty"`
+	PruneDisabledProb       float64  `json:"prune_disabled_prob,omitempty"`
+	TrackInnovations        bool     `json:"track_innovations,omitempty"`
+	InitialSensorsMin       int      `json:"initial_sensors_min,omitempty"`
+	InitialSensorsMax       int      `json:"initial_sensors_max,omitempty"`
+	EvaluationTimeoutMS     int64    `json:"evaluation_timeout_ms,omitempty"`
+	KarmaStrikes            int      `json:"karma_strikes,omitempty"`
+	KarmaCooldown           int      `json:"karma_cooldown,omitempty"`
+	ScapeSandbox            bool     `json:"scape_sandbox,omitempty"`
+	SandboxCommand          string   `json:"sandbox_command,omitempty"`
+	SandboxCPUSeconds       int      `json:"sandbox_cpu_seconds,omitempty"`
+	SandboxMemoryMB         int      `json:"sandbox_memory_mb,omitempty"`
+	SandboxTimeoutMS        int64    `json:"sandbox_timeout_ms,omitempty"`
+	SandboxFailureFitness   float64  `json:"sandbox_failure_fitness,omitempty"`
 	EliteCount              int      `json:"elite_count"`
+	ParityStrict            bool     `json:"parity_strict,omitempty"`
 	Selection               string   `json:"selection"`
+	TournamentSize          int      `json:"tournament_size,omitempty"`
+	TournamentNoReplace     bool     `json:"tournament_no_replace,omitempty"`
+	TournamentWinProb       float64  `json:"tournament_win_prob,omitempty"`
 	FitnessPostprocessor    string   `json:"fitness_postprocessor"`
 	TopologicalPolicy       string   `json:"topological_policy"`
 	TopologicalCount        int      `json:"topological_count"`
@@ -83,6 +138,8 @@ type RunConfig struct {
 	TunePerturbationRange   float64  `json:"tune_perturbation_range"`
 	TuneAnnealingFactor     float64  `json:"tune_annealing_factor"`
 	TuneMinImprovement      float64  `json:"tune_min_improvement"`
+	TuningTrace             bool     `json:"tuning_trace,omitempty"`
+	TuneAcceptance          string   `json:"tune_acceptance,omitempty"`
 	WeightPerturb           float64  `json:"weight_perturb"`
 	WeightBias              float64  `json:"weight_bias"`
 	WeightRemoveBias        float64  `json:"weight_remove_bias"`
@@ -95,17 +152,86 @@ type RunConfig struct {
 	WeightPlasticityRule    float64  `json:"weight_plasticity_rule"`
 	WeightPlasticity        float64  `json:"weight_plasticity"`
 	WeightSubstrate         float64  `json:"weight_substrate"`
+
+	// ScapeParams holds the construction parameters of a factory-built scape.
+	ScapeParams map[string]string `json:"scape_params,omitempty"`
+	// StopCondition is the run's compound stop rule, when one replaced the
+	// fitness goal and evaluations limit.
+	StopCondition string `json:"stop,omitempty"`
+	// Entropy restart detector settings; see evo.MonitorConfig.
+	EntropyThreshold float64 `json:"entropy_threshold,omitempty"`
+	EntropyMeasure   string  `json:"entropy_measure,omitempty"`
+	EntropyAction    string  `json:"entropy_action,omitempty"`
+	EntropyCooldown  int     `json:"entropy_cooldown,omitempty"`
+	// Online evolution cadence; see evo.MonitorConfig.
+	ReproductionInterval int `json:"reproduction_interval,omitempty"`
+	DiagnosticsWindow    int `json:"diagnostics_window,omitempty"`
+	// Island model layout and migration; see evo.MonitorConfig.
+	Islands           int `json:"islands,omitempty"`
+	MigrationInterval int `json:"migration_interval,omitempty"`
+	Migrants          int `json:"migrants,omitempty"`
+	// CheckpointInterval is the generations between saved checkpoints.
+	CheckpointInterval int `json:"checkpoint_interval,omitempty"`
+	// SnapshotInterval is the generations between saved population
+	// snapshots a run can be forked from.
+	SnapshotInterval int `json:"snapshot_interval,omitempty"`
+	// Weight and cycle bound of the add_recurrent_loop operator.
+	WeightRecurrentLoop    float64 `json:"weight_recurrent_loop,omitempty"`
+	RecurrentLoopMaxLength int     `json:"recurrent_loop_max_length,omitempty"`
+	// Weight of the duplicate_neuron operator.
+	WeightDuplicateNeuron float64 `json:"weight_duplicate_neuron,omitempty"`
+	// Weight of the toggle_synapse_enabled operator.
+	WeightToggleSynapse float64 `json:"weight_toggle_synapse,omitempty"`
+	// Weight of the insert_module operator and the library modules it may
+	// graft; no ids means the whole library.
+	WeightInsertModule float64  `json:"weight_insert_module,omitempty"`
+	Modules            []string `json:"modules,omitempty"`
+	// Weight of the perturb_all_biases operator and the per-step bias change
+	// shared by the bias operators.
+	WeightAllBiases float64 `json:"weight_all_biases,omitempty"`
+	BiasMaxDelta    float64 `json:"bias_max_delta,omitempty"`
+	// ES is set when the run is an evolution strategies baseline rather
+	// than a neuroevolution run.
+	ES *ESRunConfig `json:"es,omitempty"`
+	// BehaviorClone is set when part of the initial population was fitted
+	// to imitate a scripted policy.
+	BehaviorClone *BehaviorCloneRunConfig `json:"behavior_clone,omitempty"`
+}
+
+// ESRunConfig records how an evolution strategies baseline optimized the
+// weights of its fixed topology.
+type ESRunConfig struct {
+	Sigma         float64 `json:"sigma"`
+	LearningRate  float64 `json:"learning_rate"`
+	WeightDecay   float64 `json:"weight_decay,omitempty"`
+	Parameters    int     `json:"parameters"`
+	TopologyRunID string  `json:"topology_run_id,omitempty"`
 }
 
+// BehaviorCloneRunConfig records how a run's initial population was fitted
+// to a scripted policy: the fitness the policy earned, how many of its steps
+// were imitated and the mean imitation error of the fitted genomes.
+type BehaviorCloneRunConfig struct {
+	Genomes        int     `json:"genomes"`
+	Iterations     int     `json:"iterations"`
+	Demonstrations int     `json:"demonstrations"`
+	PolicyFitness  float64 `json:"policy_fitness"`
+	MeanLoss       float64 `json:"mean_loss"`
+}
+
+// TopGenome is one of a run's best genomes. FoldFitness holds the fitness of
+// each fold when the scape cross-validated the genome's evaluation.
 type TopGenome struct {
-	Rank    int          `json:"rank"`
-	Fitness float64      `json:"fitness"`
-	Genome  model.Genome `json:"genome"`
+	Rank        int          `json:"rank"`
+	Fitness     float64      `json:"fitness"`
+	FoldFitness []float64    `json:"fold_fitness,omitempty"`
+	Genome      model.Genome `json:"genome"`
 }
 
 type TraceGeneration struct {
-	Generation int              `json:"generation"`
-	Stats      []TraceStatEntry `json:"stats"`
+	Generation int                 `json:"generation"`
+	Stats      []TraceStatEntry    `json:"stats"`
+	Phases     *model.PhaseTimings `json:"phases,omitempty"`
 }
 
 type TraceStatEntry struct {
@@ -124,8 +250,11 @@ type RunArtifacts struct {
 	SpeciesHistory        []model.SpeciesGeneration     `json:"species_history,omitempty"`
 	TraceAcc              []TraceGeneration             `json:"trace_acc,omitempty"`
 	FinalBestFitness      float64                       `json:"final_best_fitness"`
+	ChampionFitnessCI     *ConfidenceInterval           `json:"champion_fitness_ci,omitempty"`
 	TopGenomes            []TopGenome                   `json:"top_genomes"`
 	Lineage               []LineageEntry                `json:"lineage"`
+	Events                []model.EvolutionEvent        `json:"events,omitempty"`
+	TuningTraces          []model.TuningTrace           `json:"tuning_traces,omitempty"`
 }
 
 type LineageEntry struct {
@@ -133,6 +262,7 @@ type LineageEntry struct {
 	ParentID    string                  `json:"parent_id"`
 	Generation  int                     `json:"generation"`
 	Operation   string                  `json:"operation"`
+	MateID      string                  `json:"mate_id,omitempty"`
 	Events      []model.EvoHistoryEvent `json:"events,omitempty"`
 	Fingerprint string                  `json:"fingerprint,omitempty"`
 	Summary     map[string]any          `json:"summary,omitempty"`
@@ -148,8 +278,20 @@ type TuningComparison struct {
 	WithoutFinalBest  float64   `json:"without_final_best"`
 	WithFinalBest     float64   `json:"with_final_best"`
 	FinalImprovement  float64   `json:"final_improvement"`
+	// Pairing records how environment noise was matched between the arms.
+	// With common random numbers, slot i of generation g sees the same
+	// episode in both arms, so per-generation differences are paired. They
+	// come from a single pair of runs and are autocorrelated, so the report
+	// carries no standard error for them.
+	Pairing               string    `json:"pairing,omitempty"`
+	PairedImprovement     []float64 `json:"paired_improvement,omitempty"`
+	MeanPairedImprovement float64   `json:"mean_paired_improvement,omitempty"`
 }
 
+// TuningPairingCommonRandomNumbers marks comparisons whose arms shared
+// per-slot, per-generation environment noise.
+const TuningPairingCommonRandomNumbers = "common_random_numbers"
+
 type BenchmarkSummary struct {
 	RunID                  string  `json:"run_id"`
 	Scape                  string  `json:"scape"`
@@ -171,6 +313,33 @@ type BenchmarkSummary struct {
 	Improvement            float64 `json:"improvement"`
 	MinImprovement         float64 `json:"min_improvement"`
 	Passed                 bool    `json:"passed"`
+	// EvaluationCost is present when the run metered its evaluations.
+	EvaluationCost *EvaluationCost `json:"evaluation_cost,omitempty"`
+	// SuccessRate is present for success-rate benchmarks, which repeat the
+	// run over consecutive seeds.
+	SuccessRate *SuccessRate `json:"success_rate,omitempty"`
+}
+
+// SuccessRate is the fraction of repetitions reaching FitnessGoal within
+// EvaluationsLimit evaluations, with the evaluations-to-success statistics
+// of the successful ones.
+type SuccessRate struct {
+	Repetitions              int              `json:"repetitions"`
+	FitnessGoal              float64          `json:"fitness_goal"`
+	EvaluationsLimit         int              `json:"evaluations_limit"`
+	Successes                int              `json:"successes"`
+	Rate                     float64          `json:"rate"`
+	MeanEvaluationsToSuccess float64          `json:"mean_evaluations_to_success"`
+	StdEvaluationsToSuccess  float64          `json:"std_evaluations_to_success"`
+	Runs                     []SuccessRateRun `json:"runs"`
+}
+
+type SuccessRateRun struct {
+	RunID       string  `json:"run_id"`
+	Seed        int64   `json:"seed"`
+	Success     bool    `json:"success"`
+	Evaluations int     `json:"evaluations"`
+	FinalBest   float64 `json:"final_best"`
 }
 
 type RunIndexEntry struct {
@@ -189,7 +358,13 @@ type RunIndexEntry struct {
 	EliteCount             int     `json:"elite_count"`
 	TuningEnabled          bool    `json:"tuning_enabled"`
 	FinalBestFitness       float64 `json:"final_best_fitness"`
+	ForkedFrom             string  `json:"forked_from,omitempty"`
+	ForkGeneration         int     `json:"fork_generation,omitempty"`
 	CreatedAtUTC           string  `json:"created_at_utc"`
+
+	// Resources is the run's resource usage; absent for runs recorded
+	// before it was tracked.
+	Resources *RunResources `json:"resources,omitempty"`
 }
 
 func BenchmarkMorphologyLabel(scapeName, gtsaProfile, fxProfile, epitopesProfile, llvmProfile, flatlandScannerProfile string) string {
@@ -362,7 +537,11 @@ func WriteRunArtifacts(baseDir string, artifacts RunArtifacts) (string, error) {
 	if err := writeJSON(filepath.Join(runDir, "config.json"), artifacts.Config); err != nil {
 		return "", err
 	}
-	if err := writeJSON(filepath.Join(runDir, "fitness_history.json"), map[string]any{"best_by_generation": artifacts.BestByGeneration, "final_best_fitness": artifacts.FinalBestFitness}); err != nil {
+	fitnessHistory := map[string]any{"best_by_generation": artifacts.BestByGeneration, "final_best_fitness": artifacts.FinalBestFitness}
+	if artifacts.ChampionFitnessCI != nil {
+		fitnessHistory["champion_fitness_ci"] = artifacts.ChampionFitnessCI
+	}
+	if err := writeJSON(filepath.Join(runDir, "fitness_history.json"), fitnessHistory); err != nil {
 		return "", err
 	}
 	if err := writeJSON(filepath.Join(runDir, "top_genomes.json"), artifacts.TopGenomes); err != nil {
@@ -374,12 +553,23 @@ func WriteRunArtifacts(baseDir string, artifacts RunArtifacts) (string, error) {
 	if err := writeJSON(filepath.Join(runDir, "generation_diagnostics.json"), artifacts.GenerationDiagnostics); err != nil {
 		return "", err
 	}
-	if err := writeJSON(filepath.Join(runDir, "species_history.json"), artifacts.SpeciesHistory); err != nil {
+	if err := writeJSON(filepath.Join(runDir, "species_history.json"), DeltaEncodeSpeciesHistory(artifacts.SpeciesHistory, SpeciesHistoryKeyframeInterval)); err != nil {
 		return "", err
 	}
 	if err := writeJSON(filepath.Join(runDir, "trace_acc.json"), artifacts.TraceAcc); err != nil {
 		return "", err
 	}
+	if err := writeJSON(filepath.Join(runDir, "events.json"), artifacts.Events); err != nil {
+		return "", err
+	}
+	if len(artifacts.TuningTraces) > 0 {
+		if err := writeTuningTraces(filepath.Join(runDir, TuningTraceFile), artifacts.TuningTraces); err != nil {
+			return "", err
+		}
+	}
+	if err := WriteRunManifest(runDir); err != nil {
+		return "", err
+	}
 
 	return runDir, nil
 }
@@ -392,6 +582,8 @@ func AppendRunIndex(baseDir string, entry RunIndexEntry) error {
 		return err
 	}
 
+	runIndexMu.Lock()
+	defer runIndexMu.Unlock()
 	index, err := ListRunIndex(baseDir)
 	if err != nil {
 		return err
@@ -467,13 +659,15 @@ func ExportRunArtifacts(baseDir, runID, outDir string) (string, error) {
 			return "", err
 		}
 	}
-	traceAccPath := filepath.Join(src, "trace_acc.json")
-	if _, err := os.Stat(traceAccPath); err == nil {
-		if err := copyFile(traceAccPath, filepath.Join(dst, "trace_acc.json")); err != nil {
+	for _, file := range []string{"trace_acc.json", TraceStreamFile, TuningTraceFile} {
+		tracePath := filepath.Join(src, file)
+		if _, err := os.Stat(tracePath); err == nil {
+			if err := copyFile(tracePath, filepath.Join(dst, file)); err != nil {
+				return "", err
+			}
+		} else if err != nil && !os.IsNotExist(err) {
 			return "", err
 		}
-	} else if err != nil && !os.IsNotExist(err) {
-		return "", err
 	}
 	comparePath := filepath.Join(src, "compare_tuning.json")
 	if _, err := os.Stat(comparePath); err == nil {
@@ -499,6 +693,9 @@ func ExportRunArtifacts(baseDir, runID, outDir string) (string, error) {
 	} else if err != nil && !os.IsNotExist(err) {
 		return "", err
 	}
+	if err := WriteRunManifest(dst); err != nil {
+		return "", err
+	}
 
 	return dst, nil
 }
@@ -546,7 +743,10 @@ func WriteRunConfig(baseDir, runID string, cfg RunConfig) error {
 	if err := os.MkdirAll(runDir, 0o755); err != nil {
 		return err
 	}
-	return writeJSON(filepath.Join(runDir, "config.json"), cfg)
+	if err := writeJSON(filepath.Join(runDir, "config.json"), cfg); err != nil {
+		return err
+	}
+	return WriteRunManifest(runDir)
 }
 
 func ReadTopGenomes(baseDir, runID string) ([]TopGenome, bool, error) {
@@ -566,7 +766,126 @@ func ReadTopGenomes(baseDir, runID string) ([]TopGenome, bool, error) {
 	return top, true, nil
 }
 
+// ReadEvents returns a run's event log in the order it was recorded.
+// WriteTopGenomes replaces the top genome artifact of an existing run, e.g.
+// after champions were annotated.
+func WriteTopGenomes(baseDir, runID string, top []TopGenome) error {
+	if strings.TrimSpace(runID) == "" {
+		return fmt.Errorf("run id is required")
+	}
+	runDir := filepath.Join(baseDir, runID)
+	if _, err := os.Stat(runDir); err != nil {
+		return err
+	}
+	if err := writeJSON(filepath.Join(runDir, "top_genomes.json"), top); err != nil {
+		return err
+	}
+	return WriteRunManifest(runDir)
+}
+
+func ReadEvents(baseDir, runID string) ([]model.EvolutionEvent, bool, error) {
+	path := filepath.Join(baseDir, runID, "events.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	var events []model.EvolutionEvent
+	if err := json.Unmarshal(data, &events); err != nil {
+		return nil, false, err
+	}
+	return events, true, nil
+}
+
+// TuningTraceFile holds one JSON tuning session per line; runs without
+// tuning traces have none.
+const TuningTraceFile = "tuning_trace.jsonl"
+
+func writeTuningTraces(path string, traces []model.TuningTrace) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, trace := range traces {
+		if err := enc.Encode(trace); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// ReadTuningTraces returns the tuning sessions a run recorded with tuning
+// traces enabled.
+func ReadTuningTraces(baseDir, runID string) ([]model.TuningTrace, bool, error) {
+	data, err := os.ReadFile(filepath.Join(baseDir, runID, TuningTraceFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	var traces []model.TuningTrace
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for dec.More() {
+		var trace model.TuningTrace
+		if err := dec.Decode(&trace); err != nil {
+			return nil, false, err
+		}
+		traces = append(traces, trace)
+	}
+	return traces, true, nil
+}
+
+// ReadGenerationDiagnostics returns the per-generation diagnostics a run
+// recorded in its artifacts.
+func ReadGenerationDiagnostics(baseDir, runID string) ([]model.GenerationDiagnostics, bool, error) {
+	path := filepath.Join(baseDir, runID, "generation_diagnostics.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	var diagnostics []model.GenerationDiagnostics
+	if err := json.Unmarshal(data, &diagnostics); err != nil {
+		return nil, false, err
+	}
+	return diagnostics, true, nil
+}
+
+// ReadSpeciesHistory returns the per-generation species history a run
+// recorded in its artifacts, expanding stored deltas into full lists.
+func ReadSpeciesHistory(baseDir, runID string) ([]model.SpeciesGeneration, bool, error) {
+	path := filepath.Join(baseDir, runID, "species_history.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	history, err := UnmarshalSpeciesHistory(data)
+	if err != nil {
+		return nil, false, err
+	}
+	return history, true, nil
+}
+
+// ReadTraceAcc returns a run's accumulated trace, reading the live trace.jsonl
+// stream when present and the legacy trace_acc.json otherwise.
 func ReadTraceAcc(baseDir, runID string) ([]TraceGeneration, bool, error) {
+	summary, ok, err := ReadTraceSummary(baseDir, runID)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+	return summary.TraceAcc, true, nil
+}
+
+func readLegacyTraceAcc(baseDir, runID string) ([]TraceGeneration, bool, error) {
 	path := filepath.Join(baseDir, runID, "trace_acc.json")
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -584,7 +903,10 @@ func ReadTraceAcc(baseDir, runID string) ([]TraceGeneration, bool, error) {
 }
 
 func WriteTuningComparison(runDir string, report TuningComparison) error {
-	return writeJSON(filepath.Join(runDir, "compare_tuning.json"), report)
+	if err := writeJSON(filepath.Join(runDir, "compare_tuning.json"), report); err != nil {
+		return err
+	}
+	return WriteRunManifest(runDir)
 }
 
 func ReadTuningComparison(baseDir, runID string) (TuningComparison, bool, error) {
@@ -605,7 +927,10 @@ func ReadTuningComparison(baseDir, runID string) (TuningComparison, bool, error)
 }
 
 func WriteBenchmarkSummary(runDir string, summary BenchmarkSummary) error {
-	return writeJSON(filepath.Join(runDir, "benchmark_summary.json"), summary)
+	if err := writeJSON(filepath.Join(runDir, "benchmark_summary.json"), summary); err != nil {
+		return err
+	}
+	return WriteRunManifest(runDir)
 }
 
 func ReadBenchmarkSummary(baseDir, runID string) (BenchmarkSummary, bool, error) {
@@ -645,7 +970,13 @@ func WriteBenchmarkSeries(runDir string, bestByGeneration []float64) error {
 		}
 	}
 	writer.Flush()
-	return writer.Error()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return WriteRunManifest(runDir)
 }
 
 func ReadBenchmarkSeries(baseDir, runID string) ([]float64, bool, error) {
@@ -119,6 +119,13 @@ func WriteBenchmarkerGraphsToDir(outputDir, graphPostfix string, graphs []Benchm
 }
 
 func ReadTraceAccFile(path string) ([]TraceGeneration, error) {
+	if isTraceStreamPath(path) {
+		records, err := ReadTraceStream(path)
+		if err != nil {
+			return nil, err
+		}
+		return SummarizeTraceStream(records).TraceAcc, nil
+	}
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
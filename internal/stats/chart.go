@@ -0,0 +1,233 @@
+package stats
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"strings"
+)
+
+// Chart is a line chart of series sharing the X axis. Series shorter than X
+// end early; NaN values leave gaps.
+type Chart struct {
+	Title  string
+	XLabel string
+	YLabel string
+	X      []float64
+	Series []ChartSeries
+}
+
+type ChartSeries struct {
+	Name   string
+	Values []float64
+}
+
+const (
+	chartWidth  = 720
+	chartHeight = 420
+	chartLeft   = 70
+	chartRight  = 170
+	chartTop    = 40
+	chartBottom = 50
+)
+
+var chartPalette = []color.RGBA{
+	{0x1f, 0x77, 0xb4, 0xff},
+	{0xff, 0x7f, 0x0e, 0xff},
+	{0x2c, 0xa0, 0x2c, 0xff},
+	{0xd6, 0x27, 0x28, 0xff},
+	{0x94, 0x67, 0xbd, 0xff},
+	{0x8c, 0x56, 0x4b, 0xff},
+	{0xe3, 0x77, 0xc2, 0xff},
+	{0x7f, 0x7f, 0x7f, 0xff},
+	{0xbc, 0xbd, 0x22, 0xff},
+	{0x17, 0xbe, 0xcf, 0xff},
+}
+
+func chartColor(i int) color.RGBA {
+	return chartPalette[i%len(chartPalette)]
+}
+
+func chartHex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// chartBounds returns the data ranges, widened so flat series still plot.
+func (c Chart) chartBounds() (minX, maxX, minY, maxY float64) {
+	minX, maxX = math.Inf(1), math.Inf(-1)
+	for _, x := range c.X {
+		minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+	}
+	minY, maxY = math.Inf(1), math.Inf(-1)
+	for _, series := range c.Series {
+		for i, y := range series.Values {
+			if i >= len(c.X) || math.IsNaN(y) || math.IsInf(y, 0) {
+				continue
+			}
+			minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+		}
+	}
+	if math.IsInf(minX, 1) {
+		minX, maxX = 0, 1
+	}
+	if math.IsInf(minY, 1) {
+		minY, maxY = 0, 1
+	}
+	if maxX == minX {
+		minX, maxX = minX-0.5, maxX+0.5
+	}
+	if maxY == minY {
+		pad := math.Max(math.Abs(maxY)*0.05, 0.5)
+		minY, maxY = minY-pad, maxY+pad
+	}
+	return minX, maxX, minY, maxY
+}
+
+// chartProjector maps data coordinates to pixel coordinates of the plot area.
+func (c Chart) chartProjector() func(x, y float64) (float64, float64) {
+	minX, maxX, minY, maxY := c.chartBounds()
+	plotW := float64(chartWidth - chartLeft - chartRight)
+	plotH := float64(chartHeight - chartTop - chartBottom)
+	return func(x, y float64) (float64, float64) {
+		return chartLeft + (x-minX)/(maxX-minX)*plotW, chartTop + plotH - (y-minY)/(maxY-minY)*plotH
+	}
+}
+
+// chartSegments splits a series into polylines at NaN gaps.
+func (c Chart) chartSegments(series ChartSeries) [][][2]float64 {
+	project := c.chartProjector()
+	var segments [][][2]float64
+	var current [][2]float64
+	for i, y := range series.Values {
+		if i >= len(c.X) {
+			break
+		}
+		if math.IsNaN(y) || math.IsInf(y, 0) {
+			if len(current) > 0 {
+				segments = append(segments, current)
+				current = nil
+			}
+			continue
+		}
+		px, py := project(c.X[i], y)
+		current = append(current, [2]float64{px, py})
+	}
+	if len(current) > 0 {
+		segments = append(segments, current)
+	}
+	return segments
+}
+
+// RenderChartSVG draws the chart with axes, tick labels and a legend.
+func RenderChartSVG(c Chart) []byte {
+	minX, maxX, minY, maxY := c.chartBounds()
+	project := c.chartProjector()
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="11">`+"\n", chartWidth, chartHeight, chartWidth, chartHeight)
+	b.WriteString(`<rect width="100%" height="100%" fill="#ffffff"/>` + "\n")
+	fmt.Fprintf(&b, `<text x="%d" y="22" font-size="14">%s</text>`+"\n", chartLeft, html.EscapeString(c.Title))
+
+	x0, y0 := project(minX, minY)
+	x1, y1 := project(maxX, maxY)
+	for i := 0; i <= 4; i++ {
+		frac := float64(i) / 4
+		yValue := minY + frac*(maxY-minY)
+		_, py := project(minX, yValue)
+		fmt.Fprintf(&b, `<line x1="%.2f" y1="%.2f" x2="%.2f" y2="%.2f" stroke="#e5e5e5"/>`+"\n", x0, py, x1, py)
+		fmt.Fprintf(&b, `<text x="%.2f" y="%.2f" text-anchor="end">%s</text>`+"\n", x0-6, py+4, formatChartTick(yValue))
+		xValue := minX + frac*(maxX-minX)
+		px, _ := project(xValue, minY)
+		fmt.Fprintf(&b, `<text x="%.2f" y="%.2f" text-anchor="middle">%s</text>`+"\n", px, y0+16, formatChartTick(xValue))
+	}
+	fmt.Fprintf(&b, `<rect x="%.2f" y="%.2f" width="%.2f" height="%.2f" fill="none" stroke="#333333"/>`+"\n", x0, y1, x1-x0, y0-y1)
+	fmt.Fprintf(&b, `<text x="%.2f" y="%d" text-anchor="middle">%s</text>`+"\n", (x0+x1)/2, chartHeight-12, html.EscapeString(c.XLabel))
+	fmt.Fprintf(&b, `<text x="16" y="%.2f" text-anchor="middle" transform="rotate(-90 16 %.2f)">%s</text>`+"\n", (y0+y1)/2, (y0+y1)/2, html.EscapeString(c.YLabel))
+
+	for i, series := range c.Series {
+		stroke := chartHex(chartColor(i))
+		for _, segment := range c.chartSegments(series) {
+			points := make([]string, len(segment))
+			for j, point := range segment {
+				points[j] = fmt.Sprintf("%.2f,%.2f", point[0], point[1])
+			}
+			fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`+"\n", strings.Join(points, " "), stroke)
+		}
+		legendY := chartTop + 8 + 18*i
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="14" height="4" fill="%s"/>`+"\n", chartWidth-chartRight+16, legendY-4, stroke)
+		fmt.Fprintf(&b, `<text x="%d" y="%d">%s</text>`+"\n", chartWidth-chartRight+36, legendY+1, html.EscapeString(series.Name))
+	}
+	b.WriteString("</svg>\n")
+	return []byte(b.String())
+}
+
+func formatChartTick(v float64) string {
+	return fmt.Sprintf("%.4g", v)
+}
+
+// RenderChartPNG rasterizes the chart's frame, grid, series and legend
+// swatches. The standard library has no font rendering, so the PNG carries
+// no text; legend swatches follow the series order.
+func RenderChartPNG(c Chart) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, chartWidth, chartHeight))
+	chartFillRect(img, 0, 0, chartWidth, chartHeight, color.RGBA{0xff, 0xff, 0xff, 0xff})
+	minX, maxX, minY, maxY := c.chartBounds()
+	project := c.chartProjector()
+	x0, y0 := project(minX, minY)
+	x1, y1 := project(maxX, maxY)
+	grid := color.RGBA{0xe5, 0xe5, 0xe5, 0xff}
+	for i := 1; i < 4; i++ {
+		_, py := project(minX, minY+float64(i)/4*(maxY-minY))
+		chartLine(img, x0, py, x1, py, 1, grid)
+	}
+	frame := color.RGBA{0x33, 0x33, 0x33, 0xff}
+	chartLine(img, x0, y0, x1, y0, 1, frame)
+	chartLine(img, x0, y1, x1, y1, 1, frame)
+	chartLine(img, x0, y0, x0, y1, 1, frame)
+	chartLine(img, x1, y0, x1, y1, 1, frame)
+	for i, series := range c.Series {
+		stroke := chartColor(i)
+		for _, segment := range c.chartSegments(series) {
+			if len(segment) == 1 {
+				chartFillRect(img, int(segment[0][0])-1, int(segment[0][1])-1, 3, 3, stroke)
+			}
+			for j := 1; j < len(segment); j++ {
+				chartLine(img, segment[j-1][0], segment[j-1][1], segment[j][0], segment[j][1], 2, stroke)
+			}
+		}
+		chartFillRect(img, chartWidth-chartRight+16, chartTop+4+18*i, 14, 4, stroke)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func chartFillRect(img *image.RGBA, x, y, w, h int, c color.RGBA) {
+	for py := y; py < y+h; py++ {
+		for px := x; px < x+w; px++ {
+			if image.Pt(px, py).In(img.Rect) {
+				img.SetRGBA(px, py, c)
+			}
+		}
+	}
+}
+
+// chartLine stamps width-pixel squares along the segment at unit steps.
+func chartLine(img *image.RGBA, ax, ay, bx, by float64, width int, c color.RGBA) {
+	steps := int(math.Ceil(math.Max(math.Abs(bx-ax), math.Abs(by-ay))))
+	if steps == 0 {
+		steps = 1
+	}
+	offset := width / 2
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		x := int(math.Round(ax + t*(bx-ax)))
+		y := int(math.Round(ay + t*(by-ay)))
+		chartFillRect(img, x-offset, y-offset, width, width, c)
+	}
+}
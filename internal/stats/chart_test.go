@@ -0,0 +1,75 @@
+package stats
+
+import (
+	"bytes"
+	"image/png"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestRenderChartSVGDrawsSeriesAndLegend(t *testing.T) {
+	chart := Chart{
+		Title:  "run <1>",
+		XLabel: "generation",
+		YLabel: "fitness",
+		X:      []float64{1, 2, 3, 4},
+		Series: []ChartSeries{
+			{Name: "best", Values: []float64{0.1, 0.4, 0.8, 0.9}},
+			{Name: "sp-1", Values: []float64{2, math.NaN(), 3, 4}},
+		},
+	}
+	svg := string(RenderChartSVG(chart))
+	if !strings.HasPrefix(svg, "<svg") || !strings.HasSuffix(svg, "</svg>\n") {
+		t.Fatalf("expected an svg document, got %q", svg)
+	}
+	if !strings.Contains(svg, "run &lt;1&gt;") {
+		t.Fatalf("expected escaped title: %s", svg)
+	}
+	for _, want := range []string{">best<", ">sp-1<", ">generation<", ">fitness<"} {
+		if !strings.Contains(svg, want) {
+			t.Fatalf("expected %q in svg: %s", want, svg)
+		}
+	}
+	// best is one polyline; sp-1 splits at its gap into two.
+	if got := strings.Count(svg, "<polyline"); got != 3 {
+		t.Fatalf("expected 3 polylines, got %d: %s", got, svg)
+	}
+
+	flat := string(RenderChartSVG(Chart{X: []float64{1}, Series: []ChartSeries{{Name: "one", Values: []float64{5}}}}))
+	if strings.Contains(flat, "NaN") || strings.Contains(flat, "Inf") {
+		t.Fatalf("expected finite coordinates for a single point: %s", flat)
+	}
+}
+
+func TestRenderChartPNGRastersSeries(t *testing.T) {
+	chart := Chart{
+		X:      []float64{0, 1, 2},
+		Series: []ChartSeries{{Name: "best", Values: []float64{0, 1, 0.5}}},
+	}
+	data, err := RenderChartPNG(chart)
+	if err != nil {
+		t.Fatalf("render png: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decode png: %v", err)
+	}
+	if img.Bounds().Dx() != chartWidth || img.Bounds().Dy() != chartHeight {
+		t.Fatalf("unexpected png size: %v", img.Bounds())
+	}
+	want := chartColor(0)
+	found := false
+	for y := 0; y < chartHeight && !found; y++ {
+		for x := 0; x < chartWidth-chartRight; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			if uint8(r>>8) == want.R && uint8(g>>8) == want.G && uint8(b>>8) == want.B {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		t.Fatal("expected series pixels in the plot area")
+	}
+}
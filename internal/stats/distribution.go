@@ -0,0 +1,140 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// KolmogorovSmirnov returns the two-sample Kolmogorov-Smirnov statistic, the
+// largest absolute distance between the empirical CDFs of a and b. It returns
+// 0 when either sample is empty.
+func KolmogorovSmirnov(a, b []float64) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	left := append([]float64(nil), a...)
+	right := append([]float64(nil), b...)
+	sort.Float64s(left)
+	sort.Float64s(right)
+
+	var (
+		i, j int
+		maxD float64
+	)
+	for i < len(left) && j < len(right) {
+		x := left[i]
+		if right[j] < x {
+			x = right[j]
+		}
+		for i < len(left) && left[i] <= x {
+			i++
+		}
+		for j < len(right) && right[j] <= x {
+			j++
+		}
+		d := float64(i)/float64(len(left)) - float64(j)/float64(len(right))
+		if d < 0 {
+			d = -d
+		}
+		if d > maxD {
+			maxD = d
+		}
+	}
+	return maxD
+}
+
+// ConfidenceInterval is a bootstrap interval around the mean of repeated
+// trial fitness samples.
+type ConfidenceInterval struct {
+	Mean       float64 `json:"mean"`
+	Lower      float64 `json:"lower"`
+	Upper      float64 `json:"upper"`
+	Confidence float64 `json:"confidence"`
+	Trials     int     `json:"trials"`
+}
+
+// BootstrapMeanCI returns a percentile bootstrap interval for the mean of
+// samples using the given number of resamples. Fewer than two samples yield a
+// zero-width interval at the sample mean.
+func BootstrapMeanCI(samples []float64, resamples int, confidence float64, rng *rand.Rand) ConfidenceInterval {
+	ci := ConfidenceInterval{Confidence: confidence, Trials: len(samples)}
+	if len(samples) == 0 {
+		return ci
+	}
+	ci.Mean = mean(samples)
+	ci.Lower, ci.Upper = ci.Mean, ci.Mean
+	if len(samples) < 2 || resamples <= 0 || rng == nil {
+		return ci
+	}
+	means := make([]float64, resamples)
+	for r := range means {
+		var sum float64
+		for range samples {
+			sum += samples[rng.Intn(len(samples))]
+		}
+		means[r] = sum / float64(len(samples))
+	}
+	sort.Float64s(means)
+	tail := (1 - confidence) / 2
+	ci.Lower = percentileSorted(means, tail)
+	ci.Upper = percentileSorted(means, 1-tail)
+	return ci
+}
+
+// CVaR returns the conditional value at risk of fitness samples: the mean of
+// the worst ceil(alpha*n) values. Lower values are worse, matching fitness
+// maximization. alpha outside (0,1] is clamped.
+func CVaR(samples []float64, alpha float64) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	if alpha <= 0 || alpha > 1 {
+		alpha = 1
+	}
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+	tail := int(math.Ceil(alpha * float64(len(sorted))))
+	if tail < 1 {
+		tail = 1
+	}
+	return mean(sorted[:tail])
+}
+
+// PairedDifferences returns with-minus-without best fitness per generation over
+// the generations both arms completed, with their mean. The differences of
+// one pair of runs track cumulative bests and are strongly autocorrelated, so
+// no standard error is derived from them.
+func PairedDifferences(without, with []float64) ([]float64, float64) {
+	n := len(without)
+	if len(with) < n {
+		n = len(with)
+	}
+	if n == 0 {
+		return nil, 0
+	}
+	diffs := make([]float64, n)
+	for i := 0; i < n; i++ {
+		diffs[i] = with[i] - without[i]
+	}
+	return diffs, mean(diffs)
+}
+
+func mean(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func percentileSorted(sorted []float64, q float64) float64 {
+	if q <= 0 {
+		return sorted[0]
+	}
+	if q >= 1 {
+		return sorted[len(sorted)-1]
+	}
+	idx := int(q * float64(len(sorted)-1))
+	return sorted[idx]
+}
@@ -0,0 +1,76 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestKolmogorovSmirnov(t *testing.T) {
+	if got := KolmogorovSmirnov([]float64{1, 2, 3}, []float64{3, 2, 1}); got != 0 {
+		t.Fatalf("expected identical samples to have ks=0, got %f", got)
+	}
+	if got := KolmogorovSmirnov([]float64{1, 2}, []float64{5, 6, 7}); got != 1 {
+		t.Fatalf("expected disjoint samples to have ks=1, got %f", got)
+	}
+	if got := KolmogorovSmirnov([]float64{1, 2, 3, 4}, []float64{3, 4, 5, 6}); math.Abs(got-0.5) > 1e-9 {
+		t.Fatalf("expected half-overlapping samples to have ks=0.5, got %f", got)
+	}
+	if got := KolmogorovSmirnov(nil, []float64{1}); got != 0 {
+		t.Fatalf("expected empty sample to have ks=0, got %f", got)
+	}
+}
+
+func TestBootstrapMeanCI(t *testing.T) {
+	constant := BootstrapMeanCI([]float64{2, 2, 2}, 200, 0.95, rand.New(rand.NewSource(1)))
+	if constant.Mean != 2 || constant.Lower != 2 || constant.Upper != 2 || constant.Trials != 3 {
+		t.Fatalf("expected zero-width interval for constant samples, got %+v", constant)
+	}
+
+	samples := []float64{0.8, 1.1, 0.9, 1.4, 1.0, 0.7, 1.3}
+	first := BootstrapMeanCI(samples, 500, 0.9, rand.New(rand.NewSource(7)))
+	second := BootstrapMeanCI(samples, 500, 0.9, rand.New(rand.NewSource(7)))
+	if first != second {
+		t.Fatalf("expected deterministic interval for equal seeds: %+v vs %+v", first, second)
+	}
+	if !(first.Lower < first.Mean && first.Mean < first.Upper) {
+		t.Fatalf("expected mean strictly inside interval, got %+v", first)
+	}
+	if first.Lower < 0.7 || first.Upper > 1.4 {
+		t.Fatalf("interval escapes sample range: %+v", first)
+	}
+
+	single := BootstrapMeanCI([]float64{5}, 100, 0.95, rand.New(rand.NewSource(1)))
+	if single.Lower != 5 || single.Upper != 5 {
+		t.Fatalf("expected degenerate interval for one sample, got %+v", single)
+	}
+}
+
+func TestCVaR(t *testing.T) {
+	samples := []float64{5, 1, 4, 2, 3}
+	if got := CVaR(samples, 0.4); got != 1.5 {
+		t.Fatalf("expected cvar@0.4=1.5, got %f", got)
+	}
+	if got := CVaR(samples, 0.01); got != 1 {
+		t.Fatalf("expected tiny alpha to keep the worst sample, got %f", got)
+	}
+	if got := CVaR(samples, 1); got != 3 {
+		t.Fatalf("expected cvar@1 to equal the mean, got %f", got)
+	}
+	if samples[0] != 5 {
+		t.Fatalf("expected input samples to be left unsorted: %v", samples)
+	}
+}
+
+func TestPairedDifferences(t *testing.T) {
+	diffs, avg := PairedDifferences([]float64{1, 2, 3, 9}, []float64{2, 2, 5})
+	if len(diffs) != 3 || diffs[0] != 1 || diffs[1] != 0 || diffs[2] != 2 {
+		t.Fatalf("unexpected paired differences: %v", diffs)
+	}
+	if avg != 1 {
+		t.Fatalf("expected mean paired difference 1, got %f", avg)
+	}
+	if diffs, avg := PairedDifferences(nil, []float64{1}); diffs != nil || avg != 0 {
+		t.Fatalf("expected empty pairing, got %v %f", diffs, avg)
+	}
+}
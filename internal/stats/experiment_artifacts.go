@@ -11,17 +11,19 @@ import (
 const benchmarkExperimentsDir = "experiments"
 
 type BenchmarkExperiment struct {
-	ID             string             `json:"id"`
-	Notes          string             `json:"notes,omitempty"`
-	ProgressFlag   string             `json:"progress_flag"`
-	RunIndex       int                `json:"run_index"`
-	TotalRuns      int                `json:"total_runs"`
-	StartedAtUTC   string             `json:"started_at_utc,omitempty"`
-	CompletedAtUTC string             `json:"completed_at_utc,omitempty"`
-	Interruptions  []string           `json:"interruptions,omitempty"`
-	BenchmarkArgs  []string           `json:"benchmark_args,omitempty"`
-	RunIDs         []string           `json:"run_ids,omitempty"`
-	Summaries      []BenchmarkSummary `json:"summaries,omitempty"`
+	ID                  string                     `json:"id"`
+	Notes               string                     `json:"notes,omitempty"`
+	ProgressFlag        string                     `json:"progress_flag"`
+	RunIndex            int                        `json:"run_index"`
+	TotalRuns           int                        `json:"total_runs"`
+	StartedAtUTC        string                     `json:"started_at_utc,omitempty"`
+	CompletedAtUTC      string                     `json:"completed_at_utc,omitempty"`
+	Interruptions       []string                   `json:"interruptions,omitempty"`
+	BenchmarkArgs       []string                   `json:"benchmark_args,omitempty"`
+	Grid                []string                   `json:"grid,omitempty"`
+	RunIDs              []string                   `json:"run_ids,omitempty"`
+	Summaries           []BenchmarkSummary         `json:"summaries,omitempty"`
+	ParameterImportance *ParameterImportanceReport `json:"parameter_importance,omitempty"`
 }
 
 func WriteBenchmarkExperiment(baseDir string, exp BenchmarkExperiment) error {
@@ -0,0 +1,418 @@
+package stats
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"protogonos/internal/model"
+)
+
+// Genome graph node kinds.
+const (
+	genomeNodeSensor   = "sensor"
+	genomeNodeNeuron   = "neuron"
+	genomeNodeActuator = "actuator"
+)
+
+type genomeGraphNode struct {
+	Key   string
+	Kind  string
+	Label []string
+	// Frozen marks neurons whose bias and incoming weights are frozen.
+	Frozen bool
+}
+
+type genomeGraphEdge struct {
+	From, To string
+	// Synapse is nil for sensor and actuator links.
+	Synapse *model.Synapse
+	// Back is set for recurrent synapses and links against the layering,
+	// which are drawn without constraining the layout.
+	Back bool
+}
+
+// genomeGraph places sensors in the first column, actuators in the last and
+// neurons in between by their longest feedforward path from a source neuron.
+type genomeGraph struct {
+	Columns [][]genomeGraphNode
+	Edges   []genomeGraphEdge
+	column  map[string]int
+}
+
+func buildGenomeGraph(genome model.Genome) genomeGraph {
+	var sensors, actuators []string
+	seen := map[string]bool{}
+	addSensor := func(id string) {
+		if !seen["s:"+id] {
+			seen["s:"+id] = true
+			sensors = append(sensors, id)
+		}
+	}
+	addActuator := func(id string) {
+		if !seen["a:"+id] {
+			seen["a:"+id] = true
+			actuators = append(actuators, id)
+		}
+	}
+	for _, id := range genome.SensorIDs {
+		addSensor(id)
+	}
+	for _, link := range genome.SensorNeuronLinks {
+		addSensor(link.SensorID)
+	}
+	for _, id := range genome.ActuatorIDs {
+		addActuator(id)
+	}
+	for _, link := range genome.NeuronActuatorLinks {
+		addActuator(link.ActuatorID)
+	}
+
+	layers := make(map[string]int, len(genome.Neurons))
+	for _, neuron := range genome.Neurons {
+		layers[neuron.ID] = 0
+	}
+	// Relax non-recurrent synapses into longest-path layers; cycles stop
+	// growing after one pass per neuron.
+	for i := 0; i < len(genome.Neurons); i++ {
+		changed := false
+		for _, synapse := range genome.Synapses {
+			if synapse.Recurrent || synapse.From == synapse.To {
+				continue
+			}
+			from, okFrom := layers[synapse.From]
+			to, okTo := layers[synapse.To]
+			if okFrom && okTo && from+1 > to {
+				layers[synapse.To] = from + 1
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	deepest := 0
+	for _, layer := range layers {
+		deepest = max(deepest, layer)
+	}
+	for _, link := range genome.NeuronActuatorLinks {
+		if _, ok := layers[link.NeuronID]; ok {
+			layers[link.NeuronID] = deepest
+		}
+	}
+
+	graph := genomeGraph{column: map[string]int{}}
+	neuronColumns := 1
+	if len(genome.Neurons) > 0 {
+		neuronColumns = deepest + 1
+	}
+	graph.Columns = make([][]genomeGraphNode, neuronColumns+2)
+	place := func(column int, node genomeGraphNode) {
+		graph.Columns[column] = append(graph.Columns[column], node)
+		graph.column[node.Key] = column
+	}
+	for _, id := range sensors {
+		place(0, genomeGraphNode{Key: "s:" + id, Kind: genomeNodeSensor, Label: []string{id}})
+	}
+	for _, neuron := range genome.Neurons {
+		place(1+layers[neuron.ID], genomeGraphNode{
+			Key:    "n:" + neuron.ID,
+			Kind:   genomeNodeNeuron,
+			Label:  genomeNeuronLabel(neuron),
+			Frozen: neuron.Frozen,
+		})
+	}
+	for _, id := range actuators {
+		place(neuronColumns+1, genomeGraphNode{Key: "a:" + id, Kind: genomeNodeActuator, Label: genomeActuatorLabel(genome, id)})
+	}
+
+	// Synapses and links naming unknown neurons are left out.
+	for _, link := range genome.SensorNeuronLinks {
+		if _, ok := layers[link.NeuronID]; ok {
+			graph.Edges = append(graph.Edges, genomeGraphEdge{From: "s:" + link.SensorID, To: "n:" + link.NeuronID})
+		}
+	}
+	for i := range genome.Synapses {
+		synapse := &genome.Synapses[i]
+		_, okFrom := layers[synapse.From]
+		_, okTo := layers[synapse.To]
+		if !okFrom || !okTo {
+			continue
+		}
+		from, to := "n:"+synapse.From, "n:"+synapse.To
+		graph.Edges = append(graph.Edges, genomeGraphEdge{
+			From:    from,
+			To:      to,
+			Synapse: synapse,
+			Back:    synapse.Recurrent || graph.column[from] >= graph.column[to],
+		})
+	}
+	for _, link := range genome.NeuronActuatorLinks {
+		if _, ok := layers[link.NeuronID]; ok {
+			graph.Edges = append(graph.Edges, genomeGraphEdge{From: "n:" + link.NeuronID, To: "a:" + link.ActuatorID})
+		}
+	}
+	return graph
+}
+
+// genomeNeuronLabel lists the neuron id, its activation, aggregator and bias,
+// and its plasticity rule with the rate and nonzero coefficients.
+func genomeNeuronLabel(neuron model.Neuron) []string {
+	lines := []string{neuron.ID}
+	function := neuron.Activation
+	if neuron.Aggregator != "" {
+		function += "/" + neuron.Aggregator
+	}
+	lines = append(lines, fmt.Sprintf("%s bias=%s", function, formatGenomeValue(neuron.Bias)))
+	if neuron.PlasticityRule != "" && neuron.PlasticityRule != "none" {
+		plasticity := fmt.Sprintf("%s rate=%s", neuron.PlasticityRule, formatGenomeValue(neuron.PlasticityRate))
+		for _, coeff := range []struct {
+			name  string
+			value float64
+		}{{"a", neuron.PlasticityA}, {"b", neuron.PlasticityB}, {"c", neuron.PlasticityC}, {"d", neuron.PlasticityD}} {
+			if coeff.value != 0 {
+				plasticity += fmt.Sprintf(" %s=%s", coeff.name, formatGenomeValue(coeff.value))
+			}
+		}
+		lines = append(lines, plasticity)
+	}
+	if neuron.Frozen {
+		lines = append(lines, "frozen")
+	}
+	return lines
+}
+
+func genomeActuatorLabel(genome model.Genome, id string) []string {
+	lines := []string{id}
+	if tunable, ok := genome.ActuatorTunables[id]; ok {
+		lines = append(lines, "tunable="+formatGenomeValue(tunable))
+	}
+	return lines
+}
+
+// genomeSynapseLabel is the weight, followed by the synapse plasticity
+// parameters and a frozen marker when present.
+func genomeSynapseLabel(synapse model.Synapse) string {
+	label := formatGenomeValue(synapse.Weight)
+	if len(synapse.PlasticityParams) > 0 {
+		params := make([]string, len(synapse.PlasticityParams))
+		for i, param := range synapse.PlasticityParams {
+			params[i] = formatGenomeValue(param)
+		}
+		label += " [" + strings.Join(params, ",") + "]"
+	}
+	if synapse.Frozen {
+		label += " frozen"
+	}
+	return label
+}
+
+func formatGenomeValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', 4, 64)
+}
+
+// genomeWeightColor is blue for excitatory and red for inhibitory weights,
+// grey for disabled synapses and plain links.
+func genomeWeightColor(edge genomeGraphEdge) string {
+	switch {
+	case edge.Synapse == nil || !edge.Synapse.Enabled:
+		return "#999999"
+	case edge.Synapse.Weight < 0:
+		return chartHex(chartPalette[3])
+	default:
+		return chartHex(chartPalette[0])
+	}
+}
+
+func genomeWeightWidth(edge genomeGraphEdge) float64 {
+	if edge.Synapse == nil {
+		return 1
+	}
+	return 0.75 + math.Min(math.Abs(edge.Synapse.Weight), 3)
+}
+
+func genomeGraphTitle(genome model.Genome) string {
+	title := fmt.Sprintf("genome %s neurons=%d synapses=%d", genome.ID, len(genome.Neurons), len(genome.Synapses))
+	if plasticity := genome.Plasticity; plasticity != nil && plasticity.Rule != "" {
+		title += fmt.Sprintf(" plasticity=%s rate=%s", plasticity.Rule, formatGenomeValue(plasticity.Rate))
+	}
+	return title
+}
+
+// WriteGenomeDOT renders the genome as a GraphViz digraph laid out left to
+// right: sensor and actuator boxes, neuron ellipses labeled with activation,
+// bias and plasticity, and synapses labeled with their weights. Disabled
+// synapses are dashed, recurrent ones do not constrain the ranking, and
+// frozen neurons get a double border. Neurons of one inferred layer share a
+// rank so dot output matches RenderGenomeSVG.
+func WriteGenomeDOT(w io.Writer, genome model.Genome) error {
+	graph := buildGenomeGraph(genome)
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(genome.ID))
+	b.WriteString("  rankdir=LR;\n")
+	fmt.Fprintf(&b, "  label=%s;\n  labelloc=t;\n", dotQuote(genomeGraphTitle(genome)))
+	b.WriteString("  node [fontname=\"sans-serif\", fontsize=10];\n")
+	b.WriteString("  edge [fontname=\"sans-serif\", fontsize=9, arrowsize=0.6];\n")
+	for _, column := range graph.Columns {
+		if len(column) == 0 {
+			continue
+		}
+		b.WriteString("  { rank=same;\n")
+		for _, node := range column {
+			attrs := []string{"label=" + dotQuote(strings.Join(node.Label, "\n"))}
+			switch node.Kind {
+			case genomeNodeSensor:
+				attrs = append(attrs, "shape=box", "style=filled", `fillcolor="#d9ead3"`)
+			case genomeNodeActuator:
+				attrs = append(attrs, "shape=box", "style=filled", `fillcolor="#fce5cd"`)
+			default:
+				attrs = append(attrs, "shape=ellipse")
+				if node.Frozen {
+					attrs = append(attrs, "peripheries=2")
+				}
+			}
+			fmt.Fprintf(&b, "    %s [%s];\n", dotQuote(node.Key), strings.Join(attrs, ", "))
+		}
+		b.WriteString("  }\n")
+	}
+	for _, edge := range graph.Edges {
+		attrs := []string{"color=" + dotQuote(genomeWeightColor(edge))}
+		if edge.Synapse != nil {
+			attrs = append(attrs,
+				"label="+dotQuote(genomeSynapseLabel(*edge.Synapse)),
+				fmt.Sprintf("penwidth=%.2f", genomeWeightWidth(edge)),
+			)
+			if !edge.Synapse.Enabled {
+				attrs = append(attrs, "style=dashed")
+			}
+		}
+		if edge.Back {
+			attrs = append(attrs, "constraint=false")
+		}
+		fmt.Fprintf(&b, "  %s -> %s [%s];\n", dotQuote(edge.From), dotQuote(edge.To), strings.Join(attrs, ", "))
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+const (
+	genomeSVGMargin    = 40
+	genomeSVGTitle     = 30
+	genomeSVGColumnGap = 190
+	genomeSVGRowGap    = 80
+	genomeSVGNodeWidth = 130
+	genomeSVGLineGap   = 13
+	// genomeSVGArcRoom is the space kept above and below the rows for arcs.
+	genomeSVGArcRoom = 60
+)
+
+// RenderGenomeSVG draws the genome with the same layering as WriteGenomeDOT
+// without requiring GraphViz: one column per layer, edges between adjacent
+// columns as straight arrows, back edges as arcs above their endpoints and
+// edges skipping columns as arcs below them.
+func RenderGenomeSVG(genome model.Genome) []byte {
+	graph := buildGenomeGraph(genome)
+	rows := 1
+	for _, column := range graph.Columns {
+		rows = max(rows, len(column))
+	}
+	width := 2*genomeSVGMargin + (len(graph.Columns)-1)*genomeSVGColumnGap + genomeSVGNodeWidth
+	rowTop := genomeSVGMargin + genomeSVGTitle + genomeSVGArcRoom
+	height := rowTop + (rows-1)*genomeSVGRowGap + genomeSVGArcRoom + genomeSVGMargin
+	type point struct{ x, y float64 }
+	centers := map[string]point{}
+	boxHeights := map[string]float64{}
+	for c, column := range graph.Columns {
+		// Center shorter columns vertically against the tallest one.
+		offset := float64(rows-len(column)) * genomeSVGRowGap / 2
+		for r, node := range column {
+			centers[node.Key] = point{
+				x: float64(genomeSVGMargin + c*genomeSVGColumnGap + genomeSVGNodeWidth/2),
+				y: float64(rowTop+r*genomeSVGRowGap) + offset,
+			}
+			boxHeights[node.Key] = float64(len(node.Label)*genomeSVGLineGap + 8)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="10">`+"\n", width, height, width, height)
+	b.WriteString(`<defs><marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="6" markerHeight="6" orient="auto-start-reverse"><path d="M 0 0 L 10 5 L 0 10 z" fill="#555555"/></marker></defs>` + "\n")
+	b.WriteString(`<rect width="100%" height="100%" fill="#ffffff"/>` + "\n")
+	fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="13">%s</text>`+"\n", genomeSVGMargin, genomeSVGMargin, html.EscapeString(genomeGraphTitle(genome)))
+
+	half := float64(genomeSVGNodeWidth) / 2
+	for _, edge := range graph.Edges {
+		from, okFrom := centers[edge.From]
+		to, okTo := centers[edge.To]
+		if !okFrom || !okTo {
+			continue
+		}
+		dash := ""
+		if edge.Synapse != nil && !edge.Synapse.Enabled {
+			dash = ` stroke-dasharray="5,3"`
+		}
+		stroke := genomeWeightColor(edge)
+		var labelX, labelY float64
+		if edge.Back || graph.column[edge.To]-graph.column[edge.From] > 1 {
+			side := 1.0
+			if edge.Back {
+				side = -1
+			}
+			y1 := from.y + side*boxHeights[edge.From]/2
+			y2 := to.y + side*boxHeights[edge.To]/2
+			// The curve peaks at three quarters of its control offset.
+			bend := math.Min(genomeSVGRowGap/2, genomeSVGRowGap/4+math.Abs(from.x-to.x)/10) / 0.75
+			control := math.Min(y1, y2) - bend
+			if side > 0 {
+				control = math.Max(y1, y2) + bend
+			}
+			fmt.Fprintf(&b, `<path d="M %.2f %.2f C %.2f %.2f, %.2f %.2f, %.2f %.2f" fill="none" stroke="%s" stroke-width="%.2f"%s marker-end="url(#arrow)"/>`+"\n",
+				from.x, y1, from.x, control, to.x, control, to.x, y2, stroke, genomeWeightWidth(edge), dash)
+			labelX, labelY = (from.x+to.x)/2, (y1+y2)/8+0.75*control-2
+		} else {
+			x1, x2 := from.x+half, to.x-half
+			fmt.Fprintf(&b, `<line x1="%.2f" y1="%.2f" x2="%.2f" y2="%.2f" stroke="%s" stroke-width="%.2f"%s marker-end="url(#arrow)"/>`+"\n",
+				x1, from.y, x2, to.y, stroke, genomeWeightWidth(edge), dash)
+			labelX, labelY = (x1+x2)/2, (from.y+to.y)/2-3
+		}
+		if edge.Synapse != nil {
+			fmt.Fprintf(&b, `<text x="%.2f" y="%.2f" text-anchor="middle" fill="%s">%s</text>`+"\n", labelX, labelY, stroke, html.EscapeString(genomeSynapseLabel(*edge.Synapse)))
+		}
+	}
+
+	for _, column := range graph.Columns {
+		for _, node := range column {
+			center := centers[node.Key]
+			boxHeight := float64(len(node.Label)*genomeSVGLineGap + 8)
+			x, y := center.x-half, center.y-boxHeight/2
+			switch node.Kind {
+			case genomeNodeSensor:
+				fmt.Fprintf(&b, `<rect x="%.2f" y="%.2f" width="%d" height="%.2f" fill="#d9ead3" stroke="#333333"/>`+"\n", x, y, genomeSVGNodeWidth, boxHeight)
+			case genomeNodeActuator:
+				fmt.Fprintf(&b, `<rect x="%.2f" y="%.2f" width="%d" height="%.2f" fill="#fce5cd" stroke="#333333"/>`+"\n", x, y, genomeSVGNodeWidth, boxHeight)
+			default:
+				strokeWidth := 1
+				if node.Frozen {
+					strokeWidth = 3
+				}
+				fmt.Fprintf(&b, `<rect x="%.2f" y="%.2f" width="%d" height="%.2f" rx="%.2f" fill="#ffffff" stroke="#333333" stroke-width="%d"/>`+"\n", x, y, genomeSVGNodeWidth, boxHeight, boxHeight/2, strokeWidth)
+			}
+			for i, line := range node.Label {
+				fmt.Fprintf(&b, `<text x="%.2f" y="%.2f" text-anchor="middle">%s</text>`+"\n", center.x, y+float64(genomeSVGLineGap*(i+1)), html.EscapeString(line))
+			}
+		}
+	}
+	b.WriteString("</svg>\n")
+	return []byte(b.String())
+}
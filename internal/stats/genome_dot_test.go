@@ -0,0 +1,123 @@
+package stats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func dotTestGenome() model.Genome {
+	return model.Genome{
+		ID: `g"1`,
+		Neurons: []model.Neuron{
+			{ID: "i0", Activation: "identity"},
+			{ID: "h0", Activation: "tanh", Bias: 0.25, PlasticityRule: "hebbian", PlasticityRate: 0.1, PlasticityA: 0.5, Frozen: true},
+			{ID: "o0", Activation: "sigmoid", Aggregator: "dot_product"},
+		},
+		Synapses: []model.Synapse{
+			{ID: "s0", From: "i0", To: "h0", Weight: 1.5, Enabled: true, PlasticityParams: []float64{0.2}},
+			{ID: "s1", From: "h0", To: "o0", Weight: -0.75, Enabled: true},
+			{ID: "s2", From: "i0", To: "o0", Weight: 0.5, Enabled: false},
+			{ID: "s3", From: "o0", To: "h0", Weight: 0.3, Enabled: true, Recurrent: true},
+			{ID: "s4", From: "ghost", To: "o0", Weight: 1, Enabled: true},
+		},
+		SensorIDs:           []string{"sensor:x"},
+		ActuatorIDs:         []string{"actuator:y"},
+		SensorNeuronLinks:   []model.SensorNeuronLink{{SensorID: "sensor:x", NeuronID: "i0"}},
+		NeuronActuatorLinks: []model.NeuronActuatorLink{{NeuronID: "o0", ActuatorID: "actuator:y"}},
+		Plasticity:          &model.PlasticityConfig{Rule: "hebbian", Rate: 0.1},
+	}
+}
+
+func TestBuildGenomeGraphLayersByLongestPath(t *testing.T) {
+	graph := buildGenomeGraph(dotTestGenome())
+	want := [][]string{{"s:sensor:x"}, {"n:i0"}, {"n:h0"}, {"n:o0"}, {"a:actuator:y"}}
+	if len(graph.Columns) != len(want) {
+		t.Fatalf("expected %d columns, got %+v", len(want), graph.Columns)
+	}
+	for c, keys := range want {
+		if len(graph.Columns[c]) != len(keys) {
+			t.Fatalf("column %d: expected %v, got %+v", c, keys, graph.Columns[c])
+		}
+		for r, key := range keys {
+			if graph.Columns[c][r].Key != key {
+				t.Fatalf("column %d row %d: expected %s, got %s", c, r, key, graph.Columns[c][r].Key)
+			}
+		}
+	}
+	// Two links plus the four synapses between known neurons.
+	if len(graph.Edges) != 6 {
+		t.Fatalf("expected 6 edges, got %+v", graph.Edges)
+	}
+	for _, edge := range graph.Edges {
+		if back := edge.Synapse != nil && edge.Synapse.ID == "s3"; edge.Back != back {
+			t.Fatalf("unexpected back edge flag on %+v", edge)
+		}
+	}
+}
+
+func TestWriteGenomeDOTRendersAnnotatedGraph(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteGenomeDOT(&buf, dotTestGenome()); err != nil {
+		t.Fatalf("write dot: %v", err)
+	}
+	dot := buf.String()
+	if !strings.HasPrefix(dot, `digraph "g\"1" {`) || !strings.HasSuffix(dot, "}\n") {
+		t.Fatalf("expected a quoted digraph, got %s", dot)
+	}
+	for _, want := range []string{
+		`label="genome g\"1 neurons=3 synapses=5 plasticity=hebbian rate=0.1"`,
+		`"n:h0" [label="h0\ntanh bias=0.25\nhebbian rate=0.1 a=0.5\nfrozen", shape=ellipse, peripheries=2]`,
+		`"n:o0" [label="o0\nsigmoid/dot_product bias=0", shape=ellipse]`,
+		`"s:sensor:x" -> "n:i0" [color="#999999"]`,
+		`"n:i0" -> "n:h0" [color="#1f77b4", label="1.5 [0.2]", penwidth=2.25]`,
+		`"n:h0" -> "n:o0" [color="#d62728", label="-0.75", penwidth=1.50]`,
+		`"n:i0" -> "n:o0" [color="#999999", label="0.5", penwidth=1.25, style=dashed]`,
+		`"n:o0" -> "n:h0" [color="#1f77b4", label="0.3", penwidth=1.05, constraint=false]`,
+		`"n:o0" -> "a:actuator:y"`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Fatalf("expected %q in dot output:\n%s", want, dot)
+		}
+	}
+	if strings.Contains(dot, "ghost") {
+		t.Fatalf("expected synapse from an unknown neuron to be skipped:\n%s", dot)
+	}
+	if got := strings.Count(dot, "rank=same"); got != 5 {
+		t.Fatalf("expected one rank group per column, got %d:\n%s", got, dot)
+	}
+}
+
+func TestRenderGenomeSVGDrawsLayeredGraph(t *testing.T) {
+	svg := string(RenderGenomeSVG(dotTestGenome()))
+	if !strings.HasPrefix(svg, "<svg") || !strings.HasSuffix(svg, "</svg>\n") {
+		t.Fatalf("expected an svg document, got %q", svg)
+	}
+	if !strings.Contains(svg, "genome g&#34;1") {
+		t.Fatalf("expected escaped title: %s", svg)
+	}
+	// Five nodes, four edges between adjacent columns, the recurrent arc
+	// above and the i0->o0 arc below h0.
+	if got := strings.Count(svg, "<rect x="); got != 5 {
+		t.Fatalf("expected 5 node boxes, got %d: %s", got, svg)
+	}
+	if got := strings.Count(svg, "<line "); got != 4 {
+		t.Fatalf("expected 4 straight edges, got %d: %s", got, svg)
+	}
+	if got := strings.Count(svg, " C "); got != 2 {
+		t.Fatalf("expected 2 arcs, got %d: %s", got, svg)
+	}
+	if got := strings.Count(svg, `stroke-dasharray`); got != 1 {
+		t.Fatalf("expected the disabled synapse dashed, got %d: %s", got, svg)
+	}
+	if strings.Contains(svg, "NaN") {
+		t.Fatalf("expected finite coordinates: %s", svg)
+	}
+
+	empty := string(RenderGenomeSVG(model.Genome{ID: "empty"}))
+	if !strings.HasSuffix(empty, "</svg>\n") || strings.Contains(empty, "NaN") {
+		t.Fatalf("expected a valid svg for an empty genome: %s", empty)
+	}
+}
@@ -0,0 +1,186 @@
+package stats
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// RunManifestFile lists the files of a run's artifact directory with their
+// sizes and SHA-256 checksums, so truncated or corrupted artifacts can be
+// detected before they feed analyses. The writers in this package rewrite
+// it whenever they change a run's files.
+const RunManifestFile = "manifest.json"
+
+type RunManifest struct {
+	RunID string                `json:"run_id"`
+	Files []RunManifestArtifact `json:"files"`
+}
+
+// RunManifestArtifact is one file of a run manifest; Name is relative to
+// the run directory, slash-separated.
+type RunManifestArtifact struct {
+	Name   string `json:"name"`
+	Bytes  int64  `json:"bytes"`
+	SHA256 string `json:"sha256"`
+}
+
+// Artifact problems reported by VerifyRunArtifacts.
+const (
+	ArtifactMissing  = "missing"
+	ArtifactSize     = "size_mismatch"
+	ArtifactChecksum = "checksum_mismatch"
+)
+
+type ArtifactProblem struct {
+	Name    string `json:"name"`
+	Problem string `json:"problem"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// ArtifactVerification is the result of checking a run directory against
+// its manifest. Unlisted files were added after the manifest was last
+// written; they are reported but are not problems.
+type ArtifactVerification struct {
+	RunID    string            `json:"run_id"`
+	Checked  int               `json:"checked"`
+	Problems []ArtifactProblem `json:"problems,omitempty"`
+	Unlisted []string          `json:"unlisted,omitempty"`
+}
+
+func (v ArtifactVerification) OK() bool {
+	return len(v.Problems) == 0
+}
+
+// WriteRunManifest checksums every file under runDir and writes the
+// manifest next to them.
+func WriteRunManifest(runDir string) error {
+	artifacts, err := scanRunArtifacts(runDir)
+	if err != nil {
+		return err
+	}
+	return writeJSON(filepath.Join(runDir, RunManifestFile), RunManifest{RunID: filepath.Base(runDir), Files: artifacts})
+}
+
+func ReadRunManifest(baseDir, runID string) (RunManifest, bool, error) {
+	path := filepath.Join(baseDir, runID, RunManifestFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return RunManifest{}, false, nil
+		}
+		return RunManifest{}, false, err
+	}
+	var manifest RunManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return RunManifest{}, false, fmt.Errorf("decode run manifest %s: %w", runID, err)
+	}
+	return manifest, true, nil
+}
+
+// VerifyRunArtifacts checks every file in a run's manifest for presence,
+// size and checksum.
+func VerifyRunArtifacts(baseDir, runID string) (ArtifactVerification, error) {
+	if runID == "" {
+		return ArtifactVerification{}, fmt.Errorf("run id is required")
+	}
+	manifest, ok, err := ReadRunManifest(baseDir, runID)
+	if err != nil {
+		return ArtifactVerification{}, err
+	}
+	if !ok {
+		return ArtifactVerification{}, fmt.Errorf("run manifest not found for run id: %s", runID)
+	}
+	runDir := filepath.Join(baseDir, runID)
+	actual, err := scanRunArtifacts(runDir)
+	if err != nil {
+		return ArtifactVerification{}, err
+	}
+	byName := make(map[string]RunManifestArtifact, len(actual))
+	for _, artifact := range actual {
+		byName[artifact.Name] = artifact
+	}
+
+	verification := ArtifactVerification{RunID: runID, Checked: len(manifest.Files)}
+	listed := make(map[string]bool, len(manifest.Files))
+	for _, want := range manifest.Files {
+		listed[want.Name] = true
+		got, ok := byName[want.Name]
+		switch {
+		case !ok:
+			verification.Problems = append(verification.Problems, ArtifactProblem{Name: want.Name, Problem: ArtifactMissing})
+		case got.Bytes != want.Bytes:
+			verification.Problems = append(verification.Problems, ArtifactProblem{
+				Name:    want.Name,
+				Problem: ArtifactSize,
+				Detail:  fmt.Sprintf("%d bytes, manifest has %d", got.Bytes, want.Bytes),
+			})
+		case got.SHA256 != want.SHA256:
+			verification.Problems = append(verification.Problems, ArtifactProblem{
+				Name:    want.Name,
+				Problem: ArtifactChecksum,
+				Detail:  fmt.Sprintf("sha256 %s, manifest has %s", got.SHA256, want.SHA256),
+			})
+		}
+	}
+	for _, artifact := range actual {
+		if !listed[artifact.Name] {
+			verification.Unlisted = append(verification.Unlisted, artifact.Name)
+		}
+	}
+	return verification, nil
+}
+
+// scanRunArtifacts checksums the regular files under runDir other than the
+// manifest, sorted by name.
+func scanRunArtifacts(runDir string) ([]RunManifestArtifact, error) {
+	var artifacts []RunManifestArtifact
+	err := filepath.WalkDir(runDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		name, err := filepath.Rel(runDir, path)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(name)
+		if name == RunManifestFile {
+			return nil
+		}
+		size, sum, err := hashFile(path)
+		if err != nil {
+			return err
+		}
+		artifacts = append(artifacts, RunManifestArtifact{Name: name, Bytes: size, SHA256: sum})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(artifacts, func(i, j int) bool { return artifacts[i].Name < artifacts[j].Name })
+	return artifacts, nil
+}
+
+func hashFile(path string) (int64, string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return 0, "", err
+	}
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,91 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestVerifyRunArtifactsDetectsDamage(t *testing.T) {
+	baseDir := t.TempDir()
+	runDir, err := WriteRunArtifacts(baseDir, RunArtifacts{
+		Config:           RunConfig{RunID: "run-1", Scape: "xor", PopulationSize: 4, Generations: 2, Seed: 1},
+		BestByGeneration: []float64{0.5, 0.7},
+		FinalBestFitness: 0.7,
+	})
+	if err != nil {
+		t.Fatalf("write run artifacts: %v", err)
+	}
+	if err := WriteBenchmarkSeries(runDir, []float64{0.5, 0.7}); err != nil {
+		t.Fatalf("write benchmark series: %v", err)
+	}
+	manifest, ok, err := ReadRunManifest(baseDir, "run-1")
+	if err != nil || !ok {
+		t.Fatalf("read manifest: ok=%t err=%v", ok, err)
+	}
+	var names []string
+	for _, artifact := range manifest.Files {
+		names = append(names, artifact.Name)
+	}
+	want := []string{"benchmark_series.csv", "config.json", "events.json", "fitness_history.json", "generation_diagnostics.json", "lineage.json", "species_history.json", "top_genomes.json", "trace_acc.json"}
+	if manifest.RunID != "run-1" || !reflect.DeepEqual(names, want) {
+		t.Fatalf("expected manifest of %v, got %+v", want, manifest)
+	}
+	verification, err := VerifyRunArtifacts(baseDir, "run-1")
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if !verification.OK() || verification.Checked != len(want) || len(verification.Unlisted) != 0 {
+		t.Fatalf("expected intact artifacts to verify, got %+v", verification)
+	}
+
+	fitnessPath := filepath.Join(runDir, "fitness_history.json")
+	data, err := os.ReadFile(fitnessPath)
+	if err != nil {
+		t.Fatalf("read fitness history: %v", err)
+	}
+	if err := os.WriteFile(fitnessPath, data[:len(data)/2], 0o644); err != nil {
+		t.Fatalf("truncate: %v", err)
+	}
+	configPath := filepath.Join(runDir, "config.json")
+	data, err = os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	data[1] ^= 0x20
+	if err := os.WriteFile(configPath, data, 0o644); err != nil {
+		t.Fatalf("corrupt: %v", err)
+	}
+	if err := os.Remove(filepath.Join(runDir, "lineage.json")); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(runDir, "notes.txt"), []byte("later"), 0o644); err != nil {
+		t.Fatalf("write extra file: %v", err)
+	}
+
+	verification, err = VerifyRunArtifacts(baseDir, "run-1")
+	if err != nil {
+		t.Fatalf("verify damaged: %v", err)
+	}
+	wantProblems := []ArtifactProblem{
+		{Name: "config.json", Problem: ArtifactChecksum},
+		{Name: "fitness_history.json", Problem: ArtifactSize},
+		{Name: "lineage.json", Problem: ArtifactMissing},
+	}
+	if verification.OK() || len(verification.Problems) != len(wantProblems) {
+		t.Fatalf("expected %d problems, got %+v", len(wantProblems), verification)
+	}
+	for i, problem := range verification.Problems {
+		if problem.Name != wantProblems[i].Name || problem.Problem != wantProblems[i].Problem {
+			t.Fatalf("expected problem %+v, got %+v", wantProblems[i], problem)
+		}
+	}
+	if !reflect.DeepEqual(verification.Unlisted, []string{"notes.txt"}) {
+		t.Fatalf("expected notes.txt to be unlisted, got %v", verification.Unlisted)
+	}
+
+	if _, err := VerifyRunArtifacts(baseDir, "missing-run"); err == nil {
+		t.Fatal("expected a run without a manifest to fail verification")
+	}
+}
@@ -0,0 +1,85 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"protogonos/internal/model"
+)
+
+// moduleLibraryDir holds the reusable modules shared across runs, one JSON
+// file per module id.
+const moduleLibraryDir = "modules"
+
+func WriteModule(baseDir string, module model.Module) error {
+	if err := validateModuleID(module.ID); err != nil {
+		return err
+	}
+	path := modulePath(baseDir, module.ID)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return writeJSON(path, module)
+}
+
+func ReadModule(baseDir, id string) (model.Module, bool, error) {
+	if err := validateModuleID(id); err != nil {
+		return model.Module{}, false, err
+	}
+	data, err := os.ReadFile(modulePath(baseDir, id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return model.Module{}, false, nil
+		}
+		return model.Module{}, false, err
+	}
+	var module model.Module
+	if err := json.Unmarshal(data, &module); err != nil {
+		return model.Module{}, false, err
+	}
+	return module, true, nil
+}
+
+// ListModules returns every module in the library ordered by id.
+func ListModules(baseDir string) ([]model.Module, error) {
+	entries, err := os.ReadDir(filepath.Join(baseDir, moduleLibraryDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []model.Module{}, nil
+		}
+		return nil, err
+	}
+	modules := make([]model.Module, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		module, ok, err := ReadModule(baseDir, strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			modules = append(modules, module)
+		}
+	}
+	sort.Slice(modules, func(i, j int) bool { return modules[i].ID < modules[j].ID })
+	return modules, nil
+}
+
+func validateModuleID(id string) error {
+	if id == "" {
+		return fmt.Errorf("module id is required")
+	}
+	if strings.ContainsAny(id, `/\`) || id == "." || id == ".." {
+		return fmt.Errorf("invalid module id: %s", id)
+	}
+	return nil
+}
+
+func modulePath(baseDir, id string) string {
+	return filepath.Join(baseDir, moduleLibraryDir, id+".json")
+}
@@ -0,0 +1,42 @@
+package stats
+
+import (
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func TestWriteReadAndListModules(t *testing.T) {
+	base := t.TempDir()
+	for _, module := range []model.Module{
+		{ID: "m-b", Neurons: []model.Neuron{{ID: "h"}}, Inputs: []string{"h"}, Outputs: []string{"h"}},
+		{ID: "m-a", Neurons: []model.Neuron{{ID: "x"}, {ID: "y"}}, Synapses: []model.Synapse{{ID: "s", From: "x", To: "y", Weight: 0.5, Enabled: true}}, Inputs: []string{"x"}, Outputs: []string{"y"}},
+	} {
+		if err := WriteModule(base, module); err != nil {
+			t.Fatalf("write module %s: %v", module.ID, err)
+		}
+	}
+
+	read, ok, err := ReadModule(base, "m-a")
+	if err != nil || !ok {
+		t.Fatalf("read module ok=%t err=%v", ok, err)
+	}
+	if len(read.Synapses) != 1 || read.Synapses[0].Weight != 0.5 || read.Outputs[0] != "y" {
+		t.Fatalf("unexpected module payload: %+v", read)
+	}
+	if _, ok, err := ReadModule(base, "missing"); err != nil || ok {
+		t.Fatalf("expected missing module to be absent, ok=%t err=%v", ok, err)
+	}
+
+	list, err := ListModules(base)
+	if err != nil {
+		t.Fatalf("list modules: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != "m-a" || list[1].ID != "m-b" {
+		t.Fatalf("unexpected module listing: %+v", list)
+	}
+
+	if err := WriteModule(base, model.Module{ID: "../escape"}); err == nil {
+		t.Fatal("expected path-like module id to be rejected")
+	}
+}
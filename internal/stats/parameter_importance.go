@@ -0,0 +1,265 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+const (
+	parameterImportanceMaxSweeps = 200
+	parameterImportanceTolerance = 1e-10
+)
+
+// parameterImportanceIgnored lists run config fields that identify a run
+// rather than configure it.
+var parameterImportanceIgnored = map[string]bool{
+	"run_id":                true,
+	"seed":                  true,
+	"init_champion_sources": true,
+}
+
+type ParameterLevel struct {
+	Value  string  `json:"value"`
+	Runs   int     `json:"runs"`
+	Effect float64 `json:"effect"`
+}
+
+// ParameterImportance is the share of final-fitness variance the surrogate
+// attributes to one config field. Aliases are fields that varied in lockstep
+// with Field across the sweep and cannot be told apart from it.
+type ParameterImportance struct {
+	Field      string           `json:"field"`
+	Aliases    []string         `json:"aliases,omitempty"`
+	Importance float64          `json:"importance"`
+	Levels     []ParameterLevel `json:"levels"`
+}
+
+// ParameterImportanceReport ranks the config fields that varied across an
+// experiment's runs. Explained is the share of final-fitness variance the
+// additive surrogate accounts for; the rest is seed noise and interactions.
+type ParameterImportanceReport struct {
+	Runs        int                   `json:"runs"`
+	FitnessMean float64               `json:"fitness_mean"`
+	FitnessStd  float64               `json:"fitness_std"`
+	Explained   float64               `json:"explained"`
+	Parameters  []ParameterImportance `json:"parameters"`
+}
+
+// BuildParameterImportance fits an additive fANOVA-style surrogate of each
+// run's final best fitness over the config fields that varied across the
+// experiment, treating every distinct value as a level. Main effects are
+// fitted by backfitting, so unbalanced sweeps are handled; a field's
+// importance is the variance of its main effect over the total variance.
+func BuildParameterImportance(baseDir string, exp BenchmarkExperiment) (ParameterImportanceReport, error) {
+	if len(exp.Summaries) < len(exp.RunIDs) {
+		return ParameterImportanceReport{}, fmt.Errorf("experiment %s has %d summaries for %d runs", exp.ID, len(exp.Summaries), len(exp.RunIDs))
+	}
+	fitness := make([]float64, len(exp.RunIDs))
+	configs := make([]map[string]string, len(exp.RunIDs))
+	for i, runID := range exp.RunIDs {
+		cfg, ok, err := ReadRunConfigWithProfileHints(baseDir, runID)
+		if err != nil {
+			return ParameterImportanceReport{}, err
+		}
+		if !ok {
+			return ParameterImportanceReport{}, fmt.Errorf("run config not found for run id: %s", runID)
+		}
+		fields, err := flattenRunConfig(cfg)
+		if err != nil {
+			return ParameterImportanceReport{}, err
+		}
+		fitness[i] = exp.Summaries[i].FinalBest
+		configs[i] = fields
+	}
+	return parameterImportance(configs, fitness), nil
+}
+
+func flattenRunConfig(cfg RunConfig) (map[string]string, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	fields := map[string]string{}
+	var flatten func(prefix string, value any) error
+	flatten = func(prefix string, value any) error {
+		if nested, ok := value.(map[string]any); ok {
+			for key, item := range nested {
+				if err := flatten(prefix+key+".", item); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		field := strings.TrimSuffix(prefix, ".")
+		if parameterImportanceIgnored[field] {
+			return nil
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		fields[field] = strings.Trim(string(encoded), `"`)
+		return nil
+	}
+	if err := flatten("", raw); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+type importanceFactor struct {
+	fields []string
+	levels []string
+	// level[i] is the level index of run i.
+	level  []int
+	effect []float64
+}
+
+func parameterImportance(configs []map[string]string, fitness []float64) ParameterImportanceReport {
+	n := len(fitness)
+	report := ParameterImportanceReport{Runs: n, Parameters: []ParameterImportance{}}
+	if n == 0 {
+		return report
+	}
+	report.FitnessMean = mean(fitness)
+	variance := 0.0
+	for _, y := range fitness {
+		variance += (y - report.FitnessMean) * (y - report.FitnessMean)
+	}
+	variance /= float64(n)
+	report.FitnessStd = math.Sqrt(variance)
+
+	factors := varyingFactors(configs)
+	if len(factors) == 0 {
+		return report
+	}
+	fitted := make([]float64, n)
+	for sweep := 0; sweep < parameterImportanceMaxSweeps; sweep++ {
+		change := 0.0
+		for _, factor := range factors {
+			sums := make([]float64, len(factor.levels))
+			counts := make([]int, len(factor.levels))
+			for i, y := range fitness {
+				partial := y - report.FitnessMean - fitted[i] + factor.effect[factor.level[i]]
+				sums[factor.level[i]] += partial
+				counts[factor.level[i]]++
+			}
+			updated := make([]float64, len(factor.levels))
+			center := 0.0
+			for l := range updated {
+				updated[l] = sums[l] / float64(counts[l])
+				center += updated[l] * float64(counts[l])
+			}
+			center /= float64(n)
+			for l := range updated {
+				updated[l] -= center
+				change = math.Max(change, math.Abs(updated[l]-factor.effect[l]))
+			}
+			for i := range fitted {
+				fitted[i] += updated[factor.level[i]] - factor.effect[factor.level[i]]
+			}
+			factor.effect = updated
+		}
+		if change < parameterImportanceTolerance {
+			break
+		}
+	}
+
+	residual := 0.0
+	for i, y := range fitness {
+		r := y - report.FitnessMean - fitted[i]
+		residual += r * r
+	}
+	if variance > 0 {
+		report.Explained = clamp01(1 - residual/float64(n)/variance)
+	}
+	for _, factor := range factors {
+		parameter := ParameterImportance{
+			Field:   factor.fields[0],
+			Aliases: factor.fields[1:],
+			Levels:  make([]ParameterLevel, len(factor.levels)),
+		}
+		counts := make([]int, len(factor.levels))
+		for _, l := range factor.level {
+			counts[l]++
+		}
+		effectVariance := 0.0
+		for l, value := range factor.levels {
+			parameter.Levels[l] = ParameterLevel{Value: value, Runs: counts[l], Effect: factor.effect[l]}
+			effectVariance += float64(counts[l]) * factor.effect[l] * factor.effect[l]
+		}
+		if variance > 0 {
+			parameter.Importance = clamp01(effectVariance / float64(n) / variance)
+		}
+		sort.SliceStable(parameter.Levels, func(i, j int) bool {
+			return parameter.Levels[i].Effect > parameter.Levels[j].Effect
+		})
+		report.Parameters = append(report.Parameters, parameter)
+	}
+	sort.SliceStable(report.Parameters, func(i, j int) bool {
+		if report.Parameters[i].Importance != report.Parameters[j].Importance {
+			return report.Parameters[i].Importance > report.Parameters[j].Importance
+		}
+		return report.Parameters[i].Field < report.Parameters[j].Field
+	})
+	return report
+}
+
+// varyingFactors groups the fields that take more than one value across the
+// runs into factors, merging fields that partition the runs identically.
+func varyingFactors(configs []map[string]string) []*importanceFactor {
+	names := map[string]struct{}{}
+	for _, cfg := range configs {
+		for name := range cfg {
+			names[name] = struct{}{}
+		}
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var factors []*importanceFactor
+	byPartition := map[string]*importanceFactor{}
+	for _, name := range sorted {
+		index := map[string]int{}
+		factor := &importanceFactor{fields: []string{name}, level: make([]int, len(configs))}
+		for i, cfg := range configs {
+			value, ok := cfg[name]
+			if !ok {
+				value = "<unset>"
+			}
+			l, seen := index[value]
+			if !seen {
+				l = len(factor.levels)
+				index[value] = l
+				factor.levels = append(factor.levels, value)
+			}
+			factor.level[i] = l
+		}
+		if len(factor.levels) < 2 {
+			continue
+		}
+		partition := fmt.Sprint(factor.level)
+		if existing, ok := byPartition[partition]; ok {
+			existing.fields = append(existing.fields, name)
+			continue
+		}
+		factor.effect = make([]float64, len(factor.levels))
+		byPartition[partition] = factor
+		factors = append(factors, factor)
+	}
+	return factors
+}
+
+func clamp01(value float64) float64 {
+	return math.Max(0, math.Min(1, value))
+}
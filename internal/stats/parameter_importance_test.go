@@ -0,0 +1,65 @@
+package stats
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestParameterImportanceRanksDominantField(t *testing.T) {
+	var configs []map[string]string
+	var fitness []float64
+	for _, population := range []int{10, 20, 40} {
+		for _, rate := range []string{"0.1", "0.5"} {
+			for replicate := 0; replicate < 3; replicate++ {
+				configs = append(configs, map[string]string{
+					"population":        strconv.Itoa(population),
+					"tournament_size":   strconv.Itoa(population / 5),
+					"mutation_rate":     rate,
+					"scape":             "xor",
+					"workers":           "4",
+					"replicate_noise_k": strconv.Itoa(replicate),
+				})
+				y := float64(population) / 10
+				if rate == "0.5" {
+					y += 0.2
+				}
+				fitness = append(fitness, y+0.01*float64(replicate))
+			}
+		}
+	}
+
+	report := parameterImportance(configs, fitness)
+	if report.Runs != len(fitness) || len(report.Parameters) != 3 {
+		t.Fatalf("expected three varying factors over %d runs, got %+v", len(fitness), report)
+	}
+	top := report.Parameters[0]
+	if top.Field != "population" || len(top.Aliases) != 1 || top.Aliases[0] != "tournament_size" {
+		t.Fatalf("expected population (aliased with tournament_size) to rank first, got %+v", top)
+	}
+	if top.Levels[0].Value != "40" || top.Levels[0].Runs != 6 {
+		t.Fatalf("expected population=40 as the best level, got %+v", top.Levels)
+	}
+	if report.Parameters[1].Field != "mutation_rate" || report.Parameters[1].Levels[0].Value != "0.5" {
+		t.Fatalf("expected mutation_rate second with 0.5 best, got %+v", report.Parameters[1])
+	}
+	total := 0.0
+	for _, parameter := range report.Parameters {
+		total += parameter.Importance
+	}
+	// The grid is balanced, so the main effects are orthogonal and the
+	// additive surrogate explains all the variance.
+	if math.Abs(total-1) > 1e-9 || math.Abs(report.Explained-1) > 1e-9 {
+		t.Fatalf("expected importances to sum to the explained variance 1, got total=%f explained=%f", total, report.Explained)
+	}
+	if top.Importance < 0.9 {
+		t.Fatalf("expected population to dominate, got %f", top.Importance)
+	}
+}
+
+func TestParameterImportanceWithoutVariation(t *testing.T) {
+	report := parameterImportance([]map[string]string{{"population": "10"}, {"population": "10"}}, []float64{1, 2})
+	if len(report.Parameters) != 0 || report.Explained != 0 || report.FitnessMean != 1.5 {
+		t.Fatalf("expected no factors for constant configs, got %+v", report)
+	}
+}
@@ -0,0 +1,67 @@
+package stats
+
+import (
+	"io/fs"
+	"path/filepath"
+)
+
+// RunResources records what a run cost. CPU time and peak RSS are sampled
+// from the whole process, so runs sharing a process (e.g. a server running
+// several at once) are attributed each other's usage; peak RSS is the
+// process high-water mark when the run finished, not a per-run delta.
+type RunResources struct {
+	CPUSeconds   float64 `json:"cpu_seconds"`
+	PeakRSSBytes int64   `json:"peak_rss_bytes,omitempty"`
+	Evaluations  int     `json:"evaluations"`
+	StorageBytes int64   `json:"storage_bytes"`
+	// EvaluationCost is present when the run metered its evaluations.
+	EvaluationCost *EvaluationCost `json:"evaluation_cost,omitempty"`
+}
+
+// EvaluationCost aggregates a run's metered scape evaluations: CPU time is
+// per evaluating thread where the platform reports it, allocations are
+// process-wide deltas. MaxCPUSeconds is the highest per-evaluation mean of
+// any one genome.
+type EvaluationCost struct {
+	Scape          string  `json:"scape"`
+	Evaluations    int     `json:"evaluations"`
+	CPUSeconds     float64 `json:"cpu_seconds"`
+	AllocBytes     uint64  `json:"alloc_bytes"`
+	MeanCPUSeconds float64 `json:"mean_cpu_seconds"`
+	MaxCPUSeconds  float64 `json:"max_cpu_seconds"`
+	MeanAllocBytes float64 `json:"mean_alloc_bytes"`
+}
+
+// ProcessUsage is a sample of the process's cumulative resource usage.
+type ProcessUsage struct {
+	CPUSeconds   float64
+	PeakRSSBytes int64
+}
+
+// CPUSince returns the CPU seconds spent since start.
+func (u ProcessUsage) CPUSince(start ProcessUsage) float64 {
+	if u.CPUSeconds < start.CPUSeconds {
+		return 0
+	}
+	return u.CPUSeconds - start.CPUSeconds
+}
+
+// DirBytes returns the total size of the regular files under dir.
+func DirBytes(dir string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(dir, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		total += info.Size()
+		return nil
+	})
+	return total, err
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package stats
+
+// SampleProcessUsage reads the process's user+system CPU time and peak
+// resident set size. ok is false when the platform does not report them.
+func SampleProcessUsage() (ProcessUsage, bool) {
+	return ProcessUsage{}, false
+}
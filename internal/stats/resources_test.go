@@ -0,0 +1,55 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestDirBytesSumsNestedFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.json"), make([]byte, 10), 0o644); err != nil {
+		t.Fatalf("write a: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "replay"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "replay", "b.svg"), make([]byte, 32), 0o644); err != nil {
+		t.Fatalf("write b: %v", err)
+	}
+	got, err := DirBytes(dir)
+	if err != nil {
+		t.Fatalf("dir bytes: %v", err)
+	}
+	if got != 42 {
+		t.Fatalf("expected 42 bytes, got %d", got)
+	}
+	if _, err := DirBytes(filepath.Join(dir, "missing")); err == nil {
+		t.Fatal("expected missing directory to fail")
+	}
+}
+
+func TestSampleProcessUsageIsMonotonic(t *testing.T) {
+	start, ok := SampleProcessUsage()
+	if !ok {
+		if runtime.GOOS == "linux" || runtime.GOOS == "darwin" {
+			t.Fatal("expected process usage on unix")
+		}
+		t.Skip("process usage unavailable on this platform")
+	}
+	sum := 0
+	for i := 0; i < 5_000_000; i++ {
+		sum += i % 7
+	}
+	end, _ := SampleProcessUsage()
+	if end.CPUSince(start) < 0 || sum == 0 {
+		t.Fatalf("expected non-negative cpu delta, got %f", end.CPUSince(start))
+	}
+	if end.PeakRSSBytes <= 0 || end.PeakRSSBytes < start.PeakRSSBytes {
+		t.Fatalf("expected non-decreasing peak rss, got %d then %d", start.PeakRSSBytes, end.PeakRSSBytes)
+	}
+	if (ProcessUsage{CPUSeconds: 1}).CPUSince(ProcessUsage{CPUSeconds: 2}) != 0 {
+		t.Fatal("expected a clock going backwards to clamp to zero")
+	}
+}
@@ -0,0 +1,24 @@
+//go:build unix
+
+package stats
+
+import (
+	"runtime"
+	"syscall"
+)
+
+// SampleProcessUsage reads the process's user+system CPU time and peak
+// resident set size. ok is false when the platform does not report them.
+func SampleProcessUsage() (ProcessUsage, bool) {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return ProcessUsage{}, false
+	}
+	cpu := float64(usage.Utime.Sec+usage.Stime.Sec) + float64(usage.Utime.Usec+usage.Stime.Usec)/1e6
+	peak := int64(usage.Maxrss)
+	if runtime.GOOS != "darwin" {
+		// Linux and the BSDs report kilobytes, Darwin bytes.
+		peak *= 1024
+	}
+	return ProcessUsage{CPUSeconds: cpu, PeakRSSBytes: peak}, true
+}
@@ -0,0 +1,139 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"protogonos/internal/model"
+)
+
+// SpeciesHistoryKeyframeInterval is how many generations apart stored
+// species histories repeat the full species list.
+const SpeciesHistoryKeyframeInterval = 100
+
+// SpeciesHistoryFormatDelta marks a species history stored as deltas.
+const SpeciesHistoryFormatDelta = "delta"
+
+// SpeciesHistoryDeltas is a species history stored as deltas. The first
+// generation and every KeyframeInterval-th one after it are keyframes that
+// list every species; the others list only the species added or changed
+// since the previous generation and the keys of those removed.
+type SpeciesHistoryDeltas struct {
+	Format           string                   `json:"format"`
+	KeyframeInterval int                      `json:"keyframe_interval"`
+	Generations      []SpeciesGenerationDelta `json:"generations"`
+}
+
+type SpeciesGenerationDelta struct {
+	Generation     int                    `json:"generation"`
+	Keyframe       bool                   `json:"keyframe,omitempty"`
+	Species        []model.SpeciesMetrics `json:"species,omitempty"`
+	Removed        []string               `json:"removed,omitempty"`
+	NewSpecies     []string               `json:"new_species,omitempty"`
+	ExtinctSpecies []string               `json:"extinct_species,omitempty"`
+}
+
+// DeltaEncodeSpeciesHistory stores history as deltas with a keyframe every
+// keyframeInterval generations; a non-positive interval uses
+// SpeciesHistoryKeyframeInterval.
+func DeltaEncodeSpeciesHistory(history []model.SpeciesGeneration, keyframeInterval int) SpeciesHistoryDeltas {
+	if keyframeInterval <= 0 {
+		keyframeInterval = SpeciesHistoryKeyframeInterval
+	}
+	out := SpeciesHistoryDeltas{
+		Format:           SpeciesHistoryFormatDelta,
+		KeyframeInterval: keyframeInterval,
+		Generations:      make([]SpeciesGenerationDelta, 0, len(history)),
+	}
+	previous := map[string]model.SpeciesMetrics{}
+	for i, generation := range history {
+		delta := SpeciesGenerationDelta{
+			Generation:     generation.Generation,
+			Keyframe:       i%keyframeInterval == 0,
+			NewSpecies:     generation.NewSpecies,
+			ExtinctSpecies: generation.ExtinctSpecies,
+		}
+		current := make(map[string]model.SpeciesMetrics, len(generation.Species))
+		for _, species := range generation.Species {
+			current[species.Key] = species
+			if prior, ok := previous[species.Key]; delta.Keyframe || !ok || !reflect.DeepEqual(prior, species) {
+				delta.Species = append(delta.Species, species)
+			}
+		}
+		if !delta.Keyframe {
+			for key := range previous {
+				if _, ok := current[key]; !ok {
+					delta.Removed = append(delta.Removed, key)
+				}
+			}
+			sort.Strings(delta.Removed)
+		}
+		out.Generations = append(out.Generations, delta)
+		previous = current
+	}
+	return out
+}
+
+// Expand reconstructs the full per-generation species lists, each ordered
+// by species key as the population monitor records them.
+func (d SpeciesHistoryDeltas) Expand() ([]model.SpeciesGeneration, error) {
+	history := make([]model.SpeciesGeneration, 0, len(d.Generations))
+	current := map[string]model.SpeciesMetrics{}
+	for i, delta := range d.Generations {
+		if delta.Keyframe {
+			current = make(map[string]model.SpeciesMetrics, len(delta.Species))
+		} else if i == 0 {
+			return nil, fmt.Errorf("species history starts at generation %d without a keyframe", delta.Generation)
+		}
+		for _, key := range delta.Removed {
+			delete(current, key)
+		}
+		for _, species := range delta.Species {
+			current[species.Key] = species
+		}
+		keys := make([]string, 0, len(current))
+		for key := range current {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		species := make([]model.SpeciesMetrics, 0, len(keys))
+		for _, key := range keys {
+			species = append(species, current[key])
+		}
+		history = append(history, model.SpeciesGeneration{
+			Generation:     delta.Generation,
+			Species:        species,
+			NewSpecies:     delta.NewSpecies,
+			ExtinctSpecies: delta.ExtinctSpecies,
+		})
+	}
+	return history, nil
+}
+
+// MarshalSpeciesHistory encodes history in the delta format.
+func MarshalSpeciesHistory(history []model.SpeciesGeneration) ([]byte, error) {
+	return json.Marshal(DeltaEncodeSpeciesHistory(history, SpeciesHistoryKeyframeInterval))
+}
+
+// UnmarshalSpeciesHistory decodes a species history stored either as deltas
+// or as the full per-generation list earlier runs wrote.
+func UnmarshalSpeciesHistory(data []byte) ([]model.SpeciesGeneration, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '{' {
+		var history []model.SpeciesGeneration
+		if err := json.Unmarshal(data, &history); err != nil {
+			return nil, err
+		}
+		return history, nil
+	}
+	var deltas SpeciesHistoryDeltas
+	if err := json.Unmarshal(data, &deltas); err != nil {
+		return nil, err
+	}
+	if deltas.Format != SpeciesHistoryFormatDelta {
+		return nil, fmt.Errorf("unsupported species history format: %q", deltas.Format)
+	}
+	return deltas.Expand()
+}
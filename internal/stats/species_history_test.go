@@ -0,0 +1,132 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func speciesHistoryFixture(generations int) []model.SpeciesGeneration {
+	history := make([]model.SpeciesGeneration, 0, generations)
+	for gen := 1; gen <= generations; gen++ {
+		species := []model.SpeciesMetrics{
+			{Key: "sp-a", Size: 4, MeanFitness: 0.5, BestFitness: 0.75},
+		}
+		if gen%3 != 0 {
+			species = append(species, model.SpeciesMetrics{Key: "sp-b", Size: 2, MeanFitness: float64(gen) / 10, BestFitness: float64(gen) / 5})
+		}
+		generation := model.SpeciesGeneration{Generation: gen, Species: species}
+		switch gen % 3 {
+		case 0:
+			generation.ExtinctSpecies = []string{"sp-b"}
+		case 1:
+			generation.NewSpecies = []string{"sp-b"}
+		}
+		history = append(history, generation)
+	}
+	return history
+}
+
+func TestSpeciesHistoryDeltasRoundTrip(t *testing.T) {
+	history := speciesHistoryFixture(10)
+	deltas := DeltaEncodeSpeciesHistory(history, 4)
+	for i, delta := range deltas.Generations {
+		if delta.Keyframe != (i%4 == 0) {
+			t.Fatalf("generation %d: keyframe=%t", delta.Generation, delta.Keyframe)
+		}
+	}
+	// Generation 2 only changes sp-b; generation 3 removes it.
+	if got := deltas.Generations[1].Species; len(got) != 1 || got[0].Key != "sp-b" {
+		t.Fatalf("expected only the changed species in generation 2, got %+v", got)
+	}
+	if got := deltas.Generations[2]; len(got.Species) != 0 || !reflect.DeepEqual(got.Removed, []string{"sp-b"}) {
+		t.Fatalf("expected generation 3 to remove sp-b only, got %+v", got)
+	}
+
+	expanded, err := deltas.Expand()
+	if err != nil {
+		t.Fatalf("expand: %v", err)
+	}
+	if !reflect.DeepEqual(expanded, history) {
+		t.Fatalf("expanded history differs:\ngot  %+v\nwant %+v", expanded, history)
+	}
+
+	data, err := MarshalSpeciesHistory(history)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	decoded, err := UnmarshalSpeciesHistory(data)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, history) {
+		t.Fatalf("decoded history differs:\ngot  %+v\nwant %+v", decoded, history)
+	}
+}
+
+func TestUnmarshalSpeciesHistoryReadsLegacyLists(t *testing.T) {
+	history := speciesHistoryFixture(3)
+	legacy, err := json.Marshal(history)
+	if err != nil {
+		t.Fatalf("marshal legacy: %v", err)
+	}
+	decoded, err := UnmarshalSpeciesHistory(legacy)
+	if err != nil {
+		t.Fatalf("unmarshal legacy: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, history) {
+		t.Fatalf("legacy history differs: %+v", decoded)
+	}
+
+	if _, err := UnmarshalSpeciesHistory([]byte(`{"format":"sparse","generations":[]}`)); err == nil {
+		t.Fatal("expected an unknown format to be rejected")
+	}
+	if _, err := UnmarshalSpeciesHistory([]byte(`{"format":"delta","generations":[{"generation":4}]}`)); err == nil {
+		t.Fatal("expected a history without a leading keyframe to be rejected")
+	}
+}
+
+func TestSpeciesHistoryArtifactStoresDeltas(t *testing.T) {
+	base := t.TempDir()
+	history := make([]model.SpeciesGeneration, 0, 500)
+	for gen := 1; gen <= 500; gen++ {
+		species := make([]model.SpeciesMetrics, 0, 20)
+		for i := 0; i < 20; i++ {
+			species = append(species, model.SpeciesMetrics{Key: fmt.Sprintf("sp-%02d", i), Size: 5, MeanFitness: 0.5, BestFitness: 0.9})
+		}
+		// One species improves every generation; the rest are stable.
+		species[gen%20].BestFitness = float64(gen)
+		history = append(history, model.SpeciesGeneration{Generation: gen, Species: species})
+	}
+	if _, err := WriteRunArtifacts(base, RunArtifacts{
+		Config:         RunConfig{RunID: "species-delta"},
+		SpeciesHistory: history,
+	}); err != nil {
+		t.Fatalf("write artifacts: %v", err)
+	}
+
+	stored, err := os.ReadFile(filepath.Join(base, "species-delta", "species_history.json"))
+	if err != nil {
+		t.Fatalf("read species history: %v", err)
+	}
+	full, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal full history: %v", err)
+	}
+	if len(stored)*4 > len(full) {
+		t.Fatalf("expected deltas to be under a quarter of the full history: %d vs %d bytes", len(stored), len(full))
+	}
+
+	read, ok, err := ReadSpeciesHistory(base, "species-delta")
+	if err != nil || !ok {
+		t.Fatalf("read species history: ok=%t err=%v", ok, err)
+	}
+	if !reflect.DeepEqual(read, history) {
+		t.Fatal("species history read back differs from the one written")
+	}
+}
@@ -0,0 +1,227 @@
+package stats
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// TraceStreamFile is the append-only JSONL trace written next to the other
+// per-run artifacts while a run is in progress.
+const TraceStreamFile = "trace.jsonl"
+
+const (
+	TraceRecordGeneration = "generation"
+	TraceRecordUpdate     = "update"
+	TraceRecordEnd        = "end"
+)
+
+// TraceStreamUpdate is the stream form of a monitor trace update emitted at
+// TraceStepSize cadence.
+type TraceStreamUpdate struct {
+	Reason           string  `json:"reason"`
+	TotalEvaluations int     `json:"total_evaluations"`
+	GoalReached      bool    `json:"goal_reached"`
+	StepEvaluations  int     `json:"step_evaluations,omitempty"`
+	Generation       int     `json:"generation"`
+	BestFitness      float64 `json:"best_fitness"`
+	MeanFitness      float64 `json:"mean_fitness"`
+	SpeciesCount     int     `json:"species_count"`
+}
+
+// TraceStreamRecord is one line of trace.jsonl. Exactly one payload field is
+// set, matching Kind.
+type TraceStreamRecord struct {
+	Seq        int                `json:"seq"`
+	Kind       string             `json:"kind"`
+	Generation *TraceGeneration   `json:"generation,omitempty"`
+	Update     *TraceStreamUpdate `json:"update,omitempty"`
+}
+
+// TraceStreamSummary is the state reconstructed from a trace stream.
+type TraceStreamSummary struct {
+	Records    int
+	TraceAcc   []TraceGeneration
+	LastUpdate *TraceStreamUpdate
+	Complete   bool
+}
+
+// TraceStreamWriter appends trace records to a JSONL file. Each record is
+// written with a single write call so readers tailing the file only ever see
+// whole lines or a trailing partial line.
+type TraceStreamWriter struct {
+	mu   sync.Mutex
+	file *os.File
+	seq  int
+	err  error
+}
+
+// OpenTraceStream creates (or truncates) runDir/trace.jsonl for a new run.
+func OpenTraceStream(runDir string) (*TraceStreamWriter, error) {
+	if err := os.MkdirAll(runDir, 0o755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(filepath.Join(runDir, TraceStreamFile), os.O_CREATE|os.O_WRONLY|os.O_TRUNC|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &TraceStreamWriter{file: file}, nil
+}
+
+// ResumeTraceStream rewrites runDir/trace.jsonl for a run resumed from a
+// checkpoint: the stream restarts with a generation record for each entry of
+// traceAcc, the trace the checkpoint carries, so records the interrupted run
+// wrote past its checkpoint are dropped, and the resumed run appends after
+// them.
+func ResumeTraceStream(runDir string, traceAcc []TraceGeneration) (*TraceStreamWriter, error) {
+	writer, err := OpenTraceStream(runDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, generation := range traceAcc {
+		if err := writer.AppendGeneration(generation); err != nil {
+			_ = writer.Close()
+			return nil, err
+		}
+	}
+	return writer, nil
+}
+
+func (w *TraceStreamWriter) AppendGeneration(generation TraceGeneration) error {
+	return w.append(TraceStreamRecord{Kind: TraceRecordGeneration, Generation: &generation})
+}
+
+func (w *TraceStreamWriter) AppendUpdate(update TraceStreamUpdate) error {
+	return w.append(TraceStreamRecord{Kind: TraceRecordUpdate, Update: &update})
+}
+
+// Finish appends the end marker and closes the stream. It reports the first
+// write error seen by earlier appends, which hooks are free to ignore.
+func (w *TraceStreamWriter) Finish() error {
+	if err := w.append(TraceStreamRecord{Kind: TraceRecordEnd}); err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// Close closes the stream without writing an end marker, leaving it readable
+// as an incomplete (interrupted) run.
+func (w *TraceStreamWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return w.err
+	}
+	err := w.file.Close()
+	w.file = nil
+	if w.err != nil {
+		return w.err
+	}
+	return err
+}
+
+func (w *TraceStreamWriter) append(record TraceStreamRecord) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return w.err
+	}
+	if w.file == nil {
+		return errors.New("trace stream is closed")
+	}
+	w.seq++
+	record.Seq = w.seq
+	line, err := json.Marshal(record)
+	if err != nil {
+		w.err = err
+		return err
+	}
+	line = append(line, '\n')
+	if _, err := w.file.Write(line); err != nil {
+		w.err = err
+		return err
+	}
+	return nil
+}
+
+// ReadTraceStream decodes every complete line of a trace stream. A trailing
+// line without a newline is treated as a write still in progress and ignored.
+func ReadTraceStream(path string) ([]TraceStreamRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	var records []TraceStreamRecord
+	for lineNo := 1; ; lineNo++ {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		var record TraceStreamRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			return nil, fmt.Errorf("decode %s line %d: %w", path, lineNo, err)
+		}
+		records = append(records, record)
+	}
+}
+
+// SummarizeTraceStream folds stream records into the accumulated trace and the
+// most recent step update.
+func SummarizeTraceStream(records []TraceStreamRecord) TraceStreamSummary {
+	summary := TraceStreamSummary{Records: len(records)}
+	for _, record := range records {
+		switch record.Kind {
+		case TraceRecordGeneration:
+			if record.Generation != nil {
+				summary.TraceAcc = append(summary.TraceAcc, *record.Generation)
+			}
+		case TraceRecordUpdate:
+			if record.Update != nil {
+				update := *record.Update
+				summary.LastUpdate = &update
+			}
+		case TraceRecordEnd:
+			summary.Complete = true
+		}
+	}
+	return summary
+}
+
+// ReadTraceSummary reconstructs a run's trace from trace.jsonl, falling back
+// to the legacy trace_acc.json for runs written before streaming existed.
+func ReadTraceSummary(baseDir, runID string) (TraceStreamSummary, bool, error) {
+	records, err := ReadTraceStream(filepath.Join(baseDir, runID, TraceStreamFile))
+	if err == nil {
+		return SummarizeTraceStream(records), true, nil
+	}
+	if !os.IsNotExist(err) {
+		return TraceStreamSummary{}, false, err
+	}
+	traceAcc, ok, err := readLegacyTraceAcc(baseDir, runID)
+	if err != nil || !ok {
+		return TraceStreamSummary{}, ok, err
+	}
+	return TraceStreamSummary{TraceAcc: traceAcc, Complete: true}, true, nil
+}
+
+func isTraceStreamPath(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".jsonl")
+}
@@ -0,0 +1,104 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTraceStreamTailAndSummary(t *testing.T) {
+	baseDir := t.TempDir()
+	runDir := filepath.Join(baseDir, "run-stream")
+	writer, err := OpenTraceStream(runDir)
+	if err != nil {
+		t.Fatalf("open trace stream: %v", err)
+	}
+	if err := writer.AppendUpdate(TraceStreamUpdate{Reason: "step", TotalEvaluations: 10, Generation: 1, BestFitness: 0.5}); err != nil {
+		t.Fatalf("append update: %v", err)
+	}
+	if err := writer.AppendGeneration(TraceGeneration{Generation: 1, Stats: []TraceStatEntry{{SpeciesKey: "sp-1", BestFitness: 0.5}}}); err != nil {
+		t.Fatalf("append generation: %v", err)
+	}
+
+	// A live reader sees the records written so far and no end marker.
+	summary, ok, err := ReadTraceSummary(baseDir, "run-stream")
+	if err != nil || !ok {
+		t.Fatalf("read live summary: ok=%t err=%v", ok, err)
+	}
+	if summary.Complete || summary.Records != 2 || len(summary.TraceAcc) != 1 {
+		t.Fatalf("unexpected live summary: %+v", summary)
+	}
+	if summary.LastUpdate == nil || summary.LastUpdate.TotalEvaluations != 10 {
+		t.Fatalf("unexpected last update: %+v", summary.LastUpdate)
+	}
+
+	// A torn trailing write is ignored rather than failing the reader.
+	path := filepath.Join(runDir, TraceStreamFile)
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatalf("open for partial write: %v", err)
+	}
+	if _, err := file.WriteString(`{"seq":3,"kind":"gene`); err != nil {
+		t.Fatalf("partial write: %v", err)
+	}
+	_ = file.Close()
+	records, err := ReadTraceStream(path)
+	if err != nil {
+		t.Fatalf("read stream with partial line: %v", err)
+	}
+	if len(records) != 2 || records[1].Seq != 2 {
+		t.Fatalf("unexpected records with partial line: %+v", records)
+	}
+
+	writer, err = OpenTraceStream(runDir)
+	if err != nil {
+		t.Fatalf("reopen trace stream: %v", err)
+	}
+	for gen := 1; gen <= 3; gen++ {
+		if err := writer.AppendGeneration(TraceGeneration{Generation: gen}); err != nil {
+			t.Fatalf("append generation %d: %v", gen, err)
+		}
+	}
+	if err := writer.Finish(); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+	traceAcc, ok, err := ReadTraceAcc(baseDir, "run-stream")
+	if err != nil || !ok {
+		t.Fatalf("read trace acc from stream: ok=%t err=%v", ok, err)
+	}
+	if len(traceAcc) != 3 || traceAcc[2].Generation != 3 {
+		t.Fatalf("unexpected trace acc from stream: %+v", traceAcc)
+	}
+	fromFile, err := ReadTraceAccFile(path)
+	if err != nil {
+		t.Fatalf("read trace acc file: %v", err)
+	}
+	if len(fromFile) != 3 {
+		t.Fatalf("unexpected trace acc file generations: %d", len(fromFile))
+	}
+	summary, _, err = ReadTraceSummary(baseDir, "run-stream")
+	if err != nil || !summary.Complete {
+		t.Fatalf("expected complete summary, got %+v err=%v", summary, err)
+	}
+}
+
+func TestReadTraceSummaryFallsBackToLegacyTraceAcc(t *testing.T) {
+	baseDir := t.TempDir()
+	runDir := filepath.Join(baseDir, "legacy")
+	if err := os.MkdirAll(runDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := writeJSON(filepath.Join(runDir, "trace_acc.json"), []TraceGeneration{{Generation: 1}, {Generation: 2}}); err != nil {
+		t.Fatalf("write legacy trace: %v", err)
+	}
+	summary, ok, err := ReadTraceSummary(baseDir, "legacy")
+	if err != nil || !ok {
+		t.Fatalf("read legacy summary: ok=%t err=%v", ok, err)
+	}
+	if !summary.Complete || len(summary.TraceAcc) != 2 {
+		t.Fatalf("unexpected legacy summary: %+v", summary)
+	}
+	if _, ok, err := ReadTraceSummary(baseDir, "missing"); err != nil || ok {
+		t.Fatalf("expected missing run to report not found, ok=%t err=%v", ok, err)
+	}
+}
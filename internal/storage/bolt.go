@@ -0,0 +1,509 @@
+//go:build bolt
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"protogonos/internal/model"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// BoltStore persists records in a single bbolt file, one bucket per record
+// type keyed like the sqlite tables, holding the same codec payloads. It
+// needs neither cgo nor a server, for headless machines.
+type BoltStore struct {
+	path string
+
+	mu sync.RWMutex
+	db *bolt.DB
+}
+
+func NewBoltStore(path string) *BoltStore {
+	return &BoltStore{path: path}
+}
+
+// boltBuckets are the buckets of a bolt store: the keyed record types
+// followed by the run-keyed ones.
+var boltBuckets = append([]string{"genomes", "populations", "scape_summaries", "leaderboards"}, runTables...)
+
+func (s *BoltStore) Init(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.path == "" {
+		return errors.New("bolt path is required")
+	}
+	if s.db != nil {
+		return nil
+	}
+
+	db, err := openBolt(s.path)
+	if err != nil {
+		return err
+	}
+	s.db = db
+	return nil
+}
+
+func openBolt(path string) (*bolt.DB, error) {
+	// A second process holding the file fails after the timeout instead of
+	// blocking forever.
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range boltBuckets {
+			if _, err := tx.CreateBucketIfNotExists([]byte(name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+func (s *BoltStore) Reset(ctx context.Context) error {
+	s.mu.Lock()
+	path := s.path
+	db := s.db
+	s.db = nil
+	s.mu.Unlock()
+
+	if db != nil {
+		if err := db.Close(); err != nil {
+			return err
+		}
+	}
+	if path == "" {
+		return errors.New("bolt path is required")
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return s.Init(ctx)
+}
+
+func (s *BoltStore) SaveGenome(_ context.Context, genome model.Genome) error {
+	payload, err := EncodeGenome(genome)
+	if err != nil {
+		return err
+	}
+	return s.put("genomes", genome.ID, payload)
+}
+
+func (s *BoltStore) GetGenome(_ context.Context, id string) (model.Genome, bool, error) {
+	payload, ok, err := s.get("genomes", id)
+	if err != nil || !ok {
+		return model.Genome{}, false, err
+	}
+	genome, err := DecodeGenome(payload)
+	if err != nil {
+		return model.Genome{}, false, fmt.Errorf("decode genome %s: %w", id, err)
+	}
+	return genome, true, nil
+}
+
+func (s *BoltStore) DeleteGenome(_ context.Context, id string) error {
+	return s.delete("genomes", id)
+}
+
+func (s *BoltStore) SavePopulation(_ context.Context, population model.Population) error {
+	payload, err := EncodePopulation(population)
+	if err != nil {
+		return err
+	}
+	return s.put("populations", population.ID, payload)
+}
+
+func (s *BoltStore) GetPopulation(_ context.Context, id string) (model.Population, bool, error) {
+	payload, ok, err := s.get("populations", id)
+	if err != nil || !ok {
+		return model.Population{}, false, err
+	}
+	population, err := DecodePopulation(payload)
+	if err != nil {
+		return model.Population{}, false, fmt.Errorf("decode population %s: %w", id, err)
+	}
+	return population, true, nil
+}
+
+func (s *BoltStore) DeletePopulation(_ context.Context, id string) error {
+	return s.delete("populations", id)
+}
+
+func (s *BoltStore) SaveScapeSummary(_ context.Context, summary model.ScapeSummary) error {
+	payload, err := EncodeScapeSummary(summary)
+	if err != nil {
+		return err
+	}
+	return s.put("scape_summaries", summary.Name, payload)
+}
+
+func (s *BoltStore) GetScapeSummary(_ context.Context, name string) (model.ScapeSummary, bool, error) {
+	payload, ok, err := s.get("scape_summaries", name)
+	if err != nil || !ok {
+		return model.ScapeSummary{}, false, err
+	}
+	summary, err := DecodeScapeSummary(payload)
+	if err != nil {
+		return model.ScapeSummary{}, false, fmt.Errorf("decode scape summary %s: %w", name, err)
+	}
+	return summary, true, nil
+}
+
+func (s *BoltStore) SaveFitnessHistory(_ context.Context, runID string, history []float64) error {
+	payload, err := EncodeFitnessHistory(history)
+	if err != nil {
+		return err
+	}
+	return s.put("fitness_history", runID, payload)
+}
+
+func (s *BoltStore) GetFitnessHistory(_ context.Context, runID string) ([]float64, bool, error) {
+	payload, ok, err := s.get("fitness_history", runID)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	history, err := DecodeFitnessHistory(payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("decode fitness history %s: %w", runID, err)
+	}
+	return history, true, nil
+}
+
+func (s *BoltStore) SaveGenerationDiagnostics(_ context.Context, runID string, diagnostics []model.GenerationDiagnostics) error {
+	payload, err := EncodeGenerationDiagnostics(diagnostics)
+	if err != nil {
+		return err
+	}
+	return s.put("generation_diagnostics", runID, payload)
+}
+
+func (s *BoltStore) GetGenerationDiagnostics(_ context.Context, runID string) ([]model.GenerationDiagnostics, bool, error) {
+	payload, ok, err := s.get("generation_diagnostics", runID)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	diagnostics, err := DecodeGenerationDiagnostics(payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("decode generation diagnostics %s: %w", runID, err)
+	}
+	return diagnostics, true, nil
+}
+
+func (s *BoltStore) SaveTopGenomes(_ context.Context, runID string, top []model.TopGenomeRecord) error {
+	payload, err := EncodeTopGenomes(top)
+	if err != nil {
+		return err
+	}
+	return s.put("top_genomes", runID, payload)
+}
+
+func (s *BoltStore) GetTopGenomes(_ context.Context, runID string) ([]model.TopGenomeRecord, bool, error) {
+	payload, ok, err := s.get("top_genomes", runID)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	top, err := DecodeTopGenomes(payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("decode top genomes %s: %w", runID, err)
+	}
+	return top, true, nil
+}
+
+func (s *BoltStore) SaveSpeciesHistory(_ context.Context, runID string, history []model.SpeciesGeneration) error {
+	payload, err := EncodeSpeciesHistory(history)
+	if err != nil {
+		return err
+	}
+	return s.put("species_history", runID, payload)
+}
+
+func (s *BoltStore) GetSpeciesHistory(_ context.Context, runID string) ([]model.SpeciesGeneration, bool, error) {
+	payload, ok, err := s.get("species_history", runID)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	history, err := DecodeSpeciesHistory(payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("decode species history %s: %w", runID, err)
+	}
+	return history, true, nil
+}
+
+func (s *BoltStore) SaveLineage(_ context.Context, runID string, lineage []model.LineageRecord) error {
+	payload, err := EncodeLineage(lineage)
+	if err != nil {
+		return err
+	}
+	return s.put("lineage", runID, payload)
+}
+
+func (s *BoltStore) GetLineage(_ context.Context, runID string) ([]model.LineageRecord, bool, error) {
+	payload, ok, err := s.get("lineage", runID)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	lineage, err := DecodeLineage(payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("decode lineage %s: %w", runID, err)
+	}
+	return lineage, true, nil
+}
+
+func (s *BoltStore) SaveInnovations(_ context.Context, runID string, records []model.InnovationRecord) error {
+	payload, err := EncodeInnovations(records)
+	if err != nil {
+		return err
+	}
+	return s.put("innovations", runID, payload)
+}
+
+func (s *BoltStore) GetInnovations(_ context.Context, runID string) ([]model.InnovationRecord, bool, error) {
+	payload, ok, err := s.get("innovations", runID)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	records, err := DecodeInnovations(payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("decode innovations %s: %w", runID, err)
+	}
+	return records, true, nil
+}
+
+func (s *BoltStore) SaveCheckpoint(_ context.Context, checkpoint model.RunCheckpoint) error {
+	payload, err := EncodeCheckpoint(checkpoint)
+	if err != nil {
+		return err
+	}
+	return s.put("checkpoints", checkpoint.RunID, payload)
+}
+
+func (s *BoltStore) GetCheckpoint(_ context.Context, runID string) (model.RunCheckpoint, bool, error) {
+	payload, ok, err := s.get("checkpoints", runID)
+	if err != nil || !ok {
+		return model.RunCheckpoint{}, false, err
+	}
+	checkpoint, err := DecodeCheckpoint(payload)
+	if err != nil {
+		return model.RunCheckpoint{}, false, fmt.Errorf("decode checkpoint %s: %w", runID, err)
+	}
+	return checkpoint, true, nil
+}
+
+func (s *BoltStore) DeleteCheckpoint(_ context.Context, runID string) error {
+	return s.delete("checkpoints", runID)
+}
+
+func (s *BoltStore) SaveLeaderboard(_ context.Context, scape string, entries []model.LeaderboardEntry) error {
+	payload, err := EncodeLeaderboard(entries)
+	if err != nil {
+		return err
+	}
+	return s.put("leaderboards", scape, payload)
+}
+
+func (s *BoltStore) GetLeaderboard(_ context.Context, scape string) ([]model.LeaderboardEntry, bool, error) {
+	payload, ok, err := s.get("leaderboards", scape)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	entries, err := DecodeLeaderboard(payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("decode leaderboard %s: %w", scape, err)
+	}
+	return entries, true, nil
+}
+
+func (s *BoltStore) ListGenomeIDs(_ context.Context) ([]string, error) {
+	return s.keys("genomes")
+}
+
+func (s *BoltStore) ListPopulationIDs(_ context.Context) ([]string, error) {
+	return s.keys("populations")
+}
+
+func (s *BoltStore) ListRunIDs(_ context.Context) ([]string, error) {
+	seen := map[string]bool{}
+	for _, bucket := range []string{"fitness_history", "generation_diagnostics", "species_history", "top_genomes", "lineage", "innovations", "checkpoints"} {
+		keys, err := s.keys(bucket)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			seen[key] = true
+		}
+	}
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (s *BoltStore) DeleteRunData(_ context.Context, runID string) error {
+	db, err := s.getDB()
+	if err != nil {
+		return err
+	}
+	return db.Update(func(tx *bolt.Tx) error {
+		for _, name := range runTables {
+			if err := tx.Bucket([]byte(name)).Delete([]byte(runID)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Vacuum rewrites the file without the free pages deleted records left
+// behind; bbolt reuses them but never shrinks the file on its own.
+func (s *BoltStore) Vacuum(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.db == nil {
+		return errors.New("store is not initialized")
+	}
+	compactPath := s.path + ".compact"
+	_ = os.Remove(compactPath)
+	dst, err := bolt.Open(compactPath, 0o600, nil)
+	if err != nil {
+		return err
+	}
+	if err := bolt.Compact(dst, s.db, 0); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(compactPath)
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		_ = os.Remove(compactPath)
+		return err
+	}
+	if err := s.db.Close(); err != nil {
+		return err
+	}
+	s.db = nil
+	if err := os.Rename(compactPath, s.path); err != nil {
+		return err
+	}
+	db, err := openBolt(s.path)
+	if err != nil {
+		return err
+	}
+	s.db = db
+	return nil
+}
+
+func (s *BoltStore) IntegrityCheck(_ context.Context) ([]string, error) {
+	db, err := s.getDB()
+	if err != nil {
+		return nil, err
+	}
+	var findings []string
+	err = db.View(func(tx *bolt.Tx) error {
+		for checkErr := range tx.Check() {
+			findings = append(findings, checkErr.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(findings) == 0 {
+		findings = []string{"ok"}
+	}
+	return findings, nil
+}
+
+func (s *BoltStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.db == nil {
+		return nil
+	}
+	err := s.db.Close()
+	s.db = nil
+	return err
+}
+
+func (s *BoltStore) getDB() (*bolt.DB, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.db == nil {
+		return nil, errors.New("store is not initialized")
+	}
+	return s.db, nil
+}
+
+func (s *BoltStore) put(bucket, key string, payload []byte) error {
+	db, err := s.getDB()
+	if err != nil {
+		return err
+	}
+	return db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(bucket)).Put([]byte(key), payload)
+	})
+}
+
+func (s *BoltStore) get(bucket, key string) ([]byte, bool, error) {
+	db, err := s.getDB()
+	if err != nil {
+		return nil, false, err
+	}
+	var payload []byte
+	found := false
+	err = db.View(func(tx *bolt.Tx) error {
+		// Values are only valid for the life of the transaction.
+		if value := tx.Bucket([]byte(bucket)).Get([]byte(key)); value != nil {
+			payload = append([]byte(nil), value...)
+			found = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return payload, found, nil
+}
+
+func (s *BoltStore) delete(bucket, key string) error {
+	db, err := s.getDB()
+	if err != nil {
+		return err
+	}
+	return db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(bucket)).Delete([]byte(key))
+	})
+}
+
+func (s *BoltStore) keys(bucket string) ([]string, error) {
+	db, err := s.getDB()
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	err = db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(bucket)).ForEach(func(key, _ []byte) error {
+			keys = append(keys, string(key))
+			return nil
+		})
+	})
+	return keys, err
+}
@@ -0,0 +1,168 @@
+//go:build bolt
+
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func TestBoltStoreRoundTripAndReopen(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "protogonos.bolt")
+
+	first := NewBoltStore(dbPath)
+	if err := first.Init(ctx); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	genome := model.Genome{
+		VersionedRecord: model.VersionedRecord{SchemaVersion: CurrentSchemaVersion, CodecVersion: CurrentCodecVersion},
+		ID:              "g1",
+		Neurons:         []model.Neuron{{ID: "n1", Activation: "identity", Bias: 0.5}},
+		Synapses:        []model.Synapse{{ID: "s1", From: "n1", To: "n1", Weight: 1.25, Enabled: true}},
+	}
+	if err := first.SaveGenome(ctx, genome); err != nil {
+		t.Fatalf("save genome: %v", err)
+	}
+	population := model.Population{
+		VersionedRecord: model.VersionedRecord{SchemaVersion: CurrentSchemaVersion, CodecVersion: CurrentCodecVersion},
+		ID:              "p1",
+		AgentIDs:        []string{"g1"},
+		Generation:      3,
+	}
+	if err := first.SavePopulation(ctx, population); err != nil {
+		t.Fatalf("save population: %v", err)
+	}
+	if err := first.SaveScapeSummary(ctx, model.ScapeSummary{VersionedRecord: model.VersionedRecord{SchemaVersion: CurrentSchemaVersion, CodecVersion: CurrentCodecVersion}, Name: "xor", BestFitness: 0.9}); err != nil {
+		t.Fatalf("save scape summary: %v", err)
+	}
+	if err := first.SaveSpeciesHistory(ctx, "run-1", []model.SpeciesGeneration{{Generation: 1, NewSpecies: []string{"sp-1"}}}); err != nil {
+		t.Fatalf("save species history: %v", err)
+	}
+	if err := first.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	store := NewBoltStore(dbPath)
+	if err := store.Init(ctx); err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+	loadedGenome, ok, err := store.GetGenome(ctx, "g1")
+	if err != nil || !ok {
+		t.Fatalf("get genome: ok=%t err=%v", ok, err)
+	}
+	if !reflect.DeepEqual(loadedGenome.Synapses, genome.Synapses) {
+		t.Fatalf("unexpected genome loaded: %+v", loadedGenome)
+	}
+	// Records are stored in the sqlite codec encoding.
+	want, err := EncodeGenome(genome)
+	if err != nil {
+		t.Fatalf("encode genome: %v", err)
+	}
+	if got, _, _ := store.get("genomes", "g1"); !reflect.DeepEqual(got, want) {
+		t.Fatal("expected the genome payload to use the shared codec")
+	}
+	loadedPopulation, ok, err := store.GetPopulation(ctx, "p1")
+	if err != nil || !ok || loadedPopulation.Generation != 3 {
+		t.Fatalf("unexpected population: %+v ok=%t err=%v", loadedPopulation, ok, err)
+	}
+	if summary, ok, err := store.GetScapeSummary(ctx, "xor"); err != nil || !ok || summary.BestFitness != 0.9 {
+		t.Fatalf("unexpected scape summary: %+v ok=%t err=%v", summary, ok, err)
+	}
+	if history, ok, err := store.GetSpeciesHistory(ctx, "run-1"); err != nil || !ok || len(history) != 1 {
+		t.Fatalf("unexpected species history: %+v ok=%t err=%v", history, ok, err)
+	}
+	if _, ok, err := store.GetGenome(ctx, "missing"); err != nil || ok {
+		t.Fatalf("expected a missing genome to be absent: ok=%t err=%v", ok, err)
+	}
+	if err := store.DeleteGenome(ctx, "g1"); err != nil {
+		t.Fatalf("delete genome: %v", err)
+	}
+	if _, ok, _ := store.GetGenome(ctx, "g1"); ok {
+		t.Fatal("expected deleted genome to be absent")
+	}
+
+	if err := store.Reset(ctx); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if _, ok, err := store.GetPopulation(ctx, "p1"); err != nil || ok {
+		t.Fatalf("expected reset to clear populations: ok=%t err=%v", ok, err)
+	}
+}
+
+func TestBoltStoreListDeleteRunDataAndVacuum(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "protogonos.bolt")
+	store := NewBoltStore(dbPath)
+	if err := store.Init(ctx); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+
+	if err := store.SaveGenome(ctx, model.Genome{VersionedRecord: model.VersionedRecord{SchemaVersion: CurrentSchemaVersion, CodecVersion: CurrentCodecVersion}, ID: "g1"}); err != nil {
+		t.Fatalf("save genome: %v", err)
+	}
+	if err := store.SaveFitnessHistory(ctx, "run-a", make([]float64, 4096)); err != nil {
+		t.Fatalf("save history: %v", err)
+	}
+	if err := store.SaveLineage(ctx, "run-b", []model.LineageRecord{{GenomeID: "g1"}}); err != nil {
+		t.Fatalf("save lineage: %v", err)
+	}
+	if err := store.SaveInnovations(ctx, "run-a", []model.InnovationRecord{{Key: "s:i->o", Innovation: 1}}); err != nil {
+		t.Fatalf("save innovations: %v", err)
+	}
+	if err := store.SaveLeaderboard(ctx, "xor", []model.LeaderboardEntry{{RunID: "run-a", GenomeID: "g1", Fitness: 0.5}}); err != nil {
+		t.Fatalf("save leaderboard: %v", err)
+	}
+	if leaderboard, ok, err := store.GetLeaderboard(ctx, "xor"); err != nil || !ok || len(leaderboard) != 1 {
+		t.Fatalf("unexpected leaderboard: %+v ok=%t err=%v", leaderboard, ok, err)
+	}
+	if err := store.SaveCheckpoint(ctx, model.RunCheckpoint{RunID: "run-a", Generation: 4, Config: []byte(`{}`), State: []byte(`{}`)}); err != nil {
+		t.Fatalf("save checkpoint: %v", err)
+	}
+
+	genomeIDs, err := store.ListGenomeIDs(ctx)
+	if err != nil || !reflect.DeepEqual(genomeIDs, []string{"g1"}) {
+		t.Fatalf("unexpected genome ids: %v err=%v", genomeIDs, err)
+	}
+	runIDs, err := store.ListRunIDs(ctx)
+	if err != nil || !reflect.DeepEqual(runIDs, []string{"run-a", "run-b"}) {
+		t.Fatalf("unexpected run ids: %v err=%v", runIDs, err)
+	}
+	if err := store.DeleteRunData(ctx, "run-a"); err != nil {
+		t.Fatalf("delete run data: %v", err)
+	}
+	for _, get := range []func() (bool, error){
+		func() (bool, error) { _, ok, err := store.GetFitnessHistory(ctx, "run-a"); return ok, err },
+		func() (bool, error) { _, ok, err := store.GetInnovations(ctx, "run-a"); return ok, err },
+		func() (bool, error) { _, ok, err := store.GetCheckpoint(ctx, "run-a"); return ok, err },
+	} {
+		if ok, err := get(); err != nil || ok {
+			t.Fatalf("expected run-a data to be deleted: ok=%t err=%v", ok, err)
+		}
+	}
+
+	if err := store.Vacuum(ctx); err != nil {
+		t.Fatalf("vacuum: %v", err)
+	}
+	if _, err := os.Stat(dbPath + ".compact"); !os.IsNotExist(err) {
+		t.Fatalf("expected vacuum to replace the store file, got %v", err)
+	}
+	if genome, ok, err := store.GetGenome(ctx, "g1"); err != nil || !ok {
+		t.Fatalf("expected records to survive vacuum: %+v ok=%t err=%v", genome, ok, err)
+	}
+	findings, err := store.IntegrityCheck(ctx)
+	if err != nil || !reflect.DeepEqual(findings, []string{"ok"}) {
+		t.Fatalf("unexpected integrity findings: %v err=%v", findings, err)
+	}
+}
@@ -1,10 +1,17 @@
 package storage
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
 
 	"protogonos/internal/model"
+	"protogonos/internal/stats"
 )
 
 const (
@@ -19,8 +26,50 @@ func EncodeGenome(g model.Genome) ([]byte, error) {
 }
 
 func DecodeGenome(data []byte) (model.Genome, error) {
+	return DecodeGenomeWithOptions(data, DecodeOptions{})
+}
+
+// DecodeOptions controls DecodeGenomeWithOptions. Strict rejects fields the
+// genome format does not define and data after the genome, and reports
+// failures as a *DecodeError; use it for genomes produced outside
+// protogonos, which the default decode would silently truncate.
+// DecodeOptions controls how DecodeGenomeWithOptions reads a genome. Strict
+// rejects unknown fields and trailing data instead of ignoring them, and
+// reports failures as *DecodeError.
+type DecodeOptions struct {
+	Strict bool
+}
+
+// DecodeError locates a strict decode failure: the 1-based line and column
+// in the input and, when known, the offending field.
+type DecodeError struct {
+	Line   int
+	Column int
+	Field  string
+	Err    error
+}
+
+func (e *DecodeError) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("line %d column %d: field %s: %v", e.Line, e.Column, e.Field, e.Err)
+	}
+	return fmt.Sprintf("line %d column %d: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeGenomeWithOptions decodes a genome the way DecodeGenome does, or
+// strictly when opts.Strict is set, so externally produced genomes that do
+// not match GenomeSchema fail instead of silently losing data.
+func DecodeGenomeWithOptions(data []byte, opts DecodeOptions) (model.Genome, error) {
 	var genome model.Genome
-	if err := json.Unmarshal(data, &genome); err != nil {
+	if !opts.Strict {
+		if err := json.Unmarshal(data, &genome); err != nil {
+			return model.Genome{}, err
+		}
+	} else if err := decodeStrict(data, &genome); err != nil {
 		return model.Genome{}, err
 	}
 	if err := checkVersion(genome.VersionedRecord); err != nil {
@@ -29,6 +78,150 @@ func DecodeGenome(data []byte) (model.Genome, error) {
 	return genome, nil
 }
 
+func decodeStrict(data []byte, out any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(out); err != nil {
+		return locateDecodeError(data, dec.InputOffset(), err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return locateDecodeError(data, dec.InputOffset(), errors.New("unexpected data after genome"))
+	}
+	return nil
+}
+
+// locateDecodeError wraps err with the input position it refers to, preferring
+// the offsets encoding/json reports over where the decoder stopped.
+func locateDecodeError(data []byte, offset int64, err error) error {
+	decodeErr := &DecodeError{Err: err}
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+		decodeErr.Field = typeErr.Field
+	case errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.EOF):
+		offset = int64(len(data))
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json names the field but not where it is.
+		if path, keyOffset, ok := findUnknownField(data, reflect.TypeOf(model.Genome{})); ok {
+			decodeErr.Field = path
+			offset = keyOffset
+		}
+	}
+	offset = min(max(offset, 0), int64(len(data)))
+	decodeErr.Line = 1 + bytes.Count(data[:offset], []byte{'\n'})
+	decodeErr.Column = int(offset) - bytes.LastIndexByte(data[:offset], '\n')
+	return decodeErr
+}
+
+// findUnknownField walks data against t and returns the path and offset of
+// the first object key t does not define, matching keys case-insensitively
+// like encoding/json.
+func findUnknownField(data []byte, t reflect.Type) (string, int64, bool) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	path, offset, found, err := walkUnknownField(data, dec, t, "")
+	return path, offset, found && err == nil
+}
+
+func walkUnknownField(data []byte, dec *json.Decoder, t reflect.Type, path string) (string, int64, bool, error) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	token, err := dec.Token()
+	if err != nil {
+		return "", 0, false, err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return "", 0, false, nil
+	}
+	if delim == '[' {
+		var elem reflect.Type
+		if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+			elem = t.Elem()
+		}
+		for i := 0; dec.More(); i++ {
+			if path, offset, found, err := walkUnknownField(data, dec, elem, joinFieldPath(path, strconv.Itoa(i))); found || err != nil {
+				return path, offset, found, err
+			}
+		}
+		_, err := dec.Token()
+		return "", 0, false, err
+	}
+
+	fields := jsonFieldTypes(t)
+	for dec.More() {
+		// The decoder stops after the previous token; the key follows
+		// separators and whitespace.
+		keyOffset := dec.InputOffset()
+		for keyOffset < int64(len(data)) && strings.IndexByte(" \t\r\n,", data[keyOffset]) >= 0 {
+			keyOffset++
+		}
+		token, err := dec.Token()
+		if err != nil {
+			return "", 0, false, err
+		}
+		key, _ := token.(string)
+		next := joinFieldPath(path, key)
+		var valueType reflect.Type
+		switch {
+		case t != nil && t.Kind() == reflect.Struct:
+			field, ok := fields[strings.ToLower(key)]
+			if !ok {
+				return next, keyOffset, true, nil
+			}
+			valueType = field
+		case t != nil && t.Kind() == reflect.Map:
+			valueType = t.Elem()
+		}
+		if path, offset, found, err := walkUnknownField(data, dec, valueType, next); found || err != nil {
+			return path, offset, found, err
+		}
+	}
+	_, err = dec.Token()
+	return "", 0, false, err
+}
+
+// jsonFieldTypes maps the lower-cased JSON names of a struct's fields,
+// including those of embedded structs, to their types.
+func jsonFieldTypes(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	if t == nil || t.Kind() != reflect.Struct {
+		return fields
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			for name, fieldType := range jsonFieldTypes(field.Type) {
+				fields[name] = fieldType
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = field.Type
+	}
+	return fields
+}
+
+func joinFieldPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
 func EncodeAgent(a model.Agent) ([]byte, error) {
 	return json.Marshal(a)
 }
@@ -115,16 +308,50 @@ func DecodeGenerationDiagnostics(data []byte) ([]model.GenerationDiagnostics, er
 	return diagnostics, nil
 }
 
+// EncodeSpeciesHistory stores history as keyframed deltas; see
+// stats.SpeciesHistoryDeltas.
 func EncodeSpeciesHistory(history []model.SpeciesGeneration) ([]byte, error) {
-	return json.Marshal(history)
+	return stats.MarshalSpeciesHistory(history)
 }
 
 func DecodeSpeciesHistory(data []byte) ([]model.SpeciesGeneration, error) {
-	var history []model.SpeciesGeneration
-	if err := json.Unmarshal(data, &history); err != nil {
+	return stats.UnmarshalSpeciesHistory(data)
+}
+
+func EncodeInnovations(records []model.InnovationRecord) ([]byte, error) {
+	return json.Marshal(records)
+}
+
+func DecodeInnovations(data []byte) ([]model.InnovationRecord, error) {
+	var records []model.InnovationRecord
+	if err := json.Unmarshal(data, &records); err != nil {
 		return nil, err
 	}
-	return history, nil
+	return records, nil
+}
+
+func EncodeCheckpoint(checkpoint model.RunCheckpoint) ([]byte, error) {
+	return json.Marshal(checkpoint)
+}
+
+func DecodeCheckpoint(data []byte) (model.RunCheckpoint, error) {
+	var checkpoint model.RunCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return model.RunCheckpoint{}, err
+	}
+	return checkpoint, nil
+}
+
+func EncodeLeaderboard(entries []model.LeaderboardEntry) ([]byte, error) {
+	return json.Marshal(entries)
+}
+
+func DecodeLeaderboard(data []byte) ([]model.LeaderboardEntry, error) {
+	var entries []model.LeaderboardEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
 }
 
 func EncodeTopGenomes(top []model.TopGenomeRecord) ([]byte, error) {
@@ -596,3 +596,58 @@ func decodeGenomeFixture(t *testing.T, name string) model.Genome {
 
 	return genome
 }
+
+func TestStrictDecodeGenomeAcceptsFixtures(t *testing.T) {
+	paths, err := filepath.Glob(fixturePath("*genome*.json"))
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("glob genome fixtures: %v", err)
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read fixture: %v", err)
+		}
+		if _, err := DecodeGenomeWithOptions(data, DecodeOptions{Strict: true}); err != nil {
+			t.Fatalf("%s: strict decode: %v", filepath.Base(path), err)
+		}
+	}
+}
+
+func TestStrictDecodeGenomeLocatesUnknownField(t *testing.T) {
+	data := []byte(`{
+  "schema_version": 1,
+  "codec_version": 1,
+  "id": "g",
+  "neurons": [
+    {"id": "n1", "activation": "identity", "bais": 0.5}
+  ]
+}`)
+	if _, err := DecodeGenome(data); err != nil {
+		t.Fatalf("expected the default decode to ignore unknown fields: %v", err)
+	}
+
+	_, err := DecodeGenomeWithOptions(data, DecodeOptions{Strict: true})
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected a DecodeError, got %v", err)
+	}
+	if decodeErr.Line != 6 || decodeErr.Column != 44 || decodeErr.Field != "neurons.0.bais" {
+		t.Fatalf("unexpected location: %v", decodeErr)
+	}
+}
+
+func TestStrictDecodeGenomeLocatesTypeErrorsAndTrailingData(t *testing.T) {
+	_, err := DecodeGenomeWithOptions([]byte("{\"schema_version\": 1, \"codec_version\": 1, \"id\": \"g\",\n \"neurons\": [{\"id\": \"n1\", \"bias\": \"high\"}]}"), DecodeOptions{Strict: true})
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected a DecodeError, got %v", err)
+	}
+	if decodeErr.Line != 2 || decodeErr.Field != "neurons.0.bias" {
+		t.Fatalf("unexpected type error location: %v", decodeErr)
+	}
+
+	_, err = DecodeGenomeWithOptions([]byte(`{"schema_version": 1, "codec_version": 1, "id": "g"} {}`), DecodeOptions{Strict: true})
+	if !errors.As(err, &decodeErr) || decodeErr.Line != 1 {
+		t.Fatalf("expected trailing data to be rejected with a location, got %v", err)
+	}
+}
@@ -0,0 +1,274 @@
+package storage
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"protogonos/internal/model"
+)
+
+// CurrentArchiveVersion identifies the run archive payload layout.
+const CurrentArchiveVersion = 1
+
+// RunArchive is a self-contained, re-importable copy of one run's persisted
+// records. Genomes are the members of the run's population snapshot;
+// Innovations and Checkpoint are the run's innovation registry and latest
+// resumable checkpoint when the store keeps them.
+type RunArchive struct {
+	ArchiveVersion        int                           `json:"archive_version"`
+	RunID                 string                        `json:"run_id"`
+	Population            *model.Population             `json:"population,omitempty"`
+	Genomes               []model.Genome                `json:"genomes,omitempty"`
+	FitnessHistory        []float64                     `json:"fitness_history,omitempty"`
+	GenerationDiagnostics []model.GenerationDiagnostics `json:"generation_diagnostics,omitempty"`
+	SpeciesHistory        []model.SpeciesGeneration     `json:"species_history,omitempty"`
+	TopGenomes            []model.TopGenomeRecord       `json:"top_genomes,omitempty"`
+	Lineage               []model.LineageRecord         `json:"lineage,omitempty"`
+	Innovations           []model.InnovationRecord      `json:"innovations,omitempty"`
+	Checkpoint            *model.RunCheckpoint          `json:"checkpoint,omitempty"`
+}
+
+// CompactResult summarizes a compaction pass.
+type CompactResult struct {
+	ReferencedGenomes int
+	RemovedGenomes    int
+	Vacuumed          bool
+}
+
+// CompactGenomes deletes genomes that are not members of any persisted
+// population and vacuums the store when supported.
+func CompactGenomes(ctx context.Context, store Store) (CompactResult, error) {
+	lister, ok := store.(Lister)
+	if !ok {
+		return CompactResult{}, errors.New("store does not support record listing")
+	}
+	populationIDs, err := lister.ListPopulationIDs(ctx)
+	if err != nil {
+		return CompactResult{}, err
+	}
+	referenced := make(map[string]struct{})
+	for _, populationID := range populationIDs {
+		population, ok, err := store.GetPopulation(ctx, populationID)
+		if err != nil {
+			return CompactResult{}, err
+		}
+		if !ok {
+			continue
+		}
+		for _, agentID := range population.AgentIDs {
+			referenced[agentID] = struct{}{}
+		}
+	}
+
+	genomeIDs, err := lister.ListGenomeIDs(ctx)
+	if err != nil {
+		return CompactResult{}, err
+	}
+	result := CompactResult{ReferencedGenomes: len(referenced)}
+	for _, genomeID := range genomeIDs {
+		if _, ok := referenced[genomeID]; ok {
+			continue
+		}
+		if err := store.DeleteGenome(ctx, genomeID); err != nil {
+			return CompactResult{}, err
+		}
+		result.RemovedGenomes++
+	}
+	if vacuumer, ok := store.(Vacuumer); ok {
+		if err := vacuumer.Vacuum(ctx); err != nil {
+			return CompactResult{}, err
+		}
+		result.Vacuumed = true
+	}
+	return result, nil
+}
+
+// ExportRunArchive collects every persisted record for runID.
+func ExportRunArchive(ctx context.Context, store Store, runID string) (RunArchive, error) {
+	if runID == "" {
+		return RunArchive{}, errors.New("run id is required")
+	}
+	archive := RunArchive{ArchiveVersion: CurrentArchiveVersion, RunID: runID}
+	population, ok, err := store.GetPopulation(ctx, runID)
+	if err != nil {
+		return RunArchive{}, err
+	}
+	if ok {
+		archive.Population = &population
+		for _, agentID := range population.AgentIDs {
+			genome, ok, err := store.GetGenome(ctx, agentID)
+			if err != nil {
+				return RunArchive{}, err
+			}
+			if ok {
+				archive.Genomes = append(archive.Genomes, genome)
+			}
+		}
+	}
+	if archive.FitnessHistory, _, err = store.GetFitnessHistory(ctx, runID); err != nil {
+		return RunArchive{}, err
+	}
+	if archive.GenerationDiagnostics, _, err = store.GetGenerationDiagnostics(ctx, runID); err != nil {
+		return RunArchive{}, err
+	}
+	if archive.SpeciesHistory, _, err = store.GetSpeciesHistory(ctx, runID); err != nil {
+		return RunArchive{}, err
+	}
+	if archive.TopGenomes, _, err = store.GetTopGenomes(ctx, runID); err != nil {
+		return RunArchive{}, err
+	}
+	if archive.Lineage, _, err = store.GetLineage(ctx, runID); err != nil {
+		return RunArchive{}, err
+	}
+	if innovationStore, ok := store.(InnovationStore); ok {
+		if archive.Innovations, _, err = innovationStore.GetInnovations(ctx, runID); err != nil {
+			return RunArchive{}, err
+		}
+	}
+	if checkpointStore, ok := store.(CheckpointStore); ok {
+		checkpoint, ok, err := checkpointStore.GetCheckpoint(ctx, runID)
+		if err != nil {
+			return RunArchive{}, err
+		}
+		if ok {
+			archive.Checkpoint = &checkpoint
+		}
+	}
+	return archive, nil
+}
+
+// ImportRunArchive restores an archive's records into store.
+func ImportRunArchive(ctx context.Context, store Store, archive RunArchive) error {
+	if archive.ArchiveVersion != CurrentArchiveVersion {
+		return fmt.Errorf("%w: archive=%d expected=%d", ErrVersionMismatch, archive.ArchiveVersion, CurrentArchiveVersion)
+	}
+	if archive.RunID == "" {
+		return errors.New("archive run id is required")
+	}
+	for _, genome := range archive.Genomes {
+		if err := store.SaveGenome(ctx, genome); err != nil {
+			return err
+		}
+	}
+	if archive.Population != nil {
+		if err := store.SavePopulation(ctx, *archive.Population); err != nil {
+			return err
+		}
+	}
+	if archive.FitnessHistory != nil {
+		if err := store.SaveFitnessHistory(ctx, archive.RunID, archive.FitnessHistory); err != nil {
+			return err
+		}
+	}
+	if archive.GenerationDiagnostics != nil {
+		if err := store.SaveGenerationDiagnostics(ctx, archive.RunID, archive.GenerationDiagnostics); err != nil {
+			return err
+		}
+	}
+	if archive.SpeciesHistory != nil {
+		if err := store.SaveSpeciesHistory(ctx, archive.RunID, archive.SpeciesHistory); err != nil {
+			return err
+		}
+	}
+	if archive.TopGenomes != nil {
+		if err := store.SaveTopGenomes(ctx, archive.RunID, archive.TopGenomes); err != nil {
+			return err
+		}
+	}
+	if archive.Lineage != nil {
+		if err := store.SaveLineage(ctx, archive.RunID, archive.Lineage); err != nil {
+			return err
+		}
+	}
+	if archive.Innovations != nil {
+		innovationStore, ok := store.(InnovationStore)
+		if !ok {
+			return errors.New("store does not support innovation registries")
+		}
+		if err := innovationStore.SaveInnovations(ctx, archive.RunID, archive.Innovations); err != nil {
+			return err
+		}
+	}
+	if archive.Checkpoint != nil {
+		checkpointStore, ok := store.(CheckpointStore)
+		if !ok {
+			return errors.New("store does not support checkpoints")
+		}
+		if err := checkpointStore.SaveCheckpoint(ctx, *archive.Checkpoint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OffloadRun writes runID's records to a gzip-compressed archive under dir
+// and removes them from store. Genomes are left for CompactGenomes to reclaim
+// once no population references them.
+func OffloadRun(ctx context.Context, store Store, runID, dir string) (string, error) {
+	deleter, ok := store.(RunDeleter)
+	if !ok {
+		return "", errors.New("store does not support run deletion")
+	}
+	archive, err := ExportRunArchive(ctx, store, runID)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, runID+".json.gz")
+	if err := WriteRunArchiveFile(path, archive); err != nil {
+		return "", err
+	}
+	if archive.Population != nil {
+		if err := store.DeletePopulation(ctx, runID); err != nil {
+			return "", err
+		}
+	}
+	if err := deleter.DeleteRunData(ctx, runID); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func WriteRunArchiveFile(path string, archive RunArchive) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(f)
+	if err := json.NewEncoder(zw).Encode(archive); err != nil {
+		_ = zw.Close()
+		_ = f.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func ReadRunArchiveFile(path string) (RunArchive, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return RunArchive{}, err
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return RunArchive{}, err
+	}
+	defer zr.Close()
+
+	var archive RunArchive
+	if err := json.NewDecoder(zr).Decode(&archive); err != nil {
+		return RunArchive{}, fmt.Errorf("decode run archive %s: %w", path, err)
+	}
+	return archive, nil
+}
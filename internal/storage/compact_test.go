@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func TestCompactGenomesRemovesUnreferencedGenomes(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	if err := store.Init(ctx); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	for _, id := range []string{"g1", "g2", "orphan"} {
+		if err := store.SaveGenome(ctx, model.Genome{ID: id}); err != nil {
+			t.Fatalf("save genome %s: %v", id, err)
+		}
+	}
+	if err := store.SavePopulation(ctx, model.Population{ID: "p1", AgentIDs: []string{"g1", "g2"}}); err != nil {
+		t.Fatalf("save population: %v", err)
+	}
+
+	result, err := CompactGenomes(ctx, store)
+	if err != nil {
+		t.Fatalf("compact: %v", err)
+	}
+	if result.RemovedGenomes != 1 || result.ReferencedGenomes != 2 {
+		t.Fatalf("unexpected compact result: %+v", result)
+	}
+	if _, ok, _ := store.GetGenome(ctx, "orphan"); ok {
+		t.Fatal("expected orphan genome to be removed")
+	}
+	if _, ok, _ := store.GetGenome(ctx, "g1"); !ok {
+		t.Fatal("expected referenced genome to remain")
+	}
+}
+
+func TestOffloadRunArchiveRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	if err := store.Init(ctx); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	if err := store.SaveGenome(ctx, model.Genome{ID: "g1"}); err != nil {
+		t.Fatalf("save genome: %v", err)
+	}
+	if err := store.SavePopulation(ctx, model.Population{ID: "run-1", AgentIDs: []string{"g1"}, Generation: 4}); err != nil {
+		t.Fatalf("save population: %v", err)
+	}
+	if err := store.SaveFitnessHistory(ctx, "run-1", []float64{0.1, 0.4}); err != nil {
+		t.Fatalf("save history: %v", err)
+	}
+	if err := store.SaveLineage(ctx, "run-1", []model.LineageRecord{{GenomeID: "g1", Operation: "seed"}}); err != nil {
+		t.Fatalf("save lineage: %v", err)
+	}
+	innovations := []model.InnovationRecord{{Key: "link:i0->o0", Innovation: 1}, {Key: "split:1", Innovation: 2}}
+	if err := store.SaveInnovations(ctx, "run-1", innovations); err != nil {
+		t.Fatalf("save innovations: %v", err)
+	}
+	checkpoint := model.RunCheckpoint{RunID: "run-1", Generation: 4, Config: []byte(`{"scape":"xor"}`), State: []byte(`{"generation":4}`)}
+	if err := store.SaveCheckpoint(ctx, checkpoint); err != nil {
+		t.Fatalf("save checkpoint: %v", err)
+	}
+
+	path, err := OffloadRun(ctx, store, "run-1", t.TempDir())
+	if err != nil {
+		t.Fatalf("offload run: %v", err)
+	}
+	if filepath.Base(path) != "run-1.json.gz" {
+		t.Fatalf("unexpected archive path: %s", path)
+	}
+	if _, ok, _ := store.GetPopulation(ctx, "run-1"); ok {
+		t.Fatal("expected offloaded population to be removed")
+	}
+	if _, ok, _ := store.GetFitnessHistory(ctx, "run-1"); ok {
+		t.Fatal("expected offloaded fitness history to be removed")
+	}
+	if _, ok, _ := store.GetInnovations(ctx, "run-1"); ok {
+		t.Fatal("expected offloaded innovations to be removed")
+	}
+	if _, ok, _ := store.GetCheckpoint(ctx, "run-1"); ok {
+		t.Fatal("expected offloaded checkpoint to be removed")
+	}
+	if result, err := CompactGenomes(ctx, store); err != nil || result.RemovedGenomes != 1 {
+		t.Fatalf("expected offloaded genome to become unreferenced: result=%+v err=%v", result, err)
+	}
+
+	archive, err := ReadRunArchiveFile(path)
+	if err != nil {
+		t.Fatalf("read archive: %v", err)
+	}
+	if err := ImportRunArchive(ctx, store, archive); err != nil {
+		t.Fatalf("import archive: %v", err)
+	}
+	pop, ok, err := store.GetPopulation(ctx, "run-1")
+	if err != nil || !ok || pop.Generation != 4 {
+		t.Fatalf("expected restored population: %+v ok=%t err=%v", pop, ok, err)
+	}
+	if _, ok, _ := store.GetGenome(ctx, "g1"); !ok {
+		t.Fatal("expected restored genome")
+	}
+	history, ok, _ := store.GetFitnessHistory(ctx, "run-1")
+	if !ok || len(history) != 2 {
+		t.Fatalf("expected restored fitness history: %v", history)
+	}
+	restored, ok, _ := store.GetInnovations(ctx, "run-1")
+	if !ok || !reflect.DeepEqual(restored, innovations) {
+		t.Fatalf("expected restored innovations: %+v", restored)
+	}
+	resumable, ok, _ := store.GetCheckpoint(ctx, "run-1")
+	if !ok || !reflect.DeepEqual(resumable, checkpoint) {
+		t.Fatalf("expected restored checkpoint: %+v", resumable)
+	}
+
+	archive.ArchiveVersion = CurrentArchiveVersion + 1
+	if err := ImportRunArchive(ctx, store, archive); err == nil {
+		t.Fatal("expected archive version mismatch to fail")
+	}
+}
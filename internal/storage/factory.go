@@ -2,12 +2,19 @@ package storage
 
 import "fmt"
 
-func NewStore(kind, sqlitePath string) (Store, error) {
+// NewStore returns the store backend named kind. path is the database file
+// of the sqlite and bolt backends and the connection string of the postgres
+// backend.
+func NewStore(kind, path string) (Store, error) {
 	switch kind {
 	case "", "memory":
 		return NewMemoryStore(), nil
 	case "sqlite":
-		return newSQLiteStore(sqlitePath)
+		return newSQLiteStore(path)
+	case "postgres":
+		return newPostgresStore(path)
+	case "bolt":
+		return newBoltStore(path)
 	default:
 		return nil, fmt.Errorf("unsupported store backend: %s", kind)
 	}
@@ -0,0 +1,7 @@
+//go:build bolt
+
+package storage
+
+func newBoltStore(path string) (Store, error) {
+	return NewBoltStore(path), nil
+}
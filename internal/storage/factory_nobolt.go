@@ -0,0 +1,9 @@
+//go:build !bolt
+
+package storage
+
+import "fmt"
+
+func newBoltStore(_ string) (Store, error) {
+	return nil, fmt.Errorf("bolt backend unavailable in this build; rebuild with -tags bolt")
+}
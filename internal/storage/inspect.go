@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// IntegrityChecker is an optional capability that runs a backend-level
+// consistency check and returns its findings ("ok" when clean).
+type IntegrityChecker interface {
+	IntegrityCheck(ctx context.Context) ([]string, error)
+}
+
+// EntityUsage reports record count and encoded payload bytes for one entity type.
+type EntityUsage struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+	Bytes int64  `json:"bytes"`
+}
+
+// RunUsage reports encoded payload bytes attributable to one run: its
+// run-keyed records plus its population snapshot and member genomes.
+type RunUsage struct {
+	RunID string `json:"run_id"`
+	Bytes int64  `json:"bytes"`
+}
+
+// InspectReport summarizes store contents for operators.
+type InspectReport struct {
+	SchemaVersion   int           `json:"schema_version"`
+	CodecVersion    int           `json:"codec_version"`
+	Entities        []EntityUsage `json:"entities"`
+	TotalBytes      int64         `json:"total_bytes"`
+	Runs            []RunUsage    `json:"runs"`
+	OrphanGenomes   int           `json:"orphan_genomes"`
+	DanglingMembers int           `json:"dangling_members"`
+	DecodeErrors    []string      `json:"decode_errors,omitempty"`
+	Integrity       []string      `json:"integrity"`
+}
+
+var inspectedEntities = []string{"genomes", "populations", "fitness_history", "generation_diagnostics", "species_history", "top_genomes", "lineage", "innovations", "checkpoints"}
+
+// Inspect walks every listed record, measuring encoded payload sizes and
+// checking population membership references. Records that fail to decode are
+// reported rather than aborting the walk.
+func Inspect(ctx context.Context, store Store) (InspectReport, error) {
+	lister, ok := store.(Lister)
+	if !ok {
+		return InspectReport{}, errors.New("store does not support record listing")
+	}
+	report := InspectReport{
+		SchemaVersion: CurrentSchemaVersion,
+		CodecVersion:  CurrentCodecVersion,
+	}
+	usage := map[string]*EntityUsage{}
+	for _, name := range inspectedEntities {
+		usage[name] = &EntityUsage{Name: name}
+	}
+	add := func(name string, payload []byte) int64 {
+		usage[name].Count++
+		usage[name].Bytes += int64(len(payload))
+		return int64(len(payload))
+	}
+	decodeError := func(kind, id string, err error) {
+		report.DecodeErrors = append(report.DecodeErrors, fmt.Sprintf("%s %s: %v", kind, id, err))
+	}
+
+	genomeIDs, err := lister.ListGenomeIDs(ctx)
+	if err != nil {
+		return InspectReport{}, err
+	}
+	genomeBytes := make(map[string]int64, len(genomeIDs))
+	for _, id := range genomeIDs {
+		genome, ok, err := store.GetGenome(ctx, id)
+		if err != nil {
+			decodeError("genome", id, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		payload, err := EncodeGenome(genome)
+		if err != nil {
+			return InspectReport{}, err
+		}
+		genomeBytes[id] = add("genomes", payload)
+	}
+
+	runBytes := map[string]int64{}
+	referenced := map[string]struct{}{}
+	populationIDs, err := lister.ListPopulationIDs(ctx)
+	if err != nil {
+		return InspectReport{}, err
+	}
+	for _, id := range populationIDs {
+		population, ok, err := store.GetPopulation(ctx, id)
+		if err != nil {
+			decodeError("population", id, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		payload, err := EncodePopulation(population)
+		if err != nil {
+			return InspectReport{}, err
+		}
+		runBytes[id] += add("populations", payload)
+		for _, agentID := range population.AgentIDs {
+			referenced[agentID] = struct{}{}
+			size, ok := genomeBytes[agentID]
+			if !ok {
+				report.DanglingMembers++
+				continue
+			}
+			runBytes[id] += size
+		}
+	}
+	for id := range genomeBytes {
+		if _, ok := referenced[id]; !ok {
+			report.OrphanGenomes++
+		}
+	}
+
+	runIDs, err := lister.ListRunIDs(ctx)
+	if err != nil {
+		return InspectReport{}, err
+	}
+	for _, runID := range runIDs {
+		if history, ok, err := store.GetFitnessHistory(ctx, runID); err != nil {
+			decodeError("fitness_history", runID, err)
+		} else if ok {
+			payload, _ := EncodeFitnessHistory(history)
+			runBytes[runID] += add("fitness_history", payload)
+		}
+		if diagnostics, ok, err := store.GetGenerationDiagnostics(ctx, runID); err != nil {
+			decodeError("generation_diagnostics", runID, err)
+		} else if ok {
+			payload, _ := EncodeGenerationDiagnostics(diagnostics)
+			runBytes[runID] += add("generation_diagnostics", payload)
+		}
+		if history, ok, err := store.GetSpeciesHistory(ctx, runID); err != nil {
+			decodeError("species_history", runID, err)
+		} else if ok {
+			payload, _ := EncodeSpeciesHistory(history)
+			runBytes[runID] += add("species_history", payload)
+		}
+		if top, ok, err := store.GetTopGenomes(ctx, runID); err != nil {
+			decodeError("top_genomes", runID, err)
+		} else if ok {
+			payload, _ := EncodeTopGenomes(top)
+			runBytes[runID] += add("top_genomes", payload)
+		}
+		if lineage, ok, err := store.GetLineage(ctx, runID); err != nil {
+			decodeError("lineage", runID, err)
+		} else if ok {
+			payload, _ := EncodeLineage(lineage)
+			runBytes[runID] += add("lineage", payload)
+		}
+		if innovationStore, ok := store.(InnovationStore); ok {
+			if records, ok, err := innovationStore.GetInnovations(ctx, runID); err != nil {
+				decodeError("innovations", runID, err)
+			} else if ok {
+				payload, _ := EncodeInnovations(records)
+				runBytes[runID] += add("innovations", payload)
+			}
+		}
+		if checkpointStore, ok := store.(CheckpointStore); ok {
+			if checkpoint, ok, err := checkpointStore.GetCheckpoint(ctx, runID); err != nil {
+				decodeError("checkpoints", runID, err)
+			} else if ok {
+				payload, _ := EncodeCheckpoint(checkpoint)
+				runBytes[runID] += add("checkpoints", payload)
+			}
+		}
+	}
+
+	for _, name := range inspectedEntities {
+		report.Entities = append(report.Entities, *usage[name])
+		report.TotalBytes += usage[name].Bytes
+	}
+	for runID, size := range runBytes {
+		report.Runs = append(report.Runs, RunUsage{RunID: runID, Bytes: size})
+	}
+	sort.Slice(report.Runs, func(i, j int) bool {
+		if report.Runs[i].Bytes != report.Runs[j].Bytes {
+			return report.Runs[i].Bytes > report.Runs[j].Bytes
+		}
+		return report.Runs[i].RunID < report.Runs[j].RunID
+	})
+
+	if checker, ok := store.(IntegrityChecker); ok {
+		findings, err := checker.IntegrityCheck(ctx)
+		if err != nil {
+			return InspectReport{}, err
+		}
+		report.Integrity = findings
+	} else {
+		report.Integrity = []string{"ok"}
+	}
+	if report.DanglingMembers > 0 || len(report.DecodeErrors) > 0 {
+		report.Integrity = append(report.Integrity, fmt.Sprintf("logical: dangling_members=%d decode_errors=%d", report.DanglingMembers, len(report.DecodeErrors)))
+	}
+	return report, nil
+}
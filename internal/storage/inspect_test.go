@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+func TestInspectReportsUsageAndReferenceIssues(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	if err := store.Init(ctx); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	versioned := model.VersionedRecord{SchemaVersion: CurrentSchemaVersion, CodecVersion: CurrentCodecVersion}
+	for _, id := range []string{"g1", "orphan"} {
+		if err := store.SaveGenome(ctx, model.Genome{VersionedRecord: versioned, ID: id}); err != nil {
+			t.Fatalf("save genome %s: %v", id, err)
+		}
+	}
+	if err := store.SavePopulation(ctx, model.Population{VersionedRecord: versioned, ID: "big", AgentIDs: []string{"g1", "missing"}}); err != nil {
+		t.Fatalf("save population: %v", err)
+	}
+	if err := store.SaveFitnessHistory(ctx, "big", []float64{1, 2, 3, 4, 5, 6}); err != nil {
+		t.Fatalf("save history: %v", err)
+	}
+	if err := store.SaveFitnessHistory(ctx, "small", []float64{1}); err != nil {
+		t.Fatalf("save history: %v", err)
+	}
+	if err := store.SaveInnovations(ctx, "innovations-only", []model.InnovationRecord{{Key: "link:i0->o0", Innovation: 1}}); err != nil {
+		t.Fatalf("save innovations: %v", err)
+	}
+	if err := store.SaveCheckpoint(ctx, model.RunCheckpoint{RunID: "checkpoint-only", Generation: 2, Config: []byte(`{}`), State: []byte(`{}`)}); err != nil {
+		t.Fatalf("save checkpoint: %v", err)
+	}
+
+	report, err := Inspect(ctx, store)
+	if err != nil {
+		t.Fatalf("inspect: %v", err)
+	}
+	counts := map[string]int{}
+	for _, entity := range report.Entities {
+		counts[entity.Name] = entity.Count
+		if entity.Count > 0 && entity.Bytes <= 0 {
+			t.Fatalf("expected byte usage for %s: %+v", entity.Name, entity)
+		}
+	}
+	if counts["genomes"] != 2 || counts["populations"] != 1 || counts["fitness_history"] != 2 || counts["innovations"] != 1 || counts["checkpoints"] != 1 {
+		t.Fatalf("unexpected entity counts: %+v", report.Entities)
+	}
+	if report.OrphanGenomes != 1 || report.DanglingMembers != 1 {
+		t.Fatalf("unexpected reference issues: orphans=%d dangling=%d", report.OrphanGenomes, report.DanglingMembers)
+	}
+	if len(report.Runs) != 4 || report.Runs[0].RunID != "big" {
+		t.Fatalf("expected runs ordered by bytes: %+v", report.Runs)
+	}
+	if len(report.Integrity) != 2 || report.Integrity[0] != "ok" {
+		t.Fatalf("expected logical integrity finding after backend ok: %+v", report.Integrity)
+	}
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	"protogonos/internal/model"
@@ -18,6 +19,9 @@ type MemoryStore struct {
 	speciesHist map[string][]model.SpeciesGeneration
 	topGenomes  map[string][]model.TopGenomeRecord
 	lineage     map[string][]model.LineageRecord
+	innovations map[string][]model.InnovationRecord
+	leaderboard map[string][]model.LeaderboardEntry
+	checkpoints map[string]model.RunCheckpoint
 }
 
 func NewMemoryStore() *MemoryStore {
@@ -37,6 +41,9 @@ func (s *MemoryStore) Init(_ context.Context) error {
 	s.speciesHist = make(map[string][]model.SpeciesGeneration)
 	s.topGenomes = make(map[string][]model.TopGenomeRecord)
 	s.lineage = make(map[string][]model.LineageRecord)
+	s.innovations = make(map[string][]model.InnovationRecord)
+	s.leaderboard = make(map[string][]model.LeaderboardEntry)
+	s.checkpoints = make(map[string]model.RunCheckpoint)
 	return nil
 }
 
@@ -238,3 +245,134 @@ func (s *MemoryStore) GetLineage(_ context.Context, runID string) ([]model.Linea
 	copy(copied, lineage)
 	return copied, true, nil
 }
+
+func (s *MemoryStore) SaveInnovations(_ context.Context, runID string, records []model.InnovationRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.innovations[runID] = append([]model.InnovationRecord(nil), records...)
+	return nil
+}
+
+func (s *MemoryStore) GetInnovations(_ context.Context, runID string) ([]model.InnovationRecord, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records, ok := s.innovations[runID]
+	if !ok {
+		return nil, false, nil
+	}
+	return append([]model.InnovationRecord(nil), records...), true, nil
+}
+
+func (s *MemoryStore) SaveCheckpoint(_ context.Context, checkpoint model.RunCheckpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkpoints[checkpoint.RunID] = checkpoint
+	return nil
+}
+
+func (s *MemoryStore) GetCheckpoint(_ context.Context, runID string) (model.RunCheckpoint, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	checkpoint, ok := s.checkpoints[runID]
+	return checkpoint, ok, nil
+}
+
+func (s *MemoryStore) DeleteCheckpoint(_ context.Context, runID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.checkpoints, runID)
+	return nil
+}
+
+func (s *MemoryStore) SaveLeaderboard(_ context.Context, scape string, entries []model.LeaderboardEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.leaderboard[scape] = cloneLeaderboard(entries)
+	return nil
+}
+
+func (s *MemoryStore) GetLeaderboard(_ context.Context, scape string) ([]model.LeaderboardEntry, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries, ok := s.leaderboard[scape]
+	if !ok {
+		return nil, false, nil
+	}
+	return cloneLeaderboard(entries), true, nil
+}
+
+func cloneLeaderboard(entries []model.LeaderboardEntry) []model.LeaderboardEntry {
+	cloned := append([]model.LeaderboardEntry(nil), entries...)
+	for i := range cloned {
+		if cloned[i].ValidationFitness != nil {
+			validation := *cloned[i].ValidationFitness
+			cloned[i].ValidationFitness = &validation
+		}
+	}
+	return cloned
+}
+
+func (s *MemoryStore) ListGenomeIDs(_ context.Context) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return sortedKeys(s.genomes), nil
+}
+
+func (s *MemoryStore) ListPopulationIDs(_ context.Context) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return sortedKeys(s.populations), nil
+}
+
+func (s *MemoryStore) ListRunIDs(_ context.Context) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seen := make(map[string]struct{})
+	for _, ids := range [][]string{
+		sortedKeys(s.history),
+		sortedKeys(s.diagnostics),
+		sortedKeys(s.speciesHist),
+		sortedKeys(s.topGenomes),
+		sortedKeys(s.lineage),
+		sortedKeys(s.innovations),
+		sortedKeys(s.checkpoints),
+	} {
+		for _, id := range ids {
+			seen[id] = struct{}{}
+		}
+	}
+	return sortedKeys(seen), nil
+}
+
+func (s *MemoryStore) DeleteRunData(_ context.Context, runID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.history, runID)
+	delete(s.diagnostics, runID)
+	delete(s.speciesHist, runID)
+	delete(s.topGenomes, runID)
+	delete(s.lineage, runID)
+	delete(s.innovations, runID)
+	delete(s.checkpoints, runID)
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
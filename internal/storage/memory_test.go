@@ -42,6 +42,84 @@ func TestMemoryStoreLineageRoundTrip(t *testing.T) {
 	}
 }
 
+func TestMemoryStoreInnovationsRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	if err := store.Init(ctx); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	input := []model.InnovationRecord{{Key: "n:i", Innovation: 1}, {Key: "s:i->o", Innovation: 2}}
+	if err := store.SaveInnovations(ctx, "run-1", input); err != nil {
+		t.Fatalf("save innovations: %v", err)
+	}
+	output, ok, err := store.GetInnovations(ctx, "run-1")
+	if err != nil || !ok {
+		t.Fatalf("get innovations: ok=%t err=%v", ok, err)
+	}
+	if len(output) != 2 || output[1] != input[1] {
+		t.Fatalf("unexpected innovations: %+v", output)
+	}
+	if err := store.DeleteRunData(ctx, "run-1"); err != nil {
+		t.Fatalf("delete run data: %v", err)
+	}
+	if _, ok, _ := store.GetInnovations(ctx, "run-1"); ok {
+		t.Fatal("expected innovations to be deleted with the run")
+	}
+}
+
+func TestMemoryStoreCheckpointRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	if err := store.Init(ctx); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	for _, generation := range []int{2, 4} {
+		if err := store.SaveCheckpoint(ctx, model.RunCheckpoint{RunID: "run-1", Generation: generation, State: []byte(`{}`)}); err != nil {
+			t.Fatalf("save checkpoint: %v", err)
+		}
+	}
+	checkpoint, ok, err := store.GetCheckpoint(ctx, "run-1")
+	if err != nil || !ok {
+		t.Fatalf("get checkpoint: ok=%t err=%v", ok, err)
+	}
+	if checkpoint.Generation != 4 {
+		t.Fatalf("expected the latest checkpoint to replace the earlier one, got generation %d", checkpoint.Generation)
+	}
+	if err := store.DeleteCheckpoint(ctx, "run-1"); err != nil {
+		t.Fatalf("delete checkpoint: %v", err)
+	}
+	if _, ok, _ := store.GetCheckpoint(ctx, "run-1"); ok {
+		t.Fatal("expected the checkpoint to be deleted")
+	}
+}
+
+func TestMemoryStoreLeaderboardRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	if err := store.Init(ctx); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	validation := 0.75
+	input := []model.LeaderboardEntry{{RunID: "run-1", GenomeID: "g1", Fitness: 0.9, ValidationFitness: &validation}}
+	if err := store.SaveLeaderboard(ctx, "xor", input); err != nil {
+		t.Fatalf("save leaderboard: %v", err)
+	}
+	validation = 0
+	output, ok, err := store.GetLeaderboard(ctx, "xor")
+	if err != nil || !ok {
+		t.Fatalf("get leaderboard: ok=%t err=%v", ok, err)
+	}
+	if len(output) != 1 || output[0].RunID != "run-1" || output[0].ValidationFitness == nil || *output[0].ValidationFitness != 0.75 {
+		t.Fatalf("unexpected leaderboard: %+v", output)
+	}
+	if _, ok, _ := store.GetLeaderboard(ctx, "fx"); ok {
+		t.Fatal("expected no leaderboard for a scape without runs")
+	}
+}
+
 func TestMemoryStoreFitnessHistoryRoundTrip(t *testing.T) {
 	ctx := context.Background()
 	store := NewMemoryStore()
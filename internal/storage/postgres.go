@@ -0,0 +1,561 @@
+//go:build postgres
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"protogonos/internal/model"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresStore persists records in a PostgreSQL database shared by any
+// number of clients. The DSN is a libpq connection string or URL; pool
+// settings such as pool_max_conns and pool_max_conn_lifetime may be given
+// as its parameters.
+type PostgresStore struct {
+	dsn string
+
+	mu   sync.RWMutex
+	pool *pgxpool.Pool
+}
+
+func NewPostgresStore(dsn string) *PostgresStore {
+	return &PostgresStore{dsn: dsn}
+}
+
+// postgresMigrations are applied in order, each once, and recorded in
+// schema_migrations. Append new migrations; never edit applied ones.
+var postgresMigrations = []string{
+	`
+	CREATE TABLE genomes (
+		id TEXT PRIMARY KEY,
+		schema_version INTEGER NOT NULL,
+		codec_version INTEGER NOT NULL,
+		payload BYTEA NOT NULL
+	);
+	CREATE TABLE populations (
+		id TEXT PRIMARY KEY,
+		schema_version INTEGER NOT NULL,
+		codec_version INTEGER NOT NULL,
+		payload BYTEA NOT NULL
+	);
+	CREATE TABLE scape_summaries (
+		name TEXT PRIMARY KEY,
+		schema_version INTEGER NOT NULL,
+		codec_version INTEGER NOT NULL,
+		payload BYTEA NOT NULL
+	);
+	CREATE TABLE fitness_history (
+		run_id TEXT PRIMARY KEY,
+		payload BYTEA NOT NULL
+	);
+	CREATE TABLE generation_diagnostics (
+		run_id TEXT PRIMARY KEY,
+		payload BYTEA NOT NULL
+	);
+	CREATE TABLE top_genomes (
+		run_id TEXT PRIMARY KEY,
+		payload BYTEA NOT NULL
+	);
+	CREATE TABLE species_history (
+		run_id TEXT PRIMARY KEY,
+		payload BYTEA NOT NULL
+	);
+	CREATE TABLE lineage (
+		run_id TEXT PRIMARY KEY,
+		payload BYTEA NOT NULL
+	);
+	CREATE TABLE innovations (
+		run_id TEXT PRIMARY KEY,
+		payload BYTEA NOT NULL
+	);
+	CREATE TABLE checkpoints (
+		run_id TEXT PRIMARY KEY,
+		payload BYTEA NOT NULL
+	);
+	CREATE TABLE leaderboards (
+		scape TEXT PRIMARY KEY,
+		payload BYTEA NOT NULL
+	);
+	`,
+}
+
+// postgresMigrationLock is the advisory lock key that serializes migrations
+// when several clients start against a fresh database at once.
+const postgresMigrationLock = 0x70726f746f
+
+func (s *PostgresStore) Init(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.dsn == "" {
+		return errors.New("postgres connection string is required")
+	}
+	if s.pool != nil {
+		return nil
+	}
+
+	pool, err := pgxpool.New(ctx, s.dsn)
+	if err != nil {
+		return err
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return err
+	}
+	if err := migratePostgres(ctx, pool); err != nil {
+		pool.Close()
+		return err
+	}
+
+	s.pool = pool
+	return nil
+}
+
+// SchemaVersion reports how many migrations the database has applied.
+func (s *PostgresStore) SchemaVersion(ctx context.Context) (int, error) {
+	pool, err := s.getPool()
+	if err != nil {
+		return 0, err
+	}
+	var version int
+	err = pool.QueryRow(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	return version, err
+}
+
+func migratePostgres(ctx context.Context, pool *pgxpool.Pool) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, postgresMigrationLock); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)
+	`); err != nil {
+		return err
+	}
+	var applied int
+	if err := tx.QueryRow(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&applied); err != nil {
+		return err
+	}
+	if applied > len(postgresMigrations) {
+		return fmt.Errorf("postgres schema version %d is newer than this build supports (%d)", applied, len(postgresMigrations))
+	}
+	for version := applied + 1; version <= len(postgresMigrations); version++ {
+		if _, err := tx.Exec(ctx, postgresMigrations[version-1]); err != nil {
+			return fmt.Errorf("postgres migration %d: %w", version, err)
+		}
+		if _, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version) VALUES ($1)`, version); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// Reset drops every table, including the migration history, and migrates
+// the empty database again.
+func (s *PostgresStore) Reset(ctx context.Context) error {
+	s.mu.Lock()
+	pool := s.pool
+	s.pool = nil
+	s.mu.Unlock()
+
+	if pool == nil {
+		if s.dsn == "" {
+			return errors.New("postgres connection string is required")
+		}
+		var err error
+		if pool, err = pgxpool.New(ctx, s.dsn); err != nil {
+			return err
+		}
+	}
+	tables := append([]string{"schema_migrations", "genomes", "populations", "scape_summaries", "leaderboards"}, runTables...)
+	for _, table := range tables {
+		if _, err := pool.Exec(ctx, `DROP TABLE IF EXISTS `+table); err != nil {
+			pool.Close()
+			return err
+		}
+	}
+	pool.Close()
+	return s.Init(ctx)
+}
+
+func (s *PostgresStore) SaveGenome(ctx context.Context, genome model.Genome) error {
+	payload, err := EncodeGenome(genome)
+	if err != nil {
+		return err
+	}
+	return s.saveVersioned(ctx, "genomes", "id", genome.ID, genome.SchemaVersion, genome.CodecVersion, payload)
+}
+
+func (s *PostgresStore) GetGenome(ctx context.Context, id string) (model.Genome, bool, error) {
+	payload, ok, err := s.getPayload(ctx, "genomes", "id", id)
+	if err != nil || !ok {
+		return model.Genome{}, false, err
+	}
+	genome, err := DecodeGenome(payload)
+	if err != nil {
+		return model.Genome{}, false, fmt.Errorf("decode genome %s: %w", id, err)
+	}
+	return genome, true, nil
+}
+
+func (s *PostgresStore) DeleteGenome(ctx context.Context, id string) error {
+	return s.deletePayload(ctx, "genomes", "id", id)
+}
+
+func (s *PostgresStore) SavePopulation(ctx context.Context, population model.Population) error {
+	payload, err := EncodePopulation(population)
+	if err != nil {
+		return err
+	}
+	return s.saveVersioned(ctx, "populations", "id", population.ID, population.SchemaVersion, population.CodecVersion, payload)
+}
+
+func (s *PostgresStore) GetPopulation(ctx context.Context, id string) (model.Population, bool, error) {
+	payload, ok, err := s.getPayload(ctx, "populations", "id", id)
+	if err != nil || !ok {
+		return model.Population{}, false, err
+	}
+	population, err := DecodePopulation(payload)
+	if err != nil {
+		return model.Population{}, false, fmt.Errorf("decode population %s: %w", id, err)
+	}
+	return population, true, nil
+}
+
+func (s *PostgresStore) DeletePopulation(ctx context.Context, id string) error {
+	return s.deletePayload(ctx, "populations", "id", id)
+}
+
+func (s *PostgresStore) SaveScapeSummary(ctx context.Context, summary model.ScapeSummary) error {
+	payload, err := EncodeScapeSummary(summary)
+	if err != nil {
+		return err
+	}
+	return s.saveVersioned(ctx, "scape_summaries", "name", summary.Name, summary.SchemaVersion, summary.CodecVersion, payload)
+}
+
+func (s *PostgresStore) GetScapeSummary(ctx context.Context, name string) (model.ScapeSummary, bool, error) {
+	payload, ok, err := s.getPayload(ctx, "scape_summaries", "name", name)
+	if err != nil || !ok {
+		return model.ScapeSummary{}, false, err
+	}
+	summary, err := DecodeScapeSummary(payload)
+	if err != nil {
+		return model.ScapeSummary{}, false, fmt.Errorf("decode scape summary %s: %w", name, err)
+	}
+	return summary, true, nil
+}
+
+func (s *PostgresStore) SaveFitnessHistory(ctx context.Context, runID string, history []float64) error {
+	payload, err := EncodeFitnessHistory(history)
+	if err != nil {
+		return err
+	}
+	return s.savePayload(ctx, "fitness_history", "run_id", runID, payload)
+}
+
+func (s *PostgresStore) GetFitnessHistory(ctx context.Context, runID string) ([]float64, bool, error) {
+	payload, ok, err := s.getPayload(ctx, "fitness_history", "run_id", runID)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	history, err := DecodeFitnessHistory(payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("decode fitness history %s: %w", runID, err)
+	}
+	return history, true, nil
+}
+
+func (s *PostgresStore) SaveGenerationDiagnostics(ctx context.Context, runID string, diagnostics []model.GenerationDiagnostics) error {
+	payload, err := EncodeGenerationDiagnostics(diagnostics)
+	if err != nil {
+		return err
+	}
+	return s.savePayload(ctx, "generation_diagnostics", "run_id", runID, payload)
+}
+
+func (s *PostgresStore) GetGenerationDiagnostics(ctx context.Context, runID string) ([]model.GenerationDiagnostics, bool, error) {
+	payload, ok, err := s.getPayload(ctx, "generation_diagnostics", "run_id", runID)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	diagnostics, err := DecodeGenerationDiagnostics(payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("decode generation diagnostics %s: %w", runID, err)
+	}
+	return diagnostics, true, nil
+}
+
+func (s *PostgresStore) SaveTopGenomes(ctx context.Context, runID string, top []model.TopGenomeRecord) error {
+	payload, err := EncodeTopGenomes(top)
+	if err != nil {
+		return err
+	}
+	return s.savePayload(ctx, "top_genomes", "run_id", runID, payload)
+}
+
+func (s *PostgresStore) GetTopGenomes(ctx context.Context, runID string) ([]model.TopGenomeRecord, bool, error) {
+	payload, ok, err := s.getPayload(ctx, "top_genomes", "run_id", runID)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	top, err := DecodeTopGenomes(payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("decode top genomes %s: %w", runID, err)
+	}
+	return top, true, nil
+}
+
+func (s *PostgresStore) SaveSpeciesHistory(ctx context.Context, runID string, history []model.SpeciesGeneration) error {
+	payload, err := EncodeSpeciesHistory(history)
+	if err != nil {
+		return err
+	}
+	return s.savePayload(ctx, "species_history", "run_id", runID, payload)
+}
+
+func (s *PostgresStore) GetSpeciesHistory(ctx context.Context, runID string) ([]model.SpeciesGeneration, bool, error) {
+	payload, ok, err := s.getPayload(ctx, "species_history", "run_id", runID)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	history, err := DecodeSpeciesHistory(payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("decode species history %s: %w", runID, err)
+	}
+	return history, true, nil
+}
+
+func (s *PostgresStore) SaveLineage(ctx context.Context, runID string, lineage []model.LineageRecord) error {
+	payload, err := EncodeLineage(lineage)
+	if err != nil {
+		return err
+	}
+	return s.savePayload(ctx, "lineage", "run_id", runID, payload)
+}
+
+func (s *PostgresStore) GetLineage(ctx context.Context, runID string) ([]model.LineageRecord, bool, error) {
+	payload, ok, err := s.getPayload(ctx, "lineage", "run_id", runID)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	lineage, err := DecodeLineage(payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("decode lineage %s: %w", runID, err)
+	}
+	return lineage, true, nil
+}
+
+func (s *PostgresStore) SaveInnovations(ctx context.Context, runID string, records []model.InnovationRecord) error {
+	payload, err := EncodeInnovations(records)
+	if err != nil {
+		return err
+	}
+	return s.savePayload(ctx, "innovations", "run_id", runID, payload)
+}
+
+func (s *PostgresStore) GetInnovations(ctx context.Context, runID string) ([]model.InnovationRecord, bool, error) {
+	payload, ok, err := s.getPayload(ctx, "innovations", "run_id", runID)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	records, err := DecodeInnovations(payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("decode innovations %s: %w", runID, err)
+	}
+	return records, true, nil
+}
+
+func (s *PostgresStore) SaveCheckpoint(ctx context.Context, checkpoint model.RunCheckpoint) error {
+	payload, err := EncodeCheckpoint(checkpoint)
+	if err != nil {
+		return err
+	}
+	return s.savePayload(ctx, "checkpoints", "run_id", checkpoint.RunID, payload)
+}
+
+func (s *PostgresStore) GetCheckpoint(ctx context.Context, runID string) (model.RunCheckpoint, bool, error) {
+	payload, ok, err := s.getPayload(ctx, "checkpoints", "run_id", runID)
+	if err != nil || !ok {
+		return model.RunCheckpoint{}, false, err
+	}
+	checkpoint, err := DecodeCheckpoint(payload)
+	if err != nil {
+		return model.RunCheckpoint{}, false, fmt.Errorf("decode checkpoint %s: %w", runID, err)
+	}
+	return checkpoint, true, nil
+}
+
+func (s *PostgresStore) DeleteCheckpoint(ctx context.Context, runID string) error {
+	return s.deletePayload(ctx, "checkpoints", "run_id", runID)
+}
+
+func (s *PostgresStore) SaveLeaderboard(ctx context.Context, scape string, entries []model.LeaderboardEntry) error {
+	payload, err := EncodeLeaderboard(entries)
+	if err != nil {
+		return err
+	}
+	return s.savePayload(ctx, "leaderboards", "scape", scape, payload)
+}
+
+func (s *PostgresStore) GetLeaderboard(ctx context.Context, scape string) ([]model.LeaderboardEntry, bool, error) {
+	payload, ok, err := s.getPayload(ctx, "leaderboards", "scape", scape)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	entries, err := DecodeLeaderboard(payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("decode leaderboard %s: %w", scape, err)
+	}
+	return entries, true, nil
+}
+
+func (s *PostgresStore) ListGenomeIDs(ctx context.Context) ([]string, error) {
+	return s.queryStrings(ctx, `SELECT id FROM genomes ORDER BY id`)
+}
+
+func (s *PostgresStore) ListPopulationIDs(ctx context.Context) ([]string, error) {
+	return s.queryStrings(ctx, `SELECT id FROM populations ORDER BY id`)
+}
+
+func (s *PostgresStore) ListRunIDs(ctx context.Context) ([]string, error) {
+	return s.queryStrings(ctx, `
+		SELECT run_id FROM fitness_history
+		UNION SELECT run_id FROM generation_diagnostics
+		UNION SELECT run_id FROM species_history
+		UNION SELECT run_id FROM top_genomes
+		UNION SELECT run_id FROM lineage
+		UNION SELECT run_id FROM innovations
+		UNION SELECT run_id FROM checkpoints
+		ORDER BY run_id
+	`)
+}
+
+func (s *PostgresStore) DeleteRunData(ctx context.Context, runID string) error {
+	pool, err := s.getPool()
+	if err != nil {
+		return err
+	}
+	return pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+		for _, table := range runTables {
+			if _, err := tx.Exec(ctx, `DELETE FROM `+table+` WHERE run_id = $1`, runID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *PostgresStore) Vacuum(ctx context.Context) error {
+	pool, err := s.getPool()
+	if err != nil {
+		return err
+	}
+	_, err = pool.Exec(ctx, `VACUUM`)
+	return err
+}
+
+func (s *PostgresStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pool != nil {
+		s.pool.Close()
+		s.pool = nil
+	}
+	return nil
+}
+
+func (s *PostgresStore) getPool() (*pgxpool.Pool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.pool == nil {
+		return nil, errors.New("store is not initialized")
+	}
+	return s.pool, nil
+}
+
+func (s *PostgresStore) saveVersioned(ctx context.Context, table, keyColumn, key string, schemaVersion, codecVersion int, payload []byte) error {
+	pool, err := s.getPool()
+	if err != nil {
+		return err
+	}
+	_, err = pool.Exec(ctx, `
+		INSERT INTO `+table+` (`+keyColumn+`, schema_version, codec_version, payload)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT(`+keyColumn+`) DO UPDATE SET
+			schema_version = excluded.schema_version,
+			codec_version = excluded.codec_version,
+			payload = excluded.payload
+	`, key, schemaVersion, codecVersion, payload)
+	return err
+}
+
+func (s *PostgresStore) savePayload(ctx context.Context, table, keyColumn, key string, payload []byte) error {
+	pool, err := s.getPool()
+	if err != nil {
+		return err
+	}
+	_, err = pool.Exec(ctx, `
+		INSERT INTO `+table+` (`+keyColumn+`, payload)
+		VALUES ($1, $2)
+		ON CONFLICT(`+keyColumn+`) DO UPDATE SET
+			payload = excluded.payload
+	`, key, payload)
+	return err
+}
+
+func (s *PostgresStore) getPayload(ctx context.Context, table, keyColumn, key string) ([]byte, bool, error) {
+	pool, err := s.getPool()
+	if err != nil {
+		return nil, false, err
+	}
+	var payload []byte
+	err = pool.QueryRow(ctx, `SELECT payload FROM `+table+` WHERE `+keyColumn+` = $1`, key).Scan(&payload)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return payload, true, nil
+}
+
+func (s *PostgresStore) deletePayload(ctx context.Context, table, keyColumn, key string) error {
+	pool, err := s.getPool()
+	if err != nil {
+		return err
+	}
+	_, err = pool.Exec(ctx, `DELETE FROM `+table+` WHERE `+keyColumn+` = $1`, key)
+	return err
+}
+
+func (s *PostgresStore) queryStrings(ctx context.Context, query string) ([]string, error) {
+	pool, err := s.getPool()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := pool.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
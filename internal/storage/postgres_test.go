@@ -0,0 +1,148 @@
+//go:build postgres
+
+package storage
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+// newTestPostgresStore returns a reset store on the database named by
+// PROTOGONOS_TEST_POSTGRES_DSN, skipping the test when it is unset. The
+// database is wiped, so point it at a scratch database.
+func newTestPostgresStore(t *testing.T) *PostgresStore {
+	t.Helper()
+	dsn := os.Getenv("PROTOGONOS_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("PROTOGONOS_TEST_POSTGRES_DSN is not set")
+	}
+	store := NewPostgresStore(dsn)
+	if err := store.Reset(context.Background()); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+	return store
+}
+
+func TestPostgresStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := newTestPostgresStore(t)
+
+	genome := model.Genome{
+		VersionedRecord: model.VersionedRecord{SchemaVersion: CurrentSchemaVersion, CodecVersion: CurrentCodecVersion},
+		ID:              "g1",
+		Neurons:         []model.Neuron{{ID: "n1", Activation: "identity", Bias: 0.5}},
+		Synapses:        []model.Synapse{{ID: "s1", From: "n1", To: "n1", Weight: 1.25, Enabled: true}},
+	}
+	if err := store.SaveGenome(ctx, genome); err != nil {
+		t.Fatalf("save genome: %v", err)
+	}
+	genome.Neurons[0].Bias = 0.75
+	if err := store.SaveGenome(ctx, genome); err != nil {
+		t.Fatalf("overwrite genome: %v", err)
+	}
+	loadedGenome, ok, err := store.GetGenome(ctx, genome.ID)
+	if err != nil || !ok {
+		t.Fatalf("get genome: ok=%t err=%v", ok, err)
+	}
+	if loadedGenome.Neurons[0].Bias != 0.75 {
+		t.Fatalf("expected the overwritten genome, got %+v", loadedGenome)
+	}
+	if err := store.DeleteGenome(ctx, genome.ID); err != nil {
+		t.Fatalf("delete genome: %v", err)
+	}
+	if _, ok, err := store.GetGenome(ctx, genome.ID); err != nil || ok {
+		t.Fatalf("expected deleted genome to be absent: ok=%t err=%v", ok, err)
+	}
+
+	population := model.Population{
+		VersionedRecord: model.VersionedRecord{SchemaVersion: CurrentSchemaVersion, CodecVersion: CurrentCodecVersion},
+		ID:              "p1",
+		AgentIDs:        []string{"a1", "a2"},
+		Generation:      3,
+	}
+	if err := store.SavePopulation(ctx, population); err != nil {
+		t.Fatalf("save population: %v", err)
+	}
+	loadedPopulation, ok, err := store.GetPopulation(ctx, population.ID)
+	if err != nil || !ok || !reflect.DeepEqual(loadedPopulation.AgentIDs, population.AgentIDs) {
+		t.Fatalf("unexpected population: %+v ok=%t err=%v", loadedPopulation, ok, err)
+	}
+
+	if err := store.SaveFitnessHistory(ctx, "run-1", []float64{0.5, 0.9}); err != nil {
+		t.Fatalf("save history: %v", err)
+	}
+	history, ok, err := store.GetFitnessHistory(ctx, "run-1")
+	if err != nil || !ok || !reflect.DeepEqual(history, []float64{0.5, 0.9}) {
+		t.Fatalf("unexpected history: %v ok=%t err=%v", history, ok, err)
+	}
+	if err := store.SaveLeaderboard(ctx, "xor", []model.LeaderboardEntry{{RunID: "run-1", Fitness: 0.9}}); err != nil {
+		t.Fatalf("save leaderboard: %v", err)
+	}
+	if entries, ok, err := store.GetLeaderboard(ctx, "xor"); err != nil || !ok || len(entries) != 1 {
+		t.Fatalf("unexpected leaderboard: %+v ok=%t err=%v", entries, ok, err)
+	}
+	if err := store.SaveCheckpoint(ctx, model.RunCheckpoint{RunID: "run-1", Generation: 2}); err != nil {
+		t.Fatalf("save checkpoint: %v", err)
+	}
+
+	runs, err := store.ListRunIDs(ctx)
+	if err != nil || !reflect.DeepEqual(runs, []string{"run-1"}) {
+		t.Fatalf("unexpected run ids: %v err=%v", runs, err)
+	}
+	if err := store.DeleteRunData(ctx, "run-1"); err != nil {
+		t.Fatalf("delete run data: %v", err)
+	}
+	if _, ok, err := store.GetCheckpoint(ctx, "run-1"); err != nil || ok {
+		t.Fatalf("expected run data deletion to drop the checkpoint: ok=%t err=%v", ok, err)
+	}
+	if err := store.Vacuum(ctx); err != nil {
+		t.Fatalf("vacuum: %v", err)
+	}
+}
+
+func TestPostgresStoreMigratesOnceAcrossConcurrentClients(t *testing.T) {
+	ctx := context.Background()
+	store := newTestPostgresStore(t)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			client := NewPostgresStore(store.dsn)
+			defer func() {
+				_ = client.Close()
+			}()
+			if err := client.Init(ctx); err != nil {
+				errs[i] = err
+				return
+			}
+			errs[i] = client.SaveFitnessHistory(ctx, "shared", []float64{float64(i)})
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("client %d: %v", i, err)
+		}
+	}
+	version, err := store.SchemaVersion(ctx)
+	if err != nil {
+		t.Fatalf("schema version: %v", err)
+	}
+	if version != len(postgresMigrations) {
+		t.Fatalf("expected schema version %d, got %d", len(postgresMigrations), version)
+	}
+	if _, ok, err := store.GetFitnessHistory(ctx, "shared"); err != nil || !ok {
+		t.Fatalf("expected the shared run to be visible to every client: ok=%t err=%v", ok, err)
+	}
+}
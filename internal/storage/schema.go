@@ -0,0 +1,12 @@
+package storage
+
+import _ "embed"
+
+//go:embed schema/genome.schema.json
+var genomeSchema []byte
+
+// GenomeSchema returns the JSON Schema (draft 2020-12) of the genome format
+// DecodeGenome reads, for tools that produce genomes outside protogonos.
+func GenomeSchema() []byte {
+	return append([]byte(nil), genomeSchema...)
+}
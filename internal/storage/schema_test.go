@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+
+	"protogonos/internal/model"
+)
+
+type schemaObject struct {
+	Properties map[string]json.RawMessage `json:"properties"`
+	Required   []string                   `json:"required"`
+}
+
+func TestGenomeSchemaMatchesModel(t *testing.T) {
+	var schema struct {
+		schemaObject
+		Defs map[string]schemaObject `json:"$defs"`
+	}
+	if err := json.Unmarshal(GenomeSchema(), &schema); err != nil {
+		t.Fatalf("decode schema: %v", err)
+	}
+	objects := map[string]struct {
+		object schemaObject
+		model  reflect.Type
+	}{
+		"genome":               {schema.schemaObject, reflect.TypeOf(model.Genome{})},
+		"neuron":               {schema.Defs["neuron"], reflect.TypeOf(model.Neuron{})},
+		"synapse":              {schema.Defs["synapse"], reflect.TypeOf(model.Synapse{})},
+		"sensor_neuron_link":   {schema.Defs["sensor_neuron_link"], reflect.TypeOf(model.SensorNeuronLink{})},
+		"neuron_actuator_link": {schema.Defs["neuron_actuator_link"], reflect.TypeOf(model.NeuronActuatorLink{})},
+		"substrate":            {schema.Defs["substrate"], reflect.TypeOf(model.SubstrateConfig{})},
+		"grammar":              {schema.Defs["grammar"], reflect.TypeOf(model.GrammarConfig{})},
+		"grammar_rule":         {schema.Defs["grammar_rule"], reflect.TypeOf(model.GrammarRule{})},
+		"grammar_cell":         {schema.Defs["grammar_cell"], reflect.TypeOf(model.GrammarCell{})},
+		"plasticity":           {schema.Defs["plasticity"], reflect.TypeOf(model.PlasticityConfig{})},
+		"strategy":             {schema.Defs["strategy"], reflect.TypeOf(model.StrategyConfig{})},
+		"provenance":           {schema.Defs["provenance"], reflect.TypeOf(model.GenomeProvenance{})},
+	}
+	for name, item := range objects {
+		var fields, properties []string
+		for field := range jsonFieldTypes(item.model) {
+			fields = append(fields, field)
+		}
+		for property := range item.object.Properties {
+			properties = append(properties, property)
+		}
+		slices.Sort(fields)
+		slices.Sort(properties)
+		if !slices.Equal(fields, properties) {
+			t.Fatalf("%s: schema properties %v do not match model fields %v", name, properties, fields)
+		}
+		for _, required := range item.object.Required {
+			if _, ok := item.object.Properties[required]; !ok {
+				t.Fatalf("%s: required property %s is not defined", name, required)
+			}
+		}
+	}
+}
@@ -437,6 +437,215 @@ func (s *SQLiteStore) GetLineage(ctx context.Context, runID string) ([]model.Lin
 	return lineage, true, nil
 }
 
+func (s *SQLiteStore) SaveInnovations(ctx context.Context, runID string, records []model.InnovationRecord) error {
+	db, err := s.getDB()
+	if err != nil {
+		return err
+	}
+
+	payload, err := EncodeInnovations(records)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO innovations (run_id, payload)
+		VALUES (?, ?)
+		ON CONFLICT(run_id) DO UPDATE SET
+			payload = excluded.payload
+	`, runID, payload)
+	return err
+}
+
+func (s *SQLiteStore) GetInnovations(ctx context.Context, runID string) ([]model.InnovationRecord, bool, error) {
+	db, err := s.getDB()
+	if err != nil {
+		return nil, false, err
+	}
+
+	var payload []byte
+	err = db.QueryRowContext(ctx, `SELECT payload FROM innovations WHERE run_id = ?`, runID).Scan(&payload)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	records, err := DecodeInnovations(payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("decode innovations %s: %w", runID, err)
+	}
+	return records, true, nil
+}
+
+func (s *SQLiteStore) SaveCheckpoint(ctx context.Context, checkpoint model.RunCheckpoint) error {
+	db, err := s.getDB()
+	if err != nil {
+		return err
+	}
+
+	payload, err := EncodeCheckpoint(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO checkpoints (run_id, payload)
+		VALUES (?, ?)
+		ON CONFLICT(run_id) DO UPDATE SET
+			payload = excluded.payload
+	`, checkpoint.RunID, payload)
+	return err
+}
+
+func (s *SQLiteStore) GetCheckpoint(ctx context.Context, runID string) (model.RunCheckpoint, bool, error) {
+	db, err := s.getDB()
+	if err != nil {
+		return model.RunCheckpoint{}, false, err
+	}
+
+	var payload []byte
+	err = db.QueryRowContext(ctx, `SELECT payload FROM checkpoints WHERE run_id = ?`, runID).Scan(&payload)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.RunCheckpoint{}, false, nil
+		}
+		return model.RunCheckpoint{}, false, err
+	}
+
+	checkpoint, err := DecodeCheckpoint(payload)
+	if err != nil {
+		return model.RunCheckpoint{}, false, fmt.Errorf("decode checkpoint %s: %w", runID, err)
+	}
+	return checkpoint, true, nil
+}
+
+func (s *SQLiteStore) DeleteCheckpoint(ctx context.Context, runID string) error {
+	db, err := s.getDB()
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, `DELETE FROM checkpoints WHERE run_id = ?`, runID)
+	return err
+}
+
+func (s *SQLiteStore) SaveLeaderboard(ctx context.Context, scape string, entries []model.LeaderboardEntry) error {
+	db, err := s.getDB()
+	if err != nil {
+		return err
+	}
+
+	payload, err := EncodeLeaderboard(entries)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO leaderboards (scape, payload)
+		VALUES (?, ?)
+		ON CONFLICT(scape) DO UPDATE SET
+			payload = excluded.payload
+	`, scape, payload)
+	return err
+}
+
+func (s *SQLiteStore) GetLeaderboard(ctx context.Context, scape string) ([]model.LeaderboardEntry, bool, error) {
+	db, err := s.getDB()
+	if err != nil {
+		return nil, false, err
+	}
+
+	var payload []byte
+	err = db.QueryRowContext(ctx, `SELECT payload FROM leaderboards WHERE scape = ?`, scape).Scan(&payload)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	entries, err := DecodeLeaderboard(payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("decode leaderboard %s: %w", scape, err)
+	}
+	return entries, true, nil
+}
+
+func (s *SQLiteStore) ListGenomeIDs(ctx context.Context) ([]string, error) {
+	return s.queryStrings(ctx, `SELECT id FROM genomes ORDER BY id`)
+}
+
+func (s *SQLiteStore) ListPopulationIDs(ctx context.Context) ([]string, error) {
+	return s.queryStrings(ctx, `SELECT id FROM populations ORDER BY id`)
+}
+
+func (s *SQLiteStore) ListRunIDs(ctx context.Context) ([]string, error) {
+	return s.queryStrings(ctx, `
+		SELECT run_id FROM fitness_history
+		UNION SELECT run_id FROM generation_diagnostics
+		UNION SELECT run_id FROM species_history
+		UNION SELECT run_id FROM top_genomes
+		UNION SELECT run_id FROM lineage
+		UNION SELECT run_id FROM innovations
+		UNION SELECT run_id FROM checkpoints
+		ORDER BY run_id
+	`)
+}
+
+func (s *SQLiteStore) DeleteRunData(ctx context.Context, runID string) error {
+	db, err := s.getDB()
+	if err != nil {
+		return err
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, table := range runTables {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE run_id = ?`, runID); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (s *SQLiteStore) Vacuum(ctx context.Context) error {
+	db, err := s.getDB()
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, `VACUUM`)
+	return err
+}
+
+func (s *SQLiteStore) IntegrityCheck(ctx context.Context) ([]string, error) {
+	return s.queryStrings(ctx, `PRAGMA integrity_check`)
+}
+
+func (s *SQLiteStore) queryStrings(ctx context.Context, query string) ([]string, error) {
+	db, err := s.getDB()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
 func (s *SQLiteStore) Close() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -499,6 +708,18 @@ func createTables(ctx context.Context, db *sql.DB) error {
 			run_id TEXT PRIMARY KEY,
 			payload BLOB NOT NULL
 		);
+		CREATE TABLE IF NOT EXISTS innovations (
+			run_id TEXT PRIMARY KEY,
+			payload BLOB NOT NULL
+		);
+		CREATE TABLE IF NOT EXISTS checkpoints (
+			run_id TEXT PRIMARY KEY,
+			payload BLOB NOT NULL
+		);
+		CREATE TABLE IF NOT EXISTS leaderboards (
+			scape TEXT PRIMARY KEY,
+			payload BLOB NOT NULL
+		);
 	`)
 	return err
 }
@@ -290,3 +290,68 @@ func TestSQLiteStoreResetClearsData(t *testing.T) {
 		t.Fatal("expected reset to clear populations")
 	}
 }
+
+func TestSQLiteStoreListDeleteRunDataAndVacuum(t *testing.T) {
+	ctx := context.Background()
+	store := NewSQLiteStore(filepath.Join(t.TempDir(), "protogonos.db"))
+	if err := store.Init(ctx); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+
+	if err := store.SaveGenome(ctx, model.Genome{VersionedRecord: model.VersionedRecord{SchemaVersion: CurrentSchemaVersion, CodecVersion: CurrentCodecVersion}, ID: "g1"}); err != nil {
+		t.Fatalf("save genome: %v", err)
+	}
+	if err := store.SaveFitnessHistory(ctx, "run-a", []float64{1}); err != nil {
+		t.Fatalf("save history: %v", err)
+	}
+	if err := store.SaveLineage(ctx, "run-b", []model.LineageRecord{{GenomeID: "g1"}}); err != nil {
+		t.Fatalf("save lineage: %v", err)
+	}
+	if err := store.SaveInnovations(ctx, "run-a", []model.InnovationRecord{{Key: "s:i->o", Innovation: 1}}); err != nil {
+		t.Fatalf("save innovations: %v", err)
+	}
+	if innovations, ok, err := store.GetInnovations(ctx, "run-a"); err != nil || !ok || len(innovations) != 1 || innovations[0].Key != "s:i->o" {
+		t.Fatalf("unexpected innovations: %+v ok=%t err=%v", innovations, ok, err)
+	}
+	if err := store.SaveLeaderboard(ctx, "xor", []model.LeaderboardEntry{{RunID: "run-a", GenomeID: "g1", Fitness: 0.5}}); err != nil {
+		t.Fatalf("save leaderboard: %v", err)
+	}
+	if leaderboard, ok, err := store.GetLeaderboard(ctx, "xor"); err != nil || !ok || len(leaderboard) != 1 || leaderboard[0].RunID != "run-a" {
+		t.Fatalf("unexpected leaderboard: %+v ok=%t err=%v", leaderboard, ok, err)
+	}
+	for _, generation := range []int{2, 4} {
+		if err := store.SaveCheckpoint(ctx, model.RunCheckpoint{RunID: "run-a", Generation: generation, Config: []byte(`{}`), State: []byte(`{"generation":2}`)}); err != nil {
+			t.Fatalf("save checkpoint: %v", err)
+		}
+	}
+	if checkpoint, ok, err := store.GetCheckpoint(ctx, "run-a"); err != nil || !ok || checkpoint.Generation != 4 || string(checkpoint.State) != `{"generation":2}` {
+		t.Fatalf("unexpected checkpoint: %+v ok=%t err=%v", checkpoint, ok, err)
+	}
+
+	genomeIDs, err := store.ListGenomeIDs(ctx)
+	if err != nil || len(genomeIDs) != 1 || genomeIDs[0] != "g1" {
+		t.Fatalf("unexpected genome ids: %v err=%v", genomeIDs, err)
+	}
+	runIDs, err := store.ListRunIDs(ctx)
+	if err != nil || len(runIDs) != 2 || runIDs[0] != "run-a" || runIDs[1] != "run-b" {
+		t.Fatalf("unexpected run ids: %v err=%v", runIDs, err)
+	}
+	if err := store.DeleteRunData(ctx, "run-a"); err != nil {
+		t.Fatalf("delete run data: %v", err)
+	}
+	if _, ok, _ := store.GetFitnessHistory(ctx, "run-a"); ok {
+		t.Fatal("expected run-a fitness history to be deleted")
+	}
+	if _, ok, _ := store.GetInnovations(ctx, "run-a"); ok {
+		t.Fatal("expected run-a innovations to be deleted")
+	}
+	if _, ok, _ := store.GetCheckpoint(ctx, "run-a"); ok {
+		t.Fatal("expected run-a checkpoint to be deleted")
+	}
+	if err := store.Vacuum(ctx); err != nil {
+		t.Fatalf("vacuum: %v", err)
+	}
+}
@@ -33,3 +33,48 @@ type Store interface {
 type Resetter interface {
 	Reset(ctx context.Context) error
 }
+
+// Lister is an optional capability that enumerates persisted record keys.
+type Lister interface {
+	ListGenomeIDs(ctx context.Context) ([]string, error)
+	ListPopulationIDs(ctx context.Context) ([]string, error)
+	ListRunIDs(ctx context.Context) ([]string, error)
+}
+
+// RunDeleter is an optional capability that removes all run-keyed records
+// (fitness history, diagnostics, species history, top genomes, lineage,
+// innovation registry, checkpoint).
+type RunDeleter interface {
+	DeleteRunData(ctx context.Context, runID string) error
+}
+
+// runTables are the run-keyed tables of the SQL backends.
+var runTables = []string{"fitness_history", "generation_diagnostics", "species_history", "top_genomes", "lineage", "innovations", "checkpoints"}
+
+// Vacuumer is an optional capability that reclaims space left behind by
+// deleted records.
+type Vacuumer interface {
+	Vacuum(ctx context.Context) error
+}
+
+// LeaderboardStore is an optional capability that persists each scape's
+// leaderboard of champions across runs.
+type LeaderboardStore interface {
+	SaveLeaderboard(ctx context.Context, scape string, entries []model.LeaderboardEntry) error
+	GetLeaderboard(ctx context.Context, scape string) ([]model.LeaderboardEntry, bool, error)
+}
+
+// InnovationStore is an optional capability that persists the innovation
+// registry of runs that track historical markings.
+type InnovationStore interface {
+	SaveInnovations(ctx context.Context, runID string, records []model.InnovationRecord) error
+	GetInnovations(ctx context.Context, runID string) ([]model.InnovationRecord, bool, error)
+}
+
+// CheckpointStore is an optional capability that keeps the latest
+// checkpoint of each unfinished run so it can be resumed.
+type CheckpointStore interface {
+	SaveCheckpoint(ctx context.Context, checkpoint model.RunCheckpoint) error
+	GetCheckpoint(ctx context.Context, runID string) (model.RunCheckpoint, bool, error)
+	DeleteCheckpoint(ctx context.Context, runID string) error
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"math"
 	"math/rand"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -21,7 +22,40 @@ type Exoself struct {
 	MinImprovement     float64
 	GoalFitness        float64
 	CandidateSelection string
-	mu                 sync.Mutex
+	// RecordTrace fills TuneReport.Trace with every candidate evaluated.
+	RecordTrace bool
+	// Acceptance selects the fitness candidates must improve on; see
+	// TuneAcceptValidation. Empty means TuneAcceptTraining.
+	Acceptance string
+	mu         sync.Mutex
+}
+
+const (
+	// TuneAcceptTraining accepts candidates on the fitness they were tuned on.
+	TuneAcceptTraining = "training"
+	// TuneAcceptValidation accepts candidates on their validation-split
+	// fitness, scored after each training evaluation, so the hill climber does
+	// not overfit the training rows. Only runtime tuning can evaluate another
+	// mode; genome tuning falls back to training fitness.
+	TuneAcceptValidation = "validation"
+
+	validationMode = "validation"
+)
+
+// TuneAcceptanceNames lists the accepted Acceptance values.
+var TuneAcceptanceNames = []string{TuneAcceptTraining, TuneAcceptValidation}
+
+// NormalizeTuneAcceptanceName maps the empty name to TuneAcceptTraining and
+// reports whether name is a known acceptance mode.
+func NormalizeTuneAcceptanceName(name string) (string, bool) {
+	switch strings.TrimSpace(strings.ToLower(name)) {
+	case "", TuneAcceptTraining:
+		return TuneAcceptTraining, true
+	case TuneAcceptValidation:
+		return TuneAcceptValidation, true
+	default:
+		return name, false
+	}
 }
 
 const (
@@ -146,6 +180,27 @@ func (e *Exoself) TuneRuntimeWithReport(
 		return result, nil
 	}
 
+	// score is the fitness acceptance compares: training fitness, or the
+	// validation fitness of the runtime's current weights.
+	score := func(training float64) (float64, *float64, error) {
+		if e.Acceptance != TuneAcceptValidation {
+			return training, nil, nil
+		}
+		if err := runtime.Reactivate(); err != nil {
+			return 0, nil, err
+		}
+		validation, _, _, err := evaluate(ctx, validationMode)
+		if err != nil {
+			return 0, nil, err
+		}
+		result.Report.ValidationEvaluations++
+		return validation, &validation, nil
+	}
+	bestScore, bestValidation, err := score(bestFitness)
+	if err != nil {
+		return RuntimeTuneResult{}, err
+	}
+
 	consecutiveNoImprovement := 0
 	for consecutiveNoImprovement < attempts {
 		result.Report.AttemptsExecuted++
@@ -156,8 +211,9 @@ func (e *Exoself) TuneRuntimeWithReport(
 		localBest := cloneGenome(best)
 		localBestFitness := bestFitness
 		localBestTrace := cloneRuntimeTrace(bestTrace)
+		localBestScore, localBestValidation := bestScore, bestValidation
 		localGoalReached := false
-		for _, base := range bases {
+		for index, base := range bases {
 			if err := runtime.Reactivate(); err != nil {
 				return RuntimeTuneResult{}, err
 			}
@@ -176,11 +232,18 @@ func (e *Exoself) TuneRuntimeWithReport(
 			if e.GoalFitness > 0 && candidateFitness >= e.GoalFitness {
 				candidateGoalReached = true
 			}
-			if scalarFitnessDominates(candidateFitness, localBestFitness, e.MinImprovement) {
+			candidateScore, candidateValidation, err := score(candidateFitness)
+			if err != nil {
+				return RuntimeTuneResult{}, err
+			}
+			accepted := scalarFitnessDominates(candidateScore, localBestScore, e.MinImprovement)
+			e.traceCandidate(&result.Report, index, base, candidate, localBestFitness, candidateFitness, localBestValidation, candidateValidation, accepted)
+			if accepted {
 				result.Report.AcceptedCandidates++
 				localBest = candidate
 				localBestFitness = candidateFitness
 				localBestTrace = cloneRuntimeTrace(candidateTrace)
+				localBestScore, localBestValidation = candidateScore, candidateValidation
 				localGoalReached = candidateGoalReached
 			} else {
 				result.Report.RejectedCandidates++
@@ -188,7 +251,7 @@ func (e *Exoself) TuneRuntimeWithReport(
 		}
 
 		recentBase = cloneGenome(localBest)
-		improved := scalarFitnessDominates(localBestFitness, bestFitness, e.MinImprovement)
+		improved := scalarFitnessDominates(localBestScore, bestScore, e.MinImprovement)
 		if improved {
 			if err := runtime.ApplyGenome(localBest); err != nil {
 				return RuntimeTuneResult{}, err
@@ -197,6 +260,7 @@ func (e *Exoself) TuneRuntimeWithReport(
 			best = localBest
 			bestFitness = localBestFitness
 			bestTrace = cloneRuntimeTrace(localBestTrace)
+			bestScore, bestValidation = localBestScore, localBestValidation
 		} else {
 			if err := runtime.RestoreWeights(); err != nil {
 				return RuntimeTuneResult{}, err
@@ -286,7 +350,7 @@ func (e *Exoself) TuneWithReport(ctx context.Context, genome model.Genome, attem
 		}
 		localBest := cloneGenome(best)
 		localBestFitness := bestFitness
-		for _, base := range bases {
+		for index, base := range bases {
 			candidate, err := e.perturbCandidate(ctx, base, perturbationRange, annealingFactor)
 			if err != nil {
 				return model.Genome{}, report, err
@@ -296,7 +360,9 @@ func (e *Exoself) TuneWithReport(ctx context.Context, genome model.Genome, attem
 				return model.Genome{}, report, err
 			}
 			report.CandidateEvaluations++
-			if scalarFitnessDominates(candidateFitness, localBestFitness, e.MinImprovement) {
+			accepted := scalarFitnessDominates(candidateFitness, localBestFitness, e.MinImprovement)
+			e.traceCandidate(&report, index, base, candidate, localBestFitness, candidateFitness, nil, nil, accepted)
+			if accepted {
 				report.AcceptedCandidates++
 				localBest = candidate
 				localBestFitness = candidateFitness
@@ -324,6 +390,61 @@ func (e *Exoself) TuneWithReport(ctx context.Context, genome model.Genome, attem
 	return best, report, nil
 }
 
+// traceCandidate records candidate, perturbed from base and compared against
+// the incumbent fitness before, when RecordTrace is set. It attributes the
+// record to the attempt currently executing. The validation fitnesses are nil
+// unless candidates are accepted on validation.
+func (e *Exoself) traceCandidate(report *TuneReport, index int, base, candidate model.Genome, before, after float64, validationBefore, validationAfter *float64, accepted bool) {
+	if !e.RecordTrace {
+		return
+	}
+	report.Selection = NormalizeCandidateSelectionName(e.CandidateSelection)
+	elements, delta := perturbationDiff(base, candidate)
+	record := model.TuningTraceCandidate{
+		Attempt:       report.AttemptsExecuted,
+		Candidate:     index + 1,
+		Elements:      elements,
+		Delta:         delta,
+		FitnessBefore: before,
+		FitnessAfter:  after,
+		Accepted:      accepted,
+	}
+	if validationBefore != nil && validationAfter != nil {
+		before, after := *validationBefore, *validationAfter
+		record.ValidationBefore = &before
+		record.ValidationAfter = &after
+	}
+	report.Trace = append(report.Trace, record)
+}
+
+// perturbationDiff lists the tuning elements whose weights or tunables differ
+// between base and candidate, sorted, and the Euclidean norm of the change.
+func perturbationDiff(base, candidate model.Genome) ([]string, float64) {
+	touched := map[string]struct{}{}
+	sumSquares := 0.0
+	for i, syn := range candidate.Synapses {
+		if i >= len(base.Synapses) {
+			break
+		}
+		if diff := syn.Weight - base.Synapses[i].Weight; diff != 0 {
+			sumSquares += diff * diff
+			touched[tuningElementNeuron+":"+syn.To] = struct{}{}
+		}
+	}
+	for actuatorID, value := range candidate.ActuatorTunables {
+		if diff := value - base.ActuatorTunables[actuatorID]; diff != 0 {
+			sumSquares += diff * diff
+			touched[tuningElementActuator+":"+actuatorID] = struct{}{}
+		}
+	}
+	elements := make([]string, 0, len(touched))
+	for element := range touched {
+		elements = append(elements, element)
+	}
+	sort.Strings(elements)
+	return elements, math.Sqrt(sumSquares)
+}
+
 func (e *Exoself) randIntn(n int) int {
 	e.mu.Lock()
 	defer e.mu.Unlock()
@@ -378,6 +499,17 @@ func cloneGenome(g model.Genome) model.Genome {
 		s := *g.Strategy
 		out.Strategy = &s
 	}
+	if g.Annotations != nil {
+		out.Annotations = make(map[string]string, len(g.Annotations))
+		for k, v := range g.Annotations {
+			out.Annotations[k] = v
+		}
+	}
+	if g.Provenance != nil {
+		prov := *g.Provenance
+		prov.Notes = append([]string(nil), g.Provenance.Notes...)
+		out.Provenance = &prov
+	}
 	return out
 }
 
@@ -933,10 +1065,17 @@ func uniqueStrings(values []string) []string {
 	return out
 }
 
+// incomingSynapseIndexes lists the unfrozen synapses feeding neuronID, or
+// none when the neuron itself is frozen; frozen weights are never tuned.
 func incomingSynapseIndexes(genome model.Genome, neuronID string) []int {
+	for _, neuron := range genome.Neurons {
+		if neuron.ID == neuronID && neuron.Frozen {
+			return nil
+		}
+	}
 	indexes := make([]int, 0, len(genome.Synapses))
 	for i, syn := range genome.Synapses {
-		if syn.To == neuronID {
+		if syn.To == neuronID && !syn.Frozen {
 			indexes = append(indexes, i)
 		}
 	}
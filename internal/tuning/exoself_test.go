@@ -208,6 +208,74 @@ func TestExoselfTuneWithReportProvidesTelemetry(t *testing.T) {
 	}
 }
 
+func TestExoselfRecordTraceLogsEveryCandidate(t *testing.T) {
+	genome := model.Genome{
+		ID: "g",
+		Neurons: []model.Neuron{
+			{ID: "i", Activation: "identity"},
+			{ID: "o", Activation: "identity"},
+		},
+		Synapses: []model.Synapse{{ID: "s", From: "i", To: "o", Weight: -2, Enabled: true}},
+	}
+	fitnessFn := func(_ context.Context, g model.Genome) (float64, error) {
+		delta := g.Synapses[0].Weight - 1
+		return 1 - delta*delta, nil
+	}
+
+	untraced := &Exoself{Rand: rand.New(rand.NewSource(7)), Steps: 2, StepSize: 0.4}
+	want, plain, err := untraced.TuneWithReport(context.Background(), genome, 5, fitnessFn)
+	if err != nil {
+		t.Fatalf("tune without trace: %v", err)
+	}
+	if len(plain.Trace) != 0 || plain.Selection != "" {
+		t.Fatalf("expected no trace without RecordTrace, got %+v", plain)
+	}
+
+	tuner := &Exoself{Rand: rand.New(rand.NewSource(7)), Steps: 2, StepSize: 0.4, RecordTrace: true}
+	tuned, report, err := tuner.TuneWithReport(context.Background(), genome, 5, fitnessFn)
+	if err != nil {
+		t.Fatalf("tune with trace: %v", err)
+	}
+	if tuned.Synapses[0].Weight != want.Synapses[0].Weight {
+		t.Fatalf("expected tracing to leave tuning unchanged: %f vs %f", tuned.Synapses[0].Weight, want.Synapses[0].Weight)
+	}
+	if report.Selection != CandidateSelectBestSoFar {
+		t.Fatalf("expected normalized selection, got %q", report.Selection)
+	}
+	// The baseline evaluation is not a candidate.
+	if len(report.Trace) != report.CandidateEvaluations-1 {
+		t.Fatalf("expected %d trace records, got %d", report.CandidateEvaluations-1, len(report.Trace))
+	}
+	accepted, perturbed := 0, 0
+	for i, record := range report.Trace {
+		if record.Attempt < 1 || record.Attempt > report.AttemptsExecuted || record.Candidate != 1 {
+			t.Fatalf("record %d: unexpected attempt/candidate %+v", i, record)
+		}
+		// Steps that land on the input neuron have no incoming weight to move.
+		if record.Delta == 0 {
+			if len(record.Elements) != 0 {
+				t.Fatalf("record %d: unchanged candidate lists elements %+v", i, record)
+			}
+		} else if len(record.Elements) != 1 || record.Elements[0] != "neuron:o" {
+			t.Fatalf("record %d: expected a perturbation of neuron o, got %+v", i, record)
+		} else {
+			perturbed++
+		}
+		if record.Accepted != (record.FitnessAfter > record.FitnessBefore) {
+			t.Fatalf("record %d: acceptance disagrees with fitness %+v", i, record)
+		}
+		if record.Accepted {
+			accepted++
+		}
+	}
+	if perturbed == 0 {
+		t.Fatal("expected at least one perturbed candidate")
+	}
+	if accepted != report.AcceptedCandidates {
+		t.Fatalf("expected %d accepted records, got %d", report.AcceptedCandidates, accepted)
+	}
+}
+
 func TestExoselfTuneRuntimeWithReportUsesBackupAndRestore(t *testing.T) {
 	genome := model.Genome{
 		ID:       "g",
@@ -285,6 +353,78 @@ func TestExoselfTuneRuntimeWithReportStopsAtGoal(t *testing.T) {
 	}
 }
 
+func TestExoselfValidationAcceptanceFollowsValidationFitness(t *testing.T) {
+	genome := model.Genome{
+		ID: "g",
+		Neurons: []model.Neuron{
+			{ID: "i", Activation: "identity"},
+			{ID: "o", Activation: "identity"},
+		},
+		Synapses: []model.Synapse{{ID: "s", From: "i", To: "o", Weight: 0.2, Enabled: true}},
+	}
+	// Training rewards larger weights, validation smaller ones.
+	runtime := &runtimeProbeAgent{genome: cloneGenome(genome)}
+	evaluate := func(_ context.Context, mode string) (float64, map[string]any, bool, error) {
+		weight := runtime.genome.Synapses[0].Weight
+		if mode == "validation" {
+			return -weight, map[string]any{}, false, nil
+		}
+		return weight, map[string]any{}, false, nil
+	}
+	for _, acceptance := range []string{TuneAcceptTraining, TuneAcceptValidation} {
+		runtime.genome = cloneGenome(genome)
+		tuner := &Exoself{
+			Rand:               rand.New(rand.NewSource(107)),
+			Steps:              1,
+			StepSize:           0.25,
+			CandidateSelection: CandidateSelectBestSoFar,
+			RecordTrace:        true,
+			Acceptance:         acceptance,
+		}
+		result, err := tuner.TuneRuntimeWithReport(context.Background(), runtime, 8, "gt", evaluate)
+		if err != nil {
+			t.Fatalf("%s: tune runtime: %v", acceptance, err)
+		}
+		if result.Report.AcceptedCandidates == 0 {
+			t.Fatalf("%s: expected accepted candidates: %+v", acceptance, result.Report)
+		}
+		weight := result.Genome.Synapses[0].Weight
+		if acceptance == TuneAcceptTraining && weight <= 0.2 {
+			t.Fatalf("training acceptance should raise the weight, got=%f", weight)
+		}
+		if acceptance == TuneAcceptValidation && weight >= 0.2 {
+			t.Fatalf("validation acceptance should lower the weight, got=%f", weight)
+		}
+		if result.Fitness != weight {
+			t.Fatalf("%s: expected reported fitness to stay training fitness %f, got=%f", acceptance, weight, result.Fitness)
+		}
+		for _, record := range result.Report.Trace {
+			validated := record.ValidationBefore != nil && record.ValidationAfter != nil
+			if validated != (acceptance == TuneAcceptValidation) {
+				t.Fatalf("%s: unexpected validation fields in trace record %+v", acceptance, record)
+			}
+			if validated && *record.ValidationAfter != -record.FitnessAfter {
+				t.Fatalf("expected validation fitness to mirror training fitness: %+v", record)
+			}
+		}
+		if acceptance == TuneAcceptValidation && result.Report.ValidationEvaluations != result.Report.CandidateEvaluations {
+			t.Fatalf("expected one validation evaluation per candidate: %+v", result.Report)
+		}
+	}
+}
+
+func TestNormalizeTuneAcceptanceName(t *testing.T) {
+	if name, ok := NormalizeTuneAcceptanceName(""); !ok || name != TuneAcceptTraining {
+		t.Fatalf("expected empty acceptance to default to training, got=%q ok=%t", name, ok)
+	}
+	if name, ok := NormalizeTuneAcceptanceName("Validation"); !ok || name != TuneAcceptValidation {
+		t.Fatalf("expected validation acceptance, got=%q ok=%t", name, ok)
+	}
+	if _, ok := NormalizeTuneAcceptanceName("test"); ok {
+		t.Fatal("expected unknown acceptance to be rejected")
+	}
+}
+
 func TestExoselfDynamicRandomSelectionSupported(t *testing.T) {
 	genome := model.Genome{
 		ID:       "g",
@@ -918,3 +1058,35 @@ func TestVectorBasicStatsEmptyInput(t *testing.T) {
 		t.Fatalf("expected nil stats for empty input, got max=%+v min=%+v avg=%+v std=%+v", maxV, minV, avgV, stdV)
 	}
 }
+
+func TestExoselfPerturbCandidateSkipsFrozenWeights(t *testing.T) {
+	genome := model.Genome{
+		ID: "g",
+		Neurons: []model.Neuron{
+			{ID: "i", Activation: "identity"},
+			{ID: "h", Activation: "tanh", Frozen: true},
+			{ID: "o", Activation: "identity"},
+		},
+		Synapses: []model.Synapse{
+			{ID: "ih", From: "i", To: "h", Weight: 0.5, Enabled: true},
+			{ID: "ho", From: "h", To: "o", Weight: -0.5, Enabled: true, Frozen: true},
+			{ID: "io", From: "i", To: "o", Weight: 1, Enabled: true},
+		},
+	}
+	tuner := &Exoself{
+		Rand:               rand.New(rand.NewSource(5)),
+		Steps:              20,
+		StepSize:           1,
+		CandidateSelection: CandidateSelectAll,
+	}
+	tuned, err := tuner.perturbCandidate(context.Background(), genome, 1.0, 1.0)
+	if err != nil {
+		t.Fatalf("perturbCandidate: %v", err)
+	}
+	if tuned.Synapses[0].Weight != 0.5 || tuned.Synapses[1].Weight != -0.5 {
+		t.Fatalf("expected frozen weights to be untouched, got %+v", tuned.Synapses)
+	}
+	if tuned.Synapses[2].Weight == 1 {
+		t.Fatalf("expected the unfrozen weight to be tuned, got %+v", tuned.Synapses)
+	}
+}
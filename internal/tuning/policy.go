@@ -3,6 +3,8 @@ package tuning
 import (
 	"fmt"
 	"math"
+	"sync"
+	"time"
 
 	"protogonos/internal/model"
 )
@@ -114,6 +116,109 @@ func (p WSizeProportionalAttemptPolicy) Attempts(baseAttempts, _generation, _tot
 	return 10 + scaled
 }
 
+// CostAwareAttemptPolicy is an AttemptPolicy that adapts to measured tuning
+// cost. The population monitor calls BeginRun before each run with its worker
+// count and ObserveTuning after every tuning session.
+type CostAwareAttemptPolicy interface {
+	AttemptPolicy
+	BeginRun(workers int)
+	ObserveTuning(plannedAttempts, evaluations int, elapsed time.Duration)
+}
+
+// wallClockMaxAttemptsFactor caps wall-clock attempts at a multiple of the
+// base attempts so fast scapes do not tune without bound.
+const wallClockMaxAttemptsFactor = 10
+
+// WallClockAttemptPolicy spreads a run's tuning wall-clock Budget over the
+// remaining generations. Each genome gets the attempts its share of the
+// remaining time buys at the measured cost per evaluation and evaluations
+// per planned attempt; its share assumes the previous generation's number of
+// tuning jobs ran across all workers. Until a session has been measured it
+// uses the base attempts, and once the budget is spent it stops tuning.
+type WallClockAttemptPolicy struct {
+	Budget time.Duration
+
+	mu               sync.Mutex
+	start            time.Time
+	workers          int
+	generation       int
+	generationJobs   int
+	lastJobs         int
+	observedAttempts int
+	observedEvals    int
+	observedEvalTime time.Duration
+}
+
+func (*WallClockAttemptPolicy) Name() string { return "wall_clock" }
+
+func (p *WallClockAttemptPolicy) BeginRun(workers int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if workers < 1 {
+		workers = 1
+	}
+	p.start = time.Now()
+	p.workers = workers
+	p.generation = -1
+	p.generationJobs = 0
+	p.lastJobs = 0
+	p.observedAttempts = 0
+	p.observedEvals = 0
+	p.observedEvalTime = 0
+}
+
+func (p *WallClockAttemptPolicy) ObserveTuning(plannedAttempts, evaluations int, elapsed time.Duration) {
+	if plannedAttempts <= 0 || evaluations <= 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.observedAttempts += plannedAttempts
+	p.observedEvals += evaluations
+	p.observedEvalTime += elapsed
+}
+
+func (p *WallClockAttemptPolicy) Attempts(baseAttempts, generation, totalGenerations int, _ model.Genome) int {
+	if baseAttempts <= 0 {
+		return 0
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.start.IsZero() {
+		p.start = time.Now()
+		p.workers = 1
+		p.generation = -1
+	}
+	if generation != p.generation {
+		if p.generationJobs > 0 {
+			p.lastJobs = p.generationJobs
+		}
+		p.generation = generation
+		p.generationJobs = 0
+	}
+	p.generationJobs++
+
+	remaining := p.Budget - time.Since(p.start)
+	if remaining <= 0 {
+		return 0
+	}
+	if p.observedEvals == 0 || p.lastJobs == 0 {
+		return baseAttempts
+	}
+	remainingGenerations := totalGenerations - generation
+	if remainingGenerations < 1 {
+		remainingGenerations = 1
+	}
+	share := remaining.Seconds() / float64(remainingGenerations) * float64(p.workers) / float64(p.lastJobs)
+	evalCost := p.observedEvalTime.Seconds() / float64(p.observedEvals)
+	evalsPerAttempt := float64(p.observedEvals) / float64(p.observedAttempts)
+	if evalCost <= 0 {
+		return baseAttempts * wallClockMaxAttemptsFactor
+	}
+	attempts := int(share / (evalCost * evalsPerAttempt))
+	return satInt(attempts, 1, baseAttempts*wallClockMaxAttemptsFactor)
+}
+
 func AttemptPolicyFromConfig(name string, param float64) (AttemptPolicy, error) {
 	switch NormalizeAttemptPolicyName(name) {
 	case "", "fixed":
@@ -142,6 +247,11 @@ func AttemptPolicyFromConfig(name string, param float64) (AttemptPolicy, error)
 			power = 1.0
 		}
 		return WSizeProportionalAttemptPolicy{Power: power}, nil
+	case "wall_clock":
+		if param <= 0 {
+			return nil, fmt.Errorf("wall_clock tune duration policy requires a budget in seconds > 0")
+		}
+		return &WallClockAttemptPolicy{Budget: time.Duration(param * float64(time.Second))}, nil
 	default:
 		return nil, fmt.Errorf("unsupported tune duration policy: %s", name)
 	}
@@ -159,6 +269,8 @@ func NormalizeAttemptPolicyName(name string) string {
 		return "nsize_proportional"
 	case "wsize_proportional":
 		return "wsize_proportional"
+	case "wall_clock":
+		return "wall_clock"
 	default:
 		return name
 	}
@@ -2,6 +2,7 @@ package tuning
 
 import (
 	"testing"
+	"time"
 
 	"protogonos/internal/model"
 )
@@ -91,3 +92,43 @@ func TestWSizeProportionalAttemptPolicy(t *testing.T) {
 		t.Fatalf("expected wsize attempts=17, got=%d", got)
 	}
 }
+
+func TestWallClockAttemptPolicy(t *testing.T) {
+	if _, err := AttemptPolicyFromConfig("wall_clock", 0); err == nil {
+		t.Fatal("expected wall_clock policy without a budget to be rejected")
+	}
+	policy, err := AttemptPolicyFromConfig("wall_clock", 100)
+	if err != nil {
+		t.Fatalf("wall_clock policy: %v", err)
+	}
+	p, ok := policy.(*WallClockAttemptPolicy)
+	if !ok || p.Budget != 100*time.Second || p.Name() != "wall_clock" {
+		t.Fatalf("unexpected wall_clock policy: %#v", policy)
+	}
+
+	p.BeginRun(2)
+	// Unmeasured: the base attempts.
+	for i := 0; i < 4; i++ {
+		if got := p.Attempts(4, 0, 10, model.Genome{}); got != 4 {
+			t.Fatalf("expected base attempts before any measurement, got %d", got)
+		}
+		p.ObserveTuning(4, 8, 8*time.Second)
+	}
+	// 100s over 10 generations, 2 workers and 4 jobs: 5.56s per genome at
+	// 1s per evaluation and 2 evaluations per attempt buys 2 attempts.
+	if got := p.Attempts(4, 1, 10, model.Genome{}); got != 2 {
+		t.Fatalf("expected 2 measured attempts, got %d", got)
+	}
+	// Cheap evaluations are capped at ten times the base attempts.
+	p.ObserveTuning(1000, 1_000_000, time.Millisecond)
+	if got := p.Attempts(4, 1, 10, model.Genome{}); got != 40 {
+		t.Fatalf("expected capped attempts, got %d", got)
+	}
+
+	spent := &WallClockAttemptPolicy{Budget: time.Nanosecond}
+	spent.BeginRun(1)
+	time.Sleep(time.Millisecond)
+	if got := spent.Attempts(4, 0, 10, model.Genome{}); got != 0 {
+		t.Fatalf("expected no attempts once the budget is spent, got %d", got)
+	}
+}
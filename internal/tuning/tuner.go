@@ -36,6 +36,13 @@ type TuneReport struct {
 	AcceptedCandidates   int  `json:"accepted_candidates"`
 	RejectedCandidates   int  `json:"rejected_candidates"`
 	GoalReached          bool `json:"goal_reached"`
+	// ValidationEvaluations counts the extra validation-split evaluations
+	// made under validation acceptance.
+	ValidationEvaluations int `json:"validation_evaluations,omitempty"`
+	// Selection and Trace are set only by tuners recording traces: the
+	// candidate selection mode and one record per evaluated candidate.
+	Selection string                       `json:"selection,omitempty"`
+	Trace     []model.TuningTraceCandidate `json:"trace,omitempty"`
 }
 
 type Tuner interface {
@@ -0,0 +1,85 @@
+package protogonos
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"protogonos/internal/model"
+)
+
+// AnnotateGenomeRequest edits one of a run's top genomes: GenomeID when set,
+// otherwise the run champion. Set adds or replaces annotations, Unset removes
+// them and Note appends a free-form note to the genome provenance.
+type AnnotateGenomeRequest struct {
+	RunID    string
+	Latest   bool
+	GenomeID string
+	Set      map[string]string
+	Unset    []string
+	Note     string
+}
+
+type AnnotatedGenome struct {
+	RunID       string                  `json:"run_id"`
+	Rank        int                     `json:"rank"`
+	GenomeID    string                  `json:"genome_id"`
+	Annotations map[string]string       `json:"annotations,omitempty"`
+	Provenance  *model.GenomeProvenance `json:"provenance,omitempty"`
+}
+
+// AnnotateGenome applies the edit to every persisted copy of the genome: the
+// run's stored top genomes, its top genome artifact and the genome record
+// itself when the store holds one.
+func (c *Client) AnnotateGenome(ctx context.Context, req AnnotateGenomeRequest) (AnnotatedGenome, error) {
+	if req.RunID != "" && req.Latest {
+		return AnnotatedGenome{}, errors.New("use either run id or latest")
+	}
+	for key := range req.Set {
+		if strings.TrimSpace(key) == "" {
+			return AnnotatedGenome{}, errors.New("annotation key is required")
+		}
+	}
+	note := strings.TrimSpace(req.Note)
+	if len(req.Set) == 0 && len(req.Unset) == 0 && note == "" {
+		return AnnotatedGenome{}, errors.New("annotate requires annotations to set or unset, or a note")
+	}
+
+	edited, err := c.editTopGenome(ctx, req.RunID, req.Latest, req.GenomeID, func(genome *model.Genome) error {
+		applyGenomeAnnotations(genome, req.Set, req.Unset, note)
+		return nil
+	})
+	if err != nil {
+		return AnnotatedGenome{}, err
+	}
+	return AnnotatedGenome{
+		RunID:       edited.runID,
+		Rank:        edited.rank,
+		GenomeID:    edited.genome.ID,
+		Annotations: edited.genome.Annotations,
+		Provenance:  edited.genome.Provenance,
+	}, nil
+}
+
+func applyGenomeAnnotations(genome *model.Genome, set map[string]string, unset []string, note string) {
+	for key, value := range set {
+		if genome.Annotations == nil {
+			genome.Annotations = map[string]string{}
+		}
+		genome.Annotations[strings.TrimSpace(key)] = value
+	}
+	for _, key := range unset {
+		delete(genome.Annotations, strings.TrimSpace(key))
+	}
+	if len(genome.Annotations) == 0 {
+		genome.Annotations = nil
+	}
+	if note != "" {
+		prov := model.GenomeProvenance{}
+		if genome.Provenance != nil {
+			prov = *genome.Provenance
+		}
+		prov.Notes = append(append([]string(nil), prov.Notes...), note)
+		genome.Provenance = &prov
+	}
+}
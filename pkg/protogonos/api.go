@@ -2,16 +2,19 @@ package protogonos
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"math/rand"
+	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 	"time"
 
 	"protogonos/internal/agent"
+	"protogonos/internal/encoding"
 	"protogonos/internal/evo"
 	"protogonos/internal/genotype"
 	protoio "protogonos/internal/io"
@@ -23,7 +26,6 @@ import (
 	"protogonos/internal/scapeid"
 	"protogonos/internal/stats"
 	"protogonos/internal/storage"
-	"protogonos/internal/substrate"
 	"protogonos/internal/tuning"
 )
 
@@ -33,6 +35,19 @@ const (
 	defaultDBPath        = "protogonos.db"
 )
 
+// Run algorithms. Neuroevolution is the default; the others are baseline
+// controls evaluating the same number of candidates per generation.
+const (
+	AlgorithmNeuroevolution = "neuroevolution"
+	AlgorithmRandomSearch   = "random_search"
+	AlgorithmHillClimb      = "hill_climb"
+	AlgorithmES             = "es"
+)
+
+// defaultBiasMaxDelta bounds the per-step change of add_bias and
+// perturb_all_biases when a run leaves BiasMaxDelta unset.
+const defaultBiasMaxDelta = 0.3
+
 type Options struct {
 	StoreKind     string
 	DBPath        string
@@ -44,6 +59,8 @@ type Client struct {
 	store storage.Store
 	polis *platform.Polis
 
+	storeKind     string
+	dbPath        string
 	benchmarksDir string
 	exportsDir    string
 }
@@ -51,10 +68,17 @@ type Client struct {
 type RunRequest struct {
 	RunID                   string
 	ContinuePopulationID    string
+	InitFromChampions       string
+	BehaviorClone           *BehaviorCloneSpec `json:"-"`
+	ForkedFrom              string
+	ForkGeneration          int
 	SpecieIdentifier        string
 	OpMode                  string
 	EvolutionType           string
+	Encoding                string
+	Algorithm               string
 	Scape                   string
+	ScapeParams             map[string]string
 	GTSACSVPath             string
 	GTSATrainEnd            int
 	GTSAValidationEnd       int
@@ -93,9 +117,78 @@ type RunRequest struct {
 	TraceStepSize           int
 	StartPaused             bool
 	AutoContinueAfter       time.Duration
+	Progress                func(RunProgress) `json:"-"`
+	DisableBatchEvaluation  bool
+	DisableEvalCache        bool
+	MeterEvaluations        bool
+	VerifyInvariants        bool
+	EvaluationTrials        int
+	CITieBreak              bool
+	DisableRegressionWatch  bool
+	RegressionTolerance     float64
+	TrialAggregation        string
+	CVaRAlpha               float64
+	FitnessScaling          string
+	ScalingPressure         float64
+	SpeciesAllocation       string
+	AllocationFloor         int
+	AllocationCeiling       int
+	SpeciesSoftCap          int
+	SpeciesCapHysteresis    float64
+	PopulationResize        string
+	MinPopulation           int
+	MaxPopulation           int
+	ResizeTargetSeconds     float64
+	LowFidelity             float64
+	FinalistFraction        float64
+	ActivationClamp         float64
+	WeightClamp             float64
+	CrossoverRate           float64
+	InterspeciesMating      float64
+	PruneDisabledAfter      int
+	PruneDisabledProb       float64
+	TrackInnovations        bool
+	InitialSensorsMin       int
+	InitialSensorsMax       int
+	EvaluationTimeout       time.Duration
+	KarmaStrikes            int
+	KarmaCooldown           int
+	ScapeSandbox            bool
+	SandboxCommand          string
+	SandboxCPUSeconds       int
+	SandboxMemoryMB         int
+	SandboxTimeout          time.Duration
+	SandboxFailureFitness   float64
+	StopCondition           string
+	EntropyThreshold        float64
+	EntropyMeasure          string
+	EntropyAction           string
+	EntropyCooldown         int
+	ReproductionInterval    int
+	DiagnosticsWindow       int
+	Islands                 int
+	MigrationInterval       int
+	Migrants                int
+	CheckpointInterval      int
+	SnapshotInterval        int
+	WeightRecurrentLoop     float64
+	RecurrentLoopMaxLength  int
+	WeightDuplicateNeuron   float64
+	WeightToggleSynapse     float64
+	WeightInsertModule      float64
+	Modules                 []string
+	WeightAllBiases         float64
+	BiasMaxDelta            float64
 	Seed                    int64
+	SelectionSeed           *int64
+	MutationSeed            *int64
+	EnvSeed                 *int64
 	Workers                 int
+	ParityStrict            bool
 	Selection               string
+	TournamentSize          int
+	TournamentNoReplace     bool
+	TournamentWinProb       float64
 	FitnessPostprocessor    string
 	TopologicalPolicy       string
 	TopologicalCount        int
@@ -114,6 +207,8 @@ type RunRequest struct {
 	TunePerturbationRange   float64
 	TuneAnnealingFactor     float64
 	TuneMinImprovement      float64
+	TuningTrace             bool
+	TuneAcceptance          string
 	WeightPerturb           float64
 	WeightBias              float64
 	WeightRemoveBias        float64
@@ -128,18 +223,60 @@ type RunRequest struct {
 	WeightSubstrate         float64
 }
 
+// RunProgress reports fitness, speciation, tuning effort and wall-clock pace
+// after each scored generation. ETA is the projected time until the
+// generation or evaluation limit, whichever is first.
+type RunProgress struct {
+	Generation           int
+	BestFitness          float64
+	MeanFitness          float64
+	SpeciesCount         int
+	TuningInvocations    int
+	TuningEvaluations    int
+	TuningAccepted       int
+	TuningAcceptRate     float64
+	WallClock            time.Duration
+	TotalEvaluations     int
+	EvaluationsPerSecond float64
+	ETA                  time.Duration
+}
+
 type CompareSummary struct {
-	WithoutFinalBest float64
-	WithFinalBest    float64
-	FinalImprovement float64
+	WithoutFinalBest      float64
+	WithFinalBest         float64
+	FinalImprovement      float64
+	Pairing               string
+	MeanPairedImprovement float64
 }
 
 type RunSummary struct {
-	RunID            string
-	ArtifactsDir     string
-	BestByGeneration []float64
-	FinalBestFitness float64
-	Compare          *CompareSummary
+	RunID             string
+	ArtifactsDir      string
+	BestByGeneration  []float64
+	FinalBestFitness  float64
+	ChampionFitnessCI *FitnessInterval
+	Compare           *CompareSummary
+	// EvaluationCost totals the metered evaluation cost; nil unless the run
+	// metered evaluations.
+	EvaluationCost *stats.EvaluationCost
+	// Regression is the champion regression check run after every run; nil
+	// when DisableRegressionWatch is set or no leaderboard entry of an earlier
+	// comparable run could be evaluated. A failed check is recorded in its
+	// Error rather than failing the persisted run.
+	Regression *RegressionCheck
+	// IslandBestFitness is the best fitness each island reached over the
+	// run; nil unless the run used islands.
+	IslandBestFitness []float64
+}
+
+// FitnessInterval is a bootstrap confidence interval for the champion's mean
+// fitness over repeated evaluation trials.
+type FitnessInterval struct {
+	Mean       float64 `json:"mean"`
+	Lower      float64 `json:"lower"`
+	Upper      float64 `json:"upper"`
+	Confidence float64 `json:"confidence"`
+	Trials     int     `json:"trials"`
 }
 
 type materializedRunConfig struct {
@@ -149,6 +286,7 @@ type materializedRunConfig struct {
 	TopologicalPolicy evo.TopologicalMutationPolicy
 	TuneAttemptPolicy tuning.AttemptPolicy
 	SpeciationMode    string
+	Encoding          encoding.Encoding
 }
 
 type RunsRequest struct {
@@ -166,19 +304,37 @@ type RunItem struct {
 	Generations        int
 	TuningEnabled      bool
 	FinalBestFitness   float64
+	ForkedFrom         string
+	ForkGeneration     int
 	CompareImprovement *float64
+	Resources          *stats.RunResources
 }
 
+// ExportRequest copies a run's artifacts to OutDir. Profile selects the
+// layout: full (default) copies the raw artifact files, paper bundles an
+// anonymized archive for publication (see ExportProfilePaper) and compact
+// writes the champion alone in a binary encoding whose weights are quantized
+// as Quantization says: float64 (default, lossless), float32, int16 or int8.
 type ExportRequest struct {
-	RunID  string
-	Latest bool
-	OutDir string
+	RunID        string
+	Latest       bool
+	OutDir       string
+	Profile      string
+	Quantization string
 }
 
 type ExportSummary struct {
 	RunID      string
 	Morphology string
 	Directory  string
+	Profile    string
+	// Archive and Files describe the zip written by the paper profile or the
+	// champion file written by the compact profile.
+	Archive string
+	Files   int
+	// Bytes and Quantization describe the compact champion file.
+	Bytes        int
+	Quantization string
 }
 
 type LineageRequest struct {
@@ -192,6 +348,7 @@ type LineageItem struct {
 	ParentID    string
 	Generation  int
 	Operation   string
+	MateID      string
 	Events      []model.EvoHistoryEvent
 	Fingerprint string
 	Summary     model.LineageSummary
@@ -215,11 +372,23 @@ type SpeciesHistoryRequest struct {
 	Limit  int
 }
 
+// EventsRequest selects a run's event log. Type, when set, keeps only events
+// of that type; Limit keeps the first Limit matching events.
+type EventsRequest struct {
+	RunID  string
+	Latest bool
+	Type   string
+	Limit  int
+}
+
 type SpeciesDiffRequest struct {
 	RunID          string
 	Latest         bool
 	FromGeneration int
 	ToGeneration   int
+	// ChampionDeltas adds, for each changed species, the structural delta
+	// between its from- and to-generation champions recorded in trace_acc.
+	ChampionDeltas bool
 }
 
 type SpeciesDelta struct {
@@ -233,6 +402,17 @@ type SpeciesDelta struct {
 	FromBestFitness float64 `json:"from_best_fitness"`
 	ToBestFitness   float64 `json:"to_best_fitness"`
 	BestDelta       float64 `json:"best_delta"`
+	// Champion is set when champion deltas were requested and both
+	// generations recorded a champion genome for the species.
+	Champion *SpeciesChampionDelta `json:"champion,omitempty"`
+}
+
+// SpeciesChampionDelta is the structural change between a species' champions
+// at the two diffed generations.
+type SpeciesChampionDelta struct {
+	FromGenomeID string `json:"from_genome_id"`
+	ToGenomeID   string `json:"to_genome_id"`
+	genotype.GenomeDelta
 }
 
 type SpeciesDiff struct {
@@ -265,10 +445,98 @@ type MonitorControlRequest struct {
 	RunID string
 }
 
+// SetScapeParamRequest adjusts a tunable scape parameter of an active run.
+type SetScapeParamRequest struct {
+	RunID string
+	Name  string
+	Value float64
+}
+
 type DeletePopulationRequest struct {
 	PopulationID string
 }
 
+type PopulationDiffRequest struct {
+	FromID string
+	ToID   string
+}
+
+type CompactStoreRequest struct {
+	ArchiveOlderThan time.Duration
+	ArchiveDir       string
+}
+
+type CompactStoreSummary struct {
+	ArchivedRuns      []string `json:"archived_runs"`
+	ArchiveFiles      []string `json:"archive_files"`
+	ReferencedGenomes int      `json:"referenced_genomes"`
+	RemovedGenomes    int      `json:"removed_genomes"`
+	Vacuumed          bool     `json:"vacuumed"`
+}
+
+type StoreStatsRequest struct {
+	Limit int
+}
+
+type StoreStats struct {
+	StoreKind string `json:"store_kind"`
+	FileBytes int64  `json:"file_bytes,omitempty"`
+	storage.InspectReport
+}
+
+type MergePopulationsRequest struct {
+	RunIDs          []string
+	OutPopulationID string
+}
+
+type MergePopulationsSummary struct {
+	PopulationID   string         `json:"population_id"`
+	Scape          string         `json:"scape,omitempty"`
+	Generation     int            `json:"generation"`
+	SourceSizes    map[string]int `json:"source_sizes"`
+	MergedSize     int            `json:"merged_size"`
+	DuplicateCount int            `json:"duplicate_count"`
+	SpeciesCount   int            `json:"species_count"`
+	SpeciesSizes   map[string]int `json:"species_sizes"`
+}
+
+type PopulationSpeciesDelta struct {
+	Key       string `json:"key"`
+	FromSize  int    `json:"from_size"`
+	ToSize    int    `json:"to_size"`
+	SizeDelta int    `json:"size_delta"`
+}
+
+type PopulationDiff struct {
+	FromID           string                   `json:"from_id"`
+	ToID             string                   `json:"to_id"`
+	FromGeneration   int                      `json:"from_generation"`
+	ToGeneration     int                      `json:"to_generation"`
+	FromSize         int                      `json:"from_size"`
+	ToSize           int                      `json:"to_size"`
+	Added            []string                 `json:"added"`
+	Removed          []string                 `json:"removed"`
+	RetainedCount    int                      `json:"retained_count"`
+	FitnessAvailable bool                     `json:"fitness_available"`
+	FromMeanFitness  float64                  `json:"from_mean_fitness"`
+	ToMeanFitness    float64                  `json:"to_mean_fitness"`
+	MeanFitnessDelta float64                  `json:"mean_fitness_delta"`
+	FromBestFitness  float64                  `json:"from_best_fitness"`
+	ToBestFitness    float64                  `json:"to_best_fitness"`
+	BestFitnessDelta float64                  `json:"best_fitness_delta"`
+	KSStatistic      float64                  `json:"ks_statistic"`
+	SpeciesSource    string                   `json:"species_source"`
+	SpeciesAdded     []PopulationSpeciesDelta `json:"species_added"`
+	SpeciesRemoved   []PopulationSpeciesDelta `json:"species_removed"`
+	SpeciesChanged   []PopulationSpeciesDelta `json:"species_changed"`
+}
+
+// Species sources of a PopulationDiff.
+const (
+	PopulationSpeciesRecorded = "recorded"
+	PopulationSpeciesTopology = "topology"
+)
+
 type ScapeSummaryItem struct {
 	Name        string
 	Description string
@@ -339,6 +607,8 @@ func New(opts Options) (*Client, error) {
 
 	return &Client{
 		store:         store,
+		storeKind:     storeKind,
+		dbPath:        dbPath,
 		benchmarksDir: benchmarksDir,
 		exportsDir:    exportsDir,
 	}, nil
@@ -366,82 +636,22 @@ func (c *Client) Start(ctx context.Context) error {
 }
 
 func (c *Client) Run(ctx context.Context, req RunRequest) (RunSummary, error) {
-	cfg, err := materializeRunConfigFromRequest(req)
-	if err != nil {
-		return RunSummary{}, err
-	}
-	req = cfg.Request
-	runCtx, err := applyScapeDataSources(ctx, req)
-	if err != nil {
-		return RunSummary{}, err
-	}
-
-	p, err := c.ensurePolis(ctx)
-	if err != nil {
-		return RunSummary{}, err
-	}
-	if err := registerDefaultScapes(p); err != nil {
-		return RunSummary{}, err
-	}
-
-	seedPopulation, err := genotype.ConstructSeedPopulationWithOptions(req.Scape, req.Population, req.Seed, seedPopulationOptionsFromRequest(req))
+	run, err := c.prepareRun(ctx, req)
 	if err != nil {
 		return RunSummary{}, err
 	}
-	initialPopulation := seedPopulation.Genomes
-	initialGeneration := 0
-	if req.ContinuePopulationID != "" {
-		popSnapshot, continued, err := genotype.LoadPopulationSnapshot(ctx, c.store, req.ContinuePopulationID)
-		if err != nil {
-			return RunSummary{}, err
-		}
-		if len(continued) == 0 {
-			return RunSummary{}, fmt.Errorf("continued population is empty: %s", req.ContinuePopulationID)
-		}
-		initialPopulation = continued
-		req.Population = len(continued)
-		initialGeneration = popSnapshot.Generation
-	}
-	if err := morphology.EnsureScapeCompatibility(req.Scape); err != nil {
-		return RunSummary{}, err
-	}
-	if err := morphology.EnsurePopulationIOCompatibility(req.Scape, initialPopulation); err != nil {
-		return RunSummary{}, err
-	}
+	return c.evolve(ctx, run)
+}
 
-	eliteCount := req.Population / 5
-	if eliteCount < 1 {
-		eliteCount = 1
-	}
-	if req.SurvivalPercentage > 0 {
-		eliteCount = 0
-	}
-	now := time.Now().UTC()
-	runID := req.RunID
-	if runID == "" && req.ContinuePopulationID != "" {
-		runID = req.ContinuePopulationID
-	}
-	if runID == "" {
-		runID = fmt.Sprintf("%s-%d-%d", req.Scape, req.Seed, now.Unix())
+// evolve runs a prepared run to completion, records it and closes it.
+func (c *Client) evolve(ctx context.Context, run *preparedRun) (RunSummary, error) {
+	defer run.close()
+	if run.req.Algorithm != AlgorithmNeuroevolution {
+		return c.runBaseline(ctx, run, esSettings{})
 	}
+	req, runCtx := run.req, run.runCtx
 
 	runEvolution := func(useTuning bool) (platform.EvolutionResult, error) {
-		mutation := &evo.PerturbWeightsProportional{Rand: rand.New(rand.NewSource(req.Seed + 1000)), MaxDelta: 1.0}
-		policy := defaultMutationPolicy(req.Seed, req.Scape, seedPopulation.InputNeuronIDs, seedPopulation.OutputNeuronIDs, req)
-		var tuner tuning.Tuner
-		var attemptPolicy tuning.AttemptPolicy
-		if useTuning {
-			attemptPolicy = cfg.TuneAttemptPolicy
-			tuner = &tuning.Exoself{
-				Rand:               rand.New(rand.NewSource(req.Seed + 2000)),
-				Steps:              req.TuneSteps,
-				StepSize:           req.TuneStepSize,
-				PerturbationRange:  req.TunePerturbationRange,
-				AnnealingFactor:    req.TuneAnnealingFactor,
-				MinImprovement:     req.TuneMinImprovement,
-				CandidateSelection: req.TuneSelection,
-			}
-		}
 		var controlCh chan evo.MonitorCommand
 		if req.StartPaused {
 			controlCh = make(chan evo.MonitorCommand, 2)
@@ -462,38 +672,25 @@ func (c *Client) Run(ctx context.Context, req RunRequest) (RunSummary, error) {
 				}()
 			}
 		}
-		return p.RunEvolution(runCtx, platform.EvolutionConfig{
-			RunID:                runID,
-			OpMode:               req.OpMode,
-			EvolutionType:        req.EvolutionType,
-			SpeciationMode:       cfg.SpeciationMode,
-			ScapeName:            req.Scape,
-			PopulationSize:       req.Population,
-			Generations:          req.Generations,
-			InitialGeneration:    initialGeneration,
-			SurvivalPercentage:   req.SurvivalPercentage,
-			SpecieSizeLimit:      req.SpecieSizeLimit,
-			FitnessGoal:          req.FitnessGoal,
-			EvaluationsLimit:     req.EvaluationsLimit,
-			TraceStepSize:        req.TraceStepSize,
-			Control:              controlCh,
-			EliteCount:           eliteCount,
-			Workers:              req.Workers,
-			Seed:                 req.Seed,
-			InputNeuronIDs:       seedPopulation.InputNeuronIDs,
-			OutputNeuronIDs:      seedPopulation.OutputNeuronIDs,
-			Mutation:             mutation,
-			MutationPolicy:       policy,
-			Selector:             cfg.Selector,
-			Postprocessor:        cfg.Postprocessor,
-			TopologicalMutations: cfg.TopologicalPolicy,
-			Tuner:                tuner,
-			TuneAttempts:         req.TuneAttempts,
-			TuneAttemptPolicy:    attemptPolicy,
-			ValidationProbe:      req.ValidationProbe,
-			TestProbe:            req.TestProbe,
-			Initial:              initialPopulation,
-		})
+		var traceStream *stats.TraceStreamWriter
+		var err error
+		if run.resume != nil {
+			traceStream, err = stats.ResumeTraceStream(filepath.Join(c.benchmarksDir, run.runID), toStatsTraceAcc(run.resume.TraceAcc))
+		} else {
+			traceStream, err = stats.OpenTraceStream(filepath.Join(c.benchmarksDir, run.runID))
+		}
+		if err != nil {
+			return platform.EvolutionResult{}, err
+		}
+		result, err := run.polis.RunEvolution(runCtx, run.evolutionConfig(useTuning, controlCh, traceStream))
+		if err != nil {
+			_ = traceStream.Close()
+			return platform.EvolutionResult{}, dumpInvariantViolation(filepath.Join(c.benchmarksDir, run.runID), err)
+		}
+		if err := traceStream.Finish(); err != nil {
+			return platform.EvolutionResult{}, fmt.Errorf("write trace stream: %w", err)
+		}
+		return result, nil
 	}
 
 	var result platform.EvolutionResult
@@ -508,17 +705,7 @@ func (c *Client) Run(ctx context.Context, req RunRequest) (RunSummary, error) {
 			if err != nil {
 				return RunSummary{}, err
 			}
-			compareReport = &stats.TuningComparison{
-				Scape:             req.Scape,
-				PopulationSize:    req.Population,
-				Generations:       req.Generations,
-				Seed:              req.Seed,
-				WithoutTuningBest: withoutTuning.BestByGeneration,
-				WithTuningBest:    withTuning.BestByGeneration,
-				WithoutFinalBest:  withoutTuning.BestFinalFitness,
-				WithFinalBest:     withTuning.BestFinalFitness,
-				FinalImprovement:  withTuning.BestFinalFitness - withoutTuning.BestFinalFitness,
-			}
+			compareReport = newTuningComparison(req, withoutTuning, withTuning)
 			result = withTuning
 		} else {
 			withTuning, err := runEvolution(true)
@@ -529,29 +716,305 @@ func (c *Client) Run(ctx context.Context, req RunRequest) (RunSummary, error) {
 			if err != nil {
 				return RunSummary{}, err
 			}
-			compareReport = &stats.TuningComparison{
-				Scape:             req.Scape,
-				PopulationSize:    req.Population,
-				Generations:       req.Generations,
-				Seed:              req.Seed,
-				WithoutTuningBest: withoutTuning.BestByGeneration,
-				WithTuningBest:    withTuning.BestByGeneration,
-				WithoutFinalBest:  withoutTuning.BestFinalFitness,
-				WithFinalBest:     withTuning.BestFinalFitness,
-				FinalImprovement:  withTuning.BestFinalFitness - withoutTuning.BestFinalFitness,
-			}
+			compareReport = newTuningComparison(req, withoutTuning, withTuning)
 			result = withoutTuning
 		}
 	} else {
+		var err error
 		result, err = runEvolution(req.EnableTuning)
 		if err != nil {
 			return RunSummary{}, err
 		}
 	}
+	return c.recordRun(ctx, run, result, compareReport)
+}
+
+// preparedRun is a resolved and validated run request with its seed
+// population, ready to evolve. Run, RunStream and Engine share it.
+type preparedRun struct {
+	req               RunRequest
+	cfg               materializedRunConfig
+	runCtx            context.Context
+	polis             *platform.Polis
+	sandbox           *scape.SandboxScape
+	modules           []model.Module
+	seedPopulation    genotype.SeedPopulation
+	initialPopulation []model.Genome
+	initialGeneration int
+	championSources   []string
+	eliteCount        int
+	now               time.Time
+	runID             string
+	startUsage        stats.ProcessUsage
+	sampled           bool
+	// es is recorded in the run config of es runs, and
+	// baselineEvaluations counts the evaluations of baseline runs.
+	es                  *stats.ESRunConfig
+	baselineEvaluations int
+	// behaviorClone records the scripted policy fit of the initial
+	// population.
+	behaviorClone *stats.BehaviorCloneRunConfig
+	// checkpointConfig is the run config saved with each checkpoint, and
+	// resume the checkpoint a resumed run continues from.
+	checkpointConfig json.RawMessage
+	resume           *evo.Checkpoint
+	// stream receives the generation events of a run started by RunStream.
+	stream *generationStream
+}
+
+// prepareRun resolves req, registers its scapes and builds the population
+// the run starts from. The caller closes the returned run.
+func (c *Client) prepareRun(ctx context.Context, req RunRequest) (*preparedRun, error) {
+	run := &preparedRun{}
+	run.startUsage, run.sampled = stats.SampleProcessUsage()
+	cfg, err := materializeRunConfigFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	req = cfg.Request
+	runCtx, err := applyScapeDataSources(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := c.ensurePolis(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := registerDefaultScapes(p); err != nil {
+		return nil, err
+	}
+	if err := registerRunScape(p, req); err != nil {
+		return nil, err
+	}
+	sandbox, err := registerSandboxScape(p, req)
+	if err != nil {
+		return nil, err
+	}
+	run.sandbox = sandbox
+	ok := false
+	defer func() {
+		if !ok {
+			run.close()
+		}
+	}()
+	var modules []model.Module
+	if req.WeightInsertModule > 0 {
+		modules, err = c.moduleLibraryForRun(req.Modules)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	seedPopulation, err := genotype.ConstructSeedPopulationWithOptions(req.Scape, req.Population, req.Seed, seedPopulationOptionsFromRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	initialPopulation := seedPopulation.Genomes
+	initialGeneration := 0
+	var championSources []string
+	if req.InitFromChampions != "" {
+		initialPopulation, championSources, err = c.seedFromChampions(ctx, req, initialPopulation, seedPopulation.InputNeuronIDs, seedPopulation.OutputNeuronIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.ContinuePopulationID != "" {
+		popSnapshot, continued, err := genotype.LoadPopulationSnapshot(ctx, c.store, req.ContinuePopulationID)
+		if err != nil {
+			return nil, err
+		}
+		if len(continued) == 0 {
+			return nil, fmt.Errorf("continued population is empty: %s", req.ContinuePopulationID)
+		}
+		initialPopulation = continued
+		req.Population = len(continued)
+		initialGeneration = popSnapshot.Generation
+	}
+	if err := morphology.EnsureScapeCompatibility(req.Scape); err != nil {
+		return nil, err
+	}
+	if err := morphology.EnsurePopulationIOCompatibility(req.Scape, initialPopulation); err != nil {
+		return nil, err
+	}
+	initialPopulation = initializeEncodingGenes(cfg.Encoding, initialPopulation, seedPopulation.InputNeuronIDs, seedPopulation.OutputNeuronIDs, req.Seed)
+	if req.BehaviorClone != nil {
+		initialPopulation, run.behaviorClone, err = cloneScriptedPolicy(runCtx, p, req, initialPopulation, seedPopulation.InputNeuronIDs, seedPopulation.OutputNeuronIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	run.req = req
+	run.cfg = cfg
+	run.runCtx = runCtx
+	run.polis = p
+	run.modules = modules
+	run.seedPopulation = seedPopulation
+	run.initialPopulation = initialPopulation
+	run.initialGeneration = initialGeneration
+	run.championSources = championSources
+	run.eliteCount = runEliteCount(req)
+	run.now = time.Now().UTC()
+	run.runID = resolveRunID(req, run.now)
+	if req.CheckpointInterval > 0 {
+		run.checkpointConfig, err = json.Marshal(runConfigFromRequest(req, run.runID, run.eliteCount, initialGeneration, championSources))
+		if err != nil {
+			return nil, err
+		}
+	}
+	ok = true
+	return run, nil
+}
+
+func (r *preparedRun) close() {
+	if r.sandbox != nil {
+		_ = r.sandbox.Close()
+		r.sandbox = nil
+	}
+}
+
+// evolutionConfig builds the polis config for one evolution of the run, with
+// fresh mutation operators and, when useTuning is set, a fresh tuner. control
+// may be nil.
+func (r *preparedRun) evolutionConfig(useTuning bool, control chan evo.MonitorCommand, traceStream *stats.TraceStreamWriter) platform.EvolutionConfig {
+	req, cfg := r.req, r.cfg
+	mutationSeed := runMutationSeed(req)
+	// Checkpointed runs track their streams so a resume can wind them
+	// forward; the streams must be created in the same order every time.
+	var rands *evo.RandStreams
+	if req.CheckpointInterval > 0 || r.resume != nil {
+		rands = &evo.RandStreams{}
+	}
+	mutation, policy := runMutationOperators(req, r.seedPopulation.InputNeuronIDs, r.seedPopulation.OutputNeuronIDs, r.modules, rands)
+	var islandMutations func(int64) (evo.Operator, []evo.WeightedMutation)
+	if req.Islands > 1 {
+		islandMutations = func(seedOffset int64) (evo.Operator, []evo.WeightedMutation) {
+			islandReq := req
+			islandSeed := mutationSeed + seedOffset
+			islandReq.MutationSeed = &islandSeed
+			return runMutationOperators(islandReq, r.seedPopulation.InputNeuronIDs, r.seedPopulation.OutputNeuronIDs, r.modules, rands)
+		}
+	}
+	var tuner tuning.Tuner
+	var attemptPolicy tuning.AttemptPolicy
+	if useTuning {
+		attemptPolicy = cfg.TuneAttemptPolicy
+		tuner = &tuning.Exoself{
+			Rand:               rands.New(mutationSeed + 2000),
+			Steps:              req.TuneSteps,
+			StepSize:           req.TuneStepSize,
+			PerturbationRange:  req.TunePerturbationRange,
+			AnnealingFactor:    req.TuneAnnealingFactor,
+			MinImprovement:     req.TuneMinImprovement,
+			CandidateSelection: req.TuneSelection,
+			RecordTrace:        req.TuningTrace,
+			Acceptance:         req.TuneAcceptance,
+		}
+	}
+	return platform.EvolutionConfig{
+		RunID:                r.runID,
+		OpMode:               req.OpMode,
+		EvolutionType:        req.EvolutionType,
+		SpeciationMode:       cfg.SpeciationMode,
+		ScapeName:            req.Scape,
+		PopulationSize:       req.Population,
+		Generations:          req.Generations,
+		InitialGeneration:    r.initialGeneration,
+		SurvivalPercentage:   req.SurvivalPercentage,
+		SpecieSizeLimit:      req.SpecieSizeLimit,
+		FitnessGoal:          req.FitnessGoal,
+		EvaluationsLimit:     req.EvaluationsLimit,
+		TraceStepSize:        req.TraceStepSize,
+		Control:              control,
+		DisableBatchEval:     req.DisableBatchEvaluation,
+		DisableEvalCache:     req.DisableEvalCache,
+		MeterEvaluations:     req.MeterEvaluations,
+		VerifyInvariants:     req.VerifyInvariants,
+		EvaluationTrials:     req.EvaluationTrials,
+		CITieBreak:           req.CITieBreak,
+		TrialAggregation:     req.TrialAggregation,
+		CVaRAlpha:            req.CVaRAlpha,
+		FitnessScaling:       req.FitnessScaling,
+		ScalingPressure:      req.ScalingPressure,
+		SpeciesAllocation:    req.SpeciesAllocation,
+		AllocationFloor:      req.AllocationFloor,
+		AllocationCeiling:    req.AllocationCeiling,
+		SpeciesSoftCap:       req.SpeciesSoftCap,
+		SpeciesCapHysteresis: req.SpeciesCapHysteresis,
+		PopulationResize:     req.PopulationResize,
+		MinPopulation:        req.MinPopulation,
+		MaxPopulation:        req.MaxPopulation,
+		ResizeTargetSeconds:  req.ResizeTargetSeconds,
+		LowFidelity:          req.LowFidelity,
+		FinalistFraction:     req.FinalistFraction,
+		ActivationClamp:      req.ActivationClamp,
+		WeightClamp:          req.WeightClamp,
+		CrossoverRate:        req.CrossoverRate,
+		InterspeciesMating:   req.InterspeciesMating,
+		PruneDisabledAfter:   req.PruneDisabledAfter,
+		PruneDisabledProb:    req.PruneDisabledProb,
+		TrackInnovations:     req.TrackInnovations,
+		EvaluationTimeout:    req.EvaluationTimeout,
+		KarmaStrikes:         req.KarmaStrikes,
+		KarmaCooldown:        req.KarmaCooldown,
+		StopCondition:        req.StopCondition,
+		EntropyThreshold:     req.EntropyThreshold,
+		EntropyMeasure:       req.EntropyMeasure,
+		EntropyAction:        req.EntropyAction,
+		EntropyCooldown:      req.EntropyCooldown,
+		ReproductionInterval: req.ReproductionInterval,
+		DiagnosticsWindow:    req.DiagnosticsWindow,
+		Islands:              req.Islands,
+		MigrationInterval:    req.MigrationInterval,
+		Migrants:             req.Migrants,
+		IslandMutations:      islandMutations,
+		Rands:                rands,
+		CheckpointInterval:   req.CheckpointInterval,
+		CheckpointConfig:     r.checkpointConfig,
+		SnapshotInterval:     req.SnapshotInterval,
+		Resume:               r.resume,
+		NewcomerFactory:      newcomerFactory(req),
+		CommonRandomNumbers:  req.CompareTuning,
+		EliteCount:           r.eliteCount,
+		Workers:              req.Workers,
+		Seed:                 req.Seed,
+		SelectionSeed:        cloneInt64Ptr(req.SelectionSeed),
+		MutationSeed:         cloneInt64Ptr(req.MutationSeed),
+		EnvSeed:              cloneInt64Ptr(req.EnvSeed),
+		InputNeuronIDs:       r.seedPopulation.InputNeuronIDs,
+		OutputNeuronIDs:      r.seedPopulation.OutputNeuronIDs,
+		Mutation:             mutation,
+		MutationPolicy:       policy,
+		Selector:             cfg.Selector,
+		Postprocessor:        cfg.Postprocessor,
+		Encoding:             cfg.Encoding,
+		TopologicalMutations: cfg.TopologicalPolicy,
+		Tuner:                tuner,
+		TuneAttempts:         req.TuneAttempts,
+		TuneAttemptPolicy:    attemptPolicy,
+		ValidationProbe:      req.ValidationProbe,
+		TestProbe:            req.TestProbe,
+		TraceUpdateHook: func(update evo.TraceUpdate) {
+			_ = traceStream.AppendUpdate(toStatsTraceUpdate(update))
+		},
+		TraceGenerationHook: func(generation evo.TraceGeneration) {
+			_ = traceStream.AppendGeneration(toStatsTraceGeneration(generation))
+		},
+		ProgressHook: r.stream.progressHook(progressHook(req.Progress)),
+		SpeciesHook:  r.stream.speciesHook(),
+		Initial:      r.initialPopulation,
+	}
+}
 
+// recordRun writes the artifacts of an evolved run, appends it to the run
+// index and summarizes it.
+func (c *Client) recordRun(ctx context.Context, run *preparedRun, result platform.EvolutionResult, compareReport *stats.TuningComparison) (RunSummary, error) {
+	req, runID := run.req, run.runID
 	top := make([]stats.TopGenome, 0, len(result.TopFinal))
 	for i, scored := range result.TopFinal {
-		top = append(top, stats.TopGenome{Rank: i + 1, Fitness: scored.Fitness, Genome: scored.Genome})
+		foldFitness, _ := scape.TraceFoldFitnessOf(scored.Trace)
+		top = append(top, stats.TopGenome{Rank: i + 1, Fitness: scored.Fitness, FoldFitness: foldFitness, Genome: scored.Genome})
 	}
 	lineage := make([]stats.LineageEntry, 0, len(result.Lineage))
 	for _, record := range result.Lineage {
@@ -560,6 +1023,7 @@ func (c *Client) Run(ctx context.Context, req RunRequest) (RunSummary, error) {
 			ParentID:    record.ParentID,
 			Generation:  record.Generation,
 			Operation:   record.Operation,
+			MateID:      record.MateID,
 			Events:      toModelEvoHistoryEvents(record.Events),
 			Fingerprint: record.Fingerprint,
 			Summary: map[string]any{
@@ -574,99 +1038,62 @@ func (c *Client) Run(ctx context.Context, req RunRequest) (RunSummary, error) {
 		})
 	}
 
+	var championCI *stats.ConfidenceInterval
+	if len(result.TopFinal) > 0 && result.TopFinal[0].FitnessCI != nil {
+		ci := *result.TopFinal[0].FitnessCI
+		championCI = &ci
+	}
+
+	events := result.Events
+	tuningTraces := result.TuningTraces
+	if req.ContinuePopulationID != "" {
+		prior, ok, err := stats.ReadEvents(c.benchmarksDir, runID)
+		if err != nil {
+			return RunSummary{}, err
+		}
+		if ok {
+			events = append(prior, events...)
+		}
+		priorTraces, ok, err := stats.ReadTuningTraces(c.benchmarksDir, runID)
+		if err != nil {
+			return RunSummary{}, err
+		}
+		if ok {
+			tuningTraces = append(priorTraces, tuningTraces...)
+		}
+	}
+
+	runConfig := runConfigFromRequest(req, runID, run.eliteCount, run.initialGeneration, run.championSources)
+	runConfig.ES = run.es
+	runConfig.BehaviorClone = run.behaviorClone
 	runDir, err := stats.WriteRunArtifacts(c.benchmarksDir, stats.RunArtifacts{
-		Config: stats.RunConfig{
-			RunID:                   runID,
-			OpMode:                  req.OpMode,
-			EvolutionType:           req.EvolutionType,
-			Scape:                   req.Scape,
-			GTSACSVPath:             req.GTSACSVPath,
-			GTSATrainEnd:            req.GTSATrainEnd,
-			GTSAValidationEnd:       req.GTSAValidationEnd,
-			GTSATestEnd:             req.GTSATestEnd,
-			FXCSVPath:               req.FXCSVPath,
-			EpitopesCSVPath:         req.EpitopesCSVPath,
-			EpitopesTableName:       req.EpitopesTableName,
-			LLVMWorkflowJSONPath:    req.LLVMWorkflowJSONPath,
-			EpitopesGTStart:         req.EpitopesGTStart,
-			EpitopesGTEnd:           req.EpitopesGTEnd,
-			EpitopesValidationStart: req.EpitopesValidationStart,
-			EpitopesValidationEnd:   req.EpitopesValidationEnd,
-			EpitopesTestStart:       req.EpitopesTestStart,
-			EpitopesTestEnd:         req.EpitopesTestEnd,
-			EpitopesBenchmarkStart:  req.EpitopesBenchmarkStart,
-			EpitopesBenchmarkEnd:    req.EpitopesBenchmarkEnd,
-			GTSAProfile:             req.GTSAProfile,
-			FXProfile:               req.FXProfile,
-			EpitopesProfile:         req.EpitopesProfile,
-			LLVMProfile:             req.LLVMProfile,
-			FlatlandScannerProfile:  req.FlatlandScannerProfile,
-			FlatlandScannerSpread:   cloneFloat64Ptr(req.FlatlandScannerSpread),
-			FlatlandScannerOffset:   cloneFloat64Ptr(req.FlatlandScannerOffset),
-			FlatlandLayoutRandomize: cloneBoolPtr(req.FlatlandLayoutRandomize),
-			FlatlandLayoutVariants:  cloneIntPtr(req.FlatlandLayoutVariants),
-			FlatlandForceLayout:     cloneIntPtr(req.FlatlandForceLayout),
-			FlatlandBenchmarkTrials: cloneIntPtr(req.FlatlandBenchmarkTrials),
-			FlatlandMaxAge:          cloneIntPtr(req.FlatlandMaxAge),
-			FlatlandForageGoal:      cloneIntPtr(req.FlatlandForageGoal),
-			ContinuePopulationID:    req.ContinuePopulationID,
-			SpecieIdentifier:        req.SpecieIdentifier,
-			InitialGeneration:       initialGeneration,
-			PopulationSize:          req.Population,
-			Generations:             req.Generations,
-			SurvivalPercentage:      req.SurvivalPercentage,
-			SpecieSizeLimit:         req.SpecieSizeLimit,
-			FitnessGoal:             req.FitnessGoal,
-			EvaluationsLimit:        req.EvaluationsLimit,
-			TraceStepSize:           req.TraceStepSize,
-			StartPaused:             req.StartPaused,
-			AutoContinueAfterMS:     req.AutoContinueAfter.Milliseconds(),
-			Seed:                    req.Seed,
-			Workers:                 req.Workers,
-			EliteCount:              eliteCount,
-			Selection:               req.Selection,
-			FitnessPostprocessor:    req.FitnessPostprocessor,
-			TopologicalPolicy:       req.TopologicalPolicy,
-			TopologicalCount:        req.TopologicalCount,
-			TopologicalParam:        req.TopologicalParam,
-			TopologicalMax:          req.TopologicalMax,
-			TuningEnabled:           req.EnableTuning,
-			ValidationProbe:         req.ValidationProbe,
-			TestProbe:               req.TestProbe,
-			TuneSelection:           req.TuneSelection,
-			TuneDurationPolicy:      req.TuneDurationPolicy,
-			TuneDurationParam:       req.TuneDurationParam,
-			TuneAttempts:            req.TuneAttempts,
-			TuneSteps:               req.TuneSteps,
-			TuneStepSize:            req.TuneStepSize,
-			TunePerturbationRange:   req.TunePerturbationRange,
-			TuneAnnealingFactor:     req.TuneAnnealingFactor,
-			TuneMinImprovement:      req.TuneMinImprovement,
-			WeightPerturb:           req.WeightPerturb,
-			WeightBias:              req.WeightBias,
-			WeightRemoveBias:        req.WeightRemoveBias,
-			WeightActivation:        req.WeightActivation,
-			WeightAggregator:        req.WeightAggregator,
-			WeightAddSynapse:        req.WeightAddSynapse,
-			WeightRemoveSynapse:     req.WeightRemoveSynapse,
-			WeightAddNeuron:         req.WeightAddNeuron,
-			WeightRemoveNeuron:      req.WeightRemoveNeuron,
-			WeightPlasticityRule:    req.WeightPlasticityRule,
-			WeightPlasticity:        req.WeightPlasticity,
-			WeightSubstrate:         req.WeightSubstrate,
-		},
+		Config:                runConfig,
 		BestByGeneration:      result.BestByGeneration,
 		GenerationDiagnostics: result.GenerationDiagnostics,
 		SpeciesHistory:        result.SpeciesHistory,
 		TraceAcc:              toStatsTraceAcc(result.TraceAcc),
 		FinalBestFitness:      result.BestFinalFitness,
+		ChampionFitnessCI:     championCI,
 		TopGenomes:            top,
 		Lineage:               lineage,
+		Events:                events,
+		TuningTraces:          tuningTraces,
 	})
 	if err != nil {
 		return RunSummary{}, err
 	}
 
+	if compareReport != nil {
+		if err := stats.WriteTuningComparison(runDir, *compareReport); err != nil {
+			return RunSummary{}, err
+		}
+	}
+	resources, err := runResources(runDir, result.GenerationDiagnostics, run.startUsage, run.sampled)
+	if err != nil {
+		return RunSummary{}, err
+	}
+	resources.EvaluationCost = runEvaluationCost(req.Scape, result.GenerationDiagnostics)
+
 	if err := stats.AppendRunIndex(c.benchmarksDir, stats.RunIndexEntry{
 		RunID:                  runID,
 		Scape:                  req.Scape,
@@ -680,37 +1107,75 @@ func (c *Client) Run(ctx context.Context, req RunRequest) (RunSummary, error) {
 		Generations:            req.Generations,
 		Seed:                   req.Seed,
 		Workers:                req.Workers,
-		EliteCount:             eliteCount,
+		EliteCount:             run.eliteCount,
 		TuningEnabled:          req.EnableTuning,
 		FinalBestFitness:       result.BestFinalFitness,
-		CreatedAtUTC:           now.Format(time.RFC3339Nano),
+		ForkedFrom:             req.ForkedFrom,
+		ForkGeneration:         req.ForkGeneration,
+		CreatedAtUTC:           run.now.Format(time.RFC3339Nano),
+		Resources:              &resources,
 	}); err != nil {
 		return RunSummary{}, err
 	}
-	if compareReport != nil {
-		if err := stats.WriteTuningComparison(runDir, *compareReport); err != nil {
-			return RunSummary{}, err
-		}
+
+	if err := c.recordLeaderboard(ctx, run, result); err != nil {
+		return RunSummary{}, fmt.Errorf("record leaderboard: %w", err)
 	}
 
 	summary := RunSummary{
-		RunID:            runID,
-		ArtifactsDir:     filepath.Clean(runDir),
-		BestByGeneration: append([]float64(nil), result.BestByGeneration...),
-		FinalBestFitness: result.BestFinalFitness,
+		RunID:             runID,
+		ArtifactsDir:      filepath.Clean(runDir),
+		BestByGeneration:  append([]float64(nil), result.BestByGeneration...),
+		FinalBestFitness:  result.BestFinalFitness,
+		EvaluationCost:    resources.EvaluationCost,
+		IslandBestFitness: islandBestFitness(result.GenerationDiagnostics),
+	}
+	if championCI != nil {
+		summary.ChampionFitnessCI = &FitnessInterval{
+			Mean:       championCI.Mean,
+			Lower:      championCI.Lower,
+			Upper:      championCI.Upper,
+			Confidence: championCI.Confidence,
+			Trials:     championCI.Trials,
+		}
 	}
 	if compareReport != nil {
 		summary.Compare = &CompareSummary{
-			WithoutFinalBest: compareReport.WithoutFinalBest,
-			WithFinalBest:    compareReport.WithFinalBest,
-			FinalImprovement: compareReport.FinalImprovement,
+			WithoutFinalBest:      compareReport.WithoutFinalBest,
+			WithFinalBest:         compareReport.WithFinalBest,
+			FinalImprovement:      compareReport.FinalImprovement,
+			Pairing:               compareReport.Pairing,
+			MeanPairedImprovement: compareReport.MeanPairedImprovement,
+		}
+	}
+	if !req.DisableRegressionWatch {
+		regression, err := c.checkChampionRegression(ctx, req, runConfig)
+		if err != nil {
+			regression = &RegressionCheck{Mode: regressionMode, Tolerance: regressionTolerance(req), Error: err.Error()}
 		}
+		summary.Regression = regression
 	}
 	return summary, nil
 }
 
 func applyScapeDataSources(ctx context.Context, req RunRequest) (context.Context, error) {
-	scopedCtx, err := scape.WithDataSources(ctx, scape.DataSources{
+	scopedCtx, err := scape.WithDataSources(ctx, scapeDataSources(req))
+	if err != nil {
+		return nil, err
+	}
+	if !hasFlatlandOverrideConfig(req) {
+		return scopedCtx, nil
+	}
+	scopedCtx, err = scape.WithFlatlandOverrides(scopedCtx, toFlatlandOverrides(req))
+	if err != nil {
+		return nil, fmt.Errorf("configure flatland overrides: %w", err)
+	}
+	return scopedCtx, nil
+}
+
+// scapeDataSources collects the data source settings of req.
+func scapeDataSources(req RunRequest) scape.DataSources {
+	return scape.DataSources{
 		GTSA: scape.GTSADataSource{
 			CSVPath: req.GTSACSVPath,
 			Bounds: scape.GTSATableBounds{
@@ -739,23 +1204,15 @@ func applyScapeDataSources(ctx context.Context, req RunRequest) (context.Context
 		LLVM: scape.LLVMDataSource{
 			WorkflowJSONPath: req.LLVMWorkflowJSONPath,
 		},
-	})
-	if err != nil {
-		return nil, err
-	}
-	if !hasFlatlandOverrideConfig(req) {
-		return scopedCtx, nil
-	}
-	scopedCtx, err = scape.WithFlatlandOverrides(scopedCtx, toFlatlandOverrides(req))
-	if err != nil {
-		return nil, fmt.Errorf("configure flatland overrides: %w", err)
 	}
-	return scopedCtx, nil
 }
 
 func runRequestFromArtifactsConfig(cfg stats.RunConfig) RunRequest {
 	return RunRequest{
+		Encoding:                cfg.Encoding,
+		Algorithm:               cfg.Algorithm,
 		Scape:                   cfg.Scape,
+		ScapeParams:             cloneStringMap(cfg.ScapeParams),
 		GTSACSVPath:             cfg.GTSACSVPath,
 		GTSATrainEnd:            cfg.GTSATrainEnd,
 		GTSAValidationEnd:       cfg.GTSAValidationEnd,
@@ -831,46 +1288,147 @@ func defaultSeedIONeuronsForScape(req RunRequest) ([]string, []string, error) {
 		nil
 }
 
-func buildReplayCortex(scapeName string, genome model.Genome, inputNeuronIDs, outputNeuronIDs []string) (*agent.Cortex, error) {
+// buildReplayCortex builds the cortex of genome outside a run, expressing
+// it through the named encoding as the run that evolved it did.
+func buildReplayCortex(scapeName, encodingName string, genome model.Genome, inputNeuronIDs, outputNeuronIDs []string) (*agent.Cortex, error) {
 	sensors, actuators, err := buildReplayIO(scapeName, genome)
 	if err != nil {
 		return nil, err
 	}
-	substrateRuntime, err := buildReplaySubstrate(genome, outputNeuronIDs)
+	return newReplayCortex(encodingName, genome, sensors, actuators, inputNeuronIDs, outputNeuronIDs)
+}
+
+func newReplayCortex(encodingName string, genome model.Genome, sensors map[string]protoio.Sensor, actuators map[string]protoio.Actuator, inputNeuronIDs, outputNeuronIDs []string) (*agent.Cortex, error) {
+	genomeEncoding, err := encoding.Lookup(encodingName)
+	if err != nil {
+		return nil, err
+	}
+	phenotype, err := genomeEncoding.Express(genome, inputNeuronIDs, outputNeuronIDs)
 	if err != nil {
 		return nil, err
 	}
 	return agent.NewCortex(
 		genome.ID,
-		genome,
+		phenotype.Network,
 		sensors,
 		actuators,
 		inputNeuronIDs,
 		outputNeuronIDs,
-		substrateRuntime,
+		phenotype.Substrate,
 	)
 }
 
-func seedPopulationOptionsFromRequest(req RunRequest) genotype.SeedPopulationOptions {
-	return genotype.SeedPopulationOptions{
-		GTSAProfile:            req.GTSAProfile,
-		FXProfile:              req.FXProfile,
-		EpitopesProfile:        req.EpitopesProfile,
-		LLVMProfile:            req.LLVMProfile,
-		FlatlandScannerProfile: req.FlatlandScannerProfile,
-	}
-}
+const newcomerIDFormat = "newcomer-g%d-n%d"
 
-func buildReplayIO(scapeName string, genome model.Genome) (map[string]protoio.Sensor, map[string]protoio.Actuator, error) {
-	var sensors map[string]protoio.Sensor
-	if len(genome.SensorIDs) > 0 {
-		sensors = make(map[string]protoio.Sensor, len(genome.SensorIDs))
-		for _, sensorID := range genome.SensorIDs {
-			sensor, err := protoio.ResolveSensor(sensorID, scapeName)
-			if err != nil {
-				return nil, nil, fmt.Errorf("resolve sensor %s for scape %s: %w", sensorID, scapeName, err)
-			}
-			sensors[sensorID] = sensor
+// newcomerFactory seeds single random genomes for selectors that inject
+// immigrants each generation. Seeds are derived from the run seed so reruns
+// stay reproducible.
+func newcomerFactory(req RunRequest) func(generation, index int) (model.Genome, error) {
+	options := seedPopulationOptionsFromRequest(req)
+	return func(generation, index int) (model.Genome, error) {
+		seed := req.Seed + int64(generation)*1_000_003 + int64(index)*7_919
+		population, err := genotype.ConstructSeedPopulationWithOptions(req.Scape, 1, seed, options)
+		if err != nil {
+			return model.Genome{}, err
+		}
+		if len(population.Genomes) == 0 {
+			return model.Genome{}, fmt.Errorf("seed population for %s is empty", req.Scape)
+		}
+		newcomer := genotype.CloneAgent(population.Genomes[0], fmt.Sprintf(newcomerIDFormat, generation, index))
+		genomeEncoding, err := encoding.Lookup(req.Encoding)
+		if err != nil {
+			return model.Genome{}, err
+		}
+		return initializeEncodingGenes(genomeEncoding, []model.Genome{newcomer}, population.InputNeuronIDs, population.OutputNeuronIDs, seed)[0], nil
+	}
+}
+
+// initializeEncodingGenes adds the genes the run's encoding expresses, such
+// as the L-system of grammar-encoded runs, to genomes that lack them.
+func initializeEncodingGenes(genomeEncoding encoding.Encoding, genomes []model.Genome, inputNeuronIDs, outputNeuronIDs []string, seed int64) []model.Genome {
+	initializer, ok := genomeEncoding.(encoding.Initializer)
+	if !ok {
+		return genomes
+	}
+	rng := rand.New(rand.NewSource(seed + 1_300))
+	initialized := make([]model.Genome, len(genomes))
+	for i, genome := range genomes {
+		initialized[i] = initializer.Initialize(genome, inputNeuronIDs, outputNeuronIDs, rng)
+	}
+	return initialized
+}
+
+func progressHook(progress func(RunProgress)) func(evo.GenerationDiagnostics) {
+	if progress == nil {
+		return nil
+	}
+	return func(diag evo.GenerationDiagnostics) {
+		progress(RunProgress{
+			Generation:           diag.Generation,
+			BestFitness:          diag.BestFitness,
+			MeanFitness:          diag.MeanFitness,
+			SpeciesCount:         diag.SpeciesCount,
+			TuningInvocations:    diag.TuningInvocations,
+			TuningEvaluations:    diag.TuningEvaluations,
+			TuningAccepted:       diag.TuningAccepted,
+			TuningAcceptRate:     diag.TuningAcceptRate,
+			WallClock:            secondsToDuration(diag.WallClockSeconds),
+			TotalEvaluations:     diag.TotalEvaluations,
+			EvaluationsPerSecond: diag.EvaluationsPerSecond,
+			ETA:                  secondsToDuration(diag.ETASeconds),
+		})
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// newTuningComparison builds the compare-tuning report. Both arms run with
+// common random numbers, so per-generation best-fitness differences are
+// paired and reflect tuning rather than episode noise.
+func newTuningComparison(req RunRequest, withoutTuning, withTuning platform.EvolutionResult) *stats.TuningComparison {
+	paired, meanPaired := stats.PairedDifferences(withoutTuning.BestByGeneration, withTuning.BestByGeneration)
+	return &stats.TuningComparison{
+		Scape:                 req.Scape,
+		PopulationSize:        req.Population,
+		Generations:           req.Generations,
+		Seed:                  req.Seed,
+		WithoutTuningBest:     withoutTuning.BestByGeneration,
+		WithTuningBest:        withTuning.BestByGeneration,
+		WithoutFinalBest:      withoutTuning.BestFinalFitness,
+		WithFinalBest:         withTuning.BestFinalFitness,
+		FinalImprovement:      withTuning.BestFinalFitness - withoutTuning.BestFinalFitness,
+		Pairing:               stats.TuningPairingCommonRandomNumbers,
+		PairedImprovement:     paired,
+		MeanPairedImprovement: meanPaired,
+	}
+}
+
+func seedPopulationOptionsFromRequest(req RunRequest) genotype.SeedPopulationOptions {
+	return genotype.SeedPopulationOptions{
+		GTSAProfile:            req.GTSAProfile,
+		FXProfile:              req.FXProfile,
+		EpitopesProfile:        req.EpitopesProfile,
+		LLVMProfile:            req.LLVMProfile,
+		FlatlandScannerProfile: req.FlatlandScannerProfile,
+		ParityInputs:           scape.ParityInputs(req.ScapeParams),
+		FunctionInputs:         scape.FunctionApproxInputs(req.ScapeParams),
+		SensorSubsetMin:        req.InitialSensorsMin,
+		SensorSubsetMax:        req.InitialSensorsMax,
+	}
+}
+
+func buildReplayIO(scapeName string, genome model.Genome) (map[string]protoio.Sensor, map[string]protoio.Actuator, error) {
+	var sensors map[string]protoio.Sensor
+	if len(genome.SensorIDs) > 0 {
+		sensors = make(map[string]protoio.Sensor, len(genome.SensorIDs))
+		for _, sensorID := range genome.SensorIDs {
+			sensor, err := protoio.ResolveSensor(sensorID, scapeName)
+			if err != nil {
+				return nil, nil, fmt.Errorf("resolve sensor %s for scape %s: %w", sensorID, scapeName, err)
+			}
+			sensors[sensorID] = sensor
 		}
 	}
 
@@ -889,73 +1447,59 @@ func buildReplayIO(scapeName string, genome model.Genome) (map[string]protoio.Se
 	return sensors, actuators, nil
 }
 
-func buildReplaySubstrate(genome model.Genome, outputNeuronIDs []string) (substrate.Runtime, error) {
-	if genome.Substrate == nil {
-		return nil, nil
-	}
-	cfg := genome.Substrate
-	faninByCEP := genotype.ResolveSubstrateCEPFaninPIDsByCEP(genome, outputNeuronIDs)
-	faninPIDs := genotype.ResolveSubstrateCEPFaninPIDs(genome, outputNeuronIDs)
-	if flattened := genotype.FlattenSubstrateCEPFaninPIDsByCEP(faninByCEP); len(flattened) > 0 {
-		faninPIDs = flattened
-	}
-	spec := substrate.Spec{
-		CPPName:           cfg.CPPName,
-		CPPIDs:            append([]string(nil), cfg.CPPIDs...),
-		CEPName:           cfg.CEPName,
-		CEPNames:          append([]string(nil), cfg.CEPNames...),
-		CEPIDs:            append([]string(nil), cfg.CEPIDs...),
-		CEPFaninPIDs:      faninPIDs,
-		CEPFaninPIDsByCEP: faninByCEP,
-		Dimensions:        append([]int(nil), cfg.Dimensions...),
-		Parameters:        map[string]float64{},
-	}
-	for k, v := range cfg.Parameters {
-		spec.Parameters[k] = v
-	}
-	weightCount := cfg.WeightCount
-	if weightCount <= 0 {
-		weightCount = len(outputNeuronIDs)
-	}
-	rt, err := substrate.NewSimpleRuntime(spec, weightCount)
-	if err != nil {
-		return nil, fmt.Errorf("build substrate runtime for genome %s: %w", genome.ID, err)
-	}
-	return rt, nil
-}
-
 func toStatsTraceAcc(in []evo.TraceGeneration) []stats.TraceGeneration {
 	if len(in) == 0 {
 		return nil
 	}
 	out := make([]stats.TraceGeneration, 0, len(in))
 	for _, generation := range in {
-		entry := stats.TraceGeneration{
-			Generation: generation.Generation,
-			Stats:      make([]stats.TraceStatEntry, 0, len(generation.Stats)),
-		}
-		for _, stat := range generation.Stats {
-			item := stats.TraceStatEntry{
-				SpeciesKey:       stat.SpeciesKey,
-				ChampionGenomeID: stat.ChampionGenomeID,
-				ChampionGenome:   genotype.CloneGenome(stat.ChampionGenome),
-				BestFitness:      stat.BestFitness,
-			}
-			if stat.ValidationFitness != nil {
-				val := *stat.ValidationFitness
-				item.ValidationFitness = &val
-			}
-			if stat.TestFitness != nil {
-				val := *stat.TestFitness
-				item.TestFitness = &val
-			}
-			entry.Stats = append(entry.Stats, item)
-		}
-		out = append(out, entry)
+		out = append(out, toStatsTraceGeneration(generation))
 	}
 	return out
 }
 
+func toStatsTraceGeneration(generation evo.TraceGeneration) stats.TraceGeneration {
+	entry := stats.TraceGeneration{
+		Generation: generation.Generation,
+		Stats:      make([]stats.TraceStatEntry, 0, len(generation.Stats)),
+	}
+	if generation.Phases != nil {
+		phases := *generation.Phases
+		entry.Phases = &phases
+	}
+	for _, stat := range generation.Stats {
+		item := stats.TraceStatEntry{
+			SpeciesKey:       stat.SpeciesKey,
+			ChampionGenomeID: stat.ChampionGenomeID,
+			ChampionGenome:   genotype.CloneGenome(stat.ChampionGenome),
+			BestFitness:      stat.BestFitness,
+		}
+		if stat.ValidationFitness != nil {
+			val := *stat.ValidationFitness
+			item.ValidationFitness = &val
+		}
+		if stat.TestFitness != nil {
+			val := *stat.TestFitness
+			item.TestFitness = &val
+		}
+		entry.Stats = append(entry.Stats, item)
+	}
+	return entry
+}
+
+func toStatsTraceUpdate(update evo.TraceUpdate) stats.TraceStreamUpdate {
+	return stats.TraceStreamUpdate{
+		Reason:           string(update.Reason),
+		TotalEvaluations: update.TotalEvaluations,
+		GoalReached:      update.GoalReached,
+		StepEvaluations:  update.StepEvaluations,
+		Generation:       update.Diagnostics.Generation,
+		BestFitness:      update.Diagnostics.BestFitness,
+		MeanFitness:      update.Diagnostics.MeanFitness,
+		SpeciesCount:     update.Diagnostics.SpeciesCount,
+	}
+}
+
 func meanStd(values []float64) (float64, float64) {
 	if len(values) == 0 {
 		return 0, 0
@@ -988,6 +1532,68 @@ func fitnessRange(values []float64) (maxVal, minVal float64) {
 	return maxVal, minVal
 }
 
+// runResources totals a finished run's resource usage: process CPU time
+// since start, the process peak RSS, population and tuning evaluations, and
+// the size of the run's artifacts.
+func runResources(runDir string, diagnostics []model.GenerationDiagnostics, start stats.ProcessUsage, sampled bool) (stats.RunResources, error) {
+	var resources stats.RunResources
+	if sampled {
+		if end, ok := stats.SampleProcessUsage(); ok {
+			resources.CPUSeconds = end.CPUSince(start)
+			resources.PeakRSSBytes = end.PeakRSSBytes
+		}
+	}
+	for _, diag := range diagnostics {
+		resources.Evaluations += diag.TuningEvaluations
+	}
+	if len(diagnostics) > 0 {
+		resources.Evaluations += diagnostics[len(diagnostics)-1].TotalEvaluations
+	}
+	storageBytes, err := stats.DirBytes(runDir)
+	if err != nil {
+		return stats.RunResources{}, err
+	}
+	resources.StorageBytes = storageBytes
+	return resources, nil
+}
+
+// runEvaluationCost totals the per-generation metered evaluation cost; it
+// returns nil when no generation was metered.
+func runEvaluationCost(scapeName string, diagnostics []model.GenerationDiagnostics) *stats.EvaluationCost {
+	cost := stats.EvaluationCost{Scape: scapeName}
+	for _, diag := range diagnostics {
+		if diag.EvaluationCost == nil {
+			continue
+		}
+		cost.Evaluations += diag.EvaluationCost.Evaluations
+		cost.CPUSeconds += diag.EvaluationCost.CPUSeconds
+		cost.AllocBytes += diag.EvaluationCost.AllocBytes
+		cost.MaxCPUSeconds = math.Max(cost.MaxCPUSeconds, diag.EvaluationCost.MaxCPUSeconds)
+	}
+	if cost.Evaluations == 0 {
+		return nil
+	}
+	cost.MeanCPUSeconds = cost.CPUSeconds / float64(cost.Evaluations)
+	cost.MeanAllocBytes = float64(cost.AllocBytes) / float64(cost.Evaluations)
+	return &cost
+}
+
+// islandBestFitness returns the best fitness each island reached over the
+// generations of diagnostics, or nil for a run without islands.
+func islandBestFitness(diagnostics []model.GenerationDiagnostics) []float64 {
+	var bests []float64
+	for _, diag := range diagnostics {
+		if bests == nil && len(diag.IslandBest) > 0 {
+			bests = append([]float64(nil), diag.IslandBest...)
+			continue
+		}
+		for i := 0; i < min(len(bests), len(diag.IslandBest)); i++ {
+			bests[i] = math.Max(bests[i], diag.IslandBest[i])
+		}
+	}
+	return bests
+}
+
 func (c *Client) Runs(_ context.Context, req RunsRequest) ([]RunItem, error) {
 	if req.Limit <= 0 {
 		req.Limit = 20
@@ -1013,6 +1619,9 @@ func (c *Client) Runs(_ context.Context, req RunsRequest) ([]RunItem, error) {
 			Generations:      e.Generations,
 			TuningEnabled:    e.TuningEnabled,
 			FinalBestFitness: e.FinalBestFitness,
+			ForkedFrom:       e.ForkedFrom,
+			ForkGeneration:   e.ForkGeneration,
+			Resources:        e.Resources,
 		}
 		if req.ShowCompare {
 			report, ok, err := stats.ReadTuningComparison(c.benchmarksDir, e.RunID)
@@ -1039,6 +1648,21 @@ func (c *Client) Export(_ context.Context, req ExportRequest) (ExportSummary, er
 	if req.OutDir == "" {
 		req.OutDir = c.exportsDir
 	}
+	profile := strings.ToLower(strings.TrimSpace(req.Profile))
+	switch profile {
+	case "":
+		profile = ExportProfileFull
+	case ExportProfileFull, ExportProfilePaper, ExportProfileCompact:
+	default:
+		return ExportSummary{}, fmt.Errorf("unsupported export profile: %s (want full, paper or compact)", req.Profile)
+	}
+	quantization, ok := genotype.NormalizeQuantization(req.Quantization)
+	if !ok {
+		return ExportSummary{}, fmt.Errorf("unsupported quantization: %s (want float64, float32, int16 or int8)", req.Quantization)
+	}
+	if req.Quantization != "" && profile != ExportProfileCompact {
+		return ExportSummary{}, errors.New("quantization requires the compact export profile")
+	}
 
 	runID := req.RunID
 	if req.Latest {
@@ -1050,7 +1674,7 @@ func (c *Client) Export(_ context.Context, req ExportRequest) (ExportSummary, er
 			return ExportSummary{}, errors.New("no runs available to export")
 		}
 		runID = entries[0].RunID
-		return c.exportRunByID(runID, entries[0].Morphology, req.OutDir)
+		return c.exportRunByID(runID, entries[0].Morphology, req.OutDir, profile, quantization)
 	}
 	cfg, ok, err := readRunConfigWithProfileHints(c.benchmarksDir, runID)
 	if err != nil {
@@ -1063,15 +1687,21 @@ func (c *Client) Export(_ context.Context, req ExportRequest) (ExportSummary, er
 			return ExportSummary{}, err
 		}
 	}
-	return c.exportRunByID(runID, morphology, req.OutDir)
+	return c.exportRunByID(runID, morphology, req.OutDir, profile, quantization)
 }
 
-func (c *Client) exportRunByID(runID, morphology, outDir string) (ExportSummary, error) {
+func (c *Client) exportRunByID(runID, morphology, outDir, profile, quantization string) (ExportSummary, error) {
+	switch profile {
+	case ExportProfilePaper:
+		return exportPaperArchive(c.benchmarksDir, runID, morphology, outDir)
+	case ExportProfileCompact:
+		return exportCompactChampion(c.benchmarksDir, runID, morphology, outDir, quantization)
+	}
 	exportedDir, err := stats.ExportRunArtifacts(c.benchmarksDir, runID, outDir)
 	if err != nil {
 		return ExportSummary{}, err
 	}
-	return ExportSummary{RunID: runID, Morphology: morphology, Directory: filepath.Clean(exportedDir)}, nil
+	return ExportSummary{RunID: runID, Morphology: morphology, Directory: filepath.Clean(exportedDir), Profile: profile}, nil
 }
 
 func (c *Client) Lineage(ctx context.Context, req LineageRequest) ([]LineageItem, error) {
@@ -1119,6 +1749,7 @@ func (c *Client) Lineage(ctx context.Context, req LineageRequest) ([]LineageItem
 			ParentID:    rec.ParentID,
 			Generation:  rec.Generation,
 			Operation:   rec.Operation,
+			MateID:      rec.MateID,
 			Events:      cloneModelEvoHistoryEvents(rec.Events),
 			Fingerprint: rec.Fingerprint,
 			Summary:     rec.Summary,
@@ -1194,6 +1825,52 @@ func (c *Client) FitnessHistory(ctx context.Context, req FitnessHistoryRequest)
 	return append([]float64(nil), history...), nil
 }
 
+func (c *Client) Events(ctx context.Context, req EventsRequest) ([]model.EvolutionEvent, error) {
+	if req.RunID != "" && req.Latest {
+		return nil, errors.New("use either run id or latest")
+	}
+	if req.Limit < 0 {
+		return nil, errors.New("limit must be >= 0")
+	}
+	if req.Type != "" && !evo.IsEventType(req.Type) {
+		return nil, fmt.Errorf("unknown event type: %s (want one of %s)", req.Type, strings.Join(evo.EventTypes(), ", "))
+	}
+
+	runID := req.RunID
+	if req.Latest {
+		entries, err := stats.ListRunIndex(c.benchmarksDir)
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) == 0 {
+			return nil, errors.New("no runs available")
+		}
+		runID = entries[0].RunID
+	}
+	if runID == "" {
+		return nil, errors.New("events requires run id or latest")
+	}
+
+	events, ok, err := stats.ReadEvents(c.benchmarksDir, runID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("events not found for run id: %s", runID)
+	}
+	out := make([]model.EvolutionEvent, 0, len(events))
+	for _, event := range events {
+		if req.Type != "" && event.Type != req.Type {
+			continue
+		}
+		out = append(out, event)
+		if req.Limit > 0 && len(out) == req.Limit {
+			break
+		}
+	}
+	return out, nil
+}
+
 func (c *Client) Diagnostics(ctx context.Context, req DiagnosticsRequest) ([]model.GenerationDiagnostics, error) {
 	if req.RunID != "" && req.Latest {
 		return nil, errors.New("use either run id or latest")
@@ -1412,9 +2089,55 @@ func (c *Client) SpeciesDiff(ctx context.Context, req SpeciesDiffRequest) (Speci
 	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].Key < diff.Added[j].Key })
 	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].Key < diff.Removed[j].Key })
 	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].Key < diff.Changed[j].Key })
+	if req.ChampionDeltas && len(diff.Changed) > 0 {
+		if err := c.attachChampionDeltas(runID, &diff); err != nil {
+			return SpeciesDiff{}, err
+		}
+	}
 	return diff, nil
 }
 
+// attachChampionDeltas diffs the per-species champion genomes trace_acc
+// recorded for the from and to generations of diff's changed species.
+func (c *Client) attachChampionDeltas(runID string, diff *SpeciesDiff) error {
+	traceAcc, ok, err := stats.ReadTraceAcc(c.benchmarksDir, runID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("trace_acc not found for run id: %s", runID)
+	}
+	champions := func(generation int) map[string]model.Genome {
+		out := map[string]model.Genome{}
+		for _, entry := range traceAcc {
+			if entry.Generation != generation {
+				continue
+			}
+			for _, stat := range entry.Stats {
+				if stat.ChampionGenome.ID != "" {
+					out[stat.SpeciesKey] = stat.ChampionGenome
+				}
+			}
+		}
+		return out
+	}
+	fromChampions := champions(diff.FromGeneration)
+	toChampions := champions(diff.ToGeneration)
+	for i := range diff.Changed {
+		from, fromOK := fromChampions[diff.Changed[i].Key]
+		to, toOK := toChampions[diff.Changed[i].Key]
+		if !fromOK || !toOK {
+			continue
+		}
+		diff.Changed[i].Champion = &SpeciesChampionDelta{
+			FromGenomeID: from.ID,
+			ToGenomeID:   to.ID,
+			GenomeDelta:  genotype.DiffGenomes(from, to),
+		}
+	}
+	return nil
+}
+
 func (c *Client) TopGenomes(ctx context.Context, req TopGenomesRequest) ([]model.TopGenomeRecord, error) {
 	if req.RunID != "" && req.Latest {
 		return nil, errors.New("use either run id or latest")
@@ -1539,7 +2262,7 @@ func (c *Client) EpitopesReplay(ctx context.Context, req EpitopesReplayRequest)
 	items := make([]EpitopesReplayItem, 0, len(candidates))
 	tableName := ""
 	for _, candidate := range candidates {
-		cortex, err := buildReplayCortex("epitopes", candidate.Genome, inputNeuronIDs, outputNeuronIDs)
+		cortex, err := buildReplayCortex("epitopes", replayReq.Encoding, candidate.Genome, inputNeuronIDs, outputNeuronIDs)
 		if err != nil {
 			return EpitopesReplaySummary{}, fmt.Errorf("build replay cortex for genome %s: %w", candidate.Genome.ID, err)
 		}
@@ -1584,7 +2307,7 @@ func (c *Client) EpitopesReplay(ctx context.Context, req EpitopesReplayRequest)
 	bestReplayFitness := 0.0
 	bestReplayTable := ""
 	bestReplayTotal := 0
-	cortex, err := buildReplayCortex("epitopes", botb.Genome, inputNeuronIDs, outputNeuronIDs)
+	cortex, err := buildReplayCortex("epitopes", replayReq.Encoding, botb.Genome, inputNeuronIDs, outputNeuronIDs)
 	if err != nil {
 		return EpitopesReplaySummary{}, fmt.Errorf("build replay cortex for best genome %s: %w", botb.Genome.ID, err)
 	}
@@ -1806,6 +2529,23 @@ func (c *Client) PrintTraceRun(ctx context.Context, req MonitorControlRequest) e
 	return p.PrintTraceRun(req.RunID)
 }
 
+// SetScapeParamRun changes a tunable parameter of the active run's scape. The
+// change is applied from the next generation and recorded in that
+// generation's diagnostics.
+func (c *Client) SetScapeParamRun(ctx context.Context, req SetScapeParamRequest) error {
+	if req.RunID == "" {
+		return errors.New("run id is required")
+	}
+	if req.Name == "" {
+		return errors.New("scape parameter name is required")
+	}
+	p, err := c.ensurePolis(ctx)
+	if err != nil {
+		return err
+	}
+	return p.SetScapeParamRun(req.RunID, req.Name, req.Value)
+}
+
 func (c *Client) DeletePopulation(ctx context.Context, req DeletePopulationRequest) error {
 	if req.PopulationID == "" {
 		return errors.New("population id is required")
@@ -1816,6 +2556,319 @@ func (c *Client) DeletePopulation(ctx context.Context, req DeletePopulationReque
 	return genotype.DeletePopulationSnapshot(ctx, c.store, req.PopulationID)
 }
 
+// CompactStore optionally offloads runs older than ArchiveOlderThan (by run
+// index creation time) to compressed archives, then removes genomes no longer
+// referenced by any population and reclaims space.
+func (c *Client) CompactStore(ctx context.Context, req CompactStoreRequest) (CompactStoreSummary, error) {
+	if req.ArchiveOlderThan < 0 {
+		return CompactStoreSummary{}, errors.New("archive age must be >= 0")
+	}
+	if req.ArchiveOlderThan > 0 && req.ArchiveDir == "" {
+		return CompactStoreSummary{}, errors.New("archive dir is required when archiving runs")
+	}
+	if _, err := c.ensurePolis(ctx); err != nil {
+		return CompactStoreSummary{}, err
+	}
+
+	summary := CompactStoreSummary{ArchivedRuns: []string{}, ArchiveFiles: []string{}}
+	if req.ArchiveOlderThan > 0 {
+		entries, err := stats.ListRunIndex(c.benchmarksDir)
+		if err != nil {
+			return CompactStoreSummary{}, err
+		}
+		cutoff := time.Now().UTC().Add(-req.ArchiveOlderThan)
+		for _, entry := range entries {
+			createdAt, err := time.Parse(time.RFC3339Nano, entry.CreatedAtUTC)
+			if err != nil || !createdAt.Before(cutoff) {
+				continue
+			}
+			archive, err := storage.ExportRunArchive(ctx, c.store, entry.RunID)
+			if err != nil {
+				return CompactStoreSummary{}, err
+			}
+			if archive.Population == nil && archive.FitnessHistory == nil && archive.Lineage == nil {
+				continue
+			}
+			path, err := storage.OffloadRun(ctx, c.store, entry.RunID, req.ArchiveDir)
+			if err != nil {
+				return CompactStoreSummary{}, err
+			}
+			summary.ArchivedRuns = append(summary.ArchivedRuns, entry.RunID)
+			summary.ArchiveFiles = append(summary.ArchiveFiles, path)
+		}
+	}
+
+	result, err := storage.CompactGenomes(ctx, c.store)
+	if err != nil {
+		return CompactStoreSummary{}, err
+	}
+	summary.ReferencedGenomes = result.ReferencedGenomes
+	summary.RemovedGenomes = result.RemovedGenomes
+	summary.Vacuumed = result.Vacuumed
+	return summary, nil
+}
+
+// StoreStats reports per-entity record counts and payload sizes, the largest
+// runs by stored bytes, and integrity-check findings. Limit caps the number of
+// runs listed (default 10).
+func (c *Client) StoreStats(ctx context.Context, req StoreStatsRequest) (StoreStats, error) {
+	if req.Limit < 0 {
+		return StoreStats{}, errors.New("limit must be >= 0")
+	}
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+	if _, err := c.ensurePolis(ctx); err != nil {
+		return StoreStats{}, err
+	}
+	report, err := storage.Inspect(ctx, c.store)
+	if err != nil {
+		return StoreStats{}, err
+	}
+	if len(report.Runs) > req.Limit {
+		report.Runs = report.Runs[:req.Limit]
+	}
+	out := StoreStats{StoreKind: c.storeKind, InspectReport: report}
+	if c.storeKind == "sqlite" || c.storeKind == "bolt" {
+		if info, err := os.Stat(c.dbPath); err == nil {
+			out.FileBytes = info.Size()
+		}
+	}
+	return out, nil
+}
+
+// ImportRunArchive restores a run archive written by CompactStore and returns
+// the restored run id.
+func (c *Client) ImportRunArchive(ctx context.Context, path string) (string, error) {
+	if path == "" {
+		return "", errors.New("archive path is required")
+	}
+	archive, err := storage.ReadRunArchiveFile(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err := c.ensurePolis(ctx); err != nil {
+		return "", err
+	}
+	if err := storage.ImportRunArchive(ctx, c.store, archive); err != nil {
+		return "", err
+	}
+	return archive.RunID, nil
+}
+
+// MergePopulations unions the final population snapshots of several runs into
+// a new snapshot that can seed a continuation run via ContinuePopulationID.
+// Runs with recorded configs must share a scape.
+func (c *Client) MergePopulations(ctx context.Context, req MergePopulationsRequest) (MergePopulationsSummary, error) {
+	if len(req.RunIDs) < 2 {
+		return MergePopulationsSummary{}, errors.New("merge populations requires at least two run ids")
+	}
+	if req.OutPopulationID == "" {
+		return MergePopulationsSummary{}, errors.New("merge populations requires output population id")
+	}
+	scapeName := ""
+	for _, runID := range req.RunIDs {
+		cfg, ok, err := stats.ReadRunConfig(c.benchmarksDir, runID)
+		if err != nil {
+			return MergePopulationsSummary{}, err
+		}
+		if !ok {
+			continue
+		}
+		if scapeName == "" {
+			scapeName = cfg.Scape
+			continue
+		}
+		if cfg.Scape != scapeName {
+			return MergePopulationsSummary{}, fmt.Errorf("cannot merge populations from different scapes: %s and %s", scapeName, cfg.Scape)
+		}
+	}
+	if _, err := c.ensurePolis(ctx); err != nil {
+		return MergePopulationsSummary{}, err
+	}
+	merged, err := genotype.MergePopulationSnapshots(ctx, c.store, req.OutPopulationID, req.RunIDs)
+	if err != nil {
+		return MergePopulationsSummary{}, err
+	}
+	return MergePopulationsSummary{
+		PopulationID:   merged.PopulationID,
+		Scape:          scapeName,
+		Generation:     merged.Generation,
+		SourceSizes:    merged.SourceSizes,
+		MergedSize:     merged.MergedSize,
+		DuplicateCount: merged.DuplicateCount,
+		SpeciesCount:   merged.SpeciesCount,
+		SpeciesSizes:   merged.SpeciesSizes,
+	}, nil
+}
+
+// PopulationDiff compares two persisted population snapshots. When both
+// snapshots belong to the same run, species composition comes from the
+// species history that run recorded, so it agrees with species and
+// species-diff; otherwise species are derived from genome topology, which
+// also covers legacy snapshots without stored fitness.
+func (c *Client) PopulationDiff(ctx context.Context, req PopulationDiffRequest) (PopulationDiff, error) {
+	if req.FromID == "" || req.ToID == "" {
+		return PopulationDiff{}, errors.New("population diff requires from and to ids")
+	}
+	if _, err := c.ensurePolis(ctx); err != nil {
+		return PopulationDiff{}, err
+	}
+	fromPop, fromGenomes, err := genotype.LoadPopulationSnapshot(ctx, c.store, req.FromID)
+	if err != nil {
+		return PopulationDiff{}, err
+	}
+	toPop, toGenomes, err := genotype.LoadPopulationSnapshot(ctx, c.store, req.ToID)
+	if err != nil {
+		return PopulationDiff{}, err
+	}
+
+	diff := PopulationDiff{
+		FromID:         req.FromID,
+		ToID:           req.ToID,
+		FromGeneration: fromPop.Generation,
+		ToGeneration:   toPop.Generation,
+		FromSize:       len(fromPop.AgentIDs),
+		ToSize:         len(toPop.AgentIDs),
+		Added:          []string{},
+		Removed:        []string{},
+	}
+
+	fromMembers := make(map[string]struct{}, len(fromPop.AgentIDs))
+	for _, id := range fromPop.AgentIDs {
+		fromMembers[id] = struct{}{}
+	}
+	toMembers := make(map[string]struct{}, len(toPop.AgentIDs))
+	for _, id := range toPop.AgentIDs {
+		toMembers[id] = struct{}{}
+		if _, ok := fromMembers[id]; ok {
+			diff.RetainedCount++
+		} else {
+			diff.Added = append(diff.Added, id)
+		}
+	}
+	for _, id := range fromPop.AgentIDs {
+		if _, ok := toMembers[id]; !ok {
+			diff.Removed = append(diff.Removed, id)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+
+	fromFitness := populationFitnessValues(fromPop)
+	toFitness := populationFitnessValues(toPop)
+	if len(fromFitness) > 0 && len(toFitness) > 0 {
+		diff.FitnessAvailable = true
+		diff.FromMeanFitness, diff.FromBestFitness = meanAndMax(fromFitness)
+		diff.ToMeanFitness, diff.ToBestFitness = meanAndMax(toFitness)
+		diff.MeanFitnessDelta = diff.ToMeanFitness - diff.FromMeanFitness
+		diff.BestFitnessDelta = diff.ToBestFitness - diff.FromBestFitness
+		diff.KSStatistic = stats.KolmogorovSmirnov(fromFitness, toFitness)
+	}
+
+	fromSpecies, toSpecies, err := c.recordedSpeciesSizes(ctx, fromPop, toPop)
+	if err != nil {
+		return PopulationDiff{}, err
+	}
+	diff.SpeciesSource = PopulationSpeciesRecorded
+	if fromSpecies == nil || toSpecies == nil {
+		diff.SpeciesSource = PopulationSpeciesTopology
+		identifier := evo.TopologySpecieIdentifier{}
+		fromSpecies = make(map[string]int)
+		for _, genome := range fromGenomes {
+			fromSpecies[identifier.Identify(genome)]++
+		}
+		toSpecies = make(map[string]int)
+		for _, genome := range toGenomes {
+			toSpecies[identifier.Identify(genome)]++
+		}
+	}
+	for key, fromSize := range fromSpecies {
+		toSize, ok := toSpecies[key]
+		delta := PopulationSpeciesDelta{Key: key, FromSize: fromSize, ToSize: toSize, SizeDelta: toSize - fromSize}
+		switch {
+		case !ok:
+			diff.SpeciesRemoved = append(diff.SpeciesRemoved, delta)
+		case toSize != fromSize:
+			diff.SpeciesChanged = append(diff.SpeciesChanged, delta)
+		}
+	}
+	for key, toSize := range toSpecies {
+		if _, ok := fromSpecies[key]; !ok {
+			diff.SpeciesAdded = append(diff.SpeciesAdded, PopulationSpeciesDelta{Key: key, ToSize: toSize, SizeDelta: toSize})
+		}
+	}
+	sort.Slice(diff.SpeciesAdded, func(i, j int) bool { return diff.SpeciesAdded[i].Key < diff.SpeciesAdded[j].Key })
+	sort.Slice(diff.SpeciesRemoved, func(i, j int) bool { return diff.SpeciesRemoved[i].Key < diff.SpeciesRemoved[j].Key })
+	sort.Slice(diff.SpeciesChanged, func(i, j int) bool { return diff.SpeciesChanged[i].Key < diff.SpeciesChanged[j].Key })
+	return diff, nil
+}
+
+// recordedSpeciesSizes returns the species sizes the run behind both
+// snapshots recorded for their generations, or nils when the snapshots come
+// from different runs or a generation is missing from the species history.
+func (c *Client) recordedSpeciesSizes(ctx context.Context, fromPop, toPop model.Population) (map[string]int, map[string]int, error) {
+	runID := snapshotRunID(fromPop.ID)
+	if runID != snapshotRunID(toPop.ID) {
+		return nil, nil, nil
+	}
+	history, ok, err := c.store.GetSpeciesHistory(ctx, runID)
+	if err != nil || !ok {
+		return nil, nil, err
+	}
+	sizesAt := func(generation int) map[string]int {
+		for _, item := range history {
+			if item.Generation != generation {
+				continue
+			}
+			sizes := make(map[string]int, len(item.Species))
+			for _, species := range item.Species {
+				sizes[species.Key] = species.Size
+			}
+			return sizes
+		}
+		return nil
+	}
+	return sizesAt(fromPop.Generation), sizesAt(toPop.Generation), nil
+}
+
+// snapshotRunID is the run that saved a population snapshot: the run id of a
+// generation snapshot, or the population id itself for a final snapshot.
+func snapshotRunID(populationID string) string {
+	if runID, _, ok := genotype.ParseGenerationSnapshotID(populationID); ok {
+		return runID
+	}
+	return populationID
+}
+
+func populationFitnessValues(pop model.Population) []float64 {
+	if len(pop.Fitness) == 0 {
+		return nil
+	}
+	values := make([]float64, 0, len(pop.AgentIDs))
+	for _, id := range pop.AgentIDs {
+		if value, ok := pop.Fitness[id]; ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func meanAndMax(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	sum := 0.0
+	best := values[0]
+	for _, value := range values {
+		sum += value
+		if value > best {
+			best = value
+		}
+	}
+	return sum / float64(len(values)), best
+}
+
 func (c *Client) ensurePolis(ctx context.Context) (*platform.Polis, error) {
 	if c.polis != nil {
 		return c.polis, nil
@@ -1828,38 +2881,246 @@ func (c *Client) ensurePolis(ctx context.Context) (*platform.Polis, error) {
 	return c.polis, nil
 }
 
-func registerDefaultScapes(p *platform.Polis) error {
-	if err := p.RegisterScape(scape.XORScape{}); err != nil {
-		return err
+// defaultScapes lists the scape instances every run registers before its
+// own scape is built.
+func defaultScapes() []scape.Scape {
+	return []scape.Scape{
+		scape.XORScape{},
+		scape.RegressionMimicScape{},
+		scape.CartPoleLiteScape{},
+		scape.Pole2BalancingScape{},
+		scape.FlatlandScape{},
+		scape.DTMScape{},
+		scape.GTSAScape{},
+		scape.FXScape{},
+		scape.EpitopesScape{},
+		scape.LLVMPhaseOrderingScape{},
+		scape.ParityScape{Inputs: scape.DefaultParityInputs},
+		scape.ParityScape{Inputs: scape.DefaultParityInputs, Majority: true},
+		scape.FunctionApproxScape{},
+		scape.StackMachineScape{},
 	}
-	if err := p.RegisterScape(scape.RegressionMimicScape{}); err != nil {
-		return err
-	}
-	if err := p.RegisterScape(scape.CartPoleLiteScape{}); err != nil {
-		return err
+}
+
+func registerDefaultScapes(p *platform.Polis) error {
+	for _, s := range defaultScapes() {
+		if err := p.RegisterScape(s); err != nil {
+			return err
+		}
 	}
-	if err := p.RegisterScape(scape.Pole2BalancingScape{}); err != nil {
-		return err
+	return nil
+}
+
+// buildRunScape constructs the run's scape from req.ScapeParams, through its
+// factory or, for a default instance, its Configurable implementation. ok is
+// false when the default instance is used unchanged.
+func buildRunScape(req RunRequest) (built scape.Scape, ok bool, err error) {
+	if _, ok := scape.LookupFactory(req.Scape); ok {
+		built, err = scape.NewFromFactory(req.Scape, req.ScapeParams)
+		if err != nil {
+			return nil, false, err
+		}
+		return built, true, nil
 	}
-	if err := p.RegisterScape(scape.FlatlandScape{}); err != nil {
-		return err
+	if len(req.ScapeParams) == 0 {
+		return nil, false, nil
 	}
-	if err := p.RegisterScape(scape.DTMScape{}); err != nil {
-		return err
+	for _, s := range defaultScapes() {
+		if s.Name() == req.Scape {
+			built, err = scape.ConfigureScape(s, req.ScapeParams)
+			if err != nil {
+				return nil, false, err
+			}
+			return built, true, nil
+		}
 	}
-	if err := p.RegisterScape(scape.GTSAScape{}); err != nil {
+	return nil, false, fmt.Errorf("scape %s does not accept construction parameters", req.Scape)
+}
+
+// registerRunScape replaces the default instance of a parameterized scape
+// with one built from the run's construction parameters.
+func registerRunScape(p *platform.Polis, req RunRequest) error {
+	built, ok, err := buildRunScape(req)
+	if err != nil || !ok {
 		return err
 	}
-	if err := p.RegisterScape(scape.FXScape{}); err != nil {
-		return err
+	return p.RegisterScape(built)
+}
+
+// runConfigFromRequest is the config.json record of a run of req.
+func runConfigFromRequest(req RunRequest, runID string, eliteCount, initialGeneration int, championSources []string) stats.RunConfig {
+	return stats.RunConfig{
+		RunID:                   runID,
+		OpMode:                  req.OpMode,
+		EvolutionType:           req.EvolutionType,
+		Encoding:                req.Encoding,
+		Algorithm:               req.Algorithm,
+		Scape:                   req.Scape,
+		GTSACSVPath:             req.GTSACSVPath,
+		GTSATrainEnd:            req.GTSATrainEnd,
+		GTSAValidationEnd:       req.GTSAValidationEnd,
+		GTSATestEnd:             req.GTSATestEnd,
+		FXCSVPath:               req.FXCSVPath,
+		EpitopesCSVPath:         req.EpitopesCSVPath,
+		EpitopesTableName:       req.EpitopesTableName,
+		LLVMWorkflowJSONPath:    req.LLVMWorkflowJSONPath,
+		EpitopesGTStart:         req.EpitopesGTStart,
+		EpitopesGTEnd:           req.EpitopesGTEnd,
+		EpitopesValidationStart: req.EpitopesValidationStart,
+		EpitopesValidationEnd:   req.EpitopesValidationEnd,
+		EpitopesTestStart:       req.EpitopesTestStart,
+		EpitopesTestEnd:         req.EpitopesTestEnd,
+		EpitopesBenchmarkStart:  req.EpitopesBenchmarkStart,
+		EpitopesBenchmarkEnd:    req.EpitopesBenchmarkEnd,
+		GTSAProfile:             req.GTSAProfile,
+		FXProfile:               req.FXProfile,
+		EpitopesProfile:         req.EpitopesProfile,
+		LLVMProfile:             req.LLVMProfile,
+		FlatlandScannerProfile:  req.FlatlandScannerProfile,
+		FlatlandScannerSpread:   cloneFloat64Ptr(req.FlatlandScannerSpread),
+		FlatlandScannerOffset:   cloneFloat64Ptr(req.FlatlandScannerOffset),
+		FlatlandLayoutRandomize: cloneBoolPtr(req.FlatlandLayoutRandomize),
+		FlatlandLayoutVariants:  cloneIntPtr(req.FlatlandLayoutVariants),
+		FlatlandForceLayout:     cloneIntPtr(req.FlatlandForceLayout),
+		FlatlandBenchmarkTrials: cloneIntPtr(req.FlatlandBenchmarkTrials),
+		FlatlandMaxAge:          cloneIntPtr(req.FlatlandMaxAge),
+		FlatlandForageGoal:      cloneIntPtr(req.FlatlandForageGoal),
+		ContinuePopulationID:    req.ContinuePopulationID,
+		InitFromChampions:       req.InitFromChampions,
+		InitChampionSources:     championSources,
+		ForkedFrom:              req.ForkedFrom,
+		ForkGeneration:          req.ForkGeneration,
+		SpecieIdentifier:        req.SpecieIdentifier,
+		InitialGeneration:       initialGeneration,
+		PopulationSize:          req.Population,
+		Generations:             req.Generations,
+		SurvivalPercentage:      req.SurvivalPercentage,
+		SpecieSizeLimit:         req.SpecieSizeLimit,
+		FitnessGoal:             req.FitnessGoal,
+		EvaluationsLimit:        req.EvaluationsLimit,
+		TraceStepSize:           req.TraceStepSize,
+		StartPaused:             req.StartPaused,
+		AutoContinueAfterMS:     req.AutoContinueAfter.Milliseconds(),
+		Seed:                    req.Seed,
+		SelectionSeed:           cloneInt64Ptr(req.SelectionSeed),
+		MutationSeed:            cloneInt64Ptr(req.MutationSeed),
+		EnvSeed:                 cloneInt64Ptr(req.EnvSeed),
+		Workers:                 req.Workers,
+		EvaluationTrials:        req.EvaluationTrials,
+		CITieBreak:              req.CITieBreak,
+		TrialAggregation:        req.TrialAggregation,
+		CVaRAlpha:               req.CVaRAlpha,
+		FitnessScaling:          req.FitnessScaling,
+		ScalingPressure:         req.ScalingPressure,
+		SpeciesAllocation:       req.SpeciesAllocation,
+		AllocationFloor:         req.AllocationFloor,
+		AllocationCeiling:       req.AllocationCeiling,
+		SpeciesSoftCap:          req.SpeciesSoftCap,
+		SpeciesCapHysteresis:    req.SpeciesCapHysteresis,
+		PopulationResize:        req.PopulationResize,
+		MinPopulation:           req.MinPopulation,
+		MaxPopulation:           req.MaxPopulation,
+		ResizeTargetSeconds:     req.ResizeTargetSeconds,
+		LowFidelity:             req.LowFidelity,
+		FinalistFraction:        req.FinalistFraction,
+		ActivationClamp:         req.ActivationClamp,
+		WeightClamp:             req.WeightClamp,
+		CrossoverRate:           req.CrossoverRate,
+		InterspeciesMating:      req.InterspeciesMating,
+		PruneDisabledAfter:      req.PruneDisabledAfter,
+		PruneDisabledProb:       req.PruneDisabledProb,
+		TrackInnovations:        req.TrackInnovations,
+		InitialSensorsMin:       req.InitialSensorsMin,
+		InitialSensorsMax:       req.InitialSensorsMax,
+		EvaluationTimeoutMS:     req.EvaluationTimeout.Milliseconds(),
+		KarmaStrikes:            req.KarmaStrikes,
+		KarmaCooldown:           req.KarmaCooldown,
+		ScapeSandbox:            req.ScapeSandbox,
+		SandboxCommand:          req.SandboxCommand,
+		SandboxCPUSeconds:       req.SandboxCPUSeconds,
+		SandboxMemoryMB:         req.SandboxMemoryMB,
+		SandboxTimeoutMS:        req.SandboxTimeout.Milliseconds(),
+		SandboxFailureFitness:   req.SandboxFailureFitness,
+		EliteCount:              eliteCount,
+		ParityStrict:            req.ParityStrict,
+		Selection:               req.Selection,
+		TournamentSize:          req.TournamentSize,
+		TournamentNoReplace:     req.TournamentNoReplace,
+		TournamentWinProb:       req.TournamentWinProb,
+		FitnessPostprocessor:    req.FitnessPostprocessor,
+		TopologicalPolicy:       req.TopologicalPolicy,
+		TopologicalCount:        req.TopologicalCount,
+		TopologicalParam:        req.TopologicalParam,
+		TopologicalMax:          req.TopologicalMax,
+		TuningEnabled:           req.EnableTuning,
+		ValidationProbe:         req.ValidationProbe,
+		TestProbe:               req.TestProbe,
+		TuneSelection:           req.TuneSelection,
+		TuneDurationPolicy:      req.TuneDurationPolicy,
+		TuneDurationParam:       req.TuneDurationParam,
+		TuneAttempts:            req.TuneAttempts,
+		TuneSteps:               req.TuneSteps,
+		TuneStepSize:            req.TuneStepSize,
+		TunePerturbationRange:   req.TunePerturbationRange,
+		TuneAnnealingFactor:     req.TuneAnnealingFactor,
+		TuneMinImprovement:      req.TuneMinImprovement,
+		TuningTrace:             req.TuningTrace,
+		TuneAcceptance:          req.TuneAcceptance,
+		WeightPerturb:           req.WeightPerturb,
+		WeightBias:              req.WeightBias,
+		WeightRemoveBias:        req.WeightRemoveBias,
+		WeightActivation:        req.WeightActivation,
+		WeightAggregator:        req.WeightAggregator,
+		WeightAddSynapse:        req.WeightAddSynapse,
+		WeightRemoveSynapse:     req.WeightRemoveSynapse,
+		WeightAddNeuron:         req.WeightAddNeuron,
+		WeightRemoveNeuron:      req.WeightRemoveNeuron,
+		WeightPlasticityRule:    req.WeightPlasticityRule,
+		WeightPlasticity:        req.WeightPlasticity,
+		WeightSubstrate:         req.WeightSubstrate,
+		ScapeParams:             cloneStringMap(req.ScapeParams),
+		StopCondition:           req.StopCondition,
+		EntropyThreshold:        req.EntropyThreshold,
+		EntropyMeasure:          req.EntropyMeasure,
+		EntropyAction:           req.EntropyAction,
+		EntropyCooldown:         req.EntropyCooldown,
+		ReproductionInterval:    req.ReproductionInterval,
+		DiagnosticsWindow:       req.DiagnosticsWindow,
+		Islands:                 req.Islands,
+		MigrationInterval:       req.MigrationInterval,
+		Migrants:                req.Migrants,
+		CheckpointInterval:      req.CheckpointInterval,
+		SnapshotInterval:        req.SnapshotInterval,
+		WeightRecurrentLoop:     req.WeightRecurrentLoop,
+		RecurrentLoopMaxLength:  req.RecurrentLoopMaxLength,
+		WeightDuplicateNeuron:   req.WeightDuplicateNeuron,
+		WeightToggleSynapse:     req.WeightToggleSynapse,
+		WeightInsertModule:      req.WeightInsertModule,
+		Modules:                 append([]string(nil), req.Modules...),
+		WeightAllBiases:         req.WeightAllBiases,
+		BiasMaxDelta:            req.BiasMaxDelta,
+	}
+}
+
+// resolveRunID is the run id of req: the requested one, the continued
+// population's, or one derived from the scape, seed and start time.
+func resolveRunID(req RunRequest, now time.Time) string {
+	if req.RunID != "" {
+		return req.RunID
 	}
-	if err := p.RegisterScape(scape.EpitopesScape{}); err != nil {
-		return err
+	if req.ContinuePopulationID != "" {
+		return req.ContinuePopulationID
 	}
-	if err := p.RegisterScape(scape.LLVMPhaseOrderingScape{}); err != nil {
-		return err
+	return fmt.Sprintf("%s-%d-%d", req.Scape, req.Seed, now.Unix())
+}
+
+// runEliteCount is the number of elites a run of req carries over each
+// generation; survival-percentage selection replaces elitism.
+func runEliteCount(req RunRequest) int {
+	if req.SurvivalPercentage > 0 {
+		return 0
 	}
-	return nil
+	return max(1, req.Population/5)
 }
 
 func materializeRunConfigFromRequest(req RunRequest) (materializedRunConfig, error) {
@@ -1883,14 +3144,40 @@ func materializeRunConfigFromRequest(req RunRequest) (materializedRunConfig, err
 		req.EvolutionType = evo.EvolutionTypeGenerational
 	}
 	switch req.EvolutionType {
-	case evo.EvolutionTypeGenerational, evo.EvolutionTypeSteadyState:
+	case evo.EvolutionTypeGenerational, evo.EvolutionTypeSteadyState, evo.EvolutionTypeOnline:
+	default:
+		return materializedRunConfig{}, errors.New("evolution type must be one of generational|steady_state|online")
+	}
+	req.Encoding = strings.ToLower(strings.TrimSpace(req.Encoding))
+	genomeEncoding, err := encoding.Lookup(req.Encoding)
+	if err != nil {
+		return materializedRunConfig{}, err
+	}
+	if !genomeEncoding.Direct() && (req.EnableTuning || req.CompareTuning) {
+		return materializedRunConfig{}, fmt.Errorf("weight tuning is not supported with the %s encoding", genomeEncoding.Name())
+	}
+	req.Algorithm = strings.ToLower(strings.TrimSpace(req.Algorithm))
+	switch req.Algorithm {
+	case "":
+		req.Algorithm = AlgorithmNeuroevolution
+	case AlgorithmNeuroevolution, AlgorithmRandomSearch, AlgorithmHillClimb:
+	case AlgorithmES:
+		if !genomeEncoding.Direct() {
+			return materializedRunConfig{}, fmt.Errorf("the es algorithm requires a direct encoding, got %s", genomeEncoding.Name())
+		}
 	default:
-		return materializedRunConfig{}, errors.New("evolution type must be one of generational|steady_state")
+		return materializedRunConfig{}, errors.New("algorithm must be one of neuroevolution|random_search|hill_climb|es")
+	}
+	if req.Algorithm != AlgorithmNeuroevolution && (req.EnableTuning || req.CompareTuning) {
+		return materializedRunConfig{}, fmt.Errorf("weight tuning is not supported with the %s algorithm", req.Algorithm)
 	}
 	if req.Scape == "" {
 		req.Scape = "xor"
 	}
 	req.Scape = scapeid.Normalize(req.Scape)
+	if _, _, err := buildRunScape(req); err != nil {
+		return materializedRunConfig{}, err
+	}
 	if req.GTSATrainEnd < 0 {
 		return materializedRunConfig{}, errors.New("gtsa train end must be >= 0")
 	}
@@ -1938,7 +3225,8 @@ func materializeRunConfigFromRequest(req RunRequest) (materializedRunConfig, err
 	if req.Generations < 0 {
 		return materializedRunConfig{}, errors.New("generations must be >= 0")
 	}
-	if req.Generations == 0 {
+	// Online runs count diagnostics windows and may run without a bound.
+	if req.Generations == 0 && req.EvolutionType != evo.EvolutionTypeOnline {
 		req.Generations = 100
 	}
 	if req.SurvivalPercentage < 0 || req.SurvivalPercentage > 1 {
@@ -1962,12 +3250,238 @@ func materializeRunConfigFromRequest(req RunRequest) (materializedRunConfig, err
 	if req.AutoContinueAfter < 0 {
 		return materializedRunConfig{}, errors.New("auto continue after must be >= 0")
 	}
+	if req.EvaluationTrials < 0 {
+		return materializedRunConfig{}, errors.New("evaluation trials must be >= 0")
+	}
+	if req.RegressionTolerance < 0 || math.IsNaN(req.RegressionTolerance) {
+		return materializedRunConfig{}, errors.New("regression tolerance must be >= 0")
+	}
+	if req.EvaluationTrials == 0 {
+		req.EvaluationTrials = 1
+	}
+	req.TrialAggregation = strings.ToLower(strings.TrimSpace(req.TrialAggregation))
+	if req.TrialAggregation == "" {
+		req.TrialAggregation = evo.TrialAggregationMean
+	}
+	if req.TrialAggregation == evo.TrialAggregationCVaR && req.CVaRAlpha == 0 {
+		req.CVaRAlpha = 0.1
+	}
+	if _, err := evo.AggregateTrialFitness([]float64{0}, req.TrialAggregation, req.CVaRAlpha); err != nil {
+		return materializedRunConfig{}, err
+	}
+	req.FitnessScaling = strings.ToLower(strings.TrimSpace(req.FitnessScaling))
+	if req.FitnessScaling == "" {
+		req.FitnessScaling = evo.FitnessScalingNone
+	}
+	if req.ScalingPressure == 0 {
+		req.ScalingPressure = evo.DefaultScalingPressure(req.FitnessScaling)
+	}
+	if err := evo.ValidateFitnessScaling(req.FitnessScaling, req.ScalingPressure); err != nil {
+		return materializedRunConfig{}, err
+	}
+	req.SpeciesAllocation = strings.ToLower(strings.TrimSpace(req.SpeciesAllocation))
+	if req.SpeciesAllocation == "" {
+		req.SpeciesAllocation = evo.SpeciesAllocationProportional
+	}
+	if err := evo.ValidateSpeciesAllocation(req.SpeciesAllocation, req.AllocationFloor, req.AllocationCeiling); err != nil {
+		return materializedRunConfig{}, err
+	}
+	if err := evo.ValidateSpeciesSoftCap(req.SpeciesSoftCap, req.SpeciesCapHysteresis); err != nil {
+		return materializedRunConfig{}, err
+	}
+	req.PopulationResize = strings.ToLower(strings.TrimSpace(req.PopulationResize))
+	if req.PopulationResize == "" {
+		req.PopulationResize = evo.PopulationResizeOff
+	}
+	if err := evo.ValidatePopulationResize(req.PopulationResize, req.MinPopulation, req.MaxPopulation, req.ResizeTargetSeconds); err != nil {
+		return materializedRunConfig{}, err
+	}
+	if req.LowFidelity < 0 || req.LowFidelity >= 1 {
+		return materializedRunConfig{}, fmt.Errorf("low fidelity must be in [0, 1), got %f", req.LowFidelity)
+	}
+	if req.LowFidelity > 0 && req.EvolutionType == evo.EvolutionTypeOnline {
+		return materializedRunConfig{}, errors.New("multi-fidelity evaluation is not supported with online evolution")
+	}
+	if req.LowFidelity > 0 {
+		if req.FinalistFraction == 0 {
+			req.FinalistFraction = 0.25
+		}
+		if req.FinalistFraction < 0 || req.FinalistFraction > 1 {
+			return materializedRunConfig{}, fmt.Errorf("finalist fraction must be in (0, 1], got %f", req.FinalistFraction)
+		}
+	} else {
+		req.FinalistFraction = 0
+	}
+	if req.ActivationClamp < 0 || math.IsNaN(req.ActivationClamp) || math.IsInf(req.ActivationClamp, 0) {
+		return materializedRunConfig{}, fmt.Errorf("activation clamp must be a finite value >= 0, got %f", req.ActivationClamp)
+	}
+	if req.WeightClamp < 0 || math.IsNaN(req.WeightClamp) || math.IsInf(req.WeightClamp, 0) {
+		return materializedRunConfig{}, fmt.Errorf("weight clamp must be a finite value >= 0, got %f", req.WeightClamp)
+	}
+	if req.FitnessPostprocessor == "numeric_fragility" && req.ActivationClamp == 0 && req.WeightClamp == 0 {
+		return materializedRunConfig{}, errors.New("numeric_fragility postprocessor requires an activation or weight clamp")
+	}
+	if req.CrossoverRate < 0 || req.CrossoverRate > 1 || math.IsNaN(req.CrossoverRate) {
+		return materializedRunConfig{}, fmt.Errorf("crossover rate must be in [0,1], got %f", req.CrossoverRate)
+	}
+	if req.InterspeciesMating < 0 || req.InterspeciesMating > 1 || math.IsNaN(req.InterspeciesMating) {
+		return materializedRunConfig{}, fmt.Errorf("interspecies mating probability must be in [0,1], got %f", req.InterspeciesMating)
+	}
+	if req.InterspeciesMating > 0 && req.CrossoverRate == 0 {
+		return materializedRunConfig{}, errors.New("interspecies mating requires a crossover rate")
+	}
+	if err := evo.ValidateDisabledSynapsePruning(req.PruneDisabledAfter, req.PruneDisabledProb); err != nil {
+		return materializedRunConfig{}, err
+	}
+	if err := genotype.ValidateSensorSubset(req.InitialSensorsMin, req.InitialSensorsMax); err != nil {
+		return materializedRunConfig{}, err
+	}
+	if req.EvaluationTimeout < 0 {
+		return materializedRunConfig{}, errors.New("evaluation timeout must be >= 0")
+	}
+	if req.KarmaStrikes < 0 {
+		return materializedRunConfig{}, errors.New("karma strikes must be >= 0")
+	}
+	if req.InitFromChampions != "" {
+		if req.ContinuePopulationID != "" {
+			return materializedRunConfig{}, errors.New("init from champions cannot be combined with a continued population")
+		}
+		spec, err := ParseChampionSeedSpec(req.InitFromChampions, req.Scape)
+		if err != nil {
+			return materializedRunConfig{}, err
+		}
+		req.InitFromChampions = spec.String()
+	}
+	if req.BehaviorClone != nil {
+		if req.ContinuePopulationID != "" {
+			return materializedRunConfig{}, errors.New("behavior cloning cannot be combined with a continued population")
+		}
+		spec, err := normalizeBehaviorCloneSpec(*req.BehaviorClone)
+		if err != nil {
+			return materializedRunConfig{}, err
+		}
+		req.BehaviorClone = &spec
+	}
+	if !req.ScapeSandbox && (req.SandboxCommand != "" || req.SandboxCPUSeconds != 0 || req.SandboxMemoryMB != 0 || req.SandboxTimeout != 0 || req.SandboxFailureFitness != 0) {
+		return materializedRunConfig{}, errors.New("sandbox options require scape sandboxing to be enabled")
+	}
+	if req.ScapeSandbox && strings.TrimSpace(req.SandboxCommand) == "" {
+		return materializedRunConfig{}, errors.New("scape sandboxing requires a sandbox command")
+	}
+	if req.SandboxCPUSeconds < 0 {
+		return materializedRunConfig{}, errors.New("sandbox cpu seconds must be >= 0")
+	}
+	if req.SandboxMemoryMB < 0 {
+		return materializedRunConfig{}, errors.New("sandbox memory limit must be >= 0")
+	}
+	if req.SandboxTimeout < 0 {
+		return materializedRunConfig{}, errors.New("sandbox timeout must be >= 0")
+	}
+	if math.IsNaN(req.SandboxFailureFitness) || math.IsInf(req.SandboxFailureFitness, 0) {
+		return materializedRunConfig{}, errors.New("sandbox failure fitness must be finite")
+	}
+	if req.ScapeSandbox && hasFlatlandOverrideConfig(req) {
+		return materializedRunConfig{}, errors.New("flatland overrides cannot be used with scape sandboxing")
+	}
+	if req.KarmaCooldown < 0 {
+		return materializedRunConfig{}, errors.New("karma cooldown must be >= 0")
+	}
+	if req.KarmaCooldown > 0 && req.KarmaStrikes == 0 {
+		return materializedRunConfig{}, errors.New("karma cooldown requires karma strikes")
+	}
+	if strings.TrimSpace(req.StopCondition) != "" {
+		if _, err := evo.ParseStopCondition(req.StopCondition); err != nil {
+			return materializedRunConfig{}, err
+		}
+	}
+	if req.EntropyThreshold < 0 || req.EntropyThreshold > 1 {
+		return materializedRunConfig{}, fmt.Errorf("entropy threshold must be in [0, 1], got %g", req.EntropyThreshold)
+	}
+	if req.EntropyCooldown < 0 {
+		return materializedRunConfig{}, errors.New("entropy cooldown must be >= 0")
+	}
+	if req.ReproductionInterval < 0 {
+		return materializedRunConfig{}, errors.New("reproduction interval must be >= 0")
+	}
+	if req.DiagnosticsWindow < 0 {
+		return materializedRunConfig{}, errors.New("diagnostics window must be >= 0")
+	}
+	if req.EvolutionType != evo.EvolutionTypeOnline && (req.ReproductionInterval > 0 || req.DiagnosticsWindow > 0) {
+		return materializedRunConfig{}, errors.New("reproduction interval and diagnostics window require online evolution")
+	}
+	if req.Islands < 0 {
+		return materializedRunConfig{}, errors.New("islands must be >= 0")
+	}
+	if req.MigrationInterval < 0 || req.Migrants < 0 {
+		return materializedRunConfig{}, errors.New("migration interval and migrants must be >= 0")
+	}
+	if req.Islands <= 1 && (req.MigrationInterval > 0 || req.Migrants > 0) {
+		return materializedRunConfig{}, errors.New("migration interval and migrants require more than one island")
+	}
+	if req.CheckpointInterval < 0 {
+		return materializedRunConfig{}, errors.New("checkpoint interval must be >= 0")
+	}
+	if req.CheckpointInterval > 0 && (req.EvolutionType != evo.EvolutionTypeGenerational || req.Algorithm != AlgorithmNeuroevolution || req.CompareTuning) {
+		return materializedRunConfig{}, errors.New("checkpoints require generational neuroevolution without tuning comparison")
+	}
+	if req.SnapshotInterval < 0 {
+		return materializedRunConfig{}, errors.New("snapshot interval must be >= 0")
+	}
+	if req.SnapshotInterval > 0 && (req.EvolutionType != evo.EvolutionTypeGenerational || req.Algorithm != AlgorithmNeuroevolution || req.CompareTuning) {
+		return materializedRunConfig{}, errors.New("population snapshots require generational neuroevolution without tuning comparison")
+	}
+	if req.EvolutionType == evo.EvolutionTypeOnline && req.EntropyThreshold > 0 {
+		return materializedRunConfig{}, errors.New("entropy restarts are not supported with online evolution")
+	}
+	if req.EntropyThreshold == 0 && (req.EntropyMeasure != "" || req.EntropyAction != "" || req.EntropyCooldown > 0) {
+		return materializedRunConfig{}, errors.New("entropy measure, action and cooldown require an entropy threshold")
+	}
+	switch req.EntropyMeasure {
+	case "", evo.EntropyMeasureGenotype, evo.EntropyMeasureBehavior:
+	default:
+		return materializedRunConfig{}, fmt.Errorf("unsupported entropy measure: %s", req.EntropyMeasure)
+	}
+	switch req.EntropyAction {
+	case "", evo.EntropyActionMutation, evo.EntropyActionImmigrants, evo.EntropyActionRestart:
+	default:
+		return materializedRunConfig{}, fmt.Errorf("unsupported entropy action: %s", req.EntropyAction)
+	}
+	if req.WeightRecurrentLoop < 0 {
+		return materializedRunConfig{}, errors.New("recurrent loop weight must be >= 0")
+	}
+	if req.RecurrentLoopMaxLength < 0 {
+		return materializedRunConfig{}, errors.New("recurrent loop max length must be >= 0")
+	}
+	if req.WeightDuplicateNeuron < 0 {
+		return materializedRunConfig{}, errors.New("duplicate neuron weight must be >= 0")
+	}
+	if req.WeightToggleSynapse < 0 {
+		return materializedRunConfig{}, errors.New("toggle synapse weight must be >= 0")
+	}
+	if req.WeightInsertModule < 0 {
+		return materializedRunConfig{}, errors.New("insert module weight must be >= 0")
+	}
+	if len(req.Modules) > 0 && req.WeightInsertModule == 0 {
+		return materializedRunConfig{}, errors.New("modules require an insert module weight > 0")
+	}
+	if req.WeightAllBiases < 0 {
+		return materializedRunConfig{}, errors.New("all biases weight must be >= 0")
+	}
+	if req.BiasMaxDelta < 0 {
+		return materializedRunConfig{}, errors.New("bias max delta must be >= 0")
+	}
+	if req.BiasMaxDelta == 0 {
+		req.BiasMaxDelta = defaultBiasMaxDelta
+	}
 	if req.Workers < 0 {
 		return materializedRunConfig{}, errors.New("workers must be >= 0")
 	}
 	if req.Workers == 0 {
 		req.Workers = 4
 	}
+	if req.ParityStrict {
+		req = applyParityStrictDefaults(req)
+	}
 	if req.Selection == "" {
 		req.Selection = "elite"
 	}
@@ -2039,6 +3553,14 @@ func materializeRunConfigFromRequest(req RunRequest) (materializedRunConfig, err
 	if req.TuneMinImprovement < 0 {
 		return materializedRunConfig{}, errors.New("tune min improvement must be >= 0")
 	}
+	if req.TuningTrace && !req.EnableTuning {
+		return materializedRunConfig{}, errors.New("tuning trace requires tuning to be enabled")
+	}
+	acceptance, ok := tuning.NormalizeTuneAcceptanceName(req.TuneAcceptance)
+	if !ok {
+		return materializedRunConfig{}, fmt.Errorf("unsupported tune acceptance: %s (want one of %s)", req.TuneAcceptance, strings.Join(tuning.TuneAcceptanceNames, ", "))
+	}
+	req.TuneAcceptance = acceptance
 	if req.WeightPerturb == 0 && req.WeightBias == 0 && req.WeightRemoveBias == 0 && req.WeightActivation == 0 && req.WeightAggregator == 0 && req.WeightAddSynapse == 0 && req.WeightRemoveSynapse == 0 && req.WeightAddNeuron == 0 && req.WeightRemoveNeuron == 0 && req.WeightPlasticityRule == 0 && req.WeightPlasticity == 0 && req.WeightSubstrate == 0 {
 		req.WeightPerturb = 0.70
 		req.WeightBias = 0.00
@@ -2068,10 +3590,25 @@ func materializeRunConfigFromRequest(req RunRequest) (materializedRunConfig, err
 		return materializedRunConfig{}, err
 	}
 
-	selector, err := selectionFromName(req.Selection, specieIdentifier)
+	selector, err := selectionFromName(req.Selection, specieIdentifier, tournamentOptions{
+		Size:               req.TournamentSize,
+		WithoutReplacement: req.TournamentNoReplace,
+		WinProbability:     req.TournamentWinProb,
+	})
 	if err != nil {
 		return materializedRunConfig{}, err
 	}
+	if _, ok := selector.(evo.AFPOSelector); ok && req.EvolutionType != evo.EvolutionTypeGenerational {
+		return materializedRunConfig{}, errors.New("afpo selection requires generational evolution")
+	}
+	if req.CrossoverRate > 0 {
+		if req.EvolutionType != evo.EvolutionTypeGenerational && req.EvolutionType != evo.EvolutionTypeSteadyState {
+			return materializedRunConfig{}, errors.New("crossover requires generational or steady_state evolution")
+		}
+		if _, ok := selector.(evo.AFPOSelector); ok {
+			return materializedRunConfig{}, errors.New("crossover is not supported with afpo selection")
+		}
+	}
 	postprocessor, err := postprocessorFromName(req.FitnessPostprocessor)
 	if err != nil {
 		return materializedRunConfig{}, err
@@ -2088,6 +3625,11 @@ func materializeRunConfigFromRequest(req RunRequest) (materializedRunConfig, err
 			return materializedRunConfig{}, err
 		}
 	}
+	if req.ParityStrict {
+		if err := checkParityStrict(req); err != nil {
+			return materializedRunConfig{}, err
+		}
+	}
 
 	return materializedRunConfig{
 		Request:           req,
@@ -2096,6 +3638,7 @@ func materializeRunConfigFromRequest(req RunRequest) (materializedRunConfig, err
 		TopologicalPolicy: topologicalPolicy,
 		TuneAttemptPolicy: attemptPolicy,
 		SpeciationMode:    speciationModeFromIdentifier(req.SpecieIdentifier),
+		Encoding:          genomeEncoding,
 	}, nil
 }
 
@@ -2205,6 +3748,25 @@ func cloneBoolPtr(v *bool) *bool {
 	return &out
 }
 
+func cloneStringMap(v map[string]string) map[string]string {
+	if v == nil {
+		return nil
+	}
+	out := make(map[string]string, len(v))
+	for key, value := range v {
+		out[key] = value
+	}
+	return out
+}
+
+func cloneInt64Ptr(v *int64) *int64 {
+	if v == nil {
+		return nil
+	}
+	out := *v
+	return &out
+}
+
 func cloneIntPtr(v *int) *int {
 	if v == nil {
 		return nil
@@ -2213,7 +3775,44 @@ func cloneIntPtr(v *int) *int {
 	return &out
 }
 
-func defaultMutationPolicy(seed int64, scapeName string, inputNeuronIDs, outputNeuronIDs []string, req RunRequest) []evo.WeightedMutation {
+func runMutationSeed(req RunRequest) int64 {
+	if req.MutationSeed != nil {
+		return *req.MutationSeed
+	}
+	return req.Seed
+}
+
+// runMutationOperators builds a run's fallback mutation and weighted policy
+// with fresh rngs from rands, which may be nil. Lineage replay and resumed
+// runs rely on getting the same streams back.
+func runMutationOperators(req RunRequest, inputNeuronIDs, outputNeuronIDs []string, modules []model.Module, rands *evo.RandStreams) (evo.Operator, []evo.WeightedMutation) {
+	seed := runMutationSeed(req)
+	if req.Encoding == encoding.Grammar {
+		mutation := &evo.PerturbGrammarCell{Rand: rands.New(seed + 1000), MaxDelta: 1.0}
+		return mutation, grammarMutationPolicy(seed, rands)
+	}
+	mutation := &evo.PerturbWeightsProportional{Rand: rands.New(seed + 1000), MaxDelta: 1.0}
+	return mutation, defaultMutationPolicy(seed, req.Scape, inputNeuronIDs, outputNeuronIDs, req, modules, rands)
+}
+
+// grammarMutationPolicy is the operator set of grammar-encoded runs, which
+// evolve production rules and cells instead of neurons and synapses.
+func grammarMutationPolicy(seed int64, rands *evo.RandStreams) []evo.WeightedMutation {
+	return []evo.WeightedMutation{
+		{Operator: &evo.PerturbGrammarCell{Rand: rands.New(seed + 1100), MaxDelta: 1.0}, Weight: 0.45},
+		{Operator: &evo.MutateGrammarRule{Rand: rands.New(seed + 1101)}, Weight: 0.20},
+		{Operator: &evo.AddGrammarSymbol{Rand: rands.New(seed + 1102)}, Weight: 0.10},
+		{Operator: &evo.RemoveGrammarSymbol{Rand: rands.New(seed + 1103)}, Weight: 0.05},
+		{Operator: &evo.MutateGrammarIterations{Rand: rands.New(seed + 1104)}, Weight: 0.10},
+		{Operator: &evo.MutateGrammarActivation{Rand: rands.New(seed + 1105)}, Weight: 0.10},
+	}
+}
+
+func defaultMutationPolicy(seed int64, scapeName string, inputNeuronIDs, outputNeuronIDs []string, req RunRequest, modules []model.Module, rands *evo.RandStreams) []evo.WeightedMutation {
+	biasMaxDelta := req.BiasMaxDelta
+	if biasMaxDelta <= 0 {
+		biasMaxDelta = defaultBiasMaxDelta
+	}
 	protected := make(map[string]struct{}, len(inputNeuronIDs)+len(outputNeuronIDs))
 	for _, id := range inputNeuronIDs {
 		protected[id] = struct{}{}
@@ -2222,69 +3821,133 @@ func defaultMutationPolicy(seed int64, scapeName string, inputNeuronIDs, outputN
 		protected[id] = struct{}{}
 	}
 
-	return []evo.WeightedMutation{
-		{Operator: &evo.MutateWeights{Rand: rand.New(rand.NewSource(seed + 1000)), MaxDelta: 1.0}, Weight: req.WeightPerturb},
-		{Operator: &evo.AddBias{Rand: rand.New(rand.NewSource(seed + 1007)), MaxDelta: 0.3}, Weight: req.WeightBias},
-		{Operator: &evo.RemoveBias{Rand: rand.New(rand.NewSource(seed + 1010))}, Weight: req.WeightRemoveBias},
-		{Operator: &evo.MutateAF{Rand: rand.New(rand.NewSource(seed + 1008))}, Weight: req.WeightActivation},
-		{Operator: &evo.MutateAggrF{Rand: rand.New(rand.NewSource(seed + 1009))}, Weight: req.WeightAggregator},
-		{Operator: &evo.AddRandomInlink{Rand: rand.New(rand.NewSource(seed + 1001)), MaxAbsWeight: 1.0, InputNeuronIDs: inputNeuronIDs, FeedForwardOnly: true}, Weight: req.WeightAddSynapse / 2},
-		{Operator: &evo.AddRandomOutlink{Rand: rand.New(rand.NewSource(seed + 1002)), MaxAbsWeight: 1.0, OutputNeuronIDs: outputNeuronIDs, FeedForwardOnly: true}, Weight: req.WeightAddSynapse / 2},
-		{Operator: &evo.RemoveRandomInlink{Rand: rand.New(rand.NewSource(seed + 1003)), InputNeuronIDs: inputNeuronIDs, FeedForwardOnly: true}, Weight: req.WeightRemoveSynapse / 3},
-		{Operator: &evo.RemoveRandomOutlink{Rand: rand.New(rand.NewSource(seed + 1004)), OutputNeuronIDs: outputNeuronIDs, FeedForwardOnly: true}, Weight: req.WeightRemoveSynapse / 3},
-		{Operator: &evo.CutlinkFromNeuronToNeuron{Rand: rand.New(rand.NewSource(seed + 1005))}, Weight: req.WeightRemoveSynapse / 3},
-		{Operator: &evo.AddNeuron{Rand: rand.New(rand.NewSource(seed + 1005))}, Weight: req.WeightAddNeuron * 0.40},
-		{Operator: &evo.AddRandomOutsplice{Rand: rand.New(rand.NewSource(seed + 1006)), OutputNeuronIDs: outputNeuronIDs, FeedForwardOnly: true}, Weight: req.WeightAddNeuron * 0.30},
-		{Operator: &evo.AddRandomInsplice{Rand: rand.New(rand.NewSource(seed + 1007)), InputNeuronIDs: inputNeuronIDs, FeedForwardOnly: true}, Weight: req.WeightAddNeuron * 0.30},
-		{Operator: &evo.RemoveNeuronMutation{Rand: rand.New(rand.NewSource(seed + 1020)), Protected: protected}, Weight: req.WeightRemoveNeuron},
-		{Operator: &evo.MutatePF{Rand: rand.New(rand.NewSource(seed + 1021))}, Weight: req.WeightPlasticityRule},
-		{Operator: &evo.MutatePlasticityParameters{Rand: rand.New(rand.NewSource(seed + 1022)), MaxDelta: 0.15}, Weight: req.WeightPlasticity},
-		{Operator: &evo.AddRandomSensor{Rand: rand.New(rand.NewSource(seed + 1008)), ScapeName: scapeName}, Weight: req.WeightSubstrate * 0.07},
-		{Operator: &evo.AddRandomSensorLink{Rand: rand.New(rand.NewSource(seed + 1009)), ScapeName: scapeName}, Weight: req.WeightSubstrate * 0.07},
-		{Operator: &evo.AddRandomActuator{Rand: rand.New(rand.NewSource(seed + 1010)), ScapeName: scapeName}, Weight: req.WeightSubstrate * 0.07},
-		{Operator: &evo.AddRandomActuatorLink{Rand: rand.New(rand.NewSource(seed + 1011)), ScapeName: scapeName}, Weight: req.WeightSubstrate * 0.07},
-		{Operator: &evo.RemoveRandomSensor{Rand: rand.New(rand.NewSource(seed + 1012))}, Weight: req.WeightSubstrate * 0.06},
-		{Operator: &evo.CutlinkFromSensorToNeuron{Rand: rand.New(rand.NewSource(seed + 1013))}, Weight: req.WeightSubstrate * 0.06},
-		{Operator: &evo.RemoveRandomActuator{Rand: rand.New(rand.NewSource(seed + 1014))}, Weight: req.WeightSubstrate * 0.06},
-		{Operator: &evo.CutlinkFromNeuronToActuator{Rand: rand.New(rand.NewSource(seed + 1015))}, Weight: req.WeightSubstrate * 0.06},
-		{Operator: &evo.AddRandomCPP{Rand: rand.New(rand.NewSource(seed + 1016))}, Weight: req.WeightSubstrate * 0.05},
+	policy := []evo.WeightedMutation{
+		{Operator: &evo.MutateWeights{Rand: rands.New(seed + 1000), MaxDelta: 1.0}, Weight: req.WeightPerturb},
+		{Operator: &evo.AddBias{Rand: rands.New(seed + 1007), MaxDelta: biasMaxDelta}, Weight: req.WeightBias},
+		{Operator: &evo.RemoveBias{Rand: rands.New(seed + 1010)}, Weight: req.WeightRemoveBias},
+		{Operator: &evo.MutateAF{Rand: rands.New(seed + 1008)}, Weight: req.WeightActivation},
+		{Operator: &evo.MutateAggrF{Rand: rands.New(seed + 1009)}, Weight: req.WeightAggregator},
+		{Operator: &evo.AddRandomInlink{Rand: rands.New(seed + 1001), MaxAbsWeight: 1.0, InputNeuronIDs: inputNeuronIDs, FeedForwardOnly: true}, Weight: req.WeightAddSynapse / 2},
+		{Operator: &evo.AddRandomOutlink{Rand: rands.New(seed + 1002), MaxAbsWeight: 1.0, OutputNeuronIDs: outputNeuronIDs, FeedForwardOnly: true}, Weight: req.WeightAddSynapse / 2},
+		{Operator: &evo.RemoveRandomInlink{Rand: rands.New(seed + 1003), InputNeuronIDs: inputNeuronIDs, FeedForwardOnly: true}, Weight: req.WeightRemoveSynapse / 3},
+		{Operator: &evo.RemoveRandomOutlink{Rand: rands.New(seed + 1004), OutputNeuronIDs: outputNeuronIDs, FeedForwardOnly: true}, Weight: req.WeightRemoveSynapse / 3},
+		{Operator: &evo.CutlinkFromNeuronToNeuron{Rand: rands.New(seed + 1005)}, Weight: req.WeightRemoveSynapse / 3},
+		{Operator: &evo.AddNeuron{Rand: rands.New(seed + 1005)}, Weight: req.WeightAddNeuron * 0.40},
+		{Operator: &evo.AddRandomOutsplice{Rand: rands.New(seed + 1006), OutputNeuronIDs: outputNeuronIDs, FeedForwardOnly: true}, Weight: req.WeightAddNeuron * 0.30},
+		{Operator: &evo.AddRandomInsplice{Rand: rands.New(seed + 1007), InputNeuronIDs: inputNeuronIDs, FeedForwardOnly: true}, Weight: req.WeightAddNeuron * 0.30},
+		{Operator: &evo.RemoveNeuronMutation{Rand: rands.New(seed + 1020), Protected: protected}, Weight: req.WeightRemoveNeuron},
+		{Operator: &evo.MutatePF{Rand: rands.New(seed + 1021)}, Weight: req.WeightPlasticityRule},
+		{Operator: &evo.MutatePlasticityParameters{Rand: rands.New(seed + 1022), MaxDelta: 0.15}, Weight: req.WeightPlasticity},
+		{Operator: &evo.AddRandomSensor{Rand: rands.New(seed + 1008), ScapeName: scapeName}, Weight: req.WeightSubstrate * 0.07},
+		{Operator: &evo.AddRandomSensorLink{Rand: rands.New(seed + 1009), ScapeName: scapeName}, Weight: req.WeightSubstrate * 0.07},
+		{Operator: &evo.AddRandomActuator{Rand: rands.New(seed + 1010), ScapeName: scapeName}, Weight: req.WeightSubstrate * 0.07},
+		{Operator: &evo.AddRandomActuatorLink{Rand: rands.New(seed + 1011), ScapeName: scapeName}, Weight: req.WeightSubstrate * 0.07},
+		{Operator: &evo.RemoveRandomSensor{Rand: rands.New(seed + 1012)}, Weight: req.WeightSubstrate * 0.06},
+		{Operator: &evo.CutlinkFromSensorToNeuron{Rand: rands.New(seed + 1013)}, Weight: req.WeightSubstrate * 0.06},
+		{Operator: &evo.RemoveRandomActuator{Rand: rands.New(seed + 1014)}, Weight: req.WeightSubstrate * 0.06},
+		{Operator: &evo.CutlinkFromNeuronToActuator{Rand: rands.New(seed + 1015)}, Weight: req.WeightSubstrate * 0.06},
+		{Operator: &evo.AddRandomCPP{Rand: rands.New(seed + 1016)}, Weight: req.WeightSubstrate * 0.05},
 		{Operator: &evo.RemoveRandomCPP{}, Weight: req.WeightSubstrate * 0.03},
-		{Operator: &evo.AddRandomCEP{Rand: rand.New(rand.NewSource(seed + 1017))}, Weight: req.WeightSubstrate * 0.05},
+		{Operator: &evo.AddRandomCEP{Rand: rands.New(seed + 1017)}, Weight: req.WeightSubstrate * 0.05},
 		{Operator: &evo.RemoveRandomCEP{}, Weight: req.WeightSubstrate * 0.03},
-		{Operator: &evo.AddCircuitNode{Rand: rand.New(rand.NewSource(seed + 1018))}, Weight: req.WeightSubstrate * 0.05},
-		{Operator: &evo.DeleteCircuitNode{Rand: rand.New(rand.NewSource(seed + 1019))}, Weight: req.WeightSubstrate * 0.05},
-		{Operator: &evo.AddCircuitLayer{Rand: rand.New(rand.NewSource(seed + 1020))}, Weight: req.WeightSubstrate * 0.05},
-		{Operator: &evo.PerturbSubstrateParameter{Rand: rand.New(rand.NewSource(seed + 1021)), MaxDelta: 0.15}, Weight: req.WeightSubstrate * 0.05},
-		{Operator: &evo.MutateTuningSelection{Rand: rand.New(rand.NewSource(seed + 1022))}, Weight: req.WeightSubstrate * 0.03},
-		{Operator: &evo.MutateTuningAnnealing{Rand: rand.New(rand.NewSource(seed + 1023))}, Weight: req.WeightSubstrate * 0.03},
-		{Operator: &evo.MutateTotTopologicalMutations{Rand: rand.New(rand.NewSource(seed + 1024))}, Weight: req.WeightSubstrate * 0.03},
-		{Operator: &evo.MutateHeredityType{Rand: rand.New(rand.NewSource(seed + 1025))}, Weight: req.WeightSubstrate * 0.03},
+		{Operator: &evo.AddCircuitNode{Rand: rands.New(seed + 1018)}, Weight: req.WeightSubstrate * 0.05},
+		{Operator: &evo.DeleteCircuitNode{Rand: rands.New(seed + 1019)}, Weight: req.WeightSubstrate * 0.05},
+		{Operator: &evo.AddCircuitLayer{Rand: rands.New(seed + 1020)}, Weight: req.WeightSubstrate * 0.05},
+		{Operator: &evo.PerturbSubstrateParameter{Rand: rands.New(seed + 1021), MaxDelta: 0.15}, Weight: req.WeightSubstrate * 0.05},
+		{Operator: &evo.MutateTuningSelection{Rand: rands.New(seed + 1022)}, Weight: req.WeightSubstrate * 0.03},
+		{Operator: &evo.MutateTuningAnnealing{Rand: rands.New(seed + 1023)}, Weight: req.WeightSubstrate * 0.03},
+		{Operator: &evo.MutateTotTopologicalMutations{Rand: rands.New(seed + 1024)}, Weight: req.WeightSubstrate * 0.03},
+		{Operator: &evo.MutateHeredityType{Rand: rands.New(seed + 1025)}, Weight: req.WeightSubstrate * 0.03},
+	}
+	// Recurrence, duplication, module grafting and whole-vector bias search
+	// are opt-in: these operators only join the policy when weighted, so
+	// existing runs keep their operator set and rng streams.
+	if req.WeightRecurrentLoop > 0 {
+		policy = append(policy, evo.WeightedMutation{
+			Operator: &evo.AddRecurrentLoop{Rand: rands.New(seed + 1026), MaxAbsWeight: 1.0, MaxCycleLength: req.RecurrentLoopMaxLength, InputNeuronIDs: inputNeuronIDs},
+			Weight:   req.WeightRecurrentLoop,
+		})
+	}
+	if req.WeightDuplicateNeuron > 0 {
+		policy = append(policy, evo.WeightedMutation{
+			Operator: &evo.DuplicateRandomNeuron{Rand: rands.New(seed + 1027), MaxJitter: 0.1, Protected: protected},
+			Weight:   req.WeightDuplicateNeuron,
+		})
+	}
+	if req.WeightInsertModule > 0 {
+		policy = append(policy, evo.WeightedMutation{
+			Operator: &evo.InsertModule{Rand: rands.New(seed + 1028), Library: modules, MaxAbsWeight: 1.0, InputNeuronIDs: inputNeuronIDs, OutputNeuronIDs: outputNeuronIDs},
+			Weight:   req.WeightInsertModule,
+		})
+	}
+	if req.WeightAllBiases > 0 {
+		policy = append(policy, evo.WeightedMutation{
+			Operator: &evo.PerturbAllBiases{Rand: rands.New(seed + 1029), MaxDelta: biasMaxDelta},
+			Weight:   req.WeightAllBiases,
+		})
 	}
+	if req.WeightToggleSynapse > 0 {
+		policy = append(policy, evo.WeightedMutation{
+			Operator: &evo.ToggleRandomSynapseEnabled{Rand: rands.New(seed + 1030)},
+			Weight:   req.WeightToggleSynapse,
+		})
+	}
+	return policy
+}
+
+// tournamentOptions configures the tournament-based selection strategies.
+type tournamentOptions struct {
+	Size               int
+	WithoutReplacement bool
+	WinProbability     float64
 }
 
-func selectionFromName(name string, specieIdentifier evo.SpecieIdentifier) (evo.Selector, error) {
+func selectionFromName(name string, specieIdentifier evo.SpecieIdentifier, tournament tournamentOptions) (evo.Selector, error) {
+	if tournament.Size < 0 {
+		return nil, fmt.Errorf("tournament size must be >= 0, got %d", tournament.Size)
+	}
+	if tournament.Size == 0 {
+		tournament.Size = 3
+	}
+	if tournament.WinProbability < 0 || tournament.WinProbability > 1 {
+		return nil, fmt.Errorf("tournament win probability must be in [0,1], got %g", tournament.WinProbability)
+	}
+	if tournament.WinProbability == 0 {
+		tournament.WinProbability = 1
+	}
 	switch name {
 	case "elite":
 		return evo.EliteSelector{}, nil
 	case "tournament":
-		return evo.TournamentSelector{PoolSize: 0, TournamentSize: 3}, nil
+		return evo.TournamentSelector{
+			PoolSize:           0,
+			TournamentSize:     tournament.Size,
+			WithoutReplacement: tournament.WithoutReplacement,
+			WinProbability:     tournament.WinProbability,
+		}, nil
 	case "species_tournament":
 		return evo.SpeciesTournamentSelector{
-			Identifier:     specieIdentifier,
-			PoolSize:       0,
-			TournamentSize: 3,
+			Identifier:         specieIdentifier,
+			PoolSize:           0,
+			TournamentSize:     tournament.Size,
+			WithoutReplacement: tournament.WithoutReplacement,
+			WinProbability:     tournament.WinProbability,
 		}, nil
-	case "species_shared_tournament":
+	case "species_shared_tournament", "competition":
 		return &evo.SpeciesSharedTournamentSelector{
-			Identifier:     specieIdentifier,
-			PoolSize:       0,
-			TournamentSize: 3,
+			Identifier:         specieIdentifier,
+			PoolSize:           0,
+			TournamentSize:     tournament.Size,
+			WithoutReplacement: tournament.WithoutReplacement,
+			WinProbability:     tournament.WinProbability,
 		}, nil
 	case "hof_competition":
 		return &evo.SpeciesSharedTournamentSelector{
 			Identifier:            specieIdentifier,
 			PoolSize:              0,
-			TournamentSize:        3,
+			TournamentSize:        tournament.Size,
+			WithoutReplacement:    tournament.WithoutReplacement,
+			WinProbability:        tournament.WinProbability,
 			StagnationGenerations: 2,
 		}, nil
 	case "hof_rank":
@@ -2295,12 +3958,6 @@ func selectionFromName(name string, specieIdentifier evo.SpecieIdentifier) (evo.
 		return evo.EfficiencySelector{PoolSize: 0}, nil
 	case "hof_random":
 		return evo.RandomSelector{PoolSize: 0}, nil
-	case "competition":
-		return &evo.SpeciesSharedTournamentSelector{
-			Identifier:     specieIdentifier,
-			PoolSize:       0,
-			TournamentSize: 3,
-		}, nil
 	case "top3":
 		return evo.TopKFitnessSelector{K: 3}, nil
 	case "rank":
@@ -2309,6 +3966,10 @@ func selectionFromName(name string, specieIdentifier evo.SpecieIdentifier) (evo.
 		return evo.EfficiencySelector{PoolSize: 0}, nil
 	case "random":
 		return evo.RandomSelector{PoolSize: 0}, nil
+	case "afpo":
+		return evo.AFPOSelector{TournamentSize: 2, Newcomers: 1}, nil
+	case "nsga2":
+		return evo.NSGA2Selector{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported selection strategy: %s", name)
 	}
@@ -2328,6 +3989,12 @@ func postprocessorFromName(name string) (evo.FitnessPostprocessor, error) {
 		return evo.SizeProportionalPostprocessor{}, nil
 	case "novelty_proportional":
 		return evo.NoveltyProportionalPostprocessor{}, nil
+	case "novelty_archive":
+		return evo.NoveltyArchivePostprocessor{}, nil
+	case "numeric_fragility":
+		return evo.NumericFragilityPostprocessor{}, nil
+	case "cost_proportional":
+		return evo.CostProportionalPostprocessor{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported fitness postprocessor: %s", name)
 	}
@@ -1,16 +1,28 @@
 package protogonos
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"protogonos/internal/encoding"
+	"protogonos/internal/evo"
+	"protogonos/internal/genotype"
 	"protogonos/internal/model"
 	internalscape "protogonos/internal/scape"
 	"protogonos/internal/stats"
@@ -84,6 +96,9 @@ func TestClientRunRunsAndExport(t *testing.T) {
 	if runs[0].Morphology != "xor" {
 		t.Fatalf("expected xor morphology in runs list, got %+v", runs[0])
 	}
+	if resources := runs[0].Resources; resources == nil || resources.Evaluations < 2*8 || resources.StorageBytes <= 0 || resources.CPUSeconds < 0 {
+		t.Fatalf("expected resource usage in runs list, got %+v", runs[0].Resources)
+	}
 
 	lineage, err := client.Lineage(context.Background(), LineageRequest{RunID: summary.RunID, Limit: 10})
 	if err != nil {
@@ -724,6 +739,51 @@ func TestMaterializeRunConfigFromRequestParsesCompositeOpModeForGTProbes(t *test
 	}
 }
 
+func TestMaterializeRunConfigFromRequestParityStrict(t *testing.T) {
+	cfg, err := materializeRunConfigFromRequest(RunRequest{
+		Scape:        "xor",
+		Population:   6,
+		Generations:  1,
+		EnableTuning: true,
+		ParityStrict: true,
+	})
+	if err != nil {
+		t.Fatalf("materialize parity strict run config: %v", err)
+	}
+	if cfg.Request.Selection != ParityStrictSelection || cfg.Request.TuneSelection != ParityStrictTuneSelection {
+		t.Fatalf("expected reference selection defaults, got selection=%s tune_selection=%s", cfg.Request.Selection, cfg.Request.TuneSelection)
+	}
+	if cfg.Selector.Name() != "species_shared_tournament" {
+		t.Fatalf("expected hof_competition selector, got %s", cfg.Selector.Name())
+	}
+
+	_, err = materializeRunConfigFromRequest(RunRequest{
+		Scape:           "xor",
+		Population:      6,
+		Generations:     1,
+		ParityStrict:    true,
+		Selection:       "elite",
+		EnableTuning:    true,
+		TuneSelection:   "best_so_far",
+		WeightPerturb:   1,
+		WeightAllBiases: 1,
+		CrossoverRate:   0.2,
+		FitnessScaling:  "rank",
+	})
+	if err == nil {
+		t.Fatal("expected parity strict to reject non-reference options")
+	}
+	for _, want := range []string{"perturb_all_biases", "crossover", "selection elite", "fitness scaling rank", "tuning selection best_so_far"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q among parity violations, got %v", want, err)
+		}
+	}
+
+	if _, err := materializeRunConfigFromRequest(RunRequest{Scape: "xor", Population: 6, Generations: 1, Selection: "elite"}); err != nil {
+		t.Fatalf("expected elite selection without parity strict, got %v", err)
+	}
+}
+
 func TestMaterializeRunConfigFromRequestNormalizesReferenceScapeAlias(t *testing.T) {
 	cases := map[string]string{
 		"scape_LLVMPhaseOrdering": "llvm-phase-ordering",
@@ -1547,6 +1607,61 @@ func TestClientExportFallsBackToStoredMorphologyForLegacyConfig(t *testing.T) {
 	}
 }
 
+func TestClientRunBehaviorCloneSeedsFromScriptedPolicy(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	policy := func(observation []float64) ([]float64, error) {
+		return []float64{-1.2*observation[0] - 0.6*observation[1]}, nil
+	}
+	req := RunRequest{
+		RunID:       "cloned-cart-pole",
+		Scape:       "cart-pole-lite",
+		Population:  8,
+		Generations: 1,
+		Seed:        3,
+	}
+	plain, err := client.Run(context.Background(), req)
+	if err != nil {
+		t.Fatalf("plain run: %v", err)
+	}
+
+	req.RunID = "cloned-cart-pole-bc"
+	req.BehaviorClone = &BehaviorCloneSpec{Policy: policy, Fraction: 0.5, Iterations: 60}
+	cloned, err := client.Run(context.Background(), req)
+	if err != nil {
+		t.Fatalf("cloned run: %v", err)
+	}
+	cfg, ok, err := stats.ReadRunConfig(filepath.Join(base, "benchmarks"), cloned.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read config: ok=%t err=%v", ok, err)
+	}
+	if cfg.BehaviorClone == nil || cfg.BehaviorClone.Genomes != 4 || cfg.BehaviorClone.Iterations != 60 || cfg.BehaviorClone.Demonstrations != 300 {
+		t.Fatalf("expected the behavior clone fit recorded in the run config, got %+v", cfg.BehaviorClone)
+	}
+	if cfg.BehaviorClone.PolicyFitness <= 0.5 {
+		t.Fatalf("expected the scripted policy's fitness recorded, got %+v", cfg.BehaviorClone)
+	}
+	if cloned.BestByGeneration[0] <= plain.BestByGeneration[0] {
+		t.Fatalf("expected cloned genomes to start fitter than the seed population: cloned=%f plain=%f", cloned.BestByGeneration[0], plain.BestByGeneration[0])
+	}
+
+	req.BehaviorClone = &BehaviorCloneSpec{Fraction: 0.5}
+	if _, err := client.Run(context.Background(), req); err == nil || !strings.Contains(err.Error(), "scripted policy") {
+		t.Fatalf("expected a missing scripted policy to be rejected, got %v", err)
+	}
+}
+
 func TestClientRunStartPausedControls(t *testing.T) {
 	base := t.TempDir()
 	client, err := New(Options{
@@ -1920,109 +2035,4678 @@ func TestClientDeletePopulation(t *testing.T) {
 	}
 }
 
-func TestBuildReplaySubstrateUsesCEPNamesChain(t *testing.T) {
-	rt, err := buildReplaySubstrate(model.Genome{
-		ID: "replay-sub-chain-0",
-		Substrate: &model.SubstrateConfig{
-			CPPName:  internalsubstrate.DefaultCPPName,
-			CEPName:  internalsubstrate.SetWeightCEPName,
-			CEPNames: []string{internalsubstrate.SetWeightCEPName, internalsubstrate.DefaultCEPName},
-		},
-	}, []string{"o"})
+func TestClientPopulationDiff(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
 	if err != nil {
-		t.Fatalf("build replay substrate: %v", err)
+		t.Fatalf("new client: %v", err)
 	}
-	if rt == nil {
-		t.Fatal("expected replay substrate runtime")
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Run(context.Background(), RunRequest{
+		RunID:         "pop-diff-base",
+		Scape:         "xor",
+		Population:    6,
+		Generations:   1,
+		Seed:          3,
+		Selection:     "elite",
+		WeightPerturb: 1.0,
+	}); err != nil {
+		t.Fatalf("seed run: %v", err)
+	}
+	if _, err := client.Run(context.Background(), RunRequest{
+		ContinuePopulationID: "pop-diff-base",
+		RunID:                "pop-diff-next",
+		Scape:                "xor",
+		Population:           6,
+		Generations:          2,
+		Seed:                 4,
+		Selection:            "elite",
+		WeightPerturb:        1.0,
+	}); err != nil {
+		t.Fatalf("continue run: %v", err)
 	}
 
-	w, err := rt.Step(context.Background(), []float64{1})
+	diff, err := client.PopulationDiff(context.Background(), PopulationDiffRequest{FromID: "pop-diff-base", ToID: "pop-diff-next"})
 	if err != nil {
-		t.Fatalf("step replay substrate: %v", err)
+		t.Fatalf("population diff: %v", err)
 	}
-	if len(w) != 1 || w[0] != 2 {
-		t.Fatalf("expected set->delta chain result 2, got=%v", w)
+	if diff.FromSize != 6 || diff.ToSize != 6 {
+		t.Fatalf("unexpected snapshot sizes: %+v", diff)
+	}
+	if diff.RetainedCount+len(diff.Added) != diff.ToSize || diff.RetainedCount+len(diff.Removed) != diff.FromSize {
+		t.Fatalf("membership counts do not reconcile: %+v", diff)
+	}
+	if !diff.FitnessAvailable {
+		t.Fatalf("expected stored member fitness in both snapshots: %+v", diff)
+	}
+	if diff.KSStatistic < 0 || diff.KSStatistic > 1 {
+		t.Fatalf("ks statistic out of range: %f", diff.KSStatistic)
 	}
-}
 
-func TestBuildReplaySubstrateExpandsCEPChainFromCEPIDs(t *testing.T) {
-	rt, err := buildReplaySubstrate(model.Genome{
-		ID: "replay-sub-ids-chain-0",
-		Substrate: &model.SubstrateConfig{
-			CPPName: internalsubstrate.DefaultCPPName,
-			CEPName: internalsubstrate.DefaultCEPName,
-			CEPIDs:  []string{"cep_1", "cep_2"},
-		},
-	}, []string{"o"})
+	self, err := client.PopulationDiff(context.Background(), PopulationDiffRequest{FromID: "pop-diff-next", ToID: "pop-diff-next"})
 	if err != nil {
-		t.Fatalf("build replay substrate: %v", err)
+		t.Fatalf("self population diff: %v", err)
 	}
-	if rt == nil {
-		t.Fatal("expected replay substrate runtime")
+	if len(self.Added) != 0 || len(self.Removed) != 0 || self.KSStatistic != 0 || len(self.SpeciesChanged) != 0 {
+		t.Fatalf("expected empty self diff: %+v", self)
 	}
 
-	w, err := rt.Step(context.Background(), []float64{1})
+	if _, err := client.PopulationDiff(context.Background(), PopulationDiffRequest{FromID: "pop-diff-base", ToID: "missing"}); err == nil {
+		t.Fatal("expected population diff to fail for missing snapshot")
+	}
+	if diff.SpeciesSource != PopulationSpeciesTopology {
+		t.Fatalf("expected snapshots of different runs to use topology species, got %q", diff.SpeciesSource)
+	}
+
+	if _, err := client.Run(context.Background(), RunRequest{
+		RunID:            "pop-diff-gens",
+		Scape:            "xor",
+		Population:       6,
+		Generations:      3,
+		Seed:             5,
+		SnapshotInterval: 1,
+	}); err != nil {
+		t.Fatalf("snapshot run: %v", err)
+	}
+	recorded, err := client.PopulationDiff(context.Background(), PopulationDiffRequest{FromID: "pop-diff-gens@g1", ToID: "pop-diff-gens"})
 	if err != nil {
-		t.Fatalf("step replay substrate: %v", err)
+		t.Fatalf("generation population diff: %v", err)
 	}
-	if len(w) != 1 || w[0] != 2 {
-		t.Fatalf("expected delta chain expanded from cep ids to produce 2, got=%v", w)
+	if recorded.SpeciesSource != PopulationSpeciesRecorded || recorded.FromGeneration != 1 || recorded.ToGeneration != 3 {
+		t.Fatalf("expected recorded species between generations 1 and 3: %+v", recorded)
+	}
+	history, ok, err := client.store.GetSpeciesHistory(context.Background(), "pop-diff-gens")
+	if err != nil || !ok || len(history) != 3 {
+		t.Fatalf("species history: %+v ok=%t err=%v", history, ok, err)
+	}
+	toSizes := map[string]int{}
+	for _, species := range history[2].Species {
+		toSizes[species.Key] = species.Size
+	}
+	for _, delta := range append(append(recorded.SpeciesAdded, recorded.SpeciesChanged...), recorded.SpeciesRemoved...) {
+		if toSizes[delta.Key] != delta.ToSize {
+			t.Fatalf("species %s: diff size %d disagrees with the recorded history %v", delta.Key, delta.ToSize, toSizes)
+		}
 	}
 }
 
-func TestBuildReplaySubstrateExpandsSingularCEPNamesByCEPIDs(t *testing.T) {
-	rt, err := buildReplaySubstrate(model.Genome{
-		ID: "replay-sub-singular-chain-0",
-		Substrate: &model.SubstrateConfig{
-			CPPName:  internalsubstrate.DefaultCPPName,
-			CEPName:  internalsubstrate.DefaultCEPName,
-			CEPNames: []string{internalsubstrate.SetWeightCEPName},
-			CEPIDs:   []string{"cep_1", "cep_2"},
+func TestClientForkRecordsAncestryAndOverrides(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Run(context.Background(), RunRequest{
+		RunID:            "fork-parent",
+		Scape:            "xor",
+		Population:       6,
+		Generations:      3,
+		Seed:             9,
+		Selection:        "elite",
+		WeightPerturb:    1.0,
+		SnapshotInterval: 2,
+	}); err != nil {
+		t.Fatalf("parent run: %v", err)
+	}
+
+	if _, err := client.Fork(context.Background(), ForkRequest{ParentRunID: "missing-parent"}); err == nil {
+		t.Fatal("expected fork of an unknown run to fail")
+	}
+	if _, err := client.Fork(context.Background(), ForkRequest{ParentRunID: "fork-parent", AtGeneration: 1}); err == nil || !strings.Contains(err.Error(), "only persists its final population") {
+		t.Fatalf("expected fork at a generation without a snapshot to fail, got %v", err)
+	}
+	if _, err := client.Fork(context.Background(), ForkRequest{ParentRunID: "fork-parent", AtGeneration: 4}); err == nil {
+		t.Fatal("expected fork past the parent's final generation to fail")
+	}
+	if _, err := client.Fork(context.Background(), ForkRequest{ParentRunID: "fork-parent", Set: map[string]string{"bogus": "1"}}); err == nil {
+		t.Fatal("expected unsupported override to fail")
+	}
+
+	summary, err := client.Fork(context.Background(), ForkRequest{
+		ParentRunID:  "fork-parent",
+		AtGeneration: 2,
+		RunID:        "fork-child",
+		Set: map[string]string{
+			"w-add-neuron": "0.2",
+			"gens":         "1",
 		},
-	}, []string{"o"})
+	})
 	if err != nil {
-		t.Fatalf("build replay substrate: %v", err)
+		t.Fatalf("fork: %v", err)
 	}
-	if rt == nil {
-		t.Fatal("expected replay substrate runtime")
+	if summary.RunID != "fork-child" || len(summary.BestByGeneration) != 1 {
+		t.Fatalf("unexpected fork summary: %+v", summary)
 	}
 
-	w, err := rt.Step(context.Background(), []float64{1})
+	cfg, ok, err := stats.ReadRunConfig(filepath.Join(base, "benchmarks"), "fork-child")
+	if err != nil || !ok {
+		t.Fatalf("read fork config ok=%t err=%v", ok, err)
+	}
+	if cfg.ForkedFrom != "fork-parent" || cfg.ForkGeneration != 2 || cfg.InitialGeneration != 2 || cfg.ContinuePopulationID != "fork-parent@g2" {
+		t.Fatalf("unexpected fork ancestry in config: %+v", cfg)
+	}
+	if cfg.WeightAddNeuron != 0.2 || cfg.WeightPerturb != 1.0 || cfg.Seed != 9 {
+		t.Fatalf("expected fork to inherit parent config with overrides: %+v", cfg)
+	}
+
+	runs, err := client.Runs(context.Background(), RunsRequest{Limit: 10})
 	if err != nil {
-		t.Fatalf("step replay substrate: %v", err)
+		t.Fatalf("runs: %v", err)
 	}
-	if len(w) != 1 || w[0] != 1 {
-		t.Fatalf("expected singular cep-name chain expanded by cep ids to remain 1, got=%v", w)
+	found := false
+	for _, item := range runs {
+		if item.RunID == "fork-child" {
+			found = item.ForkedFrom == "fork-parent" && item.ForkGeneration == 2
+		}
+	}
+	if !found {
+		t.Fatalf("expected fork ancestry in run index: %+v", runs)
+	}
+
+	final, err := client.Fork(context.Background(), ForkRequest{ParentRunID: "fork-parent", RunID: "fork-final", Set: map[string]string{"gens": "1"}})
+	if err != nil {
+		t.Fatalf("fork final population: %v", err)
+	}
+	cfg, ok, err = stats.ReadRunConfig(filepath.Join(base, "benchmarks"), final.RunID)
+	if err != nil || !ok || cfg.ForkGeneration != 3 || cfg.ContinuePopulationID != "fork-parent" {
+		t.Fatalf("expected a default fork from the final population: %+v ok=%t err=%v", cfg, ok, err)
 	}
 }
 
-func TestBuildReplaySubstrateKeepsOutputFallbackOverCPPIDs(t *testing.T) {
-	rt, err := buildReplaySubstrate(model.Genome{
-		ID: "replay-sub-cpp-fanin-0",
-		Substrate: &model.SubstrateConfig{
-			CPPName:     internalsubstrate.DefaultCPPName,
-			CPPIDs:      []string{"cpp_endpoint_1"},
-			CEPName:     internalsubstrate.DefaultCEPName,
-			WeightCount: 1,
-		},
-	}, []string{"o"})
+func TestClientMergePopulationsSeedsContinuation(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
 	if err != nil {
-		t.Fatalf("build replay substrate: %v", err)
+		t.Fatalf("new client: %v", err)
 	}
-	if rt == nil {
-		t.Fatal("expected replay substrate runtime")
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	for i, runID := range []string{"island-a", "island-b"} {
+		if _, err := client.Run(context.Background(), RunRequest{
+			RunID:         runID,
+			Scape:         "xor",
+			Population:    6,
+			Generations:   2,
+			Seed:          int64(20 + i),
+			Selection:     "elite",
+			WeightPerturb: 1.0,
+		}); err != nil {
+			t.Fatalf("island run %s: %v", runID, err)
+		}
 	}
-	faninRT, ok := rt.(internalsubstrate.FaninRuntime)
-	if !ok {
-		t.Fatalf("expected fanin-capable runtime, got %T", rt)
+	if _, err := client.Run(context.Background(), RunRequest{
+		RunID:         "island-regression",
+		Scape:         "regression-mimic",
+		Population:    4,
+		Generations:   1,
+		Seed:          5,
+		Selection:     "elite",
+		WeightPerturb: 1.0,
+	}); err != nil {
+		t.Fatalf("regression run: %v", err)
 	}
-	w, err := faninRT.StepWithFanin(context.Background(), []float64{0}, map[string]float64{
-		"o": 1,
+
+	if _, err := client.MergePopulations(context.Background(), MergePopulationsRequest{
+		RunIDs:          []string{"island-a", "island-regression"},
+		OutPopulationID: "mixed",
+	}); err == nil {
+		t.Fatal("expected merge across scapes to fail")
+	}
+
+	summary, err := client.MergePopulations(context.Background(), MergePopulationsRequest{
+		RunIDs:          []string{"island-a", "island-b"},
+		OutPopulationID: "gene-pool",
 	})
 	if err != nil {
-		t.Fatalf("step replay substrate with output fallback fan-in: %v", err)
+		t.Fatalf("merge populations: %v", err)
 	}
-	if len(w) != 1 || w[0] != 1 {
-		t.Fatalf("expected output fallback fan-in update to 1, got=%v", w)
+	if summary.Scape != "xor" || summary.MergedSize+summary.DuplicateCount != 12 || summary.SpeciesCount == 0 {
+		t.Fatalf("unexpected merge summary: %+v", summary)
+	}
+
+	continued, err := client.Run(context.Background(), RunRequest{
+		ContinuePopulationID: "gene-pool",
+		Scape:                "xor",
+		Generations:          1,
+		Seed:                 30,
+		Selection:            "elite",
+		WeightPerturb:        1.0,
+	})
+	if err != nil {
+		t.Fatalf("continue from merged population: %v", err)
+	}
+	if continued.RunID != "gene-pool" {
+		t.Fatalf("expected continuation run id to default to merged population id, got %s", continued.RunID)
+	}
+}
+
+func TestClientRunStreamsTrace(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:         "trace-stream",
+		Scape:         "xor",
+		Population:    6,
+		Generations:   3,
+		Seed:          4,
+		Selection:     "elite",
+		TraceStepSize: 6,
+		WeightPerturb: 1.0,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	records, err := stats.ReadTraceStream(filepath.Join(summary.ArtifactsDir, stats.TraceStreamFile))
+	if err != nil {
+		t.Fatalf("read trace stream: %v", err)
+	}
+	streamed := stats.SummarizeTraceStream(records)
+	if !streamed.Complete {
+		t.Fatal("expected finished run to close its trace stream with an end record")
+	}
+	if streamed.LastUpdate == nil || streamed.LastUpdate.Reason != "completed" {
+		t.Fatalf("expected final completed update, got %+v", streamed.LastUpdate)
+	}
+	if len(streamed.TraceAcc) != 3 {
+		t.Fatalf("expected 3 streamed generations, got %d", len(streamed.TraceAcc))
+	}
+	for i := 1; i < len(records); i++ {
+		if records[i].Seq != records[i-1].Seq+1 {
+			t.Fatalf("non-contiguous stream sequence at %d: %d -> %d", i, records[i-1].Seq, records[i].Seq)
+		}
+	}
+	legacy, err := stats.ReadTraceAccFile(filepath.Join(summary.ArtifactsDir, "trace_acc.json"))
+	if err != nil {
+		t.Fatalf("read legacy trace_acc.json: %v", err)
+	}
+	if len(legacy) != len(streamed.TraceAcc) {
+		t.Fatalf("stream and legacy trace disagree: %d vs %d", len(streamed.TraceAcc), len(legacy))
+	}
+	for i := range legacy {
+		if legacy[i].Generation != streamed.TraceAcc[i].Generation || len(legacy[i].Stats) != len(streamed.TraceAcc[i].Stats) {
+			t.Fatalf("generation %d mismatch between stream and legacy trace", i)
+		}
+	}
+}
+
+func TestClientRunBatchEvaluationMatchesStepwise(t *testing.T) {
+	for _, scapeName := range []string{"xor", "regression-mimic"} {
+		var histories [][]float64
+		for _, disable := range []bool{false, true} {
+			base := t.TempDir()
+			client, err := New(Options{
+				StoreKind:     "memory",
+				BenchmarksDir: filepath.Join(base, "benchmarks"),
+				ExportsDir:    filepath.Join(base, "exports"),
+			})
+			if err != nil {
+				t.Fatalf("new client: %v", err)
+			}
+			summary, err := client.Run(context.Background(), RunRequest{
+				Scape:                  scapeName,
+				Population:             8,
+				Generations:            4,
+				Seed:                   11,
+				Workers:                2,
+				Selection:              "elite",
+				WeightPerturb:          1.0,
+				WeightAddNeuron:        0.5,
+				DisableBatchEvaluation: disable,
+			})
+			_ = client.Close()
+			if err != nil {
+				t.Fatalf("%s run (disable batch=%t): %v", scapeName, disable, err)
+			}
+			histories = append(histories, summary.BestByGeneration)
+		}
+		if len(histories[0]) != len(histories[1]) {
+			t.Fatalf("%s: history length mismatch: %v vs %v", scapeName, histories[0], histories[1])
+		}
+		for i := range histories[0] {
+			if math.Abs(histories[0][i]-histories[1][i]) > 1e-9 {
+				t.Fatalf("%s: batched and stepwise histories diverge at %d: %v vs %v", scapeName, i, histories[0], histories[1])
+			}
+		}
+	}
+}
+
+func TestClientRunEvalCacheMatchesUncachedTuning(t *testing.T) {
+	var histories [][]float64
+	var diagnostics [][]model.GenerationDiagnostics
+	for _, disable := range []bool{false, true} {
+		base := t.TempDir()
+		client, err := New(Options{
+			StoreKind:     "memory",
+			BenchmarksDir: filepath.Join(base, "benchmarks"),
+			ExportsDir:    filepath.Join(base, "exports"),
+		})
+		if err != nil {
+			t.Fatalf("new client: %v", err)
+		}
+		summary, err := client.Run(context.Background(), RunRequest{
+			Scape:            "regression-mimic",
+			Population:       6,
+			Generations:      3,
+			Seed:             5,
+			Workers:          2,
+			Selection:        "elite",
+			WeightPerturb:    1.0,
+			WeightAddNeuron:  0.5,
+			EnableTuning:     true,
+			TuneAttempts:     3,
+			TuneSteps:        3,
+			TuneStepSize:     0.2,
+			DisableEvalCache: disable,
+		})
+		if err != nil {
+			_ = client.Close()
+			t.Fatalf("run (disable cache=%t): %v", disable, err)
+		}
+		diags, err := client.Diagnostics(context.Background(), DiagnosticsRequest{RunID: summary.RunID})
+		_ = client.Close()
+		if err != nil {
+			t.Fatalf("diagnostics: %v", err)
+		}
+		histories = append(histories, summary.BestByGeneration)
+		diagnostics = append(diagnostics, diags)
+	}
+	if len(histories[0]) != len(histories[1]) {
+		t.Fatalf("history length mismatch: %v vs %v", histories[0], histories[1])
+	}
+	for i := range histories[0] {
+		if histories[0][i] != histories[1][i] {
+			t.Fatalf("cached and uncached histories diverge at %d: %v vs %v", i, histories[0], histories[1])
+		}
+	}
+	hits := 0
+	for _, d := range diagnostics[0] {
+		hits += d.EvalCacheHits
+	}
+	if hits == 0 {
+		t.Fatalf("expected eval cache hits in diagnostics: %+v", diagnostics[0])
+	}
+	for _, d := range diagnostics[1] {
+		if d.EvalCacheHits != 0 || d.EvalCacheMisses != 0 {
+			t.Fatalf("expected no cache activity with cache disabled: %+v", d)
+		}
+	}
+}
+
+func TestClientRunReportsChampionFitnessCI(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:            "trials-ci",
+		Scape:            "xor",
+		Population:       6,
+		Generations:      2,
+		Seed:             5,
+		Selection:        "elite",
+		WeightPerturb:    1.0,
+		EvaluationTrials: 3,
+		CITieBreak:       true,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	ci := summary.ChampionFitnessCI
+	if ci == nil || ci.Trials != 3 || ci.Confidence != 0.95 {
+		t.Fatalf("expected 3-trial champion interval, got %+v", ci)
+	}
+	if ci.Lower > summary.FinalBestFitness || ci.Upper < summary.FinalBestFitness {
+		t.Fatalf("champion fitness %f outside interval %+v", summary.FinalBestFitness, ci)
+	}
+
+	data, err := os.ReadFile(filepath.Join(summary.ArtifactsDir, "fitness_history.json"))
+	if err != nil {
+		t.Fatalf("read fitness history: %v", err)
+	}
+	var history struct {
+		ChampionFitnessCI *FitnessInterval `json:"champion_fitness_ci"`
+	}
+	if err := json.Unmarshal(data, &history); err != nil {
+		t.Fatalf("decode fitness history: %v", err)
+	}
+	if history.ChampionFitnessCI == nil || history.ChampionFitnessCI.Trials != 3 {
+		t.Fatalf("expected champion interval in fitness history, got %s", data)
+	}
+	cfg, ok, err := stats.ReadRunConfig(client.benchmarksDir, "trials-ci")
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.EvaluationTrials != 3 || !cfg.CITieBreak {
+		t.Fatalf("expected trials settings persisted, got trials=%d ci_tiebreak=%t", cfg.EvaluationTrials, cfg.CITieBreak)
+	}
+
+	if _, err := client.Run(context.Background(), RunRequest{Scape: "xor", Population: 4, Generations: 1, EvaluationTrials: -1}); err == nil {
+		t.Fatal("expected negative evaluation trials to fail")
+	}
+}
+
+func TestClientRunAFPOSelectionInjectsNewcomers(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:         "afpo-run",
+		Scape:         "xor",
+		Population:    6,
+		Generations:   3,
+		Seed:          9,
+		Selection:     "afpo",
+		WeightPerturb: 1.0,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	lineage, err := client.Lineage(context.Background(), LineageRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("lineage: %v", err)
+	}
+	newcomers := 0
+	for _, item := range lineage {
+		if item.Operation == "afpo_newcomer" {
+			newcomers++
+			if !strings.HasPrefix(item.GenomeID, "newcomer-g") {
+				t.Fatalf("unexpected newcomer id: %+v", item)
+			}
+		}
+	}
+	if newcomers == 0 {
+		t.Fatal("expected afpo run lineage to include newcomers")
+	}
+
+	if _, err := client.Run(context.Background(), RunRequest{
+		Scape:         "xor",
+		Population:    4,
+		Generations:   1,
+		Selection:     "afpo",
+		EvolutionType: "steady_state",
+	}); err == nil || !strings.Contains(err.Error(), "afpo") {
+		t.Fatalf("expected steady-state afpo run to be rejected, got %v", err)
+	}
+}
+
+func TestClientRunNSGA2SelectionOnFX(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:         "nsga2-run",
+		Scape:         "fx",
+		Population:    6,
+		Generations:   2,
+		Seed:          4,
+		Selection:     "nsga2",
+		WeightPerturb: 1.0,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(summary.BestByGeneration) != 2 {
+		t.Fatalf("expected two generations, got %+v", summary.BestByGeneration)
+	}
+	if _, err := selectionFromName("nsga2", evo.TopologySpecieIdentifier{}, tournamentOptions{}); err != nil {
+		t.Fatalf("nsga2 selection: %v", err)
+	}
+}
+
+func TestClientRunNoveltyArchiveOnXOR(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:                "novelty-run",
+		Scape:                "xor",
+		Population:           6,
+		Generations:          3,
+		Seed:                 9,
+		FitnessPostprocessor: "novelty_archive",
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(summary.BestByGeneration) != 3 {
+		t.Fatalf("expected three generations, got %+v", summary.BestByGeneration)
+	}
+	postprocessor, err := postprocessorFromName("novelty_archive")
+	if err != nil {
+		t.Fatalf("novelty_archive postprocessor: %v", err)
+	}
+	if _, ok := postprocessor.(evo.NoveltyArchivePostprocessor); !ok {
+		t.Fatalf("unexpected postprocessor: %#v", postprocessor)
+	}
+}
+
+func TestClientRunIslandsReportsPerIslandBests(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	req := RunRequest{
+		RunID:             "islands-run",
+		Scape:             "xor",
+		Population:        12,
+		Generations:       4,
+		Seed:              5,
+		Islands:           3,
+		MigrationInterval: 2,
+		Migrants:          2,
+	}
+	summary, err := client.Run(context.Background(), req)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(summary.IslandBestFitness) != 3 {
+		t.Fatalf("expected a best per island, got %v", summary.IslandBestFitness)
+	}
+	if slices.Max(summary.IslandBestFitness) != slices.Max(summary.BestByGeneration) {
+		t.Fatalf("expected the global best among island bests: islands=%v generations=%v", summary.IslandBestFitness, summary.BestByGeneration)
+	}
+	diagnostics, err := client.Diagnostics(context.Background(), DiagnosticsRequest{RunID: "islands-run"})
+	if err != nil {
+		t.Fatalf("diagnostics: %v", err)
+	}
+	if diagnostics[1].Migrants != 6 || diagnostics[0].Migrants != 0 {
+		t.Fatalf("expected two migrants per island after generation 2, got %d then %d", diagnostics[0].Migrants, diagnostics[1].Migrants)
+	}
+	config, ok, err := stats.ReadRunConfig(filepath.Join(base, "benchmarks"), summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if config.Islands != 3 || config.MigrationInterval != 2 || config.Migrants != 2 {
+		t.Fatalf("expected island settings in the run config, got %+v", config)
+	}
+
+	req.RunID = "islands-invalid"
+	req.Islands = 0
+	if _, err := client.Run(context.Background(), req); err == nil {
+		t.Fatal("expected migration settings without islands to be rejected")
+	}
+}
+
+func TestClientResumeContinuesCrashedRunFromCheckpoint(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	req := RunRequest{
+		RunID:              "checkpoint-full",
+		Scape:              "xor",
+		Population:         8,
+		Generations:        6,
+		Seed:               9,
+		EnableTuning:       true,
+		TuneAttempts:       1,
+		TuneSteps:          2,
+		CheckpointInterval: 2,
+	}
+	full, err := client.Run(context.Background(), req)
+	if err != nil {
+		t.Fatalf("uninterrupted run: %v", err)
+	}
+	if _, err := client.Resume(context.Background(), ResumeRequest{RunID: "checkpoint-full"}); err == nil {
+		t.Fatal("expected a finished run to leave no checkpoint behind")
+	}
+
+	// Crash the run during generation 3, after the generation 2 checkpoint.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req.RunID = "checkpoint-crash"
+	req.Progress = func(progress RunProgress) {
+		if progress.Generation == 3 {
+			cancel()
+		}
+	}
+	if _, err := client.Run(ctx, req); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the run to be interrupted, got %v", err)
+	}
+
+	resumed, err := client.Resume(context.Background(), ResumeRequest{RunID: "checkpoint-crash"})
+	if err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if !reflect.DeepEqual(resumed.BestByGeneration, full.BestByGeneration) {
+		t.Fatalf("expected the resumed run to match the uninterrupted one: got %v want %v", resumed.BestByGeneration, full.BestByGeneration)
+	}
+	if resumed.FinalBestFitness != full.FinalBestFitness {
+		t.Fatalf("expected matching final fitness: got %f want %f", resumed.FinalBestFitness, full.FinalBestFitness)
+	}
+	fullTrace, _, err := stats.ReadTraceAcc(filepath.Join(base, "benchmarks"), "checkpoint-full")
+	if err != nil {
+		t.Fatalf("read uninterrupted trace: %v", err)
+	}
+	resumedTrace, ok, err := stats.ReadTraceAcc(filepath.Join(base, "benchmarks"), "checkpoint-crash")
+	if err != nil || !ok {
+		t.Fatalf("read resumed trace: ok=%t err=%v", ok, err)
+	}
+	if len(fullTrace) == 0 || len(resumedTrace) != len(fullTrace) {
+		t.Fatalf("expected the resumed trace stream to keep the generations before the checkpoint: got %d want %d", len(resumedTrace), len(fullTrace))
+	}
+	for i := range fullTrace {
+		if resumedTrace[i].Generation != fullTrace[i].Generation {
+			t.Fatalf("expected trace generation %d at %d, got %d", fullTrace[i].Generation, i, resumedTrace[i].Generation)
+		}
+	}
+	config, ok, err := stats.ReadRunConfig(filepath.Join(base, "benchmarks"), "checkpoint-crash")
+	if err != nil || !ok || config.CheckpointInterval != 2 {
+		t.Fatalf("expected the checkpoint interval in the run config, got %+v ok=%t err=%v", config, ok, err)
+	}
+
+	req.RunID = "checkpoint-invalid"
+	req.Progress = nil
+	req.EvolutionType = evo.EvolutionTypeSteadyState
+	if _, err := client.Run(context.Background(), req); err == nil {
+		t.Fatal("expected checkpoints to require generational evolution")
+	}
+}
+
+func TestSelectionFromNameAppliesTournamentOptions(t *testing.T) {
+	selector, err := selectionFromName("tournament", evo.TopologySpecieIdentifier{}, tournamentOptions{
+		Size:               5,
+		WithoutReplacement: true,
+		WinProbability:     0.75,
+	})
+	if err != nil {
+		t.Fatalf("tournament selection: %v", err)
+	}
+	tournament, ok := selector.(evo.TournamentSelector)
+	if !ok || tournament.TournamentSize != 5 || !tournament.WithoutReplacement || tournament.WinProbability != 0.75 {
+		t.Fatalf("unexpected tournament selector: %#v", selector)
+	}
+
+	selector, err = selectionFromName("species_tournament", evo.TopologySpecieIdentifier{}, tournamentOptions{})
+	if err != nil {
+		t.Fatalf("species tournament selection: %v", err)
+	}
+	species, ok := selector.(evo.SpeciesTournamentSelector)
+	if !ok || species.TournamentSize != 3 || species.WithoutReplacement || species.WinProbability != 1 {
+		t.Fatalf("expected default tournament options, got %#v", selector)
+	}
+
+	if _, err := selectionFromName("tournament", nil, tournamentOptions{Size: -1}); err == nil {
+		t.Fatal("expected negative tournament size to fail")
+	}
+	if _, err := selectionFromName("tournament", nil, tournamentOptions{WinProbability: 1.5}); err == nil {
+		t.Fatal("expected out-of-range win probability to fail")
+	}
+}
+
+func TestClientRunPersistsDecoupledSeeds(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	selectionSeed, mutationSeed, envSeed := int64(11), int64(12), int64(13)
+	run := func(runID string) RunSummary {
+		summary, err := client.Run(context.Background(), RunRequest{
+			RunID:         runID,
+			Scape:         "xor",
+			Population:    6,
+			Generations:   3,
+			Seed:          5,
+			SelectionSeed: &selectionSeed,
+			MutationSeed:  &mutationSeed,
+			EnvSeed:       &envSeed,
+			Selection:     "tournament",
+			WeightPerturb: 1.0,
+		})
+		if err != nil {
+			t.Fatalf("run %s: %v", runID, err)
+		}
+		return summary
+	}
+	first := run("seeds-a")
+	second := run("seeds-b")
+	if fmt.Sprint(first.BestByGeneration) != fmt.Sprint(second.BestByGeneration) {
+		t.Fatalf("expected identical seeds to reproduce history: %v vs %v", first.BestByGeneration, second.BestByGeneration)
+	}
+
+	cfg, ok, err := stats.ReadRunConfig(client.benchmarksDir, "seeds-a")
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.SelectionSeed == nil || *cfg.SelectionSeed != 11 ||
+		cfg.MutationSeed == nil || *cfg.MutationSeed != 12 ||
+		cfg.EnvSeed == nil || *cfg.EnvSeed != 13 {
+		t.Fatalf("expected persisted decoupled seeds, got %+v", cfg)
+	}
+	req := runRequestFromRunConfig(cfg)
+	if err := applyRunOverride(&req, "seed-env", "21"); err != nil {
+		t.Fatalf("apply overrides: %v", err)
+	}
+	if req.EnvSeed == nil || *req.EnvSeed != 21 || *req.SelectionSeed != 11 {
+		t.Fatalf("expected env seed override with inherited search seeds, got %+v", req)
+	}
+}
+
+func TestClientCompareTuningReportsPairedImprovement(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:             "compare-crn",
+		Scape:             "xor",
+		Population:        6,
+		Generations:       3,
+		Seed:              4,
+		EnableTuning:      true,
+		CompareTuning:     true,
+		TuneAttempts:      2,
+		TuneSteps:         2,
+		TuneStepSize:      0.25,
+		TuneDurationParam: 1.0,
+		Selection:         "elite",
+		WeightPerturb:     1.0,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if summary.Compare == nil || summary.Compare.Pairing != stats.TuningPairingCommonRandomNumbers {
+		t.Fatalf("expected common-random-number pairing in compare summary, got %+v", summary.Compare)
+	}
+
+	report, ok, err := stats.ReadTuningComparison(client.benchmarksDir, "compare-crn")
+	if err != nil || !ok {
+		t.Fatalf("read comparison: ok=%t err=%v", ok, err)
+	}
+	if report.Pairing != stats.TuningPairingCommonRandomNumbers {
+		t.Fatalf("expected pairing to be documented in report, got %q", report.Pairing)
+	}
+	if len(report.PairedImprovement) != len(report.WithTuningBest) {
+		t.Fatalf("expected one paired difference per generation, got %v", report.PairedImprovement)
+	}
+	for i, diff := range report.PairedImprovement {
+		if math.Abs(diff-(report.WithTuningBest[i]-report.WithoutTuningBest[i])) > 1e-12 {
+			t.Fatalf("unexpected paired difference at generation %d: %f", i, diff)
+		}
+	}
+}
+
+func TestClientRunReportsProgress(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	var updates []RunProgress
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:         "progress-run",
+		Scape:         "xor",
+		Population:    4,
+		Generations:   3,
+		Seed:          7,
+		Selection:     "tournament",
+		WeightPerturb: 1.0,
+		Progress: func(p RunProgress) {
+			updates = append(updates, p)
+		},
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(updates) != len(summary.BestByGeneration) {
+		t.Fatalf("expected one progress update per generation, got %d for %d generations", len(updates), len(summary.BestByGeneration))
+	}
+	for i, update := range updates {
+		if update.Generation != i+1 {
+			t.Fatalf("expected generation %d, got %d", i+1, update.Generation)
+		}
+		if update.TotalEvaluations <= 0 || (i > 0 && update.TotalEvaluations <= updates[i-1].TotalEvaluations) {
+			t.Fatalf("expected increasing evaluation counts, got %+v", updates)
+		}
+		if update.WallClock < 0 || update.ETA < 0 {
+			t.Fatalf("expected non-negative timings, got %+v", update)
+		}
+	}
+	if last := updates[len(updates)-1]; last.ETA != 0 {
+		t.Fatalf("expected zero eta at the final generation, got %s", last.ETA)
+	}
+
+	diagnostics, err := client.Diagnostics(context.Background(), DiagnosticsRequest{RunID: "progress-run"})
+	if err != nil {
+		t.Fatalf("diagnostics: %v", err)
+	}
+	if len(diagnostics) != len(updates) {
+		t.Fatalf("expected %d diagnostics, got %d", len(updates), len(diagnostics))
+	}
+	if got := diagnostics[len(diagnostics)-1].TotalEvaluations; got != updates[len(updates)-1].TotalEvaluations {
+		t.Fatalf("expected persisted evaluation count %d, got %d", updates[len(updates)-1].TotalEvaluations, got)
+	}
+	for i, diag := range diagnostics {
+		update := updates[i]
+		if update.MeanFitness != diag.MeanFitness || update.SpeciesCount != diag.SpeciesCount || update.TuningEvaluations != diag.TuningEvaluations || update.TuningAcceptRate != diag.TuningAcceptRate {
+			t.Fatalf("expected progress %d to mirror diagnostics %+v, got %+v", i, diag, update)
+		}
+	}
+}
+
+func TestClientRunStreamEmitsGenerationEvents(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.RunStream(context.Background(), RunRequest{Scape: "no-such-scape"}); err == nil {
+		t.Fatal("expected an invalid request to fail before streaming")
+	}
+
+	var progressed int
+	events, err := client.RunStream(context.Background(), RunRequest{
+		RunID:         "stream-run",
+		Scape:         "xor",
+		Population:    8,
+		Generations:   3,
+		Seed:          7,
+		Selection:     "elite",
+		EnableTuning:  true,
+		TuneAttempts:  2,
+		TuneSteps:     2,
+		TuneStepSize:  0.2,
+		WeightPerturb: 1.0,
+		Progress: func(RunProgress) {
+			progressed++
+		},
+	})
+	if err != nil {
+		t.Fatalf("run stream: %v", err)
+	}
+	var generations []GenerationEvent
+	var final *GenerationEvent
+	for event := range events {
+		if final != nil {
+			t.Fatalf("expected the final event to be last, got %+v", event)
+		}
+		if event.Done {
+			final = &event
+			continue
+		}
+		generations = append(generations, event)
+	}
+	if final == nil || final.Err != nil || final.Summary == nil {
+		t.Fatalf("expected a final event with the run summary, got %+v", final)
+	}
+	if len(generations) != 3 || progressed != 3 {
+		t.Fatalf("expected three generation events alongside the progress callback, got %d events and %d updates", len(generations), progressed)
+	}
+	for i, event := range generations {
+		if event.Generation != i+1 || event.BestFitness != final.Summary.BestByGeneration[i] {
+			t.Fatalf("expected generation %d to stream the recorded best fitness, got %+v", i+1, event)
+		}
+		if event.MeanFitness > event.BestFitness || event.Progress.Generation != event.Generation || event.Progress.TotalEvaluations <= 0 {
+			t.Fatalf("expected consistent fitness and progress, got %+v", event)
+		}
+		size := 0
+		for _, species := range event.Species {
+			size += species.Size
+		}
+		if len(event.Species) == 0 || size != 8 {
+			t.Fatalf("expected species summaries covering the population, got %+v", event.Species)
+		}
+		if event.Tuning.Invocations == 0 || event.Tuning.Attempts == 0 {
+			t.Fatalf("expected tuning stats, got %+v", event.Tuning)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err = client.RunStream(ctx, RunRequest{
+		RunID:         "stream-cancelled",
+		Scape:         "xor",
+		Population:    4,
+		Generations:   50,
+		Seed:          7,
+		WeightPerturb: 1.0,
+	})
+	if err != nil {
+		t.Fatalf("run stream: %v", err)
+	}
+	<-events
+	cancel()
+	for range events {
+	}
+}
+
+func TestClientRunMultiFidelityRecordsFinalists(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Run(context.Background(), RunRequest{
+		RunID:       "fidelity-xor",
+		Scape:       "xor",
+		Population:  4,
+		Generations: 1,
+		LowFidelity: 0.5,
+	}); err == nil {
+		t.Fatal("expected scape without fidelity support to be rejected")
+	}
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:         "fidelity-run",
+		Scape:         "cart-pole-lite",
+		Population:    8,
+		Generations:   2,
+		Seed:          3,
+		Selection:     "tournament",
+		WeightPerturb: 1.0,
+		LowFidelity:   0.25,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	diagnostics, err := client.Diagnostics(context.Background(), DiagnosticsRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("diagnostics: %v", err)
+	}
+	for _, diag := range diagnostics {
+		if diag.FidelityFinalists != 2 {
+			t.Fatalf("expected default finalist fraction to promote 2 of 8 genomes, got %+v", diag)
+		}
+	}
+	cfg, ok, err := stats.ReadRunConfig(client.benchmarksDir, summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.LowFidelity != 0.25 || cfg.FinalistFraction != 0.25 {
+		t.Fatalf("expected persisted fidelity schedule, got low=%f finalists=%f", cfg.LowFidelity, cfg.FinalistFraction)
+	}
+}
+
+func TestClientReplayRendersTrajectory(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	for _, runReq := range []RunRequest{
+		{RunID: "replay-dtm", Scape: "dtm", Population: 4, Generations: 1, Seed: 5},
+		{RunID: "replay-xor", Scape: "xor", Population: 4, Generations: 1, Seed: 5},
+	} {
+		if _, err := client.Run(context.Background(), runReq); err != nil {
+			t.Fatalf("run %s: %v", runReq.RunID, err)
+		}
+	}
+
+	for _, format := range []string{ReplayRenderSVG, ReplayRenderJSON} {
+		summary, err := client.Replay(context.Background(), ReplayRequest{RunID: "replay-dtm", Render: true, Format: format})
+		if err != nil {
+			t.Fatalf("replay %s: %v", format, err)
+		}
+		if summary.Scape != "dtm" || summary.Mode != "gt" || summary.GenomeID == "" || summary.Frames == 0 {
+			t.Fatalf("unexpected replay summary: %+v", summary)
+		}
+		if filepath.Dir(summary.RenderPath) != filepath.Join(base, "benchmarks", "replay-dtm", "replay") {
+			t.Fatalf("expected render inside run replay artifacts, got %s", summary.RenderPath)
+		}
+		data, err := os.ReadFile(summary.RenderPath)
+		if err != nil {
+			t.Fatalf("read render: %v", err)
+		}
+		if format == ReplayRenderSVG && !strings.Contains(string(data), "<animate") {
+			t.Fatalf("expected animated svg, got %q", data)
+		}
+		if format == ReplayRenderJSON {
+			var trajectory internalscape.Trajectory
+			if err := json.Unmarshal(data, &trajectory); err != nil {
+				t.Fatalf("decode json render: %v", err)
+			}
+			if len(trajectory.Frames) != summary.Frames {
+				t.Fatalf("expected %d frames in json render, got %d", summary.Frames, len(trajectory.Frames))
+			}
+		}
+	}
+
+	if _, err := client.Replay(context.Background(), ReplayRequest{RunID: "replay-xor", Render: true}); err == nil {
+		t.Fatal("expected render on a non-spatial scape to be rejected")
+	}
+	if _, err := client.Replay(context.Background(), ReplayRequest{RunID: "replay-xor"}); err != nil {
+		t.Fatalf("expected plain replay on xor to succeed: %v", err)
+	}
+	if _, err := client.Replay(context.Background(), ReplayRequest{RunID: "replay-dtm", Format: "gif"}); err == nil {
+		t.Fatal("expected unsupported render format to be rejected")
+	}
+}
+
+func TestModelServerBatchesPredictRequests(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Run(context.Background(), RunRequest{RunID: "serve-xor", Scape: "xor", Population: 6, Generations: 2, Seed: 9}); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	server, err := client.NewModelServer(context.Background(), ServeModelRequest{
+		RunID:       "serve-xor",
+		MaxBatch:    8,
+		BatchWindow: 20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("new model server: %v", err)
+	}
+	t.Cleanup(server.Close)
+
+	info := server.Info()
+	if info.RunID != "serve-xor" || info.Scape != "xor" || info.Inputs != 2 || info.Outputs != 1 {
+		t.Fatalf("unexpected model info: %+v", info)
+	}
+
+	httpServer := httptest.NewServer(server.Handler())
+	t.Cleanup(httpServer.Close)
+
+	const requests = 4
+	results := make([][]float64, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			body := fmt.Sprintf(`{"inputs": [[%d, 1]]}`, i%2)
+			resp, err := http.Post(httpServer.URL+"/predict", "application/json", strings.NewReader(body))
+			if err != nil {
+				t.Errorf("predict %d: %v", i, err)
+				return
+			}
+			defer resp.Body.Close()
+			var decoded struct {
+				Outputs [][]float64 `json:"outputs"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil || resp.StatusCode != http.StatusOK {
+				t.Errorf("predict %d: status=%d err=%v", i, resp.StatusCode, err)
+				return
+			}
+			if len(decoded.Outputs) != 1 || len(decoded.Outputs[0]) != 1 {
+				t.Errorf("predict %d: unexpected outputs %v", i, decoded.Outputs)
+				return
+			}
+			results[i] = decoded.Outputs[0]
+		}(i)
+	}
+	wg.Wait()
+
+	if info.Batched && results[0][0] != results[2][0] {
+		t.Fatalf("expected identical inputs to give identical outputs, got %v and %v", results[0], results[2])
+	}
+
+	resp, err := http.Post(httpServer.URL+"/predict", "application/json", strings.NewReader(`{"inputs": [[1]]}`))
+	if err != nil {
+		t.Fatalf("predict wrong width: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected bad request for wrong input width, got %d", resp.StatusCode)
+	}
+
+	metrics := server.Metrics()
+	if metrics.Requests != requests+1 || metrics.Rows != requests || metrics.Errors != 1 {
+		t.Fatalf("unexpected metrics: %+v", metrics)
+	}
+	if metrics.Batches < 1 || metrics.Batches > requests || metrics.LatencyMaxMS <= 0 {
+		t.Fatalf("unexpected batch/latency metrics: %+v", metrics)
+	}
+}
+
+func TestClientSimilarGenomesFindsNearestAcrossRuns(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	for _, runID := range []string{"similar-a", "similar-b"} {
+		if _, err := client.Run(context.Background(), RunRequest{RunID: runID, Scape: "xor", Population: 6, Generations: 2, Seed: 11}); err != nil {
+			t.Fatalf("run %s: %v", runID, err)
+		}
+	}
+
+	for _, metric := range []string{"compatibility", "embedding"} {
+		result, err := client.SimilarGenomes(context.Background(), SimilarGenomesRequest{RunID: "similar-b", K: 3, Metric: metric})
+		if err != nil {
+			t.Fatalf("similar genomes %s: %v", metric, err)
+		}
+		if result.QueryRunID != "similar-b" || result.QueryGenomeID == "" || len(result.Matches) != 3 {
+			t.Fatalf("unexpected %s result: %+v", metric, result)
+		}
+		for i, match := range result.Matches {
+			if match.GenomeID == result.QueryGenomeID && match.RunID == result.QueryRunID {
+				t.Fatalf("expected query genome to be excluded: %+v", match)
+			}
+			if i > 0 && match.Distance < result.Matches[i-1].Distance {
+				t.Fatalf("expected matches ordered by distance: %+v", result.Matches)
+			}
+		}
+		// Both runs share a seed, so run a rediscovers run b's champion exactly.
+		best := result.Matches[0]
+		if best.RunID != "similar-a" || best.GenomeID != result.QueryGenomeID || best.Distance != 0 || !best.SameFingerprint {
+			t.Fatalf("expected identical champion from the seed-matched run first, got %+v", best)
+		}
+	}
+
+	if _, err := client.SimilarGenomes(context.Background(), SimilarGenomesRequest{RunID: "similar-a", Metric: "cosine"}); err == nil {
+		t.Fatal("expected unknown metric to be rejected")
+	}
+	if _, err := client.SimilarGenomes(context.Background(), SimilarGenomesRequest{GenomeID: "missing"}); err == nil {
+		t.Fatal("expected unknown query genome to be rejected")
+	}
+}
+
+func TestClientRunNumericGuardOptions(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Run(context.Background(), RunRequest{Scape: "xor", Population: 4, Generations: 1, WeightClamp: -1}); err == nil {
+		t.Fatal("expected negative weight clamp to be rejected")
+	}
+	if _, err := client.Run(context.Background(), RunRequest{Scape: "xor", Population: 4, Generations: 1, FitnessPostprocessor: "numeric_fragility"}); err == nil {
+		t.Fatal("expected numeric_fragility without a clamp to be rejected")
+	}
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:                "guarded-xor",
+		Scape:                "xor",
+		Population:           6,
+		Generations:          2,
+		Seed:                 4,
+		ActivationClamp:      20,
+		WeightClamp:          5,
+		FitnessPostprocessor: "numeric_fragility",
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	cfg, ok, err := stats.ReadRunConfig(client.benchmarksDir, summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.ActivationClamp != 20 || cfg.WeightClamp != 5 || cfg.FitnessPostprocessor != "numeric_fragility" {
+		t.Fatalf("expected numeric guard settings in run config, got %+v", cfg)
+	}
+}
+
+func TestClientRunCrossoverOptions(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Run(context.Background(), RunRequest{Scape: "xor", Population: 4, Generations: 1, InterspeciesMating: 0.5}); err == nil {
+		t.Fatal("expected interspecies mating without a crossover rate to be rejected")
+	}
+	if _, err := client.Run(context.Background(), RunRequest{Scape: "xor", Population: 4, Generations: 1, CrossoverRate: 0.5, EvolutionType: "online"}); err == nil {
+		t.Fatal("expected crossover with online evolution to be rejected")
+	}
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:              "crossover-xor",
+		Scape:              "xor",
+		Population:         8,
+		Generations:        3,
+		Seed:               6,
+		CrossoverRate:      0.8,
+		InterspeciesMating: 0.25,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	cfg, ok, err := stats.ReadRunConfig(client.benchmarksDir, summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.CrossoverRate != 0.8 || cfg.InterspeciesMating != 0.25 {
+		t.Fatalf("expected crossover settings in run config, got %+v", cfg)
+	}
+	lineage, err := client.Lineage(context.Background(), LineageRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("lineage: %v", err)
+	}
+	mated := 0
+	for _, item := range lineage {
+		if item.MateID != "" {
+			mated++
+		}
+	}
+	if mated == 0 {
+		t.Fatal("expected crossover offspring with a recorded mate in lineage")
+	}
+}
+
+func TestClientRunKarmaOptions(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Run(context.Background(), RunRequest{Scape: "xor", Population: 4, Generations: 1, KarmaCooldown: 3}); err == nil {
+		t.Fatal("expected karma cooldown without strikes to be rejected")
+	}
+	if _, err := client.Run(context.Background(), RunRequest{Scape: "xor", Population: 4, Generations: 1, EvaluationTimeout: -time.Second}); err == nil {
+		t.Fatal("expected negative evaluation timeout to be rejected")
+	}
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:             "karma-xor",
+		Scape:             "xor",
+		Population:        6,
+		Generations:       2,
+		Seed:              8,
+		EvaluationTimeout: time.Minute,
+		KarmaStrikes:      2,
+		KarmaCooldown:     4,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	cfg, ok, err := stats.ReadRunConfig(client.benchmarksDir, summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.EvaluationTimeoutMS != 60000 || cfg.KarmaStrikes != 2 || cfg.KarmaCooldown != 4 {
+		t.Fatalf("expected karma settings in run config, got %+v", cfg)
+	}
+}
+
+func TestClientEventsFiltersAndAppendsOnContinuation(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Events(context.Background(), EventsRequest{RunID: "missing"}); err == nil {
+		t.Fatal("expected missing run events to fail")
+	}
+
+	if _, err := client.Run(context.Background(), RunRequest{
+		RunID:       "events-xor",
+		Scape:       "xor",
+		Population:  8,
+		Generations: 2,
+		Seed:        5,
+	}); err != nil {
+		t.Fatalf("seed run: %v", err)
+	}
+	if _, err := client.Events(context.Background(), EventsRequest{RunID: "events-xor", Type: "bogus"}); err == nil {
+		t.Fatal("expected unknown event type to be rejected")
+	}
+	created, err := client.Events(context.Background(), EventsRequest{RunID: "events-xor", Type: evo.EventSpeciesCreated})
+	if err != nil {
+		t.Fatalf("events: %v", err)
+	}
+	if len(created) == 0 || created[0].Generation != 1 {
+		t.Fatalf("expected species_created events from generation 1, got %+v", created)
+	}
+	for _, event := range created {
+		if event.Type != evo.EventSpeciesCreated {
+			t.Fatalf("unexpected event outside type filter: %+v", event)
+		}
+	}
+	first, err := client.Events(context.Background(), EventsRequest{Latest: true})
+	if err != nil {
+		t.Fatalf("latest events: %v", err)
+	}
+	limited, err := client.Events(context.Background(), EventsRequest{Latest: true, Limit: 1})
+	if err != nil {
+		t.Fatalf("limited events: %v", err)
+	}
+	if len(limited) != 1 || limited[0] != first[0] {
+		t.Fatalf("expected limit to keep the first event, got %+v", limited)
+	}
+
+	if _, err := client.Run(context.Background(), RunRequest{
+		ContinuePopulationID: "events-xor",
+		Scape:                "xor",
+		Generations:          2,
+		Seed:                 6,
+	}); err != nil {
+		t.Fatalf("continued run: %v", err)
+	}
+	continued, err := client.Events(context.Background(), EventsRequest{RunID: "events-xor"})
+	if err != nil {
+		t.Fatalf("continued events: %v", err)
+	}
+	if len(continued) <= len(first) || continued[0] != first[0] {
+		t.Fatalf("expected continuation to append to the event log: before=%d after=%d", len(first), len(continued))
+	}
+	if last := continued[len(continued)-1]; last.Generation < 3 {
+		t.Fatalf("expected continuation events numbered after the seed run, got %+v", last)
+	}
+}
+
+func TestRunParityScapeUsesConstructionParams(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Run(context.Background(), RunRequest{
+		RunID:       "xor-params",
+		Scape:       "xor",
+		ScapeParams: map[string]string{"n": "4"},
+		Population:  4,
+		Generations: 1,
+	}); err == nil {
+		t.Fatal("expected xor to reject construction parameters")
+	}
+	if _, err := client.Run(context.Background(), RunRequest{
+		RunID:       "parity-wide",
+		Scape:       "parity",
+		ScapeParams: map[string]string{"n": "40"},
+		Population:  4,
+		Generations: 1,
+	}); err == nil {
+		t.Fatal("expected out-of-range parity width to be rejected")
+	}
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:       "parity-4",
+		Scape:       "parity",
+		ScapeParams: map[string]string{"n": "4"},
+		Population:  6,
+		Generations: 2,
+		Seed:        11,
+	})
+	if err != nil {
+		t.Fatalf("run parity: %v", err)
+	}
+	cfg, ok, err := stats.ReadRunConfig(filepath.Join(base, "benchmarks"), summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.ScapeParams["n"] != "4" {
+		t.Fatalf("expected scape params to be recorded, got %+v", cfg.ScapeParams)
+	}
+	top, err := client.TopGenomes(context.Background(), TopGenomesRequest{RunID: summary.RunID, Limit: 1})
+	if err != nil {
+		t.Fatalf("top genomes: %v", err)
+	}
+	if len(top) == 0 {
+		t.Fatal("expected a top genome")
+	}
+	inputs := 0
+	for _, neuron := range top[0].Genome.Neurons {
+		if strings.HasPrefix(neuron.ID, "i") {
+			inputs++
+		}
+	}
+	if inputs != 4 {
+		t.Fatalf("expected 4 input neurons, got %d", inputs)
+	}
+}
+
+func TestRunDTMScapeUsesConfigurableParams(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	params := map[string]string{"left_reward": "0.5", "runs": "12", "switch_floor": "4"}
+	if _, err := client.Run(context.Background(), RunRequest{
+		Scape:       "dtm",
+		ScapeParams: map[string]string{"runs": "0"},
+		Population:  4,
+		Generations: 1,
+	}); err == nil || !strings.Contains(err.Error(), "runs") {
+		t.Fatalf("expected invalid dtm runs to be rejected, got %v", err)
+	}
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:       "dtm-short",
+		Scape:       "dtm",
+		ScapeParams: params,
+		Population:  6,
+		Generations: 2,
+		Seed:        3,
+	})
+	if err != nil {
+		t.Fatalf("run dtm: %v", err)
+	}
+	cfg, ok, err := stats.ReadRunConfig(filepath.Join(base, "benchmarks"), summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if !reflect.DeepEqual(cfg.ScapeParams, params) {
+		t.Fatalf("expected scape params to be recorded, got %+v", cfg.ScapeParams)
+	}
+
+	built, ok, err := buildRunScape(RunRequest{Scape: "dtm", ScapeParams: params})
+	if err != nil || !ok {
+		t.Fatalf("build configured dtm: ok=%t err=%v", ok, err)
+	}
+	_, trace, err := built.Evaluate(context.Background(), flatlandRunStepAgent{id: "dtm-api"})
+	if err != nil {
+		t.Fatalf("evaluate configured dtm: %v", err)
+	}
+	if runs, _ := trace["total_runs"].(int); runs != 12 {
+		t.Fatalf("expected the run to deliver runs=12 to the scape, got %+v", trace["total_runs"])
+	}
+	if _, ok, err := buildRunScape(RunRequest{Scape: "dtm"}); ok || err != nil {
+		t.Fatalf("expected the default dtm instance without params, got ok=%t err=%v", ok, err)
+	}
+}
+
+func TestRunFunctionApproxSurfaceSeedsTwoInputs(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Run(context.Background(), RunRequest{
+		RunID:       "fnapprox-bad",
+		Scape:       "function-approx",
+		ScapeParams: map[string]string{"fn": "cosine"},
+		Population:  4,
+		Generations: 1,
+	}); err == nil {
+		t.Fatal("expected unknown target function to be rejected")
+	}
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:       "fnapprox-saddle",
+		Scape:       "fnapprox",
+		ScapeParams: map[string]string{"fn": "saddle", "samples": "16", "noise": "0.05"},
+		Population:  6,
+		Generations: 2,
+		Seed:        3,
+	})
+	if err != nil {
+		t.Fatalf("run function-approx: %v", err)
+	}
+	top, err := client.TopGenomes(context.Background(), TopGenomesRequest{RunID: summary.RunID, Limit: 1})
+	if err != nil || len(top) == 0 {
+		t.Fatalf("top genomes: %+v err=%v", top, err)
+	}
+	inputs := 0
+	for _, neuron := range top[0].Genome.Neurons {
+		if neuron.ID == "i1" || neuron.ID == "i2" {
+			inputs++
+		}
+	}
+	if inputs != 2 {
+		t.Fatalf("expected two surface inputs, got %d", inputs)
+	}
+}
+
+func TestClientRunCrossValidatedScapeStoresFoldFitness(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:       "epitopes-cv",
+		Scape:       "epitopes",
+		ScapeParams: map[string]string{"folds": "4"},
+		Population:  6,
+		Generations: 2,
+		Seed:        5,
+	})
+	if err != nil {
+		t.Fatalf("run cross-validated epitopes: %v", err)
+	}
+	top, err := client.TopGenomes(context.Background(), TopGenomesRequest{RunID: summary.RunID, Limit: 1})
+	if err != nil || len(top) == 0 {
+		t.Fatalf("top genomes: %+v err=%v", top, err)
+	}
+	if len(top[0].FoldFitness) != 4 {
+		t.Fatalf("expected the champion's four fold results in the store, got %+v", top[0].FoldFitness)
+	}
+	mean := 0.0
+	for _, fitness := range top[0].FoldFitness {
+		mean += fitness / 4
+	}
+	if math.Abs(mean-top[0].Fitness) > 1e-9 {
+		t.Fatalf("expected champion fitness %f to be the mean fold fitness %f", top[0].Fitness, mean)
+	}
+	artifact, ok, err := stats.ReadTopGenomes(filepath.Join(base, "benchmarks"), summary.RunID)
+	if err != nil || !ok || len(artifact[0].FoldFitness) != 4 {
+		t.Fatalf("expected fold results in the top genomes artifact, got %+v ok=%t err=%v", artifact, ok, err)
+	}
+}
+
+func TestReplayGruauPole2BenchmarkReportsGeneralization(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Run(context.Background(), RunRequest{
+		RunID:       "pole2-gruau",
+		Scape:       "pole2-balancing",
+		ScapeParams: map[string]string{"fitness": "gruau"},
+		Population:  6,
+		Generations: 2,
+		Seed:        7,
+	}); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	summary, err := client.Replay(context.Background(), ReplayRequest{RunID: "pole2-gruau", Mode: "benchmark"})
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if !strings.HasPrefix(summary.Report, "pole2_gruau task=dpnv ") || !strings.Contains(summary.Report, "/625") {
+		t.Fatalf("expected gruau generalization report, got %q", summary.Report)
+	}
+	gt, err := client.Replay(context.Background(), ReplayRequest{RunID: "pole2-gruau"})
+	if err != nil {
+		t.Fatalf("replay gt: %v", err)
+	}
+	if gt.Report != "" {
+		t.Fatalf("expected no report for gt replay, got %q", gt.Report)
+	}
+}
+
+func TestClientCrossEvalBuildsMatrix(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	runs := []RunRequest{
+		{RunID: "sine-clean", Scape: "function-approx", Seed: 1},
+		{RunID: "sine-noisy", Scape: "function-approx", ScapeParams: map[string]string{"noise": "0.3", "samples": "9"}, Seed: 2},
+		{RunID: "saddle", Scape: "function-approx", ScapeParams: map[string]string{"fn": "saddle"}, Seed: 3},
+	}
+	for _, req := range runs {
+		req.Population = 6
+		req.Generations = 2
+		if _, err := client.Run(context.Background(), req); err != nil {
+			t.Fatalf("run %s: %v", req.RunID, err)
+		}
+	}
+
+	if _, err := client.CrossEval(context.Background(), CrossEvalRequest{RunIDs: []string{"sine-clean"}}); err == nil {
+		t.Fatal("expected a single run to be rejected")
+	}
+	if _, err := client.CrossEval(context.Background(), CrossEvalRequest{RunIDs: []string{"sine-clean", "sine-clean"}}); err == nil {
+		t.Fatal("expected duplicate runs to be rejected")
+	}
+	if _, err := client.CrossEval(context.Background(), CrossEvalRequest{RunIDs: []string{"sine-clean", "saddle"}, Modes: []string{"bogus"}}); err == nil {
+		t.Fatal("expected unknown mode to be rejected")
+	}
+
+	result, err := client.CrossEval(context.Background(), CrossEvalRequest{RunIDs: []string{"sine-clean", "sine-noisy", "saddle"}})
+	if err != nil {
+		t.Fatalf("cross eval: %v", err)
+	}
+	if len(result.Modes) != 2 || result.Modes[0] != "validation" || result.Modes[1] != "test" {
+		t.Fatalf("expected default validation/test modes, got %v", result.Modes)
+	}
+	if len(result.Cells) != 2*3*3 {
+		t.Fatalf("expected 18 cells, got %d", len(result.Cells))
+	}
+	for _, cell := range result.Cells {
+		surface := cell.ChampionRunID == "saddle" || cell.EvalRunID == "saddle"
+		mixed := surface && cell.ChampionRunID != cell.EvalRunID
+		if mixed && !strings.Contains(cell.Error, "morphology mismatch") {
+			t.Fatalf("expected morphology mismatch for %+v", cell)
+		}
+		if !mixed && cell.Error != "" {
+			t.Fatalf("unexpected cell error: %+v", cell)
+		}
+	}
+	if len(result.Gaps) != 4 {
+		t.Fatalf("expected gaps for the two sine champions in both modes, got %+v", result.Gaps)
+	}
+	for _, gap := range result.Gaps {
+		if gap.Others != 1 || gap.Gap != gap.Self-gap.MeanOther {
+			t.Fatalf("unexpected gap: %+v", gap)
+		}
+	}
+}
+
+func TestRunStopConditionEndsRunAndPersists(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Run(context.Background(), RunRequest{
+		RunID:         "stop-invalid",
+		Scape:         "xor",
+		Population:    4,
+		Generations:   3,
+		StopCondition: "best>=1 && fitness>0",
+	}); err == nil || !strings.Contains(err.Error(), "unknown variable") {
+		t.Fatalf("expected invalid stop condition to be rejected, got %v", err)
+	}
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:         "stop-compound",
+		Scape:         "xor",
+		Population:    6,
+		Generations:   8,
+		Seed:          5,
+		StopCondition: "best>=2 || generation>=3",
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(summary.BestByGeneration) != 3 {
+		t.Fatalf("expected stop condition to end the run after 3 generations, got %d", len(summary.BestByGeneration))
+	}
+	cfg, ok, err := stats.ReadRunConfig(filepath.Join(base, "benchmarks"), summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.StopCondition != "best>=2 || generation>=3" {
+		t.Fatalf("expected stop condition in run config, got %q", cfg.StopCondition)
+	}
+}
+
+func TestRunEntropyRestartRecordsDecisions(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Run(context.Background(), RunRequest{
+		RunID:         "entropy-invalid",
+		Scape:         "xor",
+		Population:    4,
+		Generations:   2,
+		EntropyAction: "restart",
+	}); err == nil || !strings.Contains(err.Error(), "require an entropy threshold") {
+		t.Fatalf("expected entropy action without threshold to be rejected, got %v", err)
+	}
+
+	// A threshold of one fires on any population that is not fully diverse.
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:            "entropy-restart",
+		Scape:            "xor",
+		Population:       8,
+		Generations:      3,
+		Seed:             9,
+		EntropyThreshold: 1,
+		EntropyAction:    "restart",
+		EntropyCooldown:  1,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	benchmarksDir := filepath.Join(base, "benchmarks")
+	cfg, ok, err := stats.ReadRunConfig(benchmarksDir, summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.EntropyThreshold != 1 || cfg.EntropyAction != "restart" || cfg.EntropyCooldown != 1 {
+		t.Fatalf("expected entropy settings in run config, got %+v", cfg)
+	}
+	events, ok, err := stats.ReadEvents(benchmarksDir, summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read events: ok=%t err=%v", ok, err)
+	}
+	triggered := 0
+	for _, event := range events {
+		if event.Type == "entropy_low" && strings.Contains(event.Detail, ": restart ") {
+			triggered++
+		}
+	}
+	if triggered != 3 {
+		t.Fatalf("expected a restart decision every generation, got %d in %+v", triggered, events)
+	}
+}
+
+func TestSpeciesDiffChampionDeltas(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		Scape:       "xor",
+		Population:  8,
+		Generations: 4,
+		Seed:        13,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	plain, err := client.SpeciesDiff(context.Background(), SpeciesDiffRequest{RunID: summary.RunID, FromGeneration: 1, ToGeneration: 4})
+	if err != nil {
+		t.Fatalf("species diff: %v", err)
+	}
+	for _, item := range plain.Changed {
+		if item.Champion != nil {
+			t.Fatalf("expected no champion delta unless requested, got %+v", item)
+		}
+	}
+
+	diff, err := client.SpeciesDiff(context.Background(), SpeciesDiffRequest{
+		RunID:          summary.RunID,
+		FromGeneration: 1,
+		ToGeneration:   4,
+		ChampionDeltas: true,
+	})
+	if err != nil {
+		t.Fatalf("species diff with champion deltas: %v", err)
+	}
+	traceAcc, _, err := stats.ReadTraceAcc(filepath.Join(base, "benchmarks"), summary.RunID)
+	if err != nil {
+		t.Fatalf("read trace_acc: %v", err)
+	}
+	championAt := func(generation int, key string) model.Genome {
+		for _, entry := range traceAcc {
+			if entry.Generation != generation {
+				continue
+			}
+			for _, stat := range entry.Stats {
+				if stat.SpeciesKey == key {
+					return stat.ChampionGenome
+				}
+			}
+		}
+		return model.Genome{}
+	}
+	withChampion := 0
+	for _, item := range diff.Changed {
+		if item.Champion == nil {
+			continue
+		}
+		withChampion++
+		from, to := championAt(1, item.Key), championAt(4, item.Key)
+		if item.Champion.FromGenomeID != from.ID || item.Champion.ToGenomeID != to.ID {
+			t.Fatalf("champion ids %s->%s do not match trace_acc %s->%s", item.Champion.FromGenomeID, item.Champion.ToGenomeID, from.ID, to.ID)
+		}
+		want := genotype.DiffGenomes(from, to)
+		if !reflect.DeepEqual(item.Champion.GenomeDelta, want) {
+			t.Fatalf("unexpected champion delta for %s: got %+v want %+v", item.Key, item.Champion.GenomeDelta, want)
+		}
+	}
+	if withChampion == 0 {
+		t.Fatalf("expected at least one changed species with a champion delta: %+v", diff.Changed)
+	}
+}
+
+func TestClientPlotRendersRunCharts(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		Scape:       "xor",
+		Population:  6,
+		Generations: 3,
+		Seed:        21,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	fitness, err := client.Plot(context.Background(), PlotRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("plot fitness: %v", err)
+	}
+	if fitness.What != PlotFitness || fitness.Format != PlotFormatSVG || fitness.Generations != 3 {
+		t.Fatalf("unexpected plot result: %+v", fitness)
+	}
+	if svg := string(fitness.Data); !strings.Contains(svg, "<svg") || !strings.Contains(svg, ">best<") || !strings.Contains(svg, ">mean<") {
+		t.Fatalf("unexpected fitness svg: %s", svg)
+	}
+
+	species, err := client.Plot(context.Background(), PlotRequest{Latest: true, What: "species", Format: "png"})
+	if err != nil {
+		t.Fatalf("plot species: %v", err)
+	}
+	if species.RunID != summary.RunID || !bytes.HasPrefix(species.Data, []byte("\x89PNG")) {
+		t.Fatalf("expected png species plot of the latest run, got run=%s prefix=%q", species.RunID, species.Data[:min(8, len(species.Data))])
+	}
+
+	tuning, err := client.Plot(context.Background(), PlotRequest{RunID: summary.RunID, What: "tuning"})
+	if err != nil {
+		t.Fatalf("plot tuning: %v", err)
+	}
+	if !strings.Contains(string(tuning.Data), ">accepted<") {
+		t.Fatalf("unexpected tuning svg: %s", tuning.Data)
+	}
+
+	if _, err := client.Plot(context.Background(), PlotRequest{RunID: summary.RunID, What: "weights"}); err == nil {
+		t.Fatal("expected unsupported plot subject to fail")
+	}
+	if _, err := client.Plot(context.Background(), PlotRequest{RunID: summary.RunID, Format: "gif"}); err == nil {
+		t.Fatal("expected unsupported plot format to fail")
+	}
+}
+
+func TestAnnotateGenomeUpdatesStoredAndArtifactTopGenomes(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		Scape:       "xor",
+		Population:  6,
+		Generations: 2,
+		Seed:        17,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if _, err := client.AnnotateGenome(context.Background(), AnnotateGenomeRequest{RunID: summary.RunID}); err == nil {
+		t.Fatal("expected an empty annotation edit to be rejected")
+	}
+
+	annotated, err := client.AnnotateGenome(context.Background(), AnnotateGenomeRequest{
+		RunID: summary.RunID,
+		Set:   map[string]string{"reviewed": "yes", "tag": "draft"},
+		Note:  "solves xor cleanly",
+	})
+	if err != nil {
+		t.Fatalf("annotate: %v", err)
+	}
+	if annotated.Rank != 1 || annotated.Annotations["reviewed"] != "yes" {
+		t.Fatalf("unexpected annotated champion: %+v", annotated)
+	}
+	annotated, err = client.AnnotateGenome(context.Background(), AnnotateGenomeRequest{
+		RunID:    summary.RunID,
+		GenomeID: annotated.GenomeID,
+		Unset:    []string{"tag"},
+	})
+	if err != nil {
+		t.Fatalf("unset annotation: %v", err)
+	}
+	if !reflect.DeepEqual(annotated.Annotations, map[string]string{"reviewed": "yes"}) {
+		t.Fatalf("unexpected annotations after unset: %+v", annotated.Annotations)
+	}
+
+	top, err := client.TopGenomes(context.Background(), TopGenomesRequest{RunID: summary.RunID, Limit: 1})
+	if err != nil {
+		t.Fatalf("top genomes: %v", err)
+	}
+	champion := top[0].Genome
+	if champion.ID != annotated.GenomeID || champion.Annotations["reviewed"] != "yes" {
+		t.Fatalf("expected stored champion to carry annotations, got %+v", champion.Annotations)
+	}
+	if champion.Provenance == nil || !reflect.DeepEqual(champion.Provenance.Notes, []string{"solves xor cleanly"}) {
+		t.Fatalf("expected stored champion note, got %+v", champion.Provenance)
+	}
+	artifactTop, ok, err := stats.ReadTopGenomes(filepath.Join(base, "benchmarks"), summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read top genome artifact: ok=%t err=%v", ok, err)
+	}
+	if !reflect.DeepEqual(artifactTop[0].Genome.Annotations, champion.Annotations) {
+		t.Fatalf("expected artifact champion annotations %+v, got %+v", champion.Annotations, artifactTop[0].Genome.Annotations)
+	}
+
+	if _, err := client.AnnotateGenome(context.Background(), AnnotateGenomeRequest{
+		RunID:    summary.RunID,
+		GenomeID: "missing",
+		Note:     "x",
+	}); err == nil {
+		t.Fatal("expected an unknown genome to be rejected")
+	}
+}
+
+func TestRunRecurrentLoopWeightAddsRecurrentSynapses(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Run(context.Background(), RunRequest{
+		Scape:               "xor",
+		Population:          4,
+		Generations:         1,
+		WeightRecurrentLoop: -1,
+	}); err == nil {
+		t.Fatal("expected a negative recurrent loop weight to be rejected")
+	}
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:                  "recurrent-loop",
+		Scape:                  "xor",
+		Population:             8,
+		Generations:            3,
+		Seed:                   9,
+		WeightPerturb:          0.1,
+		WeightRecurrentLoop:    5,
+		RecurrentLoopMaxLength: 2,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	lineage, err := client.Lineage(context.Background(), LineageRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("lineage: %v", err)
+	}
+	looped := 0
+	for _, item := range lineage {
+		if strings.Contains(item.Operation, "add_recurrent_loop") {
+			looped++
+		}
+	}
+	if looped == 0 {
+		t.Fatal("expected offspring produced by add_recurrent_loop")
+	}
+	cfg, ok, err := stats.ReadRunConfig(filepath.Join(base, "benchmarks"), summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.WeightRecurrentLoop != 5 || cfg.RecurrentLoopMaxLength != 2 {
+		t.Fatalf("expected recurrent loop settings to be recorded, got weight=%g max=%d", cfg.WeightRecurrentLoop, cfg.RecurrentLoopMaxLength)
+	}
+}
+
+func TestRunDuplicateNeuronWeightDuplicatesHiddenNeurons(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:                 "duplicate-neuron",
+		Scape:                 "xor",
+		Population:            8,
+		Generations:           4,
+		Seed:                  13,
+		WeightPerturb:         0.1,
+		WeightAddNeuron:       1,
+		WeightDuplicateNeuron: 5,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	lineage, err := client.Lineage(context.Background(), LineageRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("lineage: %v", err)
+	}
+	duplicated := 0
+	for _, item := range lineage {
+		if strings.Contains(item.Operation, "duplicate_neuron") {
+			duplicated++
+		}
+	}
+	if duplicated == 0 {
+		t.Fatal("expected offspring produced by duplicate_neuron")
+	}
+	cfg, ok, err := stats.ReadRunConfig(filepath.Join(base, "benchmarks"), summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.WeightDuplicateNeuron != 5 {
+		t.Fatalf("expected duplicate neuron weight to be recorded, got %g", cfg.WeightDuplicateNeuron)
+	}
+}
+
+func TestRunToggleSynapseWeightDisablesAndPrunesLinks(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:               "toggle-synapse",
+		Scape:               "xor",
+		Population:          8,
+		Generations:         6,
+		Seed:                17,
+		WeightPerturb:       0.1,
+		WeightAddSynapse:    1,
+		WeightToggleSynapse: 5,
+		PruneDisabledAfter:  1,
+		PruneDisabledProb:   1,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	lineage, err := client.Lineage(context.Background(), LineageRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("lineage: %v", err)
+	}
+	toggled, pruned := 0, 0
+	for _, item := range lineage {
+		if strings.Contains(item.Operation, "toggle_synapse_enabled") {
+			toggled++
+		}
+		if strings.Contains(item.Operation, evo.OperationPruneDisabled) {
+			pruned++
+		}
+	}
+	if toggled == 0 || pruned == 0 {
+		t.Fatalf("expected toggled and pruned offspring, got toggled=%d pruned=%d", toggled, pruned)
+	}
+	cfg, ok, err := stats.ReadRunConfig(filepath.Join(base, "benchmarks"), summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.WeightToggleSynapse != 5 || cfg.PruneDisabledAfter != 1 || cfg.PruneDisabledProb != 1 {
+		t.Fatalf("expected toggle weight and pruning to be recorded, got %g/%d/%g", cfg.WeightToggleSynapse, cfg.PruneDisabledAfter, cfg.PruneDisabledProb)
+	}
+}
+
+func TestRunTrackInnovationsPersistsRegistry(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:            "innovations",
+		Scape:            "xor",
+		Population:       6,
+		Generations:      3,
+		Seed:             5,
+		WeightPerturb:    0.1,
+		WeightAddSynapse: 1,
+		TrackInnovations: true,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	records, err := client.Innovations(context.Background(), InnovationsRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("innovations: %v", err)
+	}
+	if len(records) == 0 {
+		t.Fatal("expected persisted innovation records")
+	}
+	for i, record := range records {
+		if record.Innovation <= 0 || record.Key == "" {
+			t.Fatalf("unexpected record %+v", record)
+		}
+		if i > 0 && record.Innovation < records[i-1].Innovation {
+			t.Fatalf("expected records ordered by innovation, got %+v", records)
+		}
+	}
+	cfg, ok, err := stats.ReadRunConfig(filepath.Join(base, "benchmarks"), summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if !cfg.TrackInnovations {
+		t.Fatal("expected track innovations to be recorded in run config")
+	}
+
+	if _, err := client.Run(context.Background(), RunRequest{
+		RunID:       "untracked",
+		Scape:       "xor",
+		Population:  4,
+		Generations: 1,
+		Seed:        5,
+	}); err != nil {
+		t.Fatalf("untracked run: %v", err)
+	}
+	if _, err := client.Innovations(context.Background(), InnovationsRequest{RunID: "untracked"}); err == nil {
+		t.Fatal("expected no innovations for an untracked run")
+	}
+}
+
+func TestRunInitialSensorSubsetsSeedPartialMorphologies(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:             "sensor-subsets",
+		Scape:             "fx",
+		Population:        6,
+		Generations:       1,
+		Seed:              13,
+		InitialSensorsMin: 2,
+		InitialSensorsMax: 3,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	top, err := client.TopGenomes(context.Background(), TopGenomesRequest{RunID: summary.RunID, Limit: 6})
+	if err != nil {
+		t.Fatalf("top genomes: %v", err)
+	}
+	if len(top) == 0 {
+		t.Fatal("expected top genomes")
+	}
+	for _, record := range top {
+		if len(record.Genome.SensorIDs) < 2 || len(record.Genome.SensorIDs) > 3 {
+			t.Fatalf("expected seed genomes with 2-3 sensors, got %v", record.Genome.SensorIDs)
+		}
+	}
+	cfg, ok, err := stats.ReadRunConfig(filepath.Join(base, "benchmarks"), summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.InitialSensorsMin != 2 || cfg.InitialSensorsMax != 3 {
+		t.Fatalf("expected sensor subset bounds to be recorded, got %d/%d", cfg.InitialSensorsMin, cfg.InitialSensorsMax)
+	}
+
+	if _, err := client.Run(context.Background(), RunRequest{
+		Scape:             "fx",
+		Population:        4,
+		Generations:       1,
+		InitialSensorsMin: 4,
+		InitialSensorsMax: 3,
+	}); err == nil {
+		t.Fatal("expected inverted sensor subset bounds to be rejected")
+	}
+}
+
+func TestRunRecordsChampionsOnScapeLeaderboard(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	for _, req := range []RunRequest{
+		{RunID: "board-short", Scape: "xor", Population: 6, Generations: 1, Seed: 3},
+		{RunID: "board-long", Scape: "xor", Population: 8, Generations: 3, Seed: 5},
+		{RunID: "board-other", Scape: "regression-mimic", Population: 6, Generations: 1, Seed: 3},
+	} {
+		if _, err := client.Run(context.Background(), req); err != nil {
+			t.Fatalf("run %s: %v", req.RunID, err)
+		}
+	}
+
+	entries, err := client.Leaderboard(context.Background(), LeaderboardRequest{Scape: "xor"})
+	if err != nil {
+		t.Fatalf("leaderboard: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected the two xor champions, got %+v", entries)
+	}
+	for i, entry := range entries {
+		if entry.ValidationFitness == nil || entry.GenomeID == "" || entry.CreatedAtUTC == "" {
+			t.Fatalf("expected a validated champion entry, got %+v", entry)
+		}
+		if i > 0 && *entry.ValidationFitness > *entries[i-1].ValidationFitness {
+			t.Fatalf("expected entries ranked by validation fitness, got %+v", entries)
+		}
+		if entry.RunID == "board-long" && !strings.Contains(entry.Config, "population=8 generations=3") {
+			t.Fatalf("expected the run's config summary, got %q", entry.Config)
+		}
+	}
+
+	top, err := client.Leaderboard(context.Background(), LeaderboardRequest{Scape: "xor", Limit: 1})
+	if err != nil || len(top) != 1 || top[0].RunID != entries[0].RunID {
+		t.Fatalf("expected the limited leaderboard to keep the leader, got %+v err=%v", top, err)
+	}
+	if _, err := client.Leaderboard(context.Background(), LeaderboardRequest{}); err == nil {
+		t.Fatal("expected leaderboard without scape to fail")
+	}
+}
+
+func TestMergeLeaderboardReplacesRunAndKeepsBest(t *testing.T) {
+	validation := func(v float64) *float64 { return &v }
+	entries := []model.LeaderboardEntry{
+		{RunID: "a", ValidationFitness: validation(0.9)},
+		{RunID: "b", ValidationFitness: validation(0.5)},
+	}
+	merged := mergeLeaderboard(entries, model.LeaderboardEntry{RunID: "c", ValidationFitness: validation(0.7)}, 2)
+	if len(merged) != 2 || merged[0].RunID != "a" || merged[1].RunID != "c" {
+		t.Fatalf("expected the best two entries, got %+v", merged)
+	}
+	merged = mergeLeaderboard(merged, model.LeaderboardEntry{RunID: "a", ValidationFitness: validation(0.1)}, 2)
+	if len(merged) != 2 || merged[0].RunID != "c" || merged[1].RunID != "a" {
+		t.Fatalf("expected a rerun to replace its earlier entry, got %+v", merged)
+	}
+	merged = mergeLeaderboard(merged, model.LeaderboardEntry{RunID: "d", ValidationFitness: validation(0.7)}, 3)
+	if merged[0].RunID != "c" || merged[1].RunID != "d" {
+		t.Fatalf("expected ties to keep the earlier entry ahead, got %+v", merged)
+	}
+}
+
+func TestSensorImportanceRanksChampionSensorsByAblation(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:       "sensor-importance",
+		Scape:       "fx",
+		Population:  6,
+		Generations: 2,
+		Seed:        21,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	report, err := client.SensorImportance(context.Background(), SensorImportanceRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("sensor importance: %v", err)
+	}
+	replay, err := client.Replay(context.Background(), ReplayRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if report.GenomeID != replay.GenomeID || report.BaselineFitness != replay.Fitness {
+		t.Fatalf("expected the champion's replay fitness as baseline, got %s %f vs %s %f", report.GenomeID, report.BaselineFitness, replay.GenomeID, replay.Fitness)
+	}
+	if len(report.Sensors) != 11 {
+		t.Fatalf("expected one entry per fx sensor, got %+v", report.Sensors)
+	}
+	changed := false
+	for i, sensor := range report.Sensors {
+		if i > 0 && sensor.Importance > report.Sensors[i-1].Importance {
+			t.Fatalf("expected sensors ranked by importance, got %+v", report.Sensors)
+		}
+		if sensor.Importance != report.BaselineFitness-sensor.AblatedFitness {
+			t.Fatalf("expected importance to be the ablation fitness loss, got %+v", sensor)
+		}
+		if sensor.Importance != 0 {
+			changed = true
+		}
+	}
+	if !changed {
+		t.Fatal("expected ablating some sensor to change fitness")
+	}
+
+	data, err := os.ReadFile(report.Path)
+	if err != nil {
+		t.Fatalf("read report artifact: %v", err)
+	}
+	var stored SensorImportanceReport
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatalf("decode report artifact: %v", err)
+	}
+	if filepath.Dir(report.Path) != filepath.Join(base, "benchmarks", summary.RunID, "sensor_importance") || len(stored.Sensors) != len(report.Sensors) {
+		t.Fatalf("expected the ranking stored in the run artifacts, got %s %+v", report.Path, stored)
+	}
+
+	if _, err := client.SensorImportance(context.Background(), SensorImportanceRequest{RunID: summary.RunID, Mode: "bogus"}); err == nil {
+		t.Fatal("expected unsupported mode to fail")
+	}
+}
+
+func TestTagModuleAndInsertModuleWeightGraftsLibraryModules(t *testing.T) {
+	base := t.TempDir()
+	benchmarks := filepath.Join(base, "benchmarks")
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: benchmarks,
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	ctx := context.Background()
+
+	if _, err := client.Run(ctx, RunRequest{
+		RunID:           "module-source",
+		Scape:           "xor",
+		Population:      8,
+		Generations:     3,
+		Seed:            21,
+		WeightPerturb:   0.1,
+		WeightAddNeuron: 1,
+	}); err != nil {
+		t.Fatalf("source run: %v", err)
+	}
+	module, err := client.TagModule(ctx, TagModuleRequest{RunID: "module-source", ModuleID: "xor-hidden"})
+	if err != nil {
+		t.Fatalf("tag module: %v", err)
+	}
+	if module.SourceRunID != "module-source" || len(module.Neurons) == 0 || len(module.Inputs) == 0 || len(module.Outputs) == 0 {
+		t.Fatalf("unexpected tagged module: %+v", module)
+	}
+	modules, err := client.Modules(ctx)
+	if err != nil || len(modules) != 1 || modules[0].ID != "xor-hidden" {
+		t.Fatalf("expected tagged module in library, got %+v err=%v", modules, err)
+	}
+
+	summary, err := client.Run(ctx, RunRequest{
+		RunID:              "module-graft",
+		Scape:              "xor",
+		Population:         8,
+		Generations:        3,
+		Seed:               22,
+		WeightPerturb:      0.1,
+		WeightInsertModule: 5,
+		Modules:            []string{"xor-hidden"},
+	})
+	if err != nil {
+		t.Fatalf("graft run: %v", err)
+	}
+	lineage, err := client.Lineage(ctx, LineageRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("lineage: %v", err)
+	}
+	grafted := 0
+	for _, item := range lineage {
+		if strings.Contains(item.Operation, "insert_module") {
+			grafted++
+		}
+	}
+	if grafted == 0 {
+		t.Fatal("expected offspring produced by insert_module")
+	}
+	cfg, ok, err := stats.ReadRunConfig(benchmarks, summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.WeightInsertModule != 5 || !reflect.DeepEqual(cfg.Modules, []string{"xor-hidden"}) {
+		t.Fatalf("expected insert module settings to be recorded, got weight=%g modules=%v", cfg.WeightInsertModule, cfg.Modules)
+	}
+
+	if _, err := client.Run(ctx, RunRequest{
+		Scape:              "xor",
+		Population:         4,
+		Generations:        1,
+		WeightInsertModule: 1,
+		Modules:            []string{"missing"},
+	}); err == nil || !strings.Contains(err.Error(), "module not found") {
+		t.Fatalf("expected unknown module to be rejected, got %v", err)
+	}
+}
+
+func TestEditGenomeFreezesMasksInStoredAndArtifactTopGenomes(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		Scape:       "xor",
+		Population:  6,
+		Generations: 2,
+		Seed:        19,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	top, err := client.TopGenomes(context.Background(), TopGenomesRequest{RunID: summary.RunID, Limit: 1})
+	if err != nil {
+		t.Fatalf("top genomes: %v", err)
+	}
+	champion := top[0].Genome
+	neuronID := champion.Neurons[0].ID
+	synapseID := champion.Synapses[0].ID
+
+	if _, err := client.EditGenome(context.Background(), EditGenomeRequest{
+		RunID:          summary.RunID,
+		FreezeNeurons:  []string{neuronID},
+		FreezeSynapses: []string{"missing"},
+	}); err == nil || !strings.Contains(err.Error(), "synapse missing not found") {
+		t.Fatalf("expected unknown synapse to be rejected, got %v", err)
+	}
+	top, err = client.TopGenomes(context.Background(), TopGenomesRequest{RunID: summary.RunID, Limit: 1})
+	if err != nil {
+		t.Fatalf("top genomes: %v", err)
+	}
+	if top[0].Genome.Neurons[0].Frozen {
+		t.Fatal("expected a rejected edit to leave the champion untouched")
+	}
+
+	edited, err := client.EditGenome(context.Background(), EditGenomeRequest{
+		RunID:          summary.RunID,
+		FreezeNeurons:  []string{neuronID},
+		FreezeSynapses: []string{synapseID},
+	})
+	if err != nil {
+		t.Fatalf("edit genome: %v", err)
+	}
+	if edited.Rank != 1 || edited.GenomeID != champion.ID ||
+		!reflect.DeepEqual(edited.FrozenNeurons, []string{neuronID}) ||
+		!reflect.DeepEqual(edited.FrozenSynapses, []string{synapseID}) {
+		t.Fatalf("unexpected edited genome: %+v", edited)
+	}
+	artifactTop, ok, err := stats.ReadTopGenomes(filepath.Join(base, "benchmarks"), summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read top genome artifact: ok=%t err=%v", ok, err)
+	}
+	if !artifactTop[0].Genome.Neurons[0].Frozen || !artifactTop[0].Genome.Synapses[0].Frozen {
+		t.Fatalf("expected artifact champion masks, got %+v", artifactTop[0].Genome)
+	}
+
+	edited, err = client.EditGenome(context.Background(), EditGenomeRequest{
+		RunID:           summary.RunID,
+		UnfreezeNeurons: []string{neuronID},
+	})
+	if err != nil {
+		t.Fatalf("unfreeze neuron: %v", err)
+	}
+	if len(edited.FrozenNeurons) != 0 || !reflect.DeepEqual(edited.FrozenSynapses, []string{synapseID}) {
+		t.Fatalf("unexpected masks after unfreeze: %+v", edited)
+	}
+	if _, err := client.EditGenome(context.Background(), EditGenomeRequest{RunID: summary.RunID}); err == nil {
+		t.Fatal("expected an empty genome edit to be rejected")
+	}
+}
+
+func TestRunAllBiasesWeightPerturbsBiases(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:           "all-biases",
+		Scape:           "xor",
+		Population:      8,
+		Generations:     3,
+		Seed:            29,
+		WeightAllBiases: 5,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	lineage, err := client.Lineage(context.Background(), LineageRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("lineage: %v", err)
+	}
+	perturbed := 0
+	for _, item := range lineage {
+		if strings.Contains(item.Operation, "perturb_all_biases") {
+			perturbed++
+		}
+	}
+	if perturbed == 0 {
+		t.Fatal("expected offspring produced by perturb_all_biases")
+	}
+	cfg, ok, err := stats.ReadRunConfig(filepath.Join(base, "benchmarks"), summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.WeightAllBiases != 5 || cfg.BiasMaxDelta != defaultBiasMaxDelta {
+		t.Fatalf("expected bias controls to be recorded, got all_biases=%g max_delta=%g", cfg.WeightAllBiases, cfg.BiasMaxDelta)
+	}
+	// The bias controls sit outside the default weight set: setting only
+	// them still leaves the default operator weights in place.
+	if cfg.WeightPerturb != 0.70 || cfg.WeightBias != 0 {
+		t.Fatalf("expected default mutation weights alongside bias controls, got perturb=%g bias=%g", cfg.WeightPerturb, cfg.WeightBias)
+	}
+
+	if _, err := client.Run(context.Background(), RunRequest{Scape: "xor", Population: 4, Generations: 1, BiasMaxDelta: -1}); err == nil {
+		t.Fatal("expected negative bias max delta to be rejected")
+	}
+}
+
+func TestRunStackMachineScapeSynthesizesPrograms(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Run(context.Background(), RunRequest{
+		RunID:       "stack-bad",
+		Scape:       "stack-machine",
+		ScapeParams: map[string]string{"task": "sort"},
+		Population:  4,
+		Generations: 1,
+	}); err == nil {
+		t.Fatal("expected unknown stack-machine task to be rejected")
+	}
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:       "stack-sum",
+		Scape:       "stackmachine",
+		ScapeParams: map[string]string{"task": "sum", "length": "8", "examples": "6"},
+		Population:  6,
+		Generations: 2,
+		Seed:        5,
+	})
+	if err != nil {
+		t.Fatalf("run stack-machine: %v", err)
+	}
+	if len(summary.BestByGeneration) != 2 {
+		t.Fatalf("expected two generations, got %+v", summary.BestByGeneration)
+	}
+	for _, best := range summary.BestByGeneration {
+		if best < 0 || best > 1 {
+			t.Fatalf("expected stack-machine fitness in [0,1], got %f", best)
+		}
+	}
+}
+
+func TestRunFitnessScalingRecordsConfigAndKeepsRawFitness(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	for _, req := range []RunRequest{
+		{RunID: "scaling-unknown", FitnessScaling: "boltzmann"},
+		{RunID: "scaling-rank-pressure", FitnessScaling: "rank", ScalingPressure: 3},
+		{RunID: "scaling-none-pressure", ScalingPressure: 1},
+	} {
+		req.Scape = "xor"
+		req.Population = 4
+		req.Generations = 1
+		if _, err := client.Run(context.Background(), req); err == nil {
+			t.Fatalf("expected %s to be rejected", req.RunID)
+		}
+	}
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:          "scaling-rank",
+		Scape:          "xor",
+		Population:     8,
+		Generations:    3,
+		Seed:           17,
+		Selection:      "tournament",
+		FitnessScaling: " Rank ",
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	cfg, ok, err := stats.ReadRunConfig(filepath.Join(base, "benchmarks"), summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.FitnessScaling != "rank" || cfg.ScalingPressure != evo.DefaultRankingPressure {
+		t.Fatalf("expected rank scaling with default pressure, got %q %g", cfg.FitnessScaling, cfg.ScalingPressure)
+	}
+	// Scaling only feeds parent selection: the first generation, scored
+	// before any selection, reports the same raw fitness as an unscaled run.
+	unscaled, err := client.Run(context.Background(), RunRequest{
+		RunID:       "scaling-none",
+		Scape:       "xor",
+		Population:  8,
+		Generations: 1,
+		Seed:        17,
+		Selection:   "tournament",
+	})
+	if err != nil {
+		t.Fatalf("unscaled run: %v", err)
+	}
+	if summary.BestByGeneration[0] != unscaled.BestByGeneration[0] {
+		t.Fatalf("expected raw fitness to be reported, got %f want %f", summary.BestByGeneration[0], unscaled.BestByGeneration[0])
+	}
+}
+
+func TestExportPaperProfileWritesAnonymizedChecksummedArchive(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:       "paper-run",
+		Scape:       "xor",
+		Population:  6,
+		Generations: 3,
+		Seed:        41,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if _, err := client.AnnotateGenome(context.Background(), AnnotateGenomeRequest{RunID: summary.RunID, Set: map[string]string{"lab": "private"}, Note: "private lab note"}); err != nil {
+		t.Fatalf("annotate: %v", err)
+	}
+	if _, err := client.Export(context.Background(), ExportRequest{RunID: summary.RunID, Profile: "thesis"}); err == nil {
+		t.Fatal("expected unknown export profile to be rejected")
+	}
+	exported, err := client.Export(context.Background(), ExportRequest{RunID: summary.RunID, Profile: "paper"})
+	if err != nil {
+		t.Fatalf("export paper: %v", err)
+	}
+	if exported.Profile != ExportProfilePaper || exported.Archive != filepath.Join(base, "exports", "paper-run-paper.zip") {
+		t.Fatalf("unexpected export summary: %+v", exported)
+	}
+
+	reader, err := zip.OpenReader(exported.Archive)
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+	defer reader.Close()
+	files := map[string][]byte{}
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", file.Name, err)
+		}
+		var buf bytes.Buffer
+		if _, err := buf.ReadFrom(rc); err != nil {
+			t.Fatalf("read %s: %v", file.Name, err)
+		}
+		_ = rc.Close()
+		files[file.Name] = buf.Bytes()
+	}
+	for _, name := range []string{"config.json", "generation_stats.csv", "champions.json", "plots/fitness.svg", "plots/species.svg", "manifest.json", "SHA256SUMS"} {
+		if _, ok := files[name]; !ok {
+			t.Fatalf("expected %s in archive, got %d files", name, len(files))
+		}
+	}
+	if exported.Files != len(files) {
+		t.Fatalf("expected summary file count %d, got %d", len(files), exported.Files)
+	}
+
+	var manifest PaperManifest
+	if err := json.Unmarshal(files["manifest.json"], &manifest); err != nil {
+		t.Fatalf("decode manifest: %v", err)
+	}
+	if manifest.Format != PaperArchiveFormat || manifest.Scape != "xor" || manifest.Seed != 41 || manifest.GenerationsRun != 3 {
+		t.Fatalf("unexpected manifest: %+v", manifest)
+	}
+	for _, file := range manifest.Files {
+		if got := sha256Hex(files[file.Name]); got != file.SHA256 || len(files[file.Name]) != file.Bytes {
+			t.Fatalf("manifest entry %s does not match archive contents", file.Name)
+		}
+	}
+	if !strings.Contains(string(files["SHA256SUMS"]), sha256Hex(files["manifest.json"])+"  manifest.json") {
+		t.Fatalf("expected SHA256SUMS to cover the manifest, got %s", files["SHA256SUMS"])
+	}
+	if lines := strings.Split(strings.TrimSpace(string(files["generation_stats.csv"])), "\n"); len(lines) != 4 || !strings.HasPrefix(lines[0], "generation,best_fitness") {
+		t.Fatalf("unexpected generation stats: %q", files["generation_stats.csv"])
+	}
+	for name, data := range files {
+		if strings.Contains(string(data), "paper-run") || strings.Contains(string(data), "private lab note") {
+			t.Fatalf("expected %s to be anonymized", name)
+		}
+	}
+
+	again, err := client.Export(context.Background(), ExportRequest{RunID: summary.RunID, Profile: "paper", OutDir: filepath.Join(base, "again")})
+	if err != nil {
+		t.Fatalf("export paper again: %v", err)
+	}
+	first, err := os.ReadFile(exported.Archive)
+	if err != nil {
+		t.Fatalf("read archive: %v", err)
+	}
+	second, err := os.ReadFile(again.Archive)
+	if err != nil {
+		t.Fatalf("read second archive: %v", err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Fatal("expected repeated paper exports to be byte-identical")
+	}
+}
+
+func TestRunSpeciesAllocationLogsPerSpeciesOffspring(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	for _, req := range []RunRequest{
+		{RunID: "alloc-unknown", SpeciesAllocation: "lottery"},
+		{RunID: "alloc-bounds", AllocationFloor: 3, AllocationCeiling: 2},
+	} {
+		req.Scape = "xor"
+		req.Population = 4
+		req.Generations = 1
+		if _, err := client.Run(context.Background(), req); err == nil {
+			t.Fatalf("expected %s to be rejected", req.RunID)
+		}
+	}
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:             "alloc-equal",
+		Scape:             "xor",
+		Population:        10,
+		Generations:       3,
+		Seed:              23,
+		SpeciesAllocation: "Equal",
+		AllocationCeiling: 6,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	diagnostics, err := client.Diagnostics(context.Background(), DiagnosticsRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("diagnostics: %v", err)
+	}
+	if len(diagnostics) != 3 {
+		t.Fatalf("expected three generations of diagnostics, got %d", len(diagnostics))
+	}
+	for _, d := range diagnostics {
+		total := 0
+		for key, count := range d.SpeciesAllocation {
+			if count > 6 {
+				t.Fatalf("generation %d: species %s exceeds the ceiling: %v", d.Generation, key, d.SpeciesAllocation)
+			}
+			total += count
+		}
+		if total == 0 || len(d.SpeciesAllocation) != d.SpeciesCount {
+			t.Fatalf("generation %d: expected an allocation for each of %d species, got %v", d.Generation, d.SpeciesCount, d.SpeciesAllocation)
+		}
+	}
+	cfg, ok, err := stats.ReadRunConfig(filepath.Join(base, "benchmarks"), summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.SpeciesAllocation != "equal" || cfg.AllocationCeiling != 6 {
+		t.Fatalf("expected allocation policy to be recorded, got %q ceiling=%d", cfg.SpeciesAllocation, cfg.AllocationCeiling)
+	}
+}
+
+func TestRunPopulationResizeGuardGrowsToFloor(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	for _, req := range []RunRequest{
+		{RunID: "resize-unknown", PopulationResize: "elastic"},
+		{RunID: "resize-bounds", PopulationResize: "guard", MinPopulation: 9, MaxPopulation: 8},
+		{RunID: "resize-off-bounds", MinPopulation: 8},
+		{RunID: "resize-target-guard", PopulationResize: "guard", ResizeTargetSeconds: 1},
+	} {
+		req.Scape = "xor"
+		req.Population = 4
+		req.Generations = 1
+		if _, err := client.Run(context.Background(), req); err == nil {
+			t.Fatalf("expected %s to be rejected", req.RunID)
+		}
+	}
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:            "resize-guard",
+		Scape:            "xor",
+		Population:       4,
+		Generations:      3,
+		Seed:             29,
+		PopulationResize: "Guard",
+		MinPopulation:    8,
+		MaxPopulation:    12,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	diagnostics, err := client.Diagnostics(context.Background(), DiagnosticsRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("diagnostics: %v", err)
+	}
+	if len(diagnostics) != 3 {
+		t.Fatalf("expected three generations of diagnostics, got %d", len(diagnostics))
+	}
+	if diagnostics[0].PopulationSize != 4 || diagnostics[0].PopulationResize != "guard" {
+		t.Fatalf("expected the seed generation to grow by guard, got size=%d resize=%q", diagnostics[0].PopulationSize, diagnostics[0].PopulationResize)
+	}
+	for _, d := range diagnostics[1:] {
+		if d.PopulationSize < 8 || d.PopulationSize > 12 {
+			t.Fatalf("generation %d: population %d outside [8, 12]", d.Generation, d.PopulationSize)
+		}
+	}
+	cfg, ok, err := stats.ReadRunConfig(filepath.Join(base, "benchmarks"), summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.PopulationResize != "guard" || cfg.MinPopulation != 8 || cfg.MaxPopulation != 12 {
+		t.Fatalf("expected population resize to be recorded, got %q min=%d max=%d", cfg.PopulationResize, cfg.MinPopulation, cfg.MaxPopulation)
+	}
+}
+
+func TestRunTuningTracePersistsPerAgentSessions(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Run(context.Background(), RunRequest{
+		RunID:       "trace-untuned",
+		Scape:       "xor",
+		Population:  4,
+		Generations: 1,
+		TuningTrace: true,
+	}); err == nil {
+		t.Fatal("expected tuning trace without tuning to be rejected")
+	}
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:        "trace-tuned",
+		Scape:        "xor",
+		Population:   6,
+		Generations:  2,
+		Seed:         31,
+		EnableTuning: true,
+		TuneAttempts: 2,
+		TuningTrace:  true,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	traces, ok, err := stats.ReadTuningTraces(filepath.Join(base, "benchmarks"), summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read tuning traces: ok=%t err=%v", ok, err)
+	}
+	diagnostics, err := client.Diagnostics(context.Background(), DiagnosticsRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("diagnostics: %v", err)
+	}
+	accepted := map[int]int{}
+	for _, trace := range traces {
+		if trace.Generation < 1 || trace.Generation > 2 || trace.GenomeID == "" || !strings.HasPrefix(trace.SessionID, "tune-g") {
+			t.Fatalf("unexpected tuning session: %+v", trace)
+		}
+		if trace.Selection != "best_so_far" || len(trace.Candidates) == 0 {
+			t.Fatalf("expected selection and candidates, got %+v", trace)
+		}
+		for _, candidate := range trace.Candidates {
+			if candidate.Accepted {
+				accepted[trace.Generation]++
+			}
+		}
+	}
+	for _, d := range diagnostics {
+		if accepted[d.Generation] != d.TuningAccepted {
+			t.Fatalf("generation %d: trace accepted %d, diagnostics %d", d.Generation, accepted[d.Generation], d.TuningAccepted)
+		}
+	}
+	cfg, ok, err := stats.ReadRunConfig(filepath.Join(base, "benchmarks"), summary.RunID)
+	if err != nil || !ok || !cfg.TuningTrace {
+		t.Fatalf("expected tuning trace to be recorded in config: ok=%t err=%v cfg=%t", ok, err, cfg.TuningTrace)
+	}
+}
+
+func TestRunValidationTuneAcceptanceRecordsBothFitnesses(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Run(context.Background(), RunRequest{
+		RunID:          "accept-unknown",
+		Scape:          "xor",
+		Population:     4,
+		Generations:    1,
+		EnableTuning:   true,
+		TuneAcceptance: "test",
+	}); err == nil {
+		t.Fatal("expected unknown tune acceptance to be rejected")
+	}
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:          "accept-validation",
+		Scape:          "xor",
+		Population:     6,
+		Generations:    2,
+		Seed:           37,
+		EnableTuning:   true,
+		TuneAttempts:   2,
+		TuningTrace:    true,
+		TuneAcceptance: "validation",
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	traces, ok, err := stats.ReadTuningTraces(filepath.Join(base, "benchmarks"), summary.RunID)
+	if err != nil || !ok || len(traces) == 0 {
+		t.Fatalf("read tuning traces: ok=%t err=%v traces=%d", ok, err, len(traces))
+	}
+	for _, trace := range traces {
+		for _, candidate := range trace.Candidates {
+			if candidate.ValidationBefore == nil || candidate.ValidationAfter == nil {
+				t.Fatalf("expected training and validation fitness per candidate, got %+v", candidate)
+			}
+		}
+	}
+	cfg, ok, err := stats.ReadRunConfig(filepath.Join(base, "benchmarks"), summary.RunID)
+	if err != nil || !ok || cfg.TuneAcceptance != "validation" {
+		t.Fatalf("expected tune acceptance to be recorded in config: ok=%t err=%v cfg=%q", ok, err, cfg.TuneAcceptance)
+	}
+}
+
+func TestRunWallClockTuneDurationPolicy(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:              "tune-wall-clock",
+		Scape:              "xor",
+		Population:         6,
+		Generations:        3,
+		Seed:               37,
+		EnableTuning:       true,
+		TuneAttempts:       2,
+		TuneDurationPolicy: "wall_clock",
+		TuneDurationParam:  30,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	diagnostics, err := client.Diagnostics(context.Background(), DiagnosticsRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("diagnostics: %v", err)
+	}
+	for _, d := range diagnostics {
+		if d.TuningInvocations == 0 || d.TuningAttempts == 0 {
+			t.Fatalf("generation %d: expected tuning within the wall-clock budget, got %+v", d.Generation, d)
+		}
+	}
+	cfg, ok, err := stats.ReadRunConfig(filepath.Join(base, "benchmarks"), summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.TuneDurationPolicy != "wall_clock" || cfg.TuneDurationParam != 30 {
+		t.Fatalf("expected wall_clock policy to be recorded, got %q param=%f", cfg.TuneDurationPolicy, cfg.TuneDurationParam)
+	}
+}
+
+// TestScapeSandboxWorkerProcess is the worker sandboxed test runs spawn; it is
+// skipped unless run as one.
+func TestScapeSandboxWorkerProcess(t *testing.T) {
+	if os.Getenv("PROTOGONOS_SANDBOX_WORKER") != "1" {
+		t.Skip("scape sandbox worker helper process")
+	}
+	if err := ServeScapeSandbox(context.Background(), os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func TestRunScapeSandboxMatchesInProcessRun(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Run(context.Background(), RunRequest{
+		RunID:          "sandbox-options-only",
+		Scape:          "xor",
+		Population:     4,
+		Generations:    1,
+		SandboxTimeout: time.Second,
+	}); err == nil {
+		t.Fatal("expected sandbox options without sandboxing to be rejected")
+	}
+	if _, err := client.Run(context.Background(), RunRequest{
+		RunID:        "sandbox-no-command",
+		Scape:        "xor",
+		Population:   4,
+		Generations:  1,
+		ScapeSandbox: true,
+	}); err == nil || !strings.Contains(err.Error(), "sandbox command") {
+		t.Fatalf("expected sandboxing without a worker command to be rejected, got %v", err)
+	}
+
+	t.Setenv("PROTOGONOS_SANDBOX_WORKER", "1")
+	request := RunRequest{
+		Scape:       "xor",
+		Population:  8,
+		Generations: 3,
+		Seed:        23,
+		Workers:     2,
+	}
+	inProcess := request
+	inProcess.RunID = "sandbox-baseline"
+	want, err := client.Run(context.Background(), inProcess)
+	if err != nil {
+		t.Fatalf("in-process run: %v", err)
+	}
+	sandboxed := request
+	sandboxed.RunID = "sandbox-run"
+	sandboxed.ScapeSandbox = true
+	sandboxed.SandboxCommand = os.Args[0] + " -test.run=^TestScapeSandboxWorkerProcess$"
+	sandboxed.SandboxTimeout = time.Minute
+	got, err := client.Run(context.Background(), sandboxed)
+	if err != nil {
+		t.Fatalf("sandboxed run: %v", err)
+	}
+	if got.FinalBestFitness != want.FinalBestFitness {
+		t.Fatalf("sandboxed run diverged: got=%f want=%f", got.FinalBestFitness, want.FinalBestFitness)
+	}
+	cfg, ok, err := stats.ReadRunConfig(filepath.Join(base, "benchmarks"), got.RunID)
+	if err != nil || !ok || !cfg.ScapeSandbox || cfg.SandboxTimeoutMS != time.Minute.Milliseconds() {
+		t.Fatalf("expected sandbox settings in run config: ok=%t err=%v cfg=%+v", ok, err, cfg)
+	}
+}
+
+func TestRunMeterEvaluationsRecordsEvaluationCost(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	plain, err := client.Run(context.Background(), RunRequest{RunID: "unmetered-xor", Scape: "xor", Population: 4, Generations: 1, Seed: 5})
+	if err != nil {
+		t.Fatalf("run unmetered: %v", err)
+	}
+	if plain.EvaluationCost != nil {
+		t.Fatalf("expected no evaluation cost without metering, got %+v", *plain.EvaluationCost)
+	}
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:                "metered-xor",
+		Scape:                "xor",
+		Population:           6,
+		Generations:          2,
+		Seed:                 5,
+		FitnessPostprocessor: "cost_proportional",
+	})
+	if err != nil {
+		t.Fatalf("run metered: %v", err)
+	}
+	cost := summary.EvaluationCost
+	if cost == nil {
+		t.Fatal("expected cost_proportional to meter evaluations")
+	}
+	if cost.Scape != "xor" || cost.Evaluations != 12 || cost.CPUSeconds <= 0 || cost.MaxCPUSeconds < cost.MeanCPUSeconds {
+		t.Fatalf("unexpected evaluation cost: %+v", *cost)
+	}
+	entries, err := stats.ListRunIndex(client.benchmarksDir)
+	if err != nil {
+		t.Fatalf("list run index: %v", err)
+	}
+	for _, entry := range entries {
+		if entry.RunID != summary.RunID {
+			continue
+		}
+		if entry.Resources == nil || entry.Resources.EvaluationCost == nil || entry.Resources.EvaluationCost.Evaluations != cost.Evaluations {
+			t.Fatalf("expected evaluation cost in indexed resources, got %+v", entry.Resources)
+		}
+	}
+	diagnostics, err := client.Diagnostics(context.Background(), DiagnosticsRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("diagnostics: %v", err)
+	}
+	for _, diag := range diagnostics {
+		if diag.EvaluationCost == nil || diag.EvaluationCost.Evaluations != 6 {
+			t.Fatalf("generation %d: expected metered evaluation cost, got %+v", diag.Generation, diag.EvaluationCost)
+		}
+	}
+}
+
+func TestParseChampionSeedSpec(t *testing.T) {
+	spec, err := ParseChampionSeedSpec("scape=XOR, top=20", "regression-mimic")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if spec.String() != "scape=xor top=20 fraction=0.25" {
+		t.Fatalf("unexpected canonical spec: %s", spec)
+	}
+	spec, err = ParseChampionSeedSpec("fraction=0.5", "xor")
+	if err != nil || spec.Scape != "xor" || spec.Top != defaultChampionSeedTop || spec.Fraction != 0.5 {
+		t.Fatalf("expected run scape and default top, got %+v err=%v", spec, err)
+	}
+	for _, bad := range []string{"top=0", "fraction=1.5", "fraction=0", "size=3", "scape"} {
+		if _, err := ParseChampionSeedSpec(bad, "xor"); err == nil {
+			t.Fatalf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestRunInitFromChampionsSeedsArchivedChampions(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Run(context.Background(), RunRequest{Scape: "xor", Population: 4, Generations: 1, InitFromChampions: "top=3"}); err == nil {
+		t.Fatal("expected an empty champion archive to be rejected")
+	}
+	if _, err := client.Run(context.Background(), RunRequest{Scape: "xor", Population: 4, Generations: 1, InitFromChampions: "top=3", ContinuePopulationID: "xor-archive"}); err == nil {
+		t.Fatal("expected champion seeding of a continued population to be rejected")
+	}
+
+	archive, err := client.Run(context.Background(), RunRequest{RunID: "xor-archive", Scape: "xor", Population: 8, Generations: 3, Seed: 7})
+	if err != nil {
+		t.Fatalf("archive run: %v", err)
+	}
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:             "xor-seeded",
+		Scape:             "xor",
+		Population:        8,
+		Generations:       1,
+		Seed:              8,
+		InitFromChampions: "scape=xor top=2 fraction=0.5",
+	})
+	if err != nil {
+		t.Fatalf("seeded run: %v", err)
+	}
+	cfg, ok, err := stats.ReadRunConfig(client.benchmarksDir, summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.InitFromChampions != "scape=xor top=2 fraction=0.5" {
+		t.Fatalf("expected canonical champion spec in run config, got %q", cfg.InitFromChampions)
+	}
+	if len(cfg.InitChampionSources) == 0 || len(cfg.InitChampionSources) > 2 {
+		t.Fatalf("expected one or two champion sources, got %v", cfg.InitChampionSources)
+	}
+	for _, source := range cfg.InitChampionSources {
+		if !strings.HasPrefix(source, archive.RunID+"/") {
+			t.Fatalf("expected champions from the archive run, got %v", cfg.InitChampionSources)
+		}
+	}
+
+	exported, err := client.Export(context.Background(), ExportRequest{RunID: summary.RunID, Profile: ExportProfilePaper})
+	if err != nil {
+		t.Fatalf("export paper: %v", err)
+	}
+	reader, err := zip.OpenReader(exported.Archive)
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+	defer reader.Close()
+	var paperConfig stats.RunConfig
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", file.Name, err)
+		}
+		var buf bytes.Buffer
+		if _, err := buf.ReadFrom(rc); err != nil {
+			t.Fatalf("read %s: %v", file.Name, err)
+		}
+		_ = rc.Close()
+		if strings.Contains(buf.String(), archive.RunID) {
+			t.Fatalf("expected paper export %s not to name the champion source run", file.Name)
+		}
+		if file.Name == "config.json" {
+			if err := json.Unmarshal(buf.Bytes(), &paperConfig); err != nil {
+				t.Fatalf("decode config.json: %v", err)
+			}
+		}
+	}
+	if paperConfig.InitFromChampions != "scape=xor top=2 fraction=0.5" || len(paperConfig.InitChampionSources) != 0 {
+		t.Fatalf("expected the champion spec without its sources in config.json: %+v", paperConfig)
+	}
+}
+
+func TestExportCompactProfileRoundTripsChampion(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Run(context.Background(), RunRequest{RunID: "compact-xor", Scape: "xor", Population: 6, Generations: 3, Seed: 17}); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if _, err := client.Export(context.Background(), ExportRequest{RunID: "compact-xor", Quantization: "int8"}); err == nil {
+		t.Fatal("expected quantization without the compact profile to be rejected")
+	}
+	if _, err := client.Export(context.Background(), ExportRequest{RunID: "compact-xor", Profile: ExportProfileCompact, Quantization: "int4"}); err == nil {
+		t.Fatal("expected unknown quantization to be rejected")
+	}
+	exported, err := client.Export(context.Background(), ExportRequest{RunID: "compact-xor", Profile: ExportProfileCompact})
+	if err != nil {
+		t.Fatalf("export compact: %v", err)
+	}
+	if exported.Archive != filepath.Join(base, "exports", "compact-xor-champion.pgc") || exported.Quantization != "float64" || exported.Bytes <= 0 {
+		t.Fatalf("unexpected export summary: %+v", exported)
+	}
+
+	compact, err := LoadCompactGenome(exported.Archive)
+	if err != nil {
+		t.Fatalf("load compact genome: %v", err)
+	}
+	top, ok, err := stats.ReadTopGenomes(filepath.Join(base, "benchmarks"), "compact-xor")
+	if err != nil || !ok {
+		t.Fatalf("read top genomes: ok=%t err=%v", ok, err)
+	}
+	if compact.Scape != "xor" || len(compact.InputNeuronIDs) != 2 || len(compact.OutputNeuronIDs) != 1 {
+		t.Fatalf("unexpected compact header: %+v", compact)
+	}
+	if !reflect.DeepEqual(compact.Genome, top[0].Genome) {
+		t.Fatalf("compact champion differs from stored champion:\ngot  %+v\nwant %+v", compact.Genome, top[0].Genome)
+	}
+
+	stored, err := client.NewModelServer(context.Background(), ServeModelRequest{RunID: "compact-xor", MaxBatch: 1, BatchWindow: time.Millisecond})
+	if err != nil {
+		t.Fatalf("serve stored champion: %v", err)
+	}
+	t.Cleanup(stored.Close)
+	if _, err := client.NewModelServer(context.Background(), ServeModelRequest{RunID: "compact-xor", CompactFile: exported.Archive}); err == nil {
+		t.Fatal("expected compact file combined with a run id to be rejected")
+	}
+	served, err := client.NewModelServer(context.Background(), ServeModelRequest{CompactFile: exported.Archive, MaxBatch: 1, BatchWindow: time.Millisecond})
+	if err != nil {
+		t.Fatalf("serve compact champion: %v", err)
+	}
+	t.Cleanup(served.Close)
+	if info := served.Info(); info.Scape != "xor" || info.GenomeID != top[0].Genome.ID || info.Inputs != 2 || info.Outputs != 1 {
+		t.Fatalf("unexpected compact model info: %+v", info)
+	}
+	rows := [][]float64{{0, 0}, {0, 1}, {1, 0}, {1, 1}}
+	want, err := stored.Predict(context.Background(), rows)
+	if err != nil {
+		t.Fatalf("predict stored: %v", err)
+	}
+	got, err := served.Predict(context.Background(), rows)
+	if err != nil {
+		t.Fatalf("predict compact: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("compact champion predicted %v, stored champion %v", got, want)
+	}
+}
+
+func TestRunRestartsStopsAtFirstGoal(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	run := RunRequest{RunID: "xor-restarts", Scape: "xor", Population: 8, Generations: 30, Seed: 1, FitnessGoal: 1.3}
+	if _, err := client.RunRestarts(context.Background(), RestartsRequest{Run: RunRequest{Scape: "xor", Population: 8, Generations: 2}, Restarts: 2}); err == nil {
+		t.Fatal("expected restarts without a fitness goal to be rejected")
+	}
+
+	all, err := client.RunRestarts(context.Background(), RestartsRequest{Run: run, Restarts: 6, Parallel: 3, All: true})
+	if err != nil {
+		t.Fatalf("run all restarts: %v", err)
+	}
+	if len(all.Restarts) != 6 {
+		t.Fatalf("expected every restart to run, got %+v", all.Restarts)
+	}
+	firstSuccess := -1
+	var toGoal []int
+	for i, restart := range all.Restarts {
+		if restart.Restart != i+1 || restart.Seed != int64(i+1) || restart.RunID != fmt.Sprintf("xor-restarts-restart-%03d", i+1) {
+			t.Fatalf("unexpected restart identity: %+v", restart)
+		}
+		if restart.ReachedGoal {
+			toGoal = append(toGoal, restart.Evaluations)
+			if firstSuccess < 0 {
+				firstSuccess = i
+			}
+		} else if restart.Generation != 30 {
+			t.Fatalf("expected a failed restart to use the whole budget, got %+v", restart)
+		}
+	}
+	if firstSuccess < 0 || all.Successes != len(toGoal) || all.EvaluationsToGoal.Count != len(toGoal) {
+		t.Fatalf("expected some restarts to reach the goal, got %+v", all)
+	}
+	wantTotal := 0
+	for _, restart := range all.Restarts[:firstSuccess+1] {
+		wantTotal += restart.Evaluations
+	}
+	if all.TotalEvaluations != wantTotal || all.FirstSuccess != all.Restarts[firstSuccess].RunID {
+		t.Fatalf("expected total evaluations %d up to %s, got %+v", wantTotal, all.Restarts[firstSuccess].RunID, all)
+	}
+	sort.Ints(toGoal)
+	if all.EvaluationsToGoal.Min != toGoal[0] || all.EvaluationsToGoal.Max != toGoal[len(toGoal)-1] {
+		t.Fatalf("unexpected evaluations-to-goal distribution %+v for %v", all.EvaluationsToGoal, toGoal)
+	}
+
+	run.RunID = "xor-restarts-first"
+	first, err := client.RunRestarts(context.Background(), RestartsRequest{Run: run, Restarts: 6})
+	if err != nil {
+		t.Fatalf("run restarts until goal: %v", err)
+	}
+	if len(first.Restarts) != firstSuccess+1 || first.Successes != 1 || !first.Restarts[firstSuccess].ReachedGoal {
+		t.Fatalf("expected sequential restarts to stop at restart %d, got %+v", firstSuccess+1, first)
+	}
+	if first.Restarts[firstSuccess].Evaluations != all.Restarts[firstSuccess].Evaluations {
+		t.Fatalf("expected restarts to be reproducible by seed, got %+v and %+v", first.Restarts[firstSuccess], all.Restarts[firstSuccess])
+	}
+}
+
+func TestRunVerifyInvariantsDumpsViolation(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:            "xor-invariants",
+		Scape:            "xor",
+		Population:       10,
+		Generations:      6,
+		Seed:             2,
+		EnableTuning:     true,
+		VerifyInvariants: true,
+	})
+	if err != nil {
+		t.Fatalf("run with verified invariants: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(summary.ArtifactsDir, InvariantViolationFile)); !os.IsNotExist(err) {
+		t.Fatalf("expected no violation dump for a clean run, got %v", err)
+	}
+	if _, err := client.Run(context.Background(), RunRequest{
+		Scape:            "xor",
+		Population:       10,
+		Generations:      2,
+		EvolutionType:    "steady_state",
+		VerifyInvariants: true,
+	}); err == nil {
+		t.Fatal("expected verify invariants to be rejected for steady-state evolution")
+	}
+
+	runDir := filepath.Join(base, "benchmarks", "broken")
+	violation := &evo.InvariantViolation{
+		Generation: 4,
+		Invariant:  evo.InvariantElitePreserved,
+		Detail:     "elite g1 is missing from the next generation",
+		Population: []evo.InvariantGenome{{ID: "g0", Fitness: 0.5, Elite: true}},
+	}
+	err = dumpInvariantViolation(runDir, fmt.Errorf("run evolution: %w", violation))
+	var got *evo.InvariantViolation
+	if !errors.As(err, &got) || got != violation {
+		t.Fatalf("expected wrapped violation, got %v", err)
+	}
+	if !strings.Contains(err.Error(), InvariantViolationFile) {
+		t.Fatalf("expected error to name the dump, got %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(runDir, InvariantViolationFile))
+	if err != nil {
+		t.Fatalf("read dump: %v", err)
+	}
+	var dumped evo.InvariantViolation
+	if err := json.Unmarshal(data, &dumped); err != nil {
+		t.Fatalf("decode dump: %v", err)
+	}
+	if !reflect.DeepEqual(&dumped, violation) {
+		t.Fatalf("dump mismatch: got %+v want %+v", dumped, violation)
+	}
+	plain := errors.New("boom")
+	if err := dumpInvariantViolation(runDir, plain); err != plain {
+		t.Fatalf("expected other errors to pass through, got %v", err)
+	}
+}
+
+func TestPlanRunResolvesWithoutEvaluating(t *testing.T) {
+	base := t.TempDir()
+	benchmarks := filepath.Join(base, "benchmarks")
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: benchmarks,
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	plan, err := client.PlanRun(context.Background(), RunRequest{
+		RunID:            "xor-plan",
+		Scape:            "xor",
+		Population:       10,
+		Generations:      8,
+		EvaluationsLimit: 35,
+		EvaluationTrials: 3,
+		EnableTuning:     true,
+		TuneAttempts:     2,
+	})
+	if err != nil {
+		t.Fatalf("plan run: %v", err)
+	}
+	if plan.RunID != "xor-plan" || plan.Config.RunID != "xor-plan" || plan.Config.PopulationSize != 10 || plan.Config.EliteCount != 2 {
+		t.Fatalf("unexpected resolved config: %+v", plan.Config)
+	}
+	if plan.Config.Selection == "" || plan.Config.TuneSteps <= 0 {
+		t.Fatalf("expected defaults to be materialized, got selection=%q tune_steps=%d", plan.Config.Selection, plan.Config.TuneSteps)
+	}
+	if len(plan.InputNeuronIDs) != 2 || len(plan.OutputNeuronIDs) != 1 {
+		t.Fatalf("unexpected xor morphology: inputs=%v outputs=%v", plan.InputNeuronIDs, plan.OutputNeuronIDs)
+	}
+	want := RunBudget{Generations: 4, Capped: true, Runs: 1, Evaluations: 40, EpisodesPerEvaluation: 3, Episodes: 120, MinTuningEvaluations: 120}
+	if plan.Budget != want {
+		t.Fatalf("unexpected budget: got %+v want %+v", plan.Budget, want)
+	}
+	if _, err := os.Stat(benchmarks); !os.IsNotExist(err) {
+		t.Fatalf("expected no artifacts from a dry run, got %v", err)
+	}
+
+	compare, err := client.PlanRun(context.Background(), RunRequest{Scape: "xor", Population: 6, Generations: 5, CompareTuning: true, OpMode: "validation"})
+	if err != nil {
+		t.Fatalf("plan validation run: %v", err)
+	}
+	if compare.Budget.Generations != 1 || compare.Budget.Runs != 1 || compare.Budget.MinTuningEvaluations != 0 {
+		t.Fatalf("expected a single untuned generation outside gt mode, got %+v", compare.Budget)
+	}
+	if _, err := client.PlanRun(context.Background(), RunRequest{Scape: "xor", Population: 6, Generations: 2, ContinuePopulationID: "missing"}); err == nil {
+		t.Fatal("expected a missing continued population to fail the plan")
+	}
+	if _, err := client.PlanRun(context.Background(), RunRequest{Scape: "flatland", Population: 6, Generations: 2, ScapeParams: map[string]string{"bogus": "1"}}); err == nil {
+		t.Fatal("expected unknown scape params to fail the plan")
+	}
+}
+
+func TestReplayLineageReproducesStoredRun(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	ctx := context.Background()
+	if _, err := client.Run(ctx, RunRequest{
+		RunID:         "xor-replay",
+		Scape:         "xor",
+		Population:    8,
+		Generations:   6,
+		Seed:          7,
+		CrossoverRate: 0.3,
+		EnableTuning:  true,
+		TuneAttempts:  1,
+	}); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	report, err := client.ReplayLineage(ctx, LineageReplayRequest{RunID: "xor-replay"})
+	if err != nil {
+		t.Fatalf("replay lineage: %v", err)
+	}
+	if report.Mismatched != 0 || report.Skipped != 0 {
+		for _, record := range report.Records {
+			if record.Status != evo.ReplayMatched {
+				t.Errorf("%s %s: %s", record.GenomeID, record.Status, record.Detail)
+			}
+		}
+		t.Fatalf("expected a clean replay, got %+v", report)
+	}
+	if report.Matched != len(report.Records) || report.Matched <= 8 {
+		t.Fatalf("expected every generation replayed, got %d of %d", report.Matched, len(report.Records))
+	}
+
+	lineage, _, err := client.store.GetLineage(ctx, "xor-replay")
+	if err != nil {
+		t.Fatalf("get lineage: %v", err)
+	}
+	tampered := -1
+	for i, record := range lineage {
+		if len(record.Events) > 0 && record.Generation == 1 {
+			tampered = i
+			break
+		}
+	}
+	if tampered < 0 {
+		t.Fatal("expected a mutated first-generation record")
+	}
+	lineage[tampered].Fingerprint = "0000000000000000"
+	if err := client.store.SaveLineage(ctx, "xor-replay", lineage); err != nil {
+		t.Fatalf("save lineage: %v", err)
+	}
+	report, err = client.ReplayLineage(ctx, LineageReplayRequest{Latest: true})
+	if err != nil {
+		t.Fatalf("replay tampered lineage: %v", err)
+	}
+	if report.Mismatched != 1 || report.Records[tampered].Status != evo.ReplayMismatched || !strings.Contains(report.Records[tampered].Detail, "recorded 0000000000000000") {
+		t.Fatalf("expected the tampered record to mismatch, got %+v", report.Records[tampered])
+	}
+}
+
+func TestEngineStepsRunOneGenerationAtATime(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	ctx := context.Background()
+	req := RunRequest{Scape: "xor", Population: 8, Generations: 5, Seed: 11, Workers: 1}
+	req.RunID = "xor-whole"
+	whole, err := client.Run(ctx, req)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	req.RunID = "xor-stepped"
+	engine, err := client.NewEngine(ctx, req)
+	if err != nil {
+		t.Fatalf("new engine: %v", err)
+	}
+	first, err := engine.Step(ctx)
+	if err != nil {
+		t.Fatalf("step: %v", err)
+	}
+	if first.Generation != 1 || first.Done || first.TotalEvaluations != 8 || first.Champion.ID == "" {
+		t.Fatalf("unexpected first generation: %+v", first)
+	}
+	third, err := engine.RunUntil(ctx, func(generation EngineGeneration) bool {
+		return generation.Generation >= 3
+	})
+	if err != nil {
+		t.Fatalf("run until: %v", err)
+	}
+	if third.Generation != 3 || engine.Done() {
+		t.Fatalf("expected to stop after generation 3, got %+v", third)
+	}
+	last, err := engine.RunUntil(ctx, nil)
+	if err != nil {
+		t.Fatalf("run to completion: %v", err)
+	}
+	if last.Generation != 5 || !last.Done || !engine.Done() {
+		t.Fatalf("expected a finished run, got %+v", last)
+	}
+	again, err := engine.Step(ctx)
+	if err != nil || again.Generation != 5 || !again.Done {
+		t.Fatalf("expected stepping a finished run to be a no-op, got %+v, %v", again, err)
+	}
+	stepped, err := engine.Finish(ctx)
+	if err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+	if !reflect.DeepEqual(stepped.BestByGeneration, whole.BestByGeneration) {
+		t.Fatalf("stepped run diverged from Run: %v vs %v", stepped.BestByGeneration, whole.BestByGeneration)
+	}
+	if _, err := os.Stat(filepath.Join(stepped.ArtifactsDir, "config.json")); err != nil {
+		t.Fatalf("expected stepped run artifacts: %v", err)
+	}
+	if _, err := engine.Step(ctx); err == nil {
+		t.Fatal("expected stepping a finished engine to fail")
+	}
+
+	req.RunID = "xor-early"
+	early, err := client.NewEngine(ctx, req)
+	if err != nil {
+		t.Fatalf("new early engine: %v", err)
+	}
+	if _, err := early.Step(ctx); err != nil {
+		t.Fatalf("early step: %v", err)
+	}
+	summary, err := early.Finish(ctx)
+	if err != nil {
+		t.Fatalf("finish early: %v", err)
+	}
+	if len(summary.BestByGeneration) != 1 {
+		t.Fatalf("expected one persisted generation, got %v", summary.BestByGeneration)
+	}
+
+	for name, bad := range map[string]RunRequest{
+		"compare tuning": {Scape: "xor", Population: 4, Generations: 2, CompareTuning: true},
+		"steady state":   {Scape: "xor", Population: 4, Generations: 2, EvolutionType: "steady_state"},
+	} {
+		if _, err := client.NewEngine(ctx, bad); err == nil {
+			t.Fatalf("expected %s to be rejected", name)
+		}
+	}
+}
+
+func TestRunOnlineEvolutionPersistsRollingWindows(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	ctx := context.Background()
+	summary, err := client.Run(ctx, RunRequest{
+		RunID:                "xor-online",
+		Scape:                "xor",
+		Population:           6,
+		EvolutionType:        "online",
+		EvaluationsLimit:     20,
+		ReproductionInterval: 3,
+		DiagnosticsWindow:    5,
+		Seed:                 5,
+		Workers:              1,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(summary.BestByGeneration) != 4 {
+		t.Fatalf("expected 4 diagnostics windows, got %d", len(summary.BestByGeneration))
+	}
+	cfg, ok, err := readRunConfigWithProfileHints(client.benchmarksDir, summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.Generations != 0 || cfg.ReproductionInterval != 3 || cfg.DiagnosticsWindow != 5 {
+		t.Fatalf("unexpected online config: generations=%d interval=%d window=%d", cfg.Generations, cfg.ReproductionInterval, cfg.DiagnosticsWindow)
+	}
+
+	for name, req := range map[string]RunRequest{
+		"window without online":   {Scape: "xor", Population: 4, Generations: 2, DiagnosticsWindow: 4},
+		"interval without online": {Scape: "xor", Population: 4, Generations: 2, ReproductionInterval: 2},
+		"online entropy":          {Scape: "xor", Population: 4, EvolutionType: "online", EvaluationsLimit: 8, EntropyThreshold: 0.5},
+	} {
+		if _, err := client.Run(ctx, req); err == nil {
+			t.Fatalf("%s: expected an error", name)
+		}
+	}
+	if _, err := client.NewEngine(ctx, RunRequest{Scape: "xor", Population: 4, EvolutionType: "online", EvaluationsLimit: 8}); err == nil {
+		t.Fatal("expected the engine to reject online runs")
+	}
+}
+
+func TestRunRegressionWatchComparesWithBestEarlierChampion(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Run(context.Background(), RunRequest{Scape: "xor", Population: 4, Generations: 1, RegressionTolerance: -0.1}); err == nil {
+		t.Fatal("expected a negative regression tolerance to be rejected")
+	}
+	if _, err := client.Run(context.Background(), RunRequest{RunID: "xor-larger", Scape: "xor", Population: 12, Generations: 6, Seed: 5}); err != nil {
+		t.Fatalf("larger run: %v", err)
+	}
+	first, err := client.Run(context.Background(), RunRequest{RunID: "xor-first", Scape: "xor", Population: 8, Generations: 2, Seed: 3})
+	if err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+	if first.Regression != nil {
+		t.Fatalf("expected no check without an earlier run of the same budget, got %+v", first.Regression)
+	}
+	if _, err := client.Run(context.Background(), RunRequest{RunID: "xor-second", Scape: "xor", Population: 8, Generations: 2, Seed: 7}); err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	if _, err := client.Run(context.Background(), RunRequest{RunID: "regression-other", Scape: "regression-mimic", Population: 4, Generations: 1}); err != nil {
+		t.Fatalf("other scape run: %v", err)
+	}
+
+	watched, err := client.Run(context.Background(), RunRequest{
+		RunID:               "xor-watched",
+		Scape:               "xor",
+		Population:          8,
+		Generations:         2,
+		Seed:                9,
+		RegressionTolerance: 0.1,
+	})
+	if err != nil {
+		t.Fatalf("watched run: %v", err)
+	}
+	check := watched.Regression
+	if check == nil {
+		t.Fatal("expected a regression check")
+	}
+	entries, err := client.Leaderboard(context.Background(), LeaderboardRequest{Scape: "xor"})
+	if err != nil {
+		t.Fatalf("leaderboard: %v", err)
+	}
+	reference := ""
+	for _, entry := range entries {
+		if entry.RunID == "xor-first" || entry.RunID == "xor-second" {
+			reference = entry.RunID
+			break
+		}
+	}
+	if check.Mode != "validation" || check.Error != "" || check.ReferenceRunID != reference || check.ReferenceGenomeID == "" {
+		t.Fatalf("expected the best ranked comparable xor run %s as reference, got %+v", reference, check)
+	}
+	if check.Delta != check.ChampionFitness-check.ReferenceFitness || check.Regressed != (check.Delta < -0.1*math.Abs(check.ReferenceFitness)) || check.Tolerance != 0.1 {
+		t.Fatalf("inconsistent regression check: %+v", check)
+	}
+
+	defaulted, err := client.Run(context.Background(), RunRequest{RunID: "xor-defaulted", Scape: "xor", Population: 8, Generations: 2, Seed: 13})
+	if err != nil {
+		t.Fatalf("defaulted run: %v", err)
+	}
+	if defaulted.Regression == nil || defaulted.Regression.Tolerance != DefaultRegressionTolerance || defaulted.Regression.ReferenceRunID == "xor-larger" {
+		t.Fatalf("expected a comparable reference with the default tolerance, got %+v", defaulted.Regression)
+	}
+
+	unwatched, err := client.Run(context.Background(), RunRequest{RunID: "xor-unwatched", Scape: "xor", Population: 8, Generations: 2, Seed: 11, DisableRegressionWatch: true})
+	if err != nil {
+		t.Fatalf("unwatched run: %v", err)
+	}
+	if unwatched.Regression != nil {
+		t.Fatalf("expected no check with the regression watch disabled, got %+v", unwatched.Regression)
+	}
+}
+
+func TestRunEncodingIsRecordedAndUsedByReplay(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Run(context.Background(), RunRequest{Scape: "xor", Population: 4, Generations: 1, Encoding: "morphogen"}); err == nil {
+		t.Fatal("expected an unknown encoding to be rejected")
+	}
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:       "xor-substrate",
+		Scape:       "xor",
+		Population:  6,
+		Generations: 2,
+		Seed:        5,
+		Encoding:    "Substrate",
+	})
+	if err != nil {
+		t.Fatalf("substrate run: %v", err)
+	}
+	cfg, ok, err := stats.ReadRunConfig(client.benchmarksDir, summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.Encoding != "substrate" {
+		t.Fatalf("expected the normalized encoding to be recorded, got %q", cfg.Encoding)
+	}
+
+	top, _, err := stats.ReadTopGenomes(client.benchmarksDir, summary.RunID)
+	if err != nil || len(top) == 0 {
+		t.Fatalf("read top genomes: %d err=%v", len(top), err)
+	}
+	if top[0].Genome.Substrate != nil {
+		t.Fatal("expected the champion to carry no substrate configuration of its own")
+	}
+	replayed, err := client.Replay(context.Background(), ReplayRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if math.Abs(replayed.Fitness-top[0].Fitness) > 1e-9 {
+		t.Fatalf("expected replay through the run's encoding to reproduce fitness %f, got %f", top[0].Fitness, replayed.Fitness)
+	}
+}
+
+func TestRunGrammarEncodingEvolvesProductionRules(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	if _, err := client.Run(context.Background(), RunRequest{Scape: "xor", Population: 4, Generations: 1, Encoding: "grammar", EnableTuning: true, TuneAttempts: 1}); err == nil {
+		t.Fatal("expected weight tuning to be rejected with the grammar encoding")
+	}
+	summary, err := client.Run(context.Background(), RunRequest{
+		RunID:       "xor-grammar",
+		Scape:       "xor",
+		Population:  8,
+		Generations: 4,
+		Seed:        11,
+		Encoding:    "grammar",
+	})
+	if err != nil {
+		t.Fatalf("grammar run: %v", err)
+	}
+	top, _, err := stats.ReadTopGenomes(client.benchmarksDir, summary.RunID)
+	if err != nil || len(top) == 0 {
+		t.Fatalf("read top genomes: %d err=%v", len(top), err)
+	}
+	champion := top[0].Genome
+	if champion.Grammar == nil || len(champion.Grammar.Cells) == 0 {
+		t.Fatalf("expected the champion to carry an evolved grammar, got %+v", champion.Grammar)
+	}
+	seed, err := genotype.ConstructSeedPopulationWithOptions("xor", 1, 11, genotype.SeedPopulationOptions{})
+	if err != nil {
+		t.Fatalf("seed population: %v", err)
+	}
+	if len(champion.Synapses) != len(seed.Genomes[0].Synapses) || len(champion.Neurons) != len(seed.Genomes[0].Neurons) {
+		t.Fatalf("expected grammar mutations to leave the stored network alone, got neurons=%d synapses=%d", len(champion.Neurons), len(champion.Synapses))
+	}
+	replayed, err := client.Replay(context.Background(), ReplayRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if math.Abs(replayed.Fitness-top[0].Fitness) > 1e-9 {
+		t.Fatalf("expected replay to develop the champion's grammar and reproduce fitness %f, got %f", top[0].Fitness, replayed.Fitness)
+	}
+}
+
+func TestRunBaselineAlgorithmsShareTheRunBudgetAndRecording(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	ctx := context.Background()
+
+	if _, err := client.Run(ctx, RunRequest{Scape: "xor", Population: 4, Generations: 1, Algorithm: "annealing"}); err == nil {
+		t.Fatal("expected an unknown algorithm to be rejected")
+	}
+	if _, err := client.Run(ctx, RunRequest{Scape: "xor", Population: 4, Generations: 1, Algorithm: AlgorithmHillClimb, EnableTuning: true}); err == nil {
+		t.Fatal("expected tuning to be rejected for baselines")
+	}
+	for _, algorithm := range []string{AlgorithmRandomSearch, "Hill_Climb"} {
+		summary, err := client.Run(ctx, RunRequest{RunID: "xor-" + algorithm, Scape: "xor", Population: 6, Generations: 3, Seed: 4, Workers: 2, Algorithm: algorithm})
+		if err != nil {
+			t.Fatalf("%s run: %v", algorithm, err)
+		}
+		if len(summary.BestByGeneration) != 3 {
+			t.Fatalf("%s: expected 3 generations, got %d", algorithm, len(summary.BestByGeneration))
+		}
+		cfg, ok, err := stats.ReadRunConfig(client.benchmarksDir, summary.RunID)
+		if err != nil || !ok {
+			t.Fatalf("%s: read run config: ok=%t err=%v", algorithm, ok, err)
+		}
+		if cfg.Algorithm != strings.ToLower(algorithm) {
+			t.Fatalf("expected the normalized algorithm to be recorded, got %q", cfg.Algorithm)
+		}
+		diagnostics, err := client.Diagnostics(ctx, DiagnosticsRequest{RunID: summary.RunID})
+		if err != nil {
+			t.Fatalf("%s: diagnostics: %v", algorithm, err)
+		}
+		if len(diagnostics) != 3 || diagnostics[2].TotalEvaluations != 18 {
+			t.Fatalf("%s: expected 18 evaluations over 3 generations, got %+v", algorithm, diagnostics)
+		}
+		best := summary.BestByGeneration[0]
+		for _, fitness := range summary.BestByGeneration {
+			best = math.Max(best, fitness)
+		}
+		if summary.FinalBestFitness != best {
+			t.Fatalf("%s: expected the final best %f to be the best generation's %f", algorithm, summary.FinalBestFitness, best)
+		}
+		replayed, err := client.Replay(ctx, ReplayRequest{RunID: summary.RunID})
+		if err != nil {
+			t.Fatalf("%s: replay: %v", algorithm, err)
+		}
+		if math.Abs(replayed.Fitness-summary.FinalBestFitness) > 1e-9 {
+			t.Fatalf("%s: expected replay to reproduce fitness %f, got %f", algorithm, summary.FinalBestFitness, replayed.Fitness)
+		}
+	}
+}
+
+func TestESRunMatchesTheNeuroevolutionBudgetOnAFixedTopology(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	ctx := context.Background()
+
+	neuro, err := client.Run(ctx, RunRequest{RunID: "xor-ne", Scape: "xor", Population: 8, Generations: 3, Seed: 2, Workers: 2})
+	if err != nil {
+		t.Fatalf("neuroevolution run: %v", err)
+	}
+	if _, err := client.ESRun(ctx, ESRunRequest{Run: RunRequest{Scape: "xor", Population: 7, Generations: 3}}); err == nil {
+		t.Fatal("expected an odd population to be rejected")
+	}
+	if _, err := client.ESRun(ctx, ESRunRequest{Run: RunRequest{Scape: "xor", Population: 8, Generations: 3, Encoding: "grammar"}}); err == nil {
+		t.Fatal("expected an indirect encoding to be rejected")
+	}
+	summary, err := client.ESRun(ctx, ESRunRequest{
+		Run:           RunRequest{RunID: "xor-es", Scape: "xor", Population: 8, Generations: 3, Seed: 2, Workers: 2},
+		TopologyRunID: neuro.RunID,
+		Sigma:         0.2,
+	})
+	if err != nil {
+		t.Fatalf("es run: %v", err)
+	}
+	if summary.Evaluations != 8*3 || len(summary.BestByGeneration) != 3 {
+		t.Fatalf("expected 24 evaluations over 3 iterations, got %d over %d", summary.Evaluations, len(summary.BestByGeneration))
+	}
+
+	top, _, err := stats.ReadTopGenomes(client.benchmarksDir, summary.RunID)
+	if err != nil || len(top) == 0 {
+		t.Fatalf("read top genomes: %d err=%v", len(top), err)
+	}
+	parent, _, err := stats.ReadTopGenomes(client.benchmarksDir, neuro.RunID)
+	if err != nil || len(parent) == 0 {
+		t.Fatalf("read parent top genomes: %d err=%v", len(parent), err)
+	}
+	if len(top[0].Genome.Neurons) != len(parent[0].Genome.Neurons) || len(top[0].Genome.Synapses) != len(parent[0].Genome.Synapses) {
+		t.Fatal("expected the es champion to keep the topology it was given")
+	}
+	if top[0].Fitness != summary.FinalBestFitness {
+		t.Fatalf("expected the champion to be the best candidate %f, got %f", summary.FinalBestFitness, top[0].Fitness)
+	}
+	cfg, ok, err := stats.ReadRunConfig(client.benchmarksDir, summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.ES == nil || cfg.ES.Sigma != 0.2 || cfg.ES.TopologyRunID != neuro.RunID || cfg.ES.Parameters == 0 {
+		t.Fatalf("expected the es settings to be recorded, got %+v", cfg.ES)
+	}
+	replayed, err := client.Replay(ctx, ReplayRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if math.Abs(replayed.Fitness-top[0].Fitness) > 1e-9 {
+		t.Fatalf("expected replay to reproduce fitness %f, got %f", top[0].Fitness, replayed.Fitness)
+	}
+	diagnostics, err := client.Diagnostics(ctx, DiagnosticsRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("diagnostics: %v", err)
+	}
+	if len(diagnostics) != 3 || diagnostics[2].TotalEvaluations != 24 {
+		t.Fatalf("expected per-iteration diagnostics counting 24 evaluations, got %+v", diagnostics)
+	}
+}
+
+func TestRunSpeciesSoftCapReportsRebalancing(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	ctx := context.Background()
+
+	if _, err := client.Run(ctx, RunRequest{Scape: "xor", Population: 8, Generations: 1, SpeciesCapHysteresis: 0.2}); err == nil {
+		t.Fatal("expected hysteresis without a soft cap to be rejected")
+	}
+	summary, err := client.Run(ctx, RunRequest{Scape: "xor", Population: 16, Generations: 2, Seed: 5, SpeciesSoftCap: 4, SpeciesCapHysteresis: 0.5})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	diagnostics, err := client.Diagnostics(ctx, DiagnosticsRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("diagnostics: %v", err)
+	}
+	// Every genome of the seed population starts in one species, which the
+	// first generation caps.
+	rebalancing := diagnostics[0].SpeciesRebalancing
+	if len(rebalancing) == 0 || rebalancing[0].Action != evo.SpeciesRebalanceCapped || rebalancing[0].Size != 16 {
+		t.Fatalf("expected the first generation to cap its one species, got %+v", diagnostics[0])
+	}
+	cfg, ok, err := stats.ReadRunConfig(client.benchmarksDir, summary.RunID)
+	if err != nil || !ok {
+		t.Fatalf("read run config: ok=%t err=%v", ok, err)
+	}
+	if cfg.SpeciesSoftCap != 4 || cfg.SpeciesCapHysteresis != 0.5 {
+		t.Fatalf("expected the soft cap to be recorded, got %d/%f", cfg.SpeciesSoftCap, cfg.SpeciesCapHysteresis)
+	}
+}
+
+func TestProfileRunReportsTheDominantPhaseFromTheTrace(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	ctx := context.Background()
+
+	if _, err := client.ProfileRun(ctx, ProfileRunRequest{RunID: "missing"}); err == nil {
+		t.Fatal("expected profiling an unknown run to fail")
+	}
+	summary, err := client.Run(ctx, RunRequest{Scape: "xor", Population: 8, Generations: 3, Seed: 2, Workers: 2, EnableTuning: true, TuneAttempts: 2})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	profile, err := client.ProfileRun(ctx, ProfileRunRequest{RunID: summary.RunID})
+	if err != nil {
+		t.Fatalf("profile run: %v", err)
+	}
+	if len(profile.Generations) != 3 || len(profile.Phases) != 7 {
+		t.Fatalf("expected 3 generations over 7 phases, got %+v", profile)
+	}
+	if profile.Dominant != profile.Phases[0].Phase {
+		t.Fatalf("expected the dominant phase to lead the breakdown, got %+v", profile.Phases)
+	}
+	seconds := map[string]float64{}
+	share := 0.0
+	for i, phase := range profile.Phases {
+		if i > 0 && phase.Seconds > profile.Phases[i-1].Seconds {
+			t.Fatalf("expected phases sorted by time, got %+v", profile.Phases)
+		}
+		seconds[phase.Phase] = phase.Seconds
+		share += phase.Share
+	}
+	if seconds[PhaseTuning] <= 0 || seconds[PhaseMutation] <= 0 {
+		t.Fatalf("expected tuning and mutation time in a tuned run, got %+v", profile.Phases)
+	}
+	if share < 1-1e-6 {
+		t.Fatalf("expected the phases to cover the wall-clock, got shares summing to %f", share)
+	}
+}
+
+func TestBuildReplaySubstrateUsesCEPNamesChain(t *testing.T) {
+	rt, err := encoding.NewSubstrateRuntime(model.Genome{
+		ID: "replay-sub-chain-0",
+		Substrate: &model.SubstrateConfig{
+			CPPName:  internalsubstrate.DefaultCPPName,
+			CEPName:  internalsubstrate.SetWeightCEPName,
+			CEPNames: []string{internalsubstrate.SetWeightCEPName, internalsubstrate.DefaultCEPName},
+		},
+	}, []string{"o"})
+	if err != nil {
+		t.Fatalf("build replay substrate: %v", err)
+	}
+	if rt == nil {
+		t.Fatal("expected replay substrate runtime")
+	}
+
+	w, err := rt.Step(context.Background(), []float64{1})
+	if err != nil {
+		t.Fatalf("step replay substrate: %v", err)
+	}
+	if len(w) != 1 || w[0] != 2 {
+		t.Fatalf("expected set->delta chain result 2, got=%v", w)
+	}
+}
+
+func TestBuildReplaySubstrateExpandsCEPChainFromCEPIDs(t *testing.T) {
+	rt, err := encoding.NewSubstrateRuntime(model.Genome{
+		ID: "replay-sub-ids-chain-0",
+		Substrate: &model.SubstrateConfig{
+			CPPName: internalsubstrate.DefaultCPPName,
+			CEPName: internalsubstrate.DefaultCEPName,
+			CEPIDs:  []string{"cep_1", "cep_2"},
+		},
+	}, []string{"o"})
+	if err != nil {
+		t.Fatalf("build replay substrate: %v", err)
+	}
+	if rt == nil {
+		t.Fatal("expected replay substrate runtime")
+	}
+
+	w, err := rt.Step(context.Background(), []float64{1})
+	if err != nil {
+		t.Fatalf("step replay substrate: %v", err)
+	}
+	if len(w) != 1 || w[0] != 2 {
+		t.Fatalf("expected delta chain expanded from cep ids to produce 2, got=%v", w)
+	}
+}
+
+func TestBuildReplaySubstrateExpandsSingularCEPNamesByCEPIDs(t *testing.T) {
+	rt, err := encoding.NewSubstrateRuntime(model.Genome{
+		ID: "replay-sub-singular-chain-0",
+		Substrate: &model.SubstrateConfig{
+			CPPName:  internalsubstrate.DefaultCPPName,
+			CEPName:  internalsubstrate.DefaultCEPName,
+			CEPNames: []string{internalsubstrate.SetWeightCEPName},
+			CEPIDs:   []string{"cep_1", "cep_2"},
+		},
+	}, []string{"o"})
+	if err != nil {
+		t.Fatalf("build replay substrate: %v", err)
+	}
+	if rt == nil {
+		t.Fatal("expected replay substrate runtime")
+	}
+
+	w, err := rt.Step(context.Background(), []float64{1})
+	if err != nil {
+		t.Fatalf("step replay substrate: %v", err)
+	}
+	if len(w) != 1 || w[0] != 1 {
+		t.Fatalf("expected singular cep-name chain expanded by cep ids to remain 1, got=%v", w)
+	}
+}
+
+func TestBuildReplaySubstrateKeepsOutputFallbackOverCPPIDs(t *testing.T) {
+	rt, err := encoding.NewSubstrateRuntime(model.Genome{
+		ID: "replay-sub-cpp-fanin-0",
+		Substrate: &model.SubstrateConfig{
+			CPPName:     internalsubstrate.DefaultCPPName,
+			CPPIDs:      []string{"cpp_endpoint_1"},
+			CEPName:     internalsubstrate.DefaultCEPName,
+			WeightCount: 1,
+		},
+	}, []string{"o"})
+	if err != nil {
+		t.Fatalf("build replay substrate: %v", err)
+	}
+	if rt == nil {
+		t.Fatal("expected replay substrate runtime")
+	}
+	faninRT, ok := rt.(internalsubstrate.FaninRuntime)
+	if !ok {
+		t.Fatalf("expected fanin-capable runtime, got %T", rt)
+	}
+	w, err := faninRT.StepWithFanin(context.Background(), []float64{0}, map[string]float64{
+		"o": 1,
+	})
+	if err != nil {
+		t.Fatalf("step replay substrate with output fallback fan-in: %v", err)
+	}
+	if len(w) != 1 || w[0] != 1 {
+		t.Fatalf("expected output fallback fan-in update to 1, got=%v", w)
+	}
+}
+
+func TestAutotuneBracketsFollowHyperband(t *testing.T) {
+	got := autotuneBrackets(3, 27, 3)
+	want := []autotuneBracket{{Configs: 9, Generations: 3}, {Configs: 5, Generations: 9}, {Configs: 3, Generations: 27}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected brackets %+v, got %+v", want, got)
+	}
+}
+
+func TestAutotuneHalvesTrialsWithinBracket(t *testing.T) {
+	base := t.TempDir()
+	client, err := New(Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	req := AutotuneRequest{
+		Base:           RunRequest{RunID: "xor-autotune", Scape: "xor", Population: 6, Seed: 4},
+		Budget:         time.Minute,
+		MinGenerations: 1,
+		MaxGenerations: 4,
+		Eta:            2,
+		MaxBrackets:    1,
+		Space: []AutotuneParameter{
+			{Name: "selection", Values: []string{"elite", "tournament"}},
+			{Name: "w-perturb", Values: []string{"0.5", "1"}},
+		},
+	}
+	bad := req
+	bad.Space = []AutotuneParameter{{Name: "no-such-flag", Values: []string{"1"}}}
+	if _, err := client.Autotune(context.Background(), bad); err == nil {
+		t.Fatal("expected an unknown search parameter to be rejected")
+	}
+
+	var progress int
+	req.Progress = func(AutotuneTrial) { progress++ }
+	summary, err := client.Autotune(context.Background(), req)
+	if err != nil {
+		t.Fatalf("autotune: %v", err)
+	}
+	// One bracket of 4 configurations: 4 at 1 generation, 2 at 2, 1 at 4.
+	if len(summary.Trials) != 7 || progress != 7 || summary.Brackets != 1 || summary.BudgetExhausted {
+		t.Fatalf("unexpected autotune summary: %+v", summary)
+	}
+	var rungs [3]int
+	for _, trial := range summary.Trials {
+		if trial.Generations != 1<<trial.Rung {
+			t.Fatalf("expected rung %d to run %d generations, got %+v", trial.Rung, 1<<trial.Rung, trial)
+		}
+		rungs[trial.Rung]++
+	}
+	if rungs != [3]int{4, 2, 1} {
+		t.Fatalf("expected rungs of 4, 2 and 1 trials, got %v", rungs)
+	}
+	if !reflect.DeepEqual(summary.Best, summary.Trials[6]) {
+		t.Fatalf("expected the last survivor to be best, got %+v", summary.Best)
+	}
+	if len(summary.Evidence) != 3 || summary.Evidence[2].RunID != summary.Best.RunID {
+		t.Fatalf("expected the best configuration's trials at every rung, got %+v", summary.Evidence)
+	}
+	if summary.BestConfig.RunID != summary.Best.RunID || summary.BestConfig.Generations != 4 || summary.BestConfig.Selection != summary.Best.Params["selection"] {
+		t.Fatalf("unexpected best config %+v for %+v", summary.BestConfig, summary.Best)
 	}
 }
@@ -0,0 +1,320 @@
+package protogonos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"protogonos/internal/stats"
+)
+
+const (
+	defaultAutotuneMinGenerations = 3
+	defaultAutotuneMaxGenerations = 27
+	defaultAutotuneEta            = 3
+)
+
+// AutotuneParameter is one axis of an autotune search space: a run override
+// key (see RunOverrideKeys) and the values sampled for it.
+type AutotuneParameter struct {
+	Name   string
+	Values []string
+}
+
+// DefaultAutotuneSpace is the search space of autotune when none is given:
+// the selection scheme, survival, crossover and the weights of the main
+// mutation operators, around the run defaults.
+func DefaultAutotuneSpace() []AutotuneParameter {
+	return []AutotuneParameter{
+		{Name: "selection", Values: []string{"elite", "tournament", "species_shared_tournament"}},
+		{Name: "survival-percentage", Values: []string{"0.1", "0.25", "0.5"}},
+		{Name: "crossover-rate", Values: []string{"0", "0.25", "0.5"}},
+		{Name: "w-perturb", Values: []string{"0.4", "0.7", "1"}},
+		{Name: "w-add-synapse", Values: []string{"0.05", "0.1", "0.2"}},
+		{Name: "w-remove-synapse", Values: []string{"0.02", "0.08"}},
+		{Name: "w-add-neuron", Values: []string{"0.03", "0.07", "0.15"}},
+		{Name: "w-remove-neuron", Values: []string{"0.02", "0.05"}},
+	}
+}
+
+// AutotuneRequest searches Space for the settings under which Base evolves
+// best, within Budget of wall-clock time. The search is Hyperband: brackets
+// of successive halving, each sampling configurations at random, running
+// them for a few generations and promoting the best 1/Eta of them to runs
+// Eta times longer, up to MaxGenerations. Brackets trade many short runs for
+// few long ones and repeat, with fresh samples, until the budget or
+// MaxBrackets runs out. Every trial is a recorded run seeded with Base.Seed,
+// so configurations meet the same random numbers.
+type AutotuneRequest struct {
+	Base   RunRequest
+	Budget time.Duration
+	// Space defaults to DefaultAutotuneSpace.
+	Space []AutotuneParameter
+	// MinGenerations and MaxGenerations bound the trial lengths (default 3
+	// and 27) and Eta is the halving rate (default 3).
+	MinGenerations int
+	MaxGenerations int
+	Eta            int
+	// MaxBrackets stops the search after that many brackets; 0 searches
+	// until the budget is spent.
+	MaxBrackets int
+	// Progress, when set, is called after every trial.
+	Progress func(AutotuneTrial)
+}
+
+// AutotuneTrial is one run of a sampled configuration: Config numbers the
+// configuration within its bracket and Rung the halving round it ran in.
+type AutotuneTrial struct {
+	RunID       string            `json:"run_id"`
+	Bracket     int               `json:"bracket"`
+	Rung        int               `json:"rung"`
+	Config      int               `json:"config"`
+	Params      map[string]string `json:"params"`
+	Generations int               `json:"generations"`
+	Fitness     float64           `json:"fitness"`
+	Seconds     float64           `json:"seconds"`
+}
+
+// AutotuneSummary reports the search. Best is the fittest trial among those
+// that ran the most generations, BestConfig its recorded run config and
+// Evidence the trials of its configuration, rung by rung. BudgetExhausted
+// is set when the budget ended the search, discarding the trial it cut
+// short.
+type AutotuneSummary struct {
+	Best            AutotuneTrial   `json:"best"`
+	BestConfig      stats.RunConfig `json:"best_config"`
+	Evidence        []AutotuneTrial `json:"evidence"`
+	Trials          []AutotuneTrial `json:"trials"`
+	Brackets        int             `json:"brackets"`
+	Seconds         float64         `json:"seconds"`
+	BudgetExhausted bool            `json:"budget_exhausted"`
+}
+
+// autotuneBracket is one successive-halving schedule: Configs
+// configurations start at Generations generations.
+type autotuneBracket struct {
+	Configs     int
+	Generations int
+}
+
+func (c *Client) Autotune(ctx context.Context, req AutotuneRequest) (AutotuneSummary, error) {
+	req, err := normalizeAutotuneRequest(req)
+	if err != nil {
+		return AutotuneSummary{}, err
+	}
+	baseRunID := req.Base.RunID
+	if baseRunID == "" {
+		baseRunID = fmt.Sprintf("%s-autotune-%d-%d", req.Base.Scape, req.Base.Seed, time.Now().UTC().Unix())
+	}
+	brackets := autotuneBrackets(req.MinGenerations, req.MaxGenerations, req.Eta)
+	rng := rand.New(rand.NewSource(req.Base.Seed + 3200))
+	budgetCtx, cancel := context.WithTimeout(ctx, req.Budget)
+	defer cancel()
+
+	started := time.Now()
+	summary := AutotuneSummary{}
+	trialsByConfig := map[[2]int][]AutotuneTrial{}
+search:
+	for bracket := 0; req.MaxBrackets == 0 || bracket < req.MaxBrackets; bracket++ {
+		schedule := brackets[bracket%len(brackets)]
+		configs := make([]map[string]string, schedule.Configs)
+		for i := range configs {
+			configs[i] = sampleAutotuneConfig(rng, req.Space)
+		}
+		survivors := make([]int, len(configs))
+		for i := range survivors {
+			survivors[i] = i
+		}
+		summary.Brackets++
+		for rung, generations := 0, schedule.Generations; len(survivors) > 0; rung, generations = rung+1, generations*req.Eta {
+			scored := make([]AutotuneTrial, 0, len(survivors))
+			for _, config := range survivors {
+				trial, err := c.runAutotuneTrial(budgetCtx, req.Base, baseRunID, bracket, rung, config, configs[config], generations)
+				if err != nil {
+					if ctx.Err() != nil {
+						return AutotuneSummary{}, ctx.Err()
+					}
+					if budgetCtx.Err() != nil {
+						summary.BudgetExhausted = true
+						break search
+					}
+					return AutotuneSummary{}, err
+				}
+				summary.Trials = append(summary.Trials, trial)
+				key := [2]int{bracket, config}
+				trialsByConfig[key] = append(trialsByConfig[key], trial)
+				scored = append(scored, trial)
+				if req.Progress != nil {
+					req.Progress(trial)
+				}
+			}
+			if generations >= req.MaxGenerations {
+				break
+			}
+			// Stable, so ties keep the sampling order.
+			sort.SliceStable(scored, func(i, j int) bool { return scored[i].Fitness > scored[j].Fitness })
+			keep := len(scored) / req.Eta
+			if keep == 0 {
+				break
+			}
+			survivors = survivors[:0]
+			for _, trial := range scored[:keep] {
+				survivors = append(survivors, trial.Config)
+			}
+		}
+	}
+	summary.Seconds = time.Since(started).Seconds()
+	if len(summary.Trials) == 0 {
+		return summary, errors.New("autotune budget ended before any trial finished")
+	}
+
+	best := summary.Trials[0]
+	for _, trial := range summary.Trials[1:] {
+		if trial.Generations > best.Generations || (trial.Generations == best.Generations && trial.Fitness > best.Fitness) {
+			best = trial
+		}
+	}
+	summary.Best = best
+	summary.Evidence = trialsByConfig[[2]int{best.Bracket, best.Config}]
+	cfg, ok, err := stats.ReadRunConfig(c.benchmarksDir, best.RunID)
+	if err != nil {
+		return AutotuneSummary{}, err
+	}
+	if !ok {
+		return AutotuneSummary{}, fmt.Errorf("run config not found for run id: %s", best.RunID)
+	}
+	summary.BestConfig = cfg
+	return summary, nil
+}
+
+func normalizeAutotuneRequest(req AutotuneRequest) (AutotuneRequest, error) {
+	if req.Budget <= 0 {
+		return AutotuneRequest{}, errors.New("autotune budget must be > 0")
+	}
+	if req.Base.ContinuePopulationID != "" {
+		return AutotuneRequest{}, errors.New("autotune cannot continue a population")
+	}
+	if req.MinGenerations == 0 {
+		req.MinGenerations = defaultAutotuneMinGenerations
+	}
+	if req.MaxGenerations == 0 {
+		req.MaxGenerations = max(defaultAutotuneMaxGenerations, req.MinGenerations)
+	}
+	if req.Eta == 0 {
+		req.Eta = defaultAutotuneEta
+	}
+	if req.MinGenerations < 0 || req.MaxGenerations < req.MinGenerations {
+		return AutotuneRequest{}, fmt.Errorf("autotune generations must satisfy 0 < min <= max, got min=%d max=%d", req.MinGenerations, req.MaxGenerations)
+	}
+	if req.Eta < 2 {
+		return AutotuneRequest{}, fmt.Errorf("autotune eta must be >= 2, got %d", req.Eta)
+	}
+	if req.MaxBrackets < 0 {
+		return AutotuneRequest{}, errors.New("autotune max brackets must be >= 0")
+	}
+	if len(req.Space) == 0 {
+		req.Space = DefaultAutotuneSpace()
+	}
+	seen := make(map[string]bool, len(req.Space))
+	for _, parameter := range req.Space {
+		if len(parameter.Values) == 0 {
+			return AutotuneRequest{}, fmt.Errorf("autotune parameter %s has no values", parameter.Name)
+		}
+		if seen[parameter.Name] {
+			return AutotuneRequest{}, fmt.Errorf("duplicate autotune parameter: %s", parameter.Name)
+		}
+		seen[parameter.Name] = true
+		// Reject unknown keys and malformed values before spending budget.
+		for _, value := range parameter.Values {
+			probe := req.Base
+			if err := applyRunOverride(&probe, parameter.Name, value); err != nil {
+				return AutotuneRequest{}, err
+			}
+		}
+	}
+	return req, nil
+}
+
+// autotuneBrackets returns the Hyperband brackets for generation budgets
+// from minGenerations to maxGenerations, most exploratory first: bracket s
+// starts ceil((sMax+1)/(s+1) * eta^s) configurations at maxGenerations /
+// eta^s generations.
+func autotuneBrackets(minGenerations, maxGenerations, eta int) []autotuneBracket {
+	sMax := 0
+	for span := maxGenerations / minGenerations; span >= eta; span /= eta {
+		sMax++
+	}
+	brackets := make([]autotuneBracket, 0, sMax+1)
+	for s := sMax; s >= 0; s-- {
+		scale := int(math.Pow(float64(eta), float64(s)))
+		brackets = append(brackets, autotuneBracket{
+			Configs:     int(math.Ceil(float64(sMax+1) / float64(s+1) * float64(scale))),
+			Generations: max(maxGenerations/scale, minGenerations),
+		})
+	}
+	return brackets
+}
+
+func sampleAutotuneConfig(rng *rand.Rand, space []AutotuneParameter) map[string]string {
+	config := make(map[string]string, len(space))
+	for _, parameter := range space {
+		config[parameter.Name] = parameter.Values[rng.Intn(len(parameter.Values))]
+	}
+	return config
+}
+
+func (c *Client) runAutotuneTrial(ctx context.Context, base RunRequest, baseRunID string, bracket, rung, config int, params map[string]string, generations int) (AutotuneTrial, error) {
+	req := base
+	req.Progress = nil
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := applyRunOverride(&req, name, params[name]); err != nil {
+			return AutotuneTrial{}, err
+		}
+	}
+	req.Generations = generations
+	req.RunID = fmt.Sprintf("%s-b%d-c%d-r%d", baseRunID, bracket, config, rung)
+	started := time.Now()
+	summary, err := c.Run(ctx, req)
+	if err != nil {
+		return AutotuneTrial{}, fmt.Errorf("autotune trial %s: %w", req.RunID, err)
+	}
+	fitness := summary.FinalBestFitness
+	if len(summary.BestByGeneration) > 0 {
+		fitness = slices.Max(summary.BestByGeneration)
+	}
+	return AutotuneTrial{
+		RunID:       summary.RunID,
+		Bracket:     bracket,
+		Rung:        rung,
+		Config:      config,
+		Params:      params,
+		Generations: generations,
+		Fitness:     fitness,
+		Seconds:     time.Since(started).Seconds(),
+	}, nil
+}
+
+// AutotuneFlags renders params as run command flags, sorted by name.
+func AutotuneFlags(params map[string]string) string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	flags := make([]string, 0, len(names))
+	for _, name := range names {
+		flags = append(flags, "--"+name+"="+params[name])
+	}
+	return strings.Join(flags, " ")
+}
@@ -0,0 +1,271 @@
+package protogonos
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"protogonos/internal/agent"
+	"protogonos/internal/baseline"
+	"protogonos/internal/evo"
+	"protogonos/internal/genotype"
+	"protogonos/internal/model"
+	"protogonos/internal/platform"
+	"protogonos/internal/scape"
+	"protogonos/internal/scapeid"
+	"protogonos/internal/stats"
+)
+
+// ESRunRequest runs the es algorithm with its own settings: Run.Population
+// candidates per iteration for Run.Generations iterations, the evaluation
+// budget of a neuroevolution run with the same settings. Run selects the
+// scape, its data and the seed topology as it does for Client.Run; settings
+// that only apply to neuroevolution, such as mutation weights, are ignored.
+type ESRunRequest struct {
+	Run RunRequest
+	// TopologyRunID optimizes the weights of that run's champion instead of
+	// the scape's seed genome.
+	TopologyRunID string
+	// Sigma, LearningRate and WeightDecay default to
+	// baseline.DefaultESSigma, baseline.DefaultESLearningRate and zero.
+	Sigma        float64
+	LearningRate float64
+	WeightDecay  float64
+}
+
+// ESRunSummary is the recorded es run. Its artifacts are those of a
+// neuroevolution run, so runs, fitness, top and replay work on it.
+type ESRunSummary struct {
+	RunSummary
+	Evaluations int
+	Parameters  int
+}
+
+type esSettings struct {
+	topologyRunID string
+	sigma         float64
+	learningRate  float64
+	weightDecay   float64
+}
+
+// ESRun optimizes the weights of a fixed topology with antithetic OpenAI-ES
+// and records the result as a run.
+func (c *Client) ESRun(ctx context.Context, req ESRunRequest) (ESRunSummary, error) {
+	if req.Run.Algorithm != "" && req.Run.Algorithm != AlgorithmES {
+		return ESRunSummary{}, fmt.Errorf("es runs cannot use the %s algorithm", req.Run.Algorithm)
+	}
+	if req.Run.Population%2 != 0 {
+		return ESRunSummary{}, fmt.Errorf("es population must be even for antithetic sampling, got %d", req.Run.Population)
+	}
+	req.Run.Algorithm = AlgorithmES
+	run, err := c.prepareRun(ctx, req.Run)
+	if err != nil {
+		return ESRunSummary{}, err
+	}
+	defer run.close()
+	summary, err := c.runBaseline(ctx, run, esSettings{
+		topologyRunID: req.TopologyRunID,
+		sigma:         req.Sigma,
+		learningRate:  req.LearningRate,
+		weightDecay:   req.WeightDecay,
+	})
+	if err != nil {
+		return ESRunSummary{}, err
+	}
+	return ESRunSummary{
+		RunSummary:  summary,
+		Evaluations: run.baselineEvaluations,
+		Parameters:  run.es.Parameters,
+	}, nil
+}
+
+// runBaseline runs one of the baseline algorithms on the prepared run and
+// records it like an evolved run: per-generation diagnostics, the final
+// candidates as the population snapshot and the best candidate first among
+// the top genomes.
+func (c *Client) runBaseline(ctx context.Context, run *preparedRun, settings esSettings) (RunSummary, error) {
+	req := run.req
+	evaluate, closeEvaluator, err := baselineEvaluator(run)
+	if err != nil {
+		return RunSummary{}, err
+	}
+	defer closeEvaluator()
+
+	inputNeuronIDs, outputNeuronIDs := run.seedPopulation.InputNeuronIDs, run.seedPopulation.OutputNeuronIDs
+	search := baseline.SearchConfig{Population: req.Population, Generations: req.Generations, Workers: req.Workers}
+	var optimized baseline.Result
+	switch req.Algorithm {
+	case AlgorithmRandomSearch:
+		rng := rand.New(rand.NewSource(req.Seed + 1_400))
+		sample := func(generation int) ([]model.Genome, error) {
+			if generation == 1 {
+				return run.initialPopulation, nil
+			}
+			population, err := genotype.ConstructSeedPopulationWithOptions(req.Scape, req.Population, rng.Int63(), seedPopulationOptionsFromRequest(req))
+			if err != nil {
+				return nil, err
+			}
+			genomes := initializeEncodingGenes(run.cfg.Encoding, population.Genomes, inputNeuronIDs, outputNeuronIDs, rng.Int63())
+			for i := range genomes {
+				genomes[i].ID = fmt.Sprintf("%s-rs%d", genomes[i].ID, generation)
+			}
+			return genomes, nil
+		}
+		optimized, err = baseline.RandomSearch(run.runCtx, search, sample, evaluate)
+	case AlgorithmHillClimb:
+		mutation, policy := runMutationOperators(req, inputNeuronIDs, outputNeuronIDs, run.modules, nil)
+		mutator := &evo.PolicyMutator{
+			ScapeName: req.Scape,
+			Mutation:  mutation,
+			Policy:    policy,
+			Rand:      rand.New(rand.NewSource(runMutationSeed(req) + 1_400)),
+		}
+		mutate := func(ctx context.Context, parent model.Genome, id string) (model.Genome, error) {
+			mutant, _, err := mutator.Mutate(ctx, parent, id)
+			return mutant, err
+		}
+		optimized, err = baseline.HillClimb(run.runCtx, search, run.initialPopulation, mutate, evaluate)
+	case AlgorithmES:
+		seed := run.initialPopulation[0]
+		if settings.topologyRunID != "" {
+			topology, err := c.loadTopGenome(ctx, settings.topologyRunID, false, "")
+			if err != nil {
+				return RunSummary{}, err
+			}
+			if topology.scapeName != scapeid.Normalize(req.Scape) {
+				return RunSummary{}, fmt.Errorf("topology run %s uses scape %s, not %s", settings.topologyRunID, topology.scapeName, req.Scape)
+			}
+			seed = topology.genome
+		}
+		cfg := (baseline.ESConfig{
+			Population:   req.Population,
+			Iterations:   req.Generations,
+			Sigma:        settings.sigma,
+			LearningRate: settings.learningRate,
+			WeightDecay:  settings.weightDecay,
+			Workers:      req.Workers,
+			Seed:         req.Seed,
+		}).WithDefaults()
+		optimized, err = baseline.ES(run.runCtx, cfg, seed, evaluate)
+		run.es = &stats.ESRunConfig{
+			Sigma:         cfg.Sigma,
+			LearningRate:  cfg.LearningRate,
+			WeightDecay:   cfg.WeightDecay,
+			Parameters:    optimized.Parameters,
+			TopologyRunID: settings.topologyRunID,
+		}
+	default:
+		return RunSummary{}, fmt.Errorf("unsupported baseline algorithm: %s", req.Algorithm)
+	}
+	if err != nil {
+		return RunSummary{}, err
+	}
+	run.baselineEvaluations = optimized.Evaluations
+
+	result, err := run.polis.RecordRun(ctx, platform.EvolutionConfig{
+		RunID:             run.runID,
+		ScapeName:         req.Scape,
+		InitialGeneration: run.initialGeneration,
+	}, baselineRunResult(optimized, run.initialGeneration))
+	if err != nil {
+		return RunSummary{}, err
+	}
+	return c.recordRun(ctx, run, result, nil)
+}
+
+// baselineRunResult converts a baseline result into the monitor's. The final
+// population is the last generation's candidates and, when an earlier
+// generation found it, the best candidate, so the stored top genomes start
+// with the run's champion.
+func baselineRunResult(optimized baseline.Result, initialGeneration int) evo.RunResult {
+	var out evo.RunResult
+	bestInFinal := false
+	for _, candidate := range optimized.Final {
+		out.FinalPopulation = append(out.FinalPopulation, evo.ScoredGenome{Genome: candidate.Genome, Fitness: candidate.Fitness})
+		bestInFinal = bestInFinal || candidate.Genome.ID == optimized.Best.ID
+	}
+	if !bestInFinal {
+		out.FinalPopulation = append([]evo.ScoredGenome{{Genome: optimized.Best, Fitness: optimized.BestFitness}}, out.FinalPopulation...)
+	}
+	elapsed := 0.0
+	for _, iteration := range optimized.Iterations {
+		elapsed += iteration.Seconds
+		diag := evo.GenerationDiagnostics{
+			Generation:       initialGeneration + iteration.Iteration,
+			BestFitness:      iteration.BestFitness,
+			MeanFitness:      iteration.MeanFitness,
+			MinFitness:       iteration.MinFitness,
+			WallClockSeconds: iteration.Seconds,
+			TotalEvaluations: iteration.Evaluations,
+		}
+		if elapsed > 0 {
+			diag.EvaluationsPerSecond = float64(iteration.Evaluations) / elapsed
+		}
+		out.BestByGeneration = append(out.BestByGeneration, iteration.BestFitness)
+		out.GenerationDiagnostics = append(out.GenerationDiagnostics, diag)
+	}
+	return out
+}
+
+// baselineEvaluator scores genomes on the run's scape in gt mode, checking
+// out instances of a pool sized to the workers for stateful scapes.
+func baselineEvaluator(run *preparedRun) (baseline.Evaluator, func(), error) {
+	req := run.req
+	target, ok := run.polis.GetScape(req.Scape)
+	if !ok {
+		return nil, nil, fmt.Errorf("scape not registered: %s", req.Scape)
+	}
+	if err := run.polis.PrepareScape(run.runCtx, req.Scape); err != nil {
+		return nil, nil, err
+	}
+	var pool *scape.ScapePool
+	if stateful, ok := target.(scape.StatefulScape); ok {
+		var err error
+		if pool, err = scape.NewScapePool(stateful, max(req.Workers, 1)); err != nil {
+			return nil, nil, err
+		}
+		if err := pool.Start(run.runCtx); err != nil {
+			_ = pool.Close()
+			return nil, nil, err
+		}
+	}
+	closePool := func() {
+		if pool != nil {
+			_ = pool.Close()
+		}
+	}
+	inputNeuronIDs, outputNeuronIDs := run.seedPopulation.InputNeuronIDs, run.seedPopulation.OutputNeuronIDs
+	evaluate := func(ctx context.Context, genome model.Genome) (float64, error) {
+		cortex, err := buildReplayCortex(req.Scape, req.Encoding, genome, inputNeuronIDs, outputNeuronIDs)
+		if err != nil {
+			return 0, err
+		}
+		instance := target
+		if pool != nil {
+			if instance, err = pool.Checkout(ctx); err != nil {
+				return 0, err
+			}
+			defer pool.Checkin(instance)
+		}
+		return evaluateBaselineCandidate(ctx, instance, cortex, req.DisableBatchEvaluation)
+	}
+	return evaluate, closePool, nil
+}
+
+func evaluateBaselineCandidate(ctx context.Context, target scape.Scape, cortex *agent.Cortex, disableBatch bool) (float64, error) {
+	var (
+		fitness scape.Fitness
+		err     error
+	)
+	if batchScape, ok := target.(scape.BatchScape); ok && !disableBatch && cortex.BatchEvaluable() {
+		fitness, _, err = batchScape.EvaluateBatch(ctx, cortex, evo.OpModeGT)
+	} else if modeAware, ok := target.(scape.ModeAwareScape); ok {
+		fitness, _, err = modeAware.EvaluateMode(ctx, cortex, evo.OpModeGT)
+	} else {
+		fitness, _, err = target.Evaluate(ctx, cortex)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return float64(fitness), nil
+}
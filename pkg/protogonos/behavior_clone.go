@@ -0,0 +1,97 @@
+package protogonos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"protogonos/internal/evo"
+	"protogonos/internal/model"
+	"protogonos/internal/platform"
+	"protogonos/internal/scape"
+	"protogonos/internal/stats"
+)
+
+const defaultBehaviorCloneFraction = 0.25
+
+// BehaviorCloneSpec bootstraps a run from a scripted policy: Policy is run
+// through one gt evaluation of the run's scape, which must accept step
+// agents, and Fraction of the initial population is fitted to imitate the
+// observation/action pairs it produced before evolution starts. The fitted
+// genomes take the place of the first seed genomes, keeping their ids.
+//
+// The policy itself is not recorded with the run, so forks and lineage
+// replays of the run start from the fitted population's seed instead.
+type BehaviorCloneSpec struct {
+	Policy evo.ScriptedPolicy
+	// Fraction defaults to 0.25.
+	Fraction float64
+	// Iterations is the random search budget of each fit; it defaults to
+	// 200 perturbations.
+	Iterations int
+}
+
+func normalizeBehaviorCloneSpec(spec BehaviorCloneSpec) (BehaviorCloneSpec, error) {
+	if spec.Policy == nil {
+		return BehaviorCloneSpec{}, errors.New("behavior cloning requires a scripted policy")
+	}
+	if spec.Fraction == 0 {
+		spec.Fraction = defaultBehaviorCloneFraction
+	}
+	if !(spec.Fraction > 0 && spec.Fraction <= 1) {
+		return BehaviorCloneSpec{}, fmt.Errorf("behavior clone fraction must be in (0, 1], got %g", spec.Fraction)
+	}
+	if spec.Iterations < 0 {
+		return BehaviorCloneSpec{}, errors.New("behavior clone iterations must be >= 0")
+	}
+	if spec.Iterations == 0 {
+		spec.Iterations = evo.DefaultBehaviorCloneIterations
+	}
+	return spec, nil
+}
+
+// cloneScriptedPolicy records the demonstrations of the run's scripted
+// policy and replaces the head of population with genomes fitted to them.
+func cloneScriptedPolicy(ctx context.Context, p *platform.Polis, req RunRequest, population []model.Genome, inputIDs, outputIDs []string) ([]model.Genome, *stats.BehaviorCloneRunConfig, error) {
+	spec := req.BehaviorClone
+	target, ok := p.GetScape(req.Scape)
+	if !ok {
+		return nil, nil, fmt.Errorf("scape not registered: %s", req.Scape)
+	}
+	if _, ok := target.(scape.StatefulScape); ok {
+		return nil, nil, fmt.Errorf("behavior cloning does not support stateful scape %s", req.Scape)
+	}
+	if err := p.PrepareScape(ctx, req.Scape); err != nil {
+		return nil, nil, err
+	}
+	demonstrations, policyFitness, err := evo.RecordDemonstrations(ctx, target, spec.Policy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	build := func(genome model.Genome) (scape.StepAgent, error) {
+		return buildReplayCortex(req.Scape, req.Encoding, genome, inputIDs, outputIDs)
+	}
+	count := int(math.Round(spec.Fraction * float64(len(population))))
+	count = max(1, min(count, len(population)))
+	seeded := append([]model.Genome(nil), population...)
+	record := &stats.BehaviorCloneRunConfig{
+		Genomes:        count,
+		Iterations:     spec.Iterations,
+		Demonstrations: len(demonstrations),
+		PolicyFitness:  float64(policyFitness),
+	}
+	for i := 0; i < count; i++ {
+		fitted, loss, err := evo.CloneBehavior(ctx, seeded[i], inputIDs, demonstrations, build, evo.BehaviorCloneConfig{
+			Iterations: spec.Iterations,
+			Seed:       req.Seed + 3100 + int64(i),
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("clone scripted policy into %s: %w", seeded[i].ID, err)
+		}
+		seeded[i] = fitted
+		record.MeanLoss += loss / float64(count)
+	}
+	return seeded, record, nil
+}
@@ -0,0 +1,195 @@
+package protogonos
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"protogonos/internal/evo"
+	"protogonos/internal/genotype"
+	"protogonos/internal/model"
+	"protogonos/internal/morphology"
+	"protogonos/internal/scapeid"
+	"protogonos/internal/stats"
+)
+
+const (
+	defaultChampionSeedTop      = 10
+	defaultChampionSeedFraction = 0.25
+)
+
+// ChampionSeedSpec selects archived champions to seed a run with: the Top
+// best distinct top genomes of every indexed run of Scape replace Fraction
+// of the initial population as mutated copies.
+type ChampionSeedSpec struct {
+	Scape    string
+	Top      int
+	Fraction float64
+}
+
+// String returns the canonical scape=<name> top=<n> fraction=<f> form.
+func (s ChampionSeedSpec) String() string {
+	return fmt.Sprintf("scape=%s top=%d fraction=%s", s.Scape, s.Top, strconv.FormatFloat(s.Fraction, 'g', -1, 64))
+}
+
+// ParseChampionSeedSpec parses key=value pairs separated by spaces or
+// commas, e.g. "scape=xor top=20". Scape defaults to runScape, top to 10 and
+// fraction to 0.25.
+func ParseChampionSeedSpec(spec, runScape string) (ChampionSeedSpec, error) {
+	parsed := ChampionSeedSpec{
+		Scape:    scapeid.Normalize(runScape),
+		Top:      defaultChampionSeedTop,
+		Fraction: defaultChampionSeedFraction,
+	}
+	fields := strings.FieldsFunc(spec, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok || value == "" {
+			return ChampionSeedSpec{}, fmt.Errorf("invalid init-from-champions entry %q: want key=value", field)
+		}
+		switch strings.ToLower(key) {
+		case "scape":
+			parsed.Scape = scapeid.Normalize(value)
+		case "top":
+			top, err := strconv.Atoi(value)
+			if err != nil || top <= 0 {
+				return ChampionSeedSpec{}, fmt.Errorf("init-from-champions top must be a positive integer, got %q", value)
+			}
+			parsed.Top = top
+		case "fraction":
+			fraction, err := strconv.ParseFloat(value, 64)
+			if err != nil || !(fraction > 0 && fraction <= 1) {
+				return ChampionSeedSpec{}, fmt.Errorf("init-from-champions fraction must be in (0, 1], got %q", value)
+			}
+			parsed.Fraction = fraction
+		default:
+			return ChampionSeedSpec{}, fmt.Errorf("unsupported init-from-champions key: %s", key)
+		}
+	}
+	if parsed.Scape == "" {
+		return ChampionSeedSpec{}, fmt.Errorf("init-from-champions requires a scape")
+	}
+	return parsed, nil
+}
+
+type archivedChampion struct {
+	runID   string
+	fitness float64
+	genome  model.Genome
+}
+
+// archivedChampions collects the best top genomes across the indexed runs
+// of spec.Scape, best first and one per fingerprint. Genomes that lack the
+// run's input/output neurons or do not fit its morphology are skipped.
+func (c *Client) archivedChampions(spec ChampionSeedSpec, runScape string, inputIDs, outputIDs []string) ([]archivedChampion, error) {
+	entries, err := stats.ListRunIndex(c.benchmarksDir)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []archivedChampion
+	for _, entry := range entries {
+		if scapeid.Normalize(entry.Scape) != spec.Scape {
+			continue
+		}
+		top, ok, err := stats.ReadTopGenomes(c.benchmarksDir, entry.RunID)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		for _, item := range top {
+			if !hasNeurons(item.Genome, inputIDs) || !hasNeurons(item.Genome, outputIDs) {
+				continue
+			}
+			if morphology.EnsureGenomeIOCompatibility(runScape, item.Genome) != nil {
+				continue
+			}
+			candidates = append(candidates, archivedChampion{runID: entry.RunID, fitness: item.Fitness, genome: item.Genome})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].fitness != candidates[j].fitness {
+			return candidates[i].fitness > candidates[j].fitness
+		}
+		if candidates[i].runID != candidates[j].runID {
+			return candidates[i].runID < candidates[j].runID
+		}
+		return candidates[i].genome.ID < candidates[j].genome.ID
+	})
+
+	seen := make(map[string]struct{}, len(candidates))
+	champions := make([]archivedChampion, 0, spec.Top)
+	for _, candidate := range candidates {
+		if len(champions) == spec.Top {
+			break
+		}
+		fingerprint := evo.ComputeGenomeSignature(candidate.genome).Fingerprint
+		if _, ok := seen[fingerprint]; ok {
+			continue
+		}
+		seen[fingerprint] = struct{}{}
+		champions = append(champions, candidate)
+	}
+	return champions, nil
+}
+
+// seedFromChampions replaces the tail of population with mutated copies of
+// the archived champions, cycling through them best first. Each copy takes
+// over the id of the seed genome it replaces. It returns the seeded
+// population and the run/genome ids of the champions used.
+func (c *Client) seedFromChampions(ctx context.Context, req RunRequest, population []model.Genome, inputIDs, outputIDs []string) ([]model.Genome, []string, error) {
+	spec, err := ParseChampionSeedSpec(req.InitFromChampions, req.Scape)
+	if err != nil {
+		return nil, nil, err
+	}
+	champions, err := c.archivedChampions(spec, req.Scape, inputIDs, outputIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(champions) == 0 {
+		return nil, nil, fmt.Errorf("no archived champions of scape %s are compatible with scape %s", spec.Scape, req.Scape)
+	}
+
+	count := int(math.Round(spec.Fraction * float64(len(population))))
+	count = max(1, min(count, len(population)))
+	mutation := &evo.PerturbWeightsProportional{Rand: rand.New(rand.NewSource(req.Seed + 3000)), MaxDelta: 1.0}
+	seeded := append([]model.Genome(nil), population...)
+	used := make([]string, 0, min(count, len(champions)))
+	for i := 0; i < count; i++ {
+		champion := champions[i%len(champions)]
+		if i < len(champions) {
+			used = append(used, champion.runID+"/"+champion.genome.ID)
+		}
+		copied := genotype.CloneGenome(champion.genome)
+		if mutation.Applicable(copied, req.Scape) {
+			copied, err = mutation.Apply(ctx, copied)
+			if err != nil {
+				return nil, nil, fmt.Errorf("mutate champion %s: %w", champion.genome.ID, err)
+			}
+		}
+		idx := len(seeded) - count + i
+		copied.ID = seeded[idx].ID
+		seeded[idx] = copied
+	}
+	return seeded, used, nil
+}
+
+func hasNeurons(genome model.Genome, ids []string) bool {
+	present := make(map[string]struct{}, len(genome.Neurons))
+	for _, neuron := range genome.Neurons {
+		present[neuron.ID] = struct{}{}
+	}
+	for _, id := range ids {
+		if _, ok := present[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
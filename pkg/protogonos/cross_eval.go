@@ -0,0 +1,180 @@
+package protogonos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"protogonos/internal/scape"
+)
+
+// CrossEvalRequest scores the champion of every run in RunIDs on the scape
+// configuration of every run: its construction parameters and data splits.
+// Modes defaults to validation and test.
+type CrossEvalRequest struct {
+	RunIDs []string
+	Modes  []string
+}
+
+// CrossEvalCell is one champion scored on another run's scape. Error is set
+// instead of Fitness when the champion cannot be evaluated there, e.g. because
+// the runs use different scapes or input widths.
+type CrossEvalCell struct {
+	ChampionRunID string  `json:"champion_run_id"`
+	GenomeID      string  `json:"genome_id"`
+	EvalRunID     string  `json:"eval_run_id"`
+	Mode          string  `json:"mode"`
+	Fitness       float64 `json:"fitness"`
+	Error         string  `json:"error,omitempty"`
+}
+
+// CrossEvalGap compares a champion's score on its own run (the diagonal) with
+// its mean score on the other runs it could be evaluated on. A large gap
+// suggests the champion overfits its own data split.
+type CrossEvalGap struct {
+	ChampionRunID string  `json:"champion_run_id"`
+	Mode          string  `json:"mode"`
+	Self          float64 `json:"self"`
+	MeanOther     float64 `json:"mean_other"`
+	Gap           float64 `json:"gap"`
+	Others        int     `json:"others"`
+}
+
+// CrossEvalResult holds the matrix cells ordered by mode, champion run and
+// evaluation run, followed by the per-champion gaps.
+type CrossEvalResult struct {
+	RunIDs []string        `json:"run_ids"`
+	Modes  []string        `json:"modes"`
+	Cells  []CrossEvalCell `json:"cells"`
+	Gaps   []CrossEvalGap  `json:"gaps"`
+}
+
+// CrossEval evaluates each run's champion on each run's validation/test
+// splits.
+func (c *Client) CrossEval(ctx context.Context, req CrossEvalRequest) (CrossEvalResult, error) {
+	if len(req.RunIDs) < 2 {
+		return CrossEvalResult{}, errors.New("cross evaluation requires at least two run ids")
+	}
+	seen := make(map[string]struct{}, len(req.RunIDs))
+	for _, runID := range req.RunIDs {
+		if strings.TrimSpace(runID) == "" {
+			return CrossEvalResult{}, errors.New("cross evaluation run ids must not be empty")
+		}
+		if _, ok := seen[runID]; ok {
+			return CrossEvalResult{}, fmt.Errorf("duplicate cross evaluation run id: %s", runID)
+		}
+		seen[runID] = struct{}{}
+	}
+	modes := req.Modes
+	if len(modes) == 0 {
+		modes = []string{"validation", "test"}
+	}
+	normalized := make([]string, 0, len(modes))
+	for _, mode := range modes {
+		mode = strings.ToLower(strings.TrimSpace(mode))
+		switch mode {
+		case "gt", "validation", "test", "benchmark":
+		default:
+			return CrossEvalResult{}, fmt.Errorf("unsupported cross evaluation mode: %s", mode)
+		}
+		normalized = append(normalized, mode)
+	}
+
+	loaded := make([]loadedGenome, 0, len(req.RunIDs))
+	for _, runID := range req.RunIDs {
+		run, err := c.loadTopGenome(ctx, runID, false, "")
+		if err != nil {
+			return CrossEvalResult{}, err
+		}
+		loaded = append(loaded, run)
+	}
+
+	result := CrossEvalResult{
+		RunIDs: append([]string(nil), req.RunIDs...),
+		Modes:  normalized,
+	}
+	for _, mode := range normalized {
+		for _, champion := range loaded {
+			self := 0.0
+			hasSelf := false
+			otherSum := 0.0
+			others := 0
+			for _, target := range loaded {
+				cell := CrossEvalCell{
+					ChampionRunID: champion.runID,
+					GenomeID:      champion.genome.ID,
+					EvalRunID:     target.runID,
+					Mode:          mode,
+				}
+				fitness, err := crossEvaluate(ctx, champion, target, mode)
+				if err != nil {
+					if ctx.Err() != nil {
+						return CrossEvalResult{}, ctx.Err()
+					}
+					cell.Error = err.Error()
+					result.Cells = append(result.Cells, cell)
+					continue
+				}
+				cell.Fitness = fitness
+				result.Cells = append(result.Cells, cell)
+				if target.runID == champion.runID {
+					self, hasSelf = fitness, true
+				} else {
+					otherSum += fitness
+					others++
+				}
+			}
+			if hasSelf && others > 0 {
+				meanOther := otherSum / float64(others)
+				result.Gaps = append(result.Gaps, CrossEvalGap{
+					ChampionRunID: champion.runID,
+					Mode:          mode,
+					Self:          self,
+					MeanOther:     meanOther,
+					Gap:           self - meanOther,
+					Others:        others,
+				})
+			}
+		}
+	}
+	return result, nil
+}
+
+// crossEvaluate scores champion's genome, wired for its own run, on the scape
+// instance and data sources of target.
+func crossEvaluate(ctx context.Context, champion, target loadedGenome, mode string) (float64, error) {
+	if champion.scapeName != target.scapeName {
+		return 0, fmt.Errorf("scape mismatch: champion uses %s, run uses %s", champion.scapeName, target.scapeName)
+	}
+	championInputs, championOutputs, err := defaultSeedIONeuronsForScape(champion.request)
+	if err != nil {
+		return 0, err
+	}
+	targetInputs, targetOutputs, err := defaultSeedIONeuronsForScape(target.request)
+	if err != nil {
+		return 0, err
+	}
+	if len(championInputs) != len(targetInputs) || len(championOutputs) != len(targetOutputs) {
+		return 0, fmt.Errorf("morphology mismatch: champion has %d inputs and %d outputs, run has %d and %d",
+			len(championInputs), len(championOutputs), len(targetInputs), len(targetOutputs))
+	}
+	evalCtx, err := applyScapeDataSources(ctx, target.request)
+	if err != nil {
+		return 0, err
+	}
+	cortex, err := champion.cortex()
+	if err != nil {
+		return 0, err
+	}
+	var fitness scape.Fitness
+	if modeAware, ok := target.scape.(scape.ModeAwareScape); ok {
+		fitness, _, err = modeAware.EvaluateMode(evalCtx, cortex, mode)
+	} else {
+		fitness, _, err = target.scape.Evaluate(evalCtx, cortex)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return float64(fitness), nil
+}
@@ -0,0 +1,133 @@
+package protogonos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"protogonos/internal/evo"
+	"protogonos/internal/genotype"
+	"protogonos/internal/morphology"
+	"protogonos/internal/stats"
+)
+
+// RunBudget estimates the evaluations a run will spend. Evaluations counts
+// population evaluations, each of EpisodesPerEvaluation trials, over the
+// generations the run will reach: all of them, or fewer when
+// EvaluationsLimit stops it first. MinTuningEvaluations assumes every tuned
+// genome gives up after its base attempts without improving; tuning keeps
+// going while it improves, so it usually costs more. Runs is two when
+// CompareTuning evolves the population once with and once without tuning;
+// the totals cover both.
+type RunBudget struct {
+	Generations           int
+	Capped                bool
+	Runs                  int
+	Evaluations           int
+	EpisodesPerEvaluation int
+	Episodes              int
+	MinTuningEvaluations  int
+}
+
+// RunPlan is a resolved and validated run that has not been started. Config
+// is the config.json the run would record.
+type RunPlan struct {
+	RunID           string
+	Config          stats.RunConfig
+	InputNeuronIDs  []string
+	OutputNeuronIDs []string
+	Budget          RunBudget
+}
+
+// PlanRun resolves req the way Run does and validates it against the scape
+// morphology, its data sources and the store, without evaluating anything or
+// writing artifacts.
+func (c *Client) PlanRun(ctx context.Context, req RunRequest) (RunPlan, error) {
+	cfg, err := materializeRunConfigFromRequest(req)
+	if err != nil {
+		return RunPlan{}, err
+	}
+	req = cfg.Request
+	if _, err := applyScapeDataSources(ctx, req); err != nil {
+		return RunPlan{}, err
+	}
+	if err := morphology.EnsureScapeCompatibility(req.Scape); err != nil {
+		return RunPlan{}, err
+	}
+	if _, err := c.ensurePolis(ctx); err != nil {
+		return RunPlan{}, err
+	}
+	if req.WeightInsertModule > 0 {
+		if _, err := c.moduleLibraryForRun(req.Modules); err != nil {
+			return RunPlan{}, err
+		}
+	}
+
+	seedPopulation, err := genotype.ConstructSeedPopulationWithOptions(req.Scape, req.Population, req.Seed, seedPopulationOptionsFromRequest(req))
+	if err != nil {
+		return RunPlan{}, err
+	}
+	population := seedPopulation.Genomes
+	initialGeneration := 0
+	var championSources []string
+	if req.InitFromChampions != "" {
+		population, championSources, err = c.seedFromChampions(ctx, req, population, seedPopulation.InputNeuronIDs, seedPopulation.OutputNeuronIDs)
+		if err != nil {
+			return RunPlan{}, err
+		}
+	}
+	if req.ContinuePopulationID != "" {
+		snapshot, continued, err := genotype.LoadPopulationSnapshot(ctx, c.store, req.ContinuePopulationID)
+		if err != nil {
+			return RunPlan{}, err
+		}
+		if len(continued) == 0 {
+			return RunPlan{}, fmt.Errorf("continued population is empty: %s", req.ContinuePopulationID)
+		}
+		population = continued
+		req.Population = len(continued)
+		initialGeneration = snapshot.Generation
+	}
+	if err := morphology.EnsurePopulationIOCompatibility(req.Scape, population); err != nil {
+		return RunPlan{}, err
+	}
+
+	runID := resolveRunID(req, time.Now().UTC())
+	return RunPlan{
+		RunID:           runID,
+		Config:          runConfigFromRequest(req, runID, runEliteCount(req), initialGeneration, championSources),
+		InputNeuronIDs:  seedPopulation.InputNeuronIDs,
+		OutputNeuronIDs: seedPopulation.OutputNeuronIDs,
+		Budget:          estimateRunBudget(req),
+	}, nil
+}
+
+func estimateRunBudget(req RunRequest) RunBudget {
+	budget := RunBudget{
+		Generations:           req.Generations,
+		Runs:                  1,
+		EpisodesPerEvaluation: max(1, req.EvaluationTrials),
+	}
+	if req.OpMode != evo.OpModeGT {
+		budget.Generations = 1
+	}
+	if req.EvaluationsLimit > 0 && req.Population > 0 {
+		// The limit is checked after each generation, so the generation that
+		// crosses it still completes.
+		limited := (req.EvaluationsLimit + req.Population - 1) / req.Population
+		if limited < budget.Generations {
+			budget.Generations = limited
+			budget.Capped = true
+		}
+	}
+	if req.CompareTuning {
+		budget.Runs = 2
+	}
+	perRun := req.Population * budget.Generations
+	budget.Evaluations = perRun * budget.Runs
+	budget.Episodes = budget.Evaluations * budget.EpisodesPerEvaluation
+	if req.EnableTuning || req.CompareTuning {
+		budget.MinTuningEvaluations = perRun * (1 + req.TuneAttempts)
+	}
+	return budget
+}
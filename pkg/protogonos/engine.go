@@ -0,0 +1,169 @@
+package protogonos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"protogonos/internal/model"
+	"protogonos/internal/platform"
+	"protogonos/internal/stats"
+)
+
+// Engine is a run its caller drives one generation at a time, for embedders
+// that schedule evolution inside their own loop (a game server evolving NPCs
+// between ticks, say). It resolves and persists runs exactly like Run; Finish
+// writes the same artifacts. An Engine is not safe for concurrent use.
+type Engine struct {
+	client      *Client
+	run         *preparedRun
+	evolution   *platform.EvolutionRun
+	traceStream *stats.TraceStreamWriter
+	last        EngineGeneration
+	closed      bool
+}
+
+// EngineGeneration summarizes the latest evaluated generation. Champion is
+// its best genome. Done reports that the run will not evaluate another
+// generation.
+type EngineGeneration struct {
+	Generation       int
+	BestFitness      float64
+	MeanFitness      float64
+	SpeciesCount     int
+	TotalEvaluations int
+	Champion         model.Genome
+	Done             bool
+}
+
+// NewEngine prepares req for stepping without evaluating anything. Runs that
+// evolve more than once (CompareTuning), evolve continuously (steady state
+// or online) or wait for a live command (StartPaused) cannot be stepped.
+func (c *Client) NewEngine(ctx context.Context, req RunRequest) (*Engine, error) {
+	if req.CompareTuning {
+		return nil, errors.New("engine cannot step a compare-tuning run")
+	}
+	if req.StartPaused {
+		return nil, errors.New("engine runs are paused between steps; start paused is not supported")
+	}
+	// The run outlives ctx; steps bring their own cancellation.
+	run, err := c.prepareRun(context.WithoutCancel(ctx), req)
+	if err != nil {
+		return nil, err
+	}
+	traceStream, err := stats.OpenTraceStream(filepath.Join(c.benchmarksDir, run.runID))
+	if err != nil {
+		run.close()
+		return nil, err
+	}
+	evolution, err := run.polis.StartEvolution(run.evolutionConfig(run.req.EnableTuning, nil, traceStream))
+	if err != nil {
+		_ = traceStream.Close()
+		run.close()
+		return nil, err
+	}
+	return &Engine{client: c, run: run, evolution: evolution, traceStream: traceStream}, nil
+}
+
+// RunID is the id the run's artifacts are written under.
+func (e *Engine) RunID() string {
+	return e.run.runID
+}
+
+// Done reports whether the run has finished evolving.
+func (e *Engine) Done() bool {
+	return e.evolution.Done()
+}
+
+// Step evaluates one generation and breeds the next. Once the run is done,
+// Step returns the last generation again without evaluating anything.
+func (e *Engine) Step(ctx context.Context) (EngineGeneration, error) {
+	if e.closed {
+		return EngineGeneration{}, fmt.Errorf("engine run %s is closed", e.run.runID)
+	}
+	if e.evolution.Done() {
+		e.last.Done = true
+		return e.last, nil
+	}
+	stepCtx, cancel := e.stepContext(ctx)
+	defer cancel()
+	evaluated := len(e.evolution.Diagnostics())
+	if err := e.evolution.Step(stepCtx); err != nil {
+		return EngineGeneration{}, dumpInvariantViolation(filepath.Join(e.client.benchmarksDir, e.run.runID), err)
+	}
+	diagnostics := e.evolution.Diagnostics()
+	if len(diagnostics) > evaluated {
+		latest := diagnostics[len(diagnostics)-1]
+		e.last = EngineGeneration{
+			Generation:   latest.Generation,
+			BestFitness:  latest.BestFitness,
+			MeanFitness:  latest.MeanFitness,
+			SpeciesCount: latest.SpeciesCount,
+		}
+		if scored := e.evolution.Scored(); len(scored) > 0 {
+			e.last.Champion = scored[0].Genome
+		}
+	}
+	e.last.TotalEvaluations = e.evolution.TotalEvaluations()
+	e.last.Done = e.evolution.Done()
+	return e.last, nil
+}
+
+// RunUntil steps until the run is done or stop, called after every step,
+// returns true. A nil stop runs to completion.
+func (e *Engine) RunUntil(ctx context.Context, stop func(EngineGeneration) bool) (EngineGeneration, error) {
+	for {
+		generation, err := e.Step(ctx)
+		if err != nil {
+			return EngineGeneration{}, err
+		}
+		if generation.Done || (stop != nil && stop(generation)) {
+			return generation, nil
+		}
+	}
+}
+
+// Finish persists the generations evaluated so far, writes the run's
+// artifacts and closes the engine. The run need not be done.
+func (e *Engine) Finish(ctx context.Context) (RunSummary, error) {
+	if e.closed {
+		return RunSummary{}, fmt.Errorf("engine run %s is closed", e.run.runID)
+	}
+	stepCtx, cancel := e.stepContext(ctx)
+	defer cancel()
+	result, err := e.evolution.Finish(stepCtx)
+	if err != nil {
+		_ = e.Close()
+		return RunSummary{}, err
+	}
+	if err := e.traceStream.Finish(); err != nil {
+		_ = e.Close()
+		return RunSummary{}, fmt.Errorf("write trace stream: %w", err)
+	}
+	summary, err := e.client.recordRun(stepCtx, e.run, result, nil)
+	_ = e.Close()
+	return summary, err
+}
+
+// Close abandons the run without persisting it. It is safe to call more
+// than once and after Finish.
+func (e *Engine) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	e.evolution.Close()
+	e.run.close()
+	return e.traceStream.Close()
+}
+
+// stepContext carries the run's scape data sources with ctx's cancellation.
+func (e *Engine) stepContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	stepCtx, cancel := context.WithCancel(e.run.runCtx)
+	stopAfter := context.AfterFunc(ctx, cancel)
+	return stepCtx, func() {
+		stopAfter()
+		cancel()
+	}
+}
@@ -0,0 +1,80 @@
+package protogonos
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"protogonos/internal/genotype"
+	"protogonos/internal/scapeid"
+	"protogonos/internal/stats"
+)
+
+// CompactGenomeExtension names compact champion exports.
+const CompactGenomeExtension = ".pgc"
+
+// exportCompactChampion writes a run's champion as
+// <outDir>/<runID>-champion.pgc in the compact binary encoding, with biases
+// and weights quantized as named. The file also carries the scape and the I/O
+// neuron ids, so LoadCompactGenome and serve-model need nothing else.
+func exportCompactChampion(baseDir, runID, morphology, outDir, quantization string) (ExportSummary, error) {
+	cfg, ok, err := readRunConfigWithProfileHints(baseDir, runID)
+	if err != nil {
+		return ExportSummary{}, err
+	}
+	if !ok {
+		return ExportSummary{}, fmt.Errorf("run config not found for run id: %s", runID)
+	}
+	top, ok, err := stats.ReadTopGenomes(baseDir, runID)
+	if err != nil {
+		return ExportSummary{}, err
+	}
+	if !ok || len(top) == 0 {
+		return ExportSummary{}, fmt.Errorf("top genomes not found for run id: %s", runID)
+	}
+	inputNeuronIDs, outputNeuronIDs, err := defaultSeedIONeuronsForScape(runRequestFromArtifactsConfig(cfg))
+	if err != nil {
+		return ExportSummary{}, err
+	}
+	data, err := genotype.EncodeCompactGenome(genotype.CompactGenome{
+		Scape:           scapeid.Normalize(cfg.Scape),
+		InputNeuronIDs:  inputNeuronIDs,
+		OutputNeuronIDs: outputNeuronIDs,
+		Encoding:        cfg.Encoding,
+		Genome:          top[0].Genome,
+	}, quantization)
+	if err != nil {
+		return ExportSummary{}, fmt.Errorf("encode champion %s: %w", top[0].Genome.ID, err)
+	}
+
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return ExportSummary{}, err
+	}
+	archive := filepath.Join(outDir, runID+"-champion"+CompactGenomeExtension)
+	if err := os.WriteFile(archive, data, 0o644); err != nil {
+		return ExportSummary{}, err
+	}
+	return ExportSummary{
+		RunID:        runID,
+		Morphology:   morphology,
+		Directory:    filepath.Clean(outDir),
+		Profile:      ExportProfileCompact,
+		Archive:      filepath.Clean(archive),
+		Files:        1,
+		Bytes:        len(data),
+		Quantization: quantization,
+	}, nil
+}
+
+// LoadCompactGenome reads a compact genome export.
+func LoadCompactGenome(path string) (genotype.CompactGenome, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return genotype.CompactGenome{}, err
+	}
+	compact, err := genotype.DecodeCompactGenome(data)
+	if err != nil {
+		return genotype.CompactGenome{}, fmt.Errorf("load %s: %w", path, err)
+	}
+	return compact, nil
+}
@@ -0,0 +1,244 @@
+package protogonos
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"protogonos/internal/model"
+	"protogonos/internal/stats"
+)
+
+// Export profiles.
+const (
+	ExportProfileFull    = "full"
+	ExportProfilePaper   = "paper"
+	ExportProfileCompact = "compact"
+)
+
+// PaperArchiveFormat versions the layout of paper export archives.
+const PaperArchiveFormat = "protogonos-paper/1"
+
+// paperArchiveTime stamps every archive entry so that exporting the same run
+// twice yields byte-identical archives.
+var paperArchiveTime = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// PaperManifest describes a paper export archive. Files lists every entry
+// other than the manifest itself and SHA256SUMS, with its size and SHA-256.
+type PaperManifest struct {
+	Format          string              `json:"format"`
+	Scape           string              `json:"scape"`
+	Morphology      string              `json:"morphology,omitempty"`
+	Seed            int64               `json:"seed"`
+	PopulationSize  int                 `json:"population_size"`
+	Generations     int                 `json:"generations"`
+	GenerationsRun  int                 `json:"generations_run"`
+	ChampionFitness float64             `json:"champion_fitness"`
+	Files           []PaperManifestFile `json:"files"`
+}
+
+type PaperManifestFile struct {
+	Name   string `json:"name"`
+	Bytes  int    `json:"bytes"`
+	SHA256 string `json:"sha256"`
+}
+
+type paperArchiveEntry struct {
+	name string
+	data []byte
+}
+
+// exportPaperArchive bundles a run as <outDir>/<runID>-paper.zip for
+// supplementary material: the anonymized run config, per-generation aggregate
+// statistics as CSV, the anonymized champion genomes, fitness and species
+// plots, a manifest and a sha256sum-compatible SHA256SUMS. Nothing in the
+// archive names the run, its fork parents, local file paths or free-text
+// annotations.
+func exportPaperArchive(baseDir, runID, morphology, outDir string) (ExportSummary, error) {
+	cfg, ok, err := stats.ReadRunConfig(baseDir, runID)
+	if err != nil {
+		return ExportSummary{}, err
+	}
+	if !ok {
+		return ExportSummary{}, fmt.Errorf("run config not found for run id: %s", runID)
+	}
+	diagnostics, _, err := stats.ReadGenerationDiagnostics(baseDir, runID)
+	if err != nil {
+		return ExportSummary{}, err
+	}
+	history, _, err := stats.ReadSpeciesHistory(baseDir, runID)
+	if err != nil {
+		return ExportSummary{}, err
+	}
+	top, _, err := stats.ReadTopGenomes(baseDir, runID)
+	if err != nil {
+		return ExportSummary{}, err
+	}
+
+	configData, err := paperJSON(anonymizeRunConfig(cfg))
+	if err != nil {
+		return ExportSummary{}, err
+	}
+	statsData, err := generationStatsCSV(diagnostics)
+	if err != nil {
+		return ExportSummary{}, err
+	}
+	championsData, err := paperJSON(anonymizeTopGenomes(top))
+	if err != nil {
+		return ExportSummary{}, err
+	}
+	entries := []paperArchiveEntry{
+		{name: "config.json", data: configData},
+		{name: "generation_stats.csv", data: statsData},
+		{name: "champions.json", data: championsData},
+	}
+	if len(diagnostics) > 0 {
+		chart := fitnessChart(diagnostics)
+		chart.Title = cfg.Scape + " fitness"
+		entries = append(entries, paperArchiveEntry{name: "plots/fitness.svg", data: stats.RenderChartSVG(chart)})
+	}
+	if len(history) > 0 {
+		chart := speciesChart(history)
+		chart.Title = cfg.Scape + " species"
+		entries = append(entries, paperArchiveEntry{name: "plots/species.svg", data: stats.RenderChartSVG(chart)})
+	}
+
+	manifest := PaperManifest{
+		Format:         PaperArchiveFormat,
+		Scape:          cfg.Scape,
+		Morphology:     morphology,
+		Seed:           cfg.Seed,
+		PopulationSize: cfg.PopulationSize,
+		Generations:    cfg.Generations,
+		GenerationsRun: len(diagnostics),
+	}
+	if len(top) > 0 {
+		manifest.ChampionFitness = top[0].Fitness
+	}
+	for _, entry := range entries {
+		manifest.Files = append(manifest.Files, PaperManifestFile{Name: entry.name, Bytes: len(entry.data), SHA256: sha256Hex(entry.data)})
+	}
+	manifestData, err := paperJSON(manifest)
+	if err != nil {
+		return ExportSummary{}, err
+	}
+	entries = append(entries, paperArchiveEntry{name: "manifest.json", data: manifestData})
+	var sums bytes.Buffer
+	for _, entry := range entries {
+		fmt.Fprintf(&sums, "%s  %s\n", sha256Hex(entry.data), entry.name)
+	}
+	entries = append(entries, paperArchiveEntry{name: "SHA256SUMS", data: sums.Bytes()})
+
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return ExportSummary{}, err
+	}
+	archive := filepath.Join(outDir, runID+"-paper.zip")
+	if err := writePaperArchive(archive, entries); err != nil {
+		return ExportSummary{}, err
+	}
+	return ExportSummary{
+		RunID:      runID,
+		Morphology: morphology,
+		Directory:  filepath.Clean(outDir),
+		Profile:    ExportProfilePaper,
+		Archive:    filepath.Clean(archive),
+		Files:      len(entries),
+	}, nil
+}
+
+// anonymizeRunConfig drops the run and fork identifiers and reduces data
+// file paths to their base names, which still identify the dataset.
+func anonymizeRunConfig(cfg stats.RunConfig) stats.RunConfig {
+	cfg.RunID = ""
+	cfg.ContinuePopulationID = ""
+	cfg.ForkedFrom = ""
+	cfg.ForkGeneration = 0
+	cfg.InitChampionSources = nil
+	for _, path := range []*string{&cfg.GTSACSVPath, &cfg.FXCSVPath, &cfg.EpitopesCSVPath, &cfg.LLVMWorkflowJSONPath} {
+		if *path != "" {
+			*path = filepath.Base(*path)
+		}
+	}
+	return cfg
+}
+
+// anonymizeTopGenomes strips free-text annotations and provenance notes,
+// which may carry operator comments, from the champion genomes.
+func anonymizeTopGenomes(top []stats.TopGenome) []stats.TopGenome {
+	out := make([]stats.TopGenome, len(top))
+	for i, item := range top {
+		item.Genome.Annotations = nil
+		if item.Genome.Provenance != nil {
+			provenance := *item.Genome.Provenance
+			provenance.Notes = nil
+			item.Genome.Provenance = &provenance
+		}
+		out[i] = item
+	}
+	return out
+}
+
+func generationStatsCSV(diagnostics []model.GenerationDiagnostics) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	rows := [][]string{{
+		"generation", "best_fitness", "mean_fitness", "min_fitness", "species_count",
+		"fingerprint_diversity", "mean_species_size", "largest_species_size", "total_evaluations",
+	}}
+	for _, d := range diagnostics {
+		rows = append(rows, []string{
+			strconv.Itoa(d.Generation),
+			strconv.FormatFloat(d.BestFitness, 'g', -1, 64),
+			strconv.FormatFloat(d.MeanFitness, 'g', -1, 64),
+			strconv.FormatFloat(d.MinFitness, 'g', -1, 64),
+			strconv.Itoa(d.SpeciesCount),
+			strconv.Itoa(d.FingerprintDiversity),
+			strconv.FormatFloat(d.MeanSpeciesSize, 'g', -1, 64),
+			strconv.Itoa(d.LargestSpeciesSize),
+			strconv.Itoa(d.TotalEvaluations),
+		})
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writePaperArchive(path string, entries []paperArchiveEntry) error {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, entry := range entries {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: entry.name, Method: zip.Deflate, Modified: paperArchiveTime})
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(entry.data); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+func paperJSON(value any) ([]byte, error) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
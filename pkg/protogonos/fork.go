@@ -0,0 +1,438 @@
+package protogonos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"protogonos/internal/genotype"
+	"protogonos/internal/stats"
+)
+
+// ForkRequest branches an existing run: the population ParentRunID scored at
+// AtGeneration seeds a new run that reuses the parent configuration with Set
+// overrides applied. AtGeneration 0 forks from the final population; earlier
+// generations need a snapshot the parent saved with a SnapshotInterval. Set
+// keys use the run command flag names (for example "w-add-neuron" or
+// "selection"); underscores are accepted in place of dashes.
+type ForkRequest struct {
+	ParentRunID  string
+	AtGeneration int
+	RunID        string
+	Set          map[string]string
+}
+
+func (c *Client) Fork(ctx context.Context, req ForkRequest) (RunSummary, error) {
+	runReq, err := c.forkRunRequest(ctx, req)
+	if err != nil {
+		return RunSummary{}, err
+	}
+	return c.Run(ctx, runReq)
+}
+
+func (c *Client) forkRunRequest(ctx context.Context, req ForkRequest) (RunRequest, error) {
+	if req.ParentRunID == "" {
+		return RunRequest{}, errors.New("fork requires parent run id")
+	}
+	if req.AtGeneration < 0 {
+		return RunRequest{}, errors.New("fork generation must be >= 0")
+	}
+	cfg, ok, err := readRunConfigWithProfileHints(c.benchmarksDir, req.ParentRunID)
+	if err != nil {
+		return RunRequest{}, err
+	}
+	if !ok {
+		return RunRequest{}, fmt.Errorf("run config not found for run id: %s", req.ParentRunID)
+	}
+
+	if _, err := c.ensurePolis(ctx); err != nil {
+		return RunRequest{}, err
+	}
+	pop, ok, err := c.store.GetPopulation(ctx, req.ParentRunID)
+	if err != nil {
+		return RunRequest{}, err
+	}
+	if !ok {
+		return RunRequest{}, fmt.Errorf("population snapshot not found for run id: %s", req.ParentRunID)
+	}
+	populationID := req.ParentRunID
+	atGeneration := pop.Generation
+	if req.AtGeneration > 0 && req.AtGeneration != pop.Generation {
+		if req.AtGeneration > pop.Generation {
+			return RunRequest{}, fmt.Errorf("run %s ended at generation %d, cannot fork at generation %d", req.ParentRunID, pop.Generation, req.AtGeneration)
+		}
+		populationID = genotype.GenerationSnapshotID(req.ParentRunID, req.AtGeneration)
+		if _, ok, err := c.store.GetPopulation(ctx, populationID); err != nil {
+			return RunRequest{}, err
+		} else if !ok {
+			return RunRequest{}, fmt.Errorf("run %s only persists its final population (generation %d) and no snapshot of generation %d; run it with a snapshot interval to fork earlier generations", req.ParentRunID, pop.Generation, req.AtGeneration)
+		}
+		atGeneration = req.AtGeneration
+	}
+	if _, _, err := genotype.LoadPopulationSnapshot(ctx, c.store, populationID); err != nil {
+		return RunRequest{}, err
+	}
+
+	runReq := runRequestFromRunConfig(cfg)
+	keys := make([]string, 0, len(req.Set))
+	for key := range req.Set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := applyRunOverride(&runReq, key, req.Set[key]); err != nil {
+			return RunRequest{}, err
+		}
+	}
+
+	runReq.RunID = req.RunID
+	if runReq.RunID == "" {
+		runReq.RunID = fmt.Sprintf("%s-fork-g%d-%d", req.ParentRunID, atGeneration, time.Now().UTC().UnixNano())
+	}
+	if runReq.RunID == req.ParentRunID {
+		return RunRequest{}, errors.New("fork run id must differ from parent run id")
+	}
+	runReq.ContinuePopulationID = populationID
+	runReq.ForkedFrom = req.ParentRunID
+	runReq.ForkGeneration = atGeneration
+	return runReq, nil
+}
+
+// runRequestFromRunConfig rebuilds the evolution-relevant request fields from
+// persisted run artifacts. Live-control options (start paused, auto continue)
+// are intentionally not carried over.
+func runRequestFromRunConfig(cfg stats.RunConfig) RunRequest {
+	req := runRequestFromArtifactsConfig(cfg)
+	req.OpMode = cfg.OpMode
+	req.EvolutionType = cfg.EvolutionType
+	req.SpecieIdentifier = cfg.SpecieIdentifier
+	req.Population = cfg.PopulationSize
+	req.Generations = cfg.Generations
+	req.SurvivalPercentage = cfg.SurvivalPercentage
+	req.SpecieSizeLimit = cfg.SpecieSizeLimit
+	req.FitnessGoal = cfg.FitnessGoal
+	req.EvaluationsLimit = cfg.EvaluationsLimit
+	req.TraceStepSize = cfg.TraceStepSize
+	req.Seed = cfg.Seed
+	req.SelectionSeed = cloneInt64Ptr(cfg.SelectionSeed)
+	req.MutationSeed = cloneInt64Ptr(cfg.MutationSeed)
+	req.EnvSeed = cloneInt64Ptr(cfg.EnvSeed)
+	req.Workers = cfg.Workers
+	req.EvaluationTrials = cfg.EvaluationTrials
+	req.CITieBreak = cfg.CITieBreak
+	req.TrialAggregation = cfg.TrialAggregation
+	req.CVaRAlpha = cfg.CVaRAlpha
+	req.FitnessScaling = cfg.FitnessScaling
+	req.ScalingPressure = cfg.ScalingPressure
+	req.SpeciesAllocation = cfg.SpeciesAllocation
+	req.AllocationFloor = cfg.AllocationFloor
+	req.AllocationCeiling = cfg.AllocationCeiling
+	req.SpeciesSoftCap = cfg.SpeciesSoftCap
+	req.SpeciesCapHysteresis = cfg.SpeciesCapHysteresis
+	req.PopulationResize = cfg.PopulationResize
+	req.MinPopulation = cfg.MinPopulation
+	req.MaxPopulation = cfg.MaxPopulation
+	req.ResizeTargetSeconds = cfg.ResizeTargetSeconds
+	req.LowFidelity = cfg.LowFidelity
+	req.FinalistFraction = cfg.FinalistFraction
+	req.ActivationClamp = cfg.ActivationClamp
+	req.WeightClamp = cfg.WeightClamp
+	req.CrossoverRate = cfg.CrossoverRate
+	req.InterspeciesMating = cfg.InterspeciesMating
+	req.PruneDisabledAfter = cfg.PruneDisabledAfter
+	req.PruneDisabledProb = cfg.PruneDisabledProb
+	req.TrackInnovations = cfg.TrackInnovations
+	req.InitialSensorsMin = cfg.InitialSensorsMin
+	req.InitialSensorsMax = cfg.InitialSensorsMax
+	req.EvaluationTimeout = time.Duration(cfg.EvaluationTimeoutMS) * time.Millisecond
+	req.KarmaStrikes = cfg.KarmaStrikes
+	req.KarmaCooldown = cfg.KarmaCooldown
+	req.ScapeSandbox = cfg.ScapeSandbox
+	req.SandboxCommand = cfg.SandboxCommand
+	req.SandboxCPUSeconds = cfg.SandboxCPUSeconds
+	req.SandboxMemoryMB = cfg.SandboxMemoryMB
+	req.SandboxTimeout = time.Duration(cfg.SandboxTimeoutMS) * time.Millisecond
+	req.SandboxFailureFitness = cfg.SandboxFailureFitness
+	req.StopCondition = cfg.StopCondition
+	req.EntropyThreshold = cfg.EntropyThreshold
+	req.EntropyMeasure = cfg.EntropyMeasure
+	req.EntropyAction = cfg.EntropyAction
+	req.EntropyCooldown = cfg.EntropyCooldown
+	req.ReproductionInterval = cfg.ReproductionInterval
+	req.DiagnosticsWindow = cfg.DiagnosticsWindow
+	req.Islands = cfg.Islands
+	req.MigrationInterval = cfg.MigrationInterval
+	req.Migrants = cfg.Migrants
+	req.CheckpointInterval = cfg.CheckpointInterval
+	req.SnapshotInterval = cfg.SnapshotInterval
+	req.WeightRecurrentLoop = cfg.WeightRecurrentLoop
+	req.RecurrentLoopMaxLength = cfg.RecurrentLoopMaxLength
+	req.WeightDuplicateNeuron = cfg.WeightDuplicateNeuron
+	req.WeightToggleSynapse = cfg.WeightToggleSynapse
+	req.WeightInsertModule = cfg.WeightInsertModule
+	req.Modules = append([]string(nil), cfg.Modules...)
+	req.WeightAllBiases = cfg.WeightAllBiases
+	req.BiasMaxDelta = cfg.BiasMaxDelta
+	req.ParityStrict = cfg.ParityStrict
+	req.Selection = cfg.Selection
+	req.TournamentSize = cfg.TournamentSize
+	req.TournamentNoReplace = cfg.TournamentNoReplace
+	req.TournamentWinProb = cfg.TournamentWinProb
+	req.FitnessPostprocessor = cfg.FitnessPostprocessor
+	req.TopologicalPolicy = cfg.TopologicalPolicy
+	req.TopologicalCount = cfg.TopologicalCount
+	req.TopologicalParam = cfg.TopologicalParam
+	req.TopologicalMax = cfg.TopologicalMax
+	req.EnableTuning = cfg.TuningEnabled
+	req.ValidationProbe = cfg.ValidationProbe
+	req.TestProbe = cfg.TestProbe
+	req.TuneSelection = cfg.TuneSelection
+	req.TuneDurationPolicy = cfg.TuneDurationPolicy
+	req.TuneDurationParam = cfg.TuneDurationParam
+	req.TuneAttempts = cfg.TuneAttempts
+	req.TuneSteps = cfg.TuneSteps
+	req.TuneStepSize = cfg.TuneStepSize
+	req.TunePerturbationRange = cfg.TunePerturbationRange
+	req.TuneAnnealingFactor = cfg.TuneAnnealingFactor
+	req.TuneMinImprovement = cfg.TuneMinImprovement
+	req.TuningTrace = cfg.TuningTrace
+	req.TuneAcceptance = cfg.TuneAcceptance
+	req.WeightPerturb = cfg.WeightPerturb
+	req.WeightBias = cfg.WeightBias
+	req.WeightRemoveBias = cfg.WeightRemoveBias
+	req.WeightActivation = cfg.WeightActivation
+	req.WeightAggregator = cfg.WeightAggregator
+	req.WeightAddSynapse = cfg.WeightAddSynapse
+	req.WeightRemoveSynapse = cfg.WeightRemoveSynapse
+	req.WeightAddNeuron = cfg.WeightAddNeuron
+	req.WeightRemoveNeuron = cfg.WeightRemoveNeuron
+	req.WeightPlasticityRule = cfg.WeightPlasticityRule
+	req.WeightPlasticity = cfg.WeightPlasticity
+	req.WeightSubstrate = cfg.WeightSubstrate
+	return req
+}
+
+type runOverrideFunc func(req *RunRequest, value string) error
+
+var runOverrides = map[string]runOverrideFunc{
+	"op-mode":                   stringOverride(func(r *RunRequest) *string { return &r.OpMode }),
+	"evolution-type":            stringOverride(func(r *RunRequest) *string { return &r.EvolutionType }),
+	"encoding":                  stringOverride(func(r *RunRequest) *string { return &r.Encoding }),
+	"algorithm":                 stringOverride(func(r *RunRequest) *string { return &r.Algorithm }),
+	"specie-identifier":         stringOverride(func(r *RunRequest) *string { return &r.SpecieIdentifier }),
+	"selection":                 stringOverride(func(r *RunRequest) *string { return &r.Selection }),
+	"trial-aggregation":         stringOverride(func(r *RunRequest) *string { return &r.TrialAggregation }),
+	"fitness-scaling":           stringOverride(func(r *RunRequest) *string { return &r.FitnessScaling }),
+	"species-allocation":        stringOverride(func(r *RunRequest) *string { return &r.SpeciesAllocation }),
+	"allocation-floor":          intOverride(func(r *RunRequest) *int { return &r.AllocationFloor }),
+	"allocation-ceiling":        intOverride(func(r *RunRequest) *int { return &r.AllocationCeiling }),
+	"species-soft-cap":          intOverride(func(r *RunRequest) *int { return &r.SpeciesSoftCap }),
+	"species-cap-hysteresis":    floatOverride(func(r *RunRequest) *float64 { return &r.SpeciesCapHysteresis }),
+	"population-resize":         stringOverride(func(r *RunRequest) *string { return &r.PopulationResize }),
+	"min-population":            intOverride(func(r *RunRequest) *int { return &r.MinPopulation }),
+	"max-population":            intOverride(func(r *RunRequest) *int { return &r.MaxPopulation }),
+	"fitness-postprocessor":     stringOverride(func(r *RunRequest) *string { return &r.FitnessPostprocessor }),
+	"topo-policy":               stringOverride(func(r *RunRequest) *string { return &r.TopologicalPolicy }),
+	"tune-selection":            stringOverride(func(r *RunRequest) *string { return &r.TuneSelection }),
+	"tune-duration-policy":      stringOverride(func(r *RunRequest) *string { return &r.TuneDurationPolicy }),
+	"stop":                      stringOverride(func(r *RunRequest) *string { return &r.StopCondition }),
+	"entropy-measure":           stringOverride(func(r *RunRequest) *string { return &r.EntropyMeasure }),
+	"entropy-action":            stringOverride(func(r *RunRequest) *string { return &r.EntropyAction }),
+	"entropy-cooldown":          intOverride(func(r *RunRequest) *int { return &r.EntropyCooldown }),
+	"entropy-threshold":         floatOverride(func(r *RunRequest) *float64 { return &r.EntropyThreshold }),
+	"reproduction-interval":     intOverride(func(r *RunRequest) *int { return &r.ReproductionInterval }),
+	"diagnostics-window":        intOverride(func(r *RunRequest) *int { return &r.DiagnosticsWindow }),
+	"islands":                   intOverride(func(r *RunRequest) *int { return &r.Islands }),
+	"migration-interval":        intOverride(func(r *RunRequest) *int { return &r.MigrationInterval }),
+	"migrants":                  intOverride(func(r *RunRequest) *int { return &r.Migrants }),
+	"checkpoint-interval":       intOverride(func(r *RunRequest) *int { return &r.CheckpointInterval }),
+	"snapshot-interval":         intOverride(func(r *RunRequest) *int { return &r.SnapshotInterval }),
+	"gens":                      intOverride(func(r *RunRequest) *int { return &r.Generations }),
+	"specie-size-limit":         intOverride(func(r *RunRequest) *int { return &r.SpecieSizeLimit }),
+	"evaluations-limit":         intOverride(func(r *RunRequest) *int { return &r.EvaluationsLimit }),
+	"karma-strikes":             intOverride(func(r *RunRequest) *int { return &r.KarmaStrikes }),
+	"karma-cooldown":            intOverride(func(r *RunRequest) *int { return &r.KarmaCooldown }),
+	"eval-timeout-ms":           millisecondsOverride(func(r *RunRequest) *time.Duration { return &r.EvaluationTimeout }),
+	"scape-sandbox":             boolOverride(func(r *RunRequest) *bool { return &r.ScapeSandbox }),
+	"sandbox-command":           stringOverride(func(r *RunRequest) *string { return &r.SandboxCommand }),
+	"sandbox-cpu-seconds":       intOverride(func(r *RunRequest) *int { return &r.SandboxCPUSeconds }),
+	"sandbox-memory-mb":         intOverride(func(r *RunRequest) *int { return &r.SandboxMemoryMB }),
+	"sandbox-timeout-ms":        millisecondsOverride(func(r *RunRequest) *time.Duration { return &r.SandboxTimeout }),
+	"sandbox-failure-fitness":   floatOverride(func(r *RunRequest) *float64 { return &r.SandboxFailureFitness }),
+	"trace-step-size":           intOverride(func(r *RunRequest) *int { return &r.TraceStepSize }),
+	"workers":                   intOverride(func(r *RunRequest) *int { return &r.Workers }),
+	"trials":                    intOverride(func(r *RunRequest) *int { return &r.EvaluationTrials }),
+	"tournament-size":           intOverride(func(r *RunRequest) *int { return &r.TournamentSize }),
+	"tournament-win-prob":       floatOverride(func(r *RunRequest) *float64 { return &r.TournamentWinProb }),
+	"tournament-no-replace":     boolOverride(func(r *RunRequest) *bool { return &r.TournamentNoReplace }),
+	"topo-count":                intOverride(func(r *RunRequest) *int { return &r.TopologicalCount }),
+	"topo-max":                  intOverride(func(r *RunRequest) *int { return &r.TopologicalMax }),
+	"attempts":                  intOverride(func(r *RunRequest) *int { return &r.TuneAttempts }),
+	"tune-steps":                intOverride(func(r *RunRequest) *int { return &r.TuneSteps }),
+	"seed":                      int64Override(func(r *RunRequest) *int64 { return &r.Seed }),
+	"seed-select":               int64PtrOverride(func(r *RunRequest) **int64 { return &r.SelectionSeed }),
+	"seed-mutate":               int64PtrOverride(func(r *RunRequest) **int64 { return &r.MutationSeed }),
+	"seed-env":                  int64PtrOverride(func(r *RunRequest) **int64 { return &r.EnvSeed }),
+	"tuning":                    boolOverride(func(r *RunRequest) *bool { return &r.EnableTuning }),
+	"validation-probe":          boolOverride(func(r *RunRequest) *bool { return &r.ValidationProbe }),
+	"test-probe":                boolOverride(func(r *RunRequest) *bool { return &r.TestProbe }),
+	"ci-tiebreak":               boolOverride(func(r *RunRequest) *bool { return &r.CITieBreak }),
+	"parity-strict":             boolOverride(func(r *RunRequest) *bool { return &r.ParityStrict }),
+	"survival-percentage":       floatOverride(func(r *RunRequest) *float64 { return &r.SurvivalPercentage }),
+	"fitness-goal":              floatOverride(func(r *RunRequest) *float64 { return &r.FitnessGoal }),
+	"cvar-alpha":                floatOverride(func(r *RunRequest) *float64 { return &r.CVaRAlpha }),
+	"scaling-pressure":          floatOverride(func(r *RunRequest) *float64 { return &r.ScalingPressure }),
+	"resize-target-seconds":     floatOverride(func(r *RunRequest) *float64 { return &r.ResizeTargetSeconds }),
+	"low-fidelity":              floatOverride(func(r *RunRequest) *float64 { return &r.LowFidelity }),
+	"finalist-fraction":         floatOverride(func(r *RunRequest) *float64 { return &r.FinalistFraction }),
+	"activation-clamp":          floatOverride(func(r *RunRequest) *float64 { return &r.ActivationClamp }),
+	"weight-clamp":              floatOverride(func(r *RunRequest) *float64 { return &r.WeightClamp }),
+	"crossover-rate":            floatOverride(func(r *RunRequest) *float64 { return &r.CrossoverRate }),
+	"interspecies-mating":       floatOverride(func(r *RunRequest) *float64 { return &r.InterspeciesMating }),
+	"prune-disabled-after":      intOverride(func(r *RunRequest) *int { return &r.PruneDisabledAfter }),
+	"prune-disabled-prob":       floatOverride(func(r *RunRequest) *float64 { return &r.PruneDisabledProb }),
+	"track-innovations":         boolOverride(func(r *RunRequest) *bool { return &r.TrackInnovations }),
+	"initial-sensors-min":       intOverride(func(r *RunRequest) *int { return &r.InitialSensorsMin }),
+	"initial-sensors-max":       intOverride(func(r *RunRequest) *int { return &r.InitialSensorsMax }),
+	"topo-param":                floatOverride(func(r *RunRequest) *float64 { return &r.TopologicalParam }),
+	"tune-step-size":            floatOverride(func(r *RunRequest) *float64 { return &r.TuneStepSize }),
+	"tune-perturbation-range":   floatOverride(func(r *RunRequest) *float64 { return &r.TunePerturbationRange }),
+	"tune-annealing-factor":     floatOverride(func(r *RunRequest) *float64 { return &r.TuneAnnealingFactor }),
+	"tune-min-improvement":      floatOverride(func(r *RunRequest) *float64 { return &r.TuneMinImprovement }),
+	"tuning-trace":              boolOverride(func(r *RunRequest) *bool { return &r.TuningTrace }),
+	"tune-acceptance":           stringOverride(func(r *RunRequest) *string { return &r.TuneAcceptance }),
+	"tune-duration-param":       floatOverride(func(r *RunRequest) *float64 { return &r.TuneDurationParam }),
+	"w-perturb":                 floatOverride(func(r *RunRequest) *float64 { return &r.WeightPerturb }),
+	"w-bias":                    floatOverride(func(r *RunRequest) *float64 { return &r.WeightBias }),
+	"w-remove-bias":             floatOverride(func(r *RunRequest) *float64 { return &r.WeightRemoveBias }),
+	"w-activation":              floatOverride(func(r *RunRequest) *float64 { return &r.WeightActivation }),
+	"w-aggregator":              floatOverride(func(r *RunRequest) *float64 { return &r.WeightAggregator }),
+	"w-add-synapse":             floatOverride(func(r *RunRequest) *float64 { return &r.WeightAddSynapse }),
+	"w-remove-synapse":          floatOverride(func(r *RunRequest) *float64 { return &r.WeightRemoveSynapse }),
+	"w-add-neuron":              floatOverride(func(r *RunRequest) *float64 { return &r.WeightAddNeuron }),
+	"w-remove-neuron":           floatOverride(func(r *RunRequest) *float64 { return &r.WeightRemoveNeuron }),
+	"w-plasticity-rule":         floatOverride(func(r *RunRequest) *float64 { return &r.WeightPlasticityRule }),
+	"w-plasticity":              floatOverride(func(r *RunRequest) *float64 { return &r.WeightPlasticity }),
+	"w-substrate":               floatOverride(func(r *RunRequest) *float64 { return &r.WeightSubstrate }),
+	"w-duplicate-neuron":        floatOverride(func(r *RunRequest) *float64 { return &r.WeightDuplicateNeuron }),
+	"w-toggle-synapse":          floatOverride(func(r *RunRequest) *float64 { return &r.WeightToggleSynapse }),
+	"w-recurrent-loop":          floatOverride(func(r *RunRequest) *float64 { return &r.WeightRecurrentLoop }),
+	"recurrent-loop-max-length": intOverride(func(r *RunRequest) *int { return &r.RecurrentLoopMaxLength }),
+	"w-insert-module":           floatOverride(func(r *RunRequest) *float64 { return &r.WeightInsertModule }),
+	"w-all-biases":              floatOverride(func(r *RunRequest) *float64 { return &r.WeightAllBiases }),
+	"bias-max-delta":            floatOverride(func(r *RunRequest) *float64 { return &r.BiasMaxDelta }),
+	"modules":                   stringListOverride(func(r *RunRequest) *[]string { return &r.Modules }),
+}
+
+// RunOverrideKeys lists the parameter names accepted by ForkRequest.Set.
+func RunOverrideKeys() []string {
+	keys := make([]string, 0, len(runOverrides))
+	for key := range runOverrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func applyRunOverride(req *RunRequest, key, value string) error {
+	normalized := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), "_", "-")
+	apply, ok := runOverrides[normalized]
+	if !ok {
+		return fmt.Errorf("unsupported run override: %s", key)
+	}
+	if err := apply(req, strings.TrimSpace(value)); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	return nil
+}
+
+func stringOverride(field func(*RunRequest) *string) runOverrideFunc {
+	return func(req *RunRequest, value string) error {
+		*field(req) = value
+		return nil
+	}
+}
+
+func intOverride(field func(*RunRequest) *int) runOverrideFunc {
+	return func(req *RunRequest, value string) error {
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*field(req) = v
+		return nil
+	}
+}
+
+func millisecondsOverride(field func(*RunRequest) *time.Duration) runOverrideFunc {
+	return func(req *RunRequest, value string) error {
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*field(req) = time.Duration(v) * time.Millisecond
+		return nil
+	}
+}
+
+func int64Override(field func(*RunRequest) *int64) runOverrideFunc {
+	return func(req *RunRequest, value string) error {
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		*field(req) = v
+		return nil
+	}
+}
+
+func int64PtrOverride(field func(*RunRequest) **int64) runOverrideFunc {
+	return func(req *RunRequest, value string) error {
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		*field(req) = &v
+		return nil
+	}
+}
+
+func floatOverride(field func(*RunRequest) *float64) runOverrideFunc {
+	return func(req *RunRequest, value string) error {
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		*field(req) = v
+		return nil
+	}
+}
+
+func boolOverride(field func(*RunRequest) *bool) runOverrideFunc {
+	return func(req *RunRequest, value string) error {
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*field(req) = v
+		return nil
+	}
+}
+
+// stringListOverride parses a comma-separated list; an empty value clears it.
+func stringListOverride(field func(*RunRequest) *[]string) runOverrideFunc {
+	return func(req *RunRequest, value string) error {
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		*field(req) = items
+		return nil
+	}
+}
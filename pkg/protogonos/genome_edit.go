@@ -0,0 +1,219 @@
+package protogonos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"protogonos/internal/genotype"
+	"protogonos/internal/model"
+	"protogonos/internal/stats"
+)
+
+// EditGenomeRequest sets the protection masks of one of a run's top genomes:
+// GenomeID when set, otherwise the run champion. Frozen neurons keep their
+// bias and incoming weights, frozen synapses their weight, through weight
+// mutation and tuning.
+type EditGenomeRequest struct {
+	RunID            string
+	Latest           bool
+	GenomeID         string
+	FreezeNeurons    []string
+	UnfreezeNeurons  []string
+	FreezeSynapses   []string
+	UnfreezeSynapses []string
+}
+
+type EditedGenome struct {
+	RunID          string   `json:"run_id"`
+	Rank           int      `json:"rank"`
+	GenomeID       string   `json:"genome_id"`
+	FrozenNeurons  []string `json:"frozen_neurons,omitempty"`
+	FrozenSynapses []string `json:"frozen_synapses,omitempty"`
+}
+
+// EditGenome applies the mask changes to every persisted copy of the genome,
+// like AnnotateGenome. Unknown neuron or synapse ids are rejected before
+// anything is written.
+func (c *Client) EditGenome(ctx context.Context, req EditGenomeRequest) (EditedGenome, error) {
+	if req.RunID != "" && req.Latest {
+		return EditedGenome{}, errors.New("use either run id or latest")
+	}
+	if len(req.FreezeNeurons)+len(req.UnfreezeNeurons)+len(req.FreezeSynapses)+len(req.UnfreezeSynapses) == 0 {
+		return EditedGenome{}, errors.New("genome edit requires neurons or synapses to freeze or unfreeze")
+	}
+	edited, err := c.editTopGenome(ctx, req.RunID, req.Latest, req.GenomeID, func(genome *model.Genome) error {
+		return applyGenomeMasks(genome, req)
+	})
+	if err != nil {
+		return EditedGenome{}, err
+	}
+	out := EditedGenome{RunID: edited.runID, Rank: edited.rank, GenomeID: edited.genome.ID}
+	for _, neuron := range edited.genome.Neurons {
+		if neuron.Frozen {
+			out.FrozenNeurons = append(out.FrozenNeurons, neuron.ID)
+		}
+	}
+	for _, syn := range edited.genome.Synapses {
+		if syn.Frozen {
+			out.FrozenSynapses = append(out.FrozenSynapses, syn.ID)
+		}
+	}
+	return out, nil
+}
+
+func applyGenomeMasks(genome *model.Genome, req EditGenomeRequest) error {
+	neurons := make(map[string]int, len(genome.Neurons))
+	for i, neuron := range genome.Neurons {
+		neurons[neuron.ID] = i
+	}
+	synapses := make(map[string]int, len(genome.Synapses))
+	for i, syn := range genome.Synapses {
+		synapses[syn.ID] = i
+	}
+	setNeurons := func(ids []string, frozen bool) error {
+		for _, id := range ids {
+			idx, ok := neurons[strings.TrimSpace(id)]
+			if !ok {
+				return fmt.Errorf("neuron %s not found in genome %s", id, genome.ID)
+			}
+			genome.Neurons[idx].Frozen = frozen
+		}
+		return nil
+	}
+	setSynapses := func(ids []string, frozen bool) error {
+		for _, id := range ids {
+			idx, ok := synapses[strings.TrimSpace(id)]
+			if !ok {
+				return fmt.Errorf("synapse %s not found in genome %s", id, genome.ID)
+			}
+			genome.Synapses[idx].Frozen = frozen
+		}
+		return nil
+	}
+	if err := setNeurons(req.FreezeNeurons, true); err != nil {
+		return err
+	}
+	if err := setNeurons(req.UnfreezeNeurons, false); err != nil {
+		return err
+	}
+	if err := setSynapses(req.FreezeSynapses, true); err != nil {
+		return err
+	}
+	return setSynapses(req.UnfreezeSynapses, false)
+}
+
+type editedTopGenome struct {
+	runID  string
+	rank   int
+	genome model.Genome
+}
+
+// editTopGenome applies edit to every persisted copy of one of a run's top
+// genomes: the store's top genomes, the top genome artifact and the genome
+// record itself when the store holds one. The edit runs on a scratch copy
+// first so a rejected edit leaves all copies untouched.
+func (c *Client) editTopGenome(ctx context.Context, runID string, latest bool, genomeID string, edit func(*model.Genome) error) (editedTopGenome, error) {
+	if latest {
+		entries, err := stats.ListRunIndex(c.benchmarksDir)
+		if err != nil {
+			return editedTopGenome{}, err
+		}
+		if len(entries) == 0 {
+			return editedTopGenome{}, errors.New("no runs available")
+		}
+		runID = entries[0].RunID
+	}
+	if runID == "" {
+		return editedTopGenome{}, errors.New("run id or latest is required")
+	}
+	if _, err := c.ensurePolis(ctx); err != nil {
+		return editedTopGenome{}, err
+	}
+
+	storedTop, storedOK, err := c.store.GetTopGenomes(ctx, runID)
+	if err != nil {
+		return editedTopGenome{}, err
+	}
+	artifactTop, artifactOK, err := stats.ReadTopGenomes(c.benchmarksDir, runID)
+	if err != nil {
+		return editedTopGenome{}, err
+	}
+	if (!storedOK || len(storedTop) == 0) && (!artifactOK || len(artifactTop) == 0) {
+		return editedTopGenome{}, fmt.Errorf("top genomes not found for run id: %s", runID)
+	}
+	if genomeID == "" {
+		if storedOK && len(storedTop) > 0 {
+			genomeID = storedTop[0].Genome.ID
+		} else {
+			genomeID = artifactTop[0].Genome.ID
+		}
+	}
+
+	var out editedTopGenome
+	found := false
+	apply := func(rank int, genome *model.Genome) error {
+		if !found {
+			scratch := genotype.CloneGenome(*genome)
+			if err := edit(&scratch); err != nil {
+				return err
+			}
+		}
+		if err := edit(genome); err != nil {
+			return err
+		}
+		if !found {
+			found = true
+			out = editedTopGenome{runID: runID, rank: rank, genome: *genome}
+		}
+		return nil
+	}
+	storedChanged := false
+	for i := range storedTop {
+		if storedTop[i].Genome.ID != genomeID {
+			continue
+		}
+		if err := apply(storedTop[i].Rank, &storedTop[i].Genome); err != nil {
+			return editedTopGenome{}, err
+		}
+		storedChanged = true
+	}
+	artifactChanged := false
+	for i := range artifactTop {
+		if artifactTop[i].Genome.ID != genomeID {
+			continue
+		}
+		if err := apply(artifactTop[i].Rank, &artifactTop[i].Genome); err != nil {
+			return editedTopGenome{}, err
+		}
+		artifactChanged = true
+	}
+	if !found {
+		return editedTopGenome{}, fmt.Errorf("genome %s is not among the top genomes of run %s", genomeID, runID)
+	}
+
+	if storedChanged {
+		if err := c.store.SaveTopGenomes(ctx, runID, storedTop); err != nil {
+			return editedTopGenome{}, err
+		}
+	}
+	if artifactChanged {
+		if err := stats.WriteTopGenomes(c.benchmarksDir, runID, artifactTop); err != nil {
+			return editedTopGenome{}, err
+		}
+	}
+	genome, ok, err := c.store.GetGenome(ctx, genomeID)
+	if err != nil {
+		return editedTopGenome{}, err
+	}
+	if ok {
+		if err := edit(&genome); err != nil {
+			return editedTopGenome{}, err
+		}
+		if err := c.store.SaveGenome(ctx, genome); err != nil {
+			return editedTopGenome{}, err
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,37 @@
+package protogonos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"protogonos/internal/model"
+	"protogonos/internal/storage"
+)
+
+type InnovationsRequest struct {
+	RunID string
+}
+
+// Innovations returns the innovation registry a run persisted when it was
+// started with TrackInnovations, ordered by innovation number.
+func (c *Client) Innovations(ctx context.Context, req InnovationsRequest) ([]model.InnovationRecord, error) {
+	if req.RunID == "" {
+		return nil, errors.New("innovations requires run id")
+	}
+	if _, err := c.ensurePolis(ctx); err != nil {
+		return nil, err
+	}
+	innovationStore, ok := c.store.(storage.InnovationStore)
+	if !ok {
+		return nil, errors.New("store does not persist innovations")
+	}
+	records, ok, err := innovationStore.GetInnovations(ctx, req.RunID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("innovations not found for run id: %s", req.RunID)
+	}
+	return records, nil
+}
@@ -0,0 +1,37 @@
+package protogonos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"protogonos/internal/evo"
+)
+
+// InvariantViolationFile is the run artifact a --verify-invariants run
+// writes when a generation breaks an invariant.
+const InvariantViolationFile = "invariant_violation.json"
+
+// dumpInvariantViolation writes the population dump of an invariant
+// violation into the run directory and names the file in the returned error.
+// Other errors pass through unchanged.
+func dumpInvariantViolation(runDir string, err error) error {
+	var violation *evo.InvariantViolation
+	if !errors.As(err, &violation) {
+		return err
+	}
+	data, marshalErr := json.MarshalIndent(violation, "", "  ")
+	if marshalErr != nil {
+		return errors.Join(err, marshalErr)
+	}
+	if mkdirErr := os.MkdirAll(runDir, 0o755); mkdirErr != nil {
+		return errors.Join(err, mkdirErr)
+	}
+	path := filepath.Join(runDir, InvariantViolationFile)
+	if writeErr := os.WriteFile(path, append(data, '\n'), 0o644); writeErr != nil {
+		return errors.Join(err, writeErr)
+	}
+	return fmt.Errorf("%w (population dump: %s)", err, path)
+}
@@ -0,0 +1,131 @@
+package protogonos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"protogonos/internal/model"
+	"protogonos/internal/platform"
+	"protogonos/internal/scapeid"
+	"protogonos/internal/stats"
+	"protogonos/internal/storage"
+)
+
+// LeaderboardSize is the number of champions a scape's leaderboard keeps.
+const LeaderboardSize = 20
+
+// LeaderboardRequest selects a scape's leaderboard. Limit caps the entries
+// returned; zero returns the whole board.
+type LeaderboardRequest struct {
+	Scape string
+	Limit int
+}
+
+// Leaderboard returns the best champions ever recorded for a scape, ranked
+// by validation fitness where the champion could be scored on the scape's
+// validation split and by its run fitness otherwise.
+func (c *Client) Leaderboard(ctx context.Context, req LeaderboardRequest) ([]model.LeaderboardEntry, error) {
+	scapeName := scapeid.Normalize(req.Scape)
+	if scapeName == "" {
+		return nil, errors.New("leaderboard requires scape")
+	}
+	if req.Limit < 0 {
+		return nil, errors.New("leaderboard limit must be >= 0")
+	}
+	if _, err := c.ensurePolis(ctx); err != nil {
+		return nil, err
+	}
+	leaderboardStore, ok := c.store.(storage.LeaderboardStore)
+	if !ok {
+		return nil, errors.New("store does not persist leaderboards")
+	}
+	entries, _, err := leaderboardStore.GetLeaderboard(ctx, scapeName)
+	if err != nil {
+		return nil, err
+	}
+	if req.Limit > 0 && len(entries) > req.Limit {
+		entries = entries[:req.Limit]
+	}
+	return entries, nil
+}
+
+// recordLeaderboard offers the champion of a recorded run to its scape's
+// leaderboard. A champion that cannot be scored on the validation split
+// enters with its run fitness alone.
+func (c *Client) recordLeaderboard(ctx context.Context, run *preparedRun, result platform.EvolutionResult) error {
+	leaderboardStore, ok := c.store.(storage.LeaderboardStore)
+	if !ok || len(result.TopFinal) == 0 {
+		return nil
+	}
+	req := run.req
+	entry := model.LeaderboardEntry{
+		RunID:        run.runID,
+		GenomeID:     result.TopFinal[0].Genome.ID,
+		Fitness:      result.TopFinal[0].Fitness,
+		Config:       leaderboardConfig(req),
+		CreatedAtUTC: run.now.Format(time.RFC3339Nano),
+	}
+	if champion, err := c.loadTopGenome(ctx, run.runID, false, ""); err == nil {
+		if validation, err := crossEvaluate(ctx, champion, champion, "validation"); err == nil {
+			entry.ValidationFitness = &validation
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	scapeName := scapeid.Normalize(req.Scape)
+	entries, _, err := leaderboardStore.GetLeaderboard(ctx, scapeName)
+	if err != nil {
+		return err
+	}
+	return leaderboardStore.SaveLeaderboard(ctx, scapeName, mergeLeaderboard(entries, entry, LeaderboardSize))
+}
+
+// mergeLeaderboard ranks entry into entries, replacing an earlier entry of
+// the same run, and keeps the best size entries. Ties keep the earlier entry
+// ahead.
+func mergeLeaderboard(entries []model.LeaderboardEntry, entry model.LeaderboardEntry, size int) []model.LeaderboardEntry {
+	merged := make([]model.LeaderboardEntry, 0, len(entries)+1)
+	for _, existing := range entries {
+		if existing.RunID != entry.RunID {
+			merged = append(merged, existing)
+		}
+	}
+	merged = append(merged, entry)
+	sort.SliceStable(merged, func(i, j int) bool {
+		return leaderboardScore(merged[i]) > leaderboardScore(merged[j])
+	})
+	if len(merged) > size {
+		merged = merged[:size]
+	}
+	return merged
+}
+
+func leaderboardScore(entry model.LeaderboardEntry) float64 {
+	if entry.ValidationFitness != nil {
+		return *entry.ValidationFitness
+	}
+	return entry.Fitness
+}
+
+// leaderboardConfig summarizes the settings that tell apart the runs of one
+// scape.
+func leaderboardConfig(req RunRequest) string {
+	parts := []string{
+		"morphology=" + stats.BenchmarkMorphologyLabel(req.Scape, req.GTSAProfile, req.FXProfile, req.EpitopesProfile, req.LLVMProfile, req.FlatlandScannerProfile),
+		"algorithm=" + req.Algorithm,
+		fmt.Sprintf("population=%d", req.Population),
+		fmt.Sprintf("generations=%d", req.Generations),
+		"selection=" + req.Selection,
+	}
+	if req.Encoding != "" {
+		parts = append(parts, "encoding="+req.Encoding)
+	}
+	parts = append(parts, fmt.Sprintf("tuning=%t", req.EnableTuning), fmt.Sprintf("seed=%d", req.Seed))
+	return strings.Join(parts, " ")
+}
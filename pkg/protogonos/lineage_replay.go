@@ -0,0 +1,155 @@
+package protogonos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"protogonos/internal/evo"
+	"protogonos/internal/genotype"
+	"protogonos/internal/model"
+	"protogonos/internal/stats"
+)
+
+type LineageReplayRequest struct {
+	RunID  string
+	Latest bool
+}
+
+// LineageReplayReport lists the outcome for every stored lineage record of a
+// run, in lineage order.
+type LineageReplayReport struct {
+	RunID      string
+	Records    []evo.LineageReplay
+	Matched    int
+	Mismatched int
+	Skipped    int
+}
+
+// ReplayLineage rebuilds a run's seed population and mutation operators from
+// its config.json and re-applies the stored lineage to them, checking each
+// derived genome against its recorded fingerprint and changed ids. Mismatches
+// point at a stored lineage that no longer describes the run, or at mutation
+// code that stopped being deterministic; see evo.ReplayLineage for what a
+// replay cannot reproduce.
+func (c *Client) ReplayLineage(ctx context.Context, req LineageReplayRequest) (LineageReplayReport, error) {
+	if req.RunID != "" && req.Latest {
+		return LineageReplayReport{}, errors.New("use either run id or latest")
+	}
+	runID := req.RunID
+	if req.Latest {
+		entries, err := stats.ListRunIndex(c.benchmarksDir)
+		if err != nil {
+			return LineageReplayReport{}, err
+		}
+		if len(entries) == 0 {
+			return LineageReplayReport{}, errors.New("no runs available")
+		}
+		runID = entries[0].RunID
+	}
+	if runID == "" {
+		return LineageReplayReport{}, errors.New("lineage replay requires run id or latest")
+	}
+
+	cfg, ok, err := readRunConfigWithProfileHints(c.benchmarksDir, runID)
+	if err != nil {
+		return LineageReplayReport{}, err
+	}
+	if !ok {
+		return LineageReplayReport{}, fmt.Errorf("run config not found for run id: %s", runID)
+	}
+	if _, err := c.ensurePolis(ctx); err != nil {
+		return LineageReplayReport{}, err
+	}
+	lineage, ok, err := c.store.GetLineage(ctx, runID)
+	if err != nil {
+		return LineageReplayReport{}, err
+	}
+	if !ok {
+		return LineageReplayReport{}, fmt.Errorf("lineage not found for run id: %s", runID)
+	}
+
+	runReq := runRequestFromRunConfig(cfg)
+	seedPopulation, err := genotype.ConstructSeedPopulationWithOptions(runReq.Scape, runReq.Population, runReq.Seed, seedPopulationOptionsFromRequest(runReq))
+	if err != nil {
+		return LineageReplayReport{}, err
+	}
+	seeds := seedPopulation.Genomes
+	if cfg.InitFromChampions != "" {
+		runReq.InitFromChampions = cfg.InitFromChampions
+		seeds, _, err = c.seedFromChampions(ctx, runReq, seeds, seedPopulation.InputNeuronIDs, seedPopulation.OutputNeuronIDs)
+		if err != nil {
+			return LineageReplayReport{}, err
+		}
+	}
+	if cfg.ContinuePopulationID != "" {
+		_, seeds, err = genotype.LoadPopulationSnapshot(ctx, c.store, cfg.ContinuePopulationID)
+		if err != nil {
+			return LineageReplayReport{}, err
+		}
+	}
+	var modules []model.Module
+	if runReq.WeightInsertModule > 0 {
+		modules, err = c.moduleLibraryForRun(runReq.Modules)
+		if err != nil {
+			return LineageReplayReport{}, err
+		}
+	}
+	mutation, policy := runMutationOperators(runReq, seedPopulation.InputNeuronIDs, seedPopulation.OutputNeuronIDs, modules, nil)
+	operators := make([]evo.Operator, 0, len(policy)+1)
+	operators = append(operators, mutation)
+	for _, item := range policy {
+		operators = append(operators, item.Operator)
+	}
+
+	newcomers := newcomerFactory(runReq)
+	records, err := evo.ReplayLineage(ctx, evo.LineageReplayConfig{
+		Seeds:     seeds,
+		Operators: operators,
+		Newcomer: func(record evo.LineageRecord) (model.Genome, error) {
+			var generation, index int
+			if _, err := fmt.Sscanf(record.GenomeID, newcomerIDFormat, &generation, &index); err != nil {
+				return model.Genome{}, fmt.Errorf("genome id %s is not a newcomer id", record.GenomeID)
+			}
+			return newcomers(generation, index)
+		},
+	}, fromModelLineage(lineage))
+	if err != nil {
+		return LineageReplayReport{}, err
+	}
+	report := LineageReplayReport{RunID: runID, Records: records}
+	for _, record := range records {
+		switch record.Status {
+		case evo.ReplayMatched:
+			report.Matched++
+		case evo.ReplayMismatched:
+			report.Mismatched++
+		default:
+			report.Skipped++
+		}
+	}
+	return report, nil
+}
+
+func fromModelLineage(lineage []model.LineageRecord) []evo.LineageRecord {
+	out := make([]evo.LineageRecord, 0, len(lineage))
+	for _, rec := range lineage {
+		var events []genotype.EvoHistoryEvent
+		for _, event := range rec.Events {
+			events = append(events, genotype.EvoHistoryEvent{
+				Mutation: event.Mutation,
+				IDs:      append([]string(nil), event.IDs...),
+			})
+		}
+		out = append(out, evo.LineageRecord{
+			GenomeID:    rec.GenomeID,
+			ParentID:    rec.ParentID,
+			Generation:  rec.Generation,
+			Operation:   rec.Operation,
+			MateID:      rec.MateID,
+			Events:      events,
+			Fingerprint: rec.Fingerprint,
+		})
+	}
+	return out
+}
@@ -0,0 +1,102 @@
+package protogonos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"protogonos/internal/genotype"
+	"protogonos/internal/model"
+	"protogonos/internal/stats"
+)
+
+// TagModuleRequest cuts a subgraph out of one of a run's top genomes (GenomeID
+// when set, otherwise the champion) and stores it in the module library under
+// ModuleID. NeuronIDs selects the subgraph; when empty every hidden neuron of
+// the genome is taken.
+type TagModuleRequest struct {
+	RunID     string
+	Latest    bool
+	GenomeID  string
+	ModuleID  string
+	NeuronIDs []string
+}
+
+// TagModule extracts the requested subgraph and writes it to the module
+// library, replacing any module already stored under the same id.
+func (c *Client) TagModule(ctx context.Context, req TagModuleRequest) (model.Module, error) {
+	if req.RunID != "" && req.Latest {
+		return model.Module{}, errors.New("use either run id or latest")
+	}
+	moduleID := strings.TrimSpace(req.ModuleID)
+	if moduleID == "" {
+		return model.Module{}, errors.New("module id is required")
+	}
+	loaded, err := c.loadTopGenome(ctx, req.RunID, req.Latest, req.GenomeID)
+	if err != nil {
+		return model.Module{}, err
+	}
+
+	neuronIDs := req.NeuronIDs
+	if len(neuronIDs) == 0 {
+		inputIDs, outputIDs, err := defaultSeedIONeuronsForScape(loaded.request)
+		if err != nil {
+			return model.Module{}, err
+		}
+		io := make(map[string]struct{}, len(inputIDs)+len(outputIDs))
+		for _, id := range append(inputIDs, outputIDs...) {
+			io[id] = struct{}{}
+		}
+		for _, neuron := range loaded.genome.Neurons {
+			if _, ok := io[neuron.ID]; !ok {
+				neuronIDs = append(neuronIDs, neuron.ID)
+			}
+		}
+		if len(neuronIDs) == 0 {
+			return model.Module{}, fmt.Errorf("genome %s has no hidden neurons to tag", loaded.genome.ID)
+		}
+	}
+
+	module, err := genotype.ExtractModule(loaded.genome, neuronIDs, moduleID)
+	if err != nil {
+		return model.Module{}, err
+	}
+	module.SourceRunID = loaded.runID
+	if err := stats.WriteModule(c.benchmarksDir, module); err != nil {
+		return model.Module{}, err
+	}
+	return module, nil
+}
+
+// Modules lists the module library ordered by id.
+func (c *Client) Modules(_ context.Context) ([]model.Module, error) {
+	return stats.ListModules(c.benchmarksDir)
+}
+
+// moduleLibraryForRun resolves the modules insert_module may graft: the
+// named ones when ids is set, otherwise the whole library.
+func (c *Client) moduleLibraryForRun(ids []string) ([]model.Module, error) {
+	if len(ids) == 0 {
+		modules, err := stats.ListModules(c.benchmarksDir)
+		if err != nil {
+			return nil, err
+		}
+		if len(modules) == 0 {
+			return nil, errors.New("insert module weight requires a non-empty module library")
+		}
+		return modules, nil
+	}
+	modules := make([]model.Module, 0, len(ids))
+	for _, id := range ids {
+		module, ok, err := stats.ReadModule(c.benchmarksDir, id)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("module not found: %s", id)
+		}
+		modules = append(modules, module)
+	}
+	return modules, nil
+}
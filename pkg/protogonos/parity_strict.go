@@ -0,0 +1,114 @@
+package protogonos
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"protogonos/internal/evo"
+	"protogonos/internal/tuning"
+)
+
+// A RunRequest with ParityStrict set is restricted to reference (DXNN)
+// equivalent operators, selection, tuning candidate selection and speciation,
+// and fails to start when it asks for anything else. These are the reference
+// defaults it falls back to when selection or tuning selection is unset.
+const (
+	ParityStrictSelection     = "hof_competition"
+	ParityStrictTuneSelection = tuning.CandidateSelectDynamic
+)
+
+// paritySelections are the population selection strategies with a reference
+// counterpart; species_shared_tournament is what parity profiles resolve
+// hof_competition to.
+var paritySelections = map[string]bool{
+	"hof_competition":           true,
+	"hof_rank":                  true,
+	"hof_top3":                  true,
+	"hof_efficiency":            true,
+	"hof_random":                true,
+	"competition":               true,
+	"top3":                      true,
+	"species_shared_tournament": true,
+}
+
+// parityTuneSelections are the tuning candidate selections with a reference
+// counterpart.
+var parityTuneSelections = map[string]bool{
+	tuning.CandidateSelectDynamicA:  true,
+	tuning.CandidateSelectDynamic:   true,
+	tuning.CandidateSelectAll:       true,
+	tuning.CandidateSelectAllRandom: true,
+	tuning.CandidateSelectActive:    true,
+	tuning.CandidateSelectActiveRnd: true,
+	tuning.CandidateSelectRecent:    true,
+	tuning.CandidateSelectRecentRnd: true,
+	tuning.CandidateSelectCurrent:   true,
+	tuning.CandidateSelectCurrentRd: true,
+	tuning.CandidateSelectLastGen:   true,
+	tuning.CandidateSelectLastGenRd: true,
+}
+
+// applyParityStrictDefaults fills the selection settings a parity-strict
+// request leaves unset with their reference defaults.
+func applyParityStrictDefaults(req RunRequest) RunRequest {
+	if req.Selection == "" {
+		req.Selection = ParityStrictSelection
+	}
+	if req.TuneSelection == "" {
+		req.TuneSelection = ParityStrictTuneSelection
+	}
+	return req
+}
+
+// checkParityStrict reports every setting of a materialized request whose
+// operator set, selection, tuning candidate selection, speciation or
+// population structure has no reference counterpart.
+func checkParityStrict(req RunRequest) error {
+	var violations []string
+	for _, op := range []struct {
+		name   string
+		weight float64
+	}{
+		{"add_recurrent_loop", req.WeightRecurrentLoop},
+		{"duplicate_neuron", req.WeightDuplicateNeuron},
+		{"toggle_synapse_enabled", req.WeightToggleSynapse},
+		{"insert_module", req.WeightInsertModule},
+		{"perturb_all_biases", req.WeightAllBiases},
+	} {
+		if op.weight > 0 {
+			violations = append(violations, fmt.Sprintf("mutation operator %s", op.name))
+		}
+	}
+	if req.CrossoverRate > 0 || req.InterspeciesMating > 0 {
+		violations = append(violations, "crossover")
+	}
+
+	if !paritySelections[req.Selection] {
+		violations = append(violations, fmt.Sprintf("selection %s", req.Selection))
+	}
+	if (req.TournamentSize != 0 && req.TournamentSize != 3) || req.TournamentNoReplace || (req.TournamentWinProb != 0 && req.TournamentWinProb != 1) {
+		violations = append(violations, "tournament options")
+	}
+	if req.FitnessScaling != evo.FitnessScalingNone {
+		violations = append(violations, fmt.Sprintf("fitness scaling %s", req.FitnessScaling))
+	}
+	if (req.EnableTuning || req.CompareTuning) && !parityTuneSelections[req.TuneSelection] {
+		violations = append(violations, fmt.Sprintf("tuning selection %s", req.TuneSelection))
+	}
+
+	if req.SpeciesAllocation != evo.SpeciesAllocationProportional {
+		violations = append(violations, fmt.Sprintf("species allocation %s", req.SpeciesAllocation))
+	}
+	if req.SpeciesSoftCap > 0 {
+		violations = append(violations, "species soft cap")
+	}
+	if req.Islands > 1 {
+		violations = append(violations, "islands")
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return errors.New("parity strict: no reference counterpart for " + strings.Join(violations, ", "))
+}
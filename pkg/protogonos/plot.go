@@ -0,0 +1,195 @@
+package protogonos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"protogonos/internal/model"
+	"protogonos/internal/stats"
+)
+
+// Plot subjects and formats.
+const (
+	PlotFitness = "fitness"
+	PlotSpecies = "species"
+	PlotTuning  = "tuning"
+
+	PlotFormatSVG = "svg"
+	PlotFormatPNG = "png"
+)
+
+// plotSpeciesLimit caps the species drawn individually in a species plot.
+const plotSpeciesLimit = 8
+
+// PlotRequest renders one aspect of a run per generation: fitness (best,
+// mean, min), species (species count and the sizes of the largest species)
+// or tuning (attempts, accepted, rejected). Format defaults to svg.
+type PlotRequest struct {
+	RunID  string
+	Latest bool
+	What   string
+	Format string
+}
+
+type PlotResult struct {
+	RunID       string
+	What        string
+	Format      string
+	Generations int
+	Data        []byte
+}
+
+// Plot renders a run chart without any external plotting dependency.
+func (c *Client) Plot(ctx context.Context, req PlotRequest) (PlotResult, error) {
+	if req.RunID != "" && req.Latest {
+		return PlotResult{}, errors.New("use either run id or latest")
+	}
+	what := strings.TrimSpace(strings.ToLower(req.What))
+	if what == "" {
+		what = PlotFitness
+	}
+	format := strings.TrimSpace(strings.ToLower(req.Format))
+	if format == "" {
+		format = PlotFormatSVG
+	}
+	if format != PlotFormatSVG && format != PlotFormatPNG {
+		return PlotResult{}, fmt.Errorf("unsupported plot format: %s", req.Format)
+	}
+
+	runID := req.RunID
+	if req.Latest {
+		entries, err := stats.ListRunIndex(c.benchmarksDir)
+		if err != nil {
+			return PlotResult{}, err
+		}
+		if len(entries) == 0 {
+			return PlotResult{}, errors.New("no runs available")
+		}
+		runID = entries[0].RunID
+	}
+	if runID == "" {
+		return PlotResult{}, errors.New("plot requires run id or latest")
+	}
+	if _, err := c.ensurePolis(ctx); err != nil {
+		return PlotResult{}, err
+	}
+
+	var chart stats.Chart
+	switch what {
+	case PlotFitness, PlotTuning:
+		diagnostics, ok, err := c.store.GetGenerationDiagnostics(ctx, runID)
+		if err != nil {
+			return PlotResult{}, err
+		}
+		if !ok || len(diagnostics) == 0 {
+			return PlotResult{}, fmt.Errorf("diagnostics not found for run id: %s", runID)
+		}
+		if what == PlotFitness {
+			chart = fitnessChart(diagnostics)
+		} else {
+			chart = tuningChart(diagnostics)
+		}
+	case PlotSpecies:
+		history, ok, err := c.store.GetSpeciesHistory(ctx, runID)
+		if err != nil {
+			return PlotResult{}, err
+		}
+		if !ok || len(history) == 0 {
+			return PlotResult{}, fmt.Errorf("species history not found for run id: %s", runID)
+		}
+		chart = speciesChart(history)
+	default:
+		return PlotResult{}, fmt.Errorf("unsupported plot subject: %s (want fitness, species or tuning)", req.What)
+	}
+	chart.Title = runID + " " + what
+
+	result := PlotResult{RunID: runID, What: what, Format: format, Generations: len(chart.X)}
+	if format == PlotFormatPNG {
+		data, err := stats.RenderChartPNG(chart)
+		if err != nil {
+			return PlotResult{}, err
+		}
+		result.Data = data
+	} else {
+		result.Data = stats.RenderChartSVG(chart)
+	}
+	return result, nil
+}
+
+func fitnessChart(diagnostics []model.GenerationDiagnostics) stats.Chart {
+	chart := stats.Chart{XLabel: "generation", YLabel: "fitness"}
+	best := stats.ChartSeries{Name: "best"}
+	mean := stats.ChartSeries{Name: "mean"}
+	worst := stats.ChartSeries{Name: "min"}
+	for _, d := range diagnostics {
+		chart.X = append(chart.X, float64(d.Generation))
+		best.Values = append(best.Values, d.BestFitness)
+		mean.Values = append(mean.Values, d.MeanFitness)
+		worst.Values = append(worst.Values, d.MinFitness)
+	}
+	chart.Series = []stats.ChartSeries{best, mean, worst}
+	return chart
+}
+
+func tuningChart(diagnostics []model.GenerationDiagnostics) stats.Chart {
+	chart := stats.Chart{XLabel: "generation", YLabel: "tuning attempts"}
+	attempts := stats.ChartSeries{Name: "attempts"}
+	accepted := stats.ChartSeries{Name: "accepted"}
+	rejected := stats.ChartSeries{Name: "rejected"}
+	for _, d := range diagnostics {
+		chart.X = append(chart.X, float64(d.Generation))
+		attempts.Values = append(attempts.Values, float64(d.TuningAttempts))
+		accepted.Values = append(accepted.Values, float64(d.TuningAccepted))
+		rejected.Values = append(rejected.Values, float64(d.TuningRejected))
+	}
+	chart.Series = []stats.ChartSeries{attempts, accepted, rejected}
+	return chart
+}
+
+// speciesChart plots the species count and the size of the plotSpeciesLimit
+// species with the most members over the run; generations a species is
+// absent from are gaps.
+func speciesChart(history []model.SpeciesGeneration) stats.Chart {
+	chart := stats.Chart{XLabel: "generation", YLabel: "genomes"}
+	count := stats.ChartSeries{Name: "species_count"}
+	totals := map[string]int{}
+	for _, generation := range history {
+		chart.X = append(chart.X, float64(generation.Generation))
+		count.Values = append(count.Values, float64(len(generation.Species)))
+		for _, species := range generation.Species {
+			totals[species.Key] += species.Size
+		}
+	}
+	keys := make([]string, 0, len(totals))
+	for key := range totals {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if totals[keys[i]] != totals[keys[j]] {
+			return totals[keys[i]] > totals[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) > plotSpeciesLimit {
+		keys = keys[:plotSpeciesLimit]
+	}
+	chart.Series = append(chart.Series, count)
+	for _, key := range keys {
+		series := stats.ChartSeries{Name: key, Values: make([]float64, len(history))}
+		for i, generation := range history {
+			series.Values[i] = math.NaN()
+			for _, species := range generation.Species {
+				if species.Key == key {
+					series.Values[i] = float64(species.Size)
+					break
+				}
+			}
+		}
+		chart.Series = append(chart.Series, series)
+	}
+	return chart
+}
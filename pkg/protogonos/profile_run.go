@@ -0,0 +1,119 @@
+package protogonos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"protogonos/internal/model"
+	"protogonos/internal/stats"
+)
+
+// Phases of a generation's wall-clock as recorded in the trace.
+const (
+	PhaseSelection   = "selection"
+	PhaseMutation    = "mutation"
+	PhaseEvaluation  = "evaluation"
+	PhaseTuning      = "tuning"
+	PhaseSpeciation  = "speciation"
+	PhasePersistence = "persistence"
+	PhaseOther       = "other"
+)
+
+type ProfileRunRequest struct {
+	RunID string
+}
+
+// PhaseShare is the time a phase took and its share of the profiled
+// wall-clock.
+type PhaseShare struct {
+	Phase   string  `json:"phase"`
+	Seconds float64 `json:"seconds"`
+	Share   float64 `json:"share"`
+}
+
+// GenerationProfile is one trace generation's phase breakdown and the phase
+// that took the most time in it.
+type GenerationProfile struct {
+	Generation int                `json:"generation"`
+	Timings    model.PhaseTimings `json:"timings"`
+	Dominant   string             `json:"dominant"`
+}
+
+// RunProfile totals a run's per-generation phase timings. Phases is sorted
+// by time, longest first, so Phases[0] is the dominant phase.
+type RunProfile struct {
+	RunID            string              `json:"run_id"`
+	WallClockSeconds float64             `json:"wall_clock_seconds"`
+	Phases           []PhaseShare        `json:"phases"`
+	Dominant         string              `json:"dominant"`
+	Generations      []GenerationProfile `json:"generations"`
+}
+
+// ProfileRun reads the phase timings a run recorded in its trace and reports
+// which phase dominates its wall-clock, overall and per generation.
+func (c *Client) ProfileRun(_ context.Context, req ProfileRunRequest) (RunProfile, error) {
+	if req.RunID == "" {
+		return RunProfile{}, errors.New("profile run requires run id")
+	}
+	traceAcc, ok, err := stats.ReadTraceAcc(c.benchmarksDir, req.RunID)
+	if err != nil {
+		return RunProfile{}, err
+	}
+	if !ok {
+		return RunProfile{}, fmt.Errorf("trace not found for run id: %s", req.RunID)
+	}
+
+	profile := RunProfile{RunID: req.RunID}
+	var total model.PhaseTimings
+	for _, entry := range traceAcc {
+		if entry.Phases == nil {
+			continue
+		}
+		timings := *entry.Phases
+		profile.Generations = append(profile.Generations, GenerationProfile{
+			Generation: entry.Generation,
+			Timings:    timings,
+			Dominant:   phaseShares(timings)[0].Phase,
+		})
+		total.WallClockSeconds += timings.WallClockSeconds
+		total.SelectionSeconds += timings.SelectionSeconds
+		total.MutationSeconds += timings.MutationSeconds
+		total.EvaluationSeconds += timings.EvaluationSeconds
+		total.TuningSeconds += timings.TuningSeconds
+		total.SpeciationSeconds += timings.SpeciationSeconds
+		total.PersistenceSeconds += timings.PersistenceSeconds
+		total.OtherSeconds += timings.OtherSeconds
+	}
+	if len(profile.Generations) == 0 {
+		return RunProfile{}, fmt.Errorf("run %s recorded no phase timings", req.RunID)
+	}
+	profile.WallClockSeconds = total.WallClockSeconds
+	profile.Phases = phaseShares(total)
+	profile.Dominant = profile.Phases[0].Phase
+	return profile, nil
+}
+
+// phaseShares lists every phase of timings, longest first; ties keep the
+// pipeline order.
+func phaseShares(timings model.PhaseTimings) []PhaseShare {
+	shares := []PhaseShare{
+		{Phase: PhaseSelection, Seconds: timings.SelectionSeconds},
+		{Phase: PhaseMutation, Seconds: timings.MutationSeconds},
+		{Phase: PhaseEvaluation, Seconds: timings.EvaluationSeconds},
+		{Phase: PhaseTuning, Seconds: timings.TuningSeconds},
+		{Phase: PhaseSpeciation, Seconds: timings.SpeciationSeconds},
+		{Phase: PhasePersistence, Seconds: timings.PersistenceSeconds},
+		{Phase: PhaseOther, Seconds: timings.OtherSeconds},
+	}
+	for i := range shares {
+		if timings.WallClockSeconds > 0 {
+			shares[i].Share = shares[i].Seconds / timings.WallClockSeconds
+		}
+	}
+	sort.SliceStable(shares, func(i, j int) bool {
+		return shares[i].Seconds > shares[j].Seconds
+	})
+	return shares
+}
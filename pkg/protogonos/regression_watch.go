@@ -0,0 +1,157 @@
+package protogonos
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+
+	"protogonos/internal/scapeid"
+	"protogonos/internal/stats"
+	"protogonos/internal/storage"
+)
+
+// DefaultRegressionTolerance is the fraction of the reference champion's
+// validation fitness a run's champion may fall short by before the
+// regression watch flags it, used when RunRequest.RegressionTolerance is 0.
+const DefaultRegressionTolerance = 0.05
+
+// RegressionCheck compares a run's champion with the best leaderboard
+// champion of an earlier comparable run, both scored on the run's validation
+// split, so the comparison does not depend on either run's training
+// conditions. Error is set, and the scores are not, when the check could not
+// be carried out.
+type RegressionCheck struct {
+	Mode              string  `json:"mode"`
+	ReferenceRunID    string  `json:"reference_run_id,omitempty"`
+	ReferenceGenomeID string  `json:"reference_genome_id,omitempty"`
+	ChampionFitness   float64 `json:"champion_fitness"`
+	ReferenceFitness  float64 `json:"reference_fitness"`
+	Delta             float64 `json:"delta"`
+	Tolerance         float64 `json:"tolerance"`
+	// Regressed is set when the champion scores more than Tolerance times
+	// the reference's magnitude below the reference.
+	Regressed bool   `json:"regressed"`
+	Error     string `json:"error,omitempty"`
+}
+
+const regressionMode = "validation"
+
+// checkChampionRegression scores the champion of runID and the best
+// comparable champion on its scape's leaderboard on the validation split of
+// runID. Comparable runs share the scape parameters, encoding and evaluation
+// budget of cfg; the reference is the highest ranked such entry whose
+// champion can be evaluated there, and the check is nil when there is none.
+func (c *Client) checkChampionRegression(ctx context.Context, req RunRequest, cfg stats.RunConfig) (*RegressionCheck, error) {
+	leaderboardStore, ok := c.store.(storage.LeaderboardStore)
+	if !ok {
+		return nil, nil
+	}
+	entries, _, err := leaderboardStore.GetLeaderboard(ctx, scapeid.Normalize(req.Scape))
+	if err != nil {
+		return nil, err
+	}
+	conditions, err := regressionConditions(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var current *loadedGenome
+	championFitness := 0.0
+	for _, entry := range entries {
+		if entry.RunID == cfg.RunID {
+			continue
+		}
+		referenceCfg, ok, err := stats.ReadRunConfig(c.benchmarksDir, entry.RunID)
+		if err != nil || !ok {
+			continue
+		}
+		if referenceConditions, err := regressionConditions(referenceCfg); err != nil || referenceConditions != conditions {
+			continue
+		}
+		reference, err := c.loadTopGenome(ctx, entry.RunID, false, entry.GenomeID)
+		if err != nil {
+			continue
+		}
+		if current == nil {
+			champion, err := c.loadTopGenome(ctx, cfg.RunID, false, "")
+			if err != nil {
+				return nil, err
+			}
+			if championFitness, err = crossEvaluate(ctx, champion, champion, regressionMode); err != nil {
+				return nil, err
+			}
+			current = &champion
+		}
+		referenceFitness, err := crossEvaluate(ctx, reference, *current, regressionMode)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			continue
+		}
+		tolerance := regressionTolerance(req)
+		delta := championFitness - referenceFitness
+		return &RegressionCheck{
+			Mode:              regressionMode,
+			ReferenceRunID:    reference.runID,
+			ReferenceGenomeID: reference.genome.ID,
+			ChampionFitness:   championFitness,
+			ReferenceFitness:  referenceFitness,
+			Delta:             delta,
+			Tolerance:         tolerance,
+			Regressed:         delta < -tolerance*math.Abs(referenceFitness),
+		}, nil
+	}
+	return nil, nil
+}
+
+func regressionTolerance(req RunRequest) float64 {
+	if req.RegressionTolerance == 0 {
+		return DefaultRegressionTolerance
+	}
+	return req.RegressionTolerance
+}
+
+// regressionConditions keys the settings two runs must share for their
+// champions to be compared: the scape with its data and parameters, the
+// genome encoding and the evaluation budget.
+func regressionConditions(cfg stats.RunConfig) (string, error) {
+	data, err := json.Marshal(stats.RunConfig{
+		Scape:                   scapeid.Normalize(cfg.Scape),
+		Encoding:                cfg.Encoding,
+		GTSACSVPath:             cfg.GTSACSVPath,
+		GTSATrainEnd:            cfg.GTSATrainEnd,
+		GTSAValidationEnd:       cfg.GTSAValidationEnd,
+		GTSATestEnd:             cfg.GTSATestEnd,
+		FXCSVPath:               cfg.FXCSVPath,
+		EpitopesCSVPath:         cfg.EpitopesCSVPath,
+		EpitopesTableName:       cfg.EpitopesTableName,
+		LLVMWorkflowJSONPath:    cfg.LLVMWorkflowJSONPath,
+		EpitopesGTStart:         cfg.EpitopesGTStart,
+		EpitopesGTEnd:           cfg.EpitopesGTEnd,
+		EpitopesValidationStart: cfg.EpitopesValidationStart,
+		EpitopesValidationEnd:   cfg.EpitopesValidationEnd,
+		EpitopesTestStart:       cfg.EpitopesTestStart,
+		EpitopesTestEnd:         cfg.EpitopesTestEnd,
+		EpitopesBenchmarkStart:  cfg.EpitopesBenchmarkStart,
+		EpitopesBenchmarkEnd:    cfg.EpitopesBenchmarkEnd,
+		GTSAProfile:             cfg.GTSAProfile,
+		FXProfile:               cfg.FXProfile,
+		EpitopesProfile:         cfg.EpitopesProfile,
+		LLVMProfile:             cfg.LLVMProfile,
+		FlatlandScannerProfile:  cfg.FlatlandScannerProfile,
+		FlatlandScannerSpread:   cfg.FlatlandScannerSpread,
+		FlatlandScannerOffset:   cfg.FlatlandScannerOffset,
+		FlatlandLayoutRandomize: cfg.FlatlandLayoutRandomize,
+		FlatlandLayoutVariants:  cfg.FlatlandLayoutVariants,
+		FlatlandForceLayout:     cfg.FlatlandForceLayout,
+		FlatlandBenchmarkTrials: cfg.FlatlandBenchmarkTrials,
+		FlatlandMaxAge:          cfg.FlatlandMaxAge,
+		FlatlandForageGoal:      cfg.FlatlandForageGoal,
+		PopulationSize:          cfg.PopulationSize,
+		Generations:             cfg.Generations,
+		EvaluationsLimit:        cfg.EvaluationsLimit,
+		EvaluationTrials:        cfg.EvaluationTrials,
+	})
+	return string(data), err
+}
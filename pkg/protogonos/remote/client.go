@@ -0,0 +1,83 @@
+package remote
+
+import (
+	"context"
+
+	"protogonos/internal/model"
+	protoapi "protogonos/pkg/protogonos"
+
+	"google.golang.org/grpc"
+)
+
+// Client calls a remote Server over gRPC. The caller owns the connection.
+type Client struct {
+	conn grpc.ClientConnInterface
+}
+
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{conn: conn}
+}
+
+func (c *Client) invoke(ctx context.Context, name string, req, reply any) error {
+	return c.conn.Invoke(ctx, "/"+ServiceName+"/"+name, req, reply, grpc.CallContentSubtype(CodecName))
+}
+
+func (c *Client) Run(ctx context.Context, req protoapi.RunRequest) (protoapi.RunSummary, error) {
+	var reply protoapi.RunSummary
+	err := c.invoke(ctx, "Run", req, &reply)
+	return reply, err
+}
+
+func (c *Client) StartRun(ctx context.Context, req protoapi.RunRequest) (RunStatus, error) {
+	var reply RunStatus
+	err := c.invoke(ctx, "StartRun", req, &reply)
+	return reply, err
+}
+
+func (c *Client) RunStatus(ctx context.Context, req RunStatusRequest) (RunStatus, error) {
+	var reply RunStatus
+	err := c.invoke(ctx, "RunStatus", req, &reply)
+	return reply, err
+}
+
+func (c *Client) Lineage(ctx context.Context, req protoapi.LineageRequest) ([]protoapi.LineageItem, error) {
+	var reply []protoapi.LineageItem
+	err := c.invoke(ctx, "Lineage", req, &reply)
+	return reply, err
+}
+
+func (c *Client) Diagnostics(ctx context.Context, req protoapi.DiagnosticsRequest) ([]model.GenerationDiagnostics, error) {
+	var reply []model.GenerationDiagnostics
+	err := c.invoke(ctx, "Diagnostics", req, &reply)
+	return reply, err
+}
+
+func (c *Client) SpeciesDiff(ctx context.Context, req protoapi.SpeciesDiffRequest) (protoapi.SpeciesDiff, error) {
+	var reply protoapi.SpeciesDiff
+	err := c.invoke(ctx, "SpeciesDiff", req, &reply)
+	return reply, err
+}
+
+func (c *Client) PauseRun(ctx context.Context, req protoapi.MonitorControlRequest) error {
+	return c.invoke(ctx, "PauseRun", req, &Empty{})
+}
+
+func (c *Client) ContinueRun(ctx context.Context, req protoapi.MonitorControlRequest) error {
+	return c.invoke(ctx, "ContinueRun", req, &Empty{})
+}
+
+func (c *Client) StopRun(ctx context.Context, req protoapi.MonitorControlRequest) error {
+	return c.invoke(ctx, "StopRun", req, &Empty{})
+}
+
+func (c *Client) GoalReachedRun(ctx context.Context, req protoapi.MonitorControlRequest) error {
+	return c.invoke(ctx, "GoalReachedRun", req, &Empty{})
+}
+
+func (c *Client) PrintTraceRun(ctx context.Context, req protoapi.MonitorControlRequest) error {
+	return c.invoke(ctx, "PrintTraceRun", req, &Empty{})
+}
+
+func (c *Client) SetScapeParamRun(ctx context.Context, req protoapi.SetScapeParamRequest) error {
+	return c.invoke(ctx, "SetScapeParamRun", req, &Empty{})
+}
@@ -0,0 +1,69 @@
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// GatewayPrefix is the path under which Handler serves the service methods.
+const GatewayPrefix = "/v1/"
+
+// Handler is the JSON/HTTP gateway to the service: POST /v1/<Method> with
+// the JSON request as body answers with the JSON reply, or with
+// {"error": "..."} and a 4xx status. GET /healthz reports the methods.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	for _, m := range methods {
+		mux.HandleFunc(GatewayPrefix+m.name, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				w.Header().Set("Allow", http.MethodPost)
+				writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "use POST"})
+				return
+			}
+			req := m.newRequest()
+			if err := decodeBody(r.Body, req); err != nil {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("decode %s request: %v", m.name, err)})
+				return
+			}
+			reply, err := m.invoke(r.Context(), s, req)
+			if err != nil {
+				writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+				return
+			}
+			writeJSON(w, http.StatusOK, reply)
+		})
+	}
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		names := make([]string, 0, len(methods))
+		for _, m := range methods {
+			names = append(names, m.name)
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"service": ServiceName,
+			"methods": names,
+		})
+	})
+	return mux
+}
+
+// decodeBody decodes a JSON request body into req; an empty body leaves the
+// request at its zero value.
+func decodeBody(body io.Reader, req any) error {
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(string(raw)) == "" {
+		return nil
+	}
+	return json.Unmarshal(raw, req)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
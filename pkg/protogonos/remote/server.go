@@ -0,0 +1,162 @@
+// Package remote exposes a protogonos Client to other processes, over gRPC
+// and over a JSON/HTTP gateway that shares the same methods, so long-running
+// evolution can be started, steered and inspected without local access to
+// the store.
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"protogonos/internal/model"
+	protoapi "protogonos/pkg/protogonos"
+)
+
+// States of a run started with StartRun.
+const (
+	RunStateRunning   = "running"
+	RunStateCompleted = "completed"
+	RunStateFailed    = "failed"
+)
+
+// RunStatus reports a run started with StartRun. Summary is set once the
+// run completes and Error once it fails.
+type RunStatus struct {
+	RunID   string               `json:"run_id"`
+	State   string               `json:"state"`
+	Summary *protoapi.RunSummary `json:"summary,omitempty"`
+	Error   string               `json:"error,omitempty"`
+}
+
+// RunStatusRequest names a run started with StartRun.
+type RunStatusRequest struct {
+	RunID string `json:"run_id"`
+}
+
+// Empty is the reply of methods that only report success.
+type Empty struct{}
+
+// Server serves one Client. Runs started with StartRun outlive the call that
+// started them and are cancelled by Close.
+type Server struct {
+	client *protoapi.Client
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu   sync.Mutex
+	runs map[string]*RunStatus
+}
+
+// NewServer initializes client's polis so concurrent calls share it, and
+// returns a server for it. The caller keeps ownership of client.
+func NewServer(ctx context.Context, client *protoapi.Client) (*Server, error) {
+	if client == nil {
+		return nil, errors.New("remote server requires a client")
+	}
+	if err := client.Init(ctx); err != nil {
+		return nil, err
+	}
+	runCtx, cancel := context.WithCancel(context.Background())
+	return &Server{
+		client: client,
+		ctx:    runCtx,
+		cancel: cancel,
+		runs:   map[string]*RunStatus{},
+	}, nil
+}
+
+// Close cancels the runs started with StartRun and waits for them to return.
+func (s *Server) Close() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// Run evolves req and returns once the run finishes.
+func (s *Server) Run(ctx context.Context, req protoapi.RunRequest) (protoapi.RunSummary, error) {
+	return s.client.Run(ctx, req)
+}
+
+// StartRun starts evolving req in the background and returns at once; poll
+// RunStatus for the outcome and drive the run with the monitor controls.
+// The run id is required so the caller can address the run.
+func (s *Server) StartRun(_ context.Context, req protoapi.RunRequest) (RunStatus, error) {
+	if req.RunID == "" {
+		return RunStatus{}, errors.New("start run requires run id")
+	}
+	s.mu.Lock()
+	if status, ok := s.runs[req.RunID]; ok && status.State == RunStateRunning {
+		s.mu.Unlock()
+		return RunStatus{}, fmt.Errorf("run %s is already running", req.RunID)
+	}
+	status := &RunStatus{RunID: req.RunID, State: RunStateRunning}
+	s.runs[req.RunID] = status
+	started := *status
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		summary, err := s.client.Run(s.ctx, req)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if err != nil {
+			status.State = RunStateFailed
+			status.Error = err.Error()
+			return
+		}
+		status.State = RunStateCompleted
+		status.Summary = &summary
+	}()
+	return started, nil
+}
+
+// RunStatus reports a run started with StartRun.
+func (s *Server) RunStatus(_ context.Context, req RunStatusRequest) (RunStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status, ok := s.runs[req.RunID]
+	if !ok {
+		return RunStatus{}, fmt.Errorf("run %s was not started by this server", req.RunID)
+	}
+	return *status, nil
+}
+
+func (s *Server) Lineage(ctx context.Context, req protoapi.LineageRequest) ([]protoapi.LineageItem, error) {
+	return s.client.Lineage(ctx, req)
+}
+
+func (s *Server) Diagnostics(ctx context.Context, req protoapi.DiagnosticsRequest) ([]model.GenerationDiagnostics, error) {
+	return s.client.Diagnostics(ctx, req)
+}
+
+func (s *Server) SpeciesDiff(ctx context.Context, req protoapi.SpeciesDiffRequest) (protoapi.SpeciesDiff, error) {
+	return s.client.SpeciesDiff(ctx, req)
+}
+
+func (s *Server) PauseRun(ctx context.Context, req protoapi.MonitorControlRequest) (Empty, error) {
+	return Empty{}, s.client.PauseRun(ctx, req)
+}
+
+func (s *Server) ContinueRun(ctx context.Context, req protoapi.MonitorControlRequest) (Empty, error) {
+	return Empty{}, s.client.ContinueRun(ctx, req)
+}
+
+func (s *Server) StopRun(ctx context.Context, req protoapi.MonitorControlRequest) (Empty, error) {
+	return Empty{}, s.client.StopRun(ctx, req)
+}
+
+func (s *Server) GoalReachedRun(ctx context.Context, req protoapi.MonitorControlRequest) (Empty, error) {
+	return Empty{}, s.client.GoalReachedRun(ctx, req)
+}
+
+func (s *Server) PrintTraceRun(ctx context.Context, req protoapi.MonitorControlRequest) (Empty, error) {
+	return Empty{}, s.client.PrintTraceRun(ctx, req)
+}
+
+func (s *Server) SetScapeParamRun(ctx context.Context, req protoapi.SetScapeParamRequest) (Empty, error) {
+	return Empty{}, s.client.SetScapeParamRun(ctx, req)
+}
@@ -0,0 +1,184 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	protoapi "protogonos/pkg/protogonos"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	base := t.TempDir()
+	client, err := protoapi.New(protoapi.Options{
+		StoreKind:     "memory",
+		BenchmarksDir: filepath.Join(base, "benchmarks"),
+		ExportsDir:    filepath.Join(base, "exports"),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	server, err := NewServer(context.Background(), client)
+	if err != nil {
+		t.Fatalf("new server: %v", err)
+	}
+	t.Cleanup(func() {
+		server.Close()
+		_ = client.Close()
+	})
+	return server
+}
+
+func dialTestServer(t *testing.T, server *Server) *Client {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	server.RegisterGRPC(grpcServer)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+		grpcServer.Stop()
+	})
+	return NewClient(conn)
+}
+
+func TestGRPCStartRunIsDrivenByMonitorControls(t *testing.T) {
+	server := newTestServer(t)
+	client := dialTestServer(t, server)
+	ctx := context.Background()
+
+	runID := "remote-live"
+	status, err := client.StartRun(ctx, protoapi.RunRequest{
+		RunID:         runID,
+		Scape:         "xor",
+		Population:    8,
+		Generations:   3,
+		StartPaused:   true,
+		Selection:     "elite",
+		WeightPerturb: 1.0,
+	})
+	if err != nil {
+		t.Fatalf("start run: %v", err)
+	}
+	if status.RunID != runID || status.State != RunStateRunning {
+		t.Fatalf("expected running status, got %+v", status)
+	}
+	if _, err := client.StartRun(ctx, protoapi.RunRequest{RunID: runID, Scape: "xor"}); err == nil {
+		t.Fatal("expected a second start of a running run to fail")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		err := client.ContinueRun(ctx, protoapi.MonitorControlRequest{RunID: runID})
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("continue run: %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	for status.State == RunStateRunning {
+		if time.Now().After(deadline) {
+			t.Fatal("timeout waiting for the remote run to complete")
+		}
+		time.Sleep(5 * time.Millisecond)
+		if status, err = client.RunStatus(ctx, RunStatusRequest{RunID: runID}); err != nil {
+			t.Fatalf("run status: %v", err)
+		}
+	}
+	if status.State != RunStateCompleted || status.Summary == nil || len(status.Summary.BestByGeneration) != 3 {
+		t.Fatalf("expected a completed three-generation run, got %+v", status)
+	}
+
+	lineage, err := client.Lineage(ctx, protoapi.LineageRequest{RunID: runID})
+	if err != nil || len(lineage) == 0 {
+		t.Fatalf("expected lineage over grpc, got %d items err=%v", len(lineage), err)
+	}
+	diagnostics, err := client.Diagnostics(ctx, protoapi.DiagnosticsRequest{RunID: runID})
+	if err != nil || len(diagnostics) != 3 {
+		t.Fatalf("expected three diagnostics over grpc, got %d err=%v", len(diagnostics), err)
+	}
+	diff, err := client.SpeciesDiff(ctx, protoapi.SpeciesDiffRequest{RunID: runID, FromGeneration: 1, ToGeneration: 3})
+	if err != nil || diff.RunID != runID {
+		t.Fatalf("expected species diff over grpc, got %+v err=%v", diff, err)
+	}
+	if err := client.StopRun(ctx, protoapi.MonitorControlRequest{RunID: runID}); err == nil {
+		t.Fatal("expected stop on a finished run to fail")
+	}
+	if _, err := client.RunStatus(ctx, RunStatusRequest{RunID: "unknown"}); err == nil {
+		t.Fatal("expected status of an unknown run to fail")
+	}
+}
+
+func TestGatewayServesServiceMethodsAsJSON(t *testing.T) {
+	server := newTestServer(t)
+	gateway := httptest.NewServer(server.Handler())
+	t.Cleanup(gateway.Close)
+
+	post := func(method, body string) (*http.Response, map[string]any) {
+		t.Helper()
+		resp, err := http.Post(gateway.URL+GatewayPrefix+method, "application/json", bytes.NewBufferString(body))
+		if err != nil {
+			t.Fatalf("post %s: %v", method, err)
+		}
+		defer resp.Body.Close()
+		var decoded any
+		if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+			t.Fatalf("decode %s reply: %v", method, err)
+		}
+		reply, _ := decoded.(map[string]any)
+		return resp, reply
+	}
+
+	resp, reply := post("Run", `{"RunID": "remote-http", "Scape": "xor", "Population": 6, "Generations": 2, "Seed": 3}`)
+	if resp.StatusCode != http.StatusOK || reply["RunID"] != "remote-http" {
+		t.Fatalf("expected run over http, got %d %+v", resp.StatusCode, reply)
+	}
+	resp, err := http.Post(gateway.URL+GatewayPrefix+"Lineage", "application/json", bytes.NewBufferString(`{"RunID": "remote-http"}`))
+	if err != nil {
+		t.Fatalf("post lineage: %v", err)
+	}
+	var lineage []protoapi.LineageItem
+	if err := json.NewDecoder(resp.Body).Decode(&lineage); err != nil || len(lineage) == 0 {
+		t.Fatalf("expected lineage over http, got %d items err=%v", len(lineage), err)
+	}
+	_ = resp.Body.Close()
+
+	if resp, reply := post("StartRun", `{"Scape": "xor"}`); resp.StatusCode != http.StatusUnprocessableEntity || reply["error"] == nil {
+		t.Fatalf("expected start without run id to be rejected, got %d %+v", resp.StatusCode, reply)
+	}
+	if resp, _ := post("Run", `{"Scape": `); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected malformed request to be rejected, got %d", resp.StatusCode)
+	}
+	getResp, err := http.Get(gateway.URL + GatewayPrefix + "Lineage")
+	if err != nil {
+		t.Fatalf("get lineage: %v", err)
+	}
+	_ = getResp.Body.Close()
+	if getResp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("expected GET to be rejected, got %d", getResp.StatusCode)
+	}
+}
@@ -0,0 +1,102 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/status"
+)
+
+// ServiceName is the gRPC service the server registers. Its methods are
+// named after the Server methods and carry JSON messages: call them with the
+// "json" content subtype, as Client does.
+const ServiceName = "protogonos.v1.Protogonos"
+
+// CodecName is the content subtype of the service's messages.
+const CodecName = "json"
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// jsonCodec marshals messages as JSON, so the request and reply types of the
+// Go API double as the wire schema and no generated code is needed.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return CodecName
+}
+
+// method is one unary service method, shared by the gRPC service and the
+// HTTP gateway. newRequest returns a pointer for the request to be decoded
+// into, and invoke calls the Server method with it.
+type method struct {
+	name       string
+	newRequest func() any
+	invoke     func(ctx context.Context, s *Server, req any) (any, error)
+}
+
+func unary[Req, Reply any](name string, fn func(*Server, context.Context, Req) (Reply, error)) method {
+	return method{
+		name:       name,
+		newRequest: func() any { return new(Req) },
+		invoke: func(ctx context.Context, s *Server, req any) (any, error) {
+			return fn(s, ctx, *req.(*Req))
+		},
+	}
+}
+
+// methods lists the methods of the service.
+var methods = []method{
+	unary("Run", (*Server).Run),
+	unary("StartRun", (*Server).StartRun),
+	unary("RunStatus", (*Server).RunStatus),
+	unary("Lineage", (*Server).Lineage),
+	unary("Diagnostics", (*Server).Diagnostics),
+	unary("SpeciesDiff", (*Server).SpeciesDiff),
+	unary("PauseRun", (*Server).PauseRun),
+	unary("ContinueRun", (*Server).ContinueRun),
+	unary("StopRun", (*Server).StopRun),
+	unary("GoalReachedRun", (*Server).GoalReachedRun),
+	unary("PrintTraceRun", (*Server).PrintTraceRun),
+	unary("SetScapeParamRun", (*Server).SetScapeParamRun),
+}
+
+// RegisterGRPC registers the service on registrar.
+func (s *Server) RegisterGRPC(registrar grpc.ServiceRegistrar) {
+	desc := grpc.ServiceDesc{
+		ServiceName: ServiceName,
+		HandlerType: (*any)(nil),
+		Methods:     make([]grpc.MethodDesc, 0, len(methods)),
+	}
+	for _, m := range methods {
+		desc.Methods = append(desc.Methods, grpc.MethodDesc{
+			MethodName: m.name,
+			Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+				req := m.newRequest()
+				if err := dec(req); err != nil {
+					return nil, status.Errorf(codes.InvalidArgument, "decode %s request: %v", m.name, err)
+				}
+				if interceptor == nil {
+					return m.invoke(ctx, s, req)
+				}
+				info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + ServiceName + "/" + m.name}
+				return interceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+					return m.invoke(ctx, s, req)
+				})
+			},
+		})
+	}
+	registrar.RegisterService(&desc, s)
+}
@@ -0,0 +1,241 @@
+package protogonos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"protogonos/internal/agent"
+	"protogonos/internal/model"
+	"protogonos/internal/scape"
+	"protogonos/internal/scapeid"
+	"protogonos/internal/stats"
+)
+
+const (
+	ReplayRenderSVG  = "svg"
+	ReplayRenderJSON = "json"
+)
+
+// ReplayRequest re-evaluates a stored genome of a finished run. With Render
+// set, scapes that record trajectories (flatland, dtm) also write an episode
+// playback file into the run's replay artifacts directory.
+type ReplayRequest struct {
+	RunID    string
+	Latest   bool
+	GenomeID string
+	Mode     string
+	Render   bool
+	Format   string
+}
+
+type ReplaySummary struct {
+	RunID      string
+	Scape      string
+	Mode       string
+	GenomeID   string
+	Fitness    float64
+	Frames     int
+	RenderPath string
+	// Report is the scape's literature-style result line for the replayed
+	// mode, if it provides one (see scape.ReportScape).
+	Report string
+}
+
+func (c *Client) Replay(ctx context.Context, req ReplayRequest) (ReplaySummary, error) {
+	if req.RunID != "" && req.Latest {
+		return ReplaySummary{}, errors.New("use either run id or latest")
+	}
+	format := strings.ToLower(strings.TrimSpace(req.Format))
+	if format == "" {
+		format = ReplayRenderSVG
+	}
+	if format != ReplayRenderSVG && format != ReplayRenderJSON {
+		return ReplaySummary{}, fmt.Errorf("unsupported replay render format: %s", req.Format)
+	}
+	mode := strings.ToLower(strings.TrimSpace(req.Mode))
+	if mode == "" {
+		mode = "gt"
+	}
+	switch mode {
+	case "gt", "validation", "test", "benchmark":
+	default:
+		return ReplaySummary{}, fmt.Errorf("unsupported replay mode: %s", req.Mode)
+	}
+
+	loaded, err := c.loadTopGenome(ctx, req.RunID, req.Latest, req.GenomeID)
+	if err != nil {
+		return ReplaySummary{}, err
+	}
+
+	var recorder *scape.TrajectoryRecorder
+	if req.Render {
+		if recording, ok := loaded.scape.(scape.TrajectoryScape); !ok || !recording.RecordsTrajectory() {
+			return ReplaySummary{}, fmt.Errorf("scape %s does not support trajectory rendering", loaded.scapeName)
+		}
+		recorder = scape.NewTrajectoryRecorder()
+	}
+
+	replayCtx, err := applyScapeDataSources(ctx, loaded.request)
+	if err != nil {
+		return ReplaySummary{}, err
+	}
+	if recorder != nil {
+		replayCtx = scape.WithTrajectoryRecorder(replayCtx, recorder)
+	}
+	cortex, err := loaded.cortex()
+	if err != nil {
+		return ReplaySummary{}, err
+	}
+	var fitness scape.Fitness
+	var trace scape.Trace
+	if modeAware, ok := loaded.scape.(scape.ModeAwareScape); ok {
+		fitness, trace, err = modeAware.EvaluateMode(replayCtx, cortex, mode)
+	} else {
+		fitness, trace, err = loaded.scape.Evaluate(replayCtx, cortex)
+	}
+	if err != nil {
+		return ReplaySummary{}, fmt.Errorf("evaluate replay genome %s: %w", loaded.genome.ID, err)
+	}
+
+	summary := ReplaySummary{
+		RunID:    loaded.runID,
+		Scape:    loaded.scapeName,
+		Mode:     mode,
+		GenomeID: loaded.genome.ID,
+		Fitness:  float64(fitness),
+	}
+	if reporting, ok := loaded.scape.(scape.ReportScape); ok {
+		summary.Report, _ = reporting.Report(mode, trace)
+	}
+	if recorder == nil {
+		return summary, nil
+	}
+	trajectory := recorder.Trajectory()
+	summary.Frames = len(trajectory.Frames)
+	summary.RenderPath, err = writeReplayRender(c.benchmarksDir, loaded.runID, loaded.genome.ID, mode, format, trajectory)
+	if err != nil {
+		return ReplaySummary{}, err
+	}
+	return summary, nil
+}
+
+// loadedGenome is a stored top genome resolved together with everything
+// needed to rebuild and evaluate its cortex outside the original run.
+type loadedGenome struct {
+	runID     string
+	scapeName string
+	scape     scape.Scape
+	genome    model.Genome
+	request   RunRequest
+}
+
+// loadTopGenome resolves the run (or the latest run) and picks its champion,
+// or the named genome when genomeID is set and among the stored top genomes.
+func (c *Client) loadTopGenome(ctx context.Context, runID string, latest bool, genomeID string) (loadedGenome, error) {
+	if latest {
+		entries, err := stats.ListRunIndex(c.benchmarksDir)
+		if err != nil {
+			return loadedGenome{}, err
+		}
+		if len(entries) == 0 {
+			return loadedGenome{}, errors.New("no runs available")
+		}
+		runID = entries[0].RunID
+	}
+	if strings.TrimSpace(runID) == "" {
+		return loadedGenome{}, errors.New("run id or latest is required")
+	}
+
+	runCfg, ok, err := readRunConfigWithProfileHints(c.benchmarksDir, runID)
+	if err != nil {
+		return loadedGenome{}, err
+	}
+	if !ok {
+		return loadedGenome{}, fmt.Errorf("run config not found for run id: %s", runID)
+	}
+	top, ok, err := stats.ReadTopGenomes(c.benchmarksDir, runID)
+	if err != nil {
+		return loadedGenome{}, err
+	}
+	if !ok || len(top) == 0 {
+		return loadedGenome{}, fmt.Errorf("top genomes not found for run id: %s", runID)
+	}
+	selected := top[0]
+	if genomeID != "" {
+		found := false
+		for _, candidate := range top {
+			if candidate.Genome.ID == genomeID {
+				selected, found = candidate, true
+				break
+			}
+		}
+		if !found {
+			return loadedGenome{}, fmt.Errorf("genome %s is not among the top genomes of run %s", genomeID, runID)
+		}
+	}
+
+	scapeName := scapeid.Normalize(runCfg.Scape)
+	p, err := c.ensurePolis(ctx)
+	if err != nil {
+		return loadedGenome{}, err
+	}
+	if err := registerDefaultScapes(p); err != nil {
+		return loadedGenome{}, err
+	}
+	request := runRequestFromArtifactsConfig(runCfg)
+	if err := registerRunScape(p, request); err != nil {
+		return loadedGenome{}, err
+	}
+	targetScape, ok := p.GetScape(scapeName)
+	if !ok {
+		return loadedGenome{}, fmt.Errorf("scape not registered: %s", scapeName)
+	}
+	return loadedGenome{
+		runID:     runID,
+		scapeName: scapeName,
+		scape:     targetScape,
+		genome:    selected.Genome,
+		request:   request,
+	}, nil
+}
+
+func (g loadedGenome) cortex() (*agent.Cortex, error) {
+	inputNeuronIDs, outputNeuronIDs, err := defaultSeedIONeuronsForScape(g.request)
+	if err != nil {
+		return nil, err
+	}
+	cortex, err := buildReplayCortex(g.scapeName, g.request.Encoding, g.genome, inputNeuronIDs, outputNeuronIDs)
+	if err != nil {
+		return nil, fmt.Errorf("build replay cortex for genome %s: %w", g.genome.ID, err)
+	}
+	return cortex, nil
+}
+
+func writeReplayRender(baseDir, runID, genomeID, mode, format string, trajectory scape.Trajectory) (string, error) {
+	dir := filepath.Join(baseDir, runID, "replay")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	var data []byte
+	switch format {
+	case ReplayRenderJSON:
+		encoded, err := json.MarshalIndent(trajectory, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		data = append(encoded, '\n')
+	default:
+		data = scape.RenderTrajectorySVG(trajectory)
+	}
+	name := strings.NewReplacer("/", "_", string(filepath.Separator), "_").Replace(genomeID)
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.%s", name, mode, format))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,220 @@
+package protogonos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"protogonos/internal/model"
+	"protogonos/internal/stats"
+)
+
+// RestartsRequest runs up to Restarts independent restarts of Run, restart i
+// (from 0) seeded with Run.Seed+i, until one reaches Run.FitnessGoal.
+// Parallel restarts run concurrently; once a restart reaches the goal no
+// further restarts start, but those already running finish. All runs every
+// restart regardless, to sample the evaluations-to-goal distribution.
+type RestartsRequest struct {
+	Run      RunRequest
+	Restarts int
+	Parallel int
+	All      bool
+}
+
+// RestartRun is the outcome of one restart. Evaluations counts the
+// population and tuning evaluations until the goal was reached, or over the
+// whole run when it was not.
+type RestartRun struct {
+	Restart          int
+	RunID            string
+	Seed             int64
+	ReachedGoal      bool
+	Generation       int
+	Evaluations      int
+	FinalBestFitness float64
+}
+
+// EvaluationsToGoal summarizes the evaluations-to-goal of the restarts that
+// reached the goal.
+type EvaluationsToGoal struct {
+	Count  int
+	Mean   float64
+	Std    float64
+	Median float64
+	Min    int
+	Max    int
+}
+
+// RestartsSummary lists the restarts that ran in restart order. Successes
+// and SuccessRate count the restarts that reached the goal; TotalEvaluations
+// is the evaluation cost of every restart up to and including the first
+// successful one, the cost of solving the task by restarting.
+type RestartsSummary struct {
+	Goal              float64
+	Restarts          []RestartRun
+	Successes         int
+	SuccessRate       float64
+	FirstSuccess      string
+	TotalEvaluations  int
+	EvaluationsToGoal EvaluationsToGoal
+}
+
+func (c *Client) RunRestarts(ctx context.Context, req RestartsRequest) (RestartsSummary, error) {
+	if req.Restarts <= 0 {
+		return RestartsSummary{}, errors.New("restarts must be > 0")
+	}
+	if req.Parallel <= 0 {
+		req.Parallel = 1
+	}
+	if req.Run.FitnessGoal <= 0 {
+		return RestartsSummary{}, errors.New("restarts require a fitness goal > 0")
+	}
+	if req.Run.ContinuePopulationID != "" {
+		return RestartsSummary{}, errors.New("restarts cannot continue a population")
+	}
+	baseRunID := req.Run.RunID
+	if baseRunID == "" {
+		baseRunID = fmt.Sprintf("%s-restarts-%d-%d", req.Run.Scape, req.Run.Seed, time.Now().UTC().Unix())
+	}
+
+	var (
+		mu      sync.Mutex
+		solved  bool
+		runErr  error
+		results = make([]*RestartRun, req.Restarts)
+		wg      sync.WaitGroup
+	)
+	// Taking a slot before the stop check means a sequential restart starts
+	// only after the previous one has reported.
+	slots := make(chan struct{}, req.Parallel)
+	for restart := 0; restart < req.Restarts; restart++ {
+		slots <- struct{}{}
+		mu.Lock()
+		stop := (solved && !req.All) || runErr != nil
+		mu.Unlock()
+		if stop || ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(restart int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			result, err := c.runRestart(ctx, req.Run, baseRunID, restart)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if runErr == nil {
+					runErr = err
+				}
+				return
+			}
+			results[restart] = &result
+			solved = solved || result.ReachedGoal
+		}(restart)
+	}
+	wg.Wait()
+	if runErr != nil {
+		return RestartsSummary{}, runErr
+	}
+	if err := ctx.Err(); err != nil {
+		return RestartsSummary{}, err
+	}
+
+	summary := RestartsSummary{Goal: req.Run.FitnessGoal}
+	var toGoal []int
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		summary.Restarts = append(summary.Restarts, *result)
+		if summary.FirstSuccess == "" {
+			summary.TotalEvaluations += result.Evaluations
+		}
+		if result.ReachedGoal {
+			summary.Successes++
+			toGoal = append(toGoal, result.Evaluations)
+			if summary.FirstSuccess == "" {
+				summary.FirstSuccess = result.RunID
+			}
+		}
+	}
+	summary.SuccessRate = float64(summary.Successes) / float64(len(summary.Restarts))
+	summary.EvaluationsToGoal = summarizeEvaluationsToGoal(toGoal)
+	return summary, nil
+}
+
+// runRestart runs one restart with its own run id and seeds. Explicit
+// selection and mutation seeds are offset like the base seed so restarts stay
+// independent; the environment seed is kept so every restart faces the same
+// task.
+func (c *Client) runRestart(ctx context.Context, base RunRequest, baseRunID string, restart int) (RestartRun, error) {
+	req := base
+	req.RunID = fmt.Sprintf("%s-restart-%03d", baseRunID, restart+1)
+	req.Seed = base.Seed + int64(restart)
+	if base.SelectionSeed != nil {
+		seed := *base.SelectionSeed + int64(restart)
+		req.SelectionSeed = &seed
+	}
+	if base.MutationSeed != nil {
+		seed := *base.MutationSeed + int64(restart)
+		req.MutationSeed = &seed
+	}
+	summary, err := c.Run(ctx, req)
+	if err != nil {
+		return RestartRun{}, fmt.Errorf("restart %d: %w", restart+1, err)
+	}
+	diagnostics, _, err := stats.ReadGenerationDiagnostics(c.benchmarksDir, summary.RunID)
+	if err != nil {
+		return RestartRun{}, err
+	}
+	result := restartOutcome(diagnostics, base.FitnessGoal)
+	result.Restart = restart + 1
+	result.RunID = summary.RunID
+	result.Seed = req.Seed
+	result.FinalBestFitness = summary.FinalBestFitness
+	return result, nil
+}
+
+// restartOutcome finds the first generation whose best fitness reached goal
+// and the evaluations spent up to it.
+func restartOutcome(diagnostics []model.GenerationDiagnostics, goal float64) RestartRun {
+	var result RestartRun
+	tuning := 0
+	for _, diag := range diagnostics {
+		tuning += diag.TuningEvaluations
+		result.Generation = diag.Generation
+		result.Evaluations = diag.TotalEvaluations + tuning
+		if diag.BestFitness >= goal {
+			result.ReachedGoal = true
+			break
+		}
+	}
+	return result
+}
+
+func summarizeEvaluationsToGoal(values []int) EvaluationsToGoal {
+	if len(values) == 0 {
+		return EvaluationsToGoal{}
+	}
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+	out := EvaluationsToGoal{Count: len(sorted), Min: sorted[0], Max: sorted[len(sorted)-1]}
+	for _, value := range sorted {
+		out.Mean += float64(value)
+	}
+	out.Mean /= float64(len(sorted))
+	for _, value := range sorted {
+		out.Std += (float64(value) - out.Mean) * (float64(value) - out.Mean)
+	}
+	out.Std = math.Sqrt(out.Std / float64(len(sorted)))
+	mid := len(sorted) / 2
+	out.Median = float64(sorted[mid])
+	if len(sorted)%2 == 0 {
+		out.Median = float64(sorted[mid-1]+sorted[mid]) / 2
+	}
+	return out
+}
@@ -0,0 +1,60 @@
+package protogonos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"protogonos/internal/evo"
+	"protogonos/internal/stats"
+	"protogonos/internal/storage"
+)
+
+// ResumeRequest continues a run that stopped before finishing from the last
+// checkpoint it saved; the run must have been started with a
+// CheckpointInterval.
+type ResumeRequest struct {
+	RunID string
+}
+
+func (c *Client) Resume(ctx context.Context, req ResumeRequest) (RunSummary, error) {
+	if req.RunID == "" {
+		return RunSummary{}, errors.New("resume requires run id")
+	}
+	if _, err := c.ensurePolis(ctx); err != nil {
+		return RunSummary{}, err
+	}
+	checkpointStore, ok := c.store.(storage.CheckpointStore)
+	if !ok {
+		return RunSummary{}, fmt.Errorf("store %s does not support checkpoints", c.storeKind)
+	}
+	checkpoint, ok, err := checkpointStore.GetCheckpoint(ctx, req.RunID)
+	if err != nil {
+		return RunSummary{}, err
+	}
+	if !ok {
+		return RunSummary{}, fmt.Errorf("checkpoint not found for run id: %s", req.RunID)
+	}
+	var cfg stats.RunConfig
+	if err := json.Unmarshal(checkpoint.Config, &cfg); err != nil {
+		return RunSummary{}, fmt.Errorf("decode checkpoint config %s: %w", req.RunID, err)
+	}
+	var state evo.Checkpoint
+	if err := json.Unmarshal(checkpoint.State, &state); err != nil {
+		return RunSummary{}, fmt.Errorf("decode checkpoint %s: %w", req.RunID, err)
+	}
+
+	runReq := runRequestFromRunConfig(cfg)
+	runReq.RunID = req.RunID
+	run, err := c.prepareRun(ctx, runReq)
+	if err != nil {
+		return RunSummary{}, err
+	}
+	// The population comes from the checkpoint, so the seed population
+	// prepareRun built only supplies the run's neuron ids.
+	run.initialGeneration = cfg.InitialGeneration
+	run.championSources = cfg.InitChampionSources
+	run.resume = &state
+	return c.evolve(ctx, run)
+}
@@ -0,0 +1,77 @@
+package protogonos
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"protogonos/internal/platform"
+	"protogonos/internal/scape"
+	"protogonos/internal/storage"
+)
+
+// ScapeWorkerCommand is the protogonosctl subcommand serving a scape sandbox.
+const ScapeWorkerCommand = "scape-worker"
+
+// ServeScapeSandbox runs a scape sandbox worker on in and out until in is
+// closed. The worker builds the requested scape the way a run does, from the
+// default scapes and the run's construction parameters and data sources.
+func ServeScapeSandbox(ctx context.Context, in io.Reader, out io.Writer) error {
+	return scape.ServeSandbox(ctx, in, out, resolveSandboxScape)
+}
+
+func resolveSandboxScape(ctx context.Context, init scape.SandboxInit) (context.Context, scape.Scape, error) {
+	store, err := storage.NewStore("memory", "")
+	if err != nil {
+		return nil, nil, err
+	}
+	p := platform.NewPolis(platform.Config{Store: store})
+	if err := p.Init(ctx); err != nil {
+		return nil, nil, err
+	}
+	if err := registerDefaultScapes(p); err != nil {
+		return nil, nil, err
+	}
+	if err := registerRunScape(p, RunRequest{Scape: init.Scape, ScapeParams: init.Params}); err != nil {
+		return nil, nil, err
+	}
+	target, ok := p.GetScape(init.Scape)
+	if !ok {
+		return nil, nil, fmt.Errorf("scape not registered: %s", init.Scape)
+	}
+	evalCtx, err := scape.WithDataSources(ctx, init.Sources)
+	if err != nil {
+		return nil, nil, err
+	}
+	return evalCtx, target, nil
+}
+
+// registerSandboxScape replaces the run's scape with one evaluated in sandbox
+// workers started with req.SandboxCommand when req.ScapeSandbox is set. The
+// caller closes the returned sandbox once the run ends.
+func registerSandboxScape(p *platform.Polis, req RunRequest) (*scape.SandboxScape, error) {
+	if !req.ScapeSandbox {
+		return nil, nil
+	}
+	inner, ok := p.GetScape(req.Scape)
+	if !ok {
+		return nil, fmt.Errorf("scape not registered: %s", req.Scape)
+	}
+	sandbox, err := scape.NewSandboxScape(inner, scape.SandboxConfig{
+		Command:        strings.Fields(req.SandboxCommand),
+		ScapeParams:    req.ScapeParams,
+		Sources:        scapeDataSources(req),
+		CPUSeconds:     req.SandboxCPUSeconds,
+		MemoryMB:       req.SandboxMemoryMB,
+		Timeout:        req.SandboxTimeout,
+		FailureFitness: req.SandboxFailureFitness,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := p.RegisterScape(sandbox); err != nil {
+		return nil, err
+	}
+	return sandbox, nil
+}
@@ -0,0 +1,214 @@
+package protogonos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	protoio "protogonos/internal/io"
+	"protogonos/internal/scape"
+)
+
+// SensorImportanceRequest measures how much a stored genome of a finished
+// run relies on each of its sensors. GenomeID defaults to the champion and
+// Mode to gt.
+type SensorImportanceRequest struct {
+	RunID    string
+	Latest   bool
+	GenomeID string
+	Mode     string
+}
+
+// SensorImportance is the fitness a genome loses when one sensor reads zeros.
+// A negative Importance means the genome scores better without the sensor.
+type SensorImportance struct {
+	SensorID       string  `json:"sensor_id"`
+	AblatedFitness float64 `json:"ablated_fitness"`
+	Importance     float64 `json:"importance"`
+}
+
+// SensorImportanceReport ranks a genome's sensors by Importance, highest
+// first; ties keep the genome's sensor order. Path is the run artifact the
+// report was written to.
+type SensorImportanceReport struct {
+	RunID           string             `json:"run_id"`
+	Scape           string             `json:"scape"`
+	Mode            string             `json:"mode"`
+	GenomeID        string             `json:"genome_id"`
+	BaselineFitness float64            `json:"baseline_fitness"`
+	Sensors         []SensorImportance `json:"sensors"`
+	Path            string             `json:"-"`
+}
+
+// SensorImportance re-evaluates a run's champion (or the named top genome)
+// once as stored and once per connected sensor with that sensor zeroed, and
+// writes the resulting ranking into the run's sensor_importance artifacts.
+func (c *Client) SensorImportance(ctx context.Context, req SensorImportanceRequest) (SensorImportanceReport, error) {
+	if req.RunID != "" && req.Latest {
+		return SensorImportanceReport{}, errors.New("use either run id or latest")
+	}
+	mode := strings.ToLower(strings.TrimSpace(req.Mode))
+	if mode == "" {
+		mode = "gt"
+	}
+	switch mode {
+	case "gt", "validation", "test", "benchmark":
+	default:
+		return SensorImportanceReport{}, fmt.Errorf("unsupported sensor importance mode: %s", req.Mode)
+	}
+
+	loaded, err := c.loadTopGenome(ctx, req.RunID, req.Latest, req.GenomeID)
+	if err != nil {
+		return SensorImportanceReport{}, err
+	}
+	sensorIDs := uniqueSensorIDs(loaded.genome.SensorIDs)
+	if len(sensorIDs) == 0 {
+		return SensorImportanceReport{}, fmt.Errorf("genome %s has no connected sensors", loaded.genome.ID)
+	}
+	evalCtx, err := applyScapeDataSources(ctx, loaded.request)
+	if err != nil {
+		return SensorImportanceReport{}, err
+	}
+
+	baseline, err := evaluateWithAblatedSensor(evalCtx, loaded, mode, "")
+	if err != nil {
+		return SensorImportanceReport{}, fmt.Errorf("evaluate genome %s: %w", loaded.genome.ID, err)
+	}
+	report := SensorImportanceReport{
+		RunID:           loaded.runID,
+		Scape:           loaded.scapeName,
+		Mode:            mode,
+		GenomeID:        loaded.genome.ID,
+		BaselineFitness: baseline,
+		Sensors:         make([]SensorImportance, 0, len(sensorIDs)),
+	}
+	for _, sensorID := range sensorIDs {
+		fitness, err := evaluateWithAblatedSensor(evalCtx, loaded, mode, sensorID)
+		if err != nil {
+			return SensorImportanceReport{}, fmt.Errorf("evaluate genome %s without sensor %s: %w", loaded.genome.ID, sensorID, err)
+		}
+		report.Sensors = append(report.Sensors, SensorImportance{
+			SensorID:       sensorID,
+			AblatedFitness: fitness,
+			Importance:     baseline - fitness,
+		})
+	}
+	sort.SliceStable(report.Sensors, func(i, j int) bool {
+		return report.Sensors[i].Importance > report.Sensors[j].Importance
+	})
+
+	report.Path, err = writeSensorImportance(c.benchmarksDir, report)
+	if err != nil {
+		return SensorImportanceReport{}, err
+	}
+	return report, nil
+}
+
+// evaluateWithAblatedSensor scores the loaded genome in mode on a fresh
+// cortex whose ablated sensor, if any, reads zeros.
+func evaluateWithAblatedSensor(ctx context.Context, loaded loadedGenome, mode, ablated string) (float64, error) {
+	inputNeuronIDs, outputNeuronIDs, err := defaultSeedIONeuronsForScape(loaded.request)
+	if err != nil {
+		return 0, err
+	}
+	sensors, actuators, err := buildReplayIO(loaded.scapeName, loaded.genome)
+	if err != nil {
+		return 0, err
+	}
+	if sensor, ok := sensors[ablated]; ok {
+		sensors[ablated] = zeroSensor(sensor)
+	}
+	cortex, err := newReplayCortex(loaded.request.Encoding, loaded.genome, sensors, actuators, inputNeuronIDs, outputNeuronIDs)
+	if err != nil {
+		return 0, err
+	}
+	var fitness scape.Fitness
+	if modeAware, ok := loaded.scape.(scape.ModeAwareScape); ok {
+		fitness, _, err = modeAware.EvaluateMode(ctx, cortex, mode)
+	} else {
+		fitness, _, err = loaded.scape.Evaluate(ctx, cortex)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return float64(fitness), nil
+}
+
+// zeroSensor wraps sensor so it reads zeros of its usual width. Scapes keep
+// driving it through its setter, so the rest of the episode is unchanged.
+func zeroSensor(sensor protoio.Sensor) protoio.Sensor {
+	zeroed := zeroedSensor{Sensor: sensor}
+	switch setter := sensor.(type) {
+	case protoio.ScalarSensorSetter:
+		return zeroedScalarSensor{zeroedSensor: zeroed, setter: setter}
+	case protoio.VectorSensorSetter:
+		return zeroedVectorSensor{zeroedSensor: zeroed, setter: setter}
+	default:
+		return zeroed
+	}
+}
+
+type zeroedSensor struct {
+	protoio.Sensor
+}
+
+func (s zeroedSensor) Read(ctx context.Context) ([]float64, error) {
+	values, err := s.Sensor.Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return make([]float64, len(values)), nil
+}
+
+type zeroedScalarSensor struct {
+	zeroedSensor
+	setter protoio.ScalarSensorSetter
+}
+
+func (s zeroedScalarSensor) Set(value float64) {
+	s.setter.Set(value)
+}
+
+type zeroedVectorSensor struct {
+	zeroedSensor
+	setter protoio.VectorSensorSetter
+}
+
+func (s zeroedVectorSensor) Set(values []float64) {
+	s.setter.Set(values)
+}
+
+func uniqueSensorIDs(sensorIDs []string) []string {
+	seen := make(map[string]struct{}, len(sensorIDs))
+	unique := make([]string, 0, len(sensorIDs))
+	for _, sensorID := range sensorIDs {
+		if _, ok := seen[sensorID]; ok {
+			continue
+		}
+		seen[sensorID] = struct{}{}
+		unique = append(unique, sensorID)
+	}
+	return unique
+}
+
+func writeSensorImportance(baseDir string, report SensorImportanceReport) (string, error) {
+	dir := filepath.Join(baseDir, report.RunID, "sensor_importance")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	name := strings.NewReplacer("/", "_", string(filepath.Separator), "_").Replace(report.GenomeID)
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.json", name, report.Mode))
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
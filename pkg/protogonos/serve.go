@@ -0,0 +1,360 @@
+package protogonos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"protogonos/internal/agent"
+)
+
+const (
+	defaultServeMaxBatch    = 32
+	defaultServeBatchWindow = 2 * time.Millisecond
+	serveLatencyWindow      = 1024
+)
+
+// ServeModelRequest selects the stored genome a ModelServer answers with, or
+// with CompactFile the genome of a compact export. Concurrent predict calls
+// arriving within BatchWindow are merged into one forward pass of up to
+// MaxBatch rows.
+type ServeModelRequest struct {
+	RunID       string
+	Latest      bool
+	GenomeID    string
+	CompactFile string
+	MaxBatch    int
+	BatchWindow time.Duration
+}
+
+// ModelInfo describes the model behind a ModelServer. Batched reports whether
+// rows are scored in one vectorized pass; otherwise they are stepped through
+// the cortex in arrival order and recurrent state carries across requests.
+type ModelInfo struct {
+	RunID         string  `json:"run_id"`
+	Scape         string  `json:"scape"`
+	GenomeID      string  `json:"genome_id"`
+	Inputs        int     `json:"inputs"`
+	Outputs       int     `json:"outputs"`
+	Batched       bool    `json:"batched"`
+	MaxBatch      int     `json:"max_batch"`
+	BatchWindowMS float64 `json:"batch_window_ms"`
+}
+
+// ServeMetrics aggregates predict traffic since the server started. Latency
+// percentiles cover the most recent requests and include queueing time.
+type ServeMetrics struct {
+	Requests      int64   `json:"requests"`
+	Rows          int64   `json:"rows"`
+	Batches       int64   `json:"batches"`
+	Errors        int64   `json:"errors"`
+	MeanBatchRows float64 `json:"mean_batch_rows"`
+	LatencyP50MS  float64 `json:"latency_p50_ms"`
+	LatencyP95MS  float64 `json:"latency_p95_ms"`
+	LatencyP99MS  float64 `json:"latency_p99_ms"`
+	LatencyMaxMS  float64 `json:"latency_max_ms"`
+}
+
+type predictJob struct {
+	rows  [][]float64
+	reply chan predictResult
+}
+
+type predictResult struct {
+	outputs [][]float64
+	err     error
+}
+
+// ModelServer runs a stored champion behind a batching predict queue. A single
+// goroutine owns the cortex, so callers may predict concurrently.
+type ModelServer struct {
+	info   ModelInfo
+	cortex *agent.Cortex
+	jobs   chan predictJob
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu        sync.Mutex
+	metrics   ServeMetrics
+	latencies []float64
+	next      int
+}
+
+// NewModelServer loads the champion (or the named top genome) of a run and
+// starts its batching loop. Call Close to stop it.
+func (c *Client) NewModelServer(ctx context.Context, req ServeModelRequest) (*ModelServer, error) {
+	if req.RunID != "" && req.Latest {
+		return nil, errors.New("use either run id or latest")
+	}
+	if req.MaxBatch < 0 {
+		return nil, fmt.Errorf("max batch must be >= 0, got %d", req.MaxBatch)
+	}
+	if req.BatchWindow < 0 {
+		return nil, fmt.Errorf("batch window must be >= 0, got %s", req.BatchWindow)
+	}
+	if req.MaxBatch == 0 {
+		req.MaxBatch = defaultServeMaxBatch
+	}
+	if req.BatchWindow == 0 {
+		req.BatchWindow = defaultServeBatchWindow
+	}
+
+	var (
+		runID, scapeName, genomeID      string
+		inputNeuronIDs, outputNeuronIDs []string
+		cortex                          *agent.Cortex
+	)
+	if req.CompactFile != "" {
+		if req.RunID != "" || req.Latest || req.GenomeID != "" {
+			return nil, errors.New("a compact file cannot be combined with a run or genome id")
+		}
+		compact, err := LoadCompactGenome(req.CompactFile)
+		if err != nil {
+			return nil, err
+		}
+		scapeName, genomeID = compact.Scape, compact.Genome.ID
+		inputNeuronIDs, outputNeuronIDs = compact.InputNeuronIDs, compact.OutputNeuronIDs
+		cortex, err = buildReplayCortex(scapeName, compact.Encoding, compact.Genome, inputNeuronIDs, outputNeuronIDs)
+		if err != nil {
+			return nil, fmt.Errorf("build cortex for genome %s: %w", genomeID, err)
+		}
+	} else {
+		loaded, err := c.loadTopGenome(ctx, req.RunID, req.Latest, req.GenomeID)
+		if err != nil {
+			return nil, err
+		}
+		inputNeuronIDs, outputNeuronIDs, err = defaultSeedIONeuronsForScape(loaded.request)
+		if err != nil {
+			return nil, err
+		}
+		cortex, err = loaded.cortex()
+		if err != nil {
+			return nil, err
+		}
+		runID, scapeName, genomeID = loaded.runID, loaded.scapeName, loaded.genome.ID
+	}
+
+	loopCtx, cancel := context.WithCancel(context.Background())
+	s := &ModelServer{
+		info: ModelInfo{
+			RunID:         runID,
+			Scape:         scapeName,
+			GenomeID:      genomeID,
+			Inputs:        len(inputNeuronIDs),
+			Outputs:       len(outputNeuronIDs),
+			Batched:       cortex.BatchEvaluable(),
+			MaxBatch:      req.MaxBatch,
+			BatchWindowMS: float64(req.BatchWindow) / float64(time.Millisecond),
+		},
+		cortex:    cortex,
+		jobs:      make(chan predictJob),
+		cancel:    cancel,
+		done:      make(chan struct{}),
+		latencies: make([]float64, 0, serveLatencyWindow),
+	}
+	go s.loop(loopCtx, req.BatchWindow)
+	return s, nil
+}
+
+func (s *ModelServer) Info() ModelInfo {
+	return s.info
+}
+
+// Close stops the batching loop. Pending and later predict calls fail.
+func (s *ModelServer) Close() {
+	s.cancel()
+	<-s.done
+}
+
+// Predict scores rows of exactly Info().Inputs values each and returns one
+// output row per input row.
+func (s *ModelServer) Predict(ctx context.Context, rows [][]float64) ([][]float64, error) {
+	if err := s.validateRows(rows); err != nil {
+		s.observe(0, 0, err)
+		return nil, err
+	}
+	start := time.Now()
+	job := predictJob{rows: rows, reply: make(chan predictResult, 1)}
+	select {
+	case s.jobs <- job:
+	case <-s.done:
+		return nil, errors.New("model server is closed")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	var result predictResult
+	select {
+	case result = <-job.reply:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	s.observe(len(rows), time.Since(start), result.err)
+	return result.outputs, result.err
+}
+
+func (s *ModelServer) validateRows(rows [][]float64) error {
+	if len(rows) == 0 {
+		return errors.New("predict requires at least one input row")
+	}
+	for i, row := range rows {
+		if len(row) != s.info.Inputs {
+			return fmt.Errorf("input row %d has %d values, model expects %d", i, len(row), s.info.Inputs)
+		}
+	}
+	return nil
+}
+
+func (s *ModelServer) Metrics() ServeMetrics {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := s.metrics
+	if out.Batches > 0 {
+		out.MeanBatchRows = float64(out.Rows) / float64(out.Batches)
+	}
+	sorted := append([]float64(nil), s.latencies...)
+	sort.Float64s(sorted)
+	out.LatencyP50MS = latencyPercentile(sorted, 0.50)
+	out.LatencyP95MS = latencyPercentile(sorted, 0.95)
+	out.LatencyP99MS = latencyPercentile(sorted, 0.99)
+	if len(sorted) > 0 {
+		out.LatencyMaxMS = sorted[len(sorted)-1]
+	}
+	return out
+}
+
+// Handler exposes POST /predict ({"inputs": [[...], ...]}), GET /metrics and
+// GET /healthz.
+func (s *ModelServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/predict", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeServeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "use POST"})
+			return
+		}
+		var body struct {
+			Inputs [][]float64 `json:"inputs"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeServeJSON(w, http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("decode request: %v", err)})
+			return
+		}
+		outputs, err := s.Predict(r.Context(), body.Inputs)
+		if err != nil {
+			writeServeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		writeServeJSON(w, http.StatusOK, map[string]any{
+			"genome_id": s.info.GenomeID,
+			"outputs":   outputs,
+		})
+	})
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, _ *http.Request) {
+		writeServeJSON(w, http.StatusOK, s.Metrics())
+	})
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		writeServeJSON(w, http.StatusOK, s.info)
+	})
+	return mux
+}
+
+func (s *ModelServer) loop(ctx context.Context, window time.Duration) {
+	defer close(s.done)
+	for {
+		var first predictJob
+		select {
+		case first = <-s.jobs:
+		case <-ctx.Done():
+			return
+		}
+		batch := []predictJob{first}
+		rows := len(first.rows)
+		timer := time.NewTimer(window)
+	collect:
+		for rows < s.info.MaxBatch {
+			select {
+			case job := <-s.jobs:
+				batch = append(batch, job)
+				rows += len(job.rows)
+			case <-timer.C:
+				break collect
+			case <-ctx.Done():
+				break collect
+			}
+		}
+		timer.Stop()
+		s.runBatch(ctx, batch)
+	}
+}
+
+func (s *ModelServer) runBatch(ctx context.Context, batch []predictJob) {
+	var merged [][]float64
+	for _, job := range batch {
+		merged = append(merged, job.rows...)
+	}
+	outputs, err := s.forward(ctx, merged)
+	s.mu.Lock()
+	s.metrics.Batches++
+	s.mu.Unlock()
+	offset := 0
+	for _, job := range batch {
+		if err != nil {
+			job.reply <- predictResult{err: err}
+			continue
+		}
+		job.reply <- predictResult{outputs: outputs[offset : offset+len(job.rows)]}
+		offset += len(job.rows)
+	}
+}
+
+func (s *ModelServer) forward(ctx context.Context, rows [][]float64) ([][]float64, error) {
+	if s.info.Batched {
+		return s.cortex.RunBatch(ctx, rows)
+	}
+	outputs := make([][]float64, 0, len(rows))
+	for _, row := range rows {
+		out, err := s.cortex.RunStep(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, out)
+	}
+	return outputs, nil
+}
+
+func (s *ModelServer) observe(rows int, latency time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.metrics.Requests++
+	if err != nil {
+		s.metrics.Errors++
+		return
+	}
+	s.metrics.Rows += int64(rows)
+	ms := float64(latency) / float64(time.Millisecond)
+	if len(s.latencies) < serveLatencyWindow {
+		s.latencies = append(s.latencies, ms)
+		return
+	}
+	s.latencies[s.next] = ms
+	s.next = (s.next + 1) % serveLatencyWindow
+}
+
+func latencyPercentile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(q * float64(len(sorted)-1))
+	return sorted[idx]
+}
+
+func writeServeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,189 @@
+package protogonos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"protogonos/internal/evo"
+	"protogonos/internal/model"
+	"protogonos/internal/storage"
+)
+
+// SimilarGenomesRequest searches the store for genomes close to a query
+// genome. The query is GenomeID when set (looked up among stored genomes and
+// run top genomes), otherwise the champion of RunID or of the latest run.
+type SimilarGenomesRequest struct {
+	GenomeID string
+	RunID    string
+	Latest   bool
+	K        int
+	Metric   string
+}
+
+// SimilarGenome is one search hit. RunID and Fitness are set when the genome
+// was recorded among a run's top genomes; SameFingerprint flags a topology
+// identical to the query.
+type SimilarGenome struct {
+	GenomeID        string  `json:"genome_id"`
+	RunID           string  `json:"run_id,omitempty"`
+	Fitness         float64 `json:"fitness,omitempty"`
+	Distance        float64 `json:"distance"`
+	Fingerprint     string  `json:"fingerprint"`
+	SameFingerprint bool    `json:"same_fingerprint"`
+}
+
+type SimilarGenomesResult struct {
+	QueryGenomeID    string          `json:"query_genome_id"`
+	QueryRunID       string          `json:"query_run_id,omitempty"`
+	Metric           string          `json:"metric"`
+	CandidateCount   int             `json:"candidate_count"`
+	QueryFingerprint string          `json:"query_fingerprint"`
+	Matches          []SimilarGenome `json:"matches"`
+}
+
+type similarityCandidate struct {
+	genome  model.Genome
+	runID   string
+	fitness float64
+}
+
+// SimilarGenomes returns the K genomes nearest to the query, excluding the
+// query genome itself. Useful for checking whether a new champion rediscovers
+// an earlier lineage.
+func (c *Client) SimilarGenomes(ctx context.Context, req SimilarGenomesRequest) (SimilarGenomesResult, error) {
+	if req.RunID != "" && req.Latest {
+		return SimilarGenomesResult{}, errors.New("use either run id or latest")
+	}
+	if req.GenomeID == "" && req.RunID == "" && !req.Latest {
+		return SimilarGenomesResult{}, errors.New("similar genomes requires genome id, run id or latest")
+	}
+	if req.K < 0 {
+		return SimilarGenomesResult{}, errors.New("k must be >= 0")
+	}
+	if req.K == 0 {
+		req.K = 5
+	}
+	metric := req.Metric
+	if metric == "" {
+		metric = evo.SimilarityMetricCompatibility
+	}
+	distance, err := evo.GenomeDistanceFunc(metric)
+	if err != nil {
+		return SimilarGenomesResult{}, err
+	}
+
+	if _, err := c.ensurePolis(ctx); err != nil {
+		return SimilarGenomesResult{}, err
+	}
+	candidates, err := c.similarityCandidates(ctx)
+	if err != nil {
+		return SimilarGenomesResult{}, err
+	}
+
+	var query similarityCandidate
+	if req.GenomeID != "" {
+		found := false
+		for _, candidate := range candidates {
+			if candidate.genome.ID == req.GenomeID && (req.RunID == "" || candidate.runID == req.RunID) {
+				query, found = candidate, true
+				break
+			}
+		}
+		if !found {
+			return SimilarGenomesResult{}, fmt.Errorf("genome not found: %s", req.GenomeID)
+		}
+	} else {
+		loaded, err := c.loadTopGenome(ctx, req.RunID, req.Latest, "")
+		if err != nil {
+			return SimilarGenomesResult{}, err
+		}
+		query = similarityCandidate{genome: loaded.genome, runID: loaded.runID}
+	}
+
+	queryFingerprint := evo.ComputeGenomeSignature(query.genome).Fingerprint
+	matches := make([]SimilarGenome, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.genome.ID == query.genome.ID && candidate.runID == query.runID {
+			continue
+		}
+		fingerprint := evo.ComputeGenomeSignature(candidate.genome).Fingerprint
+		matches = append(matches, SimilarGenome{
+			GenomeID:        candidate.genome.ID,
+			RunID:           candidate.runID,
+			Fitness:         candidate.fitness,
+			Distance:        distance(query.genome, candidate.genome),
+			Fingerprint:     fingerprint,
+			SameFingerprint: fingerprint == queryFingerprint,
+		})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Distance != matches[j].Distance {
+			return matches[i].Distance < matches[j].Distance
+		}
+		if matches[i].RunID != matches[j].RunID {
+			return matches[i].RunID < matches[j].RunID
+		}
+		return matches[i].GenomeID < matches[j].GenomeID
+	})
+	result := SimilarGenomesResult{
+		QueryGenomeID:    query.genome.ID,
+		QueryRunID:       query.runID,
+		Metric:           metric,
+		QueryFingerprint: queryFingerprint,
+		CandidateCount:   len(matches),
+		Matches:          matches,
+	}
+	if len(result.Matches) > req.K {
+		result.Matches = result.Matches[:req.K]
+	}
+	return result, nil
+}
+
+// similarityCandidates collects the top genomes of each persisted run plus
+// every stored genome not already recorded as a top genome. Seed genome ids
+// repeat across runs, so top-genome candidates are distinct per run.
+func (c *Client) similarityCandidates(ctx context.Context) ([]similarityCandidate, error) {
+	lister, ok := c.store.(storage.Lister)
+	if !ok {
+		return nil, errors.New("store does not support record listing")
+	}
+	var candidates []similarityCandidate
+	recorded := map[string]struct{}{}
+	runIDs, err := lister.ListRunIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(runIDs)
+	for _, runID := range runIDs {
+		top, ok, err := c.store.GetTopGenomes(ctx, runID)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		for _, record := range top {
+			recorded[record.Genome.ID] = struct{}{}
+			candidates = append(candidates, similarityCandidate{genome: record.Genome, runID: runID, fitness: record.Fitness})
+		}
+	}
+	genomeIDs, err := lister.ListGenomeIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range genomeIDs {
+		if _, ok := recorded[id]; ok {
+			continue
+		}
+		genome, ok, err := c.store.GetGenome(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			candidates = append(candidates, similarityCandidate{genome: genome})
+		}
+	}
+	return candidates, nil
+}
@@ -0,0 +1,142 @@
+package protogonos
+
+import (
+	"context"
+
+	"protogonos/internal/evo"
+)
+
+// GenerationEvent is one event of a run started with RunStream. Every event
+// but the last reports a scored generation; the last one has Done set and
+// carries the run's Summary, or Err when the run failed.
+type GenerationEvent struct {
+	Generation     int
+	BestFitness    float64
+	MeanFitness    float64
+	MinFitness     float64
+	Species        []SpeciesSummary
+	NewSpecies     []string
+	ExtinctSpecies []string
+	Tuning         TuningSummary
+	Progress       RunProgress
+
+	Done    bool
+	Summary *RunSummary
+	Err     error
+}
+
+// SpeciesSummary describes one species of a streamed generation.
+type SpeciesSummary struct {
+	Key         string
+	Size        int
+	MeanFitness float64
+	BestFitness float64
+}
+
+// TuningSummary totals the tuning work done on a streamed generation.
+type TuningSummary struct {
+	Invocations     int
+	Attempts        int
+	Evaluations     int
+	Accepted        int
+	Rejected        int
+	GoalHits        int
+	AcceptRate      float64
+	EvalsPerAttempt float64
+}
+
+// RunStream starts req like Run but returns at once with a channel of the
+// run's generation events, sent as each generation is scored, that is closed
+// once the run ends. Errors preparing the run are returned directly. The run
+// waits for the caller to receive each event, so callers must drain the
+// channel or cancel ctx; once ctx is done, events the caller does not
+// receive, the final one included, are dropped. Runs of baseline algorithms
+// only send the final event, and compare-tuning runs stream the generations
+// of both arms.
+func (c *Client) RunStream(ctx context.Context, req RunRequest) (<-chan GenerationEvent, error) {
+	run, err := c.prepareRun(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	stream := &generationStream{ctx: ctx, events: make(chan GenerationEvent, 1)}
+	run.stream = stream
+	go func() {
+		defer close(stream.events)
+		summary, err := c.evolve(ctx, run)
+		final := GenerationEvent{Done: true, Err: err}
+		if err == nil {
+			final.Summary = &summary
+		}
+		stream.send(final)
+	}()
+	return stream.events, nil
+}
+
+// generationStream joins each generation's diagnostics with its species
+// summary, which the monitor reports right after, into one event. A nil
+// stream adds no hooks.
+type generationStream struct {
+	ctx     context.Context
+	events  chan GenerationEvent
+	pending GenerationEvent
+}
+
+func (s *generationStream) progressHook(next func(evo.GenerationDiagnostics)) func(evo.GenerationDiagnostics) {
+	if s == nil {
+		return next
+	}
+	progress := progressHook(func(p RunProgress) {
+		s.pending.Progress = p
+	})
+	return func(diag evo.GenerationDiagnostics) {
+		if next != nil {
+			next(diag)
+		}
+		s.pending = GenerationEvent{
+			Generation:  diag.Generation,
+			BestFitness: diag.BestFitness,
+			MeanFitness: diag.MeanFitness,
+			MinFitness:  diag.MinFitness,
+			Tuning: TuningSummary{
+				Invocations:     diag.TuningInvocations,
+				Attempts:        diag.TuningAttempts,
+				Evaluations:     diag.TuningEvaluations,
+				Accepted:        diag.TuningAccepted,
+				Rejected:        diag.TuningRejected,
+				GoalHits:        diag.TuningGoalHits,
+				AcceptRate:      diag.TuningAcceptRate,
+				EvalsPerAttempt: diag.TuningEvalsPerAttempt,
+			},
+		}
+		progress(diag)
+	}
+}
+
+func (s *generationStream) speciesHook() func(evo.SpeciesGeneration) {
+	if s == nil {
+		return nil
+	}
+	return func(generation evo.SpeciesGeneration) {
+		event := s.pending
+		s.pending = GenerationEvent{}
+		event.Species = make([]SpeciesSummary, 0, len(generation.Species))
+		for _, species := range generation.Species {
+			event.Species = append(event.Species, SpeciesSummary{
+				Key:         species.Key,
+				Size:        species.Size,
+				MeanFitness: species.MeanFitness,
+				BestFitness: species.BestFitness,
+			})
+		}
+		event.NewSpecies = append([]string(nil), generation.NewSpecies...)
+		event.ExtinctSpecies = append([]string(nil), generation.ExtinctSpecies...)
+		s.send(event)
+	}
+}
+
+func (s *generationStream) send(event GenerationEvent) {
+	select {
+	case s.events <- event:
+	case <-s.ctx.Done():
+	}
+}